@@ -17,10 +17,13 @@ package annotator
 
 import (
 	"context"
+	"errors"
 
 	scalibrfs "github.com/google/osv-scalibr/fs"
 	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/log"
 	"github.com/google/osv-scalibr/plugin"
+	"github.com/google/osv-scalibr/stats/resourceusage"
 )
 
 // Annotator is the interface for an annotation plugin, used to add additional
@@ -38,6 +41,10 @@ type Annotator interface {
 type Config struct {
 	Annotators []Annotator
 	ScanRoot   *scalibrfs.ScanRoot
+	// Optional: How long a single plugin can run before it's cancelled.
+	PluginTimeouts *plugin.Timeouts
+	// Optional: Records the resources used by each annotator.
+	ResourceTracker *resourceusage.Tracker
 }
 
 // ScanInput provides information for the annotator about the scan.
@@ -58,8 +65,17 @@ func Run(ctx context.Context, config *Config, inventory *inventory.Inventory) ([
 	}
 
 	for _, a := range config.Annotators {
-		err := a.Annotate(ctx, input, inventory)
-		statuses = append(statuses, plugin.StatusFromErr(a, false, err))
+		if ctx.Err() != nil {
+			return statuses, ctx.Err()
+		}
+		actx := log.NewContext(ctx, log.KeyPlugin, a.Name())
+		run := config.ResourceTracker.StartPlugin(a.Name())
+		err := config.PluginTimeouts.Run(actx, a.Name(), func(ctx context.Context) error {
+			return a.Annotate(ctx, input, inventory)
+		})
+		run.Stop(0)
+		// Annotations added before a timeout are kept.
+		statuses = append(statuses, plugin.StatusFromErr(a, errors.Is(err, plugin.ErrTimeout), err))
 	}
 	return statuses, nil
 }
@@ -104,7 +104,7 @@ func TestRun(t *testing.T) {
 				Annotators: []annotator.Annotator{&failingAnnotator{}},
 			},
 			want: []*plugin.Status{
-				{Name: "failing-annotator", Version: 2, Status: &plugin.ScanStatus{Status: plugin.ScanStatusFailed, FailureReason: "some error"}},
+				{Name: "failing-annotator", Version: 2, Status: &plugin.ScanStatus{Status: plugin.ScanStatusFailed, FailureReason: "some error", ErrorCounts: []*plugin.ErrorCount{{Category: plugin.ErrorCategoryOther, Count: 1}}}},
 			},
 		},
 		{
@@ -114,7 +114,7 @@ func TestRun(t *testing.T) {
 			},
 			want: []*plugin.Status{
 				{Name: "succeeding-annotator", Version: 1, Status: &plugin.ScanStatus{Status: plugin.ScanStatusSucceeded}},
-				{Name: "failing-annotator", Version: 2, Status: &plugin.ScanStatus{Status: plugin.ScanStatusFailed, FailureReason: "some error"}},
+				{Name: "failing-annotator", Version: 2, Status: &plugin.ScanStatus{Status: plugin.ScanStatusFailed, FailureReason: "some error", ErrorCounts: []*plugin.ErrorCount{{Category: plugin.ErrorCategoryOther, Count: 1}}}},
 			},
 		},
 	}
@@ -22,7 +22,9 @@ import (
 
 	"github.com/google/osv-scalibr/annotator"
 	"github.com/google/osv-scalibr/annotator/cachedir"
+	"github.com/google/osv-scalibr/annotator/misc/filehashes"
 	"github.com/google/osv-scalibr/annotator/misc/fromnpm"
+	"github.com/google/osv-scalibr/annotator/misc/ownership"
 	noexecutabledpkg "github.com/google/osv-scalibr/annotator/noexecutable/dpkg"
 	"github.com/google/osv-scalibr/annotator/osduplicate/apk"
 	"github.com/google/osv-scalibr/annotator/osduplicate/cos"
@@ -47,7 +49,11 @@ var VEX = InitMap{
 }
 
 // Misc annotators.
-var Misc = InitMap{fromnpm.Name: {fromnpm.New}}
+var Misc = InitMap{
+	filehashes.Name: {filehashes.New},
+	fromnpm.Name:    {fromnpm.New},
+	ownership.Name:  {ownership.New},
+}
 
 // Default detectors that are recommended to be enabled.
 var Default = InitMap{cachedir.Name: {cachedir.New}}
@@ -0,0 +1,196 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package filehashes implements an annotator that adds the cryptographic
+// digests of the files at package locations, e.g. of JARs, wheels or binaries,
+// so that they can be correlated with allowlists and threat intelligence.
+package filehashes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/google/osv-scalibr/annotator"
+	"github.com/google/osv-scalibr/extractor"
+	scalibrfs "github.com/google/osv-scalibr/fs"
+	"github.com/google/osv-scalibr/hashing"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/log"
+	"github.com/google/osv-scalibr/plugin"
+)
+
+const (
+	// Name of the Annotator.
+	Name = "misc/filehashes"
+
+	// DefaultMaxBytes is the default number of bytes hashed during a scan.
+	DefaultMaxBytes int64 = 1 << 30
+)
+
+// Annotator adds the digests of the files at package locations.
+type Annotator struct {
+	// MaxBytes is the number of bytes hashed during a scan. Files that don't
+	// fit into the remaining budget aren't hashed. Zero or negative values
+	// remove the limit.
+	MaxBytes int64
+
+	hashing *hashing.Config
+}
+
+// New returns a new Annotator that computes SHA-256 digests.
+func New() annotator.Annotator {
+	return &Annotator{
+		MaxBytes: DefaultMaxBytes,
+		hashing:  hashing.DefaultConfig(),
+	}
+}
+
+// SetHashingConfig sets the digest algorithms to compute.
+func (a *Annotator) SetHashingConfig(cfg *hashing.Config) { a.hashing = cfg }
+
+// Name of the annotator.
+func (Annotator) Name() string { return Name }
+
+// Version of the annotator.
+func (Annotator) Version() int { return 0 }
+
+// Requirements of the annotator.
+func (Annotator) Requirements() *plugin.Capabilities { return &plugin.Capabilities{} }
+
+// Annotate adds the digests of the package locations that are regular files
+// in the scanned tree. Each file is read once, even if it backs several
+// packages.
+func (a *Annotator) Annotate(ctx context.Context, input *annotator.ScanInput, results *inventory.Inventory) error {
+	if input.ScanRoot == nil || input.ScanRoot.FS == nil {
+		return nil
+	}
+	cfg := a.hashing
+	if cfg == nil {
+		cfg = hashing.DefaultConfig()
+	}
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("%s: %w", Name, err)
+	}
+	h := &hasher{
+		fsys:     input.ScanRoot.FS,
+		cfg:      cfg,
+		maxBytes: a.MaxBytes,
+		digests:  map[string][]*extractor.FileDigest{},
+	}
+	var errs []error
+	for _, pkg := range results.Packages {
+		for _, loc := range pkg.Locations {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			rel, err := relativePath(input.ScanRoot, loc)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", Name, err))
+				continue
+			}
+			digests, err := h.digest(rel)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: hashing %q: %w", Name, loc, err))
+				continue
+			}
+			for _, sum := range digests {
+				d := &extractor.FileDigest{Location: loc, Algorithm: sum.Algorithm, Digest: sum.Digest}
+				if !slices.ContainsFunc(pkg.FileDigests, func(o *extractor.FileDigest) bool { return *o == *d }) {
+					pkg.FileDigests = append(pkg.FileDigests, d)
+				}
+			}
+		}
+	}
+	if h.skipped > 0 {
+		log.Warnf("%s: %d files weren't hashed since they exceed the budget of %d bytes", Name, h.skipped, a.MaxBytes)
+	}
+	return errors.Join(errs...)
+}
+
+// relativePath returns the location relative to the scan root, as a slash
+// separated path.
+func relativePath(root *scalibrfs.ScanRoot, loc string) (string, error) {
+	if filepath.IsAbs(loc) && root.Path != "" {
+		rel, err := filepath.Rel(root.Path, loc)
+		if err != nil {
+			return "", fmt.Errorf("failed to get relative path for %q from base %q: %w", loc, root.Path, err)
+		}
+		loc = rel
+	}
+	return strings.TrimPrefix(filepath.ToSlash(loc), "/"), nil
+}
+
+// hasher computes the digests of files in the scanned filesystem within a
+// byte budget. The digests are cached by path with an empty Location.
+type hasher struct {
+	fsys     scalibrfs.FS
+	cfg      *hashing.Config
+	maxBytes int64
+	hashed   int64
+	skipped  int
+	digests  map[string][]*extractor.FileDigest
+}
+
+// digest returns the digests of the file at the given path in the order of
+// the configured algorithms. Locations that aren't regular files, e.g. files
+// inside archives, and files over the budget have no digests.
+func (h *hasher) digest(path string) ([]*extractor.FileDigest, error) {
+	if d, ok := h.digests[path]; ok {
+		return d, nil
+	}
+	h.digests[path] = nil
+
+	info, err := fs.Stat(h.fsys, path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !info.Mode().IsRegular() {
+		return nil, nil
+	}
+	if h.maxBytes > 0 && h.hashed+info.Size() > h.maxBytes {
+		h.skipped++
+		return nil, nil
+	}
+	h.hashed += info.Size()
+
+	f, err := h.fsys.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	sums, err := h.cfg.Digest(f)
+	if err != nil {
+		return nil, err
+	}
+
+	var digests []*extractor.FileDigest
+	for _, alg := range h.cfg.Algorithms {
+		sum, ok := sums[alg]
+		if !ok {
+			continue
+		}
+		digests = append(digests, &extractor.FileDigest{Algorithm: string(alg), Digest: sum})
+		delete(sums, alg)
+	}
+	h.digests[path] = digests
+	return digests, nil
+}
@@ -0,0 +1,162 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filehashes_test
+
+import (
+	"context"
+	"io/fs"
+	"testing"
+	"testing/fstest"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/osv-scalibr/annotator"
+	"github.com/google/osv-scalibr/annotator/misc/filehashes"
+	"github.com/google/osv-scalibr/extractor"
+	scalibrfs "github.com/google/osv-scalibr/fs"
+	"github.com/google/osv-scalibr/hashing"
+	"github.com/google/osv-scalibr/inventory"
+)
+
+const (
+	helloSHA256 = "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"
+	helloSHA1   = "aaf4c61ddcc5e8a2dabede0f3b482cd9aea9434d"
+	helloMD5    = "5d41402abc4b2a76b9719d911017c592"
+	worldSHA256 = "486ea46224d1bb4fb680f34f7c9ad96a8f24ec88be73ea8e5a6c65260e9cb8a7"
+)
+
+func testFS() fstest.MapFS {
+	return fstest.MapFS{
+		"app/lib/guava.jar":   {Data: []byte("hello")},
+		"app/lib/shaded.jar":  {Data: []byte("hello")},
+		"usr/bin/tool":        {Data: []byte("world")},
+		"site-packages/flask": {Mode: fs.ModeDir},
+	}
+}
+
+func TestAnnotate(t *testing.T) {
+	tests := []struct {
+		desc      string
+		hashing   *hashing.Config
+		maxBytes  int64
+		locations []string
+		existing  []*extractor.FileDigest
+		want      []*extractor.FileDigest
+		wantErr   bool
+	}{
+		{
+			desc:      "default_sha256",
+			locations: []string{"app/lib/guava.jar"},
+			want: []*extractor.FileDigest{
+				{Location: "app/lib/guava.jar", Algorithm: "sha256", Digest: helloSHA256},
+			},
+		},
+		{
+			desc:      "configured_algorithms",
+			hashing:   &hashing.Config{Algorithms: []hashing.Algorithm{hashing.SHA256, hashing.SHA1, hashing.MD5}},
+			locations: []string{"app/lib/guava.jar"},
+			want: []*extractor.FileDigest{
+				{Location: "app/lib/guava.jar", Algorithm: "sha256", Digest: helloSHA256},
+				{Location: "app/lib/guava.jar", Algorithm: "sha1", Digest: helloSHA1},
+				{Location: "app/lib/guava.jar", Algorithm: "md5", Digest: helloMD5},
+			},
+		},
+		{
+			desc:      "absolute_location",
+			locations: []string{"/root/usr/bin/tool"},
+			want: []*extractor.FileDigest{
+				{Location: "/root/usr/bin/tool", Algorithm: "sha256", Digest: worldSHA256},
+			},
+		},
+		{
+			desc:      "missing_and_nested_locations_skipped",
+			locations: []string{"app/lib/missing.jar", "app/app.war:WEB-INF/lib/lib.jar", "site-packages/flask"},
+		},
+		{
+			desc:      "over_budget",
+			maxBytes:  7,
+			locations: []string{"app/lib/guava.jar", "usr/bin/tool", "app/lib/shaded.jar"},
+			want: []*extractor.FileDigest{
+				{Location: "app/lib/guava.jar", Algorithm: "sha256", Digest: helloSHA256},
+			},
+		},
+		{
+			desc:      "existing_digest_not_duplicated",
+			locations: []string{"app/lib/guava.jar"},
+			existing: []*extractor.FileDigest{
+				{Location: "app/lib/guava.jar", Algorithm: "sha256", Digest: helloSHA256},
+			},
+			want: []*extractor.FileDigest{
+				{Location: "app/lib/guava.jar", Algorithm: "sha256", Digest: helloSHA256},
+			},
+		},
+		{
+			desc:      "md5_in_fips_mode",
+			hashing:   &hashing.Config{Algorithms: []hashing.Algorithm{hashing.MD5}, FIPSMode: true},
+			locations: []string{"app/lib/guava.jar"},
+			wantErr:   true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			a := filehashes.New().(*filehashes.Annotator)
+			if tc.hashing != nil {
+				a.SetHashingConfig(tc.hashing)
+			}
+			if tc.maxBytes != 0 {
+				a.MaxBytes = tc.maxBytes
+			}
+			pkg := &extractor.Package{Name: "pkg", Locations: tc.locations, FileDigests: tc.existing}
+			input := &annotator.ScanInput{ScanRoot: &scalibrfs.ScanRoot{FS: testFS(), Path: "/root"}}
+			err := a.Annotate(context.Background(), input, &inventory.Inventory{Packages: []*extractor.Package{pkg}})
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("Annotate() error: %v, want error: %t", err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.want, pkg.FileDigests); diff != "" {
+				t.Errorf("Annotate() unexpected digests (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestAnnotate_SharedLocation(t *testing.T) {
+	pkgs := []*extractor.Package{
+		{Name: "guava", Locations: []string{"app/lib/guava.jar"}},
+		{Name: "failureaccess", Locations: []string{"app/lib/guava.jar"}},
+	}
+	a := filehashes.New().(*filehashes.Annotator)
+	// The budget only fits one read of the file.
+	a.MaxBytes = 5
+	input := &annotator.ScanInput{ScanRoot: &scalibrfs.ScanRoot{FS: testFS()}}
+	if err := a.Annotate(context.Background(), input, &inventory.Inventory{Packages: pkgs}); err != nil {
+		t.Fatalf("Annotate(): %v", err)
+	}
+	want := []*extractor.FileDigest{{Location: "app/lib/guava.jar", Algorithm: "sha256", Digest: helloSHA256}}
+	for _, pkg := range pkgs {
+		if diff := cmp.Diff(want, pkg.FileDigests); diff != "" {
+			t.Errorf("Annotate() unexpected digests of %s (-want +got):\n%s", pkg.Name, diff)
+		}
+	}
+}
+
+func TestAnnotate_NoScanRoot(t *testing.T) {
+	pkg := &extractor.Package{Name: "pkg", Locations: []string{"app/lib/guava.jar"}}
+	if err := filehashes.New().Annotate(context.Background(), &annotator.ScanInput{}, &inventory.Inventory{Packages: []*extractor.Package{pkg}}); err != nil {
+		t.Fatalf("Annotate(): %v", err)
+	}
+	if pkg.FileDigests != nil {
+		t.Errorf("Annotate() added digests %v without a scan root", pkg.FileDigests)
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ownership
+
+import (
+	"bufio"
+	"errors"
+	"io/fs"
+	"path"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing/format/gitignore"
+	scalibrfs "github.com/google/osv-scalibr/fs"
+)
+
+// codeownersLocations are the places a CODEOWNERS file is looked up in,
+// relative to the repository root, in GitHub's order of precedence.
+var codeownersLocations = []string{".github/CODEOWNERS", "CODEOWNERS", "docs/CODEOWNERS", ".gitlab/CODEOWNERS"}
+
+// codeownersFile is a parsed CODEOWNERS file.
+type codeownersFile struct {
+	// The path of the file in the scanned filesystem.
+	path string
+	// The directory the patterns are relative to.
+	root  string
+	rules []codeownersRule
+}
+
+type codeownersRule struct {
+	pattern gitignore.Pattern
+	owners  []string
+}
+
+// readCodeowners parses the CODEOWNERS file of the repository rooted at dir.
+// Returns nil if there is none.
+func readCodeowners(fsys scalibrfs.FS, dir string) (*codeownersFile, error) {
+	for _, loc := range codeownersLocations {
+		p := path.Join(dir, loc)
+		f, err := fsys.Open(p)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		co := &codeownersFile{path: p, root: dir}
+		s := bufio.NewScanner(f)
+		for s.Scan() {
+			if rule, ok := parseCodeownersLine(s.Text()); ok {
+				co.rules = append(co.rules, rule)
+			}
+		}
+		return co, s.Err()
+	}
+	return nil, nil
+}
+
+// parseCodeownersLine parses a "pattern owner..." line. Comments, GitLab
+// section headers and negated patterns, which CODEOWNERS doesn't support, are
+// skipped. A pattern without owners removes the ownership of the matched files.
+func parseCodeownersLine(line string) (codeownersRule, bool) {
+	if i := strings.Index(line, "#"); i >= 0 {
+		line = line[:i]
+	}
+	fields := strings.Fields(line)
+	if len(fields) == 0 || strings.HasPrefix(fields[0], "!") ||
+		strings.HasPrefix(fields[0], "[") || strings.HasPrefix(fields[0], "^[") {
+		return codeownersRule{}, false
+	}
+	return codeownersRule{
+		pattern: gitignore.ParsePattern(fields[0], nil),
+		owners:  fields[1:],
+	}, true
+}
+
+// owners returns the owners of the file at path p. The last matching rule
+// takes precedence.
+func (c *codeownersFile) owners(p string) []string {
+	rel := p
+	if c.root != "." {
+		rel = strings.TrimPrefix(p, c.root+"/")
+	}
+	parts := strings.Split(rel, "/")
+	for i := len(c.rules) - 1; i >= 0; i-- {
+		r := c.rules[i]
+		if r.pattern.Match(parts, false) != gitignore.NoMatch {
+			return r.owners
+		}
+		// Directory patterns match all files below the directory.
+		for j := len(parts) - 1; j > 0; j-- {
+			if r.pattern.Match(parts[:j], true) != gitignore.NoMatch {
+				return r.owners
+			}
+		}
+	}
+	return nil
+}
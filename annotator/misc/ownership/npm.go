@@ -0,0 +1,100 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ownership
+
+import (
+	"encoding/json"
+	"fmt"
+
+	scalibrfs "github.com/google/osv-scalibr/fs"
+)
+
+// maxPackageJSONSize is the maximum size of package.json files that are parsed.
+const maxPackageJSONSize = 10 << 20
+
+// npmOwners are the people listed in the package.json file of an npm project.
+type npmOwners struct {
+	// The path of the package.json file in the scanned filesystem.
+	path   string
+	owners []string
+}
+
+// person is an author or maintainer of an npm package, given either as a
+// "Name <email> (url)" string or as an object.
+type person struct {
+	Name  string `json:"name"`
+	Email string `json:"email"`
+	str   string
+}
+
+func (p *person) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &p.str); err == nil {
+		return nil
+	}
+	type obj person
+	return json.Unmarshal(data, (*obj)(p))
+}
+
+func (p *person) String() string {
+	switch {
+	case p.str != "":
+		return p.str
+	case p.Email == "":
+		return p.Name
+	case p.Name == "":
+		return p.Email
+	default:
+		return fmt.Sprintf("%s <%s>", p.Name, p.Email)
+	}
+}
+
+// readNPMOwners returns the author and maintainers of the package.json file
+// at path p. Returns nil if the file doesn't list any.
+func readNPMOwners(fsys scalibrfs.FS, p string) (*npmOwners, error) {
+	info, err := fsys.Stat(p)
+	if err != nil {
+		return nil, err
+	}
+	if info.Size() > maxPackageJSONSize {
+		return nil, fmt.Errorf("%s is too large: %d bytes", p, info.Size())
+	}
+	f, err := fsys.Open(p)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var pkg struct {
+		Author      *person  `json:"author"`
+		Maintainers []person `json:"maintainers"`
+	}
+	if err := json.NewDecoder(f).Decode(&pkg); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", p, err)
+	}
+	people := pkg.Maintainers
+	if pkg.Author != nil {
+		people = append([]person{*pkg.Author}, people...)
+	}
+	var owners []string
+	for _, person := range people {
+		if s := person.String(); s != "" {
+			owners = append(owners, s)
+		}
+	}
+	if len(owners) == 0 {
+		return nil, nil
+	}
+	return &npmOwners{path: p, owners: owners}, nil
+}
@@ -0,0 +1,191 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ownership implements an annotator that attaches ownership hints to
+// the locations of packages. The owners are read from CODEOWNERS files and
+// from the author and maintainers of the enclosing npm project.
+package ownership
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/google/osv-scalibr/annotator"
+	"github.com/google/osv-scalibr/extractor"
+	scalibrfs "github.com/google/osv-scalibr/fs"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/plugin"
+)
+
+const (
+	// Name of the Annotator.
+	Name = "misc/ownership"
+)
+
+// Annotator adds ownership hints to the locations of packages.
+type Annotator struct{}
+
+// New returns a new Annotator.
+func New() annotator.Annotator { return &Annotator{} }
+
+// Name of the annotator.
+func (Annotator) Name() string { return Name }
+
+// Version of the annotator.
+func (Annotator) Version() int { return 0 }
+
+// Requirements of the annotator.
+func (Annotator) Requirements() *plugin.Capabilities { return &plugin.Capabilities{} }
+
+// Annotate adds the owners of each package location found in the scanned
+// tree to the package's ownership hints.
+func (a Annotator) Annotate(ctx context.Context, input *annotator.ScanInput, results *inventory.Inventory) error {
+	if input.ScanRoot == nil || input.ScanRoot.FS == nil {
+		return nil
+	}
+	f := &finder{
+		fsys:       input.ScanRoot.FS,
+		codeowners: map[string]*codeownersFile{},
+		npm:        map[string]*npmOwners{},
+	}
+	var errs []error
+	for _, pkg := range results.Packages {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		for _, loc := range pkg.Locations {
+			rel, err := relativePath(input.ScanRoot, loc)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", Name, err))
+				continue
+			}
+			hints, err := f.owners(rel)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: finding owners of %q: %w", Name, loc, err))
+			}
+			for _, h := range hints {
+				h.Location = loc
+				if !slices.ContainsFunc(pkg.OwnershipHints, func(o *extractor.OwnershipHint) bool { return *o == *h }) {
+					pkg.OwnershipHints = append(pkg.OwnershipHints, h)
+				}
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// relativePath returns the location relative to the scan root, as a slash
+// separated path.
+func relativePath(root *scalibrfs.ScanRoot, loc string) (string, error) {
+	if filepath.IsAbs(loc) && root.Path != "" {
+		rel, err := filepath.Rel(root.Path, loc)
+		if err != nil {
+			return "", fmt.Errorf("failed to get relative path for %q from base %q: %w", loc, root.Path, err)
+		}
+		loc = rel
+	}
+	return strings.TrimPrefix(filepath.ToSlash(loc), "/"), nil
+}
+
+// finder looks up the owners of paths in the scanned filesystem. The parsed
+// owner files are cached by directory.
+type finder struct {
+	fsys       scalibrfs.FS
+	codeowners map[string]*codeownersFile
+	npm        map[string]*npmOwners
+}
+
+// owners returns the ownership hints for the file at the given path. The
+// Location field of the hints is left empty.
+func (f *finder) owners(p string) ([]*extractor.OwnershipHint, error) {
+	var hints []*extractor.OwnershipHint
+	var errs []error
+	dir := path.Dir(p)
+
+	co, err := f.nearestCodeowners(dir)
+	if err != nil {
+		errs = append(errs, err)
+	}
+	if co != nil {
+		for _, owner := range co.owners(p) {
+			hints = append(hints, &extractor.OwnershipHint{Owner: owner, Source: co.path})
+		}
+	}
+
+	npm, err := f.nearestNPMProject(dir)
+	if err != nil {
+		errs = append(errs, err)
+	}
+	if npm != nil {
+		for _, owner := range npm.owners {
+			hints = append(hints, &extractor.OwnershipHint{Owner: owner, Source: npm.path})
+		}
+	}
+	return hints, errors.Join(errs...)
+}
+
+// nearestCodeowners returns the CODEOWNERS file of the closest directory at or
+// above dir that has one, or nil if there is none.
+func (f *finder) nearestCodeowners(dir string) (*codeownersFile, error) {
+	for {
+		co, ok := f.codeowners[dir]
+		if !ok {
+			var err error
+			co, err = readCodeowners(f.fsys, dir)
+			if err != nil {
+				return nil, err
+			}
+			f.codeowners[dir] = co
+		}
+		if co != nil {
+			return co, nil
+		}
+		if dir == "." {
+			return nil, nil
+		}
+		dir = path.Dir(dir)
+	}
+}
+
+// nearestNPMProject returns the owners of the closest npm project at or above
+// dir. Directories inside node_modules contain dependencies rather than the
+// project and are skipped.
+func (f *finder) nearestNPMProject(dir string) (*npmOwners, error) {
+	for {
+		if !slices.Contains(strings.Split(dir, "/"), "node_modules") {
+			npm, ok := f.npm[dir]
+			if !ok {
+				var err error
+				npm, err = readNPMOwners(f.fsys, path.Join(dir, "package.json"))
+				if err != nil && !errors.Is(err, fs.ErrNotExist) {
+					return nil, err
+				}
+				f.npm[dir] = npm
+			}
+			if npm != nil {
+				return npm, nil
+			}
+		}
+		if dir == "." {
+			return nil, nil
+		}
+		dir = path.Dir(dir)
+	}
+}
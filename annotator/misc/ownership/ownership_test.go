@@ -0,0 +1,149 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ownership_test
+
+import (
+	"context"
+	"testing"
+	"testing/fstest"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/osv-scalibr/annotator"
+	"github.com/google/osv-scalibr/annotator/misc/ownership"
+	"github.com/google/osv-scalibr/extractor"
+	scalibrfs "github.com/google/osv-scalibr/fs"
+	"github.com/google/osv-scalibr/inventory"
+)
+
+const codeowners = `# Default owners.
+*                   @org/everyone
+/services/          @org/backend   # Backend services.
+/services/legacy/
+*.md                @org/docs docs@example.com
+[Frontend]
+/web/**/*.js        @org/frontend
+!ignored
+`
+
+func testFS() fstest.MapFS {
+	return fstest.MapFS{
+		".github/CODEOWNERS": {Data: []byte(codeowners)},
+		"web/package.json": {Data: []byte(`{
+			"author": "Jane Doe <jane@example.com>",
+			"maintainers": [{"name": "John Roe", "email": "john@example.com"}, {"name": "Jim"}]
+		}`)},
+		"web/node_modules/left-pad/package.json": {Data: []byte(`{"author": "Someone Else"}`)},
+		"web/src/app.js":                         {},
+		"vendor/lib/CODEOWNERS":                  {Data: []byte("* @lib-maintainers\n")},
+		"vendor/lib/go.mod":                      {},
+		"broken/package.json":                    {Data: []byte(`{`)},
+	}
+}
+
+func TestAnnotate(t *testing.T) {
+	tests := []struct {
+		desc       string
+		locations  []string
+		existing   []*extractor.OwnershipHint
+		want       []*extractor.OwnershipHint
+		wantAnyErr bool
+	}{
+		{
+			desc:      "default_owner",
+			locations: []string{"go.mod"},
+			want: []*extractor.OwnershipHint{
+				{Location: "go.mod", Owner: "@org/everyone", Source: ".github/CODEOWNERS"},
+			},
+		},
+		{
+			desc:      "directory_owner",
+			locations: []string{"services/api/go.mod"},
+			want: []*extractor.OwnershipHint{
+				{Location: "services/api/go.mod", Owner: "@org/backend", Source: ".github/CODEOWNERS"},
+			},
+		},
+		{
+			desc:      "unowned_directory",
+			locations: []string{"services/legacy/go.mod"},
+		},
+		{
+			desc:      "multiple_owners",
+			locations: []string{"services/README.md"},
+			want: []*extractor.OwnershipHint{
+				{Location: "services/README.md", Owner: "@org/docs", Source: ".github/CODEOWNERS"},
+				{Location: "services/README.md", Owner: "docs@example.com", Source: ".github/CODEOWNERS"},
+			},
+		},
+		{
+			desc:      "codeowners_and_package_json",
+			locations: []string{"web/node_modules/left-pad/index.js"},
+			want: []*extractor.OwnershipHint{
+				{Location: "web/node_modules/left-pad/index.js", Owner: "@org/frontend", Source: ".github/CODEOWNERS"},
+				{Location: "web/node_modules/left-pad/index.js", Owner: "Jane Doe <jane@example.com>", Source: "web/package.json"},
+				{Location: "web/node_modules/left-pad/index.js", Owner: "John Roe <john@example.com>", Source: "web/package.json"},
+				{Location: "web/node_modules/left-pad/index.js", Owner: "Jim", Source: "web/package.json"},
+			},
+		},
+		{
+			desc:      "nested_codeowners",
+			locations: []string{"vendor/lib/go.mod"},
+			want: []*extractor.OwnershipHint{
+				{Location: "vendor/lib/go.mod", Owner: "@lib-maintainers", Source: "vendor/lib/CODEOWNERS"},
+			},
+		},
+		{
+			desc:      "absolute_location",
+			locations: []string{"/root/go.mod"},
+			want: []*extractor.OwnershipHint{
+				{Location: "/root/go.mod", Owner: "@org/everyone", Source: ".github/CODEOWNERS"},
+			},
+		},
+		{
+			desc:      "existing_hints_are_not_duplicated",
+			locations: []string{"go.mod", "go.sum"},
+			existing: []*extractor.OwnershipHint{
+				{Location: "go.mod", Owner: "@org/everyone", Source: ".github/CODEOWNERS"},
+			},
+			want: []*extractor.OwnershipHint{
+				{Location: "go.mod", Owner: "@org/everyone", Source: ".github/CODEOWNERS"},
+				{Location: "go.sum", Owner: "@org/everyone", Source: ".github/CODEOWNERS"},
+			},
+		},
+		{
+			desc:      "invalid_package_json",
+			locations: []string{"broken/go.mod"},
+			want: []*extractor.OwnershipHint{
+				{Location: "broken/go.mod", Owner: "@org/everyone", Source: ".github/CODEOWNERS"},
+			},
+			wantAnyErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			pkg := &extractor.Package{Name: "pkg", Locations: tc.locations, OwnershipHints: tc.existing}
+			inv := &inventory.Inventory{Packages: []*extractor.Package{pkg}}
+			input := &annotator.ScanInput{ScanRoot: &scalibrfs.ScanRoot{FS: testFS(), Path: "/root"}}
+
+			err := ownership.New().Annotate(context.Background(), input, inv)
+			if (err != nil) != tc.wantAnyErr {
+				t.Errorf("Annotate(%v) error: %v, want error presence: %t", tc.locations, err, tc.wantAnyErr)
+			}
+			if diff := cmp.Diff(tc.want, pkg.OwnershipHints); diff != "" {
+				t.Errorf("Annotate(%v) returned unexpected ownership hints (-want +got):\n%s", tc.locations, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,291 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package appliance opens virtual appliances (OVA and OVF bundles) and
+// standalone VHD and VHDX disk images so that the filesystems on their virtual
+// disks can be scanned, with the appliance's product metadata attached to the
+// results.
+package appliance
+
+import (
+	"archive/tar"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	scalibrfs "github.com/google/osv-scalibr/fs"
+)
+
+// ErrNoDiskOpener is returned when the filesystem of a disk is requested
+// without a DiskOpener that can read it.
+var ErrNoDiskOpener = errors.New("no disk opener configured")
+
+// Metadata describes the virtual appliance, as declared in its OVF descriptor.
+type Metadata struct {
+	// The ID of the virtual system, e.g. "vm-name".
+	Name string
+	// The product name, vendor and version from the ProductSection.
+	Product string
+	Vendor  string
+	Version string
+	// The description of the guest operating system, e.g. "Ubuntu Linux (64-bit)".
+	OperatingSystem string
+}
+
+// Disk is a virtual disk of an appliance.
+type Disk struct {
+	// The ID of the disk in the OVF descriptor, or the file name for standalone
+	// disk images.
+	ID string
+	// The file name of the disk image, relative to the appliance.
+	FileName string
+	// The format of the disk image.
+	Format Format
+	// The capacity of the virtual disk in bytes, or 0 if unknown.
+	CapacityBytes int64
+	// The compression of the disk image file declared in the OVF descriptor,
+	// e.g. "gzip". Empty if the file isn't compressed.
+	Compression string
+
+	r *io.SectionReader
+}
+
+// Reader returns a reader for the raw contents of the disk image file.
+func (d *Disk) Reader() *io.SectionReader {
+	return io.NewSectionReader(d.r, 0, d.r.Size())
+}
+
+// Appliance is an opened virtual appliance or disk image.
+type Appliance struct {
+	// The path the appliance was opened from.
+	Path string
+	// The metadata of the appliance. Nil for standalone disk images.
+	Metadata *Metadata
+	// The virtual disks of the appliance.
+	Disks []*Disk
+
+	files []*os.File
+}
+
+// DiskOpener returns the filesystem stored on a virtual disk.
+type DiskOpener func(d *Disk) (scalibrfs.FS, error)
+
+// Open opens the OVA, OVF, VHD or VHDX file at the given path. OVA archives
+// are read in place, without unpacking the contained disks. The appliance
+// has to be closed after use.
+func Open(p string) (*Appliance, error) {
+	a := &Appliance{Path: p}
+	var err error
+	switch strings.ToLower(filepath.Ext(p)) {
+	case ".ova":
+		err = a.openOVA(p)
+	case ".ovf":
+		err = a.openOVF(p)
+	case ".vhd", ".vhdx":
+		err = a.openDiskImage(p)
+	default:
+		err = fmt.Errorf("unsupported appliance file %q: want .ova, .ovf, .vhd or .vhdx", p)
+	}
+	if err != nil {
+		a.Close()
+		return nil, err
+	}
+	return a, nil
+}
+
+// Close closes the files of the appliance.
+func (a *Appliance) Close() error {
+	var errs []error
+	for _, f := range a.files {
+		errs = append(errs, f.Close())
+	}
+	a.files = nil
+	return errors.Join(errs...)
+}
+
+// DiskFS returns the filesystem stored on the given disk using the provided
+// opener.
+func (a *Appliance) DiskFS(d *Disk, open DiskOpener) (scalibrfs.FS, error) {
+	if open == nil {
+		return nil, ErrNoDiskOpener
+	}
+	if d.Compression != "" {
+		return nil, fmt.Errorf("disk %q: %s compressed disks are not supported", d.ID, d.Compression)
+	}
+	fsys, err := open(d)
+	if err != nil {
+		return nil, fmt.Errorf("disk %q: %w", d.ID, err)
+	}
+	return fsys, nil
+}
+
+func (a *Appliance) openFile(p string) (*os.File, error) {
+	f, err := os.Open(p)
+	if err != nil {
+		return nil, err
+	}
+	a.files = append(a.files, f)
+	return f, nil
+}
+
+func (a *Appliance) openDiskImage(p string) error {
+	f, err := a.openFile(p)
+	if err != nil {
+		return err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	r := io.NewSectionReader(f, 0, info.Size())
+	format, err := DetectFormat(r)
+	if err != nil {
+		return err
+	}
+	name := filepath.Base(p)
+	a.Disks = []*Disk{{ID: name, FileName: name, Format: format, r: r}}
+	return nil
+}
+
+func (a *Appliance) openOVF(p string) error {
+	f, err := a.openFile(p)
+	if err != nil {
+		return err
+	}
+	env, err := parseOVF(f)
+	if err != nil {
+		return err
+	}
+	dir := filepath.Dir(p)
+	return a.addDisks(env, func(name string) (*io.SectionReader, error) {
+		f, err := a.openFile(filepath.Join(dir, filepath.FromSlash(name)))
+		if err != nil {
+			return nil, err
+		}
+		info, err := f.Stat()
+		if err != nil {
+			return nil, err
+		}
+		return io.NewSectionReader(f, 0, info.Size()), nil
+	})
+}
+
+// openOVA indexes the members of an OVA archive. OVA files are uncompressed
+// tar archives, so the disks can be read directly from the archive.
+func (a *Appliance) openOVA(p string) error {
+	f, err := a.openFile(p)
+	if err != nil {
+		return err
+	}
+	members := map[string]*io.SectionReader{}
+	var descriptor string
+	pr := &positionReader{r: f}
+	tr := tar.NewReader(pr)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("reading OVA archive: %w", err)
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		name := path.Clean(hdr.Name)
+		members[name] = io.NewSectionReader(f, pr.pos, hdr.Size)
+		// The OVF descriptor is the first file of the archive.
+		if descriptor == "" && strings.EqualFold(path.Ext(name), ".ovf") {
+			descriptor = name
+		}
+	}
+	if descriptor == "" {
+		return errors.New("no OVF descriptor found in OVA archive")
+	}
+	env, err := parseOVF(members[descriptor])
+	if err != nil {
+		return err
+	}
+	dir := path.Dir(descriptor)
+	return a.addDisks(env, func(name string) (*io.SectionReader, error) {
+		r, ok := members[path.Join(dir, name)]
+		if !ok {
+			return nil, fmt.Errorf("%q not found in OVA archive", name)
+		}
+		return r, nil
+	})
+}
+
+// addDisks sets the appliance metadata and adds the disks declared in the
+// OVF descriptor, reading their files with the given function.
+func (a *Appliance) addDisks(env *envelope, open func(name string) (*io.SectionReader, error)) error {
+	a.Metadata = env.metadata()
+	for _, d := range env.disks() {
+		if !isLocalPath(d.href) {
+			return fmt.Errorf("disk %q: unsupported file reference %q", d.id, d.href)
+		}
+		r, err := open(d.href)
+		if err != nil {
+			return fmt.Errorf("disk %q: %w", d.id, err)
+		}
+		disk := &Disk{
+			ID:            d.id,
+			FileName:      d.href,
+			Format:        d.format,
+			CapacityBytes: d.capacity,
+			Compression:   d.compression,
+			r:             r,
+		}
+		if disk.Compression == "" {
+			if disk.Format, err = DetectFormat(r); err != nil {
+				return fmt.Errorf("disk %q: %w", d.id, err)
+			}
+		}
+		a.Disks = append(a.Disks, disk)
+	}
+	return nil
+}
+
+// isLocalPath returns whether the file reference of an OVF descriptor points
+// to a file next to the descriptor, as opposed to a URL or a path outside of
+// the appliance.
+func isLocalPath(href string) bool {
+	return href != "" && !strings.Contains(href, ":") && filepath.IsLocal(filepath.FromSlash(href))
+}
+
+// positionReader tracks the offset of the underlying file so that the
+// position of the tar members can be determined.
+type positionReader struct {
+	r   io.ReadSeeker
+	pos int64
+}
+
+func (p *positionReader) Read(b []byte) (int, error) {
+	n, err := p.r.Read(b)
+	p.pos += int64(n)
+	return n, err
+}
+
+// Seek lets the tar reader skip over the disk contents without reading them.
+func (p *positionReader) Seek(offset int64, whence int) (int64, error) {
+	pos, err := p.r.Seek(offset, whence)
+	if err == nil {
+		p.pos = pos
+	}
+	return pos, err
+}
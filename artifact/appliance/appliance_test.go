@@ -0,0 +1,277 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package appliance_test
+
+import (
+	"archive/tar"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/osv-scalibr/artifact/appliance"
+	scalibrfs "github.com/google/osv-scalibr/fs"
+)
+
+const descriptor = `<?xml version="1.0" encoding="UTF-8"?>
+<Envelope xmlns="http://schemas.dmtf.org/ovf/envelope/1" xmlns:ovf="http://schemas.dmtf.org/ovf/envelope/1">
+  <References>
+    <File ovf:id="file1" ovf:href="appliance-disk1.vmdk"/>
+    <File ovf:id="file2" ovf:href="appliance-disk2.vmdk" ovf:compression="gzip"/>
+  </References>
+  <DiskSection>
+    <Info>Virtual disk information</Info>
+    <Disk ovf:diskId="vmdisk1" ovf:fileRef="file1" ovf:capacity="16" ovf:capacityAllocationUnits="byte * 2^30" ovf:format="http://www.vmware.com/interfaces/specifications/vmdk.html#streamOptimized"/>
+    <Disk ovf:diskId="vmdisk2" ovf:fileRef="file2" ovf:capacity="1048576" ovf:format="http://www.vmware.com/interfaces/specifications/vmdk.html#streamOptimized"/>
+    <Disk ovf:diskId="scratch" ovf:capacity="1" ovf:capacityAllocationUnits="byte * 2^30"/>
+  </DiskSection>
+  <VirtualSystem ovf:id="gateway-vm">
+    <Info>A virtual machine</Info>
+    <ProductSection>
+      <Info>Product information</Info>
+      <Product>Example Gateway</Product>
+      <Vendor>Example Corp</Vendor>
+      <Version>4.2.1</Version>
+    </ProductSection>
+    <OperatingSystemSection ovf:id="96">
+      <Info>The guest operating system</Info>
+      <Description>Ubuntu Linux (64-bit)</Description>
+    </OperatingSystemSection>
+  </VirtualSystem>
+</Envelope>
+`
+
+var (
+	vmdkContent = append([]byte("KDMV"), make([]byte, 60)...)
+	gzipContent = []byte("\x1f\x8b compressed disk")
+	wantMeta    = &appliance.Metadata{
+		Name:            "gateway-vm",
+		Product:         "Example Gateway",
+		Vendor:          "Example Corp",
+		Version:         "4.2.1",
+		OperatingSystem: "Ubuntu Linux (64-bit)",
+	}
+	wantDisks = []*appliance.Disk{
+		{
+			ID:            "vmdisk1",
+			FileName:      "appliance-disk1.vmdk",
+			Format:        appliance.FormatVMDK,
+			CapacityBytes: 16 << 30,
+		},
+		{
+			ID:            "vmdisk2",
+			FileName:      "appliance-disk2.vmdk",
+			Format:        appliance.FormatVMDK,
+			CapacityBytes: 1048576,
+			Compression:   "gzip",
+		},
+	}
+)
+
+type file struct {
+	name    string
+	content []byte
+}
+
+func applianceFiles() []file {
+	return []file{
+		{"appliance.ovf", []byte(descriptor)},
+		{"appliance.mf", []byte("SHA256(appliance.ovf)= 0000\n")},
+		{"appliance-disk1.vmdk", vmdkContent},
+		{"appliance-disk2.vmdk", gzipContent},
+	}
+}
+
+func writeOVA(t *testing.T, files []file) string {
+	t.Helper()
+	p := filepath.Join(t.TempDir(), "appliance.ova")
+	f, err := os.Create(p)
+	if err != nil {
+		t.Fatalf("os.Create(%q): %v", p, err)
+	}
+	defer f.Close()
+	tw := tar.NewWriter(f)
+	for _, file := range files {
+		hdr := &tar.Header{Name: file.name, Mode: 0644, Size: int64(len(file.content)), Typeflag: tar.TypeReg}
+		if err := tw.WriteHeader(hdr); err != nil {
+			t.Fatalf("WriteHeader(%q): %v", file.name, err)
+		}
+		if _, err := tw.Write(file.content); err != nil {
+			t.Fatalf("Write(%q): %v", file.name, err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatalf("tar.Close(): %v", err)
+	}
+	return p
+}
+
+func writeFiles(t *testing.T, files []file) string {
+	t.Helper()
+	dir := t.TempDir()
+	for _, file := range files {
+		if err := os.WriteFile(filepath.Join(dir, file.name), file.content, 0644); err != nil {
+			t.Fatalf("os.WriteFile(%q): %v", file.name, err)
+		}
+	}
+	return dir
+}
+
+func readDisk(t *testing.T, d *appliance.Disk) []byte {
+	t.Helper()
+	content, err := io.ReadAll(d.Reader())
+	if err != nil {
+		t.Fatalf("ReadAll(%q): %v", d.ID, err)
+	}
+	return content
+}
+
+func TestOpen(t *testing.T) {
+	vhd := make([]byte, 2048)
+	copy(vhd[len(vhd)-512:], "conectix")
+	vhdx := append([]byte("vhdxfile"), make([]byte, 100)...)
+	dir := writeFiles(t, append(applianceFiles(),
+		file{"disk.vhd", vhd},
+		file{"disk.vhdx", vhdx},
+		file{"disk.img", []byte("raw")},
+	))
+
+	tests := []struct {
+		desc        string
+		path        string
+		wantMeta    *appliance.Metadata
+		wantDisks   []*appliance.Disk
+		wantContent [][]byte
+	}{
+		{
+			desc:        "ova",
+			path:        writeOVA(t, applianceFiles()),
+			wantMeta:    wantMeta,
+			wantDisks:   wantDisks,
+			wantContent: [][]byte{vmdkContent, gzipContent},
+		},
+		{
+			desc:        "ovf",
+			path:        filepath.Join(dir, "appliance.ovf"),
+			wantMeta:    wantMeta,
+			wantDisks:   wantDisks,
+			wantContent: [][]byte{vmdkContent, gzipContent},
+		},
+		{
+			desc:        "fixed_vhd",
+			path:        filepath.Join(dir, "disk.vhd"),
+			wantDisks:   []*appliance.Disk{{ID: "disk.vhd", FileName: "disk.vhd", Format: appliance.FormatVHD}},
+			wantContent: [][]byte{vhd},
+		},
+		{
+			desc:        "vhdx",
+			path:        filepath.Join(dir, "disk.vhdx"),
+			wantDisks:   []*appliance.Disk{{ID: "disk.vhdx", FileName: "disk.vhdx", Format: appliance.FormatVHDX}},
+			wantContent: [][]byte{vhdx},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			a, err := appliance.Open(tc.path)
+			if err != nil {
+				t.Fatalf("Open(%q): %v", tc.path, err)
+			}
+			defer a.Close()
+
+			if diff := cmp.Diff(tc.wantMeta, a.Metadata); diff != "" {
+				t.Errorf("Open(%q) returned unexpected metadata (-want +got):\n%s", tc.path, diff)
+			}
+			if diff := cmp.Diff(tc.wantDisks, a.Disks, cmpopts.IgnoreUnexported(appliance.Disk{})); diff != "" {
+				t.Errorf("Open(%q) returned unexpected disks (-want +got):\n%s", tc.path, diff)
+			}
+			for i, d := range a.Disks {
+				if i >= len(tc.wantContent) {
+					break
+				}
+				if diff := cmp.Diff(tc.wantContent[i], readDisk(t, d)); diff != "" {
+					t.Errorf("Disk %q has unexpected content (-want +got):\n%s", d.ID, diff)
+				}
+			}
+		})
+	}
+}
+
+func TestOpen_Errors(t *testing.T) {
+	outside := []file{{"appliance.ovf", []byte(`<Envelope>
+  <References><File id="file1" href="../disk.vmdk"/></References>
+  <DiskSection><Disk diskId="vmdisk1" fileRef="file1"/></DiskSection>
+</Envelope>`)}}
+	url := []file{{"appliance.ovf", []byte(`<Envelope>
+  <References><File id="file1" href="https://example.com/disk.vmdk"/></References>
+  <DiskSection><Disk diskId="vmdisk1" fileRef="file1"/></DiskSection>
+</Envelope>`)}}
+	dir := writeFiles(t, []file{{"disk.qcow2", []byte("QFI\xfb")}})
+
+	tests := []struct {
+		desc string
+		path string
+	}{
+		{desc: "unsupported_extension", path: filepath.Join(dir, "disk.qcow2")},
+		{desc: "missing_file", path: filepath.Join(dir, "missing.ova")},
+		{desc: "no_descriptor", path: writeOVA(t, []file{{"disk.vmdk", vmdkContent}})},
+		{desc: "missing_disk", path: writeOVA(t, applianceFiles()[:2])},
+		{desc: "disk_outside_of_appliance", path: writeOVA(t, outside)},
+		{desc: "disk_url", path: filepath.Join(writeFiles(t, url), "appliance.ovf")},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			if a, err := appliance.Open(tc.path); err == nil {
+				a.Close()
+				t.Errorf("Open(%q) succeeded, want error", tc.path)
+			}
+		})
+	}
+}
+
+func TestDiskFS(t *testing.T) {
+	a, err := appliance.Open(writeOVA(t, applianceFiles()))
+	if err != nil {
+		t.Fatalf("Open(): %v", err)
+	}
+	defer a.Close()
+
+	diskFS := fstest.MapFS{"etc/os-release": {Data: []byte("ID=ubuntu")}}
+	opener := func(d *appliance.Disk) (scalibrfs.FS, error) {
+		if d.Format != appliance.FormatVMDK {
+			return nil, errors.New("unsupported format")
+		}
+		return diskFS, nil
+	}
+
+	got, err := a.DiskFS(a.Disks[0], opener)
+	if err != nil {
+		t.Fatalf("DiskFS(%q): %v", a.Disks[0].ID, err)
+	}
+	if _, err := got.Stat("etc/os-release"); err != nil {
+		t.Errorf("DiskFS(%q).Stat(etc/os-release): %v", a.Disks[0].ID, err)
+	}
+	if _, err := a.DiskFS(a.Disks[0], nil); !errors.Is(err, appliance.ErrNoDiskOpener) {
+		t.Errorf("DiskFS(%q) without opener returned %v, want %v", a.Disks[0].ID, err, appliance.ErrNoDiskOpener)
+	}
+	if _, err := a.DiskFS(a.Disks[1], opener); err == nil {
+		t.Errorf("DiskFS(%q) on a compressed disk succeeded, want error", a.Disks[1].ID)
+	}
+}
@@ -0,0 +1,89 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package appliance
+
+import (
+	"bytes"
+	"io"
+	"strings"
+)
+
+// Format is the format of a virtual disk image.
+type Format string
+
+// The supported disk image formats.
+const (
+	FormatRaw   Format = "raw"
+	FormatVMDK  Format = "vmdk"
+	FormatVHD   Format = "vhd"
+	FormatVHDX  Format = "vhdx"
+	FormatQCOW2 Format = "qcow2"
+)
+
+var (
+	vmdkMagic  = []byte("KDMV")
+	qcow2Magic = []byte("QFI\xfb")
+	vhdxMagic  = []byte("vhdxfile")
+	// VHD files end with a footer starting with the "conectix" cookie. Dynamic
+	// disks also have a copy of it at the start of the file.
+	vhdMagic = []byte("conectix")
+)
+
+// vhdFooterSize is the size of the footer of VHD files.
+const vhdFooterSize = 512
+
+// DetectFormat returns the format of the disk image based on its magic
+// bytes. Images without a known header are treated as raw disks.
+func DetectFormat(r *io.SectionReader) (Format, error) {
+	header := make([]byte, 8)
+	n, err := r.ReadAt(header, 0)
+	if err != nil && err != io.EOF {
+		return "", err
+	}
+	header = header[:n]
+	switch {
+	case bytes.HasPrefix(header, vmdkMagic):
+		return FormatVMDK, nil
+	case bytes.HasPrefix(header, qcow2Magic):
+		return FormatQCOW2, nil
+	case bytes.HasPrefix(header, vhdxMagic):
+		return FormatVHDX, nil
+	case bytes.HasPrefix(header, vhdMagic):
+		return FormatVHD, nil
+	}
+	if r.Size() >= vhdFooterSize {
+		footer := make([]byte, len(vhdMagic))
+		if _, err := r.ReadAt(footer, r.Size()-vhdFooterSize); err != nil {
+			return "", err
+		}
+		if bytes.Equal(footer, vhdMagic) {
+			return FormatVHD, nil
+		}
+	}
+	return FormatRaw, nil
+}
+
+// formatFromURL returns the disk format from the format URL of an OVF disk,
+// e.g. "http://www.vmware.com/interfaces/specifications/vmdk.html#streamOptimized".
+// The format detected from the disk contents takes precedence.
+func formatFromURL(url string) Format {
+	url = strings.ToLower(url)
+	for _, f := range []Format{FormatVMDK, FormatVHDX, FormatVHD, FormatQCOW2} {
+		if strings.Contains(url, string(f)) {
+			return f
+		}
+	}
+	return FormatRaw
+}
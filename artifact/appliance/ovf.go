@@ -0,0 +1,132 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package appliance
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// maxDescriptorSize is the maximum size of OVF descriptors that are parsed.
+const maxDescriptorSize = 10 << 20
+
+// envelope is the root element of an OVF descriptor. Elements and attributes
+// are matched by their local name since both the OVF 1.x and 2.x namespaces
+// are in use.
+type envelope struct {
+	References struct {
+		Files []struct {
+			ID          string `xml:"id,attr"`
+			Href        string `xml:"href,attr"`
+			Compression string `xml:"compression,attr"`
+		} `xml:"File"`
+	} `xml:"References"`
+	DiskSection struct {
+		Disks []struct {
+			DiskID    string `xml:"diskId,attr"`
+			FileRef   string `xml:"fileRef,attr"`
+			Capacity  string `xml:"capacity,attr"`
+			Units     string `xml:"capacityAllocationUnits,attr"`
+			FormatURL string `xml:"format,attr"`
+		} `xml:"Disk"`
+	} `xml:"DiskSection"`
+	VirtualSystem struct {
+		ID             string `xml:"id,attr"`
+		ProductSection struct {
+			Product string `xml:"Product"`
+			Vendor  string `xml:"Vendor"`
+			Version string `xml:"Version"`
+		} `xml:"ProductSection"`
+		OperatingSystemSection struct {
+			Description string `xml:"Description"`
+		} `xml:"OperatingSystemSection"`
+	} `xml:"VirtualSystem"`
+}
+
+// ovfDisk is a disk declared in an OVF descriptor.
+type ovfDisk struct {
+	id          string
+	href        string
+	format      Format
+	capacity    int64
+	compression string
+}
+
+func parseOVF(r io.Reader) (*envelope, error) {
+	env := &envelope{}
+	if err := xml.NewDecoder(io.LimitReader(r, maxDescriptorSize)).Decode(env); err != nil {
+		return nil, fmt.Errorf("parsing OVF descriptor: %w", err)
+	}
+	return env, nil
+}
+
+func (e *envelope) metadata() *Metadata {
+	vs := e.VirtualSystem
+	return &Metadata{
+		Name:            vs.ID,
+		Product:         strings.TrimSpace(vs.ProductSection.Product),
+		Vendor:          strings.TrimSpace(vs.ProductSection.Vendor),
+		Version:         strings.TrimSpace(vs.ProductSection.Version),
+		OperatingSystem: strings.TrimSpace(vs.OperatingSystemSection.Description),
+	}
+}
+
+// disks returns the disks of the descriptor that are backed by a file. Empty
+// disks which are created on import don't have one.
+func (e *envelope) disks() []*ovfDisk {
+	var disks []*ovfDisk
+	for _, d := range e.DiskSection.Disks {
+		for _, f := range e.References.Files {
+			if d.FileRef == "" || f.ID != d.FileRef {
+				continue
+			}
+			disks = append(disks, &ovfDisk{
+				id:          d.DiskID,
+				href:        f.Href,
+				format:      formatFromURL(d.FormatURL),
+				capacity:    capacityBytes(d.Capacity, d.Units),
+				compression: f.Compression,
+			})
+			break
+		}
+	}
+	return disks
+}
+
+// capacityBytes converts an OVF disk capacity to bytes. The allocation units
+// are given as a programmatic unit, e.g. "byte * 2^30". Returns 0 if the
+// capacity can't be parsed.
+func capacityBytes(capacity, units string) int64 {
+	c, err := strconv.ParseInt(strings.TrimSpace(capacity), 10, 64)
+	if err != nil {
+		return 0
+	}
+	units = strings.ReplaceAll(units, " ", "")
+	if units == "" || units == "byte" {
+		return c
+	}
+	exp, ok := strings.CutPrefix(units, "byte*2^")
+	if !ok {
+		return 0
+	}
+	e, err := strconv.Atoi(exp)
+	if err != nil || e < 0 || e > 62 {
+		return 0
+	}
+	return c << e
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/google/go-containerregistry/pkg/v1/remote"
 	"github.com/google/osv-scalibr/artifact/image/require"
 	"github.com/google/osv-scalibr/artifact/image/unpack"
+	"github.com/google/osv-scalibr/inventory"
 	"github.com/opencontainers/go-digest"
 
 	scalibrfs "github.com/google/osv-scalibr/fs"
@@ -76,6 +77,15 @@ type Image interface {
 	FS() scalibrfs.FS
 }
 
+// ConfiguredImage is an Image that also provides the runtime configuration
+// from its config file.
+type ConfiguredImage interface {
+	Image
+	// ContainerImageMetadata returns the runtime configuration of the image, or
+	// nil if the image has no config file.
+	ContainerImageMetadata() *inventory.ContainerImageMetadata
+}
+
 // V1ImageFromRemoteName creates a v1.Image from a remote container image name.
 func V1ImageFromRemoteName(imageName string, imageOptions ...remote.Option) (v1.Image, error) {
 	imageName = strings.TrimPrefix(imageName, "https://")
@@ -23,10 +23,12 @@ import (
 	"fmt"
 	"io"
 	"io/fs"
+	"maps"
 	"os"
 	"path"
 	"path/filepath"
 	"runtime"
+	"slices"
 	"strings"
 
 	"archive/tar"
@@ -39,6 +41,7 @@ import (
 	"github.com/google/osv-scalibr/artifact/image/symlink"
 	"github.com/google/osv-scalibr/artifact/image/whiteout"
 	scalibrfs "github.com/google/osv-scalibr/fs"
+	"github.com/google/osv-scalibr/inventory"
 	"github.com/google/osv-scalibr/log"
 	"github.com/opencontainers/go-digest"
 )
@@ -73,6 +76,9 @@ var (
 	ErrInvalidConfig = errors.New("invalid image config")
 	// ErrNoLayersFound is returned when no layers are found in the image.
 	ErrNoLayersFound = errors.New("no layers found in image")
+	// ErrSparseFileNotStreamable is returned when a sparse file is found while streaming files
+	// from the layers, as its data can't be read from a single section of the layer.
+	ErrSparseFileNotStreamable = errors.New("sparse files can't be streamed from the layer")
 )
 
 // ========================================================
@@ -83,6 +89,10 @@ var (
 type Config struct {
 	MaxFileBytes    int64
 	MaxSymlinkDepth int
+	// StreamFromLayers serves file reads directly from the layer tar streams instead of copying
+	// the file contents to a temporary file. This avoids writing the image contents to disk, at
+	// the cost of decompressing layers again when files are read out of order.
+	StreamFromLayers bool
 }
 
 // DefaultConfig returns the default configuration to load an Image.
@@ -116,6 +126,8 @@ type Image struct {
 	size           int64
 	BaseImageIndex int
 	contentBlob    *os.File
+	layerReaders   []*layerReaderAt
+	configFile     *v1.ConfigFile
 }
 
 // FS returns the filesystem of the top-most chainlayer of the image. All available files should
@@ -157,6 +169,12 @@ func (img *Image) ChainLayers() ([]scalibrimage.ChainLayer, error) {
 
 // CleanUp removes the temporary directory used to store the image files.
 func (img *Image) CleanUp() error {
+	for _, lr := range img.layerReaders {
+		if err := lr.Close(); err != nil {
+			log.Warnf("failed to close layer stream: %v", err)
+		}
+	}
+
 	if img.contentBlob == nil {
 		return nil
 	}
@@ -173,7 +191,25 @@ func (img *Image) CleanUp() error {
 	return err
 }
 
-// Size returns the size of the underlying directory of the image in bytes.
+// ContainerImageMetadata returns the runtime configuration of the image, or nil
+// if the image has no config file.
+func (img *Image) ContainerImageMetadata() *inventory.ContainerImageMetadata {
+	if img.configFile == nil {
+		return nil
+	}
+	cfg := img.configFile.Config
+	ports := slices.Sorted(maps.Keys(cfg.ExposedPorts))
+	return &inventory.ContainerImageMetadata{
+		User:         cfg.User,
+		Entrypoint:   cfg.Entrypoint,
+		Cmd:          cfg.Cmd,
+		Env:          cfg.Env,
+		ExposedPorts: ports,
+	}
+}
+
+// Size returns the size of the underlying directory of the image in bytes. It is 0 if the files
+// are streamed from the layers.
 func (img *Image) Size() int64 {
 	return img.size
 }
@@ -283,7 +319,8 @@ func FromTarball(tarPath string, config *Config) (*Image, error) {
 // follows:
 //
 //		(1) Validates the user input image config object.
-//		(2) Retrieves v1.Layers, configFile. Creates tempPath to store the image files.
+//		(2) Retrieves v1.Layers, configFile. Creates tempPath to store the image files, unless the
+//	      files are streamed from the layers.
 //		(3) Initializes the output image and the chain layers.
 //		(4) Unpacks the layers by looping through the layers in reverse, while filling in the files
 //	      into the appropriate chain layer.
@@ -315,11 +352,6 @@ func FromV1Image(v1Image v1.Image, config *Config) (*Image, error) {
 		return nil, fmt.Errorf("failed to initialize chain layers: %w", err)
 	}
 
-	imageContentBlob, err := os.CreateTemp("", "image-blob-*")
-	if err != nil {
-		return nil, fmt.Errorf("failed to create image content file: %w", err)
-	}
-
 	baseImageIndex, err := findBaseImageIndex(history)
 	if err != nil {
 		baseImageIndex = -1
@@ -329,25 +361,14 @@ func FromV1Image(v1Image v1.Image, config *Config) (*Image, error) {
 		chainLayers:    chainLayers,
 		config:         config,
 		BaseImageIndex: baseImageIndex,
-		contentBlob:    imageContentBlob,
+		configFile:     configFile,
 	}
 
-	// Attach a cleanup function to the outputImage.
-	// This is done to ensure that the imageContentBlob file is removed even if the caller does not
-	// call CleanUp() or there is an error during creation of the image.
-	runtime.AddCleanup(outputImage, func(file *os.File) {
-		// Defensively close the file. Ignore the error because the file may already be closed.
-		_ = file.Close()
-		err := os.Remove(file.Name())
-		if err == nil {
-			log.Warnf("%q was removed through cleanup function. This is unexpected as the user should have called CleanUp()", file.Name())
-			return
-		}
-		if errors.Is(err, os.ErrNotExist) {
-			return
+	if !config.StreamFromLayers {
+		if err := outputImage.createContentBlob(); err != nil {
+			return nil, err
 		}
-		log.Warnf("%q failed to be removed through GC cleanup function: %v", file.Name(), err)
-	}, imageContentBlob)
+	}
 
 	// Since the layers are in reverse order, the v1LayerIndex starts at the last layer and works
 	// its way to the first layer.
@@ -370,30 +391,80 @@ func FromV1Image(v1Image v1.Image, config *Config) (*Image, error) {
 		chainLayersToFill := chainLayers[i:]
 
 		v1Layer := v1Layers[v1LayerIndex]
-		layerReader, err := v1Layer.Uncompressed()
+		v1LayerIndex--
+
+		if config.StreamFromLayers {
+			err = outputImage.indexLayer(v1Layer, chainLayersToFill)
+		} else {
+			err = outputImage.unpackLayer(v1Layer, chainLayersToFill)
+		}
 		if err != nil {
 			return nil, handleImageError(outputImage, err)
 		}
-		v1LayerIndex--
-
-		err = func() error {
-			// Manually close at the end of the for loop.
-			defer layerReader.Close()
+	}
 
-			tarReader := tar.NewReader(layerReader)
-			if err := fillChainLayersWithFilesFromTar(outputImage, tarReader, chainLayersToFill); err != nil {
-				return fmt.Errorf("failed to fill chain layer with v1 layer tar: %w", err)
-			}
+	return outputImage, nil
+}
 
-			return nil
-		}()
+// createContentBlob creates the temporary file that the contents of the unpacked files are copied
+// to.
+func (img *Image) createContentBlob() error {
+	imageContentBlob, err := os.CreateTemp("", "image-blob-*")
+	if err != nil {
+		return fmt.Errorf("failed to create image content file: %w", err)
+	}
+	img.contentBlob = imageContentBlob
 
-		if err != nil {
-			return nil, handleImageError(outputImage, err)
+	// Attach a cleanup function to the image.
+	// This is done to ensure that the imageContentBlob file is removed even if the caller does not
+	// call CleanUp() or there is an error during creation of the image.
+	runtime.AddCleanup(img, func(file *os.File) {
+		// Defensively close the file. Ignore the error because the file may already be closed.
+		_ = file.Close()
+		err := os.Remove(file.Name())
+		if err == nil {
+			log.Warnf("%q was removed through cleanup function. This is unexpected as the user should have called CleanUp()", file.Name())
+			return
+		}
+		if errors.Is(err, os.ErrNotExist) {
+			return
 		}
+		log.Warnf("%q failed to be removed through GC cleanup function: %v", file.Name(), err)
+	}, imageContentBlob)
+
+	return nil
+}
+
+// unpackLayer copies the files of the v1 layer to the content blob and fills the chain layers with
+// them.
+func (img *Image) unpackLayer(v1Layer v1.Layer, chainLayersToFill []*chainLayer) error {
+	layerReader, err := v1Layer.Uncompressed()
+	if err != nil {
+		return err
 	}
+	defer layerReader.Close()
 
-	return outputImage, nil
+	tarReader := tar.NewReader(layerReader)
+	if err := fillChainLayersWithFilesFromTar(img, tarReader, nil, chainLayersToFill); err != nil {
+		return fmt.Errorf("failed to fill chain layer with v1 layer tar: %w", err)
+	}
+	return nil
+}
+
+// indexLayer fills the chain layers with the files of the v1 layer without copying their contents.
+// Instead, the offsets of the files in the layer stream are recorded and later reads are served
+// from the stream.
+func (img *Image) indexLayer(v1Layer v1.Layer, chainLayersToFill []*chainLayer) error {
+	lr := newLayerReaderAt(v1Layer)
+	img.layerReaders = append(img.layerReaders, lr)
+	// Release the stream once the layer is indexed. It is reopened when a file is read.
+	defer lr.Close()
+
+	tarReader := tar.NewReader(lr)
+	if err := fillChainLayersWithFilesFromTar(img, tarReader, lr, chainLayersToFill); err != nil {
+		return fmt.Errorf("failed to fill chain layer with v1 layer tar: %w", err)
+	}
+	return nil
 }
 
 // ========================================================
@@ -517,10 +588,11 @@ func initializeChainLayers(v1Layers []v1.Layer, history []v1.History, maxSymlink
 	return chainLayers, nil
 }
 
-// fillChainLayersWithFilåesFromTar fills the chain layers with the files found in the tar. The
+// fillChainLayersWithFilesFromTar fills the chain layers with the files found in the tar. The
 // chainLayersToFill are the chain layers that will be filled with the files via the virtual
-// filesystem.
-func fillChainLayersWithFilesFromTar(img *Image, tarReader *tar.Reader, chainLayersToFill []*chainLayer) error {
+// filesystem. If layerReader is set, it is the reader underlying the tarReader and the file
+// contents are served from it instead of being copied to the content blob.
+func fillChainLayersWithFilesFromTar(img *Image, tarReader *tar.Reader, layerReader *layerReaderAt, chainLayersToFill []*chainLayer) error {
 	if len(chainLayersToFill) == 0 {
 		return errors.New("no chain layers provided, this should not happen")
 	}
@@ -596,7 +668,11 @@ func fillChainLayersWithFilesFromTar(img *Image, tarReader *tar.Reader, chainLay
 		case tar.TypeDir:
 			newVirtualFile = img.handleDir(virtualPath, header, isWhiteout)
 		case tar.TypeReg:
-			newVirtualFile, err = img.handleFile(virtualPath, tarReader, header, isWhiteout)
+			if layerReader != nil {
+				newVirtualFile, err = img.handleStreamedFile(virtualPath, layerReader, header, isWhiteout)
+			} else {
+				newVirtualFile, err = img.handleFile(virtualPath, tarReader, header, isWhiteout)
+			}
 		case tar.TypeSymlink, tar.TypeLink:
 			newVirtualFile, err = img.handleSymlink(virtualPath, header, isWhiteout)
 		default:
@@ -607,7 +683,7 @@ func fillChainLayersWithFilesFromTar(img *Image, tarReader *tar.Reader, chainLay
 		if err != nil {
 			// If the error is due to a file read limit being exceeded or a symlink pointing outside the
 			// root, then we fail open and skip the file.
-			if errors.Is(err, ErrFileReadLimitExceeded) || errors.Is(err, ErrSymlinkPointsOutsideRoot) {
+			if errors.Is(err, ErrFileReadLimitExceeded) || errors.Is(err, ErrSymlinkPointsOutsideRoot) || errors.Is(err, ErrSparseFileNotStreamable) {
 				log.Warnf("failed to handle tar entry with path %s: %w", virtualPath, err)
 				continue
 			}
@@ -736,6 +812,45 @@ func (img *Image) handleFile(virtualPath string, tarReader *tar.Reader, header *
 	}, nil
 }
 
+// handleStreamedFile records the offset of the file in the layer stream without reading its
+// contents. The function returns a virtual file whose reads are served from the layer stream.
+func (img *Image) handleStreamedFile(virtualPath string, layerReader *layerReaderAt, header *tar.Header, isWhiteout bool) (*virtualFile, error) {
+	if header.Size >= img.config.MaxFileBytes {
+		return nil, ErrFileReadLimitExceeded
+	}
+	// The data of sparse files is not stored contiguously in the layer.
+	if isSparse(header) {
+		return nil, ErrSparseFileNotStreamable
+	}
+
+	// The tar reader doesn't read ahead, so once it returns a header the stream is positioned at the
+	// start of the file's data.
+	offset := layerReader.offset()
+	fileInfo := header.FileInfo()
+
+	return &virtualFile{
+		virtualPath: virtualPath,
+		isWhiteout:  isWhiteout,
+		mode:        fileInfo.Mode(),
+		modTime:     fileInfo.ModTime(),
+		size:        header.Size,
+		reader:      io.NewSectionReader(layerReader, offset, header.Size),
+	}, nil
+}
+
+// isSparse returns whether the tar header describes a GNU sparse file.
+func isSparse(header *tar.Header) bool {
+	if header.Typeflag == tar.TypeGNUSparse {
+		return true
+	}
+	for k := range header.PAXRecords {
+		if strings.HasPrefix(k, "GNU.sparse.") {
+			return true
+		}
+	}
+	return false
+}
+
 // fillChainLayersWithVirtualFile fills the chain layers with a new fileNode.
 func fillChainLayersWithVirtualFile(chainLayersToFill []*chainLayer, newNode *virtualFile) {
 	virtualPath := newNode.virtualPath
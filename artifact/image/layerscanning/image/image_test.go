@@ -37,6 +37,7 @@ import (
 	"github.com/google/go-containerregistry/pkg/v1/types"
 	"github.com/google/osv-scalibr/artifact/image"
 	"github.com/google/osv-scalibr/artifact/image/layerscanning/testing/fakev1layer"
+	"github.com/google/osv-scalibr/inventory"
 	"github.com/opencontainers/go-digest"
 )
 
@@ -335,6 +336,87 @@ func TestFromTarball(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:    "files streamed from layers",
+			tarPath: filepath.Join(testdataDir, "multiple-files.tar"),
+			config: &Config{
+				MaxFileBytes:     DefaultMaxFileBytes,
+				MaxSymlinkDepth:  DefaultMaxSymlinkDepth,
+				StreamFromLayers: true,
+			},
+			wantChainLayerEntries: []chainLayerEntries{
+				{
+					filepathContentPairs: []filepathContentPair{
+						{
+							filepath: "foo.txt",
+							content:  "foo\n",
+						},
+					},
+				},
+				{
+					filepathContentPairs: []filepathContentPair{
+						{
+							filepath: "dir1/baz.txt",
+							content:  "baz\n",
+						},
+						{
+							filepath: "foo.txt",
+							content:  "foo\n",
+						},
+						{
+							filepath: "dir1/bar.txt",
+							content:  "bar\n",
+						},
+					},
+				},
+			},
+		},
+		{
+			name:    "overwritten file streamed from layers",
+			tarPath: filepath.Join(testdataDir, "overwrite-file.tar"),
+			config: &Config{
+				MaxFileBytes:     DefaultMaxFileBytes,
+				MaxSymlinkDepth:  DefaultMaxSymlinkDepth,
+				StreamFromLayers: true,
+			},
+			wantChainLayerEntries: []chainLayerEntries{
+				{
+					filepathContentPairs: []filepathContentPair{
+						{
+							filepath: "sample.txt",
+							content:  "sample text file\n",
+						},
+					},
+				},
+				{
+					filepathContentPairs: []filepathContentPair{
+						{
+							filepath: "sample.txt",
+							content:  "overwritten sample text file\n",
+						},
+					},
+				},
+			},
+		},
+		{
+			name:    "file surpassing max file size streamed from layers",
+			tarPath: filepath.Join(testdataDir, "single-file.tar"),
+			config: &Config{
+				MaxFileBytes:     1,
+				StreamFromLayers: true,
+			},
+			wantChainLayerEntries: []chainLayerEntries{
+				{
+					filepathContentPairs: []filepathContentPair{
+						{
+							filepath: "foo.txt",
+							content:  "foo\n",
+						},
+					},
+				},
+			},
+			wantErrWhileReadingFiles: fs.ErrNotExist,
+		},
 		{
 			name:    "image with file surpassing max file size",
 			tarPath: filepath.Join(testdataDir, "single-file.tar"),
@@ -1008,6 +1090,66 @@ func scalibrFilesInTmp(t *testing.T) []string {
 	return filenames
 }
 
+func TestContainerImageMetadata(t *testing.T) {
+	baseImage := constructImageWithTarEntries(t, []*tarEntry{
+		{
+			Header: &tar.Header{
+				Name: "etc/os-release",
+				Mode: 0777,
+				Size: int64(len(osContents)),
+			},
+			Data: bytes.NewBufferString(osContents),
+		},
+	})
+	configuredImage, err := mutate.Config(baseImage, v1.Config{
+		User:         "nobody",
+		Entrypoint:   []string{"/docker-entrypoint.sh"},
+		Cmd:          []string{"nginx", "-g", "daemon off;"},
+		Env:          []string{"PATH=/usr/local/bin:/usr/bin"},
+		ExposedPorts: map[string]struct{}{"443/tcp": {}, "80/tcp": {}},
+	})
+	if err != nil {
+		t.Fatalf("mutate.Config(): %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		v1Image v1.Image
+		want    *inventory.ContainerImageMetadata
+	}{
+		{
+			name:    "image without runtime config",
+			v1Image: baseImage,
+			want:    &inventory.ContainerImageMetadata{},
+		},
+		{
+			name:    "configured image",
+			v1Image: configuredImage,
+			want: &inventory.ContainerImageMetadata{
+				User:         "nobody",
+				Entrypoint:   []string{"/docker-entrypoint.sh"},
+				Cmd:          []string{"nginx", "-g", "daemon off;"},
+				Env:          []string{"PATH=/usr/local/bin:/usr/bin"},
+				ExposedPorts: []string{"443/tcp", "80/tcp"},
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			img, err := FromV1Image(tc.v1Image, DefaultConfig())
+			if err != nil {
+				t.Fatalf("FromV1Image(): %v", err)
+			}
+			defer img.CleanUp()
+
+			got := img.ContainerImageMetadata()
+			if diff := cmp.Diff(tc.want, got, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("ContainerImageMetadata() returned diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestInitializeChainLayers(t *testing.T) {
 	fakeV1Layer1 := fakev1layer.New(t, diffID1.Encoded(), "COPY ./foo.txt /foo.txt # buildkit", false, nil, false)
 	fakeV1Layer2 := fakev1layer.New(t, diffID2.Encoded(), "COPY ./bar.txt /bar.txt # buildkit", false, nil, false)
@@ -0,0 +1,129 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package image
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+)
+
+// layerReaderAt serves reads of the files of a layer straight from the uncompressed layer stream
+// instead of from a copy on disk.
+//
+// While the layer is unpacked, the tar.Reader reads the stream through Read() and the current
+// position is used to index the offset of each file's data. Later reads of a file go through
+// ReadAt(), which moves forward in the stream if possible and reopens the layer otherwise. Reading
+// files in the order they appear in the layer is therefore cheap, while reading them out of order
+// may decompress parts of the layer several times.
+type layerReaderAt struct {
+	layer v1.Layer
+
+	mu     sync.Mutex
+	stream io.ReadCloser
+	pos    int64
+}
+
+func newLayerReaderAt(layer v1.Layer) *layerReaderAt {
+	return &layerReaderAt{layer: layer}
+}
+
+// Read reads the next bytes of the layer stream, opening it if needed.
+func (r *layerReaderAt) Read(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.open(); err != nil {
+		return 0, err
+	}
+	n, err := r.stream.Read(p)
+	r.pos += int64(n)
+	return n, err
+}
+
+// offset returns the current position in the layer stream.
+func (r *layerReaderAt) offset() int64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.pos
+}
+
+// ReadAt reads len(p) bytes of the uncompressed layer starting at off.
+func (r *layerReaderAt) ReadAt(p []byte, off int64) (int, error) {
+	if off < 0 {
+		return 0, fmt.Errorf("negative offset %d", off)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.stream != nil && off < r.pos {
+		r.closeStream()
+	}
+	if err := r.open(); err != nil {
+		return 0, err
+	}
+
+	if off > r.pos {
+		skipped, err := io.CopyN(io.Discard, r.stream, off-r.pos)
+		r.pos += skipped
+		if errors.Is(err, io.EOF) {
+			return 0, io.EOF
+		}
+		if err != nil {
+			return 0, fmt.Errorf("failed to seek to offset %d in layer: %w", off, err)
+		}
+	}
+
+	n, err := io.ReadFull(r.stream, p)
+	r.pos += int64(n)
+	if errors.Is(err, io.ErrUnexpectedEOF) {
+		err = io.EOF
+	}
+	return n, err
+}
+
+// Close closes the layer stream. The stream is reopened by the next read.
+func (r *layerReaderAt) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.closeStream()
+}
+
+func (r *layerReaderAt) open() error {
+	if r.stream != nil {
+		return nil
+	}
+	stream, err := r.layer.Uncompressed()
+	if err != nil {
+		return fmt.Errorf("failed to open layer: %w", err)
+	}
+	r.stream = stream
+	r.pos = 0
+	return nil
+}
+
+func (r *layerReaderAt) closeStream() error {
+	if r.stream == nil {
+		return nil
+	}
+	err := r.stream.Close()
+	r.stream = nil
+	r.pos = 0
+	return err
+}
@@ -0,0 +1,117 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package image
+
+import (
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+)
+
+func TestLayerReaderAt(t *testing.T) {
+	const content = "0123456789abcdefghij"
+	lr := newLayerReaderAt(static.NewLayer([]byte(content), types.DockerLayer))
+	//nolint:errcheck
+	defer lr.Close()
+
+	// Reads jump forward and backward in the stream.
+	tests := []struct {
+		name    string
+		off     int64
+		n       int
+		want    string
+		wantErr error
+	}{
+		{
+			name: "start",
+			off:  0,
+			n:    4,
+			want: "0123",
+		},
+		{
+			name: "forward",
+			off:  10,
+			n:    3,
+			want: "abc",
+		},
+		{
+			name: "backward",
+			off:  2,
+			n:    5,
+			want: "23456",
+		},
+		{
+			name: "same offset twice",
+			off:  2,
+			n:    2,
+			want: "23",
+		},
+		{
+			name:    "past the end",
+			off:     18,
+			n:       4,
+			want:    "ij",
+			wantErr: io.EOF,
+		},
+		{
+			name:    "offset past the end",
+			off:     30,
+			n:       1,
+			want:    "",
+			wantErr: io.EOF,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			buf := make([]byte, tc.n)
+			n, err := lr.ReadAt(buf, tc.off)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("ReadAt(%d) returned error: %v, want error: %v", tc.off, err, tc.wantErr)
+			}
+			if got := string(buf[:n]); got != tc.want {
+				t.Errorf("ReadAt(%d) = %q, want %q", tc.off, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestLayerReaderAtOffset(t *testing.T) {
+	lr := newLayerReaderAt(static.NewLayer([]byte("0123456789"), types.DockerLayer))
+	//nolint:errcheck
+	defer lr.Close()
+
+	if _, err := io.ReadFull(lr, make([]byte, 6)); err != nil {
+		t.Fatalf("Read() returned error: %v", err)
+	}
+	if got := lr.offset(); got != 6 {
+		t.Errorf("offset() = %d, want 6", got)
+	}
+
+	// A new stream is opened after Close().
+	if err := lr.Close(); err != nil {
+		t.Fatalf("Close() returned error: %v", err)
+	}
+	buf := make([]byte, 3)
+	if _, err := lr.ReadAt(buf, 7); err != nil {
+		t.Fatalf("ReadAt() returned error: %v", err)
+	}
+	if got := string(buf); got != "789" {
+		t.Errorf("ReadAt(7) = %q, want %q", got, "789")
+	}
+}
@@ -52,6 +52,8 @@ type locationAndIndex struct {
 // exists in layer 2, but not in layer 1. Package A is attributed to layer 0 because it is present
 // in all layers.
 //
+// Secrets are attributed to the last layer that added or modified the file they were found in.
+//
 // Note that a precondition of this algorithm is that the chain layers are ordered by order of
 // creation.
 func PopulateLayerDetails(ctx context.Context, inventory inventory.Inventory, chainLayers []scalibrimage.ChainLayer, extractors []filesystem.Extractor, config *filesystem.Config) {
@@ -187,6 +189,24 @@ func PopulateLayerDetails(ctx context.Context, inventory inventory.Inventory, ch
 		}
 		pkg.LayerDetails = layerDetails
 	}
+
+	populateSecretLayerDetails(inventory.Secrets, chainLayers, chainLayerDetailsList)
+}
+
+// populateSecretLayerDetails attributes each secret to the last layer that added or modified the
+// file containing it, i.e. the layer whose contents are visible in the final image.
+func populateSecretLayerDetails(secrets []*inventory.Secret, chainLayers []scalibrimage.ChainLayer, chainLayerDetailsList []*extractor.LayerDetails) {
+	for _, s := range secrets {
+		if s == nil || s.Location == "" {
+			continue
+		}
+		for i := len(chainLayers) - 1; i >= 0; i-- {
+			if filesExistInLayer(chainLayers[i], []string{s.Location}) {
+				s.LayerDetails = chainLayerDetailsList[i]
+				break
+			}
+		}
+	}
 }
 
 // areLocationsEqual checks if the package location strings are equal.
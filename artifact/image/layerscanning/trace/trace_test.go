@@ -446,3 +446,59 @@ func TestPopulateLayerDetails(t *testing.T) {
 		})
 	}
 }
+
+func TestPopulateLayerDetailsForSecrets(t *testing.T) {
+	fakeChainLayers := fakelayerbuilder.BuildFakeChainLayersFromPath(t, t.TempDir(), "testdata/populatelayers.yml")
+
+	secrets := []*inventory.Secret{
+		// Edited in place in the last layer.
+		{Secret: "secret-foo", Location: "foo.txt"},
+		// Deleted in layer 1 and re-added in layer 3.
+		{Secret: "secret-bar", Location: "bar.txt"},
+		{Secret: "secret-baz", Location: "baz.txt"},
+		{Secret: "secret-missing", Location: "missing.txt"},
+		{Secret: "secret-no-location"},
+	}
+	want := []*inventory.Secret{
+		{
+			Secret:   "secret-foo",
+			Location: "foo.txt",
+			LayerDetails: &extractor.LayerDetails{
+				Index:   4,
+				DiffID:  "diff-id-4",
+				Command: "command-4",
+			},
+		},
+		{
+			Secret:   "secret-bar",
+			Location: "bar.txt",
+			LayerDetails: &extractor.LayerDetails{
+				Index:   3,
+				DiffID:  "diff-id-3",
+				Command: "command-3",
+			},
+		},
+		{
+			Secret:   "secret-baz",
+			Location: "baz.txt",
+			LayerDetails: &extractor.LayerDetails{
+				Index:   2,
+				DiffID:  "diff-id-2",
+				Command: "command-2",
+			},
+		},
+		{Secret: "secret-missing", Location: "missing.txt"},
+		{Secret: "secret-no-location"},
+	}
+
+	chainLayers := make([]image.ChainLayer, 0, len(fakeChainLayers))
+	for _, cl := range fakeChainLayers {
+		chainLayers = append(chainLayers, cl)
+	}
+
+	config := &filesystem.Config{Stats: stats.NoopCollector{}}
+	PopulateLayerDetails(context.Background(), inventory.Inventory{Secrets: secrets}, chainLayers, nil, config)
+	if diff := cmp.Diff(want, secrets); diff != "" {
+		t.Errorf("PopulateLayerDetails(ctx, %v, %v, config) returned an unexpected diff (-want +got): %v", secrets, chainLayers, diff)
+	}
+}
@@ -0,0 +1,85 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package squashfs
+
+import (
+	"bytes"
+	"compress/zlib"
+	"errors"
+	"io"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// Decompressor decompresses a metadata or data block. It returns an error if the decompressed
+// data is larger than maxSize.
+type Decompressor func(src []byte, maxSize int) ([]byte, error)
+
+var (
+	decompressorsMu sync.RWMutex
+	decompressors   = map[Compression]Decompressor{
+		Gzip: decompressGzip,
+		Zstd: decompressZstd,
+	}
+)
+
+// RegisterDecompressor sets the decompressor used for images compressed with c, e.g. to add
+// support for xz or lz4 compressed images.
+func RegisterDecompressor(c Compression, d Decompressor) {
+	decompressorsMu.Lock()
+	defer decompressorsMu.Unlock()
+	decompressors[c] = d
+}
+
+func decompressor(c Compression) (Decompressor, bool) {
+	decompressorsMu.RLock()
+	defer decompressorsMu.RUnlock()
+	d, ok := decompressors[c]
+	return d, ok
+}
+
+var errBlockTooLarge = errors.New("decompressed block exceeds the maximum size")
+
+// readLimited reads at most maxSize bytes from r and returns an error if there's more data.
+func readLimited(r io.Reader, maxSize int) ([]byte, error) {
+	data, err := io.ReadAll(io.LimitReader(r, int64(maxSize)+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxSize {
+		return nil, errBlockTooLarge
+	}
+	return data, nil
+}
+
+// decompressGzip decompresses zlib streams, which squashfs calls "gzip".
+func decompressGzip(src []byte, maxSize int) ([]byte, error) {
+	r, err := zlib.NewReader(bytes.NewReader(src))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return readLimited(r, maxSize)
+}
+
+func decompressZstd(src []byte, maxSize int) ([]byte, error) {
+	r, err := zstd.NewReader(bytes.NewReader(src), zstd.WithDecoderConcurrency(1), zstd.WithDecoderMaxMemory(uint64(maxSize)+1))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return readLimited(r, maxSize)
+}
@@ -0,0 +1,851 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package squashfs provides a read-only SCALIBR filesystem for squashfs images, e.g. snap
+// packages or the root filesystems of embedded Linux firmware, so that they can be scanned by
+// the standard extractors without mounting them.
+//
+// Images compressed with gzip and zstd are supported out of the box. Decompressors for the
+// other algorithms can be added with RegisterDecompressor.
+package squashfs
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	scalibrfs "github.com/google/osv-scalibr/fs"
+)
+
+const (
+	magic            = 0x73717368
+	superblockSize   = 96
+	metadataSize     = 8192
+	noFragment       = 0xffffffff
+	fragmentsPerMeta = metadataSize / 16
+	// maxMetadataCache is the number of decompressed metadata blocks cached per image.
+	maxMetadataCache = 4096
+	// maxSymlinkHops limits symlink resolution, like Linux's ELOOP limit.
+	maxSymlinkHops = 40
+
+	uncompressedMetadata = 0x8000
+	uncompressedBlock    = 1 << 24
+)
+
+// Inode types.
+const (
+	typeDir = iota + 1
+	typeFile
+	typeSymlink
+	typeBlockDev
+	typeCharDev
+	typeFifo
+	typeSocket
+	typeExtDir
+	typeExtFile
+	typeExtSymlink
+	typeExtBlockDev
+	typeExtCharDev
+	typeExtFifo
+	typeExtSocket
+)
+
+// Compression is the compression algorithm of an image.
+type Compression uint16
+
+// Compression algorithms.
+const (
+	Gzip Compression = iota + 1
+	LZMA
+	LZO
+	XZ
+	LZ4
+	Zstd
+)
+
+func (c Compression) String() string {
+	switch c {
+	case Gzip:
+		return "gzip"
+	case LZMA:
+		return "lzma"
+	case LZO:
+		return "lzo"
+	case XZ:
+		return "xz"
+	case LZ4:
+		return "lz4"
+	case Zstd:
+		return "zstd"
+	default:
+		return fmt.Sprintf("unknown(%d)", uint16(c))
+	}
+}
+
+// ErrUnsupportedCompression is returned for images compressed with an algorithm that has no
+// registered decompressor.
+var ErrUnsupportedCompression = errors.New("unsupported squashfs compression")
+
+type superblock struct {
+	Magic              uint32
+	InodeCount         uint32
+	ModTime            uint32
+	BlockSize          uint32
+	FragmentCount      uint32
+	Compression        Compression
+	BlockLog           uint16
+	Flags              uint16
+	IDCount            uint16
+	VersionMajor       uint16
+	VersionMinor       uint16
+	RootInode          uint64
+	BytesUsed          uint64
+	IDTableStart       uint64
+	XattrTableStart    uint64
+	InodeTableStart    uint64
+	DirTableStart      uint64
+	FragmentTableStart uint64
+	ExportTableStart   uint64
+}
+
+// FS is a read-only scalibrfs.FS implementation for squashfs images.
+type FS struct {
+	r          io.ReaderAt
+	closer     io.Closer
+	sb         superblock
+	decompress Decompressor
+
+	mu        sync.Mutex
+	metadata  map[int64]metadataBlock
+	fragment  []byte
+	fragIndex uint32
+}
+
+var _ scalibrfs.FS = &FS{}
+
+// Open opens the squashfs image at the given path. The image file stays open until Close is
+// called.
+func Open(path string) (*FS, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	sqfs, err := New(f)
+	if err != nil {
+		_ = f.Close()
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	sqfs.closer = f
+	return sqfs, nil
+}
+
+// New returns an FS that reads the squashfs image from r.
+func New(r io.ReaderAt) (*FS, error) {
+	buf := make([]byte, superblockSize)
+	if _, err := r.ReadAt(buf, 0); err != nil {
+		return nil, fmt.Errorf("failed to read squashfs superblock: %w", err)
+	}
+	var sb superblock
+	if err := binary.Read(bytes.NewReader(buf), binary.LittleEndian, &sb); err != nil {
+		return nil, err
+	}
+	if sb.Magic != magic {
+		return nil, errors.New("not a squashfs image")
+	}
+	if sb.VersionMajor != 4 {
+		return nil, fmt.Errorf("unsupported squashfs version %d.%d", sb.VersionMajor, sb.VersionMinor)
+	}
+	if sb.BlockSize < 4096 || sb.BlockSize > 1<<20 || sb.BlockSize&(sb.BlockSize-1) != 0 {
+		return nil, fmt.Errorf("invalid squashfs block size %d", sb.BlockSize)
+	}
+	d, ok := decompressor(sb.Compression)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedCompression, sb.Compression)
+	}
+	return &FS{
+		r:          r,
+		sb:         sb,
+		decompress: d,
+		metadata:   make(map[int64]metadataBlock),
+		fragIndex:  noFragment,
+	}, nil
+}
+
+// Close closes the image file if the FS was created with Open.
+func (f *FS) Close() error {
+	if f.closer == nil {
+		return nil
+	}
+	return f.closer.Close()
+}
+
+// Compression returns the compression algorithm of the image.
+func (f *FS) Compression() Compression { return f.sb.Compression }
+
+// Open opens the named file or directory for reading. Symlinks are followed within the image.
+func (f *FS) Open(name string) (fs.File, error) {
+	in, err := f.resolve("open", name, true)
+	if err != nil {
+		return nil, err
+	}
+	return &file{fs: f, name: name, info: &fileInfo{name: path.Base(name), inode: in}}, nil
+}
+
+// ReadDir reads the named directory and returns its entries sorted by name.
+func (f *FS) ReadDir(name string) ([]fs.DirEntry, error) {
+	in, err := f.resolve("readdir", name, true)
+	if err != nil {
+		return nil, err
+	}
+	if !in.isDir() {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: errors.New("not a directory")}
+	}
+	entries, err := f.readDir(in)
+	if err != nil {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: err}
+	}
+	result := make([]fs.DirEntry, 0, len(entries))
+	for _, e := range entries {
+		result = append(result, &dirEntry{fs: f, entry: e})
+	}
+	// Directory entries are stored sorted by name already.
+	return result, nil
+}
+
+// Stat returns a FileInfo describing the named file or directory. Symlinks are followed within
+// the image.
+func (f *FS) Stat(name string) (fs.FileInfo, error) {
+	in, err := f.resolve("stat", name, true)
+	if err != nil {
+		return nil, err
+	}
+	return &fileInfo{name: path.Base(name), inode: in}, nil
+}
+
+// resolve returns the inode of the named file. Symlinks in the path are resolved relative to
+// the image root, the last element is only followed if followLast is set.
+func (f *FS) resolve(op, name string, followLast bool) (*inode, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: op, Path: name, Err: fs.ErrInvalid}
+	}
+	root, err := f.readInode(f.sb.RootInode)
+	if err != nil {
+		return nil, &fs.PathError{Op: op, Path: name, Err: err}
+	}
+	if name == "." {
+		return root, nil
+	}
+
+	hops := 0
+	elems := strings.Split(name, "/")
+	// parents holds the inodes of the directories leading to the current one.
+	parents := []*inode{root}
+	for len(elems) > 0 {
+		elem := elems[0]
+		elems = elems[1:]
+		cur := parents[len(parents)-1]
+		switch elem {
+		case "", ".":
+			continue
+		case "..":
+			if len(parents) > 1 {
+				parents = parents[:len(parents)-1]
+			}
+			continue
+		}
+		if !cur.isDir() {
+			return nil, &fs.PathError{Op: op, Path: name, Err: fs.ErrNotExist}
+		}
+		entries, err := f.readDir(cur)
+		if err != nil {
+			return nil, &fs.PathError{Op: op, Path: name, Err: err}
+		}
+		i, found := slices.BinarySearchFunc(entries, elem, func(e entry, name string) int {
+			return strings.Compare(e.name, name)
+		})
+		if !found {
+			return nil, &fs.PathError{Op: op, Path: name, Err: fs.ErrNotExist}
+		}
+		in, err := f.readInode(entries[i].ref)
+		if err != nil {
+			return nil, &fs.PathError{Op: op, Path: name, Err: err}
+		}
+		if in.isSymlink() && (len(elems) > 0 || followLast) {
+			hops++
+			if hops > maxSymlinkHops {
+				return nil, &fs.PathError{Op: op, Path: name, Err: errors.New("too many levels of symbolic links")}
+			}
+			target := in.target
+			if strings.HasPrefix(target, "/") {
+				parents = parents[:1]
+			}
+			elems = append(strings.Split(target, "/"), elems...)
+			continue
+		}
+		parents = append(parents, in)
+	}
+	return parents[len(parents)-1], nil
+}
+
+// entry is a directory entry as stored in the directory table.
+type entry struct {
+	name string
+	typ  uint16
+	ref  uint64
+}
+
+func (f *FS) readDir(in *inode) ([]entry, error) {
+	// The stored size includes 3 bytes for the implicit "." and ".." entries.
+	if in.dirSize <= 3 {
+		return nil, nil
+	}
+	mr := f.newMetadataReader(int64(f.sb.DirTableStart)+int64(in.dirBlock), int(in.dirOffset))
+	r := io.LimitReader(mr, int64(in.dirSize)-3)
+	var entries []entry
+	for {
+		var hdr struct {
+			Count       uint32
+			Start       uint32
+			InodeNumber uint32
+		}
+		if err := binary.Read(r, binary.LittleEndian, &hdr); err != nil {
+			if errors.Is(err, io.EOF) {
+				return entries, nil
+			}
+			return nil, fmt.Errorf("failed to read directory header: %w", err)
+		}
+		// The count is stored minus one and limited to 256 entries per header.
+		if hdr.Count >= 256 {
+			return nil, fmt.Errorf("invalid directory header count %d", hdr.Count)
+		}
+		for range hdr.Count + 1 {
+			var e struct {
+				Offset     uint16
+				InodeDelta int16
+				Type       uint16
+				NameSize   uint16
+			}
+			if err := binary.Read(r, binary.LittleEndian, &e); err != nil {
+				return nil, fmt.Errorf("failed to read directory entry: %w", err)
+			}
+			name := make([]byte, int(e.NameSize)+1)
+			if _, err := io.ReadFull(r, name); err != nil {
+				return nil, fmt.Errorf("failed to read directory entry name: %w", err)
+			}
+			entries = append(entries, entry{
+				name: string(name),
+				typ:  e.Type,
+				ref:  uint64(hdr.Start)<<16 | uint64(e.Offset),
+			})
+		}
+	}
+}
+
+// inode is the subset of the inode fields used by the FS.
+type inode struct {
+	typ   uint16
+	perm  uint16
+	mtime uint32
+
+	// Directories.
+	dirBlock  uint32
+	dirOffset uint16
+	dirSize   uint32
+
+	// Regular files.
+	size        uint64
+	blocksStart uint64
+	fragIndex   uint32
+	fragOffset  uint32
+	blockSizes  []uint32
+
+	// Symlinks.
+	target string
+}
+
+func (in *inode) isDir() bool { return in.typ == typeDir || in.typ == typeExtDir }
+
+func (in *inode) isSymlink() bool { return in.typ == typeSymlink || in.typ == typeExtSymlink }
+
+func (in *inode) isFile() bool { return in.typ == typeFile || in.typ == typeExtFile }
+
+func (f *FS) readInode(ref uint64) (*inode, error) {
+	mr := f.newMetadataReader(int64(f.sb.InodeTableStart)+int64(ref>>16), int(ref&0xffff))
+	var hdr struct {
+		Type        uint16
+		Permissions uint16
+		UID         uint16
+		GID         uint16
+		ModTime     uint32
+		InodeNumber uint32
+	}
+	if err := binary.Read(mr, binary.LittleEndian, &hdr); err != nil {
+		return nil, fmt.Errorf("failed to read inode header: %w", err)
+	}
+	in := &inode{typ: hdr.Type, perm: hdr.Permissions, mtime: hdr.ModTime}
+
+	var err error
+	switch hdr.Type {
+	case typeDir:
+		var d struct {
+			BlockIndex  uint32
+			LinkCount   uint32
+			FileSize    uint16
+			BlockOffset uint16
+			ParentInode uint32
+		}
+		err = binary.Read(mr, binary.LittleEndian, &d)
+		in.dirBlock, in.dirOffset, in.dirSize = d.BlockIndex, d.BlockOffset, uint32(d.FileSize)
+	case typeExtDir:
+		var d struct {
+			LinkCount   uint32
+			FileSize    uint32
+			BlockIndex  uint32
+			ParentInode uint32
+			IndexCount  uint16
+			BlockOffset uint16
+			XattrIndex  uint32
+		}
+		err = binary.Read(mr, binary.LittleEndian, &d)
+		in.dirBlock, in.dirOffset, in.dirSize = d.BlockIndex, d.BlockOffset, d.FileSize
+	case typeFile:
+		var d struct {
+			BlocksStart uint32
+			FragIndex   uint32
+			FragOffset  uint32
+			FileSize    uint32
+		}
+		err = binary.Read(mr, binary.LittleEndian, &d)
+		in.blocksStart, in.fragIndex, in.fragOffset, in.size = uint64(d.BlocksStart), d.FragIndex, d.FragOffset, uint64(d.FileSize)
+	case typeExtFile:
+		var d struct {
+			BlocksStart uint64
+			FileSize    uint64
+			Sparse      uint64
+			LinkCount   uint32
+			FragIndex   uint32
+			FragOffset  uint32
+			XattrIndex  uint32
+		}
+		err = binary.Read(mr, binary.LittleEndian, &d)
+		in.blocksStart, in.fragIndex, in.fragOffset, in.size = d.BlocksStart, d.FragIndex, d.FragOffset, d.FileSize
+	case typeSymlink, typeExtSymlink:
+		var d struct {
+			LinkCount  uint32
+			TargetSize uint32
+		}
+		if err = binary.Read(mr, binary.LittleEndian, &d); err == nil {
+			if d.TargetSize > 4096 {
+				return nil, fmt.Errorf("invalid symlink target size %d", d.TargetSize)
+			}
+			target := make([]byte, d.TargetSize)
+			_, err = io.ReadFull(mr, target)
+			in.target = string(target)
+		}
+	case typeBlockDev, typeCharDev, typeFifo, typeSocket,
+		typeExtBlockDev, typeExtCharDev, typeExtFifo, typeExtSocket:
+		// Only the type is needed.
+	default:
+		return nil, fmt.Errorf("unknown inode type %d", hdr.Type)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read inode: %w", err)
+	}
+
+	if in.isFile() {
+		blocks := in.size / uint64(f.sb.BlockSize)
+		if in.fragIndex == noFragment && in.size%uint64(f.sb.BlockSize) != 0 {
+			blocks++
+		}
+		// Each block has a 4 byte size entry in the image.
+		if blocks*4 > f.sb.BytesUsed {
+			return nil, fmt.Errorf("invalid file size %d", in.size)
+		}
+		in.blockSizes = make([]uint32, blocks)
+		if err := binary.Read(mr, binary.LittleEndian, in.blockSizes); err != nil {
+			return nil, fmt.Errorf("failed to read block list: %w", err)
+		}
+	}
+	return in, nil
+}
+
+type metadataBlock struct {
+	data []byte
+	next int64
+}
+
+// metadataReader reads consecutive metadata blocks, e.g. inodes and directory listings that
+// span block boundaries.
+type metadataReader struct {
+	fs  *FS
+	pos int64
+	buf []byte
+	off int
+	err error
+}
+
+func (f *FS) newMetadataReader(pos int64, off int) *metadataReader {
+	m := &metadataReader{fs: f, pos: pos}
+	block, err := f.readMetadataBlock(pos)
+	if err != nil {
+		m.err = err
+		return m
+	}
+	if off > len(block.data) {
+		m.err = fmt.Errorf("invalid metadata offset %d", off)
+		return m
+	}
+	m.buf, m.off, m.pos = block.data, off, block.next
+	return m
+}
+
+func (m *metadataReader) Read(p []byte) (int, error) {
+	if m.err != nil {
+		return 0, m.err
+	}
+	n := 0
+	for n < len(p) {
+		if m.off >= len(m.buf) {
+			block, err := m.fs.readMetadataBlock(m.pos)
+			if err != nil {
+				if n > 0 {
+					return n, nil
+				}
+				return 0, err
+			}
+			m.buf, m.off, m.pos = block.data, 0, block.next
+		}
+		c := copy(p[n:], m.buf[m.off:])
+		m.off += c
+		n += c
+	}
+	return n, nil
+}
+
+func (f *FS) readMetadataBlock(pos int64) (metadataBlock, error) {
+	f.mu.Lock()
+	block, ok := f.metadata[pos]
+	f.mu.Unlock()
+	if ok {
+		return block, nil
+	}
+
+	if pos < 0 || uint64(pos) >= f.sb.BytesUsed {
+		return metadataBlock{}, io.EOF
+	}
+	var hdr [2]byte
+	if _, err := f.r.ReadAt(hdr[:], pos); err != nil {
+		return metadataBlock{}, fmt.Errorf("failed to read metadata block header at %d: %w", pos, err)
+	}
+	h := binary.LittleEndian.Uint16(hdr[:])
+	size := int(h &^ uncompressedMetadata)
+	if size == 0 || size > metadataSize {
+		return metadataBlock{}, fmt.Errorf("invalid metadata block size %d at %d", size, pos)
+	}
+	raw := make([]byte, size)
+	if _, err := f.r.ReadAt(raw, pos+2); err != nil {
+		return metadataBlock{}, fmt.Errorf("failed to read metadata block at %d: %w", pos, err)
+	}
+	data := raw
+	if h&uncompressedMetadata == 0 {
+		var err error
+		if data, err = f.decompress(raw, metadataSize); err != nil {
+			return metadataBlock{}, fmt.Errorf("failed to decompress metadata block at %d: %w", pos, err)
+		}
+	}
+	block = metadataBlock{data: data, next: pos + 2 + int64(size)}
+
+	f.mu.Lock()
+	if len(f.metadata) >= maxMetadataCache {
+		clear(f.metadata)
+	}
+	f.metadata[pos] = block
+	f.mu.Unlock()
+	return block, nil
+}
+
+// readDataBlock reads a data or fragment block whose size entry is sizeEntry. Sparse blocks of
+// length n are returned as zeros.
+func (f *FS) readDataBlock(pos int64, sizeEntry uint32, n int) ([]byte, error) {
+	size := int(sizeEntry &^ uncompressedBlock)
+	if size == 0 {
+		return make([]byte, n), nil
+	}
+	if size > int(f.sb.BlockSize) {
+		return nil, fmt.Errorf("invalid data block size %d at %d", size, pos)
+	}
+	raw := make([]byte, size)
+	if _, err := f.r.ReadAt(raw, pos); err != nil {
+		return nil, fmt.Errorf("failed to read data block at %d: %w", pos, err)
+	}
+	if sizeEntry&uncompressedBlock != 0 {
+		return raw, nil
+	}
+	data, err := f.decompress(raw, int(f.sb.BlockSize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress data block at %d: %w", pos, err)
+	}
+	return data, nil
+}
+
+// readFragment returns the fragment block with the given index. The last fragment block is
+// cached since it's shared by many small files.
+func (f *FS) readFragment(index uint32) ([]byte, error) {
+	f.mu.Lock()
+	if f.fragIndex == index {
+		data := f.fragment
+		f.mu.Unlock()
+		return data, nil
+	}
+	f.mu.Unlock()
+
+	if index >= f.sb.FragmentCount {
+		return nil, fmt.Errorf("invalid fragment index %d", index)
+	}
+	var ptr [8]byte
+	if _, err := f.r.ReadAt(ptr[:], int64(f.sb.FragmentTableStart)+8*int64(index/fragmentsPerMeta)); err != nil {
+		return nil, fmt.Errorf("failed to read fragment table: %w", err)
+	}
+	mr := f.newMetadataReader(int64(binary.LittleEndian.Uint64(ptr[:])), int(index%fragmentsPerMeta)*16)
+	var e struct {
+		Start  uint64
+		Size   uint32
+		Unused uint32
+	}
+	if err := binary.Read(mr, binary.LittleEndian, &e); err != nil {
+		return nil, fmt.Errorf("failed to read fragment entry: %w", err)
+	}
+	data, err := f.readDataBlock(int64(e.Start), e.Size, int(f.sb.BlockSize))
+	if err != nil {
+		return nil, err
+	}
+
+	f.mu.Lock()
+	f.fragIndex, f.fragment = index, data
+	f.mu.Unlock()
+	return data, nil
+}
+
+// fileInfo implements fs.FileInfo for squashfs inodes.
+type fileInfo struct {
+	name  string
+	inode *inode
+}
+
+func (i *fileInfo) Name() string { return i.name }
+func (i *fileInfo) Size() int64 {
+	switch {
+	case i.inode.isFile():
+		return int64(i.inode.size)
+	case i.inode.isSymlink():
+		return int64(len(i.inode.target))
+	default:
+		return 0
+	}
+}
+func (i *fileInfo) ModTime() time.Time { return time.Unix(int64(i.inode.mtime), 0) }
+func (i *fileInfo) IsDir() bool        { return i.inode.isDir() }
+func (i *fileInfo) Sys() any           { return nil }
+func (i *fileInfo) Mode() fs.FileMode {
+	return fs.FileMode(i.inode.perm&0777) | typeMode(i.inode.typ)
+}
+
+func typeMode(typ uint16) fs.FileMode {
+	switch typ {
+	case typeDir, typeExtDir:
+		return fs.ModeDir
+	case typeSymlink, typeExtSymlink:
+		return fs.ModeSymlink
+	case typeBlockDev, typeExtBlockDev:
+		return fs.ModeDevice
+	case typeCharDev, typeExtCharDev:
+		return fs.ModeDevice | fs.ModeCharDevice
+	case typeFifo, typeExtFifo:
+		return fs.ModeNamedPipe
+	case typeSocket, typeExtSocket:
+		return fs.ModeSocket
+	default:
+		return 0
+	}
+}
+
+// dirEntry implements fs.DirEntry. The inode is only read when Info is called.
+type dirEntry struct {
+	fs    *FS
+	entry entry
+}
+
+func (e *dirEntry) Name() string      { return e.entry.name }
+func (e *dirEntry) IsDir() bool       { return e.Type().IsDir() }
+func (e *dirEntry) Type() fs.FileMode { return typeMode(e.entry.typ) }
+func (e *dirEntry) Info() (fs.FileInfo, error) {
+	in, err := e.fs.readInode(e.entry.ref)
+	if err != nil {
+		return nil, err
+	}
+	return &fileInfo{name: e.entry.name, inode: in}, nil
+}
+
+// file is an opened file or directory of the image.
+type file struct {
+	fs   *FS
+	name string
+	info *fileInfo
+	pos  int64
+	// The last data block read, as reads are usually sequential.
+	block      []byte
+	blockIndex int
+	hasBlock   bool
+	// entries holds the directory entries not yet returned by ReadDir.
+	entries []fs.DirEntry
+	listed  bool
+}
+
+var _ io.ReaderAt = &file{}
+
+func (f *file) Stat() (fs.FileInfo, error) { return f.info, nil }
+
+func (f *file) Read(b []byte) (int, error) {
+	n, err := f.ReadAt(b, f.pos)
+	f.pos += int64(n)
+	if errors.Is(err, io.EOF) && n > 0 {
+		err = nil
+	}
+	return n, err
+}
+
+func (f *file) ReadAt(b []byte, off int64) (int, error) {
+	in := f.info.inode
+	if !in.isFile() {
+		return 0, &fs.PathError{Op: "read", Path: f.name, Err: errors.New("not a regular file")}
+	}
+	if off < 0 {
+		return 0, &fs.PathError{Op: "read", Path: f.name, Err: fs.ErrInvalid}
+	}
+	n := 0
+	for n < len(b) {
+		if uint64(off) >= in.size {
+			return n, io.EOF
+		}
+		blockSize := int64(f.fs.sb.BlockSize)
+		index := int(off / blockSize)
+		data, err := f.readBlock(index)
+		if err != nil {
+			return n, &fs.PathError{Op: "read", Path: f.name, Err: err}
+		}
+		blockOff := int(off % blockSize)
+		if blockOff >= len(data) {
+			return n, &fs.PathError{Op: "read", Path: f.name, Err: errors.New("truncated data block")}
+		}
+		c := copy(b[n:], data[blockOff:])
+		n += c
+		off += int64(c)
+	}
+	return n, nil
+}
+
+func (f *file) readBlock(index int) ([]byte, error) {
+	if f.hasBlock && f.blockIndex == index {
+		return f.block, nil
+	}
+	in := f.info.inode
+	blockSize := uint64(f.fs.sb.BlockSize)
+	var data []byte
+	if index < len(in.blockSizes) {
+		pos := int64(in.blocksStart)
+		for _, s := range in.blockSizes[:index] {
+			pos += int64(s &^ uncompressedBlock)
+		}
+		n := min(blockSize, in.size-uint64(index)*blockSize)
+		var err error
+		if data, err = f.fs.readDataBlock(pos, in.blockSizes[index], int(n)); err != nil {
+			return nil, err
+		}
+		data = data[:min(len(data), int(n))]
+	} else {
+		if in.fragIndex == noFragment {
+			return nil, errors.New("missing data block")
+		}
+		frag, err := f.fs.readFragment(in.fragIndex)
+		if err != nil {
+			return nil, err
+		}
+		start := uint64(in.fragOffset)
+		end := start + in.size%blockSize
+		if end > uint64(len(frag)) {
+			return nil, errors.New("invalid fragment offset")
+		}
+		data = frag[start:end]
+	}
+	f.block, f.blockIndex, f.hasBlock = data, index, true
+	return data, nil
+}
+
+func (f *file) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += f.pos
+	case io.SeekEnd:
+		offset += f.info.Size()
+	default:
+		return 0, &fs.PathError{Op: "seek", Path: f.name, Err: fs.ErrInvalid}
+	}
+	if offset < 0 {
+		return 0, &fs.PathError{Op: "seek", Path: f.name, Err: fs.ErrInvalid}
+	}
+	f.pos = offset
+	return offset, nil
+}
+
+func (f *file) ReadDir(n int) ([]fs.DirEntry, error) {
+	if !f.info.IsDir() {
+		return nil, &fs.PathError{Op: "readdir", Path: f.name, Err: errors.New("not a directory")}
+	}
+	if !f.listed {
+		entries, err := f.fs.ReadDir(f.name)
+		if err != nil {
+			return nil, err
+		}
+		f.entries = entries
+		f.listed = true
+	}
+	if n <= 0 {
+		entries := f.entries
+		f.entries = nil
+		return entries, nil
+	}
+	if len(f.entries) == 0 {
+		return nil, io.EOF
+	}
+	n = min(n, len(f.entries))
+	entries := f.entries[:n]
+	f.entries = f.entries[n:]
+	return entries, nil
+}
+
+func (f *file) Close() error {
+	f.block = nil
+	f.entries = nil
+	return nil
+}
@@ -0,0 +1,502 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package squashfs_test
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/osv-scalibr/artifact/image/squashfs"
+)
+
+func testFiles() map[string]string {
+	files := map[string]string{
+		"etc/os-release":      "ID=ubuntu\nVERSION_ID=\"22.04\"\n",
+		"usr/lib/big.bin":     strings.Repeat("0123456789abcdef", 700),
+		"usr/share/empty":     "",
+		"snap/manifest.yaml":  "name: hello\nversion: 2.10\n",
+		"usr/share/zeros.bin": strings.Repeat("\x00", 8192) + "end",
+	}
+	for i := range 300 {
+		files[fmt.Sprintf("many/file-%03d", i)] = fmt.Sprintf("content %d", i)
+	}
+	return files
+}
+
+func mustNew(t *testing.T, img []byte) *squashfs.FS {
+	t.Helper()
+	sqfs, err := squashfs.New(bytes.NewReader(img))
+	if err != nil {
+		t.Fatalf("squashfs.New(): %v", err)
+	}
+	return sqfs
+}
+
+func TestFS(t *testing.T) {
+	files := testFiles()
+	for _, compress := range []bool{false, true} {
+		t.Run(fmt.Sprintf("compressed_%t", compress), func(t *testing.T) {
+			sqfs := mustNew(t, buildImage(t, files, nil, compress))
+			var names []string
+			for name := range files {
+				names = append(names, name)
+			}
+			if err := fstest.TestFS(sqfs, names...); err != nil {
+				t.Errorf("fstest.TestFS(): %v", err)
+			}
+			for name, want := range files {
+				got, err := fs.ReadFile(sqfs, name)
+				if err != nil {
+					t.Fatalf("fs.ReadFile(%q): %v", name, err)
+				}
+				if string(got) != want {
+					t.Errorf("fs.ReadFile(%q) returned %d bytes, want %d", name, len(got), len(want))
+				}
+			}
+		})
+	}
+}
+
+func TestSymlinks(t *testing.T) {
+	files := map[string]string{
+		"etc/os-release":  "ID=alpine\n",
+		"usr/lib/libc.so": "libc",
+	}
+	symlinks := map[string]string{
+		"etc/alias": "os-release",
+		"lib":       "usr/lib",
+		"root/etc":  "/etc",
+		"up":        "usr/lib/../../etc/os-release",
+		"loop":      "loop",
+		"dangling":  "missing",
+	}
+	sqfs := mustNew(t, buildImage(t, files, symlinks, true))
+
+	testCases := []struct {
+		path    string
+		want    string
+		wantErr bool
+	}{
+		{path: "etc/alias", want: "ID=alpine\n"},
+		{path: "lib/libc.so", want: "libc"},
+		{path: "root/etc/os-release", want: "ID=alpine\n"},
+		{path: "up", want: "ID=alpine\n"},
+		{path: "loop", wantErr: true},
+		{path: "dangling", wantErr: true},
+		{path: "etc/os-release/x", wantErr: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.path, func(t *testing.T) {
+			got, err := fs.ReadFile(sqfs, tc.path)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("fs.ReadFile(%q) error: %v, want error: %t", tc.path, err, tc.wantErr)
+			}
+			if string(got) != tc.want {
+				t.Errorf("fs.ReadFile(%q) = %q, want %q", tc.path, got, tc.want)
+			}
+		})
+	}
+
+	entries, err := sqfs.ReadDir(".")
+	if err != nil {
+		t.Fatalf("ReadDir(.): %v", err)
+	}
+	modes := map[string]fs.FileMode{}
+	for _, e := range entries {
+		modes[e.Name()] = e.Type()
+	}
+	wantModes := map[string]fs.FileMode{
+		"dangling": fs.ModeSymlink,
+		"etc":      fs.ModeDir,
+		"lib":      fs.ModeSymlink,
+		"loop":     fs.ModeSymlink,
+		"root":     fs.ModeDir,
+		"up":       fs.ModeSymlink,
+		"usr":      fs.ModeDir,
+	}
+	if diff := cmp.Diff(wantModes, modes); diff != "" {
+		t.Errorf("ReadDir(.) returned unexpected entry types (-want +got):\n%s", diff)
+	}
+	if info, err := sqfs.Stat("lib"); err != nil || !info.IsDir() {
+		t.Errorf("Stat(lib) = %v, %v, want directory", info, err)
+	}
+}
+
+func TestReadAt(t *testing.T) {
+	content := testFiles()["usr/lib/big.bin"]
+	sqfs := mustNew(t, buildImage(t, map[string]string{"big.bin": content}, nil, true))
+	f, err := sqfs.Open("big.bin")
+	if err != nil {
+		t.Fatalf("Open(big.bin): %v", err)
+	}
+	defer f.Close()
+	r, ok := f.(io.ReaderAt)
+	if !ok {
+		t.Fatalf("Open(big.bin) returned a %T, want io.ReaderAt", f)
+	}
+	// Read across the block boundary and into the fragment.
+	for _, off := range []int{0, 4000, 8000, len(content) - 10} {
+		buf := make([]byte, 200)
+		n, err := r.ReadAt(buf, int64(off))
+		want := content[off:min(off+200, len(content))]
+		if err != nil && !errors.Is(err, io.EOF) {
+			t.Fatalf("ReadAt(%d): %v", off, err)
+		}
+		if string(buf[:n]) != want {
+			t.Errorf("ReadAt(%d) = %q, want %q", off, buf[:n], want)
+		}
+	}
+}
+
+func TestOpen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.squashfs")
+	img := buildImage(t, map[string]string{"a/b": "c"}, nil, true)
+	if err := os.WriteFile(path, img, 0644); err != nil {
+		t.Fatal(err)
+	}
+	sqfs, err := squashfs.Open(path)
+	if err != nil {
+		t.Fatalf("Open(%q): %v", path, err)
+	}
+	defer sqfs.Close()
+	if got := sqfs.Compression(); got != squashfs.Gzip {
+		t.Errorf("Compression() = %v, want %v", got, squashfs.Gzip)
+	}
+	got, err := fs.ReadFile(sqfs, "a/b")
+	if err != nil || string(got) != "c" {
+		t.Errorf("fs.ReadFile(a/b) = %q, %v, want %q", got, err, "c")
+	}
+}
+
+func TestNew_Invalid(t *testing.T) {
+	valid := buildImage(t, map[string]string{"a": "b"}, nil, false)
+	withCompression := func(c squashfs.Compression) []byte {
+		img := slices.Clone(valid)
+		binary.LittleEndian.PutUint16(img[20:], uint16(c))
+		return img
+	}
+
+	testCases := []struct {
+		desc    string
+		img     []byte
+		wantErr error
+	}{
+		{desc: "too_short", img: []byte("hsqs")},
+		{desc: "bad_magic", img: append([]byte("xxxx"), valid[4:]...)},
+		{desc: "unsupported_compression", img: withCompression(squashfs.XZ), wantErr: squashfs.ErrUnsupportedCompression},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := squashfs.New(bytes.NewReader(tc.img))
+			if err == nil {
+				t.Fatal("squashfs.New() succeeded, want error")
+			}
+			if tc.wantErr != nil && !errors.Is(err, tc.wantErr) {
+				t.Errorf("squashfs.New() error: %v, want %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestRegisterDecompressor(t *testing.T) {
+	img := buildImage(t, map[string]string{"a": "b"}, nil, false)
+	binary.LittleEndian.PutUint16(img[20:], uint16(squashfs.LZ4))
+	// The image has no compressed blocks so the decompressor is never called.
+	squashfs.RegisterDecompressor(squashfs.LZ4, func([]byte, int) ([]byte, error) {
+		return nil, errors.New("not implemented")
+	})
+	sqfs := mustNew(t, img)
+	if got, err := fs.ReadFile(sqfs, "a"); err != nil || string(got) != "b" {
+		t.Errorf("fs.ReadFile(a) = %q, %v, want %q", got, err, "b")
+	}
+}
+
+// The code below builds squashfs 4.0 images with gzip compression. It follows the on-disk
+// format closely enough to test the reader without mksquashfs or checked-in fixtures.
+
+const blockSize = 4096
+
+type node struct {
+	name     string
+	content  string
+	target   string
+	isLink   bool
+	children map[string]*node
+}
+
+func (n *node) isDir() bool { return n.children != nil }
+
+func (n *node) add(name string) *node {
+	parts := strings.Split(name, "/")
+	cur := n
+	for _, p := range parts[:len(parts)-1] {
+		child, ok := cur.children[p]
+		if !ok {
+			child = &node{name: p, children: map[string]*node{}}
+			cur.children[p] = child
+		}
+		cur = child
+	}
+	leaf := &node{name: parts[len(parts)-1]}
+	cur.children[leaf.name] = leaf
+	return leaf
+}
+
+// metaWriter writes metadata blocks and tracks the position of the current block.
+type metaWriter struct {
+	compress bool
+	out      bytes.Buffer
+	buf      []byte
+}
+
+// ref returns the position of the next write as (block start, offset in block).
+func (w *metaWriter) ref() (uint32, uint16) { return uint32(w.out.Len()), uint16(len(w.buf)) }
+
+func (w *metaWriter) write(t *testing.T, data []byte) {
+	w.buf = append(w.buf, data...)
+	for len(w.buf) >= 8192 {
+		w.flushBlock(t, w.buf[:8192])
+		w.buf = w.buf[8192:]
+	}
+}
+
+func (w *metaWriter) flush(t *testing.T) []byte {
+	if len(w.buf) > 0 {
+		w.flushBlock(t, w.buf)
+		w.buf = nil
+	}
+	return w.out.Bytes()
+}
+
+func (w *metaWriter) flushBlock(t *testing.T, data []byte) {
+	if compressed, ok := compress(t, data, w.compress); ok {
+		_ = binary.Write(&w.out, binary.LittleEndian, uint16(len(compressed)))
+		w.out.Write(compressed)
+		return
+	}
+	_ = binary.Write(&w.out, binary.LittleEndian, uint16(len(data))|0x8000)
+	w.out.Write(data)
+}
+
+// compress returns the zlib compressed data if it's smaller than the input.
+func compress(t *testing.T, data []byte, enabled bool) ([]byte, bool) {
+	if !enabled {
+		return nil, false
+	}
+	var b bytes.Buffer
+	w := zlib.NewWriter(&b)
+	if _, err := w.Write(data); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return b.Bytes(), b.Len() < len(data)
+}
+
+type imageBuilder struct {
+	t        *testing.T
+	compress bool
+	data     bytes.Buffer
+	inodes   metaWriter
+	dirs     metaWriter
+	// The fragment block being filled and the entries of the written ones.
+	fragment  []byte
+	fragments [][16]byte
+	inodeNum  uint32
+}
+
+func le(v ...any) []byte {
+	var b bytes.Buffer
+	for _, x := range v {
+		_ = binary.Write(&b, binary.LittleEndian, x)
+	}
+	return b.Bytes()
+}
+
+// buildImage returns a squashfs image with the given files and symlinks.
+func buildImage(t *testing.T, files, symlinks map[string]string, compress bool) []byte {
+	t.Helper()
+	root := &node{children: map[string]*node{}}
+	for name, content := range files {
+		root.add(name).content = content
+	}
+	for name, target := range symlinks {
+		n := root.add(name)
+		n.isLink, n.target = true, target
+	}
+
+	b := &imageBuilder{t: t, compress: compress}
+	b.inodes.compress, b.dirs.compress = compress, compress
+	// Data blocks start after the superblock.
+	b.data.Write(make([]byte, 96))
+	rootRef, _, _ := b.writeNode(root)
+	b.flushFragment()
+
+	inodeTableStart := b.data.Len()
+	inodeTable := b.inodes.flush(t)
+	dirTableStart := inodeTableStart + len(inodeTable)
+	dirTable := b.dirs.flush(t)
+
+	img := bytes.NewBuffer(b.data.Bytes())
+	img.Write(inodeTable)
+	img.Write(dirTable)
+
+	var fragTable metaWriter
+	for _, e := range b.fragments {
+		fragTable.write(t, e[:])
+	}
+	fragMetaStart := img.Len()
+	img.Write(fragTable.flush(t))
+	fragTableStart := img.Len()
+	for i := range (len(b.fragments) + 511) / 512 {
+		img.Write(le(uint64(fragMetaStart + i*(8192+2))))
+	}
+
+	var idTable metaWriter
+	idTable.write(t, le(uint32(0)))
+	idMetaStart := img.Len()
+	img.Write(idTable.flush(t))
+	idTableStart := img.Len()
+	img.Write(le(uint64(idMetaStart)))
+	bytesUsed := img.Len()
+
+	compression := squashfs.Gzip
+	sb := le(
+		uint32(0x73717368), b.inodeNum, uint32(0), uint32(blockSize), uint32(len(b.fragments)),
+		uint16(compression), uint16(12), uint16(0), uint16(1), uint16(4), uint16(0),
+		rootRef, uint64(bytesUsed), uint64(idTableStart), ^uint64(0),
+		uint64(inodeTableStart), uint64(dirTableStart), uint64(fragTableStart), ^uint64(0),
+	)
+	out := img.Bytes()
+	copy(out, sb)
+	return out
+}
+
+// writeNode writes the node and its children and returns the inode reference, type and number.
+func (b *imageBuilder) writeNode(n *node) (uint64, uint16, uint32) {
+	b.inodeNum++
+	num := b.inodeNum
+	var inode []byte
+	var typ uint16
+	switch {
+	case n.isDir():
+		typ = 1
+		var names []string
+		for name := range n.children {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		type child struct {
+			name string
+			ref  uint64
+			typ  uint16
+			num  uint32
+		}
+		var children []child
+		for _, name := range names {
+			ref, typ, num := b.writeNode(n.children[name])
+			children = append(children, child{name: name, ref: ref, typ: typ, num: num})
+		}
+		dirBlock, dirOffset := b.dirs.ref()
+		size := 3
+		for i := 0; i < len(children); {
+			// Entries of a header must share the inode metadata block.
+			j := i
+			for j < len(children) && j-i < 256 && children[j].ref>>16 == children[i].ref>>16 {
+				j++
+			}
+			hdr := le(uint32(j-i-1), uint32(children[i].ref>>16), children[i].num)
+			b.dirs.write(b.t, hdr)
+			size += len(hdr)
+			for _, c := range children[i:j] {
+				e := le(uint16(c.ref&0xffff), int16(c.num-children[i].num), c.typ, uint16(len(c.name)-1))
+				e = append(e, c.name...)
+				b.dirs.write(b.t, e)
+				size += len(e)
+			}
+			i = j
+		}
+		inode = le(dirBlock, uint32(2), uint16(size), dirOffset, uint32(0))
+	case n.isLink:
+		typ = 3
+		inode = append(le(uint32(1), uint32(len(n.target))), n.target...)
+	default:
+		typ = 2
+		start := b.data.Len()
+		var sizes []uint32
+		content := []byte(n.content)
+		for len(content) >= blockSize {
+			block := content[:blockSize]
+			content = content[blockSize:]
+			switch {
+			case bytes.Count(block, []byte{0}) == blockSize:
+				sizes = append(sizes, 0)
+			default:
+				if c, ok := compress(b.t, block, b.compress); ok {
+					b.data.Write(c)
+					sizes = append(sizes, uint32(len(c)))
+				} else {
+					b.data.Write(block)
+					sizes = append(sizes, uint32(len(block))|1<<24)
+				}
+			}
+		}
+		fragIndex, fragOffset := uint32(0xffffffff), uint32(0)
+		if len(content) > 0 {
+			if len(b.fragment)+len(content) > blockSize {
+				b.flushFragment()
+			}
+			fragIndex, fragOffset = uint32(len(b.fragments)), uint32(len(b.fragment))
+			b.fragment = append(b.fragment, content...)
+		}
+		inode = le(uint32(start), fragIndex, fragOffset, uint32(len(n.content)), sizes)
+	}
+	block, offset := b.inodes.ref()
+	b.inodes.write(b.t, le(typ, uint16(0755), uint16(0), uint16(0), uint32(0), num))
+	b.inodes.write(b.t, inode)
+	return uint64(block)<<16 | uint64(offset), typ, num
+}
+
+func (b *imageBuilder) flushFragment() {
+	if len(b.fragment) == 0 {
+		return
+	}
+	start := b.data.Len()
+	size := uint32(len(b.fragment)) | 1<<24
+	if c, ok := compress(b.t, b.fragment, b.compress); ok {
+		b.data.Write(c)
+		size = uint32(len(c))
+	} else {
+		b.data.Write(b.fragment)
+	}
+	var e [16]byte
+	copy(e[:], le(uint64(start), size))
+	b.fragments = append(b.fragments, e)
+	b.fragment = nil
+}
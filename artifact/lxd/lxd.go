@@ -0,0 +1,296 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package lxd finds the root filesystems of the LXD, Incus and LXC system
+// containers stored on a host so that they can be scanned one by one and the
+// results attributed to the containers.
+package lxd
+
+import (
+	"bufio"
+	"errors"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	scalibrfs "github.com/google/osv-scalibr/fs"
+	"github.com/google/osv-scalibr/log"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	// lxdDirs are the state directories of the LXD (deb and snap) and Incus
+	// daemons, relative to the host root.
+	lxdDirs = []string{
+		"var/lib/lxd",
+		"var/snap/lxd/common/lxd",
+		"var/lib/incus",
+	}
+	// lxcDir contains the containers managed by the classic LXC tools.
+	lxcDir = "var/lib/lxc"
+)
+
+// Container is an LXD, Incus or LXC system container stored on the host.
+type Container struct {
+	// Name of the container.
+	Name string
+	// The LXD project of the container. Empty for the default project.
+	Project string
+	// The storage pool the container is stored in. Empty for LXC containers.
+	Pool string
+	// The storage pool driver, e.g. "dir" or "zfs". Empty if unknown.
+	Driver string
+	// Path of the container's root filesystem, relative to the host root.
+	RootfsPath string
+	// Architecture of the container, e.g. "x86_64".
+	Architecture string
+	// The OS and release of the image the container was created from, e.g.
+	// "Ubuntu" and "jammy". Empty if unknown.
+	ImageOS      string
+	ImageRelease string
+}
+
+// backupFile is the backup.yaml file that LXD stores next to the root
+// filesystem of each container.
+type backupFile struct {
+	Container *instance `yaml:"container"`
+	Pool      *struct {
+		Name   string `yaml:"name"`
+		Driver string `yaml:"driver"`
+	} `yaml:"pool"`
+}
+
+type instance struct {
+	Name         string            `yaml:"name"`
+	Project      string            `yaml:"project"`
+	Architecture string            `yaml:"architecture"`
+	Config       map[string]string `yaml:"config"`
+}
+
+// Containers returns the containers stored on the host. Containers whose root
+// filesystem isn't accessible, e.g. because their ZFS dataset isn't mounted,
+// are skipped.
+func Containers(host *scalibrfs.ScanRoot) ([]*Container, error) {
+	var containers []*Container
+	for _, dir := range lxdDirs {
+		c, err := lxdContainers(host.FS, dir)
+		if err != nil {
+			return nil, err
+		}
+		containers = append(containers, c...)
+	}
+	c, err := lxcContainers(host.FS)
+	if err != nil {
+		return nil, err
+	}
+	return append(containers, c...), nil
+}
+
+// lxdContainers returns the containers in the storage pools of an LXD or
+// Incus daemon. Each container is stored in
+// <state dir>/storage-pools/<pool>/containers/<name>, where non-default
+// projects prefix the name with "<project>_". ZFS datasets are mounted at the
+// same location.
+func lxdContainers(fsys scalibrfs.FS, stateDir string) ([]*Container, error) {
+	poolsDir := path.Join(stateDir, "storage-pools")
+	pools, err := readDir(fsys, poolsDir)
+	if err != nil {
+		return nil, err
+	}
+	var containers []*Container
+	for _, pool := range pools {
+		if !pool.IsDir() {
+			continue
+		}
+		containersDir := path.Join(poolsDir, pool.Name(), "containers")
+		entries, err := readDir(fsys, containersDir)
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range entries {
+			if !e.IsDir() {
+				continue
+			}
+			dir := path.Join(containersDir, e.Name())
+			rootfs := path.Join(dir, "rootfs")
+			if !isDir(fsys, rootfs) {
+				log.Infof("lxd: skipping %s: root filesystem isn't accessible, the storage volume might not be mounted", dir)
+				continue
+			}
+			c := &Container{
+				Name:       e.Name(),
+				Pool:       pool.Name(),
+				RootfsPath: rootfs,
+			}
+			if project, name, ok := strings.Cut(e.Name(), "_"); ok {
+				c.Project, c.Name = project, name
+			}
+			readBackupFile(fsys, path.Join(dir, "backup.yaml"), c)
+			containers = append(containers, c)
+		}
+	}
+	return containers, nil
+}
+
+// readBackupFile sets the container details stored in LXD's backup.yaml file.
+func readBackupFile(fsys scalibrfs.FS, filePath string, c *Container) {
+	f, err := fsys.Open(filePath)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+	var b backupFile
+	if err := yaml.NewDecoder(f).Decode(&b); err != nil {
+		log.Warnf("lxd: failed to parse %s: %v", filePath, err)
+		return
+	}
+	if b.Pool != nil {
+		c.Driver = b.Pool.Driver
+	}
+	if b.Container == nil {
+		return
+	}
+	if b.Container.Name != "" {
+		c.Name = b.Container.Name
+		c.Project = ""
+		if b.Container.Project != "default" {
+			c.Project = b.Container.Project
+		}
+	}
+	c.Architecture = b.Container.Architecture
+	c.ImageOS = b.Container.Config["image.os"]
+	c.ImageRelease = b.Container.Config["image.release"]
+}
+
+// lxcContainers returns the containers created with the classic LXC tools.
+// Each is stored in var/lib/lxc/<name> with a "config" file and the "rootfs"
+// directory.
+func lxcContainers(fsys scalibrfs.FS) ([]*Container, error) {
+	entries, err := readDir(fsys, lxcDir)
+	if err != nil {
+		return nil, err
+	}
+	var containers []*Container
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+		dir := path.Join(lxcDir, e.Name())
+		rootfs := path.Join(dir, "rootfs")
+		if !isDir(fsys, rootfs) {
+			continue
+		}
+		c := &Container{
+			Name:       e.Name(),
+			RootfsPath: rootfs,
+			Driver:     "dir",
+		}
+		readLXCConfig(fsys, path.Join(dir, "config"), c)
+		containers = append(containers, c)
+	}
+	return containers, nil
+}
+
+// readLXCConfig sets the container details stored in an LXC config file.
+func readLXCConfig(fsys scalibrfs.FS, filePath string, c *Container) {
+	f, err := fsys.Open(filePath)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		key, value, ok := strings.Cut(s.Text(), "=")
+		if !ok {
+			continue
+		}
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		switch key {
+		case "lxc.arch":
+			c.Architecture = value
+		case "lxc.rootfs.path", "lxc.rootfs":
+			// E.g. "dir:/var/lib/lxc/web/rootfs" or "zfs:lxc/web".
+			if driver, _, ok := strings.Cut(value, ":"); ok {
+				c.Driver = driver
+			}
+		}
+	}
+}
+
+// ScanRoot returns a scan root for the container's root filesystem.
+func (c *Container) ScanRoot(host *scalibrfs.ScanRoot) *scalibrfs.ScanRoot {
+	if !host.IsVirtual() {
+		p := filepath.Join(host.Path, filepath.FromSlash(c.RootfsPath))
+		return &scalibrfs.ScanRoot{FS: scalibrfs.DirFS(p), Path: p}
+	}
+	return &scalibrfs.ScanRoot{FS: &subFS{fsys: host.FS, dir: c.RootfsPath}}
+}
+
+// subFS is a scalibrfs.FS rooted at a sub-directory of another one.
+type subFS struct {
+	fsys scalibrfs.FS
+	dir  string
+}
+
+func (s *subFS) fullName(op, name string) (string, error) {
+	if !fs.ValidPath(name) {
+		return "", &fs.PathError{Op: op, Path: name, Err: fs.ErrInvalid}
+	}
+	return path.Join(s.dir, name), nil
+}
+
+func (s *subFS) Open(name string) (fs.File, error) {
+	full, err := s.fullName("open", name)
+	if err != nil {
+		return nil, err
+	}
+	return s.fsys.Open(full)
+}
+
+func (s *subFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	full, err := s.fullName("readdir", name)
+	if err != nil {
+		return nil, err
+	}
+	return s.fsys.ReadDir(full)
+}
+
+func (s *subFS) Stat(name string) (fs.FileInfo, error) {
+	full, err := s.fullName("stat", name)
+	if err != nil {
+		return nil, err
+	}
+	return s.fsys.Stat(full)
+}
+
+// readDir returns the sorted entries of a directory, or none if it doesn't
+// exist.
+func readDir(fsys scalibrfs.FS, dir string) ([]fs.DirEntry, error) {
+	entries, err := fsys.ReadDir(dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	slices.SortFunc(entries, func(a, b fs.DirEntry) int { return strings.Compare(a.Name(), b.Name()) })
+	return entries, nil
+}
+
+func isDir(fsys scalibrfs.FS, p string) bool {
+	info, err := fsys.Stat(p)
+	return err == nil && info.IsDir()
+}
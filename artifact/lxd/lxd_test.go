@@ -0,0 +1,157 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lxd_test
+
+import (
+	"io"
+	"io/fs"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/osv-scalibr/artifact/lxd"
+	scalibrfs "github.com/google/osv-scalibr/fs"
+)
+
+const backupYAML = `container:
+  name: web
+  project: default
+  architecture: x86_64
+  config:
+    image.os: Ubuntu
+    image.release: jammy
+pool:
+  name: default
+  driver: zfs
+`
+
+const projectBackupYAML = `container:
+  name: db
+  project: staging
+  architecture: aarch64
+  config: {}
+pool:
+  name: fast
+  driver: dir
+`
+
+const lxcConfig = `# Template used to create this container
+lxc.rootfs.path = dir:/var/lib/lxc/legacy/rootfs
+lxc.arch = amd64
+lxc.uts.name = legacy
+`
+
+func testFS() fstest.MapFS {
+	return fstest.MapFS{
+		"var/snap/lxd/common/lxd/storage-pools/default/containers/web/backup.yaml":         {Data: []byte(backupYAML)},
+		"var/snap/lxd/common/lxd/storage-pools/default/containers/web/rootfs/etc/hostname": {Data: []byte("web")},
+		// An unmounted ZFS dataset.
+		"var/snap/lxd/common/lxd/storage-pools/default/containers/stopped": {Mode: fs.ModeDir | 0755},
+		"var/lib/lxd/storage-pools/fast/containers/staging_db/backup.yaml": {Data: []byte(projectBackupYAML)},
+		"var/lib/lxd/storage-pools/fast/containers/staging_db/rootfs/etc":  {Mode: fs.ModeDir | 0755},
+		"var/lib/incus/storage-pools/pool1/containers/nobackup/rootfs/etc": {Mode: fs.ModeDir | 0755},
+		"var/lib/lxc/legacy/config":                                        {Data: []byte(lxcConfig)},
+		"var/lib/lxc/legacy/rootfs/etc":                                    {Mode: fs.ModeDir | 0755},
+		"var/lib/lxc/not-a-container/file":                                 {},
+	}
+}
+
+func TestContainers(t *testing.T) {
+	host := &scalibrfs.ScanRoot{FS: testFS()}
+	got, err := lxd.Containers(host)
+	if err != nil {
+		t.Fatalf("Containers(): %v", err)
+	}
+
+	want := []*lxd.Container{
+		{
+			Name:         "db",
+			Project:      "staging",
+			Pool:         "fast",
+			Driver:       "dir",
+			RootfsPath:   "var/lib/lxd/storage-pools/fast/containers/staging_db/rootfs",
+			Architecture: "aarch64",
+		},
+		{
+			Name:         "web",
+			Pool:         "default",
+			Driver:       "zfs",
+			RootfsPath:   "var/snap/lxd/common/lxd/storage-pools/default/containers/web/rootfs",
+			Architecture: "x86_64",
+			ImageOS:      "Ubuntu",
+			ImageRelease: "jammy",
+		},
+		{
+			Name:       "nobackup",
+			Pool:       "pool1",
+			RootfsPath: "var/lib/incus/storage-pools/pool1/containers/nobackup/rootfs",
+		},
+		{
+			Name:         "legacy",
+			Driver:       "dir",
+			RootfsPath:   "var/lib/lxc/legacy/rootfs",
+			Architecture: "amd64",
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Containers() returned unexpected diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestContainers_NoContainers(t *testing.T) {
+	host := &scalibrfs.ScanRoot{FS: fstest.MapFS{"etc/hostname": {}}}
+	got, err := lxd.Containers(host)
+	if err != nil {
+		t.Fatalf("Containers(): %v", err)
+	}
+	if len(got) != 0 {
+		t.Errorf("Containers() = %v, want no containers", got)
+	}
+}
+
+func TestScanRoot(t *testing.T) {
+	c := &lxd.Container{Name: "web", RootfsPath: "var/snap/lxd/common/lxd/storage-pools/default/containers/web/rootfs"}
+
+	t.Run("virtual", func(t *testing.T) {
+		root := c.ScanRoot(&scalibrfs.ScanRoot{FS: testFS()})
+		if !root.IsVirtual() {
+			t.Errorf("ScanRoot().IsVirtual() = false, want true")
+		}
+		f, err := root.FS.Open("etc/hostname")
+		if err != nil {
+			t.Fatalf("ScanRoot().FS.Open(etc/hostname): %v", err)
+		}
+		defer f.Close()
+		content, err := io.ReadAll(f)
+		if err != nil {
+			t.Fatalf("io.ReadAll(): %v", err)
+		}
+		if string(content) != "web" {
+			t.Errorf("ScanRoot().FS.Open(etc/hostname) returned %q, want %q", content, "web")
+		}
+		if _, err := root.FS.Open("../../../../../../../../../etc/passwd"); err == nil {
+			t.Error("ScanRoot().FS.Open() outside of the rootfs succeeded, want error")
+		}
+	})
+
+	t.Run("real", func(t *testing.T) {
+		root := c.ScanRoot(&scalibrfs.ScanRoot{Path: "/host"})
+		want := filepath.Join("/host", "var/snap/lxd/common/lxd/storage-pools/default/containers/web/rootfs")
+		if root.Path != want {
+			t.Errorf("ScanRoot().Path = %q, want %q", root.Path, want)
+		}
+	})
+}
@@ -0,0 +1,809 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ext4 provides a read-only SCALIBR filesystem for ext2, ext3 and ext4
+// filesystems, e.g. the root partitions of virtual machine disks, so that they
+// can be scanned without mounting them.
+//
+// The journal isn't replayed, so changes that were only written to the
+// journal of an uncleanly unmounted filesystem aren't visible.
+package ext4
+
+import (
+	"cmp"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	scalibrfs "github.com/google/osv-scalibr/fs"
+)
+
+const (
+	superblockOffset = 1024
+	superblockSize   = 1024
+	magic            = 0xef53
+	rootInode        = 2
+	// maxDirCache is the number of parsed directories cached per filesystem.
+	maxDirCache = 4096
+	// maxSymlinkHops limits symlink resolution, like Linux's ELOOP limit.
+	maxSymlinkHops = 40
+)
+
+// Incompatible features.
+const (
+	incompatCompression = 0x1
+	incompatFiletype    = 0x2
+	incompatMetaBG      = 0x10
+	incompat64Bit       = 0x80
+)
+
+// supportedIncompat are the incompatible features that the FS can read.
+// Unknown features are rejected since they might change the on-disk format.
+const supportedIncompat = incompatFiletype | 0x4 /* recover */ | 0x8 /* journal_dev */ |
+	0x40 /* extents */ | incompat64Bit | 0x100 /* mmp */ | 0x200 /* flex_bg */ |
+	0x400 /* ea_inode */ | 0x1000 /* dirdata */ | 0x2000 /* csum_seed */ |
+	0x4000 /* largedir */ | 0x8000 /* inline_data */ | 0x10000 /* encrypt */ |
+	0x20000 /* casefold */
+
+// Inode flags.
+const (
+	flagExtents    = 0x80000
+	flagInlineData = 0x10000000
+)
+
+// File types of the inode mode.
+const (
+	modeTypeMask = 0xf000
+	modeFifo     = 0x1000
+	modeCharDev  = 0x2000
+	modeDir      = 0x4000
+	modeBlockDev = 0x6000
+	modeFile     = 0x8000
+	modeSymlink  = 0xa000
+	modeSocket   = 0xc000
+)
+
+// ErrUnsupportedFeature is returned for filesystems and files using features
+// that can't be read, e.g. compression or inline data.
+var ErrUnsupportedFeature = errors.New("unsupported ext4 feature")
+
+type superblock struct {
+	blockSize      int64
+	inodesPerGroup uint32
+	inodeSize      uint32
+	descSize       uint32
+	firstDataBlock uint32
+	groupCount     uint32
+	incompat       uint32
+}
+
+// FS is a read-only scalibrfs.FS implementation for ext2, ext3 and ext4
+// filesystems.
+type FS struct {
+	r      io.ReaderAt
+	closer io.Closer
+	sb     superblock
+
+	mu   sync.Mutex
+	dirs map[uint32][]entry
+}
+
+var _ scalibrfs.FS = &FS{}
+
+// Open opens the filesystem image at the given path. The image file stays
+// open until Close is called.
+func Open(path string) (*FS, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	extfs, err := New(f)
+	if err != nil {
+		_ = f.Close()
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	extfs.closer = f
+	return extfs, nil
+}
+
+// IsExt returns whether r contains an ext2, ext3 or ext4 filesystem.
+func IsExt(r io.ReaderAt) bool {
+	var m [2]byte
+	if _, err := r.ReadAt(m[:], superblockOffset+0x38); err != nil {
+		return false
+	}
+	return binary.LittleEndian.Uint16(m[:]) == magic
+}
+
+// New returns an FS that reads the filesystem from r.
+func New(r io.ReaderAt) (*FS, error) {
+	buf := make([]byte, superblockSize)
+	if _, err := r.ReadAt(buf, superblockOffset); err != nil {
+		return nil, fmt.Errorf("failed to read ext4 superblock: %w", err)
+	}
+	le := binary.LittleEndian
+	if le.Uint16(buf[0x38:]) != magic {
+		return nil, errors.New("not an ext2/3/4 filesystem")
+	}
+	logBlockSize := le.Uint32(buf[0x18:])
+	if logBlockSize > 6 {
+		return nil, fmt.Errorf("invalid ext4 block size 2^%d KiB", logBlockSize)
+	}
+	sb := superblock{
+		blockSize:      1024 << logBlockSize,
+		inodesPerGroup: le.Uint32(buf[0x28:]),
+		inodeSize:      128,
+		descSize:       32,
+		firstDataBlock: le.Uint32(buf[0x14:]),
+	}
+	if revLevel := le.Uint32(buf[0x4c:]); revLevel >= 1 {
+		sb.inodeSize = uint32(le.Uint16(buf[0x58:]))
+		sb.incompat = le.Uint32(buf[0x60:])
+	}
+	if unsupported := sb.incompat &^ supportedIncompat; unsupported != 0 {
+		return nil, fmt.Errorf("%w: incompatible features %#x", ErrUnsupportedFeature, unsupported)
+	}
+	if sb.incompat&incompatMetaBG != 0 {
+		return nil, fmt.Errorf("%w: meta_bg", ErrUnsupportedFeature)
+	}
+	if sb.incompat&incompat64Bit != 0 {
+		if sb.descSize = uint32(le.Uint16(buf[0xfe:])); sb.descSize < 32 {
+			return nil, fmt.Errorf("invalid ext4 group descriptor size %d", sb.descSize)
+		}
+	}
+	if sb.inodeSize < 128 || sb.inodesPerGroup == 0 {
+		return nil, errors.New("invalid ext4 inode geometry")
+	}
+	blocksPerGroup := uint64(le.Uint32(buf[0x20:]))
+	if blocksPerGroup == 0 {
+		return nil, errors.New("invalid ext4 blocks per group")
+	}
+	blockCount := uint64(le.Uint32(buf[0x4:]))
+	if sb.incompat&incompat64Bit != 0 {
+		blockCount |= uint64(le.Uint32(buf[0x150:])) << 32
+	}
+	sb.groupCount = uint32((blockCount - uint64(sb.firstDataBlock) + blocksPerGroup - 1) / blocksPerGroup)
+	return &FS{r: r, sb: sb, dirs: make(map[uint32][]entry)}, nil
+}
+
+// Close closes the image file if the FS was created with Open.
+func (f *FS) Close() error {
+	if f.closer == nil {
+		return nil
+	}
+	return f.closer.Close()
+}
+
+// Open opens the named file or directory for reading. Symlinks are followed
+// within the filesystem.
+func (f *FS) Open(name string) (fs.File, error) {
+	in, err := f.resolve("open", name, true)
+	if err != nil {
+		return nil, err
+	}
+	return &file{fs: f, name: name, info: &fileInfo{name: path.Base(name), inode: in}}, nil
+}
+
+// ReadDir reads the named directory and returns its entries sorted by name.
+func (f *FS) ReadDir(name string) ([]fs.DirEntry, error) {
+	in, err := f.resolve("readdir", name, true)
+	if err != nil {
+		return nil, err
+	}
+	if !in.isDir() {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: errors.New("not a directory")}
+	}
+	entries, err := f.readDir(in)
+	if err != nil {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: err}
+	}
+	result := make([]fs.DirEntry, 0, len(entries))
+	for _, e := range entries {
+		result = append(result, &dirEntry{fs: f, entry: e})
+	}
+	return result, nil
+}
+
+// Stat returns a FileInfo describing the named file or directory. Symlinks
+// are followed within the filesystem.
+func (f *FS) Stat(name string) (fs.FileInfo, error) {
+	in, err := f.resolve("stat", name, true)
+	if err != nil {
+		return nil, err
+	}
+	return &fileInfo{name: path.Base(name), inode: in}, nil
+}
+
+// resolve returns the inode of the named file. Symlinks in the path are
+// resolved relative to the filesystem root, the last element is only followed
+// if followLast is set.
+func (f *FS) resolve(op, name string, followLast bool) (*inode, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: op, Path: name, Err: fs.ErrInvalid}
+	}
+	root, err := f.readInode(rootInode)
+	if err != nil {
+		return nil, &fs.PathError{Op: op, Path: name, Err: err}
+	}
+	if name == "." {
+		return root, nil
+	}
+
+	hops := 0
+	elems := strings.Split(name, "/")
+	// parents holds the inodes of the directories leading to the current one.
+	parents := []*inode{root}
+	for len(elems) > 0 {
+		elem := elems[0]
+		elems = elems[1:]
+		cur := parents[len(parents)-1]
+		switch elem {
+		case "", ".":
+			continue
+		case "..":
+			if len(parents) > 1 {
+				parents = parents[:len(parents)-1]
+			}
+			continue
+		}
+		if !cur.isDir() {
+			return nil, &fs.PathError{Op: op, Path: name, Err: fs.ErrNotExist}
+		}
+		entries, err := f.readDir(cur)
+		if err != nil {
+			return nil, &fs.PathError{Op: op, Path: name, Err: err}
+		}
+		i, found := slices.BinarySearchFunc(entries, elem, func(e entry, name string) int {
+			return strings.Compare(e.name, name)
+		})
+		if !found {
+			return nil, &fs.PathError{Op: op, Path: name, Err: fs.ErrNotExist}
+		}
+		in, err := f.readInode(entries[i].ino)
+		if err != nil {
+			return nil, &fs.PathError{Op: op, Path: name, Err: err}
+		}
+		if in.isSymlink() && (len(elems) > 0 || followLast) {
+			hops++
+			if hops > maxSymlinkHops {
+				return nil, &fs.PathError{Op: op, Path: name, Err: errors.New("too many levels of symbolic links")}
+			}
+			target, err := f.readlink(in)
+			if err != nil {
+				return nil, &fs.PathError{Op: op, Path: name, Err: err}
+			}
+			if strings.HasPrefix(target, "/") {
+				parents = parents[:1]
+			}
+			elems = append(strings.Split(target, "/"), elems...)
+			continue
+		}
+		parents = append(parents, in)
+	}
+	return parents[len(parents)-1], nil
+}
+
+// inode is the subset of the inode fields used by the FS.
+type inode struct {
+	num   uint32
+	mode  uint16
+	flags uint32
+	size  uint64
+	mtime uint32
+	// The raw i_block field, which holds the extent tree root, the block
+	// map or the target of fast symlinks.
+	block [60]byte
+	// extents maps the logical blocks of the file, loaded on first use.
+	extents []extent
+	loaded  bool
+}
+
+func (in *inode) isDir() bool { return in.mode&modeTypeMask == modeDir }
+
+func (in *inode) isSymlink() bool { return in.mode&modeTypeMask == modeSymlink }
+
+func (in *inode) isFile() bool { return in.mode&modeTypeMask == modeFile }
+
+func (f *FS) readInode(num uint32) (*inode, error) {
+	if num == 0 {
+		return nil, errors.New("invalid inode 0")
+	}
+	group := (num - 1) / f.sb.inodesPerGroup
+	index := (num - 1) % f.sb.inodesPerGroup
+	if group >= f.sb.groupCount {
+		return nil, fmt.Errorf("inode %d out of range", num)
+	}
+
+	descBlock := int64(f.sb.firstDataBlock) + 1
+	desc := make([]byte, f.sb.descSize)
+	if _, err := f.r.ReadAt(desc, descBlock*f.sb.blockSize+int64(group)*int64(f.sb.descSize)); err != nil {
+		return nil, fmt.Errorf("failed to read group descriptor %d: %w", group, err)
+	}
+	le := binary.LittleEndian
+	table := uint64(le.Uint32(desc[0x8:]))
+	if f.sb.descSize >= 64 {
+		table |= uint64(le.Uint32(desc[0x28:])) << 32
+	}
+
+	buf := make([]byte, 0x70)
+	if _, err := f.r.ReadAt(buf, int64(table)*f.sb.blockSize+int64(index)*int64(f.sb.inodeSize)); err != nil {
+		return nil, fmt.Errorf("failed to read inode %d: %w", num, err)
+	}
+	in := &inode{
+		num:   num,
+		mode:  le.Uint16(buf[0x0:]),
+		size:  uint64(le.Uint32(buf[0x4:])) | uint64(le.Uint32(buf[0x6c:]))<<32,
+		mtime: le.Uint32(buf[0x10:]),
+		flags: le.Uint32(buf[0x20:]),
+	}
+	copy(in.block[:], buf[0x28:0x28+60])
+	return in, nil
+}
+
+// readlink returns the target of a symlink. Short targets are stored in the
+// inode itself.
+func (f *FS) readlink(in *inode) (string, error) {
+	if in.size < 60 && in.flags&(flagExtents|flagInlineData) == 0 {
+		return string(in.block[:in.size]), nil
+	}
+	if in.size > 4096 {
+		return "", fmt.Errorf("symlink target of inode %d too long", in.num)
+	}
+	buf := make([]byte, in.size)
+	if _, err := f.readAt(in, buf, 0); err != nil && !errors.Is(err, io.EOF) {
+		return "", err
+	}
+	return string(buf), nil
+}
+
+// entry is a directory entry.
+type entry struct {
+	name string
+	ino  uint32
+	typ  uint8
+}
+
+// Directory entry file types.
+const (
+	ftFile     = 1
+	ftDir      = 2
+	ftCharDev  = 3
+	ftBlockDev = 4
+	ftFifo     = 5
+	ftSocket   = 6
+	ftSymlink  = 7
+)
+
+// readDir returns the entries of the directory sorted by name, without "."
+// and "..". Hashed directories are read linearly, as their index blocks look
+// like empty directory blocks.
+func (f *FS) readDir(in *inode) ([]entry, error) {
+	f.mu.Lock()
+	cached, ok := f.dirs[in.num]
+	f.mu.Unlock()
+	if ok {
+		return cached, nil
+	}
+
+	if in.size > 1<<30 {
+		return nil, fmt.Errorf("directory inode %d too large", in.num)
+	}
+	data := make([]byte, in.size)
+	if _, err := f.readAt(in, data, 0); err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	hasType := f.sb.incompat&incompatFiletype != 0
+	var entries []entry
+	for block := int64(0); block < int64(len(data)); block += f.sb.blockSize {
+		b := data[block:min(block+f.sb.blockSize, int64(len(data)))]
+		for off := 0; off+8 <= len(b); {
+			ino := binary.LittleEndian.Uint32(b[off:])
+			recLen := int(binary.LittleEndian.Uint16(b[off+4:]))
+			if recLen == 0 || recLen == 0xffff {
+				// Used for empty blocks of 64 KiB.
+				recLen = len(b) - off
+			}
+			if recLen < 8 || off+recLen > len(b) {
+				return nil, fmt.Errorf("invalid directory entry in inode %d", in.num)
+			}
+			nameLen := int(b[off+6])
+			var typ uint8
+			if hasType {
+				typ = b[off+7]
+			} else {
+				nameLen |= int(b[off+7]) << 8
+			}
+			if 8+nameLen > recLen {
+				return nil, fmt.Errorf("invalid directory entry name in inode %d", in.num)
+			}
+			name := string(b[off+8 : off+8+nameLen])
+			if ino != 0 && name != "." && name != ".." {
+				entries = append(entries, entry{name: name, ino: ino, typ: typ})
+			}
+			off += recLen
+		}
+	}
+	slices.SortFunc(entries, func(a, b entry) int { return strings.Compare(a.name, b.name) })
+
+	f.mu.Lock()
+	if len(f.dirs) >= maxDirCache {
+		clear(f.dirs)
+	}
+	f.dirs[in.num] = entries
+	f.mu.Unlock()
+	return entries, nil
+}
+
+// extent maps a run of logical blocks of a file to physical blocks.
+type extent struct {
+	logical  uint32
+	length   uint32
+	physical uint64
+	// Uninitialized extents are allocated but read as zeros.
+	uninit bool
+}
+
+// blockMap returns the extents of the file sorted by logical block.
+func (f *FS) blockMap(in *inode) ([]extent, error) {
+	if in.loaded {
+		return in.extents, nil
+	}
+	if in.flags&flagInlineData != 0 {
+		return nil, fmt.Errorf("%w: inline data in inode %d", ErrUnsupportedFeature, in.num)
+	}
+	var err error
+	if in.flags&flagExtents != 0 {
+		in.extents, err = f.readExtentTree(in.block[:], 0)
+	} else {
+		in.extents, err = f.readBlockMap(in)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("inode %d: %w", in.num, err)
+	}
+	slices.SortFunc(in.extents, func(a, b extent) int { return cmp.Compare(a.logical, b.logical) })
+	in.loaded = true
+	return in.extents, nil
+}
+
+// readExtentTree reads the extent tree node stored in b and its children.
+func (f *FS) readExtentTree(b []byte, level int) ([]extent, error) {
+	le := binary.LittleEndian
+	if len(b) < 12 || le.Uint16(b[0:]) != 0xf30a {
+		return nil, errors.New("invalid extent header")
+	}
+	count := int(le.Uint16(b[2:]))
+	depth := le.Uint16(b[6:])
+	if 12+count*12 > len(b) || level > 5 {
+		return nil, errors.New("invalid extent tree")
+	}
+	var extents []extent
+	for i := range count {
+		e := b[12+i*12:]
+		if depth == 0 {
+			length := uint32(le.Uint16(e[4:]))
+			uninit := length > 32768
+			if uninit {
+				length -= 32768
+			}
+			extents = append(extents, extent{
+				logical:  le.Uint32(e[0:]),
+				length:   length,
+				physical: uint64(le.Uint16(e[6:]))<<32 | uint64(le.Uint32(e[8:])),
+				uninit:   uninit,
+			})
+			continue
+		}
+		leaf := uint64(le.Uint16(e[8:]))<<32 | uint64(le.Uint32(e[4:]))
+		node := make([]byte, f.sb.blockSize)
+		if _, err := f.r.ReadAt(node, int64(leaf)*f.sb.blockSize); err != nil {
+			return nil, fmt.Errorf("failed to read extent block %d: %w", leaf, err)
+		}
+		children, err := f.readExtentTree(node, level+1)
+		if err != nil {
+			return nil, err
+		}
+		extents = append(extents, children...)
+	}
+	return extents, nil
+}
+
+// readBlockMap converts the direct and indirect block pointers used by ext2
+// and ext3 into extents.
+func (f *FS) readBlockMap(in *inode) ([]extent, error) {
+	blockCount := uint32((int64(in.size) + f.sb.blockSize - 1) / f.sb.blockSize)
+	ptrsPerBlock := uint32(f.sb.blockSize / 4)
+	var extents []extent
+	add := func(logical uint32, physical uint64) {
+		if physical == 0 {
+			return
+		}
+		if n := len(extents); n > 0 {
+			last := &extents[n-1]
+			if last.logical+last.length == logical && last.physical+uint64(last.length) == physical {
+				last.length++
+				return
+			}
+		}
+		extents = append(extents, extent{logical: logical, length: 1, physical: physical})
+	}
+
+	// walk maps the blocks referenced by the indirect block at the given level,
+	// starting at the given logical block, and returns the next logical block.
+	var walk func(ptr uint32, level int, logical uint32) (uint32, error)
+	walk = func(ptr uint32, level int, logical uint32) (uint32, error) {
+		span := uint32(1)
+		for range level {
+			span *= ptrsPerBlock
+		}
+		if ptr == 0 {
+			return logical + span, nil
+		}
+		if level == 0 {
+			add(logical, uint64(ptr))
+			return logical + 1, nil
+		}
+		buf := make([]byte, f.sb.blockSize)
+		if _, err := f.r.ReadAt(buf, int64(ptr)*f.sb.blockSize); err != nil {
+			return 0, fmt.Errorf("failed to read indirect block %d: %w", ptr, err)
+		}
+		for i := range ptrsPerBlock {
+			if logical >= blockCount {
+				break
+			}
+			var err error
+			if logical, err = walk(binary.LittleEndian.Uint32(buf[i*4:]), level-1, logical); err != nil {
+				return 0, err
+			}
+		}
+		return logical, nil
+	}
+
+	logical := uint32(0)
+	for i := 0; i < 15 && logical < blockCount; i++ {
+		level := max(0, i-11)
+		var err error
+		if logical, err = walk(binary.LittleEndian.Uint32(in.block[i*4:]), level, logical); err != nil {
+			return nil, err
+		}
+	}
+	return extents, nil
+}
+
+// readAt reads the contents of the file at the given offset. Holes and
+// uninitialized extents read as zeros.
+func (f *FS) readAt(in *inode, b []byte, off int64) (int, error) {
+	extents, err := f.blockMap(in)
+	if err != nil {
+		return 0, err
+	}
+	n := 0
+	for n < len(b) {
+		if off >= int64(in.size) {
+			return n, io.EOF
+		}
+		want := min(int64(len(b)-n), int64(in.size)-off)
+		logical := uint32(off / f.sb.blockSize)
+		i, found := slices.BinarySearchFunc(extents, logical, func(e extent, l uint32) int {
+			switch {
+			case l < e.logical:
+				return 1
+			case l >= e.logical+e.length:
+				return -1
+			default:
+				return 0
+			}
+		})
+		if !found {
+			// A hole: read zeros up to the next extent.
+			end := int64(in.size)
+			if i < len(extents) {
+				end = int64(extents[i].logical) * f.sb.blockSize
+			}
+			c := int(min(want, end-off))
+			clear(b[n : n+c])
+			n += c
+			off += int64(c)
+			continue
+		}
+		e := extents[i]
+		extentEnd := int64(e.logical+e.length) * f.sb.blockSize
+		c := int(min(want, extentEnd-off))
+		if e.uninit {
+			clear(b[n : n+c])
+		} else {
+			pos := int64(e.physical)*f.sb.blockSize + off - int64(e.logical)*f.sb.blockSize
+			if _, err := f.r.ReadAt(b[n:n+c], pos); err != nil {
+				return n, fmt.Errorf("failed to read data of inode %d: %w", in.num, err)
+			}
+		}
+		n += c
+		off += int64(c)
+	}
+	return n, nil
+}
+
+// fileInfo implements fs.FileInfo for ext4 inodes.
+type fileInfo struct {
+	name  string
+	inode *inode
+}
+
+func (i *fileInfo) Name() string { return i.name }
+func (i *fileInfo) Size() int64 {
+	if i.inode.isDir() {
+		return 0
+	}
+	return int64(i.inode.size)
+}
+func (i *fileInfo) ModTime() time.Time { return time.Unix(int64(i.inode.mtime), 0) }
+func (i *fileInfo) IsDir() bool        { return i.inode.isDir() }
+func (i *fileInfo) Sys() any           { return nil }
+func (i *fileInfo) Mode() fs.FileMode {
+	mode := fs.FileMode(i.inode.mode & 0777)
+	switch i.inode.mode & modeTypeMask {
+	case modeDir:
+		mode |= fs.ModeDir
+	case modeSymlink:
+		mode |= fs.ModeSymlink
+	case modeBlockDev:
+		mode |= fs.ModeDevice
+	case modeCharDev:
+		mode |= fs.ModeDevice | fs.ModeCharDevice
+	case modeFifo:
+		mode |= fs.ModeNamedPipe
+	case modeSocket:
+		mode |= fs.ModeSocket
+	}
+	return mode
+}
+
+func typeMode(typ uint8) fs.FileMode {
+	switch typ {
+	case ftDir:
+		return fs.ModeDir
+	case ftSymlink:
+		return fs.ModeSymlink
+	case ftBlockDev:
+		return fs.ModeDevice
+	case ftCharDev:
+		return fs.ModeDevice | fs.ModeCharDevice
+	case ftFifo:
+		return fs.ModeNamedPipe
+	case ftSocket:
+		return fs.ModeSocket
+	default:
+		return 0
+	}
+}
+
+// dirEntry implements fs.DirEntry. The inode is only read when Info is called
+// or when the filesystem doesn't store the file type in directory entries.
+type dirEntry struct {
+	fs    *FS
+	entry entry
+}
+
+func (e *dirEntry) Name() string { return e.entry.name }
+func (e *dirEntry) IsDir() bool  { return e.Type().IsDir() }
+func (e *dirEntry) Type() fs.FileMode {
+	if e.entry.typ != 0 {
+		return typeMode(e.entry.typ)
+	}
+	info, err := e.Info()
+	if err != nil {
+		return 0
+	}
+	return info.Mode().Type()
+}
+func (e *dirEntry) Info() (fs.FileInfo, error) {
+	in, err := e.fs.readInode(e.entry.ino)
+	if err != nil {
+		return nil, err
+	}
+	return &fileInfo{name: e.entry.name, inode: in}, nil
+}
+
+// file is an opened file or directory of the filesystem.
+type file struct {
+	fs   *FS
+	name string
+	info *fileInfo
+	pos  int64
+	// entries holds the directory entries not yet returned by ReadDir.
+	entries []fs.DirEntry
+	listed  bool
+}
+
+var _ io.ReaderAt = &file{}
+
+func (f *file) Stat() (fs.FileInfo, error) { return f.info, nil }
+
+func (f *file) Read(b []byte) (int, error) {
+	n, err := f.ReadAt(b, f.pos)
+	f.pos += int64(n)
+	if errors.Is(err, io.EOF) && n > 0 {
+		err = nil
+	}
+	return n, err
+}
+
+func (f *file) ReadAt(b []byte, off int64) (int, error) {
+	if !f.info.inode.isFile() {
+		return 0, &fs.PathError{Op: "read", Path: f.name, Err: errors.New("not a regular file")}
+	}
+	if off < 0 {
+		return 0, &fs.PathError{Op: "read", Path: f.name, Err: fs.ErrInvalid}
+	}
+	n, err := f.fs.readAt(f.info.inode, b, off)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return n, &fs.PathError{Op: "read", Path: f.name, Err: err}
+	}
+	return n, err
+}
+
+func (f *file) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += f.pos
+	case io.SeekEnd:
+		offset += f.info.Size()
+	default:
+		return 0, &fs.PathError{Op: "seek", Path: f.name, Err: fs.ErrInvalid}
+	}
+	if offset < 0 {
+		return 0, &fs.PathError{Op: "seek", Path: f.name, Err: fs.ErrInvalid}
+	}
+	f.pos = offset
+	return offset, nil
+}
+
+func (f *file) ReadDir(n int) ([]fs.DirEntry, error) {
+	if !f.info.IsDir() {
+		return nil, &fs.PathError{Op: "readdir", Path: f.name, Err: errors.New("not a directory")}
+	}
+	if !f.listed {
+		entries, err := f.fs.ReadDir(f.name)
+		if err != nil {
+			return nil, err
+		}
+		f.entries = entries
+		f.listed = true
+	}
+	if n <= 0 {
+		entries := f.entries
+		f.entries = nil
+		return entries, nil
+	}
+	if len(f.entries) == 0 {
+		return nil, io.EOF
+	}
+	n = min(n, len(f.entries))
+	entries := f.entries[:n]
+	f.entries = f.entries[n:]
+	return entries, nil
+}
+
+func (f *file) Close() error {
+	f.entries = nil
+	return nil
+}
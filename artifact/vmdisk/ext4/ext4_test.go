@@ -0,0 +1,262 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ext4_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/osv-scalibr/artifact/vmdisk/ext4"
+)
+
+// The test images were created with
+//
+//	mke2fs -t ext4 -b 4096 -d src ext4.img 4M
+//	mke2fs -t ext2 -b 1024 -d src ext2.img 4M
+//
+// from a directory with the files described by testFiles and testSymlinks.
+// The ext2 image stores files with direct and indirect block pointers instead
+// of extents.
+var images = []string{"testdata/ext4.img.gz", "testdata/ext2.img.gz"}
+
+func testFiles() map[string]string {
+	data := make([]byte, 300000)
+	for i := range data {
+		data[i] = byte((i*7 + 3) % 251)
+	}
+	files := map[string]string{
+		"etc/os-release":         "ID=debian\nVERSION_ID=\"12\"\n",
+		"var/lib/dpkg/status":    "Package: bash\nStatus: install ok installed\nVersion: 5.2.15-2\n",
+		"usr/share/zoneinfo/UTC": "UTC\n",
+		"usr/lib/libdata.so":     string(data),
+	}
+	for i := 1; i <= 300; i++ {
+		files[fmt.Sprintf("many/file%d.txt", i)] = fmt.Sprintf("%d\n", i)
+	}
+	return files
+}
+
+func readImage(t *testing.T, path string) []byte {
+	t.Helper()
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("os.Open(%q): %v", path, err)
+	}
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		t.Fatalf("gzip.NewReader(%q): %v", path, err)
+	}
+	img, err := io.ReadAll(gz)
+	if err != nil {
+		t.Fatalf("io.ReadAll(%q): %v", path, err)
+	}
+	return img
+}
+
+func mustNew(t *testing.T, img []byte) *ext4.FS {
+	t.Helper()
+	extfs, err := ext4.New(bytes.NewReader(img))
+	if err != nil {
+		t.Fatalf("ext4.New(): %v", err)
+	}
+	return extfs
+}
+
+func TestFS(t *testing.T) {
+	files := testFiles()
+	for _, path := range images {
+		t.Run(filepath.Base(path), func(t *testing.T) {
+			extfs := mustNew(t, readImage(t, path))
+			// fstest.TestFS expects symlinks to be reported by Lstat, so only the
+			// directories without symlinks are checked.
+			for _, dir := range []string{"many", "usr", "var"} {
+				sub, err := fs.Sub(extfs, dir)
+				if err != nil {
+					t.Fatalf("fs.Sub(%q): %v", dir, err)
+				}
+				var names []string
+				for name := range files {
+					if rel, ok := strings.CutPrefix(name, dir+"/"); ok {
+						names = append(names, rel)
+					}
+				}
+				if err := fstest.TestFS(sub, names...); err != nil {
+					t.Errorf("fstest.TestFS(%q): %v", dir, err)
+				}
+			}
+			for name, want := range files {
+				got, err := fs.ReadFile(extfs, name)
+				if err != nil {
+					t.Fatalf("fs.ReadFile(%q): %v", name, err)
+				}
+				if string(got) != want {
+					t.Errorf("fs.ReadFile(%q) returned %d bytes, want %d", name, len(got), len(want))
+				}
+			}
+		})
+	}
+}
+
+func TestSymlinks(t *testing.T) {
+	for _, path := range images {
+		t.Run(filepath.Base(path), func(t *testing.T) {
+			extfs := mustNew(t, readImage(t, path))
+
+			testCases := []struct {
+				path    string
+				want    string
+				wantErr bool
+			}{
+				// Absolute target stored in the inode.
+				{path: "etc/localtime", want: "UTC\n"},
+				// Relative directory symlink.
+				{path: "lib/libdata.so", want: testFiles()["usr/lib/libdata.so"]},
+				// Target longer than 60 bytes, stored in a data block.
+				{path: "etc/longlink", want: "UTC\n"},
+				{path: "etc/os-release/x", wantErr: true},
+				{path: "missing", wantErr: true},
+			}
+			for _, tc := range testCases {
+				got, err := fs.ReadFile(extfs, tc.path)
+				if (err != nil) != tc.wantErr {
+					t.Fatalf("fs.ReadFile(%q) error: %v, want error: %t", tc.path, err, tc.wantErr)
+				}
+				if string(got) != tc.want {
+					t.Errorf("fs.ReadFile(%q) = %q, want %q", tc.path, got, tc.want)
+				}
+			}
+
+			entries, err := extfs.ReadDir(".")
+			if err != nil {
+				t.Fatalf("ReadDir(.): %v", err)
+			}
+			modes := map[string]fs.FileMode{}
+			for _, e := range entries {
+				modes[e.Name()] = e.Type()
+			}
+			wantModes := map[string]fs.FileMode{
+				"etc":        fs.ModeDir,
+				"lib":        fs.ModeSymlink,
+				"lost+found": fs.ModeDir,
+				"many":       fs.ModeDir,
+				"usr":        fs.ModeDir,
+				"var":        fs.ModeDir,
+			}
+			if diff := cmp.Diff(wantModes, modes); diff != "" {
+				t.Errorf("ReadDir(.) returned unexpected entry types (-want +got):\n%s", diff)
+			}
+			if info, err := extfs.Stat("lib"); err != nil || !info.IsDir() {
+				t.Errorf("Stat(lib) = %v, %v, want directory", info, err)
+			}
+		})
+	}
+}
+
+func TestReadAt(t *testing.T) {
+	content := testFiles()["usr/lib/libdata.so"]
+	for _, path := range images {
+		t.Run(filepath.Base(path), func(t *testing.T) {
+			extfs := mustNew(t, readImage(t, path))
+			f, err := extfs.Open("usr/lib/libdata.so")
+			if err != nil {
+				t.Fatalf("Open(usr/lib/libdata.so): %v", err)
+			}
+			defer f.Close()
+			r, ok := f.(io.ReaderAt)
+			if !ok {
+				t.Fatalf("Open(usr/lib/libdata.so) returned a %T, want io.ReaderAt", f)
+			}
+			// Read across block boundaries and the indirect blocks of the ext2 image.
+			for _, off := range []int{0, 4000, 12*1024 - 100, 268*1024 - 100, len(content) - 10} {
+				buf := make([]byte, 200)
+				n, err := r.ReadAt(buf, int64(off))
+				want := content[off:min(off+200, len(content))]
+				if err != nil && !errors.Is(err, io.EOF) {
+					t.Fatalf("ReadAt(%d): %v", off, err)
+				}
+				if string(buf[:n]) != want {
+					t.Errorf("ReadAt(%d) = %q, want %q", off, buf[:n], want)
+				}
+			}
+		})
+	}
+}
+
+func TestOpen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ext4.img")
+	if err := os.WriteFile(path, readImage(t, images[0]), 0644); err != nil {
+		t.Fatal(err)
+	}
+	extfs, err := ext4.Open(path)
+	if err != nil {
+		t.Fatalf("Open(%q): %v", path, err)
+	}
+	defer extfs.Close()
+	got, err := fs.ReadFile(extfs, "etc/os-release")
+	if err != nil || string(got) != testFiles()["etc/os-release"] {
+		t.Errorf("fs.ReadFile(etc/os-release) = %q, %v, want %q", got, err, testFiles()["etc/os-release"])
+	}
+}
+
+func TestNew_Invalid(t *testing.T) {
+	valid := readImage(t, images[0])
+	withIncompat := func(features uint32) []byte {
+		img := slices.Clone(valid)
+		img[1024+0x60] |= byte(features)
+		return img
+	}
+	testCases := []struct {
+		desc    string
+		img     []byte
+		wantErr error
+	}{
+		{desc: "empty", img: nil},
+		{desc: "not_ext", img: make([]byte, 4096)},
+		{desc: "compression", img: withIncompat(0x1), wantErr: ext4.ErrUnsupportedFeature},
+		{desc: "meta_bg", img: withIncompat(0x10), wantErr: ext4.ErrUnsupportedFeature},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := ext4.New(bytes.NewReader(tc.img))
+			if err == nil {
+				t.Fatalf("ext4.New() succeeded, want error")
+			}
+			if tc.wantErr != nil && !errors.Is(err, tc.wantErr) {
+				t.Errorf("ext4.New() error: %v, want %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestIsExt(t *testing.T) {
+	if !ext4.IsExt(bytes.NewReader(readImage(t, images[0]))) {
+		t.Errorf("IsExt(ext4 image) = false, want true")
+	}
+	if ext4.IsExt(bytes.NewReader(make([]byte, 4096))) {
+		t.Errorf("IsExt(zeros) = true, want false")
+	}
+}
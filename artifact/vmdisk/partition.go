@@ -0,0 +1,263 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vmdisk
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/google/osv-scalibr/artifact/vmdisk/ext4"
+)
+
+const (
+	mbrSignatureOffset = 510
+	mbrEntriesOffset   = 446
+	mbrTypeGPT         = 0xee
+	// maxLogicalPartitions limits the chain of extended boot records.
+	maxLogicalPartitions = 128
+	maxGPTEntries        = 1024
+)
+
+// Partition is a partition of a disk image, or the whole disk if it isn't
+// partitioned.
+type Partition struct {
+	// The number of the partition as used by Linux, e.g. 1 for /dev/sda1. 0 if
+	// the disk isn't partitioned.
+	Index int
+	// The partition type: the hexadecimal MBR type, e.g. "0x83", or the GPT
+	// partition type GUID.
+	Type string
+	// The name of GPT partitions.
+	Name string
+	// The location of the partition on the disk in bytes.
+	Offset int64
+	Size   int64
+	// The filesystem detected on the partition.
+	Filesystem Filesystem
+
+	r *io.SectionReader
+}
+
+// Reader returns a reader for the raw contents of the partition.
+func (p *Partition) Reader() *io.SectionReader {
+	return io.NewSectionReader(p.r, 0, p.r.Size())
+}
+
+// readPartitions returns the partitions of the disk. Disks without a partition
+// table are returned as a single partition.
+func readPartitions(dev device) ([]*Partition, error) {
+	whole := newPartition(dev, 0, "", "", 0, dev.Size())
+	// Filesystems like FAT and NTFS also end their boot sector with the MBR
+	// signature, so they're checked first.
+	if whole.Filesystem != FilesystemUnknown {
+		return []*Partition{whole}, nil
+	}
+	mbr := make([]byte, sectorSize)
+	if _, err := dev.ReadAt(mbr, 0); err != nil {
+		return nil, fmt.Errorf("failed to read partition table: %w", err)
+	}
+	if mbr[mbrSignatureOffset] != 0x55 || mbr[mbrSignatureOffset+1] != 0xaa {
+		return []*Partition{whole}, nil
+	}
+
+	var parts []*Partition
+	for i := range 4 {
+		e := mbr[mbrEntriesOffset+16*i:]
+		typ := e[4]
+		start := int64(binary.LittleEndian.Uint32(e[8:])) * sectorSize
+		size := int64(binary.LittleEndian.Uint32(e[12:])) * sectorSize
+		switch {
+		case typ == 0 || size == 0:
+			continue
+		case typ == mbrTypeGPT:
+			return readGPT(dev)
+		case isExtended(typ):
+			logical, err := readLogicalPartitions(dev, start)
+			if err != nil {
+				return nil, err
+			}
+			parts = append(parts, logical...)
+			continue
+		}
+		if p := newPartition(dev, i+1, fmt.Sprintf("0x%02x", typ), "", start, size); p != nil {
+			parts = append(parts, p)
+		}
+	}
+	if len(parts) == 0 {
+		return []*Partition{whole}, nil
+	}
+	return parts, nil
+}
+
+func isExtended(typ byte) bool { return typ == 0x05 || typ == 0x0f || typ == 0x85 }
+
+// readLogicalPartitions follows the chain of extended boot records that
+// describe the logical partitions inside an extended partition.
+func readLogicalPartitions(dev device, extStart int64) ([]*Partition, error) {
+	var parts []*Partition
+	ebrStart := extStart
+	ebr := make([]byte, sectorSize)
+	for i := range maxLogicalPartitions {
+		if _, err := dev.ReadAt(ebr, ebrStart); err != nil {
+			return nil, fmt.Errorf("failed to read extended boot record: %w", err)
+		}
+		if ebr[mbrSignatureOffset] != 0x55 || ebr[mbrSignatureOffset+1] != 0xaa {
+			break
+		}
+		e := ebr[mbrEntriesOffset:]
+		if typ := e[4]; typ != 0 {
+			// The logical partition starts relative to its boot record.
+			start := ebrStart + int64(binary.LittleEndian.Uint32(e[8:]))*sectorSize
+			size := int64(binary.LittleEndian.Uint32(e[12:])) * sectorSize
+			if p := newPartition(dev, 5+i, fmt.Sprintf("0x%02x", typ), "", start, size); p != nil {
+				parts = append(parts, p)
+			}
+		}
+		next := ebr[mbrEntriesOffset+16:]
+		if next[4] == 0 {
+			break
+		}
+		// The next boot record is relative to the extended partition.
+		ebrStart = extStart + int64(binary.LittleEndian.Uint32(next[8:]))*sectorSize
+	}
+	return parts, nil
+}
+
+// readGPT reads the partitions of a GUID partition table.
+func readGPT(dev device) ([]*Partition, error) {
+	hdr := make([]byte, 92)
+	if _, err := dev.ReadAt(hdr, sectorSize); err != nil {
+		return nil, fmt.Errorf("failed to read GPT header: %w", err)
+	}
+	if string(hdr[:8]) != "EFI PART" {
+		return nil, errors.New("invalid GPT header")
+	}
+	le := binary.LittleEndian
+	entriesLBA := int64(le.Uint64(hdr[72:]))
+	count := le.Uint32(hdr[80:])
+	entrySize := le.Uint32(hdr[84:])
+	if count > maxGPTEntries || entrySize < 128 || entrySize > 4096 {
+		return nil, errors.New("invalid GPT partition entries")
+	}
+	buf := make([]byte, count*entrySize)
+	if _, err := dev.ReadAt(buf, entriesLBA*sectorSize); err != nil {
+		return nil, fmt.Errorf("failed to read GPT partition entries: %w", err)
+	}
+	var parts []*Partition
+	for i := range count {
+		e := buf[i*entrySize:]
+		typ := e[:16]
+		if bytes.Equal(typ, make([]byte, 16)) {
+			continue
+		}
+		first := int64(le.Uint64(e[32:]))
+		last := int64(le.Uint64(e[40:]))
+		if last < first {
+			continue
+		}
+		p := newPartition(dev, int(i)+1, formatGUID(typ), decodeUTF16(e[56:128]), first*sectorSize, (last-first+1)*sectorSize)
+		if p != nil {
+			parts = append(parts, p)
+		}
+	}
+	return parts, nil
+}
+
+// newPartition returns the partition with the given location, or nil if it
+// doesn't fit on the disk.
+func newPartition(dev device, index int, typ, name string, offset, size int64) *Partition {
+	if offset < 0 || size <= 0 || offset+size > dev.Size() {
+		return nil
+	}
+	r := io.NewSectionReader(dev, offset, size)
+	return &Partition{
+		Index:      index,
+		Type:       typ,
+		Name:       name,
+		Offset:     offset,
+		Size:       size,
+		Filesystem: detectFilesystem(r),
+		r:          r,
+	}
+}
+
+// formatGUID formats a GUID stored in the mixed-endian layout used by GPT.
+func formatGUID(b []byte) string {
+	le := binary.LittleEndian
+	return strings.ToUpper(fmt.Sprintf("%08x-%04x-%04x-%x-%x",
+		le.Uint32(b[0:]), le.Uint16(b[4:]), le.Uint16(b[6:]), b[8:10], b[10:16]))
+}
+
+func decodeUTF16(b []byte) string {
+	u := make([]uint16, 0, len(b)/2)
+	for i := 0; i+1 < len(b); i += 2 {
+		c := binary.LittleEndian.Uint16(b[i:])
+		if c == 0 {
+			break
+		}
+		u = append(u, c)
+	}
+	return string(utf16.Decode(u))
+}
+
+// Filesystem is the type of a filesystem found on a disk.
+type Filesystem string
+
+// The detected filesystems. Only ext2, ext3 and ext4 filesystems can be read.
+const (
+	FilesystemUnknown Filesystem = ""
+	FilesystemExt     Filesystem = "ext"
+	FilesystemXFS     Filesystem = "xfs"
+	FilesystemBtrfs   Filesystem = "btrfs"
+	FilesystemNTFS    Filesystem = "ntfs"
+	FilesystemFAT     Filesystem = "fat"
+	FilesystemLVM     Filesystem = "lvm2"
+	FilesystemSwap    Filesystem = "swap"
+)
+
+// detectFilesystem returns the filesystem stored in r based on its magic
+// bytes.
+func detectFilesystem(r io.ReaderAt) Filesystem {
+	buf := make([]byte, 4096)
+	n, _ := r.ReadAt(buf, 0)
+	buf = buf[:n]
+	hasAt := func(off int, magic string) bool {
+		return len(buf) >= off+len(magic) && string(buf[off:off+len(magic)]) == magic
+	}
+	switch {
+	case ext4.IsExt(r):
+		return FilesystemExt
+	case hasAt(0, "XFSB"):
+		return FilesystemXFS
+	case hasAt(3, "NTFS    "):
+		return FilesystemNTFS
+	case hasAt(54, "FAT12") || hasAt(54, "FAT16") || hasAt(82, "FAT32"):
+		return FilesystemFAT
+	case hasAt(512, "LABELONE"):
+		return FilesystemLVM
+	case hasAt(4086, "SWAPSPACE2"):
+		return FilesystemSwap
+	}
+	btrfs := make([]byte, 8)
+	if _, err := r.ReadAt(btrfs, 0x10040); err == nil && string(btrfs) == "_BHRfS_M" {
+		return FilesystemBtrfs
+	}
+	return FilesystemUnknown
+}
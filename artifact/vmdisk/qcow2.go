@@ -0,0 +1,209 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vmdisk
+
+import (
+	"bytes"
+	"compress/flate"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+)
+
+const (
+	qcow2HeaderSize = 104
+	// qcow2OffsetMask extracts the host offset from L1 and standard L2 entries.
+	qcow2OffsetMask          = 0x00fffffffffffe00
+	qcow2Compressed          = 1 << 62
+	qcow2ZeroCluster         = 1
+	qcow2MaxL2Cache          = 64
+	qcow2IncompatDirty       = 1 << 0
+	qcow2IncompatCompression = 1 << 3
+)
+
+// qcow2 reads the virtual disk of a QEMU copy-on-write image.
+type qcow2 struct {
+	r           io.ReaderAt
+	size        int64
+	clusterBits uint32
+	l1          []uint64
+
+	mu      sync.Mutex
+	l2      map[uint64][]uint64
+	cluster []byte
+	// The host offset of the cached decompressed cluster.
+	clusterOffset uint64
+}
+
+func newQCOW2(r io.ReaderAt) (*qcow2, error) {
+	buf := make([]byte, qcow2HeaderSize)
+	if _, err := r.ReadAt(buf, 0); err != nil {
+		return nil, fmt.Errorf("failed to read qcow2 header: %w", err)
+	}
+	be := binary.BigEndian
+	version := be.Uint32(buf[4:])
+	if version != 2 && version != 3 {
+		return nil, fmt.Errorf("%w: qcow2 version %d", ErrUnsupportedFormat, version)
+	}
+	if be.Uint64(buf[8:]) != 0 {
+		return nil, fmt.Errorf("%w: qcow2 images with a backing file", ErrUnsupportedFormat)
+	}
+	if be.Uint32(buf[32:]) != 0 {
+		return nil, fmt.Errorf("%w: encrypted qcow2 images", ErrUnsupportedFormat)
+	}
+	q := &qcow2{
+		r:           r,
+		size:        int64(be.Uint64(buf[24:])),
+		clusterBits: be.Uint32(buf[20:]),
+		l2:          make(map[uint64][]uint64),
+	}
+	if q.clusterBits < 9 || q.clusterBits > 21 || q.size < 0 {
+		return nil, errors.New("invalid qcow2 header")
+	}
+	if version == 3 {
+		incompat := be.Uint64(buf[72:])
+		if incompat&^(qcow2IncompatDirty|qcow2IncompatCompression) != 0 {
+			return nil, fmt.Errorf("%w: qcow2 incompatible features %#x", ErrUnsupportedFormat, incompat)
+		}
+		// Only the default deflate compression is supported.
+		if incompat&qcow2IncompatCompression != 0 && be.Uint32(buf[100:]) > 104 {
+			var compression [1]byte
+			if _, err := r.ReadAt(compression[:], 104); err != nil {
+				return nil, fmt.Errorf("failed to read qcow2 header: %w", err)
+			}
+			if compression[0] != 0 {
+				return nil, fmt.Errorf("%w: qcow2 compression type %d", ErrUnsupportedFormat, compression[0])
+			}
+		}
+	}
+
+	l1Size := be.Uint32(buf[36:])
+	if int64(l1Size) > q.size>>q.clusterBits/int64(q.l2Entries())+1 {
+		return nil, errors.New("invalid qcow2 L1 table size")
+	}
+	l1 := make([]byte, 8*int(l1Size))
+	if _, err := r.ReadAt(l1, int64(be.Uint64(buf[40:]))); err != nil {
+		return nil, fmt.Errorf("failed to read qcow2 L1 table: %w", err)
+	}
+	q.l1 = make([]uint64, l1Size)
+	for i := range q.l1 {
+		q.l1[i] = be.Uint64(l1[8*i:])
+	}
+	return q, nil
+}
+
+func (q *qcow2) Size() int64 { return q.size }
+
+func (q *qcow2) clusterSize() int64 { return 1 << q.clusterBits }
+
+func (q *qcow2) l2Entries() uint64 { return uint64(q.clusterSize()) / 8 }
+
+// ReadAt reads the virtual disk. Unallocated clusters read as zeros.
+func (q *qcow2) ReadAt(b []byte, off int64) (int, error) {
+	n := 0
+	for n < len(b) {
+		if off >= q.size {
+			return n, io.EOF
+		}
+		within := off & (q.clusterSize() - 1)
+		c := int(min(int64(len(b)-n), q.clusterSize()-within, q.size-off))
+		if err := q.readCluster(b[n:n+c], uint64(off>>q.clusterBits), within); err != nil {
+			return n, err
+		}
+		n += c
+		off += int64(c)
+	}
+	return n, nil
+}
+
+func (q *qcow2) readCluster(b []byte, index uint64, within int64) error {
+	l1Index := index / q.l2Entries()
+	if l1Index >= uint64(len(q.l1)) || q.l1[l1Index]&qcow2OffsetMask == 0 {
+		clear(b)
+		return nil
+	}
+	l2, err := q.l2Table(q.l1[l1Index] & qcow2OffsetMask)
+	if err != nil {
+		return err
+	}
+	entry := l2[index%q.l2Entries()]
+	if entry&qcow2Compressed != 0 {
+		data, err := q.compressedCluster(entry)
+		if err != nil {
+			return err
+		}
+		copy(b, data[within:])
+		return nil
+	}
+	offset := entry & qcow2OffsetMask
+	if offset == 0 || entry&qcow2ZeroCluster != 0 {
+		clear(b)
+		return nil
+	}
+	if _, err := q.r.ReadAt(b, int64(offset)+within); err != nil {
+		return fmt.Errorf("failed to read qcow2 cluster: %w", err)
+	}
+	return nil
+}
+
+func (q *qcow2) l2Table(offset uint64) ([]uint64, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if t, ok := q.l2[offset]; ok {
+		return t, nil
+	}
+	buf := make([]byte, q.clusterSize())
+	if _, err := q.r.ReadAt(buf, int64(offset)); err != nil {
+		return nil, fmt.Errorf("failed to read qcow2 L2 table: %w", err)
+	}
+	t := make([]uint64, q.l2Entries())
+	for i := range t {
+		t[i] = binary.BigEndian.Uint64(buf[8*i:])
+	}
+	if len(q.l2) >= qcow2MaxL2Cache {
+		clear(q.l2)
+	}
+	q.l2[offset] = t
+	return t, nil
+}
+
+// compressedCluster returns the decompressed contents of a compressed
+// cluster. The last cluster is cached since reads are usually sequential.
+func (q *qcow2) compressedCluster(entry uint64) ([]byte, error) {
+	// The entry holds the host offset in the low bits and the number of
+	// additional 512 byte sectors in the bits above it.
+	offsetBits := 62 - (q.clusterBits - 8)
+	offset := entry & (1<<offsetBits - 1)
+	sectors := (entry>>offsetBits)&(1<<(62-offsetBits)-1) + 1
+	size := int64(sectors)*512 - int64(offset&511)
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.cluster != nil && q.clusterOffset == offset {
+		return q.cluster, nil
+	}
+	compressed := make([]byte, size)
+	if n, err := q.r.ReadAt(compressed, int64(offset)); err != nil && (!errors.Is(err, io.EOF) || n == 0) {
+		return nil, fmt.Errorf("failed to read compressed qcow2 cluster: %w", err)
+	}
+	data := make([]byte, q.clusterSize())
+	if _, err := io.ReadFull(flate.NewReader(bytes.NewReader(compressed)), data); err != nil {
+		return nil, fmt.Errorf("failed to decompress qcow2 cluster: %w", err)
+	}
+	q.cluster, q.clusterOffset = data, offset
+	return data, nil
+}
@@ -0,0 +1,209 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package vmdisk opens virtual machine disk images (raw, fixed VHD, qcow2 and
+// VMDK), locates their partitions and filesystems and exposes them as SCALIBR
+// filesystems, so that whole VM images can be scanned without mounting them
+// on the host.
+//
+// Only ext2, ext3 and ext4 filesystems can be read. XFS, Btrfs, NTFS and LVM
+// volumes are detected but not supported yet.
+package vmdisk
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/google/osv-scalibr/artifact/appliance"
+	"github.com/google/osv-scalibr/artifact/vmdisk/ext4"
+	scalibrfs "github.com/google/osv-scalibr/fs"
+)
+
+var (
+	// ErrUnsupportedFormat is returned for disk images that can't be read,
+	// e.g. dynamic VHDs or qcow2 images with a backing file.
+	ErrUnsupportedFormat = errors.New("unsupported disk image")
+	// ErrUnsupportedFilesystem is returned for filesystems that can be
+	// detected but not read.
+	ErrUnsupportedFilesystem = errors.New("unsupported filesystem")
+	// ErrNoRootFilesystem is returned when no partition of the disk holds a
+	// readable root filesystem.
+	ErrNoRootFilesystem = errors.New("no root filesystem found")
+)
+
+// vmdkDescriptor starts the text descriptor files of split and flat VMDKs.
+var vmdkDescriptor = []byte("# Disk DescriptorFile")
+
+// device is the virtual disk stored in an image.
+type device interface {
+	io.ReaderAt
+	Size() int64
+}
+
+// Image is an opened virtual machine disk image.
+type Image struct {
+	// The format of the image file.
+	Format appliance.Format
+	// The size of the virtual disk in bytes.
+	Size int64
+	// The partitions of the disk, or the whole disk if it isn't partitioned.
+	Partitions []*Partition
+
+	closer io.Closer
+}
+
+// Open opens the disk image at the given path. The image has to be closed
+// after use.
+func Open(path string) (*Image, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+	img, err := New(io.NewSectionReader(f, 0, info.Size()))
+	if err != nil {
+		_ = f.Close()
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	img.closer = f
+	return img, nil
+}
+
+// New returns the disk image read from r. The format is detected from the
+// contents of the image.
+func New(r *io.SectionReader) (*Image, error) {
+	format, err := appliance.DetectFormat(r)
+	if err != nil {
+		return nil, err
+	}
+	dev, err := newDevice(r, format)
+	if err != nil {
+		return nil, err
+	}
+	parts, err := readPartitions(dev)
+	if err != nil {
+		return nil, err
+	}
+	return &Image{Format: format, Size: dev.Size(), Partitions: parts}, nil
+}
+
+func newDevice(r *io.SectionReader, format appliance.Format) (device, error) {
+	switch format {
+	case appliance.FormatQCOW2:
+		return newQCOW2(r)
+	case appliance.FormatVMDK:
+		return newVMDK(r)
+	case appliance.FormatVHD:
+		return newFixedVHD(r)
+	case appliance.FormatRaw:
+		header := make([]byte, len(vmdkDescriptor))
+		if n, _ := r.ReadAt(header, 0); bytes.Equal(header[:n], vmdkDescriptor) {
+			return nil, fmt.Errorf("%w: VMDK descriptor files, open the extent file instead", ErrUnsupportedFormat)
+		}
+		return r, nil
+	default:
+		return nil, fmt.Errorf("%w: %s images", ErrUnsupportedFormat, format)
+	}
+}
+
+// newFixedVHD returns the disk of a fixed size VHD, which is stored as raw
+// data followed by the VHD footer.
+func newFixedVHD(r *io.SectionReader) (device, error) {
+	if r.Size() < vhdFooterSize {
+		return nil, errors.New("invalid VHD footer")
+	}
+	footer := make([]byte, vhdFooterSize)
+	if _, err := r.ReadAt(footer, r.Size()-vhdFooterSize); err != nil {
+		return nil, fmt.Errorf("failed to read VHD footer: %w", err)
+	}
+	const diskTypeFixed = 2
+	if diskType := uint32(footer[60])<<24 | uint32(footer[61])<<16 | uint32(footer[62])<<8 | uint32(footer[63]); diskType != diskTypeFixed {
+		return nil, fmt.Errorf("%w: VHD disk type %d, only fixed VHDs are supported", ErrUnsupportedFormat, diskType)
+	}
+	return io.NewSectionReader(r, 0, r.Size()-vhdFooterSize), nil
+}
+
+// vhdFooterSize is the size of the footer of VHD files.
+const vhdFooterSize = 512
+
+// Close closes the image file if the image was opened with Open.
+func (img *Image) Close() error {
+	if img.closer == nil {
+		return nil
+	}
+	return img.closer.Close()
+}
+
+// FS returns the filesystem stored on the partition.
+func (p *Partition) FS() (scalibrfs.FS, error) {
+	switch p.Filesystem {
+	case FilesystemExt:
+		return ext4.New(p.r)
+	case FilesystemUnknown:
+		return nil, fmt.Errorf("partition %d: no filesystem found", p.Index)
+	default:
+		return nil, fmt.Errorf("partition %d: %w: %s", p.Index, ErrUnsupportedFilesystem, p.Filesystem)
+	}
+}
+
+// RootFS returns the root filesystem of the operating system installed on the
+// disk, i.e. the first readable filesystem with an /etc directory. Disks with
+// a single readable filesystem, e.g. data disks, return that filesystem.
+func (img *Image) RootFS() (scalibrfs.FS, error) {
+	var readable []scalibrfs.FS
+	var unsupported []string
+	for _, p := range img.Partitions {
+		fsys, err := p.FS()
+		if err != nil {
+			if errors.Is(err, ErrUnsupportedFilesystem) {
+				unsupported = append(unsupported, string(p.Filesystem))
+			}
+			continue
+		}
+		if info, err := fs.Stat(fsys, "etc"); err == nil && info.IsDir() {
+			return fsys, nil
+		}
+		readable = append(readable, fsys)
+	}
+	if len(readable) == 1 {
+		return readable[0], nil
+	}
+	if len(readable) == 0 && len(unsupported) > 0 {
+		slices.Sort(unsupported)
+		return nil, fmt.Errorf("%w: %w: %s", ErrNoRootFilesystem, ErrUnsupportedFilesystem, strings.Join(slices.Compact(unsupported), ", "))
+	}
+	return nil, ErrNoRootFilesystem
+}
+
+// OpenDisk returns the root filesystem of an appliance disk. It can be used
+// as the appliance.DiskOpener of Scanner.ScanAppliance.
+func OpenDisk(d *appliance.Disk) (scalibrfs.FS, error) {
+	img, err := New(d.Reader())
+	if err != nil {
+		return nil, err
+	}
+	return img.RootFS()
+}
+
+var _ appliance.DiskOpener = OpenDisk
@@ -0,0 +1,441 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vmdisk_test
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"unicode/utf16"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/osv-scalibr/artifact/appliance"
+	"github.com/google/osv-scalibr/artifact/vmdisk"
+)
+
+const (
+	sector      = 512
+	wantRelease = "ID=debian\nVERSION_ID=\"12\"\n"
+)
+
+// ext4Image returns the ext4 filesystem used by the tests of the ext4 package.
+func ext4Image(t *testing.T) []byte {
+	t.Helper()
+	f, err := os.Open("ext4/testdata/ext4.img.gz")
+	if err != nil {
+		t.Fatalf("os.Open(): %v", err)
+	}
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		t.Fatalf("gzip.NewReader(): %v", err)
+	}
+	img, err := io.ReadAll(gz)
+	if err != nil {
+		t.Fatalf("io.ReadAll(): %v", err)
+	}
+	return img
+}
+
+func mbrEntry(typ byte, startSector, sectors uint32) []byte {
+	e := make([]byte, 16)
+	e[4] = typ
+	binary.LittleEndian.PutUint32(e[8:], startSector)
+	binary.LittleEndian.PutUint32(e[12:], sectors)
+	return e
+}
+
+// buildDisk writes the partitions at the given sectors and the boot sector.
+func buildDisk(size int, bootSector []byte, parts map[int][]byte) []byte {
+	disk := make([]byte, size)
+	copy(disk, bootSector)
+	for s, data := range parts {
+		copy(disk[s*sector:], data)
+	}
+	return disk
+}
+
+func mbr(entries ...[]byte) []byte {
+	b := make([]byte, sector)
+	for i, e := range entries {
+		copy(b[446+16*i:], e)
+	}
+	b[510], b[511] = 0x55, 0xaa
+	return b
+}
+
+// guid encodes a GUID in the mixed-endian layout used by GPT.
+func guid(t *testing.T, s string) []byte {
+	t.Helper()
+	b, err := hex.DecodeString(strings.ReplaceAll(s, "-", ""))
+	if err != nil {
+		t.Fatalf("invalid GUID %q: %v", s, err)
+	}
+	for _, r := range [][2]int{{0, 4}, {4, 6}, {6, 8}} {
+		for i, j := r[0], r[1]-1; i < j; i, j = i+1, j-1 {
+			b[i], b[j] = b[j], b[i]
+		}
+	}
+	return b
+}
+
+func fakeFilesystem(magic string, offset int) []byte {
+	b := make([]byte, 4096)
+	copy(b[offset:], magic)
+	return b
+}
+
+func fixedVHD(raw []byte, diskType uint32) []byte {
+	footer := make([]byte, 512)
+	copy(footer, "conectix")
+	binary.BigEndian.PutUint32(footer[60:], diskType)
+	return append(bytes.Clone(raw), footer...)
+}
+
+// buildQCOW2 converts a raw disk into a qcow2 image with 64 KiB clusters,
+// leaving out clusters that only contain zeros.
+func buildQCOW2(t *testing.T, raw []byte, compress bool) []byte {
+	t.Helper()
+	const clusterBits = 16
+	const clusterSize = 1 << clusterBits
+	clusters := (len(raw) + clusterSize - 1) / clusterSize
+	l2Entries := clusterSize / 8
+	l1Size := (clusters + l2Entries - 1) / l2Entries
+
+	img := make([]byte, (2+l1Size)*clusterSize)
+	be := binary.BigEndian
+	copy(img, "QFI\xfb")
+	be.PutUint32(img[4:], 3)
+	be.PutUint32(img[20:], clusterBits)
+	be.PutUint64(img[24:], uint64(len(raw)))
+	be.PutUint32(img[36:], uint32(l1Size))
+	be.PutUint64(img[40:], clusterSize)
+	be.PutUint32(img[96:], 4)
+	be.PutUint32(img[100:], 104)
+	for i := range l1Size {
+		be.PutUint64(img[clusterSize+8*i:], uint64((2+i)*clusterSize)|1<<63)
+	}
+	for c := range clusters {
+		data := make([]byte, clusterSize)
+		copy(data, raw[c*clusterSize:])
+		if bytes.Equal(data, make([]byte, clusterSize)) {
+			continue
+		}
+		var entry uint64
+		if compress {
+			var buf bytes.Buffer
+			w, err := flate.NewWriter(&buf, flate.BestCompression)
+			if err != nil {
+				t.Fatal(err)
+			}
+			w.Write(data)
+			w.Close()
+			offset := uint64(len(img))
+			const offsetBits = 62 - (clusterBits - 8)
+			sectors := uint64(buf.Len()+sector-1) / sector
+			entry = 1<<62 | (sectors-1)<<offsetBits | offset
+			img = append(img, buf.Bytes()...)
+			img = append(img, make([]byte, int(sectors)*sector-buf.Len())...)
+		} else {
+			entry = uint64(len(img)) | 1<<63
+			img = append(img, data...)
+		}
+		be.PutUint64(img[(2+c/l2Entries)*clusterSize+8*(c%l2Entries):], entry)
+	}
+	return img
+}
+
+// buildVMDK converts a raw disk into a sparse VMDK with 64 KiB grains. The
+// compressed images use the streamOptimized layout, with the grain directory
+// location only stored in the footer.
+func buildVMDK(t *testing.T, raw []byte, compress bool) []byte {
+	t.Helper()
+	const grainSectors = 128
+	const grainSize = grainSectors * sector
+	const gtEntries = 512
+	grains := (len(raw) + grainSize - 1) / grainSize
+	gts := (grains + gtEntries - 1) / gtEntries
+
+	header := func(gdOffset uint64) []byte {
+		h := make([]byte, sector)
+		le := binary.LittleEndian
+		copy(h, "KDMV")
+		le.PutUint32(h[4:], 3)
+		if compress {
+			le.PutUint32(h[8:], 1<<16|1<<17)
+			le.PutUint16(h[77:], 1)
+		}
+		le.PutUint64(h[12:], uint64(len(raw)/sector))
+		le.PutUint64(h[20:], grainSectors)
+		le.PutUint32(h[44:], gtEntries)
+		le.PutUint64(h[56:], gdOffset)
+		return h
+	}
+
+	// Leave room for the header and the descriptor before the first grain.
+	img := make([]byte, 128*sector)
+	gt := make([]byte, 4*gts*gtEntries)
+	for g := range grains {
+		data := make([]byte, grainSize)
+		copy(data, raw[g*grainSize:])
+		if bytes.Equal(data, make([]byte, grainSize)) {
+			continue
+		}
+		binary.LittleEndian.PutUint32(gt[4*g:], uint32(len(img)/sector))
+		if compress {
+			var buf bytes.Buffer
+			w := zlib.NewWriter(&buf)
+			w.Write(data)
+			w.Close()
+			marker := make([]byte, 12)
+			binary.LittleEndian.PutUint64(marker, uint64(g*grainSectors))
+			binary.LittleEndian.PutUint32(marker[8:], uint32(buf.Len()))
+			grain := append(marker, buf.Bytes()...)
+			img = append(img, grain...)
+			img = append(img, make([]byte, (sector-len(grain)%sector)%sector)...)
+		} else {
+			img = append(img, data...)
+		}
+	}
+	gtStart := len(img) / sector
+	img = append(img, gt...)
+	img = append(img, make([]byte, (sector-len(gt)%sector)%sector)...)
+	gdOffset := uint64(len(img) / sector)
+	gd := make([]byte, 4*gts)
+	for i := range gts {
+		binary.LittleEndian.PutUint32(gd[4*i:], uint32(gtStart+i*gtEntries*4/sector))
+	}
+	img = append(img, gd...)
+	img = append(img, make([]byte, (sector-len(gd)%sector)%sector)...)
+
+	if compress {
+		copy(img, header(0xffffffffffffffff))
+		// Footer marker, footer and end-of-stream marker.
+		img = append(img, make([]byte, sector)...)
+		img = append(img, header(gdOffset)...)
+		img = append(img, make([]byte, sector)...)
+	} else {
+		copy(img, header(gdOffset))
+	}
+	return img
+}
+
+func readRelease(t *testing.T, img *vmdisk.Image) string {
+	t.Helper()
+	fsys, err := img.RootFS()
+	if err != nil {
+		t.Fatalf("RootFS(): %v", err)
+	}
+	got, err := fs.ReadFile(fsys, "etc/os-release")
+	if err != nil {
+		t.Fatalf("fs.ReadFile(etc/os-release): %v", err)
+	}
+	return string(got)
+}
+
+func TestNew(t *testing.T) {
+	ext4 := ext4Image(t)
+	mbrDisk := buildDisk(2048*sector+len(ext4), mbr(mbrEntry(0x83, 2048, uint32(len(ext4)/sector))), map[int][]byte{2048: ext4})
+
+	testCases := []struct {
+		desc       string
+		img        []byte
+		wantFormat appliance.Format
+	}{
+		{desc: "raw_unpartitioned", img: ext4, wantFormat: appliance.FormatRaw},
+		{desc: "raw_mbr", img: mbrDisk, wantFormat: appliance.FormatRaw},
+		{desc: "fixed_vhd", img: fixedVHD(mbrDisk, 2), wantFormat: appliance.FormatVHD},
+		{desc: "qcow2", img: buildQCOW2(t, mbrDisk, false), wantFormat: appliance.FormatQCOW2},
+		{desc: "qcow2_compressed", img: buildQCOW2(t, mbrDisk, true), wantFormat: appliance.FormatQCOW2},
+		{desc: "vmdk", img: buildVMDK(t, mbrDisk, false), wantFormat: appliance.FormatVMDK},
+		{desc: "vmdk_stream_optimized", img: buildVMDK(t, mbrDisk, true), wantFormat: appliance.FormatVMDK},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			img, err := vmdisk.New(io.NewSectionReader(bytes.NewReader(tc.img), 0, int64(len(tc.img))))
+			if err != nil {
+				t.Fatalf("New(): %v", err)
+			}
+			if img.Format != tc.wantFormat {
+				t.Errorf("New() format = %q, want %q", img.Format, tc.wantFormat)
+			}
+			if got := readRelease(t, img); got != wantRelease {
+				t.Errorf("etc/os-release = %q, want %q", got, wantRelease)
+			}
+		})
+	}
+}
+
+func TestPartitions(t *testing.T) {
+	ext4 := ext4Image(t)
+	ext4Sectors := uint32(len(ext4) / sector)
+	xfs := fakeFilesystem("XFSB", 0)
+
+	// An MBR disk with a primary ext4 partition and a logical XFS partition.
+	extStart := 2048 + ext4Sectors
+	ebr := mbr(mbrEntry(0x83, 2048, 8))
+	mbrDisk := buildDisk(int(extStart+2048+8)*sector, mbr(
+		mbrEntry(0x83, 2048, ext4Sectors),
+		mbrEntry(0x05, extStart, 2048+8),
+	), map[int][]byte{2048: ext4, int(extStart): ebr, int(extStart) + 2048: xfs})
+
+	// A GPT disk with an EFI system partition and an ext4 root partition.
+	fat := fakeFilesystem("FAT32", 82)
+	gptHeader := make([]byte, sector)
+	copy(gptHeader, "EFI PART")
+	binary.LittleEndian.PutUint64(gptHeader[72:], 2)
+	binary.LittleEndian.PutUint32(gptHeader[80:], 128)
+	binary.LittleEndian.PutUint32(gptHeader[84:], 128)
+	entries := make([]byte, 128*128)
+	gptEntry := func(i int, typ string, first, last uint64, name string) {
+		e := entries[i*128:]
+		copy(e, guid(t, typ))
+		binary.LittleEndian.PutUint64(e[32:], first)
+		binary.LittleEndian.PutUint64(e[40:], last)
+		for j, c := range utf16.Encode([]rune(name)) {
+			binary.LittleEndian.PutUint16(e[56+2*j:], c)
+		}
+	}
+	gptEntry(0, "C12A7328-F81F-11D2-BA4B-00A0C93EC93B", 2048, 2048+7, "EFI System")
+	gptEntry(1, "0FC63DAF-8483-4772-8E79-3D69D8477DE4", 4096, 4096+uint64(ext4Sectors)-1, "root")
+	gptDisk := buildDisk((4096+int(ext4Sectors))*sector, mbr(mbrEntry(0xee, 1, 0xffffffff)), map[int][]byte{
+		1:    gptHeader,
+		2:    entries,
+		2048: fat,
+		4096: ext4,
+	})
+
+	testCases := []struct {
+		desc string
+		img  []byte
+		want []*vmdisk.Partition
+	}{
+		{
+			desc: "unpartitioned",
+			img:  ext4,
+			want: []*vmdisk.Partition{
+				{Index: 0, Offset: 0, Size: int64(len(ext4)), Filesystem: vmdisk.FilesystemExt},
+			},
+		},
+		{
+			desc: "mbr_with_logical_partition",
+			img:  mbrDisk,
+			want: []*vmdisk.Partition{
+				{Index: 1, Type: "0x83", Offset: 2048 * sector, Size: int64(len(ext4)), Filesystem: vmdisk.FilesystemExt},
+				{Index: 5, Type: "0x83", Offset: int64(extStart+2048) * sector, Size: 8 * sector, Filesystem: vmdisk.FilesystemXFS},
+			},
+		},
+		{
+			desc: "gpt",
+			img:  gptDisk,
+			want: []*vmdisk.Partition{
+				{Index: 1, Type: "C12A7328-F81F-11D2-BA4B-00A0C93EC93B", Name: "EFI System", Offset: 2048 * sector, Size: 8 * sector, Filesystem: vmdisk.FilesystemFAT},
+				{Index: 2, Type: "0FC63DAF-8483-4772-8E79-3D69D8477DE4", Name: "root", Offset: 4096 * sector, Size: int64(len(ext4)), Filesystem: vmdisk.FilesystemExt},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			img, err := vmdisk.New(io.NewSectionReader(bytes.NewReader(tc.img), 0, int64(len(tc.img))))
+			if err != nil {
+				t.Fatalf("New(): %v", err)
+			}
+			if diff := cmp.Diff(tc.want, img.Partitions, cmpopts.IgnoreUnexported(vmdisk.Partition{})); diff != "" {
+				t.Errorf("New() unexpected partitions (-want +got):\n%s", diff)
+			}
+			if got := readRelease(t, img); got != wantRelease {
+				t.Errorf("etc/os-release = %q, want %q", got, wantRelease)
+			}
+		})
+	}
+}
+
+func TestNew_Unsupported(t *testing.T) {
+	ext4 := ext4Image(t)
+	xfsDisk := buildDisk(4096*sector, mbr(mbrEntry(0x83, 2048, 2048)), map[int][]byte{2048: fakeFilesystem("XFSB", 0)})
+
+	testCases := []struct {
+		desc       string
+		img        []byte
+		wantNewErr error
+		wantFSErr  error
+	}{
+		{desc: "dynamic_vhd", img: fixedVHD(ext4, 3), wantNewErr: vmdisk.ErrUnsupportedFormat},
+		{desc: "vhdx", img: append([]byte("vhdxfile"), make([]byte, 4096)...), wantNewErr: vmdisk.ErrUnsupportedFormat},
+		{desc: "vmdk_descriptor", img: []byte("# Disk DescriptorFile\nversion=1\n"), wantNewErr: vmdisk.ErrUnsupportedFormat},
+		{desc: "xfs", img: xfsDisk, wantFSErr: vmdisk.ErrUnsupportedFilesystem},
+		{desc: "no_filesystem", img: make([]byte, 8192), wantFSErr: vmdisk.ErrNoRootFilesystem},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			img, err := vmdisk.New(io.NewSectionReader(bytes.NewReader(tc.img), 0, int64(len(tc.img))))
+			if !errors.Is(err, tc.wantNewErr) {
+				t.Fatalf("New() error: %v, want %v", err, tc.wantNewErr)
+			}
+			if err != nil {
+				return
+			}
+			if _, err := img.RootFS(); !errors.Is(err, tc.wantFSErr) {
+				t.Errorf("RootFS() error: %v, want %v", err, tc.wantFSErr)
+			}
+		})
+	}
+}
+
+func TestOpenDisk(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "disk.vhd")
+	if err := os.WriteFile(path, fixedVHD(ext4Image(t), 2), 0644); err != nil {
+		t.Fatal(err)
+	}
+	app, err := appliance.Open(path)
+	if err != nil {
+		t.Fatalf("appliance.Open(%q): %v", path, err)
+	}
+	defer app.Close()
+	fsys, err := app.DiskFS(app.Disks[0], vmdisk.OpenDisk)
+	if err != nil {
+		t.Fatalf("DiskFS(): %v", err)
+	}
+	got, err := fs.ReadFile(fsys, "etc/os-release")
+	if err != nil || string(got) != wantRelease {
+		t.Errorf("fs.ReadFile(etc/os-release) = %q, %v, want %q", got, err, wantRelease)
+	}
+}
+
+func TestOpen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "disk.qcow2")
+	if err := os.WriteFile(path, buildQCOW2(t, ext4Image(t), true), 0644); err != nil {
+		t.Fatal(err)
+	}
+	img, err := vmdisk.Open(path)
+	if err != nil {
+		t.Fatalf("Open(%q): %v", path, err)
+	}
+	defer img.Close()
+	if got := readRelease(t, img); got != wantRelease {
+		t.Errorf("etc/os-release = %q, want %q", got, wantRelease)
+	}
+}
@@ -0,0 +1,222 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vmdisk
+
+import (
+	"compress/zlib"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+)
+
+const (
+	sectorSize     = 512
+	vmdkHeaderSize = 79
+	// vmdkGDAtEnd marks streamOptimized images whose grain directory location
+	// is only stored in the footer.
+	vmdkGDAtEnd            = 0xffffffffffffffff
+	vmdkFlagCompressed     = 1 << 16
+	vmdkCompressDeflate    = 1
+	vmdkMaxGrainTableCache = 64
+	// vmdkGrainMarkerSize is the size of the LBA and length that precede
+	// compressed grains.
+	vmdkGrainMarkerSize = 12
+)
+
+// vmdk reads the virtual disk of a hosted sparse VMDK extent, including
+// the compressed streamOptimized images found in OVA archives.
+type vmdk struct {
+	r          io.ReaderAt
+	size       int64
+	grainSize  int64
+	gtEntries  uint32
+	gd         []uint32
+	compressed bool
+
+	mu    sync.Mutex
+	gts   map[uint32][]uint32
+	grain []byte
+	// The sector of the cached decompressed grain.
+	grainSector uint32
+}
+
+type vmdkHeader struct {
+	flags       uint32
+	capacity    uint64
+	grainSize   uint64
+	gtEntries   uint32
+	gdOffset    uint64
+	compression uint16
+}
+
+func readVMDKHeader(r io.ReaderAt, off int64) (*vmdkHeader, error) {
+	buf := make([]byte, vmdkHeaderSize)
+	if _, err := r.ReadAt(buf, off); err != nil {
+		return nil, fmt.Errorf("failed to read VMDK header: %w", err)
+	}
+	if string(buf[:4]) != "KDMV" {
+		return nil, errors.New("invalid VMDK header")
+	}
+	le := binary.LittleEndian
+	return &vmdkHeader{
+		flags:       le.Uint32(buf[8:]),
+		capacity:    le.Uint64(buf[12:]),
+		grainSize:   le.Uint64(buf[20:]),
+		gtEntries:   le.Uint32(buf[44:]),
+		gdOffset:    le.Uint64(buf[56:]),
+		compression: le.Uint16(buf[77:]),
+	}, nil
+}
+
+func newVMDK(r *io.SectionReader) (*vmdk, error) {
+	h, err := readVMDKHeader(r, 0)
+	if err != nil {
+		return nil, err
+	}
+	if h.gdOffset == vmdkGDAtEnd {
+		// The footer is followed by the end-of-stream marker.
+		if h, err = readVMDKHeader(r, r.Size()-2*sectorSize); err != nil {
+			return nil, fmt.Errorf("VMDK footer: %w", err)
+		}
+	}
+	if h.grainSize == 0 || h.grainSize > 1<<16 || h.gtEntries == 0 || h.gtEntries > 1<<16 {
+		return nil, errors.New("invalid VMDK grain geometry")
+	}
+	v := &vmdk{
+		r:          r,
+		size:       int64(h.capacity) * sectorSize,
+		grainSize:  int64(h.grainSize) * sectorSize,
+		gtEntries:  h.gtEntries,
+		compressed: h.flags&vmdkFlagCompressed != 0,
+		gts:        make(map[uint32][]uint32),
+	}
+	if v.compressed && h.compression != vmdkCompressDeflate {
+		return nil, fmt.Errorf("%w: VMDK compression %d", ErrUnsupportedFormat, h.compression)
+	}
+	grains := (h.capacity + h.grainSize - 1) / h.grainSize
+	gdEntries := (grains + uint64(h.gtEntries) - 1) / uint64(h.gtEntries)
+	if int64(gdEntries)*4 > r.Size() {
+		return nil, errors.New("invalid VMDK grain directory size")
+	}
+	buf := make([]byte, 4*gdEntries)
+	if _, err := r.ReadAt(buf, int64(h.gdOffset)*sectorSize); err != nil {
+		return nil, fmt.Errorf("failed to read VMDK grain directory: %w", err)
+	}
+	v.gd = make([]uint32, gdEntries)
+	for i := range v.gd {
+		v.gd[i] = binary.LittleEndian.Uint32(buf[4*i:])
+	}
+	return v, nil
+}
+
+func (v *vmdk) Size() int64 { return v.size }
+
+// ReadAt reads the virtual disk. Unallocated grains read as zeros.
+func (v *vmdk) ReadAt(b []byte, off int64) (int, error) {
+	n := 0
+	for n < len(b) {
+		if off >= v.size {
+			return n, io.EOF
+		}
+		within := off % v.grainSize
+		c := int(min(int64(len(b)-n), v.grainSize-within, v.size-off))
+		if err := v.readGrain(b[n:n+c], uint64(off/v.grainSize), within); err != nil {
+			return n, err
+		}
+		n += c
+		off += int64(c)
+	}
+	return n, nil
+}
+
+func (v *vmdk) readGrain(b []byte, index uint64, within int64) error {
+	gdIndex := index / uint64(v.gtEntries)
+	if gdIndex >= uint64(len(v.gd)) || v.gd[gdIndex] == 0 {
+		clear(b)
+		return nil
+	}
+	gt, err := v.grainTable(v.gd[gdIndex])
+	if err != nil {
+		return err
+	}
+	sector := gt[index%uint64(v.gtEntries)]
+	// 0 marks unallocated grains and 1 grains that were explicitly zeroed.
+	if sector <= 1 {
+		clear(b)
+		return nil
+	}
+	if !v.compressed {
+		if _, err := v.r.ReadAt(b, int64(sector)*sectorSize+within); err != nil {
+			return fmt.Errorf("failed to read VMDK grain: %w", err)
+		}
+		return nil
+	}
+	data, err := v.compressedGrain(sector)
+	if err != nil {
+		return err
+	}
+	copy(b, data[within:])
+	return nil
+}
+
+func (v *vmdk) grainTable(sector uint32) ([]uint32, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if gt, ok := v.gts[sector]; ok {
+		return gt, nil
+	}
+	buf := make([]byte, 4*v.gtEntries)
+	if _, err := v.r.ReadAt(buf, int64(sector)*sectorSize); err != nil {
+		return nil, fmt.Errorf("failed to read VMDK grain table: %w", err)
+	}
+	gt := make([]uint32, v.gtEntries)
+	for i := range gt {
+		gt[i] = binary.LittleEndian.Uint32(buf[4*i:])
+	}
+	if len(v.gts) >= vmdkMaxGrainTableCache {
+		clear(v.gts)
+	}
+	v.gts[sector] = gt
+	return gt, nil
+}
+
+// compressedGrain returns the decompressed contents of the grain stored at
+// the given sector. The last grain is cached since reads are usually
+// sequential.
+func (v *vmdk) compressedGrain(sector uint32) ([]byte, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if v.grain != nil && v.grainSector == sector {
+		return v.grain, nil
+	}
+	var marker [vmdkGrainMarkerSize]byte
+	if _, err := v.r.ReadAt(marker[:], int64(sector)*sectorSize); err != nil {
+		return nil, fmt.Errorf("failed to read VMDK grain marker: %w", err)
+	}
+	size := int64(binary.LittleEndian.Uint32(marker[8:]))
+	zr, err := zlib.NewReader(io.NewSectionReader(v.r, int64(sector)*sectorSize+vmdkGrainMarkerSize, size))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress VMDK grain: %w", err)
+	}
+	data := make([]byte, v.grainSize)
+	// The last grain of the disk can be shorter.
+	if _, err := io.ReadFull(zr, data); err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, fmt.Errorf("failed to decompress VMDK grain: %w", err)
+	}
+	v.grain, v.grainSector = data, sector
+	return data, nil
+}
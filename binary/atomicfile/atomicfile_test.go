@@ -0,0 +1,94 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package atomicfile_test
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/osv-scalibr/binary/atomicfile"
+)
+
+func TestWrite(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "result.textproto")
+	if err := os.WriteFile(path, []byte("old"), 0644); err != nil {
+		t.Fatalf("os.WriteFile(): %v", err)
+	}
+
+	err := atomicfile.Write(path, func(w io.Writer) error {
+		_, err := io.WriteString(w, "new")
+		return err
+	})
+	if err != nil {
+		t.Fatalf("Write(): %v", err)
+	}
+
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("os.ReadFile(): %v", err)
+	}
+	if string(got) != "new" {
+		t.Errorf("Write() wrote %q, want %q", got, "new")
+	}
+	assertNoTempFiles(t, dir)
+}
+
+func TestWrite_Error(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "result.textproto")
+	if err := os.WriteFile(path, []byte("old"), 0644); err != nil {
+		t.Fatalf("os.WriteFile(): %v", err)
+	}
+
+	wantErr := errors.New("interrupted")
+	err := atomicfile.Write(path, func(w io.Writer) error {
+		if _, err := io.WriteString(w, "partial"); err != nil {
+			return err
+		}
+		return wantErr
+	})
+	if !errors.Is(err, wantErr) {
+		t.Fatalf("Write() returned error %v, want %v", err, wantErr)
+	}
+
+	// The existing file is left untouched.
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("os.ReadFile(): %v", err)
+	}
+	if string(got) != "old" {
+		t.Errorf("file content after failed Write(): %q, want %q", got, "old")
+	}
+	assertNoTempFiles(t, dir)
+}
+
+func assertNoTempFiles(t *testing.T, dir string) {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("os.ReadDir(): %v", err)
+	}
+	if len(entries) != 1 {
+		var names []string
+		for _, e := range entries {
+			names = append(names, e.Name())
+		}
+		t.Errorf("directory contains %v, want only the written file", names)
+	}
+}
@@ -17,9 +17,10 @@ package cdx
 
 import (
 	"fmt"
-	"os"
+	"io"
 
 	"github.com/CycloneDX/cyclonedx-go"
+	"github.com/google/osv-scalibr/binary/atomicfile"
 )
 
 // Write writes an CDX document into a file in the chosen format.
@@ -33,11 +34,8 @@ func Write(doc *cyclonedx.BOM, path string, format string) error {
 	default:
 		return fmt.Errorf("%s has an invalid CDX format or not supported by SCALIBR", path)
 	}
-	f, err := os.Create(path)
-	if err != nil {
-		return err
-	}
-	defer f.Close()
-	encoder := cyclonedx.NewBOMEncoder(f, cdxFormat).SetPretty(true)
-	return encoder.Encode(doc)
+	return atomicfile.Write(path, func(w io.Writer) error {
+		encoder := cyclonedx.NewBOMEncoder(w, cdxFormat).SetPretty(true)
+		return encoder.Encode(doc)
+	})
 }
@@ -16,38 +16,73 @@
 package cli
 
 import (
+	"bufio"
+	"context"
 	"errors"
 	"fmt"
+	"io"
 	"os"
+	"path"
 	"path/filepath"
 	"regexp"
 	"slices"
 	"strings"
+	"time"
 
 	"github.com/gobwas/glob"
 	"github.com/google/go-containerregistry/pkg/authn"
 	v1 "github.com/google/go-containerregistry/pkg/v1"
 	"github.com/google/go-containerregistry/pkg/v1/remote"
 	scalibr "github.com/google/osv-scalibr"
+	"github.com/google/osv-scalibr/annotator/misc/filehashes"
 	scalibrimage "github.com/google/osv-scalibr/artifact/image"
+	"github.com/google/osv-scalibr/artifact/image/squashfs"
+	"github.com/google/osv-scalibr/artifact/vmdisk"
+	"github.com/google/osv-scalibr/binary/atomicfile"
 	"github.com/google/osv-scalibr/binary/cdx"
 	"github.com/google/osv-scalibr/binary/platform"
 	"github.com/google/osv-scalibr/binary/proto"
+	"github.com/google/osv-scalibr/binary/sarif"
 	"github.com/google/osv-scalibr/binary/spdx"
+	"github.com/google/osv-scalibr/clients/datasource"
 	"github.com/google/osv-scalibr/clients/resolution"
 	"github.com/google/osv-scalibr/converter"
+	"github.com/google/osv-scalibr/converter/depgraph"
 	"github.com/google/osv-scalibr/detector"
 	"github.com/google/osv-scalibr/detector/govulncheck/binary"
+	"github.com/google/osv-scalibr/enricher"
+	"github.com/google/osv-scalibr/enricher/transitivedependency/pomxml"
 	"github.com/google/osv-scalibr/enricher/transitivedependency/requirements"
+	"github.com/google/osv-scalibr/enricher/vex/baseline"
+	"github.com/google/osv-scalibr/enricher/vex/suppression"
+	"github.com/google/osv-scalibr/enricher/vulnmatch/osvlocal"
 	"github.com/google/osv-scalibr/extractor/filesystem/language/golang/gobinary"
 	"github.com/google/osv-scalibr/extractor/filesystem/language/java/pomxmlnet"
+	"github.com/google/osv-scalibr/extractor/filesystem/language/rust/cargoauditable"
+	"github.com/google/osv-scalibr/extractor/filesystem/pathfilter"
+	"github.com/google/osv-scalibr/extractor/filesystem/targetenv"
 	scalibrfs "github.com/google/osv-scalibr/fs"
+	"github.com/google/osv-scalibr/fs/memfs"
+	"github.com/google/osv-scalibr/fs/mounts"
+	"github.com/google/osv-scalibr/fs/webdav"
+	"github.com/google/osv-scalibr/hashing"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/inventory/dedup"
+	"github.com/google/osv-scalibr/inventory/severity"
 	"github.com/google/osv-scalibr/log"
 	"github.com/google/osv-scalibr/plugin"
+	"github.com/google/osv-scalibr/plugin/dynamic"
 	pl "github.com/google/osv-scalibr/plugin/list"
+	scalibrbaseline "github.com/google/osv-scalibr/result/baseline"
+	"github.com/google/osv-scalibr/result/policy"
+	"github.com/google/osv-scalibr/stats"
 	"github.com/spdx/tools-golang/spdx/v2/common"
 )
 
+// WebDAVPasswordEnv is the environment variable holding the password for --webdav-user.
+// It's not passed as a flag to avoid leaking it through the process list.
+const WebDAVPasswordEnv = "SCALIBR_WEBDAV_PASSWORD"
+
 // Array is a type to be passed to flag.Var that supports arrays passed as repeated flags,
 // e.g. ./scalibr -o binproto=out.bp -o spdx23-json=out.spdx.json
 type Array []string
@@ -117,27 +152,50 @@ func (s *StringListFlag) Reset() {
 
 // Flags contains a field for all the cli flags that can be set.
 type Flags struct {
-	PrintVersion               bool
-	Root                       string
-	ResultFile                 string
-	Output                     Array
-	ExtractorsToRun            []string
-	DetectorsToRun             []string
-	AnnotatorsToRun            []string
-	PluginsToRun               []string
-	PathsToExtract             []string
-	IgnoreSubDirs              bool
-	DirsToSkip                 []string
-	SkipDirRegex               string
-	SkipDirGlob                string
-	MaxFileSize                int
-	UseGitignore               bool
-	RemoteImage                string
-	ImageLocal                 string
-	ImageTarball               string
-	ImagePlatform              string
+	PrintVersion      bool
+	Root              string
+	ResultFile        string
+	Output            Array
+	ExtractorsToRun   []string
+	DetectorsToRun    []string
+	AnnotatorsToRun   []string
+	PluginsToRun      []string
+	Ecosystems        []string
+	PathsToExtract    []string
+	IgnoreSubDirs     bool
+	DirsToSkip        []string
+	SkipDirRegex      string
+	SkipDirGlob       string
+	PathFilterConfig  string
+	MountPolicy       string
+	MaxFileSize       int
+	UseGitignore      bool
+	OnlyGitTracked    bool
+	HashAlgorithms    []string
+	FileHashBudget    int64
+	FIPSMode          bool
+	RemoteImage       string
+	ImageLocal        string
+	ImageTarball      string
+	StreamImageLayers bool
+	ImagePlatform     string
+	ImageSquashfs     string
+	ImageVMDisk       string
+	// File with a newline-separated list of files to extract in addition to
+	// PathsToExtract, or "-" to read the list from stdin. Relative paths are
+	// resolved from the scan root.
+	FilesFrom string
+	// Scan the files of a tar archive piped to stdin instead of a directory.
+	StdinTar                   bool
+	WebDAVURL                  string
+	WebDAVUser                 string
 	GoBinaryVersionFromContent bool
+	GoBinaryStrippedBinaries   bool
+	CargoAuditablePanicPaths   bool
 	GovulncheckDBPath          string
+	OSVDBPath                  string
+	SuppressionsFile           string
+	OpenVEXFiles               []string
 	SPDXDocumentName           string
 	SPDXDocumentNamespace      string
 	SPDXCreators               string
@@ -145,6 +203,7 @@ type Flags struct {
 	CDXComponentType           string
 	CDXComponentVersion        string
 	CDXAuthors                 string
+	SBOMNamingRules            string
 	Verbose                    bool
 	ExplicitExtractors         bool
 	FilterByCapabilities       bool
@@ -152,10 +211,44 @@ type Flags struct {
 	WindowsAllDrives           bool
 	Offline                    bool
 	LocalRegistry              string
+	MavenRegistry              string
+	PluginDir                  string
+	FailOnSeverity             string
+	FailOnPluginErrors         bool
+	SummaryJSON                bool
+	DedupStrategies            []string
+	Progress                   bool
+	ExpectedInodes             int
+	TargetOS                   string
+	TargetArch                 string
+	TargetPythonVersion        string
+	TargetNodeVersion          string
+	PluginTimeout              time.Duration
+	PluginTimeoutOverrides     []string
+	DepGraphDir                string
+	DepGraphFormat             string
+	ReportResourceUsage        bool
+	MaxBytesPerFile            int64
+	MaxTotalBytes              int64
+	// SkipEnrichers removes all enrichers from the plugins to run, e.g. for
+	// "scalibr extract" which leaves enrichment to a later "scalibr enrich" run.
+	SkipEnrichers bool
+	// How long to wait for the plugins to stop after SIGINT or SIGTERM before
+	// exiting without writing the partial results.
+	InterruptGracePeriod time.Duration
+	// Baseline file used by the vex/baseline enricher to remove known findings.
+	Baseline string
+	// Path to write a baseline of the security findings of the scan to.
+	WriteBaselineFile string
+	// Policy file that the scan result is evaluated against.
+	PolicyFile string
 }
 
+// targetVersionRe matches the runtime versions accepted by the --target-*-version flags.
+var targetVersionRe = regexp.MustCompile(`^\d+(\.\d+){0,2}$`)
+
 var supportedOutputFormats = []string{
-	"textproto", "binproto", "spdx23-tag-value", "spdx23-json", "spdx23-yaml", "cdx-json", "cdx-xml",
+	"textproto", "binproto", "spdx23-tag-value", "spdx23-json", "spdx23-yaml", "spdx30-json", "cdx-json", "cdx-xml", "sarif",
 }
 
 var supportedComponentTypes = []string{
@@ -194,6 +287,58 @@ func ValidateFlags(flags *Flags) error {
 	if flags.ImageLocal != "" && flags.ImageTarball != "" {
 		return errors.New("image-local-docker cannot be used with --image-tarball")
 	}
+	if flags.StreamImageLayers && flags.ImageTarball == "" && flags.ImageLocal == "" {
+		return errors.New("--stream-image-layers can only be used with --image-tarball or --image-local-docker")
+	}
+	if flags.WebDAVURL != "" && (flags.Root != "" || flags.WindowsAllDrives || flags.RemoteImage != "" || flags.ImageTarball != "" || flags.ImageLocal != "") {
+		return errors.New("--webdav-url cannot be used with --root, --windows-all-drives or the image scanning flags")
+	}
+	if flags.ImageSquashfs != "" && (flags.Root != "" || flags.WindowsAllDrives || flags.RemoteImage != "" || flags.ImageTarball != "" || flags.ImageLocal != "" || flags.ImagePlatform != "" || flags.WebDAVURL != "") {
+		return errors.New("--image-squashfs cannot be used with --root, --windows-all-drives, --webdav-url or the container image flags")
+	}
+	if flags.ImageVMDisk != "" && (flags.Root != "" || flags.WindowsAllDrives || flags.RemoteImage != "" || flags.ImageTarball != "" || flags.ImageLocal != "" || flags.ImagePlatform != "" || flags.ImageSquashfs != "" || flags.WebDAVURL != "") {
+		return errors.New("--image-vm-disk cannot be used with --root, --windows-all-drives, --webdav-url or the other image scanning flags")
+	}
+	if flags.StdinTar && (flags.Root != "" || flags.WindowsAllDrives || flags.RemoteImage != "" || flags.ImageTarball != "" || flags.ImageLocal != "" || flags.ImageSquashfs != "" || flags.ImageVMDisk != "" || flags.WebDAVURL != "") {
+		return errors.New("--stdin-tar cannot be used with --root, --windows-all-drives, --webdav-url or the image scanning flags")
+	}
+	if flags.StdinTar && flags.FilesFrom == "-" {
+		return errors.New("--stdin-tar and --files-from=- cannot both read from stdin")
+	}
+	if err := validatePluginDir(flags.PluginDir); err != nil {
+		return fmt.Errorf("--plugin-dir: %w", err)
+	}
+	if flags.WebDAVUser != "" && flags.WebDAVURL == "" {
+		return errors.New("--webdav-user cannot be used without --webdav-url")
+	}
+	if flags.ExpectedInodes < 0 {
+		return errors.New("--expected-inodes cannot be negative")
+	}
+	if flags.MaxBytesPerFile < 0 {
+		return errors.New("--max-bytes-per-file cannot be negative")
+	}
+	if flags.MaxTotalBytes < 0 {
+		return errors.New("--max-total-bytes cannot be negative")
+	}
+	if flags.InterruptGracePeriod < 0 {
+		return errors.New("--interrupt-grace-period cannot be negative")
+	}
+	if flags.TargetPythonVersion != "" && !targetVersionRe.MatchString(flags.TargetPythonVersion) {
+		return fmt.Errorf("--target-python-version %q: expected a version like 3.11 or 3.11.4", flags.TargetPythonVersion)
+	}
+	if flags.TargetNodeVersion != "" && !targetVersionRe.MatchString(strings.TrimPrefix(flags.TargetNodeVersion, "v")) {
+		return fmt.Errorf("--target-node-version %q: expected a version like 20 or 20.11.0", flags.TargetNodeVersion)
+	}
+	if _, err := flags.pluginTimeouts(); err != nil {
+		return err
+	}
+	if err := validateDepGraphFlags(flags); err != nil {
+		return err
+	}
+	if flags.Baseline != "" && flags.WriteBaselineFile != "" {
+		// The baselined findings would be missing from the new baseline.
+		return errors.New("--baseline and --write-baseline can't be used together")
+	}
 	if err := validateResultPath(flags.ResultFile); err != nil {
 		return fmt.Errorf("--result %w", err)
 	}
@@ -206,6 +351,9 @@ func ValidateFlags(flags *Flags) error {
 	if err := validateMultiStringArg(flags.PluginsToRun); err != nil {
 		return fmt.Errorf("--plugins: %w", err)
 	}
+	if _, err := pl.PluginNamesFromEcosystems(multiStringToList(flags.Ecosystems)); err != nil {
+		return fmt.Errorf("--ecosystems: %w", err)
+	}
 	// Legacy args for setting plugins.
 	if err := validateMultiStringArg(flags.ExtractorsToRun); err != nil {
 		return fmt.Errorf("--extractors: %w", err)
@@ -226,6 +374,24 @@ func ValidateFlags(flags *Flags) error {
 	if err := validateGlob(flags.SkipDirGlob); err != nil {
 		return fmt.Errorf("--skip-dir-glob: %w", err)
 	}
+	if _, err := flags.pathFilter(); err != nil {
+		return fmt.Errorf("--path-filter-config: %w", err)
+	}
+	if _, err := mounts.ParsePolicy(flags.MountPolicy); err != nil {
+		return fmt.Errorf("--mount-policy: %w", err)
+	}
+	if _, err := flags.sbomNaming(); err != nil {
+		return fmt.Errorf("--sbom-naming-rules: %w", err)
+	}
+	if _, err := flags.hashingConfig(); err != nil {
+		return fmt.Errorf("--hash-algorithms: %w", err)
+	}
+	if _, err := flags.dedupConfig(); err != nil {
+		return fmt.Errorf("--dedup: %w", err)
+	}
+	if _, err := flags.SeverityThreshold(); err != nil {
+		return fmt.Errorf("--fail-on-severity: %w", err)
+	}
 	pluginsToRun := slices.Concat(flags.PluginsToRun, flags.ExtractorsToRun, flags.DetectorsToRun, flags.AnnotatorsToRun)
 	if err := validateDependency(pluginsToRun, flags.ExplicitExtractors); err != nil {
 		return err
@@ -260,6 +426,36 @@ func validateOutput(output []string) error {
 	return nil
 }
 
+func validateDepGraphFlags(flags *Flags) error {
+	if flags.DepGraphFormat != "" && flags.DepGraphDir == "" {
+		return errors.New("--dep-graph-format cannot be used without --dep-graph-dir")
+	}
+	if flags.DepGraphDir == "" {
+		return nil
+	}
+	if f := flags.depGraphFormat(); f != depgraph.FormatDOT && f != depgraph.FormatJSON {
+		return fmt.Errorf("--dep-graph-format %q: expected dot or json", f)
+	}
+	if flags.RemoteImage != "" || flags.ImageTarball != "" || flags.ImageLocal != "" {
+		return errors.New("--dep-graph-dir cannot be used with the image scanning flags")
+	}
+	return nil
+}
+
+func validatePluginDir(dir string) error {
+	if dir == "" {
+		return nil
+	}
+	info, err := os.Stat(dir)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("%s is not a directory", dir)
+	}
+	return nil
+}
+
 func validateImagePlatform(imagePlatform string) error {
 	if len(imagePlatform) == 0 {
 		return nil
@@ -343,6 +539,9 @@ func (f *Flags) GetScanConfig() (*scalibr.ScanConfig, error) {
 	if f.FilterByCapabilities {
 		plugins = filterByCapabilities(plugins, capab)
 	}
+	if f.SkipEnrichers {
+		plugins = withoutEnrichers(plugins)
+	}
 	var skipDirRegex *regexp.Regexp
 	if f.SkipDirRegex != "" {
 		skipDirRegex, err = regexp.Compile(f.SkipDirRegex)
@@ -362,22 +561,210 @@ func (f *Flags) GetScanConfig() (*scalibr.ScanConfig, error) {
 	if err != nil {
 		return nil, err
 	}
+	pathsToExtract, err := f.pathsToExtract()
+	if err != nil {
+		return nil, err
+	}
+	hashingConfig, err := f.hashingConfig()
+	if err != nil {
+		return nil, err
+	}
+	dedupConfig, err := f.dedupConfig()
+	if err != nil {
+		return nil, err
+	}
+	pluginTimeouts, err := f.pluginTimeouts()
+	if err != nil {
+		return nil, err
+	}
+	pathFilter, err := f.pathFilter()
+	if err != nil {
+		return nil, err
+	}
+	mountPolicy, err := mounts.ParsePolicy(f.MountPolicy)
+	if err != nil {
+		return nil, err
+	}
 
 	return &scalibr.ScanConfig{
-		ScanRoots:         scanRoots,
-		Plugins:           plugins,
-		Capabilities:      capab,
-		PathsToExtract:    f.PathsToExtract,
-		IgnoreSubDirs:     f.IgnoreSubDirs,
-		DirsToSkip:        f.dirsToSkip(scanRoots),
-		SkipDirRegex:      skipDirRegex,
-		SkipDirGlob:       skipDirGlob,
-		MaxFileSize:       f.MaxFileSize,
-		UseGitignore:      f.UseGitignore,
-		StoreAbsolutePath: f.StoreAbsolutePath,
+		ScanRoots:           scanRoots,
+		Plugins:             plugins,
+		Capabilities:        capab,
+		PathsToExtract:      pathsToExtract,
+		IgnoreSubDirs:       f.IgnoreSubDirs,
+		DirsToSkip:          f.dirsToSkip(scanRoots),
+		SkipDirRegex:        skipDirRegex,
+		SkipDirGlob:         skipDirGlob,
+		PathFilter:          pathFilter,
+		MountPolicy:         mountPolicy,
+		MaxFileSize:         f.MaxFileSize,
+		UseGitignore:        f.UseGitignore,
+		OnlyGitTracked:      f.OnlyGitTracked,
+		StoreAbsolutePath:   f.StoreAbsolutePath,
+		Hashing:             hashingConfig,
+		Dedup:               dedupConfig,
+		Progress:            f.progressReporter(),
+		ExpectedInodes:      f.ExpectedInodes,
+		TargetEnv:           f.targetEnv(),
+		PluginTimeouts:      pluginTimeouts,
+		ReportResourceUsage: f.ReportResourceUsage,
+		IOBudget:            f.ioBudget(),
 	}, nil
 }
 
+// ioBudget returns the IO budget set by --max-bytes-per-file and
+// --max-total-bytes, or nil if neither is set.
+func (f *Flags) ioBudget() *scalibrfs.IOBudget {
+	if f.MaxBytesPerFile == 0 && f.MaxTotalBytes == 0 {
+		return nil
+	}
+	return &scalibrfs.IOBudget{
+		MaxBytesPerFile: f.MaxBytesPerFile,
+		MaxTotalBytes:   f.MaxTotalBytes,
+	}
+}
+
+// pathFilter returns the path filter loaded from --path-filter-config, or nil
+// if no config was specified.
+func (f *Flags) pathFilter() (*pathfilter.Filter, error) {
+	if f.PathFilterConfig == "" {
+		return nil, nil
+	}
+	return pathfilter.LoadFile(f.PathFilterConfig)
+}
+
+// sbomNaming returns the SBOM naming policy loaded from --sbom-naming-rules, or
+// nil if no rules file was specified.
+func (f *Flags) sbomNaming() (*converter.NamingPolicy, error) {
+	if f.SBOMNamingRules == "" {
+		return nil, nil
+	}
+	return converter.LoadNamingRules(f.SBOMNamingRules)
+}
+
+// targetEnv returns the target environment to evaluate conditional
+// dependencies against, or nil if none was specified.
+func (f *Flags) targetEnv() *targetenv.Env {
+	env := &targetenv.Env{
+		OS:            f.TargetOS,
+		Arch:          f.TargetArch,
+		PythonVersion: f.TargetPythonVersion,
+		NodeVersion:   strings.TrimPrefix(f.TargetNodeVersion, "v"),
+	}
+	if env.IsEmpty() {
+		return nil
+	}
+	return env
+}
+
+// progressReporter returns a reporter that logs the scan progress if enabled
+// through the CLI flags, or nil otherwise.
+func (f *Flags) progressReporter() stats.ProgressReporter {
+	if !f.Progress {
+		return nil
+	}
+	return stats.ProgressFunc(logProgress)
+}
+
+func logProgress(p *stats.Progress) {
+	pkgs := 0
+	for _, n := range p.ExtractorPackages {
+		pkgs += n
+	}
+	eta := "unknown"
+	if d, ok := p.ETA(); ok {
+		eta = d.Round(time.Second).String()
+	}
+	if p.Done {
+		log.Infof("Progress: done, %d inodes visited, %d files matched, %d packages found, %s elapsed",
+			p.InodesVisited, p.FilesMatched, pkgs, p.Elapsed.Round(time.Second))
+		return
+	}
+	log.Infof("Progress: %d inodes visited, %d files matched, %d packages found, %s elapsed, ETA %s, path: %q",
+		p.InodesVisited, p.FilesMatched, pkgs, p.Elapsed.Round(time.Second), eta, p.CurrentPath)
+}
+
+// SeverityThreshold returns the minimum severity of findings that make the scan
+// exit with a non-zero code, or SeverityUnspecified if no threshold is set.
+func (f *Flags) SeverityThreshold() (inventory.SeverityEnum, error) {
+	if f.FailOnSeverity == "" {
+		return inventory.SeverityUnspecified, nil
+	}
+	sev, ok := severity.Parse(f.FailOnSeverity)
+	if !ok {
+		return inventory.SeverityUnspecified, fmt.Errorf("severity %q not recognized, supported values are minimal, low, medium, high, critical", f.FailOnSeverity)
+	}
+	return sev, nil
+}
+
+// hashingConfig returns the hashing config set through the CLI flags or nil if
+// the plugin defaults should be used.
+func (f *Flags) hashingConfig() (*hashing.Config, error) {
+	if len(f.HashAlgorithms) == 0 && !f.FIPSMode {
+		return nil, nil
+	}
+	cfg := hashing.DefaultConfig()
+	cfg.FIPSMode = f.FIPSMode
+	if len(f.HashAlgorithms) > 0 {
+		cfg.Algorithms = nil
+		for _, name := range f.HashAlgorithms {
+			a, err := hashing.ParseAlgorithm(name)
+			if err != nil {
+				return nil, err
+			}
+			cfg.Algorithms = append(cfg.Algorithms, a)
+		}
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// dedupConfig returns the config for merging duplicate packages set through
+// the CLI flags or nil if duplicates should be kept.
+func (f *Flags) dedupConfig() (*dedup.Config, error) {
+	if len(f.DedupStrategies) == 0 {
+		return nil, nil
+	}
+	cfg := &dedup.Config{}
+	for _, name := range f.DedupStrategies {
+		s, err := dedup.ParseStrategy(name)
+		if err != nil {
+			return nil, err
+		}
+		cfg.Strategies = append(cfg.Strategies, s)
+	}
+	return cfg, nil
+}
+
+// pluginTimeouts returns the plugin timeouts set through the CLI flags, or nil
+// if none were specified.
+func (f *Flags) pluginTimeouts() (*plugin.Timeouts, error) {
+	if f.PluginTimeout == 0 && len(f.PluginTimeoutOverrides) == 0 {
+		return nil, nil
+	}
+	timeouts := &plugin.Timeouts{Default: f.PluginTimeout}
+	for _, o := range f.PluginTimeoutOverrides {
+		name, value, ok := strings.Cut(o, "=")
+		if !ok || name == "" {
+			return nil, fmt.Errorf("--plugin-timeout-overrides %q: expected plugin=duration", o)
+		}
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return nil, fmt.Errorf("--plugin-timeout-overrides %q: %w", o, err)
+		}
+		if timeouts.PerPlugin == nil {
+			timeouts.PerPlugin = make(map[string]time.Duration)
+		}
+		timeouts.PerPlugin[name] = d
+	}
+	if err := timeouts.Validate(); err != nil {
+		return nil, err
+	}
+	return timeouts, nil
+}
+
 // GetSPDXConfig creates an SPDXConfig struct based on the CLI flags.
 func (f *Flags) GetSPDXConfig() converter.SPDXConfig {
 	var creators []common.Creator
@@ -422,6 +809,14 @@ func (f *Flags) WriteScanResults(result *scalibr.ScanResult) error {
 		}
 	}
 	if len(f.Output) > 0 {
+		naming, err := f.sbomNaming()
+		if err != nil {
+			return err
+		}
+		spdxConfig := f.GetSPDXConfig()
+		spdxConfig.Naming = naming
+		cdxConfig := f.GetCDXConfig()
+		cdxConfig.Naming = naming
 		for _, item := range f.Output {
 			o := strings.Split(item, "=")
 			oFormat := o[0]
@@ -436,21 +831,122 @@ func (f *Flags) WriteScanResults(result *scalibr.ScanResult) error {
 					return err
 				}
 			} else if strings.Contains(oFormat, "spdx23") {
-				doc := converter.ToSPDX23(result, f.GetSPDXConfig())
+				doc := converter.ToSPDX23(result, spdxConfig)
 				if err := spdx.Write23(doc, oPath, oFormat); err != nil {
 					return err
 				}
+			} else if strings.Contains(oFormat, "spdx30") {
+				doc := converter.ToSPDX30(result, spdxConfig)
+				if err := spdx.Write30(doc, oPath, oFormat); err != nil {
+					return err
+				}
 			} else if strings.Contains(oFormat, "cdx") {
-				doc := converter.ToCDX(result, f.GetCDXConfig())
+				doc := converter.ToCDX(result, cdxConfig)
 				if err := cdx.Write(doc, oPath, oFormat); err != nil {
 					return err
 				}
+			} else if oFormat == "sarif" {
+				doc := converter.ToSARIF(result)
+				if err := sarif.Write(doc, oPath, oFormat); err != nil {
+					return err
+				}
 			}
 		}
 	}
 	return nil
 }
 
+// Policy returns the policy set with --policy or nil if none is set.
+func (f *Flags) Policy() (*policy.Policy, error) {
+	if f.PolicyFile == "" {
+		return nil, nil
+	}
+	return policy.ReadFile(f.PolicyFile)
+}
+
+// WriteBaseline writes a baseline of the security findings of the scan to the
+// file specified by --write-baseline, if set.
+func (f *Flags) WriteBaseline(result *scalibr.ScanResult) error {
+	if f.WriteBaselineFile == "" {
+		return nil
+	}
+	b := scalibrbaseline.New(&result.Inventory)
+	log.Infof("Writing baseline of %d findings to %s", len(b.Entries), f.WriteBaselineFile)
+	return atomicfile.Write(f.WriteBaselineFile, b.Write)
+}
+
+func (f *Flags) depGraphFormat() depgraph.Format {
+	if f.DepGraphFormat == "" {
+		return depgraph.FormatDOT
+	}
+	return depgraph.Format(f.DepGraphFormat)
+}
+
+// WriteDependencyGraphs writes the dependency graphs of the applications whose
+// lockfiles were found during the scan into --dep-graph-dir, one file per
+// lockfile. Lockfiles that can't be parsed are skipped with a warning.
+func (f *Flags) WriteDependencyGraphs(result *scalibr.ScanResult, scanRoots []*scalibrfs.ScanRoot) error {
+	if f.DepGraphDir == "" {
+		return nil
+	}
+	apps := depgraph.Applications(result.Inventory.Packages)
+	if len(apps) == 0 {
+		return nil
+	}
+	if err := os.MkdirAll(f.DepGraphDir, 0755); err != nil {
+		return err
+	}
+	format := f.depGraphFormat()
+	for _, app := range apps {
+		g, err := dependencyGraph(scanRoots, app)
+		if err != nil {
+			log.Warnf("Failed to build the dependency graph of %s: %v", app, err)
+			continue
+		}
+		graphPath := filepath.Join(f.DepGraphDir, depgraph.FileName(app, format))
+		log.Infof("Writing dependency graph to %s", graphPath)
+		if err := writeDependencyGraph(graphPath, g, format); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// dependencyGraph builds the graph of the lockfile from the first scan root it
+// can be read from. The lockfile path is either relative to the scan root or,
+// with --store-absolute-path, absolute.
+func dependencyGraph(scanRoots []*scalibrfs.ScanRoot, lockfile string) (*depgraph.Graph, error) {
+	err := fmt.Errorf("%s not found in the scan roots", lockfile)
+	for _, root := range scanRoots {
+		rel := lockfile
+		if root.Path != "" && filepath.IsAbs(lockfile) {
+			r, relErr := filepath.Rel(root.Path, lockfile)
+			if relErr != nil || strings.HasPrefix(r, "..") {
+				continue
+			}
+			rel = r
+		}
+		var g *depgraph.Graph
+		if g, err = depgraph.FromLockfile(root.FS, filepath.ToSlash(rel)); err == nil {
+			g.Application = lockfile
+			return g, nil
+		}
+	}
+	return nil, err
+}
+
+func writeDependencyGraph(graphPath string, g *depgraph.Graph, format depgraph.Format) error {
+	out, err := os.Create(graphPath)
+	if err != nil {
+		return err
+	}
+	if err := g.Write(out, format); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
 // TODO(b/279413691): Allow commas in argument names.
 func (f *Flags) pluginsToRun() ([]plugin.Plugin, error) {
 	result := make([]plugin.Plugin, 0, len(f.PluginsToRun))
@@ -458,9 +954,13 @@ func (f *Flags) pluginsToRun() ([]plugin.Plugin, error) {
 	extractorNames := addPluginPrefixToGroups("extractors/", multiStringToList(f.ExtractorsToRun))
 	detectorNames := addPluginPrefixToGroups("detectors/", multiStringToList(f.DetectorsToRun))
 	annotatorNames := addPluginPrefixToGroups("annotators/", multiStringToList(f.AnnotatorsToRun))
+	ecosystemPluginNames, err := pl.PluginNamesFromEcosystems(multiStringToList(f.Ecosystems))
+	if err != nil {
+		return nil, err
+	}
 
 	// Use the default plugins if nothing is specified.
-	allPluginNames := slices.Concat(pluginNames, extractorNames, detectorNames, annotatorNames)
+	allPluginNames := slices.Concat(pluginNames, extractorNames, detectorNames, annotatorNames, ecosystemPluginNames)
 	if len(allPluginNames) == 0 {
 		allPluginNames = []string{"default"}
 	}
@@ -475,14 +975,36 @@ func (f *Flags) pluginsToRun() ([]plugin.Plugin, error) {
 		for _, p := range plugins {
 			if p.Name() == gobinary.Name {
 				p.(*gobinary.Extractor).VersionFromContent = f.GoBinaryVersionFromContent
+				p.(*gobinary.Extractor).StrippedBinaries = f.GoBinaryStrippedBinaries
+			}
+			if p.Name() == cargoauditable.Name {
+				p.(*cargoauditable.Extractor).PanicPathFallback = f.CargoAuditablePanicPaths
 			}
 			if p.Name() == binary.Name {
 				p.(*binary.Detector).OfflineVulnDBPath = f.GovulncheckDBPath
 			}
+			if p.Name() == osvlocal.Name {
+				p.(*osvlocal.Enricher).DBPath = f.OSVDBPath
+			}
+			if p.Name() == filehashes.Name && f.FileHashBudget != 0 {
+				p.(*filehashes.Annotator).MaxBytes = f.FileHashBudget
+			}
+			if p.Name() == baseline.Name {
+				p.(*baseline.Enricher).Path = f.Baseline
+			}
+			if p.Name() == suppression.Name {
+				p.(*suppression.Enricher).RulesPath = f.SuppressionsFile
+				p.(*suppression.Enricher).VEXPaths = f.OpenVEXFiles
+			}
+			if err := f.applyMavenRegistry(p); err != nil {
+				return nil, err
+			}
 			if f.LocalRegistry != "" {
 				switch p.Name() {
 				case pomxmlnet.Name:
 					p.(*pomxmlnet.Extractor).MavenClient.SetLocalRegistry(filepath.Join(f.LocalRegistry, "maven"))
+				case pomxml.Name:
+					p.(*pomxml.Enricher).Resolver.MavenClient.SetLocalRegistry(filepath.Join(f.LocalRegistry, "maven"))
 				case requirements.Name:
 					if client, ok := p.(*requirements.Enricher).Client.(*resolution.PyPIRegistryClient); ok {
 						// The resolution client is the native PyPI registry client.
@@ -495,9 +1017,38 @@ func (f *Flags) pluginsToRun() ([]plugin.Plugin, error) {
 		result = append(result, plugins...)
 	}
 
+	if f.PluginDir != "" {
+		plugins, err := dynamic.Load(context.Background(), f.PluginDir)
+		if err != nil {
+			return nil, fmt.Errorf("loading plugins from %s: %w", f.PluginDir, err)
+		}
+		result = append(result, plugins...)
+	}
+
 	return result, nil
 }
 
+// applyMavenRegistry makes the plugins that fetch metadata from Maven Central
+// use the registry set with --maven-registry instead.
+func (f *Flags) applyMavenRegistry(p plugin.Plugin) error {
+	if f.MavenRegistry == "" {
+		return nil
+	}
+	var client *datasource.MavenRegistryAPIClient
+	switch p.Name() {
+	case pomxmlnet.Name:
+		client = p.(*pomxmlnet.Extractor).MavenClient
+	case pomxml.Name:
+		client = p.(*pomxml.Enricher).Resolver.MavenClient
+	default:
+		return nil
+	}
+	if err := client.SetDefaultRegistry(datasource.MavenRegistry{URL: f.MavenRegistry, ReleasesEnabled: true}); err != nil {
+		return fmt.Errorf("--maven-registry: %w", err)
+	}
+	return nil
+}
+
 // addPluginPrefixToGroups adds the specified prefix to the "default" and "all"
 // plugin group names so that they're only applied for a specific plugin type
 // so that e.g. --extractors=all only enables all extractors and not other plugins.
@@ -531,6 +1082,50 @@ func (f *Flags) scanRoots() ([]*scalibrfs.ScanRoot, error) {
 		return []*scalibrfs.ScanRoot{{FS: fs, Path: ""}}, nil
 	}
 
+	if f.WebDAVURL != "" {
+		fs, err := webdav.New(context.Background(), webdav.Config{
+			URL:      f.WebDAVURL,
+			Username: f.WebDAVUser,
+			Password: os.Getenv(WebDAVPasswordEnv),
+		})
+		if err != nil {
+			return nil, err
+		}
+		// We're scanning a virtual filesystem that describes the remote share.
+		return []*scalibrfs.ScanRoot{{FS: fs, Path: ""}}, nil
+	}
+
+	if f.ImageSquashfs != "" {
+		fs, err := squashfs.Open(f.ImageSquashfs)
+		if err != nil {
+			return nil, err
+		}
+		// We're scanning a virtual filesystem that describes the image contents.
+		return []*scalibrfs.ScanRoot{{FS: fs, Path: ""}}, nil
+	}
+
+	if f.ImageVMDisk != "" {
+		img, err := vmdisk.Open(f.ImageVMDisk)
+		if err != nil {
+			return nil, err
+		}
+		fs, err := img.RootFS()
+		if err != nil {
+			_ = img.Close()
+			return nil, fmt.Errorf("%s: %w", f.ImageVMDisk, err)
+		}
+		// We're scanning the root filesystem stored on the disk image.
+		return []*scalibrfs.ScanRoot{{FS: fs, Path: ""}}, nil
+	}
+
+	if f.StdinTar {
+		fs, err := memfs.FromTar(os.Stdin, 0)
+		if err != nil {
+			return nil, fmt.Errorf("--stdin-tar: %w", err)
+		}
+		return []*scalibrfs.ScanRoot{fs.ScanRoot()}, nil
+	}
+
 	if len(f.Root) != 0 {
 		return scalibrfs.RealFSScanRoots(f.Root), nil
 	}
@@ -559,6 +1154,43 @@ func (f *Flags) scanRoots() ([]*scalibrfs.ScanRoot, error) {
 	return scanRoots, nil
 }
 
+// pathsToExtract returns the files passed as arguments and the ones listed in
+// --files-from.
+func (f *Flags) pathsToExtract() ([]string, error) {
+	if f.FilesFrom == "" {
+		return f.PathsToExtract, nil
+	}
+	var r io.Reader = os.Stdin
+	if f.FilesFrom != "-" {
+		file, err := os.Open(f.FilesFrom)
+		if err != nil {
+			return nil, fmt.Errorf("--files-from: %w", err)
+		}
+		defer file.Close()
+		r = file
+	}
+	paths := slices.Clone(f.PathsToExtract)
+	s := bufio.NewScanner(r)
+	for s.Scan() {
+		p := strings.TrimSpace(s.Text())
+		if p == "" {
+			continue
+		}
+		switch {
+		case f.StdinTar:
+			// Paths inside the virtual filesystem of the archive.
+			p = strings.TrimPrefix(path.Clean("/"+filepath.ToSlash(p)), "/")
+		case f.Root != "" && !filepath.IsAbs(p):
+			p = filepath.Join(f.Root, p)
+		}
+		paths = append(paths, p)
+	}
+	if err := s.Err(); err != nil {
+		return nil, fmt.Errorf("--files-from: %w", err)
+	}
+	return paths, nil
+}
+
 func (f *Flags) scanRemoteImageOptions() *[]remote.Option {
 	imageOptions := []remote.Option{
 		remote.WithAuthFromKeychain(authn.DefaultKeychain),
@@ -581,6 +1213,43 @@ func (f *Flags) capabilities() *plugin.Capabilities {
 	if f.Offline {
 		network = plugin.NetworkOffline
 	}
+	if f.WebDAVURL != "" {
+		// We're scanning a remote file share whose OS we don't know.
+		return &plugin.Capabilities{
+			OS:            plugin.OSUnknown,
+			Network:       network,
+			DirectFS:      false,
+			RunningSystem: false,
+		}
+	}
+	if f.StdinTar {
+		// We're scanning files from an unknown system, e.g. the build context of
+		// a build system.
+		return &plugin.Capabilities{
+			OS:            plugin.OSAny,
+			Network:       network,
+			DirectFS:      false,
+			RunningSystem: false,
+		}
+	}
+	if f.ImageVMDisk != "" {
+		// We're scanning the root filesystem of a Linux VM.
+		return &plugin.Capabilities{
+			OS:            plugin.OSLinux,
+			Network:       network,
+			DirectFS:      false,
+			RunningSystem: false,
+		}
+	}
+	if f.ImageSquashfs != "" {
+		// We're scanning a snap or the root filesystem of a Linux-based firmware image.
+		return &plugin.Capabilities{
+			OS:            plugin.OSLinux,
+			Network:       network,
+			DirectFS:      false,
+			RunningSystem: false,
+		}
+	}
 	if f.RemoteImage != "" {
 		// We're scanning a Linux container image whose filesystem is mounted to the host's disk.
 		return &plugin.Capabilities{
@@ -610,6 +1279,16 @@ func filterByCapabilities(plugins []plugin.Plugin, capab *plugin.Capabilities) [
 	return fp
 }
 
+func withoutEnrichers(plugins []plugin.Plugin) []plugin.Plugin {
+	fp := make([]plugin.Plugin, 0, len(plugins))
+	for _, p := range plugins {
+		if _, ok := p.(enricher.Enricher); !ok {
+			fp = append(fp, p)
+		}
+	}
+	return fp
+}
+
 func (f *Flags) dirsToSkip(scanRoots []*scalibrfs.ScanRoot) []string {
 	paths, err := platform.DefaultIgnoredDirectories()
 	if err != nil {
@@ -15,20 +15,41 @@
 package cli_test
 
 import (
+	"context"
 	"os"
 	"path/filepath"
 	"runtime"
 	"strings"
 	"testing"
+	"testing/fstest"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	scalibr "github.com/google/osv-scalibr"
+	"github.com/google/osv-scalibr/annotator/misc/filehashes"
 	"github.com/google/osv-scalibr/binary/cli"
+	"github.com/google/osv-scalibr/clients/clienttest"
+	"github.com/google/osv-scalibr/clients/datasource"
 	"github.com/google/osv-scalibr/detector/govulncheck/binary"
+	"github.com/google/osv-scalibr/enricher/transitivedependency/pomxml"
+	"github.com/google/osv-scalibr/enricher/vex/baseline"
+	"github.com/google/osv-scalibr/enricher/vex/suppression"
+	"github.com/google/osv-scalibr/enricher/vulnmatch/osvlocal"
+	"github.com/google/osv-scalibr/extractor"
 	"github.com/google/osv-scalibr/extractor/filesystem/language/golang/gobinary"
+	"github.com/google/osv-scalibr/extractor/filesystem/language/java/pomxmlnet"
+	"github.com/google/osv-scalibr/extractor/filesystem/language/rust/cargoauditable"
+	"github.com/google/osv-scalibr/extractor/filesystem/targetenv"
+	scalibrfs "github.com/google/osv-scalibr/fs"
+	"github.com/google/osv-scalibr/fs/mounts"
+	"github.com/google/osv-scalibr/hashing"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/inventory/dedup"
 	"github.com/google/osv-scalibr/plugin"
 	pl "github.com/google/osv-scalibr/plugin/list"
+	scalibrbaseline "github.com/google/osv-scalibr/result/baseline"
+	"github.com/ossf/osv-schema/bindings/go/osvschema"
 )
 
 func TestValidateFlags(t *testing.T) {
@@ -177,6 +198,233 @@ func TestValidateFlags(t *testing.T) {
 			},
 			wantErr: cmpopts.AnyError,
 		},
+		{
+			desc: "Unknown hash algorithm",
+			flags: &cli.Flags{
+				Root:           "/",
+				ResultFile:     "result.textproto",
+				HashAlgorithms: []string{"md4"},
+			},
+			wantErr: cmpopts.AnyError,
+		},
+		{
+			desc: "Non-FIPS hash algorithm in FIPS mode",
+			flags: &cli.Flags{
+				Root:           "/",
+				ResultFile:     "result.textproto",
+				HashAlgorithms: []string{"blake3"},
+				FIPSMode:       true,
+			},
+			wantErr: cmpopts.AnyError,
+		},
+		{
+			desc: "Unknown ecosystem",
+			flags: &cli.Flags{
+				Root:       "/",
+				ResultFile: "result.textproto",
+				Ecosystems: []string{"cobol"},
+			},
+			wantErr: cmpopts.AnyError,
+		},
+		{
+			desc: "Negative expected inodes",
+			flags: &cli.Flags{
+				Root:           "/",
+				ResultFile:     "result.textproto",
+				ExpectedInodes: -1,
+			},
+			wantErr: cmpopts.AnyError,
+		},
+		{
+			desc: "Negative max bytes per file",
+			flags: &cli.Flags{
+				Root:            "/",
+				ResultFile:      "result.textproto",
+				MaxBytesPerFile: -1,
+			},
+			wantErr: cmpopts.AnyError,
+		},
+		{
+			desc: "Stream image layers without image",
+			flags: &cli.Flags{
+				Root:              "/",
+				ResultFile:        "result.textproto",
+				StreamImageLayers: true,
+			},
+			wantErr: cmpopts.AnyError,
+		},
+		{
+			desc: "Stream image layers of image tarball",
+			flags: &cli.Flags{
+				ResultFile:        "result.textproto",
+				ImageTarball:      "image.tar",
+				StreamImageLayers: true,
+			},
+			wantErr: nil,
+		},
+		{
+			desc: "stdin tar with root",
+			flags: &cli.Flags{
+				Root:       "/",
+				ResultFile: "result.textproto",
+				StdinTar:   true,
+			},
+			wantErr: cmpopts.AnyError,
+		},
+		{
+			desc: "stdin tar and file list from stdin",
+			flags: &cli.Flags{
+				ResultFile: "result.textproto",
+				StdinTar:   true,
+				FilesFrom:  "-",
+			},
+			wantErr: cmpopts.AnyError,
+		},
+		{
+			desc: "stdin tar with file list",
+			flags: &cli.Flags{
+				ResultFile: "result.textproto",
+				StdinTar:   true,
+				FilesFrom:  "changed-files.txt",
+			},
+			wantErr: nil,
+		},
+		{
+			desc: "Negative interrupt grace period",
+			flags: &cli.Flags{
+				Root:                 "/",
+				ResultFile:           "result.textproto",
+				InterruptGracePeriod: -time.Second,
+			},
+			wantErr: cmpopts.AnyError,
+		},
+		{
+			desc: "Invalid target Python version",
+			flags: &cli.Flags{
+				Root:                "/",
+				ResultFile:          "result.textproto",
+				TargetPythonVersion: "three",
+			},
+			wantErr: cmpopts.AnyError,
+		},
+		{
+			desc: "Invalid target Node version",
+			flags: &cli.Flags{
+				Root:              "/",
+				ResultFile:        "result.textproto",
+				TargetNodeVersion: ">=20",
+			},
+			wantErr: cmpopts.AnyError,
+		},
+		{
+			desc: "Negative plugin timeout",
+			flags: &cli.Flags{
+				Root:          "/",
+				ResultFile:    "result.textproto",
+				PluginTimeout: -time.Second,
+			},
+			wantErr: cmpopts.AnyError,
+		},
+		{
+			desc: "Invalid plugin timeout override",
+			flags: &cli.Flags{
+				Root:                   "/",
+				ResultFile:             "result.textproto",
+				PluginTimeoutOverrides: []string{"python/wheelegg:2m"},
+			},
+			wantErr: cmpopts.AnyError,
+		},
+		{
+			desc: "Missing path filter config",
+			flags: &cli.Flags{
+				Root:             "/",
+				ResultFile:       "result.textproto",
+				PathFilterConfig: "/does/not/exist.yaml",
+			},
+			wantErr: cmpopts.AnyError,
+		},
+		{
+			desc: "Invalid mount policy",
+			flags: &cli.Flags{
+				Root:        "/",
+				ResultFile:  "result.textproto",
+				MountPolicy: "nfs4=ignore",
+			},
+			wantErr: cmpopts.AnyError,
+		},
+		{
+			desc: "Missing SBOM naming rules",
+			flags: &cli.Flags{
+				Root:            "/",
+				ResultFile:      "result.textproto",
+				SBOMNamingRules: "/does/not/exist.yaml",
+			},
+			wantErr: cmpopts.AnyError,
+		},
+		{
+			desc: "Unknown dependency graph format",
+			flags: &cli.Flags{
+				Root:           "/",
+				ResultFile:     "result.textproto",
+				DepGraphDir:    "graphs",
+				DepGraphFormat: "svg",
+			},
+			wantErr: cmpopts.AnyError,
+		},
+		{
+			desc: "Dependency graph format without dir",
+			flags: &cli.Flags{
+				Root:           "/",
+				ResultFile:     "result.textproto",
+				DepGraphFormat: "json",
+			},
+			wantErr: cmpopts.AnyError,
+		},
+		{
+			desc: "Dependency graphs for image scan",
+			flags: &cli.Flags{
+				ResultFile:   "result.textproto",
+				ImageTarball: "image.tar",
+				DepGraphDir:  "graphs",
+			},
+			wantErr: cmpopts.AnyError,
+		},
+		{
+			desc: "Unknown dedup strategy",
+			flags: &cli.Flags{
+				Root:            "/",
+				ResultFile:      "result.textproto",
+				DedupStrategies: []string{"prefer-newest"},
+			},
+			wantErr: cmpopts.AnyError,
+		},
+		{
+			desc: "Non-existent plugin dir",
+			flags: &cli.Flags{
+				Root:       "/",
+				ResultFile: "result.textproto",
+				PluginDir:  "/non-existent-dir",
+			},
+			wantErr: cmpopts.AnyError,
+		},
+		{
+			desc: "Valid severity threshold",
+			flags: &cli.Flags{
+				Root:           "/",
+				ResultFile:     "result.textproto",
+				FailOnSeverity: "HIGH",
+			},
+			wantErr: nil,
+		},
+		{
+			desc: "Invalid severity threshold",
+			flags: &cli.Flags{
+				Root:           "/",
+				ResultFile:     "result.textproto",
+				FailOnSeverity: "severe",
+			},
+			wantErr: cmpopts.AnyError,
+		},
 		{
 			desc: "Invalid SPDX creator format",
 			flags: &cli.Flags{
@@ -227,6 +475,93 @@ func TestValidateFlags(t *testing.T) {
 			},
 			wantErr: cmpopts.AnyError,
 		},
+		{
+			desc: "WebDAV share",
+			flags: &cli.Flags{
+				WebDAVURL:  "https://files.example.com/dav/share",
+				WebDAVUser: "scanner",
+				ResultFile: "result.textproto",
+			},
+			wantErr: nil,
+		},
+		{
+			desc: "WebDAV share with Root",
+			flags: &cli.Flags{
+				WebDAVURL:  "https://files.example.com/dav/share",
+				Root:       "/",
+				ResultFile: "result.textproto",
+			},
+			wantErr: cmpopts.AnyError,
+		},
+		{
+			desc: "squashfs image",
+			flags: &cli.Flags{
+				ImageSquashfs: "firmware.squashfs",
+				ResultFile:    "result.textproto",
+			},
+			wantErr: nil,
+		},
+		{
+			desc: "squashfs image with Root",
+			flags: &cli.Flags{
+				ImageSquashfs: "firmware.squashfs",
+				Root:          "/",
+				ResultFile:    "result.textproto",
+			},
+			wantErr: cmpopts.AnyError,
+		},
+		{
+			desc: "squashfs image with remote image",
+			flags: &cli.Flags{
+				ImageSquashfs: "firmware.squashfs",
+				RemoteImage:   "docker",
+				ResultFile:    "result.textproto",
+			},
+			wantErr: cmpopts.AnyError,
+		},
+		{
+			desc: "VM disk image",
+			flags: &cli.Flags{
+				ImageVMDisk: "disk.qcow2",
+				ResultFile:  "result.textproto",
+			},
+			wantErr: nil,
+		},
+		{
+			desc: "VM disk image with Root",
+			flags: &cli.Flags{
+				ImageVMDisk: "disk.qcow2",
+				Root:        "/",
+				ResultFile:  "result.textproto",
+			},
+			wantErr: cmpopts.AnyError,
+		},
+		{
+			desc: "VM disk image with squashfs image",
+			flags: &cli.Flags{
+				ImageVMDisk:   "disk.qcow2",
+				ImageSquashfs: "firmware.squashfs",
+				ResultFile:    "result.textproto",
+			},
+			wantErr: cmpopts.AnyError,
+		},
+		{
+			desc: "Baseline and write baseline",
+			flags: &cli.Flags{
+				Baseline:          "baseline.json",
+				WriteBaselineFile: "new-baseline.json",
+				ResultFile:        "result.textproto",
+			},
+			wantErr: cmpopts.AnyError,
+		},
+		{
+			desc: "WebDAV user without WebDAV URL",
+			flags: &cli.Flags{
+				WebDAVUser: "scanner",
+				ResultFile: "result.textproto",
+			},
+			wantErr: cmpopts.AnyError,
+		},
 	} {
 		t.Run(tc.desc, func(t *testing.T) {
 			err := cli.ValidateFlags(tc.flags)
@@ -430,199 +765,702 @@ func TestGetScanConfig_DirsToSkip(t *testing.T) {
 			if err != nil {
 				t.Errorf("%v.GetScanConfig(): %v", flags, err)
 			}
-			if diff := cmp.Diff(wantDirsToSkip, cfg.DirsToSkip); diff != "" {
-				t.Errorf("%v.GetScanConfig() dirsToSkip got diff (-want +got):\n%s", flags, diff)
+			if diff := cmp.Diff(wantDirsToSkip, cfg.DirsToSkip); diff != "" {
+				t.Errorf("%v.GetScanConfig() dirsToSkip got diff (-want +got):\n%s", flags, diff)
+			}
+		})
+	}
+}
+
+func TestGetScanConfig_SkipDirRegex(t *testing.T) {
+	for _, tc := range []struct {
+		desc             string
+		flags            *cli.Flags
+		wantSkipDirRegex string
+		wantNil          bool
+	}{
+		{
+			desc: "simple regex",
+			flags: &cli.Flags{
+				Root:         "/",
+				SkipDirRegex: "asdf.*foo",
+			},
+			wantSkipDirRegex: "asdf.*foo",
+		},
+		{
+			desc: "no regex",
+			flags: &cli.Flags{
+				Root: "/",
+			},
+			wantNil: true,
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			cfg, err := tc.flags.GetScanConfig()
+			if err != nil {
+				t.Errorf("%v.GetScanConfig(): %v", tc.flags, err)
+			}
+			if tc.wantNil && cfg.SkipDirRegex != nil {
+				t.Errorf("%v.GetScanConfig() SkipDirRegex got %q, want nil", tc.flags, cfg.SkipDirRegex)
+			}
+			if !tc.wantNil && tc.wantSkipDirRegex != cfg.SkipDirRegex.String() {
+				t.Errorf("%v.GetScanConfig() SkipDirRegex got %q, want %q", tc.flags, cfg.SkipDirRegex.String(), tc.wantSkipDirRegex)
+			}
+		})
+	}
+}
+
+func TestGetScanConfig_PathFilter(t *testing.T) {
+	config := filepath.Join(t.TempDir(), "filter.yaml")
+	rules := "rules:\n  - action: exclude\n    glob: \"**/node_modules\"\n"
+	if err := os.WriteFile(config, []byte(rules), 0644); err != nil {
+		t.Fatalf("os.WriteFile(%q): %v", config, err)
+	}
+
+	flags := &cli.Flags{Root: "/", PathFilterConfig: config}
+	cfg, err := flags.GetScanConfig()
+	if err != nil {
+		t.Fatalf("%v.GetScanConfig(): %v", flags, err)
+	}
+	if !cfg.PathFilter.Excluded("app/node_modules") {
+		t.Errorf("%v.GetScanConfig() PathFilter doesn't exclude app/node_modules", flags)
+	}
+
+	cfg, err = (&cli.Flags{Root: "/"}).GetScanConfig()
+	if err != nil {
+		t.Fatalf("GetScanConfig(): %v", err)
+	}
+	if cfg.PathFilter != nil {
+		t.Errorf("GetScanConfig() PathFilter got %v, want nil", cfg.PathFilter)
+	}
+}
+
+func TestGetScanConfig_MountPolicy(t *testing.T) {
+	nfs := &mounts.Mount{MountPoint: "/mnt/share", FSType: "nfs4"}
+	for _, tc := range []struct {
+		desc        string
+		mountPolicy string
+		want        mounts.Action
+	}{
+		{
+			desc: "default_policy",
+			want: mounts.Skip,
+		},
+		{
+			desc:        "override",
+			mountPolicy: "nfs4=defer",
+			want:        mounts.Defer,
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			flags := &cli.Flags{Root: "/", MountPolicy: tc.mountPolicy}
+			cfg, err := flags.GetScanConfig()
+			if err != nil {
+				t.Fatalf("%v.GetScanConfig(): %v", flags, err)
+			}
+			if got := cfg.MountPolicy.Action(nfs); got != tc.want {
+				t.Errorf("%v.GetScanConfig() MountPolicy.Action(%v) = %q, want %q", flags, nfs, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestGetScanConfig_CreatePlugins(t *testing.T) {
+	for _, tc := range []struct {
+		desc            string
+		flags           *cli.Flags
+		wantPluginCount int
+	}{
+		{
+			desc: "Create an extractor",
+			flags: &cli.Flags{
+				PluginsToRun: []string{"python/wheelegg"},
+			},
+			wantPluginCount: 1,
+		},
+		{
+			desc: "Create an extractor - legacy field",
+			flags: &cli.Flags{
+				ExtractorsToRun: []string{"python/wheelegg"},
+			},
+			wantPluginCount: 1,
+		},
+		{
+			desc: "Create a detector - legacy field",
+			flags: &cli.Flags{
+				PluginsToRun: []string{"cis"},
+			},
+			wantPluginCount: 1,
+		},
+		{
+			desc: "Create a detector - legacy field",
+			flags: &cli.Flags{
+				DetectorsToRun: []string{"cis"},
+			},
+			wantPluginCount: 1,
+		},
+		{
+			desc: "Create an annotator",
+			flags: &cli.Flags{
+				PluginsToRun: []string{"vex/cachedir"},
+			},
+			wantPluginCount: 1,
+		},
+		{
+			desc: "Create an enricher",
+			flags: &cli.Flags{
+				PluginsToRun: []string{"python/wheelegg", "remediation/fixedversion"},
+			},
+			wantPluginCount: 2,
+		},
+		{
+			desc: "Skip enrichers",
+			flags: &cli.Flags{
+				PluginsToRun:  []string{"python/wheelegg", "remediation/fixedversion"},
+				SkipEnrichers: true,
+			},
+			wantPluginCount: 1,
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			cfg, err := tc.flags.GetScanConfig()
+			if err != nil {
+				t.Errorf("%v.GetScanConfig(): %v", tc.flags, err)
+			}
+			if len(cfg.Plugins) != tc.wantPluginCount {
+				t.Errorf("%v.GetScanConfig() want plugin count %d got %d", tc.flags, tc.wantPluginCount, len(cfg.Plugins))
+			}
+		})
+	}
+}
+
+func TestGetScanConfig_GovulncheckParams(t *testing.T) {
+	dbPath := "path/to/db"
+	flags := &cli.Flags{
+		ExtractorsToRun:   []string{"go"},
+		DetectorsToRun:    []string{binary.Detector{}.Name()},
+		GovulncheckDBPath: dbPath,
+	}
+
+	cfg, err := flags.GetScanConfig()
+	if err != nil {
+		t.Errorf("%v.GetScanConfig(): %v", flags, err)
+	}
+	detectors := pl.Detectors(cfg.Plugins)
+	if len(detectors) != 1 {
+		t.Fatalf("%v.GetScanConfig() want 1 detector got %d", flags, len(detectors))
+	}
+	got := detectors[0].(*binary.Detector).OfflineVulnDBPath
+	if got != dbPath {
+		t.Errorf("%v.GetScanConfig() want govulncheck detector with DB path %q got %q", flags, dbPath, got)
+	}
+}
+
+func TestGetScanConfig_OSVDBPath(t *testing.T) {
+	dbPath := "path/to/osv"
+	flags := &cli.Flags{
+		PluginsToRun: []string{osvlocal.Name},
+		OSVDBPath:    dbPath,
+	}
+
+	cfg, err := flags.GetScanConfig()
+	if err != nil {
+		t.Fatalf("%v.GetScanConfig(): %v", flags, err)
+	}
+	enrichers := pl.Enrichers(cfg.Plugins)
+	if len(enrichers) != 1 {
+		t.Fatalf("%v.GetScanConfig() want 1 enricher got %d", flags, len(enrichers))
+	}
+	if got := enrichers[0].(*osvlocal.Enricher).DBPath; got != dbPath {
+		t.Errorf("%v.GetScanConfig() want osvlocal enricher with DB path %q got %q", flags, dbPath, got)
+	}
+}
+
+func TestGetScanConfig_Suppressions(t *testing.T) {
+	rulesPath := "path/to/suppressions.yaml"
+	vexPaths := []string{"path/to/a.openvex.json", "path/to/b.openvex.json"}
+	flags := &cli.Flags{
+		PluginsToRun:     []string{suppression.Name},
+		SuppressionsFile: rulesPath,
+		OpenVEXFiles:     vexPaths,
+	}
+
+	cfg, err := flags.GetScanConfig()
+	if err != nil {
+		t.Fatalf("%v.GetScanConfig(): %v", flags, err)
+	}
+	enrichers := pl.Enrichers(cfg.Plugins)
+	if len(enrichers) != 1 {
+		t.Fatalf("%v.GetScanConfig() want 1 enricher got %d", flags, len(enrichers))
+	}
+	e := enrichers[0].(*suppression.Enricher)
+	if e.RulesPath != rulesPath {
+		t.Errorf("%v.GetScanConfig() want suppression enricher with rules path %q got %q", flags, rulesPath, e.RulesPath)
+	}
+	if diff := cmp.Diff(vexPaths, e.VEXPaths); diff != "" {
+		t.Errorf("%v.GetScanConfig() returned unexpected OpenVEX paths (-want +got):\n%s", flags, diff)
+	}
+}
+
+func TestGetScanConfig_FileHashBudget(t *testing.T) {
+	tests := []struct {
+		desc   string
+		budget int64
+		want   int64
+	}{
+		{desc: "default", budget: 0, want: filehashes.DefaultMaxBytes},
+		{desc: "custom", budget: 1024, want: 1024},
+		{desc: "unlimited", budget: -1, want: -1},
+	}
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			flags := &cli.Flags{
+				PluginsToRun:   []string{filehashes.Name},
+				FileHashBudget: tc.budget,
+			}
+			cfg, err := flags.GetScanConfig()
+			if err != nil {
+				t.Fatalf("%v.GetScanConfig(): %v", flags, err)
+			}
+			annotators := pl.Annotators(cfg.Plugins)
+			if len(annotators) != 1 {
+				t.Fatalf("%v.GetScanConfig() want 1 annotator got %d", flags, len(annotators))
+			}
+			if got := annotators[0].(*filehashes.Annotator).MaxBytes; got != tc.want {
+				t.Errorf("%v.GetScanConfig() want filehashes annotator with budget %d got %d", flags, tc.want, got)
+			}
+		})
+	}
+}
+
+func TestGetScanConfig_MavenRegistry(t *testing.T) {
+	srv := clienttest.NewMockHTTPServer(t)
+	srv.SetResponse(t, "org/example/x.y.z/1.0.0/x.y.z-1.0.0.pom", []byte(`
+	<project>
+	  <groupId>org.example</groupId>
+	  <artifactId>x.y.z</artifactId>
+	  <version>1.0.0</version>
+	</project>
+	`))
+	flags := &cli.Flags{
+		PluginsToRun:  []string{pomxmlnet.Name, pomxml.Name},
+		MavenRegistry: srv.URL,
+	}
+	cfg, err := flags.GetScanConfig()
+	if err != nil {
+		t.Fatalf("%v.GetScanConfig(): %v", flags, err)
+	}
+
+	var clients []*datasource.MavenRegistryAPIClient
+	for _, p := range cfg.Plugins {
+		switch p := p.(type) {
+		case *pomxmlnet.Extractor:
+			clients = append(clients, p.MavenClient)
+		case *pomxml.Enricher:
+			clients = append(clients, p.Resolver.MavenClient)
+		}
+	}
+	if len(clients) != 2 {
+		t.Fatalf("%v.GetScanConfig() got %d Maven plugins, want 2", flags, len(clients))
+	}
+	for _, c := range clients {
+		// The project is only available from the configured registry.
+		if _, err := c.GetProject(context.Background(), "org.example", "x.y.z", "1.0.0"); err != nil {
+			t.Errorf("GetProject() from the configured registry: %v", err)
+		}
+	}
+}
+
+func TestGetScanConfig_FilesFrom(t *testing.T) {
+	dir := t.TempDir()
+	root := filepath.Join(dir, "root")
+	list := filepath.Join(dir, "changed-files.txt")
+	abs := filepath.Join(root, "abs", "go.mod")
+	content := "app/requirements.txt\n\n  lib/package.json  \n" + abs + "\n"
+	if err := os.WriteFile(list, []byte(content), 0644); err != nil {
+		t.Fatalf("os.WriteFile(%q): %v", list, err)
+	}
+	flags := &cli.Flags{
+		Root:           root,
+		PathsToExtract: []string{filepath.Join(root, "arg.txt")},
+		FilesFrom:      list,
+	}
+
+	cfg, err := flags.GetScanConfig()
+	if err != nil {
+		t.Fatalf("%v.GetScanConfig(): %v", flags, err)
+	}
+	want := []string{
+		filepath.Join(root, "arg.txt"),
+		filepath.Join(root, "app", "requirements.txt"),
+		filepath.Join(root, "lib", "package.json"),
+		abs,
+	}
+	if diff := cmp.Diff(want, cfg.PathsToExtract); diff != "" {
+		t.Errorf("%v.GetScanConfig() returned unexpected PathsToExtract (-want +got):\n%s", flags, diff)
+	}
+}
+
+func TestGetScanConfig_FilesFromMissing(t *testing.T) {
+	flags := &cli.Flags{
+		Root:      t.TempDir(),
+		FilesFrom: filepath.Join(t.TempDir(), "missing.txt"),
+	}
+	if _, err := flags.GetScanConfig(); err == nil {
+		t.Errorf("%v.GetScanConfig() succeeded, want error", flags)
+	}
+}
+
+func TestGetScanConfig_GoBinaryVersionFromContent(t *testing.T) {
+	for _, tc := range []struct {
+		desc                   string
+		flags                  *cli.Flags
+		wantVersionFromContent bool
+	}{
+		{
+			desc: "version_from_content_enabled",
+			flags: &cli.Flags{
+				ExtractorsToRun:            []string{"go"},
+				GoBinaryVersionFromContent: true,
+			},
+			wantVersionFromContent: true,
+		},
+		{
+			desc: "version_from_content_disabled",
+			flags: &cli.Flags{
+				ExtractorsToRun:            []string{"go"},
+				GoBinaryVersionFromContent: false,
+			},
+			wantVersionFromContent: false,
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			cfg, err := tc.flags.GetScanConfig()
+			if err != nil {
+				t.Errorf("%+v.GetScanConfig(): %v", tc.flags, err)
+			}
+			var gobinaryExt *gobinary.Extractor
+			for _, p := range cfg.Plugins {
+				if p.Name() == gobinary.Name {
+					gobinaryExt = p.(*gobinary.Extractor)
+				}
+			}
+			if gobinaryExt == nil {
+				t.Fatalf("%+v.GetScanConfig() want go binary extractor got nil", tc.flags)
+			}
+			if gobinaryExt.VersionFromContent != tc.wantVersionFromContent {
+				t.Errorf("%+v.GetScanConfig() want go binary extractor with version from content %v got %v", tc.flags, tc.wantVersionFromContent, gobinaryExt.VersionFromContent)
+			}
+		})
+	}
+}
+
+func TestGetScanConfig_GoBinaryStrippedBinaries(t *testing.T) {
+	for _, tc := range []struct {
+		desc                 string
+		flags                *cli.Flags
+		wantStrippedBinaries bool
+	}{
+		{
+			desc: "stripped_binaries_enabled",
+			flags: &cli.Flags{
+				ExtractorsToRun:          []string{"go"},
+				GoBinaryStrippedBinaries: true,
+			},
+			wantStrippedBinaries: true,
+		},
+		{
+			desc: "stripped_binaries_disabled",
+			flags: &cli.Flags{
+				ExtractorsToRun: []string{"go"},
+			},
+			wantStrippedBinaries: false,
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			cfg, err := tc.flags.GetScanConfig()
+			if err != nil {
+				t.Errorf("%+v.GetScanConfig(): %v", tc.flags, err)
+			}
+			var gobinaryExt *gobinary.Extractor
+			for _, p := range cfg.Plugins {
+				if p.Name() == gobinary.Name {
+					gobinaryExt = p.(*gobinary.Extractor)
+				}
+			}
+			if gobinaryExt == nil {
+				t.Fatalf("%+v.GetScanConfig() want go binary extractor got nil", tc.flags)
+			}
+			if gobinaryExt.StrippedBinaries != tc.wantStrippedBinaries {
+				t.Errorf("%+v.GetScanConfig() want go binary extractor with stripped binaries %v got %v", tc.flags, tc.wantStrippedBinaries, gobinaryExt.StrippedBinaries)
 			}
 		})
 	}
 }
 
-func TestGetScanConfig_SkipDirRegex(t *testing.T) {
+func TestGetScanConfig_CargoAuditablePanicPaths(t *testing.T) {
 	for _, tc := range []struct {
-		desc             string
-		flags            *cli.Flags
-		wantSkipDirRegex string
-		wantNil          bool
+		desc                  string
+		flags                 *cli.Flags
+		wantPanicPathFallback bool
 	}{
 		{
-			desc: "simple regex",
+			desc: "panic_paths_enabled",
 			flags: &cli.Flags{
-				Root:         "/",
-				SkipDirRegex: "asdf.*foo",
+				ExtractorsToRun:          []string{cargoauditable.Name},
+				CargoAuditablePanicPaths: true,
 			},
-			wantSkipDirRegex: "asdf.*foo",
+			wantPanicPathFallback: true,
 		},
 		{
-			desc: "no regex",
+			desc: "panic_paths_disabled",
 			flags: &cli.Flags{
-				Root: "/",
+				ExtractorsToRun: []string{cargoauditable.Name},
 			},
-			wantNil: true,
+			wantPanicPathFallback: false,
 		},
 	} {
 		t.Run(tc.desc, func(t *testing.T) {
 			cfg, err := tc.flags.GetScanConfig()
 			if err != nil {
-				t.Errorf("%v.GetScanConfig(): %v", tc.flags, err)
+				t.Errorf("%+v.GetScanConfig(): %v", tc.flags, err)
 			}
-			if tc.wantNil && cfg.SkipDirRegex != nil {
-				t.Errorf("%v.GetScanConfig() SkipDirRegex got %q, want nil", tc.flags, cfg.SkipDirRegex)
+			var cargoExt *cargoauditable.Extractor
+			for _, p := range cfg.Plugins {
+				if p.Name() == cargoauditable.Name {
+					cargoExt = p.(*cargoauditable.Extractor)
+				}
 			}
-			if !tc.wantNil && tc.wantSkipDirRegex != cfg.SkipDirRegex.String() {
-				t.Errorf("%v.GetScanConfig() SkipDirRegex got %q, want %q", tc.flags, cfg.SkipDirRegex.String(), tc.wantSkipDirRegex)
+			if cargoExt == nil {
+				t.Fatalf("%+v.GetScanConfig() want cargo auditable extractor got nil", tc.flags)
+			}
+			if cargoExt.PanicPathFallback != tc.wantPanicPathFallback {
+				t.Errorf("%+v.GetScanConfig() want cargo auditable extractor with panic path fallback %v got %v", tc.flags, tc.wantPanicPathFallback, cargoExt.PanicPathFallback)
 			}
 		})
 	}
 }
 
-func TestGetScanConfig_CreatePlugins(t *testing.T) {
+func TestGetScanConfig_MaxFileSize(t *testing.T) {
 	for _, tc := range []struct {
 		desc            string
 		flags           *cli.Flags
-		wantPluginCount int
+		wantMaxFileSize int
 	}{
 		{
-			desc: "Create an extractor",
+			desc: "max file size unset",
 			flags: &cli.Flags{
-				PluginsToRun: []string{"python/wheelegg"},
+				MaxFileSize: 0,
 			},
-			wantPluginCount: 1,
+			wantMaxFileSize: 0,
 		},
 		{
-			desc: "Create an extractor - legacy field",
+			desc: "max file size set",
 			flags: &cli.Flags{
-				ExtractorsToRun: []string{"python/wheelegg"},
+				MaxFileSize: 100,
 			},
-			wantPluginCount: 1,
+			wantMaxFileSize: 100,
 		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			cfg, err := tc.flags.GetScanConfig()
+			if err != nil {
+				t.Errorf("%+v.GetScanConfig(): %v", tc.flags, err)
+			}
+			if cfg.MaxFileSize != tc.wantMaxFileSize {
+				t.Errorf("%+v.GetScanConfig() got max file size %d, want %d", tc.flags, cfg.MaxFileSize, tc.wantMaxFileSize)
+			}
+		})
+	}
+}
+
+func TestGetScanConfig_IOBudget(t *testing.T) {
+	for _, tc := range []struct {
+		desc  string
+		flags *cli.Flags
+		want  *scalibrfs.IOBudget
+	}{
 		{
-			desc: "Create a detector - legacy field",
-			flags: &cli.Flags{
-				PluginsToRun: []string{"cis"},
-			},
-			wantPluginCount: 1,
+			desc:  "no_limits",
+			flags: &cli.Flags{},
+			want:  nil,
 		},
 		{
-			desc: "Create a detector - legacy field",
-			flags: &cli.Flags{
-				DetectorsToRun: []string{"cis"},
-			},
-			wantPluginCount: 1,
+			desc:  "limits_set",
+			flags: &cli.Flags{MaxBytesPerFile: 100, MaxTotalBytes: 1000},
+			want:  &scalibrfs.IOBudget{MaxBytesPerFile: 100, MaxTotalBytes: 1000},
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			cfg, err := tc.flags.GetScanConfig()
+			if err != nil {
+				t.Fatalf("%+v.GetScanConfig(): %v", tc.flags, err)
+			}
+			if diff := cmp.Diff(tc.want, cfg.IOBudget, cmpopts.IgnoreUnexported(scalibrfs.IOBudget{})); diff != "" {
+				t.Errorf("%+v.GetScanConfig() returned unexpected IO budget (-want +got):\n%s", tc.flags, diff)
+			}
+		})
+	}
+}
+
+func TestGetScanConfig_Hashing(t *testing.T) {
+	for _, tc := range []struct {
+		desc  string
+		flags *cli.Flags
+		want  *hashing.Config
+	}{
+		{
+			desc:  "hashing unset",
+			flags: &cli.Flags{},
+			want:  nil,
 		},
 		{
-			desc: "Create an annotator",
-			flags: &cli.Flags{
-				PluginsToRun: []string{"vex/cachedir"},
-			},
-			wantPluginCount: 1,
+			desc:  "FIPS mode with default algorithms",
+			flags: &cli.Flags{FIPSMode: true},
+			want:  &hashing.Config{Algorithms: []hashing.Algorithm{hashing.SHA256}, FIPSMode: true},
+		},
+		{
+			desc:  "custom algorithms",
+			flags: &cli.Flags{HashAlgorithms: []string{"SHA-1", "blake3"}},
+			want:  &hashing.Config{Algorithms: []hashing.Algorithm{hashing.SHA1, hashing.BLAKE3}},
 		},
 	} {
 		t.Run(tc.desc, func(t *testing.T) {
 			cfg, err := tc.flags.GetScanConfig()
 			if err != nil {
-				t.Errorf("%v.GetScanConfig(): %v", tc.flags, err)
+				t.Fatalf("%+v.GetScanConfig(): %v", tc.flags, err)
 			}
-			if len(cfg.Plugins) != tc.wantPluginCount {
-				t.Errorf("%v.GetScanConfig() want plugin count %d got %d", tc.flags, tc.wantPluginCount, len(cfg.Plugins))
+			if diff := cmp.Diff(tc.want, cfg.Hashing); diff != "" {
+				t.Errorf("%+v.GetScanConfig() returned unexpected hashing config (-want +got):\n%s", tc.flags, diff)
 			}
 		})
 	}
 }
 
-func TestGetScanConfig_GovulncheckParams(t *testing.T) {
-	dbPath := "path/to/db"
-	flags := &cli.Flags{
-		ExtractorsToRun:   []string{"go"},
-		DetectorsToRun:    []string{binary.Detector{}.Name()},
-		GovulncheckDBPath: dbPath,
+func TestGetScanConfig_Dedup(t *testing.T) {
+	for _, tc := range []struct {
+		desc  string
+		flags *cli.Flags
+		want  *dedup.Config
+	}{
+		{
+			desc:  "dedup unset",
+			flags: &cli.Flags{},
+			want:  nil,
+		},
+		{
+			desc:  "strategies set",
+			flags: &cli.Flags{DedupStrategies: []string{"Union-Locations", "prefer-lockfile"}},
+			want:  &dedup.Config{Strategies: []dedup.Strategy{dedup.UnionLocations, dedup.PreferLockfile}},
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			cfg, err := tc.flags.GetScanConfig()
+			if err != nil {
+				t.Fatalf("%+v.GetScanConfig(): %v", tc.flags, err)
+			}
+			if diff := cmp.Diff(tc.want, cfg.Dedup); diff != "" {
+				t.Errorf("%+v.GetScanConfig() returned unexpected dedup config (-want +got):\n%s", tc.flags, diff)
+			}
+		})
 	}
+}
 
-	cfg, err := flags.GetScanConfig()
-	if err != nil {
-		t.Errorf("%v.GetScanConfig(): %v", flags, err)
-	}
-	detectors := pl.Detectors(cfg.Plugins)
-	if len(detectors) != 1 {
-		t.Fatalf("%v.GetScanConfig() want 1 detector got %d", flags, len(detectors))
-	}
-	got := detectors[0].(*binary.Detector).OfflineVulnDBPath
-	if got != dbPath {
-		t.Errorf("%v.GetScanConfig() want govulncheck detector with DB path %q got %q", flags, dbPath, got)
+func TestGetScanConfig_Progress(t *testing.T) {
+	for _, tc := range []struct {
+		desc         string
+		flags        *cli.Flags
+		wantReporter bool
+	}{
+		{
+			desc:  "progress unset",
+			flags: &cli.Flags{},
+		},
+		{
+			desc:         "progress set",
+			flags:        &cli.Flags{Progress: true, ExpectedInodes: 1000},
+			wantReporter: true,
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			cfg, err := tc.flags.GetScanConfig()
+			if err != nil {
+				t.Fatalf("%+v.GetScanConfig(): %v", tc.flags, err)
+			}
+			if got := cfg.Progress != nil; got != tc.wantReporter {
+				t.Errorf("%+v.GetScanConfig(): got progress reporter %t, want %t", tc.flags, got, tc.wantReporter)
+			}
+			if cfg.ExpectedInodes != tc.flags.ExpectedInodes {
+				t.Errorf("%+v.GetScanConfig(): got ExpectedInodes %d, want %d", tc.flags, cfg.ExpectedInodes, tc.flags.ExpectedInodes)
+			}
+		})
 	}
 }
 
-func TestGetScanConfig_GoBinaryVersionFromContent(t *testing.T) {
+func TestGetScanConfig_TargetEnv(t *testing.T) {
 	for _, tc := range []struct {
-		desc                   string
-		flags                  *cli.Flags
-		wantVersionFromContent bool
+		desc  string
+		flags *cli.Flags
+		want  *targetenv.Env
 	}{
 		{
-			desc: "version_from_content_enabled",
-			flags: &cli.Flags{
-				ExtractorsToRun:            []string{"go"},
-				GoBinaryVersionFromContent: true,
-			},
-			wantVersionFromContent: true,
+			desc:  "no target",
+			flags: &cli.Flags{},
+			want:  nil,
 		},
 		{
-			desc: "version_from_content_disabled",
+			desc: "target set",
 			flags: &cli.Flags{
-				ExtractorsToRun:            []string{"go"},
-				GoBinaryVersionFromContent: false,
+				TargetOS:            "windows",
+				TargetArch:          "amd64",
+				TargetPythonVersion: "3.11",
+				TargetNodeVersion:   "v20.11.0",
 			},
-			wantVersionFromContent: false,
+			want: &targetenv.Env{OS: "windows", Arch: "amd64", PythonVersion: "3.11", NodeVersion: "20.11.0"},
 		},
 	} {
 		t.Run(tc.desc, func(t *testing.T) {
 			cfg, err := tc.flags.GetScanConfig()
 			if err != nil {
-				t.Errorf("%+v.GetScanConfig(): %v", tc.flags, err)
-			}
-			var gobinaryExt *gobinary.Extractor
-			for _, p := range cfg.Plugins {
-				if p.Name() == gobinary.Name {
-					gobinaryExt = p.(*gobinary.Extractor)
-				}
+				t.Fatalf("%+v.GetScanConfig(): %v", tc.flags, err)
 			}
-			if gobinaryExt == nil {
-				t.Fatalf("%+v.GetScanConfig() want go binary extractor got nil", tc.flags)
-			}
-			if gobinaryExt.VersionFromContent != tc.wantVersionFromContent {
-				t.Errorf("%+v.GetScanConfig() want go binary extractor with version from content %v got %v", tc.flags, tc.wantVersionFromContent, gobinaryExt.VersionFromContent)
+			if diff := cmp.Diff(tc.want, cfg.TargetEnv); diff != "" {
+				t.Errorf("%+v.GetScanConfig(): unexpected TargetEnv (-want +got):\n%s", tc.flags, diff)
 			}
 		})
 	}
 }
 
-func TestGetScanConfig_MaxFileSize(t *testing.T) {
+func TestGetScanConfig_PluginTimeouts(t *testing.T) {
 	for _, tc := range []struct {
-		desc            string
-		flags           *cli.Flags
-		wantMaxFileSize int
+		desc  string
+		flags *cli.Flags
+		want  *plugin.Timeouts
 	}{
 		{
-			desc: "max file size unset",
-			flags: &cli.Flags{
-				MaxFileSize: 0,
-			},
-			wantMaxFileSize: 0,
+			desc:  "no timeouts",
+			flags: &cli.Flags{},
+			want:  nil,
 		},
 		{
-			desc: "max file size set",
+			desc: "default and overrides",
 			flags: &cli.Flags{
-				MaxFileSize: 100,
+				PluginTimeout:          30 * time.Second,
+				PluginTimeoutOverrides: []string{"python/wheelegg=2m", "govulncheck/binary=0"},
+			},
+			want: &plugin.Timeouts{
+				Default:   30 * time.Second,
+				PerPlugin: map[string]time.Duration{"python/wheelegg": 2 * time.Minute, "govulncheck/binary": 0},
 			},
-			wantMaxFileSize: 100,
 		},
 	} {
 		t.Run(tc.desc, func(t *testing.T) {
 			cfg, err := tc.flags.GetScanConfig()
 			if err != nil {
-				t.Errorf("%+v.GetScanConfig(): %v", tc.flags, err)
+				t.Fatalf("%+v.GetScanConfig(): %v", tc.flags, err)
 			}
-			if cfg.MaxFileSize != tc.wantMaxFileSize {
-				t.Errorf("%+v.GetScanConfig() got max file size %d, want %d", tc.flags, cfg.MaxFileSize, tc.wantMaxFileSize)
+			if diff := cmp.Diff(tc.want, cfg.PluginTimeouts); diff != "" {
+				t.Errorf("%+v.GetScanConfig(): unexpected PluginTimeouts (-want +got):\n%s", tc.flags, diff)
 			}
 		})
 	}
@@ -711,6 +1549,39 @@ func TestGetScanConfig_PluginGroups(t *testing.T) {
 				"vex/cachedir",
 			},
 		},
+		{
+			desc: "ecosystems",
+			flags: &cli.Flags{
+				Ecosystems: []string{"npm,debian"},
+			},
+			wantPlugins: []string{
+				"javascript/packagelockjson",
+				"misc/from-npm",
+				"os/dpkg",
+				"vex/os-duplicate/dpkg",
+			},
+			dontWantPlugins: []string{
+				// Other ecosystems
+				"python/wheelegg",
+				"os/rpm",
+				// Not enabled by default if ecosystems are set
+				"vex/cachedir",
+			},
+		},
+		{
+			desc: "ecosystems_and_plugins",
+			flags: &cli.Flags{
+				PluginsToRun: []string{"vex/cachedir"},
+				Ecosystems:   []string{"PyPI"},
+			},
+			wantPlugins: []string{
+				"python/wheelegg",
+				"vex/cachedir",
+			},
+			dontWantPlugins: []string{
+				"javascript/packagelockjson",
+			},
+		},
 		{
 			desc: "all_extractors",
 			flags: &cli.Flags{
@@ -830,6 +1701,48 @@ func TestGetScanConfig_PluginGroups(t *testing.T) {
 	}
 }
 
+func TestGetScanConfig_Baseline(t *testing.T) {
+	path := "path/to/baseline.json"
+	flags := &cli.Flags{
+		PluginsToRun: []string{baseline.Name},
+		Baseline:     path,
+	}
+
+	cfg, err := flags.GetScanConfig()
+	if err != nil {
+		t.Fatalf("%v.GetScanConfig(): %v", flags, err)
+	}
+	enrichers := pl.Enrichers(cfg.Plugins)
+	if len(enrichers) != 1 {
+		t.Fatalf("%v.GetScanConfig() want 1 enricher got %d", flags, len(enrichers))
+	}
+	if got := enrichers[0].(*baseline.Enricher).Path; got != path {
+		t.Errorf("%v.GetScanConfig() want baseline enricher with path %q got %q", flags, path, got)
+	}
+}
+
+func TestWriteBaseline(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "baseline.json")
+	result := &scalibr.ScanResult{
+		Inventory: inventory.Inventory{
+			PackageVulns: []*inventory.PackageVuln{{Vulnerability: osvschema.Vulnerability{ID: "CVE-1"}}},
+		},
+	}
+	flags := &cli.Flags{WriteBaselineFile: path}
+	if err := flags.WriteBaseline(result); err != nil {
+		t.Fatalf("%v.WriteBaseline(%v): %v", flags, result, err)
+	}
+
+	b, err := scalibrbaseline.ReadFile(path)
+	if err != nil {
+		t.Fatalf("baseline.ReadFile(%q): %v", path, err)
+	}
+	inv := result.Inventory
+	if got := b.Filter(&inv); got != 1 {
+		t.Errorf("baseline written by %v.WriteBaseline() filtered %d findings, want 1", flags, got)
+	}
+}
+
 func TestWriteScanResults(t *testing.T) {
 	testDirPath := t.TempDir()
 	result := &scalibr.ScanResult{
@@ -874,6 +1787,14 @@ func TestWriteScanResults(t *testing.T) {
 			wantFilename:      "result.cyclonedx.json",
 			wantContentPrefix: "{\n  \"$schema\": \"http://cyclonedx.org/schema/bom-1.6.schema.json\"",
 		},
+		{
+			desc: "Create SARIF",
+			flags: &cli.Flags{
+				Output: []string{"sarif=" + filepath.Join(testDirPath, "result.sarif")},
+			},
+			wantFilename:      "result.sarif",
+			wantContentPrefix: "{\n  \"$schema\": \"https://json.schemastore.org/sarif-2.1.0.json\"",
+		},
 	} {
 		t.Run(tc.desc, func(t *testing.T) {
 			if err := tc.flags.WriteScanResults(result); err != nil {
@@ -893,3 +1814,58 @@ func TestWriteScanResults(t *testing.T) {
 		})
 	}
 }
+
+func TestWriteDependencyGraphs(t *testing.T) {
+	root := &scalibrfs.ScanRoot{
+		Path: "/root",
+		FS: fstest.MapFS{
+			"web/go.mod": {Data: []byte("module example.com/web\n\nrequire example.com/lib v1.0.0\n")},
+		},
+	}
+	result := &scalibr.ScanResult{
+		Status: &plugin.ScanStatus{Status: plugin.ScanStatusSucceeded},
+	}
+	result.Inventory.Packages = []*extractor.Package{
+		{Name: "example.com/lib", Version: "1.0.0", Locations: []string{"web/go.mod"}},
+		{Name: "missing", Version: "1.0.0", Locations: []string{"missing/go.mod"}},
+	}
+
+	for _, tc := range []struct {
+		format       string
+		wantFilename string
+		wantContent  string
+	}{
+		{
+			format:       "",
+			wantFilename: "web_go.mod.dot",
+			wantContent:  "\"example.com/web\" -> \"example.com/lib@v1.0.0\";",
+		},
+		{
+			format:       "json",
+			wantFilename: "web_go.mod.json",
+			wantContent:  "\"application\": \"web/go.mod\"",
+		},
+	} {
+		t.Run(tc.wantFilename, func(t *testing.T) {
+			flags := &cli.Flags{DepGraphDir: t.TempDir(), DepGraphFormat: tc.format}
+			if err := flags.WriteDependencyGraphs(result, []*scalibrfs.ScanRoot{root}); err != nil {
+				t.Fatalf("%v.WriteDependencyGraphs(): %v", flags, err)
+			}
+
+			files, err := os.ReadDir(flags.DepGraphDir)
+			if err != nil {
+				t.Fatalf("os.ReadDir(%s): %v", flags.DepGraphDir, err)
+			}
+			if len(files) != 1 || files[0].Name() != tc.wantFilename {
+				t.Fatalf("%v.WriteDependencyGraphs() wrote %v, want only %s", flags, files, tc.wantFilename)
+			}
+			got, err := os.ReadFile(filepath.Join(flags.DepGraphDir, tc.wantFilename))
+			if err != nil {
+				t.Fatalf("os.ReadFile(%s): %v", tc.wantFilename, err)
+			}
+			if !strings.Contains(string(got), tc.wantContent) {
+				t.Errorf("%v.WriteDependencyGraphs() wrote %q, want it to contain %q", flags, got, tc.wantContent)
+			}
+		})
+	}
+}
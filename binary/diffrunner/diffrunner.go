@@ -0,0 +1,302 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package diffrunner provides the main function for comparing two scan results
+// with the SCALIBR binary.
+package diffrunner
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/google/osv-scalibr/binary/proto"
+	spb "github.com/google/osv-scalibr/binary/proto/scan_result_go_proto"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/log"
+	"github.com/google/osv-scalibr/result/diff"
+)
+
+// Exit codes of the diff subcommand.
+const (
+	// ExitCodeSuccess means that the results were compared successfully and
+	// either no new findings were introduced or --fail-on-new-findings isn't set.
+	ExitCodeSuccess = 0
+	// ExitCodeFatal means that the scan results couldn't be read.
+	ExitCodeFatal = 1
+	// ExitCodeNewFindings means that --fail-on-new-findings is set and the head
+	// scan result contains findings that weren't in the base.
+	ExitCodeNewFindings = 2
+)
+
+// Output formats.
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+)
+
+// Flags contains the command line flags of the diff subcommand.
+type Flags struct {
+	// Path of the scan result to compare against, e.g. from the base commit.
+	Base string
+	// Path of the new scan result, e.g. from the head commit.
+	Head string
+	// Output format, "text" or "json".
+	Format string
+	// Whether to exit with ExitCodeNewFindings if new findings were introduced.
+	FailOnNewFindings bool
+}
+
+// ValidateFlags validates the passed command line flags.
+func ValidateFlags(flags *Flags) error {
+	if flags.Base == "" || flags.Head == "" {
+		return errors.New("--base and --head must both be set")
+	}
+	switch flags.Format {
+	case "", FormatText, FormatJSON:
+	default:
+		return fmt.Errorf("unknown --format %q, must be %q or %q", flags.Format, FormatText, FormatJSON)
+	}
+	return nil
+}
+
+// RunDiff compares the scan results specified in the flags, prints the
+// differences to stdout and returns the exit code passed to os.Exit() in the
+// main binary.
+func RunDiff(flags *Flags) int {
+	return runDiff(flags, os.Stdout)
+}
+
+func runDiff(flags *Flags, out io.Writer) int {
+	if err := ValidateFlags(flags); err != nil {
+		log.Errorf("Error validating flags: %v", err)
+		return ExitCodeFatal
+	}
+	base, err := readInventory(flags.Base)
+	if err != nil {
+		log.Errorf("Failed to read base scan result: %v", err)
+		return ExitCodeFatal
+	}
+	head, err := readInventory(flags.Head)
+	if err != nil {
+		log.Errorf("Failed to read head scan result: %v", err)
+		return ExitCodeFatal
+	}
+
+	r := diff.Compare(base, head)
+	if flags.Format == FormatJSON {
+		err = writeJSON(out, r)
+	} else {
+		err = writeText(out, r)
+	}
+	if err != nil {
+		log.Errorf("Failed to write diff: %v", err)
+		return ExitCodeFatal
+	}
+
+	if flags.FailOnNewFindings && (len(r.NewFindings) > 0 || len(r.NewPackageVulns) > 0) {
+		return ExitCodeNewFindings
+	}
+	return ExitCodeSuccess
+}
+
+func readInventory(path string) (*inventory.Inventory, error) {
+	result := &spb.ScanResult{}
+	if err := proto.Read(path, result); err != nil {
+		return nil, err
+	}
+	inv := proto.InventoryToStruct(result.GetInventory())
+	if inv == nil {
+		return &inventory.Inventory{}, nil
+	}
+	return inv, nil
+}
+
+func writeText(w io.Writer, r *diff.Result) error {
+	if r.IsEmpty() {
+		_, err := fmt.Fprintln(w, "No differences found")
+		return err
+	}
+	var lines []string
+	for _, p := range r.AddedPackages {
+		lines = append(lines, fmt.Sprintf("+ %s", pkgString(p)))
+	}
+	for _, p := range r.RemovedPackages {
+		lines = append(lines, fmt.Sprintf("- %s", pkgString(p)))
+	}
+	for _, c := range r.ChangedPackages {
+		lines = append(lines, fmt.Sprintf("~ %s: %s -> %s (%s)", pkgString(c.Head), c.Base.Version, c.Head.Version, c.Type))
+	}
+	for _, f := range r.NewFindings {
+		lines = append(lines, "new finding: "+findingString(f))
+	}
+	for _, f := range r.FixedFindings {
+		lines = append(lines, "fixed finding: "+findingString(f))
+	}
+	for _, v := range r.NewPackageVulns {
+		lines = append(lines, fmt.Sprintf("new vulnerability: %s in %s", v.ID, pkgString(v.Package)))
+	}
+	for _, v := range r.FixedPackageVulns {
+		lines = append(lines, fmt.Sprintf("fixed vulnerability: %s in %s", v.ID, pkgString(v.Package)))
+	}
+	for _, l := range lines {
+		if _, err := fmt.Fprintln(w, l); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func pkgString(p *extractor.Package) string {
+	if p == nil {
+		return "<unknown package>"
+	}
+	s := p.Name
+	if p.Version != "" {
+		s += "@" + p.Version
+	}
+	if len(p.Locations) > 0 {
+		s += " (" + p.Locations[0] + ")"
+	}
+	return s
+}
+
+func findingString(f *inventory.GenericFinding) string {
+	var s string
+	if f.Adv != nil {
+		if f.Adv.ID != nil {
+			s = f.Adv.ID.Publisher + "/" + f.Adv.ID.Reference
+		}
+		if f.Adv.Title != "" {
+			s += ": " + f.Adv.Title
+		}
+	}
+	if f.Target != nil && f.Target.Extra != "" {
+		s += " (" + f.Target.Extra + ")"
+	}
+	return s
+}
+
+// jsonPackage is the JSON representation of a package in the diff output.
+type jsonPackage struct {
+	Name     string `json:"name"`
+	Version  string `json:"version,omitempty"`
+	PURL     string `json:"purl,omitempty"`
+	Location string `json:"location,omitempty"`
+}
+
+type jsonChange struct {
+	jsonPackage
+
+	BaseVersion string `json:"base_version"`
+	Type        string `json:"type"`
+}
+
+type jsonFinding struct {
+	Publisher string `json:"publisher,omitempty"`
+	Reference string `json:"reference,omitempty"`
+	Title     string `json:"title,omitempty"`
+	Extra     string `json:"extra,omitempty"`
+}
+
+type jsonVuln struct {
+	ID      string       `json:"id"`
+	Package *jsonPackage `json:"package,omitempty"`
+}
+
+type jsonResult struct {
+	AddedPackages     []*jsonPackage `json:"added_packages"`
+	RemovedPackages   []*jsonPackage `json:"removed_packages"`
+	ChangedPackages   []*jsonChange  `json:"changed_packages"`
+	NewFindings       []*jsonFinding `json:"new_findings"`
+	FixedFindings     []*jsonFinding `json:"fixed_findings"`
+	NewPackageVulns   []*jsonVuln    `json:"new_package_vulns"`
+	FixedPackageVulns []*jsonVuln    `json:"fixed_package_vulns"`
+}
+
+func writeJSON(w io.Writer, r *diff.Result) error {
+	res := &jsonResult{
+		AddedPackages:     []*jsonPackage{},
+		RemovedPackages:   []*jsonPackage{},
+		ChangedPackages:   []*jsonChange{},
+		NewFindings:       []*jsonFinding{},
+		FixedFindings:     []*jsonFinding{},
+		NewPackageVulns:   []*jsonVuln{},
+		FixedPackageVulns: []*jsonVuln{},
+	}
+	for _, p := range r.AddedPackages {
+		res.AddedPackages = append(res.AddedPackages, toJSONPackage(p))
+	}
+	for _, p := range r.RemovedPackages {
+		res.RemovedPackages = append(res.RemovedPackages, toJSONPackage(p))
+	}
+	for _, c := range r.ChangedPackages {
+		res.ChangedPackages = append(res.ChangedPackages, &jsonChange{
+			jsonPackage: *toJSONPackage(c.Head),
+			BaseVersion: c.Base.Version,
+			Type:        string(c.Type),
+		})
+	}
+	for _, f := range r.NewFindings {
+		res.NewFindings = append(res.NewFindings, toJSONFinding(f))
+	}
+	for _, f := range r.FixedFindings {
+		res.FixedFindings = append(res.FixedFindings, toJSONFinding(f))
+	}
+	for _, v := range r.NewPackageVulns {
+		res.NewPackageVulns = append(res.NewPackageVulns, toJSONVuln(v))
+	}
+	for _, v := range r.FixedPackageVulns {
+		res.FixedPackageVulns = append(res.FixedPackageVulns, toJSONVuln(v))
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(res)
+}
+
+func toJSONPackage(p *extractor.Package) *jsonPackage {
+	if p == nil {
+		return nil
+	}
+	jp := &jsonPackage{Name: p.Name, Version: p.Version}
+	if purl := p.PURL(); purl != nil {
+		jp.PURL = purl.String()
+	}
+	if len(p.Locations) > 0 {
+		jp.Location = p.Locations[0]
+	}
+	return jp
+}
+
+func toJSONFinding(f *inventory.GenericFinding) *jsonFinding {
+	jf := &jsonFinding{}
+	if f.Adv != nil {
+		jf.Title = f.Adv.Title
+		if f.Adv.ID != nil {
+			jf.Publisher = f.Adv.ID.Publisher
+			jf.Reference = f.Adv.ID.Reference
+		}
+	}
+	if f.Target != nil {
+		jf.Extra = f.Target.Extra
+	}
+	return jf
+}
+
+func toJSONVuln(v *inventory.PackageVuln) *jsonVuln {
+	return &jsonVuln{ID: v.ID, Package: toJSONPackage(v.Package)}
+}
@@ -0,0 +1,163 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diffrunner
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/osv-scalibr/binary/proto"
+	spb "github.com/google/osv-scalibr/binary/proto/scan_result_go_proto"
+)
+
+func npmPkg(name, version string) *spb.Package {
+	return &spb.Package{
+		Name:      name,
+		Version:   version,
+		Purl:      &spb.Purl{Type: "npm", Name: name, Version: version},
+		Locations: []string{"package-lock.json"},
+	}
+}
+
+func writeResult(t *testing.T, path string, pkgs []*spb.Package, findings []*spb.GenericFinding) {
+	t.Helper()
+	result := &spb.ScanResult{Inventory: &spb.Inventory{Packages: pkgs, GenericFindings: findings}}
+	if err := proto.Write(path, result); err != nil {
+		t.Fatalf("proto.Write(%s): %v", path, err)
+	}
+}
+
+func TestRunDiff(t *testing.T) {
+	dir := t.TempDir()
+	base := filepath.Join(dir, "base.textproto")
+	head := filepath.Join(dir, "head.binproto.gz")
+	weakCreds := &spb.GenericFinding{
+		Adv: &spb.GenericFindingAdvisory{
+			Id:    &spb.AdvisoryId{Publisher: "SCALIBR", Reference: "weak-credentials"},
+			Title: "Weak credentials",
+		},
+		Target: &spb.GenericFindingTargetDetails{Extra: "user root"},
+	}
+	writeResult(t, base, []*spb.Package{npmPkg("lodash", "4.17.20"), npmPkg("left-pad", "1.3.0")}, nil)
+	writeResult(t, head, []*spb.Package{npmPkg("lodash", "4.17.21"), npmPkg("chalk", "5.3.0")}, []*spb.GenericFinding{weakCreds})
+
+	testCases := []struct {
+		desc       string
+		flags      *Flags
+		wantCode   int
+		wantOutput string
+	}{
+		{
+			desc:     "text",
+			flags:    &Flags{Base: base, Head: head},
+			wantCode: ExitCodeSuccess,
+			wantOutput: `+ chalk@5.3.0 (package-lock.json)
+- left-pad@1.3.0 (package-lock.json)
+~ lodash@4.17.21 (package-lock.json): 4.17.20 -> 4.17.21 (upgraded)
+new finding: SCALIBR/weak-credentials: Weak credentials (user root)
+`,
+		},
+		{
+			desc:       "no_differences",
+			flags:      &Flags{Base: base, Head: base, FailOnNewFindings: true},
+			wantCode:   ExitCodeSuccess,
+			wantOutput: "No differences found\n",
+		},
+		{
+			desc:     "fail_on_new_findings",
+			flags:    &Flags{Base: base, Head: head, Format: FormatJSON, FailOnNewFindings: true},
+			wantCode: ExitCodeNewFindings,
+			wantOutput: `{
+  "added_packages": [
+    {
+      "name": "chalk",
+      "version": "5.3.0",
+      "purl": "pkg:npm/chalk@5.3.0",
+      "location": "package-lock.json"
+    }
+  ],
+  "removed_packages": [
+    {
+      "name": "left-pad",
+      "version": "1.3.0",
+      "purl": "pkg:npm/left-pad@1.3.0",
+      "location": "package-lock.json"
+    }
+  ],
+  "changed_packages": [
+    {
+      "name": "lodash",
+      "version": "4.17.21",
+      "purl": "pkg:npm/lodash@4.17.21",
+      "location": "package-lock.json",
+      "base_version": "4.17.20",
+      "type": "upgraded"
+    }
+  ],
+  "new_findings": [
+    {
+      "publisher": "SCALIBR",
+      "reference": "weak-credentials",
+      "title": "Weak credentials",
+      "extra": "user root"
+    }
+  ],
+  "fixed_findings": [],
+  "new_package_vulns": [],
+  "fixed_package_vulns": []
+}
+`,
+		},
+		{
+			desc:     "fixed_findings_dont_fail",
+			flags:    &Flags{Base: head, Head: base, FailOnNewFindings: true},
+			wantCode: ExitCodeSuccess,
+			wantOutput: `+ left-pad@1.3.0 (package-lock.json)
+- chalk@5.3.0 (package-lock.json)
+~ lodash@4.17.20 (package-lock.json): 4.17.21 -> 4.17.20 (downgraded)
+fixed finding: SCALIBR/weak-credentials: Weak credentials (user root)
+`,
+		},
+		{
+			desc:     "missing_head",
+			flags:    &Flags{Base: base},
+			wantCode: ExitCodeFatal,
+		},
+		{
+			desc:     "nonexistent_file",
+			flags:    &Flags{Base: base, Head: filepath.Join(dir, "missing.textproto")},
+			wantCode: ExitCodeFatal,
+		},
+		{
+			desc:     "unknown_format",
+			flags:    &Flags{Base: base, Head: head, Format: "xml"},
+			wantCode: ExitCodeFatal,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			var out bytes.Buffer
+			if got := runDiff(tc.flags, &out); got != tc.wantCode {
+				t.Errorf("runDiff(%+v) returned exit code %d, want %d", tc.flags, got, tc.wantCode)
+			}
+			if diff := cmp.Diff(tc.wantOutput, out.String()); diff != "" {
+				t.Errorf("runDiff(%+v) returned unexpected output (-want +got):\n%s", tc.flags, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,168 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package enrichrunner provides the main function for running enrichers on a
+// previously saved scan result with the SCALIBR binary.
+package enrichrunner
+
+import (
+	"context"
+	"errors"
+
+	scalibr "github.com/google/osv-scalibr"
+	"github.com/google/osv-scalibr/binary/cli"
+	"github.com/google/osv-scalibr/binary/proto"
+	spb "github.com/google/osv-scalibr/binary/proto/scan_result_go_proto"
+	"github.com/google/osv-scalibr/log"
+	"github.com/google/osv-scalibr/plugin"
+	pl "github.com/google/osv-scalibr/plugin/list"
+)
+
+// Exit codes of the enrich subcommand.
+const (
+	// ExitCodeSuccess means that the enrichers ran successfully.
+	ExitCodeSuccess = 0
+	// ExitCodeFatal means that the input couldn't be read, the enrichers failed
+	// or the output couldn't be written.
+	ExitCodeFatal = 1
+)
+
+// Flags contains the command line flags of the enrich subcommand.
+type Flags struct {
+	// Path of the scan result to enrich, e.g. one created by "scalibr extract".
+	Input string
+	// Path of the enriched output scan result file.
+	ResultFile string
+	// Paths of the enriched scan results in various formats, e.g. spdx23-json=result.spdx.json
+	Output []string
+	// Names of the enrichers or enricher groups to run.
+	PluginsToRun []string
+	// Root of the scanned filesystem, for enrichers that need to access the
+	// files of the scanned artifact. Leave empty if it's not available anymore.
+	Root string
+	// Whether the enrichers must not access the network, e.g. to only use
+	// offline vulnerability databases.
+	Offline bool
+	// Path of a local registry mirror used by the enrichers that resolve
+	// dependencies.
+	LocalRegistry string
+	// URL of a Maven registry used instead of Maven Central, e.g. a mirror.
+	MavenRegistry string
+	// Path of a local OSV database export used by the vulnmatch/osvlocal
+	// enricher.
+	OSVDBPath string
+	// Path of a suppression rules file used by the vex/suppression enricher.
+	SuppressionsFile string
+	// Paths of OpenVEX documents used by the vex/suppression enricher.
+	OpenVEXFiles []string
+	// Path of a baseline file used by the vex/baseline enricher.
+	Baseline string
+	Verbose  bool
+}
+
+// ValidateFlags validates the passed command line flags.
+func ValidateFlags(flags *Flags) error {
+	if flags.Input == "" {
+		return errors.New("--input must be set")
+	}
+	if len(flags.PluginsToRun) == 0 {
+		return errors.New("--plugins must be set")
+	}
+	if err := proto.ValidExtension(flags.Input); err != nil {
+		return err
+	}
+	if err := cli.ValidateFlags(flags.cliFlags()); err != nil {
+		return err
+	}
+	return nil
+}
+
+// cliFlags returns the scan flags used to configure the enrichers and write the
+// enriched scan result.
+func (f *Flags) cliFlags() *cli.Flags {
+	return &cli.Flags{
+		ResultFile:       f.ResultFile,
+		Output:           f.Output,
+		PluginsToRun:     f.PluginsToRun,
+		Root:             f.Root,
+		Offline:          f.Offline,
+		LocalRegistry:    f.LocalRegistry,
+		MavenRegistry:    f.MavenRegistry,
+		OSVDBPath:        f.OSVDBPath,
+		SuppressionsFile: f.SuppressionsFile,
+		OpenVEXFiles:     f.OpenVEXFiles,
+		Baseline:         f.Baseline,
+	}
+}
+
+// RunEnrich runs the enrichers specified in the flags on the input scan result,
+// writes the enriched result and returns the exit code passed to os.Exit() in
+// the main binary.
+func RunEnrich(flags *Flags) int {
+	if flags.Verbose {
+		log.SetLogger(&log.DefaultLogger{Verbose: true})
+	}
+	if err := ValidateFlags(flags); err != nil {
+		log.Errorf("Error validating flags: %v", err)
+		return ExitCodeFatal
+	}
+
+	resultProto := &spb.ScanResult{}
+	if err := proto.Read(flags.Input, resultProto); err != nil {
+		log.Errorf("Failed to read scan result: %v", err)
+		return ExitCodeFatal
+	}
+	result := proto.ScanResultToStruct(resultProto)
+
+	cliFlags := flags.cliFlags()
+	cfg, err := cliFlags.GetScanConfig()
+	if err != nil {
+		log.Errorf("%v.GetScanConfig(): %v", cliFlags, err)
+		return ExitCodeFatal
+	}
+	enrichers := pl.Enrichers(cfg.Plugins)
+	if len(enrichers) == 0 {
+		log.Errorf("No enrichers found in --plugins %v", flags.PluginsToRun)
+		return ExitCodeFatal
+	}
+	cfg.Plugins = make([]plugin.Plugin, 0, len(enrichers))
+	for _, e := range enrichers {
+		cfg.Plugins = append(cfg.Plugins, e)
+	}
+	if flags.Root == "" {
+		// The scanned files aren't available anymore.
+		cfg.ScanRoots = nil
+		cfg.Capabilities.DirectFS = false
+	}
+
+	log.Infof("Running %d enrichers on %s", len(enrichers), flags.Input)
+	extractionStatus := result.Status
+	result = scalibr.New().Enrich(context.Background(), result, cfg)
+	// Enrich only replaces the overall status if the enrichers couldn't be run.
+	if result.Status != extractionStatus {
+		log.Errorf("Enrichment failed: %s", result.Status.FailureReason)
+		return ExitCodeFatal
+	}
+	for _, s := range result.PluginStatus {
+		if s.Status.Status != plugin.ScanStatusSucceeded {
+			log.Warnf("Plugin %q failed: %s", s.Name, s.Status.FailureReason)
+		}
+	}
+
+	if err := cliFlags.WriteScanResults(result); err != nil {
+		log.Errorf("Error writing scan results: %v", err)
+		return ExitCodeFatal
+	}
+	return ExitCodeSuccess
+}
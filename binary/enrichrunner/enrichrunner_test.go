@@ -0,0 +1,155 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enrichrunner
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/osv-scalibr/binary/proto"
+	spb "github.com/google/osv-scalibr/binary/proto/scan_result_go_proto"
+	"google.golang.org/protobuf/testing/protocmp"
+)
+
+func TestRunEnrich(t *testing.T) {
+	dir := t.TempDir()
+	input := filepath.Join(dir, "extracted.textproto")
+	lodash := &spb.Package{
+		Name:      "lodash",
+		Version:   "4.17.20",
+		Purl:      &spb.Purl{Purl: "pkg:npm/lodash@4.17.20", Type: "npm", Name: "lodash", Version: "4.17.20"},
+		Ecosystem: "npm",
+		Locations: []string{"package-lock.json"},
+		Plugins:   []string{"javascript/packagelockjson"},
+		// Set by the proto conversion for backwards compatibility.
+		ExtractorDeprecated: "javascript/packagelockjson",
+	}
+	extracted := &spb.ScanResult{
+		Status: &spb.ScanStatus{Status: spb.ScanStatus_SUCCEEDED},
+		PluginStatus: []*spb.PluginStatus{
+			{Name: "javascript/packagelockjson", Version: 1, Status: &spb.ScanStatus{Status: spb.ScanStatus_SUCCEEDED}},
+		},
+		Inventory: &spb.Inventory{Packages: []*spb.Package{lodash}},
+	}
+	if err := proto.Write(input, extracted); err != nil {
+		t.Fatalf("proto.Write(%s): %v", input, err)
+	}
+
+	testCases := []struct {
+		desc     string
+		flags    *Flags
+		wantCode int
+		want     *spb.ScanResult
+	}{
+		{
+			desc:     "enrichers_run",
+			flags:    &Flags{Input: input, PluginsToRun: []string{"remediation/fixedversion"}, Offline: true},
+			wantCode: ExitCodeSuccess,
+			want: &spb.ScanResult{
+				Status: &spb.ScanStatus{Status: spb.ScanStatus_SUCCEEDED},
+				PluginStatus: []*spb.PluginStatus{
+					{Name: "javascript/packagelockjson", Version: 1, Status: &spb.ScanStatus{Status: spb.ScanStatus_SUCCEEDED}},
+					{Name: "remediation/fixedversion", Status: &spb.ScanStatus{Status: spb.ScanStatus_SUCCEEDED}},
+				},
+				Inventory: &spb.Inventory{Packages: []*spb.Package{lodash}},
+			},
+		},
+		{
+			desc:     "no_enrichers",
+			flags:    &Flags{Input: input, PluginsToRun: []string{"python/wheelegg"}},
+			wantCode: ExitCodeFatal,
+		},
+		{
+			desc:     "missing_input",
+			flags:    &Flags{Input: filepath.Join(dir, "missing.textproto"), PluginsToRun: []string{"remediation/fixedversion"}},
+			wantCode: ExitCodeFatal,
+		},
+		{
+			desc:     "invalid_flags",
+			flags:    &Flags{PluginsToRun: []string{"remediation/fixedversion"}},
+			wantCode: ExitCodeFatal,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			output := filepath.Join(t.TempDir(), "enriched.textproto")
+			tc.flags.ResultFile = output
+			if got := RunEnrich(tc.flags); got != tc.wantCode {
+				t.Fatalf("RunEnrich(%+v) returned exit code %d, want %d", tc.flags, got, tc.wantCode)
+			}
+			if tc.want == nil {
+				return
+			}
+			got := &spb.ScanResult{}
+			if err := proto.Read(output, got); err != nil {
+				t.Fatalf("proto.Read(%s): %v", output, err)
+			}
+			opts := []cmp.Option{
+				protocmp.Transform(),
+				protocmp.IgnoreFields(&spb.ScanResult{}, "start_time", "end_time", "inventories_deprecated"),
+				protocmp.IgnoreFields(&spb.Package{}, "fingerprint"),
+				cmpopts.EquateEmpty(),
+			}
+			if diff := cmp.Diff(tc.want, got, opts...); diff != "" {
+				t.Errorf("RunEnrich(%+v) wrote unexpected result (-want +got):\n%s", tc.flags, diff)
+			}
+		})
+	}
+}
+
+func TestValidateFlags(t *testing.T) {
+	testCases := []struct {
+		desc    string
+		flags   *Flags
+		wantErr bool
+	}{
+		{
+			desc:  "valid",
+			flags: &Flags{Input: "in.textproto", ResultFile: "out.textproto", PluginsToRun: []string{"remediation/fixedversion"}},
+		},
+		{
+			desc:    "no_input",
+			flags:   &Flags{ResultFile: "out.textproto", PluginsToRun: []string{"remediation/fixedversion"}},
+			wantErr: true,
+		},
+		{
+			desc:    "no_plugins",
+			flags:   &Flags{Input: "in.textproto", ResultFile: "out.textproto"},
+			wantErr: true,
+		},
+		{
+			desc:    "no_output",
+			flags:   &Flags{Input: "in.textproto", PluginsToRun: []string{"remediation/fixedversion"}},
+			wantErr: true,
+		},
+		{
+			desc:    "invalid_input_extension",
+			flags:   &Flags{Input: "in.json", ResultFile: "out.textproto", PluginsToRun: []string{"remediation/fixedversion"}},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := ValidateFlags(tc.flags)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("ValidateFlags(%+v) returned error %v, want error: %t", tc.flags, err, tc.wantErr)
+			}
+		})
+	}
+}
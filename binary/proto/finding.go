@@ -18,6 +18,7 @@ import (
 	"errors"
 
 	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/inventory/fingerprint"
 	"github.com/google/osv-scalibr/inventory/vex"
 
 	spb "github.com/google/osv-scalibr/binary/proto/scan_result_go_proto"
@@ -94,9 +95,28 @@ func GenericFindingToProto(f *inventory.GenericFinding) (*spb.GenericFinding, er
 		Target:                target,
 		Plugins:               f.Plugins,
 		ExploitabilitySignals: exps,
+		Remediation:           RemediationToProto(f.Remediation),
+		Fingerprint:           fingerprint.GenericFinding(f),
 	}, nil
 }
 
+// RemediationToProto converts a Remediation go struct into the equivalent proto.
+func RemediationToProto(r *inventory.Remediation) *spb.Remediation {
+	if r == nil {
+		return nil
+	}
+	var path []*spb.UpgradeStep
+	for _, s := range r.UpgradePath {
+		path = append(path, &spb.UpgradeStep{Name: s.Name, Version: s.Version})
+	}
+	return &spb.Remediation{
+		FixedVersion: r.FixedVersion,
+		Backported:   r.Backported,
+		UpgradePath:  path,
+		PatchUrls:    r.PatchURLs,
+	}
+}
+
 // --- Proto to Struct
 
 // GenericFindingToStruct converts a GenericFinding proto into the equivalent go struct.
@@ -135,5 +155,23 @@ func GenericFindingToStruct(f *spb.GenericFinding) (*inventory.GenericFinding, e
 		Target:                target,
 		Plugins:               f.GetPlugins(),
 		ExploitabilitySignals: exps,
+		Remediation:           RemediationToStruct(f.GetRemediation()),
 	}, nil
 }
+
+// RemediationToStruct converts a Remediation proto into the equivalent go struct.
+func RemediationToStruct(r *spb.Remediation) *inventory.Remediation {
+	if r == nil {
+		return nil
+	}
+	var path []*inventory.UpgradeStep
+	for _, s := range r.GetUpgradePath() {
+		path = append(path, &inventory.UpgradeStep{Name: s.GetName(), Version: s.GetVersion()})
+	}
+	return &inventory.Remediation{
+		FixedVersion: r.GetFixedVersion(),
+		Backported:   r.GetBackported(),
+		UpgradePath:  path,
+		PatchURLs:    r.GetPatchUrls(),
+	}
+}
@@ -47,6 +47,12 @@ var (
 			Plugin:        "some-plugin",
 			Justification: vex.ComponentNotPresent,
 		}},
+		Remediation: &inventory.Remediation{
+			FixedVersion: "1.2.4",
+			Backported:   true,
+			UpgradePath:  []*inventory.UpgradeStep{{Name: "foo", Version: "2.0.0"}, {Name: "bar", Version: "1.2.4"}},
+			PatchURLs:    []string{"https://github.com/foo/bar/commit/abc"},
+		},
 	}
 
 	genericFindingProto1 = &spb.GenericFinding{
@@ -68,6 +74,12 @@ var (
 			Plugin:        "some-plugin",
 			Justification: spb.VexJustification_COMPONENT_NOT_PRESENT,
 		}},
+		Remediation: &spb.Remediation{
+			FixedVersion: "1.2.4",
+			Backported:   true,
+			UpgradePath:  []*spb.UpgradeStep{{Name: "foo", Version: "2.0.0"}, {Name: "bar", Version: "1.2.4"}},
+			PatchUrls:    []string{"https://github.com/foo/bar/commit/abc"},
+		},
 	}
 )
 
@@ -120,7 +132,7 @@ func TestGenericFindingToProto(t *testing.T) {
 			if !errors.Is(err, tc.wantErr) {
 				t.Errorf("GenericFindingToProto(%v) returned error %v, want error %v", tc.finding, err, tc.wantErr)
 			}
-			if diff := cmp.Diff(tc.want, got, protocmp.Transform()); diff != "" {
+			if diff := cmp.Diff(tc.want, got, protocmp.Transform(), ignoreFingerprints); diff != "" {
 				t.Fatalf("GenericFindingToProto(%v) returned diff (-want +got):\n%s", tc.finding, diff)
 			}
 
@@ -134,7 +146,7 @@ func TestGenericFindingToProto(t *testing.T) {
 			if err != nil {
 				t.Fatalf("GenericFindingToStruct(%v) returned error %v, want nil", got, err)
 			}
-			if diff := cmp.Diff(tc.finding, gotPB, protocmp.Transform()); diff != "" {
+			if diff := cmp.Diff(tc.finding, gotPB, protocmp.Transform(), ignoreFingerprints); diff != "" {
 				t.Fatalf("GenericFindingToStruct(%v) returned diff (-want +got):\n%s", got, diff)
 			}
 		})
@@ -190,7 +202,7 @@ func TestGenericFindingToStruct(t *testing.T) {
 			if !errors.Is(err, tc.wantErr) {
 				t.Fatalf("GenericFindingToStruct(%v) returned error %v, want error %v", tc.finding, err, tc.wantErr)
 			}
-			if diff := cmp.Diff(tc.want, got, protocmp.Transform()); diff != "" {
+			if diff := cmp.Diff(tc.want, got, protocmp.Transform(), ignoreFingerprints); diff != "" {
 				t.Fatalf("GenericFindingToStruct(%v) returned diff (-want +got):\n%s", tc.finding, diff)
 			}
 
@@ -204,7 +216,7 @@ func TestGenericFindingToStruct(t *testing.T) {
 			if err != nil {
 				t.Fatalf("GenericFindingToProto(%v) returned error %v, want nil", got, err)
 			}
-			if diff := cmp.Diff(tc.finding, gotPB, protocmp.Transform()); diff != "" {
+			if diff := cmp.Diff(tc.finding, gotPB, protocmp.Transform(), ignoreFingerprints); diff != "" {
 				t.Fatalf("GenericFindingToProto(%v) returned diff (-want +got):\n%s", got, diff)
 			}
 		})
@@ -0,0 +1,77 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proto_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/osv-scalibr/binary/proto"
+	spb "github.com/google/osv-scalibr/binary/proto/scan_result_go_proto"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/inventory/fingerprint"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scalibr/veles/secrets/gcpsak"
+	"google.golang.org/protobuf/testing/protocmp"
+)
+
+// ignoreFingerprints ignores the output-only fingerprint fields, which are
+// covered by TestFingerprints.
+var ignoreFingerprints = cmp.Options{
+	protocmp.IgnoreFields(&spb.Package{}, "fingerprint"),
+	protocmp.IgnoreFields(&spb.GenericFinding{}, "fingerprint"),
+	protocmp.IgnoreFields(&spb.Secret{}, "fingerprint"),
+}
+
+func TestFingerprints(t *testing.T) {
+	pkg := &extractor.Package{
+		Name:      "software",
+		Version:   "1.0.0",
+		PURLType:  purl.TypePyPi,
+		Locations: []string{"/file1"},
+		Plugins:   []string{"python/wheelegg"},
+	}
+	pkgPB := proto.PackageToProto(pkg)
+	if got, want := pkgPB.GetFingerprint(), fingerprint.Package(pkg); got != want || got == "" {
+		t.Errorf("proto.PackageToProto(%v).Fingerprint: got %q, want %q", pkg, got, want)
+	}
+
+	finding := &inventory.GenericFinding{
+		Adv: &inventory.GenericFindingAdvisory{
+			ID: &inventory.AdvisoryID{Publisher: "CVE", Reference: "CVE-1234"},
+		},
+		Target: &inventory.GenericFindingTargetDetails{Extra: "/etc/passwd: weak permissions"},
+	}
+	findingPB, err := proto.GenericFindingToProto(finding)
+	if err != nil {
+		t.Fatalf("proto.GenericFindingToProto(%v): %v", finding, err)
+	}
+	if got, want := findingPB.GetFingerprint(), fingerprint.GenericFinding(finding); got != want || got == "" {
+		t.Errorf("proto.GenericFindingToProto(%v).Fingerprint: got %q, want %q", finding, got, want)
+	}
+
+	secret := &inventory.Secret{
+		Secret:   gcpsak.GCPSAK{PrivateKeyID: "some-private-key-id"},
+		Location: "/foo/bar/baz.json",
+	}
+	secretPB, err := proto.SecretToProto(secret)
+	if err != nil {
+		t.Fatalf("proto.SecretToProto(%v): %v", secret, err)
+	}
+	if got, want := secretPB.GetFingerprint(), fingerprint.Secret(secret); got != want || got == "" {
+		t.Errorf("proto.SecretToProto(%v).Fingerprint: got %q, want %q", secret, got, want)
+	}
+}
@@ -56,13 +56,32 @@ func InventoryToProto(inv *inventory.Inventory) (*spb.Inventory, error) {
 		secrets = append(secrets, p)
 	}
 
+	imageMetadata := make([]*spb.ContainerImageMetadata, 0, len(inv.ContainerImageMetadata))
+	for _, m := range inv.ContainerImageMetadata {
+		imageMetadata = append(imageMetadata, containerImageMetadataToProto(m))
+	}
+
 	return &spb.Inventory{
-		Packages:        packages,
-		GenericFindings: genericFindings,
-		Secrets:         secrets,
+		Packages:               packages,
+		GenericFindings:        genericFindings,
+		Secrets:                secrets,
+		ContainerImageMetadata: imageMetadata,
 	}, nil
 }
 
+func containerImageMetadataToProto(m *inventory.ContainerImageMetadata) *spb.ContainerImageMetadata {
+	if m == nil {
+		return nil
+	}
+	return &spb.ContainerImageMetadata{
+		User:         m.User,
+		Entrypoint:   m.Entrypoint,
+		Cmd:          m.Cmd,
+		Env:          m.Env,
+		ExposedPorts: m.ExposedPorts,
+	}
+}
+
 // --- Proto to Struct
 
 // InventoryToStruct converts a ScanResult proto into the equivalent go struct.
@@ -99,9 +118,28 @@ func InventoryToStruct(invProto *spb.Inventory) *inventory.Inventory {
 		secrets = append(secrets, s)
 	}
 
+	var imageMetadata []*inventory.ContainerImageMetadata
+	for _, mProto := range invProto.GetContainerImageMetadata() {
+		imageMetadata = append(imageMetadata, containerImageMetadataToStruct(mProto))
+	}
+
 	return &inventory.Inventory{
-		Packages:        packages,
-		GenericFindings: genericFindings,
-		Secrets:         secrets,
+		Packages:               packages,
+		GenericFindings:        genericFindings,
+		Secrets:                secrets,
+		ContainerImageMetadata: imageMetadata,
+	}
+}
+
+func containerImageMetadataToStruct(m *spb.ContainerImageMetadata) *inventory.ContainerImageMetadata {
+	if m == nil {
+		return nil
+	}
+	return &inventory.ContainerImageMetadata{
+		User:         m.GetUser(),
+		Entrypoint:   m.GetEntrypoint(),
+		Cmd:          m.GetCmd(),
+		Env:          m.GetEnv(),
+		ExposedPorts: m.GetExposedPorts(),
 	}
 }
@@ -56,6 +56,15 @@ func TestInventoryToProto(t *testing.T) {
 				Secrets: []*inventory.Secret{
 					secretGCPSAKStruct1,
 				},
+				ContainerImageMetadata: []*inventory.ContainerImageMetadata{
+					{
+						User:         "nobody",
+						Entrypoint:   []string{"/docker-entrypoint.sh"},
+						Cmd:          []string{"nginx", "-g", "daemon off;"},
+						Env:          []string{"PATH=/usr/local/bin:/usr/bin"},
+						ExposedPorts: []string{"80/tcp"},
+					},
+				},
 			},
 			want: &pb.Inventory{
 				Packages: []*pb.Package{
@@ -67,6 +76,15 @@ func TestInventoryToProto(t *testing.T) {
 				Secrets: []*pb.Secret{
 					secretGCPSAKProto1,
 				},
+				ContainerImageMetadata: []*pb.ContainerImageMetadata{
+					{
+						User:         "nobody",
+						Entrypoint:   []string{"/docker-entrypoint.sh"},
+						Cmd:          []string{"nginx", "-g", "daemon off;"},
+						Env:          []string{"PATH=/usr/local/bin:/usr/bin"},
+						ExposedPorts: []string{"80/tcp"},
+					},
+				},
 			},
 		},
 	}
@@ -77,7 +95,7 @@ func TestInventoryToProto(t *testing.T) {
 			if !errors.Is(err, tc.wantErr) {
 				t.Errorf("InventoryToProto(%v) returned error %v, want error %v", tc.inv, err, tc.wantErr)
 			}
-			if diff := cmp.Diff(tc.want, got, protocmp.Transform()); diff != "" {
+			if diff := cmp.Diff(tc.want, got, protocmp.Transform(), ignoreFingerprints); diff != "" {
 				t.Errorf("InventoryToProto(%v) returned diff (-want +got):\n%s", tc.inv, diff)
 			}
 
@@ -118,6 +136,15 @@ func TestInventoryToStruct(t *testing.T) {
 				Secrets: []*pb.Secret{
 					secretGCPSAKProto1,
 				},
+				ContainerImageMetadata: []*pb.ContainerImageMetadata{
+					{
+						User:         "nobody",
+						Entrypoint:   []string{"/docker-entrypoint.sh"},
+						Cmd:          []string{"nginx", "-g", "daemon off;"},
+						Env:          []string{"PATH=/usr/local/bin:/usr/bin"},
+						ExposedPorts: []string{"80/tcp"},
+					},
+				},
 			},
 			want: &inventory.Inventory{
 				Packages: []*extractor.Package{
@@ -129,6 +156,15 @@ func TestInventoryToStruct(t *testing.T) {
 				Secrets: []*inventory.Secret{
 					secretGCPSAKStruct1,
 				},
+				ContainerImageMetadata: []*inventory.ContainerImageMetadata{
+					{
+						User:         "nobody",
+						Entrypoint:   []string{"/docker-entrypoint.sh"},
+						Cmd:          []string{"nginx", "-g", "daemon off;"},
+						Env:          []string{"PATH=/usr/local/bin:/usr/bin"},
+						ExposedPorts: []string{"80/tcp"},
+					},
+				},
 			},
 		},
 	}
@@ -145,7 +181,7 @@ func TestInventoryToStruct(t *testing.T) {
 			if err != nil {
 				t.Fatalf("InventoryToProto(%v) returned error %v, want nil", got, err)
 			}
-			if diff := cmp.Diff(tc.inv, gotPB, protocmp.Transform()); diff != "" {
+			if diff := cmp.Diff(tc.inv, gotPB, protocmp.Transform(), ignoreFingerprints); diff != "" {
 				t.Fatalf("InventoryToProto(%v) returned diff (-want +got):\n%s", got, diff)
 			}
 		})
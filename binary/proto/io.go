@@ -17,10 +17,12 @@ package proto
 import (
 	"compress/gzip"
 	"errors"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
 
+	"github.com/google/osv-scalibr/binary/atomicfile"
 	"github.com/google/osv-scalibr/log"
 	"google.golang.org/protobuf/encoding/prototext"
 	"google.golang.org/protobuf/proto"
@@ -84,6 +86,40 @@ func WriteWithFormat(filePath string, outputProto proto.Message, format string)
 	return write(filePath, outputProto, ft)
 }
 
+// Read reads a proto message from a .textproto or .binproto file, based on the
+// file extension. Files with the .gz suffix are unzipped before parsing.
+func Read(filePath string, outputProto proto.Message) error {
+	ft, err := typeForPath(filePath)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Open(filePath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	var reader io.Reader = f
+	if ft.isGZipped {
+		gz, err := gzip.NewReader(f)
+		if err != nil {
+			return err
+		}
+		defer gz.Close()
+		reader = gz
+	}
+	p, err := io.ReadAll(reader)
+	if err != nil {
+		return err
+	}
+
+	if ft.isBinProto {
+		return proto.Unmarshal(p, outputProto)
+	}
+	return prototext.Unmarshal(p, outputProto)
+}
+
 func write(filePath string, outputProto proto.Message, ft *fileType) error {
 	var p []byte
 	var err error
@@ -100,21 +136,15 @@ func write(filePath string, outputProto proto.Message, ft *fileType) error {
 
 	log.Infof("Marshaled result proto has %d bytes", len(p))
 
-	f, err := os.Create(filePath)
-	if err != nil {
-		return err
-	}
-	defer f.Close()
-	if ft.isGZipped {
-		writer := gzip.NewWriter(f)
-		if _, err := writer.Write(p); err != nil {
-			return err
+	return atomicfile.Write(filePath, func(f io.Writer) error {
+		if ft.isGZipped {
+			writer := gzip.NewWriter(f)
+			if _, err := writer.Write(p); err != nil {
+				return err
+			}
+			return writer.Close()
 		}
-		if err := writer.Close(); err != nil {
-			return err
-		}
-	} else if _, err := f.Write(p); err != nil {
+		_, err := f.Write(p)
 		return err
-	}
-	return nil
+	})
 }
@@ -22,6 +22,7 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/osv-scalibr/binary/proto"
+	"google.golang.org/protobuf/testing/protocmp"
 
 	spb "github.com/google/osv-scalibr/binary/proto/scan_result_go_proto"
 )
@@ -88,6 +89,36 @@ func TestWrite_InvalidFilename(t *testing.T) {
 	}
 }
 
+func TestRead(t *testing.T) {
+	testDirPath := t.TempDir()
+	want := &spb.ScanResult{Version: "1.0.0", Inventory: &spb.Inventory{
+		Packages: []*spb.Package{{Name: "software", Version: "1.2.3"}},
+	}}
+	for _, path := range []string{"output.textproto", "output.binproto", "output.textproto.gz", "output.binproto.gz"} {
+		t.Run(path, func(t *testing.T) {
+			fullPath := filepath.Join(testDirPath, path)
+			if err := proto.Write(fullPath, want); err != nil {
+				t.Fatalf("proto.Write(%s, %v) returned an error: %v", fullPath, want, err)
+			}
+
+			got := &spb.ScanResult{}
+			if err := proto.Read(fullPath, got); err != nil {
+				t.Fatalf("proto.Read(%s) returned an error: %v", fullPath, err)
+			}
+			if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("proto.Read(%s) returned unexpected diff (-want +got):\n%s", fullPath, diff)
+			}
+		})
+	}
+}
+
+func TestRead_InvalidFilename(t *testing.T) {
+	if err := proto.Read("config.invalid-extension", &spb.ScanResult{}); err == nil ||
+		!strings.HasPrefix(err.Error(), "invalid filename") {
+		t.Errorf("proto.Read(config.invalid-extension) didn't return an invalid file error: %v", err)
+	}
+}
+
 func TestWriteWithFormat(t *testing.T) {
 	testDirPath := t.TempDir()
 	var result = &spb.ScanResult{Version: "1.0.0"}
@@ -16,6 +16,7 @@ package proto
 
 import (
 	"reflect"
+	"time"
 
 	"github.com/docker/docker/api/types/container"
 	"github.com/google/osv-scalibr/converter"
@@ -40,6 +41,7 @@ import (
 	ctrdruntime "github.com/google/osv-scalibr/extractor/standalone/containers/containerd/containerdmetadata"
 	"github.com/google/osv-scalibr/extractor/standalone/containers/docker"
 	winmetadata "github.com/google/osv-scalibr/extractor/standalone/windows/common/metadata"
+	"github.com/google/osv-scalibr/inventory/fingerprint"
 	"github.com/google/osv-scalibr/purl"
 
 	spb "github.com/google/osv-scalibr/binary/proto/scan_result_go_proto"
@@ -94,7 +96,12 @@ func PackageToProto(pkg *extractor.Package) *spb.Package {
 		AnnotationsDeprecated: annotations,
 		ExploitabilitySignals: exps,
 		LayerDetails:          layerDetailsToProto(pkg.LayerDetails),
+		LocationProvenance:    locationProvenanceToProto(pkg.LocationProvenance),
+		OwnershipHints:        ownershipHintsToProto(pkg.OwnershipHints),
+		FileDigests:           fileDigestsToProto(pkg.FileDigests),
+		ProjectInfo:           projectInfoToProto(pkg.ProjectInfo),
 		Licenses:              pkg.Licenses,
+		Fingerprint:           fingerprint.Package(pkg),
 	}
 	setProtoMetadata(pkg.Metadata, packageProto)
 	return packageProto
@@ -123,6 +130,74 @@ func layerDetailsToProto(ld *extractor.LayerDetails) *spb.LayerDetails {
 	}
 }
 
+func locationProvenanceToProto(provs []*extractor.LocationProvenance) []*spb.LocationProvenance {
+	var result []*spb.LocationProvenance
+	for _, p := range provs {
+		result = append(result, &spb.LocationProvenance{
+			Location:     p.Location,
+			LayerDigest:  p.LayerDigest,
+			ArchiveChain: p.ArchiveChain,
+			Path:         p.Path,
+		})
+	}
+	return result
+}
+
+func ownershipHintsToProto(hints []*extractor.OwnershipHint) []*spb.OwnershipHint {
+	var result []*spb.OwnershipHint
+	for _, h := range hints {
+		result = append(result, &spb.OwnershipHint{
+			Location: h.Location,
+			Owner:    h.Owner,
+			Source:   h.Source,
+		})
+	}
+	return result
+}
+
+func fileDigestsToProto(digests []*extractor.FileDigest) []*spb.FileDigest {
+	var result []*spb.FileDigest
+	for _, d := range digests {
+		result = append(result, &spb.FileDigest{
+			Location:  d.Location,
+			Algorithm: d.Algorithm,
+			Digest:    d.Digest,
+		})
+	}
+	return result
+}
+
+func projectInfoToProto(info *extractor.ProjectInfo) *spb.ProjectInfo {
+	if info == nil {
+		return nil
+	}
+	return &spb.ProjectInfo{
+		DirectDependencies:   int32(info.DirectDependencies),
+		IndirectDependencies: int32(info.IndirectDependencies),
+		SourceRepository:     info.SourceRepository,
+		Scorecard:            scorecardToProto(info.Scorecard),
+	}
+}
+
+func scorecardToProto(sc *extractor.Scorecard) *spb.Scorecard {
+	if sc == nil {
+		return nil
+	}
+	var checks []*spb.ScorecardCheck
+	for _, c := range sc.Checks {
+		checks = append(checks, &spb.ScorecardCheck{Name: c.Name, Score: int32(c.Score)})
+	}
+	var date *timestamppb.Timestamp
+	if !sc.Date.IsZero() {
+		date = timestamppb.New(sc.Date)
+	}
+	return &spb.Scorecard{
+		Date:         date,
+		OverallScore: sc.OverallScore,
+		Checks:       checks,
+	}
+}
+
 func setProtoMetadata(meta any, p *spb.Package) {
 	if meta == nil {
 		return
@@ -142,7 +217,11 @@ func setProtoMetadata(meta any, p *spb.Package) {
 	switch m := meta.(type) {
 	case *homebrew.Metadata:
 		p.Metadata = &spb.Package_HomebrewMetadata{
-			HomebrewMetadata: &spb.HomebrewPackageMetadata{},
+			HomebrewMetadata: &spb.HomebrewPackageMetadata{
+				Tap:                   m.Tap,
+				InstalledOnRequest:    m.InstalledOnRequest,
+				InstalledAsDependency: m.InstalledAsDependency,
+			},
 		}
 	case *modulemeta.Metadata:
 		p.Metadata = &spb.Package_KernelModuleMetadata{
@@ -220,17 +299,21 @@ func setProtoMetadata(meta any, p *spb.Package) {
 	case *archivemeta.Metadata:
 		p.Metadata = &spb.Package_JavaArchiveMetadata{
 			JavaArchiveMetadata: &spb.JavaArchiveMetadata{
-				ArtifactId: m.ArtifactID,
-				GroupId:    m.GroupID,
-				Sha1:       m.SHA1,
+				ArtifactId:   m.ArtifactID,
+				GroupId:      m.GroupID,
+				Sha1:         m.SHA1,
+				Fingerprint:  m.Fingerprint,
+				ClassDigests: classDigestsToProto(m.ClassDigests),
 			},
 		}
 	case *javalockfile.Metadata:
 		p.Metadata = &spb.Package_JavaLockfileMetadata{
 			JavaLockfileMetadata: &spb.JavaLockfileMetadata{
-				ArtifactId:   m.ArtifactID,
-				GroupId:      m.GroupID,
-				IsTransitive: m.IsTransitive,
+				ArtifactId:     m.ArtifactID,
+				GroupId:        m.GroupID,
+				DepGroupVals:   m.DepGroupVals,
+				IsTransitive:   m.IsTransitive,
+				Configurations: m.Configurations,
 			},
 		}
 	case *osv.Metadata:
@@ -392,6 +475,10 @@ func PackageToStruct(pkgProto *spb.Package) *extractor.Package {
 		AnnotationsDeprecated: annotations,
 		ExploitabilitySignals: exps,
 		LayerDetails:          layerDetailsToStruct(pkgProto.GetLayerDetails()),
+		LocationProvenance:    locationProvenanceToStruct(pkgProto.GetLocationProvenance()),
+		OwnershipHints:        ownershipHintsToStruct(pkgProto.GetOwnershipHints()),
+		FileDigests:           fileDigestsToStruct(pkgProto.GetFileDigests()),
+		ProjectInfo:           projectInfoToStruct(pkgProto.GetProjectInfo()),
 		Metadata:              metadataToStruct(pkgProto),
 		Licenses:              pkgProto.GetLicenses(),
 	}
@@ -421,6 +508,74 @@ func layerDetailsToStruct(ld *spb.LayerDetails) *extractor.LayerDetails {
 	}
 }
 
+func locationProvenanceToStruct(provs []*spb.LocationProvenance) []*extractor.LocationProvenance {
+	var result []*extractor.LocationProvenance
+	for _, p := range provs {
+		result = append(result, &extractor.LocationProvenance{
+			Location:     p.GetLocation(),
+			LayerDigest:  p.GetLayerDigest(),
+			ArchiveChain: p.GetArchiveChain(),
+			Path:         p.GetPath(),
+		})
+	}
+	return result
+}
+
+func ownershipHintsToStruct(hints []*spb.OwnershipHint) []*extractor.OwnershipHint {
+	var result []*extractor.OwnershipHint
+	for _, h := range hints {
+		result = append(result, &extractor.OwnershipHint{
+			Location: h.GetLocation(),
+			Owner:    h.GetOwner(),
+			Source:   h.GetSource(),
+		})
+	}
+	return result
+}
+
+func fileDigestsToStruct(digests []*spb.FileDigest) []*extractor.FileDigest {
+	var result []*extractor.FileDigest
+	for _, d := range digests {
+		result = append(result, &extractor.FileDigest{
+			Location:  d.GetLocation(),
+			Algorithm: d.GetAlgorithm(),
+			Digest:    d.GetDigest(),
+		})
+	}
+	return result
+}
+
+func projectInfoToStruct(info *spb.ProjectInfo) *extractor.ProjectInfo {
+	if info == nil {
+		return nil
+	}
+	return &extractor.ProjectInfo{
+		DirectDependencies:   int(info.GetDirectDependencies()),
+		IndirectDependencies: int(info.GetIndirectDependencies()),
+		SourceRepository:     info.GetSourceRepository(),
+		Scorecard:            scorecardToStruct(info.GetScorecard()),
+	}
+}
+
+func scorecardToStruct(sc *spb.Scorecard) *extractor.Scorecard {
+	if sc == nil {
+		return nil
+	}
+	var checks []*extractor.ScorecardCheck
+	for _, c := range sc.GetChecks() {
+		checks = append(checks, &extractor.ScorecardCheck{Name: c.GetName(), Score: int(c.GetScore())})
+	}
+	var date time.Time
+	if sc.GetDate() != nil {
+		date = sc.GetDate().AsTime()
+	}
+	return &extractor.Scorecard{
+		Date:         date,
+		OverallScore: sc.GetOverallScore(),
+		Checks:       checks,
+	}
+}
+
 func metadataToStruct(md *spb.Package) any {
 	if md.GetMetadata() == nil {
 		return nil
@@ -434,7 +589,11 @@ func metadataToStruct(md *spb.Package) any {
 	// TODO: b/421456154 - Remove this switch statement once all metadata types implement MetadataProtoSetter.
 	switch md.GetMetadata().(type) {
 	case *spb.Package_HomebrewMetadata:
-		return &homebrew.Metadata{}
+		return &homebrew.Metadata{
+			Tap:                   md.GetHomebrewMetadata().GetTap(),
+			InstalledOnRequest:    md.GetHomebrewMetadata().GetInstalledOnRequest(),
+			InstalledAsDependency: md.GetHomebrewMetadata().GetInstalledAsDependency(),
+		}
 	case *spb.Package_KernelModuleMetadata:
 		return &modulemeta.Metadata{
 			PackageName:                    md.GetKernelModuleMetadata().GetPackageName(),
@@ -499,15 +658,19 @@ func metadataToStruct(md *spb.Package) any {
 		}
 	case *spb.Package_JavaArchiveMetadata:
 		return &archivemeta.Metadata{
-			ArtifactID: md.GetJavaArchiveMetadata().GetArtifactId(),
-			GroupID:    md.GetJavaArchiveMetadata().GetGroupId(),
-			SHA1:       md.GetJavaArchiveMetadata().GetSha1(),
+			ArtifactID:   md.GetJavaArchiveMetadata().GetArtifactId(),
+			GroupID:      md.GetJavaArchiveMetadata().GetGroupId(),
+			SHA1:         md.GetJavaArchiveMetadata().GetSha1(),
+			Fingerprint:  md.GetJavaArchiveMetadata().GetFingerprint(),
+			ClassDigests: classDigestsToStruct(md.GetJavaArchiveMetadata().GetClassDigests()),
 		}
 	case *spb.Package_JavaLockfileMetadata:
 		return &javalockfile.Metadata{
-			ArtifactID:   md.GetJavaLockfileMetadata().GetArtifactId(),
-			GroupID:      md.GetJavaLockfileMetadata().GetGroupId(),
-			IsTransitive: md.GetJavaLockfileMetadata().GetIsTransitive(),
+			ArtifactID:     md.GetJavaLockfileMetadata().GetArtifactId(),
+			GroupID:        md.GetJavaLockfileMetadata().GetGroupId(),
+			DepGroupVals:   md.GetJavaLockfileMetadata().GetDepGroupVals(),
+			IsTransitive:   md.GetJavaLockfileMetadata().GetIsTransitive(),
+			Configurations: md.GetJavaLockfileMetadata().GetConfigurations(),
 		}
 	case *spb.Package_OsvMetadata:
 		return &osv.Metadata{
@@ -677,3 +840,19 @@ func qualifiersToStruct(qs []*spb.Qualifier) purl.Qualifiers {
 	}
 	return purl.QualifiersFromMap(qsmap)
 }
+
+func classDigestsToProto(digests []*archivemeta.ClassDigest) []*spb.JavaClassDigest {
+	var result []*spb.JavaClassDigest
+	for _, d := range digests {
+		result = append(result, &spb.JavaClassDigest{Name: d.Name, Sha256: d.SHA256})
+	}
+	return result
+}
+
+func classDigestsToStruct(digests []*spb.JavaClassDigest) []*archivemeta.ClassDigest {
+	var result []*archivemeta.ClassDigest
+	for _, d := range digests {
+		result = append(result, &archivemeta.ClassDigest{Name: d.GetName(), SHA256: d.GetSha256()})
+	}
+	return result
+}
@@ -17,11 +17,28 @@ package proto
 import (
 	"reflect"
 
+	"github.com/google/osv-scalibr/extractor/standalone/os/kernelruntime"
 	"github.com/google/osv-scalibr/extractor/standalone/os/netports"
+	"github.com/google/osv-scalibr/extractor/standalone/windows/dotnetframework"
+	"github.com/google/osv-scalibr/extractor/standalone/windows/services"
+	"github.com/google/osv-scalibr/extractor/standalone/windows/vcredist"
 
+	"github.com/google/osv-scalibr/extractor/filesystem/language/dart/pubspec"
 	"github.com/google/osv-scalibr/extractor/filesystem/language/dotnet/depsjson"
+	"github.com/google/osv-scalibr/extractor/filesystem/language/dotnet/nugetlock"
+	"github.com/google/osv-scalibr/extractor/filesystem/language/javascript/nativeaddon"
+	"github.com/google/osv-scalibr/extractor/filesystem/language/javascript/npmtarball"
 	javascriptmeta "github.com/google/osv-scalibr/extractor/filesystem/language/javascript/packagejson/metadata"
+	juliameta "github.com/google/osv-scalibr/extractor/filesystem/language/julia/metadata"
+	"github.com/google/osv-scalibr/extractor/filesystem/language/ocaml/opam"
+	"github.com/google/osv-scalibr/extractor/filesystem/language/python/pythonenv"
 	"github.com/google/osv-scalibr/extractor/filesystem/language/python/wheelegg"
+	"github.com/google/osv-scalibr/extractor/filesystem/language/swift/podfilelock"
+	"github.com/google/osv-scalibr/extractor/filesystem/misc/codeclib"
+	"github.com/google/osv-scalibr/extractor/filesystem/misc/embeddedversion"
+	firefoxextensions "github.com/google/osv-scalibr/extractor/filesystem/misc/firefox/extensions"
+	"github.com/google/osv-scalibr/extractor/filesystem/misc/mlmodel"
+	"github.com/google/osv-scalibr/extractor/filesystem/misc/runtimeversion"
 	apkmeta "github.com/google/osv-scalibr/extractor/filesystem/os/apk/metadata"
 	cosmeta "github.com/google/osv-scalibr/extractor/filesystem/os/cos/metadata"
 	dpkgmeta "github.com/google/osv-scalibr/extractor/filesystem/os/dpkg/metadata"
@@ -32,6 +49,8 @@ import (
 	portagemeta "github.com/google/osv-scalibr/extractor/filesystem/os/portage/metadata"
 	rpmmeta "github.com/google/osv-scalibr/extractor/filesystem/os/rpm/metadata"
 	snapmeta "github.com/google/osv-scalibr/extractor/filesystem/os/snap/metadata"
+	"github.com/google/osv-scalibr/extractor/filesystem/os/winapps"
+	yoctometa "github.com/google/osv-scalibr/extractor/filesystem/os/yocto/metadata"
 
 	spb "github.com/google/osv-scalibr/binary/proto/scan_result_go_proto"
 )
@@ -80,10 +99,68 @@ var (
 		reflect.TypeOf(&spb.Package_MacAppsMetadata{}): func(p *spb.Package) any {
 			return macapps.ToStruct(p.GetMacAppsMetadata())
 		},
+		reflect.TypeOf(&spb.Package_WindowsAppMetadata{}): func(p *spb.Package) any {
+			return winapps.ToStruct(p.GetWindowsAppMetadata())
+		},
+		reflect.TypeOf(&spb.Package_NpmTarballMetadata{}): func(p *spb.Package) any {
+			return npmtarball.ToStruct(p.GetNpmTarballMetadata())
+		},
+		reflect.TypeOf(&spb.Package_KernelRuntimeMetadata{}): func(p *spb.Package) any {
+			return kernelruntime.ToStruct(p.GetKernelRuntimeMetadata())
+		},
+		reflect.TypeOf(&spb.Package_MlModelMetadata{}): func(p *spb.Package) any {
+			return mlmodel.ToStruct(p.GetMlModelMetadata())
+		},
+		reflect.TypeOf(&spb.Package_NodeNativeAddonMetadata{}): func(p *spb.Package) any {
+			return nativeaddon.ToStruct(p.GetNodeNativeAddonMetadata())
+		},
+		reflect.TypeOf(&spb.Package_PubspecMetadata{}): func(p *spb.Package) any {
+			return pubspec.ToStruct(p.GetPubspecMetadata())
+		},
+		reflect.TypeOf(&spb.Package_EmbeddedVersionMetadata{}): func(p *spb.Package) any {
+			return embeddedversion.ToStruct(p.GetEmbeddedVersionMetadata())
+		},
+		reflect.TypeOf(&spb.Package_PythonEnvironmentMetadata{}): func(p *spb.Package) any {
+			return pythonenv.ToStruct(p.GetPythonEnvironmentMetadata())
+		},
+		reflect.TypeOf(&spb.Package_CocoapodsMetadata{}): func(p *spb.Package) any {
+			return podfilelock.ToStruct(p.GetCocoapodsMetadata())
+		},
+		reflect.TypeOf(&spb.Package_CodecLibraryMetadata{}): func(p *spb.Package) any {
+			return codeclib.ToStruct(p.GetCodecLibraryMetadata())
+		},
+		reflect.TypeOf(&spb.Package_WindowsServiceMetadata{}): func(p *spb.Package) any {
+			return services.ToStruct(p.GetWindowsServiceMetadata())
+		},
+		reflect.TypeOf(&spb.Package_DotnetFrameworkMetadata{}): func(p *spb.Package) any {
+			return dotnetframework.ToStruct(p.GetDotnetFrameworkMetadata())
+		},
+		reflect.TypeOf(&spb.Package_VcRedistMetadata{}): func(p *spb.Package) any {
+			return vcredist.ToStruct(p.GetVcRedistMetadata())
+		},
+		reflect.TypeOf(&spb.Package_NugetLockfileMetadata{}): func(p *spb.Package) any {
+			return nugetlock.ToStruct(p.GetNugetLockfileMetadata())
+		},
+		reflect.TypeOf(&spb.Package_FirefoxExtensionsMetadata{}): func(p *spb.Package) any {
+			return firefoxextensions.ToStruct(p.GetFirefoxExtensionsMetadata())
+		},
+		reflect.TypeOf(&spb.Package_JuliaMetadata{}): func(p *spb.Package) any {
+			return juliameta.ToStruct(p.GetJuliaMetadata())
+		},
+		reflect.TypeOf(&spb.Package_OpamMetadata{}): func(p *spb.Package) any {
+			return opam.ToStruct(p.GetOpamMetadata())
+		},
+		reflect.TypeOf(&spb.Package_YoctoMetadata{}): func(p *spb.Package) any {
+			return yoctometa.ToStruct(p.GetYoctoMetadata())
+		},
+		reflect.TypeOf(&spb.Package_RuntimeVersionMetadata{}): func(p *spb.Package) any {
+			return runtimeversion.ToStruct(p.GetRuntimeVersionMetadata())
+		},
 	}
 
 	_ = []MetadataProtoSetter{
 		(*wheelegg.PythonPackageMetadata)(nil),
+		(*pythonenv.Metadata)(nil),
 		(*javascriptmeta.JavascriptPackageJSONMetadata)(nil),
 		(*depsjson.Metadata)(nil),
 		(*netports.Metadata)(nil),
@@ -97,5 +174,23 @@ var (
 		(*flatpakmeta.Metadata)(nil),
 		(*nixmeta.Metadata)(nil),
 		(*macapps.Metadata)(nil),
+		(*winapps.Metadata)(nil),
+		(*npmtarball.Metadata)(nil),
+		(*kernelruntime.Metadata)(nil),
+		(*mlmodel.Metadata)(nil),
+		(*nativeaddon.Metadata)(nil),
+		(*pubspec.Metadata)(nil),
+		(*podfilelock.Metadata)(nil),
+		(*codeclib.Metadata)(nil),
+		(*services.Metadata)(nil),
+		(*dotnetframework.Metadata)(nil),
+		(*vcredist.Metadata)(nil),
+		(*nugetlock.Metadata)(nil),
+		(*firefoxextensions.Metadata)(nil),
+		(*juliameta.Metadata)(nil),
+		(*opam.Metadata)(nil),
+		(*yoctometa.Metadata)(nil),
+		(*runtimeversion.Metadata)(nil),
+		(*embeddedversion.Metadata)(nil),
 	}
 )
@@ -26,6 +26,7 @@ var (
 		plugin.ScanStatusSucceeded:          spb.ScanStatus_SUCCEEDED,
 		plugin.ScanStatusPartiallySucceeded: spb.ScanStatus_PARTIALLY_SUCCEEDED,
 		plugin.ScanStatusFailed:             spb.ScanStatus_FAILED,
+		plugin.ScanStatusInterrupted:        spb.ScanStatus_INTERRUPTED,
 		plugin.ScanStatusUnspecified:        spb.ScanStatus_UNSPECIFIED,
 	}
 
@@ -39,6 +40,29 @@ var (
 		}
 		return m
 	}()
+
+	// structToProtoErrorCategory is a map of struct ErrorCategory to their corresponding proto values.
+	structToProtoErrorCategory = map[plugin.ErrorCategory]spb.ErrorCount_ErrorCategory{
+		plugin.ErrorCategoryUnspecified:        spb.ErrorCount_UNSPECIFIED,
+		plugin.ErrorCategoryOther:              spb.ErrorCount_OTHER,
+		plugin.ErrorCategoryPermissionDenied:   spb.ErrorCount_PERMISSION_DENIED,
+		plugin.ErrorCategoryParse:              spb.ErrorCount_PARSE_ERROR,
+		plugin.ErrorCategoryUnsupportedVersion: spb.ErrorCount_UNSUPPORTED_VERSION,
+		plugin.ErrorCategoryTimeout:            spb.ErrorCount_TIMEOUT,
+		plugin.ErrorCategoryPartial:            spb.ErrorCount_PARTIAL,
+		plugin.ErrorCategoryBudgetExceeded:     spb.ErrorCount_BUDGET_EXCEEDED,
+	}
+
+	protoToStructErrorCategory = func() map[spb.ErrorCount_ErrorCategory]plugin.ErrorCategory {
+		m := make(map[spb.ErrorCount_ErrorCategory]plugin.ErrorCategory)
+		for k, v := range structToProtoErrorCategory {
+			m[v] = k
+		}
+		if len(m) != len(structToProtoErrorCategory) {
+			panic("protoToStructErrorCategory does not contain all values from structToProtoErrorCategory")
+		}
+		return m
+	}()
 )
 
 // --- Struct to Proto
@@ -61,7 +85,22 @@ func scanStatusToProto(s *plugin.ScanStatus) *spb.ScanStatus {
 		return nil
 	}
 	statusEnum := structToProtoScanStatus[s.Status]
-	return &spb.ScanStatus{Status: statusEnum, FailureReason: s.FailureReason}
+	return &spb.ScanStatus{
+		Status:        statusEnum,
+		FailureReason: s.FailureReason,
+		ErrorCounts:   errorCountsToProto(s.ErrorCounts),
+	}
+}
+
+func errorCountsToProto(counts []*plugin.ErrorCount) []*spb.ErrorCount {
+	var result []*spb.ErrorCount
+	for _, c := range counts {
+		result = append(result, &spb.ErrorCount{
+			Category: structToProtoErrorCategory[c.Category],
+			Count:    int32(c.Count),
+		})
+	}
+	return result
 }
 
 // --- Proto to Struct
@@ -84,5 +123,20 @@ func scanStatusToStruct(s *spb.ScanStatus) *plugin.ScanStatus {
 		return nil
 	}
 	statusEnum := protoToStructScanStatus[s.GetStatus()]
-	return &plugin.ScanStatus{Status: statusEnum, FailureReason: s.GetFailureReason()}
+	return &plugin.ScanStatus{
+		Status:        statusEnum,
+		FailureReason: s.GetFailureReason(),
+		ErrorCounts:   errorCountsToStruct(s.GetErrorCounts()),
+	}
+}
+
+func errorCountsToStruct(counts []*spb.ErrorCount) []*plugin.ErrorCount {
+	var result []*plugin.ErrorCount
+	for _, c := range counts {
+		result = append(result, &plugin.ErrorCount{
+			Category: protoToStructErrorCategory[c.GetCategory()],
+			Count:    int(c.GetCount()),
+		})
+	}
+	return result
 }
@@ -15,9 +15,14 @@
 package proto
 
 import (
+	"time"
+
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/plugin"
 	"github.com/google/osv-scalibr/result"
 
 	spb "github.com/google/osv-scalibr/binary/proto/scan_result_go_proto"
+	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -46,7 +51,107 @@ func ScanResultToProto(r *result.ScanResult) (*spb.ScanResult, error) {
 		InventoriesDeprecated: inventory.GetPackages(),
 		FindingsDeprecated:    inventory.GetGenericFindings(),
 		Inventory:             inventory,
+		ResourceUsage:         resourceUsageToProto(r.ResourceUsage),
+		ScanRoots:             scanRootsToProto(r.ScanRoots),
 	}, nil
 }
 
+func scanRootsToProto(roots []*result.ScanRoot) []*spb.ScanRoot {
+	var ps []*spb.ScanRoot
+	for _, r := range roots {
+		ps = append(ps, &spb.ScanRoot{Path: r.Path, CanonicalPath: r.CanonicalPath})
+	}
+	return ps
+}
+
+func resourceUsageToProto(u *result.ResourceUsage) *spb.ResourceUsage {
+	if u == nil {
+		return nil
+	}
+	p := &spb.ResourceUsage{
+		PeakMemoryBytes: u.PeakMemoryBytes,
+		CpuTime:         durationpb.New(u.CPUTime),
+		FilesOpened:     u.FilesOpened,
+		BytesRead:       u.BytesRead,
+	}
+	for _, pu := range u.Plugins {
+		p.Plugins = append(p.Plugins, &spb.PluginResourceUsage{
+			Name:           pu.Name,
+			WallTime:       durationpb.New(pu.WallTime),
+			CpuTime:        durationpb.New(pu.CPUTime),
+			FilesExtracted: pu.FilesExtracted,
+			BytesRead:      pu.BytesRead,
+			AllocatedBytes: pu.AllocatedBytes,
+		})
+	}
+	return p
+}
+
 // --- Proto to Struct
+
+// ScanResultToStruct converts a ScanResult proto into the equivalent go struct.
+func ScanResultToStruct(r *spb.ScanResult) *result.ScanResult {
+	if r == nil {
+		return nil
+	}
+
+	pluginStatus := make([]*plugin.Status, 0, len(r.GetPluginStatus()))
+	for _, s := range r.GetPluginStatus() {
+		pluginStatus = append(pluginStatus, PluginStatusToStruct(s))
+	}
+
+	inv := InventoryToStruct(r.GetInventory())
+	if inv == nil {
+		inv = &inventory.Inventory{}
+	}
+
+	var startTime, endTime time.Time
+	if r.GetStartTime() != nil {
+		startTime = r.GetStartTime().AsTime()
+	}
+	if r.GetEndTime() != nil {
+		endTime = r.GetEndTime().AsTime()
+	}
+
+	return &result.ScanResult{
+		Version:       r.GetVersion(),
+		StartTime:     startTime,
+		EndTime:       endTime,
+		Status:        scanStatusToStruct(r.GetStatus()),
+		PluginStatus:  pluginStatus,
+		Inventory:     *inv,
+		ResourceUsage: resourceUsageToStruct(r.GetResourceUsage()),
+		ScanRoots:     scanRootsToStruct(r.GetScanRoots()),
+	}
+}
+
+func scanRootsToStruct(roots []*spb.ScanRoot) []*result.ScanRoot {
+	var rs []*result.ScanRoot
+	for _, r := range roots {
+		rs = append(rs, &result.ScanRoot{Path: r.GetPath(), CanonicalPath: r.GetCanonicalPath()})
+	}
+	return rs
+}
+
+func resourceUsageToStruct(u *spb.ResourceUsage) *result.ResourceUsage {
+	if u == nil {
+		return nil
+	}
+	r := &result.ResourceUsage{
+		PeakMemoryBytes: u.GetPeakMemoryBytes(),
+		CPUTime:         u.GetCpuTime().AsDuration(),
+		FilesOpened:     u.GetFilesOpened(),
+		BytesRead:       u.GetBytesRead(),
+	}
+	for _, pu := range u.GetPlugins() {
+		r.Plugins = append(r.Plugins, &result.PluginResourceUsage{
+			Name:           pu.GetName(),
+			WallTime:       pu.GetWallTime().AsDuration(),
+			CPUTime:        pu.GetCpuTime().AsDuration(),
+			FilesExtracted: pu.GetFilesExtracted(),
+			BytesRead:      pu.GetBytesRead(),
+			AllocatedBytes: pu.GetAllocatedBytes(),
+		})
+	}
+	return r
+}
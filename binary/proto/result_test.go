@@ -57,6 +57,7 @@ import (
 	"github.com/google/osv-scalibr/inventory/vex"
 	"github.com/google/osv-scalibr/plugin"
 	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scalibr/result"
 	"github.com/google/osv-scalibr/veles"
 	"github.com/google/osv-scalibr/veles/secrets/gcpsak"
 	"github.com/mohae/deepcopy"
@@ -64,6 +65,7 @@ import (
 	"google.golang.org/protobuf/testing/protocmp"
 
 	spb "github.com/google/osv-scalibr/binary/proto/scan_result_go_proto"
+	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -132,8 +134,16 @@ func TestScanResultToProtoAndBack(t *testing.T) {
 	startTime := endTime.Add(time.Second * -10)
 	success := &plugin.ScanStatus{Status: plugin.ScanStatusSucceeded}
 	successProto := &spb.ScanStatus{Status: spb.ScanStatus_SUCCEEDED}
-	failure := &plugin.ScanStatus{Status: plugin.ScanStatusFailed, FailureReason: "failure"}
-	failureProto := &spb.ScanStatus{Status: spb.ScanStatus_FAILED, FailureReason: "failure"}
+	failure := &plugin.ScanStatus{
+		Status:        plugin.ScanStatusFailed,
+		FailureReason: "failure",
+		ErrorCounts:   []*plugin.ErrorCount{{Category: plugin.ErrorCategoryParse, Count: 2}},
+	}
+	failureProto := &spb.ScanStatus{
+		Status:        spb.ScanStatus_FAILED,
+		FailureReason: "failure",
+		ErrorCounts:   []*spb.ErrorCount{{Category: spb.ErrorCount_PARSE_ERROR, Count: 2}},
+	}
 	purlDPKGPackage := &extractor.Package{
 		Name:     "software",
 		Version:  "1.0.0",
@@ -383,6 +393,22 @@ func TestScanResultToProtoAndBack(t *testing.T) {
 		Licenses:  []string{"BSD"},
 		Locations: []string{"/file1"},
 		Plugins:   []string{rpm.Name},
+		OwnershipHints: []*extractor.OwnershipHint{
+			{Location: "/file1", Owner: "@org/security", Source: ".github/CODEOWNERS"},
+		},
+		FileDigests: []*extractor.FileDigest{
+			{Location: "/file1", Algorithm: "sha256", Digest: "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"},
+		},
+		ProjectInfo: &extractor.ProjectInfo{
+			DirectDependencies:   2,
+			IndirectDependencies: 5,
+			SourceRepository:     "github.com/openssh/openssh-portable",
+			Scorecard: &extractor.Scorecard{
+				Date:         time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC),
+				OverallScore: 6.5,
+				Checks:       []*extractor.ScorecardCheck{{Name: "Code-Review", Score: 8}},
+			},
+		},
 	}
 	purlRPMPackageProto := &spb.Package{
 		Name:    "openssh-clients",
@@ -417,6 +443,22 @@ func TestScanResultToProtoAndBack(t *testing.T) {
 		},
 		Locations: []string{"/file1"},
 		Plugins:   []string{"os/rpm"},
+		OwnershipHints: []*spb.OwnershipHint{
+			{Location: "/file1", Owner: "@org/security", Source: ".github/CODEOWNERS"},
+		},
+		FileDigests: []*spb.FileDigest{
+			{Location: "/file1", Algorithm: "sha256", Digest: "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"},
+		},
+		ProjectInfo: &spb.ProjectInfo{
+			DirectDependencies:   2,
+			IndirectDependencies: 5,
+			SourceRepository:     "github.com/openssh/openssh-portable",
+			Scorecard: &spb.Scorecard{
+				Date:         timestamppb.New(time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC)),
+				OverallScore: 6.5,
+				Checks:       []*spb.ScorecardCheck{{Name: "Code-Review", Score: 8}},
+			},
+		},
 	}
 	purlPACMANPackage := &extractor.Package{
 		Name:     "zstd",
@@ -534,10 +576,13 @@ func TestScanResultToProtoAndBack(t *testing.T) {
 		Plugins:   []string{"os/nix"},
 	}
 	purlHomebrewPackage := &extractor.Package{
-		Name:      "rclone",
-		Version:   "1.67.0",
-		PURLType:  purl.TypeBrew,
-		Metadata:  &homebrew.Metadata{},
+		Name:     "rclone",
+		Version:  "1.67.0",
+		PURLType: purl.TypeBrew,
+		Metadata: &homebrew.Metadata{
+			Tap:                "homebrew/core",
+			InstalledOnRequest: true,
+		},
 		Locations: []string{"/file1"},
 		Plugins:   []string{homebrew.Name},
 	}
@@ -550,7 +595,12 @@ func TestScanResultToProtoAndBack(t *testing.T) {
 			Name:    "rclone",
 			Version: "1.67.0",
 		},
-		Metadata:  &spb.Package_HomebrewMetadata{},
+		Metadata: &spb.Package_HomebrewMetadata{
+			HomebrewMetadata: &spb.HomebrewPackageMetadata{
+				Tap:                "homebrew/core",
+				InstalledOnRequest: true,
+			},
+		},
 		Locations: []string{"/file1"},
 		Plugins:   []string{"os/homebrew"},
 	}
@@ -667,6 +717,11 @@ func TestScanResultToProtoAndBack(t *testing.T) {
 			Command:     "command1",
 			InBaseImage: true,
 		},
+		LocationProvenance: []*extractor.LocationProvenance{{
+			Location:    "/file1",
+			LayerDigest: "hash1",
+			Path:        "/file1",
+		}},
 	}
 	purlPythonPackageWithLayerDetailsProto := &spb.Package{
 		Name:    "software",
@@ -693,6 +748,11 @@ func TestScanResultToProtoAndBack(t *testing.T) {
 			Command:     "command1",
 			InBaseImage: true,
 		},
+		LocationProvenance: []*spb.LocationProvenance{{
+			Location:    "/file1",
+			LayerDigest: "hash1",
+			Path:        "/file1",
+		}},
 	}
 	mavenPackage := &extractor.Package{
 		Name:      "abc:xyz",
@@ -1291,6 +1351,13 @@ func TestScanResultToProtoAndBack(t *testing.T) {
 				Inventory: inventory.Inventory{
 					Packages: []*extractor.Package{dockerPackage},
 				},
+				ResourceUsage: &result.ResourceUsage{
+					PeakMemoryBytes: 1024,
+					CPUTime:         2 * time.Second,
+					FilesOpened:     3,
+					BytesRead:       4096,
+				},
+				ScanRoots: []*result.ScanRoot{{Path: "/scan", CanonicalPath: "/mnt/scan"}},
 			},
 			want: &spb.ScanResult{
 				Version:   "1.0.0",
@@ -1308,6 +1375,13 @@ func TestScanResultToProtoAndBack(t *testing.T) {
 					Packages:        []*spb.Package{dockerPackageProto},
 					GenericFindings: []*spb.GenericFinding{},
 				},
+				ResourceUsage: &spb.ResourceUsage{
+					PeakMemoryBytes: 1024,
+					CpuTime:         durationpb.New(2 * time.Second),
+					FilesOpened:     3,
+					BytesRead:       4096,
+				},
+				ScanRoots: []*spb.ScanRoot{{Path: "/scan", CanonicalPath: "/mnt/scan"}},
 			},
 		},
 		{
@@ -1563,6 +1637,7 @@ func TestScanResultToProtoAndBack(t *testing.T) {
 				protocmp.IgnoreFields(&spb.Package{}, "extractor_deprecated"),
 				protocmp.IgnoreFields(&spb.ScanResult{}, "inventories_deprecated"),
 				protocmp.IgnoreFields(&spb.ScanResult{}, "findings_deprecated"),
+				ignoreFingerprints,
 			}
 
 			if diff := cmp.Diff(tc.want, got, opts...); diff != "" {
@@ -1589,3 +1664,37 @@ func TestScanResultToProtoAndBack(t *testing.T) {
 		})
 	}
 }
+
+func TestScanResultToStruct(t *testing.T) {
+	startTime := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	endTime := startTime.Add(time.Minute)
+	res := &result.ScanResult{
+		Version:   "1.2.3",
+		StartTime: startTime,
+		EndTime:   endTime,
+		Status:    &plugin.ScanStatus{Status: plugin.ScanStatusSucceeded},
+		PluginStatus: []*plugin.Status{{
+			Name:    wheelegg.Name,
+			Version: 1,
+			Status:  &plugin.ScanStatus{Status: plugin.ScanStatusSucceeded},
+		}},
+		Inventory: inventory.Inventory{Packages: []*extractor.Package{{
+			Name:      "software",
+			Version:   "1.0.0",
+			PURLType:  purl.TypePyPi,
+			Locations: []string{"/file1"},
+			Plugins:   []string{wheelegg.Name},
+		}}},
+		ResourceUsage: &result.ResourceUsage{PeakMemoryBytes: 100, CPUTime: time.Second, FilesOpened: 2, BytesRead: 30},
+		ScanRoots:     []*result.ScanRoot{{Path: "/scan", CanonicalPath: "/mnt/scan"}},
+	}
+
+	resProto, err := proto.ScanResultToProto(res)
+	if err != nil {
+		t.Fatalf("proto.ScanResultToProto(%v): %v", res, err)
+	}
+	got := proto.ScanResultToStruct(resProto)
+	if diff := cmp.Diff(res, got); diff != "" {
+		t.Errorf("proto.ScanResultToStruct(%v) returned unexpected diff (-want +got):\n%s", resProto, diff)
+	}
+}
@@ -20,8 +20,13 @@ import (
 	"time"
 
 	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/inventory/fingerprint"
 	"github.com/google/osv-scalibr/veles"
+	velesawsaccesskey "github.com/google/osv-scalibr/veles/secrets/awsaccesskey"
 	velesgcpsak "github.com/google/osv-scalibr/veles/secrets/gcpsak"
+	velesk8ssatoken "github.com/google/osv-scalibr/veles/secrets/k8ssatoken"
+	veleskubeconfig "github.com/google/osv-scalibr/veles/secrets/kubeconfig"
+	velessshprivatekey "github.com/google/osv-scalibr/veles/secrets/sshprivatekey"
 
 	spb "github.com/google/osv-scalibr/binary/proto/scan_result_go_proto"
 	"google.golang.org/protobuf/types/known/timestamppb"
@@ -76,9 +81,12 @@ func SecretToProto(s *inventory.Secret) (*spb.Secret, error) {
 		return nil, err
 	}
 	return &spb.Secret{
-		Secret:    sec,
-		Status:    res,
-		Locations: secretLocationToProto(s.Location),
+		Secret:       sec,
+		Status:       res,
+		Locations:    secretLocationToProto(s.Location),
+		LayerDetails: layerDetailsToProto(s.LayerDetails),
+		Severity:     genericFindingSeverityEnumToProto[s.Severity],
+		Fingerprint:  fingerprint.Secret(s),
 	}, nil
 }
 
@@ -86,6 +94,14 @@ func velesSecretToProto(s veles.Secret) (*spb.SecretData, error) {
 	switch t := s.(type) {
 	case velesgcpsak.GCPSAK:
 		return gcpsakToProto(t), nil
+	case velessshprivatekey.SSHPrivateKey:
+		return sshPrivateKeyToProto(t), nil
+	case veleskubeconfig.Kubeconfig:
+		return kubeconfigToProto(t), nil
+	case velesk8ssatoken.ServiceAccountToken:
+		return k8sSATokenToProto(t), nil
+	case velesawsaccesskey.AccessKey:
+		return awsAccessKeyToProto(t), nil
 	default:
 		return nil, fmt.Errorf("%w: %T", ErrUnsupportedSecretType, s)
 	}
@@ -115,6 +131,54 @@ func gcpsakToProto(sak velesgcpsak.GCPSAK) *spb.SecretData {
 	}
 }
 
+func sshPrivateKeyToProto(k velessshprivatekey.SSHPrivateKey) *spb.SecretData {
+	return &spb.SecretData{
+		Secret: &spb.SecretData_SshPrivateKey{
+			SshPrivateKey: &spb.SecretData_SSHPrivateKey{
+				Algorithm:   k.Algorithm,
+				Fingerprint: k.Fingerprint,
+				Encrypted:   k.Encrypted,
+			},
+		},
+	}
+}
+
+func kubeconfigToProto(k veleskubeconfig.Kubeconfig) *spb.SecretData {
+	return &spb.SecretData{
+		Secret: &spb.SecretData_Kubeconfig_{
+			Kubeconfig: &spb.SecretData_Kubeconfig{
+				User:              k.User,
+				Cluster:           k.Cluster,
+				Server:            k.Server,
+				ClientCertificate: k.ClientCertificate,
+				Token:             k.Token,
+			},
+		},
+	}
+}
+
+func k8sSATokenToProto(t velesk8ssatoken.ServiceAccountToken) *spb.SecretData {
+	return &spb.SecretData{
+		Secret: &spb.SecretData_KubernetesServiceAccountToken_{
+			KubernetesServiceAccountToken: &spb.SecretData_KubernetesServiceAccountToken{
+				Issuer:         t.Issuer,
+				Namespace:      t.Namespace,
+				ServiceAccount: t.ServiceAccount,
+			},
+		},
+	}
+}
+
+func awsAccessKeyToProto(k velesawsaccesskey.AccessKey) *spb.SecretData {
+	return &spb.SecretData{
+		Secret: &spb.SecretData_AwsAccessKey{
+			AwsAccessKey: &spb.SecretData_AWSAccessKey{
+				AccessKeyId: k.ID,
+			},
+		},
+	}
+}
+
 func validationResultToProto(r inventory.SecretValidationResult) (*spb.SecretStatus, error) {
 	status, err := validationStatusToProto(r.Status)
 	if err != nil {
@@ -183,9 +247,11 @@ func SecretToStruct(s *spb.Secret) (*inventory.Secret, error) {
 	}
 
 	return &inventory.Secret{
-		Secret:     sec,
-		Location:   path,
-		Validation: res,
+		Secret:       sec,
+		Location:     path,
+		Validation:   res,
+		LayerDetails: layerDetailsToStruct(s.GetLayerDetails()),
+		Severity:     genericFindingSeverityEnumToStruct[s.GetSeverity()],
 	}, nil
 }
 
@@ -193,6 +259,14 @@ func velesSecretToStruct(s *spb.SecretData) (veles.Secret, error) {
 	switch s.Secret.(type) {
 	case *spb.SecretData_Gcpsak:
 		return gcpsakToStruct(s.GetGcpsak()), nil
+	case *spb.SecretData_SshPrivateKey:
+		return sshPrivateKeyToStruct(s.GetSshPrivateKey()), nil
+	case *spb.SecretData_Kubeconfig_:
+		return kubeconfigToStruct(s.GetKubeconfig()), nil
+	case *spb.SecretData_KubernetesServiceAccountToken_:
+		return k8sSATokenToStruct(s.GetKubernetesServiceAccountToken()), nil
+	case *spb.SecretData_AwsAccessKey:
+		return awsAccessKeyToStruct(s.GetAwsAccessKey()), nil
 	default:
 		return nil, fmt.Errorf("%w: %T", ErrUnsupportedSecretType, s.GetSecret())
 	}
@@ -220,6 +294,38 @@ func gcpsakToStruct(sakPB *spb.SecretData_GCPSAK) velesgcpsak.GCPSAK {
 	return sak
 }
 
+func sshPrivateKeyToStruct(kPB *spb.SecretData_SSHPrivateKey) velessshprivatekey.SSHPrivateKey {
+	return velessshprivatekey.SSHPrivateKey{
+		Algorithm:   kPB.GetAlgorithm(),
+		Fingerprint: kPB.GetFingerprint(),
+		Encrypted:   kPB.GetEncrypted(),
+	}
+}
+
+func kubeconfigToStruct(kPB *spb.SecretData_Kubeconfig) veleskubeconfig.Kubeconfig {
+	return veleskubeconfig.Kubeconfig{
+		User:              kPB.GetUser(),
+		Cluster:           kPB.GetCluster(),
+		Server:            kPB.GetServer(),
+		ClientCertificate: kPB.GetClientCertificate(),
+		Token:             kPB.GetToken(),
+	}
+}
+
+func k8sSATokenToStruct(tPB *spb.SecretData_KubernetesServiceAccountToken) velesk8ssatoken.ServiceAccountToken {
+	return velesk8ssatoken.ServiceAccountToken{
+		Issuer:         tPB.GetIssuer(),
+		Namespace:      tPB.GetNamespace(),
+		ServiceAccount: tPB.GetServiceAccount(),
+	}
+}
+
+func awsAccessKeyToStruct(kPB *spb.SecretData_AWSAccessKey) velesawsaccesskey.AccessKey {
+	return velesawsaccesskey.AccessKey{
+		ID: kPB.GetAccessKeyId(),
+	}
+}
+
 func validationResultToStruct(r *spb.SecretStatus) (inventory.SecretValidationResult, error) {
 	status, err := validationStatusToStruct(r.GetStatus())
 	if err != nil {
@@ -37,6 +37,24 @@ var (
 		vex.VulnerableCodeCannotBeControlledByAdversary: spb.VexJustification_VULNERABLE_CODE_CANNOT_BE_CONTROLLED_BY_ADVERSARY,
 		vex.InlineMitigationAlreadyExists:               spb.VexJustification_INLINE_MITIGATION_ALREADY_EXISTS,
 	}
+	// structToProtoVEXStatus is a map of struct VEX statuses to their corresponding proto values.
+	structToProtoVEXStatus = map[vex.Status]spb.VexStatus{
+		vex.StatusUnspecified:   spb.VexStatus_VEX_STATUS_UNSPECIFIED,
+		vex.StatusNotAffected:   spb.VexStatus_NOT_AFFECTED,
+		vex.StatusFalsePositive: spb.VexStatus_FALSE_POSITIVE,
+		vex.StatusAcceptedRisk:  spb.VexStatus_ACCEPTED_RISK,
+	}
+	// protoToStructVEXStatus is a map of proto VEX statuses to their corresponding struct values.
+	protoToStructVEXStatus = func() map[spb.VexStatus]vex.Status {
+		m := make(map[spb.VexStatus]vex.Status)
+		for k, v := range structToProtoVEXStatus {
+			m[v] = k
+		}
+		if len(m) != len(structToProtoVEXStatus) {
+			panic("protoToStructVEXStatus does not contain all values from structToProtoVEXStatus")
+		}
+		return m
+	}()
 	// protoToStructVEX is a map of proto VEX justifications to their corresponding struct values.
 	// It is initialized from structToProtoVEX during runtime to ensure both maps are in sync.
 	protoToStructVEX = func() map[spb.VexJustification]vex.Justification {
@@ -65,6 +83,8 @@ func PackageVEXToProto(v *vex.PackageExploitabilitySignal) (*spb.PackageExploita
 	p := &spb.PackageExploitabilitySignal{
 		Plugin:        v.Plugin,
 		Justification: structToProtoVEX[v.Justification],
+		Reason:        v.Reason,
+		Status:        structToProtoVEXStatus[v.Status],
 	}
 	if v.MatchesAllVulns {
 		p.VulnFilter = &spb.PackageExploitabilitySignal_MatchesAllVulns{MatchesAllVulns: true}
@@ -85,6 +105,8 @@ func FindingVEXToProto(v *vex.FindingExploitabilitySignal) *spb.FindingExploitab
 	return &spb.FindingExploitabilitySignal{
 		Plugin:        v.Plugin,
 		Justification: structToProtoVEX[v.Justification],
+		Reason:        v.Reason,
+		Status:        structToProtoVEXStatus[v.Status],
 	}
 }
 
@@ -99,6 +121,8 @@ func PackageVEXToStruct(p *spb.PackageExploitabilitySignal) (*vex.PackageExploit
 	v := &vex.PackageExploitabilitySignal{
 		Plugin:        p.Plugin,
 		Justification: protoToStructVEX[p.Justification],
+		Reason:        p.Reason,
+		Status:        protoToStructVEXStatus[p.Status],
 	}
 	if ids := p.GetVulnIdentifiers(); ids != nil {
 		v.VulnIdentifiers = ids.Identifiers
@@ -117,5 +141,7 @@ func FindingVEXToStruct(p *spb.FindingExploitabilitySignal) *vex.FindingExploita
 	return &vex.FindingExploitabilitySignal{
 		Plugin:        p.Plugin,
 		Justification: protoToStructVEX[p.Justification],
+		Reason:        p.Reason,
+		Status:        protoToStructVEXStatus[p.Status],
 	}
 }
@@ -77,6 +77,36 @@ func TestPackageVEXToProto(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc: "with reason",
+			v: &vex.PackageExploitabilitySignal{
+				Plugin:          "python/requirements",
+				VulnIdentifiers: []string{"CVE-1234"},
+				Reason:          "not reachable",
+			},
+			want: &spb.PackageExploitabilitySignal{
+				Plugin: "python/requirements",
+				VulnFilter: &spb.PackageExploitabilitySignal_VulnIdentifiers{
+					VulnIdentifiers: &spb.VulnIdentifiers{Identifiers: []string{"CVE-1234"}},
+				},
+				Reason: "not reachable",
+			},
+		},
+		{
+			desc: "with status",
+			v: &vex.PackageExploitabilitySignal{
+				Plugin:          "vex/suppression",
+				Status:          vex.StatusAcceptedRisk,
+				MatchesAllVulns: true,
+			},
+			want: &spb.PackageExploitabilitySignal{
+				Plugin: "vex/suppression",
+				Status: spb.VexStatus_ACCEPTED_RISK,
+				VulnFilter: &spb.PackageExploitabilitySignal_MatchesAllVulns{
+					MatchesAllVulns: true,
+				},
+			},
+		},
 		{
 			desc: "both vuln identifiers and matches all vulns set",
 			v: &vex.PackageExploitabilitySignal{
@@ -138,6 +168,19 @@ func TestFindingVEXToProto(t *testing.T) {
 				Justification: spb.VexJustification_COMPONENT_NOT_PRESENT,
 			},
 		},
+		{
+			desc: "with status",
+			v: &vex.FindingExploitabilitySignal{
+				Plugin: "vex/suppression",
+				Status: vex.StatusFalsePositive,
+				Reason: "misidentified package",
+			},
+			want: &spb.FindingExploitabilitySignal{
+				Plugin: "vex/suppression",
+				Status: spb.VexStatus_FALSE_POSITIVE,
+				Reason: "misidentified package",
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -0,0 +1,37 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package sarif provides utilities for writing SARIF logs to the filesystem.
+package sarif
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/google/osv-scalibr/binary/atomicfile"
+	"github.com/google/osv-scalibr/converter/sarif"
+)
+
+// Write writes a SARIF log into a file in the chosen format.
+func Write(doc *sarif.Log, path string, format string) error {
+	if format != "sarif" {
+		return fmt.Errorf("%s has an invalid SARIF format or not supported by SCALIBR", path)
+	}
+	return atomicfile.Write(path, func(w io.Writer) error {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(doc)
+	})
+}
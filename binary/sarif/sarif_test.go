@@ -0,0 +1,84 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sarif_test
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/osv-scalibr/binary/sarif"
+	sarifmodel "github.com/google/osv-scalibr/converter/sarif"
+)
+
+var doc = &sarifmodel.Log{
+	Schema:  sarifmodel.Schema,
+	Version: sarifmodel.Version,
+	Runs: []*sarifmodel.Run{{
+		Tool: &sarifmodel.Tool{Driver: &sarifmodel.Driver{
+			Name: "osv-scalibr",
+			Rules: []*sarifmodel.Rule{{
+				ID:                   "GHSA-1234",
+				DefaultConfiguration: &sarifmodel.ReportingConfiguration{Level: sarifmodel.LevelWarning},
+			}},
+		}},
+		Results: []*sarifmodel.Result{{
+			RuleID:  "GHSA-1234",
+			Level:   sarifmodel.LevelWarning,
+			Message: &sarifmodel.Message{Text: "software 1.0 is affected by GHSA-1234"},
+			Locations: []*sarifmodel.Location{{PhysicalLocation: &sarifmodel.PhysicalLocation{
+				ArtifactLocation: &sarifmodel.ArtifactLocation{URI: "package-lock.json", URIBaseID: sarifmodel.SrcRoot},
+			}}},
+		}},
+	}},
+}
+
+func TestWrite(t *testing.T) {
+	fullPath := filepath.Join(t.TempDir(), "output")
+	if err := sarif.Write(doc, fullPath, "sarif"); err != nil {
+		t.Fatalf("sarif.Write(%v, %s, sarif) returned an error: %v", doc, fullPath, err)
+	}
+
+	got, err := os.ReadFile(fullPath)
+	if err != nil {
+		t.Fatalf("error while reading %s: %v", fullPath, err)
+	}
+	want, err := os.ReadFile("testdata/log.sarif")
+	if err != nil {
+		t.Fatalf("error while reading testdata/log.sarif: %v", err)
+	}
+	wantStr := strings.TrimSpace(string(want))
+	gotStr := strings.TrimSpace(string(got))
+	if runtime.GOOS == "windows" {
+		wantStr = strings.ReplaceAll(wantStr, "\r", "")
+		gotStr = strings.ReplaceAll(gotStr, "\r", "")
+	}
+
+	if diff := cmp.Diff(wantStr, gotStr); diff != "" {
+		t.Errorf("sarif.Write(%v, %s, sarif) produced unexpected results, diff (-want +got):\n%s", doc, fullPath, diff)
+	}
+}
+
+func TestWrite_InvalidFormat(t *testing.T) {
+	fullPath := filepath.Join(t.TempDir(), "output")
+	format := "invalid-format"
+	if err := sarif.Write(doc, fullPath, format); err == nil ||
+		!strings.Contains(err.Error(), "invalid SARIF format") {
+		t.Errorf("sarif.Write(%s, %s) didn't return an invalid format error: %v", fullPath, format, err)
+	}
+}
@@ -19,9 +19,13 @@ package main
 import (
 	"flag"
 	"os"
+	"time"
 
 	"github.com/google/osv-scalibr/binary/cli"
+	"github.com/google/osv-scalibr/binary/diffrunner"
+	"github.com/google/osv-scalibr/binary/enrichrunner"
 	"github.com/google/osv-scalibr/binary/scanrunner"
+	"github.com/google/osv-scalibr/binary/validaterunner"
 	"github.com/google/osv-scalibr/log"
 )
 
@@ -42,6 +46,37 @@ func run(args []string) int {
 			return 1
 		}
 		return scanrunner.RunScan(flags)
+	case "extract":
+		// Like 'scan' but leaves enrichment to a later 'enrich' run, e.g. on a
+		// machine with network access.
+		flags, err := parseFlags(args[2:])
+		if err != nil {
+			log.Errorf("Error parsing CLI args: %v", err)
+			return 1
+		}
+		flags.SkipEnrichers = true
+		return scanrunner.RunScan(flags)
+	case "enrich":
+		flags, err := parseEnrichFlags(args[2:])
+		if err != nil {
+			log.Errorf("Error parsing CLI args: %v", err)
+			return 1
+		}
+		return enrichrunner.RunEnrich(flags)
+	case "diff":
+		flags, err := parseDiffFlags(args[2:])
+		if err != nil {
+			log.Errorf("Error parsing CLI args: %v", err)
+			return 1
+		}
+		return diffrunner.RunDiff(flags)
+	case "validate-sbom":
+		flags, err := parseValidateFlags(args[2:])
+		if err != nil {
+			log.Errorf("Error parsing CLI args: %v", err)
+			return 1
+		}
+		return validaterunner.RunValidate(flags)
 	default:
 		// Assume 'scan' if subcommand is not recognized/specified.
 		flags, err := parseFlags(args[1:])
@@ -53,15 +88,99 @@ func run(args []string) int {
 	}
 }
 
+func parseDiffFlags(args []string) (*diffrunner.Flags, error) {
+	fs := flag.NewFlagSet("scalibr diff", flag.ExitOnError)
+	base := fs.String("base", "", "The path of the scan result to compare against, e.g. from the base commit of a pull request")
+	head := fs.String("head", "", "The path of the new scan result, e.g. from the head commit of a pull request")
+	format := fs.String("format", diffrunner.FormatText, "The output format of the differences: text or json")
+	failOnNewFindings := fs.Bool("fail-on-new-findings", false, "Exit with code 2 if the head scan result contains security findings that aren't in the base scan result")
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	flags := &diffrunner.Flags{
+		Base:              *base,
+		Head:              *head,
+		Format:            *format,
+		FailOnNewFindings: *failOnNewFindings,
+	}
+	if err := diffrunner.ValidateFlags(flags); err != nil {
+		return nil, err
+	}
+	return flags, nil
+}
+
+func parseValidateFlags(args []string) (*validaterunner.Flags, error) {
+	fs := flag.NewFlagSet("scalibr validate-sbom", flag.ExitOnError)
+	input := fs.String("input", "", "The path of the SPDX 2.3 or CycloneDX SBOM to validate")
+	format := fs.String("format", "", "The format of the SBOM, e.g. spdx23-json or cdx-xml. Detected from the file name if not set")
+	repairOutput := fs.String("repair-output", "", "If set, common problems are repaired and the fixed SBOM is written to this path in the input format")
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	flags := &validaterunner.Flags{
+		Input:        *input,
+		Format:       *format,
+		RepairOutput: *repairOutput,
+	}
+	if err := validaterunner.ValidateFlags(flags); err != nil {
+		return nil, err
+	}
+	return flags, nil
+}
+
+func parseEnrichFlags(args []string) (*enrichrunner.Flags, error) {
+	fs := flag.NewFlagSet("scalibr enrich", flag.ExitOnError)
+	input := fs.String("input", "", "The path of the scan result to enrich, e.g. one created with 'scalibr extract'")
+	resultFile := fs.String("result", "", "The path of the enriched output scan result file")
+	var output cli.Array
+	fs.Var(&output, "o", "The path of the enriched scan results in various formats, e.g. -o textproto=result.textproto -o spdx23-json=result.spdx.json")
+	pluginsToRun := cli.NewStringListFlag(nil)
+	fs.Var(&pluginsToRun, "plugins", "Comma-separated list of enrichers to run")
+	root := fs.String("root", "", "The root dir of the scanned artifact, for enrichers that need to access its files. Leave empty if the files aren't available.")
+	offline := fs.Bool("offline", false, "Offline mode: Only run enrichers that don't require network access")
+	localRegistry := fs.String("local-registry", "", "The local directory to store the downloaded manifests during dependency resolution.")
+	mavenRegistry := fs.String("maven-registry", "", "URL of a Maven registry, e.g. a mirror, that is used instead of Maven Central to fetch parent POMs and dependency metadata.")
+	osvDBPath := fs.String("osv-db", "", "Path of a local OSV database export used by the vulnmatch/osvlocal enricher.")
+	suppressionsFile := fs.String("suppressions", "", "Path of a YAML file with suppression rules used by the vex/suppression enricher.")
+	openVEXFiles := cli.NewStringListFlag(nil)
+	fs.Var(&openVEXFiles, "openvex", "Comma-separated list of OpenVEX documents used by the vex/suppression enricher.")
+	baselineFile := fs.String("baseline", "", "Path of a baseline file whose findings are removed from the results by the vex/baseline enricher.")
+	verbose := fs.Bool("verbose", false, "Enable this to print debug logs")
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	flags := &enrichrunner.Flags{
+		Input:            *input,
+		ResultFile:       *resultFile,
+		Output:           output,
+		PluginsToRun:     pluginsToRun.GetSlice(),
+		Root:             *root,
+		Offline:          *offline,
+		LocalRegistry:    *localRegistry,
+		MavenRegistry:    *mavenRegistry,
+		OSVDBPath:        *osvDBPath,
+		SuppressionsFile: *suppressionsFile,
+		OpenVEXFiles:     openVEXFiles.GetSlice(),
+		Baseline:         *baselineFile,
+		Verbose:          *verbose,
+	}
+	if err := enrichrunner.ValidateFlags(flags); err != nil {
+		return nil, err
+	}
+	return flags, nil
+}
+
 func parseFlags(args []string) (*cli.Flags, error) {
 	fs := flag.NewFlagSet("scalibr", flag.ExitOnError)
 	printVersion := fs.Bool("version", false, `Prints the version of the scanner`)
 	root := fs.String("root", "", `The root dir used by detectors and by file walking during extraction (e.g.: "/", "c:\" or ".")`)
 	resultFile := fs.String("result", "", "The path of the output scan result file")
 	var output cli.Array
-	fs.Var(&output, "o", "The path of the scanner outputs in various formats, e.g. -o textproto=result.textproto -o spdx23-json=result.spdx.json -o cdx-json=result.cyclonedx.json")
+	fs.Var(&output, "o", "The path of the scanner outputs in various formats, e.g. -o textproto=result.textproto -o spdx23-json=result.spdx.json -o cdx-json=result.cyclonedx.json -o sarif=result.sarif")
 	pluginsToRun := cli.NewStringListFlag(nil)
 	fs.Var(&pluginsToRun, "plugins", "Comma-separated list of plugin to run")
+	ecosystems := cli.NewStringListFlag(nil)
+	fs.Var(&ecosystems, "ecosystems", "Comma-separated list of ecosystems to scan for, e.g. npm,pypi,debian. Enables the plugins that find and annotate packages of these ecosystems in addition to the ones set with --plugins.")
 	extractorsToRun := cli.NewStringListFlag(nil)
 	fs.Var(&extractorsToRun, "extractors", "[Legacy field, prefer using --plugins instead] Comma-separated list of extractor plugins to run")
 	detectorsToRun := cli.NewStringListFlag(nil)
@@ -74,13 +193,37 @@ func parseFlags(args []string) (*cli.Flags, error) {
 	fs.Var(&dirsToSkip, "skip-dirs", "Comma-separated list of file paths to avoid traversing")
 	skipDirRegex := fs.String("skip-dir-regex", "", "If the regex matches a directory, it will be skipped. The regex is matched against the absolute file path.")
 	skipDirGlob := fs.String("skip-dir-glob", "", "If the glob matches a directory, it will be skipped. The glob is matched against the absolute file path.")
+	pathFilterConfig := fs.String("path-filter-config", "", "Path of a YAML file with ordered include and exclude rules (globs or regexes) for the paths visited during the filesystem walk. The first matching rule decides whether a path is scanned.")
+	mountPolicy := fs.String("mount-policy", "", "Comma-separated list of <class or filesystem type>=<scan|defer|skip> entries that override what the walk does with mount points of the scanned host, e.g. \"network=defer,nfs4=scan\". The classes are local, pseudo, network and fuse. By default pseudo and network filesystems are skipped and FUSE filesystems are walked last.")
 	maxFileSize := fs.Int("max-file-size", 0, "Files larger than this size in bytes are skipped. If 0, no limit is applied.")
 	useGitignore := fs.Bool("use-gitignore", false, "Skip files declared in .gitignore files in source repos.")
+	onlyGitTracked := fs.Bool("only-git-tracked", false, "Only extract from files tracked in the git index of the scan root, including staged changes. Untracked and ignored files are skipped.")
+	hashAlgorithms := cli.NewStringListFlag(nil)
+	fs.Var(&hashAlgorithms, "hash-algorithms", "Comma-separated list of digest algorithms used by plugins that hash files, e.g. sha256,blake3. Supported: sha256, sha512, sha1, blake3, md5")
+	fileHashBudget := fs.Int64("file-hash-budget", 0, "The number of bytes the misc/filehashes annotator hashes during a scan, 1 GiB by default. Files that don't fit into the budget aren't hashed. Set to -1 to hash all files.")
+	dedupStrategies := cli.NewStringListFlag(nil)
+	fs.Var(&dedupStrategies, "dedup", "Comma-separated list of strategies for merging packages reported by several extractors. Supported: union-locations, prefer-lockfile, merge-metadata. If not set, duplicates are kept.")
+	fipsMode := fs.Bool("fips", false, "FIPS-compliant mode: Only allow FIPS 140 approved hash algorithms")
 	remoteImage := fs.String("remote-image", "", "The remote image to scan. If specified, SCALIBR pulls and scans this image instead of the local filesystem.")
 	imageTarball := fs.String("image-tarball", "", "The path to a tarball containing a container image. These are commonly procuded using `docker save`. If specified, SCALIBR scans this image instead of the local filesystem.")
 	imageDockerLocal := fs.String("image-local-docker", "", "The docker image that is available in the local filesystem. These are the images from the output of \"docker image ls\". If specified, SCALIBR scans this image. The name of the image MUST also include the tag of the image <image_name>:<image_tag>.")
+	streamImageLayers := fs.Bool("stream-image-layers", false, "Read the files of --image-tarball or --image-local-docker directly from the image layers instead of unpacking them to a temporary file. Uses less disk space but can be slower on images with many files.")
 	imagePlatform := fs.String("image-platform", "", "The platform of the remote image to scan. If not specified, the platform of the client is used. Format is os/arch (e.g. linux/arm64)")
+	filesFrom := fs.String("files-from", "", "A file with a newline-separated list of files to extract, e.g. the files changed in a build, or - to read the list from stdin. Relative paths are resolved from --root. Can be combined with the files passed as arguments.")
+	stdinTar := fs.Bool("stdin-tar", false, "Scan the files of a tar archive piped to stdin, e.g. from 'git archive HEAD'. The archive is read into memory.")
+	imageSquashfs := fs.String("image-squashfs", "", "The path to a squashfs image to scan, e.g. a snap package or the root filesystem of a firmware image. If specified, SCALIBR scans the image contents instead of the local filesystem.")
+	imageVMDisk := fs.String("image-vm-disk", "", "The path to a virtual machine disk image (raw, qcow2, VMDK or fixed VHD) to scan. If specified, SCALIBR scans the root filesystem stored on the disk instead of the local filesystem. Only ext2/3/4 filesystems are supported.")
+	webDAVURL := fs.String("webdav-url", "", "The URL of a WebDAV share to scan. If specified, SCALIBR scans the share instead of the local filesystem.")
+	webDAVUser := fs.String("webdav-user", "", "The username for authenticating to the --webdav-url share. The password is read from the "+cli.WebDAVPasswordEnv+" environment variable.")
 	goBinaryVersionFromContent := fs.Bool("gobinary-version-from-content", false, "Parse the main module version from the binary content. Off by default because this drastically increases latency (~10x).")
+	cargoAuditablePanicPaths := fs.Bool("cargoauditable-panic-paths", false, "Recover the crates of Rust binaries without cargo auditable data from the source file paths of panic locations. Off by default because every executable without cargo auditable data is read in full. The result only includes crates from a registry that contain a panic location.")
+	goBinaryStrippedBinaries := fs.Bool("gobinary-stripped-binaries", false, "Recover the modules of Go binaries without buildinfo from the source file paths in the binary content. Off by default because every executable that isn't a regular Go binary is read in full.")
+	osvDBPath := fs.String("osv-db", "", "Path of a local OSV database export (a directory or zip file of OSV records, e.g. the per-ecosystem all.zip files) used by the vulnmatch/osvlocal enricher to find vulnerabilities without network access.")
+	suppressionsFile := fs.String("suppressions", "", "Path of a YAML file with suppression rules used by the vex/suppression enricher to mark findings as not-affected, false-positive or accepted-risk.")
+	openVEXFiles := cli.NewStringListFlag(nil)
+	fs.Var(&openVEXFiles, "openvex", "Comma-separated list of OpenVEX documents used by the vex/suppression enricher to mark findings as not affected.")
+	baselineFile := fs.String("baseline", "", "Path of a baseline file, e.g. one created with --write-baseline. The vex/baseline enricher removes the findings in it from the results so that only new findings are reported.")
+	writeBaseline := fs.String("write-baseline", "", "Path to write a baseline of the security findings of this scan to, e.g. to commit it to the scanned repository and pass it to later scans with --baseline.")
 	govulncheckDBPath := fs.String("govulncheck-db", "", "Path to the offline DB for the govulncheck detectors to use. Leave empty to run the detectors in online mode.")
 	spdxDocumentName := fs.String("spdx-document-name", "", "The 'name' field for the output SPDX document")
 	spdxDocumentNamespace := fs.String("spdx-document-namespace", "", "The 'documentNamespace' field for the output SPDX document")
@@ -89,12 +232,34 @@ func parseFlags(args []string) (*cli.Flags, error) {
 	cdxComponentType := fs.String("cdx-component-type", "", "The 'metadata.component.type' field for the output CDX document")
 	cdxComponentVersion := fs.String("cdx-component-version", "", "The 'metadata.component.version' field for the output CDX document")
 	cdxAuthors := fs.String("cdx-authors", "", "The 'authors' field for the output CDX document. Format is --cdx-authors=author1,author2")
+	sbomNamingRules := fs.String("sbom-naming-rules", "", "Path of a YAML file with rules for the package names, component IDs (SPDX IDs and CycloneDX bom-refs) and document namespace of the output SBOMs.")
 	verbose := fs.Bool("verbose", false, "Enable this to print debug logs")
 	explicitExtractors := fs.Bool("explicit-extractors", false, "If set, the program will exit with an error if not all extractors required by enabled detectors are explicitly enabled.")
 	filterByCapabilities := fs.Bool("filter-by-capabilities", true, "If set, plugins whose requirements (network access, OS, etc.) aren't satisfied by the scanning environment will be silently disabled instead of throwing a validation error.")
 	windowsAllDrives := fs.Bool("windows-all-drives", false, "Scan all drives on Windows")
 	offline := fs.Bool("offline", false, "Offline mode: Run only plugins that don't require network access")
+	pluginDir := fs.String("plugin-dir", "", "Directory to load additional plugins from at startup: Go plugins (.so) exporting a Plugins() function or executables implementing the exec plugin protocol. All loaded plugins are enabled.")
+	failOnSeverity := fs.String("fail-on-severity", "", "Exit with code 2 if a security finding of at least this severity is found. One of minimal, low, medium, high, critical")
+	failOnPluginErrors := fs.Bool("fail-on-plugin-errors", false, "Exit with code 3 if any of the plugins failed or only partially succeeded")
+	policyFile := fs.String("policy", "", "Path of a YAML policy file that the scan result is checked against, e.g. the maximum severity of findings and banned licenses or packages. Exits with code 5 if the policy is violated.")
+	summaryJSON := fs.Bool("summary-json", false, "Print a single-line JSON summary of the scan to stderr once the scan is done")
+	progress := fs.Bool("progress", false, "Periodically log the progress of the filesystem walk: visited inodes, matched files, found packages and the current path")
+	expectedInodes := fs.Int("expected-inodes", 0, "The expected number of inodes to visit, e.g. from a previous scan of the same host. Used to estimate the remaining scan time in the --progress logs.")
+	targetOS := fs.String("target-os", "", "The OS the scanned software is deployed to, e.g. linux, windows or darwin. Used to skip conditional lockfile dependencies (PEP 508 markers, npm os filters) that aren't installed there.")
+	targetArch := fs.String("target-arch", "", "The CPU architecture the scanned software is deployed to in GOARCH notation, e.g. amd64 or arm64.")
+	targetPythonVersion := fs.String("target-python-version", "", "The Python version of the target environment, e.g. 3.11, used to evaluate PEP 508 markers.")
+	targetNodeVersion := fs.String("target-node-version", "", "The Node.js version of the target environment, e.g. 20.11.0, used to evaluate the engines of optional npm dependencies.")
+	pluginTimeout := fs.Duration("plugin-timeout", 0, "How long a single plugin run (for extractors: the extraction of a single file) can take before it's cancelled, e.g. 30s. Plugins that time out are reported as failed or partially succeeded instead of failing the scan. If 0, no limit is applied.")
+	pluginTimeoutOverrides := cli.NewStringListFlag(nil)
+	interruptGracePeriod := fs.Duration("interrupt-grace-period", 10*time.Second, "How long to wait for the running plugins to stop after SIGINT or SIGTERM. The results found until then are written and marked as interrupted. If the plugins don't stop in time, the scan exits without writing any results.")
+	fs.Var(&pluginTimeoutOverrides, "plugin-timeout-overrides", "Comma-separated list of per-plugin timeouts that override --plugin-timeout, e.g. python/wheelegg=2m,govulncheck/binary=0")
+	depGraphDir := fs.String("dep-graph-dir", "", "Directory to write the resolved dependency graph of each Cargo, Go and npm application found during the scan to, one file per lockfile")
+	depGraphFormat := fs.String("dep-graph-format", "", "The format of the dependency graphs written to --dep-graph-dir: dot (default) or json")
+	maxBytesPerFile := fs.Int64("max-bytes-per-file", 0, "Plugins fail with a budget exceeded error when reading more than this many bytes from a single file. If 0, no limit is applied.")
+	maxTotalBytes := fs.Int64("max-total-bytes", 0, "Plugins fail with a budget exceeded error once the scan has read this many bytes in total. If 0, no limit is applied.")
+	reportResourceUsage := fs.Bool("report-resource-usage", false, "Record the peak memory, CPU time, files opened and bytes read of the scan, overall and per plugin, in the scan result.")
 	localRegistry := fs.String("local-registry", "", "The local directory to store the downloaded manifests during dependency resolution.")
+	mavenRegistry := fs.String("maven-registry", "", "URL of a Maven registry, e.g. a mirror, that is used instead of Maven Central to fetch parent POMs and dependency metadata.")
 
 	if err := fs.Parse(args); err != nil {
 		return nil, err
@@ -107,6 +272,7 @@ func parseFlags(args []string) (*cli.Flags, error) {
 		ResultFile:                 *resultFile,
 		Output:                     output,
 		PluginsToRun:               pluginsToRun.GetSlice(),
+		Ecosystems:                 ecosystems.GetSlice(),
 		ExtractorsToRun:            extractorsToRun.GetSlice(),
 		DetectorsToRun:             detectorsToRun.GetSlice(),
 		AnnotatorsToRun:            annotatorsToRun.GetSlice(),
@@ -115,14 +281,33 @@ func parseFlags(args []string) (*cli.Flags, error) {
 		DirsToSkip:                 dirsToSkip.GetSlice(),
 		SkipDirRegex:               *skipDirRegex,
 		SkipDirGlob:                *skipDirGlob,
+		PathFilterConfig:           *pathFilterConfig,
+		MountPolicy:                *mountPolicy,
 		MaxFileSize:                *maxFileSize,
 		UseGitignore:               *useGitignore,
+		OnlyGitTracked:             *onlyGitTracked,
+		HashAlgorithms:             hashAlgorithms.GetSlice(),
+		FileHashBudget:             *fileHashBudget,
+		FIPSMode:                   *fipsMode,
+		DedupStrategies:            dedupStrategies.GetSlice(),
 		RemoteImage:                *remoteImage,
 		ImageLocal:                 *imageDockerLocal,
 		ImageTarball:               *imageTarball,
+		StreamImageLayers:          *streamImageLayers,
 		ImagePlatform:              *imagePlatform,
+		ImageSquashfs:              *imageSquashfs,
+		ImageVMDisk:                *imageVMDisk,
+		FilesFrom:                  *filesFrom,
+		StdinTar:                   *stdinTar,
+		WebDAVURL:                  *webDAVURL,
+		WebDAVUser:                 *webDAVUser,
 		GoBinaryVersionFromContent: *goBinaryVersionFromContent,
+		GoBinaryStrippedBinaries:   *goBinaryStrippedBinaries,
+		CargoAuditablePanicPaths:   *cargoAuditablePanicPaths,
 		GovulncheckDBPath:          *govulncheckDBPath,
+		OSVDBPath:                  *osvDBPath,
+		SuppressionsFile:           *suppressionsFile,
+		OpenVEXFiles:               openVEXFiles.GetSlice(),
 		SPDXDocumentName:           *spdxDocumentName,
 		SPDXDocumentNamespace:      *spdxDocumentNamespace,
 		SPDXCreators:               *spdxCreators,
@@ -130,12 +315,35 @@ func parseFlags(args []string) (*cli.Flags, error) {
 		CDXComponentType:           *cdxComponentType,
 		CDXComponentVersion:        *cdxComponentVersion,
 		CDXAuthors:                 *cdxAuthors,
+		SBOMNamingRules:            *sbomNamingRules,
 		Verbose:                    *verbose,
 		ExplicitExtractors:         *explicitExtractors,
 		FilterByCapabilities:       *filterByCapabilities,
 		WindowsAllDrives:           *windowsAllDrives,
 		Offline:                    *offline,
 		LocalRegistry:              *localRegistry,
+		MavenRegistry:              *mavenRegistry,
+		PluginDir:                  *pluginDir,
+		FailOnSeverity:             *failOnSeverity,
+		FailOnPluginErrors:         *failOnPluginErrors,
+		SummaryJSON:                *summaryJSON,
+		Progress:                   *progress,
+		ExpectedInodes:             *expectedInodes,
+		TargetOS:                   *targetOS,
+		TargetArch:                 *targetArch,
+		TargetPythonVersion:        *targetPythonVersion,
+		TargetNodeVersion:          *targetNodeVersion,
+		PluginTimeout:              *pluginTimeout,
+		PluginTimeoutOverrides:     pluginTimeoutOverrides.GetSlice(),
+		DepGraphDir:                *depGraphDir,
+		DepGraphFormat:             *depGraphFormat,
+		ReportResourceUsage:        *reportResourceUsage,
+		MaxBytesPerFile:            *maxBytesPerFile,
+		MaxTotalBytes:              *maxTotalBytes,
+		InterruptGracePeriod:       *interruptGracePeriod,
+		Baseline:                   *baselineFile,
+		WriteBaselineFile:          *writeBaseline,
+		PolicyFile:                 *policyFile,
 	}
 	if err := cli.ValidateFlags(flags); err != nil {
 		return nil, err
@@ -15,6 +15,7 @@
 package main
 
 import (
+	"os"
 	"path/filepath"
 	"strings"
 	"testing"
@@ -63,6 +64,70 @@ func TestRun(t *testing.T) {
 			args: []string{"scalibr", "unknown", "--root", "{dir}", "--result", filepath.Join("{dir}", "result.textproto")},
 			want: 1,
 		},
+		{
+			desc: "diff subcommand",
+			setupFunc: func(t *testing.T) string {
+				t.Helper()
+				dir := t.TempDir()
+				if err := os.WriteFile(filepath.Join(dir, "result.textproto"), []byte("inventory: {}"), 0644); err != nil {
+					t.Fatalf("os.WriteFile(): %v", err)
+				}
+				return dir
+			},
+			args: []string{"scalibr", "diff", "--base", filepath.Join("{dir}", "result.textproto"), "--head", filepath.Join("{dir}", "result.textproto")},
+			want: 0,
+		},
+		{
+			desc:      "extract subcommand",
+			setupFunc: tempDir,
+			args:      []string{"scalibr", "extract", "--root", "{dir}", "--result", filepath.Join("{dir}", "result.textproto")},
+			want:      0,
+		},
+		{
+			desc: "enrich subcommand",
+			setupFunc: func(t *testing.T) string {
+				t.Helper()
+				dir := t.TempDir()
+				if err := os.WriteFile(filepath.Join(dir, "result.textproto"), []byte("inventory: {}"), 0644); err != nil {
+					t.Fatalf("os.WriteFile(): %v", err)
+				}
+				return dir
+			},
+			args: []string{"scalibr", "enrich", "--input", filepath.Join("{dir}", "result.textproto"), "--plugins", "remediation/fixedversion", "--result", filepath.Join("{dir}", "enriched.textproto")},
+			want: 0,
+		},
+		{
+			desc:      "enrich subcommand without plugins",
+			setupFunc: tempDir,
+			args:      []string{"scalibr", "enrich", "--input", filepath.Join("{dir}", "result.textproto"), "--result", filepath.Join("{dir}", "enriched.textproto")},
+			want:      1,
+		},
+		{
+			desc:      "diff subcommand without head",
+			setupFunc: tempDir,
+			args:      []string{"scalibr", "diff", "--base", filepath.Join("{dir}", "result.textproto")},
+			want:      1,
+		},
+		{
+			desc: "validate-sbom subcommand",
+			setupFunc: func(t *testing.T) string {
+				t.Helper()
+				dir := t.TempDir()
+				bom := `{"bomFormat": "CycloneDX", "specVersion": "1.6", "version": 1}`
+				if err := os.WriteFile(filepath.Join(dir, "bom.cdx.json"), []byte(bom), 0644); err != nil {
+					t.Fatalf("os.WriteFile(): %v", err)
+				}
+				return dir
+			},
+			args: []string{"scalibr", "validate-sbom", "--input", filepath.Join("{dir}", "bom.cdx.json")},
+			want: 0,
+		},
+		{
+			desc:      "validate-sbom subcommand without input",
+			setupFunc: tempDir,
+			args:      []string{"scalibr", "validate-sbom"},
+			want:      1,
+		},
 		{
 			desc:      "extract with unknown cdx-component-type",
 			setupFunc: tempDir,
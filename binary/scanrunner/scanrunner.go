@@ -16,22 +16,33 @@
 package scanrunner
 
 import (
+	"cmp"
 	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"slices"
+	"strings"
+	"syscall"
+	"time"
 
 	scalibr "github.com/google/osv-scalibr"
 	scalibrlayerimage "github.com/google/osv-scalibr/artifact/image/layerscanning/image"
 	"github.com/google/osv-scalibr/binary/cli"
 	"github.com/google/osv-scalibr/log"
 	"github.com/google/osv-scalibr/plugin"
+	"github.com/google/osv-scalibr/result"
 	"github.com/google/osv-scalibr/version"
 )
 
 // RunScan executes the scan with the given CLI flags
 // and returns the exit code passed to os.Exit() in the main binary.
+// See the ExitCode constants for the possible values.
 func RunScan(flags *cli.Flags) int {
 	if flags.PrintVersion {
 		log.Infof("OSV-SCALIBR v%s", version.ScannerVersion)
-		return 0
+		return ExitCodeSuccess
 	}
 
 	if flags.Verbose {
@@ -41,7 +52,13 @@ func RunScan(flags *cli.Flags) int {
 	cfg, err := flags.GetScanConfig()
 	if err != nil {
 		log.Errorf("%v.GetScanConfig(): %v", flags, err)
-		return 1
+		return ExitCodeFatal
+	}
+	// Read the policy before scanning so that an invalid policy fails fast.
+	pol, err := flags.Policy()
+	if err != nil {
+		log.Errorf("Error reading policy: %v", err)
+		return ExitCodeFatal
 	}
 
 	log.Infof("Running scan with %d plugins", len(cfg.Plugins))
@@ -49,70 +66,172 @@ func RunScan(flags *cli.Flags) int {
 		log.Infof("Paths to extract: %s", cfg.PathsToExtract)
 	}
 
-	var result *scalibr.ScanResult
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	result, err := runWithGracePeriod(ctx, stop, flags.InterruptGracePeriod, func(ctx context.Context) (*scalibr.ScanResult, error) {
+		return scan(ctx, flags, cfg)
+	})
+	if err != nil {
+		log.Errorf("%v", err)
+		if ctx.Err() != nil {
+			return ExitCodeInterrupted
+		}
+		return ExitCodeFatal
+	}
+
+	log.Infof("Scan status: %v", result.Status)
+	log.Infof(
+		"Found %d software packages, %d security findings",
+		len(result.Inventory.Packages),
+		len(result.Inventory.PackageVulns)+len(result.Inventory.GenericFindings),
+	)
+	if u := result.ResourceUsage; u != nil {
+		log.Infof(
+			"Resource usage: peak memory %d bytes, CPU time %v, %d files opened, %d bytes read",
+			u.PeakMemoryBytes, u.CPUTime, u.FilesOpened, u.BytesRead,
+		)
+		logSlowestPlugins(u.Plugins)
+	}
+
+	if err := flags.WriteBaseline(result); err != nil {
+		log.Errorf("Error writing baseline: %v", err)
+		return ExitCodeFatal
+	}
+	if err := flags.WriteScanResults(result); err != nil {
+		log.Errorf("Error writing scan results: %v", err)
+		return ExitCodeFatal
+	}
+	if err := flags.WriteDependencyGraphs(result, cfg.ScanRoots); err != nil {
+		log.Errorf("Error writing dependency graphs: %v", err)
+		return ExitCodeFatal
+	}
+
+	if result.Status.Status != plugin.ScanStatusSucceeded {
+		log.Errorf("Scan wasn't successful: %s", result.Status.FailureReason)
+	}
+
+	// The threshold was already validated together with the other flags.
+	threshold, _ := flags.SeverityThreshold()
+	summary := Summarize(result, threshold, flags.FailOnPluginErrors)
+	if pol != nil {
+		summary.ApplyPolicy(pol.Evaluate(result))
+	}
+	switch summary.ExitCode {
+	case ExitCodeFindings:
+		log.Errorf("Found %d security findings with severity %s or higher", summary.FindingsAboveThreshold, flags.FailOnSeverity)
+	case ExitCodePartialErrors:
+		log.Errorf("Plugins failed: %s", strings.Join(summary.FailedPlugins, ", "))
+	case ExitCodePolicyViolations:
+		for _, v := range summary.PolicyViolations {
+			log.Errorf("Policy violation (%s): %s", v.Rule, v.Message)
+		}
+	}
+	if flags.SummaryJSON {
+		printSummary(summary)
+	}
+	return summary.ExitCode
+}
+
+// slowestPluginsToLog is the number of plugins logSlowestPlugins logs.
+const slowestPluginsToLog = 5
+
+// logSlowestPlugins logs the resource usage of the plugins that ran the longest.
+func logSlowestPlugins(usage []*result.PluginResourceUsage) {
+	usage = slices.Clone(usage)
+	slices.SortFunc(usage, func(a, b *result.PluginResourceUsage) int {
+		return cmp.Compare(b.WallTime, a.WallTime)
+	})
+	for _, u := range usage[:min(len(usage), slowestPluginsToLog)] {
+		log.Infof(
+			"Plugin %s: wall time %v, CPU time %v, %d files extracted, %d bytes read, %d bytes allocated",
+			u.Name, u.WallTime, u.CPUTime, u.FilesExtracted, u.BytesRead, u.AllocatedBytes,
+		)
+	}
+}
+
+// scan runs the scan on the target specified in the flags.
+func scan(ctx context.Context, flags *cli.Flags, cfg *scalibr.ScanConfig) (*scalibr.ScanResult, error) {
 	if flags.ImageTarball != "" {
 		layerCfg := scalibrlayerimage.DefaultConfig()
+		layerCfg.StreamFromLayers = flags.StreamImageLayers
 		log.Infof("Scanning image tarball: %s", flags.ImageTarball)
 		img, err := scalibrlayerimage.FromTarball(flags.ImageTarball, layerCfg)
 		if err != nil {
-			log.Errorf("Failed to create image from tarball: %v", err)
-			return 1
+			return nil, fmt.Errorf("failed to create image from tarball: %w", err)
 		}
 		defer func() {
-			if tmpErr := img.CleanUp(); tmpErr != nil {
-				log.Errorf("Failed to clean up image: %v", tmpErr)
+			if err := img.CleanUp(); err != nil {
+				log.Errorf("Failed to clean up image: %v", err)
 			}
 		}()
-		result, err = scalibr.New().ScanContainer(context.Background(), img, cfg)
-
-		cleanupErr := img.CleanUp()
-		if cleanupErr != nil {
-			log.Errorf("failed to clean up image: %s", err)
-		}
-
+		result, err := scalibr.New().ScanContainer(ctx, img, cfg)
 		if err != nil {
-			log.Errorf("Failed to scan tarball: %v", err)
-			return 1
+			return nil, fmt.Errorf("failed to scan tarball: %w", err)
 		}
-	} else if flags.ImageLocal != "" { // We will scan an image in the local hard disk
+		return result, nil
+	}
+	if flags.ImageLocal != "" { // We will scan an image in the local hard disk
 		layerCfg := scalibrlayerimage.DefaultConfig()
+		layerCfg.StreamFromLayers = flags.StreamImageLayers
 		log.Infof("Scanning local image: %s", flags.ImageLocal)
 		img, err := scalibrlayerimage.FromLocalDockerImage(flags.ImageLocal, layerCfg)
 		if err != nil {
-			log.Errorf("Failed to scan local image: %v", err)
-			return 1
+			return nil, fmt.Errorf("failed to scan local image: %w", err)
 		}
 		defer func() {
-			if tmpErr := img.CleanUp(); tmpErr != nil {
-				log.Errorf("Failed to clean up image: %v", tmpErr)
+			if err := img.CleanUp(); err != nil {
+				log.Errorf("Failed to clean up image: %v", err)
 			}
 		}()
-		result, err = scalibr.New().ScanContainer(context.Background(), img, cfg)
+		result, err := scalibr.New().ScanContainer(ctx, img, cfg)
 		if err != nil {
-			log.Errorf("Failed to scan container: %v", err)
-			return 1
+			return nil, fmt.Errorf("failed to scan container: %w", err)
 		}
-	} else {
-		log.Infof("Scan roots: %s", cfg.ScanRoots)
-		result = scalibr.New().Scan(context.Background(), cfg)
+		return result, nil
 	}
+	log.Infof("Scan roots: %s", cfg.ScanRoots)
+	return scalibr.New().Scan(ctx, cfg), nil
+}
 
-	log.Infof("Scan status: %v", result.Status)
-	log.Infof(
-		"Found %d software packages, %d security findings",
-		len(result.Inventory.Packages),
-		len(result.Inventory.PackageVulns)+len(result.Inventory.GenericFindings),
-	)
-
-	if err := flags.WriteScanResults(result); err != nil {
-		log.Errorf("Error writing scan results: %v", err)
-		return 1
+// runWithGracePeriod runs the scan function until it returns. Once ctx is
+// cancelled, e.g. on SIGTERM, the function gets the grace period to return the
+// results found until then. stop is called on cancellation so that a second
+// signal terminates the process right away.
+func runWithGracePeriod(ctx context.Context, stop func(), gracePeriod time.Duration, f func(context.Context) (*scalibr.ScanResult, error)) (*scalibr.ScanResult, error) {
+	type scanOutput struct {
+		result *scalibr.ScanResult
+		err    error
 	}
+	done := make(chan scanOutput, 1)
+	go func() {
+		result, err := f(ctx)
+		done <- scanOutput{result, err}
+	}()
 
-	if result.Status.Status != plugin.ScanStatusSucceeded {
-		log.Errorf("Scan wasn't successful: %s", result.Status.FailureReason)
-		return 1
+	select {
+	case out := <-done:
+		return out.result, out.err
+	case <-ctx.Done():
+	}
+	stop()
+	log.Warnf("Scan interrupted, waiting up to %v for the plugins to stop", gracePeriod)
+	timer := time.NewTimer(gracePeriod)
+	defer timer.Stop()
+	select {
+	case out := <-done:
+		return out.result, out.err
+	case <-timer.C:
+		return nil, fmt.Errorf("plugins didn't stop within %v after the scan was interrupted", gracePeriod)
 	}
+}
 
-	return 0
+// printSummary prints the summary as a single JSON line to stderr so that CI systems
+// can parse it from the last line of the output.
+func printSummary(summary *Summary) {
+	b, err := json.Marshal(summary)
+	if err != nil {
+		log.Errorf("json.Marshal(%v): %v", summary, err)
+		return
+	}
+	fmt.Fprintln(os.Stderr, string(b))
 }
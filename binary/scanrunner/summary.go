@@ -0,0 +1,141 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scanrunner
+
+import (
+	scalibr "github.com/google/osv-scalibr"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/inventory/severity"
+	"github.com/google/osv-scalibr/plugin"
+	"github.com/google/osv-scalibr/result/policy"
+)
+
+// Exit codes returned by RunScan.
+const (
+	// ExitCodeSuccess means that the scan completed and no findings at or above the
+	// --fail-on-severity threshold were found.
+	ExitCodeSuccess = 0
+	// ExitCodeFatal means that the scan could not be run or its results could not be written.
+	ExitCodeFatal = 1
+	// ExitCodeFindings means that findings at or above the --fail-on-severity threshold were found.
+	ExitCodeFindings = 2
+	// ExitCodePartialErrors means that some plugins failed and --fail-on-plugin-errors is set.
+	ExitCodePartialErrors = 3
+	// ExitCodeInterrupted means that the scan was interrupted, e.g. by SIGTERM, and
+	// only the partial results found until then were written.
+	ExitCodeInterrupted = 4
+	// ExitCodePolicyViolations means that the scan result doesn't meet the --policy.
+	ExitCodePolicyViolations = 5
+)
+
+// Summary is a machine-readable summary of a scan, printed as a single JSON line
+// with --summary-json.
+type Summary struct {
+	Status                 string         `json:"status"`
+	ExitCode               int            `json:"exit_code"`
+	Packages               int            `json:"packages"`
+	Findings               int            `json:"findings"`
+	FindingsBySeverity     map[string]int `json:"findings_by_severity"`
+	FindingsAboveThreshold int            `json:"findings_above_threshold"`
+	// Findings with exploitability signals, e.g. from VEX statements or
+	// suppression rules. They don't count towards the threshold.
+	Suppressed    int      `json:"suppressed"`
+	FailedPlugins []string `json:"failed_plugins"`
+	// Violations of the --policy, if set.
+	PolicyViolations []*policy.Violation `json:"policy_violations,omitempty"`
+}
+
+// Summarize creates a summary of the scan result and computes the exit code of the scan.
+// Findings at or above the threshold severity make the scan fail with ExitCodeFindings.
+// A threshold of SeverityUnspecified disables the severity check.
+func Summarize(result *scalibr.ScanResult, threshold inventory.SeverityEnum, failOnPluginErrors bool) *Summary {
+	s := &Summary{
+		Status:             scanStatusName(result.Status),
+		Packages:           len(result.Inventory.Packages),
+		FindingsBySeverity: map[string]int{},
+		FailedPlugins:      []string{},
+	}
+
+	type finding struct {
+		sev        inventory.SeverityEnum
+		suppressed bool
+	}
+	var findings []finding
+	for _, v := range result.Inventory.PackageVulns {
+		findings = append(findings, finding{severity.PackageVuln(v), len(v.ExploitabilitySignals) > 0})
+	}
+	for _, f := range result.Inventory.GenericFindings {
+		findings = append(findings, finding{severity.GenericFinding(f), len(f.ExploitabilitySignals) > 0})
+	}
+	for _, f := range findings {
+		s.Findings++
+		s.FindingsBySeverity[severity.Name(f.sev)]++
+		if f.suppressed {
+			s.Suppressed++
+			continue
+		}
+		if threshold != inventory.SeverityUnspecified && f.sev >= threshold {
+			s.FindingsAboveThreshold++
+		}
+	}
+
+	for _, ps := range result.PluginStatus {
+		if ps.Status != nil && ps.Status.Status != plugin.ScanStatusSucceeded {
+			s.FailedPlugins = append(s.FailedPlugins, ps.Name)
+		}
+	}
+
+	switch {
+	case result.Status != nil && result.Status.Status == plugin.ScanStatusInterrupted:
+		s.ExitCode = ExitCodeInterrupted
+	case result.Status == nil || result.Status.Status != plugin.ScanStatusSucceeded:
+		s.ExitCode = ExitCodeFatal
+	case s.FindingsAboveThreshold > 0:
+		s.ExitCode = ExitCodeFindings
+	case failOnPluginErrors && len(s.FailedPlugins) > 0:
+		s.ExitCode = ExitCodePartialErrors
+	default:
+		s.ExitCode = ExitCodeSuccess
+	}
+	return s
+}
+
+// ApplyPolicy adds the violations of the policy report to the summary. The scan
+// fails with ExitCodePolicyViolations if there are any, unless it already failed
+// for a more severe reason.
+func (s *Summary) ApplyPolicy(r *policy.Report) {
+	s.PolicyViolations = r.Violations
+	if !r.Passed() && (s.ExitCode == ExitCodeSuccess || s.ExitCode == ExitCodePartialErrors) {
+		s.ExitCode = ExitCodePolicyViolations
+	}
+}
+
+func scanStatusName(s *plugin.ScanStatus) string {
+	if s == nil {
+		return "unspecified"
+	}
+	switch s.Status {
+	case plugin.ScanStatusSucceeded:
+		return "succeeded"
+	case plugin.ScanStatusPartiallySucceeded:
+		return "partially_succeeded"
+	case plugin.ScanStatusFailed:
+		return "failed"
+	case plugin.ScanStatusInterrupted:
+		return "interrupted"
+	default:
+		return "unspecified"
+	}
+}
@@ -0,0 +1,298 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scanrunner_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	scalibr "github.com/google/osv-scalibr"
+	"github.com/google/osv-scalibr/binary/scanrunner"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/inventory/vex"
+	"github.com/google/osv-scalibr/plugin"
+	"github.com/google/osv-scalibr/result/policy"
+	"github.com/ossf/osv-schema/bindings/go/osvschema"
+)
+
+func finding(sev inventory.SeverityEnum) *inventory.GenericFinding {
+	return &inventory.GenericFinding{Adv: &inventory.GenericFindingAdvisory{Sev: sev}}
+}
+
+func TestSummarize(t *testing.T) {
+	succeeded := &plugin.ScanStatus{Status: plugin.ScanStatusSucceeded}
+	failed := &plugin.ScanStatus{Status: plugin.ScanStatusFailed, FailureReason: "error"}
+
+	testCases := []struct {
+		desc               string
+		result             *scalibr.ScanResult
+		threshold          inventory.SeverityEnum
+		failOnPluginErrors bool
+		want               *scanrunner.Summary
+	}{
+		{
+			desc: "clean_scan",
+			result: &scalibr.ScanResult{
+				Status:       succeeded,
+				PluginStatus: []*plugin.Status{{Name: "python/wheelegg", Status: succeeded}},
+				Inventory:    inventory.Inventory{Packages: []*extractor.Package{{Name: "pip"}}},
+			},
+			threshold: inventory.SeverityLow,
+			want: &scanrunner.Summary{
+				Status:             "succeeded",
+				ExitCode:           scanrunner.ExitCodeSuccess,
+				Packages:           1,
+				FindingsBySeverity: map[string]int{},
+				FailedPlugins:      []string{},
+			},
+		},
+		{
+			desc: "findings_below_threshold",
+			result: &scalibr.ScanResult{
+				Status: succeeded,
+				Inventory: inventory.Inventory{
+					GenericFindings: []*inventory.GenericFinding{finding(inventory.SeverityMedium)},
+				},
+			},
+			threshold: inventory.SeverityHigh,
+			want: &scanrunner.Summary{
+				Status:             "succeeded",
+				ExitCode:           scanrunner.ExitCodeSuccess,
+				Findings:           1,
+				FindingsBySeverity: map[string]int{"medium": 1},
+				FailedPlugins:      []string{},
+			},
+		},
+		{
+			desc: "findings_above_threshold",
+			result: &scalibr.ScanResult{
+				Status: succeeded,
+				Inventory: inventory.Inventory{
+					GenericFindings: []*inventory.GenericFinding{
+						finding(inventory.SeverityMedium),
+						finding(inventory.SeverityCritical),
+					},
+					PackageVulns: []*inventory.PackageVuln{
+						{Vulnerability: osvschema.Vulnerability{
+							ID: "CVE-2024-1234",
+							Severity: []osvschema.Severity{{
+								Type:  osvschema.SeverityCVSSV3,
+								Score: "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:N/A:N",
+							}},
+						}},
+						{Vulnerability: osvschema.Vulnerability{
+							ID:               "GHSA-xxxx-xxxx-xxxx",
+							DatabaseSpecific: map[string]any{"severity": "MODERATE"},
+						}},
+					},
+				},
+			},
+			threshold: inventory.SeverityHigh,
+			want: &scanrunner.Summary{
+				Status:                 "succeeded",
+				ExitCode:               scanrunner.ExitCodeFindings,
+				Findings:               4,
+				FindingsBySeverity:     map[string]int{"medium": 2, "high": 1, "critical": 1},
+				FindingsAboveThreshold: 2,
+				FailedPlugins:          []string{},
+			},
+		},
+		{
+			desc: "suppressed_findings_below_threshold",
+			result: &scalibr.ScanResult{
+				Status: succeeded,
+				Inventory: inventory.Inventory{
+					GenericFindings: []*inventory.GenericFinding{
+						finding(inventory.SeverityMedium),
+						{
+							Adv: &inventory.GenericFindingAdvisory{Sev: inventory.SeverityCritical},
+							ExploitabilitySignals: []*vex.FindingExploitabilitySignal{
+								{Plugin: "vex/suppression", Status: vex.StatusAcceptedRisk},
+							},
+						},
+					},
+				},
+			},
+			threshold: inventory.SeverityHigh,
+			want: &scanrunner.Summary{
+				Status:             "succeeded",
+				ExitCode:           scanrunner.ExitCodeSuccess,
+				Findings:           2,
+				FindingsBySeverity: map[string]int{"medium": 1, "critical": 1},
+				Suppressed:         1,
+				FailedPlugins:      []string{},
+			},
+		},
+		{
+			desc: "no_threshold",
+			result: &scalibr.ScanResult{
+				Status: succeeded,
+				Inventory: inventory.Inventory{
+					GenericFindings: []*inventory.GenericFinding{finding(inventory.SeverityCritical)},
+				},
+			},
+			want: &scanrunner.Summary{
+				Status:             "succeeded",
+				ExitCode:           scanrunner.ExitCodeSuccess,
+				Findings:           1,
+				FindingsBySeverity: map[string]int{"critical": 1},
+				FailedPlugins:      []string{},
+			},
+		},
+		{
+			desc: "plugin_errors_ignored_by_default",
+			result: &scalibr.ScanResult{
+				Status:       succeeded,
+				PluginStatus: []*plugin.Status{{Name: "cis", Status: failed}},
+			},
+			want: &scanrunner.Summary{
+				Status:             "succeeded",
+				ExitCode:           scanrunner.ExitCodeSuccess,
+				FindingsBySeverity: map[string]int{},
+				FailedPlugins:      []string{"cis"},
+			},
+		},
+		{
+			desc: "fail_on_plugin_errors",
+			result: &scalibr.ScanResult{
+				Status: succeeded,
+				PluginStatus: []*plugin.Status{
+					{Name: "python/wheelegg", Status: succeeded},
+					{Name: "cis", Status: failed},
+				},
+			},
+			failOnPluginErrors: true,
+			want: &scanrunner.Summary{
+				Status:             "succeeded",
+				ExitCode:           scanrunner.ExitCodePartialErrors,
+				FindingsBySeverity: map[string]int{},
+				FailedPlugins:      []string{"cis"},
+			},
+		},
+		{
+			desc: "findings_take_precedence_over_plugin_errors",
+			result: &scalibr.ScanResult{
+				Status:       succeeded,
+				PluginStatus: []*plugin.Status{{Name: "cis", Status: failed}},
+				Inventory: inventory.Inventory{
+					GenericFindings: []*inventory.GenericFinding{finding(inventory.SeverityLow)},
+				},
+			},
+			threshold:          inventory.SeverityLow,
+			failOnPluginErrors: true,
+			want: &scanrunner.Summary{
+				Status:                 "succeeded",
+				ExitCode:               scanrunner.ExitCodeFindings,
+				Findings:               1,
+				FindingsBySeverity:     map[string]int{"low": 1},
+				FindingsAboveThreshold: 1,
+				FailedPlugins:          []string{"cis"},
+			},
+		},
+		{
+			desc:   "failed_scan",
+			result: &scalibr.ScanResult{Status: failed},
+			want: &scanrunner.Summary{
+				Status:             "failed",
+				ExitCode:           scanrunner.ExitCodeFatal,
+				FindingsBySeverity: map[string]int{},
+				FailedPlugins:      []string{},
+			},
+		},
+		{
+			desc: "interrupted_scan",
+			result: &scalibr.ScanResult{
+				Status: &plugin.ScanStatus{Status: plugin.ScanStatusInterrupted, FailureReason: "scan interrupted: context canceled"},
+				Inventory: inventory.Inventory{
+					GenericFindings: []*inventory.GenericFinding{finding(inventory.SeverityCritical)},
+				},
+			},
+			threshold: inventory.SeverityLow,
+			want: &scanrunner.Summary{
+				Status:                 "interrupted",
+				ExitCode:               scanrunner.ExitCodeInterrupted,
+				Findings:               1,
+				FindingsBySeverity:     map[string]int{"critical": 1},
+				FindingsAboveThreshold: 1,
+				FailedPlugins:          []string{},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := scanrunner.Summarize(tc.result, tc.threshold, tc.failOnPluginErrors)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Summarize() returned unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestApplyPolicy(t *testing.T) {
+	violation := &policy.Violation{Rule: policy.RuleBannedLicense, Message: `mongodb@7.0.0 is licensed under "SSPL-1.0", which is banned`}
+
+	testCases := []struct {
+		desc         string
+		exitCode     int
+		report       *policy.Report
+		wantExitCode int
+	}{
+		{
+			desc:         "policy_passed",
+			exitCode:     scanrunner.ExitCodeSuccess,
+			report:       &policy.Report{Violations: []*policy.Violation{}},
+			wantExitCode: scanrunner.ExitCodeSuccess,
+		},
+		{
+			desc:         "policy_violated",
+			exitCode:     scanrunner.ExitCodeSuccess,
+			report:       &policy.Report{Violations: []*policy.Violation{violation}},
+			wantExitCode: scanrunner.ExitCodePolicyViolations,
+		},
+		{
+			desc:         "violations_take_precedence_over_plugin_errors",
+			exitCode:     scanrunner.ExitCodePartialErrors,
+			report:       &policy.Report{Violations: []*policy.Violation{violation}},
+			wantExitCode: scanrunner.ExitCodePolicyViolations,
+		},
+		{
+			desc:         "findings_above_threshold_take_precedence",
+			exitCode:     scanrunner.ExitCodeFindings,
+			report:       &policy.Report{Violations: []*policy.Violation{violation}},
+			wantExitCode: scanrunner.ExitCodeFindings,
+		},
+		{
+			desc:         "failed_scan",
+			exitCode:     scanrunner.ExitCodeFatal,
+			report:       &policy.Report{Violations: []*policy.Violation{violation}},
+			wantExitCode: scanrunner.ExitCodeFatal,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			s := &scanrunner.Summary{ExitCode: tc.exitCode}
+			s.ApplyPolicy(tc.report)
+			if s.ExitCode != tc.wantExitCode {
+				t.Errorf("ApplyPolicy() set exit code %d, want %d", s.ExitCode, tc.wantExitCode)
+			}
+			if diff := cmp.Diff(tc.report.Violations, s.PolicyViolations); diff != "" {
+				t.Errorf("ApplyPolicy() unexpected violations (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -16,11 +16,13 @@
 package spdx
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
-	"os"
 
-	"github.com/spdx/tools-golang/json"
+	"github.com/google/osv-scalibr/binary/atomicfile"
+	"github.com/google/osv-scalibr/converter/spdx30"
+	spdxjson "github.com/spdx/tools-golang/json"
 	"github.com/spdx/tools-golang/spdx/v2/v2_3"
 	"github.com/spdx/tools-golang/tagvalue"
 	"github.com/spdx/tools-golang/yaml"
@@ -42,15 +44,9 @@ func Write23(doc *v2_3.Document, path string, format string) error {
 		return fmt.Errorf("%s has an invalid SPDX format or not supported by SCALIBR", path)
 	}
 
-	f, err := os.Create(path)
-	if err != nil {
-		return err
-	}
-	defer f.Close()
-	if err = writeFun(doc, f); err != nil {
-		return err
-	}
-	return nil
+	return atomicfile.Write(path, func(w io.Writer) error {
+		return writeFun(doc, w)
+	})
 }
 
 func writeSPDX23TagValue(doc *v2_3.Document, w io.Writer) error {
@@ -62,5 +58,18 @@ func writeSPDX23YAML(doc *v2_3.Document, w io.Writer) error {
 }
 
 func writeSPDX23JSON(doc *v2_3.Document, w io.Writer) error {
-	return json.Write(doc, w, json.Indent("  "))
+	return spdxjson.Write(doc, w, spdxjson.Indent("  "))
+}
+
+// Write30 writes an SPDX v3.0 document into a file in the JSON-LD format.
+func Write30(doc *spdx30.Document, path string, format string) error {
+	if format != "spdx30-json" {
+		return fmt.Errorf("%s has an invalid SPDX format or not supported by SCALIBR", path)
+	}
+
+	return atomicfile.Write(path, func(w io.Writer) error {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(doc)
+	})
 }
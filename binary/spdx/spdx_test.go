@@ -23,6 +23,7 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/osv-scalibr/binary/spdx"
+	"github.com/google/osv-scalibr/converter/spdx30"
 	"github.com/spdx/tools-golang/spdx/v2/v2_3"
 )
 
@@ -99,3 +100,46 @@ func TestWrite_InvalidFormat(t *testing.T) {
 		t.Errorf("spdx.Write23(%s, %s) didn't return an invalid extension error: %v", fullPath, format, err)
 	}
 }
+
+func TestWrite30(t *testing.T) {
+	doc30 := &spdx30.Document{
+		Context: spdx30.Context,
+		Graph: []any{
+			&spdx30.CreationInfo{
+				Type:        spdx30.TypeCreationInfo,
+				ID:          spdx30.CreationInfoID,
+				SpecVersion: spdx30.SpecVersion,
+				Created:     "2006-01-02T15:04:05Z",
+				CreatedBy:   []string{"https://example.com/ns#SPDXRef-Agent"},
+			},
+			&spdx30.Agent{Element: spdx30.Element{
+				Type:         spdx30.TypeSoftwareAgent,
+				SpdxID:       "https://example.com/ns#SPDXRef-Agent",
+				CreationInfo: spdx30.CreationInfoID,
+				Name:         "SCALIBR",
+			}},
+		},
+	}
+	fullPath := filepath.Join(t.TempDir(), "output")
+	if err := spdx.Write30(doc30, fullPath, "spdx30-json"); err != nil {
+		t.Fatalf("spdx.Write30(%v, %s) returned an error: %v", doc30, fullPath, err)
+	}
+
+	got, err := os.ReadFile(fullPath)
+	if err != nil {
+		t.Fatalf("error while reading %s: %v", fullPath, err)
+	}
+	want, err := os.ReadFile("testdata/json-format.spdx3.json")
+	if err != nil {
+		t.Fatalf("error while reading testdata: %v", err)
+	}
+	wantStr := strings.TrimSpace(strings.ReplaceAll(string(want), "\r", ""))
+	gotStr := strings.TrimSpace(strings.ReplaceAll(string(got), "\r", ""))
+	if diff := cmp.Diff(wantStr, gotStr); diff != "" {
+		t.Errorf("spdx.Write30(%v, %s) produced unexpected results, diff (-want +got):\n%s", doc30, fullPath, diff)
+	}
+
+	if err := spdx.Write30(doc30, fullPath, "spdx30-yaml"); err == nil {
+		t.Errorf("spdx.Write30(%s, spdx30-yaml) didn't return an invalid format error", fullPath)
+	}
+}
@@ -0,0 +1,217 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package validaterunner provides the main function for validating and
+// repairing SBOMs with the SCALIBR binary.
+package validaterunner
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/CycloneDX/cyclonedx-go"
+	"github.com/google/osv-scalibr/binary/cdx"
+	"github.com/google/osv-scalibr/binary/spdx"
+	"github.com/google/osv-scalibr/converter/validate"
+	"github.com/google/osv-scalibr/log"
+	spdxjson "github.com/spdx/tools-golang/json"
+	"github.com/spdx/tools-golang/spdx/v2/v2_3"
+	"github.com/spdx/tools-golang/tagvalue"
+	"github.com/spdx/tools-golang/yaml"
+)
+
+// Exit codes of the validate-sbom subcommand.
+const (
+	// ExitCodeSuccess means that the SBOM has no errors, or that all of them
+	// were repaired.
+	ExitCodeSuccess = 0
+	// ExitCodeFatal means that the SBOM couldn't be read or written.
+	ExitCodeFatal = 1
+	// ExitCodeInvalid means that the SBOM has errors that weren't repaired.
+	ExitCodeInvalid = 2
+)
+
+// The supported SBOM formats. The names match the ones of the -o flag of the
+// scan subcommand.
+var formats = []string{"spdx23-json", "spdx23-tag-value", "spdx23-yaml", "cdx-json", "cdx-xml"}
+
+// File name suffixes used to detect the format if --format isn't set.
+var formatSuffixes = []struct {
+	suffix string
+	format string
+}{
+	{".spdx.json", "spdx23-json"},
+	{".spdx", "spdx23-tag-value"},
+	{".spdx.yml", "spdx23-yaml"},
+	{".spdx.yaml", "spdx23-yaml"},
+	{".cdx.json", "cdx-json"},
+	{".cyclonedx.json", "cdx-json"},
+	{"bom.json", "cdx-json"},
+	{".cdx.xml", "cdx-xml"},
+	{".cyclonedx.xml", "cdx-xml"},
+	{"bom.xml", "cdx-xml"},
+}
+
+// Flags contains the command line flags of the validate-sbom subcommand.
+type Flags struct {
+	// Path of the SBOM to validate.
+	Input string
+	// Format of the SBOM, e.g. "spdx23-json". Detected from the file name if empty.
+	Format string
+	// If set, the problems that can be fixed are repaired and the SBOM is
+	// written to this path in the input format.
+	RepairOutput string
+}
+
+// ValidateFlags validates the passed command line flags.
+func ValidateFlags(flags *Flags) error {
+	if flags.Input == "" {
+		return errors.New("--input must be set")
+	}
+	if _, err := sbomFormat(flags); err != nil {
+		return err
+	}
+	return nil
+}
+
+func sbomFormat(flags *Flags) (string, error) {
+	if flags.Format != "" {
+		for _, f := range formats {
+			if f == flags.Format {
+				return f, nil
+			}
+		}
+		return "", fmt.Errorf("unknown --format %q, supported formats are %v", flags.Format, formats)
+	}
+	lower := strings.ToLower(flags.Input)
+	for _, s := range formatSuffixes {
+		if strings.HasSuffix(lower, s.suffix) {
+			return s.format, nil
+		}
+	}
+	return "", fmt.Errorf("can't detect the SBOM format of %s, set --format to one of %v", flags.Input, formats)
+}
+
+// RunValidate validates the SBOM specified in the flags, prints the issues
+// found to stdout and returns the exit code passed to os.Exit() in the main
+// binary.
+func RunValidate(flags *Flags) int {
+	return runValidate(flags, os.Stdout)
+}
+
+func runValidate(flags *Flags, out io.Writer) int {
+	if err := ValidateFlags(flags); err != nil {
+		log.Errorf("Error validating flags: %v", err)
+		return ExitCodeFatal
+	}
+	format, _ := sbomFormat(flags)
+	repair := flags.RepairOutput != ""
+
+	var issues []*validate.Issue
+	var err error
+	if strings.HasPrefix(format, "spdx23") {
+		issues, err = checkSPDX(flags, format, repair)
+	} else {
+		issues, err = checkCDX(flags, format, repair)
+	}
+	if err != nil {
+		log.Errorf("%v", err)
+		return ExitCodeFatal
+	}
+
+	if err := writeIssues(out, issues); err != nil {
+		log.Errorf("Failed to write issues: %v", err)
+		return ExitCodeFatal
+	}
+	if validate.HasErrors(issues) {
+		return ExitCodeInvalid
+	}
+	return ExitCodeSuccess
+}
+
+func checkSPDX(flags *Flags, format string, repair bool) ([]*validate.Issue, error) {
+	f, err := os.Open(flags.Input)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var doc *v2_3.Document
+	switch format {
+	case "spdx23-json":
+		doc, err = spdxjson.Read(f)
+	case "spdx23-tag-value":
+		doc, err = tagvalue.Read(f)
+	default:
+		doc, err = yaml.Read(f)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", flags.Input, err)
+	}
+
+	if !repair {
+		return validate.ValidateSPDX23(doc), nil
+	}
+	issues := validate.RepairSPDX23(doc)
+	if err := spdx.Write23(doc, flags.RepairOutput, format); err != nil {
+		return nil, fmt.Errorf("failed to write the repaired SBOM: %w", err)
+	}
+	return issues, nil
+}
+
+func checkCDX(flags *Flags, format string, repair bool) ([]*validate.Issue, error) {
+	f, err := os.Open(flags.Input)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	fileFormat := cyclonedx.BOMFileFormatJSON
+	if format == "cdx-xml" {
+		fileFormat = cyclonedx.BOMFileFormatXML
+	}
+	bom := &cyclonedx.BOM{}
+	if err := cyclonedx.NewBOMDecoder(f, fileFormat).Decode(bom); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", flags.Input, err)
+	}
+	if fileFormat == cyclonedx.BOMFileFormatXML {
+		// bomFormat only exists in the JSON format.
+		bom.BOMFormat = cyclonedx.BOMFormat
+	}
+
+	if !repair {
+		return validate.ValidateCDX(bom), nil
+	}
+	issues := validate.RepairCDX(bom)
+	if err := cdx.Write(bom, flags.RepairOutput, format); err != nil {
+		return nil, fmt.Errorf("failed to write the repaired SBOM: %w", err)
+	}
+	return issues, nil
+}
+
+func writeIssues(w io.Writer, issues []*validate.Issue) error {
+	if len(issues) == 0 {
+		_, err := fmt.Fprintln(w, "No issues found")
+		return err
+	}
+	for _, i := range issues {
+		if _, err := fmt.Fprintln(w, i.String()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
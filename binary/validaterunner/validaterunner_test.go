@@ -0,0 +1,207 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validaterunner
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+const validCDX = `{
+  "bomFormat": "CycloneDX",
+  "specVersion": "1.6",
+  "version": 1,
+  "components": [
+    {"bom-ref": "lib", "type": "library", "name": "lib"}
+  ],
+  "dependencies": [
+    {"ref": "lib"}
+  ]
+}`
+
+const invalidCDX = `{
+  "bomFormat": "CycloneDX",
+  "specVersion": "1.6",
+  "version": 1,
+  "components": [
+    {"bom-ref": "lib", "name": "lib", "licenses": [{"expression": "MIT or ISC"}]}
+  ],
+  "dependencies": [
+    {"ref": "missing"}
+  ]
+}`
+
+const unrepairableCDX = `{
+  "bomFormat": "CycloneDX",
+  "specVersion": "1.6",
+  "version": 1,
+  "components": [
+    {"bom-ref": "lib", "type": "library"}
+  ]
+}`
+
+const invalidSPDX = `SPDXVersion: SPDX-2.3
+DataLicense: CC0-1.0
+SPDXID: SPDXRef-DOCUMENT
+DocumentName: app
+DocumentNamespace: https://example.com/app
+Creator: Tool: custom
+Created: 2025-01-01T00:00:00Z
+
+PackageName: app
+SPDXID: SPDXRef-Package-app
+PackageLicenseDeclared: MIT and ISC
+`
+
+func writeFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("os.WriteFile(%s): %v", path, err)
+	}
+	return path
+}
+
+func TestRunValidate(t *testing.T) {
+	dir := t.TempDir()
+	valid := writeFile(t, dir, "valid.cdx.json", validCDX)
+	invalid := writeFile(t, dir, "invalid.cdx.json", invalidCDX)
+	unrepairable := writeFile(t, dir, "unrepairable.cdx.json", unrepairableCDX)
+	invalidTagValue := writeFile(t, dir, "invalid.spdx", invalidSPDX)
+	unknownFormat := writeFile(t, dir, "sbom.txt", validCDX)
+
+	testCases := []struct {
+		desc       string
+		flags      *Flags
+		wantCode   int
+		wantOutput string
+	}{
+		{
+			desc:       "valid",
+			flags:      &Flags{Input: valid},
+			wantCode:   ExitCodeSuccess,
+			wantOutput: "No issues found\n",
+		},
+		{
+			desc:     "invalid",
+			flags:    &Flags{Input: invalid},
+			wantCode: ExitCodeInvalid,
+			wantOutput: `error: lib: missing component type
+error: lib: license expression "MIT or ISC" isn't a valid SPDX license expression
+error: dependencies[0]: refers to unknown bom-ref "missing"
+`,
+		},
+		{
+			desc:     "repair",
+			flags:    &Flags{Input: invalid, RepairOutput: filepath.Join(dir, "repaired.cdx.json")},
+			wantCode: ExitCodeSuccess,
+			wantOutput: `error: lib: missing component type (repaired)
+error: lib: license expression "MIT or ISC" isn't a valid SPDX license expression (repaired)
+error: dependencies[0]: refers to unknown bom-ref "missing" (repaired)
+`,
+		},
+		{
+			desc:       "unrepairable",
+			flags:      &Flags{Input: unrepairable, RepairOutput: filepath.Join(dir, "unrepairable-out.cdx.json")},
+			wantCode:   ExitCodeInvalid,
+			wantOutput: "error: lib: missing component name\n",
+		},
+		{
+			desc:     "spdx_tag_value",
+			flags:    &Flags{Input: invalidTagValue},
+			wantCode: ExitCodeInvalid,
+			wantOutput: `error: SPDXRef-Package-app: missing downloadLocation
+error: SPDXRef-Package-app: licenseDeclared "MIT and ISC" isn't a valid SPDX license expression
+error: the document doesn't DESCRIBE any element
+`,
+		},
+		{
+			desc:       "explicit_format",
+			flags:      &Flags{Input: unknownFormat, Format: "cdx-json"},
+			wantCode:   ExitCodeSuccess,
+			wantOutput: "No issues found\n",
+		},
+		{
+			desc:     "unknown_format",
+			flags:    &Flags{Input: unknownFormat},
+			wantCode: ExitCodeFatal,
+		},
+		{
+			desc:     "missing_file",
+			flags:    &Flags{Input: filepath.Join(dir, "missing.cdx.json")},
+			wantCode: ExitCodeFatal,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			var out bytes.Buffer
+			if got := runValidate(tc.flags, &out); got != tc.wantCode {
+				t.Errorf("runValidate(%+v) returned exit code %d, want %d", tc.flags, got, tc.wantCode)
+			}
+			if diff := cmp.Diff(tc.wantOutput, out.String()); diff != "" {
+				t.Errorf("runValidate(%+v) unexpected output (-want +got):\n%s", tc.flags, diff)
+			}
+		})
+	}
+}
+
+func TestRunValidate_RepairedOutputIsValid(t *testing.T) {
+	dir := t.TempDir()
+	invalid := writeFile(t, dir, "invalid.spdx", invalidSPDX)
+	repaired := filepath.Join(dir, "repaired.spdx")
+	if got := runValidate(&Flags{Input: invalid, RepairOutput: repaired}, &bytes.Buffer{}); got != ExitCodeSuccess {
+		t.Fatalf("runValidate(repair) returned exit code %d, want %d", got, ExitCodeSuccess)
+	}
+
+	var out bytes.Buffer
+	if got := runValidate(&Flags{Input: repaired}, &out); got != ExitCodeSuccess {
+		t.Errorf("runValidate(%s) returned exit code %d, want %d: %s", repaired, got, ExitCodeSuccess, out.String())
+	}
+	content, err := os.ReadFile(repaired)
+	if err != nil {
+		t.Fatalf("os.ReadFile(%s): %v", repaired, err)
+	}
+	if !strings.Contains(string(content), "PackageLicenseDeclared: MIT AND ISC") {
+		t.Errorf("repaired SBOM doesn't contain the repaired license expression:\n%s", content)
+	}
+}
+
+func TestValidateFlags(t *testing.T) {
+	testCases := []struct {
+		desc    string
+		flags   *Flags
+		wantErr bool
+	}{
+		{desc: "detected_format", flags: &Flags{Input: "bom.cdx.xml"}},
+		{desc: "explicit_format", flags: &Flags{Input: "sbom", Format: "spdx23-yaml"}},
+		{desc: "missing_input", flags: &Flags{}, wantErr: true},
+		{desc: "unknown_format", flags: &Flags{Input: "sbom", Format: "spdx30-json"}, wantErr: true},
+		{desc: "undetectable_format", flags: &Flags{Input: "sbom"}, wantErr: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := ValidateFlags(tc.flags)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("ValidateFlags(%+v) returned error %v, want error: %v", tc.flags, err, tc.wantErr)
+			}
+		})
+	}
+}
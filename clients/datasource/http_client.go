@@ -0,0 +1,23 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datasource
+
+import (
+	scalibrhttp "github.com/google/osv-scalibr/clients/http"
+)
+
+// httpClient is the client the registry clients send their requests with.
+// Responses are cached by the registry clients themselves, so only retries are enabled.
+var httpClient = scalibrhttp.New(scalibrhttp.DefaultConfig())
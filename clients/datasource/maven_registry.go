@@ -105,6 +105,16 @@ func (m *MavenRegistryAPIClient) SetLocalRegistry(localRegistry string) {
 	m.localRegistry = localRegistry
 }
 
+// SetDefaultRegistry replaces the default registry, e.g. with a mirror of
+// Maven Central. The ID of the current default registry is kept if the new
+// registry has none.
+func (m *MavenRegistryAPIClient) SetDefaultRegistry(registry MavenRegistry) error {
+	if registry.ID == "" {
+		registry.ID = m.defaultRegistry.ID
+	}
+	return m.updateDefaultRegistry(registry)
+}
+
 // WithoutRegistries makes MavenRegistryAPIClient including its cache but not registries.
 func (m *MavenRegistryAPIClient) WithoutRegistries() *MavenRegistryAPIClient {
 	return &MavenRegistryAPIClient{
@@ -268,7 +278,7 @@ func (m *MavenRegistryAPIClient) get(ctx context.Context, auth *HTTPAuthenticati
 
 	u := registry.Parsed.JoinPath(paths...).String()
 	resp, err := m.responses.Get(u, func() (response, error) {
-		resp, err := auth.Get(ctx, http.DefaultClient, u)
+		resp, err := auth.Get(ctx, httpClient, u)
 		if err != nil {
 			return response{}, fmt.Errorf("%w: Maven registry query failed: %w", errAPIFailed, err)
 		}
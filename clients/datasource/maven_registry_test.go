@@ -250,6 +250,37 @@ func TestUpdateDefaultRegistry(t *testing.T) {
 	}
 }
 
+func TestSetDefaultRegistry(t *testing.T) {
+	// The default registry is Maven Central.
+	client, _ := datasource.NewMavenRegistryAPIClient(datasource.MavenRegistry{ReleasesEnabled: true}, "")
+	mirror := clienttest.NewMockHTTPServer(t)
+	if err := client.SetDefaultRegistry(datasource.MavenRegistry{URL: mirror.URL, ReleasesEnabled: true}); err != nil {
+		t.Fatalf("failed to set default registry %s: %v", mirror.URL, err)
+	}
+	mirror.SetResponse(t, "org/example/x.y.z/1.0.0/x.y.z-1.0.0.pom", []byte(`
+	<project>
+	  <groupId>org.example</groupId>
+	  <artifactId>x.y.z</artifactId>
+	  <version>1.0.0</version>
+	</project>
+	`))
+
+	got, err := client.GetProject(context.Background(), "org.example", "x.y.z", "1.0.0")
+	if err != nil {
+		t.Fatalf("failed to get Maven project %s:%s verion %s: %v", "org.example", "x.y.z", "1.0.0", err)
+	}
+	want := maven.Project{
+		ProjectKey: maven.ProjectKey{
+			GroupID:    "org.example",
+			ArtifactID: "x.y.z",
+			Version:    "1.0.0",
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GetProject(%s, %s, %s):\ngot %v\nwant %v\n", "org.example", "x.y.z", "1.0.0", got, want)
+	}
+}
+
 func TestMavenLocalRegistry(t *testing.T) {
 	tempDir := t.TempDir()
 	srv := clienttest.NewMockHTTPServer(t)
@@ -87,7 +87,7 @@ func (c *NPMRegistryAPIClient) FullJSON(ctx context.Context, pkg, version string
 }
 
 func (c *NPMRegistryAPIClient) get(ctx context.Context, urlComponents ...string) (gjson.Result, error) {
-	resp, err := c.registries.MakeRequest(ctx, http.DefaultClient, urlComponents...)
+	resp, err := c.registries.MakeRequest(ctx, httpClient, urlComponents...)
 	if err != nil {
 		return gjson.Result{}, err
 	}
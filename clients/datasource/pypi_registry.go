@@ -122,7 +122,7 @@ func (p *PyPIRegistryAPIClient) get(ctx context.Context, url string, queryIndex
 		if queryIndex {
 			req.Header.Set("Accept", "application/vnd.pypi.simple.v1+json")
 		}
-		resp, err := http.DefaultClient.Do(req)
+		resp, err := httpClient.Do(req)
 		if err != nil {
 			return response{}, fmt.Errorf("%w: PyPI registry query failed: %w", errAPIFailed, err)
 		}
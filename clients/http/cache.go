@@ -0,0 +1,75 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// CachedResponse is an HTTP response stored in a Cache.
+type CachedResponse struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+}
+
+func (c *CachedResponse) response(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:        http.StatusText(c.StatusCode),
+		StatusCode:    c.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        c.Header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(c.Body)),
+		ContentLength: int64(len(c.Body)),
+		Request:       req,
+	}
+}
+
+// Cache stores HTTP responses by request URL. Implementations must be safe for concurrent use.
+type Cache interface {
+	Get(key string) (*CachedResponse, bool)
+	Set(key string, resp *CachedResponse)
+}
+
+// MemoryCache is a Cache that keeps all responses in memory.
+type MemoryCache struct {
+	mu        sync.RWMutex
+	responses map[string]*CachedResponse
+}
+
+// NewMemoryCache returns an empty in-memory cache.
+func NewMemoryCache() *MemoryCache {
+	return &MemoryCache{responses: map[string]*CachedResponse{}}
+}
+
+// Get returns the response cached for the key.
+func (c *MemoryCache) Get(key string) (*CachedResponse, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	resp, ok := c.responses[key]
+	return resp, ok
+}
+
+// Set caches the response for the key.
+func (c *MemoryCache) Set(key string, resp *CachedResponse) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.responses[key] = resp
+}
@@ -0,0 +1,246 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package http provides the HTTP client that network-using SCALIBR plugins share.
+// It adds client-side rate limiting, retries with exponential backoff, response
+// caching and a request budget on top of a regular net/http client.
+package http
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"time"
+)
+
+// ErrBudgetExceeded is returned when a request would exceed the request budget of the client.
+var ErrBudgetExceeded = errors.New("HTTP request budget exceeded")
+
+// Backoff configures how failed requests are retried. Requests are retried on
+// network errors and on 429 Too Many Requests, 502 Bad Gateway, 503 Service Unavailable
+// and 504 Gateway Timeout responses.
+type Backoff struct {
+	// MaxRetries is the maximum number of retries after the first attempt. 0 disables retries.
+	MaxRetries int
+	// InitialInterval is the time to wait before the first retry.
+	InitialInterval time.Duration
+	// MaxInterval caps the time to wait between retries, including waits requested by the
+	// server through the Retry-After header.
+	MaxInterval time.Duration
+	// Multiplier is the factor the interval grows by after each retry.
+	Multiplier float64
+}
+
+// interval returns the time to wait before the given retry (starting at 0).
+func (b Backoff) interval(retry int) time.Duration {
+	d := float64(b.InitialInterval) * math.Pow(b.Multiplier, float64(retry))
+	if b.MaxInterval > 0 && d > float64(b.MaxInterval) {
+		return b.MaxInterval
+	}
+	return time.Duration(d)
+}
+
+// Config is the configuration for the HTTP client.
+type Config struct {
+	// RateLimiter limits the rate of outgoing requests, including retries. No limit is applied if nil.
+	RateLimiter RateLimiter
+	// Backoff configures the retries of failed requests.
+	Backoff Backoff
+	// Cache stores successful GET responses. Responses aren't cached if nil.
+	Cache Cache
+	// MaxRequests is the maximum number of requests (including retries) the client sends
+	// during its lifetime. Create a new client for each scan to get a per-scan budget.
+	// No limit is applied if 0.
+	MaxRequests int64
+	// Timeout is the time limit for each request, including retries. No timeout is applied if 0.
+	Timeout time.Duration
+	// Transport is the underlying transport. http.DefaultTransport is used if nil.
+	Transport http.RoundTripper
+}
+
+// DefaultConfig returns the default configuration for the HTTP client.
+func DefaultConfig() Config {
+	return Config{
+		Backoff: Backoff{
+			MaxRetries:      3,
+			InitialInterval: time.Second,
+			MaxInterval:     30 * time.Second,
+			Multiplier:      2,
+		},
+		Timeout: 5 * time.Minute,
+	}
+}
+
+// New returns an *http.Client that applies the config to all requests it sends.
+//
+// For most use cases, initialize with:
+// ```
+// c := New(DefaultConfig())
+// ```
+func New(cfg Config) *http.Client {
+	base := cfg.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &http.Client{
+		Timeout: cfg.Timeout,
+		Transport: &transport{
+			base:        base,
+			rateLimiter: cfg.RateLimiter,
+			backoff:     cfg.Backoff,
+			cache:       cfg.Cache,
+			maxRequests: cfg.MaxRequests,
+		},
+	}
+}
+
+type transport struct {
+	base        http.RoundTripper
+	rateLimiter RateLimiter
+	backoff     Backoff
+	cache       Cache
+	maxRequests int64
+	requests    atomic.Int64
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	cacheKey := ""
+	if t.cache != nil && isCacheable(req) {
+		cacheKey = req.URL.String()
+		if cached, ok := t.cache.Get(cacheKey); ok {
+			return cached.response(req), nil
+		}
+	}
+
+	resp, err := t.roundTripWithRetries(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if cacheKey != "" && resp.StatusCode == http.StatusOK {
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		t.cache.Set(cacheKey, &CachedResponse{
+			StatusCode: resp.StatusCode,
+			Header:     resp.Header.Clone(),
+			Body:       body,
+		})
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	return resp, nil
+}
+
+func (t *transport) roundTripWithRetries(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	// Requests with a body can only be retried if the body can be recreated.
+	canRetry := req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+
+	for retry := 0; ; retry++ {
+		if t.maxRequests > 0 && t.requests.Add(1) > t.maxRequests {
+			return nil, ErrBudgetExceeded
+		}
+		if t.rateLimiter != nil {
+			if err := t.rateLimiter.Wait(ctx); err != nil {
+				return nil, err
+			}
+		}
+
+		attempt := req
+		if retry > 0 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			attempt = req.Clone(ctx)
+			attempt.Body = body
+		}
+
+		resp, err := t.base.RoundTrip(attempt)
+		if !canRetry || retry >= t.backoff.MaxRetries || !shouldRetry(ctx, resp, err) {
+			return resp, err
+		}
+
+		wait := t.backoff.interval(retry)
+		if resp != nil {
+			if d, ok := retryAfter(resp); ok {
+				wait = d
+				if t.backoff.MaxInterval > 0 && wait > t.backoff.MaxInterval {
+					wait = t.backoff.MaxInterval
+				}
+			}
+			// Drain the body so that the connection can be reused.
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+		if err := sleep(ctx, wait); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func shouldRetry(ctx context.Context, resp *http.Response, err error) bool {
+	if err != nil {
+		// Don't retry if the request was cancelled by the caller.
+		return ctx.Err() == nil
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// retryAfter parses the Retry-After header, which holds either a number of seconds or an HTTP date.
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	v := resp.Header.Get("Retry-After")
+	if v == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(v); err == nil && secs >= 0 {
+		return time.Duration(secs) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		return max(time.Until(t), 0), true
+	}
+	return 0, false
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// isCacheable returns true for GET requests that don't depend on credentials or byte ranges.
+func isCacheable(req *http.Request) bool {
+	return req.Method == http.MethodGet && req.Header.Get("Authorization") == "" && req.Header.Get("Range") == ""
+}
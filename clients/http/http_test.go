@@ -0,0 +1,287 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	scalibrhttp "github.com/google/osv-scalibr/clients/http"
+)
+
+// testBackoff retries quickly so that the tests don't take long.
+var testBackoff = scalibrhttp.Backoff{
+	MaxRetries:      3,
+	InitialInterval: time.Millisecond,
+	MaxInterval:     10 * time.Millisecond,
+	Multiplier:      2,
+}
+
+// newServer returns a server that responds with the given status codes in order,
+// then with 200 OK for all further requests.
+func newServer(t *testing.T, statuses ...int) (*httptest.Server, *atomic.Int64) {
+	t.Helper()
+	var requests atomic.Int64
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := requests.Add(1)
+		if r.Body != nil {
+			if body, _ := io.ReadAll(r.Body); len(body) > 0 {
+				w.Header().Set("X-Request-Body", string(body))
+			}
+		}
+		if int(n) <= len(statuses) {
+			w.WriteHeader(statuses[n-1])
+			return
+		}
+		_, _ = w.Write([]byte("ok"))
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &requests
+}
+
+func TestRetries(t *testing.T) {
+	tests := []struct {
+		name         string
+		statuses     []int
+		backoff      scalibrhttp.Backoff
+		wantStatus   int
+		wantRequests int64
+	}{
+		{
+			name:         "success",
+			backoff:      testBackoff,
+			wantStatus:   http.StatusOK,
+			wantRequests: 1,
+		},
+		{
+			name:         "retries_transient_errors",
+			statuses:     []int{http.StatusTooManyRequests, http.StatusServiceUnavailable, http.StatusBadGateway},
+			backoff:      testBackoff,
+			wantStatus:   http.StatusOK,
+			wantRequests: 4,
+		},
+		{
+			name:         "gives_up_after_max_retries",
+			statuses:     []int{503, 503, 503, 503, 503},
+			backoff:      testBackoff,
+			wantStatus:   http.StatusServiceUnavailable,
+			wantRequests: 4,
+		},
+		{
+			name:         "no_retry_on_client_error",
+			statuses:     []int{http.StatusNotFound},
+			backoff:      testBackoff,
+			wantStatus:   http.StatusNotFound,
+			wantRequests: 1,
+		},
+		{
+			name:         "no_retry_on_internal_server_error",
+			statuses:     []int{http.StatusInternalServerError},
+			backoff:      testBackoff,
+			wantStatus:   http.StatusInternalServerError,
+			wantRequests: 1,
+		},
+		{
+			name:         "retries_disabled",
+			statuses:     []int{http.StatusTooManyRequests},
+			wantStatus:   http.StatusTooManyRequests,
+			wantRequests: 1,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			srv, requests := newServer(t, tc.statuses...)
+			c := scalibrhttp.New(scalibrhttp.Config{Backoff: tc.backoff})
+
+			resp, err := c.Get(srv.URL)
+			if err != nil {
+				t.Fatalf("Get(%q) returned an error: %v", srv.URL, err)
+			}
+			resp.Body.Close()
+
+			if resp.StatusCode != tc.wantStatus {
+				t.Errorf("Get(%q) returned status %d, want %d", srv.URL, resp.StatusCode, tc.wantStatus)
+			}
+			if got := requests.Load(); got != tc.wantRequests {
+				t.Errorf("Get(%q) sent %d requests, want %d", srv.URL, got, tc.wantRequests)
+			}
+		})
+	}
+}
+
+func TestRetriesResendBody(t *testing.T) {
+	srv, requests := newServer(t, http.StatusServiceUnavailable)
+	c := scalibrhttp.New(scalibrhttp.Config{Backoff: testBackoff})
+
+	resp, err := c.Post(srv.URL, "text/plain", strings.NewReader("payload"))
+	if err != nil {
+		t.Fatalf("Post(%q) returned an error: %v", srv.URL, err)
+	}
+	resp.Body.Close()
+
+	if got := requests.Load(); got != 2 {
+		t.Errorf("Post(%q) sent %d requests, want 2", srv.URL, got)
+	}
+	if got := resp.Header.Get("X-Request-Body"); got != "payload" {
+		t.Errorf("Post(%q) retried with body %q, want %q", srv.URL, got, "payload")
+	}
+}
+
+func TestRetryAfterIsCapped(t *testing.T) {
+	var requests atomic.Int64
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) == 1 {
+			w.Header().Set("Retry-After", "3600")
+			w.WriteHeader(http.StatusTooManyRequests)
+		}
+	}))
+	defer srv.Close()
+	c := scalibrhttp.New(scalibrhttp.Config{Backoff: testBackoff})
+
+	start := time.Now()
+	resp, err := c.Get(srv.URL)
+	if err != nil {
+		t.Fatalf("Get(%q) returned an error: %v", srv.URL, err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Get(%q) returned status %d, want %d", srv.URL, resp.StatusCode, http.StatusOK)
+	}
+	if elapsed := time.Since(start); elapsed > time.Minute {
+		t.Errorf("Get(%q) took %v, want the Retry-After wait to be capped", srv.URL, elapsed)
+	}
+}
+
+func TestBudget(t *testing.T) {
+	srv, requests := newServer(t, http.StatusServiceUnavailable)
+	c := scalibrhttp.New(scalibrhttp.Config{Backoff: testBackoff, MaxRequests: 3})
+
+	// The first call uses two requests because of the retry.
+	for range 2 {
+		resp, err := c.Get(srv.URL)
+		if err != nil {
+			t.Fatalf("Get(%q) returned an error: %v", srv.URL, err)
+		}
+		resp.Body.Close()
+	}
+
+	if _, err := c.Get(srv.URL); !errors.Is(err, scalibrhttp.ErrBudgetExceeded) {
+		t.Errorf("Get(%q) returned error %v, want %v", srv.URL, err, scalibrhttp.ErrBudgetExceeded)
+	}
+	if got := requests.Load(); got != 3 {
+		t.Errorf("server received %d requests, want 3", got)
+	}
+}
+
+func TestCache(t *testing.T) {
+	srv, requests := newServer(t)
+	c := scalibrhttp.New(scalibrhttp.Config{Cache: scalibrhttp.NewMemoryCache()})
+
+	for range 3 {
+		resp, err := c.Get(srv.URL)
+		if err != nil {
+			t.Fatalf("Get(%q) returned an error: %v", srv.URL, err)
+		}
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			t.Fatalf("reading response body: %v", err)
+		}
+		if string(body) != "ok" {
+			t.Errorf("Get(%q) returned body %q, want %q", srv.URL, body, "ok")
+		}
+	}
+	if got := requests.Load(); got != 1 {
+		t.Errorf("server received %d requests, want 1", got)
+	}
+
+	// Authenticated requests bypass the cache.
+	req, err := http.NewRequest(http.MethodGet, srv.URL, nil)
+	if err != nil {
+		t.Fatalf("http.NewRequest: %v", err)
+	}
+	req.Header.Set("Authorization", "Bearer token")
+	resp, err := c.Do(req)
+	if err != nil {
+		t.Fatalf("Do(%q) returned an error: %v", srv.URL, err)
+	}
+	resp.Body.Close()
+	if got := requests.Load(); got != 2 {
+		t.Errorf("server received %d requests, want 2", got)
+	}
+}
+
+func TestCacheSkipsErrors(t *testing.T) {
+	srv, requests := newServer(t, http.StatusNotFound)
+	c := scalibrhttp.New(scalibrhttp.Config{Cache: scalibrhttp.NewMemoryCache()})
+
+	for _, want := range []int{http.StatusNotFound, http.StatusOK, http.StatusOK} {
+		resp, err := c.Get(srv.URL)
+		if err != nil {
+			t.Fatalf("Get(%q) returned an error: %v", srv.URL, err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != want {
+			t.Errorf("Get(%q) returned status %d, want %d", srv.URL, resp.StatusCode, want)
+		}
+	}
+	if got := requests.Load(); got != 2 {
+		t.Errorf("server received %d requests, want 2", got)
+	}
+}
+
+func TestTokenBucket(t *testing.T) {
+	srv, requests := newServer(t)
+	// A burst of 2, then one request every 20ms.
+	c := scalibrhttp.New(scalibrhttp.Config{RateLimiter: scalibrhttp.NewTokenBucket(50, 2)})
+
+	start := time.Now()
+	for range 4 {
+		resp, err := c.Get(srv.URL)
+		if err != nil {
+			t.Fatalf("Get(%q) returned an error: %v", srv.URL, err)
+		}
+		resp.Body.Close()
+	}
+
+	if got := requests.Load(); got != 4 {
+		t.Errorf("server received %d requests, want 4", got)
+	}
+	if elapsed := time.Since(start); elapsed < 35*time.Millisecond {
+		t.Errorf("4 requests took %v, want at least 35ms", elapsed)
+	}
+}
+
+func TestTokenBucketCancelled(t *testing.T) {
+	b := scalibrhttp.NewTokenBucket(0.001, 1)
+	if err := b.Wait(t.Context()); err != nil {
+		t.Fatalf("Wait() returned an error: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Millisecond)
+	defer cancel()
+	if err := b.Wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Wait() returned error %v, want %v", err, context.DeadlineExceeded)
+	}
+}
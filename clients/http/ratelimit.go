@@ -0,0 +1,73 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RateLimiter limits the rate of outgoing requests.
+type RateLimiter interface {
+	// Wait blocks until the next request is allowed to be sent or the context is done.
+	Wait(ctx context.Context) error
+}
+
+// TokenBucket is a RateLimiter that allows a steady rate of requests with bursts.
+type TokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// NewTokenBucket returns a RateLimiter that allows requestsPerSecond requests per second
+// on average, with bursts of up to burst requests.
+func NewTokenBucket(requestsPerSecond float64, burst int) *TokenBucket {
+	burst = max(burst, 1)
+	return &TokenBucket{
+		rate:   requestsPerSecond,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// Wait blocks until a token is available or the context is done.
+func (b *TokenBucket) Wait(ctx context.Context) error {
+	b.mu.Lock()
+	now := time.Now()
+	b.tokens = min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	b.last = now
+	// Reserve a token even if it's not available yet so that concurrent
+	// callers queue up behind each other.
+	b.tokens--
+	var wait time.Duration
+	if b.tokens < 0 {
+		wait = time.Duration(-b.tokens / b.rate * float64(time.Second))
+	}
+	b.mu.Unlock()
+
+	if err := sleep(ctx, wait); err != nil {
+		// Return the reserved token.
+		b.mu.Lock()
+		b.tokens++
+		b.mu.Unlock()
+		return err
+	}
+	return nil
+}
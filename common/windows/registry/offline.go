@@ -54,6 +54,17 @@ func (o *OfflineOpener) Open() (Registry, error) {
 	return &OfflineRegistry{reg, f}, nil
 }
 
+// NewOfflineRegistry parses a registry hive from the given reader, e.g. a hive file read from
+// a mounted disk image. Closing the returned registry does not close the reader.
+func NewOfflineRegistry(r io.ReaderAt) (*OfflineRegistry, error) {
+	reg, err := regparser.NewRegistry(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return &OfflineRegistry{reg, io.NopCloser(nil)}, nil
+}
+
 // OfflineRegistry wraps the regparser library to provide offline (from file) parsing of the Windows
 // registry.
 type OfflineRegistry struct {
@@ -19,12 +19,18 @@ package converter
 import (
 	"fmt"
 	"regexp"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/CycloneDX/cyclonedx-go"
+	"github.com/google/osv-scalibr/converter/spdx30"
 	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem/misc/embeddedversion"
+	"github.com/google/osv-scalibr/extractor/filesystem/misc/runtimeversion"
 	cdxmeta "github.com/google/osv-scalibr/extractor/filesystem/sbom/cdx/metadata"
 	spdxmeta "github.com/google/osv-scalibr/extractor/filesystem/sbom/spdx/metadata"
+	"github.com/google/osv-scalibr/inventory/fingerprint"
 	"github.com/google/osv-scalibr/log"
 	"github.com/google/osv-scalibr/purl"
 	"github.com/google/osv-scalibr/result"
@@ -55,6 +61,8 @@ type SPDXConfig struct {
 	DocumentName      string
 	DocumentNamespace string
 	Creators          []common.Creator
+	// Naming customizes the package names and IDs and the document namespace.
+	Naming *NamingPolicy
 }
 
 // ToSPDX23 converts the SCALIBR scan results into an SPDX v2.3 document.
@@ -82,28 +90,24 @@ func ToSPDX23(r *result.ScanResult, c SPDXConfig) *v2_3.Document {
 		Relationship: "DESCRIBES",
 	})
 
+	ids := uniqueIDs{}
 	for _, pkg := range r.Inventory.Packages {
 		p := ToPURL(pkg)
 		if p == nil {
 			log.Warnf("Package %v has no PURL, skipping", pkg)
 			continue
 		}
-		pName := p.Name
-		pVersion := p.Version
-		if pName == "" || pVersion == "" {
+		if p.Name == "" || p.Version == "" {
 			log.Warnf("Package %v PURL name or version empty, skipping", pkg)
 			continue
 		}
+		pName := c.Naming.componentName(pkg, p.Name)
+		pVersion := p.Version
 		pID := SPDXRefPrefix + "Package-" + replaceSPDXIDInvalidChars(pName) + "-" + uuid.New().String()
-		pSourceInfo := ""
-		if len(pkg.Plugins) > 0 {
-			pSourceInfo = fmt.Sprintf("Identified by the %s extractor", pkg.Plugins[0])
-		}
-		if len(pkg.Locations) == 1 {
-			pSourceInfo += " from " + pkg.Locations[0]
-		} else if l := len(pkg.Locations); l > 1 {
-			pSourceInfo += fmt.Sprintf(" from %d locations, including %s and %s", l, pkg.Locations[0], pkg.Locations[1])
+		if id := c.Naming.componentID(pkg, pName); id != "" {
+			pID = SPDXRefPrefix + "Package-" + ids.get(replaceSPDXIDInvalidChars(id))
 		}
+		pSourceInfo := sourceInfo(pkg)
 
 		packages = append(packages, &v2_3.Package{
 			PackageName:           pName,
@@ -116,13 +120,18 @@ func ToSPDX23(r *result.ScanResult, c SPDXConfig) *v2_3.Document {
 			PackageDownloadLocation:   NoAssertion,
 			IsFilesAnalyzedTagPresent: false,
 			PackageSourceInfo:         pSourceInfo,
-			PackageExternalReferences: []*v2_3.PackageExternalReference{
+			PackageExternalReferences: append([]*v2_3.PackageExternalReference{
 				{
 					Category: "PACKAGE-MANAGER",
 					RefType:  "purl",
 					Locator:  p.String(),
 				},
-			},
+				{
+					Category: "OTHER",
+					RefType:  fingerprintRefType,
+					Locator:  fingerprint.Package(pkg),
+				},
+			}, spdxProvenanceRefs(pkg)...),
 		})
 		// TODO(b/313658493): Add a DESCRIBES relationship or a DocumentDescribes field.
 		relationships = append(relationships, &v2_3.Relationship{
@@ -142,7 +151,7 @@ func ToSPDX23(r *result.ScanResult, c SPDXConfig) *v2_3.Document {
 	}
 	namespace := c.DocumentNamespace
 	if namespace == "" {
-		namespace = "https://spdx.google/" + uuid.New().String()
+		namespace = c.Naming.documentNamespace(name)
 	}
 	creators := []common.Creator{
 		{
@@ -166,6 +175,196 @@ func ToSPDX23(r *result.ScanResult, c SPDXConfig) *v2_3.Document {
 	}
 }
 
+// sourceInfo describes how and where the package was found.
+func sourceInfo(pkg *extractor.Package) string {
+	info := ""
+	if len(pkg.Plugins) > 0 {
+		info = fmt.Sprintf("Identified by the %s extractor", pkg.Plugins[0])
+	}
+	if len(pkg.Locations) == 1 {
+		info += " from " + pkg.Locations[0]
+	} else if l := len(pkg.Locations); l > 1 {
+		info += fmt.Sprintf(" from %d locations, including %s and %s", l, pkg.Locations[0], pkg.Locations[1])
+	}
+	return info
+}
+
+// ToSPDX30 converts the SCALIBR scan results into an SPDX v3.0 document.
+// Container image layers the packages were found in are represented as packages
+// that contain them.
+func ToSPDX30(r *result.ScanResult, c SPDXConfig) *spdx30.Document {
+	name := c.DocumentName
+	if name == "" {
+		name = "SCALIBR-generated SPDX"
+	}
+	namespace := c.DocumentNamespace
+	if namespace == "" {
+		namespace = c.Naming.documentNamespace(name)
+	}
+	b := &spdx30Builder{namespace: namespace}
+
+	// Creators are Agents while the tools used for creating the document are listed separately.
+	scalibrAgent := &spdx30.Agent{Element: b.newElement(spdx30.TypeSoftwareAgent, "Agent", "SCALIBR")}
+	creationInfo := &spdx30.CreationInfo{
+		Type:        spdx30.TypeCreationInfo,
+		ID:          spdx30.CreationInfoID,
+		SpecVersion: spdx30.SpecVersion,
+		Created:     time.Now().UTC().Format("2006-01-02T15:04:05Z"),
+		CreatedBy:   []string{scalibrAgent.SpdxID},
+	}
+	b.graph = append(b.graph, creationInfo)
+	b.add(scalibrAgent, scalibrAgent.SpdxID)
+	for _, creator := range c.Creators {
+		switch creator.CreatorType {
+		case "Person", "Organization":
+			agent := &spdx30.Agent{Element: b.newElement(creator.CreatorType, creator.CreatorType, creator.Creator)}
+			b.add(agent, agent.SpdxID)
+			creationInfo.CreatedBy = append(creationInfo.CreatedBy, agent.SpdxID)
+		case "Tool":
+			tool := &spdx30.Agent{Element: b.newElement(spdx30.TypeTool, "Tool", creator.Creator)}
+			b.add(tool, tool.SpdxID)
+			creationInfo.CreatedUsing = append(creationInfo.CreatedUsing, tool.SpdxID)
+		default:
+			log.Warnf("Unsupported SPDX creator type %q, skipping", creator.CreatorType)
+		}
+	}
+
+	// Add a main package that contains all other top-level packages.
+	mainPackage := &spdx30.Package{
+		Element:        b.newElement(spdx30.TypePackage, "Package-main", "main"),
+		PackageVersion: "0",
+	}
+	b.add(mainPackage, mainPackage.SpdxID)
+	mainContains := b.newContains(mainPackage.SpdxID)
+
+	// Relationships from each layer to the packages found in it, keyed by diff ID.
+	layers := map[string]*spdx30.Relationship{}
+	var layerRels []*spdx30.Relationship
+	ids := uniqueIDs{}
+	for _, pkg := range r.Inventory.Packages {
+		p := ToPURL(pkg)
+		if p == nil {
+			log.Warnf("Package %v has no PURL, skipping", pkg)
+			continue
+		}
+		if p.Name == "" || p.Version == "" {
+			log.Warnf("Package %v PURL name or version empty, skipping", pkg)
+			continue
+		}
+		pName := c.Naming.componentName(pkg, p.Name)
+		sp := &spdx30.Package{
+			Element:        b.newElement(spdx30.TypePackage, "Package-"+replaceSPDXIDInvalidChars(pName), pName),
+			PackageVersion: p.Version,
+			PackageURL:     p.String(),
+			SourceInfo:     sourceInfo(pkg),
+		}
+		if id := c.Naming.componentID(pkg, pName); id != "" {
+			sp.SpdxID = namespace + "#" + SPDXRefPrefix + "Package-" + ids.get(replaceSPDXIDInvalidChars(id))
+		}
+		for _, cpe := range extractCPEs(pkg) {
+			sp.ExternalIdentifiers = append(sp.ExternalIdentifiers, &spdx30.ExternalIdentifier{
+				Type:                   "ExternalIdentifier",
+				ExternalIdentifierType: "cpe23",
+				Identifier:             cpe,
+			})
+		}
+		sp.ExternalIdentifiers = append(sp.ExternalIdentifiers, &spdx30.ExternalIdentifier{
+			Type:                   "ExternalIdentifier",
+			ExternalIdentifierType: "other",
+			Identifier:             fingerprint.Package(pkg),
+			IssuingAuthority:       fingerprintRefType,
+		})
+		b.add(sp, sp.SpdxID)
+		mainContains.To = append(mainContains.To, sp.SpdxID)
+
+		if ld := pkg.LayerDetails; ld != nil && ld.DiffID != "" {
+			rel, ok := layers[ld.DiffID]
+			if !ok {
+				rel = b.addLayer(ld)
+				layers[ld.DiffID] = rel
+				layerRels = append(layerRels, rel)
+			}
+			rel.To = append(rel.To, sp.SpdxID)
+		}
+	}
+	if len(mainContains.To) > 0 {
+		b.add(mainContains, mainContains.SpdxID)
+	}
+	for _, rel := range layerRels {
+		b.add(rel, rel.SpdxID)
+	}
+
+	sbom := &spdx30.Sbom{
+		Element:     b.newElement(spdx30.TypeSbom, "Sbom", ""),
+		SbomTypes:   []string{"analyzed"},
+		RootElement: []string{mainPackage.SpdxID},
+		Elements:    b.elementIDs,
+	}
+	doc := &spdx30.SpdxDocument{
+		Element:            b.newElement(spdx30.TypeSpdxDocument, "DOCUMENT", name),
+		ProfileConformance: []string{"core", "software"},
+		RootElement:        []string{sbom.SpdxID},
+		Elements:           append(slices.Clone(b.elementIDs), sbom.SpdxID),
+	}
+	b.graph = append(b.graph, sbom, doc)
+
+	return &spdx30.Document{
+		Context: spdx30.Context,
+		Graph:   b.graph,
+	}
+}
+
+// spdx30Builder collects the elements of an SPDX 3.0 document.
+type spdx30Builder struct {
+	namespace  string
+	graph      []any
+	elementIDs []string
+}
+
+func (b *spdx30Builder) newElement(typ, kind, name string) spdx30.Element {
+	return spdx30.Element{
+		Type:         typ,
+		SpdxID:       b.namespace + "#" + SPDXRefPrefix + kind + "-" + uuid.New().String(),
+		CreationInfo: spdx30.CreationInfoID,
+		Name:         name,
+	}
+}
+
+func (b *spdx30Builder) add(e any, id string) {
+	b.graph = append(b.graph, e)
+	b.elementIDs = append(b.elementIDs, id)
+}
+
+func (b *spdx30Builder) newContains(from string) *spdx30.Relationship {
+	return &spdx30.Relationship{
+		Element:          b.newElement(spdx30.TypeRelationship, "Relationship", ""),
+		From:             from,
+		RelationshipType: spdx30.RelationshipContains,
+	}
+}
+
+// addLayer adds a package describing the container image layer and returns the
+// relationship to which the packages found in the layer should be added.
+func (b *spdx30Builder) addLayer(ld *extractor.LayerDetails) *spdx30.Relationship {
+	layer := &spdx30.Package{
+		Element:        b.newElement(spdx30.TypePackage, "Layer", fmt.Sprintf("layer-%d", ld.Index)),
+		PrimaryPurpose: "archive",
+	}
+	layer.Comment = ld.Command
+	if strings.HasPrefix(ld.DiffID, "sha256:") {
+		layer.VerifiedUsing = []*spdx30.Hash{{
+			Type:      "Hash",
+			Algorithm: "sha256",
+			HashValue: strings.TrimPrefix(ld.DiffID, "sha256:"),
+		}}
+	}
+	if ld.InBaseImage {
+		layer.SourceInfo = "Layer of the base image"
+	}
+	b.add(layer, layer.SpdxID)
+	return b.newContains(layer.SpdxID)
+}
+
 func replaceSPDXIDInvalidChars(id string) string {
 	return spdxIDInvalidCharRe.ReplaceAllString(id, "-")
 }
@@ -187,6 +386,8 @@ type CDXConfig struct {
 	ComponentVersion string
 	ComponentType    string
 	Authors          []string
+	// Naming customizes the component names and bom-refs.
+	Naming *NamingPolicy
 }
 
 // ToCDX converts the SCALIBR scan results into a CycloneDX document.
@@ -226,13 +427,17 @@ func ToCDX(r *result.ScanResult, c CDXConfig) *cyclonedx.BOM {
 	}
 
 	comps := make([]cyclonedx.Component, 0, len(r.Inventory.Packages))
+	ids := uniqueIDs{}
 	for _, pkg := range r.Inventory.Packages {
 		comp := cyclonedx.Component{
 			BOMRef:  uuid.New().String(),
 			Type:    cyclonedx.ComponentTypeLibrary,
-			Name:    pkg.Name,
+			Name:    c.Naming.componentName(pkg, pkg.Name),
 			Version: pkg.Version,
 		}
+		if id := c.Naming.componentID(pkg, comp.Name); id != "" {
+			comp.BOMRef = ids.get(id)
+		}
 		if p := ToPURL(pkg); p != nil {
 			comp.PackageURL = p.String()
 		}
@@ -250,6 +455,13 @@ func ToCDX(r *result.ScanResult, c CDXConfig) *cyclonedx.BOM {
 				Occurrences: &occ,
 			}
 		}
+		if refs := cdxProvenanceRefs(pkg); len(refs) > 0 {
+			comp.ExternalReferences = &refs
+		}
+		if hashes := cdxHashes(pkg); len(hashes) > 0 {
+			comp.Hashes = &hashes
+		}
+		comp.Properties = &[]cyclonedx.Property{{Name: cdxFingerprintProperty, Value: fingerprint.Package(pkg)}}
 		comps = append(comps, comp)
 	}
 	bom.Components = &comps
@@ -257,12 +469,81 @@ func ToCDX(r *result.ScanResult, c CDXConfig) *cyclonedx.BOM {
 	return bom
 }
 
+// fingerprintRefType is the external reference type used to store the stable
+// ID of packages in SBOMs, see the fingerprint package.
+const fingerprintRefType = "scalibr-fingerprint"
+
+// cdxFingerprintProperty is the name of the CycloneDX component property that
+// stores the stable ID of packages.
+const cdxFingerprintProperty = "scalibr:fingerprint"
+
+// locationProvenanceRefType is the external reference type used to store the
+// provenance of package locations in SBOMs. The locator is the provenance
+// encoded with LocationProvenance.String.
+const locationProvenanceRefType = "location-provenance"
+
+func spdxProvenanceRefs(pkg *extractor.Package) []*v2_3.PackageExternalReference {
+	var refs []*v2_3.PackageExternalReference
+	for _, lp := range pkg.LocationProvenance {
+		refs = append(refs, &v2_3.PackageExternalReference{
+			Category:           "OTHER",
+			RefType:            locationProvenanceRefType,
+			Locator:            lp.String(),
+			ExternalRefComment: lp.Location,
+		})
+	}
+	return refs
+}
+
+func cdxProvenanceRefs(pkg *extractor.Package) []cyclonedx.ExternalReference {
+	var refs []cyclonedx.ExternalReference
+	for _, lp := range pkg.LocationProvenance {
+		refs = append(refs, cyclonedx.ExternalReference{
+			Type:    cyclonedx.ERTypeOther,
+			URL:     lp.String(),
+			Comment: locationProvenanceRefType + ": " + lp.Location,
+		})
+	}
+	return refs
+}
+
+// cdxHashAlgorithms maps the digest algorithms of FileDigests to CycloneDX.
+var cdxHashAlgorithms = map[string]cyclonedx.HashAlgorithm{
+	"md5":    cyclonedx.HashAlgoMD5,
+	"sha1":   cyclonedx.HashAlgoSHA1,
+	"sha256": cyclonedx.HashAlgoSHA256,
+	"sha512": cyclonedx.HashAlgoSHA512,
+	"blake3": cyclonedx.HashAlgoBlake3,
+}
+
+// cdxHashes returns the digests of the package's first location, which is the
+// file the package was extracted from.
+func cdxHashes(pkg *extractor.Package) []cyclonedx.Hash {
+	if len(pkg.Locations) == 0 {
+		return nil
+	}
+	var hashes []cyclonedx.Hash
+	for _, d := range pkg.FileDigests {
+		alg, ok := cdxHashAlgorithms[d.Algorithm]
+		if !ok || d.Location != pkg.Locations[0] {
+			continue
+		}
+		hashes = append(hashes, cyclonedx.Hash{Algorithm: alg, Value: d.Digest})
+	}
+	return hashes
+}
+
 func extractCPEs(p *extractor.Package) []string {
-	// Only the two SBOM package types support storing CPEs.
-	if m, ok := p.Metadata.(*spdxmeta.Metadata); ok {
+	// Only the two SBOM package types, software found from embedded version
+	// strings and language runtimes support storing CPEs.
+	switch m := p.Metadata.(type) {
+	case *spdxmeta.Metadata:
 		return m.CPEs
-	}
-	if m, ok := p.Metadata.(*cdxmeta.Metadata); ok {
+	case *cdxmeta.Metadata:
+		return m.CPEs
+	case *embeddedversion.Metadata:
+		return m.CPEs
+	case *runtimeversion.Metadata:
 		return m.CPEs
 	}
 	return nil
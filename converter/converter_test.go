@@ -17,12 +17,14 @@ package converter_test
 import (
 	"math/rand"
 	"runtime"
+	"slices"
 	"testing"
 
 	"github.com/CycloneDX/cyclonedx-go"
 	"github.com/google/go-cmp/cmp"
 	scalibr "github.com/google/osv-scalibr"
 	"github.com/google/osv-scalibr/converter"
+	"github.com/google/osv-scalibr/converter/spdx30"
 	"github.com/google/osv-scalibr/extractor"
 	"github.com/google/osv-scalibr/extractor/filesystem/language/python/wheelegg"
 	"github.com/google/osv-scalibr/inventory"
@@ -97,6 +99,11 @@ func TestToSPDX23(t *testing.T) {
 								RefType:  "purl",
 								Locator:  "pkg:pypi/software@1.2.3",
 							},
+							{
+								Category: "OTHER",
+								RefType:  "scalibr-fingerprint",
+								Locator:  "50d9db4ebf594fb54da6fe5aec5afa57e037e71ec5d51451ad194b89d4fa1e6e",
+							},
 						},
 					},
 				},
@@ -200,6 +207,11 @@ func TestToSPDX23(t *testing.T) {
 								RefType:  "purl",
 								Locator:  "pkg:pypi/software@1.2.3",
 							},
+							{
+								Category: "OTHER",
+								RefType:  "scalibr-fingerprint",
+								Locator:  "50d9db4ebf594fb54da6fe5aec5afa57e037e71ec5d51451ad194b89d4fa1e6e",
+							},
 						},
 					},
 				},
@@ -345,6 +357,11 @@ func TestToSPDX23(t *testing.T) {
 								RefType:  "purl",
 								Locator:  "pkg:pypi/softw%40re%26@1.2.3",
 							},
+							{
+								Category: "OTHER",
+								RefType:  "scalibr-fingerprint",
+								Locator:  "1b7bf0767d5cbee7ac2823bae2a0d06d2333e857671f82d15a9c8011a19bc265",
+							},
 						},
 					},
 				},
@@ -435,6 +452,11 @@ func TestToSPDX23(t *testing.T) {
 								RefType:  "purl",
 								Locator:  "pkg:pypi/software@1.2.3",
 							},
+							{
+								Category: "OTHER",
+								RefType:  "scalibr-fingerprint",
+								Locator:  "62afbf5e2338f02db96ba365b015d6875ae1d47b73112c72d7cb265a25637796",
+							},
 						},
 					},
 				},
@@ -525,6 +547,11 @@ func TestToSPDX23(t *testing.T) {
 								RefType:  "purl",
 								Locator:  "pkg:pypi/software@1.2.3",
 							},
+							{
+								Category: "OTHER",
+								RefType:  "scalibr-fingerprint",
+								Locator:  "d42eeb0609e44dd0ef9339842cedda6cc0c2c5b59d674f2eb4d49699199a7038",
+							},
 						},
 					},
 				},
@@ -559,6 +586,113 @@ func TestToSPDX23(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc: "Location provenance reported",
+			scanResult: &scalibr.ScanResult{
+				Inventory: inventory.Inventory{
+					Packages: []*extractor.Package{{
+						Name:      "software",
+						Version:   "1.2.3",
+						Plugins:   []string{wheelegg.Name},
+						PURLType:  purl.TypePyPi,
+						Locations: []string{"/app.jar/pom.properties"},
+						LocationProvenance: []*extractor.LocationProvenance{{
+							Location:     "/app.jar/pom.properties",
+							LayerDigest:  "sha256:abc",
+							ArchiveChain: []string{"/app.jar"},
+							Path:         "pom.properties",
+						}},
+					}},
+				},
+			},
+			want: &v2_3.Document{
+				SPDXVersion:       "SPDX-2.3",
+				DataLicense:       "CC0-1.0",
+				SPDXIdentifier:    "DOCUMENT",
+				DocumentName:      "SCALIBR-generated SPDX",
+				DocumentNamespace: "https://spdx.google/8d019192-c242-44e2-8afc-cae3a61fb586",
+				CreationInfo: &v2_3.CreationInfo{
+					Creators: []common.Creator{
+						{
+							CreatorType: "Tool",
+							Creator:     "SCALIBR",
+						},
+					},
+				},
+				Packages: []*v2_3.Package{
+					{
+						PackageName:           "main",
+						PackageSPDXIdentifier: "SPDXRef-Package-main-29b0223b-eea5-44f7-8391-f445d15afd42",
+						PackageVersion:        "0",
+						PackageSupplier: &common.Supplier{
+							Supplier:     converter.NoAssertion,
+							SupplierType: converter.NoAssertion,
+						},
+						PackageDownloadLocation:   converter.NoAssertion,
+						IsFilesAnalyzedTagPresent: false,
+					},
+					{
+						PackageName:           "software",
+						PackageSPDXIdentifier: "SPDXRef-Package-software-94040374-f692-4b98-8bf8-713f8d962d7c",
+						PackageVersion:        "1.2.3",
+						PackageSupplier: &common.Supplier{
+							Supplier:     converter.NoAssertion,
+							SupplierType: converter.NoAssertion,
+						},
+						PackageDownloadLocation:   converter.NoAssertion,
+						IsFilesAnalyzedTagPresent: false,
+						PackageSourceInfo:         "Identified by the python/wheelegg extractor from /app.jar/pom.properties",
+						PackageExternalReferences: []*v2_3.PackageExternalReference{
+							{
+								Category: "PACKAGE-MANAGER",
+								RefType:  "purl",
+								Locator:  "pkg:pypi/software@1.2.3",
+							},
+							{
+								Category: "OTHER",
+								RefType:  "scalibr-fingerprint",
+								Locator:  "753d067c5f280badd4e851db7dc012bb0acc3f1de3f5bd9ad71d18c05a963001",
+							},
+							{
+								Category:           "OTHER",
+								RefType:            "location-provenance",
+								Locator:            "archive=%2Fapp.jar&layer=sha256%3Aabc&path=pom.properties",
+								ExternalRefComment: "/app.jar/pom.properties",
+							},
+						},
+					},
+				},
+				Relationships: []*v2_3.Relationship{
+					{
+						RefA: common.DocElementID{
+							ElementRefID: "SPDXRef-DOCUMENT",
+						},
+						RefB: common.DocElementID{
+							ElementRefID: "SPDXRef-Package-main-29b0223b-eea5-44f7-8391-f445d15afd42",
+						},
+						Relationship: "DESCRIBES",
+					},
+					{
+						RefA: common.DocElementID{
+							ElementRefID: "SPDXRef-Package-main-29b0223b-eea5-44f7-8391-f445d15afd42",
+						},
+						RefB: common.DocElementID{
+							ElementRefID: "SPDXRef-Package-software-94040374-f692-4b98-8bf8-713f8d962d7c",
+						},
+						Relationship: "CONTAINS",
+					},
+					{
+						RefA: common.DocElementID{
+							ElementRefID: "SPDXRef-Package-software-94040374-f692-4b98-8bf8-713f8d962d7c",
+						},
+						RefB: common.DocElementID{
+							SpecialID: converter.NoAssertion,
+						},
+						Relationship: "CONTAINS",
+					},
+				},
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -574,6 +708,139 @@ func TestToSPDX23(t *testing.T) {
 	}
 }
 
+func TestToSPDX30(t *testing.T) {
+	// Make UUIDs deterministic
+	uuid.SetRand(rand.New(rand.NewSource(1)))
+
+	scanResult := &scalibr.ScanResult{
+		Inventory: inventory.Inventory{
+			Packages: []*extractor.Package{
+				{
+					Name:      "software",
+					Version:   "1.2.3",
+					PURLType:  purl.TypePyPi,
+					Plugins:   []string{wheelegg.Name},
+					Locations: []string{"usr/lib/python3/site-packages/software"},
+					LayerDetails: &extractor.LayerDetails{
+						Index:   2,
+						DiffID:  "sha256:abc",
+						Command: "RUN pip install software",
+					},
+				},
+				{
+					// Skipped since it has no version.
+					Name:     "no-version",
+					PURLType: purl.TypePyPi,
+				},
+			},
+		},
+	}
+	config := converter.SPDXConfig{
+		DocumentName:      "Custom name",
+		DocumentNamespace: "https://example.com/ns",
+		Creators: []common.Creator{
+			{CreatorType: "Organization", Creator: "Google"},
+			{CreatorType: "Tool", Creator: "custom-tool"},
+		},
+	}
+
+	id := func(kind, uuid string) string {
+		return "https://example.com/ns#SPDXRef-" + kind + "-" + uuid
+	}
+	agentID := id("Agent", "52fdfc07-2182-454f-963f-5f0f9a621d72")
+	orgID := id("Organization", "9566c74d-1003-4c4d-bbbb-0407d1e2c649")
+	toolID := id("Tool", "81855ad8-681d-4d86-91e9-1e00167939cb")
+	mainID := id("Package-main", "6694d2c4-22ac-4208-a007-2939487f6999")
+	pkgID := id("Package-software", "95af5a25-3679-41ba-a2ff-6cd471c483f1")
+	layerID := id("Layer", "5fb90bad-b37c-4821-b6d9-5526a41a9504")
+	mainRelID := id("Relationship", "eb9d18a4-4784-445d-87f3-c67cf22746e9")
+	layerRelID := id("Relationship", "680b4e7c-8b76-4a1b-9d49-d4955c848621")
+	sbomID := id("Sbom", "6325253f-ec73-4dd7-a9e2-8bf921119c16")
+	docID := id("DOCUMENT", "0f070244-8615-4bda-8831-3f6a8eb668d2")
+	elementIDs := []string{agentID, orgID, toolID, mainID, pkgID, layerID, mainRelID, layerRelID}
+	element := func(typ, id, name string) spdx30.Element {
+		return spdx30.Element{Type: typ, SpdxID: id, CreationInfo: spdx30.CreationInfoID, Name: name}
+	}
+
+	want := &spdx30.Document{
+		Context: spdx30.Context,
+		Graph: []any{
+			&spdx30.CreationInfo{
+				Type:         spdx30.TypeCreationInfo,
+				ID:           spdx30.CreationInfoID,
+				SpecVersion:  "3.0.1",
+				CreatedBy:    []string{agentID, orgID},
+				CreatedUsing: []string{toolID},
+			},
+			&spdx30.Agent{Element: element(spdx30.TypeSoftwareAgent, agentID, "SCALIBR")},
+			&spdx30.Agent{Element: element(spdx30.TypeOrganization, orgID, "Google")},
+			&spdx30.Agent{Element: element(spdx30.TypeTool, toolID, "custom-tool")},
+			&spdx30.Package{Element: element(spdx30.TypePackage, mainID, "main"), PackageVersion: "0"},
+			&spdx30.Package{
+				Element: spdx30.Element{
+					Type:         spdx30.TypePackage,
+					SpdxID:       pkgID,
+					CreationInfo: spdx30.CreationInfoID,
+					Name:         "software",
+					ExternalIdentifiers: []*spdx30.ExternalIdentifier{{
+						Type:                   "ExternalIdentifier",
+						ExternalIdentifierType: "other",
+						Identifier:             "9f608be7ecb05472781a43ecb55b5ca59749ac2c459913024f4f5c40faa2abd8",
+						IssuingAuthority:       "scalibr-fingerprint",
+					}},
+				},
+				PackageVersion: "1.2.3",
+				PackageURL:     "pkg:pypi/software@1.2.3",
+				SourceInfo:     "Identified by the python/wheelegg extractor from usr/lib/python3/site-packages/software",
+			},
+			&spdx30.Package{
+				Element: spdx30.Element{
+					Type:          spdx30.TypePackage,
+					SpdxID:        layerID,
+					CreationInfo:  spdx30.CreationInfoID,
+					Name:          "layer-2",
+					Comment:       "RUN pip install software",
+					VerifiedUsing: []*spdx30.Hash{{Type: "Hash", Algorithm: "sha256", HashValue: "abc"}},
+				},
+				PrimaryPurpose: "archive",
+			},
+			&spdx30.Relationship{
+				Element:          element(spdx30.TypeRelationship, mainRelID, ""),
+				From:             mainID,
+				To:               []string{pkgID},
+				RelationshipType: spdx30.RelationshipContains,
+			},
+			&spdx30.Relationship{
+				Element:          element(spdx30.TypeRelationship, layerRelID, ""),
+				From:             layerID,
+				To:               []string{pkgID},
+				RelationshipType: spdx30.RelationshipContains,
+			},
+			&spdx30.Sbom{
+				Element:     element(spdx30.TypeSbom, sbomID, ""),
+				SbomTypes:   []string{"analyzed"},
+				RootElement: []string{mainID},
+				Elements:    elementIDs,
+			},
+			&spdx30.SpdxDocument{
+				Element:            element(spdx30.TypeSpdxDocument, docID, "Custom name"),
+				ProfileConformance: []string{"core", "software"},
+				RootElement:        []string{sbomID},
+				Elements:           append(slices.Clone(elementIDs), sbomID),
+			},
+		},
+	}
+
+	got := converter.ToSPDX30(scanResult, config)
+	// Can't mock time.Now() so skip verifying the timestamp.
+	if ci, ok := got.Graph[0].(*spdx30.CreationInfo); ok {
+		want.Graph[0].(*spdx30.CreationInfo).Created = ci.Created
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("converter.ToSPDX30(%v): unexpected diff (-want +got):\n%s", scanResult, diff)
+	}
+}
+
 func ptr[T any](v T) *T {
 	return &v
 }
@@ -633,6 +900,7 @@ func TestToCDX(t *testing.T) {
 						Name:       "software",
 						Version:    "1.2.3",
 						PackageURL: "pkg:pypi/software@1.2.3",
+						Properties: ptr([]cyclonedx.Property{{Name: "scalibr:fingerprint", Value: "50d9db4ebf594fb54da6fe5aec5afa57e037e71ec5d51451ad194b89d4fa1e6e"}}),
 					},
 				}),
 			},
@@ -683,6 +951,117 @@ func TestToCDX(t *testing.T) {
 						Name:       "software",
 						Version:    "1.2.3",
 						PackageURL: "pkg:pypi/software@1.2.3",
+						Properties: ptr([]cyclonedx.Property{{Name: "scalibr:fingerprint", Value: "50d9db4ebf594fb54da6fe5aec5afa57e037e71ec5d51451ad194b89d4fa1e6e"}}),
+					},
+				}),
+			},
+		},
+		{
+			desc: "Package with location provenance",
+			scanResult: &scalibr.ScanResult{
+				Inventory: inventory.Inventory{
+					Packages: []*extractor.Package{{
+						Name:      "software",
+						Version:   "1.2.3",
+						PURLType:  purl.TypePyPi,
+						Plugins:   []string{wheelegg.Name},
+						Locations: []string{"/app.jar/pom.properties"},
+						LocationProvenance: []*extractor.LocationProvenance{{
+							Location:     "/app.jar/pom.properties",
+							LayerDigest:  "sha256:abc",
+							ArchiveChain: []string{"/app.jar"},
+							Path:         "pom.properties",
+						}},
+					}},
+				},
+			},
+			want: &cyclonedx.BOM{
+				Metadata: &cyclonedx.Metadata{
+					Component: &cyclonedx.Component{
+						BOMRef: "eb9d18a4-4784-445d-87f3-c67cf22746e9",
+					},
+					Tools: &cyclonedx.ToolsChoice{
+						Components: &[]cyclonedx.Component{
+							{
+								Type: cyclonedx.ComponentTypeApplication,
+								Name: "SCALIBR",
+								ExternalReferences: ptr([]cyclonedx.ExternalReference{
+									{URL: "https://github.com/google/osv-scalibr", Type: cyclonedx.ERTypeWebsite},
+								}),
+							},
+						},
+					},
+				},
+				Components: ptr([]cyclonedx.Component{
+					{
+						BOMRef:     "95af5a25-3679-41ba-a2ff-6cd471c483f1",
+						Type:       "library",
+						Name:       "software",
+						Version:    "1.2.3",
+						PackageURL: "pkg:pypi/software@1.2.3",
+						Evidence: &cyclonedx.Evidence{
+							Occurrences: ptr([]cyclonedx.EvidenceOccurrence{{Location: "/app.jar/pom.properties"}}),
+						},
+						ExternalReferences: ptr([]cyclonedx.ExternalReference{{
+							Type:    cyclonedx.ERTypeOther,
+							URL:     "archive=%2Fapp.jar&layer=sha256%3Aabc&path=pom.properties",
+							Comment: "location-provenance: /app.jar/pom.properties",
+						}}),
+						Properties: ptr([]cyclonedx.Property{{Name: "scalibr:fingerprint", Value: "753d067c5f280badd4e851db7dc012bb0acc3f1de3f5bd9ad71d18c05a963001"}}),
+					},
+				}),
+			},
+		},
+		{
+			desc: "Package with file digests",
+			scanResult: &scalibr.ScanResult{
+				Inventory: inventory.Inventory{
+					Packages: []*extractor.Package{{
+						Name:      "software",
+						Version:   "1.2.3",
+						PURLType:  purl.TypePyPi,
+						Plugins:   []string{wheelegg.Name},
+						Locations: []string{"/software-1.2.3.whl", "/requirements.txt"},
+						FileDigests: []*extractor.FileDigest{
+							{Location: "/software-1.2.3.whl", Algorithm: "sha256", Digest: "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"},
+							{Location: "/software-1.2.3.whl", Algorithm: "md5", Digest: "5d41402abc4b2a76b9719d911017c592"},
+							{Location: "/requirements.txt", Algorithm: "sha256", Digest: "486ea46224d1bb4fb680f34f7c9ad96a8f24ec88be73ea8e5a6c65260e9cb8a7"},
+						},
+					}},
+				},
+			},
+			want: &cyclonedx.BOM{
+				Metadata: &cyclonedx.Metadata{
+					Component: &cyclonedx.Component{
+						BOMRef: "5fb90bad-b37c-4821-b6d9-5526a41a9504",
+					},
+					Tools: &cyclonedx.ToolsChoice{
+						Components: &[]cyclonedx.Component{
+							{
+								Type: cyclonedx.ComponentTypeApplication,
+								Name: "SCALIBR",
+								ExternalReferences: ptr([]cyclonedx.ExternalReference{
+									{URL: "https://github.com/google/osv-scalibr", Type: cyclonedx.ERTypeWebsite},
+								}),
+							},
+						},
+					},
+				},
+				Components: ptr([]cyclonedx.Component{
+					{
+						BOMRef:     "680b4e7c-8b76-4a1b-9d49-d4955c848621",
+						Type:       "library",
+						Name:       "software",
+						Version:    "1.2.3",
+						PackageURL: "pkg:pypi/software@1.2.3",
+						Evidence: &cyclonedx.Evidence{
+							Occurrences: ptr([]cyclonedx.EvidenceOccurrence{{Location: "/software-1.2.3.whl"}, {Location: "/requirements.txt"}}),
+						},
+						Hashes: ptr([]cyclonedx.Hash{
+							{Algorithm: cyclonedx.HashAlgoSHA256, Value: "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"},
+							{Algorithm: cyclonedx.HashAlgoMD5, Value: "5d41402abc4b2a76b9719d911017c592"},
+						}),
+						Properties: ptr([]cyclonedx.Property{{Name: "scalibr:fingerprint", Value: "507308b3f54827414d71a632315c27e4af3819791124093ec025d6ca2b346d3d"}}),
 					},
 				}),
 			},
@@ -0,0 +1,84 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package depgraph
+
+import (
+	"io"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+type cargoLockFile struct {
+	Packages []cargoPackage `toml:"package"`
+}
+
+type cargoPackage struct {
+	Name         string   `toml:"name"`
+	Version      string   `toml:"version"`
+	Source       string   `toml:"source"`
+	Dependencies []string `toml:"dependencies"`
+}
+
+// parseCargoLock builds the graph from the dependency lists of a Cargo.lock
+// file. Packages without a source are workspace members and become roots.
+func parseCargoLock(r io.Reader, lockfile string) (*Graph, error) {
+	var lock cargoLockFile
+	if _, err := toml.NewDecoder(r).Decode(&lock); err != nil {
+		return nil, err
+	}
+
+	g := newGraph(lockfile, "crates.io")
+	byName := make(map[string][]*cargoPackage)
+	ids := make(map[*cargoPackage]string)
+	for i := range lock.Packages {
+		p := &lock.Packages[i]
+		byName[p.Name] = append(byName[p.Name], p)
+		ids[p] = g.addNode(p.Name, p.Version, p.Source == "")
+	}
+
+	for i := range lock.Packages {
+		p := &lock.Packages[i]
+		for _, dep := range p.Dependencies {
+			if d := resolveCargoDep(byName, dep); d != nil {
+				g.addEdge(ids[p], ids[d], "")
+			}
+		}
+	}
+	return g, nil
+}
+
+// resolveCargoDep finds the package a dependency entry refers to. Entries are
+// just the name when only one version of the crate is in the lockfile, and
+// "name version" or "name version (source)" otherwise.
+func resolveCargoDep(byName map[string][]*cargoPackage, dep string) *cargoPackage {
+	fields := strings.Fields(dep)
+	if len(fields) == 0 {
+		return nil
+	}
+	candidates := byName[fields[0]]
+	if len(fields) == 1 {
+		if len(candidates) == 1 {
+			return candidates[0]
+		}
+		return nil
+	}
+	for _, c := range candidates {
+		if c.Version == fields[1] {
+			return c
+		}
+	}
+	return nil
+}
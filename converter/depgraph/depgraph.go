@@ -0,0 +1,238 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package depgraph builds the resolved dependency graph of an application from
+// its lockfile and renders it as DOT or JSON.
+package depgraph
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/google/osv-scalibr/extractor"
+)
+
+// Format is the output format of a dependency graph.
+type Format string
+
+const (
+	// FormatDOT renders graphs in the Graphviz DOT language.
+	FormatDOT Format = "dot"
+	// FormatJSON renders graphs as JSON objects with node and edge lists.
+	FormatJSON Format = "json"
+)
+
+// Edge kinds for dependencies that are not regular runtime dependencies.
+const (
+	KindDev      = "dev"
+	KindOptional = "optional"
+	KindPeer     = "peer"
+	KindIndirect = "indirect"
+)
+
+// ErrUnsupported is returned for lockfiles the graph can't be built from.
+var ErrUnsupported = errors.New("unsupported lockfile")
+
+// Node is a resolved package in a dependency graph.
+type Node struct {
+	// ID uniquely identifies the node in the graph, usually "name@version".
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+	// Root is set for the application itself and for workspace members.
+	Root bool `json:"root,omitempty"`
+}
+
+// Edge is a dependency of the From node on the To node.
+type Edge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+	// Kind is empty for regular dependencies, or one of the Kind constants.
+	Kind string `json:"kind,omitempty"`
+}
+
+// Graph is the dependency graph of a single application.
+type Graph struct {
+	// Application is the path of the lockfile the graph was built from.
+	Application string  `json:"application"`
+	Ecosystem   string  `json:"ecosystem"`
+	Nodes       []*Node `json:"nodes"`
+	Edges       []*Edge `json:"edges"`
+
+	nodes map[string]*Node
+	edges map[Edge]bool
+}
+
+func newGraph(application, ecosystem string) *Graph {
+	return &Graph{
+		Application: application,
+		Ecosystem:   ecosystem,
+		nodes:       make(map[string]*Node),
+		edges:       make(map[Edge]bool),
+	}
+}
+
+// addNode adds the node with the given name and version to the graph if it's not
+// there yet and returns its ID.
+func (g *Graph) addNode(name, version string, root bool) string {
+	id := name
+	if version != "" {
+		id += "@" + version
+	}
+	if n, ok := g.nodes[id]; ok {
+		n.Root = n.Root || root
+		return id
+	}
+	n := &Node{ID: id, Name: name, Version: version, Root: root}
+	g.nodes[id] = n
+	g.Nodes = append(g.Nodes, n)
+	return id
+}
+
+func (g *Graph) addEdge(from, to, kind string) {
+	e := Edge{From: from, To: to, Kind: kind}
+	if from == to || g.edges[e] {
+		return
+	}
+	g.edges[e] = true
+	g.Edges = append(g.Edges, &e)
+}
+
+// sort orders nodes and edges by ID so that the output is deterministic.
+func (g *Graph) sort() {
+	slices.SortFunc(g.Nodes, func(a, b *Node) int { return strings.Compare(a.ID, b.ID) })
+	slices.SortFunc(g.Edges, func(a, b *Edge) int {
+		if c := strings.Compare(a.From, b.From); c != 0 {
+			return c
+		}
+		if c := strings.Compare(a.To, b.To); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Kind, b.Kind)
+	})
+}
+
+// FromLockfile builds the dependency graph of the application described by the
+// lockfile at the given path. Cargo.lock, package-lock.json, npm-shrinkwrap.json
+// and go.mod files are supported, other files return ErrUnsupported.
+func FromLockfile(fsys fs.FS, lockfile string) (*Graph, error) {
+	parse := parserFor(lockfile)
+	if parse == nil {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupported, lockfile)
+	}
+	f, err := fsys.Open(lockfile)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	g, err := parse(f, lockfile)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", lockfile, err)
+	}
+	g.sort()
+	return g, nil
+}
+
+func parserFor(lockfile string) func(io.Reader, string) (*Graph, error) {
+	switch path.Base(lockfile) {
+	case "Cargo.lock":
+		return parseCargoLock
+	case "package-lock.json", "npm-shrinkwrap.json":
+		return parsePackageLock
+	case "go.mod":
+		return parseGoMod
+	default:
+		return nil
+	}
+}
+
+// Applications returns the paths of the supported lockfiles the given packages
+// were extracted from, in the order they were first seen.
+func Applications(pkgs []*extractor.Package) []string {
+	var apps []string
+	seen := make(map[string]bool)
+	for _, pkg := range pkgs {
+		if len(pkg.Locations) == 0 {
+			continue
+		}
+		loc := pkg.Locations[0]
+		if seen[loc] || parserFor(loc) == nil {
+			continue
+		}
+		seen[loc] = true
+		apps = append(apps, loc)
+	}
+	return apps
+}
+
+// FileName returns the name of the file the graph of the given application is
+// written to, e.g. "web_package-lock.json.dot" for "web/package-lock.json".
+func FileName(application string, format Format) string {
+	name := strings.TrimLeft(path.Clean(application), "/")
+	return strings.ReplaceAll(name, "/", "_") + "." + string(format)
+}
+
+// Write renders the graph in the given format.
+func (g *Graph) Write(w io.Writer, format Format) error {
+	switch format {
+	case FormatDOT:
+		return g.WriteDOT(w)
+	case FormatJSON:
+		return g.WriteJSON(w)
+	default:
+		return fmt.Errorf("unknown dependency graph format %q", format)
+	}
+}
+
+// WriteJSON renders the graph as an indented JSON object.
+func (g *Graph) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(g)
+}
+
+// WriteDOT renders the graph as a Graphviz digraph. Root nodes are drawn as boxes
+// and dev, optional, peer and indirect dependencies as dashed edges.
+func (g *Graph) WriteDOT(w io.Writer) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "digraph %s {\n", strconv.Quote(g.Application))
+	for _, n := range g.Nodes {
+		label := n.Name
+		if n.Version != "" {
+			label += "\n" + n.Version
+		}
+		attrs := "label=" + strconv.Quote(label)
+		if n.Root {
+			attrs += ", shape=box"
+		}
+		fmt.Fprintf(&b, "  %s [%s];\n", strconv.Quote(n.ID), attrs)
+	}
+	for _, e := range g.Edges {
+		fmt.Fprintf(&b, "  %s -> %s", strconv.Quote(e.From), strconv.Quote(e.To))
+		if e.Kind != "" {
+			fmt.Fprintf(&b, " [style=dashed, label=%s]", strconv.Quote(e.Kind))
+		}
+		b.WriteString(";\n")
+	}
+	b.WriteString("}\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
@@ -0,0 +1,223 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package depgraph_test
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/osv-scalibr/converter/depgraph"
+	"github.com/google/osv-scalibr/extractor"
+)
+
+func TestFromLockfile(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		lockfile string
+		want     *depgraph.Graph
+	}{
+		{
+			desc:     "cargo",
+			lockfile: "Cargo.lock",
+			want: &depgraph.Graph{
+				Application: "Cargo.lock",
+				Ecosystem:   "crates.io",
+				Nodes: []*depgraph.Node{
+					{ID: "app@0.1.0", Name: "app", Version: "0.1.0", Root: true},
+					{ID: "rand@0.7.3", Name: "rand", Version: "0.7.3"},
+					{ID: "rand@0.8.5", Name: "rand", Version: "0.8.5"},
+					{ID: "serde@1.0.200", Name: "serde", Version: "1.0.200"},
+					{ID: "util@0.1.0", Name: "util", Version: "0.1.0", Root: true},
+				},
+				Edges: []*depgraph.Edge{
+					{From: "app@0.1.0", To: "rand@0.8.5"},
+					{From: "app@0.1.0", To: "serde@1.0.200"},
+					{From: "app@0.1.0", To: "util@0.1.0"},
+					{From: "util@0.1.0", To: "rand@0.7.3"},
+				},
+			},
+		},
+		{
+			desc:     "npm_with_workspace",
+			lockfile: "package-lock.json",
+			want: &depgraph.Graph{
+				Application: "package-lock.json",
+				Ecosystem:   "npm",
+				Nodes: []*depgraph.Node{
+					{ID: "a@1.0.0", Name: "a", Version: "1.0.0"},
+					{ID: "app@1.0.0", Name: "app", Version: "1.0.0", Root: true},
+					{ID: "b@1.5.0", Name: "b", Version: "1.5.0"},
+					{ID: "b@2.0.0", Name: "b", Version: "2.0.0"},
+					{ID: "c@1.2.0", Name: "c", Version: "1.2.0"},
+					{ID: "ui@0.0.1", Name: "ui", Version: "0.0.1", Root: true},
+				},
+				Edges: []*depgraph.Edge{
+					{From: "a@1.0.0", To: "b@1.5.0"},
+					{From: "a@1.0.0", To: "c@1.2.0"},
+					{From: "app@1.0.0", To: "a@1.0.0"},
+					{From: "app@1.0.0", To: "b@2.0.0", Kind: depgraph.KindDev},
+					{From: "app@1.0.0", To: "ui@0.0.1"},
+					{From: "b@2.0.0", To: "c@1.2.0", Kind: depgraph.KindPeer},
+					{From: "ui@0.0.1", To: "c@1.2.0", Kind: depgraph.KindOptional},
+				},
+			},
+		},
+		{
+			desc:     "go",
+			lockfile: "go.mod",
+			want: &depgraph.Graph{
+				Application: "go.mod",
+				Ecosystem:   "Go",
+				Nodes: []*depgraph.Node{
+					{ID: "example.com/app", Name: "example.com/app", Root: true},
+					{ID: "example.com/lib", Name: "example.com/lib"},
+					{ID: "github.com/google/go-cmp@v0.6.0", Name: "github.com/google/go-cmp", Version: "v0.6.0"},
+					{ID: "golang.org/x/mod@v0.17.0", Name: "golang.org/x/mod", Version: "v0.17.0"},
+				},
+				Edges: []*depgraph.Edge{
+					{From: "example.com/app", To: "example.com/lib"},
+					{From: "example.com/app", To: "github.com/google/go-cmp@v0.6.0"},
+					{From: "example.com/app", To: "golang.org/x/mod@v0.17.0", Kind: depgraph.KindIndirect},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := depgraph.FromLockfile(os.DirFS("testdata"), tc.lockfile)
+			if err != nil {
+				t.Fatalf("FromLockfile(%q): %v", tc.lockfile, err)
+			}
+			if diff := cmp.Diff(tc.want, got, cmpopts.IgnoreUnexported(depgraph.Graph{})); diff != "" {
+				t.Errorf("FromLockfile(%q) returned unexpected diff (-want +got):\n%s", tc.lockfile, diff)
+			}
+		})
+	}
+}
+
+func TestFromLockfile_Unsupported(t *testing.T) {
+	_, err := depgraph.FromLockfile(os.DirFS("testdata"), "yarn.lock")
+	if !errors.Is(err, depgraph.ErrUnsupported) {
+		t.Errorf("FromLockfile(yarn.lock) returned error %v, want %v", err, depgraph.ErrUnsupported)
+	}
+}
+
+func TestApplications(t *testing.T) {
+	pkgs := []*extractor.Package{
+		{Name: "a", Locations: []string{"web/package-lock.json"}},
+		{Name: "b", Locations: []string{"web/package-lock.json"}},
+		{Name: "c", Locations: []string{"web/yarn.lock"}},
+		{Name: "d", Locations: []string{"svc/Cargo.lock"}},
+		{Name: "e"},
+	}
+	want := []string{"web/package-lock.json", "svc/Cargo.lock"}
+	if diff := cmp.Diff(want, depgraph.Applications(pkgs)); diff != "" {
+		t.Errorf("Applications() returned unexpected diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestFileName(t *testing.T) {
+	got := depgraph.FileName("/web/app/package-lock.json", depgraph.FormatDOT)
+	if want := "web_app_package-lock.json.dot"; got != want {
+		t.Errorf("FileName() = %q, want %q", got, want)
+	}
+}
+
+func TestWrite(t *testing.T) {
+	g, err := depgraph.FromLockfile(os.DirFS("testdata"), "go.mod")
+	if err != nil {
+		t.Fatalf("FromLockfile(go.mod): %v", err)
+	}
+
+	testCases := []struct {
+		format depgraph.Format
+		want   string
+	}{
+		{
+			format: depgraph.FormatDOT,
+			want: `digraph "go.mod" {
+  "example.com/app" [label="example.com/app", shape=box];
+  "example.com/lib" [label="example.com/lib"];
+  "github.com/google/go-cmp@v0.6.0" [label="github.com/google/go-cmp\nv0.6.0"];
+  "golang.org/x/mod@v0.17.0" [label="golang.org/x/mod\nv0.17.0"];
+  "example.com/app" -> "example.com/lib";
+  "example.com/app" -> "github.com/google/go-cmp@v0.6.0";
+  "example.com/app" -> "golang.org/x/mod@v0.17.0" [style=dashed, label="indirect"];
+}
+`,
+		},
+		{
+			format: depgraph.FormatJSON,
+			want: `{
+  "application": "go.mod",
+  "ecosystem": "Go",
+  "nodes": [
+    {
+      "id": "example.com/app",
+      "name": "example.com/app",
+      "root": true
+    },
+    {
+      "id": "example.com/lib",
+      "name": "example.com/lib"
+    },
+    {
+      "id": "github.com/google/go-cmp@v0.6.0",
+      "name": "github.com/google/go-cmp",
+      "version": "v0.6.0"
+    },
+    {
+      "id": "golang.org/x/mod@v0.17.0",
+      "name": "golang.org/x/mod",
+      "version": "v0.17.0"
+    }
+  ],
+  "edges": [
+    {
+      "from": "example.com/app",
+      "to": "example.com/lib"
+    },
+    {
+      "from": "example.com/app",
+      "to": "github.com/google/go-cmp@v0.6.0"
+    },
+    {
+      "from": "example.com/app",
+      "to": "golang.org/x/mod@v0.17.0",
+      "kind": "indirect"
+    }
+  ]
+}
+`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(string(tc.format), func(t *testing.T) {
+			var b strings.Builder
+			if err := g.Write(&b, tc.format); err != nil {
+				t.Fatalf("Write(%q): %v", tc.format, err)
+			}
+			if diff := cmp.Diff(tc.want, b.String()); diff != "" {
+				t.Errorf("Write(%q) returned unexpected diff (-want +got):\n%s", tc.format, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package depgraph
+
+import (
+	"io"
+
+	"golang.org/x/mod/modfile"
+)
+
+// parseGoMod builds the graph from the requirements of a go.mod file. go.mod
+// only records the requirements of the main module, so the graph has the main
+// module as its root and edges to every required module, with modules marked as
+// "// indirect" linked by indirect edges. Replace directives are applied.
+func parseGoMod(r io.Reader, lockfile string) (*Graph, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	f, err := modfile.Parse(lockfile, data, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	g := newGraph(lockfile, "Go")
+	rootName := "main"
+	if f.Module != nil {
+		rootName = f.Module.Mod.Path
+	}
+	root := g.addNode(rootName, "", true)
+
+	replaced := make(map[string]*modfile.Replace)
+	for _, r := range f.Replace {
+		replaced[r.Old.Path] = r
+	}
+	for _, req := range f.Require {
+		mod := req.Mod
+		if r, ok := replaced[mod.Path]; ok && (r.Old.Version == "" || r.Old.Version == mod.Version) {
+			if r.New.Version != "" {
+				mod = r.New
+			} else {
+				// Replaced by a local directory, which has no version.
+				mod.Version = ""
+			}
+		}
+		kind := ""
+		if req.Indirect {
+			kind = KindIndirect
+		}
+		g.addEdge(root, g.addNode(mod.Path, mod.Version, false), kind)
+	}
+	return g, nil
+}
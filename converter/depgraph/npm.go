@@ -0,0 +1,125 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package depgraph
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/google/osv-scalibr/internal/dependencyfile/packagelockjson"
+)
+
+var errNPMLockfileV1 = errors.New("lockfileVersion 1 has no \"packages\" section, regenerate it with npm 7 or later")
+
+// parsePackageLock builds the graph from the "packages" section of a v2 or v3
+// package-lock.json. Dependencies are resolved the way Node.js does: from the
+// node_modules directory of the dependent package upwards. The application
+// and its workspaces become roots.
+func parsePackageLock(r io.Reader, lockfile string) (*Graph, error) {
+	var lock packagelockjson.LockFile
+	if err := json.NewDecoder(r).Decode(&lock); err != nil {
+		return nil, err
+	}
+	if lock.Packages == nil {
+		return nil, errNPMLockfileV1
+	}
+
+	g := newGraph(lockfile, "npm")
+	ids := make(map[string]string)
+	for p, pkg := range lock.Packages {
+		if pkg.Link {
+			continue
+		}
+		ids[p] = g.addNode(npmPackageName(p, pkg), pkg.Version, !strings.Contains(p, "node_modules/"))
+	}
+
+	for p, pkg := range lock.Packages {
+		if pkg.Link {
+			continue
+		}
+		deps := []struct {
+			names map[string]string
+			kind  string
+		}{
+			{pkg.Dependencies, ""},
+			{pkg.DevDependencies, KindDev},
+			{pkg.OptionalDependencies, KindOptional},
+			{pkg.PeerDependencies, KindPeer},
+		}
+		for _, d := range deps {
+			for name := range d.names {
+				to, ok := resolveNPMDep(lock.Packages, p, name)
+				if !ok {
+					continue
+				}
+				if id, ok := ids[to]; ok {
+					g.addEdge(ids[p], id, d.kind)
+				}
+			}
+		}
+	}
+	return g, nil
+}
+
+// npmPackageName returns the name of the package installed at the given path of
+// the lockfile's "packages" section.
+func npmPackageName(p string, pkg packagelockjson.Package) string {
+	if pkg.Name != "" {
+		return pkg.Name
+	}
+	if i := strings.LastIndex(p, "node_modules/"); i >= 0 {
+		return p[i+len("node_modules/"):]
+	}
+	if p == "" {
+		return "root"
+	}
+	return path.Base(p)
+}
+
+// resolveNPMDep returns the path of the package that the package at p gets when
+// requiring name, following workspace links.
+func resolveNPMDep(pkgs map[string]packagelockjson.Package, p, name string) (string, bool) {
+	dir := p
+	for {
+		candidate := "node_modules/" + name
+		if dir != "" {
+			candidate = dir + "/" + candidate
+		}
+		if pkg, ok := pkgs[candidate]; ok {
+			if pkg.Link {
+				return pkg.Resolved, true
+			}
+			return candidate, true
+		}
+		if dir == "" {
+			return "", false
+		}
+		dir = npmParentDir(dir)
+	}
+}
+
+// npmParentDir returns the directory whose node_modules is searched after the
+// one of dir, e.g. "node_modules/a" for "node_modules/a/node_modules/b" and ""
+// for "node_modules/a" or a workspace directory.
+func npmParentDir(dir string) string {
+	i := strings.LastIndex(dir, "/node_modules/")
+	if i < 0 {
+		return ""
+	}
+	return dir[:i]
+}
@@ -0,0 +1,11 @@
+module example.com/app
+
+go 1.22
+
+require (
+	github.com/google/go-cmp v0.6.0
+	golang.org/x/mod v0.17.0 // indirect
+	example.com/lib v1.0.0
+)
+
+replace example.com/lib => ../lib
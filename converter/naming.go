@@ -0,0 +1,223 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package converter
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/uuid"
+	"gopkg.in/yaml.v3"
+)
+
+// NamingPolicy controls the names and identifiers of the packages in the generated SBOMs,
+// e.g. to follow the naming conventions of a downstream system. Nil fields keep the default
+// behavior.
+type NamingPolicy struct {
+	// ComponentName returns the name of the package in the SBOM. name is the default name.
+	ComponentName func(pkg *extractor.Package, name string) string
+	// ComponentID returns the identifier of the package: the bom-ref in CycloneDX and the part
+	// of the SPDX ID after "SPDXRef-Package-". Returning "" keeps the random default ID.
+	// Duplicate IDs are made unique by appending a counter.
+	ComponentID func(pkg *extractor.Package, name string) string
+	// DocumentNamespace returns the namespace URI of SPDX documents for which no namespace
+	// was configured. Returning "" keeps the random default namespace.
+	DocumentNamespace func(documentName string) string
+}
+
+func (p *NamingPolicy) componentName(pkg *extractor.Package, name string) string {
+	if p == nil || p.ComponentName == nil {
+		return name
+	}
+	return p.ComponentName(pkg, name)
+}
+
+func (p *NamingPolicy) componentID(pkg *extractor.Package, name string) string {
+	if p == nil || p.ComponentID == nil {
+		return ""
+	}
+	return p.ComponentID(pkg, name)
+}
+
+func (p *NamingPolicy) documentNamespace(documentName string) string {
+	if p != nil && p.DocumentNamespace != nil {
+		if ns := p.DocumentNamespace(documentName); ns != "" {
+			return ns
+		}
+	}
+	return "https://spdx.google/" + uuid.New().String()
+}
+
+// uniqueIDs makes the component IDs returned by a naming policy unique within a document.
+type uniqueIDs map[string]int
+
+func (u uniqueIDs) get(id string) string {
+	u[id]++
+	if n := u[id]; n > 1 {
+		unique := id + "-" + strconv.Itoa(n)
+		// The suffixed ID might be taken by another package already.
+		for u[unique] > 0 {
+			n++
+			unique = id + "-" + strconv.Itoa(n)
+		}
+		u[unique]++
+		return unique
+	}
+	return id
+}
+
+// NameRule rewrites the names of packages matching a regex.
+type NameRule struct {
+	// Ecosystem restricts the rule to packages of the given ecosystem, e.g. "npm". Versioned
+	// ecosystems like "Debian:12" are matched by their base name.
+	Ecosystem string `yaml:"ecosystem,omitempty"`
+	// Regex is matched unanchored against the name.
+	Regex string `yaml:"regex"`
+	// Replacement replaces the matches of Regex, using regexp.Expand syntax, e.g. "$1".
+	Replacement string `yaml:"replacement"`
+}
+
+// NamingRules is the format of SBOM naming rules files.
+type NamingRules struct {
+	// Names are applied in order to the package names.
+	Names []NameRule `yaml:"names"`
+	// Lowercase lowercases the package names after applying Names.
+	Lowercase bool `yaml:"lowercase"`
+	// ComponentID is a text/template for the component IDs with the fields .Name (after
+	// applying the name rules), .Version, .Ecosystem and .PURL.
+	ComponentID string `yaml:"component_id"`
+	// DocumentNamespace is a text/template for the SPDX document namespace with the fields
+	// .DocumentName and .UUID.
+	DocumentNamespace string `yaml:"document_namespace"`
+}
+
+type componentIDData struct {
+	Name      string
+	Version   string
+	Ecosystem string
+	PURL      string
+}
+
+type namespaceData struct {
+	DocumentName string
+	UUID         string
+}
+
+type compiledNameRule struct {
+	ecosystem   string
+	re          *regexp.Regexp
+	replacement string
+}
+
+// Policy compiles the rules into a naming policy.
+func (r *NamingRules) Policy() (*NamingPolicy, error) {
+	var names []compiledNameRule
+	for i, n := range r.Names {
+		if n.Regex == "" {
+			return nil, fmt.Errorf("name rule %d: regex is empty", i+1)
+		}
+		re, err := regexp.Compile(n.Regex)
+		if err != nil {
+			return nil, fmt.Errorf("name rule %d: %w", i+1, err)
+		}
+		names = append(names, compiledNameRule{ecosystem: n.Ecosystem, re: re, replacement: n.Replacement})
+	}
+	p := &NamingPolicy{}
+	if len(names) > 0 || r.Lowercase {
+		p.ComponentName = func(pkg *extractor.Package, name string) string {
+			ecosystem, _, _ := strings.Cut(pkg.Ecosystem(), ":")
+			for _, n := range names {
+				if n.ecosystem == "" || strings.EqualFold(n.ecosystem, ecosystem) {
+					name = n.re.ReplaceAllString(name, n.replacement)
+				}
+			}
+			if r.Lowercase {
+				name = strings.ToLower(name)
+			}
+			return name
+		}
+	}
+	if r.ComponentID != "" {
+		tmpl, err := template.New("component_id").Option("missingkey=error").Parse(r.ComponentID)
+		if err != nil {
+			return nil, fmt.Errorf("component_id: %w", err)
+		}
+		p.ComponentID = func(pkg *extractor.Package, name string) string {
+			data := componentIDData{Name: name, Version: pkg.Version, Ecosystem: pkg.Ecosystem()}
+			if purl := pkg.PURL(); purl != nil {
+				data.PURL = purl.String()
+			}
+			return execute(tmpl, data)
+		}
+	}
+	if r.DocumentNamespace != "" {
+		tmpl, err := template.New("document_namespace").Option("missingkey=error").Parse(r.DocumentNamespace)
+		if err != nil {
+			return nil, fmt.Errorf("document_namespace: %w", err)
+		}
+		p.DocumentNamespace = func(documentName string) string {
+			return execute(tmpl, namespaceData{DocumentName: documentName, UUID: uuid.New().String()})
+		}
+	}
+	return p, nil
+}
+
+// execute returns the template output, or "" if it can't be executed so that the default is
+// used instead.
+func execute(tmpl *template.Template, data any) string {
+	var b bytes.Buffer
+	if err := tmpl.Execute(&b, data); err != nil {
+		return ""
+	}
+	return b.String()
+}
+
+// LoadNamingRules reads an SBOM naming rules YAML file and returns the policy it describes,
+// e.g.
+//
+//	names:
+//	  - ecosystem: npm
+//	    regex: "^@acme/(.*)$"
+//	    replacement: "acme-$1"
+//	lowercase: true
+//	component_id: "{{.Ecosystem}}-{{.Name}}-{{.Version}}"
+//	document_namespace: "https://sbom.example.com/{{.DocumentName}}/{{.UUID}}"
+//
+// renames the npm packages of the @acme scope, lowercases all package names and uses stable
+// component IDs.
+func LoadNamingRules(path string) (*NamingPolicy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var rules NamingRules
+	if err := yaml.Unmarshal(data, &rules); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if rules.Names == nil && !rules.Lowercase && rules.ComponentID == "" && rules.DocumentNamespace == "" {
+		return nil, fmt.Errorf("%s: no naming rules found", path)
+	}
+	p, err := rules.Policy()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return p, nil
+}
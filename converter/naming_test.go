@@ -0,0 +1,142 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package converter_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/osv-scalibr/converter"
+	"github.com/google/osv-scalibr/converter/spdx30"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scalibr/result"
+)
+
+const namingRules = `
+names:
+  - ecosystem: npm
+    regex: "^@acme/(.*)$"
+    replacement: "acme-$1"
+  - ecosystem: PyPI
+    regex: "_"
+    replacement: "-"
+lowercase: true
+component_id: "{{.Ecosystem}}/{{.Name}}@{{.Version}}"
+document_namespace: "https://sbom.example.com/{{.DocumentName}}"
+`
+
+func loadNamingRules(t *testing.T, rules string) (*converter.NamingPolicy, error) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "naming.yaml")
+	if err := os.WriteFile(path, []byte(rules), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return converter.LoadNamingRules(path)
+}
+
+func namingScanResult() *result.ScanResult {
+	return &result.ScanResult{
+		Inventory: inventory.Inventory{
+			Packages: []*extractor.Package{
+				{Name: "@acme/Widgets", Version: "1.0.0", PURLType: purl.TypeNPM},
+				{Name: "Typing_Extensions", Version: "4.0.0", PURLType: purl.TypePyPi},
+				// Same name and version in a second location.
+				{Name: "typing_extensions", Version: "4.0.0", PURLType: purl.TypePyPi},
+			},
+		},
+	}
+}
+
+func TestNamingRules_CDX(t *testing.T) {
+	naming, err := loadNamingRules(t, namingRules)
+	if err != nil {
+		t.Fatalf("LoadNamingRules(): %v", err)
+	}
+	bom := converter.ToCDX(namingScanResult(), converter.CDXConfig{Naming: naming})
+
+	type nameAndRef struct{ Name, BOMRef string }
+	var got []nameAndRef
+	for _, c := range *bom.Components {
+		got = append(got, nameAndRef{c.Name, c.BOMRef})
+	}
+	want := []nameAndRef{
+		{"acme-widgets", "npm/acme-widgets@1.0.0"},
+		{"typing-extensions", "PyPI/typing-extensions@4.0.0"},
+		{"typing-extensions", "PyPI/typing-extensions@4.0.0-2"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ToCDX() returned unexpected components (-want +got):\n%s", diff)
+	}
+}
+
+func TestNamingRules_SPDX23(t *testing.T) {
+	naming, err := loadNamingRules(t, namingRules)
+	if err != nil {
+		t.Fatalf("LoadNamingRules(): %v", err)
+	}
+	doc := converter.ToSPDX23(namingScanResult(), converter.SPDXConfig{DocumentName: "app", Naming: naming})
+
+	if got, want := doc.DocumentNamespace, "https://sbom.example.com/app"; got != want {
+		t.Errorf("ToSPDX23() DocumentNamespace = %q, want %q", got, want)
+	}
+	var got []string
+	for _, p := range doc.Packages[1:] {
+		got = append(got, p.PackageName+" "+string(p.PackageSPDXIdentifier))
+	}
+	want := []string{
+		"acme-widgets SPDXRef-Package-npm-acme-widgets-1.0.0",
+		"typing-extensions SPDXRef-Package-PyPI-typing-extensions-4.0.0",
+		"typing-extensions SPDXRef-Package-PyPI-typing-extensions-4.0.0-2",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ToSPDX23() returned unexpected packages (-want +got):\n%s", diff)
+	}
+}
+
+func TestNamingPolicy_SPDX30(t *testing.T) {
+	naming := &converter.NamingPolicy{
+		ComponentName: func(_ *extractor.Package, name string) string { return strings.ToUpper(name) },
+	}
+	doc := converter.ToSPDX30(namingScanResult(), converter.SPDXConfig{DocumentNamespace: "https://ns", Naming: naming})
+	var got []string
+	for _, e := range doc.Graph {
+		if p, ok := e.(*spdx30.Package); ok && p.PackageURL != "" {
+			got = append(got, p.Name)
+		}
+	}
+	want := []string{"@ACME/WIDGETS", "TYPING-EXTENSIONS", "TYPING-EXTENSIONS"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ToSPDX30() returned unexpected package names (-want +got):\n%s", diff)
+	}
+}
+
+func TestLoadNamingRules_Invalid(t *testing.T) {
+	for _, rules := range []string{
+		"",
+		"names: [{regex: '('}]",
+		"names: [{replacement: x}]",
+		"component_id: '{{.Name'",
+		"lowercase: [",
+	} {
+		if _, err := loadNamingRules(t, rules); err == nil {
+			t.Errorf("LoadNamingRules(%q) succeeded, want error", rules)
+		}
+	}
+}
@@ -0,0 +1,249 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package converter
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/osv-scalibr/converter/sarif"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/inventory/fingerprint"
+	"github.com/google/osv-scalibr/inventory/vex"
+	"github.com/google/osv-scalibr/result"
+)
+
+const (
+	sarifToolName       = "osv-scalibr"
+	sarifInformationURI = "https://github.com/google/osv-scalibr"
+	// Key of the fingerprint that identifies the same finding across scans.
+	sarifFingerprintKey = "scalibrFingerprint/v2"
+)
+
+// ToSARIF converts the findings of a SCALIBR scan into a SARIF 2.1.0 log that
+// can be uploaded to GitHub Code Scanning and other SARIF-aware dashboards.
+// Package vulns, generic findings and secrets each become results of a rule
+// that describes the advisory or the secret type.
+func ToSARIF(r *result.ScanResult) *sarif.Log {
+	// SARIF requires the rules and results arrays even if nothing was found.
+	b := &sarifBuilder{
+		rules:     []*sarif.Rule{},
+		ruleIndex: map[string]int{},
+		results:   []*sarif.Result{},
+	}
+	for _, v := range r.Inventory.PackageVulns {
+		b.addPackageVuln(v)
+	}
+	for _, f := range r.Inventory.GenericFindings {
+		b.addGenericFinding(f)
+	}
+	for _, s := range r.Inventory.Secrets {
+		b.addSecret(s)
+	}
+
+	return &sarif.Log{
+		Schema:  sarif.Schema,
+		Version: sarif.Version,
+		Runs: []*sarif.Run{{
+			Tool: &sarif.Tool{Driver: &sarif.Driver{
+				Name:           sarifToolName,
+				Version:        r.Version,
+				InformationURI: sarifInformationURI,
+				Rules:          b.rules,
+			}},
+			Results: b.results,
+		}},
+	}
+}
+
+type sarifBuilder struct {
+	rules     []*sarif.Rule
+	ruleIndex map[string]int
+	results   []*sarif.Result
+}
+
+// addRule adds the rule unless a rule with the same ID exists and returns its index.
+func (b *sarifBuilder) addRule(rule *sarif.Rule) int {
+	if i, ok := b.ruleIndex[rule.ID]; ok {
+		return i
+	}
+	b.rules = append(b.rules, rule)
+	b.ruleIndex[rule.ID] = len(b.rules) - 1
+	return len(b.rules) - 1
+}
+
+func (b *sarifBuilder) addResult(rule *sarif.Rule, msg string, paths []string, fp string) *sarif.Result {
+	res := &sarif.Result{
+		RuleID:    rule.ID,
+		RuleIndex: b.addRule(rule),
+		Level:     rule.DefaultConfiguration.Level,
+		Message:   &sarif.Message{Text: msg},
+	}
+	for _, p := range paths {
+		res.Locations = append(res.Locations, sarifLocation(p))
+	}
+	res.PartialFingerprints = map[string]string{sarifFingerprintKey: fp}
+	b.results = append(b.results, res)
+	return res
+}
+
+func (b *sarifBuilder) addPackageVuln(v *inventory.PackageVuln) {
+	rule := &sarif.Rule{
+		ID:                   v.ID,
+		HelpURI:              "https://osv.dev/vulnerability/" + v.ID,
+		DefaultConfiguration: &sarif.ReportingConfiguration{Level: sarif.LevelWarning},
+		Properties:           &sarif.RuleProperties{Tags: []string{"security", "vulnerability"}},
+	}
+	if v.Summary != "" {
+		rule.ShortDescription = &sarif.Message{Text: v.Summary}
+	}
+	if v.Details != "" {
+		rule.FullDescription = &sarif.Message{Text: v.Details}
+	}
+
+	var msg string
+	var paths []string
+	if p := v.Package; p != nil {
+		msg = fmt.Sprintf("%s %s is affected by %s", p.Name, p.Version, v.ID)
+		paths = p.Locations
+	} else {
+		msg = "Affected by " + v.ID
+	}
+	if v.Summary != "" {
+		msg += ": " + v.Summary
+	}
+	res := b.addResult(rule, msg, paths, fingerprint.PackageVuln(v))
+	res.Suppressions = sarifSuppressions(v.ExploitabilitySignals)
+}
+
+func (b *sarifBuilder) addGenericFinding(f *inventory.GenericFinding) {
+	if f.Adv == nil || f.Adv.ID == nil {
+		return
+	}
+	id := f.Adv.ID.Reference
+	if f.Adv.ID.Publisher != "" {
+		id = f.Adv.ID.Publisher + "/" + id
+	}
+	level, score := sarifSeverity(f.Adv.Sev)
+	rule := &sarif.Rule{
+		ID:                   id,
+		Name:                 f.Adv.Title,
+		DefaultConfiguration: &sarif.ReportingConfiguration{Level: level},
+		Properties:           &sarif.RuleProperties{Tags: []string{"security"}, SecuritySeverity: score},
+	}
+	if f.Adv.Title != "" {
+		rule.ShortDescription = &sarif.Message{Text: f.Adv.Title}
+	}
+	if f.Adv.Description != "" {
+		rule.FullDescription = &sarif.Message{Text: f.Adv.Description}
+	}
+	if f.Adv.Recommendation != "" {
+		rule.Help = &sarif.Message{Text: f.Adv.Recommendation}
+	}
+
+	msg := f.Adv.Title
+	var paths []string
+	if f.Target != nil && f.Target.Extra != "" {
+		extra := strings.TrimSpace(f.Target.Extra)
+		msg += ": " + extra
+		if p := findingPath(extra); p != "" {
+			paths = []string{p}
+		}
+	}
+	res := b.addResult(rule, msg, paths, fingerprint.GenericFinding(f))
+	res.Suppressions = sarifSuppressions(f.ExploitabilitySignals)
+}
+
+func (b *sarifBuilder) addSecret(s *inventory.Secret) {
+	secretType := strings.TrimPrefix(fmt.Sprintf("%T", s.Secret), "*")
+	level, score := sarifSeverity(s.Severity)
+	if s.Severity == inventory.SeverityUnspecified {
+		level = sarif.LevelError
+	}
+	rule := &sarif.Rule{
+		ID:                   "secret/" + secretType,
+		ShortDescription:     &sarif.Message{Text: "Exposed secret: " + secretType},
+		DefaultConfiguration: &sarif.ReportingConfiguration{Level: level},
+		Properties:           &sarif.RuleProperties{Tags: []string{"security", "secret"}, SecuritySeverity: score},
+	}
+	var paths []string
+	if s.Location != "" {
+		paths = []string{s.Location}
+	}
+	// The secret value itself is deliberately left out of the message.
+	b.addResult(rule, fmt.Sprintf("%s found in %s", secretType, s.Location), paths, fingerprint.Secret(s))
+}
+
+// sarifSuppressions returns the exploitability signals of a finding as
+// external suppressions, e.g. "accepted-risk: waiting for upstream fix".
+func sarifSuppressions(signals []*vex.FindingExploitabilitySignal) []*sarif.Suppression {
+	var result []*sarif.Suppression
+	for _, s := range signals {
+		status := s.Status
+		if status == vex.StatusUnspecified {
+			status = vex.StatusNotAffected
+		}
+		justification := status.String()
+		if s.Reason != "" {
+			justification += ": " + s.Reason
+		}
+		result = append(result, &sarif.Suppression{
+			Kind:          sarif.SuppressionKindExternal,
+			Status:        sarif.SuppressionStatusAccepted,
+			Justification: justification,
+		})
+	}
+	return result
+}
+
+// findingPath returns the file path at the start of a generic finding's details,
+// following the "path: details" convention the detectors use. Returns an empty
+// string if the details don't start with a path.
+func findingPath(extra string) string {
+	line, _, _ := strings.Cut(extra, "\n")
+	p, _, found := strings.Cut(line, ": ")
+	if !found || !strings.Contains(p, "/") || strings.ContainsAny(p, " \t") {
+		return ""
+	}
+	return p
+}
+
+func sarifLocation(path string) *sarif.Location {
+	return &sarif.Location{PhysicalLocation: &sarif.PhysicalLocation{
+		ArtifactLocation: &sarif.ArtifactLocation{
+			URI:       strings.TrimPrefix(path, "/"),
+			URIBaseID: sarif.SrcRoot,
+		},
+	}}
+}
+
+// sarifSeverity returns the SARIF level and the security-severity score used by
+// GitHub Code Scanning for the given severity.
+func sarifSeverity(sev inventory.SeverityEnum) (level string, score string) {
+	switch sev {
+	case inventory.SeverityCritical:
+		return sarif.LevelError, "9.5"
+	case inventory.SeverityHigh:
+		return sarif.LevelError, "8.0"
+	case inventory.SeverityMedium:
+		return sarif.LevelWarning, "5.5"
+	case inventory.SeverityLow:
+		return sarif.LevelNote, "3.0"
+	case inventory.SeverityMinimal:
+		return sarif.LevelNote, "1.0"
+	default:
+		return sarif.LevelWarning, ""
+	}
+}
@@ -0,0 +1,128 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package sarif contains the subset of the SARIF 2.1.0 object model that SCALIBR emits.
+// See https://docs.oasis-open.org/sarif/sarif/v2.1.0/sarif-v2.1.0.html
+package sarif
+
+const (
+	// Schema is the JSON schema of SARIF 2.1.0 logs.
+	Schema = "https://json.schemastore.org/sarif-2.1.0.json"
+	// Version is the SARIF version the logs conform to.
+	Version = "2.1.0"
+	// SrcRoot is the URI base ID that result locations are relative to.
+	SrcRoot = "%SRCROOT%"
+)
+
+// Result levels.
+const (
+	LevelError   = "error"
+	LevelWarning = "warning"
+	LevelNote    = "note"
+)
+
+// Log is the top-level SARIF document.
+type Log struct {
+	Schema  string `json:"$schema"`
+	Version string `json:"version"`
+	Runs    []*Run `json:"runs"`
+}
+
+// Run describes a single invocation of a tool and its results.
+type Run struct {
+	Tool    *Tool     `json:"tool"`
+	Results []*Result `json:"results"`
+}
+
+// Tool describes the tool that produced the results.
+type Tool struct {
+	Driver *Driver `json:"driver"`
+}
+
+// Driver is the tool component that ran the analysis.
+type Driver struct {
+	Name           string  `json:"name"`
+	Version        string  `json:"version,omitempty"`
+	InformationURI string  `json:"informationUri,omitempty"`
+	Rules          []*Rule `json:"rules"`
+}
+
+// Rule describes a type of finding, e.g. a vulnerability or misconfiguration.
+type Rule struct {
+	ID                   string                  `json:"id"`
+	Name                 string                  `json:"name,omitempty"`
+	ShortDescription     *Message                `json:"shortDescription,omitempty"`
+	FullDescription      *Message                `json:"fullDescription,omitempty"`
+	Help                 *Message                `json:"help,omitempty"`
+	HelpURI              string                  `json:"helpUri,omitempty"`
+	DefaultConfiguration *ReportingConfiguration `json:"defaultConfiguration,omitempty"`
+	Properties           *RuleProperties         `json:"properties,omitempty"`
+}
+
+// ReportingConfiguration contains the default settings of a rule.
+type ReportingConfiguration struct {
+	Level string `json:"level"`
+}
+
+// RuleProperties are additional properties of a rule. security-severity is the
+// CVSS-like score GitHub Code Scanning uses to rank security findings.
+type RuleProperties struct {
+	Tags             []string `json:"tags,omitempty"`
+	SecuritySeverity string   `json:"security-severity,omitempty"`
+}
+
+// Message is a plain text message.
+type Message struct {
+	Text string `json:"text"`
+}
+
+// Result is a single finding.
+type Result struct {
+	RuleID              string            `json:"ruleId"`
+	RuleIndex           int               `json:"ruleIndex"`
+	Level               string            `json:"level"`
+	Message             *Message          `json:"message"`
+	Locations           []*Location       `json:"locations,omitempty"`
+	PartialFingerprints map[string]string `json:"partialFingerprints,omitempty"`
+	Suppressions        []*Suppression    `json:"suppressions,omitempty"`
+}
+
+// Suppression kinds and statuses.
+const (
+	SuppressionKindExternal   = "external"
+	SuppressionStatusAccepted = "accepted"
+)
+
+// Suppression records that a result was triaged, e.g. by a VEX statement.
+type Suppression struct {
+	Kind          string `json:"kind"`
+	Status        string `json:"status,omitempty"`
+	Justification string `json:"justification,omitempty"`
+}
+
+// Location is where a result was found.
+type Location struct {
+	PhysicalLocation *PhysicalLocation `json:"physicalLocation"`
+}
+
+// PhysicalLocation is a location in a file.
+type PhysicalLocation struct {
+	ArtifactLocation *ArtifactLocation `json:"artifactLocation"`
+}
+
+// ArtifactLocation is the URI of a file, relative to URIBaseID.
+type ArtifactLocation struct {
+	URI       string `json:"uri"`
+	URIBaseID string `json:"uriBaseId,omitempty"`
+}
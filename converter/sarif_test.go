@@ -0,0 +1,188 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package converter_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	scalibr "github.com/google/osv-scalibr"
+	"github.com/google/osv-scalibr/converter"
+	"github.com/google/osv-scalibr/converter/sarif"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/inventory/vex"
+	"github.com/google/osv-scalibr/veles/secrets/gcpapikey"
+	"github.com/ossf/osv-schema/bindings/go/osvschema"
+)
+
+func sarifLocations(paths ...string) []*sarif.Location {
+	var locs []*sarif.Location
+	for _, p := range paths {
+		locs = append(locs, &sarif.Location{PhysicalLocation: &sarif.PhysicalLocation{
+			ArtifactLocation: &sarif.ArtifactLocation{URI: p, URIBaseID: sarif.SrcRoot},
+		}})
+	}
+	return locs
+}
+
+func TestToSARIF(t *testing.T) {
+	pkg := &extractor.Package{Name: "lodash", Version: "4.17.20", Locations: []string{"app/package-lock.json"}}
+	jmxAdv := &inventory.GenericFindingAdvisory{
+		ID:             &inventory.AdvisoryID{Publisher: "SCALIBR", Reference: "jvm-unauthenticated-remote-jmx"},
+		Title:          "Unauthenticated remote JMX",
+		Description:    "JMX is reachable without authentication.",
+		Recommendation: "Enable JMX authentication.",
+		Sev:            inventory.SeverityCritical,
+	}
+	result := &scalibr.ScanResult{
+		Version: "1.2.3",
+		Inventory: inventory.Inventory{
+			PackageVulns: []*inventory.PackageVuln{
+				{
+					Vulnerability: osvschema.Vulnerability{ID: "GHSA-35jh-r3h4-6jhm", Summary: "Command injection in lodash"},
+					Package:       pkg,
+				},
+			},
+			GenericFindings: []*inventory.GenericFinding{
+				{
+					Adv:    jmxAdv,
+					Target: &inventory.GenericFindingTargetDetails{Extra: "/etc/systemd/system/app.service: -Dcom.sun.management.jmxremote.authenticate=false"},
+				},
+				{
+					Adv:    jmxAdv,
+					Target: &inventory.GenericFindingTargetDetails{Extra: "distro: fedora"},
+					ExploitabilitySignals: []*vex.FindingExploitabilitySignal{
+						{Plugin: "vex/suppression", Status: vex.StatusAcceptedRisk, Reason: "internal network only"},
+						{Plugin: "vex/cachedir", Justification: vex.ComponentNotPresent},
+					},
+				},
+			},
+			Secrets: []*inventory.Secret{
+				{Secret: gcpapikey.GCPAPIKey{Key: "AIzaSyDummyKey"}, Location: "config/settings.json"},
+			},
+		},
+	}
+
+	want := &sarif.Log{
+		Schema:  sarif.Schema,
+		Version: sarif.Version,
+		Runs: []*sarif.Run{{
+			Tool: &sarif.Tool{Driver: &sarif.Driver{
+				Name:           "osv-scalibr",
+				Version:        "1.2.3",
+				InformationURI: "https://github.com/google/osv-scalibr",
+				Rules: []*sarif.Rule{
+					{
+						ID:                   "GHSA-35jh-r3h4-6jhm",
+						ShortDescription:     &sarif.Message{Text: "Command injection in lodash"},
+						HelpURI:              "https://osv.dev/vulnerability/GHSA-35jh-r3h4-6jhm",
+						DefaultConfiguration: &sarif.ReportingConfiguration{Level: sarif.LevelWarning},
+						Properties:           &sarif.RuleProperties{Tags: []string{"security", "vulnerability"}},
+					},
+					{
+						ID:                   "SCALIBR/jvm-unauthenticated-remote-jmx",
+						Name:                 "Unauthenticated remote JMX",
+						ShortDescription:     &sarif.Message{Text: "Unauthenticated remote JMX"},
+						FullDescription:      &sarif.Message{Text: "JMX is reachable without authentication."},
+						Help:                 &sarif.Message{Text: "Enable JMX authentication."},
+						DefaultConfiguration: &sarif.ReportingConfiguration{Level: sarif.LevelError},
+						Properties:           &sarif.RuleProperties{Tags: []string{"security"}, SecuritySeverity: "9.5"},
+					},
+					{
+						ID:                   "secret/gcpapikey.GCPAPIKey",
+						ShortDescription:     &sarif.Message{Text: "Exposed secret: gcpapikey.GCPAPIKey"},
+						DefaultConfiguration: &sarif.ReportingConfiguration{Level: sarif.LevelError},
+						Properties:           &sarif.RuleProperties{Tags: []string{"security", "secret"}},
+					},
+				},
+			}},
+			Results: []*sarif.Result{
+				{
+					RuleID:    "GHSA-35jh-r3h4-6jhm",
+					RuleIndex: 0,
+					Level:     sarif.LevelWarning,
+					Message:   &sarif.Message{Text: "lodash 4.17.20 is affected by GHSA-35jh-r3h4-6jhm: Command injection in lodash"},
+					Locations: sarifLocations("app/package-lock.json"),
+				},
+				{
+					RuleID:    "SCALIBR/jvm-unauthenticated-remote-jmx",
+					RuleIndex: 1,
+					Level:     sarif.LevelError,
+					Message:   &sarif.Message{Text: "Unauthenticated remote JMX: /etc/systemd/system/app.service: -Dcom.sun.management.jmxremote.authenticate=false"},
+					Locations: sarifLocations("etc/systemd/system/app.service"),
+				},
+				{
+					RuleID:    "SCALIBR/jvm-unauthenticated-remote-jmx",
+					RuleIndex: 1,
+					Level:     sarif.LevelError,
+					Message:   &sarif.Message{Text: "Unauthenticated remote JMX: distro: fedora"},
+					Suppressions: []*sarif.Suppression{
+						{Kind: "external", Status: "accepted", Justification: "accepted-risk: internal network only"},
+						{Kind: "external", Status: "accepted", Justification: "not-affected"},
+					},
+				},
+				{
+					RuleID:    "secret/gcpapikey.GCPAPIKey",
+					RuleIndex: 2,
+					Level:     sarif.LevelError,
+					Message:   &sarif.Message{Text: "gcpapikey.GCPAPIKey found in config/settings.json"},
+					Locations: sarifLocations("config/settings.json"),
+				},
+			},
+		}},
+	}
+
+	got := converter.ToSARIF(result)
+	if diff := cmp.Diff(want, got, cmpopts.IgnoreFields(sarif.Result{}, "PartialFingerprints")); diff != "" {
+		t.Errorf("converter.ToSARIF(%v) returned unexpected result, diff (-want +got):\n%s", result, diff)
+	}
+
+	// Every result has a distinct fingerprint that doesn't change between runs.
+	seen := map[string]bool{}
+	again := converter.ToSARIF(result)
+	for i, r := range got.Runs[0].Results {
+		fp := r.PartialFingerprints["scalibrFingerprint/v2"]
+		if fp == "" || seen[fp] {
+			t.Errorf("converter.ToSARIF(%v): result %d has empty or duplicate fingerprint %q", result, i, fp)
+		}
+		seen[fp] = true
+		if againFP := again.Runs[0].Results[i].PartialFingerprints["scalibrFingerprint/v2"]; againFP != fp {
+			t.Errorf("converter.ToSARIF(%v): result %d fingerprint changed between runs: %q, %q", result, i, fp, againFP)
+		}
+	}
+}
+
+func TestToSARIF_Empty(t *testing.T) {
+	result := &scalibr.ScanResult{Version: "1.2.3"}
+	want := &sarif.Log{
+		Schema:  sarif.Schema,
+		Version: sarif.Version,
+		Runs: []*sarif.Run{{
+			Tool: &sarif.Tool{Driver: &sarif.Driver{
+				Name:           "osv-scalibr",
+				Version:        "1.2.3",
+				InformationURI: "https://github.com/google/osv-scalibr",
+				Rules:          []*sarif.Rule{},
+			}},
+			Results: []*sarif.Result{},
+		}},
+	}
+	got := converter.ToSARIF(result)
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("converter.ToSARIF(%v) returned unexpected result, diff (-want +got):\n%s", result, diff)
+	}
+}
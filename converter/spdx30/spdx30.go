@@ -0,0 +1,124 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package spdx30 contains the subset of the SPDX 3.0 element model that SCALIBR emits.
+// Documents are serialized as JSON-LD following https://spdx.github.io/spdx-spec/v3.0.1/serializations/.
+package spdx30
+
+const (
+	// Context is the JSON-LD context of SPDX 3.0.1 documents.
+	Context = "https://spdx.org/rdf/3.0.1/spdx-context.jsonld"
+	// SpecVersion is the SPDX specification version the documents conform to.
+	SpecVersion = "3.0.1"
+	// CreationInfoID is the blank node ID of the CreationInfo shared by all elements.
+	CreationInfoID = "_:creationinfo"
+)
+
+// Element types.
+const (
+	TypeCreationInfo  = "CreationInfo"
+	TypeSpdxDocument  = "SpdxDocument"
+	TypeSbom          = "software_Sbom"
+	TypePackage       = "software_Package"
+	TypeRelationship  = "Relationship"
+	TypeTool          = "Tool"
+	TypePerson        = "Person"
+	TypeOrganization  = "Organization"
+	TypeSoftwareAgent = "SoftwareAgent"
+)
+
+// RelationshipContains is the relationship type between an element and the elements it contains.
+const RelationshipContains = "contains"
+
+// Document is an SPDX 3.0 JSON-LD document. Graph holds the CreationInfo and
+// all elements of the document.
+type Document struct {
+	Context string `json:"@context"`
+	Graph   []any  `json:"@graph"`
+}
+
+// CreationInfo describes when and by whom the elements were created.
+type CreationInfo struct {
+	Type         string   `json:"type"`
+	ID           string   `json:"@id"`
+	SpecVersion  string   `json:"specVersion"`
+	Created      string   `json:"created"`
+	CreatedBy    []string `json:"createdBy"`
+	CreatedUsing []string `json:"createdUsing,omitempty"`
+}
+
+// Element contains the properties shared by all SPDX 3.0 elements.
+type Element struct {
+	Type                string                `json:"type"`
+	SpdxID              string                `json:"spdxId"`
+	CreationInfo        string                `json:"creationInfo"`
+	Name                string                `json:"name,omitempty"`
+	Comment             string                `json:"comment,omitempty"`
+	ExternalIdentifiers []*ExternalIdentifier `json:"externalIdentifier,omitempty"`
+	VerifiedUsing       []*Hash               `json:"verifiedUsing,omitempty"`
+}
+
+// ExternalIdentifier is an identifier of an element in an external system, e.g. a CPE.
+type ExternalIdentifier struct {
+	Type                   string `json:"type"`
+	ExternalIdentifierType string `json:"externalIdentifierType"`
+	Identifier             string `json:"identifier"`
+	// Scheme of "other" identifiers, e.g. the tool that assigned them.
+	IssuingAuthority string `json:"issuingAuthority,omitempty"`
+}
+
+// Hash is a digest of an element's content.
+type Hash struct {
+	Type      string `json:"type"`
+	Algorithm string `json:"algorithm"`
+	HashValue string `json:"hashValue"`
+}
+
+// Agent is a Person, Organization, SoftwareAgent or Tool that created the document.
+type Agent struct {
+	Element
+}
+
+// SpdxDocument is the root element of the document.
+type SpdxDocument struct {
+	Element
+	ProfileConformance []string `json:"profileConformance"`
+	RootElement        []string `json:"rootElement"`
+	Elements           []string `json:"element"`
+}
+
+// Sbom is a collection of software elements.
+type Sbom struct {
+	Element
+	SbomTypes   []string `json:"software_sbomType,omitempty"`
+	RootElement []string `json:"rootElement"`
+	Elements    []string `json:"element"`
+}
+
+// Package is a software package.
+type Package struct {
+	Element
+	PackageVersion string `json:"software_packageVersion,omitempty"`
+	PackageURL     string `json:"software_packageUrl,omitempty"`
+	PrimaryPurpose string `json:"software_primaryPurpose,omitempty"`
+	SourceInfo     string `json:"software_sourceInfo,omitempty"`
+}
+
+// Relationship describes a relationship between elements.
+type Relationship struct {
+	Element
+	From             string   `json:"from"`
+	To               []string `json:"to"`
+	RelationshipType string   `json:"relationshipType"`
+}
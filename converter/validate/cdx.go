@@ -0,0 +1,167 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validate
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+
+	"github.com/CycloneDX/cyclonedx-go"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/uuid"
+)
+
+var cdxSerialNumberRe = regexp.MustCompile(`^urn:uuid:[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// ValidateCDX checks a CycloneDX BOM for missing required fields, duplicate
+// bom-refs, invalid licenses and dependencies on unknown components.
+func ValidateCDX(bom *cyclonedx.BOM) []*Issue {
+	return checkCDX(bom, false)
+}
+
+// RepairCDX fixes the problems ValidateCDX finds in bom where possible and
+// returns all issues found. Issues that couldn't be fixed, e.g. a missing
+// component name, aren't marked as repaired.
+func RepairCDX(bom *cyclonedx.BOM) []*Issue {
+	return checkCDX(bom, true)
+}
+
+func checkCDX(bom *cyclonedx.BOM, repair bool) []*Issue {
+	c := &checker{repair: repair}
+	if bom.BOMFormat != cyclonedx.BOMFormat && c.fixable("", "bomFormat is %q, want %q", bom.BOMFormat, cyclonedx.BOMFormat) {
+		bom.BOMFormat = cyclonedx.BOMFormat
+	}
+	if bom.SpecVersion == 0 && c.fixable("", "missing specVersion") {
+		bom.SpecVersion = cyclonedx.SpecVersion1_6
+	}
+	if bom.SerialNumber != "" && !cdxSerialNumberRe.MatchString(bom.SerialNumber) {
+		if c.fixable("", "serialNumber %q isn't a UUID URN", bom.SerialNumber) {
+			bom.SerialNumber = uuid.New().URN()
+		}
+	}
+
+	refs := map[string]bool{}
+	if bom.Metadata != nil && bom.Metadata.Component != nil {
+		c.checkCDXComponent(refs, bom.Metadata.Component, "metadata.component")
+	}
+	if bom.Components != nil {
+		c.checkCDXComponents(refs, *bom.Components, "components")
+	}
+	if bom.Dependencies != nil {
+		c.checkCDXDependencies(bom, refs)
+	}
+	return c.issues
+}
+
+func (c *checker) checkCDXComponents(refs map[string]bool, components []cyclonedx.Component, prefix string) {
+	for i := range components {
+		c.checkCDXComponent(refs, &components[i], fmt.Sprintf("%s[%d]", prefix, i))
+	}
+}
+
+func (c *checker) checkCDXComponent(refs map[string]bool, comp *cyclonedx.Component, element string) {
+	if comp.BOMRef != "" {
+		element = comp.BOMRef
+	}
+	if comp.Name == "" {
+		c.errorf(element, "missing component name")
+	}
+	if comp.Type == "" && c.fixable(element, "missing component type") {
+		comp.Type = cyclonedx.ComponentTypeLibrary
+	}
+	if comp.BOMRef != "" {
+		if refs[comp.BOMRef] && c.fixable(element, "duplicate bom-ref") {
+			base := comp.BOMRef
+			for n := 2; refs[comp.BOMRef]; n++ {
+				comp.BOMRef = fmt.Sprintf("%s-%d", base, n)
+			}
+			element = comp.BOMRef
+		}
+		refs[comp.BOMRef] = true
+	}
+	if comp.PackageURL != "" {
+		if _, err := purl.FromString(comp.PackageURL); err != nil {
+			c.warnf(element, "invalid package URL %q: %v", comp.PackageURL, err)
+		}
+	}
+	if comp.Licenses != nil {
+		c.checkCDXLicenses(comp, element)
+	}
+	if comp.Components != nil {
+		c.checkCDXComponents(refs, *comp.Components, element+".components")
+	}
+}
+
+func (c *checker) checkCDXLicenses(comp *cyclonedx.Component, element string) {
+	var kept cyclonedx.Licenses
+	for _, l := range *comp.Licenses {
+		switch {
+		case l.Expression != "":
+			if !ValidLicenseExpression(l.Expression) {
+				if !c.fixable(element, "license expression %q isn't a valid SPDX license expression", l.Expression) {
+					break
+				}
+				// CycloneDX has no NOASSERTION, so drop expressions that can't be fixed.
+				if l.Expression = repairLicenseExpression(l.Expression); l.Expression == noAssertion {
+					continue
+				}
+			}
+		case l.License == nil || (l.License.ID == "" && l.License.Name == ""):
+			if c.fixable(element, "license has neither an id nor a name") {
+				continue
+			}
+		case l.License.ID != "" && !isLicenseID(l.License.ID):
+			if c.fixable(element, "license id %q isn't an SPDX license ID", l.License.ID) {
+				// Keep the information as a free-text license name.
+				l.License.Name, l.License.ID = l.License.ID, ""
+			}
+		}
+		kept = append(kept, l)
+	}
+	if !c.repair {
+		return
+	}
+	if len(kept) == 0 {
+		comp.Licenses = nil
+	} else {
+		comp.Licenses = &kept
+	}
+}
+
+func (c *checker) checkCDXDependencies(bom *cyclonedx.BOM, refs map[string]bool) {
+	var kept []cyclonedx.Dependency
+	for i, d := range *bom.Dependencies {
+		element := fmt.Sprintf("dependencies[%d]", i)
+		if !refs[d.Ref] {
+			if c.fixable(element, "refers to unknown bom-ref %q", d.Ref) {
+				continue
+			}
+		}
+		if d.Dependencies != nil {
+			dependsOn := slices.Clone(*d.Dependencies)
+			dependsOn = slices.DeleteFunc(dependsOn, func(ref string) bool {
+				return !refs[ref] && c.fixable(element, "dependsOn refers to unknown bom-ref %q", ref)
+			})
+			if c.repair {
+				d.Dependencies = &dependsOn
+			}
+		}
+		kept = append(kept, d)
+	}
+	if c.repair {
+		bom.Dependencies = &kept
+	}
+}
@@ -0,0 +1,123 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validate_test
+
+import (
+	"testing"
+
+	"github.com/CycloneDX/cyclonedx-go"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/osv-scalibr/converter"
+	"github.com/google/osv-scalibr/converter/validate"
+)
+
+func brokenCDX() *cyclonedx.BOM {
+	return &cyclonedx.BOM{
+		BOMFormat:    "CycloneDX",
+		SpecVersion:  cyclonedx.SpecVersion1_6,
+		SerialNumber: "1234",
+		Metadata: &cyclonedx.Metadata{
+			Component: &cyclonedx.Component{BOMRef: "app", Type: cyclonedx.ComponentTypeApplication, Name: "app"},
+		},
+		Components: &[]cyclonedx.Component{
+			{
+				BOMRef: "lib",
+				Name:   "lib",
+				Licenses: &cyclonedx.Licenses{
+					{Expression: "MIT or ISC"},
+					{License: &cyclonedx.License{ID: "Apache License"}},
+					{License: &cyclonedx.License{}},
+				},
+			},
+			{
+				BOMRef:     "lib",
+				Type:       cyclonedx.ComponentTypeLibrary,
+				Name:       "left-pad",
+				PackageURL: "pkg:npm/left-pad@1.3.0",
+				Components: &[]cyclonedx.Component{
+					{Type: cyclonedx.ComponentTypeFile},
+				},
+			},
+		},
+		Dependencies: &[]cyclonedx.Dependency{
+			{Ref: "app", Dependencies: &[]string{"lib", "missing"}},
+			{Ref: "unknown"},
+		},
+	}
+}
+
+func TestValidateCDX_GeneratedDocument(t *testing.T) {
+	bom := converter.ToCDX(scanResult(), converter.CDXConfig{ComponentName: "app", ComponentType: "application"})
+	if issues := validate.ValidateCDX(bom); len(issues) != 0 {
+		t.Errorf("ValidateCDX(converter.ToCDX()) returned issues: %v", issues)
+	}
+}
+
+func TestValidateCDX(t *testing.T) {
+	bom := brokenCDX()
+	got := validate.ValidateCDX(bom)
+	want := []*validate.Issue{
+		{Severity: validate.SeverityError, Message: `serialNumber "1234" isn't a UUID URN`},
+		{Severity: validate.SeverityError, Element: "lib", Message: "missing component type"},
+		{Severity: validate.SeverityError, Element: "lib", Message: `license expression "MIT or ISC" isn't a valid SPDX license expression`},
+		{Severity: validate.SeverityError, Element: "lib", Message: `license id "Apache License" isn't an SPDX license ID`},
+		{Severity: validate.SeverityError, Element: "lib", Message: "license has neither an id nor a name"},
+		{Severity: validate.SeverityError, Element: "lib", Message: "duplicate bom-ref"},
+		{Severity: validate.SeverityError, Element: "lib.components[0]", Message: "missing component name"},
+		{Severity: validate.SeverityError, Element: "dependencies[0]", Message: `dependsOn refers to unknown bom-ref "missing"`},
+		{Severity: validate.SeverityError, Element: "dependencies[1]", Message: `refers to unknown bom-ref "unknown"`},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ValidateCDX() returned unexpected issues (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(brokenCDX(), bom); diff != "" {
+		t.Errorf("ValidateCDX() modified the BOM (-want +got):\n%s", diff)
+	}
+}
+
+func TestRepairCDX(t *testing.T) {
+	bom := brokenCDX()
+	issues := validate.RepairCDX(bom)
+	var unrepaired []*validate.Issue
+	for _, i := range issues {
+		if !i.Repaired {
+			unrepaired = append(unrepaired, i)
+		}
+	}
+	wantUnrepaired := []*validate.Issue{
+		{Severity: validate.SeverityError, Element: "lib-2.components[0]", Message: "missing component name"},
+	}
+	if diff := cmp.Diff(wantUnrepaired, unrepaired); diff != "" {
+		t.Errorf("RepairCDX() unrepaired issues (-want +got):\n%s", diff)
+	}
+
+	want := brokenCDX()
+	(*want.Components)[0].Type = cyclonedx.ComponentTypeLibrary
+	(*want.Components)[0].Licenses = &cyclonedx.Licenses{
+		{Expression: "MIT OR ISC"},
+		{License: &cyclonedx.License{Name: "Apache License"}},
+	}
+	(*want.Components)[1].BOMRef = "lib-2"
+	want.Dependencies = &[]cyclonedx.Dependency{
+		{Ref: "app", Dependencies: &[]string{"lib"}},
+	}
+	if diff := cmp.Diff(want, bom, cmpopts.IgnoreFields(cyclonedx.BOM{}, "SerialNumber")); diff != "" {
+		t.Errorf("RepairCDX() unexpected BOM (-want +got):\n%s", diff)
+	}
+	if got := validate.ValidateCDX(bom); len(got) != 1 {
+		t.Errorf("ValidateCDX(repaired) = %v, want only the missing component name", got)
+	}
+}
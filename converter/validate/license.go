@@ -0,0 +1,160 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validate
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	// A license from the SPDX license list, optionally with a "+" suffix.
+	licenseIDRe = regexp.MustCompile(`^[A-Za-z0-9.-]+\+?$`)
+	// A custom license defined in this or another document.
+	licenseRefRe = regexp.MustCompile(`^(DocumentRef-[A-Za-z0-9.-]+:)?LicenseRef-[A-Za-z0-9.-]+$`)
+	// Lower or mixed case operators, which SPDX requires to be upper case.
+	lowerOperatorRe = regexp.MustCompile(`(?i)(^|[\s()])(and|or|with)([\s()]|$)`)
+)
+
+// ValidLicenseExpression returns whether expr is a syntactically valid SPDX
+// license expression, e.g. "MIT OR (Apache-2.0 WITH LLVM-exception)".
+// NONE and NOASSERTION are only valid on their own. Whether the license IDs
+// are on the SPDX license list isn't checked.
+func ValidLicenseExpression(expr string) bool {
+	expr = strings.TrimSpace(expr)
+	if expr == noneValue || expr == noAssertion {
+		return true
+	}
+	p := &licenseParser{tokens: tokenizeLicenseExpression(expr)}
+	return len(p.tokens) > 0 && p.parseOr() && p.pos == len(p.tokens)
+}
+
+// repairLicenseExpression upper-cases the operators of expr. Returns
+// NOASSERTION if the expression is still invalid.
+func repairLicenseExpression(expr string) string {
+	fixed := expr
+	// Adjacent operators share the separating whitespace, so replace until stable.
+	for prev := ""; prev != fixed; {
+		prev = fixed
+		fixed = lowerOperatorRe.ReplaceAllStringFunc(fixed, strings.ToUpper)
+	}
+	if ValidLicenseExpression(fixed) {
+		return strings.TrimSpace(fixed)
+	}
+	return noAssertion
+}
+
+func tokenizeLicenseExpression(expr string) []string {
+	var tokens []string
+	for _, f := range strings.Fields(expr) {
+		start := 0
+		for i, c := range f {
+			if c == '(' || c == ')' {
+				if i > start {
+					tokens = append(tokens, f[start:i])
+				}
+				tokens = append(tokens, string(c))
+				start = i + 1
+			}
+		}
+		if start < len(f) {
+			tokens = append(tokens, f[start:])
+		}
+	}
+	return tokens
+}
+
+// licenseParser is a recursive descent parser for the SPDX license expression grammar:
+//
+//	or   = and *("OR" and)
+//	and  = with *("AND" with)
+//	with = atom ["WITH" exception-id]
+//	atom = license-id / license-ref / "(" or ")"
+type licenseParser struct {
+	tokens []string
+	pos    int
+}
+
+func (p *licenseParser) peek() string {
+	if p.pos >= len(p.tokens) {
+		return ""
+	}
+	return p.tokens[p.pos]
+}
+
+func (p *licenseParser) parseOr() bool {
+	if !p.parseAnd() {
+		return false
+	}
+	for p.peek() == "OR" {
+		p.pos++
+		if !p.parseAnd() {
+			return false
+		}
+	}
+	return true
+}
+
+func (p *licenseParser) parseAnd() bool {
+	if !p.parseWith() {
+		return false
+	}
+	for p.peek() == "AND" {
+		p.pos++
+		if !p.parseWith() {
+			return false
+		}
+	}
+	return true
+}
+
+func (p *licenseParser) parseWith() bool {
+	if !p.parseAtom() {
+		return false
+	}
+	if p.peek() == "WITH" {
+		p.pos++
+		if !isLicenseID(p.peek()) {
+			return false
+		}
+		p.pos++
+	}
+	return true
+}
+
+func (p *licenseParser) parseAtom() bool {
+	tok := p.peek()
+	if tok == "(" {
+		p.pos++
+		if !p.parseOr() || p.peek() != ")" {
+			return false
+		}
+		p.pos++
+		return true
+	}
+	if !isLicenseID(tok) && !licenseRefRe.MatchString(tok) {
+		return false
+	}
+	p.pos++
+	return true
+}
+
+func isLicenseID(tok string) bool {
+	switch tok {
+	case "AND", "OR", "WITH", noneValue, noAssertion:
+		return false
+	}
+	return licenseIDRe.MatchString(tok)
+}
@@ -0,0 +1,66 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validate
+
+import "testing"
+
+func TestValidLicenseExpression(t *testing.T) {
+	tests := []struct {
+		expr string
+		want bool
+	}{
+		{expr: "MIT", want: true},
+		{expr: "GPL-2.0+", want: true},
+		{expr: "NOASSERTION", want: true},
+		{expr: "NONE", want: true},
+		{expr: "MIT OR Apache-2.0", want: true},
+		{expr: "(MIT OR Apache-2.0) AND BSD-3-Clause", want: true},
+		{expr: "GPL-2.0-only WITH Classpath-exception-2.0", want: true},
+		{expr: "LicenseRef-custom AND DocumentRef-other:LicenseRef-x", want: true},
+		{expr: "((MIT))", want: true},
+		{expr: "", want: false},
+		{expr: "MIT and Apache-2.0", want: false},
+		{expr: "MIT Apache-2.0", want: false},
+		{expr: "MIT OR", want: false},
+		{expr: "(MIT OR Apache-2.0", want: false},
+		{expr: "MIT OR NOASSERTION", want: false},
+		{expr: "MIT WITH", want: false},
+		{expr: "MIT/Apache-2.0", want: false},
+		{expr: "Apache License 2.0", want: false},
+	}
+	for _, tc := range tests {
+		if got := ValidLicenseExpression(tc.expr); got != tc.want {
+			t.Errorf("ValidLicenseExpression(%q): got %v, want %v", tc.expr, got, tc.want)
+		}
+	}
+}
+
+func TestRepairLicenseExpression(t *testing.T) {
+	tests := []struct {
+		expr string
+		want string
+	}{
+		{expr: "MIT and Apache-2.0", want: "MIT AND Apache-2.0"},
+		{expr: "(MIT or BSD-2-Clause) and ISC", want: "(MIT OR BSD-2-Clause) AND ISC"},
+		{expr: "GPL-2.0-only with Classpath-exception-2.0", want: "GPL-2.0-only WITH Classpath-exception-2.0"},
+		{expr: "Apache License 2.0", want: "NOASSERTION"},
+		{expr: "MIT/Apache-2.0", want: "NOASSERTION"},
+	}
+	for _, tc := range tests {
+		if got := repairLicenseExpression(tc.expr); got != tc.want {
+			t.Errorf("repairLicenseExpression(%q): got %q, want %q", tc.expr, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,295 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validate
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/uuid"
+	"github.com/spdx/tools-golang/spdx/v2/common"
+	"github.com/spdx/tools-golang/spdx/v2/v2_3"
+)
+
+const (
+	spdxRefPrefix  = "SPDXRef-"
+	spdxDocumentID = "DOCUMENT"
+	spdxTimeFormat = "2006-01-02T15:04:05Z"
+)
+
+var spdxIDInvalidCharRe = regexp.MustCompile(`[^a-zA-Z0-9.-]`)
+
+// The relationship types defined in SPDX 2.3.
+var spdxRelationshipTypes = map[string]bool{
+	"DESCRIBES": true, "DESCRIBED_BY": true, "CONTAINS": true, "CONTAINED_BY": true,
+	"DEPENDS_ON": true, "DEPENDENCY_OF": true, "DEPENDENCY_MANIFEST_OF": true,
+	"BUILD_DEPENDENCY_OF": true, "DEV_DEPENDENCY_OF": true, "OPTIONAL_DEPENDENCY_OF": true,
+	"PROVIDED_DEPENDENCY_OF": true, "TEST_DEPENDENCY_OF": true, "RUNTIME_DEPENDENCY_OF": true,
+	"EXAMPLE_OF": true, "GENERATES": true, "GENERATED_FROM": true, "ANCESTOR_OF": true,
+	"DESCENDANT_OF": true, "VARIANT_OF": true, "DISTRIBUTION_ARTIFACT": true, "PATCH_FOR": true,
+	"PATCH_APPLIED": true, "COPY_OF": true, "FILE_ADDED": true, "FILE_DELETED": true,
+	"FILE_MODIFIED": true, "EXPANDED_FROM_ARCHIVE": true, "DYNAMIC_LINK": true, "STATIC_LINK": true,
+	"DATA_FILE_OF": true, "TEST_CASE_OF": true, "BUILD_TOOL_OF": true, "DEV_TOOL_OF": true,
+	"TEST_OF": true, "TEST_TOOL_OF": true, "DOCUMENTATION_OF": true, "OPTIONAL_COMPONENT_OF": true,
+	"METAFILE_OF": true, "PACKAGE_OF": true, "AMENDS": true, "PREREQUISITE_FOR": true,
+	"HAS_PREREQUISITE": true, "REQUIREMENT_DESCRIPTION_FOR": true, "SPECIFICATION_FOR": true,
+	"OTHER": true,
+}
+
+// ValidateSPDX23 checks an SPDX 2.3 document for missing required fields,
+// invalid identifiers and license expressions and relationships that refer to
+// unknown elements.
+func ValidateSPDX23(doc *v2_3.Document) []*Issue {
+	return checkSPDX23(doc, false)
+}
+
+// RepairSPDX23 fixes the problems ValidateSPDX23 finds in doc where possible
+// and returns all issues found. Issues that couldn't be fixed, e.g. a missing
+// package name, aren't marked as repaired.
+func RepairSPDX23(doc *v2_3.Document) []*Issue {
+	return checkSPDX23(doc, true)
+}
+
+func checkSPDX23(doc *v2_3.Document, repair bool) []*Issue {
+	c := &checker{repair: repair}
+	c.checkSPDXDocument(doc)
+	s := &spdxElements{ids: map[string]bool{spdxDocumentID: true}, renamed: map[string]string{}}
+	for i, p := range doc.Packages {
+		c.checkSPDXPackage(s, p, i)
+	}
+	for i, f := range doc.Files {
+		c.checkSPDXFile(s, f, fmt.Sprintf("files[%d]", i))
+	}
+	for _, sn := range doc.Snippets {
+		s.ids[spdxID(sn.SnippetSPDXIdentifier)] = true
+	}
+	c.checkSPDXRelationships(doc, s)
+	return c.issues
+}
+
+// spdxID returns the element ID without the optional "SPDXRef-" prefix.
+func spdxID(id common.ElementID) string {
+	return strings.TrimPrefix(string(id), spdxRefPrefix)
+}
+
+func (c *checker) checkSPDXDocument(doc *v2_3.Document) {
+	if doc.SPDXVersion != v2_3.Version && c.fixable("", "spdxVersion is %q, want %q", doc.SPDXVersion, v2_3.Version) {
+		doc.SPDXVersion = v2_3.Version
+	}
+	if doc.DataLicense != v2_3.DataLicense && c.fixable("", "dataLicense is %q, want %q", doc.DataLicense, v2_3.DataLicense) {
+		doc.DataLicense = v2_3.DataLicense
+	}
+	if spdxID(doc.SPDXIdentifier) != spdxDocumentID && c.fixable("", "SPDXID is %q, want %q", doc.SPDXIdentifier, spdxRefPrefix+spdxDocumentID) {
+		doc.SPDXIdentifier = spdxDocumentID
+	}
+	if doc.DocumentName == "" {
+		c.errorf("", "missing document name")
+	}
+	if u, err := url.Parse(doc.DocumentNamespace); err != nil || u.Scheme == "" || u.Fragment != "" || strings.Contains(doc.DocumentNamespace, "#") {
+		if c.fixable("", "documentNamespace %q isn't an absolute URI without a fragment", doc.DocumentNamespace) {
+			name := spdxIDInvalidCharRe.ReplaceAllString(doc.DocumentName, "-")
+			doc.DocumentNamespace = "https://spdx.org/spdxdocs/" + name + "-" + uuid.New().String()
+		}
+	}
+
+	if doc.CreationInfo == nil {
+		if !c.fixable("", "missing creationInfo") {
+			return
+		}
+		doc.CreationInfo = &v2_3.CreationInfo{}
+	}
+	if len(doc.CreationInfo.Creators) == 0 && c.fixable("", "creationInfo has no creators") {
+		doc.CreationInfo.Creators = []common.Creator{{CreatorType: "Tool", Creator: toolName}}
+	}
+	if _, err := time.Parse(time.RFC3339, doc.CreationInfo.Created); err != nil {
+		if c.fixable("", "creationInfo.created %q isn't a timestamp in the %s format", doc.CreationInfo.Created, spdxTimeFormat) {
+			doc.CreationInfo.Created = time.Now().UTC().Format(spdxTimeFormat)
+		}
+	}
+}
+
+// spdxElements tracks the element IDs of a document.
+type spdxElements struct {
+	ids map[string]bool
+	// IDs with invalid characters that were replaced during repair.
+	renamed map[string]string
+}
+
+// checkID checks that the ID is set, only uses valid characters and is unique.
+func (c *checker) checkID(s *spdxElements, id *common.ElementID, element string, fallback string) {
+	trimmed := spdxID(*id)
+	if trimmed == "" {
+		if !c.fixable(element, "missing SPDXID") {
+			return
+		}
+		trimmed = fallback
+	}
+	if spdxIDInvalidCharRe.MatchString(trimmed) {
+		if !c.fixable(element, "SPDXID %q contains characters other than letters, numbers, '.' and '-'", *id) {
+			s.ids[trimmed] = true
+			return
+		}
+		fixed := spdxIDInvalidCharRe.ReplaceAllString(trimmed, "-")
+		s.renamed[trimmed] = fixed
+		trimmed = fixed
+	}
+	if s.ids[trimmed] {
+		if !c.fixable(element, "duplicate SPDXID %q", spdxRefPrefix+trimmed) {
+			return
+		}
+		base := trimmed
+		for n := 2; s.ids[trimmed]; n++ {
+			trimmed = fmt.Sprintf("%s-%d", base, n)
+		}
+	}
+	s.ids[trimmed] = true
+	if c.repair {
+		*id = common.ElementID(spdxRefPrefix + trimmed)
+	}
+}
+
+func (c *checker) checkSPDXPackage(s *spdxElements, p *v2_3.Package, index int) {
+	element := fmt.Sprintf("packages[%d]", index)
+	if p.PackageSPDXIdentifier != "" {
+		element = spdxRefPrefix + spdxID(p.PackageSPDXIdentifier)
+	}
+	if p.PackageName == "" {
+		c.errorf(element, "missing package name")
+	}
+	c.checkID(s, &p.PackageSPDXIdentifier, element, fmt.Sprintf("Package-%d", index))
+	if p.PackageDownloadLocation == "" && c.fixable(element, "missing downloadLocation") {
+		p.PackageDownloadLocation = noAssertion
+	}
+	c.checkLicense(element, "licenseConcluded", &p.PackageLicenseConcluded)
+	c.checkLicense(element, "licenseDeclared", &p.PackageLicenseDeclared)
+	for _, ref := range p.PackageExternalReferences {
+		if ref == nil || ref.RefType != "purl" {
+			continue
+		}
+		if _, err := purl.FromString(ref.Locator); err != nil {
+			c.warnf(element, "invalid package URL %q: %v", ref.Locator, err)
+		}
+	}
+	for i, f := range p.Files {
+		c.checkSPDXFile(s, f, fmt.Sprintf("%s.files[%d]", element, i))
+	}
+}
+
+func (c *checker) checkSPDXFile(s *spdxElements, f *v2_3.File, element string) {
+	if f == nil {
+		return
+	}
+	if f.FileSPDXIdentifier != "" {
+		element = spdxRefPrefix + spdxID(f.FileSPDXIdentifier)
+	}
+	if f.FileName == "" {
+		c.errorf(element, "missing file name")
+	}
+	c.checkID(s, &f.FileSPDXIdentifier, element, "File-"+uuid.New().String())
+	c.checkLicense(element, "licenseConcluded", &f.LicenseConcluded)
+}
+
+func (c *checker) checkLicense(element string, field string, expr *string) {
+	if *expr == "" || ValidLicenseExpression(*expr) {
+		return
+	}
+	if c.fixable(element, "%s %q isn't a valid SPDX license expression", field, *expr) {
+		*expr = repairLicenseExpression(*expr)
+	}
+}
+
+func (c *checker) checkSPDXRelationships(doc *v2_3.Document, s *spdxElements) {
+	kept := make([]*v2_3.Relationship, 0, len(doc.Relationships))
+	describes := false
+	targets := map[string]bool{}
+	for i, r := range doc.Relationships {
+		if r == nil {
+			continue
+		}
+		element := fmt.Sprintf("relationships[%d]", i)
+		if c.repair {
+			for _, ref := range []*common.DocElementID{&r.RefA, &r.RefB} {
+				if fixed, ok := s.renamed[spdxID(ref.ElementRefID)]; ok && ref.DocumentRefID == "" {
+					ref.ElementRefID = common.ElementID(spdxRefPrefix + fixed)
+				}
+			}
+		}
+
+		drop := false
+		if !spdxRelationshipTypes[r.Relationship] {
+			normalized := strings.ReplaceAll(strings.ToUpper(r.Relationship), "-", "_")
+			if c.fixable(element, "unknown relationship type %q", r.Relationship) {
+				if spdxRelationshipTypes[normalized] {
+					r.Relationship = normalized
+				} else {
+					drop = true
+				}
+			}
+		}
+		if r.RefA.SpecialID != "" && c.fixable(element, "spdxElementId must refer to an element, got %s", r.RefA.SpecialID) {
+			drop = true
+		}
+		for _, ref := range []common.DocElementID{r.RefA, r.RefB} {
+			if ref.DocumentRefID != "" || ref.SpecialID != "" || s.ids[spdxID(ref.ElementRefID)] {
+				continue
+			}
+			if c.fixable(element, "refers to unknown element %q", spdxRefPrefix+spdxID(ref.ElementRefID)) {
+				drop = true
+			}
+		}
+		if drop {
+			continue
+		}
+
+		if r.Relationship == "DESCRIBES" && r.RefA.DocumentRefID == "" && spdxID(r.RefA.ElementRefID) == spdxDocumentID {
+			describes = true
+		}
+		if r.RefB.DocumentRefID == "" {
+			targets[spdxID(r.RefB.ElementRefID)] = true
+		}
+		kept = append(kept, r)
+	}
+	if c.repair {
+		doc.Relationships = kept
+	}
+
+	if describes || len(doc.Packages) == 0 {
+		return
+	}
+	if !c.fixable("", "the document doesn't DESCRIBE any element") {
+		return
+	}
+	// Describe the top-level packages, i.e. the ones no other element refers to.
+	var described []*v2_3.Package
+	for _, p := range doc.Packages {
+		if !targets[spdxID(p.PackageSPDXIdentifier)] {
+			described = append(described, p)
+		}
+	}
+	if len(described) == 0 {
+		described = doc.Packages[:1]
+	}
+	for _, p := range described {
+		doc.Relationships = append(doc.Relationships, &v2_3.Relationship{
+			RefA:         common.DocElementID{ElementRefID: spdxRefPrefix + spdxDocumentID},
+			RefB:         common.DocElementID{ElementRefID: common.ElementID(spdxRefPrefix + spdxID(p.PackageSPDXIdentifier))},
+			Relationship: "DESCRIBES",
+		})
+	}
+}
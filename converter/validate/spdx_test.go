@@ -0,0 +1,179 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validate_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	scalibr "github.com/google/osv-scalibr"
+	"github.com/google/osv-scalibr/converter"
+	"github.com/google/osv-scalibr/converter/validate"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem/language/javascript/packagejson"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/spdx/tools-golang/spdx/v2/common"
+	"github.com/spdx/tools-golang/spdx/v2/v2_3"
+)
+
+func scanResult() *scalibr.ScanResult {
+	return &scalibr.ScanResult{
+		Version: "1.2.3",
+		Inventory: inventory.Inventory{
+			Packages: []*extractor.Package{
+				{Name: "lodash", Version: "4.17.21", PURLType: "npm", Plugins: []string{packagejson.Name}, Locations: []string{"package.json"}},
+				{Name: "left-pad", Version: "1.3.0", PURLType: "npm", Plugins: []string{packagejson.Name}, Locations: []string{"package.json"}},
+			},
+		},
+	}
+}
+
+func docRef(id string) common.DocElementID {
+	return common.DocElementID{ElementRefID: common.ElementID(id)}
+}
+
+func brokenSPDX() *v2_3.Document {
+	return &v2_3.Document{
+		SPDXVersion:       "SPDX-2.2",
+		DataLicense:       "CC0-1.0",
+		SPDXIdentifier:    "DOCUMENT",
+		DocumentName:      "app",
+		DocumentNamespace: "app#1",
+		CreationInfo: &v2_3.CreationInfo{
+			Creators: []common.Creator{{CreatorType: "Tool", Creator: "custom"}},
+			Created:  "yesterday",
+		},
+		Packages: []*v2_3.Package{
+			{
+				PackageName:             "app",
+				PackageSPDXIdentifier:   "SPDXRef-Package-app",
+				PackageDownloadLocation: "NOASSERTION",
+				PackageLicenseDeclared:  "MIT and Apache-2.0",
+			},
+			{
+				PackageName:            "lib",
+				PackageSPDXIdentifier:  "SPDXRef-Package-@scope/lib",
+				PackageLicenseDeclared: "Apache License 2.0",
+				PackageExternalReferences: []*v2_3.PackageExternalReference{
+					{Category: "PACKAGE-MANAGER", RefType: "purl", Locator: "not-a-purl"},
+				},
+			},
+			{
+				PackageName:             "",
+				PackageSPDXIdentifier:   "SPDXRef-Package-app",
+				PackageDownloadLocation: "NOASSERTION",
+			},
+		},
+		Relationships: []*v2_3.Relationship{
+			{RefA: docRef("SPDXRef-Package-app"), RefB: docRef("SPDXRef-Package-@scope/lib"), Relationship: "depends-on"},
+			{RefA: docRef("SPDXRef-Package-app"), RefB: docRef("SPDXRef-Package-missing"), Relationship: "CONTAINS"},
+			{RefA: docRef("SPDXRef-Package-app"), RefB: common.DocElementID{SpecialID: "NOASSERTION"}, Relationship: "CONTAINS"},
+		},
+	}
+}
+
+func TestValidateSPDX23_GeneratedDocument(t *testing.T) {
+	doc := converter.ToSPDX23(scanResult(), converter.SPDXConfig{})
+	if issues := validate.ValidateSPDX23(doc); len(issues) != 0 {
+		t.Errorf("ValidateSPDX23(converter.ToSPDX23()) returned issues: %v", issues)
+	}
+}
+
+func TestValidateSPDX23(t *testing.T) {
+	doc := brokenSPDX()
+	got := validate.ValidateSPDX23(doc)
+	want := []*validate.Issue{
+		{Severity: validate.SeverityError, Message: `spdxVersion is "SPDX-2.2", want "SPDX-2.3"`},
+		{Severity: validate.SeverityError, Message: `documentNamespace "app#1" isn't an absolute URI without a fragment`},
+		{Severity: validate.SeverityError, Message: `creationInfo.created "yesterday" isn't a timestamp in the 2006-01-02T15:04:05Z format`},
+		{Severity: validate.SeverityError, Element: "SPDXRef-Package-app", Message: `licenseDeclared "MIT and Apache-2.0" isn't a valid SPDX license expression`},
+		{Severity: validate.SeverityError, Element: "SPDXRef-Package-@scope/lib", Message: `SPDXID "SPDXRef-Package-@scope/lib" contains characters other than letters, numbers, '.' and '-'`},
+		{Severity: validate.SeverityError, Element: "SPDXRef-Package-@scope/lib", Message: "missing downloadLocation"},
+		{Severity: validate.SeverityError, Element: "SPDXRef-Package-@scope/lib", Message: `licenseDeclared "Apache License 2.0" isn't a valid SPDX license expression`},
+		{Severity: validate.SeverityWarning, Element: "SPDXRef-Package-@scope/lib"},
+		{Severity: validate.SeverityError, Element: "SPDXRef-Package-app", Message: "missing package name"},
+		{Severity: validate.SeverityError, Element: "SPDXRef-Package-app", Message: `duplicate SPDXID "SPDXRef-Package-app"`},
+		{Severity: validate.SeverityError, Element: "relationships[0]", Message: `unknown relationship type "depends-on"`},
+		{Severity: validate.SeverityError, Element: "relationships[1]", Message: `refers to unknown element "SPDXRef-Package-missing"`},
+		{Severity: validate.SeverityError, Message: "the document doesn't DESCRIBE any element"},
+	}
+	// The purl parser's error message isn't part of the API.
+	ignorePURLError := cmp.FilterPath(func(p cmp.Path) bool {
+		return p.Last().String() == ".Message"
+	}, cmp.Comparer(func(a, b string) bool {
+		return a == b || (a == "" && strings.HasPrefix(b, "invalid package URL")) || (b == "" && strings.HasPrefix(a, "invalid package URL"))
+	}))
+	if diff := cmp.Diff(want, got, ignorePURLError); diff != "" {
+		t.Errorf("ValidateSPDX23() returned unexpected issues (-want +got):\n%s", diff)
+	}
+	if !validate.HasErrors(got) {
+		t.Errorf("HasErrors(ValidateSPDX23()) = false, want true")
+	}
+	if diff := cmp.Diff(brokenSPDX(), doc, cmpopts.IgnoreUnexported(v2_3.Package{})); diff != "" {
+		t.Errorf("ValidateSPDX23() modified the document (-want +got):\n%s", diff)
+	}
+}
+
+func TestRepairSPDX23(t *testing.T) {
+	doc := brokenSPDX()
+	issues := validate.RepairSPDX23(doc)
+
+	// Only the missing package name can't be repaired.
+	var unrepaired []string
+	for _, i := range issues {
+		if !i.Repaired && i.Severity == validate.SeverityError {
+			unrepaired = append(unrepaired, i.String())
+		}
+	}
+	wantUnrepaired := []string{"error: SPDXRef-Package-app: missing package name"}
+	if diff := cmp.Diff(wantUnrepaired, unrepaired); diff != "" {
+		t.Errorf("RepairSPDX23() unrepaired errors (-want +got):\n%s", diff)
+	}
+
+	if !strings.HasPrefix(doc.DocumentNamespace, "https://spdx.org/spdxdocs/app-") {
+		t.Errorf("RepairSPDX23() documentNamespace = %q, want a generated namespace", doc.DocumentNamespace)
+	}
+	want := brokenSPDX()
+	want.SPDXVersion = "SPDX-2.3"
+	want.Packages[0].PackageLicenseDeclared = "MIT AND Apache-2.0"
+	want.Packages[1].PackageSPDXIdentifier = "SPDXRef-Package--scope-lib"
+	want.Packages[1].PackageDownloadLocation = "NOASSERTION"
+	want.Packages[1].PackageLicenseDeclared = "NOASSERTION"
+	want.Packages[2].PackageSPDXIdentifier = "SPDXRef-Package-app-2"
+	want.Relationships = []*v2_3.Relationship{
+		{RefA: docRef("SPDXRef-Package-app"), RefB: docRef("SPDXRef-Package--scope-lib"), Relationship: "DEPENDS_ON"},
+		{RefA: docRef("SPDXRef-Package-app"), RefB: common.DocElementID{SpecialID: "NOASSERTION"}, Relationship: "CONTAINS"},
+		{RefA: docRef("SPDXRef-DOCUMENT"), RefB: docRef("SPDXRef-Package-app"), Relationship: "DESCRIBES"},
+		{RefA: docRef("SPDXRef-DOCUMENT"), RefB: docRef("SPDXRef-Package-app-2"), Relationship: "DESCRIBES"},
+	}
+	if diff := cmp.Diff(want, doc, cmpopts.IgnoreFields(v2_3.Document{}, "DocumentNamespace"), cmpopts.IgnoreFields(v2_3.CreationInfo{}, "Created"), cmpopts.IgnoreUnexported(v2_3.Package{})); diff != "" {
+		t.Errorf("RepairSPDX23() unexpected document (-want +got):\n%s", diff)
+	}
+
+	// A repaired document only keeps the issues that couldn't be fixed.
+	var remaining []string
+	for _, i := range validate.ValidateSPDX23(doc) {
+		remaining = append(remaining, i.Severity.String()+": "+i.Element)
+	}
+	wantRemaining := []string{
+		"warning: SPDXRef-Package--scope-lib", // Invalid package URL.
+		"error: SPDXRef-Package-app-2",        // Missing package name.
+	}
+	if diff := cmp.Diff(wantRemaining, remaining); diff != "" {
+		t.Errorf("ValidateSPDX23(repaired) unexpected issues (-want +got):\n%s", diff)
+	}
+}
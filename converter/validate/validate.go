@@ -0,0 +1,105 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package validate checks SPDX and CycloneDX documents against the required
+// fields and semantic rules of their specifications and repairs common problems
+// that cause downstream SBOM validators to reject them.
+package validate
+
+import (
+	"fmt"
+	"slices"
+)
+
+const (
+	noAssertion = "NOASSERTION"
+	noneValue   = "NONE"
+	toolName    = "osv-scalibr"
+)
+
+// Severity of a validation issue.
+type Severity int
+
+// Severity values.
+const (
+	// SeverityError means the document violates the specification.
+	SeverityError Severity = iota
+	// SeverityWarning means the document is valid but likely to cause problems
+	// for consumers, e.g. because of an unparsable package URL.
+	SeverityWarning
+)
+
+func (s Severity) String() string {
+	if s == SeverityWarning {
+		return "warning"
+	}
+	return "error"
+}
+
+// Issue is a problem found in an SBOM.
+type Issue struct {
+	Severity Severity
+	// The element the issue was found in, e.g. "SPDXRef-Package-foo" or
+	// "relationships[3]". Empty for document-level issues.
+	Element string
+	Message string
+	// Whether the issue was fixed in the document by a Repair function.
+	Repaired bool
+}
+
+func (i *Issue) String() string {
+	s := i.Severity.String() + ": "
+	if i.Element != "" {
+		s += i.Element + ": "
+	}
+	s += i.Message
+	if i.Repaired {
+		s += " (repaired)"
+	}
+	return s
+}
+
+// HasErrors returns whether any of the issues is an error that wasn't repaired.
+func HasErrors(issues []*Issue) bool {
+	return slices.ContainsFunc(issues, func(i *Issue) bool {
+		return i.Severity == SeverityError && !i.Repaired
+	})
+}
+
+// checker collects the issues of a document. If repair is set, the check
+// functions fix the problems they can and mark the issues as repaired.
+type checker struct {
+	repair bool
+	issues []*Issue
+}
+
+func (c *checker) errorf(element string, format string, args ...any) {
+	c.issues = append(c.issues, &Issue{Severity: SeverityError, Element: element, Message: fmt.Sprintf(format, args...)})
+}
+
+func (c *checker) warnf(element string, format string, args ...any) {
+	c.issues = append(c.issues, &Issue{Severity: SeverityWarning, Element: element, Message: fmt.Sprintf(format, args...)})
+}
+
+// fixable records an error that the caller fixes if c.repair is set and
+// returns whether it should be fixed.
+func (c *checker) fixable(element string, format string, args ...any) bool {
+	c.issues = append(c.issues, &Issue{
+		Severity: SeverityError,
+		Element:  element,
+		Message:  fmt.Sprintf(format, args...),
+		Repaired: c.repair,
+	})
+	return c.repair
+}
@@ -17,6 +17,7 @@ package detectorrunner
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"reflect"
 	"time"
@@ -24,22 +25,35 @@ import (
 	"github.com/google/osv-scalibr/detector"
 	scalibrfs "github.com/google/osv-scalibr/fs"
 	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/log"
 	"github.com/google/osv-scalibr/packageindex"
 	"github.com/google/osv-scalibr/plugin"
 	"github.com/google/osv-scalibr/stats"
+	"github.com/google/osv-scalibr/stats/resourceusage"
 )
 
 // Run runs the specified detectors and returns their findings,
 // as well as info about whether the plugin runs completed successfully.
-func Run(ctx context.Context, c stats.Collector, detectors []detector.Detector, scanRoot *scalibrfs.ScanRoot, index *packageindex.PackageIndex) (inventory.Finding, []*plugin.Status, error) {
+// Detectors that exceed their timeout are marked as failed, or as partially
+// succeeded if they returned findings. The resources used by each detector are
+// recorded in the optional tracker.
+func Run(ctx context.Context, c stats.Collector, detectors []detector.Detector, scanRoot *scalibrfs.ScanRoot, index *packageindex.PackageIndex, timeouts *plugin.Timeouts, tracker *resourceusage.Tracker) (inventory.Finding, []*plugin.Status, error) {
 	findings := inventory.Finding{}
 	status := []*plugin.Status{}
 	for _, d := range detectors {
 		if ctx.Err() != nil {
-			return inventory.Finding{}, nil, ctx.Err()
+			return findings, status, ctx.Err()
 		}
 		start := time.Now()
-		result, err := d.Scan(ctx, scanRoot, index)
+		var result inventory.Finding
+		dctx := log.NewContext(ctx, log.KeyPlugin, d.Name())
+		run := tracker.StartPlugin(d.Name())
+		err := timeouts.Run(dctx, d.Name(), func(ctx context.Context) error {
+			var err error
+			result, err = d.Scan(ctx, scanRoot, index)
+			return err
+		})
+		run.Stop(0)
 		c.AfterDetectorRun(d.Name(), time.Since(start), err)
 		for _, v := range result.PackageVulns {
 			v.Plugins = []string{d.Name()}
@@ -49,7 +63,8 @@ func Run(ctx context.Context, c stats.Collector, detectors []detector.Detector,
 		}
 		findings.PackageVulns = append(findings.PackageVulns, result.PackageVulns...)
 		findings.GenericFindings = append(findings.GenericFindings, result.GenericFindings...)
-		status = append(status, plugin.StatusFromErr(d, false, err))
+		partial := errors.Is(err, plugin.ErrTimeout) && (len(result.PackageVulns) > 0 || len(result.GenericFindings) > 0)
+		status = append(status, plugin.StatusFromErr(d, partial, err))
 	}
 	if err := validateAdvisories(findings.GenericFindings); err != nil {
 		return inventory.Finding{}, status, err
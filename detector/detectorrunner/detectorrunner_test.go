@@ -17,7 +17,9 @@ package detectorrunner_test
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -106,6 +108,7 @@ func TestRun(t *testing.T) {
 				{Name: "det1", Version: 1, Status: success},
 				{Name: "det2", Version: 2, Status: &plugin.ScanStatus{
 					Status: plugin.ScanStatusFailed, FailureReason: "detection failed",
+					ErrorCounts: []*plugin.ErrorCount{{Category: plugin.ErrorCategoryOther, Count: 1}},
 				}},
 			},
 		},
@@ -183,7 +186,7 @@ func TestRun(t *testing.T) {
 			px, _ := packageindex.New([]*extractor.Package{})
 			tmp := t.TempDir()
 			gotFindings, gotStatus, err := detectorrunner.Run(
-				context.Background(), stats.NoopCollector{}, tc.det, scalibrfs.RealFSScanRoot(tmp), px,
+				context.Background(), stats.NoopCollector{}, tc.det, scalibrfs.RealFSScanRoot(tmp), px, nil, nil,
 			)
 			if diff := cmp.Diff(tc.wantErr, err, cmpopts.EquateErrors()); diff != "" {
 				t.Errorf("detectorrunner.Run(%v): unexpected error (-want +got):\n%s", tc.det, diff)
@@ -209,3 +212,53 @@ func pkgVulnWithDetectorName(v *inventory.PackageVuln, det string) *inventory.Pa
 	c.Plugins = []string{det}
 	return &c
 }
+
+// slowDetector returns its findings only once the context is cancelled.
+type slowDetector struct {
+	detector.Detector
+}
+
+func (d slowDetector) Scan(ctx context.Context, scanRoot *scalibrfs.ScanRoot, px *packageindex.PackageIndex) (inventory.Finding, error) {
+	findings, _ := d.Detector.Scan(ctx, scanRoot, px)
+	<-ctx.Done()
+	return findings, ctx.Err()
+}
+
+func TestRun_Timeout(t *testing.T) {
+	finding := &inventory.GenericFinding{
+		Adv: &inventory.GenericFindingAdvisory{ID: &inventory.AdvisoryID{Publisher: "CVE", Reference: "CVE-1234"}},
+	}
+	dets := []detector.Detector{
+		slowDetector{fd.New().WithName("slow-with-findings").WithGenericFinding(finding)},
+		slowDetector{fd.New().WithName("slow")},
+		fd.New().WithName("fast"),
+	}
+	timeouts := &plugin.Timeouts{Default: time.Millisecond, PerPlugin: map[string]time.Duration{"fast": 0}}
+	px, _ := packageindex.New([]*extractor.Package{})
+
+	gotFindings, gotStatus, err := detectorrunner.Run(
+		context.Background(), stats.NoopCollector{}, dets, scalibrfs.RealFSScanRoot(t.TempDir()), px, timeouts, nil,
+	)
+	if err != nil {
+		t.Fatalf("detectorrunner.Run(): %v", err)
+	}
+	wantFindings := inventory.Finding{
+		GenericFindings: []*inventory.GenericFinding{withDetectorName(finding, "slow-with-findings")},
+	}
+	if diff := cmp.Diff(wantFindings, gotFindings); diff != "" {
+		t.Errorf("detectorrunner.Run(): unexpected findings (-want +got):\n%s", diff)
+	}
+	want := map[string]plugin.ScanStatusEnum{
+		"slow-with-findings": plugin.ScanStatusPartiallySucceeded,
+		"slow":               plugin.ScanStatusFailed,
+		"fast":               plugin.ScanStatusSucceeded,
+	}
+	for _, s := range gotStatus {
+		if s.Status.Status != want[s.Name] {
+			t.Errorf("detectorrunner.Run(): status of %q = %v, want %v", s.Name, s.Status.Status, want[s.Name])
+		}
+		if s.Status.Status != plugin.ScanStatusSucceeded && !strings.Contains(s.Status.FailureReason, plugin.ErrTimeout.Error()) {
+			t.Errorf("detectorrunner.Run(): failure reason of %q = %q, want timeout", s.Name, s.Status.FailureReason)
+		}
+	}
+}
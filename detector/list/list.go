@@ -30,6 +30,16 @@ import (
 	"github.com/google/osv-scalibr/detector/cve/untested/cve20242912"
 	"github.com/google/osv-scalibr/detector/endoflife/linuxdistro"
 	"github.com/google/osv-scalibr/detector/govulncheck/binary"
+	"github.com/google/osv-scalibr/detector/misconfig/apkworld"
+	"github.com/google/osv-scalibr/detector/misconfig/containerdconfig"
+	"github.com/google/osv-scalibr/detector/misconfig/containerspolicy"
+	"github.com/google/osv-scalibr/detector/misconfig/dockerdaemon"
+	"github.com/google/osv-scalibr/detector/misconfig/jvmflags"
+	"github.com/google/osv-scalibr/detector/misconfig/mongodbconfig"
+	"github.com/google/osv-scalibr/detector/misconfig/mysqlconfig"
+	"github.com/google/osv-scalibr/detector/misconfig/postgresconfig"
+	"github.com/google/osv-scalibr/detector/misconfig/redisconfig"
+	"github.com/google/osv-scalibr/detector/mlmodel/unsafepickle"
 	"github.com/google/osv-scalibr/detector/weakcredentials/codeserver"
 	"github.com/google/osv-scalibr/detector/weakcredentials/etcshadow"
 	"github.com/google/osv-scalibr/detector/weakcredentials/filebrowser"
@@ -51,6 +61,22 @@ var Govulncheck = InitMap{binary.Name: {binary.New}}
 // EndOfLife detectors.
 var EndOfLife = InitMap{linuxdistro.Name: {linuxdistro.New}}
 
+// Misconfig detectors for insecure container runtime, JVM and system configurations.
+var Misconfig = InitMap{
+	apkworld.Name:         {apkworld.New},
+	containerdconfig.Name: {containerdconfig.New},
+	containerspolicy.Name: {containerspolicy.New},
+	dockerdaemon.Name:     {dockerdaemon.New},
+	jvmflags.Name:         {jvmflags.New},
+	mongodbconfig.Name:    {mongodbconfig.New},
+	mysqlconfig.Name:      {mysqlconfig.New},
+	postgresconfig.Name:   {postgresconfig.New},
+	redisconfig.Name:      {redisconfig.New},
+}
+
+// MLModel detectors for unsafe machine learning model files.
+var MLModel = InitMap{unsafepickle.Name: {unsafepickle.New}}
+
 // Untested CVE scanning related detectors - since they don't have proper testing they
 // might not work as expected in the future.
 // TODO(b/405223999): Add tests.
@@ -85,6 +111,8 @@ var All = concat(
 	CIS,
 	EndOfLife,
 	Govulncheck,
+	Misconfig,
+	MLModel,
 	Weakcredentials,
 	Untested,
 )
@@ -93,6 +121,8 @@ var detectorNames = concat(All, InitMap{
 	"cis":               vals(CIS),
 	"endoflife":         vals(EndOfLife),
 	"govulncheck":       vals(Govulncheck),
+	"misconfig":         vals(Misconfig),
+	"mlmodel":           vals(MLModel),
 	"weakcredentials":   vals(Weakcredentials),
 	"untested":          vals(Untested),
 	"detectors/default": vals(Default),
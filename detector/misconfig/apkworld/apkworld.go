@@ -0,0 +1,300 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package apkworld implements a detector that cross-checks the packages
+// requested in Alpine's /etc/apk/world file against the installed package
+// database, finding packages that are installed without being needed by the
+// world and world entries that aren't installed.
+package apkworld
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"slices"
+	"strings"
+
+	"github.com/google/osv-scalibr/detector"
+	"github.com/google/osv-scalibr/extractor/filesystem/os/apk/apkutil"
+	scalibrfs "github.com/google/osv-scalibr/fs"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/packageindex"
+	"github.com/google/osv-scalibr/plugin"
+)
+
+const (
+	// Name of the detector.
+	Name = "misconfig/apkworld"
+
+	worldPath = "etc/apk/world"
+)
+
+// installedDBPaths are the locations of the installed package database, in
+// order of preference. Newer apk versions moved it to /usr.
+var installedDBPaths = []string{"lib/apk/db/installed", "usr/lib/apk/db/installed"}
+
+// Detector is a SCALIBR Detector for inconsistencies between the apk world file
+// and the installed package database.
+type Detector struct{}
+
+// New returns a detector.
+func New() detector.Detector {
+	return &Detector{}
+}
+
+// Name of the detector.
+func (Detector) Name() string { return Name }
+
+// Version of the detector.
+func (Detector) Version() int { return 0 }
+
+// Requirements of the detector.
+func (Detector) Requirements() *plugin.Capabilities {
+	return &plugin.Capabilities{OS: plugin.OSLinux}
+}
+
+// RequiredExtractors returns nothing (no deps).
+func (Detector) RequiredExtractors() []string { return []string{} }
+
+// DetectedFinding returns generic vulnerability information about what is detected.
+func (d Detector) DetectedFinding() inventory.Finding {
+	return inventory.Finding{GenericFindings: []*inventory.GenericFinding{
+		orphanedFinding(nil),
+		missingFinding(nil),
+	}}
+}
+
+func orphanedFinding(target *inventory.GenericFindingTargetDetails) *inventory.GenericFinding {
+	return &inventory.GenericFinding{
+		Adv: &inventory.GenericFindingAdvisory{
+			ID: &inventory.AdvisoryID{
+				Publisher: "SCALIBR",
+				Reference: "apk-orphaned-packages",
+			},
+			Title: "Alpine packages installed outside of the apk world",
+			Description: "Some installed packages are neither listed in /etc/apk/world nor " +
+				"needed by a package that is. They are orphaned leftovers or were added " +
+				"manually, e.g. by extracting .apk files or editing the package database, " +
+				"and increase the attack surface without being tracked.",
+			Recommendation: "Remove the packages with \"apk del\" or add them to the world " +
+				"with \"apk add\" if they're needed, then run \"apk fix\".",
+			Sev: inventory.SeverityLow,
+		},
+		Target: target,
+	}
+}
+
+func missingFinding(target *inventory.GenericFindingTargetDetails) *inventory.GenericFinding {
+	return &inventory.GenericFinding{
+		Adv: &inventory.GenericFindingAdvisory{
+			ID: &inventory.AdvisoryID{
+				Publisher: "SCALIBR",
+				Reference: "apk-world-not-installed",
+			},
+			Title: "Alpine world entries are not installed",
+			Description: "Some packages listed in /etc/apk/world are not installed, so the " +
+				"world file and the package database are out of sync. This is usually " +
+				"caused by manual changes to either of them.",
+			Recommendation: "Run \"apk fix\" to install the missing packages or remove " +
+				"the entries from the world with \"apk del\".",
+			Sev: inventory.SeverityMinimal,
+		},
+		Target: target,
+	}
+}
+
+// Scan cross-checks the apk world file of the host against its installed packages.
+func (d Detector) Scan(ctx context.Context, scanRoot *scalibrfs.ScanRoot, px *packageindex.PackageIndex) (inventory.Finding, error) {
+	return d.ScanFS(ctx, scanRoot.FS, px)
+}
+
+// installedPackage is an entry of the installed package database.
+type installedPackage struct {
+	name    string
+	version string
+	// Names of the packages or virtual names this package depends on.
+	deps []string
+	// Virtual names provided by this package.
+	provides []string
+	// Names of the packages that trigger the installation of this package if
+	// they're all installed.
+	installIf []string
+}
+
+// ScanFS cross-checks the apk world file in the given filesystem against the
+// installed packages. Systems without a world file are skipped.
+func (d Detector) ScanFS(ctx context.Context, fsys fs.FS, px *packageindex.PackageIndex) (inventory.Finding, error) {
+	worldData, err := fs.ReadFile(fsys, worldPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return inventory.Finding{}, nil
+	}
+	if err != nil {
+		return inventory.Finding{}, err
+	}
+	pkgs, err := readInstalledDB(fsys)
+	if err != nil {
+		return inventory.Finding{}, err
+	}
+	if pkgs == nil {
+		return inventory.Finding{}, nil
+	}
+
+	// Map the package names and the names provided by the packages, e.g.
+	// "so:libc.musl-x86_64.so.1" or "cmd:sh", to the packages.
+	providers := map[string][]*installedPackage{}
+	for _, p := range pkgs {
+		providers[p.name] = append(providers[p.name], p)
+		for _, name := range p.provides {
+			providers[name] = append(providers[name], p)
+		}
+	}
+
+	var missing []string
+	var queue []*installedPackage
+	for _, entry := range strings.Fields(string(worldData)) {
+		name, ok := dependencyName(entry)
+		if !ok {
+			continue
+		}
+		if len(providers[name]) == 0 {
+			missing = append(missing, entry)
+			continue
+		}
+		queue = append(queue, providers[name]...)
+	}
+
+	// Mark everything reachable from the world, including packages that are
+	// automatically installed through their install_if conditions.
+	needed := map[*installedPackage]bool{}
+	for len(queue) > 0 {
+		if err := ctx.Err(); err != nil {
+			return inventory.Finding{}, err
+		}
+		for len(queue) > 0 {
+			p := queue[0]
+			queue = queue[1:]
+			if needed[p] {
+				continue
+			}
+			needed[p] = true
+			for _, dep := range p.deps {
+				queue = append(queue, providers[dep]...)
+			}
+		}
+		for _, p := range pkgs {
+			if !needed[p] && len(p.installIf) > 0 && allProvided(p.installIf, providers, needed) {
+				queue = append(queue, p)
+			}
+		}
+	}
+
+	var orphaned []string
+	for _, p := range pkgs {
+		if !needed[p] {
+			orphaned = append(orphaned, p.name+"-"+p.version)
+		}
+	}
+
+	var findings []*inventory.GenericFinding
+	if len(orphaned) > 0 {
+		findings = append(findings, orphanedFinding(&inventory.GenericFindingTargetDetails{
+			Extra: "packages not required by /" + worldPath + ": " + strings.Join(orphaned, ", "),
+		}))
+	}
+	if len(missing) > 0 {
+		findings = append(findings, missingFinding(&inventory.GenericFindingTargetDetails{
+			Extra: "/" + worldPath + " entries not installed: " + strings.Join(missing, ", "),
+		}))
+	}
+	return inventory.Finding{GenericFindings: findings}, nil
+}
+
+// readInstalledDB returns the packages in the installed package database, or
+// nil if there is none.
+func readInstalledDB(fsys fs.FS) ([]*installedPackage, error) {
+	for _, path := range installedDBPaths {
+		f, err := fsys.Open(path)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+
+		pkgs := []*installedPackage{}
+		s := apkutil.NewScanner(f)
+		for s.Scan() {
+			record := s.RecordMultiValue()
+			p := &installedPackage{
+				name:      last(record["P"]),
+				version:   last(record["V"]),
+				deps:      dependencyNames(last(record["D"])),
+				provides:  dependencyNames(last(record["p"])),
+				installIf: dependencyNames(last(record["i"])),
+			}
+			if p.name != "" {
+				pkgs = append(pkgs, p)
+			}
+		}
+		if err := s.Err(); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+		return pkgs, nil
+	}
+	return nil, nil
+}
+
+// dependencyName returns the package name of a world or dependency entry,
+// e.g. "musl" for "musl>=1.2" or "curl" for "curl@edge". Returns false for
+// conflicts, which start with "!".
+func dependencyName(entry string) (string, bool) {
+	if entry == "" || strings.HasPrefix(entry, "!") {
+		return "", false
+	}
+	if i := strings.IndexAny(entry, "=<>~@"); i >= 0 {
+		entry = entry[:i]
+	}
+	return entry, entry != ""
+}
+
+// dependencyNames returns the package names of a space separated list of
+// dependencies.
+func dependencyNames(list string) []string {
+	var names []string
+	for _, entry := range strings.Fields(list) {
+		if name, ok := dependencyName(entry); ok {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// allProvided returns whether all of the given names are provided by needed packages.
+func allProvided(names []string, providers map[string][]*installedPackage, needed map[*installedPackage]bool) bool {
+	for _, name := range names {
+		if !slices.ContainsFunc(providers[name], func(p *installedPackage) bool { return needed[p] }) {
+			return false
+		}
+	}
+	return true
+}
+
+func last(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+	return values[len(values)-1]
+}
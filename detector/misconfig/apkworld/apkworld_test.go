@@ -0,0 +1,148 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apkworld_test
+
+import (
+	"context"
+	"testing"
+	"testing/fstest"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/osv-scalibr/detector/misconfig/apkworld"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/packageindex"
+)
+
+const installedDB = `P:musl
+V:1.2.4-r2
+p:so:libc.musl-x86_64.so.1=1
+
+P:busybox
+V:1.36.1-r5
+D:so:libc.musl-x86_64.so.1
+p:cmd:sh=1.36.1-r5
+
+P:alpine-baselayout
+V:3.4.3-r1
+D:busybox musl>=1.2
+
+P:curl
+V:8.5.0-r0
+D:so:libc.musl-x86_64.so.1 libcurl=8.5.0-r0
+
+P:libcurl
+V:8.5.0-r0
+
+P:curl-doc
+V:8.5.0-r0
+i:curl=8.5.0-r0 docs
+
+P:docs
+V:0.2-r6
+
+P:.build-deps
+V:20240101.000000
+D:gcc
+
+P:gcc
+V:13.2.1-r0
+D:!gcc-old
+
+P:netcat-openbsd
+V:1.226-r0
+D:musl
+
+P:leftover-lib
+V:1.0-r0
+`
+
+func TestScanFS(t *testing.T) {
+	det := apkworld.Detector{}
+	advs := det.DetectedFinding().GenericFindings
+	orphanedAdv, missingAdv := advs[0].Adv, advs[1].Adv
+
+	px, _ := packageindex.New([]*extractor.Package{})
+	testCases := []struct {
+		desc         string
+		world        string
+		installed    string
+		wantFindings []*inventory.GenericFinding
+		wantErr      bool
+	}{
+		{
+			desc:      "no_world_file",
+			installed: installedDB,
+		},
+		{
+			desc:  "no_installed_db",
+			world: "alpine-baselayout\n",
+		},
+		{
+			desc:      "consistent",
+			world:     "alpine-baselayout\ncurl\ndocs\n.build-deps\nnetcat-openbsd\nleftover-lib\n",
+			installed: installedDB,
+		},
+		{
+			desc:      "orphaned_packages",
+			world:     "alpine-baselayout curl>=8 .build-deps\n",
+			installed: installedDB,
+			wantFindings: []*inventory.GenericFinding{{
+				Adv: orphanedAdv,
+				Target: &inventory.GenericFindingTargetDetails{
+					Extra: "packages not required by /etc/apk/world: curl-doc-8.5.0-r0, docs-0.2-r6, " +
+						"netcat-openbsd-1.226-r0, leftover-lib-1.0-r0",
+				},
+			}},
+		},
+		{
+			desc:      "missing_world_entries",
+			world:     "alpine-baselayout curl docs .build-deps netcat-openbsd leftover-lib nginx@edge !openssl\n",
+			installed: installedDB,
+			wantFindings: []*inventory.GenericFinding{{
+				Adv: missingAdv,
+				Target: &inventory.GenericFindingTargetDetails{
+					Extra: "/etc/apk/world entries not installed: nginx@edge",
+				},
+			}},
+		},
+		{
+			desc:      "invalid_installed_db",
+			world:     "alpine-baselayout\n",
+			installed: "not a record\n",
+			wantErr:   true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			fsys := fstest.MapFS{}
+			if tc.world != "" {
+				fsys["etc/apk/world"] = &fstest.MapFile{Data: []byte(tc.world)}
+			}
+			if tc.installed != "" {
+				fsys["lib/apk/db/installed"] = &fstest.MapFile{Data: []byte(tc.installed)}
+			}
+
+			finding, err := det.ScanFS(context.Background(), fsys, px)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("ScanFS() error: %v, want error: %t", err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.wantFindings, finding.GenericFindings); diff != "" {
+				t.Errorf("ScanFS() returned unexpected findings (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
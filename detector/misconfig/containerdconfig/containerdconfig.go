@@ -0,0 +1,208 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package containerdconfig implements a detector for insecure containerd configurations
+// set in /etc/containerd/config.toml.
+package containerdconfig
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/google/osv-scalibr/detector"
+	scalibrfs "github.com/google/osv-scalibr/fs"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/packageindex"
+	"github.com/google/osv-scalibr/plugin"
+)
+
+const (
+	// Name of the detector.
+	Name = "misconfig/containerdconfig"
+
+	configPath = "etc/containerd/config.toml"
+)
+
+// criPlugins are the names of the CRI plugins that hold the registry config in the
+// different containerd config versions.
+var criPlugins = []string{
+	"io.containerd.grpc.v1.cri",
+	"io.containerd.cri.v1.images",
+}
+
+// config contains the security relevant fields of the containerd config.
+// See https://github.com/containerd/containerd/blob/main/docs/man/containerd-config.toml.5.md
+type config struct {
+	GRPC    grpcConfig                 `toml:"grpc"`
+	Plugins map[string]criPluginConfig `toml:"plugins"`
+}
+
+type grpcConfig struct {
+	TCPAddress string `toml:"tcp_address"`
+	TCPTLSCA   string `toml:"tcp_tls_ca"`
+}
+
+type criPluginConfig struct {
+	Registry registryConfig `toml:"registry"`
+}
+
+type registryConfig struct {
+	Mirrors map[string]mirrorConfig       `toml:"mirrors"`
+	Configs map[string]registryHostConfig `toml:"configs"`
+}
+
+type mirrorConfig struct {
+	Endpoint []string `toml:"endpoint"`
+}
+
+type registryHostConfig struct {
+	TLS struct {
+		InsecureSkipVerify bool `toml:"insecure_skip_verify"`
+	} `toml:"tls"`
+}
+
+// Detector is a SCALIBR Detector for insecure containerd configurations.
+type Detector struct{}
+
+// New returns a detector.
+func New() detector.Detector {
+	return &Detector{}
+}
+
+// Name of the detector.
+func (Detector) Name() string { return Name }
+
+// Version of the detector.
+func (Detector) Version() int { return 0 }
+
+// Requirements of the detector.
+func (Detector) Requirements() *plugin.Capabilities {
+	return &plugin.Capabilities{OS: plugin.OSLinux}
+}
+
+// RequiredExtractors returns nothing (no deps).
+func (Detector) RequiredExtractors() []string { return []string{} }
+
+// DetectedFinding returns generic vulnerability information about what is detected.
+func (d Detector) DetectedFinding() inventory.Finding {
+	return inventory.Finding{GenericFindings: []*inventory.GenericFinding{
+		unauthenticatedSocketFinding(nil),
+		insecureRegistriesFinding(nil),
+	}}
+}
+
+func unauthenticatedSocketFinding(target *inventory.GenericFindingTargetDetails) *inventory.GenericFinding {
+	return &inventory.GenericFinding{
+		Adv: &inventory.GenericFindingAdvisory{
+			ID: &inventory.AdvisoryID{
+				Publisher: "SCALIBR",
+				Reference: "containerd-unauthenticated-tcp-socket",
+			},
+			Title: "containerd exposes an unauthenticated TCP socket",
+			Description: "The containerd gRPC API listens on a TCP address without TLS client " +
+				"certificate verification. Anyone who can reach the socket can run containers " +
+				"and thus gain root access to the host.",
+			Recommendation: "Remove grpc.tcp_address from /etc/containerd/config.toml or set " +
+				"grpc.tcp_tls_ca, grpc.tcp_tls_cert and grpc.tcp_tls_key to require mutual TLS.",
+			Sev: inventory.SeverityCritical,
+		},
+		Target: target,
+	}
+}
+
+func insecureRegistriesFinding(target *inventory.GenericFindingTargetDetails) *inventory.GenericFinding {
+	return &inventory.GenericFinding{
+		Adv: &inventory.GenericFindingAdvisory{
+			ID: &inventory.AdvisoryID{
+				Publisher: "SCALIBR",
+				Reference: "containerd-insecure-registries",
+			},
+			Title: "containerd allows insecure registries",
+			Description: "containerd is configured to pull images from registries over plain " +
+				"HTTP or without verifying their TLS certificates. An attacker in a privileged " +
+				"network position can tamper with the pulled images.",
+			Recommendation: "Use https:// registry endpoints and remove insecure_skip_verify " +
+				"from the registry configs in /etc/containerd/config.toml.",
+			Sev: inventory.SeverityMedium,
+		},
+		Target: target,
+	}
+}
+
+// Scan checks the containerd config of the host for insecure settings.
+func (d Detector) Scan(ctx context.Context, scanRoot *scalibrfs.ScanRoot, px *packageindex.PackageIndex) (inventory.Finding, error) {
+	return d.ScanFS(ctx, scanRoot.FS, px)
+}
+
+// ScanFS checks the containerd config in the given filesystem for insecure settings.
+// Hosts without a config.toml are skipped.
+func (d Detector) ScanFS(ctx context.Context, fsys fs.FS, px *packageindex.PackageIndex) (inventory.Finding, error) {
+	data, err := fs.ReadFile(fsys, configPath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			// containerd is not installed or runs with the default settings.
+			return inventory.Finding{}, nil
+		}
+		return inventory.Finding{}, err
+	}
+
+	var cfg config
+	if _, err := toml.Decode(string(data), &cfg); err != nil {
+		return inventory.Finding{}, fmt.Errorf("failed to parse %s: %w", configPath, err)
+	}
+
+	var findings []*inventory.GenericFinding
+	if cfg.GRPC.TCPAddress != "" && cfg.GRPC.TCPTLSCA == "" {
+		findings = append(findings, unauthenticatedSocketFinding(&inventory.GenericFindingTargetDetails{
+			Extra: fmt.Sprintf("/%s: grpc.tcp_address: %s", configPath, cfg.GRPC.TCPAddress),
+		}))
+	}
+	if insecure := insecureRegistries(cfg); len(insecure) > 0 {
+		findings = append(findings, insecureRegistriesFinding(&inventory.GenericFindingTargetDetails{
+			Extra: fmt.Sprintf("/%s: insecure registries: %s", configPath, strings.Join(insecure, ", ")),
+		}))
+	}
+
+	return inventory.Finding{GenericFindings: findings}, nil
+}
+
+// insecureRegistries returns the sorted list of registries that are accessed over plain HTTP or
+// without TLS verification.
+func insecureRegistries(cfg config) []string {
+	var insecure []string
+	for _, name := range criPlugins {
+		registry := cfg.Plugins[name].Registry
+		for host, mirror := range registry.Mirrors {
+			for _, endpoint := range mirror.Endpoint {
+				if strings.HasPrefix(strings.ToLower(endpoint), "http://") {
+					insecure = append(insecure, host)
+					break
+				}
+			}
+		}
+		for host, c := range registry.Configs {
+			if c.TLS.InsecureSkipVerify {
+				insecure = append(insecure, host)
+			}
+		}
+	}
+	slices.Sort(insecure)
+	return slices.Compact(insecure)
+}
@@ -0,0 +1,129 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package containerdconfig_test
+
+import (
+	"context"
+	"testing"
+	"testing/fstest"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/osv-scalibr/detector/misconfig/containerdconfig"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/packageindex"
+)
+
+func TestScanFS(t *testing.T) {
+	det := containerdconfig.Detector{}
+	advs := det.DetectedFinding().GenericFindings
+	socketAdv, registriesAdv := advs[0].Adv, advs[1].Adv
+
+	px, _ := packageindex.New([]*extractor.Package{})
+	testCases := []struct {
+		desc         string
+		config       string
+		wantFindings []*inventory.GenericFinding
+		wantErr      bool
+	}{
+		{
+			desc:         "no_config",
+			wantFindings: nil,
+		},
+		{
+			desc: "secure_config",
+			config: `
+version = 2
+
+[grpc]
+  address = "/run/containerd/containerd.sock"
+  tcp_address = "0.0.0.0:10010"
+  tcp_tls_ca = "/etc/containerd/ca.pem"
+  tcp_tls_cert = "/etc/containerd/cert.pem"
+  tcp_tls_key = "/etc/containerd/key.pem"
+
+[plugins."io.containerd.grpc.v1.cri".registry.mirrors."docker.io"]
+  endpoint = ["https://mirror.gcr.io"]
+`,
+			wantFindings: nil,
+		},
+		{
+			desc: "insecure_v2_config",
+			config: `
+version = 2
+
+[grpc]
+  tcp_address = "0.0.0.0:10010"
+
+[plugins."io.containerd.grpc.v1.cri".registry.mirrors."docker.io"]
+  endpoint = ["https://mirror.gcr.io"]
+[plugins."io.containerd.grpc.v1.cri".registry.mirrors."registry.local:5000"]
+  endpoint = ["http://registry.local:5000"]
+[plugins."io.containerd.grpc.v1.cri".registry.configs."gcr.io".tls]
+  insecure_skip_verify = true
+`,
+			wantFindings: []*inventory.GenericFinding{
+				{
+					Adv: socketAdv,
+					Target: &inventory.GenericFindingTargetDetails{
+						Extra: "/etc/containerd/config.toml: grpc.tcp_address: 0.0.0.0:10010",
+					},
+				},
+				{
+					Adv: registriesAdv,
+					Target: &inventory.GenericFindingTargetDetails{
+						Extra: "/etc/containerd/config.toml: insecure registries: gcr.io, registry.local:5000",
+					},
+				},
+			},
+		},
+		{
+			desc: "insecure_v3_config",
+			config: `
+version = 3
+
+[plugins."io.containerd.cri.v1.images".registry.configs."registry.local".tls]
+  insecure_skip_verify = true
+`,
+			wantFindings: []*inventory.GenericFinding{{
+				Adv: registriesAdv,
+				Target: &inventory.GenericFindingTargetDetails{
+					Extra: "/etc/containerd/config.toml: insecure registries: registry.local",
+				},
+			}},
+		},
+		{
+			desc:    "invalid_toml",
+			config:  `[grpc`,
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			fsys := fstest.MapFS{}
+			if tc.config != "" {
+				fsys["etc/containerd/config.toml"] = &fstest.MapFile{Data: []byte(tc.config)}
+			}
+			got, err := det.ScanFS(context.Background(), fsys, px)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("ScanFS(%s) error: %v, want error: %v", tc.desc, err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.wantFindings, got.GenericFindings); diff != "" {
+				t.Errorf("ScanFS(%s): unexpected findings (-want +got):\n%s", tc.desc, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,167 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package containerspolicy implements a detector for disabled image signature
+// verification in /etc/containers/policy.json, the trust policy used by
+// Podman, CRI-O, Buildah and Skopeo.
+package containerspolicy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/google/osv-scalibr/detector"
+	scalibrfs "github.com/google/osv-scalibr/fs"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/packageindex"
+	"github.com/google/osv-scalibr/plugin"
+)
+
+const (
+	// Name of the detector.
+	Name = "misconfig/containerspolicy"
+
+	policyPath = "etc/containers/policy.json"
+
+	// The requirement type that accepts any image, signed or not.
+	insecureAcceptAnything = "insecureAcceptAnything"
+	// The transport used to pull images from registries.
+	dockerTransport = "docker"
+)
+
+// policy is the containers-policy.json format.
+// See https://github.com/containers/image/blob/main/docs/containers-policy.json.5.md
+type policy struct {
+	Default    []requirement                       `json:"default"`
+	Transports map[string]map[string][]requirement `json:"transports"`
+}
+
+type requirement struct {
+	Type string `json:"type"`
+}
+
+// Detector is a SCALIBR Detector for disabled container image signature verification.
+type Detector struct{}
+
+// New returns a detector.
+func New() detector.Detector {
+	return &Detector{}
+}
+
+// Name of the detector.
+func (Detector) Name() string { return Name }
+
+// Version of the detector.
+func (Detector) Version() int { return 0 }
+
+// Requirements of the detector.
+func (Detector) Requirements() *plugin.Capabilities {
+	return &plugin.Capabilities{OS: plugin.OSLinux}
+}
+
+// RequiredExtractors returns nothing (no deps).
+func (Detector) RequiredExtractors() []string { return []string{} }
+
+// DetectedFinding returns generic vulnerability information about what is detected.
+func (d Detector) DetectedFinding() inventory.Finding {
+	return inventory.Finding{GenericFindings: []*inventory.GenericFinding{
+		signatureVerificationFinding(nil),
+	}}
+}
+
+func signatureVerificationFinding(target *inventory.GenericFindingTargetDetails) *inventory.GenericFinding {
+	return &inventory.GenericFinding{
+		Adv: &inventory.GenericFindingAdvisory{
+			ID: &inventory.AdvisoryID{
+				Publisher: "SCALIBR",
+				Reference: "containers-signature-verification-disabled",
+			},
+			Title: "Container image signature verification is disabled",
+			Description: "The containers trust policy accepts unsigned images from registries. " +
+				"Podman, CRI-O and other tools using the policy run images without checking " +
+				"who published them, so a compromised registry or mirror can serve tampered images.",
+			Recommendation: "Require signatures for the registries you pull from in " +
+				"/etc/containers/policy.json, e.g. with \"sigstoreSigned\" or \"signedBy\" " +
+				"requirements, and set \"default\" to [{\"type\": \"reject\"}].",
+			Sev: inventory.SeverityLow,
+		},
+		Target: target,
+	}
+}
+
+// Scan checks the containers trust policy of the host.
+func (d Detector) Scan(ctx context.Context, scanRoot *scalibrfs.ScanRoot, px *packageindex.PackageIndex) (inventory.Finding, error) {
+	return d.ScanFS(ctx, scanRoot.FS, px)
+}
+
+// ScanFS checks the containers trust policy in the given filesystem. Hosts
+// without a policy.json are skipped.
+func (d Detector) ScanFS(ctx context.Context, fsys fs.FS, px *packageindex.PackageIndex) (inventory.Finding, error) {
+	data, err := fs.ReadFile(fsys, policyPath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			// No container tools using the policy are installed.
+			return inventory.Finding{}, nil
+		}
+		return inventory.Finding{}, err
+	}
+
+	var p policy
+	if err := json.Unmarshal(data, &p); err != nil {
+		return inventory.Finding{}, fmt.Errorf("failed to parse %s: %w", policyPath, err)
+	}
+
+	var scopes []string
+	registryScopes := p.Transports[dockerTransport]
+	// The transport-wide "" scope overrides the default for registry pulls.
+	if _, ok := registryScopes[""]; !ok && acceptsAnything(p.Default) {
+		scopes = append(scopes, "default")
+	}
+	for scope, reqs := range registryScopes {
+		if acceptsAnything(reqs) {
+			scopes = append(scopes, fmt.Sprintf("transports.%s[%q]", dockerTransport, scope))
+		}
+	}
+	if len(scopes) == 0 {
+		return inventory.Finding{}, nil
+	}
+	slices.Sort(scopes)
+
+	return inventory.Finding{GenericFindings: []*inventory.GenericFinding{
+		signatureVerificationFinding(&inventory.GenericFindingTargetDetails{
+			Extra: fmt.Sprintf("/%s: %s", policyPath, strings.Join(scopes, ", ")),
+		}),
+	}}, nil
+}
+
+// acceptsAnything returns whether the requirements accept unsigned images.
+// All requirements of a scope need to be satisfied, so a single signature
+// requirement is enough to enforce verification.
+func acceptsAnything(reqs []requirement) bool {
+	if len(reqs) == 0 {
+		return false
+	}
+	for _, r := range reqs {
+		if r.Type != insecureAcceptAnything {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,121 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package containerspolicy_test
+
+import (
+	"context"
+	"testing"
+	"testing/fstest"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/osv-scalibr/detector/misconfig/containerspolicy"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/packageindex"
+)
+
+func TestScanFS(t *testing.T) {
+	det := containerspolicy.Detector{}
+	adv := det.DetectedFinding().GenericFindings[0].Adv
+
+	px, _ := packageindex.New([]*extractor.Package{})
+	testCases := []struct {
+		desc         string
+		policy       string
+		wantFindings []*inventory.GenericFinding
+		wantErr      bool
+	}{
+		{
+			desc:         "no_policy",
+			wantFindings: nil,
+		},
+		{
+			desc: "distro_default_policy",
+			policy: `{
+				"default": [{"type": "insecureAcceptAnything"}],
+				"transports": {"docker-daemon": {"": [{"type": "insecureAcceptAnything"}]}}
+			}`,
+			wantFindings: []*inventory.GenericFinding{{
+				Adv:    adv,
+				Target: &inventory.GenericFindingTargetDetails{Extra: "/etc/containers/policy.json: default"},
+			}},
+		},
+		{
+			desc: "signatures_required",
+			policy: `{
+				"default": [{"type": "reject"}],
+				"transports": {"docker": {
+					"registry.example.com": [{"type": "sigstoreSigned", "keyPath": "/etc/pki/key.pub"}]
+				}}
+			}`,
+			wantFindings: nil,
+		},
+		{
+			desc: "transport_wide_scope_overrides_default",
+			policy: `{
+				"default": [{"type": "insecureAcceptAnything"}],
+				"transports": {"docker": {"": [{"type": "reject"}]}}
+			}`,
+			wantFindings: nil,
+		},
+		{
+			desc: "signature_and_accept_anything",
+			policy: `{
+				"default": [{"type": "reject"}],
+				"transports": {"docker": {
+					"quay.io": [{"type": "insecureAcceptAnything"}, {"type": "signedBy", "keyType": "GPGKeys"}]
+				}}
+			}`,
+			wantFindings: nil,
+		},
+		{
+			desc: "unsigned_registries",
+			policy: `{
+				"default": [{"type": "insecureAcceptAnything"}],
+				"transports": {"docker": {
+					"docker.io": [{"type": "insecureAcceptAnything"}],
+					"registry.example.com": [{"type": "signedBy", "keyType": "GPGKeys"}]
+				}}
+			}`,
+			wantFindings: []*inventory.GenericFinding{{
+				Adv: adv,
+				Target: &inventory.GenericFindingTargetDetails{
+					Extra: `/etc/containers/policy.json: default, transports.docker["docker.io"]`,
+				},
+			}},
+		},
+		{
+			desc:    "invalid_json",
+			policy:  `{"default": `,
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			fsys := fstest.MapFS{}
+			if tc.policy != "" {
+				fsys["etc/containers/policy.json"] = &fstest.MapFile{Data: []byte(tc.policy)}
+			}
+			got, err := det.ScanFS(context.Background(), fsys, px)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("ScanFS(%s) error: %v, want error: %v", tc.desc, err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.wantFindings, got.GenericFindings); diff != "" {
+				t.Errorf("ScanFS(%s): unexpected findings (-want +got):\n%s", tc.desc, diff)
+			}
+		})
+	}
+}
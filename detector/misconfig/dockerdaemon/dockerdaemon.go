@@ -0,0 +1,222 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package dockerdaemon implements a detector for insecure Docker daemon configurations
+// set in /etc/docker/daemon.json or passed to dockerd as command line flags.
+package dockerdaemon
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+
+	"github.com/google/osv-scalibr/detector"
+	scalibrfs "github.com/google/osv-scalibr/fs"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/packageindex"
+	"github.com/google/osv-scalibr/plugin"
+)
+
+const (
+	// Name of the detector.
+	Name = "misconfig/dockerdaemon"
+
+	daemonConfigPath = "etc/docker/daemon.json"
+)
+
+// daemonConfig contains the security relevant fields of the Docker daemon.json config.
+// See https://docs.docker.com/reference/cli/dockerd/#daemon-configuration-file
+type daemonConfig struct {
+	Hosts              []string `json:"hosts"`
+	TLSVerify          bool     `json:"tlsverify"`
+	InsecureRegistries []string `json:"insecure-registries"`
+	UsernsRemap        string   `json:"userns-remap"`
+}
+
+// Detector is a SCALIBR Detector for insecure Docker daemon configurations.
+type Detector struct{}
+
+// New returns a detector.
+func New() detector.Detector {
+	return &Detector{}
+}
+
+// Name of the detector.
+func (Detector) Name() string { return Name }
+
+// Version of the detector.
+func (Detector) Version() int { return 0 }
+
+// Requirements of the detector.
+func (Detector) Requirements() *plugin.Capabilities {
+	return &plugin.Capabilities{OS: plugin.OSLinux}
+}
+
+// RequiredExtractors returns nothing (no deps).
+func (Detector) RequiredExtractors() []string { return []string{} }
+
+// DetectedFinding returns generic vulnerability information about what is detected.
+func (d Detector) DetectedFinding() inventory.Finding {
+	return inventory.Finding{GenericFindings: []*inventory.GenericFinding{
+		unauthenticatedSocketFinding(nil),
+		insecureRegistriesFinding(nil),
+		usernsRemapFinding(nil),
+	}}
+}
+
+func unauthenticatedSocketFinding(target *inventory.GenericFindingTargetDetails) *inventory.GenericFinding {
+	return &inventory.GenericFinding{
+		Adv: &inventory.GenericFindingAdvisory{
+			ID: &inventory.AdvisoryID{
+				Publisher: "SCALIBR",
+				Reference: "docker-unauthenticated-tcp-socket",
+			},
+			Title: "Docker daemon exposes an unauthenticated TCP socket",
+			Description: "The Docker daemon listens on a TCP socket without TLS client " +
+				"certificate verification. Anyone who can reach the socket can control the " +
+				"daemon and thus gain root access to the host.",
+			Recommendation: "Remove the tcp:// entries from \"hosts\" in /etc/docker/daemon.json " +
+				"and the -H flags of dockerd, or set \"tlsverify\": true and configure " +
+				"\"tlscacert\", \"tlscert\" and \"tlskey\".",
+			Sev: inventory.SeverityCritical,
+		},
+		Target: target,
+	}
+}
+
+func insecureRegistriesFinding(target *inventory.GenericFindingTargetDetails) *inventory.GenericFinding {
+	return &inventory.GenericFinding{
+		Adv: &inventory.GenericFindingAdvisory{
+			ID: &inventory.AdvisoryID{
+				Publisher: "SCALIBR",
+				Reference: "docker-insecure-registries",
+			},
+			Title: "Docker daemon allows insecure registries",
+			Description: "The Docker daemon is configured to pull images from registries over " +
+				"plain HTTP or without verifying their TLS certificates. An attacker in a " +
+				"privileged network position can tamper with the pulled images.",
+			Recommendation: "Remove the \"insecure-registries\" entry from /etc/docker/daemon.json " +
+				"and the --insecure-registry flags of dockerd, and serve the registries over HTTPS with a trusted certificate.",
+			Sev: inventory.SeverityMedium,
+		},
+		Target: target,
+	}
+}
+
+func usernsRemapFinding(target *inventory.GenericFindingTargetDetails) *inventory.GenericFinding {
+	return &inventory.GenericFinding{
+		Adv: &inventory.GenericFindingAdvisory{
+			ID: &inventory.AdvisoryID{
+				Publisher: "SCALIBR",
+				Reference: "docker-userns-remap-disabled",
+			},
+			Title: "Docker daemon user namespace remapping is disabled",
+			Description: "Containers run without user namespace remapping, so root inside a " +
+				"container is root on the host. This increases the impact of container escapes.",
+			Recommendation: "Set \"userns-remap\": \"default\" in /etc/docker/daemon.json and " +
+				"restart the Docker daemon.",
+			Sev: inventory.SeverityLow,
+		},
+		Target: target,
+	}
+}
+
+// Scan checks the Docker daemon config of the host for insecure settings.
+func (d Detector) Scan(ctx context.Context, scanRoot *scalibrfs.ScanRoot, px *packageindex.PackageIndex) (inventory.Finding, error) {
+	return d.ScanFS(ctx, scanRoot.FS, px)
+}
+
+// daemonOptions are the security relevant daemon settings read from a single
+// config source, i.e. daemon.json or a file with dockerd command line flags.
+type daemonOptions struct {
+	path               string
+	hosts              []string
+	insecureRegistries []string
+	tlsVerify          bool
+	usernsRemap        string
+}
+
+// ScanFS checks the Docker daemon config in the given filesystem for insecure settings.
+// The settings are read from daemon.json and from the dockerd flags in the
+// systemd unit and /etc/default/docker. Hosts without any of them are skipped.
+func (d Detector) ScanFS(ctx context.Context, fsys fs.FS, px *packageindex.PackageIndex) (inventory.Finding, error) {
+	var opts []*daemonOptions
+	data, err := fs.ReadFile(fsys, daemonConfigPath)
+	hasDaemonConfig := err == nil
+	switch {
+	case err == nil:
+		var cfg daemonConfig
+		if err := json.Unmarshal(data, &cfg); err != nil {
+			return inventory.Finding{}, fmt.Errorf("failed to parse %s: %w", daemonConfigPath, err)
+		}
+		opts = append(opts, &daemonOptions{
+			path:               daemonConfigPath,
+			hosts:              cfg.Hosts,
+			insecureRegistries: cfg.InsecureRegistries,
+			tlsVerify:          cfg.TLSVerify,
+			usernsRemap:        cfg.UsernsRemap,
+		})
+	case !errors.Is(err, os.ErrNotExist):
+		return inventory.Finding{}, err
+	}
+	flagOpts, err := flagOptions(fsys)
+	if err != nil {
+		return inventory.Finding{}, err
+	}
+	opts = append(opts, flagOpts...)
+
+	// TLS verification and user namespaces apply to the whole daemon, no matter
+	// where they're configured.
+	tlsVerify := false
+	usernsRemap := false
+	for _, o := range opts {
+		tlsVerify = tlsVerify || o.tlsVerify
+		usernsRemap = usernsRemap || o.usernsRemap != ""
+	}
+
+	var findings []*inventory.GenericFinding
+	for _, o := range opts {
+		if !tlsVerify {
+			var tcpHosts []string
+			for _, h := range o.hosts {
+				if strings.HasPrefix(strings.ToLower(h), "tcp://") {
+					tcpHosts = append(tcpHosts, h)
+				}
+			}
+			if len(tcpHosts) > 0 {
+				findings = append(findings, unauthenticatedSocketFinding(target(o.path, "hosts", tcpHosts)))
+			}
+		}
+		if len(o.insecureRegistries) > 0 {
+			findings = append(findings, insecureRegistriesFinding(target(o.path, "insecure-registries", o.insecureRegistries)))
+		}
+	}
+	if hasDaemonConfig && !usernsRemap {
+		findings = append(findings, usernsRemapFinding(&inventory.GenericFindingTargetDetails{
+			Extra: "/" + daemonConfigPath + ": userns-remap not set",
+		}))
+	}
+
+	return inventory.Finding{GenericFindings: findings}, nil
+}
+
+func target(path string, field string, values []string) *inventory.GenericFindingTargetDetails {
+	return &inventory.GenericFindingTargetDetails{
+		Extra: fmt.Sprintf("/%s: %s: %s", path, field, strings.Join(values, ", ")),
+	}
+}
@@ -0,0 +1,203 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dockerdaemon_test
+
+import (
+	"context"
+	"testing"
+	"testing/fstest"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/osv-scalibr/detector/misconfig/dockerdaemon"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/packageindex"
+)
+
+func TestScanFS(t *testing.T) {
+	det := dockerdaemon.Detector{}
+	advs := det.DetectedFinding().GenericFindings
+	socketAdv, registriesAdv, usernsAdv := advs[0].Adv, advs[1].Adv, advs[2].Adv
+
+	px, _ := packageindex.New([]*extractor.Package{})
+	testCases := []struct {
+		desc         string
+		config       string
+		wantFindings []*inventory.GenericFinding
+		wantErr      bool
+	}{
+		{
+			desc:         "no_daemon_config",
+			wantFindings: nil,
+		},
+		{
+			desc: "secure_config",
+			config: `{
+				"hosts": ["unix:///var/run/docker.sock", "tcp://0.0.0.0:2376"],
+				"tlsverify": true,
+				"userns-remap": "default"
+			}`,
+			wantFindings: nil,
+		},
+		{
+			desc:   "userns_remap_not_set",
+			config: `{}`,
+			wantFindings: []*inventory.GenericFinding{{
+				Adv: usernsAdv,
+				Target: &inventory.GenericFindingTargetDetails{
+					Extra: "/etc/docker/daemon.json: userns-remap not set",
+				},
+			}},
+		},
+		{
+			desc: "insecure_config",
+			config: `{
+				"hosts": ["unix:///var/run/docker.sock", "tcp://0.0.0.0:2375"],
+				"insecure-registries": ["registry.local:5000", "10.0.0.0/8"]
+			}`,
+			wantFindings: []*inventory.GenericFinding{
+				{
+					Adv: socketAdv,
+					Target: &inventory.GenericFindingTargetDetails{
+						Extra: "/etc/docker/daemon.json: hosts: tcp://0.0.0.0:2375",
+					},
+				},
+				{
+					Adv: registriesAdv,
+					Target: &inventory.GenericFindingTargetDetails{
+						Extra: "/etc/docker/daemon.json: insecure-registries: registry.local:5000, 10.0.0.0/8",
+					},
+				},
+				{
+					Adv: usernsAdv,
+					Target: &inventory.GenericFindingTargetDetails{
+						Extra: "/etc/docker/daemon.json: userns-remap not set",
+					},
+				},
+			},
+		},
+		{
+			desc:    "invalid_json",
+			config:  `{"hosts": `,
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			fsys := fstest.MapFS{}
+			if tc.config != "" {
+				fsys["etc/docker/daemon.json"] = &fstest.MapFile{Data: []byte(tc.config)}
+			}
+			got, err := det.ScanFS(context.Background(), fsys, px)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("ScanFS(%s) error: %v, want error: %v", tc.desc, err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.wantFindings, got.GenericFindings); diff != "" {
+				t.Errorf("ScanFS(%s): unexpected findings (-want +got):\n%s", tc.desc, diff)
+			}
+		})
+	}
+}
+
+func TestScanFS_Flags(t *testing.T) {
+	det := dockerdaemon.Detector{}
+	advs := det.DetectedFinding().GenericFindings
+	socketAdv, registriesAdv := advs[0].Adv, advs[1].Adv
+
+	px, _ := packageindex.New([]*extractor.Package{})
+	testCases := []struct {
+		desc         string
+		files        map[string]string
+		wantFindings []*inventory.GenericFinding
+	}{
+		{
+			desc: "default_unit",
+			files: map[string]string{
+				"lib/systemd/system/docker.service": "[Service]\nExecStart=/usr/bin/dockerd -H fd:// --containerd=/run/containerd/containerd.sock\n",
+			},
+			wantFindings: nil,
+		},
+		{
+			desc: "tcp_host_in_unit",
+			files: map[string]string{
+				"lib/systemd/system/docker.service": "[Service]\nExecStart=/usr/bin/dockerd -H fd:// \\\n  -H tcp://0.0.0.0:2375\n",
+			},
+			wantFindings: []*inventory.GenericFinding{{
+				Adv: socketAdv,
+				Target: &inventory.GenericFindingTargetDetails{
+					Extra: "/lib/systemd/system/docker.service: hosts: tcp://0.0.0.0:2375",
+				},
+			}},
+		},
+		{
+			desc: "drop_in_replaces_unit_command",
+			files: map[string]string{
+				"lib/systemd/system/docker.service":                 "[Service]\nExecStart=/usr/bin/dockerd -H tcp://0.0.0.0:2375\n",
+				"etc/systemd/system/docker.service.d/override.conf": "[Service]\nExecStart=\nExecStart=/usr/bin/dockerd --host=tcp://127.0.0.1:2375 --insecure-registry registry.local:5000\n",
+			},
+			wantFindings: []*inventory.GenericFinding{
+				{
+					Adv: socketAdv,
+					Target: &inventory.GenericFindingTargetDetails{
+						Extra: "/etc/systemd/system/docker.service.d/override.conf: hosts: tcp://127.0.0.1:2375",
+					},
+				},
+				{
+					Adv: registriesAdv,
+					Target: &inventory.GenericFindingTargetDetails{
+						Extra: "/etc/systemd/system/docker.service.d/override.conf: insecure-registries: registry.local:5000",
+					},
+				},
+			},
+		},
+		{
+			desc: "tls_verify_in_daemon_config",
+			files: map[string]string{
+				"etc/docker/daemon.json": `{"tlsverify": true, "userns-remap": "default"}`,
+				"etc/default/docker":     `DOCKER_OPTS="-H tcp://0.0.0.0:2376"`,
+			},
+			wantFindings: nil,
+		},
+		{
+			desc: "defaults_file",
+			files: map[string]string{
+				"etc/default/docker": "# Use DOCKER_OPTS to modify the daemon startup options.\nDOCKER_OPTS=\"-H=tcp://0.0.0.0:2375 --userns-remap=default\"\n",
+			},
+			wantFindings: []*inventory.GenericFinding{{
+				Adv: socketAdv,
+				Target: &inventory.GenericFindingTargetDetails{
+					Extra: "/etc/default/docker: hosts: tcp://0.0.0.0:2375",
+				},
+			}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			fsys := fstest.MapFS{}
+			for path, content := range tc.files {
+				fsys[path] = &fstest.MapFile{Data: []byte(content)}
+			}
+			got, err := det.ScanFS(context.Background(), fsys, px)
+			if err != nil {
+				t.Fatalf("ScanFS(%s): %v", tc.desc, err)
+			}
+			if diff := cmp.Diff(tc.wantFindings, got.GenericFindings); diff != "" {
+				t.Errorf("ScanFS(%s): unexpected findings (-want +got):\n%s", tc.desc, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,162 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dockerdaemon
+
+import (
+	"bufio"
+	"errors"
+	"io/fs"
+	"os"
+	"strings"
+)
+
+// unitPaths are the systemd unit files that can start dockerd, in the order
+// of their precedence.
+var unitPaths = []string{
+	"etc/systemd/system/docker.service",
+	"usr/lib/systemd/system/docker.service",
+	"lib/systemd/system/docker.service",
+}
+
+const (
+	dropInGlob = "etc/systemd/system/docker.service.d/*.conf"
+	// Used by the SysV init scripts of Debian based distros.
+	defaultsPath = "etc/default/docker"
+)
+
+// flagOptions returns the daemon settings passed to dockerd as command line
+// flags in the systemd units and /etc/default/docker.
+func flagOptions(fsys fs.FS) ([]*daemonOptions, error) {
+	var result []*daemonOptions
+
+	dropIns, err := fs.Glob(fsys, dropInGlob)
+	if err != nil {
+		return nil, err
+	}
+	var dropInOpts []*daemonOptions
+	execStartReset := false
+	for _, path := range dropIns {
+		cmds, reset, err := readAssignments(fsys, path, "ExecStart")
+		if err != nil {
+			return nil, err
+		}
+		execStartReset = execStartReset || reset
+		for _, args := range cmds {
+			dropInOpts = append(dropInOpts, parseFlags(path, args))
+		}
+	}
+
+	// An empty ExecStart= in a drop-in replaces the command line of the unit.
+	if !execStartReset {
+		for _, path := range unitPaths {
+			cmds, _, err := readAssignments(fsys, path, "ExecStart")
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			for _, args := range cmds {
+				result = append(result, parseFlags(path, args))
+			}
+			break
+		}
+	}
+	result = append(result, dropInOpts...)
+
+	opts, _, err := readAssignments(fsys, defaultsPath, "DOCKER_OPTS")
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	for _, args := range opts {
+		result = append(result, parseFlags(defaultsPath, args))
+	}
+	return result, nil
+}
+
+// readAssignments returns the whitespace separated values of all "key=value"
+// lines in the given file, and whether the key was reset with an empty value.
+// Lines can be continued with a trailing backslash.
+func readAssignments(fsys fs.FS, path string, key string) (values [][]string, reset bool, err error) {
+	f, err := fsys.Open(path)
+	if err != nil {
+		return nil, false, err
+	}
+	defer f.Close()
+
+	s := bufio.NewScanner(f)
+	var line strings.Builder
+	for s.Scan() {
+		l := strings.TrimSpace(s.Text())
+		if cont, ok := strings.CutSuffix(l, `\`); ok {
+			line.WriteString(cont + " ")
+			continue
+		}
+		line.WriteString(l)
+		l = line.String()
+		line.Reset()
+
+		if strings.HasPrefix(l, "#") {
+			continue
+		}
+		l = strings.TrimPrefix(l, "export ")
+		k, v, ok := strings.Cut(l, "=")
+		if !ok || strings.TrimSpace(k) != key {
+			continue
+		}
+		v = strings.Trim(strings.TrimSpace(v), `"'`)
+		if v == "" {
+			reset = true
+			values = nil
+			continue
+		}
+		var fields []string
+		for _, f := range strings.Fields(v) {
+			fields = append(fields, strings.Trim(f, `"'`))
+		}
+		values = append(values, fields)
+	}
+	return values, reset, s.Err()
+}
+
+// parseFlags returns the daemon settings set by the given dockerd arguments.
+// See https://docs.docker.com/reference/cli/dockerd/
+func parseFlags(path string, args []string) *daemonOptions {
+	o := &daemonOptions{path: path}
+	for i := 0; i < len(args); i++ {
+		name, value, hasValue := strings.Cut(args[i], "=")
+		switch name {
+		case "-H", "--host", "--insecure-registry", "--userns-remap":
+			if !hasValue {
+				if i+1 == len(args) {
+					continue
+				}
+				i++
+				value = args[i]
+			}
+		}
+		switch name {
+		case "-H", "--host":
+			o.hosts = append(o.hosts, value)
+		case "--insecure-registry":
+			o.insecureRegistries = append(o.insecureRegistries, value)
+		case "--tlsverify":
+			o.tlsVerify = !hasValue || value == "true"
+		case "--userns-remap":
+			o.usernsRemap = value
+		}
+	}
+	return o
+}
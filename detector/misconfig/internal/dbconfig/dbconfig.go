@@ -0,0 +1,138 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package dbconfig contains helpers for the detectors that check the configs
+// and init scripts of database services for weak credentials and network
+// exposure.
+package dbconfig
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/google/osv-scalibr/inventory"
+)
+
+// InitScriptsDir is the directory with the init scripts run by the official
+// database container images on first startup.
+const InitScriptsDir = "docker-entrypoint-initdb.d"
+
+// defaultPasswords are empty, example and well-known default passwords of
+// database services and their container images.
+var defaultPasswords = []string{
+	"",
+	"123456",
+	"admin",
+	"changeit",
+	"changeme",
+	"example",
+	"foobared",
+	"mongo",
+	"mongodb",
+	"my-secret-pw",
+	"mypassword",
+	"mysecretpassword",
+	"mysql",
+	"pass",
+	"password",
+	"postgres",
+	"redis",
+	"root",
+	"secret",
+	"test",
+	"toor",
+}
+
+// IsWeakPassword returns whether the password is empty, a well-known default
+// password or the same as the user name.
+func IsWeakPassword(user, password string) bool {
+	p := strings.ToLower(password)
+	if user != "" && p == strings.ToLower(user) {
+		return true
+	}
+	return slices.Contains(defaultPasswords, p)
+}
+
+// IsAllInterfaces returns whether the listen address binds to all network
+// interfaces of the host.
+func IsAllInterfaces(addr string) bool {
+	switch strings.Trim(strings.TrimSpace(addr), `"'`) {
+	case "*", "0.0.0.0", "::", "[::]", "::0", "0:0:0:0:0:0:0:0":
+		return true
+	}
+	return false
+}
+
+// File is a config file or init script read from the scanned filesystem.
+type File struct {
+	Path string
+	Data []byte
+}
+
+// ReadFiles reads the regular files matching the glob patterns. Files that
+// match several patterns are only returned once.
+func ReadFiles(fsys fs.FS, patterns ...string) ([]*File, error) {
+	var files []*File
+	seen := make(map[string]bool)
+	for _, pattern := range patterns {
+		matches, err := fs.Glob(fsys, pattern)
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range matches {
+			if seen[p] {
+				continue
+			}
+			seen[p] = true
+			info, err := fs.Stat(fsys, p)
+			if err != nil {
+				if errors.Is(err, os.ErrNotExist) {
+					continue
+				}
+				return nil, err
+			}
+			if !info.Mode().IsRegular() {
+				continue
+			}
+			data, err := fs.ReadFile(fsys, p)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read %s: %w", p, err)
+			}
+			files = append(files, &File{Path: p, Data: data})
+		}
+	}
+	return files, nil
+}
+
+// InitScriptPatterns returns the glob patterns of the container image init
+// scripts with the given file extensions.
+func InitScriptPatterns(exts ...string) []string {
+	patterns := make([]string, 0, len(exts))
+	for _, ext := range exts {
+		patterns = append(patterns, path.Join(InitScriptsDir, "*"+ext))
+	}
+	return patterns
+}
+
+// Target returns the finding target for an insecure setting in a config file.
+func Target(path string, format string, args ...any) *inventory.GenericFindingTargetDetails {
+	return &inventory.GenericFindingTargetDetails{
+		Extra: fmt.Sprintf("/%s: %s", path, fmt.Sprintf(format, args...)),
+	}
+}
@@ -0,0 +1,61 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbconfig_test
+
+import (
+	"testing"
+
+	"github.com/google/osv-scalibr/detector/misconfig/internal/dbconfig"
+)
+
+func TestIsWeakPassword(t *testing.T) {
+	testCases := []struct {
+		user     string
+		password string
+		want     bool
+	}{
+		{user: "root", password: "", want: true},
+		{user: "root", password: "Password", want: true},
+		{user: "app", password: "App", want: true},
+		{user: "", password: "foobared", want: true},
+		{user: "", password: "", want: true},
+		{user: "app", password: "Zq8#mV2!kP9xLw4t", want: false},
+	}
+	for _, tc := range testCases {
+		if got := dbconfig.IsWeakPassword(tc.user, tc.password); got != tc.want {
+			t.Errorf("IsWeakPassword(%q, %q) = %v, want %v", tc.user, tc.password, got, tc.want)
+		}
+	}
+}
+
+func TestIsAllInterfaces(t *testing.T) {
+	testCases := []struct {
+		addr string
+		want bool
+	}{
+		{addr: "0.0.0.0", want: true},
+		{addr: " '*' ", want: true},
+		{addr: "::", want: true},
+		{addr: "[::]", want: true},
+		{addr: "127.0.0.1", want: false},
+		{addr: "10.0.0.5", want: false},
+		{addr: "localhost", want: false},
+	}
+	for _, tc := range testCases {
+		if got := dbconfig.IsAllInterfaces(tc.addr); got != tc.want {
+			t.Errorf("IsAllInterfaces(%q) = %v, want %v", tc.addr, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,280 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package jvmflags implements a detector for dangerous JVM system properties
+// set in startup scripts and service definitions, such as unauthenticated
+// remote JMX, disabled deserialization filters and JNDI lookups that load
+// classes from remote codebases.
+package jvmflags
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/google/osv-scalibr/detector"
+	scalibrfs "github.com/google/osv-scalibr/fs"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/packageindex"
+	"github.com/google/osv-scalibr/plugin"
+)
+
+const (
+	// Name of the detector.
+	Name = "misconfig/jvmflags"
+)
+
+// configGlobs are the files that commonly pass options to the JVM of a service,
+// e.g. through JAVA_OPTS or directly on the java command line.
+var configGlobs = []string{
+	"etc/environment",
+	"etc/default/*",
+	"etc/sysconfig/*",
+	"etc/init.d/*",
+	"etc/systemd/system/*.service",
+	"etc/systemd/system/*.service.d/*.conf",
+	"usr/lib/systemd/system/*.service",
+	"lib/systemd/system/*.service",
+	// Tomcat and other servers that source a setenv.sh on startup.
+	"opt/*/bin/setenv.sh",
+	"usr/share/*/bin/setenv.sh",
+	"usr/local/*/bin/setenv.sh",
+	// Elasticsearch, Cassandra and others read the JVM options from a file.
+	"etc/*/jvm.options",
+	"etc/*/jvm.options.d/*.options",
+}
+
+// e.g. -Dcom.sun.management.jmxremote.authenticate=false
+var propertyRe = regexp.MustCompile(`-D([A-Za-z0-9_.]+)=([^\s"']*)`)
+
+// serialFilterProperties are the properties that configure deserialization
+// filters, see https://docs.oracle.com/en/java/javase/21/core/serialization-filtering1.html
+var serialFilterProperties = []string{
+	"jdk.serialFilter",
+	"sun.rmi.registry.registryFilter",
+	"sun.rmi.transport.dgcFilter",
+}
+
+// remoteCodebaseProperties are the properties that allow JNDI and RMI to load
+// classes from a remote codebase if set to the given value.
+var remoteCodebaseProperties = map[string]string{
+	"com.sun.jndi.rmi.object.trustURLCodebase":       "true",
+	"com.sun.jndi.ldap.object.trustURLCodebase":      "true",
+	"com.sun.jndi.cosnaming.object.trustURLCodebase": "true",
+	"java.rmi.server.useCodebaseOnly":                "false",
+}
+
+// Detector is a SCALIBR Detector for dangerous JVM flags.
+type Detector struct{}
+
+// New returns a detector.
+func New() detector.Detector {
+	return &Detector{}
+}
+
+// Name of the detector.
+func (Detector) Name() string { return Name }
+
+// Version of the detector.
+func (Detector) Version() int { return 0 }
+
+// Requirements of the detector.
+func (Detector) Requirements() *plugin.Capabilities {
+	return &plugin.Capabilities{OS: plugin.OSLinux}
+}
+
+// RequiredExtractors returns nothing (no deps).
+func (Detector) RequiredExtractors() []string { return []string{} }
+
+// DetectedFinding returns generic vulnerability information about what is detected.
+func (d Detector) DetectedFinding() inventory.Finding {
+	return inventory.Finding{GenericFindings: []*inventory.GenericFinding{
+		unauthenticatedJMXFinding(nil),
+		serialFilterFinding(nil),
+		remoteCodebaseFinding(nil),
+	}}
+}
+
+func unauthenticatedJMXFinding(target *inventory.GenericFindingTargetDetails) *inventory.GenericFinding {
+	return &inventory.GenericFinding{
+		Adv: &inventory.GenericFindingAdvisory{
+			ID: &inventory.AdvisoryID{
+				Publisher: "SCALIBR",
+				Reference: "jvm-unauthenticated-remote-jmx",
+			},
+			Title: "JVM exposes remote JMX without authentication",
+			Description: "The JVM is started with remote JMX enabled and authentication " +
+				"disabled. Anyone who can reach the JMX port can execute arbitrary code in " +
+				"the JVM, e.g. by loading an MLet.",
+			Recommendation: "Remove -Dcom.sun.management.jmxremote.authenticate=false from the " +
+				"JVM options, or bind remote JMX to localhost and configure password and SSL " +
+				"authentication.",
+			Sev: inventory.SeverityCritical,
+		},
+		Target: target,
+	}
+}
+
+func serialFilterFinding(target *inventory.GenericFindingTargetDetails) *inventory.GenericFinding {
+	return &inventory.GenericFinding{
+		Adv: &inventory.GenericFindingAdvisory{
+			ID: &inventory.AdvisoryID{
+				Publisher: "SCALIBR",
+				Reference: "jvm-serialization-filter-disabled",
+			},
+			Title: "JVM deserialization filter allows all classes",
+			Description: "A deserialization filter of the JVM accepts any class. Untrusted " +
+				"serialized data, e.g. received over RMI, can then instantiate gadget classes " +
+				"and lead to remote code execution.",
+			Recommendation: "Remove the catch-all \"*\" pattern from the jdk.serialFilter, " +
+				"sun.rmi.registry.registryFilter and sun.rmi.transport.dgcFilter properties and " +
+				"only allow the classes the application needs to deserialize.",
+			Sev: inventory.SeverityHigh,
+		},
+		Target: target,
+	}
+}
+
+func remoteCodebaseFinding(target *inventory.GenericFindingTargetDetails) *inventory.GenericFinding {
+	return &inventory.GenericFinding{
+		Adv: &inventory.GenericFindingAdvisory{
+			ID: &inventory.AdvisoryID{
+				Publisher: "SCALIBR",
+				Reference: "jvm-jndi-remote-codebase",
+			},
+			Title: "JVM loads classes from remote JNDI and RMI codebases",
+			Description: "The JVM is configured to trust the codebase URLs of JNDI references " +
+				"or RMI objects. An attacker who controls a JNDI lookup, e.g. through Log4Shell, " +
+				"can make the JVM load and execute classes from a server of their choice.",
+			Recommendation: "Remove the trustURLCodebase=true and " +
+				"java.rmi.server.useCodebaseOnly=false properties from the JVM options.",
+			Sev: inventory.SeverityHigh,
+		},
+		Target: target,
+	}
+}
+
+// Scan checks the JVM options of the services of the host for dangerous flags.
+func (d Detector) Scan(ctx context.Context, scanRoot *scalibrfs.ScanRoot, px *packageindex.PackageIndex) (inventory.Finding, error) {
+	return d.ScanFS(ctx, scanRoot.FS, px)
+}
+
+// ScanFS checks the JVM options in the given filesystem for dangerous flags.
+func (d Detector) ScanFS(ctx context.Context, fsys fs.FS, px *packageindex.PackageIndex) (inventory.Finding, error) {
+	var paths []string
+	for _, g := range configGlobs {
+		matches, err := fs.Glob(fsys, g)
+		if err != nil {
+			return inventory.Finding{}, err
+		}
+		paths = append(paths, matches...)
+	}
+	slices.Sort(paths)
+	paths = slices.Compact(paths)
+
+	var findings []*inventory.GenericFinding
+	for _, path := range paths {
+		if err := ctx.Err(); err != nil {
+			return inventory.Finding{}, err
+		}
+		props, err := readProperties(fsys, path)
+		if err != nil {
+			// Skip directories and unreadable files.
+			continue
+		}
+		findings = append(findings, checkProperties(path, props)...)
+	}
+	return inventory.Finding{GenericFindings: findings}, nil
+}
+
+// property is a system property set with -D.
+type property struct {
+	name  string
+	value string
+}
+
+// readProperties returns the system properties set in the given file, in
+// order. Commented out lines are skipped.
+func readProperties(fsys fs.FS, path string) ([]property, error) {
+	f, err := fsys.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var props []property
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		line := strings.TrimSpace(s.Text())
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		for _, m := range propertyRe.FindAllStringSubmatch(line, -1) {
+			props = append(props, property{name: m[1], value: m[2]})
+		}
+	}
+	return props, s.Err()
+}
+
+// checkProperties returns the findings for the dangerous properties set in a
+// file. If a property is set several times, the last value takes effect, as
+// with the java command line.
+func checkProperties(path string, props []property) []*inventory.GenericFinding {
+	values := map[string]string{}
+	var names []string
+	for _, p := range props {
+		if _, ok := values[p.name]; !ok {
+			names = append(names, p.name)
+		}
+		values[p.name] = p.value
+	}
+
+	var findings []*inventory.GenericFinding
+	if strings.EqualFold(values["com.sun.management.jmxremote.authenticate"], "false") {
+		findings = append(findings, unauthenticatedJMXFinding(target(path, "com.sun.management.jmxremote.authenticate", "false")))
+	}
+	for _, name := range names {
+		if slices.Contains(serialFilterProperties, name) && allowsAll(values[name]) {
+			findings = append(findings, serialFilterFinding(target(path, name, values[name])))
+		}
+	}
+	for _, name := range names {
+		if want, ok := remoteCodebaseProperties[name]; ok && strings.EqualFold(values[name], want) {
+			findings = append(findings, remoteCodebaseFinding(target(path, name, values[name])))
+		}
+	}
+	return findings
+}
+
+// allowsAll returns whether the deserialization filter pattern accepts any
+// class. The patterns are matched in order, so a "*" pattern accepts all
+// classes that aren't rejected before it.
+func allowsAll(filter string) bool {
+	for _, p := range strings.Split(filter, ";") {
+		if strings.TrimSpace(p) == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+func target(path string, name string, value string) *inventory.GenericFindingTargetDetails {
+	return &inventory.GenericFindingTargetDetails{
+		Extra: fmt.Sprintf("/%s: -D%s=%s", path, name, value),
+	}
+}
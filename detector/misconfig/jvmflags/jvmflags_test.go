@@ -0,0 +1,126 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jvmflags_test
+
+import (
+	"context"
+	"testing"
+	"testing/fstest"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/osv-scalibr/detector/misconfig/jvmflags"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/packageindex"
+)
+
+func TestScanFS(t *testing.T) {
+	det := jvmflags.Detector{}
+	advs := det.DetectedFinding().GenericFindings
+	jmxAdv, filterAdv, codebaseAdv := advs[0].Adv, advs[1].Adv, advs[2].Adv
+
+	px, _ := packageindex.New([]*extractor.Package{})
+	testCases := []struct {
+		desc         string
+		files        map[string]string
+		wantFindings []*inventory.GenericFinding
+	}{
+		{
+			desc:         "no_config",
+			wantFindings: nil,
+		},
+		{
+			desc: "secure_options",
+			files: map[string]string{
+				"opt/tomcat/bin/setenv.sh": "CATALINA_OPTS=\"-Xmx2g -Dcom.sun.management.jmxremote.authenticate=true -Djdk.serialFilter=java.base/*;!*\"\n",
+			},
+			wantFindings: nil,
+		},
+		{
+			desc: "unauthenticated_jmx_in_unit",
+			files: map[string]string{
+				"etc/systemd/system/app.service": "[Service]\nExecStart=/usr/bin/java -Dcom.sun.management.jmxremote.port=9010 \\\n  -Dcom.sun.management.jmxremote.authenticate=false -jar /opt/app.jar\n",
+			},
+			wantFindings: []*inventory.GenericFinding{{
+				Adv: jmxAdv,
+				Target: &inventory.GenericFindingTargetDetails{
+					Extra: "/etc/systemd/system/app.service: -Dcom.sun.management.jmxremote.authenticate=false",
+				},
+			}},
+		},
+		{
+			desc: "last_value_wins",
+			files: map[string]string{
+				"etc/default/app": "JAVA_OPTS=\"-Dcom.sun.management.jmxremote.authenticate=false\"\nJAVA_OPTS=\"$JAVA_OPTS -Dcom.sun.management.jmxremote.authenticate=true\"\n",
+			},
+			wantFindings: nil,
+		},
+		{
+			desc: "commented_out",
+			files: map[string]string{
+				"etc/default/app": "# JAVA_OPTS=\"-Dcom.sun.jndi.ldap.object.trustURLCodebase=true\"\n",
+			},
+			wantFindings: nil,
+		},
+		{
+			desc: "allow_all_serial_filter",
+			files: map[string]string{
+				"etc/elasticsearch/jvm.options": "-Xms1g\n-Dsun.rmi.registry.registryFilter=maxdepth=5;*\n",
+			},
+			wantFindings: []*inventory.GenericFinding{{
+				Adv: filterAdv,
+				Target: &inventory.GenericFindingTargetDetails{
+					Extra: "/etc/elasticsearch/jvm.options: -Dsun.rmi.registry.registryFilter=maxdepth=5;*",
+				},
+			}},
+		},
+		{
+			desc: "remote_codebases",
+			files: map[string]string{
+				"etc/sysconfig/app": "JAVA_OPTS='-Dcom.sun.jndi.rmi.object.trustURLCodebase=true -Djava.rmi.server.useCodebaseOnly=false'\n",
+			},
+			wantFindings: []*inventory.GenericFinding{
+				{
+					Adv: codebaseAdv,
+					Target: &inventory.GenericFindingTargetDetails{
+						Extra: "/etc/sysconfig/app: -Dcom.sun.jndi.rmi.object.trustURLCodebase=true",
+					},
+				},
+				{
+					Adv: codebaseAdv,
+					Target: &inventory.GenericFindingTargetDetails{
+						Extra: "/etc/sysconfig/app: -Djava.rmi.server.useCodebaseOnly=false",
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			fsys := fstest.MapFS{}
+			for path, content := range tc.files {
+				fsys[path] = &fstest.MapFile{Data: []byte(content)}
+			}
+			got, err := det.ScanFS(context.Background(), fsys, px)
+			if err != nil {
+				t.Fatalf("ScanFS(%s): %v", tc.desc, err)
+			}
+			if diff := cmp.Diff(tc.wantFindings, got.GenericFindings); diff != "" {
+				t.Errorf("ScanFS(%s): unexpected findings (-want +got):\n%s", tc.desc, diff)
+			}
+		})
+	}
+}
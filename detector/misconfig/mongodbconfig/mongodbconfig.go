@@ -0,0 +1,243 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package mongodbconfig implements a detector for weak credentials and
+// insecure network settings in MongoDB configs and init scripts.
+package mongodbconfig
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"strings"
+
+	"github.com/google/osv-scalibr/detector"
+	"github.com/google/osv-scalibr/detector/misconfig/internal/dbconfig"
+	scalibrfs "github.com/google/osv-scalibr/fs"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/packageindex"
+	"github.com/google/osv-scalibr/plugin"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// Name of the detector.
+	Name = "misconfig/mongodbconfig"
+)
+
+// configPatterns are the locations of the mongod configs of the current and
+// legacy Linux distro packages.
+var configPatterns = []string{
+	"etc/mongod.conf",
+	"etc/mongodb.conf",
+}
+
+// Matches the users created by init scripts, e.g.
+// "db.createUser({user: 'app', pwd: 'password', roles: [...]})".
+var reCreateUser = regexp.MustCompile(`\buser\s*:\s*["']([^"']*)["']\s*,\s*pwd\s*:\s*["']([^"']*)["']`)
+
+// config contains the security relevant settings of a mongod config.
+// See https://www.mongodb.com/docs/manual/reference/configuration-options/
+type config struct {
+	Net struct {
+		BindIP    string `yaml:"bindIp"`
+		BindIPAll bool   `yaml:"bindIpAll"`
+	} `yaml:"net"`
+	Security struct {
+		Authorization string `yaml:"authorization"`
+		KeyFile       string `yaml:"keyFile"`
+	} `yaml:"security"`
+}
+
+// Detector is a SCALIBR Detector for weak credentials and insecure network
+// settings of MongoDB servers.
+type Detector struct{}
+
+// New returns a detector.
+func New() detector.Detector {
+	return &Detector{}
+}
+
+// Name of the detector.
+func (Detector) Name() string { return Name }
+
+// Version of the detector.
+func (Detector) Version() int { return 0 }
+
+// Requirements of the detector.
+func (Detector) Requirements() *plugin.Capabilities {
+	return &plugin.Capabilities{OS: plugin.OSLinux}
+}
+
+// RequiredExtractors returns nothing (no deps).
+func (Detector) RequiredExtractors() []string { return []string{} }
+
+// DetectedFinding returns generic vulnerability information about what is detected.
+func (d Detector) DetectedFinding() inventory.Finding {
+	return inventory.Finding{GenericFindings: []*inventory.GenericFinding{
+		weakPasswordFinding(nil),
+		noAuthFinding(nil),
+		bindAllInterfacesFinding(nil),
+	}}
+}
+
+func weakPasswordFinding(target *inventory.GenericFindingTargetDetails) *inventory.GenericFinding {
+	return &inventory.GenericFinding{
+		Adv: &inventory.GenericFindingAdvisory{
+			ID: &inventory.AdvisoryID{
+				Publisher: "SCALIBR",
+				Reference: "mongodb-weak-password",
+			},
+			Title: "MongoDB user with an empty or default password",
+			Description: "A MongoDB init script creates a user with an empty, well-known " +
+				"default or easily guessable password. Attackers who can reach the server " +
+				"can log in and read or modify all data the user has access to.",
+			Recommendation: "Set a strong, randomly generated password for the user with " +
+				"db.changeUserPassword() and remove the password from the init scripts.",
+			Sev: inventory.SeverityCritical,
+		},
+		Target: target,
+	}
+}
+
+func noAuthFinding(target *inventory.GenericFindingTargetDetails) *inventory.GenericFinding {
+	return &inventory.GenericFinding{
+		Adv: &inventory.GenericFindingAdvisory{
+			ID: &inventory.AdvisoryID{
+				Publisher: "SCALIBR",
+				Reference: "mongodb-no-authentication",
+			},
+			Title: "MongoDB accepts remote connections without authentication",
+			Description: "Access control is disabled while the MongoDB server listens on all " +
+				"network interfaces. Anyone who can reach the server has full access to all databases.",
+			Recommendation: "Create an administrative user, set security.authorization to " +
+				"enabled in /etc/mongod.conf and restart the server.",
+			Sev: inventory.SeverityCritical,
+		},
+		Target: target,
+	}
+}
+
+func bindAllInterfacesFinding(target *inventory.GenericFindingTargetDetails) *inventory.GenericFinding {
+	return &inventory.GenericFinding{
+		Adv: &inventory.GenericFindingAdvisory{
+			ID: &inventory.AdvisoryID{
+				Publisher: "SCALIBR",
+				Reference: "mongodb-bind-all-interfaces",
+			},
+			Title: "MongoDB server listens on all network interfaces",
+			Description: "The MongoDB server accepts connections on all network interfaces of " +
+				"the host, which exposes it to password guessing and exploits from other hosts.",
+			Recommendation: "Set net.bindIp to 127.0.0.1 or to the addresses of the interfaces " +
+				"the clients connect through, and restrict access with a firewall.",
+			Sev: inventory.SeverityMedium,
+		},
+		Target: target,
+	}
+}
+
+// Scan checks the MongoDB config of the host for weak credentials and insecure settings.
+func (d Detector) Scan(ctx context.Context, scanRoot *scalibrfs.ScanRoot, px *packageindex.PackageIndex) (inventory.Finding, error) {
+	return d.ScanFS(ctx, scanRoot.FS, px)
+}
+
+// ScanFS checks the MongoDB configs and init scripts in the given filesystem
+// for weak credentials and insecure settings.
+func (d Detector) ScanFS(ctx context.Context, fsys fs.FS, px *packageindex.PackageIndex) (inventory.Finding, error) {
+	files, err := dbconfig.ReadFiles(fsys, configPatterns...)
+	if err != nil {
+		return inventory.Finding{}, err
+	}
+
+	var findings []*inventory.GenericFinding
+	for _, f := range files {
+		cfg, err := parseConfig(f.Data)
+		if err != nil {
+			return inventory.Finding{}, fmt.Errorf("failed to parse %s: %w", f.Path, err)
+		}
+		bindAll := cfg.Net.BindIPAll
+		for _, addr := range strings.Split(cfg.Net.BindIP, ",") {
+			bindAll = bindAll || dbconfig.IsAllInterfaces(addr)
+		}
+		if !bindAll {
+			continue
+		}
+		if cfg.Net.BindIPAll {
+			findings = append(findings, bindAllInterfacesFinding(dbconfig.Target(f.Path, "net.bindIpAll: true")))
+		} else {
+			findings = append(findings, bindAllInterfacesFinding(dbconfig.Target(f.Path, "net.bindIp: %s", cfg.Net.BindIP)))
+		}
+		// A key file for internal authentication also enables access control.
+		if cfg.Security.Authorization != "enabled" && cfg.Security.KeyFile == "" {
+			findings = append(findings, noAuthFinding(dbconfig.Target(f.Path, "security.authorization not enabled")))
+		}
+	}
+
+	scripts, err := dbconfig.ReadFiles(fsys, dbconfig.InitScriptPatterns(".js")...)
+	if err != nil {
+		return inventory.Finding{}, err
+	}
+	for _, s := range scripts {
+		for _, m := range reCreateUser.FindAllSubmatch(s.Data, -1) {
+			user, password := string(m[1]), string(m[2])
+			if dbconfig.IsWeakPassword(user, password) {
+				findings = append(findings, weakPasswordFinding(dbconfig.Target(s.Path, "password of user %q", user)))
+			}
+		}
+	}
+
+	return inventory.Finding{GenericFindings: findings}, nil
+}
+
+// parseConfig parses a mongod config in the YAML format or in the legacy
+// "key = value" format used before MongoDB 2.6.
+func parseConfig(data []byte) (*config, error) {
+	cfg := &config{}
+	yamlErr := yaml.Unmarshal(data, cfg)
+	if yamlErr == nil {
+		return cfg, nil
+	}
+
+	cfg = &config{}
+	found := false
+	s := bufio.NewScanner(bytes.NewReader(data))
+	for s.Scan() {
+		line, _, _ := strings.Cut(s.Text(), "#")
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		found = true
+		value = strings.TrimSpace(value)
+		switch strings.TrimSpace(key) {
+		case "bind_ip":
+			cfg.Net.BindIP = value
+		case "bind_ip_all":
+			cfg.Net.BindIPAll = value == "true"
+		case "auth":
+			if value == "true" {
+				cfg.Security.Authorization = "enabled"
+			}
+		case "keyFile":
+			cfg.Security.KeyFile = value
+		}
+	}
+	if !found {
+		return nil, yamlErr
+	}
+	return cfg, nil
+}
@@ -0,0 +1,133 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mongodbconfig_test
+
+import (
+	"context"
+	"testing"
+	"testing/fstest"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/osv-scalibr/detector/misconfig/mongodbconfig"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/packageindex"
+)
+
+func finding(adv *inventory.GenericFindingAdvisory, extra string) *inventory.GenericFinding {
+	return &inventory.GenericFinding{
+		Adv:    adv,
+		Target: &inventory.GenericFindingTargetDetails{Extra: extra},
+	}
+}
+
+func TestScanFS(t *testing.T) {
+	det := mongodbconfig.Detector{}
+	advs := det.DetectedFinding().GenericFindings
+	passwordAdv, noAuthAdv, bindAdv := advs[0].Adv, advs[1].Adv, advs[2].Adv
+
+	px, _ := packageindex.New([]*extractor.Package{})
+	testCases := []struct {
+		desc         string
+		files        map[string]string
+		wantFindings []*inventory.GenericFinding
+		wantErr      bool
+	}{
+		{
+			desc:         "no_config",
+			wantFindings: nil,
+		},
+		{
+			desc: "default_config",
+			files: map[string]string{
+				"etc/mongod.conf": "storage:\n  dbPath: /var/lib/mongodb\nnet:\n  port: 27017\n  bindIp: 127.0.0.1\n",
+			},
+			wantFindings: nil,
+		},
+		{
+			desc: "bind_all_with_authorization",
+			files: map[string]string{
+				"etc/mongod.conf": "net:\n  bindIp: 127.0.0.1,0.0.0.0\nsecurity:\n  authorization: enabled\n",
+			},
+			wantFindings: []*inventory.GenericFinding{
+				finding(bindAdv, "/etc/mongod.conf: net.bindIp: 127.0.0.1,0.0.0.0"),
+			},
+		},
+		{
+			desc: "bind_all_with_key_file",
+			files: map[string]string{
+				"etc/mongod.conf": "net:\n  bindIpAll: true\nsecurity:\n  keyFile: /etc/mongodb/keyfile\n",
+			},
+			wantFindings: []*inventory.GenericFinding{
+				finding(bindAdv, "/etc/mongod.conf: net.bindIpAll: true"),
+			},
+		},
+		{
+			desc: "no_authentication",
+			files: map[string]string{
+				"etc/mongod.conf": "net:\n  bindIpAll: true\nsecurity:\n  authorization: disabled\n",
+			},
+			wantFindings: []*inventory.GenericFinding{
+				finding(bindAdv, "/etc/mongod.conf: net.bindIpAll: true"),
+				finding(noAuthAdv, "/etc/mongod.conf: security.authorization not enabled"),
+			},
+		},
+		{
+			desc: "legacy_config",
+			files: map[string]string{
+				"etc/mongodb.conf": "# mongodb.conf\ndbpath=/var/lib/mongodb\nbind_ip = 0.0.0.0\n#auth = true\n",
+			},
+			wantFindings: []*inventory.GenericFinding{
+				finding(bindAdv, "/etc/mongodb.conf: net.bindIp: 0.0.0.0"),
+				finding(noAuthAdv, "/etc/mongodb.conf: security.authorization not enabled"),
+			},
+		},
+		{
+			desc: "init_scripts",
+			files: map[string]string{
+				"docker-entrypoint-initdb.d/users.js": "db.createUser({user: 'app', pwd: 'app', roles: ['readWrite']});\n" +
+					"db.createUser({ user: \"ops\", pwd: passwordPrompt(), roles: [] });\n" +
+					"db.createUser({user: \"admin\", pwd: \"changeme\", roles: ['root']});\n",
+			},
+			wantFindings: []*inventory.GenericFinding{
+				finding(passwordAdv, "/docker-entrypoint-initdb.d/users.js: password of user \"app\""),
+				finding(passwordAdv, "/docker-entrypoint-initdb.d/users.js: password of user \"admin\""),
+			},
+		},
+		{
+			desc: "invalid_config",
+			files: map[string]string{
+				"etc/mongod.conf": "net: [\n",
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			fsys := fstest.MapFS{}
+			for path, content := range tc.files {
+				fsys[path] = &fstest.MapFile{Data: []byte(content)}
+			}
+			got, err := det.ScanFS(context.Background(), fsys, px)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("ScanFS(%s) error: %v, want error: %v", tc.desc, err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.wantFindings, got.GenericFindings); diff != "" {
+				t.Errorf("ScanFS(%s): unexpected findings (-want +got):\n%s", tc.desc, diff)
+			}
+		})
+	}
+}
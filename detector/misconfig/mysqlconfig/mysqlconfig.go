@@ -0,0 +1,306 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package mysqlconfig implements a detector for weak credentials and insecure
+// network settings in MySQL and MariaDB option files and init scripts.
+package mysqlconfig
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io/fs"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/google/osv-scalibr/detector"
+	"github.com/google/osv-scalibr/detector/misconfig/internal/dbconfig"
+	scalibrfs "github.com/google/osv-scalibr/fs"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/packageindex"
+	"github.com/google/osv-scalibr/plugin"
+)
+
+const (
+	// Name of the detector.
+	Name = "misconfig/mysqlconfig"
+)
+
+// optionFilePatterns are the locations of the MySQL and MariaDB option files,
+// including the client option files that store passwords.
+var optionFilePatterns = []string{
+	"etc/my.cnf",
+	"etc/my.cnf.d/*.cnf",
+	"etc/mysql/my.cnf",
+	"etc/mysql/debian.cnf",
+	"etc/mysql/conf.d/*.cnf",
+	"etc/mysql/mysql.conf.d/*.cnf",
+	"etc/mysql/mariadb.conf.d/*.cnf",
+	"root/.my.cnf",
+}
+
+// clientGroups are the option groups read by the MySQL client programs.
+var clientGroups = map[string]bool{
+	"client":         true,
+	"client-server":  true,
+	"client-mariadb": true,
+	"mariadb-client": true,
+	"mysql":          true,
+	"mysqladmin":     true,
+	"mysqldump":      true,
+}
+
+var (
+	// Matches the passwords of CREATE USER, ALTER USER and GRANT statements,
+	// e.g. "CREATE USER 'app'@'%' IDENTIFIED BY 'password'".
+	reIdentifiedBy = regexp.MustCompile(`(?i)\b(?:USER|TO)\s+(?:IF\s+NOT\s+EXISTS\s+)?['"\x60]?([^'"\x60@\s]*)['"\x60]?(?:@\S+)?\s+IDENTIFIED\s+(?:WITH\s+\S+\s+)?BY\s+'([^']*)'`)
+	// Matches "SET PASSWORD FOR 'root'@'localhost' = 'password'".
+	reSetPassword = regexp.MustCompile(`(?i)\bSET\s+PASSWORD\s+FOR\s+['"\x60]?([^'"\x60@\s]*)['"\x60]?(?:@\S+)?\s*=\s*(?:PASSWORD\s*\(\s*)?'([^']*)'`)
+)
+
+// Detector is a SCALIBR Detector for weak credentials and insecure network
+// settings of MySQL and MariaDB servers.
+type Detector struct{}
+
+// New returns a detector.
+func New() detector.Detector {
+	return &Detector{}
+}
+
+// Name of the detector.
+func (Detector) Name() string { return Name }
+
+// Version of the detector.
+func (Detector) Version() int { return 0 }
+
+// Requirements of the detector.
+func (Detector) Requirements() *plugin.Capabilities {
+	return &plugin.Capabilities{OS: plugin.OSLinux}
+}
+
+// RequiredExtractors returns nothing (no deps).
+func (Detector) RequiredExtractors() []string { return []string{} }
+
+// DetectedFinding returns generic vulnerability information about what is detected.
+func (d Detector) DetectedFinding() inventory.Finding {
+	return inventory.Finding{GenericFindings: []*inventory.GenericFinding{
+		weakPasswordFinding(nil),
+		skipGrantTablesFinding(nil),
+		bindAllInterfacesFinding(nil),
+	}}
+}
+
+func weakPasswordFinding(target *inventory.GenericFindingTargetDetails) *inventory.GenericFinding {
+	return &inventory.GenericFinding{
+		Adv: &inventory.GenericFindingAdvisory{
+			ID: &inventory.AdvisoryID{
+				Publisher: "SCALIBR",
+				Reference: "mysql-weak-password",
+			},
+			Title: "MySQL account with an empty or default password",
+			Description: "A MySQL option file or init script sets an empty, well-known default " +
+				"or easily guessable password for a database account. Attackers who can " +
+				"reach the server can log in and read or modify all data the account has access to.",
+			Recommendation: "Set a strong, randomly generated password for the account with " +
+				"ALTER USER and remove the password from the option files and init scripts.",
+			Sev: inventory.SeverityCritical,
+		},
+		Target: target,
+	}
+}
+
+func skipGrantTablesFinding(target *inventory.GenericFindingTargetDetails) *inventory.GenericFinding {
+	return &inventory.GenericFinding{
+		Adv: &inventory.GenericFindingAdvisory{
+			ID: &inventory.AdvisoryID{
+				Publisher: "SCALIBR",
+				Reference: "mysql-skip-grant-tables",
+			},
+			Title: "MySQL server runs without authentication",
+			Description: "The MySQL server is started with skip-grant-tables, which disables " +
+				"authentication and privilege checks. Anyone who can connect to the server " +
+				"has full access to all databases.",
+			Recommendation: "Remove skip-grant-tables from the [mysqld] option group and " +
+				"restart the server.",
+			Sev: inventory.SeverityCritical,
+		},
+		Target: target,
+	}
+}
+
+func bindAllInterfacesFinding(target *inventory.GenericFindingTargetDetails) *inventory.GenericFinding {
+	return &inventory.GenericFinding{
+		Adv: &inventory.GenericFindingAdvisory{
+			ID: &inventory.AdvisoryID{
+				Publisher: "SCALIBR",
+				Reference: "mysql-bind-all-interfaces",
+			},
+			Title: "MySQL server listens on all network interfaces",
+			Description: "The MySQL server accepts connections on all network interfaces of " +
+				"the host, which exposes it to password guessing and exploits from other hosts.",
+			Recommendation: "Set bind-address to 127.0.0.1 or to the address of the interface " +
+				"the clients connect through, and restrict access with a firewall.",
+			Sev: inventory.SeverityMedium,
+		},
+		Target: target,
+	}
+}
+
+// Scan checks the MySQL config of the host for weak credentials and insecure settings.
+func (d Detector) Scan(ctx context.Context, scanRoot *scalibrfs.ScanRoot, px *packageindex.PackageIndex) (inventory.Finding, error) {
+	return d.ScanFS(ctx, scanRoot.FS, px)
+}
+
+// ScanFS checks the MySQL option files and init scripts in the given
+// filesystem for weak credentials and insecure settings.
+func (d Detector) ScanFS(ctx context.Context, fsys fs.FS, px *packageindex.PackageIndex) (inventory.Finding, error) {
+	files, err := dbconfig.ReadFiles(fsys, optionFilePatterns...)
+	if err != nil {
+		return inventory.Finding{}, err
+	}
+
+	var findings []*inventory.GenericFinding
+	var initFiles []string
+	for _, f := range files {
+		var clientUser, clientPassword string
+		hasClientPassword := false
+		for _, o := range parseOptions(f.Data) {
+			switch {
+			case clientGroups[o.group]:
+				switch o.name {
+				case "user":
+					clientUser = o.value
+				case "password":
+					// A password option without a value makes the client prompt for it.
+					clientPassword = o.value
+					hasClientPassword = o.hasValue
+				}
+			case isServerGroup(o.group):
+				switch o.name {
+				case "skip-grant-tables":
+					if isEnabled(o) {
+						findings = append(findings, skipGrantTablesFinding(dbconfig.Target(f.Path, "[%s] %s", o.group, o.name)))
+					}
+				case "bind-address":
+					for _, addr := range strings.Split(o.value, ",") {
+						if dbconfig.IsAllInterfaces(addr) {
+							findings = append(findings, bindAllInterfacesFinding(dbconfig.Target(f.Path, "[%s] bind-address: %s", o.group, o.value)))
+							break
+						}
+					}
+				case "init-file":
+					initFiles = append(initFiles, strings.TrimPrefix(path.Clean(o.value), "/"))
+				}
+			}
+		}
+		if hasClientPassword && dbconfig.IsWeakPassword(clientUser, clientPassword) {
+			findings = append(findings, weakPasswordFinding(dbconfig.Target(f.Path, "client password of user %q", clientUser)))
+		}
+	}
+
+	scripts, err := dbconfig.ReadFiles(fsys, append(dbconfig.InitScriptPatterns(".sql"), initFiles...)...)
+	if err != nil {
+		return inventory.Finding{}, err
+	}
+	for _, s := range scripts {
+		for _, re := range []*regexp.Regexp{reIdentifiedBy, reSetPassword} {
+			for _, m := range re.FindAllSubmatch(s.Data, -1) {
+				user, password := string(m[1]), string(m[2])
+				if dbconfig.IsWeakPassword(user, password) {
+					findings = append(findings, weakPasswordFinding(dbconfig.Target(s.Path, "password of user %q", user)))
+				}
+			}
+		}
+	}
+
+	return inventory.Finding{GenericFindings: findings}, nil
+}
+
+// option is a single option set in an option file.
+type option struct {
+	// The option group, e.g. "mysqld".
+	group string
+	// The option name without the "loose-" prefix and with dashes instead of
+	// underscores, e.g. "bind-address".
+	name     string
+	value    string
+	hasValue bool
+}
+
+// parseOptions parses the options in a MySQL option file.
+// See https://dev.mysql.com/doc/refman/8.4/en/option-files.html
+func parseOptions(data []byte) []*option {
+	var opts []*option
+	group := ""
+	s := bufio.NewScanner(bytes.NewReader(data))
+	for s.Scan() {
+		line := strings.TrimSpace(s.Text())
+		switch {
+		case line == "", line[0] == '#', line[0] == ';', line[0] == '!':
+			// Empty lines, comments and !include directives.
+			continue
+		case line[0] == '[':
+			group = strings.ToLower(strings.TrimSpace(strings.Trim(line, "[]")))
+			continue
+		}
+		name, value, hasValue := strings.Cut(line, "=")
+		name = strings.ToLower(strings.TrimSpace(name))
+		name = strings.ReplaceAll(name, "_", "-")
+		name = strings.TrimPrefix(name, "loose-")
+		opts = append(opts, &option{
+			group:    group,
+			name:     name,
+			value:    unquote(stripComment(strings.TrimSpace(value))),
+			hasValue: hasValue,
+		})
+	}
+	return opts
+}
+
+// stripComment removes a trailing comment from an unquoted option value.
+func stripComment(value string) string {
+	if strings.HasPrefix(value, `"`) || strings.HasPrefix(value, "'") {
+		return value
+	}
+	if i := strings.Index(value, "#"); i >= 0 {
+		return strings.TrimSpace(value[:i])
+	}
+	return value
+}
+
+func unquote(value string) string {
+	if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+		return value[1 : len(value)-1]
+	}
+	return value
+}
+
+// isServerGroup returns whether the option group is read by the server.
+func isServerGroup(group string) bool {
+	return group == "mysqld" || group == "server" || group == "mariadbd" ||
+		strings.HasPrefix(group, "mysqld-") || strings.HasPrefix(group, "mariadb")
+}
+
+// isEnabled returns whether a boolean option is turned on.
+func isEnabled(o *option) bool {
+	if !o.hasValue {
+		return true
+	}
+	switch strings.ToLower(o.value) {
+	case "0", "off", "false":
+		return false
+	}
+	return true
+}
@@ -0,0 +1,124 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysqlconfig_test
+
+import (
+	"context"
+	"testing"
+	"testing/fstest"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/osv-scalibr/detector/misconfig/mysqlconfig"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/packageindex"
+)
+
+func finding(adv *inventory.GenericFindingAdvisory, extra string) *inventory.GenericFinding {
+	return &inventory.GenericFinding{
+		Adv:    adv,
+		Target: &inventory.GenericFindingTargetDetails{Extra: extra},
+	}
+}
+
+func TestScanFS(t *testing.T) {
+	det := mysqlconfig.Detector{}
+	advs := det.DetectedFinding().GenericFindings
+	passwordAdv, grantTablesAdv, bindAdv := advs[0].Adv, advs[1].Adv, advs[2].Adv
+
+	px, _ := packageindex.New([]*extractor.Package{})
+	testCases := []struct {
+		desc         string
+		files        map[string]string
+		wantFindings []*inventory.GenericFinding
+	}{
+		{
+			desc:         "no_config",
+			wantFindings: nil,
+		},
+		{
+			desc: "secure_config",
+			files: map[string]string{
+				"etc/mysql/mysql.conf.d/mysqld.cnf": "[mysqld]\nbind-address = 127.0.0.1\n# bind-address = 0.0.0.0\n",
+				"etc/mysql/debian.cnf":              "[client]\nhost = localhost\nuser = debian-sys-maint\npassword = Xq3kUe8vT1bZp0sW\n",
+				"root/.my.cnf":                      "[client]\nuser=root\npassword\n",
+			},
+			wantFindings: nil,
+		},
+		{
+			desc: "bind_all_interfaces",
+			files: map[string]string{
+				"etc/mysql/mariadb.conf.d/50-server.cnf": "[mariadb]\nbind_address = 0.0.0.0 # listen everywhere\n",
+			},
+			wantFindings: []*inventory.GenericFinding{
+				finding(bindAdv, "/etc/mysql/mariadb.conf.d/50-server.cnf: [mariadb] bind-address: 0.0.0.0"),
+			},
+		},
+		{
+			desc: "skip_grant_tables",
+			files: map[string]string{
+				"etc/my.cnf": "!includedir /etc/my.cnf.d\n[mysqld]\nskip-grant-tables\n",
+				// Disabled explicitly.
+				"etc/my.cnf.d/server.cnf": "[mysqld]\nloose_skip_grant_tables = OFF\n",
+			},
+			wantFindings: []*inventory.GenericFinding{
+				finding(grantTablesAdv, "/etc/my.cnf: [mysqld] skip-grant-tables"),
+			},
+		},
+		{
+			desc: "weak_client_passwords",
+			files: map[string]string{
+				"root/.my.cnf":       "[client]\nuser = root\npassword = \"root\"\n",
+				"etc/mysql/my.cnf":   "[mysql]\nuser=app\npassword=\n",
+				"etc/mysql/conf.d/x": "[client]\npassword=root\n",
+			},
+			wantFindings: []*inventory.GenericFinding{
+				finding(passwordAdv, "/etc/mysql/my.cnf: client password of user \"app\""),
+				finding(passwordAdv, "/root/.my.cnf: client password of user \"root\""),
+			},
+		},
+		{
+			desc: "init_scripts",
+			files: map[string]string{
+				"etc/mysql/conf.d/init.cnf": "[mysqld]\ninit-file = /etc/mysql/init.sql\n",
+				"etc/mysql/init.sql":        "SET PASSWORD FOR 'root'@'localhost' = PASSWORD('');\n",
+				"docker-entrypoint-initdb.d/01-users.sql": "CREATE USER IF NOT EXISTS 'app'@'%' IDENTIFIED BY 'changeme';\n" +
+					"CREATE USER `reporting`@`%` IDENTIFIED WITH mysql_native_password BY 'reporting';\n" +
+					"CREATE USER 'admin'@'%' IDENTIFIED BY 'c0rr3ct-h0rse-b4ttery';\n",
+			},
+			wantFindings: []*inventory.GenericFinding{
+				finding(passwordAdv, "/docker-entrypoint-initdb.d/01-users.sql: password of user \"app\""),
+				finding(passwordAdv, "/docker-entrypoint-initdb.d/01-users.sql: password of user \"reporting\""),
+				finding(passwordAdv, "/etc/mysql/init.sql: password of user \"root\""),
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			fsys := fstest.MapFS{}
+			for path, content := range tc.files {
+				fsys[path] = &fstest.MapFile{Data: []byte(content)}
+			}
+			got, err := det.ScanFS(context.Background(), fsys, px)
+			if err != nil {
+				t.Fatalf("ScanFS(%s): %v", tc.desc, err)
+			}
+			if diff := cmp.Diff(tc.wantFindings, got.GenericFindings); diff != "" {
+				t.Errorf("ScanFS(%s): unexpected findings (-want +got):\n%s", tc.desc, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,274 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package postgresconfig implements a detector for weak credentials and
+// insecure network settings in PostgreSQL configs and init scripts.
+package postgresconfig
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io/fs"
+	"math/bits"
+	"net/netip"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/google/osv-scalibr/detector"
+	"github.com/google/osv-scalibr/detector/misconfig/internal/dbconfig"
+	scalibrfs "github.com/google/osv-scalibr/fs"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/packageindex"
+	"github.com/google/osv-scalibr/plugin"
+)
+
+const (
+	// Name of the detector.
+	Name = "misconfig/postgresconfig"
+)
+
+// dataDirPatterns are the locations of the PostgreSQL config dirs of the
+// Debian and Red Hat packages and the official container image.
+var dataDirPatterns = []string{
+	"etc/postgresql/*/*",
+	"var/lib/pgsql/data",
+	"var/lib/pgsql/*/data",
+	"var/lib/postgresql/data",
+}
+
+var (
+	// Matches the passwords of CREATE ROLE, CREATE USER and ALTER ROLE statements,
+	// e.g. "CREATE USER app WITH PASSWORD 'password'".
+	rePassword = regexp.MustCompile(`(?i)\b(?:ROLE|USER)\s+(?:IF\s+NOT\s+EXISTS\s+)?"?([^"\s;]+)"?\s+(?:WITH\s+)?(?:[A-Z]+\s+)*?(?:ENCRYPTED\s+)?PASSWORD\s+'([^']*)'`)
+)
+
+// Detector is a SCALIBR Detector for weak credentials and insecure network
+// settings of PostgreSQL servers.
+type Detector struct{}
+
+// New returns a detector.
+func New() detector.Detector {
+	return &Detector{}
+}
+
+// Name of the detector.
+func (Detector) Name() string { return Name }
+
+// Version of the detector.
+func (Detector) Version() int { return 0 }
+
+// Requirements of the detector.
+func (Detector) Requirements() *plugin.Capabilities {
+	return &plugin.Capabilities{OS: plugin.OSLinux}
+}
+
+// RequiredExtractors returns nothing (no deps).
+func (Detector) RequiredExtractors() []string { return []string{} }
+
+// DetectedFinding returns generic vulnerability information about what is detected.
+func (d Detector) DetectedFinding() inventory.Finding {
+	return inventory.Finding{GenericFindings: []*inventory.GenericFinding{
+		weakPasswordFinding(nil),
+		trustAuthFinding(nil),
+		listenAllInterfacesFinding(nil),
+	}}
+}
+
+func weakPasswordFinding(target *inventory.GenericFindingTargetDetails) *inventory.GenericFinding {
+	return &inventory.GenericFinding{
+		Adv: &inventory.GenericFindingAdvisory{
+			ID: &inventory.AdvisoryID{
+				Publisher: "SCALIBR",
+				Reference: "postgres-weak-password",
+			},
+			Title: "PostgreSQL role with an empty or default password",
+			Description: "A PostgreSQL init script sets an empty, well-known default or easily " +
+				"guessable password for a database role. Attackers who can reach the server " +
+				"can log in and read or modify all data the role has access to.",
+			Recommendation: "Set a strong, randomly generated password for the role with " +
+				"ALTER ROLE and remove the password from the init scripts.",
+			Sev: inventory.SeverityCritical,
+		},
+		Target: target,
+	}
+}
+
+func trustAuthFinding(target *inventory.GenericFindingTargetDetails) *inventory.GenericFinding {
+	return &inventory.GenericFinding{
+		Adv: &inventory.GenericFindingAdvisory{
+			ID: &inventory.AdvisoryID{
+				Publisher: "SCALIBR",
+				Reference: "postgres-trust-authentication",
+			},
+			Title: "PostgreSQL accepts remote connections without a password",
+			Description: "pg_hba.conf uses the trust authentication method for TCP/IP " +
+				"connections from other hosts. Anyone who can reach the server can log in " +
+				"as any role, including superusers, without a password.",
+			Recommendation: "Replace the trust method of the host entries in pg_hba.conf " +
+				"with scram-sha-256 and reload the server.",
+			Sev: inventory.SeverityCritical,
+		},
+		Target: target,
+	}
+}
+
+func listenAllInterfacesFinding(target *inventory.GenericFindingTargetDetails) *inventory.GenericFinding {
+	return &inventory.GenericFinding{
+		Adv: &inventory.GenericFindingAdvisory{
+			ID: &inventory.AdvisoryID{
+				Publisher: "SCALIBR",
+				Reference: "postgres-listen-all-interfaces",
+			},
+			Title: "PostgreSQL server listens on all network interfaces",
+			Description: "The PostgreSQL server accepts connections on all network interfaces " +
+				"of the host, which exposes it to password guessing and exploits from other hosts.",
+			Recommendation: "Set listen_addresses in postgresql.conf to 'localhost' or to the " +
+				"addresses of the interfaces the clients connect through, and restrict access " +
+				"with a firewall.",
+			Sev: inventory.SeverityMedium,
+		},
+		Target: target,
+	}
+}
+
+// Scan checks the PostgreSQL config of the host for weak credentials and insecure settings.
+func (d Detector) Scan(ctx context.Context, scanRoot *scalibrfs.ScanRoot, px *packageindex.PackageIndex) (inventory.Finding, error) {
+	return d.ScanFS(ctx, scanRoot.FS, px)
+}
+
+// ScanFS checks the PostgreSQL configs and init scripts in the given
+// filesystem for weak credentials and insecure settings.
+func (d Detector) ScanFS(ctx context.Context, fsys fs.FS, px *packageindex.PackageIndex) (inventory.Finding, error) {
+	var findings []*inventory.GenericFinding
+
+	configs, err := dbconfig.ReadFiles(fsys, filePatterns("postgresql.conf")...)
+	if err != nil {
+		return inventory.Finding{}, err
+	}
+	for _, f := range configs {
+		if addrs, ok := listenAddresses(f.Data); ok {
+			for _, addr := range strings.Split(addrs, ",") {
+				if dbconfig.IsAllInterfaces(addr) {
+					findings = append(findings, listenAllInterfacesFinding(dbconfig.Target(f.Path, "listen_addresses: %s", addrs)))
+					break
+				}
+			}
+		}
+	}
+
+	hbaFiles, err := dbconfig.ReadFiles(fsys, filePatterns("pg_hba.conf")...)
+	if err != nil {
+		return inventory.Finding{}, err
+	}
+	for _, f := range hbaFiles {
+		for _, entry := range remoteTrustEntries(f.Data) {
+			findings = append(findings, trustAuthFinding(dbconfig.Target(f.Path, "%s", entry)))
+		}
+	}
+
+	scripts, err := dbconfig.ReadFiles(fsys, dbconfig.InitScriptPatterns(".sql")...)
+	if err != nil {
+		return inventory.Finding{}, err
+	}
+	for _, s := range scripts {
+		for _, m := range rePassword.FindAllSubmatch(s.Data, -1) {
+			role, password := string(m[1]), string(m[2])
+			if dbconfig.IsWeakPassword(role, password) {
+				findings = append(findings, weakPasswordFinding(dbconfig.Target(s.Path, "password of role %q", role)))
+			}
+		}
+	}
+
+	return inventory.Finding{GenericFindings: findings}, nil
+}
+
+func filePatterns(name string) []string {
+	patterns := make([]string, 0, len(dataDirPatterns))
+	for _, dir := range dataDirPatterns {
+		patterns = append(patterns, path.Join(dir, name))
+	}
+	return patterns
+}
+
+// listenAddresses returns the last listen_addresses setting of a postgresql.conf file.
+func listenAddresses(data []byte) (string, bool) {
+	value := ""
+	found := false
+	s := bufio.NewScanner(bytes.NewReader(data))
+	for s.Scan() {
+		line, _, _ := strings.Cut(s.Text(), "#")
+		name, v, ok := strings.Cut(line, "=")
+		if !ok {
+			// The equal sign between name and value is optional.
+			name, v, ok = strings.Cut(strings.TrimSpace(line), " ")
+		}
+		if !ok || strings.TrimSpace(name) != "listen_addresses" {
+			continue
+		}
+		value = strings.Trim(strings.TrimSpace(v), "'")
+		found = true
+	}
+	return value, found
+}
+
+// remoteTrustEntries returns the pg_hba.conf entries that allow TCP/IP
+// connections from other hosts with the trust authentication method.
+// See https://www.postgresql.org/docs/current/auth-pg-hba-conf.html
+func remoteTrustEntries(data []byte) []string {
+	var entries []string
+	s := bufio.NewScanner(bytes.NewReader(data))
+	for s.Scan() {
+		line, _, _ := strings.Cut(s.Text(), "#")
+		fields := strings.Fields(line)
+		// host DATABASE USER ADDRESS [MASK] METHOD [OPTIONS]
+		if len(fields) < 5 || !strings.HasPrefix(fields[0], "host") {
+			continue
+		}
+		addr, method := fields[3], fields[4]
+		if mask, err := netip.ParseAddr(fields[4]); err == nil && len(fields) > 5 {
+			// The address is followed by a separate netmask.
+			addr = withMask(addr, mask)
+			method = fields[5]
+		}
+		if method == "trust" && !isLoopback(addr) {
+			entries = append(entries, strings.Join(fields, " "))
+		}
+	}
+	return entries
+}
+
+// withMask returns the address with the netmask in CIDR notation.
+func withMask(addr string, mask netip.Addr) string {
+	n := 0
+	for _, b := range mask.AsSlice() {
+		n += bits.OnesCount8(b)
+	}
+	return fmt.Sprintf("%s/%d", addr, n)
+}
+
+func isLoopback(addr string) bool {
+	if addr == "localhost" || addr == "samehost" {
+		return true
+	}
+	if p, err := netip.ParsePrefix(addr); err == nil {
+		return p.Masked().Addr().IsLoopback()
+	}
+	if a, err := netip.ParseAddr(addr); err == nil {
+		return a.IsLoopback()
+	}
+	return false
+}
@@ -0,0 +1,115 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postgresconfig_test
+
+import (
+	"context"
+	"testing"
+	"testing/fstest"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/osv-scalibr/detector/misconfig/postgresconfig"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/packageindex"
+)
+
+func finding(adv *inventory.GenericFindingAdvisory, extra string) *inventory.GenericFinding {
+	return &inventory.GenericFinding{
+		Adv:    adv,
+		Target: &inventory.GenericFindingTargetDetails{Extra: extra},
+	}
+}
+
+func TestScanFS(t *testing.T) {
+	det := postgresconfig.Detector{}
+	advs := det.DetectedFinding().GenericFindings
+	passwordAdv, trustAdv, listenAdv := advs[0].Adv, advs[1].Adv, advs[2].Adv
+
+	px, _ := packageindex.New([]*extractor.Package{})
+	testCases := []struct {
+		desc         string
+		files        map[string]string
+		wantFindings []*inventory.GenericFinding
+	}{
+		{
+			desc:         "no_config",
+			wantFindings: nil,
+		},
+		{
+			desc: "secure_config",
+			files: map[string]string{
+				"etc/postgresql/16/main/postgresql.conf": "#listen_addresses = '*'\nlisten_addresses = 'localhost'\t# what IP address(es) to listen on;\n",
+				"etc/postgresql/16/main/pg_hba.conf": "local   all             postgres                                peer\n" +
+					"local   all             all                                     trust\n" +
+					"host    all             all             127.0.0.1/32            trust\n" +
+					"host    all             all             ::1/128                 trust\n" +
+					"host    all             all             0.0.0.0/0               scram-sha-256\n",
+			},
+			wantFindings: nil,
+		},
+		{
+			desc: "listen_all_interfaces",
+			files: map[string]string{
+				"var/lib/postgresql/data/postgresql.conf": "listen_addresses = 'localhost'\nlisten_addresses '*'\n",
+			},
+			wantFindings: []*inventory.GenericFinding{
+				finding(listenAdv, "/var/lib/postgresql/data/postgresql.conf: listen_addresses: *"),
+			},
+		},
+		{
+			desc: "remote_trust",
+			files: map[string]string{
+				"var/lib/pgsql/data/pg_hba.conf": "host all all all trust\n" +
+					"hostssl replication replicator 10.0.0.0 255.0.0.0 trust # replicas\n" +
+					"host all all 127.0.0.1 255.255.255.255 trust\n" +
+					"host all all 127.0.0.1 0.0.0.0 trust\n",
+			},
+			wantFindings: []*inventory.GenericFinding{
+				finding(trustAdv, "/var/lib/pgsql/data/pg_hba.conf: host all all all trust"),
+				finding(trustAdv, "/var/lib/pgsql/data/pg_hba.conf: hostssl replication replicator 10.0.0.0 255.0.0.0 trust"),
+				finding(trustAdv, "/var/lib/pgsql/data/pg_hba.conf: host all all 127.0.0.1 0.0.0.0 trust"),
+			},
+		},
+		{
+			desc: "init_scripts",
+			files: map[string]string{
+				"docker-entrypoint-initdb.d/init.sql": "ALTER USER postgres PASSWORD 'postgres';\n" +
+					"CREATE ROLE app WITH LOGIN ENCRYPTED PASSWORD 'changeme';\n" +
+					"CREATE USER \"reporting\" PASSWORD 'k7#Vq9!mZ2pL';\n",
+			},
+			wantFindings: []*inventory.GenericFinding{
+				finding(passwordAdv, "/docker-entrypoint-initdb.d/init.sql: password of role \"postgres\""),
+				finding(passwordAdv, "/docker-entrypoint-initdb.d/init.sql: password of role \"app\""),
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			fsys := fstest.MapFS{}
+			for path, content := range tc.files {
+				fsys[path] = &fstest.MapFile{Data: []byte(content)}
+			}
+			got, err := det.ScanFS(context.Background(), fsys, px)
+			if err != nil {
+				t.Fatalf("ScanFS(%s): %v", tc.desc, err)
+			}
+			if diff := cmp.Diff(tc.wantFindings, got.GenericFindings); diff != "" {
+				t.Errorf("ScanFS(%s): unexpected findings (-want +got):\n%s", tc.desc, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,272 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package redisconfig implements a detector for weak credentials and insecure
+// network settings in Redis configs.
+package redisconfig
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io/fs"
+	"strings"
+
+	"github.com/google/osv-scalibr/detector"
+	"github.com/google/osv-scalibr/detector/misconfig/internal/dbconfig"
+	scalibrfs "github.com/google/osv-scalibr/fs"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/packageindex"
+	"github.com/google/osv-scalibr/plugin"
+)
+
+const (
+	// Name of the detector.
+	Name = "misconfig/redisconfig"
+)
+
+// configPatterns are the locations of the Redis configs of the Linux distro
+// packages and the official container image.
+var configPatterns = []string{
+	"etc/redis.conf",
+	"etc/redis/*.conf",
+	"usr/local/etc/redis/redis.conf",
+}
+
+// Detector is a SCALIBR Detector for weak credentials and insecure network
+// settings of Redis servers.
+type Detector struct{}
+
+// New returns a detector.
+func New() detector.Detector {
+	return &Detector{}
+}
+
+// Name of the detector.
+func (Detector) Name() string { return Name }
+
+// Version of the detector.
+func (Detector) Version() int { return 0 }
+
+// Requirements of the detector.
+func (Detector) Requirements() *plugin.Capabilities {
+	return &plugin.Capabilities{OS: plugin.OSLinux}
+}
+
+// RequiredExtractors returns nothing (no deps).
+func (Detector) RequiredExtractors() []string { return []string{} }
+
+// DetectedFinding returns generic vulnerability information about what is detected.
+func (d Detector) DetectedFinding() inventory.Finding {
+	return inventory.Finding{GenericFindings: []*inventory.GenericFinding{
+		weakPasswordFinding(nil),
+		noAuthFinding(nil),
+		bindAllInterfacesFinding(nil),
+	}}
+}
+
+func weakPasswordFinding(target *inventory.GenericFindingTargetDetails) *inventory.GenericFinding {
+	return &inventory.GenericFinding{
+		Adv: &inventory.GenericFindingAdvisory{
+			ID: &inventory.AdvisoryID{
+				Publisher: "SCALIBR",
+				Reference: "redis-weak-password",
+			},
+			Title: "Redis user with a default password",
+			Description: "The Redis config sets a well-known default or easily guessable " +
+				"password for a user. Attackers who can reach the server can log in, read " +
+				"and modify all data and often execute code through modules or config rewrites.",
+			Recommendation: "Set a strong, randomly generated password with requirepass or " +
+				"the ACL rules of the user.",
+			Sev: inventory.SeverityCritical,
+		},
+		Target: target,
+	}
+}
+
+func noAuthFinding(target *inventory.GenericFindingTargetDetails) *inventory.GenericFinding {
+	return &inventory.GenericFinding{
+		Adv: &inventory.GenericFindingAdvisory{
+			ID: &inventory.AdvisoryID{
+				Publisher: "SCALIBR",
+				Reference: "redis-no-authentication",
+			},
+			Title: "Redis accepts remote connections without a password",
+			Description: "The default Redis user has no password and protected mode is " +
+				"disabled while the server listens on all network interfaces. Anyone who " +
+				"can reach the server has full access to it.",
+			Recommendation: "Set a strong password with requirepass, enable protected-mode " +
+				"and bind the server to the interfaces the clients connect through.",
+			Sev: inventory.SeverityCritical,
+		},
+		Target: target,
+	}
+}
+
+func bindAllInterfacesFinding(target *inventory.GenericFindingTargetDetails) *inventory.GenericFinding {
+	return &inventory.GenericFinding{
+		Adv: &inventory.GenericFindingAdvisory{
+			ID: &inventory.AdvisoryID{
+				Publisher: "SCALIBR",
+				Reference: "redis-bind-all-interfaces",
+			},
+			Title: "Redis server listens on all network interfaces",
+			Description: "The Redis server accepts connections on all network interfaces of " +
+				"the host, which exposes it to password guessing and exploits from other hosts.",
+			Recommendation: "Set bind to 127.0.0.1 -::1 or to the addresses of the interfaces " +
+				"the clients connect through, and restrict access with a firewall.",
+			Sev: inventory.SeverityMedium,
+		},
+		Target: target,
+	}
+}
+
+// Scan checks the Redis config of the host for weak credentials and insecure settings.
+func (d Detector) Scan(ctx context.Context, scanRoot *scalibrfs.ScanRoot, px *packageindex.PackageIndex) (inventory.Finding, error) {
+	return d.ScanFS(ctx, scanRoot.FS, px)
+}
+
+// ScanFS checks the Redis configs in the given filesystem for weak credentials
+// and insecure settings.
+func (d Detector) ScanFS(ctx context.Context, fsys fs.FS, px *packageindex.PackageIndex) (inventory.Finding, error) {
+	files, err := dbconfig.ReadFiles(fsys, configPatterns...)
+	if err != nil {
+		return inventory.Finding{}, err
+	}
+
+	var findings []*inventory.GenericFinding
+	for _, f := range files {
+		cfg := parseConfig(f.Data)
+		if cfg == nil {
+			// Sentinel configs don't configure a data server.
+			continue
+		}
+		if cfg.requirePass != "" && dbconfig.IsWeakPassword("", cfg.requirePass) {
+			findings = append(findings, weakPasswordFinding(dbconfig.Target(f.Path, "requirepass")))
+		}
+		for _, u := range cfg.weakUsers {
+			findings = append(findings, weakPasswordFinding(dbconfig.Target(f.Path, "user %s", u)))
+		}
+		bindAll := cfg.bindsAllInterfaces()
+		if bindAll && len(cfg.bind) > 0 {
+			findings = append(findings, bindAllInterfacesFinding(dbconfig.Target(f.Path, "bind %s", strings.Join(cfg.bind, " "))))
+		}
+		if bindAll && !cfg.protectedMode && cfg.defaultUserNoPass() {
+			findings = append(findings, noAuthFinding(dbconfig.Target(f.Path, "protected-mode no, no password for the default user")))
+		}
+	}
+	return inventory.Finding{GenericFindings: findings}, nil
+}
+
+// config contains the security relevant directives of a Redis config.
+// See https://redis.io/docs/latest/operate/oss_and_stack/management/config-file/
+type config struct {
+	bind          []string
+	protectedMode bool
+	requirePass   string
+	// Whether the ACL rules of the default user allow logins without a password.
+	defaultNoPass bool
+	// Names of the enabled users with a weak password in their ACL rules.
+	weakUsers []string
+}
+
+// bindsAllInterfaces returns whether the server listens on all interfaces,
+// which is also the case if no bind directive is set.
+func (c *config) bindsAllInterfaces() bool {
+	if len(c.bind) == 0 {
+		return true
+	}
+	for _, addr := range c.bind {
+		// Addresses prefixed with "-" are optional.
+		addr = strings.TrimPrefix(addr, "-")
+		if addr == "::*" || dbconfig.IsAllInterfaces(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *config) defaultUserNoPass() bool {
+	return c.requirePass == "" || c.defaultNoPass
+}
+
+// parseConfig parses a Redis config. Returns nil for Sentinel configs.
+func parseConfig(data []byte) *config {
+	cfg := &config{protectedMode: true}
+	s := bufio.NewScanner(bytes.NewReader(data))
+	for s.Scan() {
+		line := strings.TrimSpace(s.Text())
+		if line == "" || line[0] == '#' {
+			continue
+		}
+		args := strings.Fields(line)
+		for i, a := range args {
+			args[i] = strings.Trim(a, `"'`)
+		}
+		directive := strings.ToLower(args[0])
+		args = args[1:]
+		switch directive {
+		case "bind":
+			cfg.bind = args
+		case "protected-mode":
+			cfg.protectedMode = len(args) == 0 || strings.ToLower(args[0]) != "no"
+		case "requirepass":
+			cfg.requirePass = ""
+			if len(args) > 0 {
+				cfg.requirePass = args[0]
+			}
+		case "user":
+			if len(args) == 0 {
+				continue
+			}
+			cfg.parseUser(args[0], args[1:])
+		case "sentinel":
+			return nil
+		}
+	}
+	return cfg
+}
+
+// parseUser checks the ACL rules of a user for missing or weak passwords.
+// See https://redis.io/docs/latest/operate/oss_and_stack/management/security/acl/
+func (c *config) parseUser(name string, rules []string) {
+	enabled := false
+	noPass := false
+	weak := false
+	for _, r := range rules {
+		switch {
+		case r == "on":
+			enabled = true
+		case r == "off":
+			enabled = false
+		case r == "nopass":
+			noPass = true
+		case r == "resetpass":
+			noPass = false
+			weak = false
+		case strings.HasPrefix(r, ">"):
+			noPass = false
+			weak = weak || dbconfig.IsWeakPassword(name, r[1:])
+		}
+	}
+	if !enabled {
+		return
+	}
+	if name == "default" && noPass {
+		c.defaultNoPass = true
+	}
+	if weak {
+		c.weakUsers = append(c.weakUsers, name)
+	}
+}
@@ -0,0 +1,130 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redisconfig_test
+
+import (
+	"context"
+	"testing"
+	"testing/fstest"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/osv-scalibr/detector/misconfig/redisconfig"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/packageindex"
+)
+
+func finding(adv *inventory.GenericFindingAdvisory, extra string) *inventory.GenericFinding {
+	return &inventory.GenericFinding{
+		Adv:    adv,
+		Target: &inventory.GenericFindingTargetDetails{Extra: extra},
+	}
+}
+
+func TestScanFS(t *testing.T) {
+	det := redisconfig.Detector{}
+	advs := det.DetectedFinding().GenericFindings
+	passwordAdv, noAuthAdv, bindAdv := advs[0].Adv, advs[1].Adv, advs[2].Adv
+
+	px, _ := packageindex.New([]*extractor.Package{})
+	testCases := []struct {
+		desc         string
+		files        map[string]string
+		wantFindings []*inventory.GenericFinding
+	}{
+		{
+			desc:         "no_config",
+			wantFindings: nil,
+		},
+		{
+			desc: "default_config",
+			files: map[string]string{
+				"etc/redis/redis.conf": "# bind 0.0.0.0\nbind 127.0.0.1 -::1\nprotected-mode yes\nport 6379\n# requirepass foobared\n",
+			},
+			wantFindings: nil,
+		},
+		{
+			desc: "no_bind_with_protected_mode",
+			files: map[string]string{
+				"etc/redis.conf": "port 6379\n",
+			},
+			wantFindings: nil,
+		},
+		{
+			desc: "no_authentication",
+			files: map[string]string{
+				"usr/local/etc/redis/redis.conf": "bind * -::*\nprotected-mode no\nrequirepass \"\"\n",
+			},
+			wantFindings: []*inventory.GenericFinding{
+				finding(bindAdv, "/usr/local/etc/redis/redis.conf: bind * -::*"),
+				finding(noAuthAdv, "/usr/local/etc/redis/redis.conf: protected-mode no, no password for the default user"),
+			},
+		},
+		{
+			desc: "strong_password",
+			files: map[string]string{
+				"etc/redis/redis.conf": "bind 0.0.0.0\nprotected-mode no\nrequirepass Zq8#mV2!kP9xLw4t\n",
+			},
+			wantFindings: []*inventory.GenericFinding{
+				finding(bindAdv, "/etc/redis/redis.conf: bind 0.0.0.0"),
+			},
+		},
+		{
+			desc: "weak_passwords",
+			files: map[string]string{
+				"etc/redis/redis.conf": "bind 10.0.0.5\nrequirepass foobared\n" +
+					"user admin on >admin ~* +@all\n" +
+					"user app on >Zq8#mV2!kP9xLw4t ~app:* +@read\n" +
+					"user legacy off >password ~* +@all\n",
+			},
+			wantFindings: []*inventory.GenericFinding{
+				finding(passwordAdv, "/etc/redis/redis.conf: requirepass"),
+				finding(passwordAdv, "/etc/redis/redis.conf: user admin"),
+			},
+		},
+		{
+			desc: "default_user_without_password",
+			files: map[string]string{
+				"etc/redis/redis.conf": "protected-mode no\nrequirepass Zq8#mV2!kP9xLw4t\nuser default on nopass ~* +@all\n",
+			},
+			wantFindings: []*inventory.GenericFinding{
+				finding(noAuthAdv, "/etc/redis/redis.conf: protected-mode no, no password for the default user"),
+			},
+		},
+		{
+			desc: "sentinel_config",
+			files: map[string]string{
+				"etc/redis/sentinel.conf": "port 26379\nprotected-mode no\nsentinel monitor mymaster 127.0.0.1 6379 2\n",
+			},
+			wantFindings: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			fsys := fstest.MapFS{}
+			for path, content := range tc.files {
+				fsys[path] = &fstest.MapFile{Data: []byte(content)}
+			}
+			got, err := det.ScanFS(context.Background(), fsys, px)
+			if err != nil {
+				t.Fatalf("ScanFS(%s): %v", tc.desc, err)
+			}
+			if diff := cmp.Diff(tc.wantFindings, got.GenericFindings); diff != "" {
+				t.Errorf("ScanFS(%s): unexpected findings (-want +got):\n%s", tc.desc, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,170 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package unsafepickle implements a detector for pickle-based machine learning
+// models. Loading a pickle runs the Python callables it references, so these
+// models can execute arbitrary code.
+package unsafepickle
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/google/osv-scalibr/detector"
+	"github.com/google/osv-scalibr/extractor/filesystem/misc/mlmodel"
+	scalibrfs "github.com/google/osv-scalibr/fs"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/packageindex"
+	"github.com/google/osv-scalibr/plugin"
+)
+
+// Name of the detector.
+const Name = "mlmodel/unsafepickle"
+
+// dangerousModules are Python modules whose callables give a pickle access to
+// the shell, the file system, the network or the interpreter itself.
+var dangerousModules = []string{
+	"os", "posix", "nt", "subprocess", "sys", "shutil", "socket", "runpy", "pty",
+	"importlib", "code", "ctypes", "webbrowser", "asyncio", "multiprocessing",
+	"urllib", "requests", "httplib", "http.client", "pickle", "_pickle", "marshal",
+}
+
+// dangerousBuiltins are the builtins that evaluate code or import modules.
+var dangerousBuiltins = []string{
+	"eval", "exec", "execfile", "compile", "open", "getattr", "apply", "__import__", "breakpoint",
+}
+
+// Detector is a SCALIBR Detector for pickle-based ML models.
+type Detector struct{}
+
+// New returns a detector.
+func New() detector.Detector {
+	return &Detector{}
+}
+
+// Name of the detector.
+func (Detector) Name() string { return Name }
+
+// Version of the detector.
+func (Detector) Version() int { return 0 }
+
+// Requirements of the detector.
+func (Detector) Requirements() *plugin.Capabilities { return &plugin.Capabilities{} }
+
+// RequiredExtractors returns the ML model extractor.
+func (Detector) RequiredExtractors() []string { return []string{mlmodel.Name} }
+
+// DetectedFinding returns generic vulnerability information about what is detected.
+func (d Detector) DetectedFinding() inventory.Finding {
+	return inventory.Finding{GenericFindings: []*inventory.GenericFinding{
+		pickleFormatFinding(nil),
+		dangerousImportsFinding(nil),
+	}}
+}
+
+func pickleFormatFinding(target *inventory.GenericFindingTargetDetails) *inventory.GenericFinding {
+	return &inventory.GenericFinding{
+		Adv: &inventory.GenericFindingAdvisory{
+			ID: &inventory.AdvisoryID{
+				Publisher: "SCALIBR",
+				Reference: "ml-model-pickle-format",
+			},
+			Title: "ML model is stored in a pickle-based format",
+			Description: "The model file is serialized with Python's pickle module, e.g. by " +
+				"torch.save() or joblib.dump(). Loading a pickle runs the callables it " +
+				"references, so a tampered model file can execute arbitrary code.",
+			Recommendation: "Convert the model to a format that doesn't contain code, such as " +
+				"safetensors or ONNX, or load it with torch.load(weights_only=True). Only load " +
+				"pickle-based models from trusted sources.",
+			Sev: inventory.SeverityMedium,
+		},
+		Target: target,
+	}
+}
+
+func dangerousImportsFinding(target *inventory.GenericFindingTargetDetails) *inventory.GenericFinding {
+	return &inventory.GenericFinding{
+		Adv: &inventory.GenericFindingAdvisory{
+			ID: &inventory.AdvisoryID{
+				Publisher: "SCALIBR",
+				Reference: "ml-model-pickle-dangerous-imports",
+			},
+			Title: "ML model executes dangerous code when loaded",
+			Description: "The pickle-based model file references Python callables that run " +
+				"shell commands, access files or the network, or evaluate code, or hides the " +
+				"callables it imports. Loading the model executes them.",
+			Recommendation: "Don't load the model. Investigate where the file came from and " +
+				"replace it with a model from a trusted source.",
+			Sev: inventory.SeverityCritical,
+		},
+		Target: target,
+	}
+}
+
+// Scan checks the extracted ML models for pickle-based formats.
+func (d Detector) Scan(ctx context.Context, scanRoot *scalibrfs.ScanRoot, px *packageindex.PackageIndex) (inventory.Finding, error) {
+	var findings []*inventory.GenericFinding
+	for _, pkg := range px.GetAll() {
+		if err := ctx.Err(); err != nil {
+			return inventory.Finding{}, err
+		}
+		m, ok := pkg.Metadata.(*mlmodel.Metadata)
+		if !ok || !m.IsPickleBased() || len(pkg.Locations) == 0 {
+			continue
+		}
+
+		location := pkg.Locations[0]
+		if dangerous := dangerousImports(m.Imports); len(dangerous) > 0 {
+			findings = append(findings, dangerousImportsFinding(&inventory.GenericFindingTargetDetails{
+				Extra: fmt.Sprintf("%s: imports %s", location, strings.Join(dangerous, ", ")),
+			}))
+			continue
+		}
+		findings = append(findings, pickleFormatFinding(&inventory.GenericFindingTargetDetails{
+			Extra: fmt.Sprintf("%s: %s", location, m.Format),
+		}))
+	}
+	return inventory.Finding{GenericFindings: findings}, nil
+}
+
+// dangerousImports returns the imports that give the pickle code execution or
+// can't be determined statically.
+func dangerousImports(imports []string) []string {
+	var result []string
+	for _, imp := range imports {
+		if isDangerous(imp) {
+			result = append(result, imp)
+		}
+	}
+	return result
+}
+
+func isDangerous(imp string) bool {
+	if imp == mlmodel.UnknownImport {
+		return true
+	}
+	for _, module := range dangerousModules {
+		if strings.HasPrefix(imp, module+".") {
+			return true
+		}
+	}
+	for _, builtins := range []string{"builtins.", "__builtin__."} {
+		if name, ok := strings.CutPrefix(imp, builtins); ok && slices.Contains(dangerousBuiltins, name) {
+			return true
+		}
+	}
+	return false
+}
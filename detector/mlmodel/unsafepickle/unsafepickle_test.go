@@ -0,0 +1,150 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package unsafepickle_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/osv-scalibr/detector/mlmodel/unsafepickle"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem/misc/mlmodel"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/packageindex"
+	"github.com/google/osv-scalibr/purl"
+)
+
+func TestScan(t *testing.T) {
+	det := unsafepickle.Detector{}
+	advs := det.DetectedFinding().GenericFindings
+	formatAdv, importsAdv := advs[0].Adv, advs[1].Adv
+
+	testCases := []struct {
+		desc         string
+		pkgs         []*extractor.Package
+		wantFindings []*inventory.GenericFinding
+	}{
+		{
+			desc: "no_models",
+			pkgs: []*extractor.Package{{
+				Name:      "requests",
+				Version:   "2.31.0",
+				PURLType:  purl.TypePyPi,
+				Locations: []string{"usr/lib/python3/dist-packages/requests-2.31.0.dist-info/METADATA"},
+			}},
+			wantFindings: nil,
+		},
+		{
+			desc: "safetensors_model",
+			pkgs: []*extractor.Package{{
+				Name:      "model.safetensors",
+				PURLType:  purl.TypeGeneric,
+				Metadata:  &mlmodel.Metadata{Format: mlmodel.FormatSafetensors, Framework: "pytorch"},
+				Locations: []string{"models/model.safetensors"},
+			}},
+			wantFindings: nil,
+		},
+		{
+			desc: "benign_pytorch_model",
+			pkgs: []*extractor.Package{{
+				Name:     "model.pt",
+				PURLType: purl.TypeGeneric,
+				Metadata: &mlmodel.Metadata{
+					Format:    mlmodel.FormatPyTorchZip,
+					Framework: "pytorch",
+					Imports:   []string{"collections.OrderedDict", "torch._utils._rebuild_tensor_v2"},
+				},
+				Locations: []string{"models/model.pt"},
+			}},
+			wantFindings: []*inventory.GenericFinding{{
+				Adv: formatAdv,
+				Target: &inventory.GenericFindingTargetDetails{
+					Extra: "models/model.pt: pytorch-zip",
+				},
+			}},
+		},
+		{
+			desc: "malicious_pickle",
+			pkgs: []*extractor.Package{{
+				Name:     "model.pkl",
+				PURLType: purl.TypeGeneric,
+				Metadata: &mlmodel.Metadata{
+					Format:  mlmodel.FormatPickle,
+					Imports: []string{"sklearn.linear_model._base.LinearRegression", "posix.system", "builtins.exec"},
+				},
+				Locations: []string{"models/model.pkl"},
+			}},
+			wantFindings: []*inventory.GenericFinding{{
+				Adv: importsAdv,
+				Target: &inventory.GenericFindingTargetDetails{
+					Extra: "models/model.pkl: imports posix.system, builtins.exec",
+				},
+			}},
+		},
+		{
+			desc: "obfuscated_import",
+			pkgs: []*extractor.Package{{
+				Name:     "model.pkl",
+				PURLType: purl.TypeGeneric,
+				Metadata: &mlmodel.Metadata{
+					Format:  mlmodel.FormatPickle,
+					Imports: []string{mlmodel.UnknownImport},
+				},
+				Locations: []string{"models/model.pkl"},
+			}},
+			wantFindings: []*inventory.GenericFinding{{
+				Adv: importsAdv,
+				Target: &inventory.GenericFindingTargetDetails{
+					Extra: "models/model.pkl: imports <unknown>",
+				},
+			}},
+		},
+		{
+			desc: "safe_builtins",
+			pkgs: []*extractor.Package{{
+				Name:     "model.pkl",
+				PURLType: purl.TypeGeneric,
+				Metadata: &mlmodel.Metadata{
+					Format:  mlmodel.FormatPickle,
+					Imports: []string{"builtins.set", "numpy.core.multiarray._reconstruct"},
+				},
+				Locations: []string{"models/model.pkl"},
+			}},
+			wantFindings: []*inventory.GenericFinding{{
+				Adv: formatAdv,
+				Target: &inventory.GenericFindingTargetDetails{
+					Extra: "models/model.pkl: pickle",
+				},
+			}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			px, err := packageindex.New(tc.pkgs)
+			if err != nil {
+				t.Fatalf("packageindex.New(): %v", err)
+			}
+			finding, err := det.Scan(context.Background(), nil, px)
+			if err != nil {
+				t.Fatalf("det.Scan(): %v", err)
+			}
+			if diff := cmp.Diff(tc.wantFindings, finding.GenericFindings); diff != "" {
+				t.Errorf("det.Scan(): unexpected findings (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -26,15 +26,21 @@ import (
 
 	scalibrfs "github.com/google/osv-scalibr/fs"
 	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/log"
 	"github.com/google/osv-scalibr/plugin"
+	"github.com/google/osv-scalibr/stats/resourceusage"
 )
 
 var (
 	// ErrNoDirectFS is returned when an enricher requires direct filesystem access but the scan root is nil.
 	ErrNoDirectFS = errors.New("enrichment requires direct filesystem access but scan root is nil")
 
-	// EnricherOrder describes the order in which specific enrichers need to run in.
-	// TODO(b/416106602): Use required enrichers instead of a global ordering list.
+	// ErrDependencyCycle is returned when the enabled enrichers depend on each other.
+	ErrDependencyCycle = errors.New("enrichers have a dependency cycle")
+
+	// EnricherOrder describes the order in which specific enrichers run in when
+	// they don't declare their dependencies on each other. Dependencies declared
+	// through RequiredPlugins or RunAfter take precedence.
 	EnricherOrder = []string{
 		"reachability/java",
 		"vulnmatch/osvdev",
@@ -52,10 +58,22 @@ type Enricher interface {
 	Enrich(ctx context.Context, input *ScanInput, inv *inventory.Inventory) error
 }
 
+// RunAfter can be implemented by enrichers that consume the output of other
+// enrichers without requiring them to be enabled.
+type RunAfter interface {
+	// RunAfter returns the names of the enrichers that need to run before this
+	// one if they are enabled.
+	RunAfter() []string
+}
+
 // Config for running enrichers.
 type Config struct {
 	Enrichers []Enricher
 	ScanRoot  *scalibrfs.ScanRoot
+	// Optional: How long a single plugin can run before it's cancelled.
+	PluginTimeouts *plugin.Timeouts
+	// Optional: Records the resources used by each enricher.
+	ResourceTracker *resourceusage.Tracker
 }
 
 // ScanInput provides information for the enricher about the scan.
@@ -71,7 +89,9 @@ func Run(ctx context.Context, config *Config, inventory *inventory.Inventory) ([
 		return statuses, nil
 	}
 
-	orderEnrichers(config.Enrichers)
+	if err := orderEnrichers(config.Enrichers); err != nil {
+		return nil, err
+	}
 
 	for _, e := range config.Enrichers {
 		capabilities := e.Requirements()
@@ -95,15 +115,27 @@ func Run(ctx context.Context, config *Config, inventory *inventory.Inventory) ([
 	}
 
 	for _, e := range config.Enrichers {
-		err := e.Enrich(ctx, input, inventory)
+		if ctx.Err() != nil {
+			return statuses, ctx.Err()
+		}
+		ectx := log.NewContext(ctx, log.KeyPlugin, e.Name())
+		run := config.ResourceTracker.StartPlugin(e.Name())
+		err := config.PluginTimeouts.Run(ectx, e.Name(), func(ctx context.Context) error {
+			return e.Enrich(ctx, input, inventory)
+		})
+		run.Stop(0)
 		// TODO - b/410630503: Support partial success.
-		statuses = append(statuses, plugin.StatusFromErr(e, false, err))
+		// Enrichments done before a timeout are kept.
+		statuses = append(statuses, plugin.StatusFromErr(e, errors.Is(err, plugin.ErrTimeout), err))
 	}
 	return statuses, nil
 }
 
-// Orders the enrichers to make sure they're run in the order specified by EnricherOrder.
-func orderEnrichers(enrichers []Enricher) {
+// orderEnrichers topologically sorts the enrichers so that every enricher runs
+// after the enabled enrichers it depends on. Enrichers that are ready to run at
+// the same time are ordered by EnricherOrder, then by name to keep the
+// ordering deterministic.
+func orderEnrichers(enrichers []Enricher) error {
 	nameToPlace := make(map[string]int)
 	for i, name := range EnricherOrder {
 		nameToPlace[name] = i
@@ -115,12 +147,68 @@ func orderEnrichers(enrichers []Enricher) {
 		// Enrichers not in the explicit list can run in any order.
 		return len(nameToPlace)
 	}
-
-	slices.SortFunc(enrichers, func(a Enricher, b Enricher) int {
+	less := func(a Enricher, b Enricher) int {
 		return cmp.Or(
 			cmp.Compare(getPlace(a.Name()), getPlace(b.Name())),
-			// Use the name as a tie-breaker to keep ordering deterministic.
 			strings.Compare(a.Name(), b.Name()),
 		)
-	})
+	}
+
+	enabled := make(map[string]bool)
+	for _, e := range enrichers {
+		enabled[e.Name()] = true
+	}
+	// dependents maps the name of an enricher to the indices of the enrichers
+	// that need to run after it.
+	dependents := make(map[string][]int)
+	pending := make([]int, len(enrichers))
+	for i, e := range enrichers {
+		deps := e.RequiredPlugins()
+		if ra, ok := e.(RunAfter); ok {
+			deps = append(slices.Clone(deps), ra.RunAfter()...)
+		}
+		seen := make(map[string]bool)
+		for _, dep := range deps {
+			// Required extractors and detectors always run before the enrichers.
+			if !enabled[dep] || seen[dep] || dep == e.Name() {
+				continue
+			}
+			seen[dep] = true
+			dependents[dep] = append(dependents[dep], i)
+			pending[i]++
+		}
+	}
+
+	var ready []int
+	for i := range enrichers {
+		if pending[i] == 0 {
+			ready = append(ready, i)
+		}
+	}
+	ordered := make([]Enricher, 0, len(enrichers))
+	for len(ready) > 0 {
+		slices.SortFunc(ready, func(a, b int) int { return less(enrichers[a], enrichers[b]) })
+		next := enrichers[ready[0]]
+		ready = ready[1:]
+		ordered = append(ordered, next)
+		for _, d := range dependents[next.Name()] {
+			pending[d]--
+			if pending[d] == 0 {
+				ready = append(ready, d)
+			}
+		}
+	}
+
+	if len(ordered) != len(enrichers) {
+		var cycle []string
+		for i, e := range enrichers {
+			if pending[i] > 0 {
+				cycle = append(cycle, e.Name())
+			}
+		}
+		slices.Sort(cycle)
+		return fmt.Errorf("%w: %s", ErrDependencyCycle, strings.Join(cycle, ", "))
+	}
+	copy(enrichers, ordered)
+	return nil
 }
@@ -152,7 +152,7 @@ func TestRun(t *testing.T) {
 			},
 			inv: inventory1,
 			want: []*plugin.Status{
-				{Name: "enricher1", Version: 1, Status: &plugin.ScanStatus{Status: plugin.ScanStatusFailed, FailureReason: "some error"}},
+				{Name: "enricher1", Version: 1, Status: &plugin.ScanStatus{Status: plugin.ScanStatusFailed, FailureReason: "some error", ErrorCounts: []*plugin.ErrorCount{{Category: plugin.ErrorCategoryOther, Count: 1}}}},
 				{Name: "enricher2", Version: 2, Status: &plugin.ScanStatus{Status: plugin.ScanStatusSucceeded}},
 			},
 			wantInv: inventory3,
@@ -248,3 +248,102 @@ func TestRunEnricherOrdering(t *testing.T) {
 		t.Errorf("Run(%+v) returned an unexpected diff of mutated inventory (-want +got): %v", cfg, diff)
 	}
 }
+
+// Records the order in which it runs.
+type fakeDependentEnricher struct {
+	name     string
+	required []string
+	runAfter []string
+	order    *[]string
+}
+
+func (e fakeDependentEnricher) Name() string                     { return e.name }
+func (fakeDependentEnricher) Version() int                       { return 0 }
+func (fakeDependentEnricher) Requirements() *plugin.Capabilities { return &plugin.Capabilities{} }
+func (e fakeDependentEnricher) RequiredPlugins() []string        { return e.required }
+func (e fakeDependentEnricher) RunAfter() []string               { return e.runAfter }
+func (e fakeDependentEnricher) Enrich(_ context.Context, _ *enricher.ScanInput, _ *inventory.Inventory) error {
+	*e.order = append(*e.order, e.name)
+	return nil
+}
+
+func TestRunEnricherDependencies(t *testing.T) {
+	type enricherDef struct {
+		name     string
+		required []string
+		runAfter []string
+	}
+	tests := []struct {
+		desc      string
+		enrichers []enricherDef
+		wantOrder []string
+		wantErr   error
+	}{
+		{
+			desc: "required_plugins",
+			enrichers: []enricherDef{
+				{name: "a", required: []string{"b"}},
+				{name: "b", required: []string{"c", "extractor/not-an-enricher"}},
+				{name: "c"},
+			},
+			wantOrder: []string{"c", "b", "a"},
+		},
+		{
+			desc: "run_after",
+			enrichers: []enricherDef{
+				{name: "a", runAfter: []string{"c"}},
+				{name: "b"},
+				{name: "c"},
+			},
+			wantOrder: []string{"b", "c", "a"},
+		},
+		{
+			desc: "run_after_disabled_enricher",
+			enrichers: []enricherDef{
+				{name: "a", runAfter: []string{"disabled"}},
+				{name: "b"},
+			},
+			wantOrder: []string{"a", "b"},
+		},
+		{
+			desc: "dependencies_override_enricher_order",
+			enrichers: []enricherDef{
+				{name: "vulnmatch/osvdev", runAfter: []string{"vex/filter"}},
+				{name: "vex/filter"},
+			},
+			wantOrder: []string{"vex/filter", "vulnmatch/osvdev"},
+		},
+		{
+			desc: "cycle",
+			enrichers: []enricherDef{
+				{name: "a", runAfter: []string{"b"}},
+				{name: "b", required: []string{"a"}},
+				{name: "c"},
+			},
+			wantErr: enricher.ErrDependencyCycle,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			var gotOrder []string
+			cfg := &enricher.Config{}
+			for _, e := range tc.enrichers {
+				cfg.Enrichers = append(cfg.Enrichers, fakeDependentEnricher{
+					name:     e.name,
+					required: e.required,
+					runAfter: e.runAfter,
+					order:    &gotOrder,
+				})
+			}
+
+			_, err := enricher.Run(context.Background(), cfg, &inventory.Inventory{})
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("Run() error: got %v, want %v", err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.wantOrder, gotOrder); diff != "" {
+				t.Errorf("Run() unexpected enricher order (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
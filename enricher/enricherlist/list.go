@@ -23,10 +23,16 @@ import (
 	"github.com/google/osv-scalibr/enricher"
 	"github.com/google/osv-scalibr/enricher/baseimage"
 	"github.com/google/osv-scalibr/enricher/license"
+	"github.com/google/osv-scalibr/enricher/projectinfo"
 	"github.com/google/osv-scalibr/enricher/reachability/java"
+	"github.com/google/osv-scalibr/enricher/remediation/fixedversion"
 	"github.com/google/osv-scalibr/enricher/secrets"
+	"github.com/google/osv-scalibr/enricher/transitivedependency/pomxml"
 	"github.com/google/osv-scalibr/enricher/transitivedependency/requirements"
+	"github.com/google/osv-scalibr/enricher/vex/baseline"
 	"github.com/google/osv-scalibr/enricher/vex/filter"
+	"github.com/google/osv-scalibr/enricher/vex/suppression"
+	"github.com/google/osv-scalibr/enricher/vulnmatch/osvlocal"
 )
 
 // InitFn is the enricher initializer function.
@@ -47,14 +53,27 @@ var (
 		license.Name: {license.New},
 	}
 
+	// ProjectInfo enrichers.
+	ProjectInfo = InitMap{
+		projectinfo.Name: {projectinfo.NewDefault},
+	}
+
 	// VulnMatching enrichers.
 	VulnMatching = InitMap{
 		// TODO(https://github.com/google/osv-scalibr/issues/858): Add OSV.dev enricher.
+		osvlocal.Name: {osvlocal.NewDefault},
 	}
 
 	// VEX related enrichers.
 	VEX = InitMap{
-		filter.Name: {filter.New},
+		baseline.Name:    {baseline.NewDefault},
+		suppression.Name: {suppression.NewDefault},
+		filter.Name:      {filter.New},
+	}
+
+	// Remediation enrichers.
+	Remediation = InitMap{
+		fixedversion.Name: {fixedversion.New},
 	}
 
 	// Secrets enrichers.
@@ -70,6 +89,7 @@ var (
 	// TransitiveDependency enrichers.
 	TransitiveDependency = InitMap{
 		requirements.Name: {requirements.NewDefault},
+		pomxml.Name:       {pomxml.NewDefault},
 	}
 
 	// Default enrichers.
@@ -80,15 +100,19 @@ var (
 		LayerDetails,
 		VulnMatching,
 		VEX,
+		Remediation,
 		Secrets,
 		License,
+		ProjectInfo,
 		Reachability,
 		TransitiveDependency,
 	)
 
 	enricherNames = concat(All, InitMap{
 		"license":              vals(License),
+		"projectinfo":          vals(ProjectInfo),
 		"vex":                  vals(VEX),
+		"remediation":          vals(Remediation),
 		"vulnmatch":            vals(VulnMatching),
 		"layerdetails":         vals(LayerDetails),
 		"secrets":              vals(Secrets),
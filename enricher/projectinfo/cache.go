@@ -0,0 +1,89 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package projectinfo
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+
+	depsdevpb "deps.dev/api/v3"
+	"github.com/google/osv-scalibr/extractor"
+)
+
+// cache is the content of the cache file.
+type cache struct {
+	// The deps.dev data of package versions, keyed by cacheKey. Versions that
+	// are unknown to deps.dev have empty entries.
+	Versions map[string]*versionInfo `json:"versions"`
+}
+
+// versionInfo is the deps.dev data of a package version.
+type versionInfo struct {
+	Licenses    []string               `json:"licenses,omitempty"`
+	ProjectInfo *extractor.ProjectInfo `json:"projectInfo,omitempty"`
+}
+
+// cacheKey returns the key of a package version in the cache, e.g.
+// "NPM:express@4.17.1".
+func cacheKey(k *depsdevpb.VersionKey) string {
+	return fmt.Sprintf("%s:%s@%s", k.GetSystem(), k.GetName(), k.GetVersion())
+}
+
+// loadCache reads the cache file. A missing file results in an empty cache.
+func loadCache(path string) (*cache, error) {
+	c := &cache{Versions: map[string]*versionInfo{}}
+	if path == "" {
+		return c, nil
+	}
+	content, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return c, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cache file: %w", err)
+	}
+	if err := json.Unmarshal(content, c); err != nil {
+		return nil, fmt.Errorf("failed to parse cache file %s: %w", path, err)
+	}
+	if c.Versions == nil {
+		c.Versions = map[string]*versionInfo{}
+	}
+	return c, nil
+}
+
+// save writes the cache into the given file. Versions that couldn't be
+// queried are left out.
+func (c *cache) save(path string) error {
+	if path == "" {
+		return nil
+	}
+	versions := make(map[string]*versionInfo, len(c.Versions))
+	for k, v := range c.Versions {
+		if v != nil {
+			versions[k] = v
+		}
+	}
+	content, err := json.MarshalIndent(&cache{Versions: versions}, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, content, 0644); err != nil {
+		return fmt.Errorf("failed to write cache file: %w", err)
+	}
+	return nil
+}
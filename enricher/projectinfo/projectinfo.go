@@ -0,0 +1,275 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package projectinfo contains an Enricher that adds declared licenses,
+// dependency counts and OpenSSF Scorecard results to software packages by
+// querying deps.dev.
+package projectinfo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	depsdevpb "deps.dev/api/v3"
+	"github.com/google/osv-scalibr/clients/datasource"
+	"github.com/google/osv-scalibr/depsdev"
+	"github.com/google/osv-scalibr/enricher"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/plugin"
+	scalibrversion "github.com/google/osv-scalibr/version"
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// Name is the unique name of this Enricher.
+	Name    = "projectinfo/depsdev"
+	version = 0
+)
+
+const maxConcurrentRequests = 100
+
+var _ enricher.Enricher = &Enricher{}
+
+// Config is the configuration for the Enricher.
+type Config struct {
+	// Optional: A JSON file that caches the deps.dev data across scans. It's
+	// read before querying deps.dev and updated afterwards.
+	CacheFile string
+	// Optional: Only use the data from the cache file and don't query deps.dev.
+	Offline bool
+}
+
+// Enricher adds license, dependency and source project data to software
+// packages by querying deps.dev.
+type Enricher struct {
+	client    Client
+	cacheFile string
+	offline   bool
+}
+
+// New returns an Enricher with the given config.
+func New(cfg *Config) *Enricher {
+	return &Enricher{cacheFile: cfg.CacheFile, offline: cfg.Offline}
+}
+
+// NewDefault returns an Enricher that queries deps.dev without a cache file.
+func NewDefault() enricher.Enricher {
+	return New(&Config{})
+}
+
+// NewWithClient returns an Enricher which uses a specified deps.dev client.
+func NewWithClient(cfg *Config, c Client) enricher.Enricher {
+	e := New(cfg)
+	e.client = c
+	return e
+}
+
+// Name of the Enricher.
+func (Enricher) Name() string {
+	return Name
+}
+
+// Version of the Enricher.
+func (Enricher) Version() int {
+	return version
+}
+
+// Requirements of the Enricher. Needs network access to query deps.dev unless
+// it only uses the cache file.
+func (e Enricher) Requirements() *plugin.Capabilities {
+	if e.offline {
+		return &plugin.Capabilities{}
+	}
+	return &plugin.Capabilities{
+		Network: plugin.NetworkOnline,
+	}
+}
+
+// RequiredPlugins returns the plugins that are required to be enabled for this
+// Enricher to run. While it works on the results of other extractors,
+// the Enricher itself can run independently.
+func (Enricher) RequiredPlugins() []string {
+	return []string{}
+}
+
+// Enrich adds the deps.dev data of the package versions to the packages.
+// Licenses from deps.dev replace the ones found by the extractors.
+func (e *Enricher) Enrich(ctx context.Context, _ *enricher.ScanInput, inv *inventory.Inventory) error {
+	c, err := loadCache(e.cacheFile)
+	if err != nil {
+		return err
+	}
+
+	keys := make([]string, len(inv.Packages))
+	var missing []*depsdevpb.VersionKey
+	for i, pkg := range inv.Packages {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		system, ok := depsdev.System[pkg.PURLType]
+		if !ok {
+			continue
+		}
+		k := versionKey(system, pkg.Name, pkg.Version)
+		keys[i] = cacheKey(k)
+		if _, ok := c.Versions[keys[i]]; ok {
+			continue
+		}
+		// Mark the version as requested so it's only queried once.
+		c.Versions[keys[i]] = nil
+		missing = append(missing, k)
+	}
+
+	var fetchErr error
+	if !e.offline && len(missing) > 0 {
+		if e.client == nil {
+			depsDevAPIClient, err := datasource.NewCachedInsightsClient(depsdev.DepsdevAPI, "osv-scalibr/"+scalibrversion.ScannerVersion)
+			if err != nil {
+				return fmt.Errorf("cannot connect with deps.dev %w", err)
+			}
+			e.client = depsDevAPIClient
+		}
+		fetchErr = e.fetch(ctx, missing, c)
+	}
+
+	for i, pkg := range inv.Packages {
+		info := c.Versions[keys[i]]
+		if keys[i] == "" || info == nil {
+			continue
+		}
+		if len(info.Licenses) > 0 {
+			pkg.Licenses = info.Licenses
+		}
+		pkg.ProjectInfo = info.ProjectInfo
+	}
+
+	return errors.Join(fetchErr, c.save(e.cacheFile))
+}
+
+// fetch queries deps.dev for the given versions concurrently and adds the
+// results to the cache.
+func (e *Enricher) fetch(ctx context.Context, keys []*depsdevpb.VersionKey, c *cache) error {
+	var mu sync.Mutex
+	scorecards := datasource.NewRequestCache[string, *extractor.Scorecard]()
+	g, ctx := errgroup.WithContext(ctx)
+	g.SetLimit(maxConcurrentRequests)
+	for _, k := range keys {
+		g.Go(func() error {
+			info, err := e.versionInfo(ctx, k, scorecards)
+			if err != nil {
+				return err
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			c.Versions[cacheKey(k)] = info
+			return nil
+		})
+	}
+	return g.Wait()
+}
+
+// versionInfo returns the deps.dev data of a package version.
+func (e *Enricher) versionInfo(ctx context.Context, k *depsdevpb.VersionKey, scorecards *datasource.RequestCache[string, *extractor.Scorecard]) (*versionInfo, error) {
+	v, err := e.client.GetVersion(ctx, &depsdevpb.GetVersionRequest{VersionKey: k})
+	if status.Code(err) == codes.NotFound {
+		return &versionInfo{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	info := &extractor.ProjectInfo{}
+	info.DirectDependencies, info.IndirectDependencies, err = e.dependencyCounts(ctx, k)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range v.GetRelatedProjects() {
+		if p.GetRelationType() == depsdevpb.ProjectRelationType_SOURCE_REPO {
+			info.SourceRepository = p.GetProjectKey().GetId()
+			break
+		}
+	}
+	if info.SourceRepository != "" {
+		info.Scorecard, err = scorecards.Get(info.SourceRepository, func() (*extractor.Scorecard, error) {
+			return e.scorecard(ctx, info.SourceRepository)
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &versionInfo{Licenses: v.GetLicenses(), ProjectInfo: info}, nil
+}
+
+// dependencyCounts returns the number of direct and indirect dependencies in
+// the resolved dependency graph of a package version.
+func (e *Enricher) dependencyCounts(ctx context.Context, k *depsdevpb.VersionKey) (int, int, error) {
+	deps, err := e.client.GetDependencies(ctx, &depsdevpb.GetDependenciesRequest{VersionKey: k})
+	if code := status.Code(err); code == codes.NotFound || code == codes.InvalidArgument {
+		// Not all systems have resolved dependency graphs.
+		return 0, 0, nil
+	}
+	if err != nil {
+		return 0, 0, err
+	}
+	var direct, indirect int
+	for _, n := range deps.GetNodes() {
+		switch n.GetRelation() {
+		case depsdevpb.DependencyRelation_DIRECT:
+			direct++
+		case depsdevpb.DependencyRelation_INDIRECT:
+			indirect++
+		}
+	}
+	return direct, indirect, nil
+}
+
+// scorecard returns the OpenSSF Scorecard of a source repository, or nil if
+// deps.dev doesn't have one.
+func (e *Enricher) scorecard(ctx context.Context, repo string) (*extractor.Scorecard, error) {
+	p, err := e.client.GetProject(ctx, &depsdevpb.GetProjectRequest{ProjectKey: &depsdevpb.ProjectKey{Id: repo}})
+	if status.Code(err) == codes.NotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	sc := p.GetScorecard()
+	if sc == nil {
+		return nil, nil
+	}
+	result := &extractor.Scorecard{
+		Date:         sc.GetDate().AsTime(),
+		OverallScore: float64(sc.GetOverallScore()),
+	}
+	for _, c := range sc.GetChecks() {
+		result.Checks = append(result.Checks, &extractor.ScorecardCheck{Name: c.GetName(), Score: int(c.GetScore())})
+	}
+	return result, nil
+}
+
+func versionKey(system depsdevpb.System, name string, version string) *depsdevpb.VersionKey {
+	if system == depsdevpb.System_GO {
+		version = "v" + version
+	}
+	return &depsdevpb.VersionKey{
+		System:  system,
+		Name:    name,
+		Version: version,
+	}
+}
@@ -0,0 +1,199 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package projectinfo_test
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	depsdevpb "deps.dev/api/v3"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/osv-scalibr/enricher/projectinfo"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/purl"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var scorecardDate = time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC)
+
+// fakeClient serves fixed deps.dev responses and counts the requests.
+type fakeClient struct {
+	versions map[string]*depsdevpb.Version
+	deps     map[string]*depsdevpb.Dependencies
+	projects map[string]*depsdevpb.Project
+	err      error
+
+	mu       sync.Mutex
+	requests int
+}
+
+func key(k *depsdevpb.VersionKey) string {
+	return k.GetSystem().String() + ":" + k.GetName() + "@" + k.GetVersion()
+}
+
+func (c *fakeClient) count() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.requests++
+}
+
+func (c *fakeClient) GetVersion(_ context.Context, in *depsdevpb.GetVersionRequest, _ ...grpc.CallOption) (*depsdevpb.Version, error) {
+	c.count()
+	if c.err != nil {
+		return nil, c.err
+	}
+	if v, ok := c.versions[key(in.GetVersionKey())]; ok {
+		return v, nil
+	}
+	return nil, status.Error(codes.NotFound, "not found")
+}
+
+func (c *fakeClient) GetDependencies(_ context.Context, in *depsdevpb.GetDependenciesRequest, _ ...grpc.CallOption) (*depsdevpb.Dependencies, error) {
+	c.count()
+	if d, ok := c.deps[key(in.GetVersionKey())]; ok {
+		return d, nil
+	}
+	return nil, status.Error(codes.NotFound, "not found")
+}
+
+func (c *fakeClient) GetProject(_ context.Context, in *depsdevpb.GetProjectRequest, _ ...grpc.CallOption) (*depsdevpb.Project, error) {
+	c.count()
+	if p, ok := c.projects[in.GetProjectKey().GetId()]; ok {
+		return p, nil
+	}
+	return nil, status.Error(codes.NotFound, "not found")
+}
+
+func newFakeClient() *fakeClient {
+	repo := &depsdevpb.Version_Project{
+		ProjectKey:   &depsdevpb.ProjectKey{Id: "github.com/expressjs/express"},
+		RelationType: depsdevpb.ProjectRelationType_SOURCE_REPO,
+	}
+	return &fakeClient{
+		versions: map[string]*depsdevpb.Version{
+			"NPM:express@4.17.1":   {Licenses: []string{"MIT"}, RelatedProjects: []*depsdevpb.Version_Project{repo}},
+			"NPM:express@4.18.0":   {Licenses: []string{"MIT"}, RelatedProjects: []*depsdevpb.Version_Project{repo}},
+			"PYPI:requests@2.26.0": {Licenses: []string{"Apache-2.0"}},
+		},
+		deps: map[string]*depsdevpb.Dependencies{
+			"NPM:express@4.17.1": {Nodes: []*depsdevpb.Dependencies_Node{
+				{Relation: depsdevpb.DependencyRelation_SELF},
+				{Relation: depsdevpb.DependencyRelation_DIRECT},
+				{Relation: depsdevpb.DependencyRelation_DIRECT},
+				{Relation: depsdevpb.DependencyRelation_INDIRECT},
+			}},
+		},
+		projects: map[string]*depsdevpb.Project{
+			"github.com/expressjs/express": {Scorecard: &depsdevpb.Project_Scorecard{
+				Date:         timestamppb.New(scorecardDate),
+				OverallScore: 7.5,
+				Checks: []*depsdevpb.Project_Scorecard_Check{
+					{Name: "Code-Review", Score: 9},
+					{Name: "Fuzzing", Score: -1},
+				},
+			}},
+		},
+	}
+}
+
+func testPackages() []*extractor.Package {
+	return []*extractor.Package{
+		{Name: "express", Version: "4.17.1", PURLType: purl.TypeNPM, Licenses: []string{"BSD"}},
+		{Name: "express", Version: "4.17.1", PURLType: purl.TypeNPM, Locations: []string{"other/package.json"}},
+		{Name: "express", Version: "4.18.0", PURLType: purl.TypeNPM},
+		{Name: "requests", Version: "2.26.0", PURLType: purl.TypePyPi, Licenses: []string{"Apache 2.0"}},
+		{Name: "unknown", Version: "1.0.0", PURLType: purl.TypeNPM, Licenses: []string{"ISC"}},
+		{Name: "openssl", Version: "3.0.11", PURLType: purl.TypeDebian},
+	}
+}
+
+func wantPackages() []*extractor.Package {
+	scorecard := &extractor.Scorecard{
+		Date:         scorecardDate,
+		OverallScore: 7.5,
+		Checks: []*extractor.ScorecardCheck{
+			{Name: "Code-Review", Score: 9},
+			{Name: "Fuzzing", Score: -1},
+		},
+	}
+	express := &extractor.ProjectInfo{
+		DirectDependencies:   2,
+		IndirectDependencies: 1,
+		SourceRepository:     "github.com/expressjs/express",
+		Scorecard:            scorecard,
+	}
+	return []*extractor.Package{
+		{Name: "express", Version: "4.17.1", PURLType: purl.TypeNPM, Licenses: []string{"MIT"}, ProjectInfo: express},
+		{Name: "express", Version: "4.17.1", PURLType: purl.TypeNPM, Locations: []string{"other/package.json"}, Licenses: []string{"MIT"}, ProjectInfo: express},
+		{Name: "express", Version: "4.18.0", PURLType: purl.TypeNPM, Licenses: []string{"MIT"}, ProjectInfo: &extractor.ProjectInfo{
+			SourceRepository: "github.com/expressjs/express",
+			Scorecard:        scorecard,
+		}},
+		{Name: "requests", Version: "2.26.0", PURLType: purl.TypePyPi, Licenses: []string{"Apache-2.0"}, ProjectInfo: &extractor.ProjectInfo{}},
+		{Name: "unknown", Version: "1.0.0", PURLType: purl.TypeNPM, Licenses: []string{"ISC"}},
+		{Name: "openssl", Version: "3.0.11", PURLType: purl.TypeDebian},
+	}
+}
+
+func TestEnrich(t *testing.T) {
+	client := newFakeClient()
+	cacheFile := filepath.Join(t.TempDir(), "depsdev.json")
+	inv := &inventory.Inventory{Packages: testPackages()}
+
+	e := projectinfo.NewWithClient(&projectinfo.Config{CacheFile: cacheFile}, client)
+	if err := e.Enrich(context.Background(), nil, inv); err != nil {
+		t.Fatalf("Enrich(): %v", err)
+	}
+	if diff := cmp.Diff(wantPackages(), inv.Packages); diff != "" {
+		t.Errorf("Enrich() returned unexpected packages (-want +got):\n%s", diff)
+	}
+	// The 3 distinct versions known to deps.dev need 2 requests each and the
+	// unknown one needs 1. The scorecard shared by 2 versions is requested once.
+	if want := 3*2 + 1 + 1; client.requests != want {
+		t.Errorf("Enrich() made %d deps.dev requests, want %d", client.requests, want)
+	}
+
+	// A second scan uses the cache file and doesn't need deps.dev.
+	offline := projectinfo.New(&projectinfo.Config{CacheFile: cacheFile, Offline: true})
+	inv = &inventory.Inventory{Packages: testPackages()}
+	if err := offline.Enrich(context.Background(), nil, inv); err != nil {
+		t.Fatalf("Enrich() with cache: %v", err)
+	}
+	if diff := cmp.Diff(wantPackages(), inv.Packages); diff != "" {
+		t.Errorf("Enrich() with cache returned unexpected packages (-want +got):\n%s", diff)
+	}
+}
+
+func TestEnrich_Error(t *testing.T) {
+	client := newFakeClient()
+	client.err = errors.New("unavailable")
+	inv := &inventory.Inventory{Packages: testPackages()}
+
+	e := projectinfo.NewWithClient(&projectinfo.Config{}, client)
+	if err := e.Enrich(context.Background(), nil, inv); !errors.Is(err, client.err) {
+		t.Errorf("Enrich() error: %v, want %v", err, client.err)
+	}
+	if diff := cmp.Diff(testPackages(), inv.Packages); diff != "" {
+		t.Errorf("Enrich() modified packages on error (-want +got):\n%s", diff)
+	}
+}
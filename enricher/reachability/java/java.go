@@ -30,6 +30,7 @@ import (
 	"slices"
 	"strings"
 
+	scalibrhttp "github.com/google/osv-scalibr/clients/http"
 	"github.com/google/osv-scalibr/enricher"
 	"github.com/google/osv-scalibr/extractor"
 	"github.com/google/osv-scalibr/extractor/filesystem/language/java/archive"
@@ -89,10 +90,10 @@ func (Enricher) RequiredPlugins() []string {
 
 // NewEnricher creates a new Enricher.
 // It accepts an http.Client as a dependency. If the provided client is nil,
-// it defaults to the standard http.DefaultClient.
+// it defaults to a client that retries failed requests with backoff.
 func NewEnricher(client *http.Client) *Enricher {
 	if client == nil {
-		client = http.DefaultClient
+		client = scalibrhttp.New(scalibrhttp.DefaultConfig())
 	}
 
 	return &Enricher{
@@ -103,7 +104,7 @@ func NewEnricher(client *http.Client) *Enricher {
 // NewDefault returns a new javareach enricher with the default configuration.
 func NewDefault() enricher.Enricher {
 	return &Enricher{
-		client: http.DefaultClient,
+		client: scalibrhttp.New(scalibrhttp.DefaultConfig()),
 	}
 }
 
@@ -111,7 +112,7 @@ func NewDefault() enricher.Enricher {
 func (enr Enricher) Enrich(ctx context.Context, input *enricher.ScanInput, inv *inventory.Inventory) error {
 	client := enr.client
 	if client == nil {
-		client = http.DefaultClient
+		client = scalibrhttp.New(scalibrhttp.DefaultConfig())
 	}
 	jars := make(map[string]struct{})
 	for i := range inv.Packages {
@@ -141,7 +142,7 @@ func getFullPackageName(i *extractor.Package) string {
 func enumerateReachabilityForJar(ctx context.Context, jarPath string, input *enricher.ScanInput, inv *inventory.Inventory, client *http.Client) error {
 	var allDeps []*extractor.Package
 	if client == nil {
-		client = http.DefaultClient
+		client = scalibrhttp.New(scalibrhttp.DefaultConfig())
 	}
 	for i := range inv.Packages {
 		if inv.Packages[i].Locations[0] == jarPath {
@@ -0,0 +1,147 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package fixedversion implements an enricher that derives structured
+// remediation data for package vulnerabilities from their OSV records: the
+// lowest fixed version above the installed one, whether the fix was backported
+// to the installed OS release and the URLs of fix commits.
+package fixedversion
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/osv-scalibr/enricher"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/plugin"
+	"github.com/google/osv-scalibr/semantic"
+	"github.com/ossf/osv-schema/bindings/go/osvschema"
+)
+
+const (
+	// Name is the name of the enricher.
+	Name = "remediation/fixedversion"
+	// Version is the version of the enricher.
+	Version = 0
+)
+
+// Enricher adds remediation data to package vulnerabilities.
+type Enricher struct{}
+
+// New returns a new remediation enricher.
+func New() enricher.Enricher {
+	return &Enricher{}
+}
+
+// Name of the enricher.
+func (*Enricher) Name() string { return Name }
+
+// Version of the enricher.
+func (*Enricher) Version() int { return Version }
+
+// Requirements of the enricher.
+func (*Enricher) Requirements() *plugin.Capabilities { return &plugin.Capabilities{} }
+
+// RequiredPlugins returns the plugins that are required to be enabled for this
+// enricher to run.
+func (*Enricher) RequiredPlugins() []string { return nil }
+
+// Enrich sets the remediation data of the package vulnerabilities in the
+// inventory. Fields that were already set, e.g. by other enrichers, are kept.
+func (e *Enricher) Enrich(ctx context.Context, _ *enricher.ScanInput, inv *inventory.Inventory) error {
+	for _, v := range inv.PackageVulns {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if v.Package == nil {
+			continue
+		}
+		fixed, backported := minFixedVersion(v.Package, v.Affected)
+		patches := patchURLs(v.References)
+		if fixed == "" && len(patches) == 0 {
+			continue
+		}
+		if v.Remediation == nil {
+			v.Remediation = &inventory.Remediation{}
+		}
+		if v.Remediation.FixedVersion == "" {
+			v.Remediation.FixedVersion = fixed
+			v.Remediation.Backported = backported
+		}
+		if len(v.Remediation.PatchURLs) == 0 {
+			v.Remediation.PatchURLs = patches
+		}
+	}
+	return nil
+}
+
+// minFixedVersion returns the lowest fixed version of the package that is higher
+// than the installed one, and whether it was published for the installed OS
+// release.
+func minFixedVersion(pkg *extractor.Package, affected []osvschema.Affected) (string, bool) {
+	ecosystem := pkg.Ecosystem()
+	base, release, _ := strings.Cut(ecosystem, ":")
+	installed, err := semantic.Parse(pkg.Version, base)
+	if err != nil {
+		return "", false
+	}
+
+	var minFixed semantic.Version
+	var minFixedStr string
+	var backported bool
+	for _, a := range affected {
+		affectedBase, _, _ := strings.Cut(a.Package.Ecosystem, ":")
+		if affectedBase != base || a.Package.Name != pkg.Name {
+			continue
+		}
+		for _, r := range a.Ranges {
+			if r.Type != osvschema.RangeEcosystem && r.Type != osvschema.RangeSemVer {
+				continue
+			}
+			for _, ev := range r.Events {
+				if ev.Fixed == "" {
+					continue
+				}
+				fixed, err := semantic.Parse(ev.Fixed, base)
+				if err != nil {
+					continue
+				}
+				if c, err := installed.CompareStr(ev.Fixed); err != nil || c >= 0 {
+					continue
+				}
+				if minFixed != nil {
+					if c, err := fixed.CompareStr(minFixedStr); err != nil || c >= 0 {
+						continue
+					}
+				}
+				minFixed = fixed
+				minFixedStr = ev.Fixed
+				backported = release != "" && a.Package.Ecosystem == ecosystem
+			}
+		}
+	}
+	return minFixedStr, backported
+}
+
+// patchURLs returns the URLs of the fix references.
+func patchURLs(refs []osvschema.Reference) []string {
+	var urls []string
+	for _, r := range refs {
+		if r.Type == osvschema.ReferenceFix && r.URL != "" {
+			urls = append(urls, r.URL)
+		}
+	}
+	return urls
+}
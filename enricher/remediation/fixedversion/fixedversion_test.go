@@ -0,0 +1,170 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fixedversion_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/osv-scalibr/enricher/remediation/fixedversion"
+	"github.com/google/osv-scalibr/extractor"
+	dpkgmeta "github.com/google/osv-scalibr/extractor/filesystem/os/dpkg/metadata"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/ossf/osv-schema/bindings/go/osvschema"
+)
+
+func fixedRange(typ osvschema.RangeType, fixed ...string) osvschema.Range {
+	r := osvschema.Range{Type: typ, Events: []osvschema.Event{{Introduced: "0"}}}
+	for _, f := range fixed {
+		r.Events = append(r.Events, osvschema.Event{Fixed: f})
+	}
+	return r
+}
+
+func TestEnrich(t *testing.T) {
+	lodash := &extractor.Package{Name: "lodash", Version: "4.17.15", PURLType: purl.TypeNPM}
+	openssl := &extractor.Package{
+		Name:     "openssl",
+		Version:  "3.0.11-1~deb12u1",
+		PURLType: purl.TypeDebian,
+		Metadata: &dpkgmeta.Metadata{OSID: "debian", OSVersionID: "12"},
+	}
+	lodashAffected := []osvschema.Affected{
+		{
+			Package: osvschema.Package{Ecosystem: "npm", Name: "lodash"},
+			Ranges: []osvschema.Range{
+				fixedRange(osvschema.RangeSemVer, "4.17.12"),
+				fixedRange(osvschema.RangeSemVer, "5.0.0", "4.17.21"),
+				fixedRange(osvschema.RangeSemVer, "4.17.19"),
+				fixedRange(osvschema.RangeGit, "abcdef"),
+			},
+		},
+		{
+			Package: osvschema.Package{Ecosystem: "npm", Name: "lodash-es"},
+			Ranges:  []osvschema.Range{fixedRange(osvschema.RangeSemVer, "4.17.16")},
+		},
+	}
+
+	tests := []struct {
+		desc string
+		vuln *inventory.PackageVuln
+		want *inventory.Remediation
+	}{
+		{
+			desc: "lowest_fixed_version_above_installed",
+			vuln: &inventory.PackageVuln{
+				Vulnerability: osvschema.Vulnerability{
+					ID:       "GHSA-1",
+					Affected: lodashAffected,
+					References: []osvschema.Reference{
+						{Type: osvschema.ReferenceAdvisory, URL: "https://example.com/advisory"},
+						{Type: osvschema.ReferenceFix, URL: "https://github.com/lodash/lodash/commit/abc"},
+					},
+				},
+				Package: lodash,
+			},
+			want: &inventory.Remediation{
+				FixedVersion: "4.17.19",
+				PatchURLs:    []string{"https://github.com/lodash/lodash/commit/abc"},
+			},
+		},
+		{
+			desc: "no_fix",
+			vuln: &inventory.PackageVuln{
+				Vulnerability: osvschema.Vulnerability{
+					ID: "GHSA-2",
+					Affected: []osvschema.Affected{{
+						Package: osvschema.Package{Ecosystem: "npm", Name: "lodash"},
+						Ranges:  []osvschema.Range{fixedRange(osvschema.RangeSemVer)},
+					}},
+				},
+				Package: lodash,
+			},
+			want: nil,
+		},
+		{
+			desc: "existing_remediation_is_kept",
+			vuln: &inventory.PackageVuln{
+				Vulnerability: osvschema.Vulnerability{ID: "GHSA-1", Affected: lodashAffected},
+				Package:       lodash,
+				Remediation: &inventory.Remediation{
+					FixedVersion: "4.17.21",
+					UpgradePath:  []*inventory.UpgradeStep{{Name: "foo", Version: "2.0.0"}},
+				},
+			},
+			want: &inventory.Remediation{
+				FixedVersion: "4.17.21",
+				UpgradePath:  []*inventory.UpgradeStep{{Name: "foo", Version: "2.0.0"}},
+			},
+		},
+		{
+			desc: "os_backport",
+			vuln: &inventory.PackageVuln{
+				Vulnerability: osvschema.Vulnerability{
+					ID: "DSA-1",
+					Affected: []osvschema.Affected{
+						{
+							Package: osvschema.Package{Ecosystem: "Debian:11", Name: "openssl"},
+							Ranges:  []osvschema.Range{fixedRange(osvschema.RangeEcosystem, "1.1.1w-0+deb11u1")},
+						},
+						{
+							Package: osvschema.Package{Ecosystem: "Debian:12", Name: "openssl"},
+							Ranges:  []osvschema.Range{fixedRange(osvschema.RangeEcosystem, "3.0.13-1~deb12u1")},
+						},
+						{
+							Package: osvschema.Package{Ecosystem: "Debian:13", Name: "openssl"},
+							Ranges:  []osvschema.Range{fixedRange(osvschema.RangeEcosystem, "3.1.4-2")},
+						},
+					},
+				},
+				Package: openssl,
+			},
+			want: &inventory.Remediation{
+				FixedVersion: "3.0.13-1~deb12u1",
+				Backported:   true,
+			},
+		},
+		{
+			desc: "os_fix_only_in_newer_release",
+			vuln: &inventory.PackageVuln{
+				Vulnerability: osvschema.Vulnerability{
+					ID: "DSA-2",
+					Affected: []osvschema.Affected{{
+						Package: osvschema.Package{Ecosystem: "Debian:13", Name: "openssl"},
+						Ranges:  []osvschema.Range{fixedRange(osvschema.RangeEcosystem, "3.1.4-2")},
+					}},
+				},
+				Package: openssl,
+			},
+			want: &inventory.Remediation{
+				FixedVersion: "3.1.4-2",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			inv := &inventory.Inventory{PackageVulns: []*inventory.PackageVuln{tc.vuln}}
+			if err := fixedversion.New().Enrich(context.Background(), nil, inv); err != nil {
+				t.Fatalf("Enrich(): %v", err)
+			}
+			if diff := cmp.Diff(tc.want, inv.PackageVulns[0].Remediation); diff != "" {
+				t.Errorf("Enrich() returned unexpected remediation (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,175 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package pomxml implements an enricher to perform dependency resolution for Maven pom.xml.
+package pomxml
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/google/osv-scalibr/enricher"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/extractor/filesystem/language/java/pomxml"
+	"github.com/google/osv-scalibr/extractor/filesystem/language/java/pomxmlnet"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/log"
+	"github.com/google/osv-scalibr/plugin"
+)
+
+const (
+	// Name is the unique name of this enricher.
+	Name = "transitivedependency/pomxml"
+)
+
+// Enricher performs dependency resolution for pom.xml files extracted by the
+// offline java/pomxml extractor. Parent POMs and imported BOMs are fetched from
+// the Maven registry, so that the versions of dependencies managed there and
+// of dependencies with version ranges are filled in, and transitive
+// dependencies are added to the inventory.
+type Enricher struct {
+	// Resolves the dependencies of a single pom.xml file.
+	Resolver *pomxmlnet.Extractor
+}
+
+// Name returns the name of the enricher.
+func (Enricher) Name() string {
+	return Name
+}
+
+// Version returns the version of the enricher.
+func (Enricher) Version() int {
+	return 0
+}
+
+// Requirements returns the requirements of the enricher.
+func (Enricher) Requirements() *plugin.Capabilities {
+	return &plugin.Capabilities{
+		Network: plugin.NetworkOnline,
+	}
+}
+
+// RequiredPlugins returns the names of the plugins required by the enricher.
+func (Enricher) RequiredPlugins() []string {
+	return []string{pomxml.Name}
+}
+
+// NewDefault returns a new enricher that fetches metadata from Maven Central.
+func NewDefault() enricher.Enricher {
+	return New(pomxmlnet.DefaultConfig())
+}
+
+// New returns a new enricher with the given Maven registry clients.
+func New(c pomxmlnet.Config) *Enricher {
+	return &Enricher{
+		Resolver: pomxmlnet.New(c),
+	}
+}
+
+// Enrich resolves the dependencies of the pom.xml files in the inventory.
+func (e Enricher) Enrich(ctx context.Context, input *enricher.ScanInput, inv *inventory.Inventory) error {
+	if input == nil || input.ScanRoot == nil || input.ScanRoot.FS == nil {
+		log.Warnf("%s: the scanned files aren't available, skipping dependency resolution", Name)
+		return nil
+	}
+	pkgGroups := groupPackages(inv.Packages)
+	for _, path := range slices.Sorted(maps.Keys(pkgGroups)) {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		pkgMap := pkgGroups[path]
+
+		// For each manifest, perform dependency resolution.
+		pkgs, err := e.resolve(ctx, input, path)
+		if err != nil {
+			log.Warnf("failed resolution of %s: %v", path, err)
+			continue
+		}
+
+		for _, pkg := range pkgs {
+			indexPkg, ok := pkgMap[pkg.Name]
+			if ok {
+				// This dependency is in manifest, update the version and plugins.
+				i := indexPkg.index
+				inv.Packages[i].Version = pkg.Version
+				inv.Packages[i].Plugins = append(inv.Packages[i].Plugins, Name)
+			} else {
+				// This dependency is not found in manifest, so it's a transitive dependency.
+				pkg.Plugins = []string{Name}
+				inv.Packages = append(inv.Packages, pkg)
+			}
+		}
+	}
+	return nil
+}
+
+// packageWithIndex holds the package with its index in inv.Packages
+type packageWithIndex struct {
+	pkg   *extractor.Package
+	index int
+}
+
+// groupPackages groups packages found in pom.xml files by the first location that they are found
+// and returns a map of location -> package name -> package with index.
+func groupPackages(pkgs []*extractor.Package) map[string]map[string]packageWithIndex {
+	result := make(map[string]map[string]packageWithIndex)
+	for i, pkg := range pkgs {
+		if !slices.Contains(pkg.Plugins, pomxml.Name) {
+			continue
+		}
+		if len(pkg.Locations) == 0 {
+			log.Warnf("package %s has no locations", pkg.Name)
+			continue
+		}
+		// Use the path where this package is first found.
+		path := pkg.Locations[0]
+		if _, ok := result[path]; !ok {
+			result[path] = make(map[string]packageWithIndex)
+		}
+		result[path][pkg.Name] = packageWithIndex{pkg, i}
+	}
+	return result
+}
+
+// resolve performs dependency resolution for the pom.xml file at path.
+func (e Enricher) resolve(ctx context.Context, input *enricher.ScanInput, path string) ([]*extractor.Package, error) {
+	if e.Resolver == nil {
+		return nil, errors.New("no resolver configured")
+	}
+	f, err := input.ScanRoot.FS.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("stat(%s): %w", path, err)
+	}
+
+	inv, err := e.Resolver.Extract(ctx, &filesystem.ScanInput{
+		FS:     input.ScanRoot.FS,
+		Path:   path,
+		Root:   input.ScanRoot.Path,
+		Info:   info,
+		Reader: f,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return inv.Packages, nil
+}
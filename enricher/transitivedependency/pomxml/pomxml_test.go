@@ -0,0 +1,280 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pomxml_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/osv-scalibr/clients/clienttest"
+	"github.com/google/osv-scalibr/clients/datasource"
+	"github.com/google/osv-scalibr/enricher"
+	"github.com/google/osv-scalibr/enricher/transitivedependency/pomxml"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem/language/java/javalockfile"
+	"github.com/google/osv-scalibr/extractor/filesystem/language/java/pomxmlnet"
+	scalibrfs "github.com/google/osv-scalibr/fs"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scalibr/testing/extracttest"
+)
+
+func mavenMetadata(groupID, artifactID string) *javalockfile.Metadata {
+	return &javalockfile.Metadata{
+		ArtifactID:   artifactID,
+		GroupID:      groupID,
+		DepGroupVals: []string{},
+	}
+}
+
+func TestEnricher_Enrich(t *testing.T) {
+	srv := clienttest.NewMockHTTPServer(t)
+	srv.SetResponse(t, "org/upstream/parent-pom/1.0/parent-pom-1.0.pom", []byte(`
+	<project>
+	  <groupId>org.upstream</groupId>
+	  <artifactId>parent-pom</artifactId>
+	  <version>1.0</version>
+	  <packaging>pom</packaging>
+	  <dependencies>
+	    <dependency>
+	      <groupId>org.eve</groupId>
+	      <artifactId>eve</artifactId>
+	      <version>5.0.0</version>
+	    </dependency>
+	  </dependencies>
+	  <dependencyManagement>
+	    <dependencies>
+	      <dependency>
+	        <groupId>org.chuck</groupId>
+	        <artifactId>chuck</artifactId>
+	        <version>3.0.0</version>
+	      </dependency>
+	    </dependencies>
+	  </dependencyManagement>
+	</project>
+	`))
+	srv.SetResponse(t, "org/import/import/1.2.3/import-1.2.3.pom", []byte(`
+	<project>
+	  <groupId>org.import</groupId>
+	  <artifactId>import</artifactId>
+	  <version>1.2.3</version>
+	  <packaging>pom</packaging>
+	  <dependencyManagement>
+	    <dependencies>
+	      <dependency>
+	        <groupId>org.frank</groupId>
+	        <artifactId>frank</artifactId>
+	        <version>6.0.0</version>
+	      </dependency>
+	    </dependencies>
+	  </dependencyManagement>
+	</project>
+	`))
+	apiClient, err := datasource.NewMavenRegistryAPIClient(datasource.MavenRegistry{URL: srv.URL, ReleasesEnabled: true}, "")
+	if err != nil {
+		t.Fatalf("NewMavenRegistryAPIClient(): %v", err)
+	}
+	e := pomxml.New(pomxmlnet.Config{
+		DependencyClient:       clienttest.NewMockResolutionClient(t, "testdata/universe.yaml"),
+		MavenRegistryAPIClient: apiClient,
+	})
+
+	input := enricher.ScanInput{
+		ScanRoot: &scalibrfs.ScanRoot{
+			Path: ".",
+			FS:   scalibrfs.DirFS("."),
+		},
+	}
+	// The packages as extracted by the offline java/pomxml extractor.
+	inv := inventory.Inventory{
+		Packages: []*extractor.Package{
+			{
+				// Not extracted from a pom.xml file.
+				Name:      "abc",
+				Version:   "1.0.0",
+				PURLType:  purl.TypePyPi,
+				Locations: []string{"testdata/requirements.txt"},
+				Plugins:   []string{"python/requirements"},
+			},
+			{
+				Name:      "org.alice:alice",
+				Version:   "1.0.0",
+				PURLType:  purl.TypeMaven,
+				Locations: []string{"testdata/pom.xml"},
+				Metadata:  mavenMetadata("org.alice", "alice"),
+				Plugins:   []string{"java/pomxml"},
+			},
+			{
+				// Managed by the remote parent.
+				Name:      "org.chuck:chuck",
+				PURLType:  purl.TypeMaven,
+				Locations: []string{"testdata/pom.xml"},
+				Metadata:  mavenMetadata("org.chuck", "chuck"),
+				Plugins:   []string{"java/pomxml"},
+			},
+			{
+				// Managed by the imported BOM.
+				Name:      "org.frank:frank",
+				PURLType:  purl.TypeMaven,
+				Locations: []string{"testdata/pom.xml"},
+				Metadata:  mavenMetadata("org.frank", "frank"),
+				Plugins:   []string{"java/pomxml"},
+			},
+			{
+				// The lower bound of the version range.
+				Name:      "org.mine:ranged-package",
+				Version:   "9.4.35",
+				PURLType:  purl.TypeMaven,
+				Locations: []string{"testdata/pom.xml"},
+				Metadata:  mavenMetadata("org.mine", "ranged-package"),
+				Plugins:   []string{"java/pomxml"},
+			},
+			{
+				Name:      "org.direct:bob",
+				Version:   "2.0.0",
+				PURLType:  purl.TypeMaven,
+				Locations: []string{"testdata/pom.xml"},
+				Metadata:  mavenMetadata("org.direct", "bob"),
+				Plugins:   []string{"java/pomxml"},
+			},
+			{
+				// The pom.xml file is no longer available.
+				Name:      "org.missing:missing",
+				Version:   "1.0.0",
+				PURLType:  purl.TypeMaven,
+				Locations: []string{"testdata/missing/pom.xml"},
+				Metadata:  mavenMetadata("org.missing", "missing"),
+				Plugins:   []string{"java/pomxml"},
+			},
+		},
+	}
+
+	if err := e.Enrich(context.Background(), &input, &inv); err != nil {
+		t.Fatalf("%s.Enrich(): %v", e.Name(), err)
+	}
+
+	want := inventory.Inventory{
+		Packages: []*extractor.Package{
+			{
+				Name:      "abc",
+				Version:   "1.0.0",
+				PURLType:  purl.TypePyPi,
+				Locations: []string{"testdata/requirements.txt"},
+				Plugins:   []string{"python/requirements"},
+			},
+			{
+				Name:      "org.alice:alice",
+				Version:   "1.0.0",
+				PURLType:  purl.TypeMaven,
+				Locations: []string{"testdata/pom.xml"},
+				Metadata:  mavenMetadata("org.alice", "alice"),
+				Plugins:   []string{"java/pomxml", "transitivedependency/pomxml"},
+			},
+			{
+				Name:      "org.chuck:chuck",
+				Version:   "3.0.0",
+				PURLType:  purl.TypeMaven,
+				Locations: []string{"testdata/pom.xml"},
+				Metadata:  mavenMetadata("org.chuck", "chuck"),
+				Plugins:   []string{"java/pomxml", "transitivedependency/pomxml"},
+			},
+			{
+				Name:      "org.frank:frank",
+				Version:   "6.0.0",
+				PURLType:  purl.TypeMaven,
+				Locations: []string{"testdata/pom.xml"},
+				Metadata:  mavenMetadata("org.frank", "frank"),
+				Plugins:   []string{"java/pomxml", "transitivedependency/pomxml"},
+			},
+			{
+				Name:      "org.mine:ranged-package",
+				Version:   "9.4.37",
+				PURLType:  purl.TypeMaven,
+				Locations: []string{"testdata/pom.xml"},
+				Metadata:  mavenMetadata("org.mine", "ranged-package"),
+				Plugins:   []string{"java/pomxml", "transitivedependency/pomxml"},
+			},
+			{
+				Name:      "org.direct:bob",
+				Version:   "2.0.0",
+				PURLType:  purl.TypeMaven,
+				Locations: []string{"testdata/pom.xml"},
+				Metadata:  mavenMetadata("org.direct", "bob"),
+				Plugins:   []string{"java/pomxml", "transitivedependency/pomxml"},
+			},
+			{
+				Name:      "org.missing:missing",
+				Version:   "1.0.0",
+				PURLType:  purl.TypeMaven,
+				Locations: []string{"testdata/missing/pom.xml"},
+				Metadata:  mavenMetadata("org.missing", "missing"),
+				Plugins:   []string{"java/pomxml"},
+			},
+			{
+				// Inherited from the remote parent.
+				Name:      "org.eve:eve",
+				Version:   "5.0.0",
+				PURLType:  purl.TypeMaven,
+				Locations: []string{"testdata/pom.xml"},
+				Metadata:  mavenMetadata("org.eve", "eve"),
+				Plugins:   []string{"transitivedependency/pomxml"},
+			},
+			{
+				Name:      "org.transitive:eve",
+				Version:   "3.3.3",
+				PURLType:  purl.TypeMaven,
+				Locations: []string{"testdata/pom.xml"},
+				Metadata: &javalockfile.Metadata{
+					ArtifactID:   "eve",
+					GroupID:      "org.transitive",
+					IsTransitive: true,
+					DepGroupVals: []string{},
+				},
+				Plugins: []string{"transitivedependency/pomxml"},
+			},
+		},
+	}
+	if diff := cmp.Diff(want, inv, cmpopts.SortSlices(extracttest.PackageCmpLess)); diff != "" {
+		t.Errorf("%s.Enrich() diff (-want +got):\n%s", e.Name(), diff)
+	}
+}
+
+func TestEnricher_Enrich_NoScanRoot(t *testing.T) {
+	e := pomxml.New(pomxmlnet.Config{
+		DependencyClient:       clienttest.NewMockResolutionClient(t, "testdata/universe.yaml"),
+		MavenRegistryAPIClient: &datasource.MavenRegistryAPIClient{},
+	})
+	newPkg := func() *extractor.Package {
+		return &extractor.Package{
+			Name:      "org.chuck:chuck",
+			PURLType:  purl.TypeMaven,
+			Locations: []string{"testdata/pom.xml"},
+			Metadata:  mavenMetadata("org.chuck", "chuck"),
+			Plugins:   []string{"java/pomxml"},
+		}
+	}
+	inv := inventory.Inventory{Packages: []*extractor.Package{newPkg()}}
+
+	if err := e.Enrich(context.Background(), &enricher.ScanInput{}, &inv); err != nil {
+		t.Fatalf("%s.Enrich(): %v", e.Name(), err)
+	}
+	// The package is left unchanged.
+	want := inventory.Inventory{Packages: []*extractor.Package{newPkg()}}
+	if diff := cmp.Diff(want, inv); diff != "" {
+		t.Errorf("%s.Enrich() diff (-want +got):\n%s", e.Name(), diff)
+	}
+}
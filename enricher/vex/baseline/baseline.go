@@ -0,0 +1,89 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package baseline implements an enricher that removes the findings recorded
+// in a baseline file from the results, so that only new findings are reported.
+// See the result/baseline package for the file format.
+package baseline
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/osv-scalibr/enricher"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/log"
+	"github.com/google/osv-scalibr/plugin"
+	scalibrbaseline "github.com/google/osv-scalibr/result/baseline"
+)
+
+const (
+	// Name is the name of the enricher.
+	Name = "vex/baseline"
+	// Version is the version of the enricher.
+	Version = 0
+)
+
+// Enricher removes the findings that are in a baseline file.
+type Enricher struct {
+	// Path is the path of the baseline file.
+	Path string
+}
+
+// New returns an enricher that filters the findings in the given baseline
+// file.
+func New(path string) *Enricher {
+	return &Enricher{Path: path}
+}
+
+// NewDefault returns an enricher without a baseline file. Path needs to be set
+// for it to do anything.
+func NewDefault() enricher.Enricher {
+	return New("")
+}
+
+// Name of the enricher.
+func (*Enricher) Name() string { return Name }
+
+// Version of the enricher.
+func (*Enricher) Version() int { return Version }
+
+// Requirements of the enricher.
+func (*Enricher) Requirements() *plugin.Capabilities { return &plugin.Capabilities{} }
+
+// RequiredPlugins returns a list of Plugins that need to be enabled for this Enricher to work.
+func (*Enricher) RequiredPlugins() []string { return nil }
+
+// RunAfter returns the vuln matchers whose findings need to be filtered if
+// they're enabled.
+func (*Enricher) RunAfter() []string {
+	return []string{"vulnmatch/osvdev", "vulnmatch/osvlocal"}
+}
+
+// Enrich removes the baselined findings from the inventory.
+func (e *Enricher) Enrich(ctx context.Context, _ *enricher.ScanInput, inv *inventory.Inventory) error {
+	if e.Path == "" {
+		log.Warnf("%s: no baseline file set, nothing to filter", Name)
+		return nil
+	}
+	b, err := scalibrbaseline.ReadFile(e.Path)
+	if err != nil {
+		return fmt.Errorf("reading baseline: %w", err)
+	}
+	n := b.Filter(inv)
+	log.Infof("%s: %d findings are in the baseline %s", Name, n, e.Path)
+	return nil
+}
+
+var _ enricher.RunAfter = &Enricher{}
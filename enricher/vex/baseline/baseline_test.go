@@ -0,0 +1,83 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package baseline_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/osv-scalibr/enricher/vex/baseline"
+	"github.com/google/osv-scalibr/inventory"
+	scalibrbaseline "github.com/google/osv-scalibr/result/baseline"
+	"github.com/ossf/osv-schema/bindings/go/osvschema"
+)
+
+func TestEnrich(t *testing.T) {
+	oldVuln := &inventory.PackageVuln{Vulnerability: osvschema.Vulnerability{ID: "CVE-1"}}
+	newVuln := &inventory.PackageVuln{Vulnerability: osvschema.Vulnerability{ID: "CVE-2"}}
+
+	path := filepath.Join(t.TempDir(), "baseline.json")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("os.Create(): %v", err)
+	}
+	b := scalibrbaseline.New(&inventory.Inventory{PackageVulns: []*inventory.PackageVuln{oldVuln}})
+	if err := b.Write(f); err != nil {
+		t.Fatalf("Write(): %v", err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatalf("Close(): %v", err)
+	}
+
+	tests := []struct {
+		desc    string
+		path    string
+		want    *inventory.Inventory
+		wantErr bool
+	}{
+		{
+			desc: "baselined_vuln_removed",
+			path: path,
+			want: &inventory.Inventory{PackageVulns: []*inventory.PackageVuln{newVuln}},
+		},
+		{
+			desc: "no_baseline",
+			path: "",
+			want: &inventory.Inventory{PackageVulns: []*inventory.PackageVuln{oldVuln, newVuln}},
+		},
+		{
+			desc:    "missing_baseline",
+			path:    filepath.Join(t.TempDir(), "missing.json"),
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			inv := &inventory.Inventory{PackageVulns: []*inventory.PackageVuln{oldVuln, newVuln}}
+			err := baseline.New(tc.path).Enrich(t.Context(), nil, inv)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("Enrich() error: %v, want error: %t", err, tc.wantErr)
+			}
+			if tc.wantErr {
+				return
+			}
+			if diff := cmp.Diff(tc.want, inv); diff != "" {
+				t.Errorf("Enrich() unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
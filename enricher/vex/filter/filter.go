@@ -51,6 +51,12 @@ func (*Enricher) Requirements() *plugin.Capabilities { return &plugin.Capabiliti
 // RequiredPlugins returns a list of Plugins that need to be enabled for this Enricher to work.
 func (*Enricher) RequiredPlugins() []string { return nil }
 
+// RunAfter returns the vuln matchers whose findings need to be filtered and
+// the enrichers that add VEX signals if they're enabled.
+func (*Enricher) RunAfter() []string {
+	return []string{"vulnmatch/osvdev", "vulnmatch/osvlocal", "vex/suppression"}
+}
+
 // Enrich removes vulnerabilities that have VEX signals associated.
 func (e *Enricher) Enrich(ctx context.Context, _ *enricher.ScanInput, inv *inventory.Inventory) error {
 	inv.PackageVulns = slices.DeleteFunc(inv.PackageVulns, func(f *inventory.PackageVuln) bool {
@@ -61,3 +67,5 @@ func (e *Enricher) Enrich(ctx context.Context, _ *enricher.ScanInput, inv *inven
 	})
 	return nil
 }
+
+var _ enricher.RunAfter = &Enricher{}
@@ -0,0 +1,201 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package suppression
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/osv-scalibr/inventory/vex"
+	"github.com/google/osv-scalibr/log"
+	"github.com/google/osv-scalibr/purl"
+)
+
+// OpenVEX statuses, see https://github.com/openvex/spec.
+const (
+	openVEXNotAffected        = "not_affected"
+	openVEXAffected           = "affected"
+	openVEXFixed              = "fixed"
+	openVEXUnderInvestigation = "under_investigation"
+)
+
+type openVEXDocument struct {
+	Timestamp  time.Time          `json:"timestamp"`
+	Statements []openVEXStatement `json:"statements"`
+}
+
+type openVEXStatement struct {
+	Vulnerability   openVEXVulnerability `json:"vulnerability"`
+	Products        []openVEXProduct     `json:"products"`
+	Status          string               `json:"status"`
+	Justification   string               `json:"justification"`
+	ImpactStatement string               `json:"impact_statement"`
+	StatusNotes     string               `json:"status_notes"`
+	Timestamp       *time.Time           `json:"timestamp"`
+}
+
+// openVEXVulnerability is an object with the name and aliases of the vuln.
+// Documents from OpenVEX v0.0.1 use a plain string.
+type openVEXVulnerability struct {
+	Name    string   `json:"name"`
+	Aliases []string `json:"aliases"`
+}
+
+func (v *openVEXVulnerability) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &v.Name); err == nil {
+		return nil
+	}
+	type plain openVEXVulnerability
+	return json.Unmarshal(data, (*plain)(v))
+}
+
+// openVEXProduct is an object with the ID and subcomponents of the product.
+// Documents from OpenVEX v0.0.1 use a plain string.
+type openVEXProduct struct {
+	ID            string           `json:"@id"`
+	Subcomponents []openVEXProduct `json:"subcomponents"`
+}
+
+func (p *openVEXProduct) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &p.ID); err == nil {
+		return nil
+	}
+	type plain openVEXProduct
+	return json.Unmarshal(data, (*plain)(p))
+}
+
+// readOpenVEX reads the statements of an OpenVEX document that mark vulns as
+// not affecting a product. Statements are applied in chronological order, so
+// a later "affected" statement revokes an earlier "not_affected" one for the
+// same vuln and products.
+func readOpenVEX(path string) ([]*rule, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var doc openVEXDocument
+	if err := json.Unmarshal(content, &doc); err != nil {
+		return nil, fmt.Errorf("could not parse %s: %w", path, err)
+	}
+
+	statements := slices.Clone(doc.Statements)
+	slices.SortStableFunc(statements, func(a, b openVEXStatement) int {
+		return statementTime(a, doc).Compare(statementTime(b, doc))
+	})
+	latest := map[string]*rule{}
+	var keys []string
+	for i, s := range statements {
+		if s.Vulnerability.Name == "" {
+			return nil, fmt.Errorf("%s: statement %d has no vulnerability", path, i)
+		}
+		key := statementKey(s)
+		if _, ok := latest[key]; !ok {
+			keys = append(keys, key)
+		}
+		switch s.Status {
+		case openVEXNotAffected, openVEXFixed:
+			latest[key] = statementRule(s, fmt.Sprintf("%s statement %d", path, i))
+		case openVEXAffected, openVEXUnderInvestigation:
+			latest[key] = nil
+		default:
+			return nil, fmt.Errorf("%s: statement %d has unknown status %q", path, i, s.Status)
+		}
+	}
+
+	var rules []*rule
+	for _, k := range keys {
+		if r := latest[k]; r != nil {
+			rules = append(rules, r)
+		}
+	}
+	return rules, nil
+}
+
+// statementTime returns when the statement was made. Statements inherit the
+// timestamp of the document.
+func statementTime(s openVEXStatement, doc openVEXDocument) time.Time {
+	if s.Timestamp != nil {
+		return *s.Timestamp
+	}
+	return doc.Timestamp
+}
+
+// statementKey identifies the vuln and products a statement is about.
+func statementKey(s openVEXStatement) string {
+	var products []string
+	for _, p := range s.Products {
+		products = append(products, p.ID)
+		for _, c := range p.Subcomponents {
+			products = append(products, p.ID+">"+c.ID)
+		}
+	}
+	slices.Sort(products)
+	return s.Vulnerability.Name + "\x00" + strings.Join(products, "\x00")
+}
+
+// statementRule converts a "not_affected" or "fixed" statement into a rule.
+// The statement applies to the packages listed as subcomponents or, if there
+// are none, as products. If none of them is a package URL the products are
+// assumed to be the scanned artifact, e.g. a container image, and the
+// statement applies to all findings of the vuln.
+func statementRule(s openVEXStatement, source string) *rule {
+	r := &rule{
+		vulns:  append([]string{s.Vulnerability.Name}, s.Vulnerability.Aliases...),
+		source: source,
+		status: vex.StatusNotAffected,
+		reason: strings.TrimSpace(s.ImpactStatement),
+	}
+	if r.reason == "" {
+		r.reason = strings.TrimSpace(s.StatusNotes)
+	}
+	if s.Status == openVEXFixed && r.reason == "" {
+		r.reason = "fixed"
+	}
+	if s.Justification != "" {
+		j, ok := vex.ParseJustification(s.Justification)
+		if !ok {
+			log.Warnf("%s: unknown justification %q", source, s.Justification)
+		}
+		r.justification = j
+	}
+
+	var ids []string
+	for _, p := range s.Products {
+		for _, c := range p.Subcomponents {
+			ids = append(ids, c.ID)
+		}
+	}
+	if len(ids) == 0 {
+		for _, p := range s.Products {
+			ids = append(ids, p.ID)
+		}
+	}
+	for _, id := range ids {
+		if p, err := purl.FromString(id); err == nil && isPackage(p) {
+			r.purls = append(r.purls, p)
+		}
+	}
+	return r
+}
+
+// isPackage returns whether the PURL identifies a software package rather
+// than an artifact like a container image.
+func isPackage(p purl.PackageURL) bool {
+	return p.Type != purl.TypeOCI && p.Type != purl.TypeDocker
+}
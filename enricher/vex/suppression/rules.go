@@ -0,0 +1,215 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package suppression
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/gobwas/glob"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/inventory/vex"
+	"github.com/google/osv-scalibr/purl"
+	"gopkg.in/yaml.v3"
+)
+
+var errNoCriteria = errors.New("rule needs at least one of vulns, purl or path")
+
+// rule marks the findings it matches with an exploitability signal. All of the
+// specified criteria need to match; unset criteria match every finding.
+type rule struct {
+	// IDs and aliases of the matched vulns.
+	vulns []string
+	// Packages of the matched findings. Versions are only compared if set.
+	purls []purl.PackageURL
+	// Glob of the file paths of the matched findings.
+	path glob.Glob
+	// The rule doesn't apply after this time if set.
+	expires time.Time
+	// Where the rule is from, used in log messages.
+	source string
+
+	status        vex.Status
+	justification vex.Justification
+	reason        string
+}
+
+// rulesFile is the format of a suppression rules file, e.g.
+//
+//	rules:
+//	  - vulns: [CVE-2023-1234]
+//	    purl: pkg:pypi/requests
+//	    path: "opt/legacy/**"
+//	    status: accepted-risk
+//	    reason: Only reachable from the internal network.
+//	    expires: 2026-12-31
+type rulesFile struct {
+	Rules []struct {
+		Vulns         []string `yaml:"vulns"`
+		PURL          string   `yaml:"purl"`
+		Path          string   `yaml:"path"`
+		Status        string   `yaml:"status"`
+		Justification string   `yaml:"justification"`
+		Reason        string   `yaml:"reason"`
+		Expires       string   `yaml:"expires"`
+	} `yaml:"rules"`
+}
+
+// readRulesFile reads the suppression rules from a YAML or JSON file.
+func readRulesFile(path string) ([]*rule, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var f rulesFile
+	if err := yaml.Unmarshal(content, &f); err != nil {
+		return nil, fmt.Errorf("could not parse %s: %w", path, err)
+	}
+	var rules []*rule
+	for i, r := range f.Rules {
+		if len(r.Vulns) == 0 && r.PURL == "" && r.Path == "" {
+			return nil, fmt.Errorf("%s: rule %d: %w", path, i, errNoCriteria)
+		}
+		res := &rule{
+			vulns:  r.Vulns,
+			source: fmt.Sprintf("%s rule %d", path, i),
+			status: vex.StatusNotAffected,
+			reason: strings.TrimSpace(r.Reason),
+		}
+		if r.PURL != "" {
+			p, err := purl.FromString(r.PURL)
+			if err != nil {
+				return nil, fmt.Errorf("%s: rule %d: %w", path, i, err)
+			}
+			res.purls = []purl.PackageURL{p}
+		}
+		if r.Path != "" {
+			if res.path, err = glob.Compile(strings.TrimPrefix(r.Path, "/"), '/'); err != nil {
+				return nil, fmt.Errorf("%s: rule %d: invalid path %q: %w", path, i, r.Path, err)
+			}
+		}
+		if r.Status != "" {
+			s, ok := vex.ParseStatus(r.Status)
+			if !ok || s == vex.StatusUnspecified {
+				return nil, fmt.Errorf("%s: rule %d: unknown status %q", path, i, r.Status)
+			}
+			res.status = s
+		}
+		if r.Justification != "" {
+			j, ok := vex.ParseJustification(r.Justification)
+			if !ok {
+				return nil, fmt.Errorf("%s: rule %d: unknown justification %q", path, i, r.Justification)
+			}
+			res.justification = j
+		}
+		if r.Expires != "" {
+			if res.expires, err = time.Parse(time.DateOnly, r.Expires); err != nil {
+				return nil, fmt.Errorf("%s: rule %d: invalid expiry date %q: %w", path, i, r.Expires, err)
+			}
+			// The rule applies until the end of the day.
+			res.expires = res.expires.AddDate(0, 0, 1)
+		}
+		rules = append(rules, res)
+	}
+	return rules, nil
+}
+
+// matchesVuln returns whether the rule applies to the package vuln.
+func (r *rule) matchesVuln(v *inventory.PackageVuln) bool {
+	if len(r.vulns) > 0 && !r.matchesID(v.ID) && !r.matchesAnyID(v.Aliases) {
+		return false
+	}
+	if len(r.purls) > 0 && (v.Package == nil || !r.matchesPackage(v.Package)) {
+		return false
+	}
+	if r.path != nil && (v.Package == nil || !r.matchesAnyPath(v.Package.Locations)) {
+		return false
+	}
+	return true
+}
+
+// matchesFinding returns whether the rule applies to the generic finding.
+// Rules for specific packages never apply since the findings aren't related
+// to packages.
+func (r *rule) matchesFinding(f *inventory.GenericFinding) bool {
+	if len(r.purls) > 0 || f.Adv == nil || f.Adv.ID == nil {
+		return false
+	}
+	if len(r.vulns) > 0 && !r.matchesID(f.Adv.ID.Reference) && !r.matchesID(f.Adv.ID.Publisher+"/"+f.Adv.ID.Reference) {
+		return false
+	}
+	if r.path != nil && (f.Target == nil || !r.matchesAnyPath([]string{findingPath(f.Target.Extra)})) {
+		return false
+	}
+	return true
+}
+
+func (r *rule) matchesID(id string) bool {
+	for _, v := range r.vulns {
+		if strings.EqualFold(v, id) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *rule) matchesAnyID(ids []string) bool {
+	for _, id := range ids {
+		if r.matchesID(id) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *rule) matchesPackage(pkg *extractor.Package) bool {
+	p := pkg.PURL()
+	if p == nil {
+		return false
+	}
+	for _, want := range r.purls {
+		if want.Type == p.Type &&
+			strings.EqualFold(want.Namespace, p.Namespace) &&
+			strings.EqualFold(want.Name, p.Name) &&
+			(want.Version == "" || want.Version == p.Version) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *rule) matchesAnyPath(paths []string) bool {
+	for _, p := range paths {
+		if p != "" && r.path.Match(strings.TrimPrefix(p, "/")) {
+			return true
+		}
+	}
+	return false
+}
+
+// findingPath returns the file path at the start of a generic finding's
+// details, following the "path: details" convention of the detectors.
+func findingPath(extra string) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(extra), "\n")
+	p, _, found := strings.Cut(line, ": ")
+	if !found || strings.ContainsAny(p, " \t") {
+		return ""
+	}
+	return p
+}
@@ -0,0 +1,162 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package suppression implements an enricher that triages findings with
+// user-supplied rules, e.g. to mark them as false positives or accepted risks.
+//
+// Rules are read from a YAML or JSON rules file and from OpenVEX documents
+// (https://github.com/openvex/spec). Matching findings get an exploitability
+// signal with the status of the rule, which is carried into all output
+// formats. Enable the vex/filter enricher to remove them from the results
+// instead.
+package suppression
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/osv-scalibr/enricher"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/inventory/vex"
+	"github.com/google/osv-scalibr/log"
+	"github.com/google/osv-scalibr/plugin"
+)
+
+const (
+	// Name is the name of the enricher.
+	Name = "vex/suppression"
+	// Version is the version of the enricher.
+	Version = 0
+)
+
+// Enricher adds exploitability signals to the findings matched by
+// suppression rules and OpenVEX statements.
+type Enricher struct {
+	// RulesPath is the path of a suppression rules file.
+	RulesPath string
+	// VEXPaths are the paths of OpenVEX documents.
+	VEXPaths []string
+	// now returns the current time, used to check if rules have expired.
+	now func() time.Time
+}
+
+// New returns an enricher that applies the rules from the given rules file
+// and OpenVEX documents. Either can be empty.
+func New(rulesPath string, vexPaths []string) *Enricher {
+	return &Enricher{RulesPath: rulesPath, VEXPaths: vexPaths, now: time.Now}
+}
+
+// NewDefault returns an enricher without any rules. RulesPath or VEXPaths
+// need to be set for it to do anything.
+func NewDefault() enricher.Enricher {
+	return New("", nil)
+}
+
+// Name of the enricher.
+func (*Enricher) Name() string { return Name }
+
+// Version of the enricher.
+func (*Enricher) Version() int { return Version }
+
+// Requirements of the enricher.
+func (*Enricher) Requirements() *plugin.Capabilities { return &plugin.Capabilities{} }
+
+// RequiredPlugins returns a list of Plugins that need to be enabled for this Enricher to work.
+func (*Enricher) RequiredPlugins() []string { return nil }
+
+// RunAfter returns the vuln matchers whose findings need to be triaged if
+// they're enabled.
+func (*Enricher) RunAfter() []string {
+	return []string{"vulnmatch/osvdev", "vulnmatch/osvlocal"}
+}
+
+// Enrich adds an exploitability signal to each finding for every rule that
+// matches it.
+func (e *Enricher) Enrich(ctx context.Context, _ *enricher.ScanInput, inv *inventory.Inventory) error {
+	rules, err := e.rules()
+	if err != nil {
+		return err
+	}
+	for _, v := range inv.PackageVulns {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		for _, r := range rules {
+			if r.matchesVuln(v) {
+				v.ExploitabilitySignals = addSignal(v.ExploitabilitySignals, r)
+			}
+		}
+	}
+	for _, f := range inv.GenericFindings {
+		for _, r := range rules {
+			if r.matchesFinding(f) {
+				f.ExploitabilitySignals = addSignal(f.ExploitabilitySignals, r)
+			}
+		}
+	}
+	return nil
+}
+
+// rules returns the rules from all configured files that haven't expired.
+func (e *Enricher) rules() ([]*rule, error) {
+	var rules []*rule
+	if e.RulesPath != "" {
+		r, err := readRulesFile(e.RulesPath)
+		if err != nil {
+			return nil, fmt.Errorf("reading suppression rules: %w", err)
+		}
+		rules = append(rules, r...)
+	}
+	for _, p := range e.VEXPaths {
+		r, err := readOpenVEX(p)
+		if err != nil {
+			return nil, fmt.Errorf("reading OpenVEX document: %w", err)
+		}
+		rules = append(rules, r...)
+	}
+
+	now := time.Now
+	if e.now != nil {
+		now = e.now
+	}
+	active := rules[:0]
+	for _, r := range rules {
+		if !r.expires.IsZero() && !now().Before(r.expires) {
+			log.Warnf("%s expired on %s and is ignored", r.source, r.expires.AddDate(0, 0, -1).Format(time.DateOnly))
+			continue
+		}
+		active = append(active, r)
+	}
+	return active, nil
+}
+
+// addSignal adds the signal of the rule unless an identical one exists, e.g.
+// because the enricher already ran on the scan result.
+func addSignal(signals []*vex.FindingExploitabilitySignal, r *rule) []*vex.FindingExploitabilitySignal {
+	s := &vex.FindingExploitabilitySignal{
+		Plugin:        Name,
+		Justification: r.justification,
+		Status:        r.status,
+		Reason:        r.reason,
+	}
+	for _, existing := range signals {
+		if *existing == *s {
+			return signals
+		}
+	}
+	return append(signals, s)
+}
+
+var _ enricher.RunAfter = &Enricher{}
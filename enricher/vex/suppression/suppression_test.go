@@ -0,0 +1,201 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package suppression_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/osv-scalibr/enricher/vex/suppression"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/inventory/vex"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/ossf/osv-schema/bindings/go/osvschema"
+)
+
+func testInventory() *inventory.Inventory {
+	lodash := &extractor.Package{Name: "lodash", Version: "4.17.20", PURLType: purl.TypeNPM, Locations: []string{"app/package-lock.json"}}
+	vendored := &extractor.Package{Name: "requests", Version: "2.19.0", PURLType: purl.TypePyPi, Locations: []string{"/opt/vendor/requests/METADATA"}}
+	requests := &extractor.Package{Name: "requests", Version: "2.19.0", PURLType: purl.TypePyPi, Locations: []string{"usr/lib/python3/requests/METADATA"}}
+	xnet := &extractor.Package{Name: "golang.org/x/net", Version: "0.4.0", PURLType: purl.TypeGolang, Locations: []string{"app/server"}}
+	vuln := func(id string, pkg *extractor.Package, aliases ...string) *inventory.PackageVuln {
+		return &inventory.PackageVuln{Vulnerability: osvschema.Vulnerability{ID: id, Aliases: aliases}, Package: pkg}
+	}
+	jmx := &inventory.GenericFindingAdvisory{ID: &inventory.AdvisoryID{Publisher: "SCALIBR", Reference: "jvm-unauthenticated-remote-jmx"}}
+	return &inventory.Inventory{
+		PackageVulns: []*inventory.PackageVuln{
+			vuln("CVE-2023-0001", lodash),
+			vuln("GHSA-aaaa-bbbb-cccc", lodash),
+			vuln("CVE-2023-0100", vendored),
+			vuln("CVE-2023-0101", requests),
+			vuln("CVE-2020-9999", lodash),
+			vuln("GO-2023-0002", xnet, "CVE-2023-0002"),
+			vuln("CVE-2023-0003", lodash),
+			vuln("CVE-2023-0004", lodash),
+			vuln("CVE-2023-0005", lodash),
+			vuln("CVE-2023-0006", lodash),
+		},
+		GenericFindings: []*inventory.GenericFinding{
+			{Adv: jmx, Target: &inventory.GenericFindingTargetDetails{Extra: "/etc/systemd/system/app.service: -Dcom.sun.management.jmxremote.authenticate=false"}},
+			{Adv: jmx, Target: &inventory.GenericFindingTargetDetails{Extra: "/opt/app/run.sh: -Dcom.sun.management.jmxremote.authenticate=false"}},
+		},
+	}
+}
+
+// signals returns the exploitability signals of the findings, keyed by the
+// finding's ID and location.
+func signals(inv *inventory.Inventory) map[string][]*vex.FindingExploitabilitySignal {
+	res := map[string][]*vex.FindingExploitabilitySignal{}
+	for _, v := range inv.PackageVulns {
+		if len(v.ExploitabilitySignals) > 0 {
+			res[v.ID+" "+v.Package.Locations[0]] = v.ExploitabilitySignals
+		}
+	}
+	for _, f := range inv.GenericFindings {
+		if len(f.ExploitabilitySignals) > 0 {
+			res[f.Adv.ID.Reference+" "+f.Target.Extra[:10]] = f.ExploitabilitySignals
+		}
+	}
+	return res
+}
+
+func TestEnrich(t *testing.T) {
+	tests := []struct {
+		desc      string
+		rulesPath string
+		vexPaths  []string
+		runs      int
+		want      map[string][]*vex.FindingExploitabilitySignal
+	}{
+		{
+			desc: "no rules",
+		},
+		{
+			desc:      "rules file",
+			rulesPath: "testdata/rules.yaml",
+			want: map[string][]*vex.FindingExploitabilitySignal{
+				"CVE-2023-0001 app/package-lock.json": {{
+					Plugin: "vex/suppression",
+					Status: vex.StatusAcceptedRisk,
+					Reason: "Only reachable from the internal network.",
+				}},
+				"GHSA-aaaa-bbbb-cccc app/package-lock.json": {{
+					Plugin:        "vex/suppression",
+					Status:        vex.StatusNotAffected,
+					Justification: vex.VulnerableCodeNotInExecutePath,
+				}},
+				"CVE-2023-0100 /opt/vendor/requests/METADATA": {{
+					Plugin: "vex/suppression",
+					Status: vex.StatusFalsePositive,
+				}},
+				"jvm-unauthenticated-remote-jmx /etc/syste": {{
+					Plugin: "vex/suppression",
+					Status: vex.StatusAcceptedRisk,
+				}},
+			},
+		},
+		{
+			desc:      "rules applied twice",
+			rulesPath: "testdata/rules.yaml",
+			runs:      2,
+			want: map[string][]*vex.FindingExploitabilitySignal{
+				"CVE-2023-0001 app/package-lock.json": {{
+					Plugin: "vex/suppression",
+					Status: vex.StatusAcceptedRisk,
+					Reason: "Only reachable from the internal network.",
+				}},
+				"GHSA-aaaa-bbbb-cccc app/package-lock.json": {{
+					Plugin:        "vex/suppression",
+					Status:        vex.StatusNotAffected,
+					Justification: vex.VulnerableCodeNotInExecutePath,
+				}},
+				"CVE-2023-0100 /opt/vendor/requests/METADATA": {{
+					Plugin: "vex/suppression",
+					Status: vex.StatusFalsePositive,
+				}},
+				"jvm-unauthenticated-remote-jmx /etc/syste": {{
+					Plugin: "vex/suppression",
+					Status: vex.StatusAcceptedRisk,
+				}},
+			},
+		},
+		{
+			desc:     "OpenVEX documents",
+			vexPaths: []string{"testdata/openvex.json", "testdata/openvex_v001.json"},
+			want: map[string][]*vex.FindingExploitabilitySignal{
+				"GO-2023-0002 app/server": {{
+					Plugin:        "vex/suppression",
+					Status:        vex.StatusNotAffected,
+					Justification: vex.VulnerableCodeNotInExecutePath,
+					Reason:        "The HTTP/2 server is not used.",
+				}},
+				"CVE-2023-0003 app/package-lock.json": {{
+					Plugin:        "vex/suppression",
+					Status:        vex.StatusNotAffected,
+					Justification: vex.InlineMitigationAlreadyExists,
+				}},
+				"CVE-2023-0006 app/package-lock.json": {{
+					Plugin: "vex/suppression",
+					Status: vex.StatusNotAffected,
+					Reason: "fixed",
+				}},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			inv := testInventory()
+			e := suppression.New(tc.rulesPath, tc.vexPaths)
+			for range max(tc.runs, 1) {
+				if err := e.Enrich(context.Background(), nil, inv); err != nil {
+					t.Fatalf("Enrich() returned error: %v", err)
+				}
+			}
+			if diff := cmp.Diff(tc.want, signals(inv), cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("Enrich() returned unexpected signals (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestEnrich_Errors(t *testing.T) {
+	tests := []struct {
+		desc      string
+		rulesPath string
+		vexPaths  []string
+	}{
+		{desc: "missing rules file", rulesPath: "testdata/does-not-exist.yaml"},
+		{desc: "unknown status", rulesPath: "testdata/invalid_status.yaml"},
+		{desc: "rule without criteria", rulesPath: "testdata/no_criteria.yaml"},
+		{desc: "invalid OpenVEX document", vexPaths: []string{"testdata/invalid.json"}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			inv := testInventory()
+			err := suppression.New(tc.rulesPath, tc.vexPaths).Enrich(context.Background(), nil, inv)
+			if err == nil {
+				t.Fatalf("Enrich() succeeded, want error")
+			}
+			if got := signals(inv); len(got) > 0 {
+				t.Errorf("Enrich() added signals %v despite the error", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,393 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package osvlocal implements an enricher that matches the extracted packages
+// against a local export of the OSV database, for environments where querying
+// osv.dev isn't possible.
+//
+// The database is read from a directory or zip file in the format of the OSV
+// bulk exports, e.g. the per-ecosystem all.zip archives from
+// https://osv-vulnerabilities.storage.googleapis.com/. Each JSON file holds
+// either a single OSV record or a list of them.
+package osvlocal
+
+import (
+	"archive/zip"
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/google/osv-scalibr/enricher"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/log"
+	"github.com/google/osv-scalibr/plugin"
+	"github.com/google/osv-scalibr/semantic"
+	"github.com/ossf/osv-schema/bindings/go/osvschema"
+)
+
+const (
+	// Name is the name of the enricher.
+	Name = "vulnmatch/osvlocal"
+	// Version is the version of the enricher.
+	Version = 0
+)
+
+// ErrNoDatabase is returned if no database path was configured.
+var ErrNoDatabase = errors.New("no offline OSV database specified")
+
+// Enricher matches packages against a local OSV database.
+type Enricher struct {
+	// DBPath is the path of a directory or zip file containing OSV records.
+	DBPath string
+}
+
+// New returns an enricher that reads the OSV database from dbPath.
+func New(dbPath string) *Enricher {
+	return &Enricher{DBPath: dbPath}
+}
+
+// NewDefault returns an enricher without a database path. DBPath needs to be
+// set before running it.
+func NewDefault() enricher.Enricher {
+	return &Enricher{}
+}
+
+// Name of the enricher.
+func (*Enricher) Name() string { return Name }
+
+// Version of the enricher.
+func (*Enricher) Version() int { return Version }
+
+// Requirements of the enricher.
+func (*Enricher) Requirements() *plugin.Capabilities { return &plugin.Capabilities{} }
+
+// RequiredPlugins returns the plugins that are required to be enabled for this
+// enricher to run.
+func (*Enricher) RequiredPlugins() []string { return nil }
+
+// packageKey identifies the packages affected by an OSV record.
+type packageKey struct {
+	ecosystem string
+	name      string
+}
+
+func keyOf(ecosystem, name string) packageKey {
+	base, _, _ := strings.Cut(ecosystem, ":")
+	if base == "PyPI" {
+		name = normalizePyPIName(name)
+	}
+	return packageKey{ecosystem: ecosystem, name: name}
+}
+
+var pypiSeparatorRe = regexp.MustCompile(`[-_.]+`)
+
+// normalizePyPIName normalizes Python package names as described in PEP 503.
+func normalizePyPIName(name string) string {
+	return strings.ToLower(pypiSeparatorRe.ReplaceAllString(name, "-"))
+}
+
+// Enrich adds the vulnerabilities from the database that affect the packages
+// in the inventory. Only the records affecting at least one of the packages
+// are kept in memory.
+func (e *Enricher) Enrich(ctx context.Context, _ *enricher.ScanInput, inv *inventory.Inventory) error {
+	if e.DBPath == "" {
+		return ErrNoDatabase
+	}
+	pkgs := map[packageKey][]*extractor.Package{}
+	for _, p := range inv.Packages {
+		eco := p.Ecosystem()
+		if eco == "" || p.Version == "" {
+			continue
+		}
+		k := keyOf(eco, p.Name)
+		pkgs[k] = append(pkgs[k], p)
+	}
+	if len(pkgs) == 0 {
+		return nil
+	}
+
+	existing := map[string]bool{}
+	for _, v := range inv.PackageVulns {
+		existing[vulnKey(v.ID, v.Package)] = true
+	}
+	return walkDB(ctx, e.DBPath, pkgs, func(v *osvschema.Vulnerability) {
+		for _, p := range affectedPackages(v, pkgs) {
+			k := vulnKey(v.ID, p)
+			if existing[k] {
+				continue
+			}
+			existing[k] = true
+			inv.PackageVulns = append(inv.PackageVulns, &inventory.PackageVuln{
+				Vulnerability: *v,
+				Package:       p,
+				Plugins:       []string{Name},
+			})
+		}
+	})
+}
+
+func vulnKey(id string, p *extractor.Package) string {
+	return fmt.Sprintf("%s\x00%p", id, p)
+}
+
+// affectedPackages returns the packages that are affected by the vulnerability.
+func affectedPackages(v *osvschema.Vulnerability, pkgs map[packageKey][]*extractor.Package) []*extractor.Package {
+	var result []*extractor.Package
+	for _, a := range v.Affected {
+		for _, p := range pkgs[keyOf(a.Package.Ecosystem, a.Package.Name)] {
+			if !slices.Contains(result, p) && isAffected(p.Version, a) {
+				result = append(result, p)
+			}
+		}
+	}
+	return result
+}
+
+// isAffected returns whether the version is listed in the affected versions
+// or ranges. Ranges are evaluated with the version ordering of the ecosystem,
+// git ranges are ignored.
+func isAffected(version string, a osvschema.Affected) bool {
+	if slices.Contains(a.Versions, version) {
+		return true
+	}
+	base, _, _ := strings.Cut(a.Package.Ecosystem, ":")
+	for _, r := range a.Ranges {
+		if r.Type != osvschema.RangeEcosystem && r.Type != osvschema.RangeSemVer {
+			continue
+		}
+		if inRange(version, base, r.Events) {
+			return true
+		}
+	}
+	return false
+}
+
+// parsedEvent is a range event with its parsed version. The version is nil
+// for the "0" introduced event, which sorts before all other versions.
+type parsedEvent struct {
+	event   osvschema.Event
+	version semantic.Version
+	str     string
+}
+
+func eventVersion(e osvschema.Event) string {
+	switch {
+	case e.Introduced != "":
+		return e.Introduced
+	case e.Fixed != "":
+		return e.Fixed
+	case e.LastAffected != "":
+		return e.LastAffected
+	default:
+		return e.Limit
+	}
+}
+
+func inRange(version, ecosystem string, events []osvschema.Event) bool {
+	v, err := semantic.Parse(version, ecosystem)
+	if err != nil {
+		return false
+	}
+	parsed := make([]parsedEvent, 0, len(events))
+	for _, e := range events {
+		str := eventVersion(e)
+		if str == "" || e.Limit != "" {
+			continue
+		}
+		pe := parsedEvent{event: e, str: str}
+		if str != "0" {
+			if pe.version, err = semantic.Parse(str, ecosystem); err != nil {
+				return false
+			}
+		}
+		parsed = append(parsed, pe)
+	}
+	var sortErr error
+	slices.SortStableFunc(parsed, func(a, b parsedEvent) int {
+		switch {
+		case a.version == nil && b.version == nil:
+			return 0
+		case a.version == nil:
+			return -1
+		case b.version == nil:
+			return 1
+		}
+		c, err := a.version.CompareStr(b.str)
+		if err != nil {
+			sortErr = err
+		}
+		return c
+	})
+	if sortErr != nil {
+		return false
+	}
+
+	affected := false
+	for _, pe := range parsed {
+		c := 1
+		if pe.version != nil {
+			if c, err = v.CompareStr(pe.str); err != nil {
+				return false
+			}
+		}
+		switch {
+		case pe.event.Introduced != "":
+			if c >= 0 {
+				affected = true
+			}
+		case pe.event.Fixed != "":
+			if c >= 0 {
+				affected = false
+			}
+		case pe.event.LastAffected != "":
+			if c > 0 {
+				affected = false
+			}
+		}
+	}
+	return affected
+}
+
+// walkDB calls fn for each non-withdrawn record in the database that mentions
+// one of the packages.
+func walkDB(ctx context.Context, dbPath string, pkgs map[packageKey][]*extractor.Package, fn func(*osvschema.Vulnerability)) error {
+	return filepath.WalkDir(dbPath, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".zip":
+			return walkZip(ctx, path, pkgs, fn)
+		case ".json":
+			f, err := os.Open(path)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			if err := readRecords(f, pkgs, fn); err != nil {
+				log.Warnf("osvlocal: skipping %s: %v", path, err)
+			}
+		}
+		return nil
+	})
+}
+
+func walkZip(ctx context.Context, path string, pkgs map[packageKey][]*extractor.Package, fn func(*osvschema.Vulnerability)) error {
+	r, err := zip.OpenReader(path)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	defer r.Close()
+	for _, f := range r.File {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if !strings.EqualFold(filepath.Ext(f.Name), ".json") {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		err = readRecords(rc, pkgs, fn)
+		rc.Close()
+		if err != nil {
+			log.Warnf("osvlocal: skipping %s in %s: %v", f.Name, path, err)
+		}
+	}
+	return nil
+}
+
+// recordHeader holds the fields needed to decide whether a record is relevant
+// before decoding it fully.
+type recordHeader struct {
+	Withdrawn string           `json:"withdrawn"`
+	Affected  []affectedHeader `json:"affected"`
+}
+
+type affectedHeader struct {
+	Package struct {
+		Ecosystem string `json:"ecosystem"`
+		Name      string `json:"name"`
+	} `json:"package"`
+}
+
+// readRecords decodes a single OSV record or a list of records from r.
+func readRecords(r io.Reader, pkgs map[packageKey][]*extractor.Package, fn func(*osvschema.Vulnerability)) error {
+	br := bufio.NewReader(r)
+	var records []json.RawMessage
+	if isList(br) {
+		if err := json.NewDecoder(br).Decode(&records); err != nil {
+			return err
+		}
+	} else {
+		var record json.RawMessage
+		if err := json.NewDecoder(br).Decode(&record); err != nil {
+			return err
+		}
+		records = append(records, record)
+	}
+	for _, raw := range records {
+		var h recordHeader
+		if err := json.Unmarshal(raw, &h); err != nil {
+			return err
+		}
+		relevant := slices.ContainsFunc(h.Affected, func(a affectedHeader) bool {
+			_, ok := pkgs[keyOf(a.Package.Ecosystem, a.Package.Name)]
+			return ok
+		})
+		if h.Withdrawn != "" || !relevant {
+			continue
+		}
+		v := &osvschema.Vulnerability{}
+		if err := json.Unmarshal(raw, v); err != nil {
+			return err
+		}
+		fn(v)
+	}
+	return nil
+}
+
+// isList returns whether the next non-whitespace character is the start of a
+// JSON array.
+func isList(r *bufio.Reader) bool {
+	for {
+		b, err := r.Peek(1)
+		if err != nil {
+			return false
+		}
+		switch b[0] {
+		case ' ', '\t', '\r', '\n':
+			_, _ = r.ReadByte()
+		default:
+			return b[0] == '['
+		}
+	}
+}
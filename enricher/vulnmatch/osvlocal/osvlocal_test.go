@@ -0,0 +1,223 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package osvlocal_test
+
+import (
+	"archive/zip"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/osv-scalibr/enricher/vulnmatch/osvlocal"
+	"github.com/google/osv-scalibr/extractor"
+	dpkgmeta "github.com/google/osv-scalibr/extractor/filesystem/os/dpkg/metadata"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/ossf/osv-schema/bindings/go/osvschema"
+)
+
+const (
+	lodashVuln = `{
+  "id": "GHSA-lodash",
+  "affected": [{
+    "package": {"ecosystem": "npm", "name": "lodash"},
+    "ranges": [{"type": "SEMVER", "events": [{"introduced": "0"}, {"fixed": "4.17.21"}]}]
+  }]
+}`
+	requestsVuln = `{
+  "id": "PYSEC-requests",
+  "affected": [{
+    "package": {"ecosystem": "PyPI", "name": "Requests"},
+    "ranges": [{"type": "ECOSYSTEM", "events": [{"introduced": "2.0.0"}, {"last_affected": "2.31.0"}]}]
+  }]
+}`
+	withdrawnVuln = `{
+  "id": "GHSA-withdrawn",
+  "withdrawn": "2024-01-01T00:00:00Z",
+  "affected": [{"package": {"ecosystem": "npm", "name": "lodash"}, "versions": ["4.17.15"]}]
+}`
+	// A list of records, like in the Debian exports.
+	debianVulns = `[
+  {
+    "id": "DSA-openssl",
+    "affected": [{
+      "package": {"ecosystem": "Debian:12", "name": "openssl"},
+      "ranges": [{"type": "ECOSYSTEM", "events": [{"introduced": "0"}, {"fixed": "3.0.13-1~deb12u1"}]}]
+    }]
+  },
+  {
+    "id": "DSA-openssl-bookworm-only",
+    "affected": [{
+      "package": {"ecosystem": "Debian:11", "name": "openssl"},
+      "versions": ["3.0.11-1~deb12u1"]
+    }]
+  },
+  {
+    "id": "DSA-unrelated",
+    "affected": [{"package": {"ecosystem": "Debian:12", "name": "curl"}, "versions": ["1"]}]
+  }
+]`
+)
+
+func writeZip(t *testing.T, path string, files map[string]string) {
+	t.Helper()
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	w := zip.NewWriter(f)
+	for name, content := range files {
+		fw, err := w.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := fw.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// testDB returns a database directory with a zip per ecosystem, like the OSV
+// bulk exports, and a loose JSON file.
+func testDB(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	for _, eco := range []string{"npm", "PyPI"} {
+		if err := os.Mkdir(filepath.Join(dir, eco), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeZip(t, filepath.Join(dir, "npm", "all.zip"), map[string]string{
+		"GHSA-lodash.json":    lodashVuln,
+		"GHSA-withdrawn.json": withdrawnVuln,
+		"README.txt":          "not a record",
+	})
+	writeZip(t, filepath.Join(dir, "PyPI", "all.zip"), map[string]string{
+		"PYSEC-requests.json": requestsVuln,
+		"invalid.json":        "{",
+	})
+	if err := os.WriteFile(filepath.Join(dir, "debian.json"), []byte(debianVulns), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func TestEnrich(t *testing.T) {
+	lodashOld := &extractor.Package{Name: "lodash", Version: "4.17.15", PURLType: purl.TypeNPM}
+	lodashFixed := &extractor.Package{Name: "lodash", Version: "4.17.21", PURLType: purl.TypeNPM}
+	requests := &extractor.Package{Name: "requests", Version: "2.31.0", PURLType: purl.TypePyPi}
+	requestsNew := &extractor.Package{Name: "requests", Version: "2.32.0", PURLType: purl.TypePyPi}
+	openssl := &extractor.Package{
+		Name:     "openssl",
+		Version:  "3.0.11-1~deb12u1",
+		PURLType: purl.TypeDebian,
+		Metadata: &dpkgmeta.Metadata{OSID: "debian", OSVersionID: "12"},
+	}
+	existing := &inventory.PackageVuln{
+		Vulnerability: osvschema.Vulnerability{ID: "GHSA-lodash"},
+		Package:       lodashOld,
+		Plugins:       []string{"other"},
+	}
+
+	testCases := []struct {
+		desc string
+		pkgs []*extractor.Package
+		// Vulns already in the inventory.
+		vulns []*inventory.PackageVuln
+		// IDs of the vulns per package after enrichment.
+		want map[string][]string
+	}{
+		{
+			desc: "semver_range",
+			pkgs: []*extractor.Package{lodashOld, lodashFixed},
+			want: map[string][]string{"lodash@4.17.15": {"GHSA-lodash"}},
+		},
+		{
+			desc: "last_affected_and_normalized_name",
+			pkgs: []*extractor.Package{requests, requestsNew},
+			want: map[string][]string{"requests@2.31.0": {"PYSEC-requests"}},
+		},
+		{
+			desc: "os_release",
+			pkgs: []*extractor.Package{openssl},
+			want: map[string][]string{"openssl@3.0.11-1~deb12u1": {"DSA-openssl"}},
+		},
+		{
+			desc:  "existing_vuln_is_kept",
+			pkgs:  []*extractor.Package{lodashOld},
+			vulns: []*inventory.PackageVuln{existing},
+			want:  map[string][]string{"lodash@4.17.15": {"GHSA-lodash"}},
+		},
+		{
+			desc: "no_packages",
+			want: map[string][]string{},
+		},
+	}
+
+	dbPath := testDB(t)
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			inv := &inventory.Inventory{Packages: tc.pkgs, PackageVulns: slices.Clone(tc.vulns)}
+			if err := osvlocal.New(dbPath).Enrich(context.Background(), nil, inv); err != nil {
+				t.Fatalf("Enrich(): %v", err)
+			}
+			got := map[string][]string{}
+			for _, v := range inv.PackageVulns {
+				k := v.Package.Name + "@" + v.Package.Version
+				got[k] = append(got[k], v.ID)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Enrich() returned unexpected vulns (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestEnrich_SingleZip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "all.zip")
+	writeZip(t, path, map[string]string{"GHSA-lodash.json": lodashVuln})
+	pkg := &extractor.Package{Name: "lodash", Version: "4.17.20", PURLType: purl.TypeNPM}
+	inv := &inventory.Inventory{Packages: []*extractor.Package{pkg}}
+	if err := osvlocal.New(path).Enrich(context.Background(), nil, inv); err != nil {
+		t.Fatalf("Enrich(): %v", err)
+	}
+	if len(inv.PackageVulns) != 1 {
+		t.Fatalf("Enrich() found %d vulns, want 1", len(inv.PackageVulns))
+	}
+	got := inv.PackageVulns[0]
+	if got.ID != "GHSA-lodash" || got.Package != pkg || !slices.Equal(got.Plugins, []string{osvlocal.Name}) {
+		t.Errorf("Enrich() returned unexpected vuln %+v", got)
+	}
+}
+
+func TestEnrich_Errors(t *testing.T) {
+	inv := &inventory.Inventory{Packages: []*extractor.Package{
+		{Name: "lodash", Version: "4.17.20", PURLType: purl.TypeNPM},
+	}}
+	if err := osvlocal.NewDefault().Enrich(context.Background(), nil, inv); !errors.Is(err, osvlocal.ErrNoDatabase) {
+		t.Errorf("Enrich() without a database: %v, want %v", err, osvlocal.ErrNoDatabase)
+	}
+	if err := osvlocal.New("/does/not/exist").Enrich(context.Background(), nil, inv); err == nil {
+		t.Error("Enrich() with a missing database succeeded, want error")
+	}
+}
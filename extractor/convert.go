@@ -19,8 +19,11 @@ import (
 	gopurl "github.com/google/osv-scalibr/extractor/filesystem/language/golang/purl"
 	mavenpurl "github.com/google/osv-scalibr/extractor/filesystem/language/java/purl"
 	npmpurl "github.com/google/osv-scalibr/extractor/filesystem/language/javascript/purl"
+	juliapurl "github.com/google/osv-scalibr/extractor/filesystem/language/julia/purl"
 	"github.com/google/osv-scalibr/extractor/filesystem/language/python/pypipurl"
+	terraformpurl "github.com/google/osv-scalibr/extractor/filesystem/language/terraform/purl"
 	osecosystem "github.com/google/osv-scalibr/extractor/filesystem/os/ecosystem"
+	flakepurl "github.com/google/osv-scalibr/extractor/filesystem/os/nix/flakelock/purl"
 	ospurl "github.com/google/osv-scalibr/extractor/filesystem/os/purl"
 	cdxmeta "github.com/google/osv-scalibr/extractor/filesystem/sbom/cdx/metadata"
 	cdxpurl "github.com/google/osv-scalibr/extractor/filesystem/sbom/cdx/purl"
@@ -35,6 +38,10 @@ func toPURL(p *Package) *purl.PackageURL {
 	if p.PURLType == "" {
 		return nil
 	}
+	// Mappers registered by integrators take precedence.
+	if purl := customPURL(p); purl != nil {
+		return purl
+	}
 	// See if this needs any special type-specific conversion logic.
 	if purl := typeSpecificPURL(p); purl != nil {
 		return purl
@@ -68,8 +75,14 @@ func typeSpecificPURL(p *Package) *purl.PackageURL {
 		return gopurl.MakePackageURL(p.Name, p.Version)
 	case purl.TypeHex:
 		return hexpurl.MakePackageURL(p.Name, p.Version)
+	case purl.TypeJulia:
+		return juliapurl.MakePackageURL(p.Name, p.Version, p.Metadata)
+	case purl.TypeTerraform:
+		return terraformpurl.MakePackageURL(p.Name, p.Version)
+	case purl.TypeGithub:
+		return flakepurl.MakePackageURL(p.Name, p.Version)
 	case purl.TypeDebian, purl.TypeOpkg, purl.TypeFlatpak, purl.TypeApk, purl.TypeCOS, purl.TypeRPM,
-		purl.TypeSnap, purl.TypePacman, purl.TypePortage, purl.TypeNix:
+		purl.TypeSnap, purl.TypePacman, purl.TypePortage, purl.TypeNix, purl.TypeYocto:
 		return ospurl.MakePackageURL(p.Name, p.Version, p.PURLType, p.Metadata)
 	case "windows":
 		return winpurl.MakePackageURL(p.Name, p.Version, p.Metadata)
@@ -98,6 +111,8 @@ func toEcosystem(p *Package) string {
 		return "ConanCenter"
 	case purl.TypeCran:
 		return "CRAN"
+	case purl.TypeBioconductor:
+		return "Bioconductor"
 	case purl.TypeGem:
 		return "RubyGems"
 	case purl.TypeNuget:
@@ -112,6 +127,10 @@ func toEcosystem(p *Package) string {
 		return "crates.io"
 	case purl.TypePub:
 		return "Pub"
+	case purl.TypeJulia:
+		return "Julia"
+	case purl.TypeOpam:
+		return "opam"
 	}
 
 	// No Ecosystem defined for this package.
@@ -20,7 +20,9 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/osv-scalibr/extractor"
 	javascriptmeta "github.com/google/osv-scalibr/extractor/filesystem/language/javascript/packagejson/metadata"
+	juliameta "github.com/google/osv-scalibr/extractor/filesystem/language/julia/metadata"
 	dpkgmeta "github.com/google/osv-scalibr/extractor/filesystem/os/dpkg/metadata"
+	yoctometa "github.com/google/osv-scalibr/extractor/filesystem/os/yocto/metadata"
 	cdxmeta "github.com/google/osv-scalibr/extractor/filesystem/sbom/cdx/metadata"
 	spdxmeta "github.com/google/osv-scalibr/extractor/filesystem/sbom/spdx/metadata"
 	"github.com/google/osv-scalibr/purl"
@@ -98,6 +100,52 @@ func TestToPURL(t *testing.T) {
 				Version: "1.2.3",
 			},
 		},
+		{
+			name: "julia_purl",
+			pkg: &extractor.Package{
+				Name:      "HTTP",
+				Version:   "1.10.8",
+				PURLType:  purl.TypeJulia,
+				Locations: []string{"location"},
+				Metadata:  &juliameta.Metadata{UUID: "cd3eb016-35fb-5094-929b-558a96fad6f3"},
+			},
+			want: &purl.PackageURL{
+				Type:       purl.TypeJulia,
+				Name:       "HTTP",
+				Version:    "1.10.8",
+				Qualifiers: purl.QualifiersFromMap(map[string]string{purl.UUID: "cd3eb016-35fb-5094-929b-558a96fad6f3"}),
+			},
+		},
+		{
+			name: "terraform_purl",
+			pkg: &extractor.Package{
+				Name:      "registry.terraform.io/hashicorp/aws",
+				Version:   "5.31.0",
+				PURLType:  purl.TypeTerraform,
+				Locations: []string{"location"},
+			},
+			want: &purl.PackageURL{
+				Type:      purl.TypeTerraform,
+				Namespace: "registry.terraform.io/hashicorp",
+				Name:      "aws",
+				Version:   "5.31.0",
+			},
+		},
+		{
+			name: "github_purl",
+			pkg: &extractor.Package{
+				Name:      "NixOS/nixpkgs",
+				Version:   "1042fd8b148a9105f3c0aca3a6177fd1d9360ba5",
+				PURLType:  purl.TypeGithub,
+				Locations: []string{"location"},
+			},
+			want: &purl.PackageURL{
+				Type:      purl.TypeGithub,
+				Namespace: "nixos",
+				Name:      "nixpkgs",
+				Version:   "1042fd8b148a9105f3c0aca3a6177fd1d9360ba5",
+			},
+		},
 		{
 			name: "spdx_purl",
 			pkg: &extractor.Package{
@@ -188,6 +236,27 @@ func TestToPURL(t *testing.T) {
 				}),
 			},
 		},
+		{
+			name: "yocto_purl",
+			pkg: &extractor.Package{
+				Name:     "libssl3",
+				Version:  "3.0.10-r0",
+				PURLType: purl.TypeYocto,
+				Metadata: &yoctometa.Metadata{
+					PackageName: "libssl3",
+					RecipeName:  "openssl",
+				},
+				Locations: []string{"location"},
+			},
+			want: &purl.PackageURL{
+				Type:    purl.TypeYocto,
+				Name:    "libssl3",
+				Version: "3.0.10-r0",
+				Qualifiers: purl.QualifiersFromMap(map[string]string{
+					purl.Source: "openssl",
+				}),
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -223,6 +292,33 @@ func TestToEcosystem(t *testing.T) {
 			},
 			want: "Go",
 		},
+		{
+			name: "bioconductor",
+			pkg: &extractor.Package{
+				Name:     "BSgenome",
+				Version:  "1.60.0",
+				PURLType: purl.TypeBioconductor,
+			},
+			want: "Bioconductor",
+		},
+		{
+			name: "julia",
+			pkg: &extractor.Package{
+				Name:     "HTTP",
+				Version:  "1.10.8",
+				PURLType: purl.TypeJulia,
+			},
+			want: "Julia",
+		},
+		{
+			name: "opam",
+			pkg: &extractor.Package{
+				Name:     "lwt",
+				Version:  "5.7.0",
+				PURLType: purl.TypeOpam,
+			},
+			want: "opam",
+		},
 		{
 			name: "os_ecosystem",
 			pkg: &extractor.Package{
@@ -16,6 +16,8 @@
 package extractor
 
 import (
+	"time"
+
 	"github.com/google/osv-scalibr/inventory/vex"
 	"github.com/google/osv-scalibr/plugin"
 	"github.com/google/osv-scalibr/purl"
@@ -45,6 +47,81 @@ type LayerDetails struct {
 	InBaseImage bool
 }
 
+// LocationProvenance describes where one of the package's locations was found.
+// For example, a pom.properties file inside a JAR nested in a WAR file in a
+// container image has the provenance
+//
+//	LayerDigest:  "sha256:..."
+//	ArchiveChain: ["app/app.war", "WEB-INF/lib/lib.jar"]
+//	Path:         "META-INF/maven/org.example/lib/pom.properties"
+type LocationProvenance struct {
+	// The entry in Package.Locations this provenance describes.
+	Location string
+	// The diff ID of the container image layer that contains the file. Empty if
+	// the file wasn't found in a container image.
+	LayerDigest string
+	// The path of the outermost archive on the scanned filesystem followed by
+	// the paths of the nested archives, each relative to its parent archive.
+	// Empty if the file isn't inside an archive.
+	ArchiveChain []string
+	// The path of the file inside the innermost archive, or on the scanned
+	// filesystem if ArchiveChain is empty.
+	Path string
+}
+
+// OwnershipHint names a likely owner of one of a package's locations, e.g. a
+// team from a CODEOWNERS file.
+type OwnershipHint struct {
+	// The entry in Package.Locations this hint applies to.
+	Location string
+	// The owner, e.g. "@org/team", a user name or an email address.
+	Owner string
+	// The file the owner was read from, e.g. ".github/CODEOWNERS".
+	Source string
+}
+
+// FileDigest is a cryptographic digest of the file at one of a package's
+// locations, e.g. of a JAR or a binary.
+type FileDigest struct {
+	// The entry in Package.Locations the digest was computed for.
+	Location string
+	// The digest algorithm, e.g. "sha256".
+	Algorithm string
+	// The hex encoded digest.
+	Digest string
+}
+
+// ProjectInfo is metadata about a package version and its source project from
+// package insight services such as deps.dev.
+type ProjectInfo struct {
+	// The number of direct dependencies of the package version.
+	DirectDependencies int
+	// The number of indirect dependencies of the package version.
+	IndirectDependencies int
+	// The source repository of the package, e.g. "github.com/google/osv-scalibr".
+	SourceRepository string
+	// The OpenSSF Scorecard of the source repository, if one is available.
+	Scorecard *Scorecard
+}
+
+// Scorecard is the result of an OpenSSF Scorecard run on a source repository.
+type Scorecard struct {
+	// When the repository was evaluated.
+	Date time.Time
+	// The weighted score of all checks from 0 to 10.
+	OverallScore float64
+	// The results of the individual checks.
+	Checks []*ScorecardCheck
+}
+
+// ScorecardCheck is the result of a single OpenSSF Scorecard check.
+type ScorecardCheck struct {
+	// The name of the check, e.g. "Code-Review".
+	Name string
+	// The score of the check from 0 to 10, or -1 if it was inconclusive.
+	Score int
+}
+
 // Package is an instance of a software package or library found by the extractor.
 // TODO(b/400910349): Currently package is also used to store non-package data
 // like open ports. Move these into their own dedicated types.
@@ -74,6 +151,16 @@ type Package struct {
 	ExploitabilitySignals []*vex.PackageExploitabilitySignal
 	// Details about the layer that the package was attributed to.
 	LayerDetails *LayerDetails
+	// Where the Locations were found, from the container image layer down to
+	// the file inside nested archives. Not set for all locations.
+	LocationProvenance []*LocationProvenance
+	// Likely owners of the package's locations, e.g. from CODEOWNERS files.
+	OwnershipHints []*OwnershipHint
+	// Digests of the files at the package's locations. Not set for all locations.
+	FileDigests []*FileDigest
+	// Dependency and source project metadata of the package version, e.g. from
+	// deps.dev.
+	ProjectInfo *ProjectInfo
 	// The additional data found in the package.
 	Metadata any
 	// Licenses information of this package
@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"io"
 	"io/fs"
+	"maps"
 	"os"
 	"path/filepath"
 	"regexp"
@@ -31,11 +32,15 @@ import (
 	"github.com/gobwas/glob"
 	"github.com/google/osv-scalibr/extractor"
 	"github.com/google/osv-scalibr/extractor/filesystem/internal"
+	"github.com/google/osv-scalibr/extractor/filesystem/pathfilter"
+	"github.com/google/osv-scalibr/extractor/filesystem/targetenv"
 	scalibrfs "github.com/google/osv-scalibr/fs"
+	"github.com/google/osv-scalibr/fs/mounts"
 	"github.com/google/osv-scalibr/inventory"
 	"github.com/google/osv-scalibr/log"
 	"github.com/google/osv-scalibr/plugin"
 	"github.com/google/osv-scalibr/stats"
+	"github.com/google/osv-scalibr/stats/resourceusage"
 )
 
 var (
@@ -78,6 +83,9 @@ type ScanInput struct {
 	// A reader for accessing contents of the file.
 	// Note that the file is closed by the core library, not the plugin.
 	Reader io.Reader
+	// The environment the scanned software is deployed to. Used to evaluate
+	// conditional dependencies. Nil if unknown.
+	TargetEnv *targetenv.Env
 }
 
 // Config stores the config settings for an extraction run.
@@ -101,8 +109,20 @@ type Config struct {
 	SkipDirRegex *regexp.Regexp
 	// Optional: If the regex matches a glob, it will be skipped.
 	SkipDirGlob glob.Glob
+	// Optional: Ordered include and exclude rules for the paths of files and
+	// directories. Excluded paths are skipped before any extractor looks at them.
+	PathFilter *pathfilter.Filter
+	// Optional: Which mounted filesystems under the scan roots are skipped or
+	// walked after the rest of the scan root, e.g. network and pseudo
+	// filesystems. Has no effect on virtual scan roots. If nil, mount points are
+	// walked like any other directory.
+	MountPolicy *mounts.Policy
 	// Optional: Skip files declared in .gitignore files in source repos.
 	UseGitignore bool
+	// Optional: Only extract from files tracked in the git index of the scan
+	// root. This includes staged files that aren't committed yet. Has no effect
+	// on scan roots that aren't git repositories.
+	OnlyGitTracked bool
 	// Optional: stats allows to enter a metric hook. If left nil, no metrics will be recorded.
 	Stats stats.Collector
 	// Optional: Whether to read symlinks.
@@ -118,12 +138,59 @@ type Config struct {
 	PrintDurationAnalysis bool
 	// Optional: If true, fail the scan if any permission errors are encountered.
 	ErrorOnFSErrors bool
+	// Optional: Receives periodic progress events during the filesystem walk.
+	Progress stats.ProgressReporter
+	// Optional: The interval between two progress events. Defaults to
+	// stats.DefaultProgressInterval.
+	ProgressInterval time.Duration
+	// Optional: The expected number of inodes to visit, e.g. from a previous
+	// scan of the same host. Used to estimate the remaining scan time.
+	ExpectedInodes int
+	// Optional: The environment the scanned software is deployed to. Lockfile
+	// extractors use it to skip dependencies that aren't installed there.
+	TargetEnv *targetenv.Env
+	// Optional: How long an extractor can take to extract a single file.
+	PluginTimeouts *plugin.Timeouts
+	// Optional: Counts the files opened and bytes read by the extractors.
+	ResourceTracker *resourceusage.Tracker
+	// Optional: The extractors to run on each of the ScanRoots, in the same
+	// order as ScanRoots. Scan roots without an entry or with a nil entry run
+	// Extractors instead.
+	ScanRootExtractors [][]Extractor
+}
+
+// extractorsForScanRoot returns the extractors to run on the i-th scan root.
+func (cfg *Config) extractorsForScanRoot(i int) []Extractor {
+	if i < len(cfg.ScanRootExtractors) && cfg.ScanRootExtractors[i] != nil {
+		return cfg.ScanRootExtractors[i]
+	}
+	return cfg.Extractors
+}
+
+// allExtractors returns the extractors that run on at least one scan root,
+// deduplicated by name.
+func (cfg *Config) allExtractors(numRoots int) []Extractor {
+	var result []Extractor
+	seen := make(map[string]bool)
+	for i := range numRoots {
+		for _, ex := range cfg.extractorsForScanRoot(i) {
+			if seen[ex.Name()] {
+				continue
+			}
+			seen[ex.Name()] = true
+			result = append(result, ex)
+		}
+	}
+	return result
 }
 
 // Run runs the specified extractors and returns their extraction results,
 // as well as info about whether the plugin runs completed successfully.
+// If ctx is cancelled, the results found until then are returned together
+// with the context's error.
 func Run(ctx context.Context, config *Config) (inventory.Inventory, []*plugin.Status, error) {
-	if len(config.Extractors) == 0 {
+	extractors := config.allExtractors(len(config.ScanRoots))
+	if len(extractors) == 0 {
 		return inventory.Inventory{}, []*plugin.Status{}, nil
 	}
 
@@ -137,19 +204,23 @@ func Run(ctx context.Context, config *Config) (inventory.Inventory, []*plugin.St
 		return inventory.Inventory{}, nil, err
 	}
 
-	var status []*plugin.Status
+	// Report the final progress once all scan roots have been walked.
+	defer wc.reportProgress(true)
+
 	inv := inventory.Inventory{}
-	for _, root := range scanRoots {
-		newInv, st, err := runOnScanRoot(ctx, config, root, wc)
+	for i, root := range scanRoots {
+		wc.extractors = config.extractorsForScanRoot(i)
+		newInv, _, err := runOnScanRoot(ctx, config, root, wc)
+		// Keep the results found before the scan was cancelled.
+		inv.Append(newInv)
 		if err != nil {
-			return inv, nil, err
+			return inv, errToExtractorStatus(extractors, wc.foundInv, wc.errors), err
 		}
-
-		inv.Append(newInv)
-		status = append(status, st...)
 	}
 
-	return inv, status, nil
+	// The extractor results are collected across all scan roots, so report a
+	// single status for each extractor.
+	return inv, errToExtractorStatus(extractors, wc.foundInv, wc.errors), nil
 }
 
 func runOnScanRoot(ctx context.Context, config *Config, scanRoot *scalibrfs.ScanRoot, wc *walkContext) (inventory.Inventory, []*plugin.Status, error) {
@@ -184,6 +255,21 @@ func InitWalkContext(ctx context.Context, config *Config, absScanRoots []*scalib
 	}
 	dirsToSkip = toSlashPaths(dirsToSkip)
 
+	progressInterval := config.ProgressInterval
+	if progressInterval <= 0 {
+		progressInterval = stats.DefaultProgressInterval
+	}
+
+	var mountTable []*mounts.Mount
+	if config.MountPolicy != nil {
+		mountTable = config.MountPolicy.Mounts
+		if mountTable == nil {
+			if mountTable, err = mounts.Read(); err != nil {
+				log.FromContext(ctx).Warnf("Failed to read the mount table, walking all mount points: %v", err)
+			}
+		}
+	}
+
 	return &walkContext{
 		ctx:               ctx,
 		stats:             config.Stats,
@@ -193,16 +279,31 @@ func InitWalkContext(ctx context.Context, config *Config, absScanRoots []*scalib
 		dirsToSkip:        pathStringListToMap(dirsToSkip),
 		skipDirRegex:      config.SkipDirRegex,
 		skipDirGlob:       config.SkipDirGlob,
+		pathFilter:        config.PathFilter,
+		mountPolicy:       config.MountPolicy,
+		mountTable:        mountTable,
 		useGitignore:      config.UseGitignore,
+		onlyGitTracked:    config.OnlyGitTracked,
 		readSymlinks:      config.ReadSymlinks,
 		maxInodes:         config.MaxInodes,
 		maxFileSize:       config.MaxFileSize,
 		inodesVisited:     0,
 		storeAbsolutePath: config.StoreAbsolutePath,
 		errorOnFSErrors:   config.ErrorOnFSErrors,
+		targetEnv:         config.TargetEnv,
+		pluginTimeouts:    config.PluginTimeouts,
+		resourceTracker:   config.ResourceTracker,
 
 		lastStatus: time.Now(),
 
+		progress:          config.Progress,
+		progressInterval:  progressInterval,
+		expectedInodes:    config.ExpectedInodes,
+		walkStart:         time.Now(),
+		lastProgress:      time.Now(),
+		extractorCalls:    make(map[string]int),
+		extractorPackages: make(map[string]int),
+
 		inventory: inventory.Inventory{},
 		errors:    make(map[string]error),
 		foundInv:  make(map[string]bool),
@@ -222,7 +323,7 @@ func RunFS(ctx context.Context, config *Config, wc *walkContext) (inventory.Inve
 	}
 
 	var err error
-	log.Infof("Starting filesystem walk for root: %v", wc.scanRoot)
+	log.FromContext(ctx).Infof("Starting filesystem walk for root: %v", wc.scanRoot)
 	if len(wc.pathsToExtract) > 0 {
 		err = walkIndividualPaths(wc)
 	} else {
@@ -241,16 +342,19 @@ func RunFS(ctx context.Context, config *Config, wc *walkContext) (inventory.Inve
 		}()
 
 		err = internal.WalkDirUnsorted(wc.fs, ".", wc.handleFile, wc.postHandleFile)
+		if err == nil {
+			err = wc.walkDeferredMounts()
+		}
 
 		close(quit)
 	}
 
 	// On Windows, elapsed and wall time are probably the same. On Linux and Mac they are different,
 	// if Scalibr was suspended during runtime.
-	log.Infof("End status: %d dirs visited, %d inodes visited, %d Extract calls, %s elapsed, %s wall time",
+	log.FromContext(ctx).Infof("End status: %d dirs visited, %d inodes visited, %d Extract calls, %s elapsed, %s wall time",
 		wc.dirsVisited, wc.inodesVisited, wc.extractCalls, time.Since(start), time.Duration(time.Now().UnixNano()-start.UnixNano()))
 
-	return wc.inventory, errToExtractorStatus(config.Extractors, wc.foundInv, wc.errors), err
+	return wc.inventory, errToExtractorStatus(wc.extractors, wc.foundInv, wc.errors), err
 }
 
 type walkContext struct {
@@ -265,16 +369,33 @@ type walkContext struct {
 	dirsToSkip        map[string]bool // Anything under these paths should be skipped.
 	skipDirRegex      *regexp.Regexp
 	skipDirGlob       glob.Glob
+	pathFilter        *pathfilter.Filter
+	mountPolicy       *mounts.Policy
+	mountTable        []*mounts.Mount
 	useGitignore      bool
+	onlyGitTracked    bool
 	maxInodes         int
 	inodesVisited     int
 	maxFileSize       int // In bytes.
 	dirsVisited       int
 	storeAbsolutePath bool
 	errorOnFSErrors   bool
+	targetEnv         *targetenv.Env
+	pluginTimeouts    *plugin.Timeouts
+	resourceTracker   *resourceusage.Tracker
 
 	// applicable gitignore patterns for the current and parent directories.
 	gitignores []internal.GitignorePattern
+	// Files in the git index of the current scan root. Nil if onlyGitTracked is
+	// unset or the scan root isn't a git repository.
+	gitTracked *internal.GitTrackedFiles
+	// The actions of the mount policy for the mount points under the current
+	// scan root, keyed by their path relative to the scan root.
+	mountActions map[string]mounts.Action
+	// Mount points that are walked after the rest of the current scan root.
+	deferredMounts []string
+	// The deferred mount point that's currently walked.
+	deferredRoot string
 	// Inventories found.
 	inventory inventory.Inventory
 	// Extractor name to runtime errors.
@@ -292,6 +413,16 @@ type walkContext struct {
 
 	currentPath string
 	fileAPI     *lazyFileAPI
+
+	// Data for progress reporting.
+	progress          stats.ProgressReporter
+	progressInterval  time.Duration
+	expectedInodes    int
+	walkStart         time.Time
+	lastProgress      time.Time
+	filesMatched      int
+	extractorCalls    map[string]int
+	extractorPackages map[string]int
 }
 
 func walkIndividualPaths(wc *walkContext) error {
@@ -324,6 +455,32 @@ func walkIndividualPaths(wc *walkContext) error {
 			return err
 		}
 	}
+	return wc.walkDeferredMounts()
+}
+
+// walkDeferredMounts walks the mount points whose walk was deferred by the
+// mount policy, including the ones found while walking them.
+func (wc *walkContext) walkDeferredMounts() error {
+	defer func() {
+		wc.deferredMounts = nil
+		wc.deferredRoot = ""
+	}()
+	for i := 0; i < len(wc.deferredMounts); i++ {
+		wc.deferredRoot = wc.deferredMounts[i]
+		log.FromContext(wc.ctx).Infof("Walking deferred mount point %q", wc.deferredRoot)
+		if wc.useGitignore {
+			gitignores, err := internal.ParseParentGitignores(wc.fs, wc.deferredRoot)
+			if err != nil {
+				return err
+			}
+			wc.gitignores = gitignores
+		}
+		err := internal.WalkDirUnsorted(wc.fs, wc.deferredRoot, wc.handleFile, wc.postHandleFile)
+		wc.gitignores = nil
+		if err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -336,6 +493,9 @@ func (wc *walkContext) handleFile(path string, d fs.DirEntry, fserr error) error
 	}
 
 	wc.stats.AfterInodeVisited(path)
+	if wc.progress != nil && time.Since(wc.lastProgress) >= wc.progressInterval {
+		wc.reportProgress(false)
+	}
 	if wc.ctx.Err() != nil {
 		return wc.ctx.Err()
 	}
@@ -345,9 +505,9 @@ func (wc *walkContext) handleFile(path string, d fs.DirEntry, fserr error) error
 		}
 		if os.IsPermission(fserr) {
 			// Permission errors are expected when traversing the entire filesystem.
-			log.Debugf("fserr (permission error): %v", fserr)
+			log.FromContext(wc.ctx).Debugf("fserr (permission error): %v", fserr)
 		} else {
-			log.Errorf("fserr (non-permission error): %v", fserr)
+			log.FromContext(wc.ctx).Errorf("fserr (non-permission error): %v", fserr)
 		}
 		return nil
 	}
@@ -357,6 +517,15 @@ func (wc *walkContext) handleFile(path string, d fs.DirEntry, fserr error) error
 
 	if d.Type().IsDir() {
 		wc.dirsVisited++
+		// Check mount points before anything reads from them as they might be
+		// unresponsive network filesystems.
+		if wc.skipMount(path) {
+			if wc.useGitignore {
+				// postHandleFile removes the entry again.
+				wc.gitignores = append(wc.gitignores, internal.EmptyGitignore())
+			}
+			return fs.SkipDir
+		}
 		if wc.useGitignore {
 			gitignores := internal.EmptyGitignore()
 			var err error
@@ -368,6 +537,9 @@ func (wc *walkContext) handleFile(path string, d fs.DirEntry, fserr error) error
 			}
 			wc.gitignores = append(wc.gitignores, gitignores)
 		}
+		if wc.pathFilter.Excluded(path) {
+			return fs.SkipDir
+		}
 
 		// Pass the path to the extractors that extract from directories.
 		for _, ex := range wc.extractors {
@@ -394,13 +566,20 @@ func (wc *walkContext) handleFile(path string, d fs.DirEntry, fserr error) error
 		}
 	}
 
+	if wc.pathFilter.Excluded(path) {
+		return nil
+	}
 	if wc.useGitignore {
 		if internal.GitignoreMatch(wc.gitignores, strings.Split(path, "/"), false) {
 			return nil
 		}
 	}
+	if wc.gitTracked != nil && !wc.gitTracked.Tracked(path, false) {
+		return nil
+	}
 
 	fSize := int64(-1) // -1 means we haven't checked the file size yet.
+	matched := false
 	for _, ex := range wc.extractors {
 		if !ex.Requirements().ExtractFromDirs && ex.FileRequired(wc.fileAPI) {
 			if wc.maxFileSize > 0 && fSize == -1 {
@@ -410,10 +589,14 @@ func (wc *walkContext) handleFile(path string, d fs.DirEntry, fserr error) error
 					return fmt.Errorf("failed to get file size for %q: %w", path, err)
 				}
 				if fSize > int64(wc.maxFileSize) {
-					log.Debugf("Skipping file %q because it has size %d bytes and the maximum is %d bytes", path, fSize, wc.maxFileSize)
+					log.FromContext(wc.ctx).Debugf("Skipping file %q because it has size %d bytes and the maximum is %d bytes", path, fSize, wc.maxFileSize)
 					return nil
 				}
 			}
+			if !matched {
+				matched = true
+				wc.filesMatched++
+			}
 			wc.runExtractor(ex, path, false)
 		}
 	}
@@ -446,10 +629,31 @@ func (api *lazyFileAPI) Stat() (fs.FileInfo, error) {
 	return api.currentFileInfo, api.currentStatErr
 }
 
+// skipMount returns whether the walk doesn't descend into the directory at path
+// now because it's a mount point that the mount policy skips or defers.
+func (wc *walkContext) skipMount(path string) bool {
+	switch wc.mountActions[path] {
+	case mounts.Skip:
+		log.FromContext(wc.ctx).Debugf("Skipping mount point %q", path)
+		return true
+	case mounts.Defer:
+		if path == wc.deferredRoot {
+			return false
+		}
+		wc.deferredMounts = append(wc.deferredMounts, path)
+		return true
+	default:
+		return false
+	}
+}
+
 func (wc *walkContext) shouldSkipDir(path string) bool {
 	if _, ok := wc.dirsToSkip[path]; ok {
 		return true
 	}
+	if wc.pathFilter.Excluded(path) {
+		return true
+	}
 	if wc.ignoreSubDirs && !slices.Contains(wc.pathsToExtract, path) {
 		// Skip dirs that aren't one of the root dirs.
 		return true
@@ -457,6 +661,9 @@ func (wc *walkContext) shouldSkipDir(path string) bool {
 	if wc.useGitignore && internal.GitignoreMatch(wc.gitignores, strings.Split(path, "/"), true) {
 		return true
 	}
+	if wc.gitTracked != nil && !wc.gitTracked.Tracked(path, true) {
+		return true
+	}
 	if wc.skipDirRegex != nil {
 		return wc.skipDirRegex.MatchString(path)
 	}
@@ -486,15 +693,25 @@ func (wc *walkContext) runExtractor(ex Extractor, path string, isDir bool) {
 	}
 
 	wc.extractCalls++
+	wc.extractorCalls[ex.Name()]++
 
 	start := time.Now()
-	results, err := ex.Extract(wc.ctx, &ScanInput{
-		FS:     wc.fs,
-		Path:   path,
-		Root:   wc.scanRoot,
-		Info:   info,
-		Reader: rc,
+	run := wc.resourceTracker.StartPlugin(ex.Name())
+	var results inventory.Inventory
+	ctx := log.NewContext(wc.ctx, log.KeyPlugin, ex.Name(), log.KeyPath, path)
+	err = wc.pluginTimeouts.Run(ctx, ex.Name(), func(ctx context.Context) error {
+		var err error
+		results, err = ex.Extract(ctx, &ScanInput{
+			FS:        wc.fs,
+			Path:      path,
+			Root:      wc.scanRoot,
+			Info:      info,
+			Reader:    rc,
+			TargetEnv: wc.targetEnv,
+		})
+		return err
 	})
+	run.Stop(1)
 	wc.stats.AfterExtractorRun(ex.Name(), &stats.AfterExtractorStats{
 		Path:      path,
 		Root:      wc.scanRoot,
@@ -509,6 +726,7 @@ func (wc *walkContext) runExtractor(ex Extractor, path string, isDir bool) {
 
 	if !results.IsEmpty() {
 		wc.foundInv[ex.Name()] = true
+		wc.extractorPackages[ex.Name()] += len(results.Packages)
 		for _, r := range results.Packages {
 			r.Plugins = append(r.Plugins, ex.Name())
 			if wc.storeAbsolutePath {
@@ -523,11 +741,43 @@ func (wc *walkContext) runExtractor(ex Extractor, path string, isDir bool) {
 // currentRoot is expected to be an absolute path.
 func (wc *walkContext) UpdateScanRoot(absRoot string, fs scalibrfs.FS) error {
 	wc.scanRoot = absRoot
-	wc.fs = fs
+	wc.fs = wc.resourceTracker.WrapFS(fs)
+	wc.inventory = inventory.Inventory{}
 	wc.fileAPI.fs = fs
+	wc.gitTracked = nil
+	wc.mountActions = mountActions(wc.mountPolicy, wc.mountTable, absRoot)
+	if wc.onlyGitTracked {
+		gitTracked, err := internal.ParseGitIndex(fs)
+		if err != nil {
+			return err
+		}
+		if gitTracked == nil {
+			log.FromContext(wc.ctx).Warnf("Scan root %q is not a git repository, scanning all files", absRoot)
+		}
+		wc.gitTracked = gitTracked
+	}
 	return nil
 }
 
+// mountActions returns the actions of the mount policy for the mount points
+// under absRoot, keyed by their slash-separated path relative to absRoot.
+func mountActions(policy *mounts.Policy, mountTable []*mounts.Mount, absRoot string) map[string]mounts.Action {
+	if policy == nil || absRoot == "" {
+		return nil
+	}
+	actions := make(map[string]mounts.Action)
+	// Later entries shadow earlier ones mounted at the same location.
+	for _, m := range mountTable {
+		rel, err := filepath.Rel(absRoot, filepath.FromSlash(m.MountPoint))
+		if err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			// The scan root is inside the mount or the mount isn't under the scan root.
+			continue
+		}
+		actions[filepath.ToSlash(rel)] = policy.Action(m)
+	}
+	return actions
+}
+
 func expandAbsolutePath(scanRoot string, paths []string) []string {
 	var locations []string
 	for _, l := range paths {
@@ -562,6 +812,11 @@ func stripAllPathPrefixes(paths []string, scanRoots []*scalibrfs.ScanRoot) ([]st
 		}
 
 		rp, err := stripFromAtLeastOnePrefix(abs, scanRoots)
+		if errors.Is(err, ErrNotRelativeToScanRoots) {
+			// The path might point into one of the scan roots through a symlink or
+			// bind mount.
+			rp, err = stripFromAtLeastOneCanonicalPrefix(abs, scanRoots)
+		}
 		if err != nil {
 			return nil, err
 		}
@@ -600,6 +855,22 @@ func stripFromAtLeastOnePrefix(path string, scanRoots []*scalibrfs.ScanRoot) (st
 	return "", ErrNotRelativeToScanRoots
 }
 
+// stripFromAtLeastOneCanonicalPrefix is like stripFromAtLeastOnePrefix but
+// compares the canonical paths of the path and the scan roots.
+func stripFromAtLeastOneCanonicalPrefix(path string, scanRoots []*scalibrfs.ScanRoot) (string, error) {
+	canonical, err := scalibrfs.CanonicalPath(path)
+	if err != nil {
+		return "", ErrNotRelativeToScanRoots
+	}
+	var canonicalRoots []*scalibrfs.ScanRoot
+	for _, r := range scanRoots {
+		if c, err := r.WithCanonicalPath(); err == nil {
+			canonicalRoots = append(canonicalRoots, c)
+		}
+	}
+	return stripFromAtLeastOnePrefix(canonical, canonicalRoots)
+}
+
 func pathStringListToMap(paths []string) map[string]bool {
 	result := make(map[string]bool)
 	for _, p := range paths {
@@ -635,6 +906,26 @@ func (wc *walkContext) printStatus() {
 	wc.lastExtracts = wc.extractCalls
 }
 
+// reportProgress sends a snapshot of the walk's progress to the configured reporter.
+func (wc *walkContext) reportProgress(done bool) {
+	if wc.progress == nil {
+		return
+	}
+	wc.progress.ReportProgress(&stats.Progress{
+		ScanRoot:          wc.scanRoot,
+		CurrentPath:       wc.currentPath,
+		InodesVisited:     wc.inodesVisited,
+		DirsVisited:       wc.dirsVisited,
+		FilesMatched:      wc.filesMatched,
+		ExtractorCalls:    maps.Clone(wc.extractorCalls),
+		ExtractorPackages: maps.Clone(wc.extractorPackages),
+		Elapsed:           time.Since(wc.walkStart),
+		ExpectedInodes:    wc.expectedInodes,
+		Done:              done,
+	})
+	wc.lastProgress = time.Now()
+}
+
 // GetRealPath returns the real absolute path of the file on the scanning host's filesystem.
 // If the file is on a virtual filesystem (e.g. a remote container), it is first copied into a
 // temporary directory on the scanning host's filesystem. It's up to the caller to delete the
@@ -15,6 +15,7 @@
 package filesystem_test
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"io"
@@ -29,12 +30,16 @@ import (
 	"testing"
 	"time"
 
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/format/index"
 	"github.com/gobwas/glob"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/google/osv-scalibr/extractor"
 	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/extractor/filesystem/pathfilter"
 	scalibrfs "github.com/google/osv-scalibr/fs"
+	"github.com/google/osv-scalibr/fs/mounts"
 	"github.com/google/osv-scalibr/inventory"
 	"github.com/google/osv-scalibr/plugin"
 	"github.com/google/osv-scalibr/stats"
@@ -138,6 +143,311 @@ func TestInitWalkContext(t *testing.T) {
 }
 
 // A fake extractor that only extracts directories.
+// slowExtractor blocks on the given path until the context is cancelled.
+type slowExtractor struct {
+	filesystem.Extractor
+
+	slowPath string
+}
+
+func (e slowExtractor) Extract(ctx context.Context, input *filesystem.ScanInput) (inventory.Inventory, error) {
+	inv, err := e.Extractor.Extract(ctx, input)
+	if err != nil || input.Path != e.slowPath {
+		return inv, err
+	}
+	<-ctx.Done()
+	return inv, ctx.Err()
+}
+
+func TestRun_PluginTimeout(t *testing.T) {
+	dir := t.TempDir()
+	for _, f := range []string{"fast.txt", "slow.txt"} {
+		if err := os.WriteFile(filepath.Join(dir, f), []byte("content"), 0644); err != nil {
+			t.Fatalf("os.WriteFile(%q): %v", f, err)
+		}
+	}
+
+	ex := []filesystem.Extractor{
+		slowExtractor{
+			Extractor: fe.New("ex1", 1, []string{"fast.txt", "slow.txt"}, map[string]fe.NamesErr{
+				"fast.txt": {Names: []string{"software1"}},
+				"slow.txt": {Names: []string{"software2"}},
+			}),
+			slowPath: "slow.txt",
+		},
+		fe.New("ex2", 1, []string{"slow.txt"}, map[string]fe.NamesErr{"slow.txt": {Names: []string{"software3"}}}),
+	}
+	config := &filesystem.Config{
+		Extractors:     ex,
+		ScanRoots:      scalibrfs.RealFSScanRoots(dir),
+		Stats:          stats.NoopCollector{},
+		PluginTimeouts: &plugin.Timeouts{Default: 10 * time.Millisecond},
+	}
+	gotInv, gotStatus, err := filesystem.Run(context.Background(), config)
+	if err != nil {
+		t.Fatalf("filesystem.Run(%v): %v", config, err)
+	}
+
+	var gotNames []string
+	for _, p := range gotInv.Packages {
+		gotNames = append(gotNames, p.Name)
+	}
+	wantNames := []string{"software1", "software2", "software3"}
+	if diff := cmp.Diff(wantNames, gotNames, cmpopts.SortSlices(func(a, b string) bool { return a < b })); diff != "" {
+		t.Errorf("filesystem.Run(%v): unexpected packages (-want +got):\n%s", config, diff)
+	}
+
+	wantStatus := []*plugin.Status{
+		{Name: "ex1", Version: 1, Status: &plugin.ScanStatus{
+			Status:        plugin.ScanStatusPartiallySucceeded,
+			FailureReason: "slow.txt: plugin timed out after 10ms: context deadline exceeded",
+			ErrorCounts:   []*plugin.ErrorCount{{Category: plugin.ErrorCategoryTimeout, Count: 1}},
+		}},
+		{Name: "ex2", Version: 1, Status: &plugin.ScanStatus{Status: plugin.ScanStatusSucceeded}},
+	}
+	if diff := cmp.Diff(wantStatus, gotStatus); diff != "" {
+		t.Errorf("filesystem.Run(%v): unexpected status (-want +got):\n%s", config, diff)
+	}
+}
+
+func TestRun_PathFilter(t *testing.T) {
+	dir := t.TempDir()
+	files := []string{"app/package-lock.json", "app/node_modules/dep/package-lock.json", "testdata/Cargo.lock", "testdata/package-lock.json"}
+	for _, f := range files {
+		p := filepath.Join(dir, filepath.FromSlash(f))
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatalf("os.MkdirAll(%q): %v", filepath.Dir(p), err)
+		}
+		if err := os.WriteFile(p, []byte("content"), 0644); err != nil {
+			t.Fatalf("os.WriteFile(%q): %v", p, err)
+		}
+	}
+	results := map[string]fe.NamesErr{}
+	for _, f := range files {
+		results[f] = fe.NamesErr{Names: []string{f}}
+	}
+	filter, err := pathfilter.New([]pathfilter.Rule{
+		{Action: pathfilter.Exclude, Glob: "**/node_modules"},
+		{Action: pathfilter.Include, Glob: "**/*.lock"},
+		{Action: pathfilter.Exclude, Regex: "^testdata/"},
+	})
+	if err != nil {
+		t.Fatalf("pathfilter.New(): %v", err)
+	}
+
+	config := &filesystem.Config{
+		Extractors: []filesystem.Extractor{fe.New("ex1", 1, files, results)},
+		ScanRoots:  scalibrfs.RealFSScanRoots(dir),
+		Stats:      stats.NoopCollector{},
+		PathFilter: filter,
+	}
+	gotInv, _, err := filesystem.Run(context.Background(), config)
+	if err != nil {
+		t.Fatalf("filesystem.Run(%v): %v", config, err)
+	}
+
+	var gotNames []string
+	for _, p := range gotInv.Packages {
+		gotNames = append(gotNames, p.Name)
+	}
+	wantNames := []string{"app/package-lock.json", "testdata/Cargo.lock"}
+	if diff := cmp.Diff(wantNames, gotNames, cmpopts.SortSlices(func(a, b string) bool { return a < b })); diff != "" {
+		t.Errorf("filesystem.Run(%v): unexpected packages (-want +got):\n%s", config, diff)
+	}
+}
+
+func TestRun_ScanRootExtractors(t *testing.T) {
+	osDir := t.TempDir()
+	appDir := t.TempDir()
+	for _, p := range []string{filepath.Join(osDir, "status"), filepath.Join(osDir, "requirements.txt"), filepath.Join(appDir, "status"), filepath.Join(appDir, "requirements.txt")} {
+		if err := os.WriteFile(p, []byte("content"), 0644); err != nil {
+			t.Fatalf("os.WriteFile(%q): %v", p, err)
+		}
+	}
+	osEx := fe.New("os", 1, []string{"status"}, map[string]fe.NamesErr{"status": {Names: []string{"dpkg-package"}}})
+	langEx := fe.New("lang", 1, []string{"requirements.txt"}, map[string]fe.NamesErr{"requirements.txt": {Names: []string{"pypi-package"}}})
+
+	config := &filesystem.Config{
+		Extractors: []filesystem.Extractor{osEx, langEx},
+		ScanRoots:  append(scalibrfs.RealFSScanRoots(osDir), scalibrfs.RealFSScanRoots(appDir)...),
+		ScanRootExtractors: [][]filesystem.Extractor{
+			{osEx},
+			{langEx},
+		},
+		Stats: stats.NoopCollector{},
+	}
+	gotInv, gotStatus, err := filesystem.Run(context.Background(), config)
+	if err != nil {
+		t.Fatalf("filesystem.Run(%v): %v", config, err)
+	}
+
+	var gotPkgs []string
+	for _, p := range gotInv.Packages {
+		gotPkgs = append(gotPkgs, p.Name+"@"+p.Locations[0])
+	}
+	wantPkgs := []string{"dpkg-package@status", "pypi-package@requirements.txt"}
+	if diff := cmp.Diff(wantPkgs, gotPkgs, cmpopts.SortSlices(func(a, b string) bool { return a < b })); diff != "" {
+		t.Errorf("filesystem.Run(%v): unexpected packages (-want +got):\n%s", config, diff)
+	}
+
+	wantStatus := []*plugin.Status{
+		{Name: "os", Version: 1, Status: &plugin.ScanStatus{Status: plugin.ScanStatusSucceeded}},
+		{Name: "lang", Version: 1, Status: &plugin.ScanStatus{Status: plugin.ScanStatusSucceeded}},
+	}
+	if diff := cmp.Diff(wantStatus, gotStatus); diff != "" {
+		t.Errorf("filesystem.Run(%v): unexpected status (-want +got):\n%s", config, diff)
+	}
+}
+
+func TestRun_MountPolicy(t *testing.T) {
+	dir := t.TempDir()
+	files := []string{"app/package-lock.json", "proc/package-lock.json", "nfs/package-lock.json", "fuse/package-lock.json", "data/package-lock.json"}
+	for _, f := range files {
+		p := filepath.Join(dir, filepath.FromSlash(f))
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatalf("os.MkdirAll(%q): %v", filepath.Dir(p), err)
+		}
+		if err := os.WriteFile(p, []byte("content"), 0644); err != nil {
+			t.Fatalf("os.WriteFile(%q): %v", p, err)
+		}
+	}
+	results := map[string]fe.NamesErr{}
+	for _, f := range files {
+		results[f] = fe.NamesErr{Names: []string{f}}
+	}
+	policy := mounts.DefaultPolicy()
+	policy.Mounts = []*mounts.Mount{
+		// Mounts that contain the scan root are walked.
+		{Device: "server:/", MountPoint: filepath.Dir(dir), FSType: "nfs4"},
+		{Device: "proc", MountPoint: filepath.Join(dir, "proc"), FSType: "proc"},
+		{Device: "server:/export", MountPoint: filepath.Join(dir, "nfs"), FSType: "nfs4"},
+		{Device: "sshfs#host:", MountPoint: filepath.Join(dir, "fuse"), FSType: "fuse.sshfs"},
+		{Device: "/dev/sdb1", MountPoint: filepath.Join(dir, "data"), FSType: "ext4"},
+	}
+
+	tests := []struct {
+		desc           string
+		pathsToExtract []string
+		useGitignore   bool
+	}{
+		{
+			desc: "walk",
+		},
+		{
+			desc:         "walk_with_gitignore",
+			useGitignore: true,
+		},
+		{
+			desc:           "paths_to_extract",
+			pathsToExtract: []string{dir},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			config := &filesystem.Config{
+				Extractors:     []filesystem.Extractor{fe.New("ex1", 1, files, results)},
+				ScanRoots:      scalibrfs.RealFSScanRoots(dir),
+				PathsToExtract: tt.pathsToExtract,
+				UseGitignore:   tt.useGitignore,
+				Stats:          stats.NoopCollector{},
+				MountPolicy:    policy,
+			}
+			gotInv, _, err := filesystem.Run(context.Background(), config)
+			if err != nil {
+				t.Fatalf("filesystem.Run(%v): %v", config, err)
+			}
+
+			var gotNames []string
+			for _, p := range gotInv.Packages {
+				gotNames = append(gotNames, p.Name)
+			}
+			wantNames := []string{"app/package-lock.json", "data/package-lock.json", "fuse/package-lock.json"}
+			if diff := cmp.Diff(wantNames, gotNames, cmpopts.SortSlices(func(a, b string) bool { return a < b })); diff != "" {
+				t.Errorf("filesystem.Run(%v): unexpected packages (-want +got):\n%s", config, diff)
+			}
+			// The deferred FUSE mount is walked last.
+			if len(gotNames) > 0 && gotNames[len(gotNames)-1] != "fuse/package-lock.json" {
+				t.Errorf("filesystem.Run(%v): got packages in order %v, want the deferred mount last", config, gotNames)
+			}
+		})
+	}
+}
+
+func TestRun_OnlyGitTracked(t *testing.T) {
+	files := []string{"go.mod", "staged/go.mod", "untracked/go.mod", "vendor/dep/go.mod"}
+	results := map[string]fe.NamesErr{}
+	for _, f := range files {
+		results[f] = fe.NamesErr{Names: []string{f}}
+	}
+
+	testCases := []struct {
+		desc      string
+		tracked   []string
+		wantNames []string
+	}{
+		{
+			desc:      "git_repo",
+			tracked:   []string{"go.mod", "staged/go.mod", "vendor/dep/other.txt"},
+			wantNames: []string{"go.mod", "staged/go.mod"},
+		},
+		{
+			desc:      "not_a_git_repo",
+			wantNames: files,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			dir := t.TempDir()
+			for _, f := range files {
+				p := filepath.Join(dir, filepath.FromSlash(f))
+				if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+					t.Fatalf("os.MkdirAll(%q): %v", filepath.Dir(p), err)
+				}
+				if err := os.WriteFile(p, []byte("content"), 0644); err != nil {
+					t.Fatalf("os.WriteFile(%q): %v", p, err)
+				}
+			}
+			if tc.tracked != nil {
+				idx := &index.Index{Version: 2}
+				for _, f := range tc.tracked {
+					idx.Entries = append(idx.Entries, &index.Entry{Name: f, Mode: filemode.Regular})
+				}
+				var buf bytes.Buffer
+				if err := index.NewEncoder(&buf).Encode(idx); err != nil {
+					t.Fatalf("index.Encode(): %v", err)
+				}
+				if err := os.Mkdir(filepath.Join(dir, ".git"), 0755); err != nil {
+					t.Fatalf("os.Mkdir(.git): %v", err)
+				}
+				if err := os.WriteFile(filepath.Join(dir, ".git", "index"), buf.Bytes(), 0644); err != nil {
+					t.Fatalf("os.WriteFile(.git/index): %v", err)
+				}
+			}
+
+			config := &filesystem.Config{
+				Extractors:     []filesystem.Extractor{fe.New("ex1", 1, files, results)},
+				ScanRoots:      scalibrfs.RealFSScanRoots(dir),
+				Stats:          stats.NoopCollector{},
+				OnlyGitTracked: true,
+			}
+			gotInv, _, err := filesystem.Run(context.Background(), config)
+			if err != nil {
+				t.Fatalf("filesystem.Run(%v): %v", config, err)
+			}
+
+			var gotNames []string
+			for _, p := range gotInv.Packages {
+				gotNames = append(gotNames, p.Name)
+			}
+			if diff := cmp.Diff(tc.wantNames, gotNames, cmpopts.SortSlices(func(a, b string) bool { return a < b })); diff != "" {
+				t.Errorf("filesystem.Run(%v): unexpected packages (-want +got):\n%s", config, diff)
+			}
+		})
+	}
+}
+
 type fakeExtractorDirs struct {
 	dir  string
 	name string
@@ -516,7 +826,8 @@ func TestRunFS(t *testing.T) {
 			}},
 			wantStatus: []*plugin.Status{
 				{Name: "ex1", Version: 1, Status: &plugin.ScanStatus{
-					Status: plugin.ScanStatusPartiallySucceeded,
+					Status:      plugin.ScanStatusPartiallySucceeded,
+					ErrorCounts: []*plugin.ErrorCount{{Category: plugin.ErrorCategoryOther, Count: 1}},
 				}},
 			},
 			wantInodeCount: 6,
@@ -529,7 +840,8 @@ func TestRunFS(t *testing.T) {
 			wantPkg: inventory.Inventory{},
 			wantStatus: []*plugin.Status{
 				{Name: "ex1", Version: 1, Status: &plugin.ScanStatus{
-					Status: plugin.ScanStatusFailed,
+					Status:      plugin.ScanStatusFailed,
+					ErrorCounts: []*plugin.ErrorCount{{Category: plugin.ErrorCategoryOther, Count: 1}},
 				}},
 			},
 			wantInodeCount: 6,
@@ -545,7 +857,8 @@ func TestRunFS(t *testing.T) {
 			wantPkg: inventory.Inventory{},
 			wantStatus: []*plugin.Status{
 				{Name: "ex1", Version: 1, Status: &plugin.ScanStatus{
-					Status: plugin.ScanStatusFailed,
+					Status:      plugin.ScanStatusFailed,
+					ErrorCounts: []*plugin.ErrorCount{{Category: plugin.ErrorCategoryOther, Count: 2}},
 				}},
 			},
 			wantInodeCount: 6,
@@ -988,6 +1301,7 @@ func TestRunFS_ReadError(t *testing.T) {
 	wantStatus := []*plugin.Status{
 		{Name: "ex1", Version: 1, Status: &plugin.ScanStatus{
 			Status: plugin.ScanStatusFailed, FailureReason: "Open(file): failed to open",
+			ErrorCounts: []*plugin.ErrorCount{{Category: plugin.ErrorCategoryOther, Count: 1}},
 		}},
 	}
 	fsys := &fakeFS{}
@@ -1020,6 +1334,71 @@ func TestRunFS_ReadError(t *testing.T) {
 	}
 }
 
+func TestRun_Progress(t *testing.T) {
+	dir := t.TempDir()
+	for _, f := range []string{"dir1/file1.txt", "dir2/file2.txt", "dir2/other.txt"} {
+		p := filepath.Join(dir, filepath.FromSlash(f))
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatalf("os.MkdirAll(%q): %v", filepath.Dir(p), err)
+		}
+		if err := os.WriteFile(p, []byte("content"), 0644); err != nil {
+			t.Fatalf("os.WriteFile(%q): %v", p, err)
+		}
+	}
+
+	path1 := "dir1/file1.txt"
+	path2 := "dir2/file2.txt"
+	ex := []filesystem.Extractor{
+		fe.New("ex1", 1, []string{path1, path2}, map[string]fe.NamesErr{
+			path1: {Names: []string{"software1", "software2"}},
+			path2: {Names: []string{"software3"}},
+		}),
+		fe.New("ex2", 1, []string{path1}, map[string]fe.NamesErr{path1: {}}),
+	}
+
+	var events []*stats.Progress
+	config := &filesystem.Config{
+		Extractors: ex,
+		ScanRoots:  scalibrfs.RealFSScanRoots(dir),
+		Stats:      stats.NoopCollector{},
+		Progress: stats.ProgressFunc(func(p *stats.Progress) {
+			events = append(events, p)
+		}),
+		ProgressInterval: time.Nanosecond,
+		ExpectedInodes:   10,
+	}
+	if _, _, err := filesystem.Run(context.Background(), config); err != nil {
+		t.Fatalf("filesystem.Run(%v): %v", config, err)
+	}
+
+	if len(events) < 2 {
+		t.Fatalf("filesystem.Run(%v): got %d progress events, want at least 2", config, len(events))
+	}
+	for _, e := range events[:len(events)-1] {
+		if e.Done {
+			t.Errorf("filesystem.Run(%v): got intermediate progress event with Done set: %+v", config, e)
+		}
+	}
+
+	got := events[len(events)-1]
+	want := &stats.Progress{
+		InodesVisited:     6,
+		DirsVisited:       3,
+		FilesMatched:      2,
+		ExtractorCalls:    map[string]int{"ex1": 2, "ex2": 1},
+		ExtractorPackages: map[string]int{"ex1": 3},
+		ExpectedInodes:    10,
+		Done:              true,
+	}
+	opts := cmpopts.IgnoreFields(stats.Progress{}, "ScanRoot", "CurrentPath", "Elapsed")
+	if diff := cmp.Diff(want, got, opts); diff != "" {
+		t.Errorf("filesystem.Run(%v): unexpected final progress (-want +got):\n%s", config, diff)
+	}
+	if eta, ok := got.ETA(); !ok || eta != 0 {
+		t.Errorf("ETA() of the final progress = %v, %t, want 0, true", eta, ok)
+	}
+}
+
 type fakeFileAPI struct {
 	path string
 	info fakefs.FakeFileInfo
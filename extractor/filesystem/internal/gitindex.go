@@ -0,0 +1,75 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"fmt"
+	"os"
+	"path"
+
+	"github.com/go-git/go-git/v5/plumbing/format/index"
+	scalibrfs "github.com/google/osv-scalibr/fs"
+)
+
+// gitIndexPath is the location of the git index relative to the root of a repository.
+const gitIndexPath = ".git/index"
+
+// GitTrackedFiles is the set of files recorded in the git index of a repository.
+// Since staged changes are written to the index, newly added files that aren't
+// committed yet are also considered tracked.
+type GitTrackedFiles struct {
+	files map[string]bool
+	// Directories that contain at least one tracked file.
+	dirs map[string]bool
+}
+
+// ParseGitIndex reads the git index of the repository at the root of fs.
+// Returns nil if the root isn't a git repository.
+func ParseGitIndex(fs scalibrfs.FS) (*GitTrackedFiles, error) {
+	f, err := fs.Open(gitIndexPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	idx := &index.Index{}
+	if err := index.NewDecoder(f).Decode(idx); err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", gitIndexPath, err)
+	}
+
+	t := &GitTrackedFiles{
+		files: make(map[string]bool, len(idx.Entries)),
+		dirs:  map[string]bool{".": true},
+	}
+	for _, e := range idx.Entries {
+		t.files[e.Name] = true
+		for dir := path.Dir(e.Name); !t.dirs[dir]; dir = path.Dir(dir) {
+			t.dirs[dir] = true
+		}
+	}
+	return t, nil
+}
+
+// Tracked returns whether the specified path is tracked by git. Directories are
+// considered tracked if they contain any tracked files.
+func (t *GitTrackedFiles) Tracked(filePath string, isDir bool) bool {
+	if isDir {
+		return t.dirs[filePath]
+	}
+	return t.files[filePath]
+}
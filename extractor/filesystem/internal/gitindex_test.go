@@ -0,0 +1,88 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"bytes"
+	"testing"
+	"testing/fstest"
+
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/format/index"
+	scalibrfs "github.com/google/osv-scalibr/fs"
+)
+
+func encodeGitIndex(t *testing.T, files ...string) []byte {
+	t.Helper()
+	idx := &index.Index{Version: 2}
+	for _, f := range files {
+		idx.Entries = append(idx.Entries, &index.Entry{Name: f, Mode: filemode.Regular})
+	}
+	var buf bytes.Buffer
+	if err := index.NewEncoder(&buf).Encode(idx); err != nil {
+		t.Fatalf("index.Encode(%v): %v", files, err)
+	}
+	return buf.Bytes()
+}
+
+func TestParseGitIndex(t *testing.T) {
+	fsys := scalibrfs.FS(fstest.MapFS{
+		".git/index": {Data: encodeGitIndex(t, "go.mod", "cmd/tool/main.go", "vendor/a/b/go.mod")},
+	})
+	tracked, err := ParseGitIndex(fsys)
+	if err != nil {
+		t.Fatalf("ParseGitIndex(): %v", err)
+	}
+
+	tests := []struct {
+		path  string
+		isDir bool
+		want  bool
+	}{
+		{path: "go.mod", want: true},
+		{path: "cmd/tool/main.go", want: true},
+		{path: "cmd/tool/untracked.go", want: false},
+		{path: "cmd", isDir: true, want: true},
+		{path: "cmd/tool", isDir: true, want: true},
+		{path: "vendor/a", isDir: true, want: true},
+		{path: "node_modules", isDir: true, want: false},
+		{path: ".git", isDir: true, want: false},
+		{path: ".", isDir: true, want: true},
+		// Directories aren't tracked as files.
+		{path: "cmd", want: false},
+	}
+	for _, tc := range tests {
+		if got := tracked.Tracked(tc.path, tc.isDir); got != tc.want {
+			t.Errorf("Tracked(%q, %v): got %v, want %v", tc.path, tc.isDir, got, tc.want)
+		}
+	}
+}
+
+func TestParseGitIndexNotARepo(t *testing.T) {
+	tracked, err := ParseGitIndex(scalibrfs.FS(fstest.MapFS{"go.mod": {}}))
+	if err != nil {
+		t.Fatalf("ParseGitIndex(): %v", err)
+	}
+	if tracked != nil {
+		t.Errorf("ParseGitIndex(): got %v, want nil", tracked)
+	}
+}
+
+func TestParseGitIndexInvalid(t *testing.T) {
+	fsys := scalibrfs.FS(fstest.MapFS{".git/index": {Data: []byte("not an index")}})
+	if _, err := ParseGitIndex(fsys); err == nil {
+		t.Error("ParseGitIndex(): got nil error, want error")
+	}
+}
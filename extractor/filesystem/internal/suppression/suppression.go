@@ -0,0 +1,97 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package suppression parses inline suppression comments in manifest files,
+// e.g.
+//
+//	requests==2.19.0  # scalibr:ignore CVE-2018-18074 reason=we never follow redirects
+//
+// Suppressions are attached to the packages as exploitability signals so they
+// can be filtered or reported like VEX statements from other sources.
+package suppression
+
+import (
+	"strings"
+
+	"github.com/google/osv-scalibr/inventory/vex"
+	"github.com/google/osv-scalibr/log"
+)
+
+// Marker starts a suppression comment.
+const Marker = "scalibr:ignore"
+
+// Parse parses the text of a comment, without the comment characters, and
+// returns the exploitability signal it describes or nil if it isn't a
+// suppression comment. The comment has the format
+//
+//	scalibr:ignore [ID[,ID...]] [justification=<VEX justification>] [reason=<text>]
+//
+// where the reason extends until the end of the comment. If no vuln IDs are
+// listed, all vulns of the package are suppressed.
+func Parse(comment string, plugin string) *vex.PackageExploitabilitySignal {
+	comment = strings.TrimSpace(comment)
+	rest, ok := strings.CutPrefix(comment, Marker)
+	if !ok || (rest != "" && rest[0] != ' ' && rest[0] != '\t') {
+		return nil
+	}
+
+	signal := &vex.PackageExploitabilitySignal{Plugin: plugin}
+	rest = strings.TrimSpace(rest)
+	for rest != "" {
+		var token string
+		token, rest = cutSpace(rest)
+		switch key, value, _ := strings.Cut(token, "="); key {
+		case "reason":
+			// The reason is free-form text until the end of the comment.
+			signal.Reason = strings.Trim(strings.TrimSpace(value+" "+rest), `"'`)
+			rest = ""
+		case "justification":
+			j, ok := vex.ParseJustification(value)
+			if !ok {
+				log.Warnf("suppression comment %q: unknown justification %q", comment, value)
+			}
+			signal.Justification = j
+		default:
+			for id := range strings.SplitSeq(token, ",") {
+				if id != "" {
+					signal.VulnIdentifiers = append(signal.VulnIdentifiers, id)
+				}
+			}
+		}
+	}
+	if len(signal.VulnIdentifiers) == 0 {
+		signal.MatchesAllVulns = true
+	}
+	return signal
+}
+
+// cutSpace splits s around the first space or tab.
+func cutSpace(s string) (before, after string) {
+	if i := strings.IndexAny(s, " \t"); i >= 0 {
+		return s[:i], strings.TrimSpace(s[i:])
+	}
+	return s, ""
+}
+
+// ParseAll returns the exploitability signals described by the given
+// comments, skipping the ones that aren't suppression comments.
+func ParseAll(comments []string, plugin string) []*vex.PackageExploitabilitySignal {
+	var signals []*vex.PackageExploitabilitySignal
+	for _, c := range comments {
+		if s := Parse(c, plugin); s != nil {
+			signals = append(signals, s)
+		}
+	}
+	return signals
+}
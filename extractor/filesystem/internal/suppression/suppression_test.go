@@ -0,0 +1,83 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package suppression_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/osv-scalibr/extractor/filesystem/internal/suppression"
+	"github.com/google/osv-scalibr/inventory/vex"
+)
+
+func TestParse(t *testing.T) {
+	testCases := []struct {
+		desc    string
+		comment string
+		want    *vex.PackageExploitabilitySignal
+	}{
+		{
+			desc:    "not_a_suppression",
+			comment: " pinned for compatibility",
+			want:    nil,
+		},
+		{
+			desc:    "marker_prefix_only",
+			comment: "scalibr:ignored",
+			want:    nil,
+		},
+		{
+			desc:    "all_vulns",
+			comment: " scalibr:ignore",
+			want:    &vex.PackageExploitabilitySignal{Plugin: "p", MatchesAllVulns: true},
+		},
+		{
+			desc:    "single_id_with_reason",
+			comment: " scalibr:ignore CVE-2024-1234 reason=not reachable from our code",
+			want: &vex.PackageExploitabilitySignal{
+				Plugin:          "p",
+				VulnIdentifiers: []string{"CVE-2024-1234"},
+				Reason:          "not reachable from our code",
+			},
+		},
+		{
+			desc:    "several_ids_and_justification",
+			comment: "scalibr:ignore CVE-2024-1234,GHSA-aaaa-bbbb-cccc\tPYSEC-2024-1 justification=vulnerable_code_not_in_execute_path reason=\"only used in tests\"",
+			want: &vex.PackageExploitabilitySignal{
+				Plugin:          "p",
+				VulnIdentifiers: []string{"CVE-2024-1234", "GHSA-aaaa-bbbb-cccc", "PYSEC-2024-1"},
+				Justification:   vex.VulnerableCodeNotInExecutePath,
+				Reason:          "only used in tests",
+			},
+		},
+		{
+			desc:    "unknown_justification",
+			comment: "scalibr:ignore CVE-2024-1234 justification=because",
+			want: &vex.PackageExploitabilitySignal{
+				Plugin:          "p",
+				VulnIdentifiers: []string{"CVE-2024-1234"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := suppression.Parse(tc.comment, "p")
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Parse(%q) returned unexpected diff (-want +got):\n%s", tc.comment, diff)
+			}
+		})
+	}
+}
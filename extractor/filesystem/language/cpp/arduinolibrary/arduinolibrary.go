@@ -0,0 +1,92 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package arduinolibrary extracts the library.properties files of installed
+// Arduino libraries.
+package arduinolibrary
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/plugin"
+	"github.com/google/osv-scalibr/purl"
+)
+
+const (
+	// Name is the unique name of this extractor.
+	Name = "cpp/arduinolibrary"
+)
+
+// Extractor extracts Arduino libraries from library.properties files.
+type Extractor struct{}
+
+// New returns a new instance of the extractor.
+func New() filesystem.Extractor { return &Extractor{} }
+
+// Name of the extractor.
+func (e Extractor) Name() string { return Name }
+
+// Version of the extractor.
+func (e Extractor) Version() int { return 0 }
+
+// Requirements of the extractor.
+func (e Extractor) Requirements() *plugin.Capabilities {
+	return &plugin.Capabilities{}
+}
+
+// FileRequired returns true if the specified file is a library.properties file.
+func (e Extractor) FileRequired(api filesystem.FileAPI) bool {
+	return filepath.Base(api.Path()) == "library.properties"
+}
+
+// Extract extracts the library described by the library.properties file passed
+// through the scan input. The dependencies listed in the "depends" field are
+// not reported since they're installed as separate libraries.
+func (e Extractor) Extract(ctx context.Context, input *filesystem.ScanInput) (inventory.Inventory, error) {
+	props := map[string]string{}
+	s := bufio.NewScanner(input.Reader)
+	for s.Scan() {
+		line := strings.TrimSpace(s.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return inventory.Inventory{}, fmt.Errorf("could not extract: invalid line %q", line)
+		}
+		props[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+	if err := s.Err(); err != nil {
+		return inventory.Inventory{}, fmt.Errorf("could not extract: %w", err)
+	}
+
+	if props["name"] == "" || props["version"] == "" {
+		return inventory.Inventory{}, nil
+	}
+	return inventory.Inventory{Packages: []*extractor.Package{{
+		Name:      props["name"],
+		Version:   props["version"],
+		PURLType:  purl.TypeArduino,
+		Locations: []string{input.Path},
+	}}}, nil
+}
+
+var _ filesystem.Extractor = Extractor{}
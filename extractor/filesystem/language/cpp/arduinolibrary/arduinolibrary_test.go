@@ -0,0 +1,115 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package arduinolibrary_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem/language/cpp/arduinolibrary"
+	"github.com/google/osv-scalibr/extractor/filesystem/simplefileapi"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scalibr/testing/extracttest"
+)
+
+func TestExtractor_FileRequired(t *testing.T) {
+	tests := []struct {
+		name      string
+		inputPath string
+		want      bool
+	}{
+		{
+			name:      "sketchbook library",
+			inputPath: "home/user/Arduino/libraries/WiFiManager/library.properties",
+			want:      true,
+		},
+		{
+			name:      "platform library",
+			inputPath: "home/user/.arduino15/packages/esp32/hardware/esp32/2.0.14/libraries/WiFi/library.properties",
+			want:      true,
+		},
+		{
+			name:      "other properties file",
+			inputPath: "home/user/.arduino15/packages/esp32/hardware/esp32/2.0.14/platform.properties",
+			want:      false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := arduinolibrary.Extractor{}
+			got := e.FileRequired(simplefileapi.New(tt.inputPath, nil))
+			if got != tt.want {
+				t.Errorf("FileRequired(%s, FileInfo) got = %v, want %v", tt.inputPath, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtractor_Extract(t *testing.T) {
+	tests := []extracttest.TestTableEntry{
+		{
+			Name: "invalid line",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/invalid.properties",
+			},
+			WantErr: extracttest.ContainsErrStr{Str: "could not extract"},
+		},
+		{
+			Name: "no version",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/noversion/library.properties",
+			},
+		},
+		{
+			Name: "library",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/WiFiManager/library.properties",
+			},
+			WantPackages: []*extractor.Package{
+				{
+					Name:      "WiFiManager",
+					Version:   "2.0.16-rc.2",
+					PURLType:  purl.TypeArduino,
+					Locations: []string{"testdata/WiFiManager/library.properties"},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			extr := arduinolibrary.Extractor{}
+
+			scanInput := extracttest.GenerateScanInputMock(t, tt.InputConfig)
+			defer extracttest.CloseTestScanInput(t, scanInput)
+
+			got, err := extr.Extract(context.Background(), &scanInput)
+
+			if diff := cmp.Diff(tt.WantErr, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("%s.Extract(%q) error diff (-want +got):\n%s", extr.Name(), tt.InputConfig.Path, diff)
+				return
+			}
+
+			wantInv := inventory.Inventory{Packages: tt.WantPackages}
+			if diff := cmp.Diff(wantInv, got, cmpopts.SortSlices(extracttest.PackageCmpLess)); diff != "" {
+				t.Errorf("%s.Extract(%q) diff (-want +got):\n%s", extr.Name(), tt.InputConfig.Path, diff)
+			}
+		})
+	}
+}
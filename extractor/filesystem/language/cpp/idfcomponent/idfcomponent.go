@@ -0,0 +1,114 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package idfcomponent extracts the dependencies declared in ESP-IDF
+// idf_component.yml component manifests.
+package idfcomponent
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/plugin"
+	"github.com/google/osv-scalibr/purl"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// Name is the unique name of this extractor.
+	Name = "cpp/idfcomponent"
+)
+
+// dependency is a dependency of an ESP-IDF component, given either as a version
+// requirement string or as a mapping.
+type dependency struct {
+	Version string `yaml:"version"`
+	Git     string `yaml:"git"`
+	Path    string `yaml:"path"`
+}
+
+func (d *dependency) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		d.Version = value.Value
+		return nil
+	}
+	type dep dependency
+	return value.Decode((*dep)(d))
+}
+
+type manifest struct {
+	Dependencies map[string]dependency `yaml:"dependencies"`
+}
+
+// Extractor extracts ESP-IDF component dependencies from idf_component.yml files.
+type Extractor struct{}
+
+// New returns a new instance of the extractor.
+func New() filesystem.Extractor { return &Extractor{} }
+
+// Name of the extractor.
+func (e Extractor) Name() string { return Name }
+
+// Version of the extractor.
+func (e Extractor) Version() int { return 0 }
+
+// Requirements of the extractor.
+func (e Extractor) Requirements() *plugin.Capabilities {
+	return &plugin.Capabilities{}
+}
+
+// FileRequired returns true if the specified file is an idf_component.yml file.
+func (e Extractor) FileRequired(api filesystem.FileAPI) bool {
+	return filepath.Base(api.Path()) == "idf_component.yml"
+}
+
+// Extract extracts packages from the idf_component.yml file passed through the
+// scan input. The "idf" entry, which constrains the version of ESP-IDF itself,
+// and local components are skipped.
+func (e Extractor) Extract(ctx context.Context, input *filesystem.ScanInput) (inventory.Inventory, error) {
+	var m manifest
+	if err := yaml.NewDecoder(input.Reader).Decode(&m); err != nil {
+		return inventory.Inventory{}, fmt.Errorf("could not extract: %w", err)
+	}
+
+	packages := make([]*extractor.Package, 0, len(m.Dependencies))
+	for name, dep := range m.Dependencies {
+		if err := ctx.Err(); err != nil {
+			return inventory.Inventory{Packages: packages}, fmt.Errorf("%s halted due to context error: %w", e.Name(), err)
+		}
+		if name == "idf" || dep.Path != "" || dep.Version == "" {
+			continue
+		}
+
+		pkg := &extractor.Package{
+			Name:      name,
+			PURLType:  purl.TypeESPIDF,
+			Locations: []string{input.Path},
+		}
+		if dep.Git != "" {
+			// For git dependencies the version is a commit, branch or tag.
+			pkg.SourceCode = &extractor.SourceCodeIdentifier{Repo: dep.Git, Commit: dep.Version}
+		} else {
+			pkg.Version = dep.Version
+		}
+		packages = append(packages, pkg)
+	}
+	return inventory.Inventory{Packages: packages}, nil
+}
+
+var _ filesystem.Extractor = Extractor{}
@@ -0,0 +1,137 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package idfcomponent_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem/language/cpp/idfcomponent"
+	"github.com/google/osv-scalibr/extractor/filesystem/simplefileapi"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scalibr/testing/extracttest"
+)
+
+func TestExtractor_FileRequired(t *testing.T) {
+	tests := []struct {
+		name      string
+		inputPath string
+		want      bool
+	}{
+		{
+			name:      "project manifest",
+			inputPath: "firmware/main/idf_component.yml",
+			want:      true,
+		},
+		{
+			name:      "managed component manifest",
+			inputPath: "firmware/managed_components/espressif__led_strip/idf_component.yml",
+			want:      true,
+		},
+		{
+			name:      "yaml extension",
+			inputPath: "firmware/main/idf_component.yaml",
+			want:      false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := idfcomponent.Extractor{}
+			got := e.FileRequired(simplefileapi.New(tt.inputPath, nil))
+			if got != tt.want {
+				t.Errorf("FileRequired(%s, FileInfo) got = %v, want %v", tt.inputPath, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtractor_Extract(t *testing.T) {
+	tests := []extracttest.TestTableEntry{
+		{
+			Name: "invalid yaml",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/invalid.yml",
+			},
+			WantErr: extracttest.ContainsErrStr{Str: "could not extract"},
+		},
+		{
+			Name: "no dependencies",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/no-deps.yml",
+			},
+			WantPackages: []*extractor.Package{},
+		},
+		{
+			Name: "dependencies",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/idf_component.yml",
+			},
+			WantPackages: []*extractor.Package{
+				{
+					Name:      "espressif/led_strip",
+					Version:   "^2.5.3",
+					PURLType:  purl.TypeESPIDF,
+					Locations: []string{"testdata/idf_component.yml"},
+				},
+				{
+					Name:      "espressif/button",
+					Version:   "~3.2.0",
+					PURLType:  purl.TypeESPIDF,
+					Locations: []string{"testdata/idf_component.yml"},
+				},
+				{
+					Name:      "espressif/esp_tinyusb",
+					Version:   "*",
+					PURLType:  purl.TypeESPIDF,
+					Locations: []string{"testdata/idf_component.yml"},
+				},
+				{
+					Name:      "cjson",
+					PURLType:  purl.TypeESPIDF,
+					Locations: []string{"testdata/idf_component.yml"},
+					SourceCode: &extractor.SourceCodeIdentifier{
+						Repo:   "https://github.com/DaveGamble/cJSON.git",
+						Commit: "v1.7.17",
+					},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			extr := idfcomponent.Extractor{}
+
+			scanInput := extracttest.GenerateScanInputMock(t, tt.InputConfig)
+			defer extracttest.CloseTestScanInput(t, scanInput)
+
+			got, err := extr.Extract(context.Background(), &scanInput)
+
+			if diff := cmp.Diff(tt.WantErr, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("%s.Extract(%q) error diff (-want +got):\n%s", extr.Name(), tt.InputConfig.Path, diff)
+				return
+			}
+
+			wantInv := inventory.Inventory{Packages: tt.WantPackages}
+			if diff := cmp.Diff(wantInv, got, cmpopts.SortSlices(extracttest.PackageCmpLess)); diff != "" {
+				t.Errorf("%s.Extract(%q) diff (-want +got):\n%s", extr.Name(), tt.InputConfig.Path, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package idflock extracts the dependencies.lock files that the ESP-IDF
+// component manager writes when resolving the components of a project.
+package idflock
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/plugin"
+	"github.com/google/osv-scalibr/purl"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// Name is the unique name of this extractor.
+	Name = "cpp/idflock"
+)
+
+type lockedComponent struct {
+	Version string `yaml:"version"`
+	Source  struct {
+		Type   string `yaml:"type"`
+		GitURL string `yaml:"git"`
+	} `yaml:"source"`
+}
+
+type lockfile struct {
+	Dependencies map[string]lockedComponent `yaml:"dependencies"`
+	// ManifestHash is only present in ESP-IDF lockfiles and is used to tell
+	// them apart from other dependencies.lock files.
+	ManifestHash string `yaml:"manifest_hash"`
+}
+
+// Extractor extracts ESP-IDF components from dependencies.lock files.
+type Extractor struct{}
+
+// New returns a new instance of the extractor.
+func New() filesystem.Extractor { return &Extractor{} }
+
+// Name of the extractor.
+func (e Extractor) Name() string { return Name }
+
+// Version of the extractor.
+func (e Extractor) Version() int { return 0 }
+
+// Requirements of the extractor.
+func (e Extractor) Requirements() *plugin.Capabilities {
+	return &plugin.Capabilities{}
+}
+
+// FileRequired returns true if the specified file is a dependencies.lock file.
+func (e Extractor) FileRequired(api filesystem.FileAPI) bool {
+	return filepath.Base(api.Path()) == "dependencies.lock"
+}
+
+// Extract extracts the locked components, including ESP-IDF itself, from the
+// dependencies.lock file passed through the scan input. Local components are
+// skipped.
+func (e Extractor) Extract(ctx context.Context, input *filesystem.ScanInput) (inventory.Inventory, error) {
+	var lock lockfile
+	if err := yaml.NewDecoder(input.Reader).Decode(&lock); err != nil {
+		return inventory.Inventory{}, fmt.Errorf("could not extract: %w", err)
+	}
+	if lock.ManifestHash == "" {
+		return inventory.Inventory{}, nil
+	}
+
+	packages := make([]*extractor.Package, 0, len(lock.Dependencies))
+	for name, c := range lock.Dependencies {
+		if err := ctx.Err(); err != nil {
+			return inventory.Inventory{Packages: packages}, fmt.Errorf("%s halted due to context error: %w", e.Name(), err)
+		}
+		if c.Source.Type == "local" || c.Version == "" {
+			continue
+		}
+		pkg := &extractor.Package{
+			Name:      name,
+			Version:   c.Version,
+			PURLType:  purl.TypeESPIDF,
+			Locations: []string{input.Path},
+		}
+		if c.Source.Type == "git" {
+			pkg.Version = ""
+			pkg.SourceCode = &extractor.SourceCodeIdentifier{Repo: c.Source.GitURL, Commit: c.Version}
+		}
+		packages = append(packages, pkg)
+	}
+	return inventory.Inventory{Packages: packages}, nil
+}
+
+var _ filesystem.Extractor = Extractor{}
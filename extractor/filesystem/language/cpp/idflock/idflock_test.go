@@ -0,0 +1,130 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package idflock_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem/language/cpp/idflock"
+	"github.com/google/osv-scalibr/extractor/filesystem/simplefileapi"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scalibr/testing/extracttest"
+)
+
+func TestExtractor_FileRequired(t *testing.T) {
+	tests := []struct {
+		name      string
+		inputPath string
+		want      bool
+	}{
+		{
+			name:      "project lockfile",
+			inputPath: "firmware/dependencies.lock",
+			want:      true,
+		},
+		{
+			name:      "other lockfile",
+			inputPath: "firmware/conan.lock",
+			want:      false,
+		},
+		{
+			name:      "suffixed name",
+			inputPath: "firmware/dependencies.lock.bak",
+			want:      false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := idflock.Extractor{}
+			got := e.FileRequired(simplefileapi.New(tt.inputPath, nil))
+			if got != tt.want {
+				t.Errorf("FileRequired(%s, FileInfo) got = %v, want %v", tt.inputPath, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtractor_Extract(t *testing.T) {
+	tests := []extracttest.TestTableEntry{
+		{
+			Name: "invalid yaml",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/invalid.lock",
+			},
+			WantErr: extracttest.ContainsErrStr{Str: "could not extract"},
+		},
+		{
+			Name: "not an ESP-IDF lockfile",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/not-idf.lock",
+			},
+		},
+		{
+			Name: "components",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/dependencies.lock",
+			},
+			WantPackages: []*extractor.Package{
+				{
+					Name:      "cjson",
+					PURLType:  purl.TypeESPIDF,
+					Locations: []string{"testdata/dependencies.lock"},
+					SourceCode: &extractor.SourceCodeIdentifier{
+						Repo:   "https://github.com/DaveGamble/cJSON.git",
+						Commit: "87d8f0961a01bf09bef98ff89bae9fdec42181ee",
+					},
+				},
+				{
+					Name:      "espressif/led_strip",
+					Version:   "2.5.3",
+					PURLType:  purl.TypeESPIDF,
+					Locations: []string{"testdata/dependencies.lock"},
+				},
+				{
+					Name:      "idf",
+					Version:   "5.1.2",
+					PURLType:  purl.TypeESPIDF,
+					Locations: []string{"testdata/dependencies.lock"},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			extr := idflock.Extractor{}
+
+			scanInput := extracttest.GenerateScanInputMock(t, tt.InputConfig)
+			defer extracttest.CloseTestScanInput(t, scanInput)
+
+			got, err := extr.Extract(context.Background(), &scanInput)
+
+			if diff := cmp.Diff(tt.WantErr, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("%s.Extract(%q) error diff (-want +got):\n%s", extr.Name(), tt.InputConfig.Path, diff)
+				return
+			}
+
+			wantInv := inventory.Inventory{Packages: tt.WantPackages}
+			if diff := cmp.Diff(wantInv, got, cmpopts.SortSlices(extracttest.PackageCmpLess)); diff != "" {
+				t.Errorf("%s.Extract(%q) diff (-want +got):\n%s", extr.Name(), tt.InputConfig.Path, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package piopm extracts the .piopm metadata files that the PlatformIO package
+// manager writes next to the libraries, platforms and tools it installs, e.g.
+// in a project's .pio/libdeps directory.
+package piopm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/plugin"
+	"github.com/google/osv-scalibr/purl"
+)
+
+const (
+	// Name is the unique name of this extractor.
+	Name = "cpp/piopm"
+)
+
+type piopmFile struct {
+	Type    string `json:"type"`
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	Spec    struct {
+		Owner string `json:"owner"`
+		Name  string `json:"name"`
+		URI   string `json:"uri"`
+	} `json:"spec"`
+}
+
+// Extractor extracts PlatformIO packages from .piopm files.
+type Extractor struct{}
+
+// New returns a new instance of the extractor.
+func New() filesystem.Extractor { return &Extractor{} }
+
+// Name of the extractor.
+func (e Extractor) Name() string { return Name }
+
+// Version of the extractor.
+func (e Extractor) Version() int { return 0 }
+
+// Requirements of the extractor.
+func (e Extractor) Requirements() *plugin.Capabilities {
+	return &plugin.Capabilities{}
+}
+
+// FileRequired returns true if the specified file is a .piopm file.
+func (e Extractor) FileRequired(api filesystem.FileAPI) bool {
+	return filepath.Base(api.Path()) == ".piopm"
+}
+
+// Extract extracts the installed package described by the .piopm file passed
+// through the scan input.
+func (e Extractor) Extract(ctx context.Context, input *filesystem.ScanInput) (inventory.Inventory, error) {
+	var f piopmFile
+	if err := json.NewDecoder(input.Reader).Decode(&f); err != nil {
+		return inventory.Inventory{}, fmt.Errorf("could not extract: %w", err)
+	}
+	if f.Name == "" || f.Version == "" {
+		return inventory.Inventory{}, nil
+	}
+
+	name := f.Name
+	if f.Spec.Owner != "" {
+		name = f.Spec.Owner + "/" + f.Name
+	}
+	pkg := &extractor.Package{
+		Name:      name,
+		Version:   f.Version,
+		PURLType:  purl.TypePlatformIO,
+		Locations: []string{input.Path},
+	}
+	if f.Spec.URI != "" {
+		pkg.SourceCode = &extractor.SourceCodeIdentifier{Repo: f.Spec.URI}
+	}
+	return inventory.Inventory{Packages: []*extractor.Package{pkg}}, nil
+}
+
+var _ filesystem.Extractor = Extractor{}
@@ -0,0 +1,151 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package piopm_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem/language/cpp/piopm"
+	"github.com/google/osv-scalibr/extractor/filesystem/simplefileapi"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scalibr/testing/extracttest"
+)
+
+func TestExtractor_FileRequired(t *testing.T) {
+	tests := []struct {
+		name      string
+		inputPath string
+		want      bool
+	}{
+		{
+			name:      "installed library",
+			inputPath: "project/.pio/libdeps/esp32dev/ArduinoJson/.piopm",
+			want:      true,
+		},
+		{
+			name:      "installed tool",
+			inputPath: "root/.platformio/packages/toolchain-xtensa-esp32/.piopm",
+			want:      true,
+		},
+		{
+			name:      "other file",
+			inputPath: "project/.pio/libdeps/esp32dev/ArduinoJson/library.json",
+			want:      false,
+		},
+		{
+			name:      "similar name",
+			inputPath: "project/.pio/libdeps/esp32dev/ArduinoJson/.piopm.bak",
+			want:      false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := piopm.Extractor{}
+			got := e.FileRequired(simplefileapi.New(tt.inputPath, nil))
+			if got != tt.want {
+				t.Errorf("FileRequired(%s, FileInfo) got = %v, want %v", tt.inputPath, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtractor_Extract(t *testing.T) {
+	tests := []extracttest.TestTableEntry{
+		{
+			Name: "invalid json",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/invalid.txt",
+			},
+			WantErr: extracttest.ContainsErrStr{Str: "could not extract"},
+		},
+		{
+			Name: "no version",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/noversion/.piopm",
+			},
+		},
+		{
+			Name: "registry library",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/.pio/libdeps/esp32dev/ArduinoJson/.piopm",
+			},
+			WantPackages: []*extractor.Package{
+				{
+					Name:      "bblanchon/ArduinoJson",
+					Version:   "6.21.3",
+					PURLType:  purl.TypePlatformIO,
+					Locations: []string{"testdata/.pio/libdeps/esp32dev/ArduinoJson/.piopm"},
+				},
+			},
+		},
+		{
+			Name: "tool",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/packages/toolchain-xtensa32/.piopm",
+			},
+			WantPackages: []*extractor.Package{
+				{
+					Name:      "espressif/toolchain-xtensa-esp32",
+					Version:   "8.4.0+2021r2-patch5",
+					PURLType:  purl.TypePlatformIO,
+					Locations: []string{"testdata/packages/toolchain-xtensa32/.piopm"},
+				},
+			},
+		},
+		{
+			Name: "git library",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/git/.piopm",
+			},
+			WantPackages: []*extractor.Package{
+				{
+					Name:      "WiFiManager",
+					Version:   "2.0.16-rc.2+sha.5ffd0a5",
+					PURLType:  purl.TypePlatformIO,
+					Locations: []string{"testdata/git/.piopm"},
+					SourceCode: &extractor.SourceCodeIdentifier{
+						Repo: "git+https://github.com/tzapu/WiFiManager.git#v2.0.16-rc.2",
+					},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			extr := piopm.Extractor{}
+
+			scanInput := extracttest.GenerateScanInputMock(t, tt.InputConfig)
+			defer extracttest.CloseTestScanInput(t, scanInput)
+
+			got, err := extr.Extract(context.Background(), &scanInput)
+
+			if diff := cmp.Diff(tt.WantErr, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("%s.Extract(%q) error diff (-want +got):\n%s", extr.Name(), tt.InputConfig.Path, diff)
+				return
+			}
+
+			wantInv := inventory.Inventory{Packages: tt.WantPackages}
+			if diff := cmp.Diff(wantInv, got, cmpopts.SortSlices(extracttest.PackageCmpLess)); diff != "" {
+				t.Errorf("%s.Extract(%q) diff (-want +got):\n%s", extr.Name(), tt.InputConfig.Path, diff)
+			}
+		})
+	}
+}
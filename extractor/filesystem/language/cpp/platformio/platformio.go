@@ -0,0 +1,176 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package platformio extracts the library dependencies declared in PlatformIO
+// platformio.ini project files.
+package platformio
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/plugin"
+	"github.com/google/osv-scalibr/purl"
+	"gopkg.in/ini.v1"
+)
+
+const (
+	// Name is the unique name of this extractor.
+	Name = "cpp/platformio"
+)
+
+// Extractor extracts PlatformIO library dependencies from platformio.ini files.
+type Extractor struct{}
+
+// New returns a new instance of the extractor.
+func New() filesystem.Extractor { return &Extractor{} }
+
+// Name of the extractor.
+func (e Extractor) Name() string { return Name }
+
+// Version of the extractor.
+func (e Extractor) Version() int { return 0 }
+
+// Requirements of the extractor.
+func (e Extractor) Requirements() *plugin.Capabilities {
+	return &plugin.Capabilities{}
+}
+
+// FileRequired returns true if the specified file is a platformio.ini file.
+func (e Extractor) FileRequired(api filesystem.FileAPI) bool {
+	return filepath.Base(api.Path()) == "platformio.ini"
+}
+
+// Extract extracts the lib_deps of all sections of the platformio.ini file
+// passed through the scan input. Dependencies shared by several environments
+// are only reported once.
+func (e Extractor) Extract(ctx context.Context, input *filesystem.ScanInput) (inventory.Inventory, error) {
+	content, err := io.ReadAll(input.Reader)
+	if err != nil {
+		return inventory.Inventory{}, fmt.Errorf("could not extract: %w", err)
+	}
+	cfg, err := ini.LoadSources(ini.LoadOptions{
+		AllowPythonMultilineValues: true,
+		IgnoreInlineComment:        true,
+	}, content)
+	if err != nil {
+		return inventory.Inventory{}, fmt.Errorf("could not extract: %w", err)
+	}
+
+	packages := []*extractor.Package{}
+	seen := map[string]bool{}
+	for _, section := range cfg.Sections() {
+		if err := ctx.Err(); err != nil {
+			return inventory.Inventory{Packages: packages}, fmt.Errorf("%s halted due to context error: %w", e.Name(), err)
+		}
+		if !section.HasKey("lib_deps") {
+			continue
+		}
+		for _, spec := range splitValues(section.Key("lib_deps").String()) {
+			pkg := parseLibDep(spec)
+			if pkg == nil || seen[spec] {
+				continue
+			}
+			seen[spec] = true
+			pkg.Locations = []string{input.Path}
+			packages = append(packages, pkg)
+		}
+	}
+	return inventory.Inventory{Packages: packages}, nil
+}
+
+// splitValues splits a multi-value option into its values. Like PlatformIO,
+// values can be separated by newlines or commas.
+func splitValues(value string) []string {
+	var values []string
+	for _, line := range strings.Split(value, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, ";") || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if i := strings.Index(line, " ;"); i >= 0 {
+			line = line[:i]
+		}
+		if i := strings.Index(line, " #"); i >= 0 {
+			line = line[:i]
+		}
+		for _, v := range strings.Split(line, ",") {
+			if v = strings.TrimSpace(v); v != "" {
+				values = append(values, v)
+			}
+		}
+	}
+	return values
+}
+
+// parseLibDep parses a library dependency specification, e.g.
+// "bblanchon/ArduinoJson @ ^6.21.3" or "https://github.com/owner/lib.git#v1.0".
+// Returns nil for local dependencies, references to other options and
+// dependencies without any version information.
+func parseLibDep(spec string) *extractor.Package {
+	if strings.HasPrefix(spec, "${") {
+		return nil
+	}
+
+	// Dependencies can be given a custom name with "Name=URL".
+	name := ""
+	if i := strings.Index(spec, "="); i >= 0 && strings.Contains(spec[i+1:], "://") {
+		name, spec = strings.TrimSpace(spec[:i]), strings.TrimSpace(spec[i+1:])
+	}
+
+	if strings.Contains(spec, "://") {
+		if strings.HasPrefix(spec, "file://") || strings.HasPrefix(spec, "symlink://") {
+			return nil
+		}
+		repo, ref, _ := strings.Cut(spec, "#")
+		if ref == "" {
+			return nil
+		}
+		if name == "" {
+			name = strings.TrimSuffix(path.Base(repo), ".git")
+		}
+		return &extractor.Package{
+			Name:     name,
+			PURLType: purl.TypePlatformIO,
+			SourceCode: &extractor.SourceCodeIdentifier{
+				Repo:   repo,
+				Commit: ref,
+			},
+		}
+	}
+	if strings.Contains(spec, "=") {
+		// Legacy "id=..." specifications can't be mapped to a package name.
+		return nil
+	}
+
+	name, version, _ := strings.Cut(spec, "@")
+	name, version = strings.TrimSpace(name), strings.TrimSpace(version)
+	if name == "" || version == "" {
+		return nil
+	}
+	return &extractor.Package{
+		Name:     name,
+		Version:  version,
+		PURLType: purl.TypePlatformIO,
+	}
+}
+
+var _ filesystem.Extractor = Extractor{}
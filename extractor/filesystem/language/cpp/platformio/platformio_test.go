@@ -0,0 +1,157 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package platformio_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem/language/cpp/platformio"
+	"github.com/google/osv-scalibr/extractor/filesystem/simplefileapi"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scalibr/testing/extracttest"
+)
+
+func TestExtractor_FileRequired(t *testing.T) {
+	tests := []struct {
+		name      string
+		inputPath string
+		want      bool
+	}{
+		{
+			name:      "root project file",
+			inputPath: "platformio.ini",
+			want:      true,
+		},
+		{
+			name:      "project file in subdirectory",
+			inputPath: "firmware/sensor/platformio.ini",
+			want:      true,
+		},
+		{
+			name:      "different ini file",
+			inputPath: "firmware/extra_platformio.ini",
+			want:      false,
+		},
+		{
+			name:      "directory named platformio.ini",
+			inputPath: "firmware/platformio.ini/config",
+			want:      false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := platformio.Extractor{}
+			got := e.FileRequired(simplefileapi.New(tt.inputPath, nil))
+			if got != tt.want {
+				t.Errorf("FileRequired(%s, FileInfo) got = %v, want %v", tt.inputPath, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtractor_Extract(t *testing.T) {
+	tests := []extracttest.TestTableEntry{
+		{
+			Name: "invalid ini",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/invalid.ini",
+			},
+			WantErr: extracttest.ContainsErrStr{Str: "could not extract"},
+		},
+		{
+			Name: "no dependencies",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/no-deps.ini",
+			},
+			WantPackages: []*extractor.Package{},
+		},
+		{
+			Name: "multiple environments",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/platformio.ini",
+			},
+			WantPackages: []*extractor.Package{
+				{
+					Name:      "bblanchon/ArduinoJson",
+					Version:   "^6.21.3",
+					PURLType:  purl.TypePlatformIO,
+					Locations: []string{"testdata/platformio.ini"},
+				},
+				{
+					Name:      "knolleary/PubSubClient",
+					Version:   "2.8",
+					PURLType:  purl.TypePlatformIO,
+					Locations: []string{"testdata/platformio.ini"},
+				},
+				{
+					Name:      "adafruit/Adafruit SSD1306",
+					Version:   "~2.5.7",
+					PURLType:  purl.TypePlatformIO,
+					Locations: []string{"testdata/platformio.ini"},
+				},
+				{
+					Name:      "adafruit/Adafruit GFX Library",
+					Version:   "1.11.9",
+					PURLType:  purl.TypePlatformIO,
+					Locations: []string{"testdata/platformio.ini"},
+				},
+				{
+					Name:      "WiFiManager",
+					PURLType:  purl.TypePlatformIO,
+					Locations: []string{"testdata/platformio.ini"},
+					SourceCode: &extractor.SourceCodeIdentifier{
+						Repo:   "https://github.com/tzapu/WiFiManager.git",
+						Commit: "v2.0.16-rc.2",
+					},
+				},
+				{
+					Name:      "AsyncTCP",
+					PURLType:  purl.TypePlatformIO,
+					Locations: []string{"testdata/platformio.ini"},
+					SourceCode: &extractor.SourceCodeIdentifier{
+						Repo:   "https://github.com/me-no-dev/AsyncTCP.git",
+						Commit: "ca8ac5f",
+					},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			extr := platformio.Extractor{}
+
+			scanInput := extracttest.GenerateScanInputMock(t, tt.InputConfig)
+			defer extracttest.CloseTestScanInput(t, scanInput)
+
+			got, err := extr.Extract(context.Background(), &scanInput)
+
+			if diff := cmp.Diff(tt.WantErr, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("%s.Extract(%q) error diff (-want +got):\n%s", extr.Name(), tt.InputConfig.Path, diff)
+				return
+			}
+
+			wantInv := inventory.Inventory{Packages: tt.WantPackages}
+			if diff := cmp.Diff(wantInv, got, cmpopts.SortSlices(extracttest.PackageCmpLess)); diff != "" {
+				t.Errorf("%s.Extract(%q) diff (-want +got):\n%s", extr.Name(), tt.InputConfig.Path, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pubspec
+
+import (
+	pb "github.com/google/osv-scalibr/binary/proto/scan_result_go_proto"
+)
+
+// Sources of Dart packages.
+const (
+	SourceHosted = "hosted"
+	SourceGit    = "git"
+	SourcePath   = "path"
+	SourceSDK    = "sdk"
+)
+
+// Metadata holds the details of a Dart package from a pubspec.lock file.
+type Metadata struct {
+	DepGroupVals []string
+	// Source is where the package comes from: "hosted", "git", "path" or "sdk".
+	Source string
+	// URL is the package repository URL of hosted packages, e.g.
+	// "https://pub.dev", or the repository URL of git packages.
+	URL string
+	// Path is the local path of path packages or the path inside the repository
+	// of git packages.
+	Path string
+	// Ref is the git ref requested for git packages. The resolved commit is
+	// stored in the package's SourceCode.
+	Ref string
+	// SDK is the name of the SDK that provides sdk packages, e.g. "flutter".
+	SDK string
+}
+
+// DepGroups returns the dependency groups the package belongs to.
+func (m *Metadata) DepGroups() []string {
+	return m.DepGroupVals
+}
+
+// SetProto sets the PubspecMetadata field in the Package proto.
+func (m *Metadata) SetProto(p *pb.Package) {
+	if m == nil {
+		return
+	}
+	if p == nil {
+		return
+	}
+
+	p.Metadata = &pb.Package_PubspecMetadata{
+		PubspecMetadata: &pb.PubspecMetadata{
+			DepGroupVals: m.DepGroupVals,
+			Source:       m.Source,
+			Url:          m.URL,
+			Path:         m.Path,
+			Ref:          m.Ref,
+			Sdk:          m.SDK,
+		},
+	}
+}
+
+// ToStruct converts the PubspecMetadata proto to a Metadata struct.
+func ToStruct(m *pb.PubspecMetadata) *Metadata {
+	if m == nil {
+		return nil
+	}
+
+	return &Metadata{
+		DepGroupVals: m.GetDepGroupVals(),
+		Source:       m.GetSource(),
+		URL:          m.GetUrl(),
+		Path:         m.GetPath(),
+		Ref:          m.GetRef(),
+		SDK:          m.GetSdk(),
+	}
+}
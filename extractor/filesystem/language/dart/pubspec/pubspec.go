@@ -23,7 +23,6 @@ import (
 
 	"github.com/google/osv-scalibr/extractor"
 	"github.com/google/osv-scalibr/extractor/filesystem"
-	"github.com/google/osv-scalibr/extractor/filesystem/osv"
 	"github.com/google/osv-scalibr/inventory"
 	"github.com/google/osv-scalibr/plugin"
 	"github.com/google/osv-scalibr/purl"
@@ -36,7 +35,13 @@ const (
 )
 
 type pubspecLockDescription struct {
-	Ref string `yaml:"resolved-ref"`
+	Name        string `yaml:"name"`
+	URL         string `yaml:"url"`
+	Path        string `yaml:"path"`
+	Ref         string `yaml:"ref"`
+	ResolvedRef string `yaml:"resolved-ref"`
+	// The SDK name, set if the description is a single string.
+	SDK string `yaml:"-"`
 }
 
 var _ yaml.Unmarshaler = &pubspecLockDescription{}
@@ -44,17 +49,32 @@ var _ yaml.Unmarshaler = &pubspecLockDescription{}
 // UnmarshalYAML is a custom unmarshalling function for pubspecLockDescription.
 // We need this because descriptions can have two different formats.
 func (pld *pubspecLockDescription) UnmarshalYAML(value *yaml.Node) error {
+	// Descriptions of sdk packages are a single string with the SDK name.
+	if value.Kind == yaml.ScalarNode {
+		pld.SDK = value.Value
+		return nil
+	}
+
 	// Duplicating the struct to decode nested fields as a
 	// workaround for https://github.com/go-yaml/yaml/issues/1000
 	var m struct {
-		Ref string `yaml:"resolved-ref"`
+		Name        string `yaml:"name"`
+		URL         string `yaml:"url"`
+		Path        string `yaml:"path"`
+		Ref         string `yaml:"ref"`
+		ResolvedRef string `yaml:"resolved-ref"`
 	}
-	if err := value.Decode(&m); err == nil {
-		pld.Ref = m.Ref
+	if err := value.Decode(&m); err != nil {
+		// Ignore descriptions in unknown formats.
 		return nil
 	}
-
-	// If the above failed, the description is a single name string with no ref.
+	*pld = pubspecLockDescription{
+		Name:        m.Name,
+		URL:         m.URL,
+		Path:        m.Path,
+		Ref:         m.Ref,
+		ResolvedRef: m.ResolvedRef,
+	}
 	return nil
 }
 
@@ -62,6 +82,7 @@ type pubspecLockPackage struct {
 	Description pubspecLockDescription `yaml:"description"`
 	Version     string                 `yaml:"version"`
 	Dependency  string                 `yaml:"dependency"`
+	Source      string                 `yaml:"source"`
 }
 
 type pubspecLockfile struct {
@@ -100,23 +121,37 @@ func (e Extractor) Extract(ctx context.Context, input *filesystem.ScanInput) (in
 	packages := make([]*extractor.Package, 0, len(parsedLockfile.Packages))
 
 	for name, pkg := range parsedLockfile.Packages {
-		pkgDetails := &extractor.Package{
-			Name:      name,
-			Version:   pkg.Version,
-			PURLType:  purl.TypePub,
-			Locations: []string{input.Path},
-			SourceCode: &extractor.SourceCodeIdentifier{
-				Commit: pkg.Description.Ref,
-			},
-			Metadata: osv.DepGroupMetadata{},
-		}
+		m := &Metadata{Source: pkg.Source}
 		for _, str := range strings.Split(pkg.Dependency, " ") {
 			if str == "dev" {
-				pkgDetails.Metadata = osv.DepGroupMetadata{DepGroupVals: []string{"dev"}}
+				m.DepGroupVals = []string{"dev"}
 				break
 			}
 		}
-		packages = append(packages, pkgDetails)
+		sourceCode := &extractor.SourceCodeIdentifier{
+			Commit: pkg.Description.ResolvedRef,
+		}
+		switch pkg.Source {
+		case SourceHosted:
+			m.URL = pkg.Description.URL
+		case SourceGit:
+			m.URL = pkg.Description.URL
+			m.Path = pkg.Description.Path
+			m.Ref = pkg.Description.Ref
+			sourceCode.Repo = pkg.Description.URL
+		case SourcePath:
+			m.Path = pkg.Description.Path
+		case SourceSDK:
+			m.SDK = pkg.Description.SDK
+		}
+		packages = append(packages, &extractor.Package{
+			Name:       name,
+			Version:    pkg.Version,
+			PURLType:   purl.TypePub,
+			Locations:  []string{input.Path},
+			SourceCode: sourceCode,
+			Metadata:   m,
+		})
 	}
 
 	return inventory.Inventory{Packages: packages}, nil
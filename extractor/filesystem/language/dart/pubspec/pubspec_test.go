@@ -22,7 +22,6 @@ import (
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/google/osv-scalibr/extractor"
 	"github.com/google/osv-scalibr/extractor/filesystem/language/dart/pubspec"
-	"github.com/google/osv-scalibr/extractor/filesystem/osv"
 	"github.com/google/osv-scalibr/extractor/filesystem/simplefileapi"
 	"github.com/google/osv-scalibr/inventory"
 	"github.com/google/osv-scalibr/purl"
@@ -108,7 +107,7 @@ func TestExtractor_Extract(t *testing.T) {
 					SourceCode: &extractor.SourceCodeIdentifier{
 						Commit: "",
 					},
-					Metadata: osv.DepGroupMetadata{},
+					Metadata: &pubspec.Metadata{Source: pubspec.SourceHosted, URL: "https://pub.dartlang.org"},
 				},
 			},
 		},
@@ -126,8 +125,10 @@ func TestExtractor_Extract(t *testing.T) {
 					SourceCode: &extractor.SourceCodeIdentifier{
 						Commit: "",
 					},
-					Metadata: osv.DepGroupMetadata{
+					Metadata: &pubspec.Metadata{
 						DepGroupVals: []string{"dev"},
+						Source:       pubspec.SourceHosted,
+						URL:          "https://pub.dartlang.org",
 					},
 				},
 			},
@@ -146,7 +147,7 @@ func TestExtractor_Extract(t *testing.T) {
 					SourceCode: &extractor.SourceCodeIdentifier{
 						Commit: "",
 					},
-					Metadata: osv.DepGroupMetadata{},
+					Metadata: &pubspec.Metadata{Source: pubspec.SourceHosted, URL: "https://pub.dartlang.org"},
 				},
 				{
 					Name:      "shelf_web_socket",
@@ -156,7 +157,7 @@ func TestExtractor_Extract(t *testing.T) {
 					SourceCode: &extractor.SourceCodeIdentifier{
 						Commit: "",
 					},
-					Metadata: osv.DepGroupMetadata{},
+					Metadata: &pubspec.Metadata{Source: pubspec.SourceHosted, URL: "https://pub.dartlang.org"},
 				},
 			},
 		},
@@ -174,7 +175,7 @@ func TestExtractor_Extract(t *testing.T) {
 					SourceCode: &extractor.SourceCodeIdentifier{
 						Commit: "",
 					},
-					Metadata: osv.DepGroupMetadata{},
+					Metadata: &pubspec.Metadata{Source: pubspec.SourceHosted, URL: "https://pub.dartlang.org"},
 				},
 				{
 					Name:      "build_runner",
@@ -184,8 +185,10 @@ func TestExtractor_Extract(t *testing.T) {
 					SourceCode: &extractor.SourceCodeIdentifier{
 						Commit: "",
 					},
-					Metadata: osv.DepGroupMetadata{
+					Metadata: &pubspec.Metadata{
 						DepGroupVals: []string{"dev"},
+						Source:       pubspec.SourceHosted,
+						URL:          "https://pub.dartlang.org",
 					},
 				},
 				{
@@ -196,7 +199,7 @@ func TestExtractor_Extract(t *testing.T) {
 					SourceCode: &extractor.SourceCodeIdentifier{
 						Commit: "",
 					},
-					Metadata: osv.DepGroupMetadata{},
+					Metadata: &pubspec.Metadata{Source: pubspec.SourceHosted, URL: "https://pub.dartlang.org"},
 				},
 				{
 					Name:      "shelf_web_socket",
@@ -206,7 +209,7 @@ func TestExtractor_Extract(t *testing.T) {
 					SourceCode: &extractor.SourceCodeIdentifier{
 						Commit: "",
 					},
-					Metadata: osv.DepGroupMetadata{},
+					Metadata: &pubspec.Metadata{Source: pubspec.SourceHosted, URL: "https://pub.dartlang.org"},
 				},
 			},
 		},
@@ -222,9 +225,15 @@ func TestExtractor_Extract(t *testing.T) {
 					PURLType:  purl.TypePub,
 					Locations: []string{"testdata/source-git.lock"},
 					SourceCode: &extractor.SourceCodeIdentifier{
+						Repo:   "https://github.com/SoLongAndThanksForAllThePizza/flutter_rust_bridge",
 						Commit: "e5adce55eea0b74d3680e66a2c5252edf17b07e1",
 					},
-					Metadata: osv.DepGroupMetadata{},
+					Metadata: &pubspec.Metadata{
+						Source: pubspec.SourceGit,
+						URL:    "https://github.com/SoLongAndThanksForAllThePizza/flutter_rust_bridge",
+						Path:   "frb_dart",
+						Ref:    "master",
+					},
 				},
 				{
 					Name:      "screen_retriever",
@@ -232,9 +241,15 @@ func TestExtractor_Extract(t *testing.T) {
 					PURLType:  purl.TypePub,
 					Locations: []string{"testdata/source-git.lock"},
 					SourceCode: &extractor.SourceCodeIdentifier{
+						Repo:   "https://github.com/Kingtous/rustdesk_screen_retriever.git",
 						Commit: "406b9b038b2c1d779f1e7bf609c8c248be247372",
 					},
-					Metadata: osv.DepGroupMetadata{},
+					Metadata: &pubspec.Metadata{
+						Source: pubspec.SourceGit,
+						URL:    "https://github.com/Kingtous/rustdesk_screen_retriever.git",
+						Path:   ".",
+						Ref:    "406b9b0",
+					},
 				},
 				{
 					Name:      "tray_manager",
@@ -242,9 +257,15 @@ func TestExtractor_Extract(t *testing.T) {
 					PURLType:  purl.TypePub,
 					Locations: []string{"testdata/source-git.lock"},
 					SourceCode: &extractor.SourceCodeIdentifier{
+						Repo:   "https://github.com/Kingtous/rustdesk_tray_manager",
 						Commit: "3aa37c86e47ea748e7b5507cbe59f2c54ebdb23a",
 					},
-					Metadata: osv.DepGroupMetadata{},
+					Metadata: &pubspec.Metadata{
+						Source: pubspec.SourceGit,
+						URL:    "https://github.com/Kingtous/rustdesk_tray_manager",
+						Path:   ".",
+						Ref:    "3aa37c86e47ea748e7b5507cbe59f2c54ebdb23a",
+					},
 				},
 				{
 					Name:      "window_manager",
@@ -252,9 +273,15 @@ func TestExtractor_Extract(t *testing.T) {
 					PURLType:  purl.TypePub,
 					Locations: []string{"testdata/source-git.lock"},
 					SourceCode: &extractor.SourceCodeIdentifier{
+						Repo:   "https://github.com/Kingtous/rustdesk_window_manager",
 						Commit: "88487257cbafc501599ab4f82ec343b46acec020",
 					},
-					Metadata: osv.DepGroupMetadata{},
+					Metadata: &pubspec.Metadata{
+						Source: pubspec.SourceGit,
+						URL:    "https://github.com/Kingtous/rustdesk_window_manager",
+						Path:   ".",
+						Ref:    "88487257cbafc501599ab4f82ec343b46acec020",
+					},
 				},
 				{
 					Name:      "toggle_switch",
@@ -264,7 +291,7 @@ func TestExtractor_Extract(t *testing.T) {
 					SourceCode: &extractor.SourceCodeIdentifier{
 						Commit: "",
 					},
-					Metadata: osv.DepGroupMetadata{},
+					Metadata: &pubspec.Metadata{Source: pubspec.SourceHosted, URL: "https://pub.dartlang.org"},
 				},
 			},
 		},
@@ -282,7 +309,7 @@ func TestExtractor_Extract(t *testing.T) {
 					SourceCode: &extractor.SourceCodeIdentifier{
 						Commit: "",
 					},
-					Metadata: osv.DepGroupMetadata{},
+					Metadata: &pubspec.Metadata{Source: pubspec.SourceSDK, SDK: "flutter"},
 				},
 			},
 		},
@@ -300,7 +327,7 @@ func TestExtractor_Extract(t *testing.T) {
 					SourceCode: &extractor.SourceCodeIdentifier{
 						Commit: "",
 					},
-					Metadata: osv.DepGroupMetadata{},
+					Metadata: &pubspec.Metadata{Source: pubspec.SourcePath, Path: ".."},
 				},
 			},
 		},
@@ -135,9 +135,17 @@ func (e Extractor) Extract(ctx context.Context, input *filesystem.ScanInput) (in
 
 // DepsJSON represents the structure of the deps.json file.
 type DepsJSON struct {
+	// The target framework the application runs on, e.g. ".NETCoreApp,Version=v6.0".
+	RuntimeTarget struct {
+		Name string `json:"name"`
+	} `json:"runtimeTarget"`
+	// Targets maps target frameworks to the libraries resolved for them and
+	// their dependencies. It's only used to tell direct and transitive
+	// dependencies apart.
+	Targets map[string]map[string]struct {
+		Dependencies map[string]string `json:"dependencies"`
+	} `json:"targets"`
 	// Note: Libraries does not include transitive dependencies.
-	// Targets is not currently extracted because it introduces significant
-	// complexity and is not always necessary for basic dependency analysis.
 	Libraries map[string]struct {
 		Version string `json:"version"`
 		// Type represents the package type, if present. Examples of types include:
@@ -147,6 +155,25 @@ type DepsJSON struct {
 	} `json:"libraries"`
 }
 
+// directDependencies returns the names of the dependencies of the projects in
+// the runtime target, or nil if the deps.json has no such target.
+func (d *DepsJSON) directDependencies() map[string]bool {
+	target, ok := d.Targets[d.RuntimeTarget.Name]
+	if !ok {
+		return nil
+	}
+	direct := map[string]bool{}
+	for nameVersion, library := range d.Libraries {
+		if library.Type != "project" {
+			continue
+		}
+		for dep := range target[nameVersion].Dependencies {
+			direct[dep] = true
+		}
+	}
+	return direct
+}
+
 func (e Extractor) extractFromInput(input *filesystem.ScanInput) ([]*extractor.Package, error) {
 	var deps DepsJSON
 	decoder := json.NewDecoder(input.Reader)
@@ -161,6 +188,7 @@ func (e Extractor) extractFromInput(input *filesystem.ScanInput) ([]*extractor.P
 		return nil, errors.New("empty deps.json file or no libraries found")
 	}
 
+	direct := deps.directDependencies()
 	var packages []*extractor.Package
 	for nameVersion, library := range deps.Libraries {
 		// Split name and version from "package/version" format
@@ -178,6 +206,7 @@ func (e Extractor) extractFromInput(input *filesystem.ScanInput) ([]*extractor.P
 				PackageName:    name,
 				PackageVersion: version,
 				Type:           library.Type,
+				IsTransitive:   direct != nil && library.Type == "package" && !direct[name],
 			},
 			Locations: []string{input.Path},
 		}
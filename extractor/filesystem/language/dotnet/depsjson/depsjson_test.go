@@ -193,6 +193,7 @@ func TestExtract(t *testing.T) {
 						PackageName:    "AWSSDK.Core",
 						PackageVersion: "3.7.10.6",
 						Type:           "package",
+						IsTransitive:   true,
 					},
 					Locations: []string{"testdata/valid"},
 				},
@@ -245,6 +246,7 @@ func TestExtract(t *testing.T) {
 						PackageName:    "AWSSDK.Core",
 						PackageVersion: "3.7.10.6",
 						Type:           "package",
+						IsTransitive:   true,
 					},
 					Locations: []string{"testdata/nopackagename"},
 				},
@@ -26,6 +26,9 @@ type Metadata struct {
 	// - "package": Represents an external dependency, such as a NuGet package.
 	// - "project": Represents an internal dependency, such as the main application
 	Type string
+	// IsTransitive is true if the package isn't a dependency of the project
+	// itself but only of other packages. Only set if the deps.json has targets.
+	IsTransitive bool
 }
 
 // SetProto sets the DEPSJSONMetadata field in the Package proto.
@@ -42,6 +45,7 @@ func (m *Metadata) SetProto(p *pb.Package) {
 			PackageName:    m.PackageName,
 			PackageVersion: m.PackageVersion,
 			Type:           m.Type,
+			IsTransitive:   m.IsTransitive,
 		},
 	}
 }
@@ -56,5 +60,6 @@ func ToStruct(m *pb.DEPSJSONMetadata) *Metadata {
 		PackageName:    m.GetPackageName(),
 		PackageVersion: m.GetPackageVersion(),
 		Type:           m.GetType(),
+		IsTransitive:   m.GetIsTransitive(),
 	}
 }
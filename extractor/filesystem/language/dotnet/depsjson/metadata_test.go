@@ -83,6 +83,7 @@ func TestSetProto(t *testing.T) {
 				PackageName:    "some-package",
 				PackageVersion: "1.0.0",
 				Type:           "package",
+				IsTransitive:   true,
 			},
 			p: &pb.Package{Name: "some-package"},
 			want: &pb.Package{
@@ -92,6 +93,7 @@ func TestSetProto(t *testing.T) {
 						PackageName:    "some-package",
 						PackageVersion: "1.0.0",
 						Type:           "package",
+						IsTransitive:   true,
 					},
 				},
 			},
@@ -149,11 +151,13 @@ func TestToStruct(t *testing.T) {
 				PackageName:    "some-package",
 				PackageVersion: "1.0.0",
 				Type:           "package",
+				IsTransitive:   true,
 			},
 			want: &depsjson.Metadata{
 				PackageName:    "some-package",
 				PackageVersion: "1.0.0",
 				Type:           "package",
+				IsTransitive:   true,
 			},
 		},
 	}
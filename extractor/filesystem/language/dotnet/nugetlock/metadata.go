@@ -0,0 +1,60 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package nugetlock provides shared structures for the extractors of NuGet
+// lockfiles, i.e. packages.lock.json and project.assets.json.
+package nugetlock
+
+import (
+	pb "github.com/google/osv-scalibr/binary/proto/scan_result_go_proto"
+)
+
+// Metadata holds parsing information for a locked NuGet package.
+type Metadata struct {
+	// IsTransitive is true if the package isn't referenced by the project
+	// itself but only by other packages.
+	IsTransitive bool
+	// TargetFrameworks are the target framework monikers the package is
+	// resolved for, e.g. "net8.0", sorted.
+	TargetFrameworks []string
+}
+
+// SetProto sets the NuGetLockfileMetadata field in the Package proto.
+func (m *Metadata) SetProto(p *pb.Package) {
+	if m == nil {
+		return
+	}
+	if p == nil {
+		return
+	}
+
+	p.Metadata = &pb.Package_NugetLockfileMetadata{
+		NugetLockfileMetadata: &pb.NuGetLockfileMetadata{
+			IsTransitive:     m.IsTransitive,
+			TargetFrameworks: m.TargetFrameworks,
+		},
+	}
+}
+
+// ToStruct converts the NuGetLockfileMetadata proto to a Metadata struct.
+func ToStruct(m *pb.NuGetLockfileMetadata) *Metadata {
+	if m == nil {
+		return nil
+	}
+
+	return &Metadata{
+		IsTransitive:     m.GetIsTransitive(),
+		TargetFrameworks: m.GetTargetFrameworks(),
+	}
+}
@@ -21,9 +21,12 @@ import (
 	"fmt"
 	"io"
 	"path/filepath"
+	"slices"
+	"strings"
 
 	"github.com/google/osv-scalibr/extractor"
 	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/extractor/filesystem/language/dotnet/nugetlock"
 	"github.com/google/osv-scalibr/inventory"
 	"github.com/google/osv-scalibr/plugin"
 	"github.com/google/osv-scalibr/purl"
@@ -85,11 +88,26 @@ type PackagesLockJSON struct {
 // PackageInfo represents a single package's info, including its resolved
 // version, and its dependencies
 type PackageInfo struct {
+	// Type is how the package is referenced, e.g. TypeDirect.
+	Type string `json:"type"`
 	// Resolved is the resolved version for this dependency.
 	Resolved     string            `json:"resolved"`
 	Dependencies map[string]string `json:"dependencies"`
 }
 
+// Values of PackageInfo.Type.
+const (
+	// TypeDirect is a package referenced by the project.
+	TypeDirect = "Direct"
+	// TypeTransitive is a package referenced by other packages.
+	TypeTransitive = "Transitive"
+	// TypeCentralTransitive is a transitive package whose version is pinned
+	// with central package management.
+	TypeCentralTransitive = "CentralTransitive"
+	// TypeProject is a project referenced by the project.
+	TypeProject = "Project"
+)
+
 // Name of the extractor.
 func (e Extractor) Name() string { return Name }
 
@@ -152,20 +170,42 @@ func (e Extractor) extractFromInput(input *filesystem.ScanInput) ([]*extractor.P
 	if err != nil {
 		return nil, err
 	}
+
+	// The same package is usually listed for each target framework.
+	type key struct{ name, version string }
+	seen := map[key]*extractor.Package{}
 	var res []*extractor.Package
-	for _, packages := range p.Dependencies {
+	for tfm, packages := range p.Dependencies {
+		// Runtime specific sections are keyed by e.g. "net8.0/linux-x64".
+		framework, _, _ := strings.Cut(tfm, "/")
 		for pkgName, info := range packages {
-			pkg := &extractor.Package{
-				Name:     pkgName,
-				Version:  info.Resolved,
-				PURLType: purl.TypeNuget,
-				Locations: []string{
-					input.Path,
-				},
+			k := key{name: pkgName, version: info.Resolved}
+			pkg, ok := seen[k]
+			if !ok {
+				pkg = &extractor.Package{
+					Name:     pkgName,
+					Version:  info.Resolved,
+					PURLType: purl.TypeNuget,
+					Locations: []string{
+						input.Path,
+					},
+					Metadata: &nugetlock.Metadata{IsTransitive: true},
+				}
+				seen[k] = pkg
+				res = append(res, pkg)
+			}
+			m := pkg.Metadata.(*nugetlock.Metadata)
+			if info.Type == TypeDirect || info.Type == TypeProject {
+				m.IsTransitive = false
+			}
+			if !slices.Contains(m.TargetFrameworks, framework) {
+				m.TargetFrameworks = append(m.TargetFrameworks, framework)
 			}
-			res = append(res, pkg)
 		}
 	}
+	for _, pkg := range res {
+		slices.Sort(pkg.Metadata.(*nugetlock.Metadata).TargetFrameworks)
+	}
 
 	return res, nil
 }
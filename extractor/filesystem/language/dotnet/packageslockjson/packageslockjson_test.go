@@ -26,6 +26,7 @@ import (
 	"github.com/google/osv-scalibr/extractor"
 	"github.com/google/osv-scalibr/extractor/filesystem"
 	"github.com/google/osv-scalibr/extractor/filesystem/internal/units"
+	"github.com/google/osv-scalibr/extractor/filesystem/language/dotnet/nugetlock"
 	"github.com/google/osv-scalibr/extractor/filesystem/language/dotnet/packageslockjson"
 	"github.com/google/osv-scalibr/extractor/filesystem/simplefileapi"
 	scalibrfs "github.com/google/osv-scalibr/fs"
@@ -146,48 +147,116 @@ func TestExtractor(t *testing.T) {
 					Version:   "1.24.0",
 					PURLType:  purl.TypeNuget,
 					Locations: []string{"testdata/valid/packages.lock.json"},
+					Metadata: &nugetlock.Metadata{
+						IsTransitive:     false,
+						TargetFrameworks: []string{"net6.0"},
+					},
 				},
 				{
 					Name:      "Some.Dep.One",
 					Version:   "1.1.1",
 					PURLType:  purl.TypeNuget,
 					Locations: []string{"testdata/valid/packages.lock.json"},
+					Metadata: &nugetlock.Metadata{
+						IsTransitive:     true,
+						TargetFrameworks: []string{"net6.0"},
+					},
 				},
 				{
 					Name:      "Some.Dep.Two",
 					Version:   "4.6.0",
 					PURLType:  purl.TypeNuget,
 					Locations: []string{"testdata/valid/packages.lock.json"},
+					Metadata: &nugetlock.Metadata{
+						IsTransitive:     true,
+						TargetFrameworks: []string{"net6.0"},
+					},
 				},
 				{
 					Name:      "Some.Dep.Three",
 					Version:   "1.0.2",
 					PURLType:  purl.TypeNuget,
 					Locations: []string{"testdata/valid/packages.lock.json"},
+					Metadata: &nugetlock.Metadata{
+						IsTransitive:     true,
+						TargetFrameworks: []string{"net6.0"},
+					},
 				},
 				{
 					Name:      "Some.Dep.Four",
 					Version:   "4.5.0",
 					PURLType:  purl.TypeNuget,
 					Locations: []string{"testdata/valid/packages.lock.json"},
+					Metadata: &nugetlock.Metadata{
+						IsTransitive:     true,
+						TargetFrameworks: []string{"net6.0"},
+					},
 				},
 				{
 					Name:      "Some.Longer.Name.Dep",
 					Version:   "4.7.2",
 					PURLType:  purl.TypeNuget,
 					Locations: []string{"testdata/valid/packages.lock.json"},
+					Metadata: &nugetlock.Metadata{
+						IsTransitive:     true,
+						TargetFrameworks: []string{"net6.0"},
+					},
 				},
 				{
 					Name:      "Some.Dep.Five",
 					Version:   "4.7.2",
 					PURLType:  purl.TypeNuget,
 					Locations: []string{"testdata/valid/packages.lock.json"},
+					Metadata: &nugetlock.Metadata{
+						IsTransitive:     true,
+						TargetFrameworks: []string{"net6.0"},
+					},
 				},
 				{
 					Name:      "Another.Longer.Name.Dep",
 					Version:   "4.5.4",
 					PURLType:  purl.TypeNuget,
 					Locations: []string{"testdata/valid/packages.lock.json"},
+					Metadata: &nugetlock.Metadata{
+						IsTransitive:     true,
+						TargetFrameworks: []string{"net6.0"},
+					},
+				},
+			},
+			wantResultMetric: stats.FileExtractedResultSuccess,
+		},
+		{
+			name: "multiple target frameworks",
+			path: "testdata/multiframework/packages.lock.json",
+			wantPackages: []*extractor.Package{
+				{
+					Name:      "Newtonsoft.Json",
+					Version:   "13.0.3",
+					PURLType:  purl.TypeNuget,
+					Locations: []string{"testdata/multiframework/packages.lock.json"},
+					Metadata: &nugetlock.Metadata{
+						IsTransitive:     false,
+						TargetFrameworks: []string{"net48", "net8.0"},
+					},
+				},
+				{
+					Name:      "System.Text.Json",
+					Version:   "8.0.4",
+					PURLType:  purl.TypeNuget,
+					Locations: []string{"testdata/multiframework/packages.lock.json"},
+					Metadata: &nugetlock.Metadata{
+						IsTransitive:     true,
+						TargetFrameworks: []string{"net8.0"},
+					},
+				},
+				{
+					Name:      "MyCompany.Common",
+					PURLType:  purl.TypeNuget,
+					Locations: []string{"testdata/multiframework/packages.lock.json"},
+					Metadata: &nugetlock.Metadata{
+						IsTransitive:     false,
+						TargetFrameworks: []string{"net8.0"},
+					},
 				},
 			},
 			wantResultMetric: stats.FileExtractedResultSuccess,
@@ -0,0 +1,206 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package projectassetsjson extracts the NuGet packages that "dotnet restore"
+// resolved for a project from its obj/project.assets.json file.
+package projectassetsjson
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/extractor/filesystem/internal/units"
+	"github.com/google/osv-scalibr/extractor/filesystem/language/dotnet/nugetlock"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/plugin"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scalibr/stats"
+)
+
+const (
+	// Name is the unique name of this extractor.
+	Name = "dotnet/projectassetsjson"
+
+	// defaultMaxFileSizeBytes is the maximum file size this extractor will process.
+	defaultMaxFileSizeBytes = 50 * units.MiB
+)
+
+// Config is the configuration for the Extractor.
+type Config struct {
+	// Stats is a stats collector for reporting metrics.
+	Stats stats.Collector
+	// MaxFileSizeBytes is the maximum file size this extractor will unmarshal. If
+	// `FileRequired` gets a bigger file, it will return false.
+	MaxFileSizeBytes int64
+}
+
+// DefaultConfig returns the default configuration for the extractor.
+func DefaultConfig() Config {
+	return Config{
+		MaxFileSizeBytes: defaultMaxFileSizeBytes,
+	}
+}
+
+// Extractor extracts packages from project.assets.json files.
+type Extractor struct {
+	stats            stats.Collector
+	maxFileSizeBytes int64
+}
+
+// New returns a project.assets.json extractor.
+func New(cfg Config) *Extractor {
+	return &Extractor{
+		stats:            cfg.Stats,
+		maxFileSizeBytes: cfg.MaxFileSizeBytes,
+	}
+}
+
+// NewDefault returns an extractor with the default config settings.
+func NewDefault() filesystem.Extractor { return New(DefaultConfig()) }
+
+// projectAssets contains the parts of project.assets.json the extractor needs.
+type projectAssets struct {
+	// Targets maps the target frameworks, optionally followed by a runtime
+	// identifier, e.g. "net8.0/linux-x64", to the resolved libraries keyed by
+	// "name/version".
+	Targets map[string]map[string]struct {
+		Type string `json:"type"`
+	} `json:"targets"`
+	Project struct {
+		// Frameworks maps the target frameworks of the project to the packages
+		// it references.
+		Frameworks map[string]struct {
+			Dependencies map[string]json.RawMessage `json:"dependencies"`
+		} `json:"frameworks"`
+	} `json:"project"`
+}
+
+// Name of the extractor.
+func (e Extractor) Name() string { return Name }
+
+// Version of the extractor.
+func (e Extractor) Version() int { return 0 }
+
+// Requirements of the extractor.
+func (e Extractor) Requirements() *plugin.Capabilities { return &plugin.Capabilities{} }
+
+// FileRequired returns true if the specified file is a project.assets.json file.
+func (e Extractor) FileRequired(api filesystem.FileAPI) bool {
+	path := api.Path()
+	if filepath.Base(path) != "project.assets.json" {
+		return false
+	}
+
+	fileinfo, err := api.Stat()
+	if err != nil {
+		return false
+	}
+	if e.maxFileSizeBytes > 0 && fileinfo.Size() > e.maxFileSizeBytes {
+		e.reportFileRequired(path, fileinfo.Size(), stats.FileRequiredResultSizeLimitExceeded)
+		return false
+	}
+
+	e.reportFileRequired(path, fileinfo.Size(), stats.FileRequiredResultOK)
+	return true
+}
+
+func (e Extractor) reportFileRequired(path string, fileSizeBytes int64, result stats.FileRequiredResult) {
+	if e.stats == nil {
+		return
+	}
+	e.stats.AfterFileRequired(e.Name(), &stats.FileRequiredStats{
+		Path:          path,
+		Result:        result,
+		FileSizeBytes: fileSizeBytes,
+	})
+}
+
+// Extract returns the packages resolved in a project.assets.json file.
+func (e Extractor) Extract(ctx context.Context, input *filesystem.ScanInput) (inventory.Inventory, error) {
+	pkgs, err := e.extractFromInput(input)
+	if e.stats != nil {
+		var fileSizeBytes int64
+		if input.Info != nil {
+			fileSizeBytes = input.Info.Size()
+		}
+		e.stats.AfterFileExtracted(e.Name(), &stats.FileExtractedStats{
+			Path:          input.Path,
+			Result:        filesystem.ExtractorErrorToFileExtractedResult(err),
+			FileSizeBytes: fileSizeBytes,
+		})
+	}
+	return inventory.Inventory{Packages: pkgs}, err
+}
+
+func (e Extractor) extractFromInput(input *filesystem.ScanInput) ([]*extractor.Package, error) {
+	var assets projectAssets
+	if err := json.NewDecoder(input.Reader).Decode(&assets); err != nil {
+		return nil, fmt.Errorf("failed to decode project.assets.json file: %w", err)
+	}
+
+	// NuGet package IDs are case-insensitive.
+	direct := map[string]bool{}
+	for _, framework := range assets.Project.Frameworks {
+		for name := range framework.Dependencies {
+			direct[strings.ToLower(name)] = true
+		}
+	}
+
+	seen := map[string]*extractor.Package{}
+	var res []*extractor.Package
+	for target, libraries := range assets.Targets {
+		framework, _, _ := strings.Cut(target, "/")
+		for nameVersion, library := range libraries {
+			// Project references aren't NuGet packages.
+			if library.Type != "package" {
+				continue
+			}
+			name, version, ok := strings.Cut(nameVersion, "/")
+			if !ok || name == "" || version == "" {
+				continue
+			}
+			pkg, ok := seen[nameVersion]
+			if !ok {
+				pkg = &extractor.Package{
+					Name:      name,
+					Version:   version,
+					PURLType:  purl.TypeNuget,
+					Locations: []string{input.Path},
+					Metadata: &nugetlock.Metadata{
+						IsTransitive: !direct[strings.ToLower(name)],
+					},
+				}
+				seen[nameVersion] = pkg
+				res = append(res, pkg)
+			}
+			m := pkg.Metadata.(*nugetlock.Metadata)
+			if !slices.Contains(m.TargetFrameworks, framework) {
+				m.TargetFrameworks = append(m.TargetFrameworks, framework)
+			}
+		}
+	}
+	for _, pkg := range res {
+		slices.Sort(pkg.Metadata.(*nugetlock.Metadata).TargetFrameworks)
+	}
+
+	return res, nil
+}
+
+var _ filesystem.Extractor = Extractor{}
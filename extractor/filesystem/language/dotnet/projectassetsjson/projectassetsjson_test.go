@@ -0,0 +1,148 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package projectassetsjson_test
+
+import (
+	"context"
+	"io/fs"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem/internal/units"
+	"github.com/google/osv-scalibr/extractor/filesystem/language/dotnet/nugetlock"
+	"github.com/google/osv-scalibr/extractor/filesystem/language/dotnet/projectassetsjson"
+	"github.com/google/osv-scalibr/extractor/filesystem/simplefileapi"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scalibr/testing/extracttest"
+	"github.com/google/osv-scalibr/testing/fakefs"
+)
+
+func TestFileRequired(t *testing.T) {
+	tests := []struct {
+		name          string
+		path          string
+		fileSizeBytes int64
+		want          bool
+	}{
+		{
+			name: "project.assets.json",
+			path: "src/MyApp/obj/project.assets.json",
+			want: true,
+		},
+		{
+			name: "other file in obj",
+			path: "src/MyApp/obj/project.nuget.cache",
+			want: false,
+		},
+		{
+			name:          "too large",
+			path:          "src/MyApp/obj/project.assets.json",
+			fileSizeBytes: 100 * units.MiB,
+			want:          false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := projectassetsjson.NewDefault()
+			fileSizeBytes := tt.fileSizeBytes
+			if fileSizeBytes == 0 {
+				fileSizeBytes = 100 * units.KiB
+			}
+			got := e.FileRequired(simplefileapi.New(tt.path, fakefs.FakeFileInfo{
+				FileName: filepath.Base(tt.path),
+				FileMode: fs.ModePerm,
+				FileSize: fileSizeBytes,
+			}))
+			if got != tt.want {
+				t.Errorf("FileRequired(%s): got %v, want %v", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtract(t *testing.T) {
+	tests := []extracttest.TestTableEntry{
+		{
+			Name: "invalid json",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/invalid.json",
+			},
+			WantErr: cmpopts.AnyError,
+		},
+		{
+			Name: "packages",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/project.assets.json",
+			},
+			WantPackages: []*extractor.Package{
+				{
+					Name:      "Newtonsoft.Json",
+					Version:   "13.0.3",
+					PURLType:  purl.TypeNuget,
+					Locations: []string{"testdata/project.assets.json"},
+					Metadata: &nugetlock.Metadata{
+						IsTransitive:     false,
+						TargetFrameworks: []string{"net48", "net8.0"},
+					},
+				},
+				{
+					Name:      "Serilog",
+					Version:   "3.1.1",
+					PURLType:  purl.TypeNuget,
+					Locations: []string{"testdata/project.assets.json"},
+					Metadata: &nugetlock.Metadata{
+						IsTransitive:     false,
+						TargetFrameworks: []string{"net8.0"},
+					},
+				},
+				{
+					Name:      "System.Diagnostics.DiagnosticSource",
+					Version:   "7.0.2",
+					PURLType:  purl.TypeNuget,
+					Locations: []string{"testdata/project.assets.json"},
+					Metadata: &nugetlock.Metadata{
+						IsTransitive:     true,
+						TargetFrameworks: []string{"net8.0"},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			extr := projectassetsjson.NewDefault()
+
+			scanInput := extracttest.GenerateScanInputMock(t, tt.InputConfig)
+			defer extracttest.CloseTestScanInput(t, scanInput)
+
+			got, err := extr.Extract(context.Background(), &scanInput)
+
+			if diff := cmp.Diff(tt.WantErr, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("%s.Extract(%q) error diff (-want +got):\n%s", extr.Name(), tt.InputConfig.Path, diff)
+				return
+			}
+
+			wantInv := inventory.Inventory{Packages: tt.WantPackages}
+			if diff := cmp.Diff(wantInv, got, cmpopts.SortSlices(extracttest.PackageCmpLess)); diff != "" {
+				t.Errorf("%s.Extract(%q) diff (-want +got):\n%s", extr.Name(), tt.InputConfig.Path, diff)
+			}
+		})
+	}
+}
@@ -331,6 +331,7 @@ func TestExtractor_Extract(t *testing.T) {
 					PURLType:  purl.TypeHex,
 					Locations: []string{"testdata/git.lock"},
 					SourceCode: &extractor.SourceCodeIdentifier{
+						Repo:   "https://github.com/my-org/foe.git",
 						Commit: "a9574ab75d6ed01e1288c453ae1d943d7a964595",
 					},
 				},
@@ -340,6 +341,7 @@ func TestExtractor_Extract(t *testing.T) {
 					PURLType:  purl.TypeHex,
 					Locations: []string{"testdata/git.lock"},
 					SourceCode: &extractor.SourceCodeIdentifier{
+						Repo:   "https://github.com/my-org/foo.git",
 						Commit: "fc94cce7830fa4dc455024bc2a83720afe244531",
 					},
 				},
@@ -349,6 +351,7 @@ func TestExtractor_Extract(t *testing.T) {
 					PURLType:  purl.TypeHex,
 					Locations: []string{"testdata/git.lock"},
 					SourceCode: &extractor.SourceCodeIdentifier{
+						Repo:   "https://github.com/my-org/bar",
 						Commit: "bef3ee1d3618017061498b96c75043e8449ef9b5",
 					},
 				},
@@ -51,7 +51,7 @@ func ParseMixLockFile(input *filesystem.ScanInput) (inventory.Inventory, error)
 	for scanner.Scan() {
 		line := scanner.Text()
 
-		var name, version, commit string
+		var name, version, repo, commit string
 
 		// Matching git dependency line
 		if match := gitDependencyLineRe.FindStringSubmatch(line); match != nil {
@@ -61,6 +61,8 @@ func ParseMixLockFile(input *filesystem.ScanInput) (inventory.Inventory, error)
 				continue
 			}
 			name = match[1]
+			// The repository identifies packages that come from git instead of hex.pm.
+			repo = match[2]
 			commit = match[3]
 			version = "" // Git dependency doesn't have version info, so empty string
 		} else {
@@ -85,6 +87,7 @@ func ParseMixLockFile(input *filesystem.ScanInput) (inventory.Inventory, error)
 			PURLType:  purl.TypeHex,
 			Locations: []string{input.Path},
 			SourceCode: &extractor.SourceCodeIdentifier{
+				Repo:   repo,
 				Commit: commit,
 			},
 		})
@@ -22,6 +22,7 @@ import (
 	"errors"
 	"io"
 	"io/fs"
+	"math"
 	"regexp"
 	"runtime/debug"
 	"strings"
@@ -59,6 +60,11 @@ type Config struct {
 	// This is off by default because the operation is expensive as it uses a regexp to parse the
 	// binary content.
 	VersionFromContent bool
+	// StrippedBinaries enables recovering the modules of Go binaries without
+	// buildinfo from the source file paths in the binary content. This is off by
+	// default because it requires reading every executable that isn't a regular
+	// Go binary in full.
+	StrippedBinaries bool
 }
 
 // Extractor extracts packages from buildinfo inside go binaries files.
@@ -69,6 +75,10 @@ type Extractor struct {
 	// VersionFromBinary enables extracting the module version from the binary content.
 	// This operation is expensive because it uses a regexp to parse the binary content.
 	VersionFromContent bool
+
+	// StrippedBinaries enables recovering the modules of Go binaries without
+	// buildinfo from the source file paths in the binary content.
+	StrippedBinaries bool
 }
 
 // DefaultConfig returns a default configuration for the extractor.
@@ -77,6 +87,7 @@ func DefaultConfig() Config {
 		Stats:              nil,
 		MaxFileSizeBytes:   0,
 		VersionFromContent: false,
+		StrippedBinaries:   false,
 	}
 }
 
@@ -91,6 +102,7 @@ func New(cfg Config) *Extractor {
 		stats:              cfg.Stats,
 		maxFileSizeBytes:   cfg.MaxFileSizeBytes,
 		VersionFromContent: cfg.VersionFromContent,
+		StrippedBinaries:   cfg.StrippedBinaries,
 	}
 }
 
@@ -152,6 +164,12 @@ func (e Extractor) Extract(ctx context.Context, input *filesystem.ScanInput) (in
 	}
 
 	binfo, err := buildinfo.Read(readerAt)
+	if err != nil && e.StrippedBinaries {
+		if pkgs := e.extractStripped(readerAt, input.Path); len(pkgs) > 0 {
+			e.reportFileExtracted(input.Path, input.Info, nil)
+			return inventory.Inventory{Packages: pkgs}, nil
+		}
+	}
 	if err != nil {
 		log.Debugf("error parsing the contents of Go binary (%s) for extraction: %v", input.Path, err)
 		e.reportFileExtracted(input.Path, input.Info, err)
@@ -172,6 +190,17 @@ func (e Extractor) Extract(ctx context.Context, input *filesystem.ScanInput) (in
 	return inventory.Inventory{Packages: pkg}, nil
 }
 
+// extractStripped reads the entire binary and recovers its modules from the
+// binary content.
+func (e Extractor) extractStripped(r io.ReaderAt, filename string) []*extractor.Package {
+	data, err := io.ReadAll(io.NewSectionReader(r, 0, math.MaxInt64))
+	if err != nil {
+		log.Debugf("error reading the contents of Go binary (%s): %v", filename, err)
+		return nil
+	}
+	return extractPackagesFromContent(data, filename)
+}
+
 func (e Extractor) reportFileExtracted(path string, fileinfo fs.FileInfo, err error) {
 	if e.stats == nil {
 		return
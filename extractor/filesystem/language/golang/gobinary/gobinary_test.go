@@ -15,6 +15,7 @@
 package gobinary_test
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"io/fs"
@@ -306,7 +307,112 @@ func TestExtract(t *testing.T) {
 	}
 }
 
+func TestExtract_StrippedBinary(t *testing.T) {
+	tests := []struct {
+		name             string
+		path             string
+		strippedBinaries bool
+		wantPackages     []*extractor.Package
+		wantResultMetric stats.FileExtractedResult
+	}{
+		{
+			name:             "stripped binary is skipped by default",
+			path:             "testdata/binary_with_modules-linux-amd64",
+			wantResultMetric: stats.FileExtractedResultErrorUnknown,
+		},
+		{
+			name:             "binary_with_modules-linux-amd64",
+			path:             "testdata/binary_with_modules-linux-amd64",
+			strippedBinaries: true,
+			wantPackages:     createPackages(BinaryWithModulesPackagesStripped, "binary"),
+			wantResultMetric: stats.FileExtractedResultSuccess,
+		},
+		{
+			name:             "binary_with_modules-darwin-arm64",
+			path:             "testdata/binary_with_modules-darwin-arm64",
+			strippedBinaries: true,
+			wantPackages:     createPackages(BinaryWithModulesPackagesStripped, "binary"),
+			wantResultMetric: stats.FileExtractedResultSuccess,
+		},
+		{
+			name:             "binary_with_module_replacement-windows-386",
+			path:             "testdata/binary_with_module_replacement-windows-386",
+			strippedBinaries: true,
+			wantPackages:     createPackages(BinaryWithModuleReplacementPackages, "binary"),
+			wantResultMetric: stats.FileExtractedResultSuccess,
+		},
+		{
+			name:             "not a Go binary",
+			path:             "testdata/dummy",
+			strippedBinaries: true,
+			wantResultMetric: stats.FileExtractedResultErrorUnknown,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content, err := os.ReadFile(tt.path)
+			if err != nil {
+				t.Fatalf("os.ReadFile(%s) unexpected error: %v", tt.path, err)
+			}
+			// Remove the buildinfo header to simulate a binary without buildinfo.
+			content = bytes.ReplaceAll(content, []byte("\xff Go buildinf:"), make([]byte, 14))
+			dir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(dir, "binary"), content, 0755); err != nil {
+				t.Fatalf("os.WriteFile() unexpected error: %v", err)
+			}
+			f, err := os.Open(filepath.Join(dir, "binary"))
+			if err != nil {
+				t.Fatalf("os.Open() unexpected error: %v", err)
+			}
+			defer f.Close()
+			info, err := f.Stat()
+			if err != nil {
+				t.Fatalf("f.Stat() unexpected error: %v", err)
+			}
+
+			collector := testcollector.New()
+			cfg := gobinary.DefaultConfig()
+			cfg.Stats = collector
+			cfg.StrippedBinaries = tt.strippedBinaries
+			input := &filesystem.ScanInput{FS: scalibrfs.DirFS(dir), Path: "binary", Info: info, Reader: f}
+
+			got, err := gobinary.New(cfg).Extract(context.Background(), input)
+			if err != nil {
+				t.Fatalf("Extract(%s) unexpected error: %v", tt.path, err)
+			}
+			sort := func(a, b *extractor.Package) bool { return a.Name < b.Name }
+			wantInv := inventory.Inventory{Packages: tt.wantPackages}
+			if diff := cmp.Diff(wantInv, got, cmpopts.SortSlices(sort), cmpopts.EquateEmpty()); diff != "" {
+				t.Fatalf("Extract(%s) (-want +got):\n%s", tt.path, diff)
+			}
+			if got := collector.FileExtractedResult("binary"); got != tt.wantResultMetric {
+				t.Errorf("Extract(%s) recorded result metric %v, want %v", tt.path, got, tt.wantResultMetric)
+			}
+		})
+	}
+}
+
 var (
+	// BinaryWithModulesPackagesStripped is the subset of BinaryWithModulesPackages
+	// that can be recovered from the binary content. Modules without compiled
+	// source files and the toolchain version aren't part of it.
+	BinaryWithModulesPackagesStripped = []*extractor.Package{
+		goPackage("github.com/gin-contrib/sse", "0.1.0"),
+		goPackage("github.com/gin-gonic/gin", "1.8.1"),
+		goPackage("github.com/go-playground/validator/v10", "10.11.1"),
+		goPackage("github.com/mattn/go-isatty", "0.0.16"),
+		goPackage("github.com/pelletier/go-toml/v2", "2.0.6"),
+		goPackage("github.com/ugorji/go/codec", "1.2.7"),
+		goPackage("github.com/ulikunitz/xz", "0.5.11"),
+		goPackage("golang.org/x/crypto", "0.4.0"),
+		goPackage("golang.org/x/net", "0.4.0"),
+		goPackage("golang.org/x/sys", "0.3.0"),
+		goPackage("golang.org/x/text", "0.5.0"),
+		goPackage("google.golang.org/protobuf", "1.28.1"),
+		goPackage("gopkg.in/yaml.v2", "2.4.0"),
+	}
+
 	// BinaryWithModulesPackagesWindows is a list of packages built into the
 	// binary_with_modules-* testdata binaries, but only on Windows, where there
 	// is an indirect dependency that is not built-in.
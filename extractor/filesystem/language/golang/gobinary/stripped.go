@@ -0,0 +1,162 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gobinary
+
+import (
+	"bytes"
+	"debug/gosym"
+	"encoding/binary"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/purl"
+	"golang.org/x/mod/module"
+)
+
+var (
+	// pclntabMagics are the magic numbers at the start of the pclntab of Go 1.2
+	// and later binaries, see runtime/symtab.go.
+	pclntabMagics = []uint32{0xfffffffb, 0xfffffffa, 0xfffffff0, 0xfffffff1}
+
+	// reModuleFile matches source file paths inside the module cache, e.g.
+	// "/root/go/pkg/mod/golang.org/x/net@v0.4.0/". Paths of binaries built with
+	// -trimpath are stored without the module cache prefix.
+	reModuleFile = regexp.MustCompile(`(?:pkg/mod/|\x00)([a-z0-9][a-z0-9.\-]*\.[a-z0-9\-]+(?:/[A-Za-z0-9._~!\-]+)*)@(v\d+\.\d+\.\d+(?:-[0-9A-Za-z.\-]+)?(?:\+incompatible)?)/`)
+)
+
+// extractPackagesFromContent recovers the main module and dependencies of a Go
+// binary without buildinfo from the source file paths in its pclntab and
+// string table. Only modules that were built from the module cache can be
+// recovered, so e.g. the main module is only found for binaries built with
+// "go install <module>@<version>". Returns nil if the data isn't a Go binary.
+func extractPackagesFromContent(data []byte, filename string) []*extractor.Package {
+	tab := findSymTable(data)
+	if tab == nil {
+		return nil
+	}
+
+	versions := map[string]string{}
+	for _, m := range reModuleFile.FindAllSubmatch(data, -1) {
+		path, err := module.UnescapePath(string(m[1]))
+		if err != nil {
+			continue
+		}
+		version, err := module.UnescapeVersion(string(m[2]))
+		if err != nil {
+			continue
+		}
+		if _, ok := versions[path]; !ok {
+			versions[path] = version
+		}
+	}
+
+	var mainPath string
+	if fn := tab.LookupFunc("main.main"); fn != nil {
+		file, _, _ := tab.PCToLine(fn.Entry)
+		if m := reModuleFile.FindStringSubmatch("\x00" + strings.TrimLeft(file, "/")); m != nil {
+			mainPath, _ = module.UnescapePath(m[1])
+		}
+	}
+
+	paths := make([]string, 0, len(versions))
+	for p := range versions {
+		if p != mainPath {
+			paths = append(paths, p)
+		}
+	}
+	sort.Strings(paths)
+	if _, ok := versions[mainPath]; ok {
+		// Like with buildinfo, the main module comes last.
+		paths = append(paths, mainPath)
+	}
+
+	res := make([]*extractor.Package, 0, len(paths))
+	for _, p := range paths {
+		res = append(res, &extractor.Package{
+			Name:      p,
+			Version:   strings.TrimPrefix(versions[p], "v"),
+			PURLType:  purl.TypeGolang,
+			Locations: []string{filename},
+		})
+	}
+	return res
+}
+
+// findSymTable locates and parses the pclntab of a Go binary by its header.
+// This works regardless of the executable format and even if the section
+// headers were stripped. Returns nil if no valid pclntab was found.
+func findSymTable(data []byte) *gosym.Table {
+	// Skip the expensive search in binaries that weren't written in Go.
+	if !bytes.Contains(data, []byte("runtime.main\x00")) {
+		return nil
+	}
+	for _, magic := range pclntabMagics {
+		for _, order := range []binary.ByteOrder{binary.LittleEndian, binary.BigEndian} {
+			pattern := make([]byte, 4)
+			order.PutUint32(pattern, magic)
+			for off := 0; off < len(data); {
+				i := bytes.Index(data[off:], pattern)
+				if i < 0 {
+					break
+				}
+				off += i
+				if tab := parseSymTable(data[off:], order); tab != nil {
+					return tab
+				}
+				off++
+			}
+		}
+	}
+	return nil
+}
+
+// parseSymTable parses a pclntab candidate. The magic number is followed by
+// two zero bytes, the instruction size quantum, the pointer size and the
+// number of functions.
+func parseSymTable(data []byte, order binary.ByteOrder) (tab *gosym.Table) {
+	if len(data) < 16 || data[4] != 0 || data[5] != 0 {
+		return nil
+	}
+	if q := data[6]; q != 1 && q != 2 && q != 4 {
+		return nil
+	}
+	var nfunc uint64
+	switch data[7] {
+	case 4:
+		nfunc = uint64(order.Uint32(data[8:]))
+	case 8:
+		nfunc = order.Uint64(data[8:])
+	default:
+		return nil
+	}
+	// Each function has an entry of at least 8 bytes in the function table.
+	// This guards against huge allocations for bogus tables.
+	if nfunc == 0 || nfunc > uint64(len(data)/8) {
+		return nil
+	}
+	// The gosym package panics on malformed tables instead of returning errors.
+	defer func() {
+		if recover() != nil {
+			tab = nil
+		}
+	}()
+	t, err := gosym.NewTable(nil, gosym.NewLineTable(data, 0))
+	if err != nil || t.LookupFunc("runtime.main") == nil {
+		return nil
+	}
+	return t
+}
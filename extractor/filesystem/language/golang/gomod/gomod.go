@@ -27,6 +27,7 @@ import (
 
 	"github.com/google/osv-scalibr/extractor"
 	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/extractor/filesystem/internal/suppression"
 	"github.com/google/osv-scalibr/inventory"
 	"github.com/google/osv-scalibr/log"
 	"github.com/google/osv-scalibr/plugin"
@@ -146,10 +147,11 @@ func (e Extractor) extractGoMod(input *filesystem.ScanInput) (map[pkgKey]*extrac
 		name := require.Mod.Path
 		version := strings.TrimPrefix(require.Mod.Version, "v")
 		packages[pkgKey{name: name, version: version}] = &extractor.Package{
-			Name:      name,
-			Version:   version,
-			PURLType:  purl.TypeGolang,
-			Locations: []string{input.Path},
+			Name:                  name,
+			Version:               version,
+			PURLType:              purl.TypeGolang,
+			Locations:             []string{input.Path},
+			ExploitabilitySignals: suppression.ParseAll(lineComments(require.Syntax), Name),
 		}
 	}
 
@@ -217,4 +219,17 @@ func (e Extractor) extractGoMod(input *filesystem.ScanInput) (map[pkgKey]*extrac
 	return dedupedPs, goVersion, nil
 }
 
+// lineComments returns the text of the comments directly above and after a
+// go.mod line.
+func lineComments(line *modfile.Line) []string {
+	if line == nil {
+		return nil
+	}
+	var comments []string
+	for _, c := range slices.Concat(line.Before, line.Suffix) {
+		comments = append(comments, strings.TrimPrefix(c.Token, "//"))
+	}
+	return comments
+}
+
 var _ filesystem.Extractor = Extractor{}
@@ -24,6 +24,7 @@ import (
 	"github.com/google/osv-scalibr/extractor/filesystem/language/golang/gomod"
 	"github.com/google/osv-scalibr/extractor/filesystem/simplefileapi"
 	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/inventory/vex"
 	"github.com/google/osv-scalibr/purl"
 	"github.com/google/osv-scalibr/testing/extracttest"
 )
@@ -126,6 +127,48 @@ func TestExtractor_Extract(t *testing.T) {
 				},
 			},
 		},
+		{
+			Name: "suppression comments",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/suppressions.mod",
+			},
+			WantPackages: []*extractor.Package{
+				{
+					Name:      "github.com/BurntSushi/toml",
+					Version:   "1.0.0",
+					PURLType:  purl.TypeGolang,
+					Locations: []string{"testdata/suppressions.mod"},
+					ExploitabilitySignals: []*vex.PackageExploitabilitySignal{{
+						Plugin:          gomod.Name,
+						VulnIdentifiers: []string{"GO-2021-0061"},
+						Reason:          "we only parse trusted config files",
+					}},
+				},
+				{
+					Name:      "gopkg.in/yaml.v2",
+					Version:   "2.4.0",
+					PURLType:  purl.TypeGolang,
+					Locations: []string{"testdata/suppressions.mod"},
+				},
+				{
+					Name:      "golang.org/x/text",
+					Version:   "0.3.5",
+					PURLType:  purl.TypeGolang,
+					Locations: []string{"testdata/suppressions.mod"},
+					ExploitabilitySignals: []*vex.PackageExploitabilitySignal{{
+						Plugin:          gomod.Name,
+						VulnIdentifiers: []string{"GO-2021-0113"},
+						Justification:   vex.VulnerableCodeNotInExecutePath,
+					}},
+				},
+				{
+					Name:      "stdlib",
+					Version:   "1.17",
+					PURLType:  purl.TypeGolang,
+					Locations: []string{"testdata/suppressions.mod"},
+				},
+			},
+		},
 		{
 			Name: "toolchain",
 			InputConfig: extracttest.ScanInputMockConfig{
@@ -0,0 +1,10 @@
+module my-library
+
+go 1.17
+
+require (
+	// scalibr:ignore GO-2021-0061 reason=we only parse trusted config files
+	github.com/BurntSushi/toml v1.0.0
+	gopkg.in/yaml.v2 v2.4.0 // indirect
+	golang.org/x/text v0.3.5 // scalibr:ignore GO-2021-0113 justification=vulnerable_code_not_in_execute_path
+)
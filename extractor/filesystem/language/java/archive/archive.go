@@ -19,18 +19,20 @@ import (
 	"archive/zip"
 	"bytes"
 	"context"
-	"crypto/sha1"
 	"encoding/base64"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"io"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/google/osv-scalibr/extractor"
 	"github.com/google/osv-scalibr/extractor/filesystem"
 	"github.com/google/osv-scalibr/extractor/filesystem/internal/units"
 	archivemeta "github.com/google/osv-scalibr/extractor/filesystem/language/java/archive/metadata"
+	"github.com/google/osv-scalibr/hashing"
 	"github.com/google/osv-scalibr/inventory"
 	"github.com/google/osv-scalibr/log"
 	"github.com/google/osv-scalibr/plugin"
@@ -77,6 +79,14 @@ type Config struct {
 	ExtractFromFilename bool
 	// HashJars configures if JAR files should be hashed with base64(sha1()), which can be used in deps.dev.
 	HashJars bool
+	// DigestEntries configures if the SHA-256 digests of the class files in
+	// archives and a fingerprint over them are added to the metadata. They can
+	// be used to match renamed or repackaged JARs, e.g. ones without a
+	// pom.properties, against known artifacts.
+	DigestEntries bool
+	// Hashing restricts the digest algorithms the extractor may use, e.g. in FIPS mode.
+	// If nil, no restrictions are applied.
+	Hashing *hashing.Config
 	// Stats is a stats collector for reporting metrics.
 	Stats stats.Collector
 }
@@ -89,6 +99,8 @@ type Extractor struct {
 	minZipBytes         int
 	extractFromFilename bool
 	hashJars            bool
+	digestEntries       bool
+	hashing             *hashing.Config
 	stats               stats.Collector
 }
 
@@ -119,6 +131,8 @@ func New(cfg Config) *Extractor {
 		minZipBytes:         cfg.MinZipBytes,
 		extractFromFilename: cfg.ExtractFromFilename,
 		hashJars:            cfg.HashJars,
+		digestEntries:       cfg.DigestEntries,
+		hashing:             cfg.Hashing,
 		stats:               cfg.Stats,
 	}
 }
@@ -126,6 +140,9 @@ func New(cfg Config) *Extractor {
 // NewDefault returns an extractor with the default config settings.
 func NewDefault() filesystem.Extractor { return New(DefaultConfig()) }
 
+// SetHashingConfig sets the hashing config used when hashing JAR files.
+func (e *Extractor) SetHashingConfig(cfg *hashing.Config) { e.hashing = cfg }
+
 // Name of the extractor.
 func (e Extractor) Name() string { return Name }
 
@@ -168,7 +185,8 @@ func (e Extractor) reportFileRequired(path string, fileSizeBytes int64, result s
 
 // Extract extracts java packages from archive files passed through input.
 func (e Extractor) Extract(ctx context.Context, input *filesystem.ScanInput) (inventory.Inventory, error) {
-	pkgs, openedBytes, err := e.extractWithMax(ctx, input, 1, 0)
+	prov := &extractor.LocationProvenance{Location: input.Path, Path: input.Path}
+	pkgs, openedBytes, err := e.extractWithMax(ctx, input, prov, 1, 0)
 	if e.stats != nil {
 		var fileSizeBytes int64
 		if input.Info != nil {
@@ -188,7 +206,8 @@ func (e Extractor) Extract(ctx context.Context, input *filesystem.ScanInput) (in
 //
 // It returns early with an error if max depth or max opened bytes is reached.
 // Extracted packages are returned even if an error has occurred.
-func (e Extractor) extractWithMax(ctx context.Context, input *filesystem.ScanInput, depth int, openedBytes int64) ([]*extractor.Package, int64, error) {
+// prov describes where the input file is, including the archives it's nested in.
+func (e Extractor) extractWithMax(ctx context.Context, input *filesystem.ScanInput, prov *extractor.LocationProvenance, depth int, openedBytes int64) ([]*extractor.Package, int64, error) {
 	// Return early if any max/min thresholds are hit.
 	if depth > e.maxZipDepth {
 		return nil, openedBytes, fmt.Errorf("%s reached max zip depth %d", e.Name(), depth)
@@ -226,8 +245,8 @@ func (e Extractor) extractWithMax(ctx context.Context, input *filesystem.ScanInp
 
 	// Hash Jar
 	sha1 := ""
-	if e.hashJars {
-		h, err := hashJar(r.(io.Reader))
+	if e.hashJars && e.hashing.Allows(hashing.SHA1) {
+		h, err := hashJar(r.(io.Reader), e.hashing)
 		if err != nil {
 			log.Errorf("HashJar(%q) err: %v", input.Path, err)
 			// continue extracting even if hashing failed
@@ -246,6 +265,25 @@ func (e Extractor) extractWithMax(ctx context.Context, input *filesystem.ScanInp
 
 	log.Debugf("extract jar archive: %s", input.Path)
 
+	var fingerprint string
+	var classDigests []*archivemeta.ClassDigest
+	if e.digestEntries && e.hashing.Allows(hashing.SHA256) {
+		fingerprint, classDigests, err = digestClasses(zipReader, e.hashing)
+		if err != nil {
+			log.Errorf("%s failed to digest the class files of %q: %v", e.Name(), input.Path, err)
+			// continue extracting even if digesting failed
+		}
+	}
+	metadata := func(groupID, artifactID string) *archivemeta.Metadata {
+		return &archivemeta.Metadata{
+			ArtifactID:   artifactID,
+			GroupID:      groupID,
+			SHA1:         sha1,
+			Fingerprint:  fingerprint,
+			ClassDigests: classDigests,
+		}
+	}
+
 	// Aggregate errors while looping through files in the zip to continue extraction of other files.
 	errs := []error{}
 	pkgs := []*extractor.Package{}
@@ -264,6 +302,7 @@ func (e Extractor) extractWithMax(ctx context.Context, input *filesystem.ScanInp
 		}
 
 		path := filepath.Join(input.Path, file.Name)
+		entryProv := prov.Inside(path, file.Name)
 		switch {
 		case filepath.Base(file.Name) == "pom.properties":
 			pp, err := parsePomProps(file)
@@ -274,15 +313,12 @@ func (e Extractor) extractWithMax(ctx context.Context, input *filesystem.ScanInp
 			}
 			if pp.valid() {
 				packagePom = append(packagePom, &extractor.Package{
-					Name:     fmt.Sprintf("%s:%s", pp.GroupID, pp.ArtifactID),
-					Version:  pp.Version,
-					PURLType: purl.TypeMaven,
-					Metadata: &archivemeta.Metadata{
-						ArtifactID: pp.ArtifactID,
-						GroupID:    pp.GroupID,
-						SHA1:       sha1,
-					},
-					Locations: []string{input.Path, path},
+					Name:               fmt.Sprintf("%s:%s", pp.GroupID, pp.ArtifactID),
+					Version:            pp.Version,
+					PURLType:           purl.TypeMaven,
+					Metadata:           metadata(pp.GroupID, pp.ArtifactID),
+					Locations:          []string{input.Path, path},
+					LocationProvenance: nestedProvenance(prov, entryProv),
 				})
 			}
 
@@ -295,15 +331,12 @@ func (e Extractor) extractWithMax(ctx context.Context, input *filesystem.ScanInp
 			}
 			if mf.valid() {
 				packageManifest = append(packageManifest, &extractor.Package{
-					Name:     fmt.Sprintf("%s:%s", mf.GroupID, mf.ArtifactID),
-					Version:  mf.Version,
-					PURLType: purl.TypeMaven,
-					Metadata: &archivemeta.Metadata{
-						ArtifactID: mf.ArtifactID,
-						GroupID:    mf.GroupID,
-						SHA1:       sha1,
-					},
-					Locations: []string{input.Path, path},
+					Name:               fmt.Sprintf("%s:%s", mf.GroupID, mf.ArtifactID),
+					Version:            mf.Version,
+					PURLType:           purl.TypeMaven,
+					Metadata:           metadata(mf.GroupID, mf.ArtifactID),
+					Locations:          []string{input.Path, path},
+					LocationProvenance: nestedProvenance(prov, entryProv),
 				})
 			}
 
@@ -320,10 +353,11 @@ func (e Extractor) extractWithMax(ctx context.Context, input *filesystem.ScanInp
 				defer f.Close()
 				subInput := &filesystem.ScanInput{Path: path, Info: file.FileInfo(), Reader: f}
 				var subPackage []*extractor.Package
-				subPackage, openedBytes, err = e.extractWithMax(ctx, subInput, depth+1, openedBytes)
+				subPackage, openedBytes, err = e.extractWithMax(ctx, subInput, entryProv, depth+1, openedBytes)
 				// Prepend the current input path
 				for i := range subPackage {
 					subPackage[i].Locations = append([]string{input.Path}, subPackage[i].Locations...)
+					subPackage[i].LocationProvenance = append(nestedProvenance(prov), subPackage[i].LocationProvenance...)
 				}
 				if err != nil {
 					log.Errorf("%s failed to extract %q: %v", e.Name(), path, err)
@@ -366,15 +400,12 @@ func (e Extractor) extractWithMax(ctx context.Context, input *filesystem.ScanInp
 				}
 			}
 			packageFilename = append(packageFilename, &extractor.Package{
-				Name:     fmt.Sprintf("%s:%s", groupID, p.ArtifactID),
-				Version:  p.Version,
-				PURLType: purl.TypeMaven,
-				Metadata: &archivemeta.Metadata{
-					ArtifactID: p.ArtifactID,
-					GroupID:    groupID,
-					SHA1:       sha1,
-				},
-				Locations: []string{input.Path},
+				Name:               fmt.Sprintf("%s:%s", groupID, p.ArtifactID),
+				Version:            p.Version,
+				PURLType:           purl.TypeMaven,
+				Metadata:           metadata(groupID, p.ArtifactID),
+				Locations:          []string{input.Path},
+				LocationProvenance: nestedProvenance(prov),
 			})
 		}
 	}
@@ -385,17 +416,14 @@ func (e Extractor) extractWithMax(ctx context.Context, input *filesystem.ScanInp
 	}
 
 	// If nothing worked, return the hash.
-	if len(pkgs) == 0 && sha1 != "" {
+	if len(pkgs) == 0 && (sha1 != "" || fingerprint != "") {
 		pkgs = append(pkgs, &extractor.Package{
-			Name:     "unknown",
-			Version:  "unknown",
-			PURLType: purl.TypeMaven,
-			Metadata: &archivemeta.Metadata{
-				ArtifactID: "unknown",
-				GroupID:    "unknown",
-				SHA1:       sha1,
-			},
-			Locations: []string{input.Path},
+			Name:               "unknown",
+			Version:            "unknown",
+			PURLType:           purl.TypeMaven,
+			Metadata:           metadata("unknown", "unknown"),
+			Locations:          []string{input.Path},
+			LocationProvenance: nestedProvenance(prov),
 		})
 	}
 
@@ -408,11 +436,29 @@ func (e Extractor) extractWithMax(ctx context.Context, input *filesystem.ScanInp
 	return pkgs, openedBytes, err
 }
 
+// nestedProvenance returns copies of the provenance of the given locations
+// that are inside archives. The provenance of files on the scanned filesystem
+// only repeats their location so it's omitted.
+func nestedProvenance(provs ...*extractor.LocationProvenance) []*extractor.LocationProvenance {
+	var result []*extractor.LocationProvenance
+	for _, p := range provs {
+		if len(p.ArchiveChain) > 0 {
+			c := *p
+			c.ArchiveChain = slices.Clone(p.ArchiveChain)
+			result = append(result, &c)
+		}
+	}
+	return result
+}
+
 // hashJar returns base64(sha1()) of the file. This is compatible to dev.deps.
-func hashJar(r io.Reader) (string, error) {
+func hashJar(r io.Reader, cfg *hashing.Config) (string, error) {
 	// SHA1
-	hasher := sha1.New()
-	_, err := io.Copy(hasher, r)
+	hasher, err := cfg.New(hashing.SHA1)
+	if err != nil {
+		return "", err
+	}
+	_, err = io.Copy(hasher, r)
 	if err != nil {
 		return "", err
 	}
@@ -422,6 +468,56 @@ func hashJar(r io.Reader) (string, error) {
 	return base64.StdEncoding.EncodeToString(h), nil
 }
 
+// digestClasses returns the SHA-256 digests of the class files in the archive,
+// sorted by name, and a fingerprint over the sorted digests.
+func digestClasses(r *zip.Reader, cfg *hashing.Config) (string, []*archivemeta.ClassDigest, error) {
+	var digests []*archivemeta.ClassDigest
+	for _, file := range r.File {
+		if file.FileInfo().IsDir() || !strings.HasSuffix(file.Name, ".class") {
+			continue
+		}
+		d, err := digestEntry(file, cfg)
+		if err != nil {
+			return "", nil, fmt.Errorf("%s: %w", file.Name, err)
+		}
+		digests = append(digests, &archivemeta.ClassDigest{Name: file.Name, SHA256: d})
+	}
+	if len(digests) == 0 {
+		return "", nil, nil
+	}
+	slices.SortFunc(digests, func(a, b *archivemeta.ClassDigest) int { return strings.Compare(a.Name, b.Name) })
+
+	sorted := make([]string, 0, len(digests))
+	for _, d := range digests {
+		sorted = append(sorted, d.SHA256)
+	}
+	slices.Sort(sorted)
+	hasher, err := cfg.New(hashing.SHA256)
+	if err != nil {
+		return "", nil, err
+	}
+	for _, d := range sorted {
+		hasher.Write([]byte(d + "\n"))
+	}
+	return hex.EncodeToString(hasher.Sum(nil)), digests, nil
+}
+
+func digestEntry(file *zip.File, cfg *hashing.Config) (string, error) {
+	hasher, err := cfg.New(hashing.SHA256)
+	if err != nil {
+		return "", err
+	}
+	f, err := file.Open()
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	if _, err := io.Copy(hasher, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hasher.Sum(nil)), nil
+}
+
 // IsArchive returns true if the file path ends with one of the supported archive extensions.
 func IsArchive(path string) bool {
 	ext := filepath.Ext(path)
@@ -17,6 +17,7 @@ package archive_test
 import (
 	"archive/zip"
 	"context"
+	"crypto/sha256"
 	"errors"
 	"fmt"
 	"io"
@@ -613,7 +614,9 @@ func TestExtract(t *testing.T) {
 				t.Fatalf("Extract(%s) got error: %v, want error: %v", tt.path, err, tt.wantErr)
 			}
 			sort := func(a, b *extractor.Package) bool { return a.Name < b.Name }
-			if diff := cmp.Diff(inventory.Inventory{Packages: tt.want}, got, cmpopts.SortSlices(sort)); diff != "" {
+			// The location provenance is covered by TestExtractLocationProvenance.
+			ignoreProv := cmpopts.IgnoreFields(extractor.Package{}, "LocationProvenance")
+			if diff := cmp.Diff(inventory.Inventory{Packages: tt.want}, got, cmpopts.SortSlices(sort), ignoreProv); diff != "" {
 				t.Fatalf("Extract(%s) (-want +got):\n%s", tt.path, diff)
 			}
 
@@ -630,6 +633,167 @@ func TestExtract(t *testing.T) {
 	}
 }
 
+func TestExtractLocationProvenance(t *testing.T) {
+	path := filepath.FromSlash("testdata/complex.jar")
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("os.Open(%s): %v", path, err)
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		t.Fatalf("f.Stat(%s): %v", path, err)
+	}
+
+	e := archive.New(archive.DefaultConfig())
+	input := &filesystem.ScanInput{FS: scalibrfs.DirFS("."), Path: path, Info: info, Reader: f}
+	got, err := e.Extract(context.Background(), input)
+	if err != nil {
+		t.Fatalf("Extract(%s): %v", path, err)
+	}
+
+	want := map[string][]*extractor.LocationProvenance{
+		"com.some.package:package-name": {{
+			Location:     filepath.FromSlash("testdata/complex.jar/pom.properties"),
+			ArchiveChain: []string{path},
+			Path:         "pom.properties",
+		}},
+		"com.some.anotherpackage:another-package-name": {
+			{
+				Location:     filepath.FromSlash("testdata/complex.jar/BOOT-INF/lib/inner.jar"),
+				ArchiveChain: []string{path},
+				Path:         "BOOT-INF/lib/inner.jar",
+			},
+			{
+				Location:     filepath.FromSlash("testdata/complex.jar/BOOT-INF/lib/inner.jar/pom.properties"),
+				ArchiveChain: []string{path, "BOOT-INF/lib/inner.jar"},
+				Path:         "pom.properties",
+			},
+		},
+	}
+	gotProv := map[string][]*extractor.LocationProvenance{}
+	for _, pkg := range got.Packages {
+		gotProv[pkg.Name] = pkg.LocationProvenance
+	}
+	if diff := cmp.Diff(want, gotProv); diff != "" {
+		t.Errorf("Extract(%s) returned unexpected location provenance (-want +got):\n%s", path, diff)
+	}
+}
+
+func TestExtractDigestEntries(t *testing.T) {
+	classA := []byte("\xca\xfe\xba\xbe class A")
+	classB := []byte("\xca\xfe\xba\xbe class B")
+	digestA := fmt.Sprintf("%x", sha256.Sum256(classA))
+	digestB := fmt.Sprintf("%x", sha256.Sum256(classB))
+	wantFingerprint := fmt.Sprintf("%x", sha256.Sum256([]byte(min(digestA, digestB)+"\n"+max(digestA, digestB)+"\n")))
+
+	// The same classes, repackaged under different names with other resources
+	// and in a different order.
+	original := writeJar(t, "original-1.0.jar", []jarEntry{
+		{name: "META-INF/MANIFEST.MF", content: []byte("Manifest-Version: 1.0\n")},
+		{name: "com/example/A.class", content: classA},
+		{name: "com/example/B.class", content: classB},
+	})
+	repackaged := writeJar(t, "app-all", []jarEntry{
+		{name: "org/shaded/B.class", content: classB},
+		{name: "org/shaded/A.class", content: classA},
+		{name: "README.txt", content: []byte("hello")},
+	})
+
+	tests := []struct {
+		name string
+		path string
+		want *archivemeta.Metadata
+	}{
+		{
+			name: "original",
+			path: original,
+			want: &archivemeta.Metadata{
+				ArtifactID:  "original",
+				GroupID:     "original",
+				Fingerprint: wantFingerprint,
+				ClassDigests: []*archivemeta.ClassDigest{
+					{Name: "com/example/A.class", SHA256: digestA},
+					{Name: "com/example/B.class", SHA256: digestB},
+				},
+			},
+		},
+		{
+			name: "repackaged",
+			path: repackaged,
+			want: &archivemeta.Metadata{
+				ArtifactID:  "unknown",
+				GroupID:     "unknown",
+				Fingerprint: wantFingerprint,
+				ClassDigests: []*archivemeta.ClassDigest{
+					{Name: "org/shaded/A.class", SHA256: digestA},
+					{Name: "org/shaded/B.class", SHA256: digestB},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := os.Open(tt.path)
+			if err != nil {
+				t.Fatalf("os.Open(%s): %v", tt.path, err)
+			}
+			defer f.Close()
+			info, err := f.Stat()
+			if err != nil {
+				t.Fatalf("f.Stat(%s): %v", tt.path, err)
+			}
+
+			cfg := archive.DefaultConfig()
+			cfg.HashJars = false
+			cfg.DigestEntries = true
+			input := &filesystem.ScanInput{Path: tt.path, Info: info, Reader: f}
+			got, err := archive.New(cfg).Extract(context.Background(), input)
+			if err != nil {
+				t.Fatalf("Extract(%s): %v", tt.path, err)
+			}
+			if len(got.Packages) != 1 {
+				t.Fatalf("Extract(%s) returned %d packages, want 1", tt.path, len(got.Packages))
+			}
+			if diff := cmp.Diff(tt.want, got.Packages[0].Metadata); diff != "" {
+				t.Errorf("Extract(%s) returned unexpected metadata (-want +got):\n%s", tt.path, diff)
+			}
+		})
+	}
+}
+
+type jarEntry struct {
+	name    string
+	content []byte
+}
+
+// writeJar writes a jar file with the given entries into a temporary directory
+// and returns its path.
+func writeJar(t *testing.T, name string, entries []jarEntry) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("os.Create(%s): %v", path, err)
+	}
+	defer f.Close()
+	w := zip.NewWriter(f)
+	for _, e := range entries {
+		fw, err := w.Create(e.name)
+		if err != nil {
+			t.Fatalf("zip.Create(%s): %v", e.name, err)
+		}
+		if _, err := fw.Write(e.content); err != nil {
+			t.Fatalf("zip.Write(%s): %v", e.name, err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("zip.Close(): %v", err)
+	}
+	return path
+}
+
 type noReaderAt struct {
 	r io.Reader
 }
@@ -20,4 +20,20 @@ type Metadata struct {
 	ArtifactID string
 	GroupID    string
 	SHA1       string
+	// Hex-encoded SHA-256 over the sorted digests of the class files in the
+	// archive. It doesn't depend on the archive's name, entry order, timestamps
+	// or non-class resources, so repackaged copies of an artifact share it.
+	// Only set if the extractor is configured to digest archive entries.
+	Fingerprint string
+	// The digests of the class files in the archive, sorted by name. Only set
+	// if the extractor is configured to digest archive entries.
+	ClassDigests []*ClassDigest
+}
+
+// ClassDigest is the digest of a class file in a Java archive.
+type ClassDigest struct {
+	// The path of the class file inside the archive.
+	Name string
+	// Hex-encoded SHA-256 of the class file.
+	SHA256 string
 }
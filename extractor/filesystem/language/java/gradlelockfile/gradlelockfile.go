@@ -37,6 +37,10 @@ const (
 
 	gradleLockFileCommentPrefix = "#"
 	gradleLockFileEmptyPrefix   = "empty="
+
+	// testDepGroup is the dependency group of packages only used by tests,
+	// matching the Maven "test" scope.
+	testDepGroup = "test"
 )
 
 func isGradleLockFileDepLine(line string) bool {
@@ -56,19 +60,41 @@ func parseToGradlePackageDetail(line string) (*extractor.Package, error) {
 	if !strings.Contains(version, "=") {
 		return nil, fmt.Errorf("invalid line in gradle lockfile: %s", line)
 	}
-	version = strings.SplitN(version, "=", 2)[0]
+	version, configs, _ := strings.Cut(version, "=")
+
+	m := &javalockfile.Metadata{
+		ArtifactID: artifact,
+		GroupID:    group,
+	}
+	if configs != "" {
+		m.Configurations = strings.Split(configs, ",")
+	}
+	if isTestOnly(m.Configurations) {
+		m.DepGroupVals = []string{testDepGroup}
+	}
 
 	return &extractor.Package{
 		Name:     fmt.Sprintf("%s:%s", group, artifact),
 		Version:  version,
 		PURLType: purl.TypeMaven,
-		Metadata: &javalockfile.Metadata{
-			ArtifactID: artifact,
-			GroupID:    group,
-		},
+		Metadata: m,
 	}, nil
 }
 
+// isTestOnly returns true if all the given configurations belong to test
+// source sets, e.g. "testRuntimeClasspath" or "integrationTestCompileClasspath".
+func isTestOnly(configs []string) bool {
+	if len(configs) == 0 {
+		return false
+	}
+	for _, c := range configs {
+		if !strings.HasPrefix(c, "test") && !strings.Contains(c, "Test") {
+			return false
+		}
+	}
+	return true
+}
+
 // Extractor extracts Maven packages from Gradle files.
 type Extractor struct{}
 
@@ -118,8 +118,9 @@ func TestExtractor_Extract(t *testing.T) {
 					PURLType:  purl.TypeMaven,
 					Locations: []string{"testdata/one-pkg"},
 					Metadata: &javalockfile.Metadata{
-						ArtifactID: "spring-security-crypto",
-						GroupID:    "org.springframework.security",
+						ArtifactID:     "spring-security-crypto",
+						GroupID:        "org.springframework.security",
+						Configurations: []string{"compileClasspath", "productionRuntimeClasspath", "runtimeClasspath"},
 					},
 				},
 			},
@@ -136,8 +137,9 @@ func TestExtractor_Extract(t *testing.T) {
 					PURLType:  purl.TypeMaven,
 					Locations: []string{"testdata/5-pkg"},
 					Metadata: &javalockfile.Metadata{
-						ArtifactID: "spring-boot-autoconfigure",
-						GroupID:    "org.springframework.boot",
+						ArtifactID:     "spring-boot-autoconfigure",
+						GroupID:        "org.springframework.boot",
+						Configurations: []string{"compileClasspath", "developmentOnly", "productionRuntimeClasspath", "runtimeClasspath"},
 					},
 				},
 				{
@@ -146,8 +148,9 @@ func TestExtractor_Extract(t *testing.T) {
 					PURLType:  purl.TypeMaven,
 					Locations: []string{"testdata/5-pkg"},
 					Metadata: &javalockfile.Metadata{
-						ArtifactID: "spring-boot-configuration-processor",
-						GroupID:    "org.springframework.boot",
+						ArtifactID:     "spring-boot-configuration-processor",
+						GroupID:        "org.springframework.boot",
+						Configurations: []string{"annotationProcessor", "compileClasspath"},
 					},
 				},
 				{
@@ -156,8 +159,9 @@ func TestExtractor_Extract(t *testing.T) {
 					PURLType:  purl.TypeMaven,
 					Locations: []string{"testdata/5-pkg"},
 					Metadata: &javalockfile.Metadata{
-						ArtifactID: "spring-boot-devtools",
-						GroupID:    "org.springframework.boot",
+						ArtifactID:     "spring-boot-devtools",
+						GroupID:        "org.springframework.boot",
+						Configurations: []string{"developmentOnly", "runtimeClasspath"},
 					},
 				},
 				{
@@ -166,8 +170,9 @@ func TestExtractor_Extract(t *testing.T) {
 					PURLType:  purl.TypeMaven,
 					Locations: []string{"testdata/5-pkg"},
 					Metadata: &javalockfile.Metadata{
-						ArtifactID: "spring-boot-starter-aop",
-						GroupID:    "org.springframework.boot",
+						ArtifactID:     "spring-boot-starter-aop",
+						GroupID:        "org.springframework.boot",
+						Configurations: []string{"compileClasspath", "productionRuntimeClasspath", "runtimeClasspath"},
 					},
 				},
 				{
@@ -176,8 +181,9 @@ func TestExtractor_Extract(t *testing.T) {
 					PURLType:  purl.TypeMaven,
 					Locations: []string{"testdata/5-pkg"},
 					Metadata: &javalockfile.Metadata{
-						ArtifactID: "spring-boot-starter-data-jpa",
-						GroupID:    "org.springframework.boot",
+						ArtifactID:     "spring-boot-starter-data-jpa",
+						GroupID:        "org.springframework.boot",
+						Configurations: []string{"compileClasspath", "productionRuntimeClasspath", "runtimeClasspath"},
 					},
 				},
 			},
@@ -194,8 +200,9 @@ func TestExtractor_Extract(t *testing.T) {
 					PURLType:  purl.TypeMaven,
 					Locations: []string{"testdata/with-bad-pkg"},
 					Metadata: &javalockfile.Metadata{
-						ArtifactID: "spring-boot-autoconfigure",
-						GroupID:    "org.springframework.boot",
+						ArtifactID:     "spring-boot-autoconfigure",
+						GroupID:        "org.springframework.boot",
+						Configurations: []string{"compileClasspath", "developmentOnly", "productionRuntimeClasspath", "runtimeClasspath"},
 					},
 				},
 				{
@@ -204,8 +211,64 @@ func TestExtractor_Extract(t *testing.T) {
 					PURLType:  purl.TypeMaven,
 					Locations: []string{"testdata/with-bad-pkg"},
 					Metadata: &javalockfile.Metadata{
-						ArtifactID: "spring-boot-configuration-processor",
-						GroupID:    "org.springframework.boot",
+						ArtifactID:     "spring-boot-configuration-processor",
+						GroupID:        "org.springframework.boot",
+						Configurations: []string{"compileClasspath", "developmentOnly", "productionRuntimeClasspath", "runtimeClasspath"},
+					},
+				},
+			},
+		},
+		{
+			Name: "test only dependencies",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/test-scopes",
+			},
+			WantPackages: []*extractor.Package{
+				{
+					Name:      "com.google.guava:guava",
+					Version:   "32.1.2-jre",
+					PURLType:  purl.TypeMaven,
+					Locations: []string{"testdata/test-scopes"},
+					Metadata: &javalockfile.Metadata{
+						ArtifactID:     "guava",
+						GroupID:        "com.google.guava",
+						Configurations: []string{"compileClasspath", "runtimeClasspath", "testCompileClasspath", "testRuntimeClasspath"},
+					},
+				},
+				{
+					Name:      "junit:junit",
+					Version:   "4.13.2",
+					PURLType:  purl.TypeMaven,
+					Locations: []string{"testdata/test-scopes"},
+					Metadata: &javalockfile.Metadata{
+						ArtifactID:     "junit",
+						GroupID:        "junit",
+						DepGroupVals:   []string{"test"},
+						Configurations: []string{"testCompileClasspath", "testRuntimeClasspath"},
+					},
+				},
+				{
+					Name:      "org.testcontainers:postgresql",
+					Version:   "1.19.0",
+					PURLType:  purl.TypeMaven,
+					Locations: []string{"testdata/test-scopes"},
+					Metadata: &javalockfile.Metadata{
+						ArtifactID:     "postgresql",
+						GroupID:        "org.testcontainers",
+						DepGroupVals:   []string{"test"},
+						Configurations: []string{"integrationTestCompileClasspath", "integrationTestRuntimeClasspath"},
+					},
+				},
+				{
+					Name:      "org.hamcrest:hamcrest-core",
+					Version:   "1.3",
+					PURLType:  purl.TypeMaven,
+					Locations: []string{"testdata/test-scopes"},
+					Metadata: &javalockfile.Metadata{
+						ArtifactID:     "hamcrest-core",
+						GroupID:        "org.hamcrest",
+						DepGroupVals:   []string{"test"},
+						Configurations: []string{"testRuntimeClasspath"},
 					},
 				},
 			},
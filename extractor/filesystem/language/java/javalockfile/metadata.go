@@ -23,6 +23,9 @@ type Metadata struct {
 	Classifier   string
 	DepGroupVals []string
 	IsTransitive bool // Only set in pomxmlnet extractor
+	// The Gradle configurations that resolve the package, e.g. "runtimeClasspath".
+	// Only set in gradlelockfile extractor.
+	Configurations []string
 }
 
 // DepGroups returns the dependency groups for the package.
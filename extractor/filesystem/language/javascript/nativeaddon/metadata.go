@@ -0,0 +1,111 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nativeaddon
+
+import (
+	pb "github.com/google/osv-scalibr/binary/proto/scan_result_go_proto"
+)
+
+// Binary formats of native addons and prebuilt libraries.
+const (
+	FormatELF   = "elf"
+	FormatMachO = "macho"
+	FormatPE    = "pe"
+)
+
+// ABINodeAPI is the ABI of addons built against Node-API, which is stable
+// across Node.js versions.
+const ABINodeAPI = "napi"
+
+// Metadata holds the details of a compiled Node.js native addon or a prebuilt
+// shared library shipped in an npm package.
+type Metadata struct {
+	// PackageName is the name of the npm package that contains the file.
+	PackageName string `json:"packageName,omitempty"`
+	// PackageVersion is the version of the npm package that contains the file.
+	PackageVersion string `json:"packageVersion,omitempty"`
+	// Format is the binary format, e.g. "elf".
+	Format string `json:"format"`
+	// Architecture is the target CPU architecture, e.g. "x86_64".
+	Architecture string `json:"architecture,omitempty"`
+	// ABI is the Node.js ABI the addon was built for: "napi" for Node-API addons
+	// or "node-abi-<NODE_MODULE_VERSION>" for addons built against the V8 API
+	// of a specific Node.js version. Empty for libraries that aren't addons.
+	ABI string `json:"abi,omitempty"`
+	// LinkedLibraries are the shared libraries the file is dynamically linked against.
+	LinkedLibraries []string `json:"linkedLibraries,omitempty"`
+	// EmbeddedLibraries are the libraries compiled into the file whose versions
+	// could be determined from their version strings.
+	EmbeddedLibraries []*EmbeddedLibrary `json:"embeddedLibraries,omitempty"`
+}
+
+// EmbeddedLibrary is a library statically compiled into a native addon.
+type EmbeddedLibrary struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// SetProto sets the NodeNativeAddonMetadata field in the Package proto.
+func (m *Metadata) SetProto(p *pb.Package) {
+	if m == nil {
+		return
+	}
+	if p == nil {
+		return
+	}
+
+	var libs []*pb.NodeNativeAddonMetadata_EmbeddedLibrary
+	for _, l := range m.EmbeddedLibraries {
+		libs = append(libs, &pb.NodeNativeAddonMetadata_EmbeddedLibrary{
+			Name:    l.Name,
+			Version: l.Version,
+		})
+	}
+	p.Metadata = &pb.Package_NodeNativeAddonMetadata{
+		NodeNativeAddonMetadata: &pb.NodeNativeAddonMetadata{
+			PackageName:       m.PackageName,
+			PackageVersion:    m.PackageVersion,
+			Format:            m.Format,
+			Architecture:      m.Architecture,
+			Abi:               m.ABI,
+			LinkedLibraries:   m.LinkedLibraries,
+			EmbeddedLibraries: libs,
+		},
+	}
+}
+
+// ToStruct converts the NodeNativeAddonMetadata proto to a Metadata struct.
+func ToStruct(m *pb.NodeNativeAddonMetadata) *Metadata {
+	if m == nil {
+		return nil
+	}
+
+	var libs []*EmbeddedLibrary
+	for _, l := range m.GetEmbeddedLibraries() {
+		libs = append(libs, &EmbeddedLibrary{
+			Name:    l.GetName(),
+			Version: l.GetVersion(),
+		})
+	}
+	return &Metadata{
+		PackageName:       m.GetPackageName(),
+		PackageVersion:    m.GetPackageVersion(),
+		Format:            m.GetFormat(),
+		Architecture:      m.GetArchitecture(),
+		ABI:               m.GetAbi(),
+		LinkedLibraries:   m.GetLinkedLibraries(),
+		EmbeddedLibraries: libs,
+	}
+}
@@ -0,0 +1,394 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package nativeaddon extracts compiled Node.js native addons (.node files) and
+// prebuilt shared libraries from node_modules directories. Vulnerabilities in
+// the native code of addons such as sharp (libvips) or sqlite3 can't be found
+// from the npm metadata alone, so the extractor records the target ABI, the
+// linked libraries and the versions of well-known embedded libraries.
+package nativeaddon
+
+import (
+	"bytes"
+	"context"
+	"debug/elf"
+	"debug/macho"
+	"debug/pe"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/extractor/filesystem/internal/units"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/plugin"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scalibr/stats"
+)
+
+const (
+	// Name is the unique name of this extractor.
+	Name = "javascript/nativeaddon"
+
+	nodeModulesDir = "node_modules/"
+)
+
+var (
+	// sharedLibRe matches the file names of shared libraries, e.g.
+	// "libvips-cpp.so.42", "libvips-cpp.8.15.2.dylib" or "libvips-42.dll".
+	sharedLibRe = regexp.MustCompile(`(?i)\.(so(\.\d+)*|dylib|dll)$`)
+
+	// Symbols exported by native addons to register themselves with Node.js.
+	napiRegisterRe = regexp.MustCompile(`napi_register_module_v\d+`)
+	nodeRegisterRe = regexp.MustCompile(`node_register_module_v(\d+)`)
+
+	// embeddedLibraries are the version strings of libraries that are commonly
+	// compiled into native addons.
+	embeddedLibraries = []struct {
+		name string
+		re   *regexp.Regexp
+	}{
+		{"openssl", regexp.MustCompile(`OpenSSL (\d+\.\d+\.\d+[a-z]?)\s`)},
+		{"zlib", regexp.MustCompile(`(?:deflate|inflate) (\d+\.\d+(?:\.\d+)*) Copyright`)},
+		{"libpng", regexp.MustCompile(`libpng version (\d+\.\d+\.\d+)`)},
+		{"libjpeg-turbo", regexp.MustCompile(`libjpeg-turbo version (\d+\.\d+\.\d+)`)},
+		{"expat", regexp.MustCompile(`expat_(\d+\.\d+\.\d+)`)},
+		{"libvips", regexp.MustCompile(`libvips (\d+\.\d+\.\d+)`)},
+	}
+
+	machOMagics = [][]byte{
+		{0xfe, 0xed, 0xfa, 0xce}, {0xce, 0xfa, 0xed, 0xfe},
+		{0xfe, 0xed, 0xfa, 0xcf}, {0xcf, 0xfa, 0xed, 0xfe},
+	}
+	machOFatMagic = []byte{0xca, 0xfe, 0xba, 0xbe}
+)
+
+// Config is the configuration for the native addon extractor.
+type Config struct {
+	// Stats is a stats collector for reporting metrics.
+	Stats stats.Collector
+	// MaxFileSizeBytes is the maximum file size this extractor will read. If
+	// `FileRequired` gets a bigger file, it will return false.
+	MaxFileSizeBytes int64
+}
+
+// DefaultConfig returns the default configuration for the native addon extractor.
+func DefaultConfig() Config {
+	return Config{
+		Stats:            nil,
+		MaxFileSizeBytes: 100 * units.MiB,
+	}
+}
+
+// Extractor extracts native addons and prebuilt libraries from npm packages.
+type Extractor struct {
+	stats            stats.Collector
+	maxFileSizeBytes int64
+}
+
+// New returns a native addon extractor.
+//
+// For most use cases, initialize with:
+// ```
+// e := New(DefaultConfig())
+// ```
+func New(cfg Config) *Extractor {
+	return &Extractor{
+		stats:            cfg.Stats,
+		maxFileSizeBytes: cfg.MaxFileSizeBytes,
+	}
+}
+
+// NewDefault returns an extractor with the default config settings.
+func NewDefault() filesystem.Extractor {
+	return New(DefaultConfig())
+}
+
+// Name of the extractor.
+func (e Extractor) Name() string { return Name }
+
+// Version of the extractor.
+func (e Extractor) Version() int { return 0 }
+
+// Requirements of the extractor.
+func (e Extractor) Requirements() *plugin.Capabilities { return &plugin.Capabilities{} }
+
+// FileRequired returns true if the file is a native addon or a shared library
+// inside an npm package.
+func (e Extractor) FileRequired(api filesystem.FileAPI) bool {
+	p := filepath.ToSlash(api.Path())
+	if !strings.Contains(p, nodeModulesDir) {
+		return false
+	}
+	base := path.Base(p)
+	if !strings.HasSuffix(strings.ToLower(base), ".node") && !sharedLibRe.MatchString(base) {
+		return false
+	}
+	if name, _ := owningPackage(p); name == "" {
+		return false
+	}
+
+	fileinfo, err := api.Stat()
+	if err != nil || !fileinfo.Mode().IsRegular() {
+		return false
+	}
+
+	if e.maxFileSizeBytes > 0 && fileinfo.Size() > e.maxFileSizeBytes {
+		e.reportFileRequired(p, fileinfo.Size(), stats.FileRequiredResultSizeLimitExceeded)
+		return false
+	}
+
+	e.reportFileRequired(p, fileinfo.Size(), stats.FileRequiredResultOK)
+	return true
+}
+
+func (e Extractor) reportFileRequired(path string, fileSizeBytes int64, result stats.FileRequiredResult) {
+	if e.stats == nil {
+		return
+	}
+	e.stats.AfterFileRequired(e.Name(), &stats.FileRequiredStats{
+		Path:          path,
+		Result:        result,
+		FileSizeBytes: fileSizeBytes,
+	})
+}
+
+// Extract parses the native addon or library and returns it as a package.
+func (e Extractor) Extract(ctx context.Context, input *filesystem.ScanInput) (inventory.Inventory, error) {
+	pkg, err := e.extractFromInput(input)
+	if e.stats != nil {
+		var fileSizeBytes int64
+		if input.Info != nil {
+			fileSizeBytes = input.Info.Size()
+		}
+		e.stats.AfterFileExtracted(e.Name(), &stats.FileExtractedStats{
+			Path:          input.Path,
+			Result:        filesystem.ExtractorErrorToFileExtractedResult(err),
+			FileSizeBytes: fileSizeBytes,
+		})
+	}
+	if err != nil {
+		return inventory.Inventory{}, fmt.Errorf("%s halted at %q: %w", e.Name(), input.Path, err)
+	}
+	if pkg == nil {
+		return inventory.Inventory{}, nil
+	}
+	return inventory.Inventory{Packages: []*extractor.Package{pkg}}, nil
+}
+
+func (e Extractor) extractFromInput(input *filesystem.ScanInput) (*extractor.Package, error) {
+	r := input.Reader
+	if e.maxFileSizeBytes > 0 {
+		r = io.LimitReader(r, e.maxFileSizeBytes)
+	}
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	m, err := parseBinary(data)
+	if err != nil {
+		return nil, err
+	}
+	if m == nil {
+		// Not a binary, e.g. a linker script named like a shared library.
+		return nil, nil
+	}
+	m.ABI = abi(data)
+	m.EmbeddedLibraries = findEmbeddedLibraries(data)
+
+	p := filepath.ToSlash(input.Path)
+	var dir string
+	m.PackageName, dir = owningPackage(p)
+	m.PackageVersion = packageVersion(input.FS, dir)
+
+	return &extractor.Package{
+		Name:      path.Base(p),
+		Version:   m.PackageVersion,
+		PURLType:  purl.TypeGeneric,
+		Metadata:  m,
+		Locations: []string{input.Path},
+	}, nil
+}
+
+// parseBinary returns the format, architecture and linked libraries of the
+// given ELF, Mach-O or PE file, or nil if it's not in one of these formats.
+func parseBinary(data []byte) (*Metadata, error) {
+	r := bytes.NewReader(data)
+	switch {
+	case bytes.HasPrefix(data, []byte(elf.ELFMAG)):
+		f, err := elf.NewFile(r)
+		if err != nil {
+			return nil, fmt.Errorf("elf.NewFile: %w", err)
+		}
+		defer f.Close()
+		// Statically linked files have no dynamic section.
+		libs, _ := f.ImportedLibraries()
+		return &Metadata{Format: FormatELF, Architecture: elfArch(f.Machine), LinkedLibraries: libs}, nil
+	case bytes.HasPrefix(data, machOFatMagic):
+		ff, err := macho.NewFatFile(r)
+		if err != nil {
+			// Java class files share the magic bytes of universal binaries.
+			return nil, nil
+		}
+		defer ff.Close()
+		var archs []string
+		var libs []string
+		for _, a := range ff.Arches {
+			archs = append(archs, machOArch(a.Cpu))
+			if libs == nil {
+				libs, _ = a.ImportedLibraries()
+			}
+		}
+		return &Metadata{Format: FormatMachO, Architecture: strings.Join(archs, ","), LinkedLibraries: libs}, nil
+	case hasMachOMagic(data):
+		f, err := macho.NewFile(r)
+		if err != nil {
+			return nil, fmt.Errorf("macho.NewFile: %w", err)
+		}
+		defer f.Close()
+		libs, _ := f.ImportedLibraries()
+		return &Metadata{Format: FormatMachO, Architecture: machOArch(f.Cpu), LinkedLibraries: libs}, nil
+	case bytes.HasPrefix(data, []byte("MZ")):
+		f, err := pe.NewFile(r)
+		if err != nil {
+			return nil, fmt.Errorf("pe.NewFile: %w", err)
+		}
+		defer f.Close()
+		libs, _ := f.ImportedLibraries()
+		return &Metadata{Format: FormatPE, Architecture: peArch(f.Machine), LinkedLibraries: libs}, nil
+	default:
+		return nil, nil
+	}
+}
+
+func hasMachOMagic(data []byte) bool {
+	for _, magic := range machOMagics {
+		if bytes.HasPrefix(data, magic) {
+			return true
+		}
+	}
+	return false
+}
+
+func elfArch(m elf.Machine) string {
+	switch m {
+	case elf.EM_X86_64:
+		return "x86_64"
+	case elf.EM_AARCH64:
+		return "arm64"
+	case elf.EM_386:
+		return "x86"
+	case elf.EM_ARM:
+		return "arm"
+	default:
+		return strings.ToLower(strings.TrimPrefix(m.String(), "EM_"))
+	}
+}
+
+func machOArch(c macho.Cpu) string {
+	switch c {
+	case macho.CpuAmd64:
+		return "x86_64"
+	case macho.CpuArm64:
+		return "arm64"
+	case macho.Cpu386:
+		return "x86"
+	case macho.CpuArm:
+		return "arm"
+	default:
+		return strings.ToLower(strings.TrimPrefix(c.String(), "Cpu"))
+	}
+}
+
+func peArch(m uint16) string {
+	switch m {
+	case pe.IMAGE_FILE_MACHINE_AMD64:
+		return "x86_64"
+	case pe.IMAGE_FILE_MACHINE_ARM64:
+		return "arm64"
+	case pe.IMAGE_FILE_MACHINE_I386:
+		return "x86"
+	default:
+		return fmt.Sprintf("0x%04x", m)
+	}
+}
+
+// abi returns the Node.js ABI from the symbol the addon registers itself with.
+func abi(data []byte) string {
+	if napiRegisterRe.Match(data) {
+		return ABINodeAPI
+	}
+	if m := nodeRegisterRe.FindSubmatch(data); m != nil {
+		return "node-abi-" + string(m[1])
+	}
+	return ""
+}
+
+func findEmbeddedLibraries(data []byte) []*EmbeddedLibrary {
+	var result []*EmbeddedLibrary
+	for _, lib := range embeddedLibraries {
+		if m := lib.re.FindSubmatch(data); m != nil {
+			result = append(result, &EmbeddedLibrary{Name: lib.name, Version: string(m[1])})
+		}
+	}
+	return result
+}
+
+// owningPackage returns the name and directory of the npm package that
+// contains the file at the given path, e.g. "@img/sharp-linux-x64" and
+// "node_modules/@img/sharp-linux-x64". Returns empty strings if the file isn't
+// inside a package.
+func owningPackage(p string) (name string, dir string) {
+	idx := strings.LastIndex(p, nodeModulesDir)
+	if idx < 0 || (idx > 0 && p[idx-1] != '/') {
+		return "", ""
+	}
+	parts := strings.Split(p[idx+len(nodeModulesDir):], "/")
+	n := 1
+	if strings.HasPrefix(parts[0], "@") {
+		n = 2
+	}
+	// The last part is the file itself.
+	if len(parts) <= n {
+		return "", ""
+	}
+	name = strings.Join(parts[:n], "/")
+	return name, p[:idx+len(nodeModulesDir)] + name
+}
+
+// packageVersion returns the version from the package.json of the npm package
+// in the given directory, or an empty string if it can't be read.
+func packageVersion(fsys fs.FS, dir string) string {
+	if fsys == nil || dir == "" {
+		return ""
+	}
+	content, err := fs.ReadFile(fsys, path.Join(dir, "package.json"))
+	if err != nil {
+		return ""
+	}
+	var pkgJSON struct {
+		Version string `json:"version"`
+	}
+	if err := json.Unmarshal(content, &pkgJSON); err != nil {
+		return ""
+	}
+	return pkgJSON.Version
+}
@@ -0,0 +1,233 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nativeaddon_test
+
+import (
+	"context"
+	"io/fs"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/extractor/filesystem/internal/units"
+	"github.com/google/osv-scalibr/extractor/filesystem/language/javascript/nativeaddon"
+	"github.com/google/osv-scalibr/extractor/filesystem/simplefileapi"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scalibr/stats"
+	"github.com/google/osv-scalibr/testing/extracttest"
+	"github.com/google/osv-scalibr/testing/fakefs"
+	"github.com/google/osv-scalibr/testing/testcollector"
+)
+
+func TestFileRequired(t *testing.T) {
+	tests := []struct {
+		name             string
+		path             string
+		fileSizeBytes    int64
+		maxFileSizeBytes int64
+		wantRequired     bool
+		wantResultMetric stats.FileRequiredResult
+	}{
+		{
+			name:             "native_addon",
+			path:             "app/node_modules/sharp/build/Release/sharp-linux-x64.node",
+			wantRequired:     true,
+			wantResultMetric: stats.FileRequiredResultOK,
+		},
+		{
+			name:             "scoped_package_prebuild",
+			path:             "node_modules/@scope/pkg/prebuilds/darwin-arm64/pkg.NODE",
+			wantRequired:     true,
+			wantResultMetric: stats.FileRequiredResultOK,
+		},
+		{
+			name:             "versioned_shared_library",
+			path:             "node_modules/@img/sharp-libvips-linux-x64/lib/libvips-cpp.so.42",
+			wantRequired:     true,
+			wantResultMetric: stats.FileRequiredResultOK,
+		},
+		{
+			name:             "dylib",
+			path:             "node_modules/@img/sharp-libvips-darwin-arm64/lib/libvips-cpp.8.15.2.dylib",
+			wantRequired:     true,
+			wantResultMetric: stats.FileRequiredResultOK,
+		},
+		{
+			name:             "dll",
+			path:             "node_modules/@img/sharp-win32-x64/lib/libvips-42.dll",
+			wantRequired:     true,
+			wantResultMetric: stats.FileRequiredResultOK,
+		},
+		{
+			name:         "addon_outside_node_modules",
+			path:         "build/Release/addon.node",
+			wantRequired: false,
+		},
+		{
+			name:         "file_directly_in_node_modules",
+			path:         "node_modules/addon.node",
+			wantRequired: false,
+		},
+		{
+			name:         "dir_named_like_node_modules",
+			path:         "my_node_modules/pkg/addon.node",
+			wantRequired: false,
+		},
+		{
+			name:         "javascript_file",
+			path:         "node_modules/sharp/lib/index.js",
+			wantRequired: false,
+		},
+		{
+			name:             "file_size_greater_than_max_size",
+			path:             "node_modules/sharp/build/Release/sharp.node",
+			fileSizeBytes:    1000 * units.KiB,
+			maxFileSizeBytes: 100 * units.KiB,
+			wantRequired:     false,
+			wantResultMetric: stats.FileRequiredResultSizeLimitExceeded,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			collector := testcollector.New()
+			var e filesystem.Extractor = nativeaddon.New(nativeaddon.Config{
+				Stats:            collector,
+				MaxFileSizeBytes: tt.maxFileSizeBytes,
+			})
+
+			fileSizeBytes := tt.fileSizeBytes
+			if fileSizeBytes == 0 {
+				fileSizeBytes = 1000
+			}
+
+			isRequired := e.FileRequired(simplefileapi.New(tt.path, fakefs.FakeFileInfo{
+				FileName: filepath.Base(tt.path),
+				FileMode: fs.ModePerm,
+				FileSize: fileSizeBytes,
+			}))
+			if isRequired != tt.wantRequired {
+				t.Fatalf("FileRequired(%s): got %v, want %v", tt.path, isRequired, tt.wantRequired)
+			}
+
+			gotResultMetric := collector.FileRequiredResult(tt.path)
+			if tt.wantResultMetric != "" && gotResultMetric != tt.wantResultMetric {
+				t.Errorf("FileRequired(%s) recorded result metric %v, want result metric %v", tt.path, gotResultMetric, tt.wantResultMetric)
+			}
+		})
+	}
+}
+
+func TestExtract(t *testing.T) {
+	tests := []extracttest.TestTableEntry{
+		{
+			Name: "napi_addon_with_embedded_libraries",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/node_modules/addon-napi/build/Release/addon.node",
+			},
+			WantPackages: []*extractor.Package{{
+				Name:     "addon.node",
+				Version:  "1.2.3",
+				PURLType: purl.TypeGeneric,
+				Metadata: &nativeaddon.Metadata{
+					PackageName:     "addon-napi",
+					PackageVersion:  "1.2.3",
+					Format:          nativeaddon.FormatELF,
+					Architecture:    "x86_64",
+					ABI:             nativeaddon.ABINodeAPI,
+					LinkedLibraries: []string{"libm.so.6", "libc.so.6"},
+					EmbeddedLibraries: []*nativeaddon.EmbeddedLibrary{
+						{Name: "openssl", Version: "3.0.13"},
+						{Name: "zlib", Version: "1.3.1"},
+					},
+				},
+				Locations: []string{"testdata/node_modules/addon-napi/build/Release/addon.node"},
+			}},
+		},
+		{
+			Name: "addon_built_for_node_abi",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/node_modules/@scope/legacy/prebuilds/linux-x64/legacy.node",
+			},
+			WantPackages: []*extractor.Package{{
+				Name:     "legacy.node",
+				Version:  "0.4.0",
+				PURLType: purl.TypeGeneric,
+				Metadata: &nativeaddon.Metadata{
+					PackageName:    "@scope/legacy",
+					PackageVersion: "0.4.0",
+					Format:         nativeaddon.FormatELF,
+					Architecture:   "x86_64",
+					ABI:            "node-abi-115",
+				},
+				Locations: []string{"testdata/node_modules/@scope/legacy/prebuilds/linux-x64/legacy.node"},
+			}},
+		},
+		{
+			Name: "prebuilt_shared_library",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/node_modules/@img/sharp-libvips-linux-x64/lib/libvips-cpp.so.42",
+			},
+			WantPackages: []*extractor.Package{{
+				Name:     "libvips-cpp.so.42",
+				Version:  "1.0.2",
+				PURLType: purl.TypeGeneric,
+				Metadata: &nativeaddon.Metadata{
+					PackageName:    "@img/sharp-libvips-linux-x64",
+					PackageVersion: "1.0.2",
+					Format:         nativeaddon.FormatELF,
+					Architecture:   "x86_64",
+					EmbeddedLibraries: []*nativeaddon.EmbeddedLibrary{
+						{Name: "libpng", Version: "1.6.43"},
+						{Name: "libvips", Version: "8.15.2"},
+					},
+				},
+				Locations: []string{"testdata/node_modules/@img/sharp-libvips-linux-x64/lib/libvips-cpp.so.42"},
+			}},
+		},
+		{
+			Name: "linker_script",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/node_modules/not-binary/libfoo.so.1",
+			},
+			WantPackages: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			var e filesystem.Extractor = nativeaddon.NewDefault()
+
+			scanInput := extracttest.GenerateScanInputMock(t, tt.InputConfig)
+			defer extracttest.CloseTestScanInput(t, scanInput)
+
+			got, err := e.Extract(context.Background(), &scanInput)
+
+			if diff := cmp.Diff(tt.WantErr, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("%s.Extract(%q) error diff (-want +got):\n%s", e.Name(), tt.InputConfig.Path, diff)
+				return
+			}
+
+			want := inventory.Inventory{Packages: tt.WantPackages}
+			if diff := cmp.Diff(want, got, cmpopts.SortSlices(extracttest.PackageCmpLess)); diff != "" {
+				t.Errorf("%s.Extract(%q) diff (-want +got):\n%s", e.Name(), tt.InputConfig.Path, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package npmtarball
+
+import (
+	pb "github.com/google/osv-scalibr/binary/proto/scan_result_go_proto"
+)
+
+// Metadata holds the digests of an npm package tarball. They can be compared
+// with the "dist" fields of the package version on the npm registry.
+type Metadata struct {
+	// Shasum is the hex-encoded SHA-1 of the tarball.
+	Shasum string `json:"shasum"`
+	// Integrity is the Subresource Integrity string of the tarball, e.g. "sha512-...".
+	Integrity string `json:"integrity"`
+}
+
+// SetProto sets the NpmTarballMetadata field in the Package proto.
+func (m *Metadata) SetProto(p *pb.Package) {
+	if m == nil {
+		return
+	}
+	if p == nil {
+		return
+	}
+
+	p.Metadata = &pb.Package_NpmTarballMetadata{
+		NpmTarballMetadata: &pb.NpmTarballMetadata{
+			Shasum:    m.Shasum,
+			Integrity: m.Integrity,
+		},
+	}
+}
+
+// ToStruct converts the NpmTarballMetadata proto to a Metadata struct.
+func ToStruct(m *pb.NpmTarballMetadata) *Metadata {
+	if m == nil {
+		return nil
+	}
+
+	return &Metadata{
+		Shasum:    m.GetShasum(),
+		Integrity: m.GetIntegrity(),
+	}
+}
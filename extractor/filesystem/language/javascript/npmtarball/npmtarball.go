@@ -0,0 +1,265 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package npmtarball extracts packages from npm package tarballs, e.g. the
+// output of `npm pack` or tarballs in vendor directories and offline mirrors.
+package npmtarball
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/extractor/filesystem/internal/units"
+	scalibrfs "github.com/google/osv-scalibr/fs"
+	"github.com/google/osv-scalibr/hashing"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/plugin"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scalibr/stats"
+)
+
+const (
+	// Name is the unique name of this extractor.
+	Name = "javascript/npmtarball"
+
+	// maxManifestSizeBytes is the maximum size of the package.json read from a tarball.
+	maxManifestSizeBytes = 1 * units.MiB
+)
+
+// tarballNameRe matches the file names of npm tarballs, e.g. "left-pad-1.3.0.tgz"
+// or "types-node-20.1.0.tgz" for scoped packages.
+var tarballNameRe = regexp.MustCompile(`^.+-\d+\.\d+\.\d+.*\.tgz$`)
+
+// Config is the configuration for the npm tarball extractor.
+type Config struct {
+	// Stats is a stats collector for reporting metrics.
+	Stats stats.Collector
+	// MaxFileSizeBytes is the maximum tarball size this extractor will open. If
+	// `FileRequired` gets a bigger file, it will return false,
+	MaxFileSizeBytes int64
+	// Hashing restricts the digest algorithms the extractor may use, e.g. in FIPS mode.
+	// If nil, no restrictions are applied.
+	Hashing *hashing.Config
+}
+
+// DefaultConfig returns the default configuration for the npm tarball extractor.
+func DefaultConfig() Config {
+	return Config{
+		Stats:            nil,
+		MaxFileSizeBytes: 100 * units.MiB,
+	}
+}
+
+// Extractor extracts npm packages from package tarballs and computes the
+// digests the npm registry publishes for them.
+type Extractor struct {
+	stats            stats.Collector
+	maxFileSizeBytes int64
+	hashing          *hashing.Config
+}
+
+// New returns an npm tarball extractor.
+//
+// For most use cases, initialize with:
+// ```
+// e := New(DefaultConfig())
+// ```
+func New(cfg Config) *Extractor {
+	return &Extractor{
+		stats:            cfg.Stats,
+		maxFileSizeBytes: cfg.MaxFileSizeBytes,
+		hashing:          cfg.Hashing,
+	}
+}
+
+// NewDefault returns an extractor with the default config settings.
+func NewDefault() filesystem.Extractor {
+	return New(DefaultConfig())
+}
+
+// SetHashingConfig sets the hashing config used when hashing tarballs.
+func (e *Extractor) SetHashingConfig(cfg *hashing.Config) { e.hashing = cfg }
+
+// Name of the extractor.
+func (e Extractor) Name() string { return Name }
+
+// Version of the extractor.
+func (e Extractor) Version() int { return 0 }
+
+// Requirements of the extractor.
+func (e Extractor) Requirements() *plugin.Capabilities { return &plugin.Capabilities{} }
+
+// FileRequired returns true if the file name looks like an npm package tarball.
+func (e Extractor) FileRequired(api filesystem.FileAPI) bool {
+	p := api.Path()
+	if !tarballNameRe.MatchString(path.Base(p)) {
+		return false
+	}
+
+	fileinfo, err := api.Stat()
+	if err != nil {
+		return false
+	}
+
+	if e.maxFileSizeBytes > 0 && fileinfo.Size() > e.maxFileSizeBytes {
+		e.reportFileRequired(p, fileinfo.Size(), stats.FileRequiredResultSizeLimitExceeded)
+		return false
+	}
+
+	e.reportFileRequired(p, fileinfo.Size(), stats.FileRequiredResultOK)
+	return true
+}
+
+func (e Extractor) reportFileRequired(path string, fileSizeBytes int64, result stats.FileRequiredResult) {
+	if e.stats == nil {
+		return
+	}
+	e.stats.AfterFileRequired(e.Name(), &stats.FileRequiredStats{
+		Path:          path,
+		Result:        result,
+		FileSizeBytes: fileSizeBytes,
+	})
+}
+
+// Extract reads the package.json from the tarball and hashes the tarball.
+func (e Extractor) Extract(ctx context.Context, input *filesystem.ScanInput) (inventory.Inventory, error) {
+	pkg, err := e.extractFromInput(ctx, input)
+	if e.stats != nil {
+		var fileSizeBytes int64
+		if input.Info != nil {
+			fileSizeBytes = input.Info.Size()
+		}
+		e.stats.AfterFileExtracted(e.Name(), &stats.FileExtractedStats{
+			Path:          input.Path,
+			Result:        filesystem.ExtractorErrorToFileExtractedResult(err),
+			FileSizeBytes: fileSizeBytes,
+		})
+	}
+	if err != nil {
+		return inventory.Inventory{}, fmt.Errorf("%s halted at %q: %w", e.Name(), input.Path, err)
+	}
+	if pkg == nil {
+		return inventory.Inventory{}, nil
+	}
+	return inventory.Inventory{Packages: []*extractor.Package{pkg}}, nil
+}
+
+type packageJSON struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+func (e Extractor) extractFromInput(ctx context.Context, input *filesystem.ScanInput) (*extractor.Package, error) {
+	if input.Info == nil {
+		return nil, fmt.Errorf("file info for %s is missing", input.Path)
+	}
+	r, err := scalibrfs.NewReaderAt(input.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("NewReaderAt(%s): %w", input.Path, err)
+	}
+	size := input.Info.Size()
+
+	manifest, err := readManifest(ctx, io.NewSectionReader(r, 0, size))
+	if err != nil {
+		return nil, err
+	}
+	if manifest == nil || manifest.Name == "" || manifest.Version == "" {
+		// Not an npm package.
+		return nil, nil
+	}
+
+	m, err := e.hashTarball(io.NewSectionReader(r, 0, size))
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash tarball: %w", err)
+	}
+
+	return &extractor.Package{
+		Name:      manifest.Name,
+		Version:   manifest.Version,
+		PURLType:  purl.TypeNPM,
+		Metadata:  m,
+		Locations: []string{input.Path},
+	}, nil
+}
+
+// readManifest returns the package.json in the top-level directory of the
+// tarball, or nil if there is none. The directory is called "package" for
+// tarballs created by npm but can have any name.
+func readManifest(ctx context.Context, r io.Reader) (*packageJSON, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("gzip.NewReader: %w", err)
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("tar.Next: %w", err)
+		}
+		if hdr.Typeflag != tar.TypeReg || !isManifest(hdr.Name) {
+			continue
+		}
+		var p packageJSON
+		if err := json.NewDecoder(io.LimitReader(tr, maxManifestSizeBytes)).Decode(&p); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", hdr.Name, err)
+		}
+		return &p, nil
+	}
+}
+
+func isManifest(name string) bool {
+	dir, file := path.Split(strings.TrimPrefix(name, "./"))
+	return file == "package.json" && dir != "" && strings.Count(dir, "/") == 1
+}
+
+// hashTarball computes the digests the npm registry publishes for the
+// tarball: the hex(sha1()) "shasum" and the "sha512-" + base64(sha512())
+// Subresource Integrity string.
+func (e Extractor) hashTarball(r io.Reader) (*Metadata, error) {
+	sha1, err := e.hashing.New(hashing.SHA1)
+	if err != nil {
+		return nil, err
+	}
+	sha512, err := e.hashing.New(hashing.SHA512)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := io.Copy(io.MultiWriter(sha1, sha512), r); err != nil {
+		return nil, err
+	}
+	return &Metadata{
+		Shasum:    hex.EncodeToString(sha1.Sum(nil)),
+		Integrity: "sha512-" + base64.StdEncoding.EncodeToString(sha512.Sum(nil)),
+	}, nil
+}
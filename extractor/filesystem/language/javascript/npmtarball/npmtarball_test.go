@@ -0,0 +1,195 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package npmtarball_test
+
+import (
+	"context"
+	"io/fs"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/extractor/filesystem/internal/units"
+	"github.com/google/osv-scalibr/extractor/filesystem/language/javascript/npmtarball"
+	"github.com/google/osv-scalibr/extractor/filesystem/simplefileapi"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scalibr/stats"
+	"github.com/google/osv-scalibr/testing/extracttest"
+	"github.com/google/osv-scalibr/testing/fakefs"
+	"github.com/google/osv-scalibr/testing/testcollector"
+)
+
+func TestFileRequired(t *testing.T) {
+	tests := []struct {
+		name             string
+		path             string
+		fileSizeBytes    int64
+		maxFileSizeBytes int64
+		wantRequired     bool
+		wantResultMetric stats.FileRequiredResult
+	}{
+		{
+			name:             "npm_pack_output",
+			path:             "project/left-pad-1.3.0.tgz",
+			wantRequired:     true,
+			wantResultMetric: stats.FileRequiredResultOK,
+		},
+		{
+			name:             "prerelease_version",
+			path:             "vendor/npm/react-19.0.0-rc.1.tgz",
+			wantRequired:     true,
+			wantResultMetric: stats.FileRequiredResultOK,
+		},
+		{
+			name:         "no_version_in_name",
+			path:         "backup/site.tgz",
+			wantRequired: false,
+		},
+		{
+			name:         "tar_gz_extension",
+			path:         "dist/left-pad-1.3.0.tar.gz",
+			wantRequired: false,
+		},
+		{
+			name:             "file_size_greater_than_max_size",
+			path:             "project/left-pad-1.3.0.tgz",
+			fileSizeBytes:    1000 * units.KiB,
+			maxFileSizeBytes: 100 * units.KiB,
+			wantRequired:     false,
+			wantResultMetric: stats.FileRequiredResultSizeLimitExceeded,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			collector := testcollector.New()
+			var e filesystem.Extractor = npmtarball.New(npmtarball.Config{
+				Stats:            collector,
+				MaxFileSizeBytes: tt.maxFileSizeBytes,
+			})
+
+			fileSizeBytes := tt.fileSizeBytes
+			if fileSizeBytes == 0 {
+				fileSizeBytes = 1000
+			}
+
+			isRequired := e.FileRequired(simplefileapi.New(tt.path, fakefs.FakeFileInfo{
+				FileName: filepath.Base(tt.path),
+				FileMode: fs.ModePerm,
+				FileSize: fileSizeBytes,
+			}))
+			if isRequired != tt.wantRequired {
+				t.Fatalf("FileRequired(%s): got %v, want %v", tt.path, isRequired, tt.wantRequired)
+			}
+
+			gotResultMetric := collector.FileRequiredResult(tt.path)
+			if tt.wantResultMetric != "" && gotResultMetric != tt.wantResultMetric {
+				t.Errorf("FileRequired(%s) recorded result metric %v, want result metric %v", tt.path, gotResultMetric, tt.wantResultMetric)
+			}
+		})
+	}
+}
+
+func TestExtract(t *testing.T) {
+	tests := []extracttest.TestTableEntry{
+		{
+			Name: "npm_pack_tarball",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/left-pad-1.3.0.tgz",
+			},
+			WantPackages: []*extractor.Package{
+				{
+					Name:     "left-pad",
+					Version:  "1.3.0",
+					PURLType: purl.TypeNPM,
+					Metadata: &npmtarball.Metadata{
+						Shasum:    "968e36f66ef894b353121f459e569625e9fcc801",
+						Integrity: "sha512-ziBp9uhl3wv9Z5SL/C1MfoVSTr/w1+5vGzONPxrj4MmcLtQlQZCK3/9ahOfFBoruMEFlVJFURBYsIjczh0AvgQ==",
+					},
+					Locations: []string{"testdata/left-pad-1.3.0.tgz"},
+				},
+			},
+		},
+		{
+			Name: "scoped_package_with_custom_top_level_dir",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/types-node-20.1.0.tgz",
+			},
+			WantPackages: []*extractor.Package{
+				{
+					Name:     "@types/node",
+					Version:  "20.1.0",
+					PURLType: purl.TypeNPM,
+					Metadata: &npmtarball.Metadata{
+						Shasum:    "b2b05796cf40644786b52e3f9ac12d3eb01550ae",
+						Integrity: "sha512-/PGAjk8O+ieS2Yu0U18uZRIVtUD+xOE0/QU4oZLaWOTeeRAlfI+2zAIiyUCQdB5Y/PY47EZ0wuTbPLiT9lsbNg==",
+					},
+					Locations: []string{"testdata/types-node-20.1.0.tgz"},
+				},
+			},
+		},
+		{
+			Name: "only_nested_package_json",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/nested-only-1.0.0.tgz",
+			},
+		},
+		{
+			Name: "missing_version",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/no-version-1.0.0.tgz",
+			},
+		},
+		{
+			Name: "invalid_package_json",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/invalid-json-1.0.0.tgz",
+			},
+			WantErr: cmpopts.AnyError,
+		},
+		{
+			Name: "not_gzipped",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/invalid-1.0.0.tgz",
+			},
+			WantErr: cmpopts.AnyError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			var e filesystem.Extractor = npmtarball.NewDefault()
+
+			scanInput := extracttest.GenerateScanInputMock(t, tt.InputConfig)
+			defer extracttest.CloseTestScanInput(t, scanInput)
+
+			got, err := e.Extract(context.Background(), &scanInput)
+
+			if diff := cmp.Diff(tt.WantErr, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("%s.Extract(%q) error diff (-want +got):\n%s", e.Name(), tt.InputConfig.Path, diff)
+				return
+			}
+
+			want := inventory.Inventory{Packages: tt.WantPackages}
+			if diff := cmp.Diff(want, got, cmpopts.SortSlices(extracttest.PackageCmpLess)); diff != "" {
+				t.Errorf("%s.Extract(%q) diff (-want +got):\n%s", e.Name(), tt.InputConfig.Path, diff)
+			}
+		})
+	}
+}
@@ -29,6 +29,7 @@ import (
 	"github.com/google/osv-scalibr/extractor/filesystem"
 	"github.com/google/osv-scalibr/extractor/filesystem/language/javascript/internal/commitextractor"
 	"github.com/google/osv-scalibr/extractor/filesystem/osv"
+	"github.com/google/osv-scalibr/extractor/filesystem/targetenv"
 	"github.com/google/osv-scalibr/internal/dependencyfile/packagelockjson"
 	"github.com/google/osv-scalibr/inventory"
 	"github.com/google/osv-scalibr/plugin"
@@ -142,13 +143,36 @@ func extractNpmPackageName(name string) string {
 	return pkgName
 }
 
-func parseNpmLockPackages(packages map[string]packagelockjson.Package) map[string]packageDetails {
+// isInstalled returns whether npm installs the package on the target
+// environment. Optional packages are skipped by npm if they don't support the
+// target platform or Node.js version.
+func isInstalled(pkg packagelockjson.Package, env *targetenv.Env) bool {
+	if !pkg.Optional && !pkg.DevOptional {
+		return true
+	}
+	return env.MatchesNPMPlatform(pkg.OS, pkg.CPU) && env.MatchesNodeEngine(pkg.NodeEngine())
+}
+
+func parseNpmLockPackages(packages map[string]packagelockjson.Package, env *targetenv.Env) map[string]packageDetails {
 	details := npmPackageDetailsMap{}
 
+	// Packages nested inside a skipped package aren't installed either.
+	var skipped []string
+	for namePath, detail := range packages {
+		if namePath != "" && !isInstalled(detail, env) {
+			skipped = append(skipped, namePath+"/")
+		}
+	}
+
 	for namePath, detail := range packages {
 		if namePath == "" {
 			continue
 		}
+		if slices.ContainsFunc(skipped, func(prefix string) bool {
+			return strings.HasPrefix(namePath+"/", prefix)
+		}) {
+			continue
+		}
 
 		finalName := detail.Name
 		if finalName == "" {
@@ -176,9 +200,9 @@ func parseNpmLockPackages(packages map[string]packagelockjson.Package) map[strin
 	return details
 }
 
-func parseNpmLock(lockfile packagelockjson.LockFile) map[string]packageDetails {
+func parseNpmLock(lockfile packagelockjson.LockFile, env *targetenv.Env) map[string]packageDetails {
 	if lockfile.Packages != nil {
-		return parseNpmLockPackages(lockfile.Packages)
+		return parseNpmLockPackages(lockfile.Packages, env)
 	}
 
 	return parseNpmLockDependencies(lockfile.Dependencies)
@@ -310,7 +334,7 @@ func (e Extractor) extractPkgLock(_ context.Context, input *filesystem.ScanInput
 		return nil, fmt.Errorf("could not extract: %w", err)
 	}
 
-	packages := slices.Collect(maps.Values(parseNpmLock(*parsedLockfile)))
+	packages := slices.Collect(maps.Values(parseNpmLock(*parsedLockfile, input.TargetEnv)))
 	result := make([]*extractor.Package, len(packages))
 
 	for i, pkg := range packages {
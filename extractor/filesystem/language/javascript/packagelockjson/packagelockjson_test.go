@@ -28,6 +28,7 @@ import (
 	"github.com/google/osv-scalibr/extractor/filesystem/language/javascript/packagelockjson"
 	"github.com/google/osv-scalibr/extractor/filesystem/osv"
 	"github.com/google/osv-scalibr/extractor/filesystem/simplefileapi"
+	"github.com/google/osv-scalibr/extractor/filesystem/targetenv"
 	"github.com/google/osv-scalibr/inventory"
 	"github.com/google/osv-scalibr/purl"
 	"github.com/google/osv-scalibr/stats"
@@ -367,3 +368,78 @@ func TestExtractor_Extract_Shrinkwrap_JSON(t *testing.T) {
 		})
 	}
 }
+
+func TestExtractor_Extract_TargetEnv(t *testing.T) {
+	path := "testdata/platform-specific.v3.json"
+	npmPkg := func(name, version string, groups ...string) *extractor.Package {
+		if groups == nil {
+			groups = []string{}
+		}
+		return &extractor.Package{
+			Name:       name,
+			Version:    version,
+			PURLType:   purl.TypeNPM,
+			Locations:  []string{path},
+			SourceCode: &extractor.SourceCodeIdentifier{},
+			Metadata:   osv.DepGroupMetadata{DepGroupVals: groups},
+		}
+	}
+
+	tests := []struct {
+		name         string
+		env          *targetenv.Env
+		wantPackages []*extractor.Package
+	}{
+		{
+			name: "unknown_target_reports_all_packages",
+			env:  nil,
+			wantPackages: []*extractor.Package{
+				npmPkg("esbuild", "0.20.2"),
+				npmPkg("@esbuild/linux-x64", "0.20.2", "optional"),
+				npmPkg("@esbuild/win32-x64", "0.20.2", "optional"),
+				npmPkg("fsevents", "2.3.3", "optional"),
+				npmPkg("nan", "2.18.0", "optional"),
+				npmPkg("old-engines", "1.0.0", "optional"),
+				npmPkg("modern-only", "1.0.0", "optional"),
+			},
+		},
+		{
+			name: "windows",
+			env:  &targetenv.Env{OS: "windows", Arch: "amd64", NodeVersion: "20.11.0"},
+			wantPackages: []*extractor.Package{
+				npmPkg("esbuild", "0.20.2"),
+				npmPkg("@esbuild/win32-x64", "0.20.2", "optional"),
+				npmPkg("old-engines", "1.0.0", "optional"),
+				npmPkg("modern-only", "1.0.0", "optional"),
+			},
+		},
+		{
+			name: "linux_old_node",
+			env:  &targetenv.Env{OS: "linux", Arch: "amd64", NodeVersion: "18.19.0"},
+			wantPackages: []*extractor.Package{
+				npmPkg("esbuild", "0.20.2"),
+				npmPkg("@esbuild/linux-x64", "0.20.2", "optional"),
+				npmPkg("old-engines", "1.0.0", "optional"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			extr := packagelockjson.NewDefault()
+			scanInput := extracttest.GenerateScanInputMock(t, extracttest.ScanInputMockConfig{Path: path})
+			defer extracttest.CloseTestScanInput(t, scanInput)
+			scanInput.TargetEnv = tt.env
+
+			got, err := extr.Extract(context.Background(), &scanInput)
+			if err != nil {
+				t.Fatalf("%s.Extract(%q): %v", extr.Name(), path, err)
+			}
+
+			wantInv := inventory.Inventory{Packages: tt.wantPackages}
+			if diff := cmp.Diff(wantInv, got, cmpopts.SortSlices(extracttest.PackageCmpLess)); diff != "" {
+				t.Errorf("%s.Extract(%q) diff (-want +got):\n%s", extr.Name(), path, diff)
+			}
+		})
+	}
+}
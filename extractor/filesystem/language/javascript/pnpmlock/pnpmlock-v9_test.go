@@ -68,7 +68,7 @@ func TestExtractor_Extract_v9(t *testing.T) {
 					Locations:  []string{"testdata/one-package-dev.v9.yaml"},
 					SourceCode: &extractor.SourceCodeIdentifier{},
 					Metadata: osv.DepGroupMetadata{
-						DepGroupVals: []string{},
+						DepGroupVals: []string{"dev"},
 					},
 				},
 			},
@@ -359,10 +359,108 @@ func TestExtractor_Extract_v9(t *testing.T) {
 					PURLType:   purl.TypeNPM,
 					Locations:  []string{"testdata/mixed-groups.v9.yaml"},
 					SourceCode: &extractor.SourceCodeIdentifier{},
+					Metadata: osv.DepGroupMetadata{
+						DepGroupVals: []string{"dev"},
+					},
+				},
+			},
+		},
+		{
+			Name: "workspace",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/workspace.v9.yaml",
+			},
+			WantPackages: []*extractor.Package{
+				{
+					Name:       "ansi-regex",
+					Version:    "5.0.1",
+					PURLType:   purl.TypeNPM,
+					Locations:  []string{"testdata/workspace.v9.yaml"},
+					SourceCode: &extractor.SourceCodeIdentifier{},
+					Metadata: osv.DepGroupMetadata{
+						DepGroupVals: []string{"dev"},
+					},
+				},
+				{
+					Name:       "js-tokens",
+					Version:    "4.0.0",
+					PURLType:   purl.TypeNPM,
+					Locations:  []string{"testdata/workspace.v9.yaml"},
+					SourceCode: &extractor.SourceCodeIdentifier{},
 					Metadata: osv.DepGroupMetadata{
 						DepGroupVals: []string{},
 					},
 				},
+				{
+					Name:       "loose-envify",
+					Version:    "1.4.0",
+					PURLType:   purl.TypeNPM,
+					Locations:  []string{"testdata/workspace.v9.yaml"},
+					SourceCode: &extractor.SourceCodeIdentifier{},
+					Metadata: osv.DepGroupMetadata{
+						DepGroupVals: []string{},
+					},
+				},
+				{
+					Name:       "react-dom",
+					Version:    "18.2.0",
+					PURLType:   purl.TypeNPM,
+					Locations:  []string{"testdata/workspace.v9.yaml"},
+					SourceCode: &extractor.SourceCodeIdentifier{},
+					Metadata: osv.DepGroupMetadata{
+						DepGroupVals: []string{},
+					},
+				},
+				{
+					Name:       "react",
+					Version:    "18.2.0",
+					PURLType:   purl.TypeNPM,
+					Locations:  []string{"testdata/workspace.v9.yaml"},
+					SourceCode: &extractor.SourceCodeIdentifier{},
+					Metadata: osv.DepGroupMetadata{
+						DepGroupVals: []string{},
+					},
+				},
+				{
+					Name:       "scheduler",
+					Version:    "0.23.2",
+					PURLType:   purl.TypeNPM,
+					Locations:  []string{"testdata/workspace.v9.yaml"},
+					SourceCode: &extractor.SourceCodeIdentifier{},
+					Metadata: osv.DepGroupMetadata{
+						DepGroupVals: []string{},
+					},
+				},
+				{
+					Name:       "string-width",
+					Version:    "4.2.3",
+					PURLType:   purl.TypeNPM,
+					Locations:  []string{"testdata/workspace.v9.yaml"},
+					SourceCode: &extractor.SourceCodeIdentifier{},
+					Metadata: osv.DepGroupMetadata{
+						DepGroupVals: []string{"dev"},
+					},
+				},
+				{
+					Name:       "typescript",
+					Version:    "5.4.5",
+					PURLType:   purl.TypeNPM,
+					Locations:  []string{"testdata/workspace.v9.yaml"},
+					SourceCode: &extractor.SourceCodeIdentifier{},
+					Metadata: osv.DepGroupMetadata{
+						DepGroupVals: []string{"dev"},
+					},
+				},
+				{
+					Name:       "vitest",
+					Version:    "1.5.0",
+					PURLType:   purl.TypeNPM,
+					Locations:  []string{"testdata/workspace.v9.yaml"},
+					SourceCode: &extractor.SourceCodeIdentifier{},
+					Metadata: osv.DepGroupMetadata{
+						DepGroupVals: []string{"dev"},
+					},
+				},
 			},
 		},
 	}
@@ -20,6 +20,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"path"
 	"path/filepath"
 	"regexp"
 	"slices"
@@ -55,14 +56,40 @@ type pnpmLockPackage struct {
 	Dev        bool                      `yaml:"dev"`
 }
 
+// pnpmLockDependency is a direct dependency of an importer. Its version is the
+// resolved version, which can also include peer dependencies, point to a
+// workspace package ("link:") or be an alias ("name@version").
+type pnpmLockDependency struct {
+	Specifier string `yaml:"specifier"`
+	Version   string `yaml:"version"`
+}
+
+// pnpmLockImporter is a project of the workspace, "." being the root project.
+type pnpmLockImporter struct {
+	Dependencies         map[string]pnpmLockDependency `yaml:"dependencies,omitempty"`
+	OptionalDependencies map[string]pnpmLockDependency `yaml:"optionalDependencies,omitempty"`
+	DevDependencies      map[string]pnpmLockDependency `yaml:"devDependencies,omitempty"`
+}
+
+// pnpmLockSnapshot describes the dependencies of an installed package in v9
+// lockfiles, which no longer record them in the packages section.
+type pnpmLockSnapshot struct {
+	Dependencies         map[string]string `yaml:"dependencies,omitempty"`
+	OptionalDependencies map[string]string `yaml:"optionalDependencies,omitempty"`
+}
+
 type pnpmLockfile struct {
-	Version  float64                    `yaml:"lockfileVersion"`
-	Packages map[string]pnpmLockPackage `yaml:"packages,omitempty"`
+	Version   float64                     `yaml:"lockfileVersion"`
+	Importers map[string]pnpmLockImporter `yaml:"importers,omitempty"`
+	Packages  map[string]pnpmLockPackage  `yaml:"packages,omitempty"`
+	Snapshots map[string]pnpmLockSnapshot `yaml:"snapshots,omitempty"`
 }
 
 type pnpmLockfileV6 struct {
-	Version  string                     `yaml:"lockfileVersion"`
-	Packages map[string]pnpmLockPackage `yaml:"packages,omitempty"`
+	Version   string                      `yaml:"lockfileVersion"`
+	Importers map[string]pnpmLockImporter `yaml:"importers,omitempty"`
+	Packages  map[string]pnpmLockPackage  `yaml:"packages,omitempty"`
+	Snapshots map[string]pnpmLockSnapshot `yaml:"snapshots,omitempty"`
 }
 
 // UnmarshalYAML is a custom unmarshalling function for handling v6 lockfiles.
@@ -80,7 +107,9 @@ func (l *pnpmLockfile) UnmarshalYAML(unmarshal func(any) error) error {
 	}
 
 	l.Version = parsedVersion
+	l.Importers = lockfileV6.Importers
 	l.Packages = lockfileV6.Packages
+	l.Snapshots = lockfileV6.Snapshots
 
 	return nil
 }
@@ -105,13 +134,12 @@ func extractPnpmPackageNameAndVersion(dependencyPath string, lockfileVersion flo
 
 	// v9.0 specifies the dependencies as <package>@<version> rather than as a path
 	if lockfileVersion >= 9.0 {
-		dependencyPath = strings.Trim(dependencyPath, "'")
-		dependencyPath, isScoped := strings.CutPrefix(dependencyPath, "@")
-
-		name, version, _ := strings.Cut(dependencyPath, "@")
+		name, version := splitV9Key(trimPeerSuffix(strings.Trim(dependencyPath, "'")))
 
-		if isScoped {
-			name = "@" + name
+		// Packages which aren't from a registry, e.g. tarballs and git
+		// repositories, have their version in the "version" property.
+		if !numberMatcher.MatchString(version) {
+			version = ""
 		}
 
 		return name, version, nil
@@ -156,6 +184,110 @@ func extractPnpmPackageNameAndVersion(dependencyPath string, lockfileVersion flo
 	return name, version, nil
 }
 
+// splitV9Key splits a v9 package key, e.g. "@scope/name@1.0.0", into the
+// package name and version.
+func splitV9Key(key string) (name string, version string) {
+	key, isScoped := strings.CutPrefix(key, "@")
+	name, version, _ = strings.Cut(key, "@")
+	if isScoped {
+		name = "@" + name
+	}
+	return name, version
+}
+
+// trimPeerSuffix removes the peer dependencies that v9 lockfiles append to
+// resolved versions, e.g. "5.62.0(eslint@8.57.0)(typescript@4.9.5)".
+func trimPeerSuffix(version string) string {
+	if i := strings.Index(version, "("); i > 0 {
+		return version[:i]
+	}
+	return version
+}
+
+// snapshotKey returns the key of the snapshot that a dependency on the package
+// with the given name and resolved version refers to, or "" for workspace
+// packages.
+func snapshotKey(name string, version string) string {
+	if strings.HasPrefix(version, "link:") {
+		return ""
+	}
+	// Aliased dependencies, e.g. "string-width-cjs: string-width@4.2.3", refer
+	// to the real package.
+	if !numberMatcher.MatchString(version) && !strings.Contains(version, ":") && strings.Contains(version[1:], "@") {
+		return version
+	}
+	return name + "@" + version
+}
+
+// devOnlyPackages returns the keys of the packages in a v9 lockfile that are
+// only installed as dependencies of devDependencies. pnpm doesn't record this
+// per package since v9 so it's derived from the dependency graph of all the
+// importers in the workspace. Dependencies on other workspace packages using
+// the "workspace:" protocol are followed into the dependencies of that package.
+func devOnlyPackages(lockfile pnpmLockfile) map[string]bool {
+	visit := func(seen map[string]bool, roots []string) {
+		for len(roots) > 0 {
+			key := roots[len(roots)-1]
+			roots = roots[:len(roots)-1]
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			snapshot := lockfile.Snapshots[key]
+			for _, deps := range []map[string]string{snapshot.Dependencies, snapshot.OptionalDependencies} {
+				for name, version := range deps {
+					if k := snapshotKey(name, version); k != "" {
+						roots = append(roots, k)
+					}
+				}
+			}
+		}
+	}
+
+	// rootsOf returns the snapshots that the given dependencies of an importer
+	// refer to, including the non-dev dependencies of linked workspace packages.
+	var rootsOf func(importer string, deps []map[string]pnpmLockDependency, visited map[string]bool) []string
+	rootsOf = func(importer string, deps []map[string]pnpmLockDependency, visited map[string]bool) []string {
+		var roots []string
+		for _, d := range deps {
+			for name, dep := range d {
+				target, isLink := strings.CutPrefix(dep.Version, "link:")
+				if !isLink {
+					roots = append(roots, snapshotKey(name, dep.Version))
+					continue
+				}
+				target = path.Join(importer, target)
+				linked, ok := lockfile.Importers[target]
+				if !ok || visited[target] {
+					continue
+				}
+				visited[target] = true
+				roots = append(roots, rootsOf(target, []map[string]pnpmLockDependency{linked.Dependencies, linked.OptionalDependencies}, visited)...)
+			}
+		}
+		return roots
+	}
+
+	prod := map[string]bool{}
+	dev := map[string]bool{}
+	for importer, i := range lockfile.Importers {
+		visit(prod, rootsOf(importer, []map[string]pnpmLockDependency{i.Dependencies, i.OptionalDependencies}, map[string]bool{importer: true}))
+		visit(dev, rootsOf(importer, []map[string]pnpmLockDependency{i.DevDependencies}, map[string]bool{importer: true}))
+	}
+
+	devOnly := map[string]bool{}
+	for key := range dev {
+		if !prod[key] {
+			devOnly[trimPeerSuffix(key)] = true
+		}
+	}
+	// A package can be installed several times with different peers.
+	for key := range prod {
+		delete(devOnly, trimPeerSuffix(key))
+	}
+	return devOnly
+}
+
 func parseNameAtVersion(value string) (name string, version string) {
 	matches := nameVersionRegexp.FindStringSubmatch(value)
 
@@ -170,6 +302,11 @@ func parsePnpmLock(lockfile pnpmLockfile) ([]*extractor.Package, error) {
 	packages := make([]*extractor.Package, 0, len(lockfile.Packages))
 	errs := []error{}
 
+	var devOnly map[string]bool
+	if lockfile.Version >= 9.0 {
+		devOnly = devOnlyPackages(lockfile)
+	}
+
 	for s, pkg := range lockfile.Packages {
 		name, version, err := extractPnpmPackageNameAndVersion(s, lockfile.Version)
 		if err != nil {
@@ -205,7 +342,7 @@ func parsePnpmLock(lockfile pnpmLockfile) ([]*extractor.Package, error) {
 		}
 
 		depGroups := []string{}
-		if pkg.Dev {
+		if pkg.Dev || devOnly[strings.Trim(s, "'")] {
 			depGroups = append(depGroups, "dev")
 		}
 
@@ -0,0 +1,149 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package manifesttoml extracts Julia Manifest.toml files.
+package manifesttoml
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	juliameta "github.com/google/osv-scalibr/extractor/filesystem/language/julia/metadata"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/plugin"
+	"github.com/google/osv-scalibr/purl"
+)
+
+const (
+	// Name is the unique name of this extractor.
+	Name = "julia/manifesttoml"
+)
+
+// manifestFileRe matches Manifest.toml, JuliaManifest.toml and the
+// version-specific manifests such as Manifest-v1.11.toml.
+var manifestFileRe = regexp.MustCompile(`^(?:Julia)?Manifest(?:-v\d+\.\d+)?\.toml$`)
+
+type manifestEntry struct {
+	UUID        string `toml:"uuid"`
+	Version     string `toml:"version"`
+	GitTreeSHA1 string `toml:"git-tree-sha1"`
+	RepoURL     string `toml:"repo-url"`
+	RepoRev     string `toml:"repo-rev"`
+}
+
+// Extractor extracts Julia packages from Manifest.toml files.
+type Extractor struct{}
+
+// New returns a new instance of the extractor.
+func New() filesystem.Extractor { return &Extractor{} }
+
+// Name of the extractor
+func (e Extractor) Name() string { return Name }
+
+// Version of the extractor
+func (e Extractor) Version() int { return 0 }
+
+// FileRequired returns true if the specified file is a Julia manifest.
+func (e Extractor) FileRequired(api filesystem.FileAPI) bool {
+	return manifestFileRe.MatchString(filepath.Base(api.Path()))
+}
+
+// Requirements of the extractor
+func (e Extractor) Requirements() *plugin.Capabilities {
+	return &plugin.Capabilities{}
+}
+
+// Extract extracts packages from Manifest.toml files passed through the scan input.
+func (e Extractor) Extract(ctx context.Context, input *filesystem.ScanInput) (inventory.Inventory, error) {
+	deps, err := parseManifest(input)
+	if err != nil {
+		return inventory.Inventory{}, fmt.Errorf("could not extract: %w", err)
+	}
+
+	var packages []*extractor.Package
+	for _, name := range slices.Sorted(maps.Keys(deps)) {
+		if err := ctx.Err(); err != nil {
+			return inventory.Inventory{}, fmt.Errorf("%s halted due to context error: %w", e.Name(), err)
+		}
+		for _, dep := range deps[name] {
+			// Standard libraries of Julia versions before 1.11 have no version.
+			if dep.Version == "" {
+				continue
+			}
+			packages = append(packages, &extractor.Package{
+				Name:      name,
+				Version:   dep.Version,
+				PURLType:  purl.TypeJulia,
+				Locations: []string{input.Path},
+				Metadata: &juliameta.Metadata{
+					UUID:        dep.UUID,
+					GitTreeSHA1: dep.GitTreeSHA1,
+					RepoURL:     dep.RepoURL,
+					RepoRev:     dep.RepoRev,
+				},
+			})
+		}
+	}
+
+	return inventory.Inventory{Packages: packages}, nil
+}
+
+// parseManifest returns the entries of the manifest by package name. Since
+// Julia 1.7 the packages are listed in the deps table, before that they were
+// top-level entries. Several packages can share a name if they have
+// different UUIDs.
+func parseManifest(input *filesystem.ScanInput) (map[string][]manifestEntry, error) {
+	var top map[string]toml.Primitive
+	md, err := toml.NewDecoder(input.Reader).Decode(&top)
+	if err != nil {
+		return nil, err
+	}
+
+	deps := map[string][]manifestEntry{}
+	format, ok := top["manifest_format"]
+	if !ok {
+		for name, p := range top {
+			var entries []manifestEntry
+			if err := md.PrimitiveDecode(p, &entries); err != nil {
+				return nil, fmt.Errorf("package %q: %w", name, err)
+			}
+			deps[name] = entries
+		}
+		return deps, nil
+	}
+
+	var version string
+	if err := md.PrimitiveDecode(format, &version); err != nil {
+		return nil, fmt.Errorf("manifest_format: %w", err)
+	}
+	if !strings.HasPrefix(version, "2.") {
+		return nil, fmt.Errorf("unsupported manifest format %q", version)
+	}
+	if p, ok := top["deps"]; ok {
+		if err := md.PrimitiveDecode(p, &deps); err != nil {
+			return nil, fmt.Errorf("deps: %w", err)
+		}
+	}
+	return deps, nil
+}
+
+var _ filesystem.Extractor = Extractor{}
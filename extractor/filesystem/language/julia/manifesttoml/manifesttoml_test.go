@@ -0,0 +1,213 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manifesttoml_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem/language/julia/manifesttoml"
+	juliameta "github.com/google/osv-scalibr/extractor/filesystem/language/julia/metadata"
+	"github.com/google/osv-scalibr/extractor/filesystem/simplefileapi"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scalibr/testing/extracttest"
+)
+
+func TestExtractor_FileRequired(t *testing.T) {
+	tests := []struct {
+		inputPath string
+		want      bool
+	}{
+		{inputPath: "", want: false},
+		{inputPath: "Manifest.toml", want: true},
+		{inputPath: "path/to/project/Manifest.toml", want: true},
+		{inputPath: "path/to/project/JuliaManifest.toml", want: true},
+		{inputPath: "path/to/project/Manifest-v1.11.toml", want: true},
+		{inputPath: "home/user/.julia/environments/v1.10/Manifest.toml", want: true},
+		{inputPath: "path/to/project/Project.toml", want: false},
+		{inputPath: "path/to/project/Manifest.toml.bak", want: false},
+		{inputPath: "path/to/project/OldManifest.toml", want: false},
+		{inputPath: "path/to/project/Manifest-v1.toml", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.inputPath, func(t *testing.T) {
+			e := manifesttoml.Extractor{}
+			got := e.FileRequired(simplefileapi.New(tt.inputPath, nil))
+			if got != tt.want {
+				t.Errorf("FileRequired(%s, FileInfo) got = %v, want %v", tt.inputPath, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtractor_Extract(t *testing.T) {
+	tests := []extracttest.TestTableEntry{
+		{
+			Name: "invalid toml",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/invalid.toml",
+			},
+			WantPackages: nil,
+			WantErr:      extracttest.ContainsErrStr{Str: "could not extract"},
+		},
+		{
+			Name: "unsupported manifest format",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/unsupported-format.toml",
+			},
+			WantPackages: nil,
+			WantErr:      extracttest.ContainsErrStr{Str: "unsupported manifest format"},
+		},
+		{
+			Name: "no dependencies",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/empty.toml",
+			},
+			WantPackages: nil,
+		},
+		{
+			Name: "manifest format 2.0",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/Manifest.toml",
+			},
+			WantPackages: []*extractor.Package{
+				{
+					Name:      "CSV",
+					Version:   "0.10.14",
+					PURLType:  purl.TypeJulia,
+					Locations: []string{"testdata/Manifest.toml"},
+					Metadata: &juliameta.Metadata{
+						UUID:        "336ed68f-0bac-5ca0-87d4-7b16caf5d00b",
+						GitTreeSHA1: "6c834533dc1fabd820c1db03c839bf97e45a3fab",
+					},
+				},
+				{
+					Name:      "HTTP",
+					Version:   "1.10.8",
+					PURLType:  purl.TypeJulia,
+					Locations: []string{"testdata/Manifest.toml"},
+					Metadata: &juliameta.Metadata{
+						UUID:        "cd3eb016-35fb-5094-929b-558a96fad6f3",
+						GitTreeSHA1: "d1d712be3164d61d1fb98e7ce9bcbc6cc06b45ed",
+					},
+				},
+				{
+					Name:      "MyPrivatePkg",
+					Version:   "0.1.0",
+					PURLType:  purl.TypeJulia,
+					Locations: []string{"testdata/Manifest.toml"},
+					Metadata: &juliameta.Metadata{
+						UUID:        "8f3f7a4e-2d6b-4c1e-9a5b-3e2f1d0c9b8a",
+						GitTreeSHA1: "0f5a9e1f1e6b1c0a2b5e3f7d6c4a8b9e0d1c2f3a",
+						RepoURL:     "https://github.com/example/MyPrivatePkg.jl.git",
+						RepoRev:     "main",
+					},
+				},
+				{
+					Name:      "OpenSSL_jll",
+					Version:   "3.0.14+0",
+					PURLType:  purl.TypeJulia,
+					Locations: []string{"testdata/Manifest.toml"},
+					Metadata: &juliameta.Metadata{
+						UUID:        "458c3c95-2e84-50aa-8efc-19380b2a3a95",
+						GitTreeSHA1: "a028ee3cb5641cccc4c24e90c36b0a4f7707bdf5",
+					},
+				},
+			},
+		},
+		{
+			Name: "versioned standard libraries",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/Manifest-v1.11.toml",
+			},
+			WantPackages: []*extractor.Package{
+				{
+					Name:      "Dates",
+					Version:   "1.11.0",
+					PURLType:  purl.TypeJulia,
+					Locations: []string{"testdata/Manifest-v1.11.toml"},
+					Metadata: &juliameta.Metadata{
+						UUID: "ade2ca70-3891-5945-98fb-dc099432e06a",
+					},
+				},
+				{
+					Name:      "JSON",
+					Version:   "0.21.4",
+					PURLType:  purl.TypeJulia,
+					Locations: []string{"testdata/Manifest-v1.11.toml"},
+					Metadata: &juliameta.Metadata{
+						UUID:        "682c06a0-de6a-54ab-a142-c8b1cf79cde6",
+						GitTreeSHA1: "31e996f0a15c7b280ba9f76636b3ff9e2ae58c9a",
+					},
+				},
+			},
+		},
+		{
+			Name: "manifest format 1.0",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/Manifest-v1.toml",
+			},
+			WantPackages: []*extractor.Package{
+				{
+					Name:      "JSON",
+					Version:   "0.21.1",
+					PURLType:  purl.TypeJulia,
+					Locations: []string{"testdata/Manifest-v1.toml"},
+					Metadata: &juliameta.Metadata{
+						UUID:        "682c06a0-de6a-54ab-a142-c8b1cf79cde6",
+						GitTreeSHA1: "81690084b6198a2e1da36fcfda16eeca9f9f24e4",
+					},
+				},
+				{
+					Name:      "Parsers",
+					Version:   "1.1.0",
+					PURLType:  purl.TypeJulia,
+					Locations: []string{"testdata/Manifest-v1.toml"},
+					Metadata: &juliameta.Metadata{
+						UUID:        "69de0a69-1ddd-5017-9359-2bf0b02dc9f0",
+						GitTreeSHA1: "c8abc88faa3f7a3950832ac5d6e690881590d6dc",
+					},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			extr := manifesttoml.Extractor{}
+
+			scanInput := extracttest.GenerateScanInputMock(t, tt.InputConfig)
+			defer extracttest.CloseTestScanInput(t, scanInput)
+
+			got, err := extr.Extract(context.Background(), &scanInput)
+
+			if diff := cmp.Diff(tt.WantErr, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("%s.Extract(%q) error diff (-want +got):\n%s", extr.Name(), tt.InputConfig.Path, diff)
+				return
+			}
+
+			wantInv := inventory.Inventory{Packages: tt.WantPackages}
+			if diff := cmp.Diff(wantInv, got, cmpopts.SortSlices(extracttest.PackageCmpLess)); diff != "" {
+				t.Errorf("%s.Extract(%q) diff (-want +got):\n%s", extr.Name(), tt.InputConfig.Path, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package metadata defines the metadata of Julia packages.
+package metadata
+
+import (
+	pb "github.com/google/osv-scalibr/binary/proto/scan_result_go_proto"
+)
+
+// Metadata holds parsing information for a Julia package.
+type Metadata struct {
+	// UUID identifies the package. Julia package names aren't unique across
+	// registries.
+	UUID string
+	// GitTreeSHA1 is the git tree hash of the package source.
+	GitTreeSHA1 string
+	// RepoURL and RepoRev are set for packages added from a git repository
+	// instead of a registry.
+	RepoURL string
+	RepoRev string
+}
+
+// SetProto sets the JuliaMetadata field in the Package proto.
+func (m *Metadata) SetProto(p *pb.Package) {
+	if m == nil {
+		return
+	}
+	if p == nil {
+		return
+	}
+
+	p.Metadata = &pb.Package_JuliaMetadata{
+		JuliaMetadata: &pb.JuliaPackageMetadata{
+			Uuid:        m.UUID,
+			GitTreeSha1: m.GitTreeSHA1,
+			RepoUrl:     m.RepoURL,
+			RepoRev:     m.RepoRev,
+		},
+	}
+}
+
+// ToStruct converts the JuliaPackageMetadata proto to a Metadata struct.
+func ToStruct(m *pb.JuliaPackageMetadata) *Metadata {
+	if m == nil {
+		return nil
+	}
+
+	return &Metadata{
+		UUID:        m.GetUuid(),
+		GitTreeSHA1: m.GetGitTreeSha1(),
+		RepoURL:     m.GetRepoUrl(),
+		RepoRev:     m.GetRepoRev(),
+	}
+}
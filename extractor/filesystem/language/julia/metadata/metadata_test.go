@@ -0,0 +1,90 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metadata_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/osv-scalibr/extractor/filesystem/language/julia/metadata"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	pb "github.com/google/osv-scalibr/binary/proto/scan_result_go_proto"
+)
+
+func TestSetProto(t *testing.T) {
+	testCases := []struct {
+		desc string
+		m    *metadata.Metadata
+		p    *pb.Package
+		want *pb.Package
+	}{
+		{
+			desc: "nil metadata",
+			m:    nil,
+			p:    &pb.Package{Name: "some-package"},
+			want: &pb.Package{Name: "some-package"},
+		},
+		{
+			desc: "nil package",
+			m:    &metadata.Metadata{UUID: "336ed68f-0bac-5ca0-87d4-7b16caf5d00b"},
+			p:    nil,
+			want: nil,
+		},
+		{
+			desc: "set all fields",
+			m: &metadata.Metadata{
+				UUID:        "8f3f7a4e-2d6b-4c1e-9a5b-3e2f1d0c9b8a",
+				GitTreeSHA1: "0f5a9e1f1e6b1c0a2b5e3f7d6c4a8b9e0d1c2f3a",
+				RepoURL:     "https://github.com/example/MyPrivatePkg.jl.git",
+				RepoRev:     "main",
+			},
+			p: &pb.Package{Name: "some-package"},
+			want: &pb.Package{
+				Name: "some-package",
+				Metadata: &pb.Package_JuliaMetadata{
+					JuliaMetadata: &pb.JuliaPackageMetadata{
+						Uuid:        "8f3f7a4e-2d6b-4c1e-9a5b-3e2f1d0c9b8a",
+						GitTreeSha1: "0f5a9e1f1e6b1c0a2b5e3f7d6c4a8b9e0d1c2f3a",
+						RepoUrl:     "https://github.com/example/MyPrivatePkg.jl.git",
+						RepoRev:     "main",
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			p := proto.Clone(tc.p).(*pb.Package)
+			tc.m.SetProto(p)
+			if diff := cmp.Diff(tc.want, p, protocmp.Transform()); diff != "" {
+				t.Errorf("Metatadata{%+v}.SetProto(%+v): (-want +got):\n%s", tc.m, tc.p, diff)
+			}
+
+			// Test the reverse conversion for completeness.
+
+			if tc.p == nil && tc.want == nil {
+				return
+			}
+
+			got := metadata.ToStruct(p.GetJuliaMetadata())
+			if diff := cmp.Diff(tc.m, got); diff != "" {
+				t.Errorf("ToStruct(%+v): (-want +got):\n%s", p.GetJuliaMetadata(), diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package projecttoml extracts Julia packages from their Project.toml files,
+// e.g. the packages installed in a Julia depot.
+package projecttoml
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/BurntSushi/toml"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	juliameta "github.com/google/osv-scalibr/extractor/filesystem/language/julia/metadata"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/plugin"
+	"github.com/google/osv-scalibr/purl"
+)
+
+const (
+	// Name is the unique name of this extractor.
+	Name = "julia/projecttoml"
+)
+
+type projectFile struct {
+	Name    string `toml:"name"`
+	UUID    string `toml:"uuid"`
+	Version string `toml:"version"`
+}
+
+// Extractor extracts Julia packages from Project.toml files.
+type Extractor struct{}
+
+// New returns a new instance of the extractor.
+func New() filesystem.Extractor { return &Extractor{} }
+
+// Name of the extractor
+func (e Extractor) Name() string { return Name }
+
+// Version of the extractor
+func (e Extractor) Version() int { return 0 }
+
+// FileRequired returns true if the specified file is a Julia project file.
+func (e Extractor) FileRequired(api filesystem.FileAPI) bool {
+	base := filepath.Base(api.Path())
+	return base == "Project.toml" || base == "JuliaProject.toml"
+}
+
+// Requirements of the extractor
+func (e Extractor) Requirements() *plugin.Capabilities {
+	return &plugin.Capabilities{}
+}
+
+// Extract extracts the package defined by a Project.toml file. Project files
+// of environments and applications that don't define a versioned package
+// contain no packages; their dependencies are resolved in the Manifest.toml.
+func (e Extractor) Extract(_ context.Context, input *filesystem.ScanInput) (inventory.Inventory, error) {
+	var project projectFile
+	if _, err := toml.NewDecoder(input.Reader).Decode(&project); err != nil {
+		return inventory.Inventory{}, fmt.Errorf("could not extract: %w", err)
+	}
+	if project.Name == "" || project.UUID == "" || project.Version == "" {
+		return inventory.Inventory{}, nil
+	}
+
+	return inventory.Inventory{Packages: []*extractor.Package{{
+		Name:      project.Name,
+		Version:   project.Version,
+		PURLType:  purl.TypeJulia,
+		Locations: []string{input.Path},
+		Metadata:  &juliameta.Metadata{UUID: project.UUID},
+	}}}, nil
+}
+
+var _ filesystem.Extractor = Extractor{}
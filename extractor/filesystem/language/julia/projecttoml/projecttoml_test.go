@@ -0,0 +1,114 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package projecttoml_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	"github.com/google/osv-scalibr/extractor"
+	juliameta "github.com/google/osv-scalibr/extractor/filesystem/language/julia/metadata"
+	"github.com/google/osv-scalibr/extractor/filesystem/language/julia/projecttoml"
+	"github.com/google/osv-scalibr/extractor/filesystem/simplefileapi"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scalibr/testing/extracttest"
+)
+
+func TestExtractor_FileRequired(t *testing.T) {
+	tests := []struct {
+		inputPath string
+		want      bool
+	}{
+		{inputPath: "", want: false},
+		{inputPath: "Project.toml", want: true},
+		{inputPath: "home/user/.julia/packages/HTTP/sJD5V/Project.toml", want: true},
+		{inputPath: "path/to/project/JuliaProject.toml", want: true},
+		{inputPath: "path/to/project/pyproject.toml", want: false},
+		{inputPath: "path/to/project/Manifest.toml", want: false},
+		{inputPath: "path/to/project/Project.toml/file", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.inputPath, func(t *testing.T) {
+			e := projecttoml.Extractor{}
+			got := e.FileRequired(simplefileapi.New(tt.inputPath, nil))
+			if got != tt.want {
+				t.Errorf("FileRequired(%s, FileInfo) got = %v, want %v", tt.inputPath, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtractor_Extract(t *testing.T) {
+	tests := []extracttest.TestTableEntry{
+		{
+			Name: "invalid toml",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/invalid.toml",
+			},
+			WantPackages: nil,
+			WantErr:      extracttest.ContainsErrStr{Str: "could not extract"},
+		},
+		{
+			Name: "environment without package",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/environment.toml",
+			},
+			WantPackages: nil,
+		},
+		{
+			Name: "package",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/Project.toml",
+			},
+			WantPackages: []*extractor.Package{
+				{
+					Name:      "HTTP",
+					Version:   "1.10.8",
+					PURLType:  purl.TypeJulia,
+					Locations: []string{"testdata/Project.toml"},
+					Metadata: &juliameta.Metadata{
+						UUID: "cd3eb016-35fb-5094-929b-558a96fad6f3",
+					},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			extr := projecttoml.Extractor{}
+
+			scanInput := extracttest.GenerateScanInputMock(t, tt.InputConfig)
+			defer extracttest.CloseTestScanInput(t, scanInput)
+
+			got, err := extr.Extract(context.Background(), &scanInput)
+
+			if diff := cmp.Diff(tt.WantErr, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("%s.Extract(%q) error diff (-want +got):\n%s", extr.Name(), tt.InputConfig.Path, diff)
+				return
+			}
+
+			wantInv := inventory.Inventory{Packages: tt.WantPackages}
+			if diff := cmp.Diff(wantInv, got, cmpopts.SortSlices(extracttest.PackageCmpLess)); diff != "" {
+				t.Errorf("%s.Extract(%q) diff (-want +got):\n%s", extr.Name(), tt.InputConfig.Path, diff)
+			}
+		})
+	}
+}
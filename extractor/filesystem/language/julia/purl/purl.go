@@ -0,0 +1,36 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package purl converts Julia package details into a Julia PackageURL.
+package purl
+
+import (
+	juliameta "github.com/google/osv-scalibr/extractor/filesystem/language/julia/metadata"
+	"github.com/google/osv-scalibr/purl"
+)
+
+// MakePackageURL returns a package URL following the purl spec for Julia,
+// which identifies packages by their name and UUID.
+func MakePackageURL(name string, version string, metadata any) *purl.PackageURL {
+	var qualifiers purl.Qualifiers
+	if m, ok := metadata.(*juliameta.Metadata); ok && m.UUID != "" {
+		qualifiers = purl.QualifiersFromMap(map[string]string{purl.UUID: m.UUID})
+	}
+	return &purl.PackageURL{
+		Type:       purl.TypeJulia,
+		Name:       name,
+		Version:    version,
+		Qualifiers: qualifiers,
+	}
+}
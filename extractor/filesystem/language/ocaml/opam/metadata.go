@@ -0,0 +1,57 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opam
+
+import (
+	pb "github.com/google/osv-scalibr/binary/proto/scan_result_go_proto"
+)
+
+// Metadata holds the state of a package in an opam switch.
+type Metadata struct {
+	// Root is set if the package was installed explicitly rather than as a
+	// dependency of another package.
+	Root bool
+	// Pinned is set if the package is pinned to a version or source.
+	Pinned bool
+}
+
+// SetProto sets the OpamMetadata field in the Package proto.
+func (m *Metadata) SetProto(p *pb.Package) {
+	if m == nil {
+		return
+	}
+	if p == nil {
+		return
+	}
+
+	p.Metadata = &pb.Package_OpamMetadata{
+		OpamMetadata: &pb.OpamPackageMetadata{
+			Root:   m.Root,
+			Pinned: m.Pinned,
+		},
+	}
+}
+
+// ToStruct converts the OpamPackageMetadata proto to a Metadata struct.
+func ToStruct(m *pb.OpamPackageMetadata) *Metadata {
+	if m == nil {
+		return nil
+	}
+
+	return &Metadata{
+		Root:   m.GetRoot(),
+		Pinned: m.GetPinned(),
+	}
+}
@@ -0,0 +1,351 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package opam extracts the OCaml packages installed in opam switches.
+package opam
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/plugin"
+	"github.com/google/osv-scalibr/purl"
+)
+
+const (
+	// Name is the unique name of this extractor.
+	Name = "ocaml/opam"
+
+	// switchStateFile holds the installed packages of an opam 2 switch.
+	switchStateFile = "switch-state"
+	// installedFile and installedRootsFile hold the installed packages of an
+	// opam 1 switch.
+	installedFile      = "installed"
+	installedRootsFile = "installed.roots"
+)
+
+// exportFileRe matches the file names commonly used for the output of
+// "opam switch export", e.g. opam.export or my-switch.export.
+var exportFileRe = regexp.MustCompile(`^(?:.*[._-])?(?:opam|switch)\.export$`)
+
+// Extractor extracts the packages installed in opam switches.
+type Extractor struct{}
+
+// New returns a new instance of the extractor.
+func New() filesystem.Extractor { return &Extractor{} }
+
+// Name of the extractor.
+func (e Extractor) Name() string { return Name }
+
+// Version of the extractor.
+func (e Extractor) Version() int { return 0 }
+
+// Requirements of the extractor.
+func (e Extractor) Requirements() *plugin.Capabilities { return &plugin.Capabilities{} }
+
+// FileRequired returns true if the specified file is the switch state of an
+// opam switch or a switch export.
+func (e Extractor) FileRequired(api filesystem.FileAPI) bool {
+	p := api.Path()
+	dir, base := path.Split(p)
+	dir = path.Clean(dir)
+	switch {
+	case base == switchStateFile:
+		// <switch>/.opam-switch/switch-state
+		return path.Base(dir) == ".opam-switch"
+	case base == installedFile:
+		// ~/.opam/<switch>/installed
+		return path.Base(path.Dir(dir)) == ".opam"
+	default:
+		return exportFileRe.MatchString(base)
+	}
+}
+
+// Extract extracts the installed packages from an opam switch state or export.
+func (e Extractor) Extract(ctx context.Context, input *filesystem.ScanInput) (inventory.Inventory, error) {
+	var installed, roots, pinned []string
+	if path.Base(input.Path) == installedFile {
+		var err error
+		if installed, err = parseInstalled(input.Reader); err != nil {
+			return inventory.Inventory{}, fmt.Errorf("could not parse %s: %w", input.Path, err)
+		}
+		rootsPath := path.Join(path.Dir(input.Path), installedRootsFile)
+		f, err := input.FS.Open(rootsPath)
+		switch {
+		case err == nil:
+			roots, err = parseInstalled(f)
+			f.Close()
+			if err != nil {
+				return inventory.Inventory{}, fmt.Errorf("could not parse %s: %w", rootsPath, err)
+			}
+		case !errors.Is(err, fs.ErrNotExist):
+			return inventory.Inventory{}, fmt.Errorf("could not open %s: %w", rootsPath, err)
+		}
+	} else {
+		data, err := io.ReadAll(input.Reader)
+		if err != nil {
+			return inventory.Inventory{}, fmt.Errorf("could not read %s: %w", input.Path, err)
+		}
+		fields, err := parseFields(data)
+		if err != nil {
+			return inventory.Inventory{}, fmt.Errorf("could not parse %s: %w", input.Path, err)
+		}
+		installed, roots, pinned = fields["installed"], fields["roots"], fields["pinned"]
+	}
+
+	isRoot := toSet(roots)
+	isPinned := toSet(pinned)
+	var packages []*extractor.Package
+	for _, nv := range installed {
+		if err := ctx.Err(); err != nil {
+			return inventory.Inventory{}, fmt.Errorf("%s halted due to context error: %w", e.Name(), err)
+		}
+		// Package names can't contain dots, so everything after the first dot
+		// is the version.
+		name, version, ok := strings.Cut(nv, ".")
+		if !ok || name == "" || version == "" {
+			continue
+		}
+		packages = append(packages, &extractor.Package{
+			Name:      name,
+			Version:   version,
+			PURLType:  purl.TypeOpam,
+			Locations: []string{input.Path},
+			Metadata: &Metadata{
+				Root:   isRoot[nv],
+				Pinned: isPinned[nv],
+			},
+		})
+	}
+	return inventory.Inventory{Packages: packages}, nil
+}
+
+// parseInstalled parses the "<name> <version>" lines of the installed
+// packages files of opam 1 switches and returns them as "<name>.<version>".
+func parseInstalled(r io.Reader) ([]string, error) {
+	var result []string
+	s := bufio.NewScanner(r)
+	for s.Scan() {
+		fields := strings.Fields(s.Text())
+		if len(fields) != 2 {
+			continue
+		}
+		result = append(result, fields[0]+"."+fields[1])
+	}
+	return result, s.Err()
+}
+
+func toSet(values []string) map[string]bool {
+	result := make(map[string]bool, len(values))
+	for _, v := range values {
+		result[v] = true
+	}
+	return result
+}
+
+// parseFields returns the string values of the top-level fields of a file in
+// the opam file format. Sections such as the package definitions of full
+// switch exports are skipped, as are the filters and options of list items.
+// See https://opam.ocaml.org/doc/Manual.html#Common-file-format
+func parseFields(data []byte) (map[string][]string, error) {
+	tokens, err := tokenize(data)
+	if err != nil {
+		return nil, err
+	}
+	fields := make(map[string][]string)
+	depth := 0
+	for i := 0; i < len(tokens); i++ {
+		t := tokens[i]
+		switch {
+		case t.kind == tokenOpen && t.text == "{":
+			depth++
+			continue
+		case t.kind == tokenClose && t.text == "}":
+			depth--
+			continue
+		}
+		if depth > 0 || t.kind != tokenIdent || i+2 >= len(tokens) || tokens[i+1].kind != tokenColon {
+			continue
+		}
+		name := t.text
+		i += 2
+		values, end := stringValues(tokens, i)
+		fields[name] = values
+		i = end
+	}
+	return fields, nil
+}
+
+// stringValues returns the strings of the field value that starts at tokens[i],
+// which is either a single value or a list, and the index of the value's last
+// token.
+func stringValues(tokens []token, i int) ([]string, int) {
+	if tokens[i].kind != tokenOpen || tokens[i].text != "[" {
+		if tokens[i].kind == tokenString {
+			return []string{tokens[i].text}, i
+		}
+		return nil, i
+	}
+	var values []string
+	depth := 0
+	for ; i < len(tokens); i++ {
+		t := tokens[i]
+		switch t.kind {
+		case tokenOpen:
+			depth++
+		case tokenClose:
+			depth--
+			if depth == 0 {
+				return values, i
+			}
+		case tokenString:
+			// Nested lists and option blocks hold constraints and filters.
+			if depth == 1 {
+				values = append(values, t.text)
+			}
+		}
+	}
+	return values, i
+}
+
+type tokenKind int
+
+const (
+	tokenIdent tokenKind = iota
+	tokenString
+	tokenColon
+	tokenOpen
+	tokenClose
+	tokenOther
+)
+
+type token struct {
+	kind tokenKind
+	text string
+}
+
+func tokenize(data []byte) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(data); {
+		c := data[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '#':
+			end := bytes.IndexByte(data[i:], '\n')
+			if end < 0 {
+				return tokens, nil
+			}
+			i += end + 1
+		case bytes.HasPrefix(data[i:], []byte("(*")):
+			end, err := commentEnd(data, i)
+			if err != nil {
+				return nil, err
+			}
+			i = end
+		case bytes.HasPrefix(data[i:], []byte(`"""`)):
+			end := bytes.Index(data[i+3:], []byte(`"""`))
+			if end < 0 {
+				return nil, errors.New("unterminated string")
+			}
+			tokens = append(tokens, token{kind: tokenString, text: string(data[i+3 : i+3+end])})
+			i += end + 6
+		case c == '"':
+			s, end, err := quotedString(data, i)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, token{kind: tokenString, text: s})
+			i = end
+		case c == ':':
+			tokens = append(tokens, token{kind: tokenColon, text: ":"})
+			i++
+		case c == '[' || c == '{' || c == '(':
+			tokens = append(tokens, token{kind: tokenOpen, text: string(c)})
+			i++
+		case c == ']' || c == '}' || c == ')':
+			tokens = append(tokens, token{kind: tokenClose, text: string(c)})
+			i++
+		case isIdentChar(c):
+			start := i
+			for i < len(data) && isIdentChar(data[i]) {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenIdent, text: string(data[start:i])})
+		default:
+			// Operators such as "&", "|", "!" or ">=".
+			tokens = append(tokens, token{kind: tokenOther, text: string(c)})
+			i++
+		}
+	}
+	return tokens, nil
+}
+
+// commentEnd returns the index after the end of the possibly nested comment
+// that starts at data[start].
+func commentEnd(data []byte, start int) (int, error) {
+	depth := 0
+	for i := start; i+1 < len(data); i++ {
+		switch {
+		case data[i] == '(' && data[i+1] == '*':
+			depth++
+			i++
+		case data[i] == '*' && data[i+1] == ')':
+			depth--
+			i++
+			if depth == 0 {
+				return i + 1, nil
+			}
+		}
+	}
+	return 0, errors.New("unterminated comment")
+}
+
+// quotedString returns the unescaped string that starts at data[start] and
+// the index after its closing quote.
+func quotedString(data []byte, start int) (string, int, error) {
+	var sb strings.Builder
+	for i := start + 1; i < len(data); i++ {
+		switch data[i] {
+		case '"':
+			return sb.String(), i + 1, nil
+		case '\\':
+			if i+1 < len(data) {
+				i++
+			}
+			sb.WriteByte(data[i])
+		default:
+			sb.WriteByte(data[i])
+		}
+	}
+	return "", 0, errors.New("unterminated string")
+}
+
+func isIdentChar(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' ||
+		c == '_' || c == '-' || c == '+' || c == '.' || c == '/'
+}
+
+var _ filesystem.Extractor = Extractor{}
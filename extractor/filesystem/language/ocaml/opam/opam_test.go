@@ -0,0 +1,142 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opam_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem/language/ocaml/opam"
+	"github.com/google/osv-scalibr/extractor/filesystem/simplefileapi"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scalibr/testing/extracttest"
+)
+
+func TestExtractor_FileRequired(t *testing.T) {
+	tests := []struct {
+		inputPath string
+		want      bool
+	}{
+		{inputPath: "", want: false},
+		{inputPath: "home/user/.opam/default/.opam-switch/switch-state", want: true},
+		{inputPath: "path/to/project/_opam/.opam-switch/switch-state", want: true},
+		{inputPath: "home/user/.opam/system/installed", want: true},
+		{inputPath: "path/to/project/opam.export", want: true},
+		{inputPath: "path/to/project/switch.export", want: true},
+		{inputPath: "path/to/project/ci-switch.export", want: true},
+		{inputPath: "path/to/project/switch-state", want: false},
+		{inputPath: "home/user/.opam/system/installed.roots", want: false},
+		{inputPath: "var/lib/installed", want: false},
+		{inputPath: "path/to/project/data.export", want: false},
+		{inputPath: "path/to/project/myswitch.export", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.inputPath, func(t *testing.T) {
+			e := opam.Extractor{}
+			got := e.FileRequired(simplefileapi.New(tt.inputPath, nil))
+			if got != tt.want {
+				t.Errorf("FileRequired(%s, FileInfo) got = %v, want %v", tt.inputPath, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtractor_Extract(t *testing.T) {
+	tests := []extracttest.TestTableEntry{
+		{
+			Name: "invalid export",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/invalid.export",
+			},
+			WantPackages: nil,
+			WantErr:      extracttest.ContainsErrStr{Str: "could not parse"},
+		},
+		{
+			Name: "opam 2 switch state",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/default/.opam-switch/switch-state",
+			},
+			WantPackages: []*extractor.Package{
+				pkg("base-bigarray", "base", "testdata/default/.opam-switch/switch-state", &opam.Metadata{}),
+				pkg("dune", "3.10.0", "testdata/default/.opam-switch/switch-state", &opam.Metadata{Root: true}),
+				pkg("mylib", "dev", "testdata/default/.opam-switch/switch-state", &opam.Metadata{Root: true, Pinned: true}),
+				pkg("ocaml", "4.14.1", "testdata/default/.opam-switch/switch-state", &opam.Metadata{}),
+				pkg("ocaml-base-compiler", "4.14.1", "testdata/default/.opam-switch/switch-state", &opam.Metadata{Root: true}),
+				pkg("ocaml-config", "2", "testdata/default/.opam-switch/switch-state", &opam.Metadata{}),
+			},
+		},
+		{
+			Name: "switch export with package definitions",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/switch.export",
+			},
+			WantPackages: []*extractor.Package{
+				pkg("base-threads", "base", "testdata/switch.export", &opam.Metadata{}),
+				pkg("lwt", "5.7.0", "testdata/switch.export", &opam.Metadata{Root: true}),
+				pkg("ocaml-base-compiler", "5.1.1", "testdata/switch.export", &opam.Metadata{Root: true}),
+				pkg("ocplib-endian", "1.2", "testdata/switch.export", &opam.Metadata{}),
+			},
+		},
+		{
+			Name: "opam 1 installed packages",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/opam1/.opam/system/installed",
+			},
+			WantPackages: []*extractor.Package{
+				pkg("base-bigarray", "base", "testdata/opam1/.opam/system/installed", &opam.Metadata{}),
+				pkg("base-unix", "base", "testdata/opam1/.opam/system/installed", &opam.Metadata{}),
+				pkg("core", "113.33.03", "testdata/opam1/.opam/system/installed", &opam.Metadata{Root: true}),
+				pkg("ocamlfind", "1.7.1", "testdata/opam1/.opam/system/installed", &opam.Metadata{}),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			extr := opam.Extractor{}
+
+			scanInput := extracttest.GenerateScanInputMock(t, tt.InputConfig)
+			defer extracttest.CloseTestScanInput(t, scanInput)
+
+			got, err := extr.Extract(context.Background(), &scanInput)
+
+			if diff := cmp.Diff(tt.WantErr, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("%s.Extract(%q) error diff (-want +got):\n%s", extr.Name(), tt.InputConfig.Path, diff)
+				return
+			}
+
+			wantInv := inventory.Inventory{Packages: tt.WantPackages}
+			if diff := cmp.Diff(wantInv, got, cmpopts.SortSlices(extracttest.PackageCmpLess)); diff != "" {
+				t.Errorf("%s.Extract(%q) diff (-want +got):\n%s", extr.Name(), tt.InputConfig.Path, diff)
+			}
+		})
+	}
+}
+
+func pkg(name, version, location string, m *opam.Metadata) *extractor.Package {
+	return &extractor.Package{
+		Name:      name,
+		Version:   version,
+		PURLType:  purl.TypeOpam,
+		Locations: []string{location},
+		Metadata:  m,
+	}
+}
@@ -0,0 +1,136 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package composerinstalled extracts the vendor/composer/installed.json files
+// that Composer writes when installing the dependencies of a PHP application.
+package composerinstalled
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/extractor/filesystem/osv"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/plugin"
+	"github.com/google/osv-scalibr/purl"
+)
+
+const (
+	// Name is the unique name of this extractor.
+	Name = "php/composerinstalled"
+)
+
+type composerPackage struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	Dist    struct {
+		Reference string `json:"reference"`
+	} `json:"dist"`
+}
+
+// installedJSON is the format written by Composer 2. Composer 1 writes a plain
+// list of packages instead.
+type installedJSON struct {
+	Packages        []composerPackage `json:"packages"`
+	DevPackageNames []string          `json:"dev-package-names"`
+}
+
+// Extractor extracts vendor/composer/installed.json files.
+type Extractor struct{}
+
+// New returns a new instance of the extractor.
+func New() filesystem.Extractor { return &Extractor{} }
+
+// Name of the extractor.
+func (e Extractor) Name() string { return Name }
+
+// Version of the extractor.
+func (e Extractor) Version() int { return 0 }
+
+// Requirements of the extractor.
+func (e Extractor) Requirements() *plugin.Capabilities {
+	return &plugin.Capabilities{}
+}
+
+// FileRequired returns true if the specified file is a Composer installed.json
+// file. The vendor directory can be renamed so only its composer subdirectory
+// is checked.
+func (e Extractor) FileRequired(api filesystem.FileAPI) bool {
+	path := filepath.ToSlash(api.Path())
+	return path == "composer/installed.json" || strings.HasSuffix(path, "/composer/installed.json")
+}
+
+// Extract extracts packages from an installed.json file passed through the scan input.
+func (e Extractor) Extract(ctx context.Context, input *filesystem.ScanInput) (inventory.Inventory, error) {
+	content, err := io.ReadAll(input.Reader)
+	if err != nil {
+		return inventory.Inventory{}, fmt.Errorf("could not extract: %w", err)
+	}
+
+	var installed installedJSON
+	if bytes.HasPrefix(bytes.TrimSpace(content), []byte("[")) {
+		err = json.Unmarshal(content, &installed.Packages)
+	} else {
+		err = json.Unmarshal(content, &installed)
+	}
+	if err != nil {
+		return inventory.Inventory{}, fmt.Errorf("could not extract: %w", err)
+	}
+
+	packages := make([]*extractor.Package, 0, len(installed.Packages))
+	for _, pkg := range installed.Packages {
+		if pkg.Name == "" || pkg.Version == "" {
+			continue
+		}
+		groups := []string{}
+		if slices.Contains(installed.DevPackageNames, pkg.Name) {
+			groups = []string{"dev"}
+		}
+		packages = append(packages, buildPackage(input, pkg, groups))
+	}
+
+	return inventory.Inventory{Packages: packages}, nil
+}
+
+func buildPackage(input *filesystem.ScanInput, pkg composerPackage, groups []string) *extractor.Package {
+	commit := pkg.Dist.Reference
+
+	// a dot means the reference is likely a tag, rather than a commit
+	if strings.Contains(commit, ".") {
+		commit = ""
+	}
+
+	return &extractor.Package{
+		Name:      pkg.Name,
+		Version:   pkg.Version,
+		PURLType:  purl.TypeComposer,
+		Locations: []string{input.Path},
+		SourceCode: &extractor.SourceCodeIdentifier{
+			Commit: commit,
+		},
+		Metadata: osv.DepGroupMetadata{
+			DepGroupVals: groups,
+		},
+	}
+}
+
+var _ filesystem.Extractor = Extractor{}
@@ -0,0 +1,183 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package composerinstalled_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem/language/php/composerinstalled"
+	"github.com/google/osv-scalibr/extractor/filesystem/osv"
+	"github.com/google/osv-scalibr/extractor/filesystem/simplefileapi"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scalibr/testing/extracttest"
+)
+
+func TestExtractor_FileRequired(t *testing.T) {
+	tests := []struct {
+		name      string
+		inputPath string
+		want      bool
+	}{
+		{
+			name:      "empty name",
+			inputPath: "",
+			want:      false,
+		},
+		{
+			name:      "installed.json in vendor dir",
+			inputPath: "var/www/app/vendor/composer/installed.json",
+			want:      true,
+		},
+		{
+			name:      "installed.json in renamed vendor dir",
+			inputPath: "app/lib/composer/installed.json",
+			want:      true,
+		},
+		{
+			name:      "installed.json outside of composer dir",
+			inputPath: "app/vendor/installed.json",
+			want:      false,
+		},
+		{
+			name:      "installed.php",
+			inputPath: "app/vendor/composer/installed.php",
+			want:      false,
+		},
+		{
+			name:      "installed.json as substring",
+			inputPath: "app/vendor/mycomposer/installed.json",
+			want:      false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := composerinstalled.Extractor{}
+			got := e.FileRequired(simplefileapi.New(tt.inputPath, nil))
+			if got != tt.want {
+				t.Errorf("FileRequired(%s, FileInfo) got = %v, want %v", tt.inputPath, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtractor_Extract(t *testing.T) {
+	tests := []extracttest.TestTableEntry{
+		{
+			Name: "invalid json",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/not-json.txt",
+			},
+			WantPackages: nil,
+			WantErr:      extracttest.ContainsErrStr{Str: "could not extract"},
+		},
+		{
+			Name: "no packages",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/empty.json",
+			},
+			WantPackages: []*extractor.Package{},
+		},
+		{
+			Name: "composer 2",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/composer2.json",
+			},
+			WantPackages: []*extractor.Package{
+				{
+					Name:      "guzzlehttp/guzzle",
+					Version:   "7.8.1",
+					PURLType:  purl.TypeComposer,
+					Locations: []string{"testdata/composer2.json"},
+					SourceCode: &extractor.SourceCodeIdentifier{
+						Commit: "41042bc7ab002487b876a0683fc8dce04ddce104",
+					},
+					Metadata: osv.DepGroupMetadata{
+						DepGroupVals: []string{},
+					},
+				},
+				{
+					Name:      "monolog/monolog",
+					Version:   "3.5.0",
+					PURLType:  purl.TypeComposer,
+					Locations: []string{"testdata/composer2.json"},
+					SourceCode: &extractor.SourceCodeIdentifier{
+						Commit: "",
+					},
+					Metadata: osv.DepGroupMetadata{
+						DepGroupVals: []string{},
+					},
+				},
+				{
+					Name:      "phpunit/phpunit",
+					Version:   "10.5.9",
+					PURLType:  purl.TypeComposer,
+					Locations: []string{"testdata/composer2.json"},
+					SourceCode: &extractor.SourceCodeIdentifier{
+						Commit: "0bd663704f0165c9e76fe4f06ffa6a1ca727fdbe",
+					},
+					Metadata: osv.DepGroupMetadata{
+						DepGroupVals: []string{"dev"},
+					},
+				},
+			},
+		},
+		{
+			Name: "composer 1",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/composer1.json",
+			},
+			WantPackages: []*extractor.Package{
+				{
+					Name:      "symfony/polyfill-mbstring",
+					Version:   "v1.17.0",
+					PURLType:  purl.TypeComposer,
+					Locations: []string{"testdata/composer1.json"},
+					SourceCode: &extractor.SourceCodeIdentifier{
+						Commit: "fa79b11539418b02fc5e1897267673ba2c19419c",
+					},
+					Metadata: osv.DepGroupMetadata{
+						DepGroupVals: []string{},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			extr := composerinstalled.Extractor{}
+
+			scanInput := extracttest.GenerateScanInputMock(t, tt.InputConfig)
+			defer extracttest.CloseTestScanInput(t, scanInput)
+
+			got, err := extr.Extract(context.Background(), &scanInput)
+
+			if diff := cmp.Diff(tt.WantErr, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("%s.Extract(%q) error diff (-want +got):\n%s", extr.Name(), tt.InputConfig.Path, diff)
+				return
+			}
+
+			wantInv := inventory.Inventory{Packages: tt.WantPackages}
+			if diff := cmp.Diff(wantInv, got, cmpopts.SortSlices(extracttest.PackageCmpLess)); diff != "" {
+				t.Errorf("%s.Extract(%q) diff (-want +got):\n%s", extr.Name(), tt.InputConfig.Path, diff)
+			}
+		})
+	}
+}
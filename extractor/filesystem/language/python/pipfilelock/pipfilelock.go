@@ -27,6 +27,7 @@ import (
 	"github.com/google/osv-scalibr/extractor"
 	"github.com/google/osv-scalibr/extractor/filesystem"
 	"github.com/google/osv-scalibr/extractor/filesystem/osv"
+	"github.com/google/osv-scalibr/extractor/filesystem/targetenv"
 	"github.com/google/osv-scalibr/inventory"
 	"github.com/google/osv-scalibr/plugin"
 	"github.com/google/osv-scalibr/purl"
@@ -39,6 +40,7 @@ const (
 
 type pipenvPackage struct {
 	Version string `json:"version"`
+	Markers string `json:"markers"`
 }
 
 type pipenvLockFile struct {
@@ -80,8 +82,8 @@ func (e Extractor) Extract(ctx context.Context, input *filesystem.ScanInput) (in
 
 	details := make(map[string]*extractor.Package)
 
-	addPkgDetails(details, parsedLockfile.Packages, "")
-	addPkgDetails(details, parsedLockfile.PackagesDev, "dev")
+	addPkgDetails(details, parsedLockfile.Packages, "", input.TargetEnv)
+	addPkgDetails(details, parsedLockfile.PackagesDev, "dev", input.TargetEnv)
 
 	for key := range details {
 		details[key].Locations = []string{input.Path}
@@ -90,12 +92,17 @@ func (e Extractor) Extract(ctx context.Context, input *filesystem.ScanInput) (in
 	return inventory.Inventory{Packages: slices.Collect(maps.Values(details))}, nil
 }
 
-func addPkgDetails(details map[string]*extractor.Package, packages map[string]pipenvPackage, group string) {
+func addPkgDetails(details map[string]*extractor.Package, packages map[string]pipenvPackage, group string, env *targetenv.Env) {
 	for name, pipenvPackage := range packages {
 		if pipenvPackage.Version == "" {
 			continue
 		}
 
+		// Skip packages that pipenv doesn't install on the target environment.
+		if !env.MatchesPEP508Marker(pipenvPackage.Markers) {
+			continue
+		}
+
 		// All pipenv package versions should be pinned with a ==
 		// If it is not, this lockfile is not in the format we expect.
 		if !strings.HasPrefix(pipenvPackage.Version, "==") || len(pipenvPackage.Version) < 3 {
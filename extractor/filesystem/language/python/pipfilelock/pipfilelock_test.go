@@ -24,6 +24,7 @@ import (
 	"github.com/google/osv-scalibr/extractor/filesystem/language/python/pipfilelock"
 	"github.com/google/osv-scalibr/extractor/filesystem/osv"
 	"github.com/google/osv-scalibr/extractor/filesystem/simplefileapi"
+	"github.com/google/osv-scalibr/extractor/filesystem/targetenv"
 	"github.com/google/osv-scalibr/inventory"
 	"github.com/google/osv-scalibr/purl"
 	"github.com/google/osv-scalibr/testing/extracttest"
@@ -254,3 +255,80 @@ func TestExtractor_Extract(t *testing.T) {
 		})
 	}
 }
+
+func TestExtractor_Extract_TargetEnv(t *testing.T) {
+	path := "testdata/markers.json"
+	tests := []struct {
+		name         string
+		env          *targetenv.Env
+		wantPackages []*extractor.Package
+	}{
+		{
+			name: "linux",
+			env:  &targetenv.Env{OS: "linux", Arch: "amd64", PythonVersion: "3.12.1"},
+			wantPackages: []*extractor.Package{
+				{
+					Name:      "pytest",
+					Version:   "8.0.0",
+					PURLType:  purl.TypePyPi,
+					Locations: []string{path},
+					Metadata:  osv.DepGroupMetadata{DepGroupVals: []string{}},
+				},
+			},
+		},
+		{
+			name: "windows",
+			env:  &targetenv.Env{OS: "windows", Arch: "amd64", PythonVersion: "3.10"},
+			wantPackages: []*extractor.Package{
+				{
+					Name:      "colorama",
+					Version:   "0.4.6",
+					PURLType:  purl.TypePyPi,
+					Locations: []string{path},
+					Metadata:  osv.DepGroupMetadata{DepGroupVals: []string{}},
+				},
+				{
+					Name:      "exceptiongroup",
+					Version:   "1.2.0",
+					PURLType:  purl.TypePyPi,
+					Locations: []string{path},
+					Metadata:  osv.DepGroupMetadata{DepGroupVals: []string{}},
+				},
+				{
+					Name:      "pytest",
+					Version:   "8.0.0",
+					PURLType:  purl.TypePyPi,
+					Locations: []string{path},
+					Metadata:  osv.DepGroupMetadata{DepGroupVals: []string{}},
+				},
+				{
+					Name:      "pywin32-ctypes",
+					Version:   "0.2.2",
+					PURLType:  purl.TypePyPi,
+					Locations: []string{path},
+					Metadata:  osv.DepGroupMetadata{DepGroupVals: []string{"dev"}},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			extr := pipfilelock.Extractor{}
+
+			scanInput := extracttest.GenerateScanInputMock(t, extracttest.ScanInputMockConfig{Path: path})
+			defer extracttest.CloseTestScanInput(t, scanInput)
+			scanInput.TargetEnv = tt.env
+
+			got, err := extr.Extract(context.Background(), &scanInput)
+			if err != nil {
+				t.Fatalf("%s.Extract(%q): %v", extr.Name(), path, err)
+			}
+
+			wantInv := inventory.Inventory{Packages: tt.wantPackages}
+			if diff := cmp.Diff(wantInv, got, cmpopts.SortSlices(extracttest.PackageCmpLess)); diff != "" {
+				t.Errorf("%s.Extract(%q) diff (-want +got):\n%s", extr.Name(), path, diff)
+			}
+		})
+	}
+}
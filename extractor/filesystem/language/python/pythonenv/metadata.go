@@ -0,0 +1,64 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pythonenv
+
+import (
+	pb "github.com/google/osv-scalibr/binary/proto/scan_result_go_proto"
+)
+
+// Metadata holds the details of a Python environment.
+type Metadata struct {
+	// The type of the environment, TypeVirtualenv or TypeConda.
+	Type string `json:"type"`
+	// The version of the environment's Python interpreter, if known.
+	PythonVersion string `json:"pythonVersion,omitempty"`
+	// The Python implementation, e.g. "CPython", if known.
+	Implementation string `json:"implementation,omitempty"`
+	// The directory of the base interpreter of a virtualenv.
+	Home string `json:"home,omitempty"`
+}
+
+// SetProto sets the PythonEnvironmentMetadata field in the Package proto.
+func (m *Metadata) SetProto(p *pb.Package) {
+	if m == nil {
+		return
+	}
+	if p == nil {
+		return
+	}
+
+	p.Metadata = &pb.Package_PythonEnvironmentMetadata{
+		PythonEnvironmentMetadata: &pb.PythonEnvironmentMetadata{
+			Type:           m.Type,
+			PythonVersion:  m.PythonVersion,
+			Implementation: m.Implementation,
+			Home:           m.Home,
+		},
+	}
+}
+
+// ToStruct converts the PythonEnvironmentMetadata proto to a Metadata struct.
+func ToStruct(m *pb.PythonEnvironmentMetadata) *Metadata {
+	if m == nil {
+		return nil
+	}
+
+	return &Metadata{
+		Type:           m.GetType(),
+		PythonVersion:  m.GetPythonVersion(),
+		Implementation: m.GetImplementation(),
+		Home:           m.GetHome(),
+	}
+}
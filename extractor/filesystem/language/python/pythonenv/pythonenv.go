@@ -0,0 +1,193 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package pythonenv extracts Python virtualenvs and conda environments so that
+// the packages installed into them can be grouped by environment.
+package pythonenv
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	scalibrfs "github.com/google/osv-scalibr/fs"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/plugin"
+)
+
+const (
+	// Name is the unique name of this extractor.
+	Name = "python/pythonenv"
+
+	// TypeVirtualenv is the type of environments created by venv, virtualenv or uv.
+	TypeVirtualenv = "virtualenv"
+	// TypeConda is the type of conda environments.
+	TypeConda = "conda"
+)
+
+// condaPythonRe matches the conda-meta file of the Python interpreter package,
+// e.g. "python-3.11.4-h955ad1f_0.json", and captures its version.
+var condaPythonRe = regexp.MustCompile(`^python-(\d[^-]*)-[^-]+\.json$`)
+
+// Extractor extracts Python environments from pyvenv.cfg files and conda-meta
+// directories.
+type Extractor struct{}
+
+// New returns a new instance of the extractor.
+func New() filesystem.Extractor { return &Extractor{} }
+
+// Name of the extractor.
+func (e Extractor) Name() string { return Name }
+
+// Version of the extractor.
+func (e Extractor) Version() int { return 0 }
+
+// Requirements of the extractor.
+func (e Extractor) Requirements() *plugin.Capabilities {
+	return &plugin.Capabilities{}
+}
+
+// FileRequired returns true if the specified file is the pyvenv.cfg file of a
+// virtualenv or the history file of a conda environment.
+func (e Extractor) FileRequired(api filesystem.FileAPI) bool {
+	p := filepath.ToSlash(api.Path())
+	return path.Base(p) == "pyvenv.cfg" || strings.HasSuffix("/"+p, "/conda-meta/history")
+}
+
+// Extract returns the environment described by the file passed through the scan
+// input. The environment is reported as a package without a PURL whose name is
+// the environment's root directory and whose version is the version of its
+// Python interpreter.
+func (e Extractor) Extract(ctx context.Context, input *filesystem.ScanInput) (inventory.Inventory, error) {
+	p := filepath.ToSlash(input.Path)
+	var root string
+	var m *Metadata
+	var err error
+	if path.Base(p) == "pyvenv.cfg" {
+		root = path.Dir(p)
+		m, err = parsePyvenvCfg(input)
+	} else {
+		root = path.Dir(path.Dir(p))
+		m, err = condaMetadata(input.FS, path.Dir(p))
+	}
+	if err != nil {
+		return inventory.Inventory{}, fmt.Errorf("could not extract: %w", err)
+	}
+	return inventory.Inventory{Packages: []*extractor.Package{{
+		Name:      root,
+		Version:   m.PythonVersion,
+		Locations: []string{input.Path},
+		Metadata:  m,
+	}}}, nil
+}
+
+// parsePyvenvCfg reads the environment metadata from a pyvenv.cfg file.
+func parsePyvenvCfg(input *filesystem.ScanInput) (*Metadata, error) {
+	cfg := map[string]string{}
+	s := bufio.NewScanner(input.Reader)
+	for s.Scan() {
+		key, value, ok := strings.Cut(s.Text(), "=")
+		if !ok {
+			continue
+		}
+		cfg[strings.ToLower(strings.TrimSpace(key))] = strings.TrimSpace(value)
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+
+	// venv writes "version" while virtualenv and uv write "version_info", e.g.
+	// "3.11.4.final.0".
+	version := cfg["version"]
+	if version == "" {
+		parts := strings.Split(cfg["version_info"], ".")
+		version = strings.Join(parts[:min(len(parts), 3)], ".")
+	}
+	return &Metadata{
+		Type:           TypeVirtualenv,
+		PythonVersion:  version,
+		Implementation: cfg["implementation"],
+		Home:           cfg["home"],
+	}, nil
+}
+
+// condaMetadata reads the environment metadata from the conda-meta directory
+// of a conda environment.
+func condaMetadata(fsys scalibrfs.FS, condaMeta string) (*Metadata, error) {
+	m := &Metadata{Type: TypeConda}
+	if fsys == nil {
+		return m, nil
+	}
+	entries, err := fsys.ReadDir(condaMeta)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range entries {
+		if match := condaPythonRe.FindStringSubmatch(e.Name()); match != nil {
+			m.PythonVersion = match[1]
+			break
+		}
+	}
+	return m, nil
+}
+
+// EnvironmentRoot returns the root directory of the virtualenv or conda
+// environment whose site-packages directory contains the given path, or "" if
+// the path isn't inside an environment.
+func EnvironmentRoot(fsys scalibrfs.FS, p string) string {
+	if fsys == nil {
+		return ""
+	}
+	parts := strings.Split(filepath.ToSlash(p), "/")
+	var rootParts []string
+	for i, part := range parts {
+		if part != "site-packages" {
+			continue
+		}
+		switch {
+		// lib/python3.11/site-packages on Unix.
+		case i >= 2 && parts[i-2] == "lib" && strings.HasPrefix(parts[i-1], "python"):
+			rootParts = parts[:i-2]
+		// Lib/site-packages on Windows.
+		case i >= 1 && parts[i-1] == "Lib":
+			rootParts = parts[:i-1]
+		default:
+			continue
+		}
+		break
+	}
+	if rootParts == nil {
+		return ""
+	}
+
+	root := path.Join(rootParts...)
+	if root == "" {
+		root = "."
+	}
+	if _, err := fsys.Stat(path.Join(root, "pyvenv.cfg")); err == nil {
+		return root
+	}
+	if info, err := fsys.Stat(path.Join(root, "conda-meta")); err == nil && info.IsDir() {
+		return root
+	}
+	return ""
+}
+
+var _ filesystem.Extractor = Extractor{}
@@ -0,0 +1,159 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pythonenv_test
+
+import (
+	"context"
+	"io/fs"
+	"testing"
+	"testing/fstest"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem/language/python/pythonenv"
+	"github.com/google/osv-scalibr/extractor/filesystem/simplefileapi"
+	"github.com/google/osv-scalibr/fs/memfs"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/testing/extracttest"
+)
+
+func TestFileRequired(t *testing.T) {
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{path: "pyvenv.cfg", want: true},
+		{path: "home/user/project/.venv/pyvenv.cfg", want: true},
+		{path: "opt/conda/envs/ml/conda-meta/history", want: true},
+		{path: "conda-meta/history", want: true},
+		{path: "opt/conda/envs/ml/conda-meta/python-3.11.4-h955ad1f_0.json", want: false},
+		{path: "home/user/history", want: false},
+		{path: "home/user/pyvenv.cfg.bak", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			e := pythonenv.New()
+			if got := e.FileRequired(simplefileapi.New(tt.path, nil)); got != tt.want {
+				t.Errorf("FileRequired(%q) = %v, want %v", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtract(t *testing.T) {
+	tests := []extracttest.TestTableEntry{
+		{
+			Name:        "venv",
+			InputConfig: extracttest.ScanInputMockConfig{Path: "testdata/venv/pyvenv.cfg"},
+			WantPackages: []*extractor.Package{{
+				Name:      "testdata/venv",
+				Version:   "3.11.4",
+				Locations: []string{"testdata/venv/pyvenv.cfg"},
+				Metadata: &pythonenv.Metadata{
+					Type:          pythonenv.TypeVirtualenv,
+					PythonVersion: "3.11.4",
+					Home:          "/usr/bin",
+				},
+			}},
+		},
+		{
+			Name:        "virtualenv",
+			InputConfig: extracttest.ScanInputMockConfig{Path: "testdata/virtualenv/pyvenv.cfg"},
+			WantPackages: []*extractor.Package{{
+				Name:      "testdata/virtualenv",
+				Version:   "3.12.1",
+				Locations: []string{"testdata/virtualenv/pyvenv.cfg"},
+				Metadata: &pythonenv.Metadata{
+					Type:           pythonenv.TypeVirtualenv,
+					PythonVersion:  "3.12.1",
+					Implementation: "CPython",
+					Home:           "/opt/python/3.12.1/bin",
+				},
+			}},
+		},
+		{
+			Name:        "conda",
+			InputConfig: extracttest.ScanInputMockConfig{Path: "testdata/envs/ml/conda-meta/history"},
+			WantPackages: []*extractor.Package{{
+				Name:      "testdata/envs/ml",
+				Version:   "3.11.4",
+				Locations: []string{"testdata/envs/ml/conda-meta/history"},
+				Metadata: &pythonenv.Metadata{
+					Type:          pythonenv.TypeConda,
+					PythonVersion: "3.11.4",
+				},
+			}},
+		},
+		{
+			Name:        "conda_without_python",
+			InputConfig: extracttest.ScanInputMockConfig{Path: "testdata/envs/empty/conda-meta/history"},
+			WantPackages: []*extractor.Package{{
+				Name:      "testdata/envs/empty",
+				Locations: []string{"testdata/envs/empty/conda-meta/history"},
+				Metadata:  &pythonenv.Metadata{Type: pythonenv.TypeConda},
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			scanInput := extracttest.GenerateScanInputMock(t, tt.InputConfig)
+			defer extracttest.CloseTestScanInput(t, scanInput)
+
+			got, err := pythonenv.New().Extract(context.Background(), &scanInput)
+			if !cmp.Equal(err, tt.WantErr, cmpopts.EquateErrors()) {
+				t.Fatalf("Extract(%s) error: %v, want %v", tt.InputConfig.Path, err, tt.WantErr)
+			}
+			want := inventory.Inventory{Packages: tt.WantPackages}
+			if diff := cmp.Diff(want, got, cmpopts.SortSlices(extracttest.PackageCmpLess)); diff != "" {
+				t.Errorf("Extract(%s) (-want +got):\n%s", tt.InputConfig.Path, diff)
+			}
+		})
+	}
+}
+
+func TestEnvironmentRoot(t *testing.T) {
+	fsys := memfs.New(fstest.MapFS{
+		"home/user/venv/pyvenv.cfg":                           {},
+		"home/user/venv/lib/python3.11/site-packages/a.py":    {},
+		"opt/conda/envs/ml/conda-meta/history":                {},
+		"opt/conda/envs/ml/lib/python3.11/site-packages/b.py": {},
+		"win/venv/pyvenv.cfg":                                 {},
+		"win/venv/Lib/site-packages/c.py":                     {},
+		"usr/lib/python3.11/site-packages/d.py":               {},
+		"pyvenv.cfg":                                          {},
+		"lib/python3.12/site-packages/e.py":                   {},
+		"home/user/other/conda-meta":                          {Mode: fs.ModePerm},
+	})
+
+	tests := []struct {
+		path string
+		want string
+	}{
+		{path: "home/user/venv/lib/python3.11/site-packages/requests-2.31.0.dist-info/METADATA", want: "home/user/venv"},
+		{path: "opt/conda/envs/ml/lib/python3.11/site-packages/numpy-1.26.0.dist-info/METADATA", want: "opt/conda/envs/ml"},
+		{path: "win/venv/Lib/site-packages/six-1.16.0.dist-info/METADATA", want: "win/venv"},
+		{path: "lib/python3.12/site-packages/six-1.16.0.dist-info/METADATA", want: "."},
+		{path: "usr/lib/python3.11/site-packages/six-1.16.0.dist-info/METADATA", want: ""},
+		{path: "home/user/other/lib/python3.11/site-packages/six-1.16.0.dist-info/METADATA", want: ""},
+		{path: "home/user/venv/six-1.16.0.dist-info/METADATA", want: ""},
+	}
+	for _, tt := range tests {
+		if got := pythonenv.EnvironmentRoot(fsys, tt.path); got != tt.want {
+			t.Errorf("EnvironmentRoot(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
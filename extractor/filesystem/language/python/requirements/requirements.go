@@ -25,6 +25,8 @@ import (
 
 	"github.com/google/osv-scalibr/extractor"
 	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/extractor/filesystem/internal/suppression"
+	"github.com/google/osv-scalibr/extractor/filesystem/targetenv"
 	scalibrfs "github.com/google/osv-scalibr/fs"
 	"github.com/google/osv-scalibr/inventory"
 	"github.com/google/osv-scalibr/log"
@@ -145,7 +147,7 @@ func (e Extractor) Extract(ctx context.Context, input *filesystem.ScanInput) (in
 	// Additional paths to recursive files found during extraction.
 	var extraPaths pathQueue
 	var pkgs []*extractor.Package
-	newRepos, newPaths, err := extractFromPath(input.Reader, input.Path)
+	newRepos, newPaths, err := extractFromPath(input.Reader, input.Path, input.TargetEnv)
 	if err != nil {
 		return inventory.Inventory{}, err
 	}
@@ -156,13 +158,13 @@ func (e Extractor) Extract(ctx context.Context, input *filesystem.ScanInput) (in
 	pkgs = append(pkgs, newRepos...)
 
 	// Process all the recursive files that we found.
-	extraPKG := extractFromExtraPaths(input.Path, extraPaths, input.FS)
+	extraPKG := extractFromExtraPaths(input.Path, extraPaths, input.FS, input.TargetEnv)
 	pkgs = append(pkgs, extraPKG...)
 
 	return inventory.Inventory{Packages: pkgs}, nil
 }
 
-func extractFromExtraPaths(initPath string, extraPaths pathQueue, fs scalibrfs.FS) []*extractor.Package {
+func extractFromExtraPaths(initPath string, extraPaths pathQueue, fs scalibrfs.FS, env *targetenv.Env) []*extractor.Package {
 	// File paths with packages already found in this extraction.
 	// We store these to remove duplicates in diamond dependency cases and prevent
 	// infinite loops in misconfigured lockfiles with cyclical deps.
@@ -175,7 +177,7 @@ func extractFromExtraPaths(initPath string, extraPaths pathQueue, fs scalibrfs.F
 		if _, exists := found[path]; exists {
 			continue
 		}
-		newPKG, newPaths, err := openAndExtractFromFile(path, fs)
+		newPKG, newPaths, err := openAndExtractFromFile(path, fs, env)
 		if err != nil {
 			log.Warnf("openAndExtractFromFile(%s): %w", path, err)
 			continue
@@ -192,24 +194,41 @@ func extractFromExtraPaths(initPath string, extraPaths pathQueue, fs scalibrfs.F
 	return pkgs
 }
 
-func openAndExtractFromFile(path string, fs scalibrfs.FS) ([]*extractor.Package, pathQueue, error) {
+func openAndExtractFromFile(path string, fs scalibrfs.FS, env *targetenv.Env) ([]*extractor.Package, pathQueue, error) {
 	reader, err := fs.Open(filepath.ToSlash(path))
 	if err != nil {
 		return nil, nil, err
 	}
 	defer reader.Close()
-	return extractFromPath(reader, path)
+	return extractFromPath(reader, path, env)
 }
 
-func extractFromPath(reader io.Reader, path string) ([]*extractor.Package, pathQueue, error) {
+func extractFromPath(reader io.Reader, path string, env *targetenv.Env) ([]*extractor.Package, pathQueue, error) {
 	var pkgs []*extractor.Package
 	var extraPaths pathQueue
+	// Comments on the lines directly above the next requirement.
+	var pendingComments []string
 	s := bufio.NewScanner(reader)
 	for s.Scan() {
-		l := readLine(s, &strings.Builder{})
+		var comments []string
+		l := readLine(s, &strings.Builder{}, &comments)
+		if strings.TrimSpace(l) == "" {
+			if len(comments) == 0 {
+				// Suppression comments only apply to the requirement directly below them.
+				pendingComments = nil
+			}
+			pendingComments = append(pendingComments, comments...)
+			continue
+		}
+		comments = append(pendingComments, comments...)
+		pendingComments = nil
 		// Per-requirement options may be present. We extract the --hash options, and discard the others.
 		l, hashOptions := splitPerRequirementOptions(l)
 		requirement := strings.TrimSpace(l)
+		if _, marker, ok := strings.Cut(requirement, ";"); ok && !env.MatchesPEP508Marker(marker) {
+			// The requirement isn't installed on the target environment.
+			continue
+		}
 
 		l = removeWhiteSpaces(l)
 		l = ignorePythonSpecifier(l)
@@ -257,6 +276,7 @@ func extractFromPath(reader io.Reader, path string) ([]*extractor.Package, pathQ
 				VersionComparator:      comp,
 				Requirement:            requirement,
 			},
+			ExploitabilitySignals: suppression.ParseAll(comments, Name),
 		})
 	}
 
@@ -264,9 +284,14 @@ func extractFromPath(reader io.Reader, path string) ([]*extractor.Package, pathQ
 }
 
 // readLine reads a line from the scanner, removes comments and joins it with
-// the next line if it ends with a backslash.
-func readLine(scanner *bufio.Scanner, builder *strings.Builder) string {
+// the next line if it ends with a backslash. The text of the removed comments
+// is appended to comments.
+func readLine(scanner *bufio.Scanner, builder *strings.Builder, comments *[]string) string {
 	l := scanner.Text()
+	if c := reComment.FindString(l); c != "" {
+		_, text, _ := strings.Cut(c, "#")
+		*comments = append(*comments, text)
+	}
 	l = removeComments(l)
 
 	if hasEnvVariable(l) {
@@ -279,7 +304,7 @@ func readLine(scanner *bufio.Scanner, builder *strings.Builder) string {
 	if strings.HasSuffix(l, `\`) {
 		builder.WriteString(l[:len(l)-1])
 		scanner.Scan()
-		return readLine(scanner, builder)
+		return readLine(scanner, builder, comments)
 	}
 
 	builder.WriteString(l)
@@ -26,8 +26,10 @@ import (
 	"github.com/google/osv-scalibr/extractor/filesystem/internal/units"
 	"github.com/google/osv-scalibr/extractor/filesystem/language/python/requirements"
 	"github.com/google/osv-scalibr/extractor/filesystem/simplefileapi"
+	"github.com/google/osv-scalibr/extractor/filesystem/targetenv"
 	scalibrfs "github.com/google/osv-scalibr/fs"
 	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/inventory/vex"
 	"github.com/google/osv-scalibr/purl"
 	"github.com/google/osv-scalibr/stats"
 	"github.com/google/osv-scalibr/testing/fakefs"
@@ -135,6 +137,7 @@ func TestExtract(t *testing.T) {
 	tests := []struct {
 		name             string
 		path             string
+		targetEnv        *targetenv.Env
 		wantPackages     []*extractor.Package
 		wantResultMetric stats.FileExtractedResult
 	}{
@@ -474,6 +477,84 @@ func TestExtract(t *testing.T) {
 			},
 			wantResultMetric: stats.FileExtractedResultSuccess,
 		},
+		{
+			name:      "environment markers evaluated against target",
+			path:      "testdata/markers.txt",
+			targetEnv: &targetenv.Env{OS: "windows", Arch: "amd64", PythonVersion: "3.10"},
+			wantPackages: []*extractor.Package{
+				{
+					Name:     "pywin32",
+					Version:  "306",
+					PURLType: purl.TypePyPi,
+					Metadata: &requirements.Metadata{Requirement: `pywin32==306; sys_platform == "win32"`},
+				},
+				{
+					Name:     "tomli",
+					Version:  "2.0.1",
+					PURLType: purl.TypePyPi,
+					Metadata: &requirements.Metadata{Requirement: `tomli==2.0.1 ; python_version < "3.11"`},
+				},
+				{
+					Name:     "colorama",
+					Version:  "0.4.6",
+					PURLType: purl.TypePyPi,
+					Metadata: &requirements.Metadata{Requirement: `colorama==0.4.6; os_name == "nt" or extra == "color"`},
+				},
+				{
+					Name:     "requests",
+					Version:  "2.31.0",
+					PURLType: purl.TypePyPi,
+					Metadata: &requirements.Metadata{Requirement: "requests==2.31.0"},
+				},
+			},
+			wantResultMetric: stats.FileExtractedResultSuccess,
+		},
+		{
+			name: "suppression comments",
+			path: "testdata/suppressions.txt",
+			wantPackages: []*extractor.Package{
+				{
+					Name:     "requests",
+					Version:  "2.19.0",
+					PURLType: purl.TypePyPi,
+					Metadata: &requirements.Metadata{Requirement: "requests==2.19.0", VersionComparator: "=="},
+					ExploitabilitySignals: []*vex.PackageExploitabilitySignal{{
+						Plugin:          requirements.Name,
+						VulnIdentifiers: []string{"CVE-2018-18074"},
+						Reason:          "we never follow redirects to untrusted hosts",
+					}},
+				},
+				{
+					Name:     "urllib3",
+					Version:  "1.24.1",
+					PURLType: purl.TypePyPi,
+					Metadata: &requirements.Metadata{Requirement: "urllib3==1.24.1", VersionComparator: "=="},
+					ExploitabilitySignals: []*vex.PackageExploitabilitySignal{{
+						Plugin:          requirements.Name,
+						VulnIdentifiers: []string{"GHSA-mh33-7rrq-662w", "PYSEC-2019-132"},
+						Justification:   vex.VulnerableCodeNotInExecutePath,
+					}},
+				},
+				{
+					Name:     "flask",
+					Version:  "1.0",
+					PURLType: purl.TypePyPi,
+					Metadata: &requirements.Metadata{Requirement: "flask==1.0", VersionComparator: "=="},
+				},
+				{
+					Name:     "jinja2",
+					Version:  "2.10",
+					PURLType: purl.TypePyPi,
+					Metadata: &requirements.Metadata{Requirement: "jinja2==2.10", VersionComparator: "=="},
+					ExploitabilitySignals: []*vex.PackageExploitabilitySignal{{
+						Plugin:          requirements.Name,
+						MatchesAllVulns: true,
+						Reason:          "vendored and patched",
+					}},
+				},
+			},
+			wantResultMetric: stats.FileExtractedResultSuccess,
+		},
 	}
 
 	// fill Location and Extractor
@@ -517,7 +598,7 @@ func TestExtract(t *testing.T) {
 				t.Fatalf("Stat(): %v", err)
 			}
 
-			input := &filesystem.ScanInput{FS: scalibrfs.DirFS("."), Path: tt.path, Info: info, Reader: r}
+			input := &filesystem.ScanInput{FS: scalibrfs.DirFS("."), Path: tt.path, Info: info, Reader: r, TargetEnv: tt.targetEnv}
 			got, err := e.Extract(context.Background(), input)
 			if err != nil {
 				t.Fatalf("Extract(%s): %v", tt.path, err)
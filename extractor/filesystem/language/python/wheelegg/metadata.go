@@ -22,6 +22,12 @@ import (
 type PythonPackageMetadata struct {
 	Author      string `json:"author"`
 	AuthorEmail string `json:"authorEmail"`
+	// ArtifactSHA256 is the hex-encoded SHA-256 of the .whl or .egg file the package
+	// was extracted from. It can be compared with the digests published on PyPI.
+	ArtifactSHA256 string `json:"artifactSha256,omitempty"`
+	// Environment is the root directory of the virtualenv or conda environment
+	// the package is installed in, if any.
+	Environment string `json:"environment,omitempty"`
 }
 
 // SetProto sets the PythonMetadata field in the Package proto.
@@ -35,8 +41,10 @@ func (m *PythonPackageMetadata) SetProto(p *pb.Package) {
 
 	p.Metadata = &pb.Package_PythonMetadata{
 		PythonMetadata: &pb.PythonPackageMetadata{
-			Author:      m.Author,
-			AuthorEmail: m.AuthorEmail,
+			Author:         m.Author,
+			AuthorEmail:    m.AuthorEmail,
+			ArtifactSha256: m.ArtifactSHA256,
+			Environment:    m.Environment,
 		},
 	}
 }
@@ -48,7 +56,9 @@ func ToStruct(m *pb.PythonPackageMetadata) *PythonPackageMetadata {
 	}
 
 	return &PythonPackageMetadata{
-		Author:      m.GetAuthor(),
-		AuthorEmail: m.GetAuthorEmail(),
+		Author:         m.GetAuthor(),
+		AuthorEmail:    m.GetAuthorEmail(),
+		ArtifactSHA256: m.GetArtifactSha256(),
+		Environment:    m.GetEnvironment(),
 	}
 }
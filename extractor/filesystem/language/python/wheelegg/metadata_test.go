@@ -86,16 +86,18 @@ func TestSetProto(t *testing.T) {
 		{
 			desc: "set all fields",
 			m: &wheelegg.PythonPackageMetadata{
-				Author:      "some-author",
-				AuthorEmail: "some-author@google.com",
+				Author:         "some-author",
+				AuthorEmail:    "some-author@google.com",
+				ArtifactSHA256: "68687e19a14f11f26d140dd5c86f3dba4bf5df58003000ed467e0e2a69bca96c",
 			},
 			p: &pb.Package{Name: "some-package"},
 			want: &pb.Package{
 				Name: "some-package",
 				Metadata: &pb.Package_PythonMetadata{
 					PythonMetadata: &pb.PythonPackageMetadata{
-						Author:      "some-author",
-						AuthorEmail: "some-author@google.com",
+						Author:         "some-author",
+						AuthorEmail:    "some-author@google.com",
+						ArtifactSha256: "68687e19a14f11f26d140dd5c86f3dba4bf5df58003000ed467e0e2a69bca96c",
 					},
 				},
 			},
@@ -150,12 +152,14 @@ func TestToStruct(t *testing.T) {
 		{
 			desc: "all fields",
 			m: &pb.PythonPackageMetadata{
-				Author:      "some-author",
-				AuthorEmail: "some-author@google.com",
+				Author:         "some-author",
+				AuthorEmail:    "some-author@google.com",
+				ArtifactSha256: "68687e19a14f11f26d140dd5c86f3dba4bf5df58003000ed467e0e2a69bca96c",
 			},
 			want: &wheelegg.PythonPackageMetadata{
-				Author:      "some-author",
-				AuthorEmail: "some-author@google.com",
+				Author:         "some-author",
+				AuthorEmail:    "some-author@google.com",
+				ArtifactSHA256: "68687e19a14f11f26d140dd5c86f3dba4bf5df58003000ed467e0e2a69bca96c",
 			},
 		},
 	}
@@ -19,6 +19,7 @@ import (
 	"archive/zip"
 	"bufio"
 	"context"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"io"
@@ -29,9 +30,12 @@ import (
 	"github.com/google/osv-scalibr/extractor"
 	"github.com/google/osv-scalibr/extractor/filesystem"
 	"github.com/google/osv-scalibr/extractor/filesystem/internal/units"
+	"github.com/google/osv-scalibr/extractor/filesystem/language/python/pythonenv"
 	"github.com/google/osv-scalibr/extractor/filesystem/simplefileapi"
 	scalibrfs "github.com/google/osv-scalibr/fs"
+	"github.com/google/osv-scalibr/hashing"
 	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/log"
 	"github.com/google/osv-scalibr/plugin"
 	"github.com/google/osv-scalibr/purl"
 	"github.com/google/osv-scalibr/stats"
@@ -49,6 +53,8 @@ const (
 // Extractor extracts python packages from wheel/egg files.
 type Extractor struct {
 	maxFileSizeBytes int64
+	hashArtifacts    bool
+	hashing          *hashing.Config
 	stats            stats.Collector
 }
 
@@ -57,6 +63,12 @@ type Config struct {
 	// MaxFileSizeBytes is the maximum file size this extractor will unmarshal. If
 	// `FileRequired` gets a bigger file, it will return false,
 	MaxFileSizeBytes int64
+	// HashArtifacts configures if .whl and .egg files should be hashed with hex(sha256()),
+	// which is the digest PyPI publishes for its distribution files.
+	HashArtifacts bool
+	// Hashing restricts the digest algorithms the extractor may use, e.g. in FIPS mode.
+	// If nil, no restrictions are applied.
+	Hashing *hashing.Config
 	// Stats is a stats collector for reporting metrics.
 	Stats stats.Collector
 }
@@ -78,6 +90,8 @@ func DefaultConfig() Config {
 func New(cfg Config) *Extractor {
 	return &Extractor{
 		maxFileSizeBytes: cfg.MaxFileSizeBytes,
+		hashArtifacts:    cfg.HashArtifacts,
+		hashing:          cfg.Hashing,
 		stats:            cfg.Stats,
 	}
 }
@@ -85,6 +99,9 @@ func New(cfg Config) *Extractor {
 // NewDefault returns an extractor with the default config settings.
 func NewDefault() filesystem.Extractor { return New(DefaultConfig()) }
 
+// SetHashingConfig sets the hashing config used when hashing wheel and egg files.
+func (e *Extractor) SetHashingConfig(cfg *hashing.Config) { e.hashing = cfg }
+
 // Name of the extractor.
 func (e Extractor) Name() string { return Name }
 
@@ -160,6 +177,13 @@ func (e Extractor) Extract(ctx context.Context, input *filesystem.ScanInput) (in
 			pkgs = []*extractor.Package{p}
 		}
 	}
+	if env := pythonenv.EnvironmentRoot(input.FS, input.Path); env != "" {
+		for _, p := range pkgs {
+			if m, ok := p.Metadata.(*PythonPackageMetadata); ok {
+				m.Environment = env
+			}
+		}
+	}
 
 	if e.stats != nil {
 		var fileSizeBytes int64
@@ -207,9 +231,35 @@ func (e Extractor) extractZip(ctx context.Context, input *filesystem.ScanInput)
 		}
 		pkgs = append(pkgs, p)
 	}
+
+	if e.hashArtifacts && e.hashing.Allows(hashing.SHA256) && len(pkgs) > 0 {
+		h, err := e.hashArtifact(io.NewSectionReader(r, 0, s))
+		if err != nil {
+			log.Errorf("hashArtifact(%q) err: %v", input.Path, err)
+			// continue extracting even if hashing failed
+		}
+		for _, p := range pkgs {
+			if m, ok := p.Metadata.(*PythonPackageMetadata); ok {
+				m.ArtifactSHA256 = h
+			}
+		}
+	}
 	return pkgs, nil
 }
 
+// hashArtifact returns hex(sha256()) of the file. This is compatible with the
+// digests of distribution files published on PyPI.
+func (e Extractor) hashArtifact(r io.Reader) (string, error) {
+	hasher, err := e.hashing.New(hashing.SHA256)
+	if err != nil {
+		return "", err
+	}
+	if _, err := io.Copy(hasher, r); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hasher.Sum(nil)), nil
+}
+
 func (e Extractor) openAndExtract(f *zip.File, input *filesystem.ScanInput) (*extractor.Package, error) {
 	r, err := f.Open()
 	if err != nil {
@@ -22,6 +22,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"testing/fstest"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -30,6 +31,7 @@ import (
 	"github.com/google/osv-scalibr/extractor/filesystem/language/python/wheelegg"
 	"github.com/google/osv-scalibr/extractor/filesystem/simplefileapi"
 	scalibrfs "github.com/google/osv-scalibr/fs"
+	"github.com/google/osv-scalibr/fs/memfs"
 	"github.com/google/osv-scalibr/inventory"
 	"github.com/google/osv-scalibr/purl"
 	"github.com/google/osv-scalibr/stats"
@@ -259,6 +261,38 @@ func TestExtract(t *testing.T) {
 				},
 			}},
 		},
+		{
+			name: ".whl with artifact hash",
+			path: "testdata/monotonic-1.6-py2.py3-none-any.whl",
+			cfg:  wheelegg.Config{HashArtifacts: true},
+			wantPackages: []*extractor.Package{{
+				Name:      "monotonic",
+				Version:   "1.6",
+				PURLType:  purl.TypePyPi,
+				Locations: []string{"testdata/monotonic-1.6-py2.py3-none-any.whl"},
+				Metadata: &wheelegg.PythonPackageMetadata{
+					Author:         "Ori Livneh",
+					AuthorEmail:    "ori@wikimedia.org",
+					ArtifactSHA256: "68687e19a14f11f26d140dd5c86f3dba4bf5df58003000ed467e0e2a69bca96c",
+				},
+			}},
+		},
+		{
+			name: ".egg with artifact hash",
+			path: "testdata/monotonic-1.6-py3.10.egg",
+			cfg:  wheelegg.Config{HashArtifacts: true},
+			wantPackages: []*extractor.Package{{
+				Name:      "monotonic",
+				Version:   "1.6",
+				PURLType:  purl.TypePyPi,
+				Locations: []string{"testdata/monotonic-1.6-py3.10.egg"},
+				Metadata: &wheelegg.PythonPackageMetadata{
+					Author:         "Ori Livneh",
+					AuthorEmail:    "ori@wikimedia.org",
+					ArtifactSHA256: "f73e3f7962d1453a805e6a9b60e69b36996a7c31d62b98becef41ef3922216f7",
+				},
+			}},
+		},
 		{
 			name:         ".egg without PKG-INFO",
 			path:         "testdata/monotonic_no_pkginfo-1.6-py3.10.egg",
@@ -328,6 +362,7 @@ func defaultConfigWith(cfg wheelegg.Config) wheelegg.Config {
 	if cfg.Stats != nil {
 		newCfg.Stats = cfg.Stats
 	}
+	newCfg.HashArtifacts = cfg.HashArtifacts
 	return newCfg
 }
 
@@ -403,6 +438,70 @@ func TestExtractWithoutReadAt(t *testing.T) {
 	}
 }
 
+func TestExtractEnvironment(t *testing.T) {
+	metadataFile := "lib/python3.11/site-packages/six-1.16.0.dist-info/METADATA"
+	metadata := []byte("Metadata-Version: 2.1\nName: six\nVersion: 1.16.0\n")
+
+	tests := []struct {
+		name    string
+		files   fstest.MapFS
+		path    string
+		wantEnv string
+	}{
+		{
+			name: "virtualenv",
+			files: fstest.MapFS{
+				"venv/pyvenv.cfg":      {},
+				"venv/" + metadataFile: {Data: metadata},
+			},
+			path:    "venv/" + metadataFile,
+			wantEnv: "venv",
+		},
+		{
+			name: "conda",
+			files: fstest.MapFS{
+				"envs/ml/conda-meta/history": {},
+				"envs/ml/" + metadataFile:    {Data: metadata},
+			},
+			path:    "envs/ml/" + metadataFile,
+			wantEnv: "envs/ml",
+		},
+		{
+			name:  "system_site_packages",
+			files: fstest.MapFS{"usr/" + metadataFile: {Data: metadata}},
+			path:  "usr/" + metadataFile,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fsys := memfs.New(tt.files)
+			f, err := fsys.Open(tt.path)
+			if err != nil {
+				t.Fatalf("Open(%s): %v", tt.path, err)
+			}
+			defer f.Close()
+
+			input := &filesystem.ScanInput{FS: fsys, Path: tt.path, Reader: f}
+			got, err := wheelegg.New(wheelegg.DefaultConfig()).Extract(context.Background(), input)
+			if err != nil {
+				t.Fatalf("Extract(%s): %v", tt.path, err)
+			}
+
+			want := inventory.Inventory{Packages: []*extractor.Package{{
+				Name:      "six",
+				Version:   "1.16.0",
+				PURLType:  purl.TypePyPi,
+				Locations: []string{tt.path},
+				Metadata:  &wheelegg.PythonPackageMetadata{Environment: tt.wantEnv},
+			}}}
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("Extract(%s) (-want +got):\n%s", tt.path, diff)
+			}
+		})
+	}
+}
+
 func TestExtractErrorsWithFakeFiles(t *testing.T) {
 	tests := []struct {
 		name             string
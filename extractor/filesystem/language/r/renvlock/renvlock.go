@@ -20,6 +20,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"path/filepath"
+	"strings"
 
 	"github.com/google/osv-scalibr/extractor"
 	"github.com/google/osv-scalibr/extractor/filesystem"
@@ -36,6 +37,7 @@ const (
 type renvPackage struct {
 	Package    string `json:"Package"`
 	Version    string `json:"Version"`
+	Source     string `json:"Source"`
 	Repository string `json:"Repository"`
 }
 
@@ -43,7 +45,7 @@ type renvLockfile struct {
 	Packages map[string]renvPackage `json:"Packages"`
 }
 
-// Extractor extracts CRAN packages from renv.lock files.
+// Extractor extracts CRAN and Bioconductor packages from renv.lock files.
 type Extractor struct{}
 
 // New returns a new instance of the extractor.
@@ -78,15 +80,16 @@ func (e Extractor) Extract(ctx context.Context, input *filesystem.ScanInput) (in
 	packages := make([]*extractor.Package, 0, len(parsedLockfile.Packages))
 
 	for _, pkg := range parsedLockfile.Packages {
-		// currently we only support CRAN
-		if pkg.Repository != "CRAN" {
+		purlType := packageType(pkg)
+		// Packages installed from e.g. GitHub or local sources aren't supported.
+		if purlType == "" {
 			continue
 		}
 
 		packages = append(packages, &extractor.Package{
 			Name:      pkg.Package,
 			Version:   pkg.Version,
-			PURLType:  purl.TypeCran,
+			PURLType:  purlType,
 			Locations: []string{input.Path},
 		})
 	}
@@ -94,4 +97,19 @@ func (e Extractor) Extract(ctx context.Context, input *filesystem.ScanInput) (in
 	return inventory.Inventory{Packages: packages}, nil
 }
 
+// packageType returns the PURL type of the registry the package was installed
+// from, or "" if it's not a supported registry.
+func packageType(pkg renvPackage) string {
+	switch {
+	case pkg.Repository == "CRAN":
+		return purl.TypeCran
+	// Older renv versions only record the source, newer ones also record the
+	// Bioconductor repository, e.g. "BioCsoft".
+	case pkg.Source == "Bioconductor", strings.HasPrefix(pkg.Repository, "BioC"):
+		return purl.TypeBioconductor
+	default:
+		return ""
+	}
+}
+
 var _ filesystem.Extractor = Extractor{}
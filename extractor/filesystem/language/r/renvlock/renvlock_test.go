@@ -104,6 +104,18 @@ func TestExtractor_Extract(t *testing.T) {
 					PURLType:  purl.TypeCran,
 					Locations: []string{"testdata/with-bioconductor.lock"},
 				},
+				{
+					Name:      "BSgenome",
+					Version:   "1.60.0",
+					PURLType:  purl.TypeBioconductor,
+					Locations: []string{"testdata/with-bioconductor.lock"},
+				},
+				{
+					Name:      "S4Vectors",
+					Version:   "0.30.2",
+					PURLType:  purl.TypeBioconductor,
+					Locations: []string{"testdata/with-bioconductor.lock"},
+				},
 			},
 		},
 		{
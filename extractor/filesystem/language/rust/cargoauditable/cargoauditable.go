@@ -20,6 +20,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"math"
 
 	"github.com/google/osv-scalibr/extractor"
 	"github.com/google/osv-scalibr/extractor/filesystem"
@@ -53,6 +54,11 @@ type Config struct {
 	MaxFileSizeBytes int64
 	// ExtractBuildDependencies is whether to extract build dependencies or only runtime ones.
 	ExtractBuildDependencies bool
+	// PanicPathFallback enables recovering the crates of Rust binaries without
+	// cargo auditable data from the source file paths of panic locations. This
+	// is off by default because it requires reading every executable that
+	// doesn't contain cargo auditable data in full.
+	PanicPathFallback bool
 }
 
 // Extractor for extracting dependencies from cargo auditable inside rust binaries.
@@ -60,6 +66,10 @@ type Extractor struct {
 	stats                    stats.Collector
 	maxFileSizeBytes         int64
 	extractBuildDependencies bool
+
+	// PanicPathFallback enables recovering the crates of Rust binaries without
+	// cargo auditable data from the source file paths of panic locations.
+	PanicPathFallback bool
 }
 
 // DefaultConfig returns a default configuration for the extractor.
@@ -68,6 +78,7 @@ func DefaultConfig() Config {
 		Stats:                    nil,
 		MaxFileSizeBytes:         defaultMaxFileSizeBytes,
 		ExtractBuildDependencies: defaultExtractBuildDependencies,
+		PanicPathFallback:        false,
 	}
 }
 
@@ -82,6 +93,7 @@ func New(cfg Config) *Extractor {
 		stats:                    cfg.Stats,
 		maxFileSizeBytes:         cfg.MaxFileSizeBytes,
 		extractBuildDependencies: cfg.ExtractBuildDependencies,
+		PanicPathFallback:        cfg.PanicPathFallback,
 	}
 }
 
@@ -134,6 +146,12 @@ func (e Extractor) Extract(ctx context.Context, input *filesystem.ScanInput) (in
 	}
 
 	dependencyInfo, err := rustaudit.GetDependencyInfo(reader)
+	if e.PanicPathFallback && (errors.Is(err, rustaudit.ErrUnknownFileFormat) || errors.Is(err, rustaudit.ErrNoRustDepInfo)) {
+		if pkgs := e.extractFromPanicPaths(reader, input.Path); len(pkgs) > 0 {
+			e.reportFileExtracted(input, stats.FileExtractedResultSuccess)
+			return inventory.Inventory{Packages: pkgs}, nil
+		}
+	}
 	e.reportFileExtracted(input, filesystem.ExtractorErrorToFileExtractedResult(err))
 	// Most errors are just that the file is not a cargo auditable rust binary.
 	if err != nil {
@@ -160,6 +178,17 @@ func (e Extractor) Extract(ctx context.Context, input *filesystem.ScanInput) (in
 	return inventory.Inventory{Packages: pkgs}, nil
 }
 
+// extractFromPanicPaths reads the entire binary and recovers its crates from
+// the panic locations in the binary content.
+func (e Extractor) extractFromPanicPaths(r io.ReaderAt, filename string) []*extractor.Package {
+	data, err := io.ReadAll(io.NewSectionReader(r, 0, math.MaxInt64))
+	if err != nil {
+		log.Debugf("error reading the contents of Rust binary (%s): %v", filename, err)
+		return nil
+	}
+	return extractPackagesFromPanicPaths(data, filename)
+}
+
 func (e Extractor) reportFileExtracted(input *filesystem.ScanInput, result stats.FileExtractedResult) {
 	if e.stats == nil {
 		return
@@ -20,6 +20,7 @@ import (
 	"io/fs"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -258,3 +259,126 @@ func TestExtract(t *testing.T) {
 		})
 	}
 }
+
+func TestExtract_PanicPathFallback(t *testing.T) {
+	tests := []struct {
+		name              string
+		path              string
+		panicPathFallback bool
+		wantPackages      []*extractor.Package
+	}{
+		{
+			name:              "binary without audit data is skipped by default",
+			path:              "testdata/no_audit_data/no_audit_data",
+			panicPathFallback: false,
+			wantPackages:      nil,
+		},
+		{
+			name:              "binary without audit data",
+			path:              "testdata/no_audit_data/no_audit_data",
+			panicPathFallback: true,
+			wantPackages: []*extractor.Package{
+				{
+					Name:      "serde_json",
+					Version:   "1.0.135",
+					PURLType:  purl.TypeCargo,
+					Locations: []string{"testdata/no_audit_data/no_audit_data"},
+				},
+			},
+		},
+		{
+			name:              "audit data takes precedence",
+			path:              "testdata/no_deps/no_deps",
+			panicPathFallback: true,
+			wantPackages: []*extractor.Package{
+				{
+					Name:      "no_deps",
+					Version:   "0.1.0",
+					PURLType:  purl.TypeCargo,
+					Locations: []string{"testdata/no_deps/no_deps"},
+				},
+			},
+		},
+		{
+			name:              "not_binary",
+			path:              "testdata/not_binary/not_binary",
+			panicPathFallback: true,
+			wantPackages:      nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := os.Open(tt.path)
+			if err != nil {
+				t.Fatalf("os.Open(%s) unexpected error: %v", tt.path, err)
+			}
+			defer f.Close()
+			info, err := f.Stat()
+			if err != nil {
+				t.Fatalf("f.Stat() for %q unexpected error: %v", tt.path, err)
+			}
+			input := &filesystem.ScanInput{FS: scalibrfs.DirFS("."), Path: tt.path, Info: info, Reader: f}
+
+			cfg := cargoauditable.DefaultConfig()
+			cfg.PanicPathFallback = tt.panicPathFallback
+			got, err := cargoauditable.New(cfg).Extract(t.Context(), input)
+			if err != nil {
+				t.Fatalf("Extract(%s) unexpected error: %v", tt.path, err)
+			}
+			wantInv := inventory.Inventory{Packages: tt.wantPackages}
+			if diff := cmp.Diff(wantInv, got, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("Extract(%s) (-want +got):\n%s", tt.path, diff)
+			}
+		})
+	}
+}
+
+func TestExtract_PanicPaths(t *testing.T) {
+	const path = "bin/app"
+	tests := []struct {
+		name         string
+		content      string
+		wantPackages []*extractor.Package
+	}{
+		{
+			name: "multiple versions and Windows paths",
+			content: "\x00/rustc/90b35a6239c3d8bdabc530a6a0816f7ff89a0aaf/library/core/src/str/pattern.rs" +
+				"\x00/home/user/.cargo/registry/src/index.crates.io-6f17d22bba15001f/regex-syntax-0.8.5/src/ast/parse.rs" +
+				"\x00/home/user/.cargo/registry/src/index.crates.io-6f17d22bba15001f/regex-syntax-0.6.29/src/parser.rs" +
+				"\x00C:\\Users\\user\\.cargo\\registry\\src\\index.crates.io-6f17d22bba15001f\\tokio-1.38.0-rc.1\\src\\runtime\\mod.rs" +
+				"\x00/home/user/.cargo/registry/src/index.crates.io-6f17d22bba15001f/regex-syntax-0.8.5/src/hir/mod.rs",
+			wantPackages: []*extractor.Package{
+				{Name: "regex-syntax", Version: "0.6.29", PURLType: purl.TypeCargo, Locations: []string{path}},
+				{Name: "regex-syntax", Version: "0.8.5", PURLType: purl.TypeCargo, Locations: []string{path}},
+				{Name: "tokio", Version: "1.38.0-rc.1", PURLType: purl.TypeCargo, Locations: []string{path}},
+			},
+		},
+		{
+			name:         "not a Rust binary",
+			content:      "\x00/home/user/.cargo/registry/src/index.crates.io-6f17d22bba15001f/serde-1.0.0/src/lib.rs",
+			wantPackages: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := &filesystem.ScanInput{
+				FS:     scalibrfs.DirFS("."),
+				Path:   path,
+				Info:   fakefs.FakeFileInfo{FileName: "app", FileSize: int64(len(tt.content))},
+				Reader: strings.NewReader(tt.content),
+			}
+			cfg := cargoauditable.DefaultConfig()
+			cfg.PanicPathFallback = true
+			got, err := cargoauditable.New(cfg).Extract(t.Context(), input)
+			if err != nil {
+				t.Fatalf("Extract(%q) unexpected error: %v", tt.content, err)
+			}
+			wantInv := inventory.Inventory{Packages: tt.wantPackages}
+			if diff := cmp.Diff(wantInv, got, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("Extract(%q) (-want +got):\n%s", tt.content, diff)
+			}
+		})
+	}
+}
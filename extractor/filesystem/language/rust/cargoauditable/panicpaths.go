@@ -0,0 +1,77 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cargoauditable
+
+import (
+	"bytes"
+	"regexp"
+	"sort"
+
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/purl"
+)
+
+var (
+	// reRegistrySource matches the source file paths of crates downloaded from
+	// a Cargo registry, e.g.
+	// ".cargo/registry/src/index.crates.io-6f17d22bba15001f/serde_json-1.0.135/".
+	// They end up in release binaries through the locations of panics.
+	reRegistrySource = regexp.MustCompile(`registry[/\\]src[/\\][^/\\\x00]+[/\\]([A-Za-z0-9_-]+?)-(\d+\.\d+\.\d+(?:-[0-9A-Za-z.-]+)?(?:\+[0-9A-Za-z.-]+)?)[/\\]`)
+
+	// rustcPath is the prefix of the paths of the standard library sources,
+	// e.g. "/rustc/90b35a6239c3d8bdabc530a6a0816f7ff89a0aaf/library/core/src/".
+	// It's present in virtually every Rust binary.
+	rustcPath = []byte("/rustc/")
+)
+
+// extractPackagesFromPanicPaths recovers the crates of a Rust binary without
+// cargo auditable data from the source file paths of panic locations. Only
+// crates that have a panic location and were built from a registry are found,
+// so the result is usually incomplete and doesn't include the main crate.
+// Returns nil if the data doesn't look like a Rust binary.
+func extractPackagesFromPanicPaths(data []byte, filename string) []*extractor.Package {
+	if !bytes.Contains(data, rustcPath) {
+		return nil
+	}
+
+	type crate struct{ name, version string }
+	seen := map[crate]bool{}
+	var crates []crate
+	for _, m := range reRegistrySource.FindAllSubmatch(data, -1) {
+		// Several versions of the same crate can be linked into the binary.
+		c := crate{name: string(m[1]), version: string(m[2])}
+		if !seen[c] {
+			seen[c] = true
+			crates = append(crates, c)
+		}
+	}
+	sort.Slice(crates, func(i, j int) bool {
+		if crates[i].name != crates[j].name {
+			return crates[i].name < crates[j].name
+		}
+		return crates[i].version < crates[j].version
+	})
+
+	res := make([]*extractor.Package, 0, len(crates))
+	for _, c := range crates {
+		res = append(res, &extractor.Package{
+			Name:      c.name,
+			Version:   c.version,
+			PURLType:  purl.TypeCargo,
+			Locations: []string{filename},
+		})
+	}
+	return res
+}
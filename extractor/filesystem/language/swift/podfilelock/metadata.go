@@ -0,0 +1,53 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package podfilelock
+
+import (
+	pb "github.com/google/osv-scalibr/binary/proto/scan_result_go_proto"
+)
+
+// Metadata holds the CocoaPods specific data of a pod.
+type Metadata struct {
+	// The installed subspecs of the pod, e.g. "Analytics" for
+	// "Firebase/Analytics". Empty if only the pod itself is installed.
+	Subspecs []string `json:"subspecs,omitempty"`
+}
+
+// SetProto sets the CocoapodsMetadata field in the Package proto.
+func (m *Metadata) SetProto(p *pb.Package) {
+	if m == nil {
+		return
+	}
+	if p == nil {
+		return
+	}
+
+	p.Metadata = &pb.Package_CocoapodsMetadata{
+		CocoapodsMetadata: &pb.CocoapodsMetadata{
+			Subspecs: m.Subspecs,
+		},
+	}
+}
+
+// ToStruct converts the CocoapodsMetadata proto to a Metadata struct.
+func ToStruct(m *pb.CocoapodsMetadata) *Metadata {
+	if m == nil {
+		return nil
+	}
+
+	return &Metadata{
+		Subspecs: m.GetSubspecs(),
+	}
+}
@@ -18,6 +18,8 @@ package podfilelock
 import (
 	"context"
 	"path/filepath"
+	"slices"
+	"strings"
 
 	"github.com/google/osv-scalibr/extractor"
 	"github.com/google/osv-scalibr/extractor/filesystem"
@@ -135,14 +137,36 @@ func (e Extractor) extractFromInput(input *filesystem.ScanInput) ([]*extractor.P
 		return nil, err
 	}
 
+	// Subspecs are listed as separate pods named "<pod>/<subspec>". They're
+	// reported as part of the pod they belong to since the subspec isn't a
+	// separately versioned package.
+	type podKey struct{ name, version string }
 	var result []*extractor.Package
+	pods := make(map[podKey]*extractor.Package)
 	for _, pkg := range packages {
-		result = append(result, &extractor.Package{
-			Name:      pkg.Name,
-			Version:   pkg.Version,
-			PURLType:  purl.TypeCocoapods,
-			Locations: []string{input.Path},
-		})
+		name, subspec, _ := strings.Cut(pkg.Name, "/")
+		key := podKey{name: name, version: pkg.Version}
+		p, ok := pods[key]
+		if !ok {
+			p = &extractor.Package{
+				Name:      name,
+				Version:   pkg.Version,
+				PURLType:  purl.TypeCocoapods,
+				Locations: []string{input.Path},
+			}
+			pods[key] = p
+			result = append(result, p)
+		}
+		if subspec == "" {
+			continue
+		}
+		if p.Metadata == nil {
+			p.Metadata = &Metadata{}
+		}
+		m := p.Metadata.(*Metadata)
+		if !slices.Contains(m.Subspecs, subspec) {
+			m.Subspecs = append(m.Subspecs, subspec)
+		}
 	}
 
 	return result, nil
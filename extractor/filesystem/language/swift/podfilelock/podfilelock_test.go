@@ -213,6 +213,40 @@ func TestExtract(t *testing.T) {
 				},
 			},
 		},
+		{
+			Name: "subspecs are reported as part of their pod",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/subspecs",
+			},
+			WantPackages: []*extractor.Package{
+				{
+					Name:      "Firebase",
+					Version:   "10.18.0",
+					PURLType:  purl.TypeCocoapods,
+					Locations: []string{"testdata/subspecs"},
+					Metadata:  &podfilelock.Metadata{Subspecs: []string{"Analytics", "Core", "CoreOnly"}},
+				},
+				{
+					Name:      "FirebaseAnalytics",
+					Version:   "10.18.0",
+					PURLType:  purl.TypeCocoapods,
+					Locations: []string{"testdata/subspecs"},
+				},
+				{
+					Name:      "FirebaseCore",
+					Version:   "10.18.0",
+					PURLType:  purl.TypeCocoapods,
+					Locations: []string{"testdata/subspecs"},
+				},
+				{
+					Name:      "GoogleUtilities",
+					Version:   "7.12.0",
+					PURLType:  purl.TypeCocoapods,
+					Locations: []string{"testdata/subspecs"},
+					Metadata:  &podfilelock.Metadata{Subspecs: []string{"Environment", "NSData+zlib"}},
+				},
+			},
+		},
 		{
 			Name: "Podfile.lock file not valid",
 			InputConfig: extracttest.ScanInputMockConfig{
@@ -0,0 +1,120 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package modulesjson extracts the registry modules installed by "terraform
+// init", which are listed in .terraform/modules/modules.json.
+package modulesjson
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/plugin"
+	"github.com/google/osv-scalibr/purl"
+)
+
+const (
+	// Name is the unique name of this extractor.
+	Name = "terraform/modulesjson"
+
+	// defaultRegistry is the host of module sources that don't specify one.
+	defaultRegistry = "registry.terraform.io"
+)
+
+type module struct {
+	Key     string `json:"Key"`
+	Source  string `json:"Source"`
+	Version string `json:"Version"`
+}
+
+type modulesJSON struct {
+	Modules []module `json:"Modules"`
+}
+
+// Extractor extracts Terraform modules from .terraform/modules/modules.json files.
+type Extractor struct{}
+
+// New returns a new instance of the extractor.
+func New() filesystem.Extractor { return &Extractor{} }
+
+// Name of the extractor.
+func (e Extractor) Name() string { return Name }
+
+// Version of the extractor.
+func (e Extractor) Version() int { return 0 }
+
+// Requirements of the extractor.
+func (e Extractor) Requirements() *plugin.Capabilities {
+	return &plugin.Capabilities{}
+}
+
+// FileRequired returns true if the specified file is a .terraform/modules/modules.json file.
+func (e Extractor) FileRequired(api filesystem.FileAPI) bool {
+	return strings.HasSuffix(filepath.ToSlash(api.Path()), ".terraform/modules/modules.json")
+}
+
+// Extract extracts the installed modules from the modules.json file passed
+// through the scan input. Only registry modules have a version, so local and
+// remote modules, e.g. from git, are skipped.
+func (e Extractor) Extract(ctx context.Context, input *filesystem.ScanInput) (inventory.Inventory, error) {
+	var parsed modulesJSON
+	if err := json.NewDecoder(input.Reader).Decode(&parsed); err != nil {
+		return inventory.Inventory{}, fmt.Errorf("could not extract: %w", err)
+	}
+
+	type key struct{ source, version string }
+	seen := map[key]bool{}
+	var packages []*extractor.Package
+	for _, m := range parsed.Modules {
+		if err := ctx.Err(); err != nil {
+			return inventory.Inventory{Packages: packages}, fmt.Errorf("%s halted due to context error: %w", e.Name(), err)
+		}
+		if m.Version == "" || m.Source == "" {
+			continue
+		}
+		source := registryAddress(m.Source)
+		// The same module can be used by several modules of the configuration.
+		k := key{source: source, version: m.Version}
+		if seen[k] {
+			continue
+		}
+		seen[k] = true
+		packages = append(packages, &extractor.Package{
+			Name:      source,
+			Version:   m.Version,
+			PURLType:  purl.TypeTerraform,
+			Locations: []string{input.Path},
+		})
+	}
+	return inventory.Inventory{Packages: packages}, nil
+}
+
+// registryAddress returns the address of the registry module including its
+// host, e.g. registry.terraform.io/terraform-aws-modules/vpc/aws. Older
+// Terraform versions record the address without the host of the public
+// registry.
+func registryAddress(source string) string {
+	if strings.Count(source, "/") == 2 {
+		return defaultRegistry + "/" + source
+	}
+	return source
+}
+
+var _ filesystem.Extractor = Extractor{}
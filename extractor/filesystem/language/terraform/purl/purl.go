@@ -0,0 +1,38 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package purl converts Terraform provider and module addresses into a PackageURL.
+package purl
+
+import (
+	"strings"
+
+	"github.com/google/osv-scalibr/purl"
+)
+
+// MakePackageURL returns a package URL for a Terraform provider or module.
+// The address, e.g. registry.terraform.io/hashicorp/aws, is split into the
+// namespace and the name at its last slash.
+func MakePackageURL(address string, version string) *purl.PackageURL {
+	namespace, name := "", strings.ToLower(address)
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		namespace, name = name[:i], name[i+1:]
+	}
+	return &purl.PackageURL{
+		Type:      purl.TypeTerraform,
+		Namespace: namespace,
+		Name:      name,
+		Version:   version,
+	}
+}
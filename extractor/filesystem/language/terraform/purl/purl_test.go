@@ -0,0 +1,74 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package purl_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	terraformpurl "github.com/google/osv-scalibr/extractor/filesystem/language/terraform/purl"
+	"github.com/google/osv-scalibr/purl"
+)
+
+func TestMakePackageURL(t *testing.T) {
+	tests := []struct {
+		desc    string
+		address string
+		version string
+		want    *purl.PackageURL
+	}{
+		{
+			desc:    "provider",
+			address: "registry.terraform.io/hashicorp/aws",
+			version: "5.31.0",
+			want: &purl.PackageURL{
+				Type:      purl.TypeTerraform,
+				Namespace: "registry.terraform.io/hashicorp",
+				Name:      "aws",
+				Version:   "5.31.0",
+			},
+		},
+		{
+			desc:    "module",
+			address: "registry.terraform.io/Terraform-AWS-Modules/vpc/aws",
+			version: "5.1.2",
+			want: &purl.PackageURL{
+				Type:      purl.TypeTerraform,
+				Namespace: "registry.terraform.io/terraform-aws-modules/vpc",
+				Name:      "aws",
+				Version:   "5.1.2",
+			},
+		},
+		{
+			desc:    "no_namespace",
+			address: "aws",
+			version: "5.31.0",
+			want: &purl.PackageURL{
+				Type:    purl.TypeTerraform,
+				Name:    "aws",
+				Version: "5.31.0",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got := terraformpurl.MakePackageURL(tt.address, tt.version)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("MakePackageURL(%q, %q): unexpected PURL (-want +got):\n%s", tt.address, tt.version, diff)
+			}
+		})
+	}
+}
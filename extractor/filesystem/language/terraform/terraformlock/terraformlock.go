@@ -0,0 +1,113 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package terraformlock extracts the provider versions pinned in Terraform
+// .terraform.lock.hcl dependency lock files.
+package terraformlock
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/plugin"
+	"github.com/google/osv-scalibr/purl"
+)
+
+const (
+	// Name is the unique name of this extractor.
+	Name = "terraform/terraformlock"
+)
+
+var (
+	// e.g. provider "registry.terraform.io/hashicorp/aws" {
+	providerRe = regexp.MustCompile(`^provider\s+"([^"]+)"\s*\{$`)
+	// e.g. version = "5.31.0"
+	versionRe = regexp.MustCompile(`^version\s*=\s*"([^"]*)"$`)
+)
+
+// Extractor extracts Terraform providers from .terraform.lock.hcl files.
+type Extractor struct{}
+
+// New returns a new instance of the extractor.
+func New() filesystem.Extractor { return &Extractor{} }
+
+// Name of the extractor.
+func (e Extractor) Name() string { return Name }
+
+// Version of the extractor.
+func (e Extractor) Version() int { return 0 }
+
+// Requirements of the extractor.
+func (e Extractor) Requirements() *plugin.Capabilities {
+	return &plugin.Capabilities{}
+}
+
+// FileRequired returns true if the specified file is a .terraform.lock.hcl file.
+func (e Extractor) FileRequired(api filesystem.FileAPI) bool {
+	return filepath.Base(api.Path()) == ".terraform.lock.hcl"
+}
+
+// Extract extracts the locked providers from the .terraform.lock.hcl file
+// passed through the scan input.
+//
+// The lock file is written by "terraform init" in a fixed layout, so it's
+// parsed line by line instead of with a full HCL parser.
+func (e Extractor) Extract(ctx context.Context, input *filesystem.ScanInput) (inventory.Inventory, error) {
+	var packages []*extractor.Package
+	var provider string
+	inList := false
+	s := bufio.NewScanner(input.Reader)
+	for s.Scan() {
+		if err := ctx.Err(); err != nil {
+			return inventory.Inventory{Packages: packages}, fmt.Errorf("%s halted due to context error: %w", e.Name(), err)
+		}
+		line := strings.TrimSpace(s.Text())
+		switch {
+		case provider == "":
+			if m := providerRe.FindStringSubmatch(line); m != nil {
+				provider = m[1]
+			}
+		case inList:
+			inList = !strings.HasPrefix(line, "]")
+		case strings.HasSuffix(line, "["):
+			inList = true
+		case line == "}":
+			provider = ""
+		default:
+			m := versionRe.FindStringSubmatch(line)
+			if m == nil || m[1] == "" {
+				continue
+			}
+			packages = append(packages, &extractor.Package{
+				Name:      provider,
+				Version:   m[1],
+				PURLType:  purl.TypeTerraform,
+				Locations: []string{input.Path},
+			})
+		}
+	}
+	if err := s.Err(); err != nil {
+		return inventory.Inventory{}, fmt.Errorf("could not extract: %w", err)
+	}
+	return inventory.Inventory{Packages: packages}, nil
+}
+
+var _ filesystem.Extractor = Extractor{}
@@ -24,12 +24,18 @@ import (
 	"github.com/google/osv-scalibr/extractor/filesystem/containers/containerd"
 	"github.com/google/osv-scalibr/extractor/filesystem/containers/dockerbaseimage"
 	"github.com/google/osv-scalibr/extractor/filesystem/containers/podman"
+	"github.com/google/osv-scalibr/extractor/filesystem/language/cpp/arduinolibrary"
 	"github.com/google/osv-scalibr/extractor/filesystem/language/cpp/conanlock"
+	"github.com/google/osv-scalibr/extractor/filesystem/language/cpp/idfcomponent"
+	"github.com/google/osv-scalibr/extractor/filesystem/language/cpp/idflock"
+	"github.com/google/osv-scalibr/extractor/filesystem/language/cpp/piopm"
+	"github.com/google/osv-scalibr/extractor/filesystem/language/cpp/platformio"
 	"github.com/google/osv-scalibr/extractor/filesystem/language/dart/pubspec"
 	"github.com/google/osv-scalibr/extractor/filesystem/language/dotnet/depsjson"
 	"github.com/google/osv-scalibr/extractor/filesystem/language/dotnet/dotnetpe"
 	"github.com/google/osv-scalibr/extractor/filesystem/language/dotnet/packagesconfig"
 	"github.com/google/osv-scalibr/extractor/filesystem/language/dotnet/packageslockjson"
+	"github.com/google/osv-scalibr/extractor/filesystem/language/dotnet/projectassetsjson"
 	elixir "github.com/google/osv-scalibr/extractor/filesystem/language/elixir/mixlock"
 	"github.com/google/osv-scalibr/extractor/filesystem/language/erlang/mixlock"
 	"github.com/google/osv-scalibr/extractor/filesystem/language/golang/gobinary"
@@ -42,15 +48,22 @@ import (
 	"github.com/google/osv-scalibr/extractor/filesystem/language/java/pomxml"
 	"github.com/google/osv-scalibr/extractor/filesystem/language/java/pomxmlnet"
 	"github.com/google/osv-scalibr/extractor/filesystem/language/javascript/bunlock"
+	"github.com/google/osv-scalibr/extractor/filesystem/language/javascript/nativeaddon"
+	"github.com/google/osv-scalibr/extractor/filesystem/language/javascript/npmtarball"
 	"github.com/google/osv-scalibr/extractor/filesystem/language/javascript/packagejson"
 	"github.com/google/osv-scalibr/extractor/filesystem/language/javascript/packagelockjson"
 	"github.com/google/osv-scalibr/extractor/filesystem/language/javascript/pnpmlock"
 	"github.com/google/osv-scalibr/extractor/filesystem/language/javascript/yarnlock"
+	"github.com/google/osv-scalibr/extractor/filesystem/language/julia/manifesttoml"
+	"github.com/google/osv-scalibr/extractor/filesystem/language/julia/projecttoml"
+	"github.com/google/osv-scalibr/extractor/filesystem/language/ocaml/opam"
+	"github.com/google/osv-scalibr/extractor/filesystem/language/php/composerinstalled"
 	"github.com/google/osv-scalibr/extractor/filesystem/language/php/composerlock"
 	"github.com/google/osv-scalibr/extractor/filesystem/language/python/condameta"
 	"github.com/google/osv-scalibr/extractor/filesystem/language/python/pdmlock"
 	"github.com/google/osv-scalibr/extractor/filesystem/language/python/pipfilelock"
 	"github.com/google/osv-scalibr/extractor/filesystem/language/python/poetrylock"
+	"github.com/google/osv-scalibr/extractor/filesystem/language/python/pythonenv"
 	"github.com/google/osv-scalibr/extractor/filesystem/language/python/requirements"
 	"github.com/google/osv-scalibr/extractor/filesystem/language/python/setup"
 	"github.com/google/osv-scalibr/extractor/filesystem/language/python/uvlock"
@@ -63,7 +76,16 @@ import (
 	"github.com/google/osv-scalibr/extractor/filesystem/language/rust/cargotoml"
 	"github.com/google/osv-scalibr/extractor/filesystem/language/swift/packageresolved"
 	"github.com/google/osv-scalibr/extractor/filesystem/language/swift/podfilelock"
+	"github.com/google/osv-scalibr/extractor/filesystem/language/terraform/modulesjson"
+	"github.com/google/osv-scalibr/extractor/filesystem/language/terraform/terraformlock"
 	chromeextensions "github.com/google/osv-scalibr/extractor/filesystem/misc/chrome/extensions"
+	"github.com/google/osv-scalibr/extractor/filesystem/misc/codeclib"
+	"github.com/google/osv-scalibr/extractor/filesystem/misc/embeddedversion"
+	firefoxextensions "github.com/google/osv-scalibr/extractor/filesystem/misc/firefox/extensions"
+	jenkinsplugins "github.com/google/osv-scalibr/extractor/filesystem/misc/jenkins/plugins"
+	"github.com/google/osv-scalibr/extractor/filesystem/misc/mlmodel"
+	"github.com/google/osv-scalibr/extractor/filesystem/misc/runtimeversion"
+	teamcityplugins "github.com/google/osv-scalibr/extractor/filesystem/misc/teamcity/plugins"
 	"github.com/google/osv-scalibr/extractor/filesystem/misc/vscodeextensions"
 	wordpressplugins "github.com/google/osv-scalibr/extractor/filesystem/misc/wordpress/plugins"
 	"github.com/google/osv-scalibr/extractor/filesystem/os/apk"
@@ -75,10 +97,13 @@ import (
 	"github.com/google/osv-scalibr/extractor/filesystem/os/kernel/vmlinuz"
 	"github.com/google/osv-scalibr/extractor/filesystem/os/macapps"
 	"github.com/google/osv-scalibr/extractor/filesystem/os/nix"
+	"github.com/google/osv-scalibr/extractor/filesystem/os/nix/flakelock"
 	"github.com/google/osv-scalibr/extractor/filesystem/os/pacman"
 	"github.com/google/osv-scalibr/extractor/filesystem/os/portage"
 	"github.com/google/osv-scalibr/extractor/filesystem/os/rpm"
 	"github.com/google/osv-scalibr/extractor/filesystem/os/snap"
+	"github.com/google/osv-scalibr/extractor/filesystem/os/winapps"
+	"github.com/google/osv-scalibr/extractor/filesystem/os/yocto"
 	"github.com/google/osv-scalibr/extractor/filesystem/sbom/cdx"
 	"github.com/google/osv-scalibr/extractor/filesystem/sbom/spdx"
 	"github.com/google/osv-scalibr/extractor/filesystem/secrets"
@@ -95,7 +120,25 @@ var (
 	// Language extractors.
 
 	// C++ source extractors.
-	CppSource = InitMap{conanlock.Name: {conanlock.New}}
+	CppSource = InitMap{
+		conanlock.Name:    {conanlock.New},
+		platformio.Name:   {platformio.New},
+		idfcomponent.Name: {idfcomponent.New},
+		idflock.Name:      {idflock.New},
+	}
+	// C++ artifact extractors.
+	CppArtifact = InitMap{
+		piopm.Name:          {piopm.New},
+		arduinolibrary.Name: {arduinolibrary.New},
+	}
+	// Embedded development extractors, i.e. PlatformIO, ESP-IDF and Arduino.
+	Embedded = InitMap{
+		platformio.Name:     {platformio.New},
+		piopm.Name:          {piopm.New},
+		idfcomponent.Name:   {idfcomponent.New},
+		idflock.Name:        {idflock.New},
+		arduinolibrary.Name: {arduinolibrary.New},
+	}
 	// Java source extractors.
 	JavaSource = InitMap{
 		gradlelockfile.Name:                {gradlelockfile.New},
@@ -119,6 +162,8 @@ var (
 	// Javascript artifact extractors.
 	JavascriptArtifact = InitMap{
 		packagejson.Name: {packagejson.NewDefault},
+		npmtarball.Name:  {npmtarball.NewDefault},
+		nativeaddon.Name: {nativeaddon.NewDefault},
 	}
 	// Python source extractors.
 	PythonSource = InitMap{
@@ -133,7 +178,8 @@ var (
 	}
 	// Python artifact extractors.
 	PythonArtifact = InitMap{
-		wheelegg.Name: {wheelegg.NewDefault},
+		wheelegg.Name:  {wheelegg.NewDefault},
+		pythonenv.Name: {pythonenv.New},
 	}
 	// Go source extractors.
 	GoSource = InitMap{
@@ -154,6 +200,13 @@ var (
 		stacklock.Name: {stacklock.NewDefault},
 		cabal.Name:     {cabal.NewDefault},
 	}
+	// Julia source extractors.
+	JuliaSource = InitMap{
+		manifesttoml.Name: {manifesttoml.New},
+		projecttoml.Name:  {projecttoml.New},
+	}
+	// OCaml source extractors.
+	OCamlSource = InitMap{opam.Name: {opam.New}}
 	// R source extractors
 	RSource = InitMap{renvlock.Name: {renvlock.New}}
 	// Ruby source extractors.
@@ -177,9 +230,10 @@ var (
 	}
 	// Dotnet (.NET) source extractors.
 	DotnetSource = InitMap{
-		depsjson.Name:         {depsjson.NewDefault},
-		packagesconfig.Name:   {packagesconfig.NewDefault},
-		packageslockjson.Name: {packageslockjson.NewDefault},
+		depsjson.Name:          {depsjson.NewDefault},
+		packagesconfig.Name:    {packagesconfig.NewDefault},
+		packageslockjson.Name:  {packageslockjson.NewDefault},
+		projectassetsjson.Name: {projectassetsjson.NewDefault},
 	}
 	// Dotnet (.NET) artifact extractors.
 	DotnetArtifact = InitMap{
@@ -187,11 +241,20 @@ var (
 	}
 	// PHP Source extractors.
 	PHPSource = InitMap{composerlock.Name: {composerlock.New}}
+	// PHP artifact extractors.
+	PHPArtifact = InitMap{composerinstalled.Name: {composerinstalled.New}}
 	// Swift source extractors.
 	SwiftSource = InitMap{
 		packageresolved.Name: {packageresolved.NewDefault},
 		podfilelock.Name:     {podfilelock.NewDefault},
 	}
+	// Terraform source extractors.
+	TerraformSource = InitMap{
+		terraformlock.Name: {terraformlock.New},
+		modulesjson.Name:   {modulesjson.New},
+	}
+	// Nix source extractors.
+	NixSource = InitMap{flakelock.Name: {flakelock.New}}
 
 	// Containers extractors.
 	Containers = InitMap{
@@ -215,6 +278,8 @@ var (
 		flatpak.Name:  {flatpak.NewDefault},
 		homebrew.Name: {homebrew.New},
 		macapps.Name:  {macapps.NewDefault},
+		winapps.Name:  {winapps.NewDefault},
+		yocto.Name:    {yocto.NewDefault},
 	}
 
 	// Credential extractors.
@@ -224,9 +289,16 @@ var (
 
 	// Misc extractors.
 	Misc = InitMap{
-		vscodeextensions.Name: {vscodeextensions.New},
-		wordpressplugins.Name: {wordpressplugins.NewDefault},
-		chromeextensions.Name: {chromeextensions.New},
+		vscodeextensions.Name:  {vscodeextensions.New},
+		wordpressplugins.Name:  {wordpressplugins.NewDefault},
+		jenkinsplugins.Name:    {jenkinsplugins.NewDefault},
+		teamcityplugins.Name:   {teamcityplugins.NewDefault},
+		chromeextensions.Name:  {chromeextensions.New},
+		firefoxextensions.Name: {firefoxextensions.New},
+		mlmodel.Name:           {mlmodel.NewDefault},
+		embeddedversion.Name:   {embeddedversion.NewDefault},
+		codeclib.Name:          {codeclib.NewDefault},
+		runtimeversion.Name:    {runtimeversion.New},
 	}
 
 	// Collections of extractors.
@@ -242,23 +314,29 @@ var (
 		ErlangSource,
 		ElixirSource,
 		HaskellSource,
+		JuliaSource,
+		OCamlSource,
 		PHPSource,
 		RSource,
 		RubySource,
 		RustSource,
 		DotnetSource,
 		SwiftSource,
+		TerraformSource,
+		NixSource,
 		Secrets,
 	)
 
 	// Artifact extractors find packages on built systems (e.g. parsing
 	// descriptors of installed packages).
 	Artifact = concat(
+		CppArtifact,
 		JavaArtifact,
 		JavascriptArtifact,
 		PythonArtifact,
 		GoArtifact,
 		DotnetArtifact,
+		PHPArtifact,
 		RustArtifact,
 		SBOM,
 		OS,
@@ -284,7 +362,7 @@ var (
 
 	extractorNames = concat(All, InitMap{
 		// Languages.
-		"cpp":        vals(CppSource),
+		"cpp":        vals(concat(CppSource, CppArtifact)),
 		"java":       vals(concat(JavaSource, JavaArtifact)),
 		"javascript": vals(concat(JavascriptSource, JavascriptArtifact)),
 		"python":     vals(concat(PythonSource, PythonArtifact)),
@@ -293,18 +371,23 @@ var (
 		"erlang":     vals(ErlangSource),
 		"elixir":     vals(ElixirSource),
 		"haskell":    vals(HaskellSource),
+		"julia":      vals(JuliaSource),
+		"ocaml":      vals(OCamlSource),
 		"r":          vals(RSource),
 		"ruby":       vals(RubySource),
 		"dotnet":     vals(concat(DotnetSource, DotnetArtifact)),
-		"php":        vals(PHPSource),
+		"php":        vals(concat(PHPSource, PHPArtifact)),
 		"rust":       vals(concat(RustSource, RustArtifact)),
 		"swift":      vals(SwiftSource),
+		"terraform":  vals(TerraformSource),
+		"nix":        vals(NixSource),
 
 		"sbom":       vals(SBOM),
 		"os":         vals(OS),
 		"containers": vals(Containers),
 		"secrets":    vals(Secrets),
 		"misc":       vals(Misc),
+		"embedded":   vals(Embedded),
 
 		// Collections.
 		"artifact":           vals(Artifact),
@@ -35,15 +35,17 @@ import (
 // Name is the name for the Chrome extensions extractor
 const Name = "chrome/extensions"
 
+// The patterns match the extensions of the default profile and of the
+// additional profiles, which Chrome stores in "Profile 1", "Profile 2", etc.
 var (
-	windowsChromeExtensionsPattern   = regexp.MustCompile(`(?m)\/Google\/Chrome(?: Beta| SxS| for Testing|)\/User Data\/Default\/Extensions\/[a-p]{32}\/[^\/]+\/manifest\.json$`)
-	windowsChromiumExtensionsPattern = regexp.MustCompile(`(?m)\/Chromium\/User Data\/Default\/Extensions\/[a-p]{32}\/[^\/]+\/manifest\.json$`)
+	windowsChromeExtensionsPattern   = regexp.MustCompile(`(?m)\/Google\/Chrome(?: Beta| SxS| for Testing|)\/User Data\/(?:Default|Profile \d+)\/Extensions\/[a-p]{32}\/[^\/]+\/manifest\.json$`)
+	windowsChromiumExtensionsPattern = regexp.MustCompile(`(?m)\/Chromium\/User Data\/(?:Default|Profile \d+)\/Extensions\/[a-p]{32}\/[^\/]+\/manifest\.json$`)
 
-	macosChromeExtensionsPattern   = regexp.MustCompile(`(?m)\/Google\/Chrome(?: Beta| SxS| for Testing| Canary|)\/Default\/Extensions\/[a-p]{32}\/[^\/]+\/manifest\.json$`)
-	macosChromiumExtensionsPattern = regexp.MustCompile(`(?m)\/Chromium\/Default\/Extensions\/[a-p]{32}\/[^\/]+\/manifest\.json$`)
+	macosChromeExtensionsPattern   = regexp.MustCompile(`(?m)\/Google\/Chrome(?: Beta| SxS| for Testing| Canary|)\/(?:Default|Profile \d+)\/Extensions\/[a-p]{32}\/[^\/]+\/manifest\.json$`)
+	macosChromiumExtensionsPattern = regexp.MustCompile(`(?m)\/Chromium\/(?:Default|Profile \d+)\/Extensions\/[a-p]{32}\/[^\/]+\/manifest\.json$`)
 
-	linuxChromeExtensionsPattern   = regexp.MustCompile(`(?m)\/google-chrome(?:-beta|-unstable|-for-testing|)\/Default\/Extensions\/[a-p]{32}\/[^\/]+\/manifest\.json$`)
-	linuxChromiumExtensionsPattern = regexp.MustCompile(`(?m)\/chromium\/Default\/Extensions\/[a-p]{32}\/[^\/]+\/manifest\.json$`)
+	linuxChromeExtensionsPattern   = regexp.MustCompile(`(?m)\/google-chrome(?:-beta|-unstable|-for-testing|)\/(?:Default|Profile \d+)\/Extensions\/[a-p]{32}\/[^\/]+\/manifest\.json$`)
+	linuxChromiumExtensionsPattern = regexp.MustCompile(`(?m)\/chromium\/(?:Default|Profile \d+)\/Extensions\/[a-p]{32}\/[^\/]+\/manifest\.json$`)
 )
 
 type manifest struct {
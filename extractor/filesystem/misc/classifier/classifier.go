@@ -0,0 +1,188 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package classifier runs a caller-provided classifier on the files matching
+// configured patterns during the filesystem walk, e.g. to detect personal data
+// in CSV files and SQL dumps without walking the filesystem a second time.
+package classifier
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/plugin"
+)
+
+const (
+	// Name is the unique name of this extractor.
+	Name = "misc/classifier"
+
+	defaultPublisher = "SCALIBR"
+)
+
+// Classification is a kind of sensitive data that a Classifier found in a file.
+type Classification struct {
+	// A short identifier of the kind of data, e.g. "email-address". Used as the
+	// reference of the advisory of the reported finding.
+	Category string
+	// Optional: A human-readable summary of the data found, e.g. "1200 email
+	// addresses in column 3".
+	Details string
+	// Optional: The severity of the exposure.
+	Severity inventory.SeverityEnum
+}
+
+// Classifier inspects the contents of files for sensitive data.
+type Classifier interface {
+	// Classify returns the kinds of sensitive data found in the file at the
+	// given path, relative to the scan root.
+	Classify(ctx context.Context, path string, r io.Reader) ([]*Classification, error)
+}
+
+// ClassifierFunc is an adapter to use an ordinary function as a Classifier.
+type ClassifierFunc func(ctx context.Context, path string, r io.Reader) ([]*Classification, error)
+
+// Classify calls f(ctx, path, r).
+func (f ClassifierFunc) Classify(ctx context.Context, path string, r io.Reader) ([]*Classification, error) {
+	return f(ctx, path, r)
+}
+
+// Config is the configuration for the Extractor.
+type Config struct {
+	// The classifier to run on the matching files.
+	Classifier Classifier
+	// The patterns of the files to classify, in path.Match syntax. Patterns
+	// without a slash are matched against the file name, e.g. "*.csv", others
+	// against the path relative to the scan root, e.g. "backups/*.sql".
+	// Matching is case-insensitive.
+	Patterns []string
+	// Optional: Files larger than this are skipped. If 0, no limit is applied.
+	MaxFileSizeBytes int64
+	// Optional: The publisher of the advisories of the reported findings.
+	// Defaults to "SCALIBR".
+	Publisher string
+}
+
+// Extractor runs a classifier on the files matching the configured patterns
+// and reports the sensitive data found as generic findings.
+type Extractor struct {
+	classifier       Classifier
+	patterns         []string
+	maxFileSizeBytes int64
+	publisher        string
+}
+
+// New returns an extractor that runs the configured classifier.
+func New(cfg Config) (filesystem.Extractor, error) {
+	if cfg.Classifier == nil {
+		return nil, errors.New("no classifier configured")
+	}
+	var patterns []string
+	for _, p := range cfg.Patterns {
+		p = strings.ToLower(p)
+		if _, err := path.Match(p, ""); err != nil {
+			return nil, fmt.Errorf("invalid pattern %q: %w", p, err)
+		}
+		patterns = append(patterns, p)
+	}
+	publisher := cfg.Publisher
+	if publisher == "" {
+		publisher = defaultPublisher
+	}
+	return &Extractor{
+		classifier:       cfg.Classifier,
+		patterns:         patterns,
+		maxFileSizeBytes: cfg.MaxFileSizeBytes,
+		publisher:        publisher,
+	}, nil
+}
+
+// Name of the extractor.
+func (e Extractor) Name() string { return Name }
+
+// Version of the extractor.
+func (e Extractor) Version() int { return 0 }
+
+// Requirements of the extractor.
+func (e Extractor) Requirements() *plugin.Capabilities { return &plugin.Capabilities{} }
+
+// FileRequired returns true if the file matches one of the configured patterns.
+func (e Extractor) FileRequired(api filesystem.FileAPI) bool {
+	if !e.matches(api.Path()) {
+		return false
+	}
+	if e.maxFileSizeBytes <= 0 {
+		return true
+	}
+	info, err := api.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Size() <= e.maxFileSizeBytes
+}
+
+func (e Extractor) matches(p string) bool {
+	p = strings.ToLower(p)
+	base := path.Base(p)
+	for _, pattern := range e.patterns {
+		name := base
+		if strings.Contains(pattern, "/") {
+			name = p
+		}
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Extract runs the classifier on the file and returns a finding for each kind
+// of sensitive data it found.
+func (e Extractor) Extract(ctx context.Context, input *filesystem.ScanInput) (inventory.Inventory, error) {
+	classifications, err := e.classifier.Classify(ctx, input.Path, input.Reader)
+	if err != nil {
+		return inventory.Inventory{}, fmt.Errorf("failed to classify %s: %w", input.Path, err)
+	}
+	var findings []*inventory.GenericFinding
+	for _, c := range classifications {
+		extra := input.Path
+		if c.Details != "" {
+			extra += ": " + c.Details
+		}
+		findings = append(findings, &inventory.GenericFinding{
+			Adv: &inventory.GenericFindingAdvisory{
+				ID: &inventory.AdvisoryID{
+					Publisher: e.publisher,
+					Reference: c.Category,
+				},
+				Title: "File contains sensitive data: " + c.Category,
+				Description: "The classifier found data of this category in the file. Files with " +
+					"sensitive data that are readable on the scanned system can be exfiltrated.",
+				Recommendation: "Verify that the file is expected on this system. Remove it, or " +
+					"restrict its permissions and encrypt its contents.",
+				Sev: c.Severity,
+			},
+			Target: &inventory.GenericFindingTargetDetails{Extra: extra},
+		})
+	}
+	return inventory.Inventory{GenericFindings: findings}, nil
+}
+
+var _ filesystem.Extractor = Extractor{}
@@ -0,0 +1,201 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package classifier_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/extractor/filesystem/misc/classifier"
+	"github.com/google/osv-scalibr/extractor/filesystem/simplefileapi"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/testing/fakefs"
+)
+
+// emailClassifier reports the number of lines with an "@".
+var emailClassifier = classifier.ClassifierFunc(func(_ context.Context, _ string, r io.Reader) ([]*classifier.Classification, error) {
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	n := strings.Count(string(content), "@")
+	if n == 0 {
+		return nil, nil
+	}
+	return []*classifier.Classification{{
+		Category: "email-address",
+		Details:  strings.Repeat("*", n),
+		Severity: inventory.SeverityMedium,
+	}}, nil
+})
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		desc    string
+		cfg     classifier.Config
+		wantErr bool
+	}{
+		{
+			desc: "valid",
+			cfg:  classifier.Config{Classifier: emailClassifier, Patterns: []string{"*.csv"}},
+		},
+		{
+			desc:    "no classifier",
+			cfg:     classifier.Config{Patterns: []string{"*.csv"}},
+			wantErr: true,
+		},
+		{
+			desc:    "invalid pattern",
+			cfg:     classifier.Config{Classifier: emailClassifier, Patterns: []string{"[.csv"}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			_, err := classifier.New(tt.cfg)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("New(%+v) error: %v, want error: %v", tt.cfg, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFileRequired(t *testing.T) {
+	tests := []struct {
+		desc     string
+		path     string
+		fileSize int64
+		want     bool
+	}{
+		{
+			desc: "matching name",
+			path: "exports/users.csv",
+			want: true,
+		},
+		{
+			desc: "matching name in different case",
+			path: "exports/USERS.CSV",
+			want: true,
+		},
+		{
+			desc: "matching path",
+			path: "backups/db.sql",
+			want: true,
+		},
+		{
+			desc: "sql dump outside of backups",
+			path: "migrations/001.sql",
+			want: false,
+		},
+		{
+			desc: "other file",
+			path: "exports/users.json",
+			want: false,
+		},
+		{
+			desc:     "too large",
+			path:     "exports/users.csv",
+			fileSize: 2000,
+			want:     false,
+		},
+	}
+	e, err := classifier.New(classifier.Config{
+		Classifier:       emailClassifier,
+		Patterns:         []string{"*.csv", "backups/*.sql"},
+		MaxFileSizeBytes: 1000,
+	})
+	if err != nil {
+		t.Fatalf("New(): %v", err)
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			api := simplefileapi.New(tt.path, fakefs.FakeFileInfo{FileName: tt.path, FileSize: tt.fileSize})
+			if got := e.FileRequired(api); got != tt.want {
+				t.Errorf("FileRequired(%s): got %v, want %v", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtract(t *testing.T) {
+	tests := []struct {
+		desc         string
+		classifier   classifier.Classifier
+		content      string
+		wantFindings []*inventory.GenericFinding
+		wantErr      error
+	}{
+		{
+			desc:       "no sensitive data",
+			classifier: emailClassifier,
+			content:    "id,name\n1,alice\n",
+		},
+		{
+			desc:       "sensitive data",
+			classifier: emailClassifier,
+			content:    "id,email\n1,alice@example.com\n2,bob@example.com\n",
+			wantFindings: []*inventory.GenericFinding{{
+				Adv: &inventory.GenericFindingAdvisory{
+					ID: &inventory.AdvisoryID{
+						Publisher: "ACME",
+						Reference: "email-address",
+					},
+					Title: "File contains sensitive data: email-address",
+					Description: "The classifier found data of this category in the file. Files with " +
+						"sensitive data that are readable on the scanned system can be exfiltrated.",
+					Recommendation: "Verify that the file is expected on this system. Remove it, or " +
+						"restrict its permissions and encrypt its contents.",
+					Sev: inventory.SeverityMedium,
+				},
+				Target: &inventory.GenericFindingTargetDetails{Extra: "exports/users.csv: **"},
+			}},
+		},
+		{
+			desc: "classifier error",
+			classifier: classifier.ClassifierFunc(func(context.Context, string, io.Reader) ([]*classifier.Classification, error) {
+				return nil, errTest
+			}),
+			wantErr: errTest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			e, err := classifier.New(classifier.Config{
+				Classifier: tt.classifier,
+				Patterns:   []string{"*.csv"},
+				Publisher:  "ACME",
+			})
+			if err != nil {
+				t.Fatalf("New(): %v", err)
+			}
+			input := &filesystem.ScanInput{Path: "exports/users.csv", Reader: strings.NewReader(tt.content)}
+			got, err := e.Extract(context.Background(), input)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Extract(): got error %v, want %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.wantFindings, got.GenericFindings, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("Extract(): unexpected findings (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+var errTest = errors.New("test error")
@@ -0,0 +1,419 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package codeclib extracts the versions of multimedia codec libraries such as
+// the FFmpeg libraries, GStreamer and its plugins, libvpx and libopus that are
+// bundled in application directories instead of being installed through a
+// package manager.
+package codeclib
+
+import (
+	"bytes"
+	"context"
+	"debug/elf"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/extractor/filesystem/internal/units"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/plugin"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scalibr/stats"
+)
+
+const (
+	// Name is the unique name of this extractor.
+	Name = "binary/codeclib"
+)
+
+// library is a codec library whose files are recognized by their names.
+type library struct {
+	// name is the name of the reported package.
+	name string
+	// file matches the base names of the library's files.
+	file *regexp.Regexp
+	// versionString matches a string compiled into the library. Its first group
+	// is the version.
+	versionString *regexp.Regexp
+	// cpeVendor and cpeProduct are the vendor and product parts of the NVD CPE.
+	cpeVendor  string
+	cpeProduct string
+}
+
+var (
+	libraries = []library{
+		{
+			// Each FFmpeg library contains e.g. "FFmpeg version 6.1.1" or, if built
+			// from a release tag, "FFmpeg version n6.1.1".
+			name:          "ffmpeg",
+			file:          regexp.MustCompile(`^(?:lib)?(?:avcodec|avdevice|avfilter|avformat|avutil|postproc|swresample|swscale)(?:[.-]\d+)*\.(?:so|dll|dylib)(?:\.\d+)*$`),
+			versionString: regexp.MustCompile(`FFmpeg version n?(\d+\.\d+(?:\.\d+)?)\x00`),
+			cpeVendor:     "ffmpeg",
+			cpeProduct:    "ffmpeg",
+		},
+		{
+			// The GStreamer core and helper libraries, e.g. libgstreamer-1.0.so.0 or
+			// libgstvideo-1.0.so.0. Their version is read from the file name.
+			name:       "gstreamer",
+			file:       regexp.MustCompile(`^libgst[a-z0-9]+-1\.0\.(?:so|dylib)(?:\.\d+)*$`),
+			cpeVendor:  "gstreamer_project",
+			cpeProduct: "gstreamer",
+		},
+		{
+			name:          "libvpx",
+			file:          regexp.MustCompile(`^(?:lib)?vpx(?:-\d+)?\.(?:so|dll|dylib)(?:\.\d+)*$`),
+			versionString: regexp.MustCompile(`WebM Project VP[89] (?:En|De)coder v(\d+\.\d+\.\d+)`),
+			cpeVendor:     "webmproject",
+			cpeProduct:    "libvpx",
+		},
+		{
+			name:          "libopus",
+			file:          regexp.MustCompile(`^(?:lib)?opus(?:-\d+)?\.(?:so|dll|dylib)(?:\.\d+)*$`),
+			versionString: regexp.MustCompile(`libopus (\d+\.\d+(?:\.\d+)?)`),
+			cpeVendor:     "opus-codec",
+			cpeProduct:    "opus",
+		},
+		{
+			name:          "libvorbis",
+			file:          regexp.MustCompile(`^(?:lib)?vorbis(?:enc|file)?(?:-\d+)?\.(?:so|dll|dylib)(?:\.\d+)*$`),
+			versionString: regexp.MustCompile(`Xiph\.Org libVorbis (\d+\.\d+\.\d+)`),
+			cpeVendor:     "xiph",
+			cpeProduct:    "libvorbis",
+		},
+		{
+			name:          "libtheora",
+			file:          regexp.MustCompile(`^(?:lib)?theora(?:enc|dec)?(?:-\d+)?\.(?:so|dll|dylib)(?:\.\d+)*$`),
+			versionString: regexp.MustCompile(`Xiph\.Org libtheora (\d+\.\d+(?:\.\d+)?)`),
+			cpeVendor:     "xiph",
+			cpeProduct:    "libtheora",
+		},
+		{
+			// x264 has no release versions. It's versioned by the API version and the
+			// revision, e.g. "x264 - core 164 r3108", which distributions package as
+			// 0.164.3108.
+			name:          "x264",
+			file:          regexp.MustCompile(`^(?:lib)?x264(?:-\d+)?\.(?:so|dll|dylib)(?:\.\d+)*$`),
+			versionString: regexp.MustCompile(`x264 - core (\d+) r(\d+)`),
+		},
+	}
+
+	// gstPluginFile matches the plugins GStreamer loads from its plugin
+	// directories, e.g. gstreamer-1.0/libgstcoreelements.so.
+	gstPluginFile = regexp.MustCompile(`^libgst[a-z0-9_]+\.(?:so|dylib)$`)
+	// gstPluginDesc matches the version, license and source module of the plugin
+	// description that GST_PLUGIN_DEFINE compiles into each plugin.
+	gstPluginDesc = regexp.MustCompile(`\x00(\d+\.\d+\.\d+)\x00+(?:LGPL|GPL|MIT/X11|BSD|MPL|Proprietary)\x00+(gst-plugins-(?:base|good|bad|ugly)|gst-libav|gstreamer)\x00`)
+	// gstLibFileVersion matches the versions of the GStreamer libraries, which
+	// encode the minor and micro version as e.g. "0.2204.0" for 1.22.4.
+	gstLibFileVersion = regexp.MustCompile(`\.so\.0\.(\d{3,4})\.0$`)
+
+	// systemLibDirs are the directories whose libraries are installed by the OS
+	// package manager and reported by the OS extractors.
+	systemLibDirs = []string{"lib", "lib32", "lib64", "usr/lib", "usr/lib32", "usr/lib64"}
+
+	elfMagic    = []byte{0x7f, 'E', 'L', 'F'}
+	peMagic     = []byte{'M', 'Z'}
+	machOMagics = [][]byte{
+		{0xfe, 0xed, 0xfa, 0xce}, {0xce, 0xfa, 0xed, 0xfe},
+		{0xfe, 0xed, 0xfa, 0xcf}, {0xcf, 0xfa, 0xed, 0xfe},
+		{0xca, 0xfe, 0xba, 0xbe},
+	}
+)
+
+// Config is the configuration for the codec library extractor.
+type Config struct {
+	// Stats is a stats collector for reporting metrics.
+	Stats stats.Collector
+	// MaxFileSizeBytes is the maximum file size this extractor will read. If
+	// `FileRequired` gets a bigger file, it will return false.
+	MaxFileSizeBytes int64
+	// IncludeSystemDirs makes the extractor also report the libraries in the
+	// system library directories such as /usr/lib, which are usually installed
+	// by the OS package manager.
+	IncludeSystemDirs bool
+}
+
+// DefaultConfig returns the default configuration for the codec library extractor.
+func DefaultConfig() Config {
+	return Config{
+		Stats:            nil,
+		MaxFileSizeBytes: 100 * units.MiB,
+	}
+}
+
+// Extractor extracts codec library versions from bundled shared libraries.
+type Extractor struct {
+	stats             stats.Collector
+	maxFileSizeBytes  int64
+	includeSystemDirs bool
+}
+
+// New returns a codec library extractor.
+//
+// For most use cases, initialize with:
+// ```
+// e := New(DefaultConfig())
+// ```
+func New(cfg Config) *Extractor {
+	return &Extractor{
+		stats:             cfg.Stats,
+		maxFileSizeBytes:  cfg.MaxFileSizeBytes,
+		includeSystemDirs: cfg.IncludeSystemDirs,
+	}
+}
+
+// NewDefault returns an extractor with the default config settings.
+func NewDefault() filesystem.Extractor {
+	return New(DefaultConfig())
+}
+
+// Name of the extractor.
+func (e Extractor) Name() string { return Name }
+
+// Version of the extractor.
+func (e Extractor) Version() int { return 0 }
+
+// Requirements of the extractor.
+func (e Extractor) Requirements() *plugin.Capabilities { return &plugin.Capabilities{} }
+
+// FileRequired returns true if the file is a known codec library or a
+// GStreamer plugin.
+func (e Extractor) FileRequired(api filesystem.FileAPI) bool {
+	p := api.Path()
+	if !isCodecLibrary(p) {
+		return false
+	}
+	if !e.includeSystemDirs && inSystemLibDir(p) {
+		return false
+	}
+
+	fileinfo, err := api.Stat()
+	if err != nil || !fileinfo.Mode().IsRegular() {
+		return false
+	}
+
+	if e.maxFileSizeBytes > 0 && fileinfo.Size() > e.maxFileSizeBytes {
+		e.reportFileRequired(api.Path(), fileinfo.Size(), stats.FileRequiredResultSizeLimitExceeded)
+		return false
+	}
+
+	e.reportFileRequired(api.Path(), fileinfo.Size(), stats.FileRequiredResultOK)
+	return true
+}
+
+func isCodecLibrary(p string) bool {
+	base := path.Base(p)
+	if isGStreamerPlugin(p) {
+		return true
+	}
+	for _, l := range libraries {
+		if l.file.MatchString(base) {
+			return true
+		}
+	}
+	return false
+}
+
+func isGStreamerPlugin(p string) bool {
+	return path.Base(path.Dir(p)) == "gstreamer-1.0" && gstPluginFile.MatchString(path.Base(p))
+}
+
+func inSystemLibDir(p string) bool {
+	for _, dir := range systemLibDirs {
+		if strings.HasPrefix(p, dir+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+func (e Extractor) reportFileRequired(path string, fileSizeBytes int64, result stats.FileRequiredResult) {
+	if e.stats == nil {
+		return
+	}
+	e.stats.AfterFileRequired(e.Name(), &stats.FileRequiredStats{
+		Path:          path,
+		Result:        result,
+		FileSizeBytes: fileSizeBytes,
+	})
+}
+
+// Extract returns the codec library contained in the file, if its version
+// could be determined.
+func (e Extractor) Extract(ctx context.Context, input *filesystem.ScanInput) (inventory.Inventory, error) {
+	pkgs, err := e.extractFromInput(input)
+	if e.stats != nil {
+		var fileSizeBytes int64
+		if input.Info != nil {
+			fileSizeBytes = input.Info.Size()
+		}
+		e.stats.AfterFileExtracted(e.Name(), &stats.FileExtractedStats{
+			Path:          input.Path,
+			Result:        filesystem.ExtractorErrorToFileExtractedResult(err),
+			FileSizeBytes: fileSizeBytes,
+		})
+	}
+	if err != nil {
+		return inventory.Inventory{}, fmt.Errorf("%s halted at %q: %w", e.Name(), input.Path, err)
+	}
+	return inventory.Inventory{Packages: pkgs}, nil
+}
+
+func (e Extractor) extractFromInput(input *filesystem.ScanInput) ([]*extractor.Package, error) {
+	r := input.Reader
+	if e.maxFileSizeBytes > 0 {
+		r = io.LimitReader(r, e.maxFileSizeBytes)
+	}
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	if !isBinary(data) {
+		return nil, nil
+	}
+
+	p := input.Path
+	base := path.Base(p)
+	m := &Metadata{Library: libraryName(base), Soname: soname(data)}
+	var name, version, cpeVendor, cpeProduct string
+	if isGStreamerPlugin(p) {
+		match := gstPluginDesc.FindSubmatch(data)
+		if match == nil {
+			return nil, nil
+		}
+		name, version = string(match[2]), string(match[1])
+		m.VersionSource = VersionSourceEmbeddedString
+		m.VersionString = version
+		cpeVendor, cpeProduct = "gstreamer_project", "gstreamer"
+	} else {
+		l, ok := libraryFor(base)
+		if !ok {
+			return nil, nil
+		}
+		name, cpeVendor, cpeProduct = l.name, l.cpeVendor, l.cpeProduct
+		version = l.versionFromString(data, m)
+		if version == "" && l.name == "gstreamer" {
+			version = gstreamerFileVersion(base)
+			m.VersionSource = VersionSourceFileName
+		}
+	}
+	if version == "" {
+		return nil, nil
+	}
+	if cpeVendor != "" {
+		m.CPEs = []string{fmt.Sprintf("cpe:2.3:a:%s:%s:%s:*:*:*:*:*:*:*", cpeVendor, cpeProduct, version)}
+	}
+
+	return []*extractor.Package{{
+		Name:      name,
+		Version:   version,
+		PURLType:  purl.TypeGeneric,
+		Metadata:  m,
+		Locations: []string{input.Path},
+	}}, nil
+}
+
+func libraryFor(base string) (library, bool) {
+	for _, l := range libraries {
+		if l.file.MatchString(base) {
+			return l, true
+		}
+	}
+	return library{}, false
+}
+
+// versionFromString returns the version from the string compiled into the
+// library and records the string in the metadata.
+func (l library) versionFromString(data []byte, m *Metadata) string {
+	if l.versionString == nil {
+		return ""
+	}
+	match := l.versionString.FindSubmatch(data)
+	if match == nil {
+		return ""
+	}
+	m.VersionSource = VersionSourceEmbeddedString
+	m.VersionString = strings.TrimSuffix(string(match[0]), "\x00")
+	if l.name == "x264" {
+		return fmt.Sprintf("0.%s.%s", match[1], match[2])
+	}
+	return string(match[1])
+}
+
+// gstreamerFileVersion returns the version encoded in the file name of a
+// GStreamer library, e.g. 1.22.4 for libgstreamer-1.0.so.0.2204.0.
+func gstreamerFileVersion(base string) string {
+	match := gstLibFileVersion.FindStringSubmatch(base)
+	if match == nil {
+		return ""
+	}
+	n, err := strconv.Atoi(match[1])
+	if err != nil {
+		return ""
+	}
+	return fmt.Sprintf("1.%d.%d", n/100, n%100)
+}
+
+// libraryName returns the name of the library without its prefix, file
+// extension and version, e.g. "avcodec" for libavcodec.so.60.31.102.
+func libraryName(base string) string {
+	name := strings.TrimPrefix(base, "lib")
+	if i := strings.IndexAny(name, ".-"); i >= 0 {
+		// Keep the API version of GStreamer libraries, e.g. "gstreamer-1.0".
+		if strings.HasPrefix(name[i:], "-1.0") {
+			return name[:i+4]
+		}
+		return name[:i]
+	}
+	return name
+}
+
+// soname returns the DT_SONAME of an ELF shared library, or an empty string if
+// the file isn't a valid ELF file or has no SONAME.
+func soname(data []byte) string {
+	if !bytes.HasPrefix(data, elfMagic) {
+		return ""
+	}
+	f, err := elf.NewFile(bytes.NewReader(data))
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+	names, err := f.DynString(elf.DT_SONAME)
+	if err != nil || len(names) == 0 {
+		return ""
+	}
+	return names[0]
+}
+
+// isBinary returns whether the data is an ELF, Mach-O or PE file.
+func isBinary(data []byte) bool {
+	if bytes.HasPrefix(data, elfMagic) || bytes.HasPrefix(data, peMagic) {
+		return true
+	}
+	for _, magic := range machOMagics {
+		if bytes.HasPrefix(data, magic) {
+			return true
+		}
+	}
+	return false
+}
+
+var _ filesystem.Extractor = Extractor{}
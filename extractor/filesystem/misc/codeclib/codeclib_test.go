@@ -0,0 +1,294 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codeclib_test
+
+import (
+	"context"
+	"io/fs"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/extractor/filesystem/internal/units"
+	"github.com/google/osv-scalibr/extractor/filesystem/misc/codeclib"
+	"github.com/google/osv-scalibr/extractor/filesystem/simplefileapi"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scalibr/stats"
+	"github.com/google/osv-scalibr/testing/extracttest"
+	"github.com/google/osv-scalibr/testing/fakefs"
+	"github.com/google/osv-scalibr/testing/testcollector"
+)
+
+func TestFileRequired(t *testing.T) {
+	tests := []struct {
+		name              string
+		path              string
+		mode              fs.FileMode
+		fileSizeBytes     int64
+		maxFileSizeBytes  int64
+		includeSystemDirs bool
+		wantRequired      bool
+		wantResultMetric  stats.FileRequiredResult
+	}{
+		{
+			name:             "bundled_ffmpeg_library",
+			path:             "opt/app/lib/libavcodec.so.60.31.102",
+			wantRequired:     true,
+			wantResultMetric: stats.FileRequiredResultOK,
+		},
+		{
+			name:         "ffmpeg_library_windows",
+			path:         "Program Files/App/avformat-60.dll",
+			wantRequired: true,
+		},
+		{
+			name:         "ffmpeg_library_macos",
+			path:         "Applications/App.app/Contents/Frameworks/libavutil.58.dylib",
+			wantRequired: true,
+		},
+		{
+			name:         "gstreamer_library",
+			path:         "opt/app/lib/libgstreamer-1.0.so.0.2204.0",
+			wantRequired: true,
+		},
+		{
+			name:         "gstreamer_plugin",
+			path:         "opt/app/lib/gstreamer-1.0/libgstvideoconvertscale.so",
+			wantRequired: true,
+		},
+		{
+			name:         "gstreamer_plugin_name_outside_plugin_dir",
+			path:         "opt/app/lib/libgstvideoconvertscale.so",
+			wantRequired: false,
+		},
+		{
+			name:         "other_codec_library",
+			path:         "var/lib/flatpak/app/org.example/files/lib/libvpx.so.7.1.0",
+			wantRequired: true,
+		},
+		{
+			name:         "system_library_dir",
+			path:         "usr/lib/x86_64-linux-gnu/libavcodec.so.59",
+			wantRequired: false,
+		},
+		{
+			name:              "system_library_dir_included",
+			path:              "usr/lib/x86_64-linux-gnu/libavcodec.so.59",
+			includeSystemDirs: true,
+			wantRequired:      true,
+		},
+		{
+			name:         "unrelated_library",
+			path:         "opt/app/lib/libssl.so.3",
+			wantRequired: false,
+		},
+		{
+			name:         "symlink",
+			path:         "opt/app/lib/libavcodec.so",
+			mode:         fs.ModeSymlink,
+			wantRequired: false,
+		},
+		{
+			name:             "file_size_greater_than_max_size",
+			path:             "opt/app/lib/libavcodec.so.60",
+			fileSizeBytes:    1000 * units.KiB,
+			maxFileSizeBytes: 100 * units.KiB,
+			wantRequired:     false,
+			wantResultMetric: stats.FileRequiredResultSizeLimitExceeded,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			collector := testcollector.New()
+			var e filesystem.Extractor = codeclib.New(codeclib.Config{
+				Stats:             collector,
+				MaxFileSizeBytes:  tt.maxFileSizeBytes,
+				IncludeSystemDirs: tt.includeSystemDirs,
+			})
+
+			fileSizeBytes := tt.fileSizeBytes
+			if fileSizeBytes == 0 {
+				fileSizeBytes = 1000
+			}
+
+			isRequired := e.FileRequired(simplefileapi.New(tt.path, fakefs.FakeFileInfo{
+				FileName: filepath.Base(tt.path),
+				FileMode: tt.mode,
+				FileSize: fileSizeBytes,
+			}))
+			if isRequired != tt.wantRequired {
+				t.Fatalf("FileRequired(%s): got %v, want %v", tt.path, isRequired, tt.wantRequired)
+			}
+
+			gotResultMetric := collector.FileRequiredResult(tt.path)
+			if tt.wantResultMetric != "" && gotResultMetric != tt.wantResultMetric {
+				t.Errorf("FileRequired(%s) recorded result metric %v, want result metric %v", tt.path, gotResultMetric, tt.wantResultMetric)
+			}
+		})
+	}
+}
+
+func TestExtract(t *testing.T) {
+	tests := []extracttest.TestTableEntry{
+		{
+			Name: "ffmpeg_library",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/libavcodec.so.60.31.102",
+			},
+			WantPackages: []*extractor.Package{{
+				Name:     "ffmpeg",
+				Version:  "6.1.1",
+				PURLType: purl.TypeGeneric,
+				Metadata: &codeclib.Metadata{
+					Library:       "avcodec",
+					Soname:        "libavcodec.so.60",
+					VersionSource: codeclib.VersionSourceEmbeddedString,
+					VersionString: "FFmpeg version n6.1.1",
+					CPEs:          []string{"cpe:2.3:a:ffmpeg:ffmpeg:6.1.1:*:*:*:*:*:*:*"},
+				},
+				Locations: []string{"testdata/libavcodec.so.60.31.102"},
+			}},
+		},
+		{
+			Name: "ffmpeg_library_pe",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/avcodec-60.dll",
+			},
+			WantPackages: []*extractor.Package{{
+				Name:     "ffmpeg",
+				Version:  "6.0",
+				PURLType: purl.TypeGeneric,
+				Metadata: &codeclib.Metadata{
+					Library:       "avcodec",
+					VersionSource: codeclib.VersionSourceEmbeddedString,
+					VersionString: "FFmpeg version 6.0",
+					CPEs:          []string{"cpe:2.3:a:ffmpeg:ffmpeg:6.0:*:*:*:*:*:*:*"},
+				},
+				Locations: []string{"testdata/avcodec-60.dll"},
+			}},
+		},
+		{
+			Name: "gstreamer_library",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/libgstreamer-1.0.so.0.2204.0",
+			},
+			WantPackages: []*extractor.Package{{
+				Name:     "gstreamer",
+				Version:  "1.22.4",
+				PURLType: purl.TypeGeneric,
+				Metadata: &codeclib.Metadata{
+					Library:       "gstreamer-1.0",
+					Soname:        "libgstreamer-1.0.so.0",
+					VersionSource: codeclib.VersionSourceFileName,
+					CPEs:          []string{"cpe:2.3:a:gstreamer_project:gstreamer:1.22.4:*:*:*:*:*:*:*"},
+				},
+				Locations: []string{"testdata/libgstreamer-1.0.so.0.2204.0"},
+			}},
+		},
+		{
+			Name: "gstreamer_plugin",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/gstreamer-1.0/libgstcoreelements.so",
+			},
+			WantPackages: []*extractor.Package{{
+				Name:     "gstreamer",
+				Version:  "1.22.4",
+				PURLType: purl.TypeGeneric,
+				Metadata: &codeclib.Metadata{
+					Library:       "gstcoreelements",
+					VersionSource: codeclib.VersionSourceEmbeddedString,
+					VersionString: "1.22.4",
+					CPEs:          []string{"cpe:2.3:a:gstreamer_project:gstreamer:1.22.4:*:*:*:*:*:*:*"},
+				},
+				Locations: []string{"testdata/gstreamer-1.0/libgstcoreelements.so"},
+			}},
+		},
+		{
+			Name: "libvpx",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/libvpx.so.7.1.0",
+			},
+			WantPackages: []*extractor.Package{{
+				Name:     "libvpx",
+				Version:  "1.13.1",
+				PURLType: purl.TypeGeneric,
+				Metadata: &codeclib.Metadata{
+					Library:       "vpx",
+					VersionSource: codeclib.VersionSourceEmbeddedString,
+					VersionString: "WebM Project VP9 Decoder v1.13.1",
+					CPEs:          []string{"cpe:2.3:a:webmproject:libvpx:1.13.1:*:*:*:*:*:*:*"},
+				},
+				Locations: []string{"testdata/libvpx.so.7.1.0"},
+			}},
+		},
+		{
+			Name: "x264",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/libx264.so.164",
+			},
+			WantPackages: []*extractor.Package{{
+				Name:     "x264",
+				Version:  "0.164.3108",
+				PURLType: purl.TypeGeneric,
+				Metadata: &codeclib.Metadata{
+					Library:       "x264",
+					VersionSource: codeclib.VersionSourceEmbeddedString,
+					VersionString: "x264 - core 164 r3108",
+				},
+				Locations: []string{"testdata/libx264.so.164"},
+			}},
+		},
+		{
+			Name: "no_version_string",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/libvorbis.so.0.4.9",
+			},
+			WantPackages: nil,
+		},
+		{
+			Name: "not_a_binary",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/libopus.so.0",
+			},
+			WantPackages: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			var e filesystem.Extractor = codeclib.NewDefault()
+
+			scanInput := extracttest.GenerateScanInputMock(t, tt.InputConfig)
+			defer extracttest.CloseTestScanInput(t, scanInput)
+
+			got, err := e.Extract(context.Background(), &scanInput)
+
+			if diff := cmp.Diff(tt.WantErr, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("%s.Extract(%q) error diff (-want +got):\n%s", e.Name(), tt.InputConfig.Path, diff)
+				return
+			}
+
+			want := inventory.Inventory{Packages: tt.WantPackages}
+			if diff := cmp.Diff(want, got, cmpopts.SortSlices(extracttest.PackageCmpLess)); diff != "" {
+				t.Errorf("%s.Extract(%q) diff (-want +got):\n%s", e.Name(), tt.InputConfig.Path, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codeclib
+
+import (
+	pb "github.com/google/osv-scalibr/binary/proto/scan_result_go_proto"
+)
+
+// Sources of the versions of codec libraries.
+const (
+	// VersionSourceEmbeddedString means the version was read from a string
+	// compiled into the library.
+	VersionSourceEmbeddedString = "embedded_string"
+	// VersionSourceFileName means the version was read from the library's file
+	// name.
+	VersionSourceFileName = "file_name"
+)
+
+// Metadata holds the details of a codec library.
+type Metadata struct {
+	// Library is the name of the library, e.g. "avcodec" or "gstreamer-1.0".
+	Library string `json:"library"`
+	// Soname is the DT_SONAME of ELF libraries, e.g. "libavcodec.so.60".
+	Soname string `json:"soname,omitempty"`
+	// VersionSource is where the version was read from.
+	VersionSource string `json:"versionSource"`
+	// VersionString is the embedded string the version was read from, e.g.
+	// "FFmpeg version 6.1.1".
+	VersionString string `json:"versionString,omitempty"`
+	// CPEs are the CPE 2.3 names of the library version.
+	CPEs []string `json:"cpes,omitempty"`
+}
+
+// SetProto sets the CodecLibraryMetadata field in the Package proto.
+func (m *Metadata) SetProto(p *pb.Package) {
+	if m == nil {
+		return
+	}
+	if p == nil {
+		return
+	}
+
+	p.Metadata = &pb.Package_CodecLibraryMetadata{
+		CodecLibraryMetadata: &pb.CodecLibraryMetadata{
+			Library:       m.Library,
+			Soname:        m.Soname,
+			VersionSource: m.VersionSource,
+			VersionString: m.VersionString,
+			Cpes:          m.CPEs,
+		},
+	}
+}
+
+// ToStruct converts the CodecLibraryMetadata proto to a Metadata struct.
+func ToStruct(m *pb.CodecLibraryMetadata) *Metadata {
+	if m == nil {
+		return nil
+	}
+
+	return &Metadata{
+		Library:       m.GetLibrary(),
+		Soname:        m.GetSoname(),
+		VersionSource: m.GetVersionSource(),
+		VersionString: m.GetVersionString(),
+		CPEs:          m.GetCpes(),
+	}
+}
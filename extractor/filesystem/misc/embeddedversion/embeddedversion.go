@@ -0,0 +1,242 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package embeddedversion extracts the versions of well-known software such as
+// OpenSSL, curl, BusyBox and nginx from the version strings compiled into
+// executables and shared libraries. This covers statically built binaries that
+// aren't registered in any package database.
+package embeddedversion
+
+import (
+	"bytes"
+	"context"
+	"debug/macho"
+	"fmt"
+	"io"
+	"regexp"
+
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/extractor/filesystem/internal/units"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/plugin"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scalibr/stats"
+)
+
+const (
+	// Name is the unique name of this extractor.
+	Name = "binary/embeddedversion"
+)
+
+// software is a well-known software whose version string is compiled into the
+// binaries that contain it.
+type software struct {
+	name string
+	// re matches the version string. Its first group is the version.
+	re *regexp.Regexp
+	// cpeVendor and cpeProduct are the vendor and product parts of the NVD CPE.
+	cpeVendor  string
+	cpeProduct string
+}
+
+var (
+	knownSoftware = []software{
+		// OPENSSL_VERSION_TEXT, e.g. "OpenSSL 3.0.13 30 Jan 2024" or "OpenSSL 1.0.2k-fips  26 Jan 2017".
+		{"openssl", regexp.MustCompile(`OpenSSL (\d+\.\d+\.\d+[a-z]{0,2})(?:-[a-z]+)? +\d{1,2} [A-Z][a-z]{2} \d{4}`), "openssl", "openssl"},
+		// The --version output of the curl tool, e.g. "curl 8.5.0 (%s) %s".
+		{"curl", regexp.MustCompile(`curl (\d+\.\d+\.\d+) \(`), "haxx", "curl"},
+		// The banner of BusyBox, e.g. "BusyBox v1.36.1 (2023-11-07 18:53:09 UTC)".
+		{"busybox", regexp.MustCompile(`BusyBox v(\d+\.\d+\.\d+)`), "busybox", "busybox"},
+		// NGINX_VER, e.g. "nginx/1.25.3".
+		{"nginx", regexp.MustCompile(`nginx/(\d+\.\d+\.\d+)`), "f5", "nginx"},
+	}
+
+	elfMagic    = []byte{0x7f, 'E', 'L', 'F'}
+	peMagic     = []byte{'M', 'Z'}
+	machOMagics = [][]byte{
+		{0xfe, 0xed, 0xfa, 0xce}, {0xce, 0xfa, 0xed, 0xfe},
+		{0xfe, 0xed, 0xfa, 0xcf}, {0xcf, 0xfa, 0xed, 0xfe},
+	}
+	machOFatMagic = []byte{0xca, 0xfe, 0xba, 0xbe}
+)
+
+// Config is the configuration for the embedded version extractor.
+type Config struct {
+	// Stats is a stats collector for reporting metrics.
+	Stats stats.Collector
+	// MaxFileSizeBytes is the maximum file size this extractor will read. If
+	// `FileRequired` gets a bigger file, it will return false.
+	MaxFileSizeBytes int64
+}
+
+// DefaultConfig returns the default configuration for the embedded version extractor.
+func DefaultConfig() Config {
+	return Config{
+		Stats:            nil,
+		MaxFileSizeBytes: 200 * units.MiB,
+	}
+}
+
+// Extractor extracts software versions from strings embedded in binaries.
+type Extractor struct {
+	stats            stats.Collector
+	maxFileSizeBytes int64
+}
+
+// New returns an embedded version extractor.
+//
+// For most use cases, initialize with:
+// ```
+// e := New(DefaultConfig())
+// ```
+func New(cfg Config) *Extractor {
+	return &Extractor{
+		stats:            cfg.Stats,
+		maxFileSizeBytes: cfg.MaxFileSizeBytes,
+	}
+}
+
+// NewDefault returns an extractor with the default config settings.
+func NewDefault() filesystem.Extractor {
+	return New(DefaultConfig())
+}
+
+// Name of the extractor.
+func (e Extractor) Name() string { return Name }
+
+// Version of the extractor.
+func (e Extractor) Version() int { return 0 }
+
+// Requirements of the extractor.
+func (e Extractor) Requirements() *plugin.Capabilities { return &plugin.Capabilities{} }
+
+// FileRequired returns true if the file is likely an executable or a shared library.
+func (e Extractor) FileRequired(api filesystem.FileAPI) bool {
+	if !filesystem.IsInterestingExecutable(api) {
+		return false
+	}
+
+	fileinfo, err := api.Stat()
+	if err != nil || !fileinfo.Mode().IsRegular() {
+		return false
+	}
+
+	if e.maxFileSizeBytes > 0 && fileinfo.Size() > e.maxFileSizeBytes {
+		e.reportFileRequired(api.Path(), fileinfo.Size(), stats.FileRequiredResultSizeLimitExceeded)
+		return false
+	}
+
+	e.reportFileRequired(api.Path(), fileinfo.Size(), stats.FileRequiredResultOK)
+	return true
+}
+
+func (e Extractor) reportFileRequired(path string, fileSizeBytes int64, result stats.FileRequiredResult) {
+	if e.stats == nil {
+		return
+	}
+	e.stats.AfterFileRequired(e.Name(), &stats.FileRequiredStats{
+		Path:          path,
+		Result:        result,
+		FileSizeBytes: fileSizeBytes,
+	})
+}
+
+// Extract returns a package for each known software whose version string is
+// found in the binary.
+func (e Extractor) Extract(ctx context.Context, input *filesystem.ScanInput) (inventory.Inventory, error) {
+	pkgs, err := e.extractFromInput(input)
+	if e.stats != nil {
+		var fileSizeBytes int64
+		if input.Info != nil {
+			fileSizeBytes = input.Info.Size()
+		}
+		e.stats.AfterFileExtracted(e.Name(), &stats.FileExtractedStats{
+			Path:          input.Path,
+			Result:        filesystem.ExtractorErrorToFileExtractedResult(err),
+			FileSizeBytes: fileSizeBytes,
+		})
+	}
+	if err != nil {
+		return inventory.Inventory{}, fmt.Errorf("%s halted at %q: %w", e.Name(), input.Path, err)
+	}
+	return inventory.Inventory{Packages: pkgs}, nil
+}
+
+func (e Extractor) extractFromInput(input *filesystem.ScanInput) ([]*extractor.Package, error) {
+	r := input.Reader
+	if e.maxFileSizeBytes > 0 {
+		r = io.LimitReader(r, e.maxFileSizeBytes)
+	}
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	format := binaryFormat(data)
+	if format == "" {
+		return nil, nil
+	}
+
+	var pkgs []*extractor.Package
+	for _, s := range knownSoftware {
+		seen := make(map[string]bool)
+		for _, m := range s.re.FindAllSubmatch(data, -1) {
+			version := string(m[1])
+			if seen[version] {
+				continue
+			}
+			seen[version] = true
+			pkgs = append(pkgs, &extractor.Package{
+				Name:     s.name,
+				Version:  version,
+				PURLType: purl.TypeGeneric,
+				Metadata: &Metadata{
+					Format:        format,
+					VersionString: string(m[0]),
+					CPEs:          []string{fmt.Sprintf("cpe:2.3:a:%s:%s:%s:*:*:*:*:*:*:*", s.cpeVendor, s.cpeProduct, version)},
+				},
+				Locations: []string{input.Path},
+			})
+		}
+	}
+	return pkgs, nil
+}
+
+// binaryFormat returns the format of the given ELF, Mach-O or PE file, or an
+// empty string if it's not in one of these formats.
+func binaryFormat(data []byte) string {
+	switch {
+	case bytes.HasPrefix(data, elfMagic):
+		return FormatELF
+	case bytes.HasPrefix(data, peMagic):
+		return FormatPE
+	case bytes.HasPrefix(data, machOFatMagic):
+		// Java class files share the magic bytes of universal binaries.
+		ff, err := macho.NewFatFile(bytes.NewReader(data))
+		if err != nil {
+			return ""
+		}
+		ff.Close()
+		return FormatMachO
+	}
+	for _, magic := range machOMagics {
+		if bytes.HasPrefix(data, magic) {
+			return FormatMachO
+		}
+	}
+	return ""
+}
+
+var _ filesystem.Extractor = Extractor{}
@@ -0,0 +1,241 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package embeddedversion_test
+
+import (
+	"context"
+	"io/fs"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/extractor/filesystem/internal/units"
+	"github.com/google/osv-scalibr/extractor/filesystem/misc/embeddedversion"
+	"github.com/google/osv-scalibr/extractor/filesystem/simplefileapi"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scalibr/stats"
+	"github.com/google/osv-scalibr/testing/extracttest"
+	"github.com/google/osv-scalibr/testing/fakefs"
+	"github.com/google/osv-scalibr/testing/testcollector"
+)
+
+func TestFileRequired(t *testing.T) {
+	tests := []struct {
+		name             string
+		path             string
+		mode             fs.FileMode
+		fileSizeBytes    int64
+		maxFileSizeBytes int64
+		wantRequired     bool
+		wantResultMetric stats.FileRequiredResult
+	}{
+		{
+			name:             "executable",
+			path:             "usr/local/bin/curl",
+			mode:             0755,
+			wantRequired:     true,
+			wantResultMetric: stats.FileRequiredResultOK,
+		},
+		{
+			name:             "shared_library",
+			path:             "opt/app/lib/libssl.so",
+			mode:             0644,
+			wantRequired:     true,
+			wantResultMetric: stats.FileRequiredResultOK,
+		},
+		{
+			name:             "windows_executable",
+			path:             "nginx/nginx.exe",
+			mode:             0644,
+			wantRequired:     true,
+			wantResultMetric: stats.FileRequiredResultOK,
+		},
+		{
+			name:         "not_executable",
+			path:         "usr/share/doc/curl/README",
+			mode:         0644,
+			wantRequired: false,
+		},
+		{
+			name:         "executable_source_file",
+			path:         "src/main.go",
+			mode:         0755,
+			wantRequired: false,
+		},
+		{
+			name:             "file_size_greater_than_max_size",
+			path:             "usr/local/bin/nginx",
+			mode:             0755,
+			fileSizeBytes:    1000 * units.KiB,
+			maxFileSizeBytes: 100 * units.KiB,
+			wantRequired:     false,
+			wantResultMetric: stats.FileRequiredResultSizeLimitExceeded,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			collector := testcollector.New()
+			var e filesystem.Extractor = embeddedversion.New(embeddedversion.Config{
+				Stats:            collector,
+				MaxFileSizeBytes: tt.maxFileSizeBytes,
+			})
+
+			fileSizeBytes := tt.fileSizeBytes
+			if fileSizeBytes == 0 {
+				fileSizeBytes = 1000
+			}
+
+			isRequired := e.FileRequired(simplefileapi.New(tt.path, fakefs.FakeFileInfo{
+				FileName: filepath.Base(tt.path),
+				FileMode: tt.mode,
+				FileSize: fileSizeBytes,
+			}))
+			if isRequired != tt.wantRequired {
+				t.Fatalf("FileRequired(%s): got %v, want %v", tt.path, isRequired, tt.wantRequired)
+			}
+
+			gotResultMetric := collector.FileRequiredResult(tt.path)
+			if tt.wantResultMetric != "" && gotResultMetric != tt.wantResultMetric {
+				t.Errorf("FileRequired(%s) recorded result metric %v, want result metric %v", tt.path, gotResultMetric, tt.wantResultMetric)
+			}
+		})
+	}
+}
+
+func TestExtract(t *testing.T) {
+	tests := []extracttest.TestTableEntry{
+		{
+			Name: "static_curl_with_openssl",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/static-curl",
+			},
+			WantPackages: []*extractor.Package{
+				{
+					Name:     "openssl",
+					Version:  "3.0.13",
+					PURLType: purl.TypeGeneric,
+					Metadata: &embeddedversion.Metadata{
+						Format:        embeddedversion.FormatELF,
+						VersionString: "OpenSSL 3.0.13 30 Jan 2024",
+						CPEs:          []string{"cpe:2.3:a:openssl:openssl:3.0.13:*:*:*:*:*:*:*"},
+					},
+					Locations: []string{"testdata/static-curl"},
+				},
+				{
+					Name:     "curl",
+					Version:  "8.5.0",
+					PURLType: purl.TypeGeneric,
+					Metadata: &embeddedversion.Metadata{
+						Format:        embeddedversion.FormatELF,
+						VersionString: "curl 8.5.0 (",
+						CPEs:          []string{"cpe:2.3:a:haxx:curl:8.5.0:*:*:*:*:*:*:*"},
+					},
+					Locations: []string{"testdata/static-curl"},
+				},
+			},
+		},
+		{
+			Name: "busybox",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/busybox",
+			},
+			WantPackages: []*extractor.Package{{
+				Name:     "busybox",
+				Version:  "1.36.1",
+				PURLType: purl.TypeGeneric,
+				Metadata: &embeddedversion.Metadata{
+					Format:        embeddedversion.FormatELF,
+					VersionString: "BusyBox v1.36.1",
+					CPEs:          []string{"cpe:2.3:a:busybox:busybox:1.36.1:*:*:*:*:*:*:*"},
+				},
+				Locations: []string{"testdata/busybox"},
+			}},
+		},
+		{
+			Name: "nginx_pe",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/nginx.exe",
+			},
+			WantPackages: []*extractor.Package{{
+				Name:     "nginx",
+				Version:  "1.25.3",
+				PURLType: purl.TypeGeneric,
+				Metadata: &embeddedversion.Metadata{
+					Format:        embeddedversion.FormatPE,
+					VersionString: "nginx/1.25.3",
+					CPEs:          []string{"cpe:2.3:a:f5:nginx:1.25.3:*:*:*:*:*:*:*"},
+				},
+				Locations: []string{"testdata/nginx.exe"},
+			}},
+		},
+		{
+			Name: "openssl_macho",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/libcrypto.3.dylib",
+			},
+			WantPackages: []*extractor.Package{{
+				Name:     "openssl",
+				Version:  "1.1.1w",
+				PURLType: purl.TypeGeneric,
+				Metadata: &embeddedversion.Metadata{
+					Format:        embeddedversion.FormatMachO,
+					VersionString: "OpenSSL 1.1.1w  11 Sep 2023",
+					CPEs:          []string{"cpe:2.3:a:openssl:openssl:1.1.1w:*:*:*:*:*:*:*"},
+				},
+				Locations: []string{"testdata/libcrypto.3.dylib"},
+			}},
+		},
+		{
+			Name: "no_known_software",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/plain-elf",
+			},
+			WantPackages: nil,
+		},
+		{
+			Name: "script_is_not_a_binary",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/nobinary.sh",
+			},
+			WantPackages: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			var e filesystem.Extractor = embeddedversion.NewDefault()
+
+			scanInput := extracttest.GenerateScanInputMock(t, tt.InputConfig)
+			defer extracttest.CloseTestScanInput(t, scanInput)
+
+			got, err := e.Extract(context.Background(), &scanInput)
+
+			if diff := cmp.Diff(tt.WantErr, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("%s.Extract(%q) error diff (-want +got):\n%s", e.Name(), tt.InputConfig.Path, diff)
+				return
+			}
+
+			want := inventory.Inventory{Packages: tt.WantPackages}
+			if diff := cmp.Diff(want, got, cmpopts.SortSlices(extracttest.PackageCmpLess)); diff != "" {
+				t.Errorf("%s.Extract(%q) diff (-want +got):\n%s", e.Name(), tt.InputConfig.Path, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package embeddedversion
+
+import (
+	pb "github.com/google/osv-scalibr/binary/proto/scan_result_go_proto"
+)
+
+// Binary formats the version strings are searched in.
+const (
+	FormatELF   = "elf"
+	FormatMachO = "macho"
+	FormatPE    = "pe"
+)
+
+// Metadata holds the details of a software version found in a binary.
+type Metadata struct {
+	// Format is the format of the binary, e.g. "elf".
+	Format string `json:"format"`
+	// VersionString is the embedded string the version was read from, e.g.
+	// "OpenSSL 3.0.13 30 Jan 2024".
+	VersionString string `json:"versionString"`
+	// CPEs are the CPE 2.3 names of the found software version.
+	CPEs []string `json:"cpes,omitempty"`
+}
+
+// SetProto sets the EmbeddedVersionMetadata field in the Package proto.
+func (m *Metadata) SetProto(p *pb.Package) {
+	if m == nil {
+		return
+	}
+	if p == nil {
+		return
+	}
+
+	p.Metadata = &pb.Package_EmbeddedVersionMetadata{
+		EmbeddedVersionMetadata: &pb.EmbeddedVersionMetadata{
+			Format:        m.Format,
+			VersionString: m.VersionString,
+			Cpes:          m.CPEs,
+		},
+	}
+}
+
+// ToStruct converts the EmbeddedVersionMetadata proto to a Metadata struct.
+func ToStruct(m *pb.EmbeddedVersionMetadata) *Metadata {
+	if m == nil {
+		return nil
+	}
+
+	return &Metadata{
+		Format:        m.GetFormat(),
+		VersionString: m.GetVersionString(),
+		CPEs:          m.GetCpes(),
+	}
+}
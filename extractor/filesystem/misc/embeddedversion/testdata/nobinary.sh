@@ -0,0 +1,2 @@
+#!/bin/sh
+echo "nginx/1.25.3"
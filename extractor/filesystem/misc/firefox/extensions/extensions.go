@@ -0,0 +1,156 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package extensions extracts Firefox extensions.
+package extensions
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/plugin"
+	"github.com/google/osv-scalibr/purl"
+)
+
+// Name is the name for the Firefox extensions extractor
+const Name = "firefox/extensions"
+
+// profileExtensionsPattern matches the add-on database of a Firefox profile:
+//
+//	Linux:   ~/.mozilla/firefox/<profile>/extensions.json
+//	macOS:   ~/Library/Application Support/Firefox/Profiles/<profile>/extensions.json
+//	Windows: %APPDATA%\Mozilla\Firefox\Profiles\<profile>\extensions.json
+//
+// Snap and Flatpak installs keep the Linux layout inside their sandbox dirs.
+var profileExtensionsPattern = regexp.MustCompile(`(?:^|/)(?:\.mozilla/firefox|Firefox/Profiles)/[^/]+/extensions\.json$`)
+
+// builtinLocations are the install locations of the add-ons shipped with
+// Firefox itself, which aren't reported.
+var builtinLocations = map[string]bool{
+	"app-builtin":         true,
+	"app-builtin-addons":  true,
+	"app-system-defaults": true,
+	"app-system-addons":   true,
+}
+
+// addonDB is the add-on database Firefox keeps in each profile. The manifests
+// themselves are packed in the .xpi archives of the extensions.
+type addonDB struct {
+	Addons []*addon `json:"addons"`
+}
+
+type addon struct {
+	ID              string `json:"id"`
+	Version         string `json:"version"`
+	Type            string `json:"type"`
+	ManifestVersion int    `json:"manifestVersion"`
+	DefaultLocale   struct {
+		Name        string `json:"name"`
+		Description string `json:"description"`
+		Creator     string `json:"creator"`
+		HomepageURL string `json:"homepageURL"`
+	} `json:"defaultLocale"`
+	Active          bool   `json:"active"`
+	SourceURI       string `json:"sourceURI"`
+	Location        string `json:"location"`
+	UserPermissions struct {
+		Permissions []string `json:"permissions"`
+		Origins     []string `json:"origins"`
+	} `json:"userPermissions"`
+}
+
+func (a *addon) validate() error {
+	if a.ID == "" {
+		return errors.New("field 'id' must be specified")
+	}
+	if a.Version == "" {
+		return errors.New("field 'version' must be specified")
+	}
+	return nil
+}
+
+// Extractor extracts Firefox extensions
+type Extractor struct{}
+
+// New returns a Firefox extensions extractor.
+func New() filesystem.Extractor {
+	return &Extractor{}
+}
+
+// Name of the extractor.
+func (e Extractor) Name() string { return Name }
+
+// Version of the extractor.
+func (e Extractor) Version() int { return 0 }
+
+// Requirements of the extractor.
+func (e Extractor) Requirements() *plugin.Capabilities { return &plugin.Capabilities{} }
+
+// FileRequired returns true if the file is the add-on database of a Firefox profile
+func (e Extractor) FileRequired(api filesystem.FileAPI) bool {
+	path := filepath.ToSlash(api.Path())
+
+	// pre-check to improve performances
+	if !strings.HasSuffix(path, "/extensions.json") {
+		return false
+	}
+	return profileExtensionsPattern.MatchString(path)
+}
+
+// Extract extracts the extensions installed in a Firefox profile
+func (e Extractor) Extract(ctx context.Context, input *filesystem.ScanInput) (inventory.Inventory, error) {
+	var db addonDB
+	if err := json.NewDecoder(input.Reader).Decode(&db); err != nil {
+		return inventory.Inventory{}, fmt.Errorf("could not extract add-on database: %w", err)
+	}
+
+	var pkgs []*extractor.Package
+	for _, a := range db.Addons {
+		// Themes, dictionaries and language packs are add-ons too.
+		if a.Type != "extension" || builtinLocations[a.Location] {
+			continue
+		}
+		if err := a.validate(); err != nil {
+			return inventory.Inventory{}, fmt.Errorf("bad format in add-on database: %w", err)
+		}
+		pkgs = append(pkgs, &extractor.Package{
+			Name:      a.ID,
+			Version:   a.Version,
+			PURLType:  purl.TypeGeneric,
+			Locations: []string{input.Path},
+			Metadata: &Metadata{
+				Name:            a.DefaultLocale.Name,
+				Description:     a.DefaultLocale.Description,
+				Creator:         a.DefaultLocale.Creator,
+				HomepageURL:     a.DefaultLocale.HomepageURL,
+				ManifestVersion: a.ManifestVersion,
+				Permissions:     a.UserPermissions.Permissions,
+				HostPermissions: a.UserPermissions.Origins,
+				SourceURI:       a.SourceURI,
+				Location:        a.Location,
+				Active:          a.Active,
+			},
+		})
+	}
+
+	return inventory.Inventory{Packages: pkgs}, nil
+}
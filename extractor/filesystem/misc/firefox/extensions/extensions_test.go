@@ -0,0 +1,161 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package extensions_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem/misc/firefox/extensions"
+	"github.com/google/osv-scalibr/extractor/filesystem/simplefileapi"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scalibr/testing/extracttest"
+)
+
+func TestExtractor_FileRequired(t *testing.T) {
+	tests := []struct {
+		inputPath string
+		want      bool
+	}{
+		{inputPath: "", want: false},
+		{inputPath: "home/user/.mozilla/firefox/abcd1234.default-release/extensions.json", want: true},
+		{inputPath: "/home/user/.mozilla/firefox/abcd1234.default-release/extensions.json", want: true},
+		{inputPath: "home/user/snap/firefox/common/.mozilla/firefox/abcd1234.default/extensions.json", want: true},
+		{inputPath: "home/user/.var/app/org.mozilla.firefox/.mozilla/firefox/abcd1234.default/extensions.json", want: true},
+		{inputPath: "Users/user/Library/Application Support/Firefox/Profiles/abcd1234.default-release/extensions.json", want: true},
+		{inputPath: "Users/user/AppData/Roaming/Mozilla/Firefox/Profiles/abcd1234.default-release/extensions.json", want: true},
+		{inputPath: "home/user/.mozilla/firefox/abcd1234.default-release/addons.json", want: false},
+		{inputPath: "home/user/.mozilla/firefox/extensions.json", want: false},
+		{inputPath: "home/user/.mozilla/firefox/abcd1234.default-release/extensions/extensions.json", want: false},
+		{inputPath: "home/user/.vscode/extensions/extensions.json", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.inputPath, func(t *testing.T) {
+			e := extensions.Extractor{}
+			got := e.FileRequired(simplefileapi.New(tt.inputPath, nil))
+			if got != tt.want {
+				t.Errorf("FileRequired(%s) got = %v, want %v", tt.inputPath, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtractor_Extract(t *testing.T) {
+	tests := []extracttest.TestTableEntry{
+		{
+			Name: "invalid",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/invalid.json",
+			},
+			WantErr: extracttest.ContainsErrStr{Str: "could not extract"},
+		},
+		{
+			Name: "missing version",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/missing-version.json",
+			},
+			WantErr: extracttest.ContainsErrStr{Str: "bad format"},
+		},
+		{
+			Name: "only built-in extensions",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/no-extensions.json",
+			},
+			WantPackages: nil,
+		},
+		{
+			Name: "extensions",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/extensions.json",
+			},
+			WantPackages: []*extractor.Package{
+				{
+					Name:      "uBlock0@raymondhill.net",
+					Version:   "1.58.0",
+					PURLType:  purl.TypeGeneric,
+					Locations: []string{"testdata/extensions.json"},
+					Metadata: &extensions.Metadata{
+						Name:            "uBlock Origin",
+						Description:     "Finally, an efficient blocker. Easy on CPU and memory.",
+						Creator:         "Raymond Hill & contributors",
+						HomepageURL:     "https://github.com/gorhill/uBlock#ublock-origin",
+						ManifestVersion: 2,
+						Permissions:     []string{"alarms", "dns", "storage", "tabs", "webRequest", "webRequestBlocking"},
+						HostPermissions: []string{"<all_urls>", "http://*/*", "https://*/*"},
+						SourceURI:       "https://addons.mozilla.org/firefox/downloads/file/4290466/ublock_origin-1.58.0.xpi",
+						Location:        "app-profile",
+						Active:          true,
+					},
+				},
+				{
+					Name:      "{446900e4-71c2-419f-a6a7-df9c091e268b}",
+					Version:   "2024.6.2",
+					PURLType:  purl.TypeGeneric,
+					Locations: []string{"testdata/extensions.json"},
+					Metadata: &extensions.Metadata{
+						Name:            "Bitwarden Password Manager",
+						Description:     "At home, at work, or on the go, Bitwarden easily secures all your passwords.",
+						Creator:         "Bitwarden Inc.",
+						ManifestVersion: 3,
+						Permissions:     []string{"clipboardRead", "clipboardWrite", "storage", "tabs"},
+						HostPermissions: []string{},
+						SourceURI:       "https://addons.mozilla.org/firefox/downloads/file/4305759/bitwarden_password_manager-2024.6.2.xpi",
+						Location:        "app-profile",
+						Active:          false,
+					},
+				},
+				{
+					Name:      "corp-proxy@example.com",
+					Version:   "3.1",
+					PURLType:  purl.TypeGeneric,
+					Locations: []string{"testdata/extensions.json"},
+					Metadata: &extensions.Metadata{
+						Name:            "Corporate Proxy Helper",
+						ManifestVersion: 2,
+						Permissions:     []string{"proxy"},
+						HostPermissions: []string{},
+						Location:        "app-system-share",
+						Active:          true,
+					},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			extr := extensions.New()
+
+			scanInput := extracttest.GenerateScanInputMock(t, tt.InputConfig)
+			defer extracttest.CloseTestScanInput(t, scanInput)
+
+			got, err := extr.Extract(context.Background(), &scanInput)
+
+			if diff := cmp.Diff(tt.WantErr, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("%s.Extract(%q) error diff (-want +got):\n%s", extr.Name(), tt.InputConfig.Path, diff)
+				return
+			}
+
+			want := inventory.Inventory{Packages: tt.WantPackages}
+			if diff := cmp.Diff(want, got, cmpopts.SortSlices(extracttest.PackageCmpLess)); diff != "" {
+				t.Errorf("%s.Extract(%q) diff (-want +got):\n%s", extr.Name(), tt.InputConfig.Path, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package extensions
+
+import (
+	pb "github.com/google/osv-scalibr/binary/proto/scan_result_go_proto"
+)
+
+// Metadata contains metadata for Firefox extensions.
+type Metadata struct {
+	Name        string
+	Description string
+	Creator     string
+	HomepageURL string
+	// ManifestVersion is the version of the WebExtension manifest format.
+	ManifestVersion int
+	// Permissions are the API permissions granted to the extension.
+	Permissions []string
+	// HostPermissions are the origins the extension has access to.
+	HostPermissions []string
+	// SourceURI is where the extension was installed from, e.g. addons.mozilla.org.
+	SourceURI string
+	// Location is the install location, e.g. "app-profile" for extensions the
+	// user installed or "app-system-share" for system-wide ones.
+	Location string
+	// Active is false if the extension is disabled.
+	Active bool
+}
+
+// SetProto sets the FirefoxExtensionsMetadata field in the Package proto.
+func (m *Metadata) SetProto(p *pb.Package) {
+	if m == nil {
+		return
+	}
+	if p == nil {
+		return
+	}
+
+	p.Metadata = &pb.Package_FirefoxExtensionsMetadata{
+		FirefoxExtensionsMetadata: &pb.FirefoxExtensionsMetadata{
+			Name:            m.Name,
+			Description:     m.Description,
+			Creator:         m.Creator,
+			HomepageUrl:     m.HomepageURL,
+			ManifestVersion: int32(m.ManifestVersion),
+			Permissions:     m.Permissions,
+			HostPermissions: m.HostPermissions,
+			SourceUri:       m.SourceURI,
+			Location:        m.Location,
+			Active:          m.Active,
+		},
+	}
+}
+
+// ToStruct converts the FirefoxExtensionsMetadata proto to a Metadata struct.
+func ToStruct(m *pb.FirefoxExtensionsMetadata) *Metadata {
+	if m == nil {
+		return nil
+	}
+
+	return &Metadata{
+		Name:            m.GetName(),
+		Description:     m.GetDescription(),
+		Creator:         m.GetCreator(),
+		HomepageURL:     m.GetHomepageUrl(),
+		ManifestVersion: int(m.GetManifestVersion()),
+		Permissions:     m.GetPermissions(),
+		HostPermissions: m.GetHostPermissions(),
+		SourceURI:       m.GetSourceUri(),
+		Location:        m.GetLocation(),
+		Active:          m.GetActive(),
+	}
+}
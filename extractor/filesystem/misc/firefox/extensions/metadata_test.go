@@ -0,0 +1,102 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package extensions_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/osv-scalibr/extractor/filesystem/misc/firefox/extensions"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	pb "github.com/google/osv-scalibr/binary/proto/scan_result_go_proto"
+)
+
+func TestSetProto(t *testing.T) {
+	testCases := []struct {
+		desc string
+		m    *extensions.Metadata
+		p    *pb.Package
+		want *pb.Package
+	}{
+		{
+			desc: "nil metadata",
+			m:    nil,
+			p:    &pb.Package{Name: "some-extension"},
+			want: &pb.Package{Name: "some-extension"},
+		},
+		{
+			desc: "nil package",
+			m:    &extensions.Metadata{Name: "uBlock Origin"},
+			p:    nil,
+			want: nil,
+		},
+		{
+			desc: "set all fields",
+			m: &extensions.Metadata{
+				Name:            "uBlock Origin",
+				Description:     "Finally, an efficient blocker.",
+				Creator:         "Raymond Hill & contributors",
+				HomepageURL:     "https://github.com/gorhill/uBlock",
+				ManifestVersion: 2,
+				Permissions:     []string{"storage", "tabs"},
+				HostPermissions: []string{"<all_urls>"},
+				SourceURI:       "https://addons.mozilla.org/firefox/downloads/file/4290466/ublock_origin-1.58.0.xpi",
+				Location:        "app-profile",
+				Active:          true,
+			},
+			p: &pb.Package{Name: "some-extension"},
+			want: &pb.Package{
+				Name: "some-extension",
+				Metadata: &pb.Package_FirefoxExtensionsMetadata{
+					FirefoxExtensionsMetadata: &pb.FirefoxExtensionsMetadata{
+						Name:            "uBlock Origin",
+						Description:     "Finally, an efficient blocker.",
+						Creator:         "Raymond Hill & contributors",
+						HomepageUrl:     "https://github.com/gorhill/uBlock",
+						ManifestVersion: 2,
+						Permissions:     []string{"storage", "tabs"},
+						HostPermissions: []string{"<all_urls>"},
+						SourceUri:       "https://addons.mozilla.org/firefox/downloads/file/4290466/ublock_origin-1.58.0.xpi",
+						Location:        "app-profile",
+						Active:          true,
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			p := proto.Clone(tc.p).(*pb.Package)
+			tc.m.SetProto(p)
+			if diff := cmp.Diff(tc.want, p, protocmp.Transform()); diff != "" {
+				t.Errorf("Metatadata{%+v}.SetProto(%+v): (-want +got):\n%s", tc.m, tc.p, diff)
+			}
+
+			// Test the reverse conversion for completeness.
+
+			if tc.p == nil && tc.want == nil {
+				return
+			}
+
+			got := extensions.ToStruct(p.GetFirefoxExtensionsMetadata())
+			if diff := cmp.Diff(tc.m, got); diff != "" {
+				t.Errorf("ToStruct(%+v): (-want +got):\n%s", p.GetFirefoxExtensionsMetadata(), diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,243 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package plugins extracts packages from installed Jenkins plugins.
+package plugins
+
+import (
+	"archive/zip"
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/extractor/filesystem/internal/units"
+	archivemeta "github.com/google/osv-scalibr/extractor/filesystem/language/java/archive/metadata"
+	scalibrfs "github.com/google/osv-scalibr/fs"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/plugin"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scalibr/stats"
+)
+
+const (
+	// Name is the unique name of this extractor.
+	Name = "jenkins/plugins"
+
+	// defaultGroupID is the Maven group ID of plugins whose manifest doesn't specify one.
+	// Older plugins were all published under it.
+	defaultGroupID = "org.jenkins-ci.plugins"
+	manifestPath   = "META-INF/MANIFEST.MF"
+)
+
+// Config is the configuration for the Jenkins plugins extractor.
+type Config struct {
+	// Stats is a stats collector for reporting metrics.
+	Stats stats.Collector
+	// MaxFileSizeBytes is the maximum plugin archive size this extractor will open. If
+	// `FileRequired` gets a bigger file, it will return false,
+	MaxFileSizeBytes int64
+}
+
+// DefaultConfig returns the default configuration for the Jenkins plugins extractor.
+func DefaultConfig() Config {
+	return Config{
+		Stats:            nil,
+		MaxFileSizeBytes: 100 * units.MiB,
+	}
+}
+
+// Extractor extracts Jenkins plugins from the .jpi and .hpi archives in the
+// plugins directory of a Jenkins home directory.
+type Extractor struct {
+	stats            stats.Collector
+	maxFileSizeBytes int64
+}
+
+// New returns a Jenkins plugins extractor.
+//
+// For most use cases, initialize with:
+// ```
+// e := New(DefaultConfig())
+// ```
+func New(cfg Config) *Extractor {
+	return &Extractor{
+		stats:            cfg.Stats,
+		maxFileSizeBytes: cfg.MaxFileSizeBytes,
+	}
+}
+
+// NewDefault returns an extractor with the default config settings.
+func NewDefault() filesystem.Extractor {
+	return New(DefaultConfig())
+}
+
+// Config returns the configuration of the extractor.
+func (e Extractor) Config() Config {
+	return Config{
+		Stats:            e.stats,
+		MaxFileSizeBytes: e.maxFileSizeBytes,
+	}
+}
+
+// Name of the extractor.
+func (e Extractor) Name() string { return Name }
+
+// Version of the extractor.
+func (e Extractor) Version() int { return 0 }
+
+// Requirements of the extractor.
+func (e Extractor) Requirements() *plugin.Capabilities { return &plugin.Capabilities{} }
+
+// FileRequired returns true if the specified file is a plugin archive in a plugins/ directory.
+func (e Extractor) FileRequired(api filesystem.FileAPI) bool {
+	p := api.Path()
+	ext := strings.ToLower(path.Ext(p))
+	if ext != ".jpi" && ext != ".hpi" {
+		return false
+	}
+	if path.Base(path.Dir(p)) != "plugins" {
+		return false
+	}
+
+	fileinfo, err := api.Stat()
+	if err != nil {
+		return false
+	}
+
+	if e.maxFileSizeBytes > 0 && fileinfo.Size() > e.maxFileSizeBytes {
+		e.reportFileRequired(p, fileinfo.Size(), stats.FileRequiredResultSizeLimitExceeded)
+		return false
+	}
+
+	e.reportFileRequired(p, fileinfo.Size(), stats.FileRequiredResultOK)
+	return true
+}
+
+func (e Extractor) reportFileRequired(path string, fileSizeBytes int64, result stats.FileRequiredResult) {
+	if e.stats == nil {
+		return
+	}
+	e.stats.AfterFileRequired(e.Name(), &stats.FileRequiredStats{
+		Path:          path,
+		Result:        result,
+		FileSizeBytes: fileSizeBytes,
+	})
+}
+
+// Extract reads the plugin manifest from the plugin archive.
+func (e Extractor) Extract(ctx context.Context, input *filesystem.ScanInput) (inventory.Inventory, error) {
+	pkg, err := e.extractFromInput(input)
+	if e.stats != nil {
+		var fileSizeBytes int64
+		if input.Info != nil {
+			fileSizeBytes = input.Info.Size()
+		}
+		e.stats.AfterFileExtracted(e.Name(), &stats.FileExtractedStats{
+			Path:          input.Path,
+			Result:        filesystem.ExtractorErrorToFileExtractedResult(err),
+			FileSizeBytes: fileSizeBytes,
+		})
+	}
+	if err != nil {
+		return inventory.Inventory{}, fmt.Errorf("%s halted at %q: %w", e.Name(), input.Path, err)
+	}
+	if pkg == nil {
+		return inventory.Inventory{}, nil
+	}
+	return inventory.Inventory{Packages: []*extractor.Package{pkg}}, nil
+}
+
+func (e Extractor) extractFromInput(input *filesystem.ScanInput) (*extractor.Package, error) {
+	if input.Info == nil {
+		return nil, fmt.Errorf("file info for %s is missing", input.Path)
+	}
+	r, err := scalibrfs.NewReaderAt(input.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("NewReaderAt(%s): %w", input.Path, err)
+	}
+	zipReader, err := zip.NewReader(r, input.Info.Size())
+	if err != nil {
+		return nil, fmt.Errorf("zip.NewReader(%s): %w", input.Path, err)
+	}
+
+	f, err := zipReader.Open(manifestPath)
+	if err != nil {
+		// Not a Jenkins plugin.
+		return nil, nil
+	}
+	defer f.Close()
+	attrs, err := parseManifest(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", manifestPath, err)
+	}
+
+	shortName := attrs["Short-Name"]
+	version := attrs["Plugin-Version"]
+	if shortName == "" || version == "" {
+		// The archive is not a Jenkins plugin.
+		return nil, nil
+	}
+	groupID := attrs["Group-Id"]
+	if groupID == "" {
+		groupID = defaultGroupID
+	}
+
+	return &extractor.Package{
+		Name:     groupID + ":" + shortName,
+		Version:  version,
+		PURLType: purl.TypeMaven,
+		Metadata: &archivemeta.Metadata{
+			ArtifactID: shortName,
+			GroupID:    groupID,
+		},
+		Locations: []string{input.Path},
+	}, nil
+}
+
+// parseManifest returns the main attributes of a JAR manifest.
+// See https://docs.oracle.com/en/java/javase/21/docs/specs/jar/jar.html#jar-manifest
+func parseManifest(r io.Reader) (map[string]string, error) {
+	attrs := map[string]string{}
+	scanner := bufio.NewScanner(r)
+	var lastKey string
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if line == "" {
+			// The main section ends at the first empty line.
+			break
+		}
+		// Lines starting with a space continue the value of the previous line.
+		if strings.HasPrefix(line, " ") {
+			if lastKey != "" {
+				attrs[lastKey] += line[1:]
+			}
+			continue
+		}
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		lastKey = strings.TrimSpace(key)
+		attrs[lastKey] = strings.TrimSpace(value)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return attrs, nil
+}
@@ -0,0 +1,212 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugins_test
+
+import (
+	"context"
+	"io/fs"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/extractor/filesystem/internal/units"
+	archivemeta "github.com/google/osv-scalibr/extractor/filesystem/language/java/archive/metadata"
+	"github.com/google/osv-scalibr/extractor/filesystem/misc/jenkins/plugins"
+	"github.com/google/osv-scalibr/extractor/filesystem/simplefileapi"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scalibr/stats"
+	"github.com/google/osv-scalibr/testing/extracttest"
+	"github.com/google/osv-scalibr/testing/fakefs"
+	"github.com/google/osv-scalibr/testing/testcollector"
+)
+
+func TestFileRequired(t *testing.T) {
+	tests := []struct {
+		name             string
+		path             string
+		fileSizeBytes    int64
+		maxFileSizeBytes int64
+		wantRequired     bool
+		wantResultMetric stats.FileRequiredResult
+	}{
+		{
+			name:             "jpi_plugin",
+			path:             "var/lib/jenkins/plugins/git.jpi",
+			wantRequired:     true,
+			wantResultMetric: stats.FileRequiredResultOK,
+		},
+		{
+			name:             "hpi_plugin",
+			path:             "var/jenkins_home/plugins/legacy.HPI",
+			wantRequired:     true,
+			wantResultMetric: stats.FileRequiredResultOK,
+		},
+		{
+			name:         "not_in_plugins_dir",
+			path:         "home/user/git.jpi",
+			wantRequired: false,
+		},
+		{
+			name:         "exploded_plugin_jar",
+			path:         "var/lib/jenkins/plugins/git/WEB-INF/lib/git.jar",
+			wantRequired: false,
+		},
+		{
+			name:         "disabled_marker",
+			path:         "var/lib/jenkins/plugins/git.jpi.disabled",
+			wantRequired: false,
+		},
+		{
+			name:             "file_size_greater_than_max_size",
+			path:             "var/lib/jenkins/plugins/git.jpi",
+			fileSizeBytes:    1000 * units.KiB,
+			maxFileSizeBytes: 100 * units.KiB,
+			wantRequired:     false,
+			wantResultMetric: stats.FileRequiredResultSizeLimitExceeded,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			collector := testcollector.New()
+			var e filesystem.Extractor = plugins.New(plugins.Config{
+				Stats:            collector,
+				MaxFileSizeBytes: tt.maxFileSizeBytes,
+			})
+
+			fileSizeBytes := tt.fileSizeBytes
+			if fileSizeBytes == 0 {
+				fileSizeBytes = 1000
+			}
+
+			isRequired := e.FileRequired(simplefileapi.New(tt.path, fakefs.FakeFileInfo{
+				FileName: filepath.Base(tt.path),
+				FileMode: fs.ModePerm,
+				FileSize: fileSizeBytes,
+			}))
+			if isRequired != tt.wantRequired {
+				t.Fatalf("FileRequired(%s): got %v, want %v", tt.path, isRequired, tt.wantRequired)
+			}
+
+			gotResultMetric := collector.FileRequiredResult(tt.path)
+			if tt.wantResultMetric != "" && gotResultMetric != tt.wantResultMetric {
+				t.Errorf("FileRequired(%s) recorded result metric %v, want result metric %v", tt.path, gotResultMetric, tt.wantResultMetric)
+			}
+		})
+	}
+}
+
+func TestExtract(t *testing.T) {
+	tests := []extracttest.TestTableEntry{
+		{
+			Name: "plugin_with_wrapped_manifest_lines",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/git.jpi",
+			},
+			WantPackages: []*extractor.Package{
+				{
+					Name:     "org.jenkins-ci.plugins:git",
+					Version:  "5.2.1",
+					PURLType: purl.TypeMaven,
+					Metadata: &archivemeta.Metadata{
+						ArtifactID: "git",
+						GroupID:    "org.jenkins-ci.plugins",
+					},
+					Locations: []string{"testdata/git.jpi"},
+				},
+			},
+		},
+		{
+			Name: "legacy_plugin_without_group_id",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/legacy.hpi",
+			},
+			WantPackages: []*extractor.Package{
+				{
+					Name:     "org.jenkins-ci.plugins:legacy-plugin",
+					Version:  "1.0",
+					PURLType: purl.TypeMaven,
+					Metadata: &archivemeta.Metadata{
+						ArtifactID: "legacy-plugin",
+						GroupID:    "org.jenkins-ci.plugins",
+					},
+					Locations: []string{"testdata/legacy.hpi"},
+				},
+			},
+		},
+		{
+			Name: "custom_group_id",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/custom-group.jpi",
+			},
+			WantPackages: []*extractor.Package{
+				{
+					Name:     "io.jenkins.plugins:very-long-plugin-name",
+					Version:  "2.0.3",
+					PURLType: purl.TypeMaven,
+					Metadata: &archivemeta.Metadata{
+						ArtifactID: "very-long-plugin-name",
+						GroupID:    "io.jenkins.plugins",
+					},
+					Locations: []string{"testdata/custom-group.jpi"},
+				},
+			},
+		},
+		{
+			Name: "no_manifest",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/no-manifest.jpi",
+			},
+		},
+		{
+			Name: "not_a_plugin",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/not-a-plugin.jpi",
+			},
+		},
+		{
+			Name: "invalid_zip",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/invalid.jpi",
+			},
+			WantErr: cmpopts.AnyError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			var e filesystem.Extractor = plugins.NewDefault()
+
+			scanInput := extracttest.GenerateScanInputMock(t, tt.InputConfig)
+			defer extracttest.CloseTestScanInput(t, scanInput)
+
+			got, err := e.Extract(context.Background(), &scanInput)
+
+			if diff := cmp.Diff(tt.WantErr, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("%s.Extract(%q) error diff (-want +got):\n%s", e.Name(), tt.InputConfig.Path, diff)
+				return
+			}
+
+			want := inventory.Inventory{Packages: tt.WantPackages}
+			if diff := cmp.Diff(want, got, cmpopts.SortSlices(extracttest.PackageCmpLess)); diff != "" {
+				t.Errorf("%s.Extract(%q) diff (-want +got):\n%s", e.Name(), tt.InputConfig.Path, diff)
+			}
+		})
+	}
+}
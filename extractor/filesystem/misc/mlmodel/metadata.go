@@ -0,0 +1,88 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mlmodel
+
+import (
+	pb "github.com/google/osv-scalibr/binary/proto/scan_result_go_proto"
+)
+
+// Model serialization formats.
+const (
+	FormatPickle      = "pickle"
+	FormatPyTorchZip  = "pytorch-zip"
+	FormatSafetensors = "safetensors"
+	FormatONNX        = "onnx"
+)
+
+// UnknownImport is recorded in the Imports of pickle-based models for callables
+// whose module and name couldn't be determined statically.
+const UnknownImport = "<unknown>"
+
+// Metadata holds the details of a machine learning model file.
+type Metadata struct {
+	// Format is the serialization format of the model file.
+	Format string `json:"format"`
+	// Framework is the ML framework that likely produced the model, e.g. "pytorch".
+	Framework string `json:"framework,omitempty"`
+	// FrameworkVersion is the version of the framework, if recorded in the file.
+	FrameworkVersion string `json:"frameworkVersion,omitempty"`
+	// Imports are the Python callables ("module.name") that loading a
+	// pickle-based model imports and may call.
+	Imports []string `json:"imports,omitempty"`
+	// Properties holds the metadata embedded in the model file, e.g. the
+	// safetensors "__metadata__" header or the ONNX metadata_props.
+	Properties map[string]string `json:"properties,omitempty"`
+}
+
+// IsPickleBased returns true if loading the model unpickles data, which can
+// execute arbitrary code.
+func (m *Metadata) IsPickleBased() bool {
+	return m.Format == FormatPickle || m.Format == FormatPyTorchZip
+}
+
+// SetProto sets the MLModelMetadata field in the Package proto.
+func (m *Metadata) SetProto(p *pb.Package) {
+	if m == nil {
+		return
+	}
+	if p == nil {
+		return
+	}
+
+	p.Metadata = &pb.Package_MlModelMetadata{
+		MlModelMetadata: &pb.MLModelMetadata{
+			Format:           m.Format,
+			Framework:        m.Framework,
+			FrameworkVersion: m.FrameworkVersion,
+			Imports:          m.Imports,
+			Properties:       m.Properties,
+		},
+	}
+}
+
+// ToStruct converts the MLModelMetadata proto to a Metadata struct.
+func ToStruct(m *pb.MLModelMetadata) *Metadata {
+	if m == nil {
+		return nil
+	}
+
+	return &Metadata{
+		Format:           m.GetFormat(),
+		Framework:        m.GetFramework(),
+		FrameworkVersion: m.GetFrameworkVersion(),
+		Imports:          m.GetImports(),
+		Properties:       m.GetProperties(),
+	}
+}
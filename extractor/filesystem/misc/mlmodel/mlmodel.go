@@ -0,0 +1,312 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package mlmodel extracts machine learning model files (pickle, PyTorch,
+// safetensors and ONNX) along with their format, framework hints and embedded
+// metadata. Pickle-based models are never unpickled: their opcodes are only
+// inspected to find the Python callables they reference.
+package mlmodel
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	scalibrfs "github.com/google/osv-scalibr/fs"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/plugin"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scalibr/stats"
+)
+
+const (
+	// Name is the unique name of this extractor.
+	Name = "ml/models"
+
+	// maxPickles is the maximum number of consecutive pickles read from a
+	// pickle file. Legacy PyTorch files store the model in the third pickle.
+	maxPickles = 4
+)
+
+// modelExtensions maps the file extensions of model files to their expected format.
+var modelExtensions = map[string]string{
+	".pkl":         FormatPickle,
+	".pickle":      FormatPickle,
+	".joblib":      FormatPickle,
+	".pt":          FormatPickle,
+	".pth":         FormatPickle,
+	".ckpt":        FormatPickle,
+	".safetensors": FormatSafetensors,
+	".onnx":        FormatONNX,
+}
+
+// frameworkModules maps the top-level Python modules referenced by pickles to
+// the framework name, in order of precedence.
+var frameworkModules = []struct {
+	module    string
+	framework string
+}{
+	{"torch", "pytorch"},
+	{"sklearn", "scikit-learn"},
+	{"xgboost", "xgboost"},
+	{"lightgbm", "lightgbm"},
+	{"catboost", "catboost"},
+	{"keras", "tensorflow"},
+	{"tensorflow", "tensorflow"},
+	{"numpy", "numpy"},
+}
+
+var zipMagic = []byte("PK\x03\x04")
+
+// Config is the configuration for the ML model extractor.
+type Config struct {
+	// Stats is a stats collector for reporting metrics.
+	Stats stats.Collector
+	// MaxFileSizeBytes is the maximum model file size this extractor will open.
+	// If `FileRequired` gets a bigger file, it will return false. Only the
+	// headers of most model files are read so the default is no limit.
+	MaxFileSizeBytes int64
+}
+
+// DefaultConfig returns the default configuration for the ML model extractor.
+func DefaultConfig() Config {
+	return Config{
+		Stats:            nil,
+		MaxFileSizeBytes: 0,
+	}
+}
+
+// Extractor extracts machine learning model files.
+type Extractor struct {
+	stats            stats.Collector
+	maxFileSizeBytes int64
+}
+
+// New returns an ML model extractor.
+//
+// For most use cases, initialize with:
+// ```
+// e := New(DefaultConfig())
+// ```
+func New(cfg Config) *Extractor {
+	return &Extractor{
+		stats:            cfg.Stats,
+		maxFileSizeBytes: cfg.MaxFileSizeBytes,
+	}
+}
+
+// NewDefault returns an extractor with the default config settings.
+func NewDefault() filesystem.Extractor {
+	return New(DefaultConfig())
+}
+
+// Name of the extractor.
+func (e Extractor) Name() string { return Name }
+
+// Version of the extractor.
+func (e Extractor) Version() int { return 0 }
+
+// Requirements of the extractor.
+func (e Extractor) Requirements() *plugin.Capabilities { return &plugin.Capabilities{} }
+
+// FileRequired returns true if the file extension is used by ML model files.
+func (e Extractor) FileRequired(api filesystem.FileAPI) bool {
+	p := api.Path()
+	if !isModelFile(p) {
+		return false
+	}
+
+	fileinfo, err := api.Stat()
+	if err != nil {
+		return false
+	}
+
+	if e.maxFileSizeBytes > 0 && fileinfo.Size() > e.maxFileSizeBytes {
+		e.reportFileRequired(p, fileinfo.Size(), stats.FileRequiredResultSizeLimitExceeded)
+		return false
+	}
+
+	e.reportFileRequired(p, fileinfo.Size(), stats.FileRequiredResultOK)
+	return true
+}
+
+func isModelFile(p string) bool {
+	base := strings.ToLower(filepath.Base(p))
+	if _, ok := modelExtensions[filepath.Ext(base)]; ok {
+		return true
+	}
+	// Hugging Face PyTorch checkpoints, e.g. "pytorch_model-00001-of-00002.bin".
+	return strings.HasPrefix(base, "pytorch_model") && strings.HasSuffix(base, ".bin")
+}
+
+func (e Extractor) reportFileRequired(path string, fileSizeBytes int64, result stats.FileRequiredResult) {
+	if e.stats == nil {
+		return
+	}
+	e.stats.AfterFileRequired(e.Name(), &stats.FileRequiredStats{
+		Path:          path,
+		Result:        result,
+		FileSizeBytes: fileSizeBytes,
+	})
+}
+
+// Extract parses the model file and returns it as a package.
+func (e Extractor) Extract(ctx context.Context, input *filesystem.ScanInput) (inventory.Inventory, error) {
+	pkg, err := e.extractFromInput(ctx, input)
+	if e.stats != nil {
+		var fileSizeBytes int64
+		if input.Info != nil {
+			fileSizeBytes = input.Info.Size()
+		}
+		e.stats.AfterFileExtracted(e.Name(), &stats.FileExtractedStats{
+			Path:          input.Path,
+			Result:        filesystem.ExtractorErrorToFileExtractedResult(err),
+			FileSizeBytes: fileSizeBytes,
+		})
+	}
+	if err != nil {
+		return inventory.Inventory{}, fmt.Errorf("%s halted at %q: %w", e.Name(), input.Path, err)
+	}
+	if pkg == nil {
+		return inventory.Inventory{}, nil
+	}
+	return inventory.Inventory{Packages: []*extractor.Package{pkg}}, nil
+}
+
+func (e Extractor) extractFromInput(ctx context.Context, input *filesystem.ScanInput) (*extractor.Package, error) {
+	br := bufio.NewReader(input.Reader)
+	head, _ := br.Peek(len(zipMagic))
+
+	var m *Metadata
+	version := ""
+	var err error
+	switch {
+	case bytes.Equal(head, zipMagic):
+		m, err = parsePyTorchZip(ctx, input, br)
+	case modelExtensions[strings.ToLower(filepath.Ext(input.Path))] == FormatSafetensors:
+		m, err = parseSafetensors(br)
+	case modelExtensions[strings.ToLower(filepath.Ext(input.Path))] == FormatONNX:
+		var model *onnxModel
+		model, err = parseONNX(br)
+		if model != nil {
+			m, version = model.metadata, model.version()
+		}
+	default:
+		m, err = parsePickleFile(br)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if m == nil {
+		return nil, nil
+	}
+
+	return &extractor.Package{
+		Name:      path.Base(filepath.ToSlash(input.Path)),
+		Version:   version,
+		PURLType:  purl.TypeGeneric,
+		Metadata:  m,
+		Locations: []string{input.Path},
+	}, nil
+}
+
+func parsePickleFile(r io.Reader) (*Metadata, error) {
+	info, err := scanPickles(r, maxPickles)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse pickle: %w", err)
+	}
+	return pickleMetadata(FormatPickle, info), nil
+}
+
+// parsePyTorchZip parses the pickles in a zip-based PyTorch file, as written by
+// torch.save() since PyTorch 1.6. The model structure is stored in
+// "<archive>/data.pkl". Returns nil if the zip archive contains no pickles.
+func parsePyTorchZip(ctx context.Context, input *filesystem.ScanInput, br *bufio.Reader) (*Metadata, error) {
+	if input.Info == nil {
+		return nil, fmt.Errorf("file info for %s is missing", input.Path)
+	}
+	ra, ok := input.Reader.(io.ReaderAt)
+	if !ok {
+		var err error
+		if ra, err = scalibrfs.NewReaderAt(br); err != nil {
+			return nil, fmt.Errorf("NewReaderAt(%s): %w", input.Path, err)
+		}
+	}
+	zr, err := zip.NewReader(ra, input.Info.Size())
+	if err != nil {
+		return nil, fmt.Errorf("zip.NewReader: %w", err)
+	}
+
+	info := &pickleInfo{}
+	found := false
+	for _, f := range zr.File {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if !strings.HasSuffix(f.Name, ".pkl") {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return nil, fmt.Errorf("failed to open %s: %w", f.Name, err)
+		}
+		fileInfo, err := scanPickles(rc, 1)
+		rc.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", f.Name, err)
+		}
+		found = true
+		for _, imp := range fileInfo.imports {
+			info.addImport(imp)
+		}
+	}
+	if !found {
+		return nil, nil
+	}
+	return pickleMetadata(FormatPyTorchZip, info), nil
+}
+
+func pickleMetadata(format string, info *pickleInfo) *Metadata {
+	m := &Metadata{
+		Format:  format,
+		Imports: info.imports,
+	}
+	for _, fm := range frameworkModules {
+		if referencesModule(info.imports, fm.module) {
+			m.Framework = fm.framework
+			break
+		}
+	}
+	if m.Framework == "scikit-learn" {
+		m.FrameworkVersion = info.sklearnVersion
+	}
+	return m
+}
+
+func referencesModule(imports []string, module string) bool {
+	for _, imp := range imports {
+		if imp == module || strings.HasPrefix(imp, module+".") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,261 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mlmodel_test
+
+import (
+	"context"
+	"io/fs"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/extractor/filesystem/internal/units"
+	"github.com/google/osv-scalibr/extractor/filesystem/misc/mlmodel"
+	"github.com/google/osv-scalibr/extractor/filesystem/simplefileapi"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scalibr/stats"
+	"github.com/google/osv-scalibr/testing/extracttest"
+	"github.com/google/osv-scalibr/testing/fakefs"
+	"github.com/google/osv-scalibr/testing/testcollector"
+)
+
+func TestFileRequired(t *testing.T) {
+	tests := []struct {
+		name             string
+		path             string
+		fileSizeBytes    int64
+		maxFileSizeBytes int64
+		wantRequired     bool
+		wantResultMetric stats.FileRequiredResult
+	}{
+		{
+			name:             "pickle",
+			path:             "models/classifier.pkl",
+			wantRequired:     true,
+			wantResultMetric: stats.FileRequiredResultOK,
+		},
+		{
+			name:             "pytorch",
+			path:             "checkpoints/model.PTH",
+			wantRequired:     true,
+			wantResultMetric: stats.FileRequiredResultOK,
+		},
+		{
+			name:             "hugging_face_pytorch_checkpoint",
+			path:             "hub/models--gpt2/pytorch_model-00001-of-00002.bin",
+			wantRequired:     true,
+			wantResultMetric: stats.FileRequiredResultOK,
+		},
+		{
+			name:             "safetensors",
+			path:             "hub/models--gpt2/model.safetensors",
+			wantRequired:     true,
+			wantResultMetric: stats.FileRequiredResultOK,
+		},
+		{
+			name:             "onnx",
+			path:             "model.onnx",
+			wantRequired:     true,
+			wantResultMetric: stats.FileRequiredResultOK,
+		},
+		{
+			name:         "other_bin_file",
+			path:         "usr/share/firmware/blob.bin",
+			wantRequired: false,
+		},
+		{
+			name:         "python_source",
+			path:         "models/classifier.py",
+			wantRequired: false,
+		},
+		{
+			name:             "file_size_greater_than_max_size",
+			path:             "model.onnx",
+			fileSizeBytes:    1000 * units.KiB,
+			maxFileSizeBytes: 100 * units.KiB,
+			wantRequired:     false,
+			wantResultMetric: stats.FileRequiredResultSizeLimitExceeded,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			collector := testcollector.New()
+			var e filesystem.Extractor = mlmodel.New(mlmodel.Config{
+				Stats:            collector,
+				MaxFileSizeBytes: tt.maxFileSizeBytes,
+			})
+
+			fileSizeBytes := tt.fileSizeBytes
+			if fileSizeBytes == 0 {
+				fileSizeBytes = 1000
+			}
+
+			isRequired := e.FileRequired(simplefileapi.New(tt.path, fakefs.FakeFileInfo{
+				FileName: filepath.Base(tt.path),
+				FileMode: fs.ModePerm,
+				FileSize: fileSizeBytes,
+			}))
+			if isRequired != tt.wantRequired {
+				t.Fatalf("FileRequired(%s): got %v, want %v", tt.path, isRequired, tt.wantRequired)
+			}
+
+			gotResultMetric := collector.FileRequiredResult(tt.path)
+			if tt.wantResultMetric != "" && gotResultMetric != tt.wantResultMetric {
+				t.Errorf("FileRequired(%s) recorded result metric %v, want result metric %v", tt.path, gotResultMetric, tt.wantResultMetric)
+			}
+		})
+	}
+}
+
+func TestExtract(t *testing.T) {
+	tests := []extracttest.TestTableEntry{
+		{
+			Name: "scikit-learn_pickle",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/sklearn_model.pkl",
+			},
+			WantPackages: []*extractor.Package{{
+				Name:     "sklearn_model.pkl",
+				PURLType: purl.TypeGeneric,
+				Metadata: &mlmodel.Metadata{
+					Format:           mlmodel.FormatPickle,
+					Framework:        "scikit-learn",
+					FrameworkVersion: "1.3.0",
+					Imports:          []string{"sklearn.linear_model._base.LinearRegression"},
+				},
+				Locations: []string{"testdata/sklearn_model.pkl"},
+			}},
+		},
+		{
+			Name: "malicious_pickle_protocol_2",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/malicious_proto2.pkl",
+			},
+			WantPackages: []*extractor.Package{{
+				Name:     "malicious_proto2.pkl",
+				PURLType: purl.TypeGeneric,
+				Metadata: &mlmodel.Metadata{
+					Format:  mlmodel.FormatPickle,
+					Imports: []string{"posix.system"},
+				},
+				Locations: []string{"testdata/malicious_proto2.pkl"},
+			}},
+		},
+		{
+			Name: "malicious_pickle_protocol_4",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/malicious_proto4.pickle",
+			},
+			WantPackages: []*extractor.Package{{
+				Name:     "malicious_proto4.pickle",
+				PURLType: purl.TypeGeneric,
+				Metadata: &mlmodel.Metadata{
+					Format:  mlmodel.FormatPickle,
+					Imports: []string{"posix.system"},
+				},
+				Locations: []string{"testdata/malicious_proto4.pickle"},
+			}},
+		},
+		{
+			Name: "pytorch_zip",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/model.pt",
+			},
+			WantPackages: []*extractor.Package{{
+				Name:     "model.pt",
+				PURLType: purl.TypeGeneric,
+				Metadata: &mlmodel.Metadata{
+					Format:    mlmodel.FormatPyTorchZip,
+					Framework: "pytorch",
+					Imports:   []string{"collections.OrderedDict", "torch._utils._rebuild_tensor_v2"},
+				},
+				Locations: []string{"testdata/model.pt"},
+			}},
+		},
+		{
+			Name: "zip_without_pickles",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/no_pickle.pt",
+			},
+		},
+		{
+			Name: "safetensors",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/model.safetensors",
+			},
+			WantPackages: []*extractor.Package{{
+				Name:     "model.safetensors",
+				PURLType: purl.TypeGeneric,
+				Metadata: &mlmodel.Metadata{
+					Format:     mlmodel.FormatSafetensors,
+					Framework:  "pytorch",
+					Properties: map[string]string{"format": "pt", "source": "unit-test"},
+				},
+				Locations: []string{"testdata/model.safetensors"},
+			}},
+		},
+		{
+			Name: "onnx",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/model.onnx",
+			},
+			WantPackages: []*extractor.Package{{
+				Name:     "model.onnx",
+				Version:  "3",
+				PURLType: purl.TypeGeneric,
+				Metadata: &mlmodel.Metadata{
+					Format:           mlmodel.FormatONNX,
+					Framework:        "pytorch",
+					FrameworkVersion: "2.1.0",
+					Properties:       map[string]string{"author": "acme"},
+				},
+				Locations: []string{"testdata/model.onnx"},
+			}},
+		},
+		{
+			Name: "invalid_pickle",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/not_a_pickle.pkl",
+			},
+			WantErr: cmpopts.AnyError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			var e filesystem.Extractor = mlmodel.NewDefault()
+
+			scanInput := extracttest.GenerateScanInputMock(t, tt.InputConfig)
+			defer extracttest.CloseTestScanInput(t, scanInput)
+
+			got, err := e.Extract(context.Background(), &scanInput)
+
+			if diff := cmp.Diff(tt.WantErr, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("%s.Extract(%q) error diff (-want +got):\n%s", e.Name(), tt.InputConfig.Path, diff)
+				return
+			}
+
+			want := inventory.Inventory{Packages: tt.WantPackages}
+			if diff := cmp.Diff(want, got, cmpopts.SortSlices(extracttest.PackageCmpLess)); diff != "" {
+				t.Errorf("%s.Extract(%q) diff (-want +got):\n%s", e.Name(), tt.InputConfig.Path, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,178 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mlmodel
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// Field numbers of the ONNX ModelProto, see
+// https://github.com/onnx/onnx/blob/main/onnx/onnx.proto
+const (
+	onnxIRVersion       = 1
+	onnxProducerName    = 2
+	onnxProducerVersion = 3
+	onnxModelVersion    = 5
+	onnxMetadataProps   = 14
+)
+
+const (
+	// maxONNXFieldBytes is the maximum size of a ModelProto field that is read
+	// into memory. The graph and other big fields are skipped.
+	maxONNXFieldBytes = 1 << 20
+	// maxONNXSkipBytes is the maximum size of a field that is skipped. Anything
+	// bigger is treated as a corrupt file.
+	maxONNXSkipBytes = 1 << 40
+)
+
+// onnxModel holds the top-level fields of an ONNX ModelProto.
+type onnxModel struct {
+	irVersion    uint64
+	modelVersion uint64
+	metadata     *Metadata
+}
+
+// parseONNX reads the top-level fields of an ONNX model without loading the
+// graph into memory.
+func parseONNX(r io.Reader) (*onnxModel, error) {
+	br := bufio.NewReader(r)
+	model := &onnxModel{metadata: &Metadata{Format: FormatONNX}}
+	for {
+		tag, err := binary.ReadUvarint(br)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		num, typ := protowire.DecodeTag(tag)
+		switch typ {
+		case protowire.VarintType:
+			v, err := binary.ReadUvarint(br)
+			if err != nil {
+				return nil, err
+			}
+			switch num {
+			case onnxIRVersion:
+				model.irVersion = v
+			case onnxModelVersion:
+				model.modelVersion = v
+			}
+		case protowire.Fixed32Type, protowire.Fixed64Type:
+			n := 4
+			if typ == protowire.Fixed64Type {
+				n = 8
+			}
+			if _, err := br.Discard(n); err != nil {
+				return nil, io.ErrUnexpectedEOF
+			}
+		case protowire.BytesType:
+			size, err := binary.ReadUvarint(br)
+			if err != nil {
+				return nil, err
+			}
+			if size > maxONNXSkipBytes {
+				return nil, fmt.Errorf("invalid protobuf field size %d", size)
+			}
+			if !isONNXStringField(num) || size > maxONNXFieldBytes {
+				if _, err := io.CopyN(io.Discard, br, int64(size)); err != nil {
+					return nil, io.ErrUnexpectedEOF
+				}
+				continue
+			}
+			b := make([]byte, size)
+			if _, err := io.ReadFull(br, b); err != nil {
+				return nil, io.ErrUnexpectedEOF
+			}
+			if err := model.setField(num, b); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, fmt.Errorf("unexpected protobuf wire type %d, not an ONNX model", typ)
+		}
+	}
+	if model.irVersion == 0 {
+		return nil, errors.New("no ir_version set, not an ONNX model")
+	}
+	return model, nil
+}
+
+func isONNXStringField(num protowire.Number) bool {
+	return num == onnxProducerName || num == onnxProducerVersion || num == onnxMetadataProps
+}
+
+func (m *onnxModel) setField(num protowire.Number, b []byte) error {
+	switch num {
+	case onnxProducerName:
+		m.metadata.Framework = string(b)
+	case onnxProducerVersion:
+		m.metadata.FrameworkVersion = string(b)
+	case onnxMetadataProps:
+		key, value, err := parseStringStringEntry(b)
+		if err != nil {
+			return err
+		}
+		if m.metadata.Properties == nil {
+			m.metadata.Properties = map[string]string{}
+		}
+		m.metadata.Properties[key] = value
+	}
+	return nil
+}
+
+// parseStringStringEntry parses an ONNX StringStringEntryProto.
+func parseStringStringEntry(b []byte) (key, value string, err error) {
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return "", "", protowire.ParseError(n)
+		}
+		b = b[n:]
+		if typ != protowire.BytesType {
+			n = protowire.ConsumeFieldValue(num, typ, b)
+			if n < 0 {
+				return "", "", protowire.ParseError(n)
+			}
+			b = b[n:]
+			continue
+		}
+		v, n := protowire.ConsumeBytes(b)
+		if n < 0 {
+			return "", "", protowire.ParseError(n)
+		}
+		b = b[n:]
+		switch num {
+		case 1:
+			key = string(v)
+		case 2:
+			value = string(v)
+		}
+	}
+	return key, value, nil
+}
+
+func (m *onnxModel) version() string {
+	if m.modelVersion == 0 {
+		return ""
+	}
+	return strconv.FormatUint(m.modelVersion, 10)
+}
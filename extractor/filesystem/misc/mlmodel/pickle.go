@@ -0,0 +1,306 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mlmodel
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+)
+
+// Pickle opcodes, see https://github.com/python/cpython/blob/main/Lib/pickletools.py
+const (
+	opGlobal          = 'c'
+	opInst            = 'i'
+	opStackGlobal     = 0x93
+	opProto           = 0x80
+	opStop            = '.'
+	opMemoize         = 0x94
+	opBinPut          = 'q'
+	opLongBinPut      = 'r'
+	opBinGet          = 'h'
+	opLongBinGet      = 'j'
+	opPut             = 'p'
+	opGet             = 'g'
+	opString          = 'S'
+	opUnicode         = 'V'
+	opShortBinString  = 'U'
+	opBinString       = 'T'
+	opShortBinUnicode = 0x8c
+	opBinUnicode      = 'X'
+	opBinUnicode8     = 0x8d
+)
+
+// Opcodes that don't push strings or reference imports, by their argument type.
+var (
+	// Opcodes without arguments.
+	noArgOps = []byte("(012NRabd}el]sutoQ)\x85\x86\x87\x88\x89\x81\x92\x8f\x90\x91\x97\x98")
+	// Opcodes with a newline-terminated argument.
+	lineArgOps = []byte("IFLP")
+	// Opcodes with a fixed size argument.
+	fixedArgOps = map[byte]int{
+		'K': 1, 0x82: 1,
+		'M': 2, 0x83: 2,
+		'J': 4, 0x84: 4,
+		'G': 8, 0x95: 8,
+	}
+	// Opcodes with a length prefix of the given size followed by the data.
+	lengthArgOps = map[byte]int{
+		'C': 1, 0x8a: 1,
+		'B': 4, 0x8b: 4,
+		0x8e: 8, 0x96: 8,
+	}
+)
+
+const (
+	// maxPickleArgBytes is the maximum size of a single opcode argument that is
+	// read into memory. Bigger arguments (e.g. raw tensor data) are skipped.
+	maxPickleArgBytes = 1 << 20
+	// maxPickleSkipBytes is the maximum size of an argument that is skipped.
+	// Anything bigger is treated as a corrupt file.
+	maxPickleSkipBytes = 1 << 40
+)
+
+// pickleInfo holds what was found in a pickle stream without unpickling it.
+type pickleInfo struct {
+	// The imported callables as "module.name".
+	imports []string
+	// The value of the "_sklearn_version" attribute of scikit-learn estimators.
+	sklearnVersion string
+}
+
+func (p *pickleInfo) addImport(imp string) {
+	if !slices.Contains(p.imports, imp) {
+		p.imports = append(p.imports, imp)
+	}
+}
+
+// isPickle returns true if the data starts like a binary pickle (protocol 2+).
+func isPickle(header []byte) bool {
+	return len(header) >= 2 && header[0] == opProto && header[1] >= 2 && header[1] <= 5
+}
+
+// scanPickles walks through the opcodes of up to maxPickles consecutive pickles
+// in r, e.g. the pickles of legacy PyTorch files, and collects the imports. The
+// opcodes are never executed.
+func scanPickles(r io.Reader, maxPickles int) (*pickleInfo, error) {
+	br := bufio.NewReader(r)
+	info := &pickleInfo{}
+	for i := range maxPickles {
+		if i > 0 {
+			// Only continue if another pickle follows.
+			b, err := br.Peek(2)
+			if err != nil || !isPickle(b) {
+				break
+			}
+		}
+		if err := scanPickle(br, info); err != nil {
+			return nil, err
+		}
+	}
+	return info, nil
+}
+
+// scanPickle walks through the opcodes of a single pickle until its STOP opcode.
+func scanPickle(r *bufio.Reader, info *pickleInfo) error {
+	// The values of string opcodes on the stack, needed for STACK_GLOBAL. Other
+	// values are tracked as empty strings.
+	var last, prev string
+	push := func(s string) {
+		if last == "_sklearn_version" && info.sklearnVersion == "" {
+			info.sklearnVersion = s
+		}
+		prev, last = last, s
+	}
+	memo := map[uint64]string{}
+
+	for {
+		op, err := r.ReadByte()
+		if err != nil {
+			return unexpectedEOF(err)
+		}
+
+		switch {
+		case op == opStop:
+			return nil
+		case op == opGlobal || op == opInst:
+			module, err := readLine(r)
+			if err != nil {
+				return err
+			}
+			name, err := readLine(r)
+			if err != nil {
+				return err
+			}
+			info.addImport(module + "." + name)
+			push("")
+		case op == opStackGlobal:
+			if prev == "" || last == "" {
+				// The module and name were computed in a way we don't track,
+				// which is a common way to hide malicious imports.
+				info.addImport(UnknownImport)
+			} else {
+				info.addImport(prev + "." + last)
+			}
+			push("")
+		case op == opProto:
+			if _, err := r.ReadByte(); err != nil {
+				return unexpectedEOF(err)
+			}
+		case op == opString || op == opUnicode:
+			s, err := readLine(r)
+			if err != nil {
+				return err
+			}
+			push(strings.Trim(s, `'"`))
+		case op == opShortBinString || op == opShortBinUnicode:
+			s, err := readLengthPrefixed(r, 1)
+			if err != nil {
+				return err
+			}
+			push(s)
+		case op == opBinString || op == opBinUnicode:
+			s, err := readLengthPrefixed(r, 4)
+			if err != nil {
+				return err
+			}
+			push(s)
+		case op == opBinUnicode8:
+			s, err := readLengthPrefixed(r, 8)
+			if err != nil {
+				return err
+			}
+			push(s)
+		case op == opMemoize:
+			memo[uint64(len(memo))] = last
+		case op == opBinPut || op == opLongBinPut || op == opPut:
+			idx, err := readIndex(r, op, opBinPut, opLongBinPut)
+			if err != nil {
+				return err
+			}
+			memo[idx] = last
+		case op == opBinGet || op == opLongBinGet || op == opGet:
+			idx, err := readIndex(r, op, opBinGet, opLongBinGet)
+			if err != nil {
+				return err
+			}
+			push(memo[idx])
+		case slices.Contains(noArgOps, op):
+			push("")
+		case slices.Contains(lineArgOps, op):
+			if _, err := readLine(r); err != nil {
+				return err
+			}
+			push("")
+		case fixedArgOps[op] > 0:
+			if _, err := r.Discard(fixedArgOps[op]); err != nil {
+				return unexpectedEOF(err)
+			}
+			push("")
+		case lengthArgOps[op] > 0:
+			n, err := readLength(r, lengthArgOps[op])
+			if err != nil {
+				return err
+			}
+			if _, err := io.CopyN(io.Discard, r, n); err != nil {
+				return unexpectedEOF(err)
+			}
+			push("")
+		default:
+			return fmt.Errorf("unknown pickle opcode 0x%02x", op)
+		}
+	}
+}
+
+func unexpectedEOF(err error) error {
+	if errors.Is(err, io.EOF) {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
+
+func readLine(r *bufio.Reader) (string, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return "", unexpectedEOF(err)
+	}
+	if len(line) > maxPickleArgBytes {
+		return "", errors.New("pickle argument too long")
+	}
+	return strings.TrimSuffix(strings.TrimSuffix(line, "\n"), "\r"), nil
+}
+
+func readUint(r io.Reader, size int) (uint64, error) {
+	buf := make([]byte, 8)
+	if _, err := io.ReadFull(r, buf[:size]); err != nil {
+		return 0, unexpectedEOF(err)
+	}
+	return binary.LittleEndian.Uint64(buf), nil
+}
+
+func readLength(r io.Reader, size int) (int64, error) {
+	n, err := readUint(r, size)
+	if err != nil {
+		return 0, err
+	}
+	if n > maxPickleSkipBytes {
+		return 0, fmt.Errorf("invalid pickle argument length %d", n)
+	}
+	return int64(n), nil
+}
+
+func readLengthPrefixed(r *bufio.Reader, lenSize int) (string, error) {
+	n, err := readLength(r, lenSize)
+	if err != nil {
+		return "", err
+	}
+	if n > maxPickleArgBytes {
+		// Too big to be a module or attribute name, skip it.
+		if _, err := io.CopyN(io.Discard, r, n); err != nil {
+			return "", unexpectedEOF(err)
+		}
+		return "", nil
+	}
+	buf := make([]byte, n)
+	if _, err := io.ReadFull(r, buf); err != nil {
+		return "", unexpectedEOF(err)
+	}
+	return string(buf), nil
+}
+
+// readIndex reads the memo index of the PUT/GET opcodes, which is a 1 byte,
+// 4 byte or newline-terminated decimal argument depending on the opcode.
+func readIndex(r *bufio.Reader, op, shortOp, longOp byte) (uint64, error) {
+	switch op {
+	case shortOp:
+		return readUint(r, 1)
+	case longOp:
+		return readUint(r, 4)
+	default:
+		line, err := readLine(r)
+		if err != nil {
+			return 0, err
+		}
+		var idx uint64
+		if _, err := fmt.Sscan(line, &idx); err != nil {
+			return 0, fmt.Errorf("invalid memo index %q", line)
+		}
+		return idx, nil
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mlmodel
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// maxSafetensorsHeaderBytes is the maximum size of the JSON header of a safetensors file.
+// The reference implementation uses the same limit.
+const maxSafetensorsHeaderBytes = 100 << 20
+
+// safetensorsFrameworks maps the "format" metadata written by the
+// safetensors library to the framework names used in the Metadata.
+var safetensorsFrameworks = map[string]string{
+	"pt":     "pytorch",
+	"tf":     "tensorflow",
+	"flax":   "flax",
+	"mlx":    "mlx",
+	"np":     "numpy",
+	"paddle": "paddlepaddle",
+}
+
+// parseSafetensors reads the header of a safetensors file, see
+// https://huggingface.co/docs/safetensors/index#format
+// The file starts with the little-endian uint64 size of a JSON header that
+// maps tensor names to their layout and holds free-form string metadata under
+// the "__metadata__" key.
+func parseSafetensors(r io.Reader) (*Metadata, error) {
+	var size uint64
+	if err := binary.Read(r, binary.LittleEndian, &size); err != nil {
+		return nil, fmt.Errorf("failed to read safetensors header size: %w", err)
+	}
+	if size > maxSafetensorsHeaderBytes {
+		return nil, fmt.Errorf("safetensors header too big: %d bytes", size)
+	}
+	header := map[string]json.RawMessage{}
+	if err := json.NewDecoder(io.LimitReader(r, int64(size))).Decode(&header); err != nil {
+		return nil, fmt.Errorf("failed to parse safetensors header: %w", err)
+	}
+
+	m := &Metadata{Format: FormatSafetensors}
+	if raw, ok := header["__metadata__"]; ok {
+		if err := json.Unmarshal(raw, &m.Properties); err != nil {
+			return nil, fmt.Errorf("failed to parse safetensors metadata: %w", err)
+		}
+		m.Framework = safetensorsFrameworks[m.Properties["format"]]
+	}
+	return m, nil
+}
@@ -0,0 +1,69 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runtimeversion
+
+import (
+	pb "github.com/google/osv-scalibr/binary/proto/scan_result_go_proto"
+)
+
+// Metadata holds the details of an installed language runtime.
+type Metadata struct {
+	// Runtime is the name of the runtime, e.g. RuntimePython.
+	Runtime string `json:"runtime"`
+	// Version is the version of the runtime, e.g. "3.11.4".
+	Version string `json:"version"`
+	// InterpreterPath is the path of the runtime's interpreter, e.g.
+	// "usr/local/bin/python3.11".
+	InterpreterPath string `json:"interpreterPath"`
+	// Implementor is the vendor of Java runtimes, e.g. "Eclipse Adoptium".
+	Implementor string `json:"implementor,omitempty"`
+	// CPEs are the CPE 2.3 names of the runtime.
+	CPEs []string `json:"cpes,omitempty"`
+}
+
+// SetProto sets the RuntimeVersionMetadata field in the Package proto.
+func (m *Metadata) SetProto(p *pb.Package) {
+	if m == nil {
+		return
+	}
+	if p == nil {
+		return
+	}
+
+	p.Metadata = &pb.Package_RuntimeVersionMetadata{
+		RuntimeVersionMetadata: &pb.RuntimeVersionMetadata{
+			Runtime:         m.Runtime,
+			Version:         m.Version,
+			InterpreterPath: m.InterpreterPath,
+			Implementor:     m.Implementor,
+			Cpes:            m.CPEs,
+		},
+	}
+}
+
+// ToStruct converts the RuntimeVersionMetadata proto to a Metadata struct.
+func ToStruct(m *pb.RuntimeVersionMetadata) *Metadata {
+	if m == nil {
+		return nil
+	}
+
+	return &Metadata{
+		Runtime:         m.GetRuntime(),
+		Version:         m.GetVersion(),
+		InterpreterPath: m.GetInterpreterPath(),
+		Implementor:     m.GetImplementor(),
+		CPEs:            m.GetCpes(),
+	}
+}
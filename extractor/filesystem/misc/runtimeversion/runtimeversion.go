@@ -0,0 +1,294 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package runtimeversion extracts the versions of language runtimes such as
+// Python, Node.js, Java and Ruby that are installed outside of package
+// managers, e.g. from release tarballs, version managers like pyenv and nvm or
+// in the /usr/local prefix of container images. Advisories about end-of-life
+// runtimes can then be matched against them.
+//
+// The version is read from a file that the runtime installs next to its
+// interpreter. A runtime is only reported if its interpreter exists.
+package runtimeversion
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	scalibrfs "github.com/google/osv-scalibr/fs"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/plugin"
+	"github.com/google/osv-scalibr/purl"
+)
+
+const (
+	// Name is the unique name of this extractor.
+	Name = "runtime/version"
+
+	// RuntimePython is the CPython interpreter.
+	RuntimePython = "python"
+	// RuntimeNode is the Node.js runtime.
+	RuntimeNode = "node"
+	// RuntimeJava is a Java runtime or development kit.
+	RuntimeJava = "java"
+	// RuntimeRuby is the Ruby interpreter.
+	RuntimeRuby = "ruby"
+)
+
+var (
+	// #define PY_VERSION "3.11.4"
+	pyVersionRe = regexp.MustCompile(`^#define\s+PY_VERSION\s+"([^"]+)"`)
+	// #define NODE_MAJOR_VERSION 18
+	nodeVersionRe = regexp.MustCompile(`^#define\s+NODE_(MAJOR|MINOR|PATCH)_VERSION\s+(\d+)`)
+	// CONFIG["RUBY_PROGRAM_VERSION"] = "3.2.2"
+	rubyVersionRe = regexp.MustCompile(`CONFIG\["RUBY_PROGRAM_VERSION"\]\s*=\s*"([^"]+)"`)
+	// The include directory of a Python version, e.g. "python3.11" or "python3.13t".
+	pythonIncludeDirRe = regexp.MustCompile(`^python(\d+\.\d+)[a-z]*$`)
+)
+
+// Extractor extracts language runtime versions from the version files
+// installed next to their interpreters.
+type Extractor struct{}
+
+// New returns a new instance of the extractor.
+func New() filesystem.Extractor { return &Extractor{} }
+
+// Name of the extractor.
+func (e Extractor) Name() string { return Name }
+
+// Version of the extractor.
+func (e Extractor) Version() int { return 0 }
+
+// Requirements of the extractor.
+func (e Extractor) Requirements() *plugin.Capabilities {
+	return &plugin.Capabilities{}
+}
+
+// FileRequired returns true if the file is the version file of a runtime:
+//
+//   - <prefix>/include/python3.X/patchlevel.h, or <prefix>/include/patchlevel.h on Windows
+//   - <prefix>/include/node/node_version.h
+//   - <java home>/release
+//   - <prefix>/lib/ruby/3.X.0/<arch>/rbconfig.rb
+func (e Extractor) FileRequired(api filesystem.FileAPI) bool {
+	runtime, _ := versionFile(filepath.ToSlash(api.Path()))
+	return runtime != ""
+}
+
+// versionFile returns the runtime that the version file at the given path
+// belongs to and the directory the runtime is installed in. The runtime is
+// empty if the path isn't a version file.
+func versionFile(p string) (runtime, installDir string) {
+	dir, base := path.Dir(p), path.Base(p)
+	parent := path.Base(dir)
+	switch base {
+	case "patchlevel.h":
+		if pythonIncludeDirRe.MatchString(parent) && path.Base(path.Dir(dir)) == "include" {
+			return RuntimePython, path.Dir(path.Dir(dir))
+		}
+		if parent == "include" {
+			return RuntimePython, path.Dir(dir)
+		}
+	case "node_version.h":
+		if parent == "node" && path.Base(path.Dir(dir)) == "include" {
+			return RuntimeNode, path.Dir(path.Dir(dir))
+		}
+	case "release":
+		return RuntimeJava, dir
+	case "rbconfig.rb":
+		// lib/ruby/3.2.0/x86_64-linux/rbconfig.rb for installs from source and
+		// lib/x86_64-linux-gnu/ruby/3.1.0/rbconfig.rb in Debian.
+		parts := strings.Split(dir, "/")
+		for i := len(parts) - 1; i > 0; i-- {
+			if parts[i] != "ruby" {
+				continue
+			}
+			for j := i - 1; j >= 0 && j >= i-2; j-- {
+				if parts[j] == "lib" {
+					prefix := path.Join(parts[:j]...)
+					if prefix == "" {
+						prefix = "."
+					}
+					return RuntimeRuby, prefix
+				}
+			}
+		}
+	}
+	return "", ""
+}
+
+// interpreters returns the paths of the interpreter of the runtime relative to
+// its install directory, in order of preference.
+func interpreters(runtime, versionFilePath string) []string {
+	switch runtime {
+	case RuntimePython:
+		var paths []string
+		if m := pythonIncludeDirRe.FindStringSubmatch(path.Base(path.Dir(versionFilePath))); m != nil {
+			paths = append(paths, "bin/python"+m[1])
+		}
+		return append(paths, "bin/python3", "bin/python", "python.exe")
+	case RuntimeNode:
+		return []string{"bin/node"}
+	case RuntimeJava:
+		return []string{"bin/java", "bin/java.exe"}
+	case RuntimeRuby:
+		return []string{"bin/ruby", "bin/ruby.exe"}
+	}
+	return nil
+}
+
+// Extract returns the runtime whose version file is passed through the scan
+// input, or nothing if the file isn't a version file or the runtime's
+// interpreter doesn't exist.
+func (e Extractor) Extract(ctx context.Context, input *filesystem.ScanInput) (inventory.Inventory, error) {
+	p := filepath.ToSlash(input.Path)
+	runtime, installDir := versionFile(p)
+	if runtime == "" {
+		return inventory.Inventory{}, nil
+	}
+
+	interpreter := findInterpreter(input.FS, installDir, interpreters(runtime, p))
+	if interpreter == "" {
+		// E.g. headers downloaded by node-gyp or copied into a source tree.
+		return inventory.Inventory{}, nil
+	}
+
+	m := &Metadata{Runtime: runtime, InterpreterPath: interpreter}
+	var err error
+	switch runtime {
+	case RuntimePython:
+		m.Version, err = findVersion(input.Reader, pyVersionRe)
+	case RuntimeNode:
+		m.Version, err = parseNodeVersion(input.Reader)
+	case RuntimeJava:
+		err = parseJavaRelease(input.Reader, m)
+	case RuntimeRuby:
+		m.Version, err = findVersion(input.Reader, rubyVersionRe)
+	}
+	if err != nil {
+		return inventory.Inventory{}, fmt.Errorf("%s halted at %q: %w", e.Name(), input.Path, err)
+	}
+	if m.Version == "" {
+		return inventory.Inventory{}, nil
+	}
+	m.CPEs = cpes(m)
+
+	return inventory.Inventory{Packages: []*extractor.Package{{
+		Name:      runtime,
+		Version:   m.Version,
+		PURLType:  purl.TypeGeneric,
+		Locations: []string{input.Path},
+		Metadata:  m,
+	}}}, nil
+}
+
+// findInterpreter returns the path of the first of the candidate interpreters
+// that exists in the install directory, or "" if none does.
+func findInterpreter(fsys scalibrfs.FS, installDir string, candidates []string) string {
+	if fsys == nil {
+		return ""
+	}
+	for _, c := range candidates {
+		p := path.Join(installDir, c)
+		if info, err := fsys.Stat(p); err == nil && !info.IsDir() {
+			return p
+		}
+	}
+	return ""
+}
+
+// findVersion returns the first group of the first line matching re.
+func findVersion(r io.Reader, re *regexp.Regexp) (string, error) {
+	s := bufio.NewScanner(r)
+	for s.Scan() {
+		if m := re.FindStringSubmatch(strings.TrimSpace(s.Text())); m != nil {
+			return m[1], nil
+		}
+	}
+	return "", s.Err()
+}
+
+// parseNodeVersion reads the version from the defines of node_version.h.
+func parseNodeVersion(r io.Reader) (string, error) {
+	parts := map[string]string{}
+	s := bufio.NewScanner(r)
+	for s.Scan() {
+		if m := nodeVersionRe.FindStringSubmatch(strings.TrimSpace(s.Text())); m != nil {
+			parts[m[1]] = m[2]
+		}
+	}
+	if err := s.Err(); err != nil {
+		return "", err
+	}
+	if parts["MAJOR"] == "" || parts["MINOR"] == "" || parts["PATCH"] == "" {
+		return "", nil
+	}
+	return parts["MAJOR"] + "." + parts["MINOR"] + "." + parts["PATCH"], nil
+}
+
+// parseJavaRelease reads the version and implementor from the release file of
+// a Java home, e.g.
+//
+//	IMPLEMENTOR="Eclipse Adoptium"
+//	JAVA_VERSION="17.0.8"
+func parseJavaRelease(r io.Reader, m *Metadata) error {
+	s := bufio.NewScanner(r)
+	for s.Scan() {
+		key, value, ok := strings.Cut(s.Text(), "=")
+		if !ok {
+			continue
+		}
+		value = strings.Trim(strings.TrimSpace(value), `"`)
+		switch strings.TrimSpace(key) {
+		case "JAVA_VERSION":
+			m.Version = value
+		case "IMPLEMENTOR":
+			m.Implementor = value
+		}
+	}
+	return s.Err()
+}
+
+// cpes returns the NVD CPE 2.3 names of the runtime.
+func cpes(m *Metadata) []string {
+	var vendor, product string
+	version, update := m.Version, "*"
+	switch m.Runtime {
+	case RuntimePython:
+		vendor, product = "python", "python"
+	case RuntimeNode:
+		vendor, product = "nodejs", "node.js"
+	case RuntimeRuby:
+		vendor, product = "ruby-lang", "ruby"
+	case RuntimeJava:
+		vendor, product = "oracle", "openjdk"
+		// NVD names Java 8 releases like "1.8.0_382" as version 1.8.0, update 382.
+		if v, u, ok := strings.Cut(version, "_"); ok {
+			version, update = v, "update"+u
+		}
+	default:
+		return nil
+	}
+	return []string{fmt.Sprintf("cpe:2.3:a:%s:%s:%s:%s:*:*:*:*:*:*", vendor, product, version, update)}
+}
+
+var _ filesystem.Extractor = Extractor{}
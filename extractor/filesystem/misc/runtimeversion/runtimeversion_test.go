@@ -0,0 +1,241 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runtimeversion_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/extractor/filesystem/misc/runtimeversion"
+	"github.com/google/osv-scalibr/extractor/filesystem/simplefileapi"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scalibr/testing/extracttest"
+	"github.com/google/osv-scalibr/testing/fakefs"
+)
+
+func TestFileRequired(t *testing.T) {
+	tests := []struct {
+		name         string
+		path         string
+		wantRequired bool
+	}{
+		{
+			name:         "python_patchlevel",
+			path:         "usr/local/include/python3.11/patchlevel.h",
+			wantRequired: true,
+		},
+		{
+			name:         "free_threaded_python_patchlevel",
+			path:         "root/.pyenv/versions/3.13.0t/include/python3.13t/patchlevel.h",
+			wantRequired: true,
+		},
+		{
+			name:         "windows_python_patchlevel",
+			path:         "Python311/include/patchlevel.h",
+			wantRequired: true,
+		},
+		{
+			name:         "patchlevel_of_other_project",
+			path:         "usr/include/tcl8.6/patchlevel.h",
+			wantRequired: false,
+		},
+		{
+			name:         "node_version_header",
+			path:         "root/.nvm/versions/node/v18.17.1/include/node/node_version.h",
+			wantRequired: true,
+		},
+		{
+			name:         "node_version_header_outside_include",
+			path:         "src/node/node_version.h",
+			wantRequired: false,
+		},
+		{
+			name:         "java_release",
+			path:         "opt/java/openjdk/release",
+			wantRequired: true,
+		},
+		{
+			name:         "ruby_rbconfig",
+			path:         "usr/local/lib/ruby/3.2.0/x86_64-linux/rbconfig.rb",
+			wantRequired: true,
+		},
+		{
+			name:         "debian_ruby_rbconfig",
+			path:         "usr/lib/x86_64-linux-gnu/ruby/3.1.0/rbconfig.rb",
+			wantRequired: true,
+		},
+		{
+			name:         "rbconfig_outside_lib",
+			path:         "app/vendor/rbconfig.rb",
+			wantRequired: false,
+		},
+		{
+			name:         "interpreter",
+			path:         "usr/local/bin/python3",
+			wantRequired: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var e filesystem.Extractor = runtimeversion.New()
+			isRequired := e.FileRequired(simplefileapi.New(tt.path, fakefs.FakeFileInfo{
+				FileName: filepath.Base(tt.path),
+				FileMode: 0644,
+				FileSize: 1000,
+			}))
+			if isRequired != tt.wantRequired {
+				t.Fatalf("FileRequired(%s): got %v, want %v", tt.path, isRequired, tt.wantRequired)
+			}
+		})
+	}
+}
+
+func TestExtract(t *testing.T) {
+	tests := []extracttest.TestTableEntry{
+		{
+			Name: "python",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/python/include/python3.11/patchlevel.h",
+			},
+			WantPackages: []*extractor.Package{{
+				Name:     "python",
+				Version:  "3.11.4",
+				PURLType: purl.TypeGeneric,
+				Metadata: &runtimeversion.Metadata{
+					Runtime:         runtimeversion.RuntimePython,
+					Version:         "3.11.4",
+					InterpreterPath: "testdata/python/bin/python3.11",
+					CPEs:            []string{"cpe:2.3:a:python:python:3.11.4:*:*:*:*:*:*:*"},
+				},
+				Locations: []string{"testdata/python/include/python3.11/patchlevel.h"},
+			}},
+		},
+		{
+			Name: "node",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/node/include/node/node_version.h",
+			},
+			WantPackages: []*extractor.Package{{
+				Name:     "node",
+				Version:  "18.17.1",
+				PURLType: purl.TypeGeneric,
+				Metadata: &runtimeversion.Metadata{
+					Runtime:         runtimeversion.RuntimeNode,
+					Version:         "18.17.1",
+					InterpreterPath: "testdata/node/bin/node",
+					CPEs:            []string{"cpe:2.3:a:nodejs:node.js:18.17.1:*:*:*:*:*:*:*"},
+				},
+				Locations: []string{"testdata/node/include/node/node_version.h"},
+			}},
+		},
+		{
+			Name: "node_headers_without_interpreter",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/node-gyp/include/node/node_version.h",
+			},
+			WantPackages: nil,
+		},
+		{
+			Name: "no_version_in_file",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/noversion/include/node/node_version.h",
+			},
+			WantPackages: nil,
+		},
+		{
+			Name: "java",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/jdk17/release",
+			},
+			WantPackages: []*extractor.Package{{
+				Name:     "java",
+				Version:  "17.0.8",
+				PURLType: purl.TypeGeneric,
+				Metadata: &runtimeversion.Metadata{
+					Runtime:         runtimeversion.RuntimeJava,
+					Version:         "17.0.8",
+					InterpreterPath: "testdata/jdk17/bin/java",
+					Implementor:     "Eclipse Adoptium",
+					CPEs:            []string{"cpe:2.3:a:oracle:openjdk:17.0.8:*:*:*:*:*:*:*"},
+				},
+				Locations: []string{"testdata/jdk17/release"},
+			}},
+		},
+		{
+			Name: "java_8",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/jdk8/release",
+			},
+			WantPackages: []*extractor.Package{{
+				Name:     "java",
+				Version:  "1.8.0_382",
+				PURLType: purl.TypeGeneric,
+				Metadata: &runtimeversion.Metadata{
+					Runtime:         runtimeversion.RuntimeJava,
+					Version:         "1.8.0_382",
+					InterpreterPath: "testdata/jdk8/bin/java",
+					CPEs:            []string{"cpe:2.3:a:oracle:openjdk:1.8.0:update382:*:*:*:*:*:*"},
+				},
+				Locations: []string{"testdata/jdk8/release"},
+			}},
+		},
+		{
+			Name: "ruby",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/ruby/lib/ruby/3.2.0/x86_64-linux/rbconfig.rb",
+			},
+			WantPackages: []*extractor.Package{{
+				Name:     "ruby",
+				Version:  "3.2.2",
+				PURLType: purl.TypeGeneric,
+				Metadata: &runtimeversion.Metadata{
+					Runtime:         runtimeversion.RuntimeRuby,
+					Version:         "3.2.2",
+					InterpreterPath: "testdata/ruby/bin/ruby",
+					CPEs:            []string{"cpe:2.3:a:ruby-lang:ruby:3.2.2:*:*:*:*:*:*:*"},
+				},
+				Locations: []string{"testdata/ruby/lib/ruby/3.2.0/x86_64-linux/rbconfig.rb"},
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			var e filesystem.Extractor = runtimeversion.New()
+
+			scanInput := extracttest.GenerateScanInputMock(t, tt.InputConfig)
+			defer extracttest.CloseTestScanInput(t, scanInput)
+
+			got, err := e.Extract(context.Background(), &scanInput)
+
+			if diff := cmp.Diff(tt.WantErr, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("%s.Extract(%q) error diff (-want +got):\n%s", e.Name(), tt.InputConfig.Path, diff)
+				return
+			}
+
+			want := inventory.Inventory{Packages: tt.WantPackages}
+			if diff := cmp.Diff(want, got, cmpopts.SortSlices(extracttest.PackageCmpLess)); diff != "" {
+				t.Errorf("%s.Extract(%q) diff (-want +got):\n%s", e.Name(), tt.InputConfig.Path, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,13 @@
+#ifndef SRC_NODE_VERSION_H_
+#define SRC_NODE_VERSION_H_
+
+#define NODE_MAJOR_VERSION 18
+#define NODE_MINOR_VERSION 17
+#define NODE_PATCH_VERSION 1
+
+#define NODE_VERSION_IS_LTS 1
+#define NODE_VERSION_LTS_CODENAME "Hydrogen"
+
+#define NODE_VERSION_IS_RELEASE 1
+
+#endif  // SRC_NODE_VERSION_H_
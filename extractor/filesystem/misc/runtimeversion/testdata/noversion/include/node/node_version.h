@@ -0,0 +1,3 @@
+#ifndef SRC_NODE_VERSION_H_
+#define SRC_NODE_VERSION_H_
+#endif  // SRC_NODE_VERSION_H_
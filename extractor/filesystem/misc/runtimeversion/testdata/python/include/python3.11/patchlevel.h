@@ -0,0 +1,10 @@
+/* Python version identification scheme. */
+
+#define PY_MAJOR_VERSION        3
+#define PY_MINOR_VERSION        11
+#define PY_MICRO_VERSION        4
+#define PY_RELEASE_LEVEL        PY_RELEASE_LEVEL_FINAL
+#define PY_RELEASE_SERIAL       0
+
+/* Version as a string */
+#define PY_VERSION              "3.11.4"
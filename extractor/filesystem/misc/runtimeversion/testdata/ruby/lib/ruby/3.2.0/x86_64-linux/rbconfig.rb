@@ -0,0 +1,13 @@
+# This file was created by mkconfig.rb when ruby was built.
+module RbConfig
+  RUBY_VERSION.start_with?("3.2.") or
+    raise "ruby lib version (3.2.2) doesn't match executable version (#{RUBY_VERSION})"
+
+  TOPDIR = File.dirname(__FILE__).chomp!("/lib/ruby/3.2.0/x86_64-linux")
+  CONFIG = {}
+  CONFIG["MAJOR"] = "3"
+  CONFIG["MINOR"] = "2"
+  CONFIG["TEENY"] = "2"
+  CONFIG["PATCHLEVEL"] = "53"
+  CONFIG["RUBY_PROGRAM_VERSION"] = "3.2.2"
+end
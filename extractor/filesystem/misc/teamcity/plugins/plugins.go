@@ -0,0 +1,226 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package plugins extracts packages from installed TeamCity server plugins.
+package plugins
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/extractor/filesystem/internal/units"
+	scalibrfs "github.com/google/osv-scalibr/fs"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/plugin"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scalibr/stats"
+)
+
+const (
+	// Name is the unique name of this extractor.
+	Name = "teamcity/plugins"
+
+	descriptorName = "teamcity-plugin.xml"
+	// Plugins bundled with the server are unpacked in the web application.
+	bundledPluginsDir = "webapps/ROOT/WEB-INF/plugins/"
+	// External plugins are installed as zip files in the plugins directory of the
+	// data directory, which defaults to ~/.BuildServer.
+	externalPluginsDir = ".BuildServer/plugins/"
+)
+
+// Config is the configuration for the TeamCity plugins extractor.
+type Config struct {
+	// Stats is a stats collector for reporting metrics.
+	Stats stats.Collector
+	// MaxFileSizeBytes is the maximum file size this extractor will open. If
+	// `FileRequired` gets a bigger file, it will return false,
+	MaxFileSizeBytes int64
+}
+
+// DefaultConfig returns the default configuration for the TeamCity plugins extractor.
+func DefaultConfig() Config {
+	return Config{
+		Stats:            nil,
+		MaxFileSizeBytes: 100 * units.MiB,
+	}
+}
+
+// Extractor extracts TeamCity plugins from their teamcity-plugin.xml descriptors.
+type Extractor struct {
+	stats            stats.Collector
+	maxFileSizeBytes int64
+}
+
+// New returns a TeamCity plugins extractor.
+//
+// For most use cases, initialize with:
+// ```
+// e := New(DefaultConfig())
+// ```
+func New(cfg Config) *Extractor {
+	return &Extractor{
+		stats:            cfg.Stats,
+		maxFileSizeBytes: cfg.MaxFileSizeBytes,
+	}
+}
+
+// NewDefault returns an extractor with the default config settings.
+func NewDefault() filesystem.Extractor {
+	return New(DefaultConfig())
+}
+
+// Config returns the configuration of the extractor.
+func (e Extractor) Config() Config {
+	return Config{
+		Stats:            e.stats,
+		MaxFileSizeBytes: e.maxFileSizeBytes,
+	}
+}
+
+// Name of the extractor.
+func (e Extractor) Name() string { return Name }
+
+// Version of the extractor.
+func (e Extractor) Version() int { return 0 }
+
+// Requirements of the extractor.
+func (e Extractor) Requirements() *plugin.Capabilities { return &plugin.Capabilities{} }
+
+// FileRequired returns true if the specified file is the descriptor of a bundled plugin
+// or an external plugin zip file.
+func (e Extractor) FileRequired(api filesystem.FileAPI) bool {
+	p := api.Path()
+	if !isBundledDescriptor(p) && !isExternalPlugin(p) {
+		return false
+	}
+
+	fileinfo, err := api.Stat()
+	if err != nil {
+		return false
+	}
+
+	if e.maxFileSizeBytes > 0 && fileinfo.Size() > e.maxFileSizeBytes {
+		e.reportFileRequired(p, fileinfo.Size(), stats.FileRequiredResultSizeLimitExceeded)
+		return false
+	}
+
+	e.reportFileRequired(p, fileinfo.Size(), stats.FileRequiredResultOK)
+	return true
+}
+
+// isBundledDescriptor returns true for webapps/ROOT/WEB-INF/plugins/<plugin>/teamcity-plugin.xml files.
+func isBundledDescriptor(p string) bool {
+	if path.Base(p) != descriptorName {
+		return false
+	}
+	return strings.HasSuffix(path.Dir(path.Dir(p))+"/", bundledPluginsDir)
+}
+
+// isExternalPlugin returns true for .BuildServer/plugins/<plugin>.zip files.
+func isExternalPlugin(p string) bool {
+	return strings.EqualFold(path.Ext(p), ".zip") && strings.HasSuffix(path.Dir(p)+"/", externalPluginsDir)
+}
+
+func (e Extractor) reportFileRequired(path string, fileSizeBytes int64, result stats.FileRequiredResult) {
+	if e.stats == nil {
+		return
+	}
+	e.stats.AfterFileRequired(e.Name(), &stats.FileRequiredStats{
+		Path:          path,
+		Result:        result,
+		FileSizeBytes: fileSizeBytes,
+	})
+}
+
+// Extract parses the plugin descriptor, reading it from the plugin zip for external plugins.
+func (e Extractor) Extract(ctx context.Context, input *filesystem.ScanInput) (inventory.Inventory, error) {
+	pkg, err := e.extractFromInput(input)
+	if e.stats != nil {
+		var fileSizeBytes int64
+		if input.Info != nil {
+			fileSizeBytes = input.Info.Size()
+		}
+		e.stats.AfterFileExtracted(e.Name(), &stats.FileExtractedStats{
+			Path:          input.Path,
+			Result:        filesystem.ExtractorErrorToFileExtractedResult(err),
+			FileSizeBytes: fileSizeBytes,
+		})
+	}
+	if err != nil {
+		return inventory.Inventory{}, fmt.Errorf("%s halted at %q: %w", e.Name(), input.Path, err)
+	}
+	if pkg == nil {
+		return inventory.Inventory{}, nil
+	}
+	return inventory.Inventory{Packages: []*extractor.Package{pkg}}, nil
+}
+
+func (e Extractor) extractFromInput(input *filesystem.ScanInput) (*extractor.Package, error) {
+	if !isExternalPlugin(input.Path) {
+		return parseDescriptor(input.Reader, input.Path)
+	}
+
+	if input.Info == nil {
+		return nil, fmt.Errorf("file info for %s is missing", input.Path)
+	}
+	r, err := scalibrfs.NewReaderAt(input.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("NewReaderAt(%s): %w", input.Path, err)
+	}
+	zipReader, err := zip.NewReader(r, input.Info.Size())
+	if err != nil {
+		return nil, fmt.Errorf("zip.NewReader(%s): %w", input.Path, err)
+	}
+	f, err := zipReader.Open(descriptorName)
+	if err != nil {
+		// Not a TeamCity plugin.
+		return nil, nil
+	}
+	defer f.Close()
+	return parseDescriptor(f, input.Path)
+}
+
+type descriptor struct {
+	Info struct {
+		Name        string `xml:"name"`
+		DisplayName string `xml:"display-name"`
+		Version     string `xml:"version"`
+	} `xml:"info"`
+}
+
+func parseDescriptor(r io.Reader, location string) (*extractor.Package, error) {
+	var d descriptor
+	if err := xml.NewDecoder(r).Decode(&d); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", descriptorName, err)
+	}
+	name := strings.TrimSpace(d.Info.Name)
+	version := strings.TrimSpace(d.Info.Version)
+	// Development builds leave the version as an unexpanded build placeholder like @Version@.
+	if name == "" || version == "" || strings.Contains(version, "@") {
+		return nil, nil
+	}
+	return &extractor.Package{
+		Name:      name,
+		Version:   version,
+		PURLType:  purl.TypeGeneric,
+		Locations: []string{location},
+	}, nil
+}
@@ -0,0 +1,190 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugins_test
+
+import (
+	"context"
+	"io/fs"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/extractor/filesystem/internal/units"
+	"github.com/google/osv-scalibr/extractor/filesystem/misc/teamcity/plugins"
+	"github.com/google/osv-scalibr/extractor/filesystem/simplefileapi"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scalibr/stats"
+	"github.com/google/osv-scalibr/testing/extracttest"
+	"github.com/google/osv-scalibr/testing/fakefs"
+	"github.com/google/osv-scalibr/testing/testcollector"
+)
+
+func TestFileRequired(t *testing.T) {
+	tests := []struct {
+		name             string
+		path             string
+		fileSizeBytes    int64
+		maxFileSizeBytes int64
+		wantRequired     bool
+		wantResultMetric stats.FileRequiredResult
+	}{
+		{
+			name:             "bundled_plugin_descriptor",
+			path:             "opt/TeamCity/webapps/ROOT/WEB-INF/plugins/ant/teamcity-plugin.xml",
+			wantRequired:     true,
+			wantResultMetric: stats.FileRequiredResultOK,
+		},
+		{
+			name:             "external_plugin_zip",
+			path:             "home/teamcity/.BuildServer/plugins/slack-notifier.zip",
+			wantRequired:     true,
+			wantResultMetric: stats.FileRequiredResultOK,
+		},
+		{
+			name:         "descriptor_outside_plugins_dir",
+			path:         "home/user/teamcity-plugin.xml",
+			wantRequired: false,
+		},
+		{
+			name:         "nested_descriptor",
+			path:         "opt/TeamCity/webapps/ROOT/WEB-INF/plugins/ant/agent/teamcity-plugin.xml",
+			wantRequired: false,
+		},
+		{
+			name:         "zip_outside_data_dir",
+			path:         "home/user/plugins/slack-notifier.zip",
+			wantRequired: false,
+		},
+		{
+			name:         "unpacked_external_plugin",
+			path:         "home/teamcity/.BuildServer/plugins/.unpacked/slack-notifier/teamcity-plugin.xml",
+			wantRequired: false,
+		},
+		{
+			name:             "file_size_greater_than_max_size",
+			path:             "home/teamcity/.BuildServer/plugins/slack-notifier.zip",
+			fileSizeBytes:    1000 * units.KiB,
+			maxFileSizeBytes: 100 * units.KiB,
+			wantRequired:     false,
+			wantResultMetric: stats.FileRequiredResultSizeLimitExceeded,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			collector := testcollector.New()
+			var e filesystem.Extractor = plugins.New(plugins.Config{
+				Stats:            collector,
+				MaxFileSizeBytes: tt.maxFileSizeBytes,
+			})
+
+			fileSizeBytes := tt.fileSizeBytes
+			if fileSizeBytes == 0 {
+				fileSizeBytes = 1000
+			}
+
+			isRequired := e.FileRequired(simplefileapi.New(tt.path, fakefs.FakeFileInfo{
+				FileName: filepath.Base(tt.path),
+				FileMode: fs.ModePerm,
+				FileSize: fileSizeBytes,
+			}))
+			if isRequired != tt.wantRequired {
+				t.Fatalf("FileRequired(%s): got %v, want %v", tt.path, isRequired, tt.wantRequired)
+			}
+
+			gotResultMetric := collector.FileRequiredResult(tt.path)
+			if tt.wantResultMetric != "" && gotResultMetric != tt.wantResultMetric {
+				t.Errorf("FileRequired(%s) recorded result metric %v, want result metric %v", tt.path, gotResultMetric, tt.wantResultMetric)
+			}
+		})
+	}
+}
+
+func TestExtract(t *testing.T) {
+	tests := []extracttest.TestTableEntry{
+		{
+			Name: "bundled_plugin",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/webapps/ROOT/WEB-INF/plugins/ant/teamcity-plugin.xml",
+			},
+			WantPackages: []*extractor.Package{
+				{
+					Name:      "ant",
+					Version:   "2024.12",
+					PURLType:  purl.TypeGeneric,
+					Locations: []string{"testdata/webapps/ROOT/WEB-INF/plugins/ant/teamcity-plugin.xml"},
+				},
+			},
+		},
+		{
+			Name: "external_plugin",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/.BuildServer/plugins/slack-notifier.zip",
+			},
+			WantPackages: []*extractor.Package{
+				{
+					Name:      "slackNotifier",
+					Version:   "2.1.0",
+					PURLType:  purl.TypeGeneric,
+					Locations: []string{"testdata/.BuildServer/plugins/slack-notifier.zip"},
+				},
+			},
+		},
+		{
+			Name: "version_placeholder",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/webapps/ROOT/WEB-INF/plugins/dev/teamcity-plugin.xml",
+			},
+		},
+		{
+			Name: "zip_without_descriptor",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/.BuildServer/plugins/backup.zip",
+			},
+		},
+		{
+			Name: "invalid_descriptor",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/webapps/ROOT/WEB-INF/plugins/invalid/teamcity-plugin.xml",
+			},
+			WantErr: cmpopts.AnyError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			var e filesystem.Extractor = plugins.NewDefault()
+
+			scanInput := extracttest.GenerateScanInputMock(t, tt.InputConfig)
+			defer extracttest.CloseTestScanInput(t, scanInput)
+
+			got, err := e.Extract(context.Background(), &scanInput)
+
+			if diff := cmp.Diff(tt.WantErr, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("%s.Extract(%q) error diff (-want +got):\n%s", e.Name(), tt.InputConfig.Path, diff)
+				return
+			}
+
+			want := inventory.Inventory{Packages: tt.WantPackages}
+			if diff := cmp.Diff(want, got, cmpopts.SortSlices(extracttest.PackageCmpLess)); diff != "" {
+				t.Errorf("%s.Extract(%q) diff (-want +got):\n%s", e.Name(), tt.InputConfig.Path, diff)
+			}
+		})
+	}
+}
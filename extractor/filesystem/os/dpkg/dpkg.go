@@ -132,11 +132,18 @@ func (e Extractor) FileRequired(api filesystem.FileAPI) bool {
 	return true
 }
 
+// opkgStatusFiles are the status files of OPKG, e.g. on OpenWrt or on Yocto
+// images built with the ipk package format.
+var opkgStatusFiles = map[string]bool{
+	"usr/lib/opkg/status": true,
+	"var/lib/opkg/status": true,
+}
+
 func fileRequired(path string) bool {
 	normalized := filepath.ToSlash(path)
 
 	// Normal status file matching DPKG or OPKG format
-	if normalized == "var/lib/dpkg/status" || normalized == "usr/lib/opkg/status" {
+	if normalized == "var/lib/dpkg/status" || opkgStatusFiles[normalized] {
 		return true
 	}
 
@@ -246,7 +253,7 @@ func (e Extractor) extractFromInput(ctx context.Context, input *filesystem.ScanI
 		}
 
 		purlType := purl.TypeDebian
-		if input.Path == "usr/lib/opkg/status" {
+		if opkgStatusFiles[filepath.ToSlash(input.Path)] {
 			purlType = purl.TypeOpkg
 		}
 
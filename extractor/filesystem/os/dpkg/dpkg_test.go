@@ -149,6 +149,12 @@ func TestFileRequired(t *testing.T) {
 			wantRequired:     true,
 			wantResultMetric: stats.FileRequiredResultOK,
 		},
+		{
+			name:             "yocto opkg status file",
+			path:             "var/lib/opkg/status",
+			wantRequired:     true,
+			wantResultMetric: stats.FileRequiredResultOK,
+		},
 		{
 			name:         "status as a directory",
 			path:         "usr/lib/opkg/status/foo",
@@ -203,6 +209,13 @@ VERSION_CODENAME="openwrt-21.02.1"
 PRETTY_NAME="OpenWrt 21.02.1"
 BUILD_ID="r16279-5cc53c7f44"`
 
+const YoctoRelease = `ID=poky
+NAME="Poky (Yocto Project Reference Distro)"
+VERSION="4.0.12 (kirkstone)"
+VERSION_ID=4.0.12
+PRETTY_NAME="Poky (Yocto Project Reference Distro) 4.0.12 (kirkstone)"
+DISTRO_CODENAME="kirkstone"`
+
 func TestExtract(t *testing.T) {
 	tests := []struct {
 		name             string
@@ -210,6 +223,7 @@ func TestExtract(t *testing.T) {
 		osrelease        string
 		cfg              dpkg.Config
 		isOPKG           bool
+		opkgPath         string
 		wantPackages     []*extractor.Package
 		wantErr          error
 		wantResultMetric stats.FileExtractedResult
@@ -1104,6 +1118,47 @@ func TestExtract(t *testing.T) {
 			},
 			wantResultMetric: stats.FileExtractedResultSuccess,
 		},
+		{
+			name:      "yocto opkg status file",
+			path:      "testdata/opkg/yocto",
+			osrelease: YoctoRelease,
+			isOPKG:    true,
+			opkgPath:  "var/lib/opkg/status",
+			wantPackages: []*extractor.Package{
+				{
+					Name:     "busybox",
+					Version:  "1.35.0-r0",
+					PURLType: purl.TypeOpkg,
+					Metadata: &dpkgmeta.Metadata{
+						PackageName:    "busybox",
+						PackageVersion: "1.35.0-r0",
+						Status:         "install ok installed",
+						Architecture:   "core2-64",
+						Maintainer:     "Poky <poky@lists.yoctoproject.org>",
+						OSID:           "poky",
+						OSVersionID:    "4.0.12",
+					},
+					Locations: []string{"var/lib/opkg/status"},
+				},
+				{
+					Name:     "libssl3",
+					Version:  "3.0.10-r0",
+					PURLType: purl.TypeOpkg,
+					Metadata: &dpkgmeta.Metadata{
+						PackageName:    "libssl3",
+						PackageVersion: "3.0.10-r0",
+						Status:         "install ok installed",
+						SourceName:     "openssl",
+						Architecture:   "core2-64",
+						Maintainer:     "Poky <poky@lists.yoctoproject.org>",
+						OSID:           "poky",
+						OSVersionID:    "4.0.12",
+					},
+					Locations: []string{"var/lib/opkg/status"},
+				},
+			},
+			wantResultMetric: stats.FileExtractedResultSuccess,
+		},
 		{
 			name:             "empty",
 			path:             "testdata/opkg/empty",
@@ -1278,6 +1333,9 @@ func TestExtract(t *testing.T) {
 			// uses the path to differentiate between things like PURL types.
 			if tt.isOPKG {
 				tt.path = "usr/lib/opkg/status"
+				if tt.opkgPath != "" {
+					tt.path = tt.opkgPath
+				}
 			} else if strings.Contains(tt.path, "status.d") {
 				tt.path = "var/lib/dpkg/status.d" + strings.Split(tt.path, "status.d")[1]
 			} else {
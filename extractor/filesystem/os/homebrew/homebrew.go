@@ -12,16 +12,23 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-// Package homebrew extracts package information from OSX homebrew INSTALL_RECEIPT.json files.
+// Package homebrew extracts package information from OSX homebrew INSTALL_RECEIPT.json files and
+// Brewfile.lock.json files.
 package homebrew
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"path"
+	"slices"
 	"strings"
 
 	"github.com/google/osv-scalibr/extractor"
 	"github.com/google/osv-scalibr/extractor/filesystem"
 	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/log"
 	"github.com/google/osv-scalibr/plugin"
 	"github.com/google/osv-scalibr/purl"
 )
@@ -36,6 +43,7 @@ const (
 	caskFileName1  = ".wrapper.sh"
 	caskFileName2  = "source.properties"
 	caskFileName3  = ".app"
+	lockFileName   = "brewfile.lock.json"
 )
 
 // BrewPath struct holds homebrew package information from homebrew package path.
@@ -58,7 +66,7 @@ func New() filesystem.Extractor { return &Extractor{} }
 func (e Extractor) Name() string { return Name }
 
 // Version of the extractor.
-func (e Extractor) Version() int { return 0 }
+func (e Extractor) Version() int { return 1 }
 
 // Requirements of the extractor.
 func (e Extractor) Requirements() *plugin.Capabilities { return &plugin.Capabilities{OS: plugin.OSMac} }
@@ -72,7 +80,12 @@ func (e Extractor) FileRequired(api filesystem.FileAPI) bool {
 	// /usr/local/Cellar/... ; /opt/homebrew/Caskroom/... ; /usr/local/Caskroom/...;
 	// /Users/emat/homebrew/Caskroom/... etc.
 	// Ensure correct Homebrew path and file-name relationships are met for both Cellar and Caskroom.
-	return isCellar(filePath) || isCaskroom(filePath)
+	return isCellar(filePath) || isCaskroom(filePath) || isBrewfileLock(filePath)
+}
+
+// isBrewfileLock verifies that the file is a Brewfile.lock.json written by "brew bundle".
+func isBrewfileLock(filePath string) bool {
+	return path.Base(filePath) == lockFileName
 }
 
 // isCellar verifies Path to filename relationship.
@@ -95,21 +108,103 @@ func isCaskroom(filePath string) bool {
 
 // Extract parses the recognised Homebrew file path and returns information about the installed package.
 func (e Extractor) Extract(ctx context.Context, input *filesystem.ScanInput) (inventory.Inventory, error) {
+	filePath := strings.ToLower(input.Path)
+	if isBrewfileLock(filePath) {
+		return extractBrewfileLock(input)
+	}
+
 	p := SplitPath(input.Path)
 	if p == nil {
 		return inventory.Inventory{}, nil
 	}
+
+	m := &Metadata{}
+	if isCellar(filePath) {
+		// The package is still reported if the receipt can't be parsed, since its name and version
+		// come from the path.
+		if err := parseInstallReceipt(input, m); err != nil {
+			log.Warnf("homebrew: %v", err)
+		}
+	}
+
 	return inventory.Inventory{Packages: []*extractor.Package{
 		{
 			Name:      p.AppName,
 			Version:   p.AppVersion,
 			PURLType:  purl.TypeBrew,
 			Locations: []string{input.Path},
-			Metadata:  &Metadata{},
+			Metadata:  m,
 		},
 	}}, nil
 }
 
+// installReceipt holds the relevant fields of a Cellar INSTALL_RECEIPT.json file.
+type installReceipt struct {
+	InstalledAsDependency bool `json:"installed_as_dependency"`
+	InstalledOnRequest    bool `json:"installed_on_request"`
+	Source                struct {
+		Tap string `json:"tap"`
+	} `json:"source"`
+}
+
+// parseInstallReceipt fills the metadata with the fields of the INSTALL_RECEIPT.json file.
+func parseInstallReceipt(input *filesystem.ScanInput, m *Metadata) error {
+	var receipt installReceipt
+	if err := json.NewDecoder(input.Reader).Decode(&receipt); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", input.Path, err)
+	}
+	m.Tap = receipt.Source.Tap
+	m.InstalledOnRequest = receipt.InstalledOnRequest
+	m.InstalledAsDependency = receipt.InstalledAsDependency
+	return nil
+}
+
+// brewfileLock holds the relevant fields of a Brewfile.lock.json file.
+type brewfileLock struct {
+	Entries struct {
+		Brew map[string]brewfileLockEntry `json:"brew"`
+		Cask map[string]brewfileLockEntry `json:"cask"`
+	} `json:"entries"`
+}
+
+type brewfileLockEntry struct {
+	Version string `json:"version"`
+}
+
+// extractBrewfileLock returns the formulae and casks pinned in a Brewfile.lock.json file. Other
+// entries, e.g. taps and Mac App Store apps, are ignored.
+func extractBrewfileLock(input *filesystem.ScanInput) (inventory.Inventory, error) {
+	var lock brewfileLock
+	if err := json.NewDecoder(input.Reader).Decode(&lock); err != nil {
+		return inventory.Inventory{}, fmt.Errorf("failed to parse %s: %w", input.Path, err)
+	}
+
+	var pkgs []*extractor.Package
+	for _, entries := range []map[string]brewfileLockEntry{lock.Entries.Brew, lock.Entries.Cask} {
+		for _, fullName := range slices.Sorted(maps.Keys(entries)) {
+			name, tap := splitTapName(fullName)
+			pkgs = append(pkgs, &extractor.Package{
+				Name:      name,
+				Version:   entries[fullName].Version,
+				PURLType:  purl.TypeBrew,
+				Locations: []string{input.Path},
+				Metadata:  &Metadata{Tap: tap},
+			})
+		}
+	}
+	return inventory.Inventory{Packages: pkgs}, nil
+}
+
+// splitTapName splits a fully qualified formula or cask name like "hashicorp/tap/terraform" into
+// its name and tap.
+func splitTapName(fullName string) (name, tap string) {
+	i := strings.LastIndex(fullName, "/")
+	if i < 0 {
+		return fullName, ""
+	}
+	return fullName[i+1:], fullName[:i]
+}
+
 // SplitPath takes the package path and splits it into its recognised struct components
 func SplitPath(path string) *BrewPath {
 	path = strings.ToLower(path)
@@ -16,6 +16,7 @@ package homebrew_test
 
 import (
 	"context"
+	"os"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -84,6 +85,16 @@ func TestFileRequired(t *testing.T) {
 			path:           "somefolder/Caskroom/rclone/1.67.0/INSTALL_RECEIPT.json",
 			wantIsRequired: false,
 		},
+		{
+			name:           "brewfile.lock",
+			path:           "project/Brewfile.lock.json",
+			wantIsRequired: true,
+		},
+		{
+			name:           "brewfile.without.lock",
+			path:           "project/Brewfile",
+			wantIsRequired: false,
+		},
 		{
 			name:           "generally.wrong.path",
 			path:           "somefolder/otherfile.json",
@@ -126,6 +137,22 @@ func TestExtract(t *testing.T) {
 					Version:   "1.67.0",
 					PURLType:  purl.TypeBrew,
 					Locations: []string{"testdata/Cellar/rclone/1.67.0/INSTALL_RECEIPT.json"},
+					Metadata: &homebrew.Metadata{
+						Tap:                "homebrew/core",
+						InstalledOnRequest: true,
+					},
+				},
+			},
+		},
+		{
+			name: "cellar.invalid.receipt",
+			path: "testdata/Cellar/broken/1.0/INSTALL_RECEIPT.json",
+			wantPackages: []*extractor.Package{
+				{
+					Name:      "broken",
+					Version:   "1.0",
+					PURLType:  purl.TypeBrew,
+					Locations: []string{"testdata/Cellar/broken/1.0/INSTALL_RECEIPT.json"},
 					Metadata:  &homebrew.Metadata{},
 				},
 			},
@@ -143,6 +170,46 @@ func TestExtract(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "brewfile.lock.valid.json",
+			path: "testdata/bundle/Brewfile.lock.json",
+			wantPackages: []*extractor.Package{
+				{
+					Name:      "git",
+					Version:   "2.42.0",
+					PURLType:  purl.TypeBrew,
+					Locations: []string{"testdata/bundle/Brewfile.lock.json"},
+					Metadata:  &homebrew.Metadata{},
+				},
+				{
+					Name:      "openssl@3",
+					Version:   "3.1.3",
+					PURLType:  purl.TypeBrew,
+					Locations: []string{"testdata/bundle/Brewfile.lock.json"},
+					Metadata:  &homebrew.Metadata{},
+				},
+				{
+					Name:      "terraform",
+					Version:   "1.6.1",
+					PURLType:  purl.TypeBrew,
+					Locations: []string{"testdata/bundle/Brewfile.lock.json"},
+					Metadata:  &homebrew.Metadata{Tap: "hashicorp/tap"},
+				},
+				{
+					Name:      "firefox",
+					Version:   "118.0.1",
+					PURLType:  purl.TypeBrew,
+					Locations: []string{"testdata/bundle/Brewfile.lock.json"},
+					Metadata:  &homebrew.Metadata{},
+				},
+			},
+		},
+		{
+			name:         "brewfile.lock.invalid.json",
+			path:         "testdata/bundle-invalid/Brewfile.lock.json",
+			wantErr:      cmpopts.AnyError,
+			wantPackages: nil,
+		},
 		{
 			name:         "caskroom.null.variation",
 			path:         "testdata/Caskroom/somefolder/2.2",
@@ -166,7 +233,11 @@ func TestExtract(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			var e filesystem.Extractor = homebrew.Extractor{}
-			input := &filesystem.ScanInput{Path: tt.path, Reader: nil}
+			input := &filesystem.ScanInput{Path: tt.path}
+			if f, err := os.Open(tt.path); err == nil {
+				defer f.Close()
+				input.Reader = f
+			}
 			got, err := e.Extract(context.Background(), input)
 			if diff := cmp.Diff(tt.wantErr, err, cmpopts.EquateErrors()); diff != "" {
 				t.Errorf("Extract(%s) unexpected error (-want +got):\n%s", tt.path, diff)
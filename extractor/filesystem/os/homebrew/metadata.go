@@ -15,4 +15,11 @@
 package homebrew
 
 // Metadata is the metadata struct for information relating to the homebrew package.
-type Metadata struct{}
+type Metadata struct {
+	// Tap is the tap the formula or cask was installed from, e.g. "homebrew/core".
+	Tap string
+	// InstalledOnRequest and InstalledAsDependency are read from the INSTALL_RECEIPT.json of formulae
+	// in the Cellar.
+	InstalledOnRequest    bool
+	InstalledAsDependency bool
+}
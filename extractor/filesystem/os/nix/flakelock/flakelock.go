@@ -0,0 +1,177 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package flakelock extracts the pinned inputs of Nix flakes from flake.lock
+// files.
+package flakelock
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/plugin"
+	"github.com/google/osv-scalibr/purl"
+)
+
+const (
+	// Name is the unique name of this extractor.
+	Name = "nix/flakelock"
+
+	defaultGitlabHost    = "gitlab.com"
+	defaultSourcehutHost = "git.sr.ht"
+)
+
+// lockedRef is the resolved reference of a flake input. Which fields are set
+// depends on the input type.
+type lockedRef struct {
+	Type  string `json:"type"`
+	Owner string `json:"owner"`
+	Repo  string `json:"repo"`
+	Host  string `json:"host"`
+	URL   string `json:"url"`
+	Rev   string `json:"rev"`
+}
+
+type node struct {
+	Locked *lockedRef `json:"locked"`
+}
+
+type flakeLock struct {
+	Nodes map[string]node `json:"nodes"`
+	Root  string          `json:"root"`
+}
+
+// Extractor extracts the inputs of Nix flakes from flake.lock files.
+type Extractor struct{}
+
+// New returns a new instance of the extractor.
+func New() filesystem.Extractor { return &Extractor{} }
+
+// Name of the extractor.
+func (e Extractor) Name() string { return Name }
+
+// Version of the extractor.
+func (e Extractor) Version() int { return 0 }
+
+// Requirements of the extractor.
+func (e Extractor) Requirements() *plugin.Capabilities {
+	return &plugin.Capabilities{}
+}
+
+// FileRequired returns true if the specified file is a flake.lock file.
+func (e Extractor) FileRequired(api filesystem.FileAPI) bool {
+	return filepath.Base(api.Path()) == "flake.lock"
+}
+
+// Extract extracts the locked inputs from the flake.lock file passed through
+// the scan input. Local path inputs and inputs that were never locked are
+// skipped as they don't identify any upstream source.
+func (e Extractor) Extract(ctx context.Context, input *filesystem.ScanInput) (inventory.Inventory, error) {
+	var parsed flakeLock
+	if err := json.NewDecoder(input.Reader).Decode(&parsed); err != nil {
+		return inventory.Inventory{}, fmt.Errorf("could not extract: %w", err)
+	}
+	if parsed.Root == "" {
+		parsed.Root = "root"
+	}
+
+	type key struct{ name, version string }
+	seen := map[key]bool{}
+	var packages []*extractor.Package
+	// Iterate in a fixed order so that the first location of a source is stable.
+	for _, id := range slices.Sorted(maps.Keys(parsed.Nodes)) {
+		n := parsed.Nodes[id]
+		if err := ctx.Err(); err != nil {
+			return inventory.Inventory{Packages: packages}, fmt.Errorf("%s halted due to context error: %w", e.Name(), err)
+		}
+		if id == parsed.Root || n.Locked == nil {
+			continue
+		}
+		pkg := toPackage(n.Locked)
+		if pkg == nil {
+			continue
+		}
+		// Inputs of different flakes often resolve to the same source.
+		k := key{name: pkg.Name, version: pkg.Version}
+		if seen[k] {
+			continue
+		}
+		seen[k] = true
+		pkg.Locations = []string{input.Path}
+		packages = append(packages, pkg)
+	}
+	return inventory.Inventory{Packages: packages}, nil
+}
+
+// toPackage returns the package for a locked flake input or nil if the input
+// type isn't supported.
+func toPackage(l *lockedRef) *extractor.Package {
+	var name, repo string
+	var purlType string
+	switch l.Type {
+	case "github":
+		name = l.Owner + "/" + l.Repo
+		repo = "https://github.com/" + name
+		purlType = purl.TypeGithub
+	case "gitlab":
+		host := l.Host
+		if host == "" {
+			host = defaultGitlabHost
+		}
+		name = host + "/" + l.Owner + "/" + l.Repo
+		repo = "https://" + name
+	case "sourcehut":
+		host := l.Host
+		if host == "" {
+			host = defaultSourcehutHost
+		}
+		name = host + "/" + l.Owner + "/" + l.Repo
+		repo = "https://" + name
+	case "git", "mercurial":
+		name = l.URL
+		repo = l.URL
+	case "tarball", "file":
+		name = l.URL
+	default:
+		// "path" inputs point to the local filesystem and "indirect" inputs
+		// are never locked.
+		return nil
+	}
+	if name == "" || strings.HasSuffix(name, "/") {
+		return nil
+	}
+
+	pkg := &extractor.Package{
+		Name:     name,
+		Version:  l.Rev,
+		PURLType: purlType,
+	}
+	if repo != "" && l.Rev != "" {
+		pkg.SourceCode = &extractor.SourceCodeIdentifier{
+			Repo:   repo,
+			Commit: l.Rev,
+		}
+	}
+	return pkg
+}
+
+var _ filesystem.Extractor = Extractor{}
@@ -0,0 +1,155 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flakelock_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem/os/nix/flakelock"
+	"github.com/google/osv-scalibr/extractor/filesystem/simplefileapi"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scalibr/testing/extracttest"
+)
+
+func TestExtractor_FileRequired(t *testing.T) {
+	tests := []struct {
+		name      string
+		inputPath string
+		want      bool
+	}{
+		{
+			name:      "flake.lock",
+			inputPath: "project/flake.lock",
+			want:      true,
+		},
+		{
+			name:      "flake.nix",
+			inputPath: "project/flake.nix",
+			want:      false,
+		},
+		{
+			name:      "similar name",
+			inputPath: "project/flake.lock.bak",
+			want:      false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := flakelock.Extractor{}
+			got := e.FileRequired(simplefileapi.New(tt.inputPath, nil))
+			if got != tt.want {
+				t.Errorf("FileRequired(%s, FileInfo) got = %v, want %v", tt.inputPath, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtractor_Extract(t *testing.T) {
+	tests := []extracttest.TestTableEntry{
+		{
+			Name: "invalid json",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/invalid.json",
+			},
+			WantErr: extracttest.ContainsErrStr{Str: "could not extract"},
+		},
+		{
+			Name: "inputs",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/flake.lock",
+			},
+			WantPackages: []*extractor.Package{
+				{
+					Name:      "numtide/flake-utils",
+					Version:   "b1d9ab70662946ef0850d488da1c9019f3a9752a",
+					PURLType:  purl.TypeGithub,
+					Locations: []string{"testdata/flake.lock"},
+					SourceCode: &extractor.SourceCodeIdentifier{
+						Repo:   "https://github.com/numtide/flake-utils",
+						Commit: "b1d9ab70662946ef0850d488da1c9019f3a9752a",
+					},
+				},
+				{
+					Name:      "NixOS/nixpkgs",
+					Version:   "1042fd8b148a9105f3c0aca3a6177fd1d9360ba5",
+					PURLType:  purl.TypeGithub,
+					Locations: []string{"testdata/flake.lock"},
+					SourceCode: &extractor.SourceCodeIdentifier{
+						Repo:   "https://github.com/NixOS/nixpkgs",
+						Commit: "1042fd8b148a9105f3c0aca3a6177fd1d9360ba5",
+					},
+				},
+				{
+					Name:      "nix-systems/default",
+					Version:   "da67096a3b9bf56a91d16901293e51ba5b49a27e",
+					PURLType:  purl.TypeGithub,
+					Locations: []string{"testdata/flake.lock"},
+					SourceCode: &extractor.SourceCodeIdentifier{
+						Repo:   "https://github.com/nix-systems/default",
+						Commit: "da67096a3b9bf56a91d16901293e51ba5b49a27e",
+					},
+				},
+				{
+					Name:      "https://git.example.com/helpers.git",
+					Version:   "0f3e1c9e5a4f3b2d1c0b9a8f7e6d5c4b3a291807",
+					Locations: []string{"testdata/flake.lock"},
+					SourceCode: &extractor.SourceCodeIdentifier{
+						Repo:   "https://git.example.com/helpers.git",
+						Commit: "0f3e1c9e5a4f3b2d1c0b9a8f7e6d5c4b3a291807",
+					},
+				},
+				{
+					Name:      "gitlab.com/example/tools",
+					Version:   "4e7f0c6c2b1a5d3e9f8a7b6c5d4e3f2a1b0c9d8e",
+					Locations: []string{"testdata/flake.lock"},
+					SourceCode: &extractor.SourceCodeIdentifier{
+						Repo:   "https://gitlab.com/example/tools",
+						Commit: "4e7f0c6c2b1a5d3e9f8a7b6c5d4e3f2a1b0c9d8e",
+					},
+				},
+				{
+					Name:      "https://releases.nixos.org/nixos/23.11/nixos-23.11.7609.5c24cf2f0a12/nixexprs.tar.xz",
+					Locations: []string{"testdata/flake.lock"},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			extr := flakelock.Extractor{}
+
+			scanInput := extracttest.GenerateScanInputMock(t, tt.InputConfig)
+			defer extracttest.CloseTestScanInput(t, scanInput)
+
+			got, err := extr.Extract(context.Background(), &scanInput)
+
+			if diff := cmp.Diff(tt.WantErr, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("%s.Extract(%q) error diff (-want +got):\n%s", extr.Name(), tt.InputConfig.Path, diff)
+				return
+			}
+
+			wantInv := inventory.Inventory{Packages: tt.WantPackages}
+			if diff := cmp.Diff(wantInv, got, cmpopts.SortSlices(extracttest.PackageCmpLess)); diff != "" {
+				t.Errorf("%s.Extract(%q) diff (-want +got):\n%s", extr.Name(), tt.InputConfig.Path, diff)
+			}
+		})
+	}
+}
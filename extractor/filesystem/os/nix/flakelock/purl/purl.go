@@ -0,0 +1,39 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package purl converts GitHub flake inputs into a PackageURL.
+package purl
+
+import (
+	"strings"
+
+	"github.com/google/osv-scalibr/purl"
+)
+
+// MakePackageURL returns a package URL for a GitHub repository. The
+// repository, e.g. NixOS/nixpkgs, is split into the owner, which becomes the
+// namespace, and the name at its last slash. GitHub names are case
+// insensitive so both are lowercased.
+func MakePackageURL(repo string, version string) *purl.PackageURL {
+	namespace, name := "", strings.ToLower(repo)
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		namespace, name = name[:i], name[i+1:]
+	}
+	return &purl.PackageURL{
+		Type:      purl.TypeGithub,
+		Namespace: namespace,
+		Name:      name,
+		Version:   version,
+	}
+}
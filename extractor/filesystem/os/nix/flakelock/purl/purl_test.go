@@ -0,0 +1,63 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package purl_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	flakepurl "github.com/google/osv-scalibr/extractor/filesystem/os/nix/flakelock/purl"
+	"github.com/google/osv-scalibr/purl"
+)
+
+func TestMakePackageURL(t *testing.T) {
+	tests := []struct {
+		desc    string
+		repo    string
+		version string
+		want    *purl.PackageURL
+	}{
+		{
+			desc:    "repository",
+			repo:    "NixOS/nixpkgs",
+			version: "b134951a4c9f3c995fd7be05f3243f8ecd65d798",
+			want: &purl.PackageURL{
+				Type:      purl.TypeGithub,
+				Namespace: "nixos",
+				Name:      "nixpkgs",
+				Version:   "b134951a4c9f3c995fd7be05f3243f8ecd65d798",
+			},
+		},
+		{
+			desc:    "no_owner",
+			repo:    "nixpkgs",
+			version: "b134951a4c9f3c995fd7be05f3243f8ecd65d798",
+			want: &purl.PackageURL{
+				Type:    purl.TypeGithub,
+				Name:    "nixpkgs",
+				Version: "b134951a4c9f3c995fd7be05f3243f8ecd65d798",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got := flakepurl.MakePackageURL(tt.repo, tt.version)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("MakePackageURL(%q, %q): unexpected PURL (-want +got):\n%s", tt.repo, tt.version, diff)
+			}
+		})
+	}
+}
@@ -91,7 +91,11 @@ func (e *Extractor) FileRequired(api filesystem.FileAPI) bool {
 	return true
 }
 
-var packageStoreRegex = regexp.MustCompile(`^([a-zA-Z0-9]{32})-([a-zA-Z0-9.-]+)-([0-9.]+)(?:-(\S+))?$`)
+// Store path names follow Nix's name splitting rules: The version starts at the
+// first dash that is followed by a digit, e.g. "bash-5.2p26" or "glibc-2.39-52".
+// Non-default outputs are appended as another dash-separated suffix, e.g.
+// "curl-8.7.1-bin".
+var packageStoreRegex = regexp.MustCompile(`^([a-zA-Z0-9]{32})-([a-zA-Z0-9.+_-]+?)-([0-9][a-zA-Z0-9.+_-]*?)(?:-(bin|dev|out|lib|man|doc|devdoc|info|debug|static|dist|py|env|locale))?$`)
 var packageStoreUnstableRegex = regexp.MustCompile(`^([a-zA-Z0-9]{32})-([a-zA-Z0-9.-]+)-(unstable-[0-9]{4}-[0-9]{2}-[0-9]{2})$`)
 
 // Extract extracts packages from the filenames of the directories in the nix
@@ -166,6 +166,75 @@ func TestExtract(t *testing.T) {
 			},
 			wantResultMetric: stats.FileExtractedResultSuccess,
 		},
+		{
+			name:      "version with letters",
+			path:      "nix/store/0rz1q8dmvxrlvmzbx4ziw4gsphn2i5dn-bash-5.2p26/foo",
+			osrelease: NixVicuna,
+			wantPackages: []*extractor.Package{
+				{
+					Name:     "bash",
+					Version:  "5.2p26",
+					PURLType: purl.TypeNix,
+					Metadata: &nixmeta.Metadata{
+						PackageName:       "bash",
+						PackageVersion:    "5.2p26",
+						PackageHash:       "0rz1q8dmvxrlvmzbx4ziw4gsphn2i5dn",
+						PackageOutput:     "",
+						OSID:              "nixos",
+						OSVersionCodename: "vicuna",
+						OSVersionID:       "24.11",
+					},
+					Locations: []string{"nix/store/0rz1q8dmvxrlvmzbx4ziw4gsphn2i5dn-bash-5.2p26/foo"},
+				},
+			},
+			wantResultMetric: stats.FileExtractedResultSuccess,
+		},
+		{
+			name:      "version with release suffix",
+			path:      "nix/store/1zy01hjzwvvia6h9dq5xar88v77fgh9x-glibc-2.39-52/foo",
+			osrelease: NixVicuna,
+			wantPackages: []*extractor.Package{
+				{
+					Name:     "glibc",
+					Version:  "2.39-52",
+					PURLType: purl.TypeNix,
+					Metadata: &nixmeta.Metadata{
+						PackageName:       "glibc",
+						PackageVersion:    "2.39-52",
+						PackageHash:       "1zy01hjzwvvia6h9dq5xar88v77fgh9x",
+						PackageOutput:     "",
+						OSID:              "nixos",
+						OSVersionCodename: "vicuna",
+						OSVersionID:       "24.11",
+					},
+					Locations: []string{"nix/store/1zy01hjzwvvia6h9dq5xar88v77fgh9x-glibc-2.39-52/foo"},
+				},
+			},
+			wantResultMetric: stats.FileExtractedResultSuccess,
+		},
+		{
+			name:      "non-default output",
+			path:      "nix/store/7k6j6fd3zlhyqc1j8ahfn6l9jyw1cknm-curl-8.7.1-bin/foo",
+			osrelease: NixVicuna,
+			wantPackages: []*extractor.Package{
+				{
+					Name:     "curl",
+					Version:  "8.7.1",
+					PURLType: purl.TypeNix,
+					Metadata: &nixmeta.Metadata{
+						PackageName:       "curl",
+						PackageVersion:    "8.7.1",
+						PackageHash:       "7k6j6fd3zlhyqc1j8ahfn6l9jyw1cknm",
+						PackageOutput:     "bin",
+						OSID:              "nixos",
+						OSVersionCodename: "vicuna",
+						OSVersionID:       "24.11",
+					},
+					Locations: []string{"nix/store/7k6j6fd3zlhyqc1j8ahfn6l9jyw1cknm-curl-8.7.1-bin/foo"},
+				},
+			},
+			wantResultMetric: stats.FileExtractedResultSuccess,
+		},
 		{
 			name:         "invalid package hash",
 			path:         "nix/store/foo-webdav-server-rs-unstable-2021-08-16/foo",
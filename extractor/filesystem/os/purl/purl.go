@@ -28,6 +28,7 @@ import (
 	portagemeta "github.com/google/osv-scalibr/extractor/filesystem/os/portage/metadata"
 	rpmmeta "github.com/google/osv-scalibr/extractor/filesystem/os/rpm/metadata"
 	snapmeta "github.com/google/osv-scalibr/extractor/filesystem/os/snap/metadata"
+	yoctometa "github.com/google/osv-scalibr/extractor/filesystem/os/yocto/metadata"
 	"github.com/google/osv-scalibr/purl"
 )
 
@@ -122,6 +123,18 @@ func MakePackageURL(name string, version string, purlType string, metadata any)
 			q[purl.Distro] = distro
 		}
 
+	case *yoctometa.Metadata:
+		name = m.PackageName
+		if distro := m.ToDistro(); distro != "" {
+			q[purl.Distro] = distro
+		}
+		if m.RecipeName != "" && m.RecipeName != m.PackageName {
+			q[purl.Source] = m.RecipeName
+		}
+		if m.Architecture != "" {
+			q[purl.Arch] = m.Architecture
+		}
+
 	default:
 		return nil
 	}
@@ -28,6 +28,7 @@ import (
 	ospurl "github.com/google/osv-scalibr/extractor/filesystem/os/purl"
 	rpmmeta "github.com/google/osv-scalibr/extractor/filesystem/os/rpm/metadata"
 	snapmeta "github.com/google/osv-scalibr/extractor/filesystem/os/snap/metadata"
+	yoctometa "github.com/google/osv-scalibr/extractor/filesystem/os/yocto/metadata"
 	"github.com/google/osv-scalibr/purl"
 )
 
@@ -781,3 +782,55 @@ func TestMakePackageURLNix(t *testing.T) {
 		})
 	}
 }
+
+func TestMakePackageURLYocto(t *testing.T) {
+	tests := []struct {
+		desc     string
+		metadata *yoctometa.Metadata
+		want     *purl.PackageURL
+	}{
+		{
+			desc: "all fields present",
+			metadata: &yoctometa.Metadata{
+				PackageName:    "libssl3",
+				PackageVersion: "3.0.10-r0",
+				RecipeName:     "openssl",
+				Architecture:   "core2_64",
+				OSID:           "poky",
+				OSVersionID:    "4.0.12",
+			},
+			want: &purl.PackageURL{
+				Type:    purl.TypeYocto,
+				Name:    "libssl3",
+				Version: "3.0.10-r0",
+				Qualifiers: purl.QualifiersFromMap(map[string]string{
+					purl.Distro: "poky-4.0.12",
+					purl.Source: "openssl",
+					purl.Arch:   "core2_64",
+				}),
+			},
+		},
+		{
+			desc: "recipe with the package name and no OS",
+			metadata: &yoctometa.Metadata{
+				PackageName:    "busybox",
+				PackageVersion: "1.35.0",
+				RecipeName:     "busybox",
+			},
+			want: &purl.PackageURL{
+				Type:       purl.TypeYocto,
+				Name:       "busybox",
+				Version:    "1.35.0",
+				Qualifiers: purl.QualifiersFromMap(map[string]string{}),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got := ospurl.MakePackageURL(tt.metadata.PackageName, tt.metadata.PackageVersion, purl.TypeYocto, tt.metadata)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("ospurl.MakePackageURL(%v): unexpected PURL (-want +got):\n%s", tt.metadata, diff)
+			}
+		})
+	}
+}
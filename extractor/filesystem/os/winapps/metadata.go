@@ -0,0 +1,80 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package winapps
+
+import (
+	pb "github.com/google/osv-scalibr/binary/proto/scan_result_go_proto"
+)
+
+const (
+	// InstallerMSI denotes applications installed through Windows Installer.
+	InstallerMSI = "msi"
+	// InstallerEXE denotes applications installed through a custom setup executable.
+	InstallerEXE = "exe"
+)
+
+// Metadata is the metadata struct for information parsed from the Uninstall registry keys of
+// Windows applications.
+type Metadata struct {
+	Publisher       string
+	InstallDate     string
+	InstallLocation string
+	UninstallString string
+	// InstallerType is either InstallerMSI or InstallerEXE.
+	InstallerType string
+	// RegistryKey is the name of the Uninstall subkey, e.g. the product code for MSI packages.
+	RegistryKey string
+	// Wow64 is true for 32-bit applications installed on 64-bit Windows.
+	Wow64 bool
+}
+
+// SetProto sets the WindowsAppMetadata field in the Package proto.
+func (m *Metadata) SetProto(p *pb.Package) {
+	if m == nil {
+		return
+	}
+	if p == nil {
+		return
+	}
+
+	p.Metadata = &pb.Package_WindowsAppMetadata{
+		WindowsAppMetadata: &pb.WindowsAppMetadata{
+			Publisher:       m.Publisher,
+			InstallDate:     m.InstallDate,
+			InstallLocation: m.InstallLocation,
+			UninstallString: m.UninstallString,
+			InstallerType:   m.InstallerType,
+			RegistryKey:     m.RegistryKey,
+			Wow64:           m.Wow64,
+		},
+	}
+}
+
+// ToStruct converts the WindowsAppMetadata proto to a Metadata struct.
+func ToStruct(m *pb.WindowsAppMetadata) *Metadata {
+	if m == nil {
+		return nil
+	}
+
+	return &Metadata{
+		Publisher:       m.GetPublisher(),
+		InstallDate:     m.GetInstallDate(),
+		InstallLocation: m.GetInstallLocation(),
+		UninstallString: m.GetUninstallString(),
+		InstallerType:   m.GetInstallerType(),
+		RegistryKey:     m.GetRegistryKey(),
+		Wow64:           m.GetWow64(),
+	}
+}
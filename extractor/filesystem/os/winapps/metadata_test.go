@@ -0,0 +1,101 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package winapps_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/osv-scalibr/extractor/filesystem/os/winapps"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	pb "github.com/google/osv-scalibr/binary/proto/scan_result_go_proto"
+)
+
+func TestSetProto(t *testing.T) {
+	testCases := []struct {
+		desc string
+		m    *winapps.Metadata
+		p    *pb.Package
+		want *pb.Package
+	}{
+		{
+			desc: "nil metadata",
+			m:    nil,
+			p:    &pb.Package{Name: "some-package"},
+			want: &pb.Package{Name: "some-package"},
+		},
+		{
+			desc: "nil package",
+			m: &winapps.Metadata{
+				Publisher: "publisher",
+			},
+			p:    nil,
+			want: nil,
+		},
+		{
+			desc: "set all fields",
+			m: &winapps.Metadata{
+				Publisher:       "publisher",
+				InstallDate:     "20250101",
+				InstallLocation: `C:\Program Files\App`,
+				UninstallString: `MsiExec.exe /X{GUID}`,
+				InstallerType:   winapps.InstallerMSI,
+				RegistryKey:     "{GUID}",
+				Wow64:           true,
+			},
+			p: &pb.Package{Name: "some-package"},
+			want: &pb.Package{
+				Name: "some-package",
+				Metadata: &pb.Package_WindowsAppMetadata{
+					WindowsAppMetadata: &pb.WindowsAppMetadata{
+						Publisher:       "publisher",
+						InstallDate:     "20250101",
+						InstallLocation: `C:\Program Files\App`,
+						UninstallString: `MsiExec.exe /X{GUID}`,
+						InstallerType:   "msi",
+						RegistryKey:     "{GUID}",
+						Wow64:           true,
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			p := proto.Clone(tc.p).(*pb.Package)
+			tc.m.SetProto(p)
+			opts := []cmp.Option{
+				protocmp.Transform(),
+			}
+			if diff := cmp.Diff(tc.want, p, opts...); diff != "" {
+				t.Errorf("Metatadata{%+v}.SetProto(%+v): (-want +got):\n%s", tc.m, tc.p, diff)
+			}
+
+			// Test the reverse conversion for completeness.
+
+			if tc.p == nil && tc.want == nil {
+				return
+			}
+
+			got := winapps.ToStruct(p.GetWindowsAppMetadata())
+			if diff := cmp.Diff(tc.m, got); diff != "" {
+				t.Errorf("ToStruct(%+v): (-want +got):\n%s", p.GetWindowsAppMetadata(), diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,283 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package winapps extracts MSI and EXE installed applications from the Uninstall keys of offline
+// Windows registry hives, e.g. from a mounted Windows disk image. Unlike the windows/ospackages
+// standalone extractor, it parses the hive files directly and thus also runs on non-Windows hosts.
+package winapps
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/google/osv-scalibr/common/windows/registry"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/plugin"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scalibr/stats"
+)
+
+const (
+	// Name is the unique name of this extractor.
+	Name = "os/winapps"
+
+	// softwareHivePath is the location of the SOFTWARE hive relative to the system drive.
+	softwareHivePath = "windows/system32/config/software"
+	// userHiveName is the name of the per-user NTUSER.DAT hive in the user's profile directory.
+	userHiveName = "ntuser.dat"
+
+	// Uninstall key locations inside the SOFTWARE hive. Note that offline hives are not mounted
+	// under HKLM, so the paths are relative to the hive root.
+	regUninstallRootDefault = `Microsoft\Windows\CurrentVersion\Uninstall`
+	regUninstallRootWow64   = `Wow6432Node\Microsoft\Windows\CurrentVersion\Uninstall`
+	// Uninstall key location inside NTUSER.DAT hives.
+	regUninstallRootUser = `Software\Microsoft\Windows\CurrentVersion\Uninstall`
+
+	// googetPrefix identifies GooGet packages.
+	googetPrefix = "GooGet -"
+)
+
+// Config is the configuration for the Extractor.
+type Config struct {
+	// Stats is a stats collector for reporting metrics.
+	Stats stats.Collector
+	// MaxFileSizeBytes is the maximum hive size this extractor will parse. If
+	// `FileRequired` gets a bigger file, it will return false. If 0, no limit is applied.
+	MaxFileSizeBytes int64
+}
+
+// DefaultConfig returns the default configuration for the Windows applications extractor.
+func DefaultConfig() Config {
+	return Config{
+		Stats:            nil,
+		MaxFileSizeBytes: 0,
+	}
+}
+
+// Extractor extracts Windows applications from offline registry hives.
+type Extractor struct {
+	stats            stats.Collector
+	maxFileSizeBytes int64
+}
+
+// New returns a Windows applications extractor.
+//
+// For most use cases, initialize with:
+// ```
+// e := New(DefaultConfig())
+// ```
+func New(cfg Config) *Extractor {
+	return &Extractor{
+		stats:            cfg.Stats,
+		maxFileSizeBytes: cfg.MaxFileSizeBytes,
+	}
+}
+
+// NewDefault returns an extractor with the default config settings.
+func NewDefault() filesystem.Extractor { return New(DefaultConfig()) }
+
+// Config returns the configuration of the extractor.
+func (e Extractor) Config() Config {
+	return Config{
+		Stats:            e.stats,
+		MaxFileSizeBytes: e.maxFileSizeBytes,
+	}
+}
+
+// Name of the extractor.
+func (e Extractor) Name() string { return Name }
+
+// Version of the extractor.
+func (e Extractor) Version() int { return 0 }
+
+// Requirements of the extractor.
+func (e Extractor) Requirements() *plugin.Capabilities { return &plugin.Capabilities{} }
+
+// FileRequired returns true if the specified file is a SOFTWARE or NTUSER.DAT registry hive.
+func (e Extractor) FileRequired(api filesystem.FileAPI) bool {
+	p := api.Path()
+	if !isSoftwareHive(p) && !isUserHive(p) {
+		return false
+	}
+
+	fileinfo, err := api.Stat()
+	if err != nil {
+		return false
+	}
+	if e.maxFileSizeBytes > 0 && fileinfo.Size() > e.maxFileSizeBytes {
+		e.reportFileRequired(p, fileinfo.Size(), stats.FileRequiredResultSizeLimitExceeded)
+		return false
+	}
+
+	e.reportFileRequired(p, fileinfo.Size(), stats.FileRequiredResultOK)
+	return true
+}
+
+func isSoftwareHive(p string) bool {
+	return strings.ToLower(p) == softwareHivePath
+}
+
+// isUserHive returns true for Users/<user>/NTUSER.DAT files.
+func isUserHive(p string) bool {
+	parts := strings.Split(strings.ToLower(p), "/")
+	return len(parts) == 3 && parts[0] == "users" && parts[2] == userHiveName
+}
+
+func (e Extractor) reportFileRequired(path string, fileSizeBytes int64, result stats.FileRequiredResult) {
+	if e.stats == nil {
+		return
+	}
+	e.stats.AfterFileRequired(e.Name(), &stats.FileRequiredStats{
+		Path:          path,
+		Result:        result,
+		FileSizeBytes: fileSizeBytes,
+	})
+}
+
+// Extract extracts the installed applications from the registry hive passed through the scan input.
+func (e Extractor) Extract(ctx context.Context, input *filesystem.ScanInput) (inventory.Inventory, error) {
+	pkgs, err := e.extractFromInput(input)
+	if e.stats != nil {
+		var fileSizeBytes int64
+		if input.Info != nil {
+			fileSizeBytes = input.Info.Size()
+		}
+		e.stats.AfterFileExtracted(e.Name(), &stats.FileExtractedStats{
+			Path:          input.Path,
+			Result:        filesystem.ExtractorErrorToFileExtractedResult(err),
+			FileSizeBytes: fileSizeBytes,
+		})
+	}
+	if err != nil {
+		return inventory.Inventory{}, fmt.Errorf("%s halted at %q: %w", e.Name(), input.Path, err)
+	}
+	return inventory.Inventory{Packages: pkgs}, nil
+}
+
+func (e Extractor) extractFromInput(input *filesystem.ScanInput) ([]*extractor.Package, error) {
+	var readerAt io.ReaderAt
+	if r, ok := input.Reader.(io.ReaderAt); ok {
+		readerAt = r
+	} else {
+		content, err := io.ReadAll(input.Reader)
+		if err != nil {
+			return nil, err
+		}
+		readerAt = bytes.NewReader(content)
+	}
+
+	reg, err := registry.NewOfflineRegistry(readerAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse registry hive: %w", err)
+	}
+	defer reg.Close()
+
+	return extractFromRegistry(reg, input.Path), nil
+}
+
+type uninstallRoot struct {
+	path  string
+	wow64 bool
+}
+
+var (
+	softwareHiveRoots = []uninstallRoot{
+		{path: regUninstallRootDefault},
+		{path: regUninstallRootWow64, wow64: true},
+	}
+	userHiveRoots = []uninstallRoot{
+		{path: regUninstallRootUser},
+	}
+)
+
+// extractFromRegistry returns the applications found in the Uninstall keys of the given hive.
+// Missing Uninstall keys are not treated as errors since not all hives contain them.
+func extractFromRegistry(reg registry.Registry, hivePath string) []*extractor.Package {
+	roots := userHiveRoots
+	if isSoftwareHive(hivePath) {
+		roots = softwareHiveRoots
+	}
+
+	var pkgs []*extractor.Package
+	for _, root := range roots {
+		key, err := reg.OpenKey("", root.path)
+		if err != nil {
+			continue
+		}
+		subkeys, err := key.Subkeys()
+		if err != nil {
+			key.Close()
+			continue
+		}
+		for _, subkey := range subkeys {
+			// Silently skip entries without name or version, e.g. system components or updates.
+			if pkg := appInfo(subkey, root.wow64, hivePath); pkg != nil {
+				pkgs = append(pkgs, pkg)
+			}
+			subkey.Close()
+		}
+		key.Close()
+	}
+	return pkgs
+}
+
+func appInfo(key registry.Key, wow64 bool, hivePath string) *extractor.Package {
+	displayName, err := key.ValueString("DisplayName")
+	if err != nil || displayName == "" {
+		return nil
+	}
+	displayVersion, err := key.ValueString("DisplayVersion")
+	if err != nil || displayVersion == "" {
+		return nil
+	}
+
+	installerType := InstallerEXE
+	// WindowsInstaller is a REG_DWORD which is set to 1 for MSI packages.
+	if v, err := key.ValueString("WindowsInstaller"); err == nil && strings.TrimLeft(v, "0") == "1" {
+		installerType = InstallerMSI
+	}
+
+	purlType := "windows"
+	if strings.HasPrefix(displayName, googetPrefix) {
+		purlType = purl.TypeGooget
+	}
+	return &extractor.Package{
+		Name:     displayName,
+		Version:  displayVersion,
+		PURLType: purlType,
+		Metadata: &Metadata{
+			Publisher:       valueOrEmpty(key, "Publisher"),
+			InstallDate:     valueOrEmpty(key, "InstallDate"),
+			InstallLocation: valueOrEmpty(key, "InstallLocation"),
+			UninstallString: valueOrEmpty(key, "UninstallString"),
+			InstallerType:   installerType,
+			RegistryKey:     key.Name(),
+			Wow64:           wow64,
+		},
+		Locations: []string{hivePath},
+	}
+}
+
+func valueOrEmpty(key registry.Key, name string) string {
+	v, err := key.ValueString(name)
+	if err != nil {
+		return ""
+	}
+	return v
+}
@@ -0,0 +1,262 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package winapps
+
+import (
+	"context"
+	"io/fs"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/osv-scalibr/common/windows/registry"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/extractor/filesystem/simplefileapi"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scalibr/stats"
+	"github.com/google/osv-scalibr/testing/fakefs"
+	"github.com/google/osv-scalibr/testing/mockregistry"
+	"github.com/google/osv-scalibr/testing/testcollector"
+)
+
+func TestFileRequired(t *testing.T) {
+	tests := []struct {
+		name             string
+		path             string
+		fileSizeBytes    int64
+		maxFileSizeBytes int64
+		wantRequired     bool
+		wantResultMetric stats.FileRequiredResult
+	}{
+		{
+			name:             "software_hive",
+			path:             "Windows/System32/config/SOFTWARE",
+			wantRequired:     true,
+			wantResultMetric: stats.FileRequiredResultOK,
+		},
+		{
+			name:             "software_hive_lowercase",
+			path:             "windows/system32/config/software",
+			wantRequired:     true,
+			wantResultMetric: stats.FileRequiredResultOK,
+		},
+		{
+			name:             "user_hive",
+			path:             "Users/john/NTUSER.DAT",
+			wantRequired:     true,
+			wantResultMetric: stats.FileRequiredResultOK,
+		},
+		{
+			name:         "software_hive_log",
+			path:         "Windows/System32/config/SOFTWARE.LOG1",
+			wantRequired: false,
+		},
+		{
+			name:         "system_hive",
+			path:         "Windows/System32/config/SYSTEM",
+			wantRequired: false,
+		},
+		{
+			name:         "nested_user_hive",
+			path:         "Users/john/Documents/NTUSER.DAT",
+			wantRequired: false,
+		},
+		{
+			name:             "hive_not_required_if_file_size>max_file_size",
+			path:             "Windows/System32/config/SOFTWARE",
+			fileSizeBytes:    1000,
+			maxFileSizeBytes: 100,
+			wantRequired:     false,
+			wantResultMetric: stats.FileRequiredResultSizeLimitExceeded,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			collector := testcollector.New()
+			e := New(Config{
+				Stats:            collector,
+				MaxFileSizeBytes: tt.maxFileSizeBytes,
+			})
+
+			fileSizeBytes := tt.fileSizeBytes
+			if fileSizeBytes == 0 {
+				fileSizeBytes = 1000
+			}
+
+			isRequired := e.FileRequired(simplefileapi.New(tt.path, fakefs.FakeFileInfo{
+				FileName: filepath.Base(tt.path),
+				FileMode: fs.ModePerm,
+				FileSize: fileSizeBytes,
+			}))
+			if isRequired != tt.wantRequired {
+				t.Fatalf("FileRequired(%s): got %v, want %v", tt.path, isRequired, tt.wantRequired)
+			}
+
+			gotResultMetric := collector.FileRequiredResult(tt.path)
+			if tt.wantResultMetric != "" && gotResultMetric != tt.wantResultMetric {
+				t.Errorf("FileRequired(%s) recorded result metric %v, want result metric %v", tt.path, gotResultMetric, tt.wantResultMetric)
+			}
+		})
+	}
+}
+
+func TestExtractInvalidHive(t *testing.T) {
+	input := &filesystem.ScanInput{
+		Path:   "Windows/System32/config/SOFTWARE",
+		Reader: strings.NewReader("not a registry hive"),
+	}
+	if _, err := NewDefault().Extract(context.Background(), input); err == nil {
+		t.Errorf("Extract(%s): got nil error, want error", input.Path)
+	}
+}
+
+func uninstallEntry(name string, values map[string]string) registry.Key {
+	key := &mockregistry.MockKey{KName: name}
+	for n, v := range values {
+		key.KValues = append(key.KValues, &mockregistry.MockValue{VName: n, VDataString: v})
+	}
+	return key
+}
+
+func TestExtractFromRegistry(t *testing.T) {
+	msiApp := uninstallEntry("{6BA0D8C4-8E5B-4D2C-9E0A-1B2C3D4E5F60}", map[string]string{
+		"DisplayName":      "Some MSI App",
+		"DisplayVersion":   "1.2.3",
+		"Publisher":        "Some Publisher",
+		"InstallDate":      "20250101",
+		"InstallLocation":  `C:\Program Files\Some MSI App`,
+		"UninstallString":  `MsiExec.exe /X{6BA0D8C4-8E5B-4D2C-9E0A-1B2C3D4E5F60}`,
+		"WindowsInstaller": "1",
+	})
+	exeApp := uninstallEntry("SomeExeApp", map[string]string{
+		"DisplayName":    "Some EXE App",
+		"DisplayVersion": "4.5",
+	})
+	googetApp := uninstallEntry("googet", map[string]string{
+		"DisplayName":    "GooGet - googet",
+		"DisplayVersion": "2.18.3",
+	})
+	noVersion := uninstallEntry("Paint", map[string]string{
+		"DisplayName": "Paint",
+	})
+
+	tests := []struct {
+		name     string
+		hivePath string
+		reg      *mockregistry.MockRegistry
+		want     []*extractor.Package
+	}{
+		{
+			name:     "software_hive_with_wow64",
+			hivePath: "Windows/System32/config/SOFTWARE",
+			reg: &mockregistry.MockRegistry{
+				Keys: map[string]registry.Key{
+					regUninstallRootDefault: &mockregistry.MockKey{
+						KName:    "Uninstall",
+						KSubkeys: []registry.Key{msiApp, noVersion, googetApp},
+					},
+					regUninstallRootWow64: &mockregistry.MockKey{
+						KName:    "Uninstall",
+						KSubkeys: []registry.Key{exeApp},
+					},
+				},
+			},
+			want: []*extractor.Package{
+				{
+					Name:     "Some MSI App",
+					Version:  "1.2.3",
+					PURLType: "windows",
+					Metadata: &Metadata{
+						Publisher:       "Some Publisher",
+						InstallDate:     "20250101",
+						InstallLocation: `C:\Program Files\Some MSI App`,
+						UninstallString: `MsiExec.exe /X{6BA0D8C4-8E5B-4D2C-9E0A-1B2C3D4E5F60}`,
+						InstallerType:   InstallerMSI,
+						RegistryKey:     "{6BA0D8C4-8E5B-4D2C-9E0A-1B2C3D4E5F60}",
+					},
+					Locations: []string{"Windows/System32/config/SOFTWARE"},
+				},
+				{
+					Name:     "GooGet - googet",
+					Version:  "2.18.3",
+					PURLType: purl.TypeGooget,
+					Metadata: &Metadata{
+						InstallerType: InstallerEXE,
+						RegistryKey:   "googet",
+					},
+					Locations: []string{"Windows/System32/config/SOFTWARE"},
+				},
+				{
+					Name:     "Some EXE App",
+					Version:  "4.5",
+					PURLType: "windows",
+					Metadata: &Metadata{
+						InstallerType: InstallerEXE,
+						RegistryKey:   "SomeExeApp",
+						Wow64:         true,
+					},
+					Locations: []string{"Windows/System32/config/SOFTWARE"},
+				},
+			},
+		},
+		{
+			name:     "user_hive",
+			hivePath: "Users/john/NTUSER.DAT",
+			reg: &mockregistry.MockRegistry{
+				Keys: map[string]registry.Key{
+					// Ignored since it's not a SOFTWARE hive.
+					regUninstallRootDefault: &mockregistry.MockKey{
+						KName:    "Uninstall",
+						KSubkeys: []registry.Key{msiApp},
+					},
+					regUninstallRootUser: &mockregistry.MockKey{
+						KName:    "Uninstall",
+						KSubkeys: []registry.Key{exeApp},
+					},
+				},
+			},
+			want: []*extractor.Package{
+				{
+					Name:     "Some EXE App",
+					Version:  "4.5",
+					PURLType: "windows",
+					Metadata: &Metadata{
+						InstallerType: InstallerEXE,
+						RegistryKey:   "SomeExeApp",
+					},
+					Locations: []string{"Users/john/NTUSER.DAT"},
+				},
+			},
+		},
+		{
+			name:     "no_uninstall_keys",
+			hivePath: "Windows/System32/config/SOFTWARE",
+			reg:      &mockregistry.MockRegistry{},
+			want:     nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := extractFromRegistry(tt.reg, tt.hivePath)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("extractFromRegistry(%s) returned unexpected diff (-want +got):\n%s", tt.hivePath, diff)
+			}
+		})
+	}
+}
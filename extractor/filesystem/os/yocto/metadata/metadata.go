@@ -0,0 +1,76 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package metadata defines a metadata struct for Yocto packages.
+package metadata
+
+import (
+	pb "github.com/google/osv-scalibr/binary/proto/scan_result_go_proto"
+)
+
+// Metadata holds parsing information for a package of a Yocto Project image.
+type Metadata struct {
+	PackageName    string
+	PackageVersion string
+	// The recipe the package was built from, e.g. "openssl" for "libssl3".
+	RecipeName   string
+	Architecture string
+	OSID         string
+	OSVersionID  string
+}
+
+// ToDistro extracts the OS distro from the metadata.
+func (m *Metadata) ToDistro() string {
+	if m.OSID == "" || m.OSVersionID == "" {
+		return m.OSID
+	}
+	return m.OSID + "-" + m.OSVersionID
+}
+
+// SetProto sets the YoctoPackageMetadata field in the Package proto.
+func (m *Metadata) SetProto(p *pb.Package) {
+	if m == nil {
+		return
+	}
+	if p == nil {
+		return
+	}
+
+	p.Metadata = &pb.Package_YoctoMetadata{
+		YoctoMetadata: &pb.YoctoPackageMetadata{
+			PackageName:    m.PackageName,
+			PackageVersion: m.PackageVersion,
+			RecipeName:     m.RecipeName,
+			Architecture:   m.Architecture,
+			OsId:           m.OSID,
+			OsVersionId:    m.OSVersionID,
+		},
+	}
+}
+
+// ToStruct converts the YoctoPackageMetadata proto to a Metadata struct.
+func ToStruct(m *pb.YoctoPackageMetadata) *Metadata {
+	if m == nil {
+		return nil
+	}
+
+	return &Metadata{
+		PackageName:    m.GetPackageName(),
+		PackageVersion: m.GetPackageVersion(),
+		RecipeName:     m.GetRecipeName(),
+		Architecture:   m.GetArchitecture(),
+		OSID:           m.GetOsId(),
+		OSVersionID:    m.GetOsVersionId(),
+	}
+}
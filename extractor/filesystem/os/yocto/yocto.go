@@ -0,0 +1,280 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package yocto extracts packages from the manifests of Yocto Project images.
+package yocto
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/extractor/filesystem/internal/units"
+	"github.com/google/osv-scalibr/extractor/filesystem/os/osrelease"
+	yoctometa "github.com/google/osv-scalibr/extractor/filesystem/os/yocto/metadata"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/log"
+	"github.com/google/osv-scalibr/plugin"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scalibr/stats"
+)
+
+const (
+	// Name is the unique name of this extractor.
+	Name = "os/yocto"
+
+	// defaultMaxFileSizeBytes is the maximum file size an extractor will unmarshal.
+	// If Extract gets a bigger file, it will return an error.
+	defaultMaxFileSizeBytes = 100 * units.MiB
+
+	// licenseManifest is the name of the license manifest of an image. It's
+	// installed to /usr/share/common-licenses if COPY_LIC_MANIFEST is set and
+	// written to the deploy/licenses directory of the build.
+	licenseManifest = "license.manifest"
+	// imageManifestSuffix is the suffix of the package manifests written next
+	// to the images in the deploy/images directory of the build.
+	imageManifestSuffix = ".rootfs.manifest"
+)
+
+// Config is the configuration for the Extractor.
+type Config struct {
+	// Stats is a stats collector for reporting metrics.
+	Stats stats.Collector
+	// MaxFileSizeBytes is the maximum file size this extractor will unmarshal. If
+	// `FileRequired` gets a bigger file, it will return false,
+	MaxFileSizeBytes int64
+}
+
+// DefaultConfig returns the default configuration for the Yocto extractor.
+func DefaultConfig() Config {
+	return Config{
+		Stats:            nil,
+		MaxFileSizeBytes: defaultMaxFileSizeBytes,
+	}
+}
+
+// Extractor extracts packages from the license.manifest and *.rootfs.manifest
+// files of Yocto Project images.
+type Extractor struct {
+	stats            stats.Collector
+	maxFileSizeBytes int64
+}
+
+// New returns a Yocto extractor.
+func New(cfg Config) *Extractor {
+	return &Extractor{
+		stats:            cfg.Stats,
+		maxFileSizeBytes: cfg.MaxFileSizeBytes,
+	}
+}
+
+// NewDefault returns an extractor with the default config settings.
+func NewDefault() filesystem.Extractor { return New(DefaultConfig()) }
+
+// Config returns the configuration of the extractor.
+func (e Extractor) Config() Config {
+	return Config{
+		Stats:            e.stats,
+		MaxFileSizeBytes: e.maxFileSizeBytes,
+	}
+}
+
+// Name of the extractor.
+func (e Extractor) Name() string { return Name }
+
+// Version of the extractor.
+func (e Extractor) Version() int { return 0 }
+
+// Requirements of the extractor.
+func (e Extractor) Requirements() *plugin.Capabilities { return &plugin.Capabilities{} }
+
+// FileRequired returns true if the specified file is a license or image manifest.
+func (e Extractor) FileRequired(api filesystem.FileAPI) bool {
+	path := api.Path()
+	if !fileRequired(path) {
+		return false
+	}
+
+	fileinfo, err := api.Stat()
+	if err != nil {
+		return false
+	}
+	if e.maxFileSizeBytes > 0 && fileinfo.Size() > e.maxFileSizeBytes {
+		e.reportFileRequired(path, fileinfo.Size(), stats.FileRequiredResultSizeLimitExceeded)
+		return false
+	}
+
+	e.reportFileRequired(path, fileinfo.Size(), stats.FileRequiredResultOK)
+	return true
+}
+
+func fileRequired(p string) bool {
+	normalized := filepath.ToSlash(p)
+	base := path.Base(normalized)
+	if base == licenseManifest {
+		// Only match the manifests in common-licenses or the deploy/licenses
+		// directory of a build.
+		return strings.Contains(normalized, "licenses/")
+	}
+	return strings.HasSuffix(base, imageManifestSuffix)
+}
+
+func (e Extractor) reportFileRequired(path string, fileSizeBytes int64, result stats.FileRequiredResult) {
+	if e.stats == nil {
+		return
+	}
+	e.stats.AfterFileRequired(e.Name(), &stats.FileRequiredStats{
+		Path:          path,
+		Result:        result,
+		FileSizeBytes: fileSizeBytes,
+	})
+}
+
+// Extract extracts packages from the manifest files passed through the scan input.
+func (e Extractor) Extract(ctx context.Context, input *filesystem.ScanInput) (inventory.Inventory, error) {
+	pkgs, err := e.extractFromInput(ctx, input)
+
+	if e.stats != nil {
+		var fileSizeBytes int64
+		if input.Info != nil {
+			fileSizeBytes = input.Info.Size()
+		}
+		e.stats.AfterFileExtracted(e.Name(), &stats.FileExtractedStats{
+			Path:          input.Path,
+			Result:        filesystem.ExtractorErrorToFileExtractedResult(err),
+			FileSizeBytes: fileSizeBytes,
+		})
+	}
+	return inventory.Inventory{Packages: pkgs}, err
+}
+
+func (e Extractor) extractFromInput(ctx context.Context, input *filesystem.ScanInput) ([]*extractor.Package, error) {
+	// Manifests in the deploy directory of a build are not part of an image
+	// so there's usually no os-release file.
+	m, err := osrelease.GetOSRelease(input.FS)
+	if err != nil {
+		log.Debugf("osrelease.GetOSRelease(): %v", err)
+	}
+
+	var pkgs []*extractor.Package
+	if path.Base(filepath.ToSlash(input.Path)) == licenseManifest {
+		pkgs, err = e.parseLicenseManifest(ctx, input)
+	} else {
+		pkgs, err = e.parseImageManifest(ctx, input)
+	}
+	for _, p := range pkgs {
+		md := p.Metadata.(*yoctometa.Metadata)
+		md.OSID = m["ID"]
+		md.OSVersionID = m["VERSION_ID"]
+	}
+	return pkgs, err
+}
+
+// parseLicenseManifest parses the blocks of "KEY: value" lines of a license
+// manifest, e.g.
+//
+//	PACKAGE NAME: libssl3
+//	PACKAGE VERSION: 3.0.10
+//	RECIPE NAME: openssl
+//	LICENSE: Apache-2.0
+func (e Extractor) parseLicenseManifest(ctx context.Context, input *filesystem.ScanInput) ([]*extractor.Package, error) {
+	pkgs := []*extractor.Package{}
+	fields := map[string]string{}
+	flush := func() {
+		name, version := fields["PACKAGE NAME"], fields["PACKAGE VERSION"]
+		if name != "" && version != "" {
+			p := newPackage(name, version, input.Path)
+			md := p.Metadata.(*yoctometa.Metadata)
+			md.RecipeName = fields["RECIPE NAME"]
+			if l := fields["LICENSE"]; l != "" {
+				p.Licenses = []string{l}
+			}
+			pkgs = append(pkgs, p)
+		} else if len(fields) > 0 {
+			log.Warnf("Yocto license manifest %s has an entry without package name or version", input.Path)
+		}
+		clear(fields)
+	}
+
+	s := bufio.NewScanner(input.Reader)
+	for s.Scan() {
+		// Return if canceled or exceeding deadline.
+		if err := ctx.Err(); err != nil {
+			return pkgs, fmt.Errorf("%s halted due to context error: %w", e.Name(), err)
+		}
+
+		line := strings.TrimSpace(s.Text())
+		if line == "" {
+			flush()
+			continue
+		}
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			return pkgs, fmt.Errorf("%s: invalid license manifest line %q", e.Name(), line)
+		}
+		fields[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+	if err := s.Err(); err != nil {
+		return pkgs, fmt.Errorf("%s halted: %w", e.Name(), err)
+	}
+	flush()
+	return pkgs, nil
+}
+
+// parseImageManifest parses the "name architecture version" lines of an
+// image manifest, e.g. "libssl3 core2_64 3.0.10-r0".
+func (e Extractor) parseImageManifest(ctx context.Context, input *filesystem.ScanInput) ([]*extractor.Package, error) {
+	pkgs := []*extractor.Package{}
+	s := bufio.NewScanner(input.Reader)
+	for s.Scan() {
+		// Return if canceled or exceeding deadline.
+		if err := ctx.Err(); err != nil {
+			return pkgs, fmt.Errorf("%s halted due to context error: %w", e.Name(), err)
+		}
+
+		line := strings.TrimSpace(s.Text())
+		if line == "" {
+			continue
+		}
+		parts := strings.Fields(line)
+		if len(parts) != 3 {
+			return pkgs, fmt.Errorf("%s: invalid image manifest line %q", e.Name(), line)
+		}
+		p := newPackage(parts[0], parts[2], input.Path)
+		p.Metadata.(*yoctometa.Metadata).Architecture = parts[1]
+		pkgs = append(pkgs, p)
+	}
+	if err := s.Err(); err != nil {
+		return pkgs, fmt.Errorf("%s halted: %w", e.Name(), err)
+	}
+	return pkgs, nil
+}
+
+func newPackage(name, version, location string) *extractor.Package {
+	return &extractor.Package{
+		Name:     name,
+		Version:  version,
+		PURLType: purl.TypeYocto,
+		Metadata: &yoctometa.Metadata{
+			PackageName:    name,
+			PackageVersion: version,
+		},
+		Locations: []string{location},
+	}
+}
@@ -0,0 +1,352 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yocto_test
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/extractor/filesystem/internal/units"
+	"github.com/google/osv-scalibr/extractor/filesystem/os/yocto"
+	yoctometa "github.com/google/osv-scalibr/extractor/filesystem/os/yocto/metadata"
+	"github.com/google/osv-scalibr/extractor/filesystem/simplefileapi"
+	scalibrfs "github.com/google/osv-scalibr/fs"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scalibr/stats"
+	"github.com/google/osv-scalibr/testing/fakefs"
+	"github.com/google/osv-scalibr/testing/testcollector"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     yocto.Config
+		wantCfg yocto.Config
+	}{
+		{
+			name: "default",
+			cfg:  yocto.DefaultConfig(),
+			wantCfg: yocto.Config{
+				MaxFileSizeBytes: 100 * units.MiB,
+			},
+		},
+		{
+			name: "custom",
+			cfg: yocto.Config{
+				MaxFileSizeBytes: 10,
+			},
+			wantCfg: yocto.Config{
+				MaxFileSizeBytes: 10,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := yocto.New(tt.cfg)
+			if !reflect.DeepEqual(got.Config(), tt.wantCfg) {
+				t.Errorf("New(%+v).Config(): got %+v, want %+v", tt.cfg, got.Config(), tt.wantCfg)
+			}
+		})
+	}
+}
+
+func TestFileRequired(t *testing.T) {
+	tests := []struct {
+		name             string
+		path             string
+		fileSizeBytes    int64
+		maxFileSizeBytes int64
+		wantRequired     bool
+		wantResultMetric stats.FileRequiredResult
+	}{
+		{
+			name:             "license manifest in image",
+			path:             "usr/share/common-licenses/license.manifest",
+			wantRequired:     true,
+			wantResultMetric: stats.FileRequiredResultOK,
+		},
+		{
+			name:             "license manifest in build directory",
+			path:             "build/tmp/deploy/licenses/core-image-minimal-qemux86-64-20230818120914/license.manifest",
+			wantRequired:     true,
+			wantResultMetric: stats.FileRequiredResultOK,
+		},
+		{
+			name:             "image manifest",
+			path:             "build/tmp/deploy/images/qemux86-64/core-image-minimal-qemux86-64.rootfs.manifest",
+			wantRequired:     true,
+			wantResultMetric: stats.FileRequiredResultOK,
+		},
+		{
+			name:             "file not required if file size > max file size",
+			path:             "usr/share/common-licenses/license.manifest",
+			fileSizeBytes:    1000 * units.KiB,
+			maxFileSizeBytes: 100 * units.KiB,
+			wantRequired:     false,
+			wantResultMetric: stats.FileRequiredResultSizeLimitExceeded,
+		},
+		{
+			name:         "license manifest outside of licenses directory",
+			path:         "app/license.manifest",
+			wantRequired: false,
+		},
+		{
+			name:         "other manifest",
+			path:         "app/app.exe.manifest",
+			wantRequired: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			collector := testcollector.New()
+			var e filesystem.Extractor = yocto.New(yocto.Config{
+				Stats:            collector,
+				MaxFileSizeBytes: tt.maxFileSizeBytes,
+			})
+
+			fileSizeBytes := tt.fileSizeBytes
+			if fileSizeBytes == 0 {
+				fileSizeBytes = 1000
+			}
+
+			isRequired := e.FileRequired(simplefileapi.New(tt.path, fakefs.FakeFileInfo{
+				FileName: filepath.Base(tt.path),
+				FileMode: fs.ModePerm,
+				FileSize: fileSizeBytes,
+			}))
+			if isRequired != tt.wantRequired {
+				t.Fatalf("FileRequired(%s): got %v, want %v", tt.path, isRequired, tt.wantRequired)
+			}
+
+			gotResultMetric := collector.FileRequiredResult(tt.path)
+			if tt.wantResultMetric != "" && gotResultMetric != tt.wantResultMetric {
+				t.Errorf("FileRequired(%s) recorded result metric %v, want result metric %v", tt.path, gotResultMetric, tt.wantResultMetric)
+			}
+		})
+	}
+}
+
+const PokyKirkstone = `ID=poky
+NAME="Poky (Yocto Project Reference Distro)"
+VERSION="4.0.12 (kirkstone)"
+VERSION_ID=4.0.12
+PRETTY_NAME="Poky (Yocto Project Reference Distro) 4.0.12 (kirkstone)"
+DISTRO_CODENAME="kirkstone"
+`
+
+func TestExtract(t *testing.T) {
+	const imageManifest = "testdata/core-image-minimal-qemux86-64.rootfs.manifest"
+	tests := []struct {
+		name             string
+		path             string
+		osrelease        string
+		wantPackages     []*extractor.Package
+		wantErr          error
+		wantResultMetric stats.FileExtractedResult
+	}{
+		{
+			name:      "license manifest",
+			path:      "testdata/license.manifest",
+			osrelease: PokyKirkstone,
+			wantPackages: []*extractor.Package{
+				{
+					Name:     "base-files",
+					Version:  "3.0.14",
+					PURLType: purl.TypeYocto,
+					Metadata: &yoctometa.Metadata{
+						PackageName:    "base-files",
+						PackageVersion: "3.0.14",
+						RecipeName:     "base-files",
+						OSID:           "poky",
+						OSVersionID:    "4.0.12",
+					},
+					Licenses:  []string{"GPL-2.0-only"},
+					Locations: []string{"testdata/license.manifest"},
+				},
+				{
+					Name:     "busybox",
+					Version:  "1.35.0",
+					PURLType: purl.TypeYocto,
+					Metadata: &yoctometa.Metadata{
+						PackageName:    "busybox",
+						PackageVersion: "1.35.0",
+						RecipeName:     "busybox",
+						OSID:           "poky",
+						OSVersionID:    "4.0.12",
+					},
+					Licenses:  []string{"GPL-2.0-only & bzip2-1.0.4"},
+					Locations: []string{"testdata/license.manifest"},
+				},
+				{
+					Name:     "libssl3",
+					Version:  "3.0.10",
+					PURLType: purl.TypeYocto,
+					Metadata: &yoctometa.Metadata{
+						PackageName:    "libssl3",
+						PackageVersion: "3.0.10",
+						RecipeName:     "openssl",
+						OSID:           "poky",
+						OSVersionID:    "4.0.12",
+					},
+					Licenses:  []string{"Apache-2.0"},
+					Locations: []string{"testdata/license.manifest"},
+				},
+			},
+			wantResultMetric: stats.FileExtractedResultSuccess,
+		},
+		{
+			name: "image manifest without os-release",
+			path: imageManifest,
+			wantPackages: []*extractor.Package{
+				{
+					Name:     "base-files",
+					Version:  "3.0.14-r0",
+					PURLType: purl.TypeYocto,
+					Metadata: &yoctometa.Metadata{
+						PackageName:    "base-files",
+						PackageVersion: "3.0.14-r0",
+						Architecture:   "qemux86_64",
+					},
+					Locations: []string{imageManifest},
+				},
+				{
+					Name:     "busybox",
+					Version:  "1.35.0-r0",
+					PURLType: purl.TypeYocto,
+					Metadata: &yoctometa.Metadata{
+						PackageName:    "busybox",
+						PackageVersion: "1.35.0-r0",
+						Architecture:   "core2_64",
+					},
+					Locations: []string{imageManifest},
+				},
+				{
+					Name:     "libssl3",
+					Version:  "3.0.10-r0",
+					PURLType: purl.TypeYocto,
+					Metadata: &yoctometa.Metadata{
+						PackageName:    "libssl3",
+						PackageVersion: "3.0.10-r0",
+						Architecture:   "core2_64",
+					},
+					Locations: []string{imageManifest},
+				},
+			},
+			wantResultMetric: stats.FileExtractedResultSuccess,
+		},
+		{
+			name:      "entries without version are skipped",
+			path:      "testdata/noversion/license.manifest",
+			osrelease: PokyKirkstone,
+			wantPackages: []*extractor.Package{
+				{
+					Name:     "libssl3",
+					Version:  "3.0.10",
+					PURLType: purl.TypeYocto,
+					Metadata: &yoctometa.Metadata{
+						PackageName:    "libssl3",
+						PackageVersion: "3.0.10",
+						RecipeName:     "openssl",
+						OSID:           "poky",
+						OSVersionID:    "4.0.12",
+					},
+					Locations: []string{"testdata/noversion/license.manifest"},
+				},
+			},
+			wantResultMetric: stats.FileExtractedResultSuccess,
+		},
+		{
+			name:             "invalid license manifest",
+			path:             "testdata/invalid/license.manifest",
+			osrelease:        PokyKirkstone,
+			wantPackages:     []*extractor.Package{},
+			wantErr:          cmpopts.AnyError,
+			wantResultMetric: stats.FileExtractedResultErrorUnknown,
+		},
+		{
+			name:             "invalid image manifest",
+			path:             "testdata/invalid/core-image-minimal-qemux86-64.rootfs.manifest",
+			osrelease:        PokyKirkstone,
+			wantPackages:     []*extractor.Package{},
+			wantErr:          cmpopts.AnyError,
+			wantResultMetric: stats.FileExtractedResultErrorUnknown,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			collector := testcollector.New()
+			var e filesystem.Extractor = yocto.New(yocto.Config{
+				Stats:            collector,
+				MaxFileSizeBytes: 100,
+			})
+
+			d := t.TempDir()
+			if tt.osrelease != "" {
+				createOsRelease(t, d, tt.osrelease)
+			}
+
+			r, err := os.Open(tt.path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer r.Close()
+
+			info, err := os.Stat(tt.path)
+			if err != nil {
+				t.Fatalf("Failed to stat test file: %v", err)
+			}
+
+			input := &filesystem.ScanInput{
+				FS: scalibrfs.DirFS(d), Path: tt.path, Reader: r, Root: d, Info: info,
+			}
+
+			got, err := e.Extract(context.Background(), input)
+			if !cmp.Equal(err, tt.wantErr, cmpopts.EquateErrors()) {
+				t.Fatalf("Extract(%s) error: got %v, want %v", tt.path, err, tt.wantErr)
+			}
+
+			wantInv := inventory.Inventory{Packages: tt.wantPackages}
+			if diff := cmp.Diff(wantInv, got); diff != "" {
+				t.Errorf("Extract(%s) (-want +got):\n%s", tt.path, diff)
+			}
+
+			gotResultMetric := collector.FileExtractedResult(tt.path)
+			if gotResultMetric != tt.wantResultMetric {
+				t.Errorf("Extract(%s) recorded result metric %v, want result metric %v", tt.path, gotResultMetric, tt.wantResultMetric)
+			}
+		})
+	}
+}
+
+func createOsRelease(t *testing.T, root string, content string) {
+	t.Helper()
+	_ = os.MkdirAll(filepath.Join(root, "etc"), 0755)
+	err := os.WriteFile(filepath.Join(root, "etc/os-release"), []byte(content), 0644)
+	if err != nil {
+		t.Fatalf("write to %s: %v\n", filepath.Join(root, "etc/os-release"), err)
+	}
+}
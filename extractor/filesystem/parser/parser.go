@@ -0,0 +1,152 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package parser runs the parsers of filesystem extractors on file
+// contents read from an io.Reader, so that other programs can reuse them
+// without setting up a scan root or a filesystem.
+package parser
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"slices"
+	"testing/fstest"
+	"time"
+
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/extractor/filesystem/list"
+	"github.com/google/osv-scalibr/fs/memfs"
+	"github.com/google/osv-scalibr/plugin"
+)
+
+// ErrUnsupportedFile is returned by ParseLockfile if no extractor supports the
+// file.
+var ErrUnsupportedFile = errors.New("no extractor supports the file")
+
+// Parse parses the file contents read from r with the given extractor, as if
+// the file was found at the slash-separated filePath during a scan. filePath is
+// used as the location of the returned packages and by extractors that support
+// several file formats to pick the parser.
+//
+// The extractor doesn't see any other files, so e.g. parent POMs or go.sum
+// files next to the parsed file aren't taken into account.
+func Parse(ctx context.Context, ex filesystem.Extractor, filePath string, r io.Reader) ([]*extractor.Package, error) {
+	inv, err := ex.Extract(ctx, &filesystem.ScanInput{
+		// An empty filesystem so that extractors which look for other files
+		// get "not found" errors.
+		FS:     memfs.New(fstest.MapFS{}),
+		Path:   filePath,
+		Reader: r,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", ex.Name(), err)
+	}
+	return inv.Packages, nil
+}
+
+// ParseWithName parses the file contents read from r with the extractor of
+// the given name, e.g. "javascript/packagelockjson". See Parse for details.
+func ParseWithName(ctx context.Context, name string, filePath string, r io.Reader) ([]*extractor.Package, error) {
+	exs, err := list.ExtractorsFromName(name)
+	if err != nil {
+		return nil, err
+	}
+	if len(exs) != 1 {
+		return nil, fmt.Errorf("%q is a group of %d extractors, want a single extractor", name, len(exs))
+	}
+	return Parse(ctx, exs[0], filePath, r)
+}
+
+// ParseLockfile parses the file contents read from r with the offline source
+// code extractors that support files at filePath, e.g. the package-lock.json
+// extractor for "app/package-lock.json". ErrUnsupportedFile is returned if no
+// extractor supports the path. See Parse for details.
+func ParseLockfile(ctx context.Context, filePath string, r io.Reader) ([]*extractor.Package, error) {
+	exs := LockfileExtractors(filePath)
+	if len(exs) == 0 {
+		return nil, fmt.Errorf("%s: %w", filePath, ErrUnsupportedFile)
+	}
+	if len(exs) == 1 {
+		return Parse(ctx, exs[0], filePath, r)
+	}
+
+	// Several extractors read the same file so it's buffered once.
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	var pkgs []*extractor.Package
+	for _, ex := range exs {
+		p, err := Parse(ctx, ex, filePath, bytes.NewReader(content))
+		if err != nil {
+			return nil, err
+		}
+		pkgs = append(pkgs, p...)
+	}
+	return pkgs, nil
+}
+
+// LockfileExtractors returns the offline source code extractors that support
+// files at filePath, sorted by name.
+func LockfileExtractors(filePath string) []filesystem.Extractor {
+	api := &fileAPI{path: filePath}
+	var result []filesystem.Extractor
+	for name, initers := range list.SourceCode {
+		if _, ok := list.Secrets[name]; ok {
+			continue
+		}
+		for _, init := range initers {
+			ex := init()
+			req := ex.Requirements()
+			if req.Network == plugin.NetworkOnline || req.ExtractFromDirs {
+				continue
+			}
+			if ex.FileRequired(api) {
+				result = append(result, ex)
+			}
+		}
+	}
+	slices.SortFunc(result, func(a, b filesystem.Extractor) int {
+		return cmp.Compare(a.Name(), b.Name())
+	})
+	return result
+}
+
+// fileAPI describes a regular file of unknown size at the given path to the
+// extractors' FileRequired.
+type fileAPI struct {
+	path string
+}
+
+func (f *fileAPI) Path() string { return f.path }
+
+func (f *fileAPI) Stat() (fs.FileInfo, error) { return fileInfo{name: path.Base(f.path)}, nil }
+
+type fileInfo struct {
+	name string
+}
+
+func (i fileInfo) Name() string       { return i.name }
+func (i fileInfo) Size() int64        { return 0 }
+func (i fileInfo) Mode() fs.FileMode  { return 0444 }
+func (i fileInfo) ModTime() time.Time { return time.Time{} }
+func (i fileInfo) IsDir() bool        { return false }
+func (i fileInfo) Sys() any           { return nil }
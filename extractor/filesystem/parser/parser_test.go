@@ -0,0 +1,181 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parser_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem/language/rust/cargolock"
+	"github.com/google/osv-scalibr/extractor/filesystem/parser"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scalibr/testing/extracttest"
+)
+
+const cargoLock = `
+version = 3
+
+[[package]]
+name = "addr2line"
+version = "0.15.2"
+source = "registry+https://github.com/rust-lang/crates.io-index"
+
+[[package]]
+name = "libc"
+version = "0.2.155"
+source = "registry+https://github.com/rust-lang/crates.io-index"
+`
+
+var cargoPackages = []*extractor.Package{
+	{
+		Name:      "addr2line",
+		Version:   "0.15.2",
+		PURLType:  purl.TypeCargo,
+		Locations: []string{"app/Cargo.lock"},
+	},
+	{
+		Name:      "libc",
+		Version:   "0.2.155",
+		PURLType:  purl.TypeCargo,
+		Locations: []string{"app/Cargo.lock"},
+	},
+}
+
+func TestParse(t *testing.T) {
+	got, err := parser.Parse(context.Background(), cargolock.New(), "app/Cargo.lock", strings.NewReader(cargoLock))
+	if err != nil {
+		t.Fatalf("Parse(): %v", err)
+	}
+	if diff := cmp.Diff(cargoPackages, got, cmpopts.SortSlices(extracttest.PackageCmpLess)); diff != "" {
+		t.Errorf("Parse() diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestParse_Error(t *testing.T) {
+	_, err := parser.Parse(context.Background(), cargolock.New(), "Cargo.lock", strings.NewReader("[[package"))
+	if err == nil {
+		t.Fatal("Parse() of an invalid Cargo.lock succeeded, want error")
+	}
+	if !strings.Contains(err.Error(), cargolock.Name) {
+		t.Errorf("Parse() error %q doesn't name the extractor %q", err, cargolock.Name)
+	}
+}
+
+func TestParseWithName(t *testing.T) {
+	tests := []struct {
+		desc    string
+		name    string
+		want    []*extractor.Package
+		wantErr bool
+	}{
+		{
+			desc: "extractor",
+			name: cargolock.Name,
+			want: cargoPackages,
+		},
+		{
+			desc:    "group_of_extractors",
+			name:    "rust",
+			wantErr: true,
+		},
+		{
+			desc:    "unknown_extractor",
+			name:    "rust/unknown",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := parser.ParseWithName(context.Background(), tt.name, "app/Cargo.lock", strings.NewReader(cargoLock))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseWithName(%q) error: %v, want error: %t", tt.name, err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got, cmpopts.SortSlices(extracttest.PackageCmpLess)); diff != "" {
+				t.Errorf("ParseWithName(%q) diff (-want +got):\n%s", tt.name, diff)
+			}
+		})
+	}
+}
+
+func TestParseLockfile(t *testing.T) {
+	got, err := parser.ParseLockfile(context.Background(), "app/Cargo.lock", strings.NewReader(cargoLock))
+	if err != nil {
+		t.Fatalf("ParseLockfile(): %v", err)
+	}
+	if diff := cmp.Diff(cargoPackages, got, cmpopts.SortSlices(extracttest.PackageCmpLess)); diff != "" {
+		t.Errorf("ParseLockfile() diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestParseLockfile_SeveralExtractors(t *testing.T) {
+	mixLock := `%{
+  "bunt": {:hex, :bunt, "1.0.0", "081c2c665f086849e6d57900292b3a161727ab40431219529f13c4ddcf3e7a44", [:mix], [], "hexpm", "dc5f86aa08a5f6fa6b8096f0735c4e76d54ae5c9fa2c143e5a1fc7c1cd9bb6b5"},
+}
+`
+	got, err := parser.ParseLockfile(context.Background(), "mix.lock", strings.NewReader(mixLock))
+	if err != nil {
+		t.Fatalf("ParseLockfile(): %v", err)
+	}
+	// Both the Elixir and the Erlang extractor parse mix.lock files.
+	pkg := &extractor.Package{
+		Name:       "bunt",
+		Version:    "1.0.0",
+		PURLType:   purl.TypeHex,
+		Locations:  []string{"mix.lock"},
+		SourceCode: &extractor.SourceCodeIdentifier{Commit: "081c2c665f086849e6d57900292b3a161727ab40431219529f13c4ddcf3e7a44"},
+	}
+	want := []*extractor.Package{pkg, pkg}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ParseLockfile() diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestParseLockfile_Unsupported(t *testing.T) {
+	_, err := parser.ParseLockfile(context.Background(), "README.md", strings.NewReader("# README"))
+	if !errors.Is(err, parser.ErrUnsupportedFile) {
+		t.Errorf("ParseLockfile(README.md) error: %v, want %v", err, parser.ErrUnsupportedFile)
+	}
+}
+
+func TestLockfileExtractors(t *testing.T) {
+	tests := []struct {
+		path string
+		want []string
+	}{
+		{path: "Cargo.lock", want: []string{"rust/cargolock"}},
+		{path: "web/package-lock.json", want: []string{"javascript/packagelockjson"}},
+		{path: "requirements.txt", want: []string{"python/requirements"}},
+		{path: "Gemfile.lock", want: []string{"ruby/gemfilelock"}},
+		{path: "README.md", want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			var got []string
+			for _, ex := range parser.LockfileExtractors(tt.path) {
+				got = append(got, ex.Name())
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("LockfileExtractors(%q) diff (-want +got):\n%s", tt.path, diff)
+			}
+		})
+	}
+}
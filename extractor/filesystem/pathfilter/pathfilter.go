@@ -0,0 +1,181 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package pathfilter implements ordered include and exclude rules that select
+// the files and directories visited by the filesystem walk.
+package pathfilter
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/gobwas/glob"
+	"gopkg.in/yaml.v3"
+)
+
+// Action is what happens to the paths matched by a rule.
+type Action string
+
+const (
+	// Include keeps the matched paths in the scan.
+	Include Action = "include"
+	// Exclude removes the matched paths from the scan.
+	Exclude Action = "exclude"
+)
+
+// Rule matches paths relative to the scan root, e.g. "app/node_modules", with
+// either a glob or a regex.
+type Rule struct {
+	Action Action `yaml:"action"`
+	// Glob is a doublestar glob: "*" matches within a path segment and "**"
+	// across segments. "**/" also matches zero directories and a trailing "/**"
+	// also matches the directory itself.
+	Glob string `yaml:"glob,omitempty"`
+	// Regex is matched unanchored against the path.
+	Regex string `yaml:"regex,omitempty"`
+}
+
+// Config is the format of path filter config files.
+type Config struct {
+	Rules []Rule `yaml:"rules"`
+}
+
+type compiledRule struct {
+	action Action
+	globs  []glob.Glob
+	re     *regexp.Regexp
+}
+
+func (r *compiledRule) match(path string) bool {
+	if r.re != nil {
+		return r.re.MatchString(path)
+	}
+	for _, g := range r.globs {
+		if g.Match(path) {
+			return true
+		}
+	}
+	return false
+}
+
+// Filter decides which paths are excluded from the scan. The first rule that
+// matches a path decides about it, paths that match no rule are included.
+// Excluded directories aren't descended into, so files below them can't be
+// included again by later rules.
+type Filter struct {
+	rules []*compiledRule
+}
+
+// New compiles the given rules into a filter.
+func New(rules []Rule) (*Filter, error) {
+	f := &Filter{}
+	for i, r := range rules {
+		c, err := compile(r)
+		if err != nil {
+			return nil, fmt.Errorf("rule %d: %w", i+1, err)
+		}
+		f.rules = append(f.rules, c)
+	}
+	return f, nil
+}
+
+// LoadFile reads the rules of a YAML path filter config file, e.g.
+//
+//	rules:
+//	  - action: exclude
+//	    glob: "**/node_modules"
+//	  - action: include
+//	    glob: "**/*.lock"
+//	  - action: exclude
+//	    regex: "^testdata/"
+//
+// skips all node_modules directories and the files in testdata except for
+// lockfiles.
+func LoadFile(path string) (*Filter, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var cfg Config
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	f, err := New(cfg.Rules)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return f, nil
+}
+
+func compile(r Rule) (*compiledRule, error) {
+	if r.Action != Include && r.Action != Exclude {
+		return nil, fmt.Errorf("action %q: expected %q or %q", r.Action, Include, Exclude)
+	}
+	if (r.Glob == "") == (r.Regex == "") {
+		return nil, errors.New("exactly one of glob and regex needs to be set")
+	}
+	c := &compiledRule{action: r.Action}
+	if r.Regex != "" {
+		re, err := regexp.Compile(r.Regex)
+		if err != nil {
+			return nil, err
+		}
+		c.re = re
+		return c, nil
+	}
+	for _, p := range globVariants(strings.TrimPrefix(r.Glob, "/")) {
+		g, err := glob.Compile(p, '/')
+		if err != nil {
+			return nil, fmt.Errorf("glob %q: %w", r.Glob, err)
+		}
+		c.globs = append(c.globs, g)
+	}
+	return c, nil
+}
+
+// globVariants returns the patterns that together implement the doublestar
+// semantics of the glob, which gobwas/glob doesn't have for leading and
+// trailing "**" segments.
+func globVariants(pattern string) []string {
+	variants := []string{pattern}
+	for _, v := range variants {
+		if rest, ok := strings.CutPrefix(v, "**/"); ok {
+			variants = append(variants, rest)
+		}
+	}
+	for _, v := range variants {
+		if rest, ok := strings.CutSuffix(v, "/**"); ok && rest != "" {
+			variants = append(variants, rest)
+		}
+	}
+	return variants
+}
+
+// Excluded returns whether the file or directory at the given path, relative
+// to the scan root, is excluded from the scan. The scan root itself is never
+// excluded. A nil filter excludes nothing.
+func (f *Filter) Excluded(path string) bool {
+	if f == nil || path == "." || path == "" {
+		return false
+	}
+	for _, r := range f.rules {
+		if r.match(path) {
+			return r.action == Exclude
+		}
+	}
+	return false
+}
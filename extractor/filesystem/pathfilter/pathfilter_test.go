@@ -0,0 +1,164 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pathfilter_test
+
+import (
+	"testing"
+
+	"github.com/google/osv-scalibr/extractor/filesystem/pathfilter"
+)
+
+func TestExcluded(t *testing.T) {
+	testCases := []struct {
+		desc  string
+		rules []pathfilter.Rule
+		path  string
+		want  bool
+	}{
+		{
+			desc: "no_rules",
+			path: "a/b",
+			want: false,
+		},
+		{
+			desc:  "exclude_glob",
+			rules: []pathfilter.Rule{{Action: pathfilter.Exclude, Glob: "build/*.o"}},
+			path:  "build/main.o",
+			want:  true,
+		},
+		{
+			desc:  "star_does_not_cross_segments",
+			rules: []pathfilter.Rule{{Action: pathfilter.Exclude, Glob: "build/*.o"}},
+			path:  "build/sub/main.o",
+			want:  false,
+		},
+		{
+			desc:  "leading_doublestar_matches_zero_dirs",
+			rules: []pathfilter.Rule{{Action: pathfilter.Exclude, Glob: "**/node_modules"}},
+			path:  "node_modules",
+			want:  true,
+		},
+		{
+			desc:  "leading_doublestar_matches_nested_dirs",
+			rules: []pathfilter.Rule{{Action: pathfilter.Exclude, Glob: "**/node_modules"}},
+			path:  "a/b/node_modules",
+			want:  true,
+		},
+		{
+			desc:  "trailing_doublestar_matches_dir_itself",
+			rules: []pathfilter.Rule{{Action: pathfilter.Exclude, Glob: "vendor/**"}},
+			path:  "vendor",
+			want:  true,
+		},
+		{
+			desc:  "leading_slash_is_ignored",
+			rules: []pathfilter.Rule{{Action: pathfilter.Exclude, Glob: "/tmp"}},
+			path:  "tmp",
+			want:  true,
+		},
+		{
+			desc:  "exclude_regex",
+			rules: []pathfilter.Rule{{Action: pathfilter.Exclude, Regex: `\.min\.js$`}},
+			path:  "static/app.min.js",
+			want:  true,
+		},
+		{
+			desc: "first_matching_rule_wins",
+			rules: []pathfilter.Rule{
+				{Action: pathfilter.Include, Glob: "**/package-lock.json"},
+				{Action: pathfilter.Exclude, Glob: "**"},
+			},
+			path: "web/package-lock.json",
+			want: false,
+		},
+		{
+			desc: "later_rules_are_not_checked",
+			rules: []pathfilter.Rule{
+				{Action: pathfilter.Exclude, Glob: "**"},
+				{Action: pathfilter.Include, Glob: "**/package-lock.json"},
+			},
+			path: "web/package-lock.json",
+			want: true,
+		},
+		{
+			desc:  "root_is_never_excluded",
+			rules: []pathfilter.Rule{{Action: pathfilter.Exclude, Regex: ".*"}},
+			path:  ".",
+			want:  false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			f, err := pathfilter.New(tc.rules)
+			if err != nil {
+				t.Fatalf("New(%v): %v", tc.rules, err)
+			}
+			if got := f.Excluded(tc.path); got != tc.want {
+				t.Errorf("Excluded(%q) = %v, want %v", tc.path, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestNew_InvalidRules(t *testing.T) {
+	testCases := []struct {
+		desc string
+		rule pathfilter.Rule
+	}{
+		{desc: "unknown_action", rule: pathfilter.Rule{Action: "skip", Glob: "a"}},
+		{desc: "no_pattern", rule: pathfilter.Rule{Action: pathfilter.Exclude}},
+		{desc: "glob_and_regex", rule: pathfilter.Rule{Action: pathfilter.Exclude, Glob: "a", Regex: "a"}},
+		{desc: "invalid_regex", rule: pathfilter.Rule{Action: pathfilter.Exclude, Regex: "("}},
+		{desc: "invalid_glob", rule: pathfilter.Rule{Action: pathfilter.Exclude, Glob: "[a"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if _, err := pathfilter.New([]pathfilter.Rule{tc.rule}); err == nil {
+				t.Errorf("New(%v) succeeded, want error", tc.rule)
+			}
+		})
+	}
+}
+
+func TestLoadFile(t *testing.T) {
+	f, err := pathfilter.LoadFile("testdata/filter.yaml")
+	if err != nil {
+		t.Fatalf("LoadFile(): %v", err)
+	}
+	for path, want := range map[string]bool{
+		"app/node_modules":      true,
+		"testdata":              false,
+		"testdata/Cargo.lock":   false,
+		"testdata/package.json": true,
+		"src/testdata/main.go":  false,
+	} {
+		if got := f.Excluded(path); got != want {
+			t.Errorf("Excluded(%q) = %v, want %v", path, got, want)
+		}
+	}
+
+	if _, err := pathfilter.LoadFile("testdata/invalid.yaml"); err == nil {
+		t.Error("LoadFile(invalid.yaml) succeeded, want error")
+	}
+}
+
+func TestExcluded_NilFilter(t *testing.T) {
+	var f *pathfilter.Filter
+	if f.Excluded("a") {
+		t.Error("Excluded() on nil filter returned true, want false")
+	}
+}
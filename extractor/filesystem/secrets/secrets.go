@@ -18,65 +18,124 @@
 package secrets
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"io"
+	"path"
 	"path/filepath"
+	"reflect"
+	"regexp"
+	"slices"
 	"strings"
 
 	"github.com/google/osv-scalibr/extractor/filesystem"
 	"github.com/google/osv-scalibr/inventory"
 	"github.com/google/osv-scalibr/plugin"
 	"github.com/google/osv-scalibr/veles"
+	"github.com/google/osv-scalibr/veles/secrets/awsaccesskey"
 	"github.com/google/osv-scalibr/veles/secrets/gcpsak"
+	"github.com/google/osv-scalibr/veles/secrets/k8ssatoken"
+	"github.com/google/osv-scalibr/veles/secrets/kubeconfig"
+	"github.com/google/osv-scalibr/veles/secrets/sshprivatekey"
 )
 
 const (
 	// Name is the unique name of this extractor.
 	Name = "secrets/veles"
 
-	version = 1
+	version = 2
 )
 
 var (
 	fileExtensions = map[string]bool{
-		".cfg":       true,
-		".env":       true,
-		".html":      true,
-		".ipynb":     true,
-		".json":      true,
-		".log":       true,
-		".md":        true,
-		".py":        true,
-		".textproto": true,
-		".toml":      true,
-		".txt":       true,
-		".xml":       true,
-		".yaml":      true,
+		".cfg":        true,
+		".env":        true,
+		".html":       true,
+		".ipynb":      true,
+		".json":       true,
+		".key":        true,
+		".kubeconfig": true,
+		".log":        true,
+		".md":         true,
+		".pem":        true,
+		".py":         true,
+		".textproto":  true,
+		".toml":       true,
+		".txt":        true,
+		".xml":        true,
+		".yaml":       true,
 	}
 
+	// Default file names of SSH client keys, e.g. "id_ed25519".
+	sshKeyFileRe = regexp.MustCompile(`^id_(rsa|dsa|ecdsa|ed25519)(_sk)?$`)
+	// Host keys of the SSH server, e.g. "etc/ssh/ssh_host_ed25519_key".
+	sshHostKeyRe = regexp.MustCompile(`^etc/ssh/ssh_host_[a-z0-9]+_key$`)
+	// Default kubeconfig locations, e.g. "home/user/.kube/config".
+	kubeconfigFileRe = regexp.MustCompile(`(^|/)(\.kube/config|kubeconfig)$`)
+	// Service account tokens mounted into pods by the kubelet are expected to be
+	// found there and aren't reported. Tokens copied elsewhere are.
+	saTokenMountRe = regexp.MustCompile(`^((var/)?run/secrets/kubernetes\.io/serviceaccount|var/lib/kubelet/pods/[^/]+/volumes/kubernetes\.io~projected/[^/]+)/(\.\.[^/]+/)?token$`)
+
 	defaultEngine *veles.DetectionEngine
 )
 
 func init() { //nolint:gochecknoinits
 	var err error
 	defaultEngine, err = veles.NewDetectionEngine([]veles.Detector{
+		awsaccesskey.NewDetector(),
 		gcpsak.NewDetector(),
+		k8ssatoken.NewDetector(),
+		kubeconfig.NewDetector(),
+		sshprivatekey.NewDetector(),
 	})
 	if err != nil {
 		panic(fmt.Sprintf("Unable to initialize default Veles engine: %v", err))
 	}
 }
 
+// Config is the configuration for the Extractor.
+type Config struct {
+	// ReportSSHHostKeys controls whether the private host keys of the SSH server
+	// in /etc/ssh are reported. They are unencrypted by design and present on
+	// most Linux hosts so they're allowlisted by default.
+	ReportSSHHostKeys bool
+	// AllowedSSHKeyFingerprints are the SHA256 fingerprints of known SSH private
+	// keys that shouldn't be reported, e.g. "SHA256:ZwJ0...".
+	AllowedSSHKeyFingerprints []string
+}
+
+// DefaultConfig returns the default configuration for the Extractor.
+func DefaultConfig() Config {
+	return Config{}
+}
+
 // Extractor extracts secrets from the filesystem using the Veles secret
 // scanning library.
 // Other than most extractors, it add Secrets to the Inventory, not Packages.
 type Extractor struct {
-	e *veles.DetectionEngine
+	e                      *veles.DetectionEngine
+	reportSSHHostKeys      bool
+	allowedSSHFingerprints map[string]bool
 }
 
 // New creates a new Extractor using the default Veles detectors.
 func New() filesystem.Extractor {
-	return &Extractor{e: defaultEngine}
+	return NewWithConfig(DefaultConfig())
+}
+
+// NewWithConfig creates a new Extractor using the default Veles detectors and
+// the given configuration.
+func NewWithConfig(cfg Config) filesystem.Extractor {
+	allowed := make(map[string]bool, len(cfg.AllowedSSHKeyFingerprints))
+	for _, f := range cfg.AllowedSSHKeyFingerprints {
+		allowed[f] = true
+	}
+	return &Extractor{
+		e:                      defaultEngine,
+		reportSSHHostKeys:      cfg.ReportSSHHostKeys,
+		allowedSSHFingerprints: allowed,
+	}
 }
 
 // NewWithEngine creates a new Extractor that uses the specified
@@ -102,22 +161,99 @@ func (e Extractor) Requirements() *plugin.Capabilities {
 
 // FileRequired returns true, if the file should be checked for secrets.
 func (e Extractor) FileRequired(api filesystem.FileAPI) bool {
-	ext := strings.ToLower(filepath.Ext(api.Path()))
+	p := filepath.ToSlash(api.Path())
+	if sshKeyFileRe.MatchString(path.Base(p)) {
+		return true
+	}
+	if sshHostKeyRe.MatchString(p) {
+		return e.reportSSHHostKeys
+	}
+	if kubeconfigFileRe.MatchString(p) {
+		return true
+	}
+	if path.Base(p) == "token" {
+		return !saTokenMountRe.MatchString(p)
+	}
+	if userDataFileRe.MatchString(path.Base(p)) || imdsCredentialsRe.MatchString(p) || packerFileRe.MatchString(p) {
+		return true
+	}
+	ext := strings.ToLower(filepath.Ext(p))
 	return fileExtensions[ext]
 }
 
-// Extract extracts secrets from scan input.
+// Extract extracts secrets from scan input. Cloud-init user-data is also
+// decoded to find secrets in compressed or encoded content.
 func (e Extractor) Extract(ctx context.Context, input *filesystem.ScanInput) (inventory.Inventory, error) {
-	secrets, err := e.e.Detect(ctx, input.Reader)
+	var secrets []veles.Secret
+	var err error
+	if userDataFileRe.MatchString(path.Base(filepath.ToSlash(input.Path))) {
+		secrets, err = e.detectUserData(ctx, input.Reader)
+	} else {
+		secrets, err = e.e.Detect(ctx, input.Reader)
+	}
 	if err != nil {
 		return inventory.Inventory{}, fmt.Errorf("unable to scan for secrets: %w", err)
 	}
 	i := inventory.Inventory{}
 	for _, s := range secrets {
+		sev := inventory.SeverityUnspecified
+		if k, ok := s.(sshprivatekey.SSHPrivateKey); ok {
+			if e.sshKeyAllowed(k, input.Path) {
+				continue
+			}
+			sev = sshKeySeverity(k)
+		}
 		i.Secrets = append(i.Secrets, &inventory.Secret{
 			Secret:   s,
 			Location: input.Path,
+			Severity: sev,
 		})
 	}
 	return i, nil
 }
+
+// detectUserData finds secrets in the raw user-data and its decoded contents.
+// Secrets that are found in several forms are only reported once.
+// User-data that exceeds maxUserDataSize is only scanned in its raw form.
+func (e Extractor) detectUserData(ctx context.Context, r io.Reader) ([]veles.Secret, error) {
+	data, err := io.ReadAll(io.LimitReader(r, maxUserDataSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxUserDataSize {
+		return e.e.Detect(ctx, io.MultiReader(bytes.NewReader(data), r))
+	}
+	secrets, err := e.e.Detect(ctx, bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range expandUserData(data, 0) {
+		found, err := e.e.Detect(ctx, bytes.NewReader(d))
+		if err != nil {
+			return nil, err
+		}
+		// Short compressed data can contain the secret verbatim as well.
+		for _, s := range found {
+			if !slices.ContainsFunc(secrets, func(o veles.Secret) bool { return reflect.DeepEqual(s, o) }) {
+				secrets = append(secrets, s)
+			}
+		}
+	}
+	return secrets, nil
+}
+
+func (e Extractor) sshKeyAllowed(k sshprivatekey.SSHPrivateKey, p string) bool {
+	if !e.reportSSHHostKeys && sshHostKeyRe.MatchString(filepath.ToSlash(p)) {
+		return true
+	}
+	return k.Fingerprint != "" && e.allowedSSHFingerprints[k.Fingerprint]
+}
+
+// sshKeySeverity rates unencrypted keys higher since they can be used by
+// anyone who can read the file.
+func sshKeySeverity(k sshprivatekey.SSHPrivateKey) inventory.SeverityEnum {
+	if k.Encrypted {
+		return inventory.SeverityMedium
+	}
+	return inventory.SeverityHigh
+}
@@ -0,0 +1,189 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secrets
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// maxUserDataSize is the maximum size of user-data that is decoded. EC2
+	// limits user-data to 16 KiB but cloud-init seeds can be larger.
+	maxUserDataSize = 1 << 20
+	// maxUserDataDepth limits the nesting of compressed or encoded user-data.
+	maxUserDataDepth = 4
+)
+
+var (
+	// Files containing cloud-init user-data and vendor-data, e.g.
+	// "var/lib/cloud/instance/user-data.txt", the NoCloud seed
+	// "var/lib/cloud/seed/nocloud/user-data" or the autoinstall config served
+	// by Packer from "http/user-data".
+	userDataFileRe = regexp.MustCompile(`^(user|vendor)-data(\.txt(\.i)?)?$`)
+	// EC2 instance metadata dumps, e.g. of
+	// "latest/meta-data/iam/security-credentials/<role>".
+	imdsCredentialsRe = regexp.MustCompile(`(^|/)meta-data/(iam|identity-credentials/ec2)/security-credentials/[^/]+$`)
+	// Packer HCL templates and variable files. JSON templates are covered by
+	// the file extension.
+	packerFileRe = regexp.MustCompile(`\.(pkr|pkrvars)\.hcl$`)
+)
+
+// expandUserData returns the contents embedded in cloud-init user-data that
+// aren't visible to the detectors in the raw data: gzip-compressed or
+// base64-encoded user-data, base64-encoded MIME multipart parts and encoded
+// write_files entries of cloud-config documents.
+func expandUserData(data []byte, depth int) [][]byte {
+	if depth > maxUserDataDepth {
+		return nil
+	}
+	if d, ok := gunzip(data); ok {
+		return append([][]byte{d}, expandUserData(d, depth+1)...)
+	}
+	if d, ok := decodeBase64(data); ok {
+		return append([][]byte{d}, expandUserData(d, depth+1)...)
+	}
+
+	trimmed := bytes.TrimLeft(data, " \t\r\n")
+	switch {
+	case bytes.HasPrefix(trimmed, []byte("#cloud-config")):
+		return writeFiles(trimmed)
+	case bytes.HasPrefix(trimmed, []byte("Content-Type:")) || bytes.HasPrefix(trimmed, []byte("MIME-Version:")):
+		var res [][]byte
+		for _, p := range multipartContents(trimmed) {
+			if p.decoded {
+				res = append(res, p.data)
+			}
+			res = append(res, expandUserData(p.data, depth+1)...)
+		}
+		return res
+	default:
+		return nil
+	}
+}
+
+func gunzip(data []byte) ([]byte, bool) {
+	if !bytes.HasPrefix(data, []byte{0x1f, 0x8b}) {
+		return nil, false
+	}
+	r, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, false
+	}
+	d, err := io.ReadAll(io.LimitReader(r, maxUserDataSize))
+	if err != nil {
+		return nil, false
+	}
+	return d, true
+}
+
+// decodeBase64 decodes data that consists only of standard base64, optionally
+// split into several lines.
+func decodeBase64(data []byte) ([]byte, bool) {
+	s := strings.TrimSpace(string(data))
+	if len(s) < 16 {
+		return nil, false
+	}
+	d, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return nil, false
+	}
+	return d, true
+}
+
+type part struct {
+	data []byte
+	// decoded is true if the part was base64-encoded.
+	decoded bool
+}
+
+// multipartContents returns the parts of MIME multipart user-data, as created
+// e.g. by cloud-init's make-mime or the AWS launch wizard.
+func multipartContents(data []byte) []part {
+	msg, err := mail.ReadMessage(bufio.NewReader(bytes.NewReader(data)))
+	if err != nil {
+		return nil
+	}
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	if err != nil || !strings.HasPrefix(mediaType, "multipart/") {
+		return nil
+	}
+	var res []part
+	mr := multipart.NewReader(msg.Body, params["boundary"])
+	for {
+		p, err := mr.NextPart()
+		if err != nil {
+			return res
+		}
+		d, err := io.ReadAll(io.LimitReader(p, maxUserDataSize))
+		if err != nil {
+			return res
+		}
+		if strings.EqualFold(p.Header.Get("Content-Transfer-Encoding"), "base64") {
+			if d, ok := decodeBase64(d); ok {
+				res = append(res, part{data: d, decoded: true})
+			}
+			continue
+		}
+		res = append(res, part{data: d})
+	}
+}
+
+type cloudConfig struct {
+	WriteFiles []struct {
+		Encoding string `yaml:"encoding"`
+		Content  string `yaml:"content"`
+	} `yaml:"write_files"`
+}
+
+// writeFiles returns the decoded content of the base64-encoded or compressed
+// write_files entries of a cloud-config document. Plain text content is already
+// visible in the raw document.
+func writeFiles(data []byte) [][]byte {
+	var c cloudConfig
+	if err := yaml.Unmarshal(data, &c); err != nil {
+		return nil
+	}
+	var res [][]byte
+	for _, f := range c.WriteFiles {
+		switch strings.ToLower(f.Encoding) {
+		case "b64", "base64":
+			if d, ok := decodeBase64([]byte(f.Content)); ok {
+				res = append(res, d)
+			}
+		case "gz", "gzip":
+			if d, ok := gunzip([]byte(f.Content)); ok {
+				res = append(res, d)
+			}
+		case "gz+base64", "gzip+base64", "gz+b64", "gzip+b64":
+			if d, ok := decodeBase64([]byte(f.Content)); ok {
+				if d, ok := gunzip(d); ok {
+					res = append(res, d)
+				}
+			}
+		}
+	}
+	return res
+}
@@ -0,0 +1,431 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package targetenv
+
+import (
+	"strings"
+
+	"deps.dev/util/semver"
+)
+
+// result is the outcome of evaluating a marker. Markers referencing unknown
+// properties of the target evaluate to unknown.
+type result int
+
+const (
+	unknown result = iota
+	unsatisfied
+	satisfied
+)
+
+func and(a, b result) result {
+	if a == unsatisfied || b == unsatisfied {
+		return unsatisfied
+	}
+	if a == satisfied && b == satisfied {
+		return satisfied
+	}
+	return unknown
+}
+
+func or(a, b result) result {
+	if a == satisfied || b == satisfied {
+		return satisfied
+	}
+	if a == unsatisfied && b == unsatisfied {
+		return unsatisfied
+	}
+	return unknown
+}
+
+// versionMarkers are the marker variables that are compared as PEP 440
+// versions.
+var versionMarkers = map[string]bool{
+	"python_version":         true,
+	"python_full_version":    true,
+	"implementation_version": true,
+}
+
+// MatchesPEP508Marker returns whether a PEP 508 environment marker, e.g.
+// `sys_platform == "win32" and python_version < "3.8"`, holds on the target.
+// Markers that can't be parsed or that depend on unknown properties of the
+// target are considered satisfied.
+// https://packaging.python.org/en/latest/specifications/dependency-specifiers/#environment-markers
+func (e *Env) MatchesPEP508Marker(marker string) bool {
+	if e.IsEmpty() || strings.TrimSpace(marker) == "" {
+		return true
+	}
+	p := &markerParser{env: e, tokens: tokenizeMarker(marker)}
+	res, ok := p.parseOr()
+	if !ok || p.pos != len(p.tokens) {
+		return true
+	}
+	return res != unsatisfied
+}
+
+// markerVariable returns the value of a marker variable on the target.
+func (e *Env) markerVariable(name string) (string, bool) {
+	switch name {
+	case "os_name", "os.name":
+		if e.OS == "" {
+			return "", false
+		}
+		if e.OS == "windows" {
+			return "nt", true
+		}
+		return "posix", true
+	case "sys_platform", "sys.platform":
+		switch e.OS {
+		case "":
+			return "", false
+		case "windows":
+			return "win32", true
+		case "linux", "darwin", "aix", "cygwin":
+			return e.OS, true
+		}
+		// Other platforms include the OS major version, e.g. "freebsd14".
+		return "", false
+	case "platform_system":
+		switch e.OS {
+		case "linux":
+			return "Linux", true
+		case "windows":
+			return "Windows", true
+		case "darwin":
+			return "Darwin", true
+		case "freebsd":
+			return "FreeBSD", true
+		}
+		return "", false
+	case "platform_machine", "platform.machine":
+		return e.platformMachine()
+	case "python_version":
+		if e.PythonVersion == "" {
+			return "", false
+		}
+		parts := strings.SplitN(e.PythonVersion, ".", 3)
+		if len(parts) < 2 {
+			return "", false
+		}
+		return parts[0] + "." + parts[1], true
+	case "python_full_version":
+		if e.PythonVersion == "" {
+			return "", false
+		}
+		if strings.Count(e.PythonVersion, ".") == 1 {
+			// The patch version is unknown.
+			return "", false
+		}
+		return e.PythonVersion, true
+	}
+	// Other variables such as "extra" or "platform_release" can't be derived
+	// from the target config.
+	return "", false
+}
+
+// platformMachine returns the value of Python's platform.machine() on the
+// target, which differs between operating systems.
+func (e *Env) platformMachine() (string, bool) {
+	if e.OS == "" {
+		return "", false
+	}
+	switch e.Arch {
+	case "amd64":
+		if e.OS == "windows" {
+			return "AMD64", true
+		}
+		if e.OS == "freebsd" {
+			return "amd64", true
+		}
+		return "x86_64", true
+	case "arm64":
+		switch e.OS {
+		case "windows":
+			return "ARM64", true
+		case "linux":
+			return "aarch64", true
+		}
+		return "arm64", true
+	case "386":
+		if e.OS == "windows" {
+			return "x86", true
+		}
+		return "i686", true
+	case "ppc64le", "s390x", "riscv64":
+		return e.Arch, true
+	}
+	return "", false
+}
+
+type tokenKind int
+
+const (
+	tokenIdent tokenKind = iota
+	tokenString
+	tokenOp
+	tokenLParen
+	tokenRParen
+)
+
+type token struct {
+	kind  tokenKind
+	value string
+}
+
+// tokenizeMarker splits a marker into tokens. Unexpected characters produce
+// an operator token that fails parsing.
+func tokenizeMarker(s string) []token {
+	var tokens []token
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case c == ' ' || c == '\t':
+			i++
+		case c == '(':
+			tokens = append(tokens, token{kind: tokenLParen})
+			i++
+		case c == ')':
+			tokens = append(tokens, token{kind: tokenRParen})
+			i++
+		case c == '"' || c == '\'':
+			end := strings.IndexByte(s[i+1:], c)
+			if end < 0 {
+				return append(tokens, token{kind: tokenOp, value: s[i:]})
+			}
+			tokens = append(tokens, token{kind: tokenString, value: s[i+1 : i+1+end]})
+			i += end + 2
+		case strings.IndexByte("<>=!~", c) >= 0:
+			j := i
+			for j < len(s) && strings.IndexByte("<>=!~", s[j]) >= 0 {
+				j++
+			}
+			tokens = append(tokens, token{kind: tokenOp, value: s[i:j]})
+			i = j
+		default:
+			j := i
+			for j < len(s) && isIdentChar(s[j]) {
+				j++
+			}
+			if j == i {
+				return append(tokens, token{kind: tokenOp, value: s[i:]})
+			}
+			word := s[i:j]
+			kind := tokenIdent
+			if word == "in" || word == "not" {
+				kind = tokenOp
+			}
+			tokens = append(tokens, token{kind: kind, value: word})
+			i = j
+		}
+	}
+	return tokens
+}
+
+func isIdentChar(c byte) bool {
+	return c == '_' || c == '.' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
+
+// markerParser is a recursive descent parser for the marker grammar:
+//
+//	marker_or   = marker_and ("or" marker_and)*
+//	marker_and  = marker_atom ("and" marker_atom)*
+//	marker_atom = "(" marker_or ")" | marker_var marker_op marker_var
+type markerParser struct {
+	env    *Env
+	tokens []token
+	pos    int
+}
+
+func (p *markerParser) peek() (token, bool) {
+	if p.pos >= len(p.tokens) {
+		return token{}, false
+	}
+	return p.tokens[p.pos], true
+}
+
+func (p *markerParser) parseOr() (result, bool) {
+	res, ok := p.parseAnd()
+	if !ok {
+		return unknown, false
+	}
+	for {
+		t, found := p.peek()
+		if !found || t.kind != tokenIdent || t.value != "or" {
+			return res, true
+		}
+		p.pos++
+		next, ok := p.parseAnd()
+		if !ok {
+			return unknown, false
+		}
+		res = or(res, next)
+	}
+}
+
+func (p *markerParser) parseAnd() (result, bool) {
+	res, ok := p.parseAtom()
+	if !ok {
+		return unknown, false
+	}
+	for {
+		t, found := p.peek()
+		if !found || t.kind != tokenIdent || t.value != "and" {
+			return res, true
+		}
+		p.pos++
+		next, ok := p.parseAtom()
+		if !ok {
+			return unknown, false
+		}
+		res = and(res, next)
+	}
+}
+
+func (p *markerParser) parseAtom() (result, bool) {
+	t, found := p.peek()
+	if !found {
+		return unknown, false
+	}
+	if t.kind == tokenLParen {
+		p.pos++
+		res, ok := p.parseOr()
+		if !ok {
+			return unknown, false
+		}
+		if t, found := p.peek(); !found || t.kind != tokenRParen {
+			return unknown, false
+		}
+		p.pos++
+		return res, true
+	}
+
+	if p.pos+2 > len(p.tokens) {
+		return unknown, false
+	}
+	lhs := p.tokens[p.pos]
+	op := p.tokens[p.pos+1]
+	p.pos += 2
+	if op.kind != tokenOp {
+		return unknown, false
+	}
+	if op.value == "not" {
+		// "not in" is the only operator spanning two tokens.
+		if t, found := p.peek(); !found || t.value != "in" {
+			return unknown, false
+		}
+		op.value = "not in"
+		p.pos++
+	}
+	rhs, found := p.peek()
+	if !found {
+		return unknown, false
+	}
+	p.pos++
+	if lhs.kind != tokenIdent && lhs.kind != tokenString || rhs.kind != tokenIdent && rhs.kind != tokenString {
+		return unknown, false
+	}
+	return p.compare(lhs, op.value, rhs), true
+}
+
+// compare evaluates a single comparison of two marker values.
+func (p *markerParser) compare(lhs token, op string, rhs token) result {
+	variable := ""
+	if lhs.kind == tokenIdent {
+		variable = lhs.value
+	} else if rhs.kind == tokenIdent {
+		variable = rhs.value
+	}
+	l, ok := p.value(lhs)
+	if !ok {
+		return unknown
+	}
+	r, ok := p.value(rhs)
+	if !ok {
+		return unknown
+	}
+
+	switch op {
+	case "in":
+		return toResult(strings.Contains(r, l))
+	case "not in":
+		return toResult(!strings.Contains(r, l))
+	}
+
+	if versionMarkers[variable] {
+		if res, ok := compareVersions(l, op, r, lhs.kind == tokenIdent); ok {
+			return res
+		}
+	}
+
+	switch op {
+	case "==", "===":
+		return toResult(l == r)
+	case "!=":
+		return toResult(l != r)
+	case "<":
+		return toResult(l < r)
+	case "<=":
+		return toResult(l <= r)
+	case ">":
+		return toResult(l > r)
+	case ">=":
+		return toResult(l >= r)
+	}
+	// E.g. "~=" on non-version values.
+	return unknown
+}
+
+// flippedOps is used to evaluate comparisons with the version on the right
+// hand side, e.g. `"3.8" <= python_version`.
+var flippedOps = map[string]string{
+	"<":  ">",
+	"<=": ">=",
+	">":  "<",
+	">=": "<=",
+	"==": "==",
+	"!=": "!=",
+}
+
+// compareVersions compares two values as PEP 440 versions.
+func compareVersions(l, op, r string, varOnLeft bool) (result, bool) {
+	version, spec := l, r
+	if !varOnLeft {
+		version, spec = r, l
+		flipped, ok := flippedOps[op]
+		if !ok {
+			return unknown, false
+		}
+		op = flipped
+	}
+	c, err := semver.PyPI.ParseConstraint(op + spec)
+	if err != nil {
+		return unknown, false
+	}
+	return toResult(c.Match(version)), true
+}
+
+func (p *markerParser) value(t token) (string, bool) {
+	if t.kind == tokenString {
+		return t.value, true
+	}
+	return p.env.markerVariable(t.value)
+}
+
+func toResult(b bool) result {
+	if b {
+		return satisfied
+	}
+	return unsatisfied
+}
@@ -0,0 +1,115 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package targetenv describes the environment that the scanned software is
+// deployed to. Lockfile extractors use it to evaluate conditional dependencies
+// such as PEP 508 environment markers or npm os/cpu filters, so that the
+// inventory reflects what actually gets installed on the target instead of on
+// the scanning host.
+package targetenv
+
+import (
+	"strings"
+
+	"deps.dev/util/semver"
+)
+
+// Env is the target environment of a scan. Empty fields are treated as
+// unknown: conditions that depend on them are considered satisfied.
+type Env struct {
+	// OS of the target in GOOS notation, e.g. "linux", "windows" or "darwin".
+	OS string
+	// Arch of the target in GOARCH notation, e.g. "amd64" or "arm64".
+	Arch string
+	// PythonVersion is the Python interpreter version, e.g. "3.11" or "3.11.4".
+	PythonVersion string
+	// NodeVersion is the Node.js version, e.g. "20.11.0".
+	NodeVersion string
+}
+
+// IsEmpty returns whether no property of the target environment is known.
+func (e *Env) IsEmpty() bool {
+	return e == nil || *e == Env{}
+}
+
+// npmOS maps GOOS values to the values of Node's process.platform.
+var npmOS = map[string]string{
+	"windows": "win32",
+	"solaris": "sunos",
+}
+
+// npmCPU maps GOARCH values to the values of Node's process.arch.
+var npmCPU = map[string]string{
+	"amd64":   "x64",
+	"386":     "ia32",
+	"ppc64le": "ppc64",
+	"mipsle":  "mipsel",
+}
+
+// MatchesNPMPlatform returns whether a package with the given "os" and "cpu"
+// fields from its package.json can be installed on the target.
+func (e *Env) MatchesNPMPlatform(osList, cpuList []string) bool {
+	if e == nil {
+		return true
+	}
+	if e.OS != "" && !matchesNPMList(mapValue(npmOS, e.OS), osList) {
+		return false
+	}
+	if e.Arch != "" && !matchesNPMList(mapValue(npmCPU, e.Arch), cpuList) {
+		return false
+	}
+	return true
+}
+
+// MatchesNodeEngine returns whether the target's Node.js version satisfies the
+// "engines.node" constraint of a package.
+func (e *Env) MatchesNodeEngine(constraint string) bool {
+	if e == nil || e.NodeVersion == "" || constraint == "" {
+		return true
+	}
+	c, err := semver.NPM.ParseConstraint(constraint)
+	if err != nil {
+		return true
+	}
+	return c.Match(e.NodeVersion)
+}
+
+// matchesNPMList mirrors the os/cpu check of npm: Entries prefixed with "!"
+// exclude a value, the other entries form an allowlist.
+// https://github.com/npm/npm-install-checks/blob/main/lib/index.js
+func matchesNPMList(value string, list []string) bool {
+	if len(list) == 0 || (len(list) == 1 && list[0] == "any") {
+		return true
+	}
+	negated := 0
+	match := false
+	for _, entry := range list {
+		if test, ok := strings.CutPrefix(entry, "!"); ok {
+			negated++
+			if value == test {
+				return false
+			}
+			continue
+		}
+		match = match || value == entry
+	}
+	return match || negated == len(list)
+}
+
+func mapValue(m map[string]string, key string) string {
+	if v, ok := m[key]; ok {
+		return v
+	}
+	return key
+}
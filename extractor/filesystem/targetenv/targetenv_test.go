@@ -0,0 +1,119 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package targetenv_test
+
+import (
+	"testing"
+
+	"github.com/google/osv-scalibr/extractor/filesystem/targetenv"
+)
+
+func TestMatchesPEP508Marker(t *testing.T) {
+	windows := &targetenv.Env{OS: "windows", Arch: "amd64", PythonVersion: "3.11.4"}
+	linux := &targetenv.Env{OS: "linux", Arch: "arm64", PythonVersion: "3.7"}
+
+	testCases := []struct {
+		desc   string
+		env    *targetenv.Env
+		marker string
+		want   bool
+	}{
+		{desc: "nil_env", env: nil, marker: `sys_platform == "win32"`, want: true},
+		{desc: "empty_marker", env: windows, marker: "", want: true},
+		{desc: "sys_platform_match", env: windows, marker: `sys_platform == "win32"`, want: true},
+		{desc: "sys_platform_mismatch", env: linux, marker: `sys_platform == "win32"`, want: false},
+		{desc: "single_quotes", env: linux, marker: `sys_platform != 'win32'`, want: true},
+		{desc: "platform_system", env: windows, marker: `platform_system == "Linux"`, want: false},
+		{desc: "os_name", env: windows, marker: `os_name == "nt"`, want: true},
+		{desc: "platform_machine_windows", env: windows, marker: `platform_machine == "AMD64"`, want: true},
+		{desc: "platform_machine_linux", env: linux, marker: `platform_machine == "x86_64"`, want: false},
+		{desc: "python_version", env: windows, marker: `python_version < "3.8"`, want: false},
+		{desc: "python_version_two_digit_minor", env: windows, marker: `python_version >= "3.9"`, want: true},
+		{desc: "python_version_on_rhs", env: linux, marker: `"3.8" > python_version`, want: true},
+		{desc: "python_full_version", env: windows, marker: `python_full_version >= "3.11.5"`, want: false},
+		{desc: "python_full_version_unknown_patch", env: linux, marker: `python_full_version >= "3.7.5"`, want: true},
+		{desc: "compatible_release", env: windows, marker: `python_version ~= "3.10"`, want: true},
+		{desc: "and", env: windows, marker: `sys_platform == "win32" and python_version < "3.8"`, want: false},
+		{desc: "or", env: windows, marker: `sys_platform == "darwin" or python_version >= "3.8"`, want: true},
+		{desc: "parentheses", env: linux, marker: `(sys_platform == "win32" or sys_platform == "darwin") and python_version < "3.8"`, want: false},
+		{desc: "in", env: linux, marker: `"linux" in sys_platform`, want: true},
+		{desc: "not_in", env: windows, marker: `platform_machine not in "x86_64 aarch64"`, want: true},
+		{desc: "unknown_variable_is_satisfied", env: linux, marker: `extra == "test"`, want: true},
+		{desc: "unknown_or_false", env: linux, marker: `extra == "test" or sys_platform == "win32"`, want: true},
+		{desc: "unknown_and_false", env: linux, marker: `extra == "test" and sys_platform == "win32"`, want: false},
+		{desc: "unknown_os", env: &targetenv.Env{PythonVersion: "3.12"}, marker: `sys_platform == "win32"`, want: true},
+		{desc: "invalid_marker", env: linux, marker: `sys_platform == `, want: true},
+		{desc: "unbalanced_parentheses", env: linux, marker: `(sys_platform == "win32"`, want: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := tc.env.MatchesPEP508Marker(tc.marker); got != tc.want {
+				t.Errorf("MatchesPEP508Marker(%q) = %v, want %v", tc.marker, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestMatchesNPMPlatform(t *testing.T) {
+	testCases := []struct {
+		desc string
+		env  *targetenv.Env
+		os   []string
+		cpu  []string
+		want bool
+	}{
+		{desc: "nil_env", env: nil, os: []string{"darwin"}, want: true},
+		{desc: "no_restrictions", env: &targetenv.Env{OS: "linux", Arch: "amd64"}, want: true},
+		{desc: "os_allowlist_match", env: &targetenv.Env{OS: "windows"}, os: []string{"win32"}, want: true},
+		{desc: "os_allowlist_mismatch", env: &targetenv.Env{OS: "linux"}, os: []string{"darwin"}, want: false},
+		{desc: "os_denylist", env: &targetenv.Env{OS: "windows"}, os: []string{"!win32"}, want: false},
+		{desc: "os_denylist_other", env: &targetenv.Env{OS: "linux"}, os: []string{"!win32"}, want: true},
+		{desc: "cpu_match", env: &targetenv.Env{OS: "linux", Arch: "amd64"}, os: []string{"linux"}, cpu: []string{"x64"}, want: true},
+		{desc: "cpu_mismatch", env: &targetenv.Env{OS: "linux", Arch: "arm64"}, os: []string{"linux"}, cpu: []string{"x64"}, want: false},
+		{desc: "unknown_arch", env: &targetenv.Env{OS: "linux"}, cpu: []string{"x64"}, want: true},
+		{desc: "any", env: &targetenv.Env{OS: "linux"}, os: []string{"any"}, want: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := tc.env.MatchesNPMPlatform(tc.os, tc.cpu); got != tc.want {
+				t.Errorf("MatchesNPMPlatform(%v, %v) = %v, want %v", tc.os, tc.cpu, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestMatchesNodeEngine(t *testing.T) {
+	testCases := []struct {
+		desc       string
+		env        *targetenv.Env
+		constraint string
+		want       bool
+	}{
+		{desc: "unknown_version", env: &targetenv.Env{OS: "linux"}, constraint: ">=18", want: true},
+		{desc: "match", env: &targetenv.Env{NodeVersion: "20.11.0"}, constraint: ">=18", want: true},
+		{desc: "mismatch", env: &targetenv.Env{NodeVersion: "16.20.2"}, constraint: "^18.0.0 || >=20", want: false},
+		{desc: "invalid_constraint", env: &targetenv.Env{NodeVersion: "16.20.2"}, constraint: "not a constraint", want: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := tc.env.MatchesNodeEngine(tc.constraint); got != tc.want {
+				t.Errorf("MatchesNodeEngine(%q) = %v, want %v", tc.constraint, got, tc.want)
+			}
+		})
+	}
+}
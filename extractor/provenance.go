@@ -0,0 +1,96 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package extractor
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+)
+
+// Keys of the encoded LocationProvenance.
+const (
+	provenanceLayerKey   = "layer"
+	provenanceArchiveKey = "archive"
+	provenancePathKey    = "path"
+)
+
+// String encodes the provenance as a URL query string, e.g.
+// "archive=app%2Fapp.war&layer=sha256%3A...&path=WEB-INF%2Flib%2Flib.jar".
+// The archive chain keeps its order. Use ParseLocationProvenance to decode it.
+// The Location field is not encoded since it's derived from the other fields.
+func (p *LocationProvenance) String() string {
+	v := url.Values{}
+	if p.LayerDigest != "" {
+		v.Set(provenanceLayerKey, p.LayerDigest)
+	}
+	for _, a := range p.ArchiveChain {
+		v.Add(provenanceArchiveKey, a)
+	}
+	v.Set(provenancePathKey, p.Path)
+	return v.Encode()
+}
+
+// ParseLocationProvenance decodes a provenance encoded with
+// LocationProvenance.String.
+func ParseLocationProvenance(s string) (*LocationProvenance, error) {
+	v, err := url.ParseQuery(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid location provenance %q: %w", s, err)
+	}
+	if !v.Has(provenancePathKey) {
+		return nil, errors.New("invalid location provenance: no path")
+	}
+	return &LocationProvenance{
+		LayerDigest:  v.Get(provenanceLayerKey),
+		ArchiveChain: v[provenanceArchiveKey],
+		Path:         v.Get(provenancePathKey),
+	}, nil
+}
+
+// Inside returns the provenance of the file at the given path inside the
+// archive described by p.
+func (p *LocationProvenance) Inside(location, path string) *LocationProvenance {
+	return &LocationProvenance{
+		Location:     location,
+		LayerDigest:  p.LayerDigest,
+		ArchiveChain: append(slices.Clone(p.ArchiveChain), p.Path),
+		Path:         path,
+	}
+}
+
+// ProvenanceFor returns the provenance of the given location or nil if none is set.
+func (p *Package) ProvenanceFor(location string) *LocationProvenance {
+	for _, lp := range p.LocationProvenance {
+		if lp.Location == location {
+			return lp
+		}
+	}
+	return nil
+}
+
+// SetLayerDigest sets the layer digest on the provenance of all locations of
+// the package, adding provenance for the locations that have none.
+func (p *Package) SetLayerDigest(digest string) {
+	for _, loc := range p.Locations {
+		lp := p.ProvenanceFor(loc)
+		if lp == nil {
+			lp = &LocationProvenance{Location: loc, Path: loc}
+			p.LocationProvenance = append(p.LocationProvenance, lp)
+		}
+		lp.LayerDigest = digest
+	}
+}
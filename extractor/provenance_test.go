@@ -0,0 +1,106 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package extractor_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/osv-scalibr/extractor"
+)
+
+func TestLocationProvenanceRoundTrip(t *testing.T) {
+	testCases := []struct {
+		desc    string
+		prov    *extractor.LocationProvenance
+		wantStr string
+	}{
+		{
+			desc:    "plain_file",
+			prov:    &extractor.LocationProvenance{Path: "usr/lib/app.jar"},
+			wantStr: "path=usr%2Flib%2Fapp.jar",
+		},
+		{
+			desc: "nested_archive_in_layer",
+			prov: &extractor.LocationProvenance{
+				LayerDigest:  "sha256:abc",
+				ArchiveChain: []string{"app/app.war", "WEB-INF/lib/lib.jar"},
+				Path:         "META-INF/maven/org.example/lib/pom.properties",
+			},
+			wantStr: "archive=app%2Fapp.war&archive=WEB-INF%2Flib%2Flib.jar&layer=sha256%3Aabc&path=META-INF%2Fmaven%2Forg.example%2Flib%2Fpom.properties",
+		},
+		{
+			desc: "special_characters",
+			prov: &extractor.LocationProvenance{
+				ArchiveChain: []string{"a b&c=d.jar"},
+				Path:         "x:y/z.properties",
+			},
+			wantStr: "archive=a+b%26c%3Dd.jar&path=x%3Ay%2Fz.properties",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := tc.prov.String()
+			if got != tc.wantStr {
+				t.Errorf("String() = %q, want %q", got, tc.wantStr)
+			}
+			parsed, err := extractor.ParseLocationProvenance(got)
+			if err != nil {
+				t.Fatalf("ParseLocationProvenance(%q): %v", got, err)
+			}
+			if diff := cmp.Diff(tc.prov, parsed); diff != "" {
+				t.Errorf("ParseLocationProvenance(%q) returned unexpected diff (-want +got):\n%s", got, diff)
+			}
+		})
+	}
+}
+
+func TestParseLocationProvenanceError(t *testing.T) {
+	for _, s := range []string{"", "layer=sha256%3Aabc", "path=%zz"} {
+		if _, err := extractor.ParseLocationProvenance(s); err == nil {
+			t.Errorf("ParseLocationProvenance(%q) succeeded, want error", s)
+		}
+	}
+}
+
+func TestSetLayerDigest(t *testing.T) {
+	pkg := &extractor.Package{
+		Locations: []string{"app.jar", "app.jar/pom.properties"},
+		LocationProvenance: []*extractor.LocationProvenance{{
+			Location:     "app.jar/pom.properties",
+			ArchiveChain: []string{"app.jar"},
+			Path:         "pom.properties",
+		}},
+	}
+	pkg.SetLayerDigest("sha256:abc")
+
+	want := []*extractor.LocationProvenance{
+		{
+			Location:     "app.jar/pom.properties",
+			LayerDigest:  "sha256:abc",
+			ArchiveChain: []string{"app.jar"},
+			Path:         "pom.properties",
+		},
+		{
+			Location:    "app.jar",
+			LayerDigest: "sha256:abc",
+			Path:        "app.jar",
+		},
+	}
+	if diff := cmp.Diff(want, pkg.LocationProvenance); diff != "" {
+		t.Errorf("SetLayerDigest() returned unexpected diff (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,58 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package extractor
+
+import (
+	"sync"
+
+	"github.com/google/osv-scalibr/purl"
+)
+
+// PURLMapper computes the package URL of a package. It can return nil to fall
+// back to the built-in conversion logic.
+type PURLMapper func(p *Package) *purl.PackageURL
+
+var (
+	purlMappersMu sync.RWMutex
+	purlMappers   = map[string]PURLMapper{}
+)
+
+// RegisterPURLMapper registers a function that computes the package URLs of
+// packages with the given PURL type, e.g. packages from an internal artifact
+// repository that a custom extractor reports with its own PURL type. The
+// mapper takes precedence over the built-in conversion logic for that type.
+// Registering a mapper for a type replaces the previous one; a nil mapper
+// removes it.
+func RegisterPURLMapper(purlType string, mapper PURLMapper) {
+	purlMappersMu.Lock()
+	defer purlMappersMu.Unlock()
+	if mapper == nil {
+		delete(purlMappers, purlType)
+		return
+	}
+	purlMappers[purlType] = mapper
+}
+
+// customPURL returns the package URL computed by the mapper registered for the
+// package's PURL type, or nil if there's none.
+func customPURL(p *Package) *purl.PackageURL {
+	purlMappersMu.RLock()
+	mapper, ok := purlMappers[p.PURLType]
+	purlMappersMu.RUnlock()
+	if !ok {
+		return nil
+	}
+	return mapper(p)
+}
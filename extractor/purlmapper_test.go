@@ -0,0 +1,122 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package extractor_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/purl"
+)
+
+type internalMetadata struct {
+	Repo string
+}
+
+func TestRegisterPURLMapper(t *testing.T) {
+	extractor.RegisterPURLMapper("internal", func(p *extractor.Package) *purl.PackageURL {
+		m, ok := p.Metadata.(*internalMetadata)
+		if !ok {
+			return nil
+		}
+		return &purl.PackageURL{
+			Type:       "internal",
+			Namespace:  m.Repo,
+			Name:       p.Name,
+			Version:    p.Version,
+			Qualifiers: purl.QualifiersFromMap(map[string]string{"repository_url": "https://artifacts.example.com"}),
+		}
+	})
+	// Overrides the built-in conversion logic.
+	extractor.RegisterPURLMapper(purl.TypeGolang, func(p *extractor.Package) *purl.PackageURL {
+		return &purl.PackageURL{Type: purl.TypeGolang, Name: "mapped/" + p.Name, Version: p.Version}
+	})
+	t.Cleanup(func() {
+		extractor.RegisterPURLMapper("internal", nil)
+		extractor.RegisterPURLMapper(purl.TypeGolang, nil)
+	})
+
+	tests := []struct {
+		name string
+		pkg  *extractor.Package
+		want *purl.PackageURL
+	}{
+		{
+			name: "custom_type",
+			pkg: &extractor.Package{
+				Name:     "billing",
+				Version:  "1.2.3",
+				PURLType: "internal",
+				Metadata: &internalMetadata{Repo: "payments"},
+			},
+			want: &purl.PackageURL{
+				Type:       "internal",
+				Namespace:  "payments",
+				Name:       "billing",
+				Version:    "1.2.3",
+				Qualifiers: purl.QualifiersFromMap(map[string]string{"repository_url": "https://artifacts.example.com"}),
+			},
+		},
+		{
+			name: "mapper_falls_back_to_default",
+			pkg: &extractor.Package{
+				Name:     "billing",
+				Version:  "1.2.3",
+				PURLType: "internal",
+			},
+			want: &purl.PackageURL{
+				Type:    "internal",
+				Name:    "billing",
+				Version: "1.2.3",
+			},
+		},
+		{
+			name: "overridden_builtin_type",
+			pkg: &extractor.Package{
+				Name:     "github.com/google/osv-scalibr",
+				Version:  "1.0.0",
+				PURLType: purl.TypeGolang,
+			},
+			want: &purl.PackageURL{
+				Type:    purl.TypeGolang,
+				Name:    "mapped/github.com/google/osv-scalibr",
+				Version: "1.0.0",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.pkg.PURL()
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("%v.PURL() returned unexpected result (-want +got):\n%s", tc.pkg, diff)
+			}
+		})
+	}
+}
+
+func TestRegisterPURLMapper_Unregister(t *testing.T) {
+	extractor.RegisterPURLMapper("internal", func(p *extractor.Package) *purl.PackageURL {
+		return &purl.PackageURL{Type: "internal", Name: "mapped"}
+	})
+	extractor.RegisterPURLMapper("internal", nil)
+
+	pkg := &extractor.Package{Name: "billing", PURLType: "internal"}
+	want := &purl.PackageURL{Type: "internal", Name: "billing"}
+	if diff := cmp.Diff(want, pkg.PURL()); diff != "" {
+		t.Errorf("%v.PURL() returned unexpected result (-want +got):\n%s", pkg, diff)
+	}
+}
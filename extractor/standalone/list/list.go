@@ -23,11 +23,15 @@ import (
 	"github.com/google/osv-scalibr/extractor/standalone"
 	"github.com/google/osv-scalibr/extractor/standalone/containers/containerd"
 	"github.com/google/osv-scalibr/extractor/standalone/containers/docker"
+	"github.com/google/osv-scalibr/extractor/standalone/os/kernelruntime"
 	"github.com/google/osv-scalibr/extractor/standalone/os/netports"
 	"github.com/google/osv-scalibr/extractor/standalone/windows/dismpatch"
+	"github.com/google/osv-scalibr/extractor/standalone/windows/dotnetframework"
 	"github.com/google/osv-scalibr/extractor/standalone/windows/ospackages"
 	"github.com/google/osv-scalibr/extractor/standalone/windows/regosversion"
 	"github.com/google/osv-scalibr/extractor/standalone/windows/regpatchlevel"
+	"github.com/google/osv-scalibr/extractor/standalone/windows/services"
+	"github.com/google/osv-scalibr/extractor/standalone/windows/vcredist"
 )
 
 // InitFn is the extractor initializer function.
@@ -43,14 +47,18 @@ var (
 	// WindowsExperimental defines experimental extractors. Note that experimental does not mean
 	// dangerous.
 	WindowsExperimental = InitMap{
-		ospackages.Name:    {ospackages.NewDefault},
-		regosversion.Name:  {regosversion.NewDefault},
-		regpatchlevel.Name: {regpatchlevel.NewDefault},
+		dotnetframework.Name: {dotnetframework.NewDefault},
+		ospackages.Name:      {ospackages.NewDefault},
+		regosversion.Name:    {regosversion.NewDefault},
+		regpatchlevel.Name:   {regpatchlevel.NewDefault},
+		services.Name:        {services.NewDefault},
+		vcredist.Name:        {vcredist.NewDefault},
 	}
 
 	// OSExperimental defines experimental OS extractors.
 	OSExperimental = InitMap{
-		netports.Name: {netports.New},
+		netports.Name:      {netports.New},
+		kernelruntime.Name: {kernelruntime.New},
 	}
 
 	// Containers standalone extractors.
@@ -0,0 +1,279 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package kernelruntime extracts the kernel modules loaded into the running Linux kernel
+// and the eBPF objects pinned to the BPF filesystem. Modules that taint the kernel,
+// e.g. unsigned or out-of-tree modules, are reported as findings.
+package kernelruntime
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/standalone"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/log"
+	"github.com/google/osv-scalibr/plugin"
+)
+
+const (
+	// Name is the unique name of this extractor.
+	Name = "os/kernelruntime"
+
+	modulesPath = "proc/modules"
+	sysfsModule = "sys/module"
+	bpffsPath   = "sys/fs/bpf"
+
+	// maxBPFPinnedObjects limits the number of pinned objects reported, as the
+	// BPF filesystem can hold a large number of maps on Kubernetes nodes.
+	maxBPFPinnedObjects = 10000
+)
+
+// Kinds of the extracted kernel components.
+const (
+	KindModule          = "module"
+	KindBPFPinnedObject = "bpf-pinned-object"
+)
+
+// taintFlagDescriptions describes the module taint flags that are reported as findings.
+// See https://docs.kernel.org/admin-guide/tainted-kernels.html
+var taintFlagDescriptions = map[rune]string{
+	'F': "force-loaded",
+	'O': "out-of-tree",
+	'E': "unsigned",
+}
+
+// Extractor extracts loaded kernel modules and pinned eBPF objects from the running system.
+type Extractor struct{}
+
+// New creates a new Extractor.
+func New() standalone.Extractor {
+	return &Extractor{}
+}
+
+// Name of the extractor.
+func (e Extractor) Name() string { return Name }
+
+// Version of the extractor.
+func (e Extractor) Version() int { return 0 }
+
+// Requirements of the extractor.
+func (e Extractor) Requirements() *plugin.Capabilities {
+	return &plugin.Capabilities{
+		OS:            plugin.OSLinux,
+		RunningSystem: true,
+	}
+}
+
+// Extract extracts the loaded kernel modules and pinned eBPF objects.
+func (e Extractor) Extract(ctx context.Context, input *standalone.ScanInput) (inventory.Inventory, error) {
+	fsys := input.ScanRoot.FS
+
+	modules, err := e.extractModules(ctx, fsys)
+	if err != nil {
+		return inventory.Inventory{}, err
+	}
+	objects, err := extractBPFPinnedObjects(ctx, fsys)
+	if err != nil {
+		return inventory.Inventory{}, err
+	}
+
+	inv := inventory.Inventory{Packages: slices.Concat(modules, objects)}
+	if f := e.taintFinding(modules); f != nil {
+		inv.GenericFindings = []*inventory.GenericFinding{f}
+	}
+	return inv, nil
+}
+
+// extractModules parses the /proc/modules lines, e.g.
+//
+//	nvidia 56823808 2541 nvidia_uvm,nvidia_modeset, Live 0xffffffffc1a00000 (POE)
+//
+// and adds the version information the kernel exposes in sysfs.
+func (e Extractor) extractModules(ctx context.Context, fsys fs.FS) ([]*extractor.Package, error) {
+	content, err := fs.ReadFile(fsys, modulesPath)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			// The kernel was built without module support.
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read /%s: %w", modulesPath, err)
+	}
+
+	var pkgs []*extractor.Package
+	s := bufio.NewScanner(bytes.NewReader(content))
+	for s.Scan() {
+		if err := ctx.Err(); err != nil {
+			return nil, fmt.Errorf("%s halted due to context error: %w", e.Name(), err)
+		}
+		m, ok := parseModuleLine(s.Text())
+		if !ok {
+			log.Debugf("%s: skipping malformed line in /%s: %q", e.Name(), modulesPath, s.Text())
+			continue
+		}
+		m.SourceVersion = readSysfsAttr(fsys, m.name, "srcversion")
+		pkgs = append(pkgs, &extractor.Package{
+			Name:      m.name,
+			Version:   readSysfsAttr(fsys, m.name, "version"),
+			Metadata:  &m.Metadata,
+			Locations: []string{"/" + modulesPath},
+		})
+	}
+	if err := s.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read /%s: %w", modulesPath, err)
+	}
+	return pkgs, nil
+}
+
+type module struct {
+	Metadata
+
+	name string
+}
+
+func parseModuleLine(line string) (*module, bool) {
+	fields := strings.Fields(line)
+	if len(fields) < 5 {
+		return nil, false
+	}
+	size, err := strconv.ParseInt(fields[1], 10, 64)
+	if err != nil {
+		return nil, false
+	}
+	refCount, err := strconv.ParseInt(fields[2], 10, 64)
+	if err != nil {
+		// The reference count is "-" for modules that can't be unloaded.
+		refCount = -1
+	}
+	var deps []string
+	if fields[3] != "-" {
+		for _, d := range strings.Split(fields[3], ",") {
+			if d != "" {
+				deps = append(deps, d)
+			}
+		}
+	}
+	var taints string
+	if len(fields) > 6 {
+		taints = strings.Trim(fields[6], "()")
+	}
+	return &module{
+		name: fields[0],
+		Metadata: Metadata{
+			Kind:         KindModule,
+			Size:         size,
+			RefCount:     refCount,
+			Dependencies: deps,
+			State:        fields[4],
+			TaintFlags:   taints,
+		},
+	}, true
+}
+
+// readSysfsAttr returns an attribute of the module from /sys/module/<name>/,
+// which holds the same values as the modinfo output. Missing attributes are
+// returned as an empty string.
+func readSysfsAttr(fsys fs.FS, module, attr string) string {
+	content, err := fs.ReadFile(fsys, path.Join(sysfsModule, module, attr))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(content))
+}
+
+// extractBPFPinnedObjects lists the eBPF programs, maps and links pinned to the BPF filesystem.
+// Pinned objects outlive the process that loaded them, so they're a common place for
+// persistent eBPF-based implants.
+func extractBPFPinnedObjects(ctx context.Context, fsys fs.FS) ([]*extractor.Package, error) {
+	var pkgs []*extractor.Package
+	err := fs.WalkDir(fsys, bpffsPath, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if p == bpffsPath && errors.Is(err, fs.ErrNotExist) {
+				// The BPF filesystem is not mounted.
+				return fs.SkipAll
+			}
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		if len(pkgs) >= maxBPFPinnedObjects {
+			log.Warnf("%s: more than %d pinned BPF objects found, skipping the rest", Name, maxBPFPinnedObjects)
+			return fs.SkipAll
+		}
+		pkgs = append(pkgs, &extractor.Package{
+			Name:      strings.TrimPrefix(p, bpffsPath+"/"),
+			Metadata:  &Metadata{Kind: KindBPFPinnedObject},
+			Locations: []string{"/" + p},
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to walk /%s: %w", bpffsPath, err)
+	}
+	return pkgs, nil
+}
+
+// taintFinding returns a finding for the loaded modules that taint the kernel
+// in a security-relevant way, or nil if there are none.
+func (e Extractor) taintFinding(modules []*extractor.Package) *inventory.GenericFinding {
+	var tainted []string
+	for _, p := range modules {
+		m := p.Metadata.(*Metadata)
+		var reasons []string
+		for _, flag := range m.TaintFlags {
+			if desc, ok := taintFlagDescriptions[flag]; ok {
+				reasons = append(reasons, desc)
+			}
+		}
+		if len(reasons) > 0 {
+			tainted = append(tainted, fmt.Sprintf("%s (%s)", p.Name, strings.Join(reasons, ", ")))
+		}
+	}
+	if len(tainted) == 0 {
+		return nil
+	}
+	return &inventory.GenericFinding{
+		Adv: &inventory.GenericFindingAdvisory{
+			ID: &inventory.AdvisoryID{
+				Publisher: "SCALIBR",
+				Reference: "kernel-tainted-by-modules",
+			},
+			Title: "Kernel tainted by unsigned, out-of-tree or force-loaded modules",
+			Description: "The running kernel has modules loaded that weren't signed with a trusted " +
+				"key, weren't built with the kernel or were loaded while bypassing version checks. " +
+				"Such modules run with full kernel privileges outside of the distribution's update " +
+				"and review process and are a common way to install rootkits.",
+			Recommendation: "Verify that the listed modules are expected. Unload and remove unknown " +
+				"modules and enable module signature enforcement (module.sig_enforce=1).",
+			Sev: inventory.SeverityMedium,
+		},
+		Target: &inventory.GenericFindingTargetDetails{
+			Extra: "/" + modulesPath + ": " + strings.Join(tainted, ", "),
+		},
+		Plugins: []string{e.Name()},
+	}
+}
@@ -0,0 +1,193 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kernelruntime_test
+
+import (
+	"context"
+	"testing"
+	"testing/fstest"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/standalone"
+	"github.com/google/osv-scalibr/extractor/standalone/os/kernelruntime"
+	scalibrfs "github.com/google/osv-scalibr/fs"
+	"github.com/google/osv-scalibr/testing/extracttest"
+)
+
+const procModules = `nvidia_uvm 1794048 0 - Live 0xffffffffc2e00000 (POE)
+nvidia 56823808 2 nvidia_uvm,nvidia_modeset, Live 0xffffffffc1a00000 (PO)
+ext4 1081344 1 - Live 0xffffffffc0400000
+vboxdrv 696320 0 - Loading 0xffffffffc0900000 (OE)
+malformed
+`
+
+func TestExtract(t *testing.T) {
+	tests := []struct {
+		name             string
+		fsys             fstest.MapFS
+		wantPackages     []*extractor.Package
+		wantFindingExtra string
+	}{
+		{
+			name: "no_modules_and_no_bpffs",
+			fsys: fstest.MapFS{},
+		},
+		{
+			name: "modules_and_pinned_objects",
+			fsys: fstest.MapFS{
+				"proc/modules":                       {Data: []byte(procModules)},
+				"sys/module/nvidia/version":          {Data: []byte("550.54.14\n")},
+				"sys/module/nvidia/srcversion":       {Data: []byte("C2C4E6CB5A8C3A4F09EC51A\n")},
+				"sys/module/ext4/srcversion":         {Data: []byte("0D8F3B9AD9A0CEE6C1FBDC7\n")},
+				"sys/fs/bpf/tc/globals/cilium_calls": {},
+				"sys/fs/bpf/backdoor_prog":           {},
+				"sys/fs/bpf/cilium/nested/map_foo":   {},
+			},
+			wantPackages: []*extractor.Package{
+				{
+					Name: "nvidia_uvm",
+					Metadata: &kernelruntime.Metadata{
+						Kind:       kernelruntime.KindModule,
+						Size:       1794048,
+						State:      "Live",
+						TaintFlags: "POE",
+					},
+					Locations: []string{"/proc/modules"},
+				},
+				{
+					Name:    "nvidia",
+					Version: "550.54.14",
+					Metadata: &kernelruntime.Metadata{
+						Kind:          kernelruntime.KindModule,
+						Size:          56823808,
+						RefCount:      2,
+						Dependencies:  []string{"nvidia_uvm", "nvidia_modeset"},
+						State:         "Live",
+						TaintFlags:    "PO",
+						SourceVersion: "C2C4E6CB5A8C3A4F09EC51A",
+					},
+					Locations: []string{"/proc/modules"},
+				},
+				{
+					Name: "ext4",
+					Metadata: &kernelruntime.Metadata{
+						Kind:          kernelruntime.KindModule,
+						Size:          1081344,
+						RefCount:      1,
+						State:         "Live",
+						SourceVersion: "0D8F3B9AD9A0CEE6C1FBDC7",
+					},
+					Locations: []string{"/proc/modules"},
+				},
+				{
+					Name: "vboxdrv",
+					Metadata: &kernelruntime.Metadata{
+						Kind:       kernelruntime.KindModule,
+						Size:       696320,
+						State:      "Loading",
+						TaintFlags: "OE",
+					},
+					Locations: []string{"/proc/modules"},
+				},
+				{
+					Name:      "tc/globals/cilium_calls",
+					Metadata:  &kernelruntime.Metadata{Kind: kernelruntime.KindBPFPinnedObject},
+					Locations: []string{"/sys/fs/bpf/tc/globals/cilium_calls"},
+				},
+				{
+					Name:      "backdoor_prog",
+					Metadata:  &kernelruntime.Metadata{Kind: kernelruntime.KindBPFPinnedObject},
+					Locations: []string{"/sys/fs/bpf/backdoor_prog"},
+				},
+				{
+					Name:      "cilium/nested/map_foo",
+					Metadata:  &kernelruntime.Metadata{Kind: kernelruntime.KindBPFPinnedObject},
+					Locations: []string{"/sys/fs/bpf/cilium/nested/map_foo"},
+				},
+			},
+			wantFindingExtra: "/proc/modules: nvidia_uvm (out-of-tree, unsigned), nvidia (out-of-tree), vboxdrv (out-of-tree, unsigned)",
+		},
+		{
+			name: "untainted_modules",
+			fsys: fstest.MapFS{
+				"proc/modules": {Data: []byte("ext4 1081344 1 - Live 0xffffffffc0400000\n")},
+			},
+			wantPackages: []*extractor.Package{
+				{
+					Name: "ext4",
+					Metadata: &kernelruntime.Metadata{
+						Kind:     kernelruntime.KindModule,
+						Size:     1081344,
+						RefCount: 1,
+						State:    "Live",
+					},
+					Locations: []string{"/proc/modules"},
+				},
+			},
+		},
+		{
+			name: "permanent_module",
+			fsys: fstest.MapFS{
+				"proc/modules": {Data: []byte("forced 4096 - - Live 0xffffffffc0400000 (F)\n")},
+			},
+			wantPackages: []*extractor.Package{
+				{
+					Name: "forced",
+					Metadata: &kernelruntime.Metadata{
+						Kind:       kernelruntime.KindModule,
+						Size:       4096,
+						RefCount:   -1,
+						State:      "Live",
+						TaintFlags: "F",
+					},
+					Locations: []string{"/proc/modules"},
+				},
+			},
+			wantFindingExtra: "/proc/modules: forced (force-loaded)",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			e := kernelruntime.New()
+			input := &standalone.ScanInput{ScanRoot: &scalibrfs.ScanRoot{FS: tc.fsys}}
+
+			got, err := e.Extract(context.Background(), input)
+			if err != nil {
+				t.Fatalf("Extract() returned an error: %v", err)
+			}
+
+			if diff := cmp.Diff(tc.wantPackages, got.Packages, cmpopts.SortSlices(extracttest.PackageCmpLess), cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("Extract() packages diff (-want +got):\n%s", diff)
+			}
+
+			var gotExtra string
+			if len(got.GenericFindings) > 1 {
+				t.Fatalf("Extract() returned %d findings, want at most 1", len(got.GenericFindings))
+			}
+			for _, f := range got.GenericFindings {
+				if f.Adv.ID.Reference != "kernel-tainted-by-modules" {
+					t.Errorf("Extract() returned finding %q, want %q", f.Adv.ID.Reference, "kernel-tainted-by-modules")
+				}
+				gotExtra = f.Target.Extra
+			}
+			if gotExtra != tc.wantFindingExtra {
+				t.Errorf("Extract() finding extra: got %q, want %q", gotExtra, tc.wantFindingExtra)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kernelruntime
+
+import (
+	pb "github.com/google/osv-scalibr/binary/proto/scan_result_go_proto"
+)
+
+// Metadata contains metadata about a kernel module or eBPF object in the running kernel.
+type Metadata struct {
+	// Kind is either KindModule or KindBPFPinnedObject. The remaining fields
+	// are only set for kernel modules.
+	Kind string
+	// The memory size of the module in bytes.
+	Size int64
+	// The number of references to the module, or -1 if the module can't be unloaded.
+	RefCount int64
+	// The names of the loaded modules that depend on this module.
+	Dependencies []string
+	// The load state of the module, e.g. "Live".
+	State string
+	// The taint flags of the module, e.g. "OE" for an unsigned out-of-tree module.
+	TaintFlags string
+	// The checksum of the module's source code (modinfo srcversion).
+	SourceVersion string
+}
+
+// SetProto sets the KernelRuntimeMetadata field in the Package proto.
+func (m *Metadata) SetProto(p *pb.Package) {
+	if m == nil {
+		return
+	}
+	if p == nil {
+		return
+	}
+
+	p.Metadata = &pb.Package_KernelRuntimeMetadata{
+		KernelRuntimeMetadata: &pb.KernelRuntimeMetadata{
+			Kind:          m.Kind,
+			Size:          m.Size,
+			RefCount:      m.RefCount,
+			Dependencies:  m.Dependencies,
+			State:         m.State,
+			TaintFlags:    m.TaintFlags,
+			SourceVersion: m.SourceVersion,
+		},
+	}
+}
+
+// ToStruct converts the KernelRuntimeMetadata proto to a Metadata struct.
+func ToStruct(m *pb.KernelRuntimeMetadata) *Metadata {
+	if m == nil {
+		return nil
+	}
+
+	return &Metadata{
+		Kind:          m.GetKind(),
+		Size:          m.GetSize(),
+		RefCount:      m.GetRefCount(),
+		Dependencies:  m.GetDependencies(),
+		State:         m.GetState(),
+		TaintFlags:    m.GetTaintFlags(),
+		SourceVersion: m.GetSourceVersion(),
+	}
+}
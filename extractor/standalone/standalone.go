@@ -22,7 +22,9 @@ import (
 	"github.com/google/osv-scalibr/extractor"
 	scalibrfs "github.com/google/osv-scalibr/fs"
 	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/log"
 	"github.com/google/osv-scalibr/plugin"
+	"github.com/google/osv-scalibr/stats/resourceusage"
 )
 
 // Extractor is an interface for plugins that extract information independently. For
@@ -37,6 +39,8 @@ type Extractor interface {
 type Config struct {
 	Extractors []Extractor
 	ScanRoot   *scalibrfs.ScanRoot
+	// Optional: Records the resources used by each extractor.
+	ResourceTracker *resourceusage.Tracker
 }
 
 // ScanInput provides information for the extractor about the scan.
@@ -64,10 +68,12 @@ func Run(ctx context.Context, config *Config) (inventory.Inventory, []*plugin.St
 	inv := inventory.Inventory{}
 	for _, extractor := range config.Extractors {
 		if ctx.Err() != nil {
-			return inventory.Inventory{}, nil, ctx.Err()
+			return inv, statuses, ctx.Err()
 		}
 
-		exInv, err := extractor.Extract(ctx, scanInput)
+		run := config.ResourceTracker.StartPlugin(extractor.Name())
+		exInv, err := extractor.Extract(log.NewContext(ctx, log.KeyPlugin, extractor.Name()), scanInput)
+		run.Stop(0)
 		if err != nil {
 			statuses = append(statuses, plugin.StatusFromErr(extractor, false, err))
 			continue
@@ -0,0 +1,113 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package hive opens the registry hives stored in a scanned filesystem, e.g. a
+// mounted disk image of a Windows system.
+package hive
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"strings"
+
+	"github.com/google/osv-scalibr/common/windows/registry"
+)
+
+// Locations of the hives relative to the system drive.
+const (
+	SystemPath   = "Windows/System32/config/SYSTEM"
+	SoftwarePath = "Windows/System32/config/SOFTWARE"
+)
+
+// FindFile returns the path of the file p in fsys. Path elements are matched
+// case-insensitively, like on Windows. A fs.ErrNotExist error is returned if
+// the file doesn't exist.
+func FindFile(fsys fs.FS, p string) (string, error) {
+	if _, err := fs.Stat(fsys, p); err == nil {
+		return p, nil
+	}
+	found := "."
+	for _, elem := range strings.Split(p, "/") {
+		entries, err := fs.ReadDir(fsys, found)
+		if err != nil {
+			return "", err
+		}
+		match := ""
+		for _, entry := range entries {
+			if strings.EqualFold(entry.Name(), elem) {
+				match = entry.Name()
+				break
+			}
+		}
+		if match == "" {
+			return "", fs.ErrNotExist
+		}
+		found = path.Join(found, match)
+	}
+	return found, nil
+}
+
+// Open parses the hive stored at p in fsys and returns it together with the
+// path it was found at. Keys of the returned registry are relative to the root
+// of the hive, e.g. "Microsoft\Windows" in the SOFTWARE hive, and the hive
+// argument of OpenKey is ignored. A fs.ErrNotExist error is returned if the
+// hive doesn't exist.
+func Open(fsys fs.FS, p string) (registry.Registry, string, error) {
+	found, err := FindFile(fsys, p)
+	if err != nil {
+		return nil, "", err
+	}
+	f, err := fsys.Open(found)
+	if err != nil {
+		return nil, "", err
+	}
+	// The hive is parsed lazily so the file stays open until the registry is
+	// closed.
+	readerAt, ok := f.(io.ReaderAt)
+	if !ok {
+		content, err := io.ReadAll(f)
+		f.Close()
+		if err != nil {
+			return nil, "", err
+		}
+		readerAt, f = bytes.NewReader(content), nil
+	}
+	reg, err := registry.NewOfflineRegistry(readerAt)
+	if err != nil {
+		if f != nil {
+			f.Close()
+		}
+		return nil, "", fmt.Errorf("failed to parse registry hive %s: %w", found, err)
+	}
+	return &fileRegistry{Registry: reg, f: f}, found, nil
+}
+
+// fileRegistry is a registry that closes the hive file when it's closed.
+type fileRegistry struct {
+	registry.Registry
+	f fs.File
+}
+
+func (r *fileRegistry) Close() error {
+	err := r.Registry.Close()
+	if r.f != nil {
+		if ferr := r.f.Close(); err == nil {
+			err = ferr
+		}
+	}
+	return err
+}
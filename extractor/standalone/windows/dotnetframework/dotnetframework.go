@@ -0,0 +1,249 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package dotnetframework extracts the installed .NET Framework versions of a
+// Windows system from the registry, either from the live registry on Windows or
+// from the SOFTWARE hive of the scan root, e.g. a mounted disk image.
+package dotnetframework
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io/fs"
+	"strings"
+
+	"github.com/google/osv-scalibr/common/windows/registry"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/standalone"
+	"github.com/google/osv-scalibr/extractor/standalone/windows/common/hive"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/log"
+	"github.com/google/osv-scalibr/plugin"
+)
+
+const (
+	// Name is the unique name of this extractor.
+	Name = "windows/dotnetframework"
+	// PackageName is the name of the reported packages, which matches the
+	// product name used in CPEs.
+	PackageName = ".net_framework"
+
+	// ndpPath is the path of the .NET Framework setup key in the SOFTWARE hive.
+	ndpPath = `Microsoft\NET Framework Setup\NDP`
+)
+
+// releases maps the minimum Release value of .NET Framework 4.5 and later
+// to the product version, see
+// https://learn.microsoft.com/en-us/dotnet/framework/install/how-to-determine-which-versions-are-installed
+var releases = []struct {
+	minRelease uint32
+	version    string
+}{
+	{533320, "4.8.1"},
+	{528040, "4.8"},
+	{461808, "4.7.2"},
+	{461308, "4.7.1"},
+	{460798, "4.7"},
+	{394802, "4.6.2"},
+	{394254, "4.6.1"},
+	{393295, "4.6"},
+	{379893, "4.5.2"},
+	{378675, "4.5.1"},
+	{378389, "4.5"},
+}
+
+// Config is the configuration for the Extractor.
+type Config struct {
+	// Opener is the registry engine to read the versions from. If nil, the
+	// SOFTWARE hive of the scan root is parsed.
+	Opener registry.Opener
+}
+
+// DefaultConfig returns the default configuration of the extractor. On
+// Windows it reads the live registry.
+func DefaultConfig() Config {
+	return Config{Opener: defaultOpener()}
+}
+
+// Extractor extracts the installed .NET Framework versions.
+type Extractor struct {
+	opener registry.Opener
+}
+
+// New creates a new Extractor from a given configuration.
+func New(cfg Config) standalone.Extractor {
+	return &Extractor{opener: cfg.Opener}
+}
+
+// NewDefault returns an extractor with the default config settings.
+func NewDefault() standalone.Extractor {
+	return New(DefaultConfig())
+}
+
+// Name of the extractor.
+func (e Extractor) Name() string { return Name }
+
+// Version of the extractor.
+func (e Extractor) Version() int { return 0 }
+
+// Requirements of the extractor.
+func (e Extractor) Requirements() *plugin.Capabilities {
+	if e.opener == nil {
+		// Everything is read from the scan root.
+		return &plugin.Capabilities{}
+	}
+	return &plugin.Capabilities{OS: plugin.OSWindows, RunningSystem: true}
+}
+
+// Extract extracts the installed .NET Framework versions.
+func (e *Extractor) Extract(ctx context.Context, input *standalone.ScanInput) (inventory.Inventory, error) {
+	reg, root, location, err := e.openRegistry(input.ScanRoot.FS)
+	if err != nil {
+		return inventory.Inventory{}, err
+	}
+	if reg == nil {
+		return inventory.Inventory{}, nil
+	}
+	defer reg.Close()
+
+	key, err := reg.OpenKey("HKLM", root+ndpPath)
+	if err != nil {
+		// .NET Framework isn't installed.
+		return inventory.Inventory{}, nil
+	}
+	defer key.Close()
+	names, err := key.SubkeyNames()
+	if err != nil {
+		return inventory.Inventory{}, fmt.Errorf("failed to list %s: %w", ndpPath, err)
+	}
+
+	var pkgs []*extractor.Package
+	for _, name := range names {
+		if err := ctx.Err(); err != nil {
+			return inventory.Inventory{}, fmt.Errorf("%s halted due to context error: %w", e.Name(), err)
+		}
+		var pkg *extractor.Package
+		switch {
+		case name == "v4":
+			pkg = readV4(reg, root+ndpPath+`\v4`)
+		case name == "v4.0" || !strings.HasPrefix(name, "v"):
+			// v4.0 only holds a placeholder value for compatibility, other keys
+			// like CDF aren't versions.
+			continue
+		default:
+			pkg = readLegacy(reg, root+ndpPath, name)
+		}
+		if pkg == nil {
+			continue
+		}
+		pkg.Locations = []string{location}
+		pkgs = append(pkgs, pkg)
+	}
+	return inventory.Inventory{Packages: pkgs}, nil
+}
+
+// openRegistry opens the configured registry, or the SOFTWARE hive of the scan
+// root if none is configured. It returns the prefix of the SOFTWARE keys in the
+// registry and the location reported for the packages. A nil registry is
+// returned if the scan root has no SOFTWARE hive.
+func (e *Extractor) openRegistry(fsys fs.FS) (registry.Registry, string, string, error) {
+	if e.opener != nil {
+		reg, err := e.opener.Open()
+		if err != nil {
+			return nil, "", "", fmt.Errorf("failed to open the registry: %w", err)
+		}
+		return reg, `SOFTWARE\`, `HKLM\SOFTWARE\` + ndpPath, nil
+	}
+	reg, p, err := hive.Open(fsys, hive.SoftwarePath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, "", "", nil
+	}
+	if err != nil {
+		return nil, "", "", err
+	}
+	return reg, "", "/" + p, nil
+}
+
+// readV4 reads the .NET Framework 4 installation. 4.5 and later are in-place
+// updates of 4.0 and are told apart by the Release value.
+func readV4(reg registry.Registry, keyPath string) *extractor.Package {
+	key, err := reg.OpenKey("HKLM", keyPath+`\Full`)
+	if err != nil {
+		// The client profile alone is only installed on very old systems.
+		if key, err = reg.OpenKey("HKLM", keyPath+`\Client`); err != nil {
+			return nil
+		}
+	}
+	defer key.Close()
+	if install, ok := dword(key, "Install"); ok && install != 1 {
+		return nil
+	}
+	m := &Metadata{}
+	m.FullVersion, _ = key.ValueString("Version")
+	version := "4.0"
+	if release, ok := dword(key, "Release"); ok {
+		m.Release = release
+		for _, r := range releases {
+			if release >= r.minRelease {
+				version = r.version
+				break
+			}
+		}
+	}
+	return newPackage(version, m)
+}
+
+// readLegacy reads the installation of .NET Framework 3.5 and older, which
+// have one key per version, e.g. v2.0.50727.
+func readLegacy(reg registry.Registry, ndpKey, name string) *extractor.Package {
+	keyPath := ndpKey + `\` + name
+	key, err := reg.OpenKey("HKLM", keyPath)
+	if err != nil {
+		log.Debugf("%s: failed to open %s: %v", Name, keyPath, err)
+		return nil
+	}
+	defer key.Close()
+	if install, ok := dword(key, "Install"); !ok || install != 1 {
+		return nil
+	}
+	m := &Metadata{}
+	m.FullVersion, _ = key.ValueString("Version")
+	m.ServicePack, _ = dword(key, "SP")
+
+	// Key names hold the build number for versions before 3.0, e.g. v2.0.50727.
+	version := strings.TrimPrefix(name, "v")
+	if parts := strings.Split(version, "."); len(parts) > 2 {
+		version = strings.Join(parts[:2], ".")
+	}
+	return newPackage(version, m)
+}
+
+func newPackage(version string, m *Metadata) *extractor.Package {
+	return &extractor.Package{
+		Name:     PackageName,
+		Version:  version,
+		PURLType: "windows",
+		Metadata: m,
+	}
+}
+
+func dword(key registry.Key, name string) (uint32, bool) {
+	data, err := key.ValueBytes(name)
+	if err != nil || len(data) < 4 {
+		return 0, false
+	}
+	return binary.LittleEndian.Uint32(data), true
+}
@@ -0,0 +1,174 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dotnetframework_test
+
+import (
+	"context"
+	"encoding/binary"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/osv-scalibr/common/windows/registry"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/standalone"
+	"github.com/google/osv-scalibr/extractor/standalone/windows/dotnetframework"
+	scalibrfs "github.com/google/osv-scalibr/fs"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/testing/mockregistry"
+)
+
+const ndp = `SOFTWARE\Microsoft\NET Framework Setup\NDP`
+
+func dword(name string, v uint32) *mockregistry.MockValue {
+	return &mockregistry.MockValue{VName: name, VData: binary.LittleEndian.AppendUint32(nil, v)}
+}
+
+func str(name, v string) *mockregistry.MockValue {
+	return &mockregistry.MockValue{VName: name, VDataString: v}
+}
+
+func ndpKeys(versions ...string) map[string]registry.Key {
+	var subkeys []registry.Key
+	for _, v := range versions {
+		subkeys = append(subkeys, &mockregistry.MockKey{KName: v})
+	}
+	return map[string]registry.Key{
+		ndp: &mockregistry.MockKey{KName: "NDP", KSubkeys: subkeys},
+	}
+}
+
+func TestExtract(t *testing.T) {
+	location := []string{`HKLM\` + ndp}
+	tests := []struct {
+		desc string
+		keys map[string]registry.Key
+		want []*extractor.Package
+	}{
+		{
+			desc: "not_installed",
+			keys: map[string]registry.Key{},
+		},
+		{
+			desc: "all_versions",
+			keys: func() map[string]registry.Key {
+				keys := ndpKeys("CDF", "v2.0.50727", "v3.0", "v3.5", "v4", "v4.0")
+				keys[ndp+`\v2.0.50727`] = &mockregistry.MockKey{
+					KName:   "v2.0.50727",
+					KValues: []registry.Value{dword("Install", 1), str("Version", "2.0.50727.4927"), dword("SP", 2)},
+				}
+				// Not installed.
+				keys[ndp+`\v3.0`] = &mockregistry.MockKey{
+					KName:   "v3.0",
+					KValues: []registry.Value{str("Version", "3.0.30729.4926")},
+				}
+				keys[ndp+`\v3.5`] = &mockregistry.MockKey{
+					KName:   "v3.5",
+					KValues: []registry.Value{dword("Install", 1), str("Version", "3.5.30729.4926"), dword("SP", 1)},
+				}
+				keys[ndp+`\v4\Full`] = &mockregistry.MockKey{
+					KName:   "Full",
+					KValues: []registry.Value{dword("Install", 1), str("Version", "4.8.09037"), dword("Release", 533325)},
+				}
+				return keys
+			}(),
+			want: []*extractor.Package{
+				{
+					Name:      dotnetframework.PackageName,
+					Version:   "2.0",
+					PURLType:  "windows",
+					Metadata:  &dotnetframework.Metadata{FullVersion: "2.0.50727.4927", ServicePack: 2},
+					Locations: location,
+				},
+				{
+					Name:      dotnetframework.PackageName,
+					Version:   "3.5",
+					PURLType:  "windows",
+					Metadata:  &dotnetframework.Metadata{FullVersion: "3.5.30729.4926", ServicePack: 1},
+					Locations: location,
+				},
+				{
+					Name:      dotnetframework.PackageName,
+					Version:   "4.8.1",
+					PURLType:  "windows",
+					Metadata:  &dotnetframework.Metadata{FullVersion: "4.8.09037", Release: 533325},
+					Locations: location,
+				},
+			},
+		},
+		{
+			desc: "v4_release_between_versions",
+			keys: func() map[string]registry.Key {
+				keys := ndpKeys("v4")
+				keys[ndp+`\v4\Full`] = &mockregistry.MockKey{
+					KName:   "Full",
+					KValues: []registry.Value{dword("Install", 1), str("Version", "4.7.03062"), dword("Release", 461814)},
+				}
+				return keys
+			}(),
+			want: []*extractor.Package{{
+				Name:      dotnetframework.PackageName,
+				Version:   "4.7.2",
+				PURLType:  "windows",
+				Metadata:  &dotnetframework.Metadata{FullVersion: "4.7.03062", Release: 461814},
+				Locations: location,
+			}},
+		},
+		{
+			desc: "v4_0_client_profile",
+			keys: func() map[string]registry.Key {
+				keys := ndpKeys("v4")
+				keys[ndp+`\v4\Client`] = &mockregistry.MockKey{
+					KName:   "Client",
+					KValues: []registry.Value{dword("Install", 1), str("Version", "4.0.30319")},
+				}
+				return keys
+			}(),
+			want: []*extractor.Package{{
+				Name:      dotnetframework.PackageName,
+				Version:   "4.0",
+				PURLType:  "windows",
+				Metadata:  &dotnetframework.Metadata{FullVersion: "4.0.30319"},
+				Locations: location,
+			}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			opener := mockregistry.NewOpener(&mockregistry.MockRegistry{Keys: tc.keys})
+			e := dotnetframework.New(dotnetframework.Config{Opener: opener})
+			input := &standalone.ScanInput{ScanRoot: &scalibrfs.ScanRoot{FS: scalibrfs.DirFS(t.TempDir())}}
+			got, err := e.Extract(context.Background(), input)
+			if err != nil {
+				t.Fatalf("Extract(): %v", err)
+			}
+			if diff := cmp.Diff(inventory.Inventory{Packages: tc.want}, got); diff != "" {
+				t.Errorf("Extract() returned unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestExtract_NoHive(t *testing.T) {
+	e := dotnetframework.New(dotnetframework.Config{})
+	input := &standalone.ScanInput{ScanRoot: &scalibrfs.ScanRoot{FS: scalibrfs.DirFS(t.TempDir())}}
+	got, err := e.Extract(context.Background(), input)
+	if err != nil {
+		t.Fatalf("Extract(): %v", err)
+	}
+	if len(got.Packages) != 0 {
+		t.Errorf("Extract() without a SOFTWARE hive returned %v, want no packages", got.Packages)
+	}
+}
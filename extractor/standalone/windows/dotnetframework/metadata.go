@@ -0,0 +1,60 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dotnetframework
+
+import (
+	pb "github.com/google/osv-scalibr/binary/proto/scan_result_go_proto"
+)
+
+// Metadata contains metadata about an installed .NET Framework version.
+type Metadata struct {
+	// FullVersion is the full version of the installation, e.g. "4.8.09037".
+	FullVersion string
+	// Release is the Release value of .NET Framework 4.5 and later, e.g. 528040.
+	Release uint32
+	// ServicePack is the installed service pack of versions before 4.0.
+	ServicePack uint32
+}
+
+// SetProto sets the DotnetFrameworkMetadata field in the Package proto.
+func (m *Metadata) SetProto(p *pb.Package) {
+	if m == nil {
+		return
+	}
+	if p == nil {
+		return
+	}
+
+	p.Metadata = &pb.Package_DotnetFrameworkMetadata{
+		DotnetFrameworkMetadata: &pb.DotnetFrameworkMetadata{
+			FullVersion: m.FullVersion,
+			Release:     m.Release,
+			ServicePack: m.ServicePack,
+		},
+	}
+}
+
+// ToStruct converts the DotnetFrameworkMetadata proto to a Metadata struct.
+func ToStruct(m *pb.DotnetFrameworkMetadata) *Metadata {
+	if m == nil {
+		return nil
+	}
+
+	return &Metadata{
+		FullVersion: m.GetFullVersion(),
+		Release:     m.GetRelease(),
+		ServicePack: m.GetServicePack(),
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package services
+
+import (
+	pb "github.com/google/osv-scalibr/binary/proto/scan_result_go_proto"
+)
+
+// Metadata contains metadata about a Windows service or scheduled task.
+type Metadata struct {
+	// Kind is either KindService or KindScheduledTask.
+	Kind        string
+	DisplayName string
+	// The raw command line: the ImagePath of services or the command and arguments of the task
+	// action.
+	CommandLine string
+	// The resolved Windows path of the binary that's run. For services hosted by svchost.exe,
+	// this is the service DLL.
+	BinaryPath string
+	Arguments  string
+	// The service start type, e.g. "auto" or "manual". Not set for tasks.
+	StartType string
+	// The service type, e.g. "own_process" or "kernel_driver". Not set for tasks.
+	ServiceType string
+	// The account the service or task runs as, e.g. "LocalSystem" or "S-1-5-18".
+	Account  string
+	Disabled bool
+	// Hex-encoded digests of the binary keyed by algorithm, e.g. "sha256". Only set if the
+	// binary was found in the scan root.
+	Hashes map[string]string
+}
+
+// SetProto sets the WindowsServiceMetadata field in the Package proto.
+func (m *Metadata) SetProto(p *pb.Package) {
+	if m == nil {
+		return
+	}
+	if p == nil {
+		return
+	}
+
+	p.Metadata = &pb.Package_WindowsServiceMetadata{
+		WindowsServiceMetadata: &pb.WindowsServiceMetadata{
+			Kind:        m.Kind,
+			DisplayName: m.DisplayName,
+			CommandLine: m.CommandLine,
+			BinaryPath:  m.BinaryPath,
+			Arguments:   m.Arguments,
+			StartType:   m.StartType,
+			ServiceType: m.ServiceType,
+			Account:     m.Account,
+			Disabled:    m.Disabled,
+			Hashes:      m.Hashes,
+		},
+	}
+}
+
+// ToStruct converts the WindowsServiceMetadata proto to a Metadata struct.
+func ToStruct(m *pb.WindowsServiceMetadata) *Metadata {
+	if m == nil {
+		return nil
+	}
+
+	return &Metadata{
+		Kind:        m.GetKind(),
+		DisplayName: m.GetDisplayName(),
+		CommandLine: m.GetCommandLine(),
+		BinaryPath:  m.GetBinaryPath(),
+		Arguments:   m.GetArguments(),
+		StartType:   m.GetStartType(),
+		ServiceType: m.GetServiceType(),
+		Account:     m.GetAccount(),
+		Disabled:    m.GetDisabled(),
+		Hashes:      m.GetHashes(),
+	}
+}
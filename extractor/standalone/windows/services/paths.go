@@ -0,0 +1,98 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package services
+
+import (
+	"regexp"
+	"strings"
+)
+
+// systemRoot is the Windows directory. Services and tasks of offline systems can't be resolved
+// against the environment of the scanned system, so the default location is assumed.
+const systemRoot = `C:\Windows`
+
+// envVars are the environment variables commonly used in service and task command lines.
+var envVars = map[string]string{
+	"systemroot":         systemRoot,
+	"windir":             systemRoot,
+	"systemdrive":        `C:`,
+	"programfiles":       `C:\Program Files`,
+	"programfiles(x86)":  `C:\Program Files (x86)`,
+	"programw6432":       `C:\Program Files`,
+	"programdata":        `C:\ProgramData`,
+	"allusersprofile":    `C:\ProgramData`,
+	"commonprogramfiles": `C:\Program Files\Common Files`,
+}
+
+var (
+	envVarRe = regexp.MustCompile(`%([^%]+)%`)
+	// binaryExtRe matches the end of the binary in unquoted command lines with spaces, e.g.
+	// C:\Program Files\App\app.exe -service
+	binaryExtRe = regexp.MustCompile(`(?i)\.(exe|sys|dll|com|bat|cmd)(\s|$)`)
+)
+
+// ResolveBinaryPath returns the absolute Windows path of the binary started by the given
+// service ImagePath or task command line, and the arguments passed to it. It handles quoted and
+// unquoted paths with spaces, environment variables, NT object paths such as
+// \??\C:\Windows\foo.sys and the \SystemRoot\ and System32\ prefixes of driver paths.
+func ResolveBinaryPath(commandLine string) (binaryPath, args string) {
+	cl := strings.TrimSpace(envVarRe.ReplaceAllStringFunc(commandLine, func(v string) string {
+		if val, ok := envVars[strings.ToLower(strings.Trim(v, "%"))]; ok {
+			return val
+		}
+		return v
+	}))
+	cl = strings.TrimPrefix(cl, `\??\`)
+
+	if strings.HasPrefix(cl, `"`) {
+		if end := strings.Index(cl[1:], `"`); end >= 0 {
+			binaryPath, args = cl[1:end+1], cl[end+2:]
+		} else {
+			binaryPath = cl[1:]
+		}
+	} else if loc := binaryExtRe.FindStringSubmatchIndex(cl); loc != nil {
+		// Split after the extension.
+		binaryPath, args = cl[:loc[3]], cl[loc[3]:]
+	} else if before, after, ok := strings.Cut(cl, " "); ok {
+		binaryPath, args = before, after
+	} else {
+		binaryPath = cl
+	}
+
+	switch lower := strings.ToLower(binaryPath); {
+	case strings.HasPrefix(lower, `\systemroot\`):
+		binaryPath = systemRoot + binaryPath[len(`\SystemRoot`):]
+	case strings.HasPrefix(lower, `system32\`), strings.HasPrefix(lower, `syswow64\`):
+		// Driver paths relative to the Windows directory.
+		binaryPath = systemRoot + `\` + binaryPath
+	case !isAbs(binaryPath) && !strings.Contains(binaryPath, `\`) && binaryPath != "":
+		// Binaries without a directory are looked up in System32 first.
+		binaryPath = systemRoot + `\System32\` + binaryPath
+	}
+	return binaryPath, strings.TrimSpace(args)
+}
+
+func isAbs(p string) bool {
+	return len(p) >= 3 && p[1] == ':' && (p[2] == '\\' || p[2] == '/')
+}
+
+// scanRootPath converts an absolute Windows path to a path relative to the scan root, which is
+// assumed to be the system drive. It returns an empty string for relative or UNC paths.
+func scanRootPath(winPath string) string {
+	if !isAbs(winPath) {
+		return ""
+	}
+	return strings.ReplaceAll(strings.TrimLeft(winPath[3:], `\/`), `\`, "/")
+}
@@ -0,0 +1,326 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package services extracts the Windows services and scheduled tasks of a system together with
+// the binaries they run, so that detectors can match the binaries against known-bad hashes or
+// paths. Services are read from the live registry on Windows, which holds the service control
+// manager's database, or from the SYSTEM hive of the scan root, e.g. a mounted disk image.
+// Scheduled tasks are read from the task XML files under Windows\System32\Tasks.
+package services
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io/fs"
+
+	"github.com/google/osv-scalibr/common/windows/registry"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/standalone"
+	"github.com/google/osv-scalibr/extractor/standalone/windows/common/hive"
+	"github.com/google/osv-scalibr/hashing"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/log"
+	"github.com/google/osv-scalibr/plugin"
+)
+
+const (
+	// Name is the unique name of this extractor.
+	Name = "windows/services"
+
+	// liveServicesRoot is the services key of the live registry under HKLM.
+	liveServicesRoot = `SYSTEM\CurrentControlSet\Services`
+	// defaultMaxHashFileSizeBytes is the default size limit of the hashed binaries.
+	defaultMaxHashFileSizeBytes = 100 * 1024 * 1024
+)
+
+// Kinds of the extracted entries.
+const (
+	KindService       = "service"
+	KindScheduledTask = "scheduled_task"
+)
+
+// Config is the configuration for the Extractor.
+type Config struct {
+	// Opener is the registry engine to read the services from. If nil, the SYSTEM hive of the
+	// scan root is parsed.
+	Opener registry.Opener
+	// Hashing selects the digests computed for the service and task binaries. If nil or empty,
+	// the binaries aren't hashed.
+	Hashing *hashing.Config
+	// MaxHashFileSizeBytes is the maximum size of the binaries that are hashed. If 0, no limit
+	// is applied.
+	MaxHashFileSizeBytes int64
+}
+
+// DefaultConfig returns the default configuration of the extractor. On Windows it reads the
+// services from the live registry.
+func DefaultConfig() Config {
+	return Config{
+		Opener:               defaultOpener(),
+		Hashing:              hashing.DefaultConfig(),
+		MaxHashFileSizeBytes: defaultMaxHashFileSizeBytes,
+	}
+}
+
+// Extractor extracts Windows services and scheduled tasks.
+type Extractor struct {
+	opener               registry.Opener
+	hashing              *hashing.Config
+	maxHashFileSizeBytes int64
+}
+
+// New creates a new Extractor from a given configuration.
+func New(cfg Config) standalone.Extractor {
+	return &Extractor{
+		opener:               cfg.Opener,
+		hashing:              cfg.Hashing,
+		maxHashFileSizeBytes: cfg.MaxHashFileSizeBytes,
+	}
+}
+
+// NewDefault returns an extractor with the default config settings.
+func NewDefault() standalone.Extractor {
+	return New(DefaultConfig())
+}
+
+// SetHashingConfig sets the hashing config used when hashing the binaries.
+func (e *Extractor) SetHashingConfig(cfg *hashing.Config) { e.hashing = cfg }
+
+// Name of the extractor.
+func (e Extractor) Name() string { return Name }
+
+// Version of the extractor.
+func (e Extractor) Version() int { return 0 }
+
+// Requirements of the extractor.
+func (e Extractor) Requirements() *plugin.Capabilities {
+	if e.opener == nil {
+		// Everything is read from the scan root.
+		return &plugin.Capabilities{}
+	}
+	return &plugin.Capabilities{OS: plugin.OSWindows, RunningSystem: true}
+}
+
+// Extract extracts the services and scheduled tasks of the scanned system.
+func (e *Extractor) Extract(ctx context.Context, input *standalone.ScanInput) (inventory.Inventory, error) {
+	fsys := input.ScanRoot.FS
+
+	services, err := e.extractServices(ctx, fsys)
+	if err != nil {
+		return inventory.Inventory{}, err
+	}
+	tasks, err := e.extractTasks(ctx, fsys)
+	if err != nil {
+		return inventory.Inventory{}, err
+	}
+	return inventory.Inventory{Packages: append(services, tasks...)}, nil
+}
+
+func (e *Extractor) extractServices(ctx context.Context, fsys fs.FS) ([]*extractor.Package, error) {
+	reg, location, err := e.openRegistry(fsys)
+	if err != nil {
+		return nil, err
+	}
+	if reg == nil {
+		return nil, nil
+	}
+	defer reg.Close()
+
+	root, err := servicesRoot(reg)
+	if err != nil {
+		return nil, err
+	}
+	key, err := reg.OpenKey("HKLM", root)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", root, err)
+	}
+	defer key.Close()
+	names, err := key.SubkeyNames()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %s: %w", root, err)
+	}
+
+	var pkgs []*extractor.Package
+	for _, name := range names {
+		if err := ctx.Err(); err != nil {
+			return nil, fmt.Errorf("%s halted due to context error: %w", e.Name(), err)
+		}
+		m, err := readService(reg, root+`\`+name)
+		if err != nil {
+			log.Debugf("%s: skipping service %q: %v", e.Name(), name, err)
+			continue
+		}
+		if m == nil {
+			// Not a service with a binary, e.g. a driver group or event log source.
+			continue
+		}
+		pkgs = append(pkgs, e.newPackage(fsys, name, m, location))
+	}
+	return pkgs, nil
+}
+
+// openRegistry opens the configured registry, or the SYSTEM hive of the scan root if none is
+// configured. It also returns the location reported for services whose binary wasn't found.
+// A nil registry is returned if the scan root has no SYSTEM hive.
+func (e *Extractor) openRegistry(fsys fs.FS) (registry.Registry, string, error) {
+	if e.opener != nil {
+		reg, err := e.opener.Open()
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to open the registry: %w", err)
+		}
+		return reg, `HKLM\` + liveServicesRoot, nil
+	}
+
+	reg, p, err := hive.Open(fsys, hive.SystemPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, "", nil
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	return reg, "/" + p, nil
+}
+
+// servicesRoot returns the path of the services key. Offline SYSTEM hives aren't mounted under
+// HKLM\SYSTEM and don't have the CurrentControlSet link, so the current control set is looked
+// up from the Select key instead.
+func servicesRoot(reg registry.Registry) (string, error) {
+	if key, err := reg.OpenKey("HKLM", liveServicesRoot); err == nil {
+		key.Close()
+		return liveServicesRoot, nil
+	}
+	key, err := reg.OpenKey("HKLM", "Select")
+	if err != nil {
+		return "", errors.New("failed to find the current control set")
+	}
+	defer key.Close()
+	data, err := key.ValueBytes("Current")
+	if err != nil || len(data) < 4 {
+		return "", errors.New("failed to find the current control set")
+	}
+	return fmt.Sprintf(`ControlSet%03d\Services`, binary.LittleEndian.Uint32(data)), nil
+}
+
+// readService reads the service under the given key path. It returns nil if the key doesn't
+// describe a service binary.
+func readService(reg registry.Registry, keyPath string) (*Metadata, error) {
+	key, err := reg.OpenKey("HKLM", keyPath)
+	if err != nil {
+		return nil, err
+	}
+	defer key.Close()
+
+	imagePath, err := key.ValueString("ImagePath")
+	if err != nil || imagePath == "" {
+		return nil, nil
+	}
+	m := &Metadata{Kind: KindService, CommandLine: imagePath}
+	m.BinaryPath, m.Arguments = ResolveBinaryPath(imagePath)
+	m.DisplayName, _ = key.ValueString("DisplayName")
+	m.Account, _ = key.ValueString("ObjectName")
+	if start, ok := dword(key, "Start"); ok {
+		m.StartType = startTypes[start]
+		m.Disabled = start == startDisabled
+	}
+	if typ, ok := dword(key, "Type"); ok {
+		m.ServiceType = serviceType(typ)
+	}
+
+	// Services that share a svchost.exe process run the code of their service DLL.
+	if params, err := reg.OpenKey("HKLM", keyPath+`\Parameters`); err == nil {
+		if dll, err := params.ValueString("ServiceDll"); err == nil && dll != "" {
+			m.BinaryPath, _ = ResolveBinaryPath(dll)
+		}
+		params.Close()
+	}
+	return m, nil
+}
+
+const startDisabled = 4
+
+var startTypes = map[uint32]string{
+	0:             "boot",
+	1:             "system",
+	2:             "auto",
+	3:             "manual",
+	startDisabled: "disabled",
+}
+
+func serviceType(typ uint32) string {
+	switch {
+	case typ&0x1 != 0:
+		return "kernel_driver"
+	case typ&0x2 != 0:
+		return "file_system_driver"
+	case typ&0x10 != 0:
+		return "own_process"
+	case typ&0x20 != 0:
+		return "share_process"
+	default:
+		return ""
+	}
+}
+
+func dword(key registry.Key, name string) (uint32, bool) {
+	data, err := key.ValueBytes(name)
+	if err != nil || len(data) < 4 {
+		return 0, false
+	}
+	return binary.LittleEndian.Uint32(data), true
+}
+
+// newPackage returns the package for a service or task. Its location is the binary if it exists
+// in the scan root, or the place the entry was read from otherwise.
+func (e *Extractor) newPackage(fsys fs.FS, name string, m *Metadata, location string) *extractor.Package {
+	if p := scanRootPath(m.BinaryPath); p != "" {
+		if found, err := hive.FindFile(fsys, p); err == nil {
+			location = "/" + found
+			m.Hashes = e.hash(fsys, found)
+		}
+	}
+	return &extractor.Package{
+		Name:      name,
+		Metadata:  m,
+		Locations: []string{location},
+	}
+}
+
+func (e *Extractor) hash(fsys fs.FS, p string) map[string]string {
+	if e.hashing == nil || len(e.hashing.Algorithms) == 0 {
+		return nil
+	}
+	f, err := fsys.Open(p)
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+	if e.maxHashFileSizeBytes > 0 {
+		info, err := f.Stat()
+		if err != nil || info.Size() > e.maxHashFileSizeBytes {
+			return nil
+		}
+	}
+	digests, err := e.hashing.Digest(f)
+	if err != nil {
+		log.Debugf("%s: failed to hash %s: %v", e.Name(), p, err)
+		return nil
+	}
+	hashes := make(map[string]string, len(digests))
+	for a, d := range digests {
+		hashes[string(a)] = d
+	}
+	return hashes
+}
@@ -0,0 +1,328 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package services_test
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"testing"
+	"testing/fstest"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/osv-scalibr/common/windows/registry"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/standalone"
+	"github.com/google/osv-scalibr/extractor/standalone/windows/services"
+	scalibrfs "github.com/google/osv-scalibr/fs"
+	"github.com/google/osv-scalibr/hashing"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/testing/mockregistry"
+	"golang.org/x/text/encoding/unicode"
+)
+
+const defragTask = `<?xml version="1.0" encoding="UTF-16"?>
+<Task version="1.6" xmlns="http://schemas.microsoft.com/windows/2004/02/mit/task">
+  <Principals>
+    <Principal id="LocalSystem">
+      <UserId>S-1-5-18</UserId>
+    </Principal>
+  </Principals>
+  <Settings>
+    <Enabled>true</Enabled>
+  </Settings>
+  <Actions Context="LocalSystem">
+    <Exec>
+      <Command>%windir%\system32\defrag.exe</Command>
+      <Arguments>-c -h -o -$</Arguments>
+    </Exec>
+  </Actions>
+</Task>`
+
+const updaterTask = `<?xml version="1.0" encoding="UTF-8"?>
+<Task version="1.2" xmlns="http://schemas.microsoft.com/windows/2004/02/mit/task">
+  <Principals>
+    <Principal id="Author">
+      <GroupId>S-1-5-32-545</GroupId>
+    </Principal>
+  </Principals>
+  <Settings>
+    <Enabled>false</Enabled>
+  </Settings>
+  <Actions Context="Author">
+    <Exec>
+      <Command>powershell.exe</Command>
+      <Arguments>-enc AAAA</Arguments>
+    </Exec>
+    <ComHandler>
+      <ClassId>{00000000-0000-0000-0000-000000000000}</ClassId>
+    </ComHandler>
+  </Actions>
+</Task>`
+
+func dword(name string, v uint32) *mockregistry.MockValue {
+	return &mockregistry.MockValue{VName: name, VData: binary.LittleEndian.AppendUint32(nil, v)}
+}
+
+func str(name, v string) *mockregistry.MockValue {
+	return &mockregistry.MockValue{VName: name, VDataString: v}
+}
+
+func sha256Hex(content string) string {
+	h := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(h[:])
+}
+
+func TestExtract(t *testing.T) {
+	utf16Task, err := unicode.UTF16(unicode.LittleEndian, unicode.UseBOM).NewEncoder().String(defragTask)
+	if err != nil {
+		t.Fatalf("failed to encode task: %v", err)
+	}
+	fsys := fstest.MapFS{
+		"Program Files/Evil Corp/evil.exe":                                {Data: []byte("evil")},
+		"Windows/System32/dnsrslvr.dll":                                   {Data: []byte("dnsrslvr")},
+		"Windows/System32/defrag.exe":                                     {Data: []byte("defrag")},
+		"Windows/System32/Tasks/Microsoft/Windows/Defrag/ScheduledDefrag": {Data: []byte(utf16Task)},
+		"Windows/System32/Tasks/Updater":                                  {Data: []byte(updaterTask)},
+	}
+
+	const servicesKey = `ControlSet001\Services`
+	reg := &mockregistry.MockRegistry{
+		Keys: map[string]registry.Key{
+			"Select": &mockregistry.MockKey{
+				KName:   "Select",
+				KValues: []registry.Value{dword("Current", 1)},
+			},
+			servicesKey: &mockregistry.MockKey{
+				KName: "Services",
+				KSubkeys: []registry.Key{
+					&mockregistry.MockKey{KName: "EvilSvc"},
+					&mockregistry.MockKey{KName: "Dnscache"},
+					&mockregistry.MockKey{KName: "disk"},
+					&mockregistry.MockKey{KName: "EventLog"},
+				},
+			},
+			servicesKey + `\EvilSvc`: &mockregistry.MockKey{
+				KName: "EvilSvc",
+				KValues: []registry.Value{
+					str("ImagePath", `"C:\Program Files\Evil Corp\evil.exe" -run`),
+					str("DisplayName", "Evil Service"),
+					str("ObjectName", "LocalSystem"),
+					dword("Start", 2),
+					dword("Type", 0x10),
+				},
+			},
+			servicesKey + `\Dnscache`: &mockregistry.MockKey{
+				KName: "Dnscache",
+				KValues: []registry.Value{
+					str("ImagePath", `%SystemRoot%\system32\svchost.exe -k NetworkService -p`),
+					str("ObjectName", `NT AUTHORITY\NetworkService`),
+					dword("Start", 4),
+					dword("Type", 0x20),
+				},
+			},
+			servicesKey + `\Dnscache\Parameters`: &mockregistry.MockKey{
+				KName:   "Parameters",
+				KValues: []registry.Value{str("ServiceDll", `%SystemRoot%\System32\dnsrslvr.dll`)},
+			},
+			servicesKey + `\disk`: &mockregistry.MockKey{
+				KName: "disk",
+				KValues: []registry.Value{
+					str("ImagePath", `System32\drivers\disk.sys`),
+					dword("Start", 0),
+					dword("Type", 0x1),
+				},
+			},
+			servicesKey + `\EventLog`: &mockregistry.MockKey{KName: "EventLog"},
+		},
+	}
+
+	wantTasks := []*extractor.Package{
+		{
+			Name: `\Microsoft\Windows\Defrag\ScheduledDefrag`,
+			Metadata: &services.Metadata{
+				Kind:        services.KindScheduledTask,
+				CommandLine: `%windir%\system32\defrag.exe -c -h -o -$`,
+				BinaryPath:  `C:\Windows\system32\defrag.exe`,
+				Arguments:   "-c -h -o -$",
+				Account:     "S-1-5-18",
+				Hashes:      map[string]string{"sha256": sha256Hex("defrag")},
+			},
+			Locations: []string{"/Windows/System32/defrag.exe"},
+		},
+		{
+			Name: `\Updater`,
+			Metadata: &services.Metadata{
+				Kind:        services.KindScheduledTask,
+				CommandLine: "powershell.exe -enc AAAA",
+				BinaryPath:  `C:\Windows\System32\powershell.exe`,
+				Arguments:   "-enc AAAA",
+				Account:     "S-1-5-32-545",
+				Disabled:    true,
+			},
+			Locations: []string{"/Windows/System32/Tasks/Updater"},
+		},
+	}
+
+	tests := []struct {
+		desc string
+		cfg  services.Config
+		want []*extractor.Package
+	}{
+		{
+			desc: "registry_and_tasks",
+			cfg:  services.Config{Opener: mockregistry.NewOpener(reg), Hashing: hashing.DefaultConfig()},
+			want: append([]*extractor.Package{
+				{
+					Name: "EvilSvc",
+					Metadata: &services.Metadata{
+						Kind:        services.KindService,
+						DisplayName: "Evil Service",
+						CommandLine: `"C:\Program Files\Evil Corp\evil.exe" -run`,
+						BinaryPath:  `C:\Program Files\Evil Corp\evil.exe`,
+						Arguments:   "-run",
+						StartType:   "auto",
+						ServiceType: "own_process",
+						Account:     "LocalSystem",
+						Hashes:      map[string]string{"sha256": sha256Hex("evil")},
+					},
+					Locations: []string{"/Program Files/Evil Corp/evil.exe"},
+				},
+				{
+					Name: "Dnscache",
+					Metadata: &services.Metadata{
+						Kind:        services.KindService,
+						CommandLine: `%SystemRoot%\system32\svchost.exe -k NetworkService -p`,
+						BinaryPath:  `C:\Windows\System32\dnsrslvr.dll`,
+						Arguments:   "-k NetworkService -p",
+						StartType:   "disabled",
+						ServiceType: "share_process",
+						Account:     `NT AUTHORITY\NetworkService`,
+						Disabled:    true,
+						Hashes:      map[string]string{"sha256": sha256Hex("dnsrslvr")},
+					},
+					Locations: []string{"/Windows/System32/dnsrslvr.dll"},
+				},
+				{
+					Name: "disk",
+					Metadata: &services.Metadata{
+						Kind:        services.KindService,
+						CommandLine: `System32\drivers\disk.sys`,
+						BinaryPath:  `C:\Windows\System32\drivers\disk.sys`,
+						StartType:   "boot",
+						ServiceType: "kernel_driver",
+					},
+					Locations: []string{`HKLM\SYSTEM\CurrentControlSet\Services`},
+				},
+			}, wantTasks...),
+		},
+		{
+			desc: "no_system_hive_in_scan_root",
+			cfg:  services.Config{Hashing: hashing.DefaultConfig()},
+			want: wantTasks,
+		},
+		{
+			desc: "hashing_disabled",
+			cfg:  services.Config{},
+			want: []*extractor.Package{
+				{
+					Name: `\Microsoft\Windows\Defrag\ScheduledDefrag`,
+					Metadata: &services.Metadata{
+						Kind:        services.KindScheduledTask,
+						CommandLine: `%windir%\system32\defrag.exe -c -h -o -$`,
+						BinaryPath:  `C:\Windows\system32\defrag.exe`,
+						Arguments:   "-c -h -o -$",
+						Account:     "S-1-5-18",
+					},
+					Locations: []string{"/Windows/System32/defrag.exe"},
+				},
+				wantTasks[1],
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			e := services.New(tc.cfg)
+			input := &standalone.ScanInput{ScanRoot: &scalibrfs.ScanRoot{FS: fsys, Path: "/"}}
+			got, err := e.Extract(t.Context(), input)
+			if err != nil {
+				t.Fatalf("Extract(): %v", err)
+			}
+			want := inventory.Inventory{Packages: tc.want}
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("Extract() returned unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestResolveBinaryPath(t *testing.T) {
+	tests := []struct {
+		commandLine string
+		wantPath    string
+		wantArgs    string
+	}{
+		{
+			commandLine: `"C:\Program Files\App\app.exe" --service`,
+			wantPath:    `C:\Program Files\App\app.exe`,
+			wantArgs:    "--service",
+		},
+		{
+			commandLine: `C:\Program Files\App\app.exe --service`,
+			wantPath:    `C:\Program Files\App\app.exe`,
+			wantArgs:    "--service",
+		},
+		{
+			commandLine: `%ProgramFiles(x86)%\App\app.exe`,
+			wantPath:    `C:\Program Files (x86)\App\app.exe`,
+		},
+		{
+			commandLine: `\SystemRoot\System32\drivers\tcpip.sys`,
+			wantPath:    `C:\Windows\System32\drivers\tcpip.sys`,
+		},
+		{
+			commandLine: `\??\C:\Windows\system32\drivers\vmci.sys`,
+			wantPath:    `C:\Windows\system32\drivers\vmci.sys`,
+		},
+		{
+			commandLine: `system32\DRIVERS\ACPI.sys`,
+			wantPath:    `C:\Windows\system32\DRIVERS\ACPI.sys`,
+		},
+		{
+			commandLine: `svchost.exe -k netsvcs`,
+			wantPath:    `C:\Windows\System32\svchost.exe`,
+			wantArgs:    "-k netsvcs",
+		},
+		{
+			commandLine: `C:\tools\agent --flag value`,
+			wantPath:    `C:\tools\agent`,
+			wantArgs:    "--flag value",
+		},
+		{
+			commandLine: `"%UnknownVar%\app.exe"`,
+			wantPath:    `%UnknownVar%\app.exe`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.commandLine, func(t *testing.T) {
+			gotPath, gotArgs := services.ResolveBinaryPath(tc.commandLine)
+			if gotPath != tc.wantPath || gotArgs != tc.wantArgs {
+				t.Errorf("ResolveBinaryPath(%q) = %q, %q, want %q, %q", tc.commandLine, gotPath, gotArgs, tc.wantPath, tc.wantArgs)
+			}
+		})
+	}
+}
@@ -0,0 +1,143 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package services
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"strings"
+
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/standalone/windows/common/hive"
+	"github.com/google/osv-scalibr/log"
+	"golang.org/x/text/encoding/unicode"
+	"golang.org/x/text/transform"
+)
+
+const (
+	// tasksPath is the directory of the scheduled task definitions relative to the system drive.
+	tasksPath = "Windows/System32/Tasks"
+	// maxTaskFileSizeBytes skips files that are too large to be task definitions.
+	maxTaskFileSizeBytes = 1024 * 1024
+)
+
+// task is the subset of the Task Scheduler schema used by the extractor.
+// See https://learn.microsoft.com/en-us/windows/win32/taskschd/task-scheduler-schema
+type task struct {
+	Settings struct {
+		Enabled string `xml:"Enabled"`
+	} `xml:"Settings"`
+	Principals struct {
+		Principal []struct {
+			UserID  string `xml:"UserId"`
+			GroupID string `xml:"GroupId"`
+		} `xml:"Principal"`
+	} `xml:"Principals"`
+	Actions struct {
+		Exec []struct {
+			Command   string `xml:"Command"`
+			Arguments string `xml:"Arguments"`
+		} `xml:"Exec"`
+	} `xml:"Actions"`
+}
+
+func (e *Extractor) extractTasks(ctx context.Context, fsys fs.FS) ([]*extractor.Package, error) {
+	root, err := hive.FindFile(fsys, tasksPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var pkgs []*extractor.Package
+	err = fs.WalkDir(fsys, root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			log.Debugf("%s: skipping %s: %v", e.Name(), p, err)
+			return nil
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		t, err := readTask(fsys, p)
+		if err != nil {
+			log.Debugf("%s: skipping task %s: %v", e.Name(), p, err)
+			return nil
+		}
+		// Task names are their path in the task folder hierarchy, e.g. \Microsoft\Windows\Defrag\ScheduledDefrag
+		name := `\` + strings.ReplaceAll(strings.TrimPrefix(p, root+"/"), "/", `\`)
+		var account string
+		if len(t.Principals.Principal) > 0 {
+			account = t.Principals.Principal[0].UserID
+			if account == "" {
+				account = t.Principals.Principal[0].GroupID
+			}
+		}
+		for _, action := range t.Actions.Exec {
+			commandLine := strings.TrimSpace(action.Command)
+			if commandLine == "" {
+				continue
+			}
+			m := &Metadata{
+				Kind:        KindScheduledTask,
+				CommandLine: strings.TrimSpace(commandLine + " " + action.Arguments),
+				Account:     account,
+				Disabled:    strings.EqualFold(strings.TrimSpace(t.Settings.Enabled), "false"),
+			}
+			var args string
+			m.BinaryPath, args = ResolveBinaryPath(commandLine)
+			m.Arguments = strings.TrimSpace(args + " " + action.Arguments)
+			pkgs = append(pkgs, e.newPackage(fsys, name, m, "/"+p))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to walk /%s: %w", root, err)
+	}
+	return pkgs, nil
+}
+
+// readTask parses a task definition. These are usually UTF-16 encoded with a byte order mark.
+func readTask(fsys fs.FS, p string) (*task, error) {
+	f, err := fsys.Open(p)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if info.Size() > maxTaskFileSizeBytes {
+		return nil, fmt.Errorf("file too large: %d bytes", info.Size())
+	}
+
+	r := transform.NewReader(f, unicode.BOMOverride(unicode.UTF8.NewDecoder()))
+	dec := xml.NewDecoder(r)
+	// The content is already converted to UTF-8, regardless of the declared encoding.
+	dec.CharsetReader = func(_ string, r io.Reader) (io.Reader, error) { return r, nil }
+	t := &task{}
+	if err := dec.Decode(t); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
@@ -0,0 +1,56 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vcredist
+
+import (
+	pb "github.com/google/osv-scalibr/binary/proto/scan_result_go_proto"
+)
+
+// Metadata contains metadata about an installed Visual C++ redistributable.
+type Metadata struct {
+	// Architecture is the architecture of the runtime, e.g. "x64", "x86" or "arm64".
+	Architecture string
+	// Source is where the runtime was found: SourceRegistry or SourceWinSxS.
+	Source string
+}
+
+// SetProto sets the VCRedistMetadata field in the Package proto.
+func (m *Metadata) SetProto(p *pb.Package) {
+	if m == nil {
+		return
+	}
+	if p == nil {
+		return
+	}
+
+	p.Metadata = &pb.Package_VcRedistMetadata{
+		VcRedistMetadata: &pb.VCRedistMetadata{
+			Architecture: m.Architecture,
+			Source:       m.Source,
+		},
+	}
+}
+
+// ToStruct converts the VCRedistMetadata proto to a Metadata struct.
+func ToStruct(m *pb.VCRedistMetadata) *Metadata {
+	if m == nil {
+		return nil
+	}
+
+	return &Metadata{
+		Architecture: m.GetArchitecture(),
+		Source:       m.GetSource(),
+	}
+}
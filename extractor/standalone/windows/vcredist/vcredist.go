@@ -0,0 +1,303 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package vcredist extracts the installed Visual C++ redistributable runtimes
+// of a Windows system. They're read from the registry, either live on Windows
+// or from the SOFTWARE hive of the scan root, and from the side-by-side
+// assemblies in the WinSxS directory, which hold the runtimes of Visual C++
+// 2005 and 2008.
+package vcredist
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/google/osv-scalibr/common/windows/registry"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/standalone"
+	"github.com/google/osv-scalibr/extractor/standalone/windows/common/hive"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/plugin"
+)
+
+const (
+	// Name is the unique name of this extractor.
+	Name = "windows/vcredist"
+	// PackageName is the name of the reported packages.
+	PackageName = "visual_c++_redistributable"
+
+	// Sources of the extracted runtimes.
+	SourceRegistry = "registry"
+	SourceWinSxS   = "winsxs"
+
+	winSxSPath = "Windows/WinSxS"
+)
+
+// registryViews are the prefixes of the 64-bit and the 32-bit view of the
+// SOFTWARE hive. 32-bit runtimes are registered in the latter on 64-bit
+// systems.
+var registryViews = []string{"", `WOW6432Node\`}
+
+// runtimeKeys are the keys below each Visual Studio version that list the
+// runtimes per architecture. VC\VCRedist is used by Visual C++ 2010.
+var runtimeKeys = []string{`VC\Runtimes`, `VC\VCRedist`}
+
+// winSxSCRTRe matches the directory names of the CRT assemblies, e.g.
+// x86_microsoft.vc90.crt_1fc8b3b9a1e18e3b_9.0.30729.9518_none_508db5a1a66e94b6.
+var winSxSCRTRe = regexp.MustCompile(`(?i)^(x86|amd64|ia64|arm64)_microsoft\.vc\d+\.crt_[0-9a-f]+_(\d+(?:\.\d+){3})_`)
+
+// Config is the configuration for the Extractor.
+type Config struct {
+	// Opener is the registry engine to read the runtimes from. If nil, the
+	// SOFTWARE hive of the scan root is parsed.
+	Opener registry.Opener
+}
+
+// DefaultConfig returns the default configuration of the extractor. On
+// Windows it reads the live registry.
+func DefaultConfig() Config {
+	return Config{Opener: defaultOpener()}
+}
+
+// Extractor extracts the installed Visual C++ redistributables.
+type Extractor struct {
+	opener registry.Opener
+}
+
+// New creates a new Extractor from a given configuration.
+func New(cfg Config) standalone.Extractor {
+	return &Extractor{opener: cfg.Opener}
+}
+
+// NewDefault returns an extractor with the default config settings.
+func NewDefault() standalone.Extractor {
+	return New(DefaultConfig())
+}
+
+// Name of the extractor.
+func (e Extractor) Name() string { return Name }
+
+// Version of the extractor.
+func (e Extractor) Version() int { return 0 }
+
+// Requirements of the extractor.
+func (e Extractor) Requirements() *plugin.Capabilities {
+	if e.opener == nil {
+		// Everything is read from the scan root.
+		return &plugin.Capabilities{}
+	}
+	return &plugin.Capabilities{OS: plugin.OSWindows, RunningSystem: true}
+}
+
+// runtime identifies an installed runtime.
+type runtime struct {
+	arch    string
+	version string
+}
+
+// Extract extracts the installed Visual C++ redistributables.
+func (e *Extractor) Extract(ctx context.Context, input *standalone.ScanInput) (inventory.Inventory, error) {
+	seen := map[runtime]bool{}
+	pkgs, err := e.extractRegistry(ctx, input.ScanRoot.FS, seen)
+	if err != nil {
+		return inventory.Inventory{}, err
+	}
+	sxs, err := extractWinSxS(input.ScanRoot.FS, seen)
+	if err != nil {
+		return inventory.Inventory{}, err
+	}
+	return inventory.Inventory{Packages: append(pkgs, sxs...)}, nil
+}
+
+func (e *Extractor) extractRegistry(ctx context.Context, fsys fs.FS, seen map[runtime]bool) ([]*extractor.Package, error) {
+	reg, root, location, err := e.openRegistry(fsys)
+	if err != nil {
+		return nil, err
+	}
+	if reg == nil {
+		return nil, nil
+	}
+	defer reg.Close()
+
+	var pkgs []*extractor.Package
+	for _, view := range registryViews {
+		vsPath := root + view + `Microsoft\VisualStudio`
+		vsVersions, err := subkeyNames(reg, vsPath)
+		if err != nil {
+			continue
+		}
+		for _, vsVersion := range vsVersions {
+			for _, runtimeKey := range runtimeKeys {
+				keyPath := vsPath + `\` + vsVersion + `\` + runtimeKey
+				archs, err := subkeyNames(reg, keyPath)
+				if err != nil {
+					continue
+				}
+				for _, arch := range archs {
+					if err := ctx.Err(); err != nil {
+						return nil, fmt.Errorf("%s halted due to context error: %w", e.Name(), err)
+					}
+					version := readRuntime(reg, keyPath+`\`+arch)
+					if version == "" {
+						continue
+					}
+					r := runtime{arch: strings.ToLower(arch), version: version}
+					if seen[r] {
+						continue
+					}
+					seen[r] = true
+					loc := location
+					if loc == "" {
+						loc = `HKLM\` + keyPath + `\` + arch
+					}
+					pkgs = append(pkgs, newPackage(r, SourceRegistry, loc))
+				}
+			}
+		}
+	}
+	return pkgs, nil
+}
+
+// openRegistry opens the configured registry, or the SOFTWARE hive of the scan
+// root if none is configured. It returns the prefix of the SOFTWARE keys in the
+// registry and the location reported for the packages, which is empty for the
+// live registry. A nil registry is returned if the scan root has no SOFTWARE
+// hive.
+func (e *Extractor) openRegistry(fsys fs.FS) (registry.Registry, string, string, error) {
+	if e.opener != nil {
+		reg, err := e.opener.Open()
+		if err != nil {
+			return nil, "", "", fmt.Errorf("failed to open the registry: %w", err)
+		}
+		return reg, `SOFTWARE\`, "", nil
+	}
+	reg, p, err := hive.Open(fsys, hive.SoftwarePath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, "", "", nil
+	}
+	if err != nil {
+		return nil, "", "", err
+	}
+	return reg, "", "/" + p, nil
+}
+
+func subkeyNames(reg registry.Registry, keyPath string) ([]string, error) {
+	key, err := reg.OpenKey("HKLM", keyPath)
+	if err != nil {
+		return nil, err
+	}
+	defer key.Close()
+	return key.SubkeyNames()
+}
+
+// readRuntime returns the version of the runtime registered under the key, or
+// "" if it isn't installed.
+func readRuntime(reg registry.Registry, keyPath string) string {
+	key, err := reg.OpenKey("HKLM", keyPath)
+	if err != nil {
+		return ""
+	}
+	defer key.Close()
+	if installed, ok := dword(key, "Installed"); !ok || installed != 1 {
+		return ""
+	}
+	major, okMajor := dword(key, "Major")
+	minor, okMinor := dword(key, "Minor")
+	build, okBuild := dword(key, "Bld")
+	revision, okRevision := dword(key, "Rbld")
+	if okMajor && okMinor && okBuild && okRevision {
+		return fmt.Sprintf("%d.%d.%d.%d", major, minor, build, revision)
+	}
+	// e.g. "v14.38.33135.00".
+	version, err := key.ValueString("Version")
+	if err != nil {
+		return ""
+	}
+	return normalizeVersion(strings.TrimPrefix(version, "v"))
+}
+
+// normalizeVersion strips the zero padding of the version components so that
+// e.g. "14.38.33135.00" matches the version built from the DWORD values.
+func normalizeVersion(version string) string {
+	parts := strings.Split(version, ".")
+	for i, p := range parts {
+		n, err := strconv.ParseUint(p, 10, 32)
+		if err != nil {
+			return version
+		}
+		parts[i] = strconv.FormatUint(n, 10)
+	}
+	return strings.Join(parts, ".")
+}
+
+// extractWinSxS returns the CRT assemblies installed in the WinSxS directory.
+func extractWinSxS(fsys fs.FS, seen map[runtime]bool) ([]*extractor.Package, error) {
+	dir, err := hive.FindFile(fsys, winSxSPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+	var pkgs []*extractor.Package
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		m := winSxSCRTRe.FindStringSubmatch(entry.Name())
+		if m == nil {
+			continue
+		}
+		arch := strings.ToLower(m[1])
+		if arch == "amd64" {
+			arch = "x64"
+		}
+		r := runtime{arch: arch, version: m[2]}
+		if seen[r] {
+			continue
+		}
+		seen[r] = true
+		pkgs = append(pkgs, newPackage(r, SourceWinSxS, "/"+path.Join(dir, entry.Name())))
+	}
+	return pkgs, nil
+}
+
+func newPackage(r runtime, source, location string) *extractor.Package {
+	return &extractor.Package{
+		Name:      PackageName,
+		Version:   r.version,
+		PURLType:  "windows",
+		Metadata:  &Metadata{Architecture: r.arch, Source: source},
+		Locations: []string{location},
+	}
+}
+
+func dword(key registry.Key, name string) (uint32, bool) {
+	data, err := key.ValueBytes(name)
+	if err != nil || len(data) < 4 {
+		return 0, false
+	}
+	return binary.LittleEndian.Uint32(data), true
+}
@@ -0,0 +1,165 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vcredist_test
+
+import (
+	"context"
+	"encoding/binary"
+	"testing"
+	"testing/fstest"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/osv-scalibr/common/windows/registry"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/standalone"
+	"github.com/google/osv-scalibr/extractor/standalone/windows/vcredist"
+	scalibrfs "github.com/google/osv-scalibr/fs"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/testing/mockregistry"
+)
+
+func dword(name string, v uint32) *mockregistry.MockValue {
+	return &mockregistry.MockValue{VName: name, VData: binary.LittleEndian.AppendUint32(nil, v)}
+}
+
+func str(name, v string) *mockregistry.MockValue {
+	return &mockregistry.MockValue{VName: name, VDataString: v}
+}
+
+func subkeys(names ...string) []registry.Key {
+	var keys []registry.Key
+	for _, n := range names {
+		keys = append(keys, &mockregistry.MockKey{KName: n})
+	}
+	return keys
+}
+
+func TestExtract(t *testing.T) {
+	const (
+		vs    = `SOFTWARE\Microsoft\VisualStudio`
+		vsWow = `SOFTWARE\WOW6432Node\Microsoft\VisualStudio`
+	)
+	reg := &mockregistry.MockRegistry{
+		Keys: map[string]registry.Key{
+			vs:                       &mockregistry.MockKey{KName: "VisualStudio", KSubkeys: subkeys("14.0")},
+			vs + `\14.0\VC\Runtimes`: &mockregistry.MockKey{KName: "Runtimes", KSubkeys: subkeys("x64", "arm64")},
+			vs + `\14.0\VC\Runtimes\x64`: &mockregistry.MockKey{
+				KName: "x64",
+				KValues: []registry.Value{
+					dword("Installed", 1), str("Version", "v14.38.33135.00"),
+					dword("Major", 14), dword("Minor", 38), dword("Bld", 33135), dword("Rbld", 0),
+				},
+			},
+			// Not installed.
+			vs + `\14.0\VC\Runtimes\arm64`: &mockregistry.MockKey{
+				KName:   "arm64",
+				KValues: []registry.Value{dword("Installed", 0), str("Version", "v14.38.33135.00")},
+			},
+			vsWow:                       &mockregistry.MockKey{KName: "VisualStudio", KSubkeys: subkeys("10.0", "14.0")},
+			vsWow + `\10.0\VC\VCRedist`: &mockregistry.MockKey{KName: "VCRedist", KSubkeys: subkeys("x86")},
+			vsWow + `\10.0\VC\VCRedist\x86`: &mockregistry.MockKey{
+				KName:   "x86",
+				KValues: []registry.Value{dword("Installed", 1), str("Version", "v10.0.40219.325")},
+			},
+			vsWow + `\14.0\VC\Runtimes`: &mockregistry.MockKey{KName: "Runtimes", KSubkeys: subkeys("x64")},
+			// The 64-bit runtime is listed in both views.
+			vsWow + `\14.0\VC\Runtimes\x64`: &mockregistry.MockKey{
+				KName:   "x64",
+				KValues: []registry.Value{dword("Installed", 1), str("Version", "v14.38.33135.00")},
+			},
+		},
+	}
+	fsys := fstest.MapFS{
+		"Windows/WinSxS/x86_microsoft.vc90.crt_1fc8b3b9a1e18e3b_9.0.30729.9518_none_508db5a1a66e94b6/msvcr90.dll":   {},
+		"Windows/WinSxS/amd64_microsoft.vc80.crt_1fc8b3b9a1e18e3b_8.0.50727.6195_none_88e41e092fab0294/msvcr80.dll": {},
+		"Windows/WinSxS/amd64_microsoft-windows-notepad_31bf3856ad364e35_10.0.19041.1_none_a/notepad.exe":           {},
+		"Windows/WinSxS/x86_microsoft.vc90.crt_1fc8b3b9a1e18e3b_9.0.30729.9518_none_508db5a1a66e94b6.manifest":      {},
+	}
+
+	tests := []struct {
+		desc string
+		cfg  vcredist.Config
+		want []*extractor.Package
+	}{
+		{
+			desc: "registry_and_winsxs",
+			cfg:  vcredist.Config{Opener: mockregistry.NewOpener(reg)},
+			want: []*extractor.Package{
+				{
+					Name:      vcredist.PackageName,
+					Version:   "14.38.33135.0",
+					PURLType:  "windows",
+					Metadata:  &vcredist.Metadata{Architecture: "x64", Source: vcredist.SourceRegistry},
+					Locations: []string{`HKLM\` + vs + `\14.0\VC\Runtimes\x64`},
+				},
+				{
+					Name:      vcredist.PackageName,
+					Version:   "10.0.40219.325",
+					PURLType:  "windows",
+					Metadata:  &vcredist.Metadata{Architecture: "x86", Source: vcredist.SourceRegistry},
+					Locations: []string{`HKLM\` + vsWow + `\10.0\VC\VCRedist\x86`},
+				},
+				{
+					Name:      vcredist.PackageName,
+					Version:   "8.0.50727.6195",
+					PURLType:  "windows",
+					Metadata:  &vcredist.Metadata{Architecture: "x64", Source: vcredist.SourceWinSxS},
+					Locations: []string{"/Windows/WinSxS/amd64_microsoft.vc80.crt_1fc8b3b9a1e18e3b_8.0.50727.6195_none_88e41e092fab0294"},
+				},
+				{
+					Name:      vcredist.PackageName,
+					Version:   "9.0.30729.9518",
+					PURLType:  "windows",
+					Metadata:  &vcredist.Metadata{Architecture: "x86", Source: vcredist.SourceWinSxS},
+					Locations: []string{"/Windows/WinSxS/x86_microsoft.vc90.crt_1fc8b3b9a1e18e3b_9.0.30729.9518_none_508db5a1a66e94b6"},
+				},
+			},
+		},
+		{
+			desc: "winsxs_only_without_hive",
+			cfg:  vcredist.Config{},
+			want: []*extractor.Package{
+				{
+					Name:      vcredist.PackageName,
+					Version:   "8.0.50727.6195",
+					PURLType:  "windows",
+					Metadata:  &vcredist.Metadata{Architecture: "x64", Source: vcredist.SourceWinSxS},
+					Locations: []string{"/Windows/WinSxS/amd64_microsoft.vc80.crt_1fc8b3b9a1e18e3b_8.0.50727.6195_none_88e41e092fab0294"},
+				},
+				{
+					Name:      vcredist.PackageName,
+					Version:   "9.0.30729.9518",
+					PURLType:  "windows",
+					Metadata:  &vcredist.Metadata{Architecture: "x86", Source: vcredist.SourceWinSxS},
+					Locations: []string{"/Windows/WinSxS/x86_microsoft.vc90.crt_1fc8b3b9a1e18e3b_9.0.30729.9518_none_508db5a1a66e94b6"},
+				},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			e := vcredist.New(tc.cfg)
+			input := &standalone.ScanInput{ScanRoot: &scalibrfs.ScanRoot{FS: fsys}}
+			got, err := e.Extract(context.Background(), input)
+			if err != nil {
+				t.Fatalf("Extract(): %v", err)
+			}
+			if diff := cmp.Diff(inventory.Inventory{Packages: tc.want}, got); diff != "" {
+				t.Errorf("Extract() returned unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,176 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fs
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"sync/atomic"
+)
+
+// ErrBudgetExceeded is wrapped by the errors returned when a file access
+// exceeds a limit of an IOBudget.
+var ErrBudgetExceeded = errors.New("IO budget exceeded")
+
+// BudgetExceededError is returned by the files of a filesystem wrapped with
+// IOBudget.WrapFS when a limit of the budget is reached.
+type BudgetExceededError struct {
+	// The file that was accessed.
+	Path string
+	// The limit that was exceeded, e.g. "max file size".
+	Limit string
+	// The value of the limit in bytes.
+	Bytes int64
+}
+
+func (e *BudgetExceededError) Error() string {
+	return fmt.Sprintf("%s: %v: %s of %d bytes", e.Path, ErrBudgetExceeded, e.Limit, e.Bytes)
+}
+
+// Is makes errors.Is(err, ErrBudgetExceeded) return true for budget errors.
+func (e *BudgetExceededError) Is(target error) bool {
+	return target == ErrBudgetExceeded
+}
+
+// IOBudget limits the file sizes and the amount of data plugins can read
+// during a scan, so that a single large file matched by an overly broad
+// FileRequired can't exhaust the memory or runtime of the scan. Limits of 0
+// are not enforced. An IOBudget is shared by all filesystems wrapped with it
+// and is safe for concurrent use.
+type IOBudget struct {
+	// Files larger than this can't be opened.
+	MaxFileSizeBytes int64
+	// Reads past this many bytes of a single opened file fail.
+	MaxBytesPerFile int64
+	// Reads fail once this many bytes have been read from all files.
+	MaxTotalBytes int64
+
+	total atomic.Int64
+}
+
+// BytesRead returns the number of bytes read from the filesystems wrapped with
+// the budget so far.
+func (b *IOBudget) BytesRead() int64 {
+	if b == nil {
+		return 0
+	}
+	return b.total.Load()
+}
+
+// WrapFS returns a filesystem that enforces the budget on the files opened
+// through it. A nil budget returns fsys unchanged.
+func (b *IOBudget) WrapFS(fsys FS) FS {
+	if b == nil {
+		return fsys
+	}
+	return &budgetFS{FS: fsys, b: b}
+}
+
+type budgetFS struct {
+	FS
+
+	b *IOBudget
+}
+
+// Open opens the named file if its size is within the budget.
+func (bfs *budgetFS) Open(name string) (fs.File, error) {
+	f, err := bfs.FS.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	if info, err := f.Stat(); err == nil {
+		if info.IsDir() {
+			// Directories are opened to list their entries, which isn't a file read.
+			return f, nil
+		}
+		if limit := bfs.b.MaxFileSizeBytes; limit > 0 && info.Size() > limit {
+			f.Close()
+			return nil, &BudgetExceededError{Path: name, Limit: "max file size", Bytes: limit}
+		}
+	}
+	return &budgetFile{File: f, b: bfs.b, name: name}, nil
+}
+
+// budgetFile fails reads that exceed the per-file or the total budget.
+type budgetFile struct {
+	fs.File
+
+	b    *IOBudget
+	name string
+	read int64
+}
+
+// allowed returns how many of the n bytes to be read are within the budget and
+// the error to return if fewer than n are.
+func (f *budgetFile) allowed(n int) (int, error) {
+	var limitErr error
+	if limit := f.b.MaxBytesPerFile; limit > 0 {
+		if left := limit - f.read; int64(n) > left {
+			n = int(max(left, 0))
+			limitErr = &BudgetExceededError{Path: f.name, Limit: "max bytes per file", Bytes: limit}
+		}
+	}
+	if limit := f.b.MaxTotalBytes; limit > 0 {
+		if left := limit - f.b.total.Load(); int64(n) > left {
+			n = int(max(left, 0))
+			limitErr = &BudgetExceededError{Path: f.name, Limit: "max total bytes", Bytes: limit}
+		}
+	}
+	return n, limitErr
+}
+
+func (f *budgetFile) count(n int) {
+	f.read += int64(n)
+	f.b.total.Add(int64(n))
+}
+
+func (f *budgetFile) Read(p []byte) (int, error) {
+	n, limitErr := f.allowed(len(p))
+	if n == 0 && limitErr != nil {
+		return 0, limitErr
+	}
+	// Reads that are cut short return the bytes within the budget. The error is
+	// returned by the next read.
+	n, err := f.File.Read(p[:n])
+	f.count(n)
+	return n, err
+}
+
+// ReadAt implements io.ReaderAt, which FS files are required to support.
+func (f *budgetFile) ReadAt(p []byte, off int64) (int, error) {
+	r, ok := f.File.(io.ReaderAt)
+	if !ok {
+		return 0, &fs.PathError{Op: "readat", Path: f.name, Err: errors.ErrUnsupported}
+	}
+	n, limitErr := f.allowed(len(p))
+	n, err := r.ReadAt(p[:n], off)
+	f.count(n)
+	if err == nil {
+		// ReadAt must return an error if it reads fewer bytes than requested.
+		err = limitErr
+	}
+	return n, err
+}
+
+// Seek implements io.Seeker if the underlying file does.
+func (f *budgetFile) Seek(offset int64, whence int) (int64, error) {
+	s, ok := f.File.(io.Seeker)
+	if !ok {
+		return 0, &fs.PathError{Op: "seek", Path: f.name, Err: errors.ErrUnsupported}
+	}
+	return s.Seek(offset, whence)
+}
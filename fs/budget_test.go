@@ -0,0 +1,139 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fs_test
+
+import (
+	"errors"
+	"io"
+	"testing"
+	"testing/fstest"
+
+	"github.com/google/go-cmp/cmp"
+	scalibrfs "github.com/google/osv-scalibr/fs"
+)
+
+func budgetTestFS() scalibrfs.FS {
+	return fstest.MapFS{
+		"small.txt": {Data: []byte("0123456789")},
+		"large.txt": {Data: []byte("0123456789012345678901234567890123456789")},
+		"dir/a.txt": {Data: []byte("a")},
+	}
+}
+
+func TestIOBudget_Open(t *testing.T) {
+	fsys := (&scalibrfs.IOBudget{MaxFileSizeBytes: 20}).WrapFS(budgetTestFS())
+
+	for _, path := range []string{"small.txt", "dir"} {
+		f, err := fsys.Open(path)
+		if err != nil {
+			t.Errorf("Open(%q): %v", path, err)
+			continue
+		}
+		f.Close()
+	}
+
+	_, err := fsys.Open("large.txt")
+	if !errors.Is(err, scalibrfs.ErrBudgetExceeded) {
+		t.Errorf("Open(%q) returned error %v, want %v", "large.txt", err, scalibrfs.ErrBudgetExceeded)
+	}
+	var budgetErr *scalibrfs.BudgetExceededError
+	if !errors.As(err, &budgetErr) || budgetErr.Path != "large.txt" {
+		t.Errorf("Open(%q) returned error %#v, want a BudgetExceededError for the file", "large.txt", err)
+	}
+}
+
+func TestIOBudget_Read(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		budget    *scalibrfs.IOBudget
+		paths     []string
+		want      []string
+		wantErr   []error
+		wantTotal int64
+	}{
+		{
+			desc:      "within_budget",
+			budget:    &scalibrfs.IOBudget{MaxBytesPerFile: 100, MaxTotalBytes: 100},
+			paths:     []string{"small.txt", "large.txt"},
+			want:      []string{"0123456789", "0123456789012345678901234567890123456789"},
+			wantErr:   []error{nil, nil},
+			wantTotal: 50,
+		},
+		{
+			desc:      "per_file_limit",
+			budget:    &scalibrfs.IOBudget{MaxBytesPerFile: 15},
+			paths:     []string{"small.txt", "large.txt"},
+			want:      []string{"0123456789", "012345678901234"},
+			wantErr:   []error{nil, scalibrfs.ErrBudgetExceeded},
+			wantTotal: 25,
+		},
+		{
+			desc:      "total_limit",
+			budget:    &scalibrfs.IOBudget{MaxTotalBytes: 15},
+			paths:     []string{"small.txt", "large.txt", "dir/a.txt"},
+			want:      []string{"0123456789", "01234", ""},
+			wantErr:   []error{nil, scalibrfs.ErrBudgetExceeded, scalibrfs.ErrBudgetExceeded},
+			wantTotal: 15,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			fsys := tc.budget.WrapFS(budgetTestFS())
+			var got []string
+			var gotErr []error
+			for _, path := range tc.paths {
+				f, err := fsys.Open(path)
+				if err != nil {
+					t.Fatalf("Open(%q): %v", path, err)
+				}
+				content, err := io.ReadAll(f)
+				f.Close()
+				got = append(got, string(content))
+				gotErr = append(gotErr, err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("ReadAll() returned unexpected content (-want +got):\n%s", diff)
+			}
+			for i, err := range gotErr {
+				if !errors.Is(err, tc.wantErr[i]) {
+					t.Errorf("ReadAll(%q) returned error %v, want %v", tc.paths[i], err, tc.wantErr[i])
+				}
+			}
+			if got := tc.budget.BytesRead(); got != tc.wantTotal {
+				t.Errorf("BytesRead() = %d, want %d", got, tc.wantTotal)
+			}
+		})
+	}
+}
+
+func TestIOBudget_ReadAt(t *testing.T) {
+	budget := &scalibrfs.IOBudget{MaxBytesPerFile: 15}
+	f, err := budget.WrapFS(budgetTestFS()).Open("large.txt")
+	if err != nil {
+		t.Fatalf("Open(): %v", err)
+	}
+	defer f.Close()
+
+	r := f.(io.ReaderAt)
+	buf := make([]byte, 10)
+	if n, err := r.ReadAt(buf, 20); err != nil || n != 10 {
+		t.Errorf("ReadAt(20) = %d, %v, want 10, nil", n, err)
+	}
+	n, err := r.ReadAt(buf, 0)
+	if n != 5 || !errors.Is(err, scalibrfs.ErrBudgetExceeded) {
+		t.Errorf("ReadAt(0) = %d, %v, want 5, %v", n, err, scalibrfs.ErrBudgetExceeded)
+	}
+}
@@ -0,0 +1,48 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fs
+
+import (
+	"path/filepath"
+)
+
+// CanonicalPath returns the absolute path of the given file on the real
+// filesystem with all symlinks resolved. On Linux, paths inside bind mounts are
+// also mapped back to the location of the mounted directory, so that the same
+// directory has the same canonical path no matter how it was reached.
+func CanonicalPath(path string) (string, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+	resolved, err := filepath.EvalSymlinks(abs)
+	if err != nil {
+		return "", err
+	}
+	return resolveBindMounts(resolved), nil
+}
+
+// WithCanonicalPath returns a copy of the ScanRoot with the Path set to its
+// canonical path. Virtual scan roots are returned unchanged.
+func (r *ScanRoot) WithCanonicalPath() (*ScanRoot, error) {
+	if r.IsVirtual() {
+		return &ScanRoot{FS: r.FS, Path: r.Path}, nil
+	}
+	canonical, err := CanonicalPath(r.Path)
+	if err != nil {
+		return nil, err
+	}
+	return &ScanRoot{FS: r.FS, Path: canonical}, nil
+}
@@ -0,0 +1,40 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+
+package fs
+
+import (
+	"os"
+
+	"github.com/google/osv-scalibr/log"
+)
+
+// resolveBindMounts maps a path inside a bind mount back to the location of
+// the mounted directory, using the mount table of the current process.
+func resolveBindMounts(path string) string {
+	f, err := os.Open("/proc/self/mountinfo")
+	if err != nil {
+		log.Debugf("os.Open(/proc/self/mountinfo): %v", err)
+		return path
+	}
+	defer f.Close()
+	mounts, err := parseMountInfo(f)
+	if err != nil {
+		log.Debugf("parseMountInfo(): %v", err)
+		return path
+	}
+	return resolveBindMount(path, mounts)
+}
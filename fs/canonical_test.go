@@ -0,0 +1,60 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fs_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	scalibrfs "github.com/google/osv-scalibr/fs"
+)
+
+func TestCanonicalPath(t *testing.T) {
+	tmp, err := filepath.EvalSymlinks(t.TempDir())
+	if err != nil {
+		t.Fatalf("filepath.EvalSymlinks(): %v", err)
+	}
+	dir := filepath.Join(tmp, "dir")
+	if err := os.Mkdir(dir, 0755); err != nil {
+		t.Fatalf("os.Mkdir(): %v", err)
+	}
+	link := filepath.Join(tmp, "link")
+	if err := os.Symlink(dir, link); err != nil {
+		t.Skipf("os.Symlink(): %v", err)
+	}
+
+	for _, path := range []string{dir, link, filepath.Join(link, "..", "dir")} {
+		got, err := scalibrfs.CanonicalPath(path)
+		if err != nil {
+			t.Fatalf("CanonicalPath(%q): %v", path, err)
+		}
+		if got != dir {
+			t.Errorf("CanonicalPath(%q) = %q, want %q", path, got, dir)
+		}
+	}
+
+	root, err := (&scalibrfs.ScanRoot{Path: link}).WithCanonicalPath()
+	if err != nil {
+		t.Fatalf("WithCanonicalPath(): %v", err)
+	}
+	if root.Path != dir {
+		t.Errorf("WithCanonicalPath() returned path %q, want %q", root.Path, dir)
+	}
+
+	if _, err := scalibrfs.CanonicalPath(filepath.Join(tmp, "missing")); err == nil {
+		t.Error("CanonicalPath() succeeded for a missing file, want error")
+	}
+}
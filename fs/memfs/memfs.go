@@ -0,0 +1,219 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package memfs provides an in-memory implementation of the SCALIBR virtual
+// filesystem for running scans over test fixtures without touching the disk.
+//
+// Files are described with fstest.MapFS entries, whose Mode, ModTime and Sys
+// fields are returned as the stat info of the files. Entries with the
+// fs.ModeSymlink type are symbolic links whose Data holds the link target.
+// Relative targets are resolved from the directory of the link and absolute
+// targets from the root of the filesystem, like in a chroot.
+package memfs
+
+import (
+	"errors"
+	"io/fs"
+	"path"
+	"strings"
+	"testing/fstest"
+	"time"
+
+	scalibrfs "github.com/google/osv-scalibr/fs"
+)
+
+// maxSymlinks is the maximum number of symlinks followed when resolving a path.
+const maxSymlinks = 40
+
+// ErrTooManySymlinks is returned when resolving a path requires following more
+// than 40 symlinks, e.g. because of a symlink loop.
+var ErrTooManySymlinks = errors.New("too many levels of symbolic links")
+
+// FS is an in-memory filesystem that follows the symlinks of the underlying
+// fstest.MapFS.
+type FS struct {
+	files fstest.MapFS
+}
+
+var _ scalibrfs.FS = &FS{}
+
+// New returns an in-memory filesystem with the given files.
+func New(files fstest.MapFS) *FS {
+	return &FS{files: files}
+}
+
+// ScanRoot returns a virtual scan root for the filesystem.
+func (f *FS) ScanRoot() *scalibrfs.ScanRoot {
+	return &scalibrfs.ScanRoot{FS: f}
+}
+
+// Open opens the named file, following symlinks.
+func (f *FS) Open(name string) (fs.File, error) {
+	resolved, err := f.resolve("open", name, true)
+	if err != nil {
+		return nil, err
+	}
+	file, err := f.files.Open(resolved)
+	if err != nil {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: unwrapPathError(err)}
+	}
+	return file, nil
+}
+
+// ReadDir reads the named directory, following symlinks. Symlinks inside the
+// directory are returned as such and not followed.
+func (f *FS) ReadDir(name string) ([]fs.DirEntry, error) {
+	resolved, err := f.resolve("readdir", name, true)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := f.files.ReadDir(resolved)
+	if err != nil {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: unwrapPathError(err)}
+	}
+	return entries, nil
+}
+
+// Stat returns the file info of the named file, following symlinks.
+func (f *FS) Stat(name string) (fs.FileInfo, error) {
+	resolved, err := f.resolve("stat", name, true)
+	if err != nil {
+		return nil, err
+	}
+	info, err := f.files.Stat(resolved)
+	if err != nil {
+		return nil, &fs.PathError{Op: "stat", Path: name, Err: unwrapPathError(err)}
+	}
+	return info, nil
+}
+
+// Lstat returns the file info of the named file without following it if it's
+// a symlink.
+func (f *FS) Lstat(name string) (fs.FileInfo, error) {
+	resolved, err := f.resolve("lstat", name, false)
+	if err != nil {
+		return nil, err
+	}
+	if file := f.files[resolved]; isSymlink(file) {
+		return &linkInfo{name: path.Base(resolved), file: file}, nil
+	}
+	info, err := f.files.Stat(resolved)
+	if err != nil {
+		return nil, &fs.PathError{Op: "lstat", Path: name, Err: unwrapPathError(err)}
+	}
+	return info, nil
+}
+
+// ReadLink returns the target of the named symlink.
+func (f *FS) ReadLink(name string) (string, error) {
+	resolved, err := f.resolve("readlink", name, false)
+	if err != nil {
+		return "", err
+	}
+	file := f.files[resolved]
+	if !isSymlink(file) {
+		return "", &fs.PathError{Op: "readlink", Path: name, Err: fs.ErrInvalid}
+	}
+	return string(file.Data), nil
+}
+
+// EvalSymlink returns the path of the named file after following all symlinks.
+// Paths starting with "/" are accepted and returned as such.
+func (f *FS) EvalSymlink(name string) (string, error) {
+	abs := strings.HasPrefix(name, "/")
+	if abs {
+		name = strings.TrimPrefix(name, "/")
+		if name == "" {
+			name = "."
+		}
+	}
+	resolved, err := f.resolve("evalsymlink", name, true)
+	if err != nil {
+		return "", err
+	}
+	if _, err := f.files.Stat(resolved); err != nil {
+		return "", &fs.PathError{Op: "evalsymlink", Path: name, Err: unwrapPathError(err)}
+	}
+	if abs {
+		return path.Join("/", resolved), nil
+	}
+	return resolved, nil
+}
+
+// resolve returns the path of the named file in the underlying MapFS after
+// following the symlinks in its parent directories and, if followLast is set,
+// in the last path element.
+func (f *FS) resolve(op string, name string, followLast bool) (string, error) {
+	if !fs.ValidPath(name) {
+		return "", &fs.PathError{Op: op, Path: name, Err: fs.ErrInvalid}
+	}
+	var rest []string
+	if name != "." {
+		rest = strings.Split(name, "/")
+	}
+	resolved := "."
+	links := 0
+	for len(rest) > 0 {
+		elem := rest[0]
+		rest = rest[1:]
+		switch elem {
+		case "", ".":
+			continue
+		case "..":
+			resolved = path.Dir(resolved)
+			continue
+		}
+		next := path.Join(resolved, elem)
+		file := f.files[next]
+		if !isSymlink(file) || (len(rest) == 0 && !followLast) {
+			resolved = next
+			continue
+		}
+		links++
+		if links > maxSymlinks {
+			return "", &fs.PathError{Op: op, Path: name, Err: ErrTooManySymlinks}
+		}
+		target := string(file.Data)
+		if strings.HasPrefix(target, "/") {
+			resolved = "."
+		}
+		rest = append(strings.Split(target, "/"), rest...)
+	}
+	return resolved, nil
+}
+
+func isSymlink(file *fstest.MapFile) bool {
+	return file != nil && file.Mode.Type() == fs.ModeSymlink
+}
+
+func unwrapPathError(err error) error {
+	var pathErr *fs.PathError
+	if errors.As(err, &pathErr) {
+		return pathErr.Err
+	}
+	return err
+}
+
+// linkInfo is the file info of a symlink.
+type linkInfo struct {
+	name string
+	file *fstest.MapFile
+}
+
+func (i *linkInfo) Name() string       { return i.name }
+func (i *linkInfo) Size() int64        { return int64(len(i.file.Data)) }
+func (i *linkInfo) Mode() fs.FileMode  { return i.file.Mode }
+func (i *linkInfo) ModTime() time.Time { return i.file.ModTime }
+func (i *linkInfo) IsDir() bool        { return false }
+func (i *linkInfo) Sys() any           { return i.file.Sys }
@@ -0,0 +1,177 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memfs_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/osv-scalibr"
+	"github.com/google/osv-scalibr/extractor/filesystem/language/python/requirements"
+	scalibrfs "github.com/google/osv-scalibr/fs"
+	"github.com/google/osv-scalibr/fs/memfs"
+	"github.com/google/osv-scalibr/plugin"
+)
+
+var modTime = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func testFS() *memfs.FS {
+	return memfs.New(fstest.MapFS{
+		"usr/lib/os-release":   {Data: []byte("ID=test"), Mode: 0644, ModTime: modTime},
+		"etc/os-release":       {Data: []byte("../usr/lib/os-release"), Mode: fs.ModeSymlink},
+		"lib":                  {Data: []byte("/usr/lib"), Mode: fs.ModeSymlink},
+		"app/requirements.txt": {Data: []byte("../src/requirements.txt"), Mode: fs.ModeSymlink},
+		"src/requirements.txt": {Data: []byte("requests==2.32.3\n")},
+		"dangling":             {Data: []byte("missing"), Mode: fs.ModeSymlink},
+		"loop/a":               {Data: []byte("b"), Mode: fs.ModeSymlink},
+		"loop/b":               {Data: []byte("a"), Mode: fs.ModeSymlink},
+	})
+}
+
+func TestOpen(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    string
+		wantErr error
+	}{
+		{name: "usr/lib/os-release", want: "ID=test"},
+		{name: "etc/os-release", want: "ID=test"},
+		{name: "lib/os-release", want: "ID=test"},
+		{name: "dangling", wantErr: fs.ErrNotExist},
+		{name: "loop/a", wantErr: memfs.ErrTooManySymlinks},
+		{name: "/etc/os-release", wantErr: fs.ErrInvalid},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			f, err := testFS().Open(tc.name)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("Open(%q) error: %v, want %v", tc.name, err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			defer f.Close()
+			got, err := io.ReadAll(f)
+			if err != nil {
+				t.Fatalf("io.ReadAll(%q): %v", tc.name, err)
+			}
+			if string(got) != tc.want {
+				t.Errorf("Open(%q) returned content %q, want %q", tc.name, got, tc.want)
+			}
+			if _, ok := f.(io.ReaderAt); !ok {
+				t.Errorf("Open(%q) returned a file that doesn't implement io.ReaderAt", tc.name)
+			}
+		})
+	}
+}
+
+func TestStat(t *testing.T) {
+	fsys := testFS()
+	for _, name := range []string{"usr/lib/os-release", "etc/os-release", "lib/os-release"} {
+		info, err := fsys.Stat(name)
+		if err != nil {
+			t.Fatalf("Stat(%q): %v", name, err)
+		}
+		if info.Mode() != 0644 || !info.ModTime().Equal(modTime) || info.Size() != 7 {
+			t.Errorf("Stat(%q) = {mode %v, mod time %v, size %d}, want {mode %v, mod time %v, size 7}",
+				name, info.Mode(), info.ModTime(), info.Size(), fs.FileMode(0644), modTime)
+		}
+	}
+
+	info, err := fsys.Lstat("etc/os-release")
+	if err != nil {
+		t.Fatalf("Lstat(etc/os-release): %v", err)
+	}
+	if info.Mode().Type() != fs.ModeSymlink {
+		t.Errorf("Lstat(etc/os-release) returned mode %v, want symlink", info.Mode())
+	}
+	if info.Name() != "os-release" {
+		t.Errorf("Lstat(etc/os-release) returned name %q, want os-release", info.Name())
+	}
+}
+
+func TestReadDir(t *testing.T) {
+	entries, err := testFS().ReadDir("lib")
+	if err != nil {
+		t.Fatalf("ReadDir(lib): %v", err)
+	}
+	var got []string
+	for _, e := range entries {
+		got = append(got, e.Name())
+	}
+	if diff := cmp.Diff([]string{"os-release"}, got); diff != "" {
+		t.Errorf("ReadDir(lib) returned unexpected entries (-want +got):\n%s", diff)
+	}
+}
+
+func TestReadLinkAndEvalSymlink(t *testing.T) {
+	fsys := testFS()
+	if got, err := fsys.ReadLink("lib"); err != nil || got != "/usr/lib" {
+		t.Errorf("ReadLink(lib) = %q, %v, want /usr/lib, nil", got, err)
+	}
+	if _, err := fsys.ReadLink("usr/lib/os-release"); !errors.Is(err, fs.ErrInvalid) {
+		t.Errorf("ReadLink(usr/lib/os-release) error: %v, want %v", err, fs.ErrInvalid)
+	}
+
+	tests := []struct {
+		name string
+		want string
+	}{
+		{name: "etc/os-release", want: "usr/lib/os-release"},
+		{name: "/lib/os-release", want: "/usr/lib/os-release"},
+		{name: "src", want: "src"},
+	}
+	for _, tc := range tests {
+		got, err := fsys.EvalSymlink(tc.name)
+		if err != nil {
+			t.Fatalf("EvalSymlink(%q): %v", tc.name, err)
+		}
+		if got != tc.want {
+			t.Errorf("EvalSymlink(%q) = %q, want %q", tc.name, got, tc.want)
+		}
+	}
+	if _, err := fsys.EvalSymlink("dangling"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("EvalSymlink(dangling) error: %v, want %v", err, fs.ErrNotExist)
+	}
+}
+
+func TestScan(t *testing.T) {
+	cfg := &scalibr.ScanConfig{
+		ScanRoots:    []*scalibrfs.ScanRoot{testFS().ScanRoot()},
+		Plugins:      []plugin.Plugin{requirements.NewDefault()},
+		ReadSymlinks: true,
+	}
+	got := scalibr.New().Scan(context.Background(), cfg)
+	if got.Status.Status != plugin.ScanStatusSucceeded {
+		t.Fatalf("Scan() status: %v, want %v", got.Status, plugin.ScanStatusSucceeded)
+	}
+
+	var locations []string
+	for _, p := range got.Inventory.Packages {
+		locations = append(locations, p.Locations...)
+	}
+	want := []string{"app/requirements.txt", "src/requirements.txt"}
+	if diff := cmp.Diff(want, locations, cmpopts.SortSlices(func(a, b string) bool { return a < b })); diff != "" {
+		t.Errorf("Scan() returned unexpected package locations (-want +got):\n%s", diff)
+	}
+}
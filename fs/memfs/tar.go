@@ -0,0 +1,92 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memfs
+
+import (
+	"archive/tar"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"strings"
+	"testing/fstest"
+)
+
+// ErrTarTooLarge is returned by FromTar when the files in the archive exceed
+// the size limit.
+var ErrTarTooLarge = errors.New("tar archive exceeds the size limit")
+
+// FromTar returns an in-memory filesystem with the contents of the tar archive
+// read from r, e.g. a stream piped to the scanner. Regular files, directories,
+// symlinks and hard links are kept, other entry types are skipped. Later
+// entries replace earlier ones with the same path. If maxBytes is positive,
+// ErrTarTooLarge is returned once the total size of the files exceeds it.
+func FromTar(r io.Reader, maxBytes int64) (*FS, error) {
+	files := fstest.MapFS{}
+	tr := tar.NewReader(r)
+	var total int64
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading tar: %w", err)
+		}
+		name, ok := tarPath(hdr.Name)
+		if !ok {
+			continue
+		}
+		mode := hdr.FileInfo().Mode()
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			files[name] = &fstest.MapFile{Mode: mode, ModTime: hdr.ModTime}
+		case tar.TypeReg:
+			total += hdr.Size
+			if maxBytes > 0 && total > maxBytes {
+				return nil, ErrTarTooLarge
+			}
+			data, err := io.ReadAll(tr)
+			if err != nil {
+				return nil, fmt.Errorf("reading %s from tar: %w", hdr.Name, err)
+			}
+			files[name] = &fstest.MapFile{Data: data, Mode: mode, ModTime: hdr.ModTime}
+		case tar.TypeSymlink:
+			files[name] = &fstest.MapFile{Data: []byte(hdr.Linkname), Mode: fs.ModeSymlink | mode.Perm(), ModTime: hdr.ModTime}
+		case tar.TypeLink:
+			// Hard links point to a file earlier in the archive.
+			target, ok := tarPath(hdr.Linkname)
+			if !ok || files[target] == nil {
+				continue
+			}
+			f := *files[target]
+			files[name] = &f
+		}
+	}
+	return New(files), nil
+}
+
+// tarPath returns the path of a tar entry relative to the root of the
+// archive, or false for the root itself. Like in a chroot, ".." elements can't
+// escape the root.
+func tarPath(name string) (string, bool) {
+	name = path.Clean("/" + strings.TrimPrefix(name, "./"))
+	name = strings.TrimPrefix(name, "/")
+	if name == "" || !fs.ValidPath(name) {
+		return "", false
+	}
+	return name, true
+}
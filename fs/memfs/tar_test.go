@@ -0,0 +1,102 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memfs_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"errors"
+	"io/fs"
+	"testing"
+
+	"github.com/google/osv-scalibr/fs/memfs"
+)
+
+type tarEntry struct {
+	hdr  *tar.Header
+	data string
+}
+
+func makeTar(t *testing.T, entries []tarEntry) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for _, e := range entries {
+		e.hdr.Size = int64(len(e.data))
+		if err := tw.WriteHeader(e.hdr); err != nil {
+			t.Fatalf("WriteHeader(%v): %v", e.hdr, err)
+		}
+		if _, err := tw.Write([]byte(e.data)); err != nil {
+			t.Fatalf("Write(%v): %v", e.hdr, err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatalf("tar.Close(): %v", err)
+	}
+	return &buf
+}
+
+func TestFromTar(t *testing.T) {
+	buf := makeTar(t, []tarEntry{
+		{hdr: &tar.Header{Name: "./app/", Typeflag: tar.TypeDir, Mode: 0755}},
+		{hdr: &tar.Header{Name: "./app/requirements.txt", Typeflag: tar.TypeReg, Mode: 0644}, data: "requests==2.32.3\n"},
+		{hdr: &tar.Header{Name: "usr/lib/os-release", Typeflag: tar.TypeReg, Mode: 0644}, data: "ID=test"},
+		{hdr: &tar.Header{Name: "etc/os-release", Typeflag: tar.TypeSymlink, Linkname: "../usr/lib/os-release"}},
+		{hdr: &tar.Header{Name: "app/hardlink.txt", Typeflag: tar.TypeLink, Linkname: "./app/requirements.txt"}},
+		{hdr: &tar.Header{Name: "../../escape.txt", Typeflag: tar.TypeReg, Mode: 0644}, data: "inside"},
+		{hdr: &tar.Header{Name: "dev/null", Typeflag: tar.TypeChar}},
+	})
+
+	fsys, err := memfs.FromTar(buf, 0)
+	if err != nil {
+		t.Fatalf("FromTar(): %v", err)
+	}
+
+	for path, want := range map[string]string{
+		"app/requirements.txt": "requests==2.32.3\n",
+		"app/hardlink.txt":     "requests==2.32.3\n",
+		"etc/os-release":       "ID=test",
+		"escape.txt":           "inside",
+	} {
+		got, err := fs.ReadFile(fsys, path)
+		if err != nil {
+			t.Errorf("ReadFile(%q): %v", path, err)
+			continue
+		}
+		if string(got) != want {
+			t.Errorf("ReadFile(%q) = %q, want %q", path, got, want)
+		}
+	}
+	if _, err := fsys.Stat("dev/null"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Stat(dev/null) returned error %v, want %v", err, fs.ErrNotExist)
+	}
+}
+
+func TestFromTar_TooLarge(t *testing.T) {
+	buf := makeTar(t, []tarEntry{
+		{hdr: &tar.Header{Name: "a.txt", Typeflag: tar.TypeReg}, data: "12345"},
+		{hdr: &tar.Header{Name: "b.txt", Typeflag: tar.TypeReg}, data: "67890"},
+	})
+
+	if _, err := memfs.FromTar(buf, 8); !errors.Is(err, memfs.ErrTarTooLarge) {
+		t.Errorf("FromTar() returned error %v, want %v", err, memfs.ErrTarTooLarge)
+	}
+}
+
+func TestFromTar_Invalid(t *testing.T) {
+	if _, err := memfs.FromTar(bytes.NewReader([]byte("not a tar archive")), 0); err == nil {
+		t.Error("FromTar() succeeded on an invalid archive, want error")
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+
+package fs
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	scalibrmounts "github.com/google/osv-scalibr/fs/mounts"
+)
+
+// mountInfo is an entry of a Linux mountinfo file, see proc_pid_mountinfo(5).
+type mountInfo struct {
+	// The major:minor ID of the mounted device.
+	device string
+	// The directory of the device's filesystem that is mounted.
+	root string
+	// Where the directory is mounted.
+	mountPoint string
+}
+
+// parseMountInfo parses the entries of a mountinfo file.
+func parseMountInfo(r io.Reader) ([]*mountInfo, error) {
+	var mounts []*mountInfo
+	s := bufio.NewScanner(r)
+	for s.Scan() {
+		fields := strings.Fields(s.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) < 5 {
+			return nil, fmt.Errorf("invalid mountinfo entry %q", s.Text())
+		}
+		mounts = append(mounts, &mountInfo{
+			device:     fields[2],
+			root:       scalibrmounts.UnescapePath(fields[3]),
+			mountPoint: scalibrmounts.UnescapePath(fields[4]),
+		})
+	}
+	return mounts, s.Err()
+}
+
+// resolveBindMount maps a path inside a bind mount of a sub-directory back to
+// the path of that sub-directory in another mount of the same device. The path
+// is returned unchanged if it's not inside such a bind mount.
+func resolveBindMount(p string, mounts []*mountInfo) string {
+	// Later entries shadow earlier ones mounted at the same location.
+	var mount *mountInfo
+	for _, m := range mounts {
+		if hasPathPrefix(p, m.mountPoint) && (mount == nil || len(m.mountPoint) >= len(mount.mountPoint)) {
+			mount = m
+		}
+	}
+	if mount == nil || mount.root == "/" {
+		return p
+	}
+
+	// Find the mount that exposes the largest part of the device's filesystem.
+	var source *mountInfo
+	for _, m := range mounts {
+		if m == mount || m.device != mount.device || !hasPathPrefix(mount.root, m.root) {
+			continue
+		}
+		if source == nil || len(m.root) < len(source.root) {
+			source = m
+		}
+	}
+	if source == nil {
+		return p
+	}
+	rel := strings.TrimPrefix(mount.root, source.root)
+	rest := strings.TrimPrefix(p, mount.mountPoint)
+	return path.Join(source.mountPoint, rel, rest)
+}
+
+// hasPathPrefix returns whether p is the directory prefix or inside it.
+func hasPathPrefix(p, prefix string) bool {
+	if prefix == "/" || p == prefix {
+		return true
+	}
+	return strings.HasPrefix(p, prefix+"/")
+}
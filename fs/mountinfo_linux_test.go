@@ -0,0 +1,63 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+
+package fs
+
+import (
+	"strings"
+	"testing"
+)
+
+const testMountInfo = `22 1 8:1 / / rw,relatime shared:1 - ext4 /dev/sda1 rw
+30 22 8:1 /srv/data /mnt/data rw,relatime shared:1 - ext4 /dev/sda1 rw
+31 22 0:5 / /proc rw,nosuid - proc proc rw
+32 22 8:1 /home/my\040dir /mnt/my\040dir rw - ext4 /dev/sda1 rw
+33 22 8:2 /sub /mnt/other rw - ext4 /dev/sdb1 rw
+34 22 8:3 / /data rw - ext4 /dev/sdc1 rw
+35 22 8:3 /www /var/www rw - ext4 /dev/sdc1 rw
+`
+
+func TestResolveBindMount(t *testing.T) {
+	mounts, err := parseMountInfo(strings.NewReader(testMountInfo))
+	if err != nil {
+		t.Fatalf("parseMountInfo(): %v", err)
+	}
+
+	tests := []struct {
+		path string
+		want string
+	}{
+		{path: "/home/user", want: "/home/user"},
+		{path: "/mnt/data", want: "/srv/data"},
+		{path: "/mnt/data/app/file", want: "/srv/data/app/file"},
+		{path: "/mnt/database", want: "/mnt/database"},
+		{path: "/mnt/my dir/file", want: "/home/my dir/file"},
+		{path: "/mnt/other/file", want: "/mnt/other/file"},
+		{path: "/var/www/index.html", want: "/data/www/index.html"},
+		{path: "/proc/self", want: "/proc/self"},
+	}
+	for _, tc := range tests {
+		if got := resolveBindMount(tc.path, mounts); got != tc.want {
+			t.Errorf("resolveBindMount(%q) = %q, want %q", tc.path, got, tc.want)
+		}
+	}
+}
+
+func TestParseMountInfo_Invalid(t *testing.T) {
+	if _, err := parseMountInfo(strings.NewReader("22 1 8:1\n")); err == nil {
+		t.Error("parseMountInfo() succeeded for an invalid entry, want error")
+	}
+}
@@ -0,0 +1,224 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package mounts reads the mount table of the scanning host and decides which
+// mounted filesystems the filesystem walk skips or walks last, e.g. so that
+// host scans don't traverse /proc or hang on unresponsive NFS servers.
+package mounts
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Mount is an entry of the mount table.
+type Mount struct {
+	// The mounted device, e.g. "/dev/sda1" or "server:/export".
+	Device string
+	// The absolute path the filesystem is mounted at.
+	MountPoint string
+	// The filesystem type, e.g. "ext4" or "nfs4".
+	FSType string
+}
+
+// Class is a group of filesystem types the walk treats the same way.
+type Class string
+
+const (
+	// Local is a filesystem stored on the scanned host.
+	Local Class = "local"
+	// Pseudo is a filesystem generated by the kernel, e.g. proc or sysfs.
+	Pseudo Class = "pseudo"
+	// Network is a filesystem served by another host, e.g. NFS or SMB.
+	Network Class = "network"
+	// FUSE is a filesystem implemented by a userspace process.
+	FUSE Class = "fuse"
+)
+
+var pseudoTypes = map[string]bool{
+	"autofs":      true,
+	"binfmt_misc": true,
+	"bpf":         true,
+	"cgroup":      true,
+	"cgroup2":     true,
+	"configfs":    true,
+	"debugfs":     true,
+	"devpts":      true,
+	"devtmpfs":    true,
+	"efivarfs":    true,
+	"fusectl":     true,
+	"hugetlbfs":   true,
+	"mqueue":      true,
+	"nsfs":        true,
+	"proc":        true,
+	"pstore":      true,
+	"rpc_pipefs":  true,
+	"securityfs":  true,
+	"selinuxfs":   true,
+	"sysfs":       true,
+	"tracefs":     true,
+}
+
+var networkTypes = map[string]bool{
+	"9p":        true,
+	"afs":       true,
+	"ceph":      true,
+	"cifs":      true,
+	"davfs":     true,
+	"glusterfs": true,
+	"lustre":    true,
+	"ncpfs":     true,
+	"nfs":       true,
+	"nfs4":      true,
+	"smb3":      true,
+	"smbfs":     true,
+}
+
+// Classify returns the class of a filesystem type.
+func Classify(fsType string) Class {
+	switch {
+	case pseudoTypes[fsType]:
+		return Pseudo
+	case networkTypes[fsType]:
+		return Network
+	case fsType == "fuse" || strings.HasPrefix(fsType, "fuse."):
+		// fuseblk filesystems such as ntfs-3g are backed by a local block device.
+		return FUSE
+	default:
+		return Local
+	}
+}
+
+// Parse parses a mount table in the format of /proc/mounts, see fstab(5).
+func Parse(r io.Reader) ([]*Mount, error) {
+	var mounts []*Mount
+	s := bufio.NewScanner(r)
+	for s.Scan() {
+		fields := strings.Fields(s.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) < 3 {
+			return nil, fmt.Errorf("invalid mount table entry %q", s.Text())
+		}
+		mounts = append(mounts, &Mount{
+			Device:     UnescapePath(fields[0]),
+			MountPoint: UnescapePath(fields[1]),
+			FSType:     fields[2],
+		})
+	}
+	return mounts, s.Err()
+}
+
+// UnescapePath replaces the octal escapes of whitespace and backslashes in the
+// paths of Linux mount tables.
+func UnescapePath(p string) string {
+	if !strings.Contains(p, `\`) {
+		return p
+	}
+	var b strings.Builder
+	for i := 0; i < len(p); i++ {
+		if p[i] == '\\' && i+4 <= len(p) {
+			if c, err := strconv.ParseUint(p[i+1:i+4], 8, 8); err == nil {
+				b.WriteByte(byte(c))
+				i += 3
+				continue
+			}
+		}
+		b.WriteByte(p[i])
+	}
+	return b.String()
+}
+
+// Action is what the filesystem walk does with a mount point.
+type Action string
+
+const (
+	// Scan walks the mount point like any other directory.
+	Scan Action = "scan"
+	// Defer walks the mount point after the rest of the scan root.
+	Defer Action = "defer"
+	// Skip doesn't walk the mount point.
+	Skip Action = "skip"
+)
+
+// Policy decides what the filesystem walk does with the mount points under the
+// scan roots. Mount points that contain a scan root are always walked.
+type Policy struct {
+	// Optional: The mount table to apply the policy to. If nil, the mount table
+	// of the scanning host is read with Read.
+	Mounts []*Mount
+	// The action for each class of filesystem. Classes without an entry are
+	// scanned.
+	Classes map[Class]Action
+	// Optional: Actions for individual filesystem types, e.g. "nfs4". Take
+	// precedence over the action of the type's class.
+	Types map[string]Action
+}
+
+// DefaultPolicy returns the policy used for host scans: Pseudo and network
+// filesystems are skipped and FUSE filesystems are walked last.
+func DefaultPolicy() *Policy {
+	return &Policy{
+		Classes: map[Class]Action{
+			Pseudo:  Skip,
+			Network: Skip,
+			FUSE:    Defer,
+		},
+		Types: map[string]Action{},
+	}
+}
+
+// ParsePolicy returns the default policy with the overrides of a
+// comma-separated list of <class or filesystem type>=<action> entries, e.g.
+// "network=defer,nfs4=scan".
+func ParsePolicy(s string) (*Policy, error) {
+	p := DefaultPolicy()
+	if s == "" {
+		return p, nil
+	}
+	for entry := range strings.SplitSeq(s, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid mount policy entry %q: want <class or filesystem type>=<action>", entry)
+		}
+		action := Action(value)
+		switch action {
+		case Scan, Defer, Skip:
+		default:
+			return nil, fmt.Errorf("invalid mount policy action %q: want %q, %q or %q", value, Scan, Defer, Skip)
+		}
+		switch c := Class(key); c {
+		case Local, Pseudo, Network, FUSE:
+			p.Classes[c] = action
+		default:
+			p.Types[key] = action
+		}
+	}
+	return p, nil
+}
+
+// Action returns what the walk does with the mount point of m.
+func (p *Policy) Action(m *Mount) Action {
+	if a, ok := p.Types[m.FSType]; ok {
+		return a
+	}
+	if a, ok := p.Classes[Classify(m.FSType)]; ok {
+		return a
+	}
+	return Scan
+}
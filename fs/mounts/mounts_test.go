@@ -0,0 +1,139 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mounts_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/osv-scalibr/fs/mounts"
+)
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		fsType string
+		want   mounts.Class
+	}{
+		{fsType: "ext4", want: mounts.Local},
+		{fsType: "tmpfs", want: mounts.Local},
+		{fsType: "overlay", want: mounts.Local},
+		{fsType: "fuseblk", want: mounts.Local},
+		{fsType: "proc", want: mounts.Pseudo},
+		{fsType: "sysfs", want: mounts.Pseudo},
+		{fsType: "cgroup2", want: mounts.Pseudo},
+		{fsType: "fusectl", want: mounts.Pseudo},
+		{fsType: "nfs", want: mounts.Network},
+		{fsType: "nfs4", want: mounts.Network},
+		{fsType: "cifs", want: mounts.Network},
+		{fsType: "fuse", want: mounts.FUSE},
+		{fsType: "fuse.sshfs", want: mounts.FUSE},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.fsType, func(t *testing.T) {
+			if got := mounts.Classify(tt.fsType); got != tt.want {
+				t.Errorf("Classify(%q) = %q, want %q", tt.fsType, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	table := `
+/dev/sda1 / ext4 rw,relatime 0 0
+proc /proc proc rw,nosuid,nodev,noexec,relatime 0 0
+server:/export /mnt/my\040share nfs4 rw,relatime,vers=4.2 0 0
+sshfs#user@host: /home/user/remote fuse.sshfs rw,nosuid,nodev 0 0
+`
+	got, err := mounts.Parse(strings.NewReader(table))
+	if err != nil {
+		t.Fatalf("Parse(): %v", err)
+	}
+	want := []*mounts.Mount{
+		{Device: "/dev/sda1", MountPoint: "/", FSType: "ext4"},
+		{Device: "proc", MountPoint: "/proc", FSType: "proc"},
+		{Device: "server:/export", MountPoint: "/mnt/my share", FSType: "nfs4"},
+		{Device: "sshfs#user@host:", MountPoint: "/home/user/remote", FSType: "fuse.sshfs"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Parse() returned unexpected diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	if _, err := mounts.Parse(strings.NewReader("/dev/sda1 /\n")); err == nil {
+		t.Error("Parse() succeeded for an entry without a filesystem type, want error")
+	}
+}
+
+func TestUnescapePath(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{path: "/mnt/data", want: "/mnt/data"},
+		{path: `/mnt/my\040dir`, want: "/mnt/my dir"},
+		{path: `/mnt/tab\011and\134backslash`, want: "/mnt/tab\tand\\backslash"},
+		{path: `/mnt/trailing\04`, want: `/mnt/trailing\04`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			if got := mounts.UnescapePath(tt.path); got != tt.want {
+				t.Errorf("UnescapePath(%q) = %q, want %q", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPolicyAction(t *testing.T) {
+	tests := []struct {
+		desc   string
+		policy string
+		fsType string
+		want   mounts.Action
+	}{
+		{desc: "local_scanned", fsType: "ext4", want: mounts.Scan},
+		{desc: "pseudo_skipped", fsType: "proc", want: mounts.Skip},
+		{desc: "network_skipped", fsType: "nfs4", want: mounts.Skip},
+		{desc: "fuse_deferred", fsType: "fuse.sshfs", want: mounts.Defer},
+		{desc: "class_override", policy: "network=defer", fsType: "cifs", want: mounts.Defer},
+		{desc: "type_override", policy: "nfs4=scan", fsType: "nfs4", want: mounts.Scan},
+		{desc: "type_override_other_type", policy: "nfs4=scan", fsType: "nfs", want: mounts.Skip},
+		{desc: "type_takes_precedence", policy: "nfs4=scan, network=defer", fsType: "nfs4", want: mounts.Scan},
+		{desc: "local_type_skipped", policy: "tmpfs=skip", fsType: "tmpfs", want: mounts.Skip},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			p, err := mounts.ParsePolicy(tt.policy)
+			if err != nil {
+				t.Fatalf("ParsePolicy(%q): %v", tt.policy, err)
+			}
+			if got := p.Action(&mounts.Mount{FSType: tt.fsType}); got != tt.want {
+				t.Errorf("Action(%q) = %q, want %q", tt.fsType, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParsePolicy_Invalid(t *testing.T) {
+	for _, policy := range []string{"nfs4", "=skip", "nfs4=ignore", "network=skip,"} {
+		if _, err := mounts.ParsePolicy(policy); err == nil {
+			t.Errorf("ParsePolicy(%q) succeeded, want error", policy)
+		}
+	}
+}
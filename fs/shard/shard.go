@@ -0,0 +1,231 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package shard partitions a scan root into shards of roughly equal estimated
+// cost so that the filesystem scan can be distributed to several workers. Each
+// worker scans the paths of its shard via ScanConfig.PathsToExtract and the
+// results can be combined with result.Merge.
+package shard
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"slices"
+
+	scalibrfs "github.com/google/osv-scalibr/fs"
+)
+
+// Config configures how the scan root is partitioned.
+type Config struct {
+	// The number of shards to produce. Fewer shards are returned if the scan
+	// root doesn't contain enough files to fill all of them.
+	Shards int
+	// Optional: Directories relative to the scan root that are not scanned and
+	// thus not included in the cost estimate, e.g. "proc".
+	DirsToSkip []string
+}
+
+// Manifest describes how a scan root is partitioned into shards.
+type Manifest struct {
+	// The path of the scan root that was partitioned.
+	Root string `json:"root"`
+	// The estimated cost of scanning the whole scan root.
+	TotalCost int64 `json:"total_cost"`
+	// The shards, ordered by decreasing estimated cost.
+	Shards []*Shard `json:"shards"`
+}
+
+// Shard is a set of paths that is scanned by a single worker.
+type Shard struct {
+	// The index of the shard in the manifest.
+	Index int `json:"index"`
+	// The files and directories to scan, as slash-separated paths relative to
+	// the scan root. Directories are scanned recursively.
+	Paths []string `json:"paths"`
+	// The estimated cost of scanning the paths, in number of inodes.
+	EstimatedCost int64 `json:"estimated_cost"`
+}
+
+// PathsToExtract returns the paths of the shard with the given index in the
+// format expected by ScanConfig.PathsToExtract.
+func (m *Manifest) PathsToExtract(index int) ([]string, error) {
+	if index < 0 || index >= len(m.Shards) {
+		return nil, fmt.Errorf("shard index %d out of range [0, %d)", index, len(m.Shards))
+	}
+	paths := make([]string, 0, len(m.Shards[index].Paths))
+	for _, p := range m.Shards[index].Paths {
+		paths = append(paths, filepath.Join(m.Root, filepath.FromSlash(p)))
+	}
+	return paths, nil
+}
+
+// item is a file or directory that is assigned to a shard as a whole.
+type item struct {
+	path string
+	cost int64
+	dir  *dirNode
+}
+
+// dirNode holds the estimated cost of a directory's subtree.
+type dirNode struct {
+	path    string
+	cost    int64
+	subdirs []*dirNode
+}
+
+// Plan walks the scan root and partitions it into shards. The cost of a shard
+// is estimated as the number of inodes it contains. Directories whose subtree
+// is more expensive than the average shard are split into their entries so
+// that large directories don't end up on a single worker.
+func Plan(root *scalibrfs.ScanRoot, config *Config) (*Manifest, error) {
+	if config.Shards <= 0 {
+		return nil, errors.New("number of shards must be positive")
+	}
+	skip := map[string]bool{}
+	for _, d := range config.DirsToSkip {
+		skip[path.Clean(filepath.ToSlash(d))] = true
+	}
+
+	tree, err := estimate(root.FS, ".", skip)
+	if err != nil {
+		return nil, err
+	}
+	target := (tree.cost + int64(config.Shards) - 1) / int64(config.Shards)
+
+	items, err := expand(root.FS, tree)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		i := largestSplittable(items, target)
+		if i < 0 {
+			break
+		}
+		children, err := expand(root.FS, items[i].dir)
+		if err != nil {
+			return nil, err
+		}
+		items = slices.Replace(items, i, i+1, children...)
+	}
+
+	return &Manifest{
+		Root:      root.Path,
+		TotalCost: tree.cost,
+		Shards:    assign(items, config.Shards),
+	}, nil
+}
+
+// estimate returns the cost tree of the directory at dirPath.
+func estimate(fsys scalibrfs.FS, dirPath string, skip map[string]bool) (*dirNode, error) {
+	entries, err := fs.ReadDir(fsys, dirPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %q: %w", dirPath, err)
+	}
+	node := &dirNode{path: dirPath, cost: 1}
+	for _, e := range entries {
+		p := path.Join(dirPath, e.Name())
+		if !e.IsDir() {
+			node.cost++
+			continue
+		}
+		if skip[p] {
+			continue
+		}
+		sub, err := estimate(fsys, p, skip)
+		if err != nil {
+			return nil, err
+		}
+		node.cost += sub.cost
+		node.subdirs = append(node.subdirs, sub)
+	}
+	return node, nil
+}
+
+// expand returns the entries of the given directory as individual items.
+func expand(fsys scalibrfs.FS, dir *dirNode) ([]*item, error) {
+	entries, err := fs.ReadDir(fsys, dir.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %q: %w", dir.path, err)
+	}
+	items := make([]*item, 0, len(entries))
+	for _, sub := range dir.subdirs {
+		items = append(items, &item{path: sub.path, cost: sub.cost, dir: sub})
+	}
+	for _, e := range entries {
+		if e.IsDir() {
+			// Directories are already listed in the cost tree. Directories that
+			// were created after the estimate are not sharded.
+			continue
+		}
+		items = append(items, &item{path: path.Join(dir.path, e.Name()), cost: 1})
+	}
+	return items, nil
+}
+
+// largestSplittable returns the index of the most expensive directory whose
+// cost exceeds the target or -1 if there is none.
+func largestSplittable(items []*item, target int64) int {
+	largest := -1
+	for i, it := range items {
+		if it.dir == nil || it.cost <= target {
+			continue
+		}
+		if largest < 0 || it.cost > items[largest].cost {
+			largest = i
+		}
+	}
+	return largest
+}
+
+// assign distributes the items to the given number of shards, always adding the
+// next most expensive item to the cheapest shard.
+func assign(items []*item, n int) []*Shard {
+	slices.SortFunc(items, func(a, b *item) int {
+		if c := cmp.Compare(b.cost, a.cost); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.path, b.path)
+	})
+	shards := make([]*Shard, n)
+	for i := range shards {
+		shards[i] = &Shard{}
+	}
+	for _, it := range items {
+		cheapest := slices.MinFunc(shards, func(a, b *Shard) int {
+			return cmp.Compare(a.EstimatedCost, b.EstimatedCost)
+		})
+		cheapest.Paths = append(cheapest.Paths, it.path)
+		cheapest.EstimatedCost += it.cost
+	}
+
+	var result []*Shard
+	for _, s := range shards {
+		if len(s.Paths) == 0 {
+			continue
+		}
+		slices.Sort(s.Paths)
+		result = append(result, s)
+	}
+	slices.SortStableFunc(result, func(a, b *Shard) int {
+		return cmp.Compare(b.EstimatedCost, a.EstimatedCost)
+	})
+	for i, s := range result {
+		s.Index = i
+	}
+	return result
+}
@@ -0,0 +1,152 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shard_test
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/google/go-cmp/cmp"
+	scalibrfs "github.com/google/osv-scalibr/fs"
+	"github.com/google/osv-scalibr/fs/shard"
+)
+
+// testFS contains 27 inodes: The root, "README", "a" with 10 files, "b" with 2
+// files and "c" with two sub-directories of 4 files each.
+func testFS() fstest.MapFS {
+	fsys := fstest.MapFS{"README": {}}
+	for i := range 10 {
+		fsys[fmt.Sprintf("a/%d.txt", i)] = &fstest.MapFile{}
+	}
+	for i := range 2 {
+		fsys[fmt.Sprintf("b/%d.txt", i)] = &fstest.MapFile{}
+	}
+	for i := range 4 {
+		fsys[fmt.Sprintf("c/x/%d.txt", i)] = &fstest.MapFile{}
+		fsys[fmt.Sprintf("c/y/%d.txt", i)] = &fstest.MapFile{}
+	}
+	return fsys
+}
+
+func TestPlan(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		config *shard.Config
+		want   *shard.Manifest
+	}{
+		{
+			desc:   "single_shard",
+			config: &shard.Config{Shards: 1},
+			want: &shard.Manifest{
+				Root:      "/root",
+				TotalCost: 27,
+				Shards: []*shard.Shard{
+					{Index: 0, Paths: []string{"README", "a", "b", "c"}, EstimatedCost: 26},
+				},
+			},
+		},
+		{
+			desc:   "top_level_dirs_fit",
+			config: &shard.Config{Shards: 2},
+			want: &shard.Manifest{
+				Root:      "/root",
+				TotalCost: 27,
+				Shards: []*shard.Shard{
+					{Index: 0, Paths: []string{"a", "b"}, EstimatedCost: 14},
+					{Index: 1, Paths: []string{"README", "c"}, EstimatedCost: 12},
+				},
+			},
+		},
+		{
+			desc:   "large_dirs_are_split",
+			config: &shard.Config{Shards: 4},
+			want: &shard.Manifest{
+				Root:      "/root",
+				TotalCost: 27,
+				Shards: []*shard.Shard{
+					{Index: 0, Paths: []string{"a/6.txt", "c/x"}, EstimatedCost: 6},
+					{Index: 1, Paths: []string{"a/7.txt", "c/y"}, EstimatedCost: 6},
+					{Index: 2, Paths: []string{"a/2.txt", "a/4.txt", "a/8.txt", "b"}, EstimatedCost: 6},
+					{Index: 3, Paths: []string{"README", "a/0.txt", "a/1.txt", "a/3.txt", "a/5.txt", "a/9.txt"}, EstimatedCost: 6},
+				},
+			},
+		},
+		{
+			desc:   "skipped_dirs",
+			config: &shard.Config{Shards: 2, DirsToSkip: []string{"a"}},
+			want: &shard.Manifest{
+				Root:      "/root",
+				TotalCost: 16,
+				Shards: []*shard.Shard{
+					{Index: 0, Paths: []string{"b", "c/x"}, EstimatedCost: 8},
+					{Index: 1, Paths: []string{"README", "c/y"}, EstimatedCost: 6},
+				},
+			},
+		},
+		{
+			desc:   "more_shards_than_files",
+			config: &shard.Config{Shards: 100, DirsToSkip: []string{"a", "c"}},
+			want: &shard.Manifest{
+				Root:      "/root",
+				TotalCost: 5,
+				Shards: []*shard.Shard{
+					{Index: 0, Paths: []string{"README"}, EstimatedCost: 1},
+					{Index: 1, Paths: []string{"b/0.txt"}, EstimatedCost: 1},
+					{Index: 2, Paths: []string{"b/1.txt"}, EstimatedCost: 1},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			root := &scalibrfs.ScanRoot{FS: testFS(), Path: "/root"}
+			got, err := shard.Plan(root, tc.config)
+			if err != nil {
+				t.Fatalf("Plan(%+v): %v", tc.config, err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Plan(%+v) returned unexpected diff (-want +got):\n%s", tc.config, diff)
+			}
+		})
+	}
+}
+
+func TestPlan_InvalidShardCount(t *testing.T) {
+	root := &scalibrfs.ScanRoot{FS: testFS(), Path: "/root"}
+	if _, err := shard.Plan(root, &shard.Config{Shards: 0}); err == nil {
+		t.Error("Plan() with 0 shards succeeded, want error")
+	}
+}
+
+func TestPathsToExtract(t *testing.T) {
+	m := &shard.Manifest{
+		Root:   "/root",
+		Shards: []*shard.Shard{{Paths: []string{"README", "c/x"}}},
+	}
+	got, err := m.PathsToExtract(0)
+	if err != nil {
+		t.Fatalf("PathsToExtract(0): %v", err)
+	}
+	want := []string{filepath.Join("/root", "README"), filepath.Join("/root", "c", "x")}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("PathsToExtract(0) returned unexpected diff (-want +got):\n%s", diff)
+	}
+	if _, err := m.PathsToExtract(1); err == nil {
+		t.Error("PathsToExtract(1) succeeded for a manifest with 1 shard, want error")
+	}
+}
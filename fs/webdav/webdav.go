@@ -0,0 +1,400 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package webdav provides a read-only SCALIBR filesystem backed by a remote WebDAV share.
+// It can be used to scan corporate file shares (e.g. SharePoint, Nextcloud or IIS WebDAV
+// endpoints) without installing SCALIBR on the file server.
+package webdav
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	scalibrfs "github.com/google/osv-scalibr/fs"
+)
+
+// propfindBody requests the properties needed to build fs.FileInfo entries.
+const propfindBody = `<?xml version="1.0" encoding="utf-8"?>
+<d:propfind xmlns:d="DAV:">
+  <d:prop>
+    <d:resourcetype/>
+    <d:getcontentlength/>
+    <d:getlastmodified/>
+  </d:prop>
+</d:propfind>`
+
+// Config is the configuration for connecting to a WebDAV share.
+type Config struct {
+	// URL is the base URL of the share, e.g. https://files.example.com/dav/share/.
+	// All paths opened through the FS are resolved relative to it.
+	URL string
+	// Username and Password are used for HTTP basic authentication. No authentication
+	// header is sent if Username is empty.
+	Username string
+	Password string
+	// Client is the HTTP client used for all requests. http.DefaultClient is used if nil.
+	Client *http.Client
+}
+
+// FS is a read-only scalibrfs.FS implementation for WebDAV shares.
+type FS struct {
+	ctx      context.Context
+	base     *url.URL
+	username string
+	password string
+	client   *http.Client
+}
+
+var _ scalibrfs.FS = &FS{}
+
+// New returns an FS that accesses the WebDAV share described by the config.
+// The share root is queried once to verify that it's reachable and that the
+// credentials are valid.
+func New(ctx context.Context, cfg Config) (*FS, error) {
+	base, err := url.Parse(cfg.URL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid WebDAV URL %q: %w", cfg.URL, err)
+	}
+	if base.Scheme != "http" && base.Scheme != "https" {
+		return nil, fmt.Errorf("invalid WebDAV URL %q: scheme must be http or https", cfg.URL)
+	}
+	if !strings.HasSuffix(base.Path, "/") {
+		base.Path += "/"
+	}
+	client := cfg.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	f := &FS{
+		ctx:      ctx,
+		base:     base,
+		username: cfg.Username,
+		password: cfg.Password,
+		client:   client,
+	}
+	info, err := f.Stat(".")
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("WebDAV URL %q is not a collection", cfg.URL)
+	}
+	return f, nil
+}
+
+// Open opens the named file or directory for reading.
+func (f *FS) Open(name string) (fs.File, error) {
+	info, err := f.stat("open", name)
+	if err != nil {
+		return nil, err
+	}
+	return &file{fs: f, name: name, info: info}, nil
+}
+
+// ReadDir reads the named directory and returns its entries sorted by name.
+func (f *FS) ReadDir(name string) ([]fs.DirEntry, error) {
+	infos, err := f.propfind("readdir", name, "1")
+	if err != nil {
+		return nil, err
+	}
+	self := infos[0]
+	if !self.IsDir() {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: errors.New("not a directory")}
+	}
+	entries := make([]fs.DirEntry, 0, len(infos)-1)
+	for _, info := range infos[1:] {
+		entries = append(entries, fs.FileInfoToDirEntry(info))
+	}
+	slices.SortFunc(entries, func(a, b fs.DirEntry) int { return strings.Compare(a.Name(), b.Name()) })
+	return entries, nil
+}
+
+// Stat returns a FileInfo describing the named file or directory.
+func (f *FS) Stat(name string) (fs.FileInfo, error) {
+	return f.stat("stat", name)
+}
+
+func (f *FS) stat(op, name string) (*fileInfo, error) {
+	infos, err := f.propfind(op, name, "0")
+	if err != nil {
+		return nil, err
+	}
+	return infos[0], nil
+}
+
+// propfind returns the properties of the named resource followed by the properties of its
+// members if depth is "1". The first entry always describes the resource itself.
+func (f *FS) propfind(op, name, depth string) ([]*fileInfo, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: op, Path: name, Err: fs.ErrInvalid}
+	}
+	u := f.resolve(name)
+	req, err := f.newRequest("PROPFIND", u, strings.NewReader(propfindBody))
+	if err != nil {
+		return nil, &fs.PathError{Op: op, Path: name, Err: err}
+	}
+	req.Header.Set("Depth", depth)
+	req.Header.Set("Content-Type", "application/xml; charset=utf-8")
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, &fs.PathError{Op: op, Path: name, Err: err}
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusMultiStatus {
+		return nil, &fs.PathError{Op: op, Path: name, Err: statusError(resp)}
+	}
+
+	var ms multistatus
+	if err := xml.NewDecoder(resp.Body).Decode(&ms); err != nil {
+		return nil, &fs.PathError{Op: op, Path: name, Err: fmt.Errorf("failed to parse PROPFIND response: %w", err)}
+	}
+
+	selfPath := strings.TrimSuffix(u.Path, "/")
+	var self *fileInfo
+	var members []*fileInfo
+	for _, r := range ms.Responses {
+		p, err := hrefPath(r.Href)
+		if err != nil {
+			return nil, &fs.PathError{Op: op, Path: name, Err: err}
+		}
+		info := r.fileInfo(path.Base(p))
+		if strings.TrimSuffix(p, "/") == selfPath {
+			if name == "." {
+				info.name = "."
+			}
+			self = info
+			continue
+		}
+		members = append(members, info)
+	}
+	if self == nil {
+		return nil, &fs.PathError{Op: op, Path: name, Err: errors.New("PROPFIND response doesn't describe the requested resource")}
+	}
+	return append([]*fileInfo{self}, members...), nil
+}
+
+func (f *FS) resolve(name string) *url.URL {
+	u := *f.base
+	if name != "." {
+		u.Path = path.Join(f.base.Path, name)
+	}
+	u.RawPath = ""
+	return &u
+}
+
+func (f *FS) newRequest(method string, u *url.URL, body io.Reader) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(f.ctx, method, u.String(), body)
+	if err != nil {
+		return nil, err
+	}
+	if f.username != "" {
+		req.SetBasicAuth(f.username, f.password)
+	}
+	return req, nil
+}
+
+// get downloads the contents of the named file.
+func (f *FS) get(name string) ([]byte, error) {
+	req, err := f.newRequest(http.MethodGet, f.resolve(name), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, statusError(resp)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+func statusError(resp *http.Response) error {
+	switch resp.StatusCode {
+	case http.StatusNotFound:
+		return fs.ErrNotExist
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return fmt.Errorf("%w: %s", fs.ErrPermission, resp.Status)
+	default:
+		return fmt.Errorf("unexpected HTTP status %s", resp.Status)
+	}
+}
+
+// hrefPath returns the unescaped path of an href returned by the server. Servers may return
+// either absolute URLs or absolute paths.
+func hrefPath(href string) (string, error) {
+	u, err := url.Parse(href)
+	if err != nil {
+		return "", fmt.Errorf("invalid href %q: %w", href, err)
+	}
+	return u.Path, nil
+}
+
+type multistatus struct {
+	Responses []response `xml:"DAV: response"`
+}
+
+type response struct {
+	Href      string     `xml:"DAV: href"`
+	Propstats []propstat `xml:"DAV: propstat"`
+}
+
+type propstat struct {
+	Status string `xml:"DAV: status"`
+	Prop   struct {
+		ResourceType struct {
+			Collection *struct{} `xml:"DAV: collection"`
+		} `xml:"DAV: resourcetype"`
+		ContentLength string `xml:"DAV: getcontentlength"`
+		LastModified  string `xml:"DAV: getlastmodified"`
+	} `xml:"DAV: prop"`
+}
+
+func (r response) fileInfo(name string) *fileInfo {
+	info := &fileInfo{name: name}
+	for _, ps := range r.Propstats {
+		// Properties the server couldn't return are listed in a separate non-200 propstat.
+		if !strings.Contains(ps.Status, " 200 ") {
+			continue
+		}
+		if ps.Prop.ResourceType.Collection != nil {
+			info.isDir = true
+		}
+		if size, err := strconv.ParseInt(ps.Prop.ContentLength, 10, 64); err == nil {
+			info.size = size
+		}
+		if t, err := http.ParseTime(ps.Prop.LastModified); err == nil {
+			info.modTime = t
+		}
+	}
+	return info
+}
+
+// fileInfo implements fs.FileInfo for WebDAV resources.
+type fileInfo struct {
+	name    string
+	size    int64
+	isDir   bool
+	modTime time.Time
+}
+
+func (i *fileInfo) Name() string       { return i.name }
+func (i *fileInfo) Size() int64        { return i.size }
+func (i *fileInfo) ModTime() time.Time { return i.modTime }
+func (i *fileInfo) IsDir() bool        { return i.isDir }
+func (i *fileInfo) Sys() any           { return nil }
+func (i *fileInfo) Mode() fs.FileMode {
+	if i.isDir {
+		return fs.ModeDir | 0555
+	}
+	return 0444
+}
+
+// file is an opened WebDAV resource. File contents are downloaded on the first read and kept
+// in memory until the file is closed so that random access through io.ReaderAt is cheap.
+type file struct {
+	fs      *FS
+	name    string
+	info    *fileInfo
+	content *bytes.Reader
+	// entries holds the directory entries not yet returned by ReadDir.
+	entries []fs.DirEntry
+	listed  bool
+}
+
+var _ io.ReaderAt = &file{}
+
+func (f *file) Stat() (fs.FileInfo, error) { return f.info, nil }
+
+func (f *file) Read(b []byte) (int, error) {
+	if err := f.load("read"); err != nil {
+		return 0, err
+	}
+	return f.content.Read(b)
+}
+
+func (f *file) ReadAt(b []byte, off int64) (int, error) {
+	if err := f.load("read"); err != nil {
+		return 0, err
+	}
+	return f.content.ReadAt(b, off)
+}
+
+func (f *file) Seek(offset int64, whence int) (int64, error) {
+	if err := f.load("seek"); err != nil {
+		return 0, err
+	}
+	return f.content.Seek(offset, whence)
+}
+
+func (f *file) ReadDir(n int) ([]fs.DirEntry, error) {
+	if !f.info.IsDir() {
+		return nil, &fs.PathError{Op: "readdir", Path: f.name, Err: errors.New("not a directory")}
+	}
+	if !f.listed {
+		entries, err := f.fs.ReadDir(f.name)
+		if err != nil {
+			return nil, err
+		}
+		f.entries = entries
+		f.listed = true
+	}
+	if n <= 0 {
+		entries := f.entries
+		f.entries = nil
+		return entries, nil
+	}
+	if len(f.entries) == 0 {
+		return nil, io.EOF
+	}
+	n = min(n, len(f.entries))
+	entries := f.entries[:n]
+	f.entries = f.entries[n:]
+	return entries, nil
+}
+
+func (f *file) Close() error {
+	f.content = nil
+	f.entries = nil
+	return nil
+}
+
+func (f *file) load(op string) error {
+	if f.content != nil {
+		return nil
+	}
+	if f.info.IsDir() {
+		return &fs.PathError{Op: op, Path: f.name, Err: errors.New("is a directory")}
+	}
+	data, err := f.fs.get(f.name)
+	if err != nil {
+		return &fs.PathError{Op: op, Path: f.name, Err: err}
+	}
+	f.content = bytes.NewReader(data)
+	return nil
+}
@@ -0,0 +1,167 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webdav_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"testing"
+	"testing/fstest"
+
+	"github.com/google/osv-scalibr/fs/webdav"
+	xwebdav "golang.org/x/net/webdav"
+)
+
+const (
+	testUser     = "scanner"
+	testPassword = "hunter2"
+)
+
+var testFiles = map[string]string{
+	"package.json":                         `{"name": "app"}`,
+	"app/requirements.txt":                 "requests==2.32.3\n",
+	"app/node_modules/lodash/package.json": `{"name": "lodash", "version": "4.17.21"}`,
+	"dir with spaces/Gemfile.lock":         "GEM\n",
+}
+
+// newServer starts a WebDAV server serving testFiles under the /share/ prefix.
+func newServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	ctx := context.Background()
+	memFS := xwebdav.NewMemFS()
+	for name, content := range testFiles {
+		p := path.Join("/", name)
+		// MemFS doesn't create parent directories.
+		dir := ""
+		for _, part := range splitPath(path.Dir(p)) {
+			dir += "/" + part
+			if err := memFS.Mkdir(ctx, dir, 0755); err != nil && !errors.Is(err, os.ErrExist) {
+				t.Fatalf("Mkdir(%s): %v", dir, err)
+			}
+		}
+		f, err := memFS.OpenFile(ctx, p, os.O_CREATE|os.O_WRONLY, 0644)
+		if err != nil {
+			t.Fatalf("OpenFile(%s): %v", p, err)
+		}
+		if _, err := f.Write([]byte(content)); err != nil {
+			t.Fatalf("Write(%s): %v", p, err)
+		}
+		f.Close()
+	}
+	handler := &xwebdav.Handler{
+		Prefix:     "/share",
+		FileSystem: memFS,
+		LockSystem: xwebdav.NewMemLS(),
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, pass, ok := r.BasicAuth(); !ok || user != testUser || pass != testPassword {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		handler.ServeHTTP(w, r)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func splitPath(p string) []string {
+	var parts []string
+	for p != "/" && p != "." {
+		parts = append([]string{path.Base(p)}, parts...)
+		p = path.Dir(p)
+	}
+	return parts
+}
+
+func TestFS(t *testing.T) {
+	srv := newServer(t)
+	fsys, err := webdav.New(context.Background(), webdav.Config{
+		URL:      srv.URL + "/share",
+		Username: testUser,
+		Password: testPassword,
+	})
+	if err != nil {
+		t.Fatalf("webdav.New(): %v", err)
+	}
+
+	var want []string
+	for name := range testFiles {
+		want = append(want, name)
+	}
+	if err := fstest.TestFS(fsys, want...); err != nil {
+		t.Errorf("fstest.TestFS(): %v", err)
+	}
+}
+
+func TestReadAt(t *testing.T) {
+	srv := newServer(t)
+	fsys, err := webdav.New(context.Background(), webdav.Config{
+		URL:      srv.URL + "/share/",
+		Username: testUser,
+		Password: testPassword,
+	})
+	if err != nil {
+		t.Fatalf("webdav.New(): %v", err)
+	}
+
+	f, err := fsys.Open("app/requirements.txt")
+	if err != nil {
+		t.Fatalf("Open(): %v", err)
+	}
+	defer f.Close()
+	r, ok := f.(io.ReaderAt)
+	if !ok {
+		t.Fatalf("Open() returned a file that doesn't implement io.ReaderAt")
+	}
+	buf := make([]byte, 6)
+	if _, err := r.ReadAt(buf, 10); err != nil {
+		t.Fatalf("ReadAt(): %v", err)
+	}
+	if got, want := string(buf), "2.32.3"; got != want {
+		t.Errorf("ReadAt() got %q, want %q", got, want)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	srv := newServer(t)
+	ctx := context.Background()
+
+	if _, err := webdav.New(ctx, webdav.Config{URL: srv.URL + "/share", Username: testUser, Password: "wrong"}); !errors.Is(err, fs.ErrPermission) {
+		t.Errorf("webdav.New() with wrong password: got error %v, want %v", err, fs.ErrPermission)
+	}
+	if _, err := webdav.New(ctx, webdav.Config{URL: "ftp://example.com/share"}); err == nil {
+		t.Errorf("webdav.New() with ftp URL: got nil error, want error")
+	}
+	if _, err := webdav.New(ctx, webdav.Config{URL: srv.URL + "/share/package.json", Username: testUser, Password: testPassword}); err == nil {
+		t.Errorf("webdav.New() with file URL: got nil error, want error")
+	}
+
+	fsys, err := webdav.New(ctx, webdav.Config{URL: srv.URL + "/share", Username: testUser, Password: testPassword})
+	if err != nil {
+		t.Fatalf("webdav.New(): %v", err)
+	}
+	if _, err := fsys.Open("does/not/exist"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Open() for missing file: got error %v, want %v", err, fs.ErrNotExist)
+	}
+	if _, err := fsys.Stat("../etc/passwd"); !errors.Is(err, fs.ErrInvalid) {
+		t.Errorf("Stat() for invalid path: got error %v, want %v", err, fs.ErrInvalid)
+	}
+}
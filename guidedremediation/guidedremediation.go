@@ -213,6 +213,129 @@ func Update(opts options.UpdateOptions) (result.Result, error) {
 	}, err
 }
 
+// Bump changes the versions of the given packages in a manifest or lockfile, e.g. to build
+// automated remediation on top of SCALIBR's parsers. The formatting of the rest of the file is
+// preserved. Supported files are package.json, package-lock.json and requirements.txt.
+// Bump overwrites the file on disk and returns a Result describing the changes made.
+//
+// Note that updating package-lock.json requires access to the npm registry to fetch the
+// integrity hashes of the new versions.
+func Bump(opts options.BumpOptions) (result.Result, error) {
+	if opts.Path == "" {
+		return result.Result{}, errors.New("no manifest or lockfile provided")
+	}
+	if len(opts.Bumps) == 0 {
+		return result.Result{}, errors.New("no package bumps provided")
+	}
+
+	var (
+		sys     resolve.System
+		updates []result.PackageUpdate
+		err     error
+	)
+	if manifestRW, rwErr := readWriterForManifest(opts.Path, opts.DefaultRepository); rwErr == nil {
+		sys = manifestRW.System()
+		updates, err = bumpManifest(opts, manifestRW)
+	} else if lockfileRW, rwErr := readWriterForLockfile(opts.Path); rwErr == nil {
+		sys = lockfileRW.System()
+		updates, err = bumpLockfile(opts, lockfileRW)
+	} else {
+		return result.Result{}, fmt.Errorf("unsupported manifest or lockfile: %q", filepath.Base(opts.Path))
+	}
+
+	res := result.Result{
+		Path:      opts.Path,
+		Ecosystem: util.DepsDevToOSVEcosystem(sys),
+	}
+	if len(updates) > 0 {
+		res.Patches = []result.Patch{{PackageUpdates: updates}}
+	}
+	return res, err
+}
+
+func bumpManifest(opts options.BumpOptions, rw manifest.ReadWriter) ([]result.PackageUpdate, error) {
+	mf, err := parser.ParseManifest(opts.Path, rw)
+	if err != nil {
+		return nil, err
+	}
+
+	var updates []result.PackageUpdate
+	for _, b := range opts.Bumps {
+		found := false
+		for _, req := range mf.Requirements() {
+			if req.Name != b.Name {
+				continue
+			}
+			found = true
+			if req.Version == b.Version {
+				continue
+			}
+			updates = append(updates, result.PackageUpdate{
+				Name:        req.Name,
+				VersionFrom: req.Version,
+				VersionTo:   b.Version,
+				Type:        req.Type.Clone(),
+			})
+			// The manifest writers update all occurrences of a dependency at once.
+			break
+		}
+		if !found {
+			return nil, fmt.Errorf("package %q not found in %s", b.Name, opts.Path)
+		}
+	}
+	if len(updates) == 0 {
+		return nil, nil
+	}
+
+	return updates, parser.WriteManifestPatches(opts.Path, mf, []result.Patch{{PackageUpdates: updates}}, rw)
+}
+
+func bumpLockfile(opts options.BumpOptions, rw lockfile.ReadWriter) ([]result.PackageUpdate, error) {
+	g, err := parser.ParseLockfile(opts.Path, rw)
+	if err != nil {
+		return nil, err
+	}
+
+	// Direct dependencies are the ones required by the root node.
+	direct := make(map[resolve.NodeID]bool)
+	for _, e := range g.Edges {
+		if e.From == 0 {
+			direct[e.To] = true
+		}
+	}
+
+	var updates []result.PackageUpdate
+	for _, b := range opts.Bumps {
+		found := false
+		seen := make(map[string]bool)
+		// Skip the root node, which represents the project itself.
+		for i, n := range g.Nodes[1:] {
+			if n.Version.Name != b.Name {
+				continue
+			}
+			found = true
+			if n.Version.Version == b.Version || seen[n.Version.Version] {
+				continue
+			}
+			seen[n.Version.Version] = true
+			updates = append(updates, result.PackageUpdate{
+				Name:        b.Name,
+				VersionFrom: n.Version.Version,
+				VersionTo:   b.Version,
+				Transitive:  !direct[resolve.NodeID(i+1)],
+			})
+		}
+		if !found {
+			return nil, fmt.Errorf("package %q not found in %s", b.Name, opts.Path)
+		}
+	}
+	if len(updates) == 0 {
+		return nil, nil
+	}
+
+	return updates, parser.WriteLockfilePatches(opts.Path, []result.Patch{{PackageUpdates: updates}}, rw)
+}
+
 func doManifestStrategy(ctx context.Context, s strategy.Strategy, rw manifest.ReadWriter, opts options.FixVulnsOptions) (result.Result, error) {
 	var computePatches func(context.Context, resolve.Client, matcher.VulnerabilityMatcher, *remediation.ResolvedManifest, *options.RemediationOptions) (common.PatchResult, error)
 	switch s {
@@ -475,3 +475,91 @@ func TestUpdate(t *testing.T) {
 		})
 	}
 }
+
+func TestBump(t *testing.T) {
+	for _, tt := range []struct {
+		name        string
+		file        string
+		wantPath    string
+		bumps       []options.PackageBump
+		wantUpdates []result.PackageUpdate
+		wantErr     bool
+	}{
+		{
+			name:     "package.json",
+			file:     "testdata/bump/package.json",
+			wantPath: "testdata/bump/want.package.json",
+			bumps: []options.PackageBump{
+				{Name: "lodash", Version: "4.17.21"},
+				{Name: "chalk", Version: "^5.0.0"},
+				{Name: "mocha", Version: "~10.2.0"},
+				{Name: "left-pad", Version: "^1.1.0"},
+			},
+			wantUpdates: []result.PackageUpdate{
+				{Name: "lodash", VersionFrom: "4.17.20", VersionTo: "4.17.21"},
+				{Name: "chalk", VersionFrom: "^4.0.0", VersionTo: "^5.0.0"},
+				{Name: "mocha", VersionFrom: "~9.0.0", VersionTo: "~10.2.0"},
+			},
+		},
+		{
+			name:     "requirements.txt",
+			file:     "testdata/bump/requirements.txt",
+			wantPath: "testdata/bump/want.requirements.txt",
+			bumps: []options.PackageBump{
+				{Name: "requests", Version: "2.32.4"},
+				{Name: "urllib3", Version: ">=2.2.2,<3.0.0"},
+			},
+			wantUpdates: []result.PackageUpdate{
+				{Name: "requests", VersionFrom: "==2.31.0", VersionTo: "2.32.4"},
+				{Name: "urllib3", VersionFrom: ">= 1.26.0, < 2.0.0", VersionTo: ">=2.2.2,<3.0.0"},
+			},
+		},
+		{
+			name:     "package not found",
+			file:     "testdata/bump/requirements.txt",
+			wantPath: "testdata/bump/requirements.txt",
+			bumps:    []options.PackageBump{{Name: "django", Version: "5.0.0"}},
+			wantErr:  true,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), filepath.Base(tt.file))
+			data, err := os.ReadFile(tt.file)
+			if err != nil {
+				t.Fatalf("failed reading file for copy: %v", err)
+			}
+			if err := os.WriteFile(path, data, 0644); err != nil {
+				t.Fatalf("failed copying file: %v", err)
+			}
+
+			res, err := guidedremediation.Bump(options.BumpOptions{Path: path, Bumps: tt.bumps})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Bump() error: %v, want error: %t", err, tt.wantErr)
+			}
+
+			want, err := os.ReadFile(tt.wantPath)
+			if err != nil {
+				t.Fatalf("failed reading want file for comparison: %v", err)
+			}
+			got, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("failed reading got file for comparison: %v", err)
+			}
+			if runtime.GOOS == "windows" {
+				want = bytes.ReplaceAll(want, []byte("\r\n"), []byte("\n"))
+				got = bytes.ReplaceAll(got, []byte("\r\n"), []byte("\n"))
+			}
+			if diff := cmp.Diff(string(want), string(got)); diff != "" {
+				t.Errorf("Bump() file mismatch (-want +got):\n%s", diff)
+			}
+
+			var gotUpdates []result.PackageUpdate
+			for _, p := range res.Patches {
+				gotUpdates = append(gotUpdates, p.PackageUpdates...)
+			}
+			if diff := cmp.Diff(tt.wantUpdates, gotUpdates, cmpopts.IgnoreFields(result.PackageUpdate{}, "Type")); diff != "" {
+				t.Errorf("Bump() package updates mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -157,7 +157,7 @@ func (r readWriter) SupportedStrategies() []strategy.Strategy {
 type PackageJSON struct {
 	Name                 string            `json:"name"`
 	Version              string            `json:"version"`
-	Workspaces           []string          `json:"workspaces"`
+	Workspaces           Workspaces        `json:"workspaces"`
 	Dependencies         map[string]string `json:"dependencies"`
 	DevDependencies      map[string]string `json:"devDependencies"`
 	OptionalDependencies map[string]string `json:"optionalDependencies"`
@@ -167,6 +167,27 @@ type PackageJSON struct {
 	} `json:"peerDependenciesMeta,omitempty"`
 }
 
+// Workspaces are the workspace patterns of a package.json file. Yarn also
+// accepts an object with the patterns in its "packages" field.
+type Workspaces []string
+
+// UnmarshalJSON parses both the array and the object form of the workspaces.
+func (w *Workspaces) UnmarshalJSON(data []byte) error {
+	var patterns []string
+	if err := json.Unmarshal(data, &patterns); err == nil {
+		*w = patterns
+		return nil
+	}
+	var yarn struct {
+		Packages []string `json:"packages"`
+	}
+	if err := json.Unmarshal(data, &yarn); err != nil {
+		return fmt.Errorf("invalid workspaces: %w", err)
+	}
+	*w = yarn.Packages
+	return nil
+}
+
 // Read parses the manifest from the given file.
 func (r readWriter) Read(path string, fsys scalibrfs.FS) (manifest.Manifest, error) {
 	return parse(path, fsys, true)
@@ -387,9 +408,41 @@ func SplitNPMAlias(v string) (name, version string) {
 }
 
 // Write applies the patches to the original manifest, writing the resulting manifest file to the file path in the filesystem.
+// Patches of workspace dependencies are written to the workspace manifests, relative to the output path.
 func (r readWriter) Write(original manifest.Manifest, fsys scalibrfs.FS, patches []result.Patch, outputPath string) error {
+	// Group the updates by the manifest that declares them.
+	updates := make(map[string][]result.PackageUpdate)
+	for _, patch := range patches {
+		for _, req := range patch.PackageUpdates {
+			updates[req.Workspace] = append(updates[req.Workspace], req)
+		}
+	}
+	// Always write the root manifest, even if there are no updates to it.
+	if _, ok := updates[""]; !ok {
+		updates[""] = nil
+	}
+
+	for _, workspace := range slices.Sorted(maps.Keys(updates)) {
+		inPath, outPath := original.FilePath(), outputPath
+		if workspace != "" {
+			if !filepath.IsLocal(filepath.FromSlash(workspace)) {
+				return fmt.Errorf("workspace manifest %q is outside of the root manifest directory", workspace)
+			}
+			inPath = filepath.ToSlash(filepath.Join(filepath.Dir(inPath), workspace))
+			outPath = filepath.Join(filepath.Dir(outPath), filepath.FromSlash(workspace))
+		}
+		if err := writePatched(fsys, inPath, outPath, updates[workspace]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// writePatched applies the updates to the package.json file at inPath in the filesystem and writes the result to outPath.
+func writePatched(fsys scalibrfs.FS, inPath, outPath string, updates []result.PackageUpdate) error {
 	// Read the whole package.json into memory so we can use sjson to write in-place.
-	f, err := fsys.Open(original.FilePath())
+	f, err := fsys.Open(inPath)
 	if err != nil {
 		return err
 	}
@@ -399,74 +452,72 @@ func (r readWriter) Write(original manifest.Manifest, fsys scalibrfs.FS, patches
 		return err
 	}
 
-	for _, patch := range patches {
-		for _, req := range patch.PackageUpdates {
-			name := req.Name
-			origVer := req.VersionFrom
-			newVer := req.VersionTo
-			if knownAs, ok := req.Type.GetAttr(dep.KnownAs); ok {
-				// reconstruct alias versioning
-				origVer = fmt.Sprintf("npm:%s@%s", name, origVer)
-				newVer = fmt.Sprintf("npm:%s@%s", name, newVer)
-				name = knownAs
+	for _, req := range updates {
+		name := req.Name
+		origVer := req.VersionFrom
+		newVer := req.VersionTo
+		if knownAs, ok := req.Type.GetAttr(dep.KnownAs); ok {
+			// reconstruct alias versioning
+			origVer = fmt.Sprintf("npm:%s@%s", name, origVer)
+			newVer = fmt.Sprintf("npm:%s@%s", name, newVer)
+			name = knownAs
+		}
+
+		// Don't know what kind of dependency this is, so check them all.
+		// Check them in dev -> optional -> prod because that's the order npm seems to use when they conflict.
+		alreadyMatched := false
+		depStr := "devDependencies." + name
+		if res := gjson.GetBytes(manif, depStr); res.Exists() {
+			ver := res.String()
+			if ver != origVer {
+				return fmt.Errorf("original dependency version does not match patch: %s %q != %q", name, ver, origVer)
+			}
+			manif, err = sjson.SetBytes(manif, depStr, newVer)
+			if err != nil {
+				return err
 			}
+			alreadyMatched = true
+		}
 
-			// Don't know what kind of dependency this is, so check them all.
-			// Check them in dev -> optional -> prod because that's the order npm seems to use when they conflict.
-			alreadyMatched := false
-			depStr := "devDependencies." + name
-			if res := gjson.GetBytes(manif, depStr); res.Exists() {
-				ver := res.String()
-				if ver != origVer {
+		depStr = "optionalDependencies." + name
+		if res := gjson.GetBytes(manif, depStr); res.Exists() {
+			ver := res.String()
+			if ver != origVer {
+				if !alreadyMatched {
 					return fmt.Errorf("original dependency version does not match patch: %s %q != %q", name, ver, origVer)
 				}
+				// dependency was already matched, so we can ignore it.
+			} else {
 				manif, err = sjson.SetBytes(manif, depStr, newVer)
 				if err != nil {
 					return err
 				}
 				alreadyMatched = true
 			}
+		}
 
-			depStr = "optionalDependencies." + name
-			if res := gjson.GetBytes(manif, depStr); res.Exists() {
-				ver := res.String()
-				if ver != origVer {
-					if !alreadyMatched {
-						return fmt.Errorf("original dependency version does not match patch: %s %q != %q", name, ver, origVer)
-					}
-					// dependency was already matched, so we can ignore it.
-				} else {
-					manif, err = sjson.SetBytes(manif, depStr, newVer)
-					if err != nil {
-						return err
-					}
-					alreadyMatched = true
+		depStr = "dependencies." + name
+		if res := gjson.GetBytes(manif, depStr); res.Exists() {
+			ver := res.String()
+			if ver != origVer {
+				if !alreadyMatched {
+					return fmt.Errorf("original dependency version does not match patch: %s %q != %q", name, ver, origVer)
 				}
-			}
-
-			depStr = "dependencies." + name
-			if res := gjson.GetBytes(manif, depStr); res.Exists() {
-				ver := res.String()
-				if ver != origVer {
-					if !alreadyMatched {
-						return fmt.Errorf("original dependency version does not match patch: %s %q != %q", name, ver, origVer)
-					}
-					// dependency was already matched, so we can ignore it.
-				} else {
-					manif, err = sjson.SetBytes(manif, depStr, newVer)
-					if err != nil {
-						return err
-					}
+				// dependency was already matched, so we can ignore it.
+			} else {
+				manif, err = sjson.SetBytes(manif, depStr, newVer)
+				if err != nil {
+					return err
 				}
 			}
 		}
 	}
 
 	// Write the patched manifest to the output path.
-	if err := os.MkdirAll(filepath.Dir(outputPath), 0755); err != nil {
+	if err := os.MkdirAll(filepath.Dir(outPath), 0755); err != nil {
 		return err
 	}
-	if err := os.WriteFile(outputPath, manif, 0644); err != nil {
+	if err := os.WriteFile(outPath, manif, 0644); err != nil {
 		return err
 	}
 
@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"deps.dev/util/resolve"
@@ -326,3 +327,115 @@ func TestWrite(t *testing.T) {
 		t.Errorf("package.json (-want +got):\n%s", diff)
 	}
 }
+
+func TestReadWithYarnWorkspaces(t *testing.T) {
+	rw, err := npm.GetReadWriter("")
+	if err != nil {
+		t.Fatalf("error creating ReadWriter: %v", err)
+	}
+	fsys := scalibrfs.DirFS("./testdata/yarn-workspaces")
+	got, err := rw.Read("package.json", fsys)
+	if err != nil {
+		t.Fatalf("error reading manifest: %v", err)
+	}
+
+	want := testManifest{
+		FilePath: "package.json",
+		Root: resolve.Version{
+			VersionKey: makeVK(t, "yarn-workspace-test", "1.0.0", resolve.Concrete),
+		},
+		System: resolve.NPM,
+		Requirements: []resolve.RequirementVersion{
+			{
+				VersionKey: makeVK(t, "app:workspace", "*", resolve.Requirement),
+			},
+		},
+		Groups: map[manifest.RequirementKey][]string{},
+		LocalManifests: []testManifest{
+			{
+				FilePath: "packages/app/package.json",
+				Root: resolve.Version{
+					VersionKey: makeVK(t, "app:workspace", "0.1.0", resolve.Concrete),
+				},
+				System: resolve.NPM,
+				Requirements: []resolve.RequirementVersion{
+					{
+						VersionKey: makeVK(t, "lodash", "^4.17.20", resolve.Requirement),
+					},
+				},
+				Groups: map[manifest.RequirementKey][]string{},
+			},
+		},
+	}
+	checkManifest(t, "Manifest", got, want)
+}
+
+func TestWriteWorkspaces(t *testing.T) {
+	rw, err := npm.GetReadWriter("")
+	if err != nil {
+		t.Fatalf("error creating ReadWriter: %v", err)
+	}
+	fsys := scalibrfs.DirFS("./testdata/workspaces")
+	manif, err := rw.Read("package.json", fsys)
+	if err != nil {
+		t.Fatalf("error reading manifest: %v", err)
+	}
+
+	patches := []result.Patch{
+		{
+			PackageUpdates: []result.PackageUpdate{
+				{
+					Name:        "jquery",
+					VersionFrom: "^3.7.1",
+					VersionTo:   "^3.7.2",
+				},
+				{
+					Name:        "semver",
+					VersionFrom: "^6.3.1",
+					VersionTo:   "^6.3.2",
+					Workspace:   "ws/ugh/package.json",
+				},
+			},
+		},
+		{
+			PackageUpdates: []result.PackageUpdate{
+				{
+					Name:        "semver",
+					VersionFrom: "^5.7.2",
+					VersionTo:   "^7.6.0",
+					Workspace:   "z/package.json",
+				},
+			},
+		},
+	}
+	outDir := t.TempDir()
+	if err := rw.Write(manif, fsys, patches, filepath.Join(outDir, "package.json")); err != nil {
+		t.Fatalf("failed to write package.json: %v", err)
+	}
+
+	tests := []struct {
+		path     string
+		from, to string
+	}{
+		{path: "package.json", from: `"jquery": "^3.7.1"`, to: `"jquery": "^3.7.2"`},
+		{path: "ws/ugh/package.json", from: `"semver": "^6.3.1"`, to: `"semver": "^6.3.2"`},
+		{path: "z/package.json", from: `"semver": "^5.7.2"`, to: `"semver": "^7.6.0"`},
+	}
+	for _, tc := range tests {
+		orig, err := os.ReadFile(filepath.Join("./testdata/workspaces", tc.path))
+		if err != nil {
+			t.Fatalf("failed to read original %s: %v", tc.path, err)
+		}
+		got, err := os.ReadFile(filepath.Join(outDir, tc.path))
+		if err != nil {
+			t.Fatalf("failed to read got %s: %v", tc.path, err)
+		}
+		want := strings.Replace(string(orig), tc.from, tc.to, 1)
+		if diff := cmp.Diff(want, string(got)); diff != "" {
+			t.Errorf("%s (-want +got):\n%s", tc.path, diff)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(outDir, "ws/jquery/package.json")); err == nil {
+		t.Errorf("unpatched workspace ws/jquery/package.json was written")
+	}
+}
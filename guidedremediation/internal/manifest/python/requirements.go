@@ -240,15 +240,24 @@ func tokenizeRequirement(requirement string) []VersionConstraint {
 			continue
 		}
 
+		matched := false
 		for _, op := range operators {
 			if strings.HasPrefix(constraint, op) {
 				tokenized = append(tokenized, VersionConstraint{
 					operator: op,
 					version:  constraint[len(op):],
 				})
+				matched = true
 				break
 			}
 		}
+		if !matched {
+			// A bare version pins the requirement to that version.
+			tokenized = append(tokenized, VersionConstraint{
+				operator: "==",
+				version:  constraint,
+			})
+		}
 	}
 
 	return tokenized
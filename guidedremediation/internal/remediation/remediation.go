@@ -18,6 +18,7 @@ package remediation
 import (
 	"cmp"
 	"context"
+	"path/filepath"
 	"slices"
 
 	"deps.dev/util/resolve"
@@ -167,7 +168,11 @@ func ConstructPatches(oldRes, newRes *ResolvedManifest) result.Patch {
 			Transitive:  !direct,
 		})
 	}
+	output.PackageUpdates = append(output.PackageUpdates, workspaceUpdates(oldRes.Manifest, newRes.Manifest)...)
 	cmpFn := func(a, b result.PackageUpdate) int {
+		if c := cmp.Compare(a.Workspace, b.Workspace); c != 0 {
+			return c
+		}
 		if c := cmp.Compare(a.Name, b.Name); c != 0 {
 			return c
 		}
@@ -188,3 +193,39 @@ func ConstructPatches(oldRes, newRes *ResolvedManifest) result.Patch {
 
 	return output
 }
+
+// workspaceUpdates returns the updates made to the requirements of the local
+// manifests (e.g. npm workspaces) of oldM to get newM.
+func workspaceUpdates(oldM, newM manifest.Manifest) []result.PackageUpdate {
+	oldLocals := oldM.LocalManifests()
+	newLocals := newM.LocalManifests()
+	if len(oldLocals) != len(newLocals) {
+		return nil
+	}
+	rootDir := filepath.Dir(oldM.FilePath())
+	var updates []result.PackageUpdate
+	for i, oldLocal := range oldLocals {
+		workspace, err := filepath.Rel(rootDir, oldLocal.FilePath())
+		if err != nil {
+			workspace = oldLocal.FilePath()
+		}
+		oldReqs := make(map[manifest.RequirementKey]resolve.RequirementVersion)
+		for _, req := range oldLocal.Requirements() {
+			oldReqs[resolution.MakeRequirementKey(req)] = req
+		}
+		for _, req := range newLocals[i].Requirements() {
+			oldReq, ok := oldReqs[resolution.MakeRequirementKey(req)]
+			if !ok || req.Version == oldReq.Version {
+				continue
+			}
+			updates = append(updates, result.PackageUpdate{
+				Name:        req.Name,
+				VersionFrom: oldReq.Version,
+				VersionTo:   req.Version,
+				Type:        oldReq.Type.Clone(),
+				Workspace:   filepath.ToSlash(workspace),
+			})
+		}
+	}
+	return updates
+}
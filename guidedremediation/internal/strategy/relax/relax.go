@@ -21,6 +21,7 @@ import (
 	"slices"
 
 	"deps.dev/util/resolve"
+	"github.com/google/osv-scalibr/guidedremediation/internal/manifest"
 	"github.com/google/osv-scalibr/guidedremediation/internal/remediation"
 	"github.com/google/osv-scalibr/guidedremediation/internal/resolution"
 	"github.com/google/osv-scalibr/guidedremediation/internal/strategy/common"
@@ -64,11 +65,18 @@ func patchVulns(ctx context.Context, cl resolve.Client, vm matcher.Vulnerability
 			if opts.UpgradeConfig.Get(req.VersionKey.Name) == upgrade.None {
 				return nil, common.ErrPatchImpossible
 			}
-			newVer, ok := reqRelaxer.Relax(ctx, cl, req, opts.UpgradeConfig)
+			newVer, ok := reqRelaxer.Relax(ctx, cl, req.RequirementVersion, opts.UpgradeConfig)
 			if !ok {
 				return nil, common.ErrPatchImpossible
 			}
-			if err := resolved.Manifest.PatchRequirement(newVer); err != nil {
+			m := resolved.Manifest
+			if req.workspace != nil {
+				m = localManifest(resolved.Manifest, *req.workspace)
+			}
+			if m == nil {
+				return nil, fmt.Errorf("workspace %v not found in manifest", *req.workspace)
+			}
+			if err := m.PatchRequirement(newVer); err != nil {
 				return nil, fmt.Errorf("failed to patch requirement %v: %w", newVer, err)
 			}
 		}
@@ -91,8 +99,27 @@ func patchVulns(ctx context.Context, cl resolve.Client, vm matcher.Vulnerability
 	return resolved, nil
 }
 
-func reqsToRelax(ctx context.Context, cl resolve.Client, resolved *remediation.ResolvedManifest, vulnIDs []string, opts *options.RemediationOptions) []resolve.RequirementVersion {
-	var toRelax []resolve.RequirementVersion
+// relaxReq is a requirement to relax.
+type relaxReq struct {
+	resolve.RequirementVersion
+
+	// The root of the local manifest (e.g. an npm workspace) that declares the
+	// requirement, or nil if the root manifest declares it.
+	workspace *resolve.PackageKey
+}
+
+// localManifest returns the local manifest of m with the given root package, or nil if there is none.
+func localManifest(m manifest.Manifest, pk resolve.PackageKey) manifest.Manifest {
+	for _, lm := range m.LocalManifests() {
+		if lm.Root().PackageKey == pk {
+			return lm
+		}
+	}
+	return nil
+}
+
+func reqsToRelax(ctx context.Context, cl resolve.Client, resolved *remediation.ResolvedManifest, vulnIDs []string, opts *options.RemediationOptions) []relaxReq {
+	var toRelax []relaxReq
 	for _, v := range resolved.Vulns {
 		if !slices.Contains(vulnIDs, v.OSV.ID) {
 			continue
@@ -102,29 +129,70 @@ func reqsToRelax(ctx context.Context, cl resolve.Client, resolved *remediation.R
 			constr := sg.ConstrainingSubgraph(ctx, cl, v.OSV)
 			for _, edge := range constr.Nodes[0].Children {
 				gNode := constr.Nodes[edge.To]
-				if opts.MaxDepth > 0 && gNode.Distance+1 > opts.MaxDepth {
+				if localManifest(resolved.Manifest, gNode.Version.PackageKey) == nil {
+					if opts.MaxDepth > 0 && gNode.Distance+1 > opts.MaxDepth {
+						continue
+					}
+					toRelax = append(toRelax, relaxReq{RequirementVersion: edgeRequirement(edge, gNode)})
 					continue
 				}
-				toRelax = append(toRelax, resolve.RequirementVersion{
-					VersionKey: resolve.VersionKey{
-						PackageKey:  gNode.Version.PackageKey,
-						Version:     edge.Requirement,
-						VersionType: resolve.Requirement,
-					},
-					Type: edge.Type.Clone(),
-				})
+				// Workspaces can't be relaxed, relax the requirements of the workspace instead.
+				workspace := gNode.Version.PackageKey
+				for _, wsEdge := range gNode.Children {
+					wsNode := constr.Nodes[wsEdge.To]
+					if localManifest(resolved.Manifest, wsNode.Version.PackageKey) != nil {
+						// Requirements on sibling workspaces are relaxed through the sibling itself.
+						continue
+					}
+					if opts.MaxDepth > 0 && wsNode.Distance+1 > opts.MaxDepth {
+						continue
+					}
+					toRelax = append(toRelax, relaxReq{
+						RequirementVersion: edgeRequirement(wsEdge, wsNode),
+						workspace:          &workspace,
+					})
+				}
 			}
 		}
 	}
 
-	cmpFn := func(a, b resolve.RequirementVersion) int {
+	cmpFn := func(a, b relaxReq) int {
+		if cmp := cmpWorkspace(a.workspace, b.workspace); cmp != 0 {
+			return cmp
+		}
 		if cmp := a.VersionKey.Compare(b.VersionKey); cmp != 0 {
 			return cmp
 		}
 		return a.Type.Compare(b.Type)
 	}
 	slices.SortFunc(toRelax, cmpFn)
-	toRelax = slices.CompactFunc(toRelax, func(a, b resolve.RequirementVersion) bool { return cmpFn(a, b) == 0 })
+	toRelax = slices.CompactFunc(toRelax, func(a, b relaxReq) bool { return cmpFn(a, b) == 0 })
 
 	return toRelax
 }
+
+// edgeRequirement returns the requirement of the edge to the given node.
+func edgeRequirement(edge resolve.Edge, node resolution.GraphNode) resolve.RequirementVersion {
+	return resolve.RequirementVersion{
+		VersionKey: resolve.VersionKey{
+			PackageKey:  node.Version.PackageKey,
+			Version:     edge.Requirement,
+			VersionType: resolve.Requirement,
+		},
+		Type: edge.Type.Clone(),
+	}
+}
+
+// cmpWorkspace orders the requirements of the root manifest before those of workspaces.
+func cmpWorkspace(a, b *resolve.PackageKey) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return -1
+	case b == nil:
+		return 1
+	default:
+		return a.Compare(*b)
+	}
+}
@@ -118,6 +118,15 @@ func TestComputePatches(t *testing.T) {
 			},
 			wantFile: "testdata/npm/deepen/patches.json",
 		},
+		{
+			name:         "npm-workspaces",
+			universeFile: "testdata/npm/universe.yaml",
+			vulnsFile:    "testdata/npm/vulnerabilities.yaml",
+			manifestPath: "npm/workspaces/package.json",
+			readWriter:   npmRW,
+			opts:         options.DefaultRemediationOptions(),
+			wantFile:     "testdata/npm/workspaces/patches.json",
+		},
 		{
 			name:         "python-simple",
 			universeFile: "testdata/python/universe.yaml",
@@ -85,3 +85,19 @@ type UpdateOptions struct {
 	IgnoreDev     bool           // Whether to ignore updates on dev dependencies
 	UpgradeConfig upgrade.Config // Allowed upgrade levels per package.
 }
+
+// BumpOptions are the options for performing guidedremediation.Bump().
+type BumpOptions struct {
+	Path              string        // Path to the manifest or lockfile on disk.
+	Bumps             []PackageBump // Packages to change the version of.
+	DefaultRepository string        // Default registry to fetch dependency information from.
+}
+
+// PackageBump describes a change of a package to a specific version.
+type PackageBump struct {
+	Name string // Name of the package.
+	// Version to change the package to. For manifests, this is written as the new requirement
+	// (e.g. "^2.0.0" in package.json or ">=2.0.0,<3.0.0" in requirements.txt).
+	// For lockfiles, this must be a concrete version.
+	Version string
+}
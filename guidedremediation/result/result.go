@@ -118,6 +118,9 @@ type PackageUpdate struct {
 	VersionFrom string `json:"versionFrom"` // version of the dependency before the patch.
 	VersionTo   string `json:"versionTo"`   // version of the dependency after the patch.
 	Transitive  bool   `json:"transitive"`  // false if this package is a direct dependency, true if indirect.
+	// Path of the workspace manifest (e.g. of an npm workspace) that declares the dependency,
+	// relative to the directory of the root manifest. Empty if the root manifest declares it.
+	Workspace string `json:"workspace,omitempty"`
 
 	Type dep.Type `json:"-"`
 }
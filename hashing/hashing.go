@@ -0,0 +1,200 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package hashing provides the digest algorithms used by SCALIBR plugins and a
+// config to select them, including a FIPS-compliant mode that only allows
+// FIPS 140 approved algorithms.
+package hashing
+
+import (
+	"crypto/fips140"
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/zeebo/blake3"
+)
+
+// Algorithm is a digest algorithm supported by SCALIBR.
+type Algorithm string
+
+// Algorithm values.
+const (
+	SHA256 Algorithm = "sha256"
+	SHA512 Algorithm = "sha512"
+	SHA1   Algorithm = "sha1"
+	BLAKE3 Algorithm = "blake3"
+	// MD5 is only meant for correlating files with allowlists and threat
+	// intelligence feeds that still use it.
+	MD5 Algorithm = "md5"
+)
+
+var (
+	// ErrUnknownAlgorithm is returned when an unsupported algorithm is requested.
+	ErrUnknownAlgorithm = errors.New("unknown hash algorithm")
+	// ErrNotFIPSApproved is returned when a non FIPS 140 approved algorithm is
+	// requested while running in FIPS mode.
+	ErrNotFIPSApproved = errors.New("hash algorithm is not FIPS 140 approved")
+)
+
+// fipsApproved lists the algorithms approved by FIPS 180-4 for use in FIPS mode.
+var fipsApproved = map[Algorithm]bool{
+	SHA256: true,
+	SHA512: true,
+	SHA1:   true,
+}
+
+// Config selects the digests computed by plugins that hash file contents.
+type Config struct {
+	// The digest algorithms to compute, e.g. SHA-256 only or SHA-1 + SHA-256.
+	Algorithms []Algorithm
+	// If true, only FIPS 140 approved algorithms can be used. FIPS mode is also
+	// enabled implicitly if the Go runtime runs in FIPS 140-3 mode.
+	FIPSMode bool
+}
+
+// DefaultConfig returns the default hashing config, which only computes SHA-256.
+func DefaultConfig() *Config {
+	return &Config{Algorithms: []Algorithm{SHA256}}
+}
+
+// Configurable is implemented by plugins that compute digests and can have
+// their hashing config set by the core library.
+type Configurable interface {
+	SetHashingConfig(cfg *Config)
+}
+
+// ParseAlgorithm returns the Algorithm for the given name, e.g. "SHA-256" or "sha256".
+func ParseAlgorithm(name string) (Algorithm, error) {
+	a := Algorithm(strings.ReplaceAll(strings.ToLower(strings.TrimSpace(name)), "-", ""))
+	switch a {
+	case SHA256, SHA512, SHA1, BLAKE3, MD5:
+		return a, nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrUnknownAlgorithm, name)
+	}
+}
+
+// IsFIPSApproved returns true if the algorithm is FIPS 140 approved.
+func IsFIPSApproved(a Algorithm) bool {
+	return fipsApproved[a]
+}
+
+// FIPS returns whether the config restricts hashing to FIPS 140 approved algorithms.
+func (c *Config) FIPS() bool {
+	return (c != nil && c.FIPSMode) || fips140.Enabled()
+}
+
+// Validate checks that all configured algorithms are known and usable under
+// the configured FIPS mode.
+func (c *Config) Validate() error {
+	if c == nil {
+		return nil
+	}
+	var errs []error
+	for _, a := range c.Algorithms {
+		if err := c.check(a); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Allows returns true if the algorithm may be used under this config. Unlike
+// Enabled, this only checks FIPS restrictions and not the selected algorithms.
+// It's meant for digests that are required by external systems, e.g. the SHA-1
+// of JAR files used for deps.dev lookups.
+func (c *Config) Allows(a Algorithm) bool {
+	return c.check(a) == nil
+}
+
+// Enabled returns true if the algorithm is selected in the config and allowed
+// to be used.
+func (c *Config) Enabled(a Algorithm) bool {
+	if c == nil {
+		return false
+	}
+	return slices.Contains(c.Algorithms, a) && c.Allows(a)
+}
+
+// New returns a new hash.Hash for the algorithm, or an error if the
+// algorithm is unknown or not allowed by the config.
+func (c *Config) New(a Algorithm) (hash.Hash, error) {
+	if err := c.check(a); err != nil {
+		return nil, err
+	}
+	switch a {
+	case SHA256:
+		return sha256.New(), nil
+	case SHA512:
+		return sha512.New(), nil
+	case SHA1:
+		return sha1.New(), nil
+	case BLAKE3:
+		return blake3.New(), nil
+	case MD5:
+		return md5.New(), nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownAlgorithm, a)
+	}
+}
+
+// Digest computes the digests of all configured algorithms over the contents
+// of r in a single pass. The result maps each algorithm to the hex-encoded digest.
+func (c *Config) Digest(r io.Reader) (map[Algorithm]string, error) {
+	if c == nil || len(c.Algorithms) == 0 {
+		return nil, nil
+	}
+	hashers := make(map[Algorithm]hash.Hash, len(c.Algorithms))
+	writers := make([]io.Writer, 0, len(c.Algorithms))
+	for _, a := range c.Algorithms {
+		if _, ok := hashers[a]; ok {
+			continue
+		}
+		h, err := c.New(a)
+		if err != nil {
+			return nil, err
+		}
+		hashers[a] = h
+		writers = append(writers, h)
+	}
+	if _, err := io.Copy(io.MultiWriter(writers...), r); err != nil {
+		return nil, err
+	}
+	result := make(map[Algorithm]string, len(hashers))
+	for a, h := range hashers {
+		result[a] = hex.EncodeToString(h.Sum(nil))
+	}
+	return result, nil
+}
+
+func (c *Config) check(a Algorithm) error {
+	switch a {
+	case SHA256, SHA512, SHA1, BLAKE3, MD5:
+	default:
+		return fmt.Errorf("%w: %q", ErrUnknownAlgorithm, a)
+	}
+	if c.FIPS() && !IsFIPSApproved(a) {
+		return fmt.Errorf("%w: %q", ErrNotFIPSApproved, a)
+	}
+	return nil
+}
@@ -0,0 +1,177 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hashing_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/osv-scalibr/hashing"
+)
+
+func TestParseAlgorithm(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    hashing.Algorithm
+		wantErr error
+	}{
+		{name: "sha256", want: hashing.SHA256},
+		{name: "SHA-256", want: hashing.SHA256},
+		{name: "SHA-512", want: hashing.SHA512},
+		{name: "SHA-1", want: hashing.SHA1},
+		{name: "BLAKE3", want: hashing.BLAKE3},
+		{name: "MD5", want: hashing.MD5},
+		{name: "md4", wantErr: hashing.ErrUnknownAlgorithm},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := hashing.ParseAlgorithm(tc.name)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("ParseAlgorithm(%q) error: got %v, want %v", tc.name, err, tc.wantErr)
+			}
+			if got != tc.want {
+				t.Errorf("ParseAlgorithm(%q): got %q, want %q", tc.name, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		desc    string
+		cfg     *hashing.Config
+		wantErr error
+	}{
+		{
+			desc: "nil config",
+			cfg:  nil,
+		},
+		{
+			desc: "default config",
+			cfg:  hashing.DefaultConfig(),
+		},
+		{
+			desc: "blake3 without FIPS",
+			cfg:  &hashing.Config{Algorithms: []hashing.Algorithm{hashing.BLAKE3}},
+		},
+		{
+			desc: "approved algorithms in FIPS mode",
+			cfg:  &hashing.Config{Algorithms: []hashing.Algorithm{hashing.SHA1, hashing.SHA256}, FIPSMode: true},
+		},
+		{
+			desc:    "blake3 in FIPS mode",
+			cfg:     &hashing.Config{Algorithms: []hashing.Algorithm{hashing.SHA256, hashing.BLAKE3}, FIPSMode: true},
+			wantErr: hashing.ErrNotFIPSApproved,
+		},
+		{
+			desc:    "unknown algorithm",
+			cfg:     &hashing.Config{Algorithms: []hashing.Algorithm{"md4"}},
+			wantErr: hashing.ErrUnknownAlgorithm,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			if err := tc.cfg.Validate(); !errors.Is(err, tc.wantErr) {
+				t.Errorf("Validate() error: got %v, want %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestEnabled(t *testing.T) {
+	cfg := &hashing.Config{Algorithms: []hashing.Algorithm{hashing.SHA256, hashing.BLAKE3}, FIPSMode: true}
+	if !cfg.Enabled(hashing.SHA256) {
+		t.Errorf("Enabled(SHA256): got false, want true")
+	}
+	if cfg.Enabled(hashing.SHA1) {
+		t.Errorf("Enabled(SHA1): got true, want false since it's not selected")
+	}
+	if cfg.Enabled(hashing.BLAKE3) {
+		t.Errorf("Enabled(BLAKE3): got true, want false in FIPS mode")
+	}
+	if !cfg.Allows(hashing.SHA1) {
+		t.Errorf("Allows(SHA1): got false, want true in FIPS mode")
+	}
+}
+
+func TestDigest(t *testing.T) {
+	tests := []struct {
+		desc    string
+		cfg     *hashing.Config
+		want    map[hashing.Algorithm]string
+		wantErr error
+	}{
+		{
+			desc: "sha256 only",
+			cfg:  hashing.DefaultConfig(),
+			want: map[hashing.Algorithm]string{
+				hashing.SHA256: "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824",
+			},
+		},
+		{
+			desc: "sha1 and sha256",
+			cfg:  &hashing.Config{Algorithms: []hashing.Algorithm{hashing.SHA1, hashing.SHA256, hashing.SHA1}},
+			want: map[hashing.Algorithm]string{
+				hashing.SHA1:   "aaf4c61ddcc5e8a2dabede0f3b482cd9aea9434d",
+				hashing.SHA256: "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824",
+			},
+		},
+		{
+			desc: "blake3",
+			cfg:  &hashing.Config{Algorithms: []hashing.Algorithm{hashing.BLAKE3}},
+			want: map[hashing.Algorithm]string{
+				hashing.BLAKE3: "ea8f163db38682925e4491c5e58d4bb3506ef8c14eb78a86e908c5624a67200f",
+			},
+		},
+		{
+			desc:    "blake3 in FIPS mode",
+			cfg:     &hashing.Config{Algorithms: []hashing.Algorithm{hashing.BLAKE3}, FIPSMode: true},
+			wantErr: hashing.ErrNotFIPSApproved,
+		},
+		{
+			desc: "md5",
+			cfg:  &hashing.Config{Algorithms: []hashing.Algorithm{hashing.MD5}},
+			want: map[hashing.Algorithm]string{
+				hashing.MD5: "5d41402abc4b2a76b9719d911017c592",
+			},
+		},
+		{
+			desc:    "md5 in FIPS mode",
+			cfg:     &hashing.Config{Algorithms: []hashing.Algorithm{hashing.MD5}, FIPSMode: true},
+			wantErr: hashing.ErrNotFIPSApproved,
+		},
+		{
+			desc: "no algorithms",
+			cfg:  &hashing.Config{},
+			want: nil,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := tc.cfg.Digest(strings.NewReader("hello"))
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("Digest() error: got %v, want %v", err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Digest() returned unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -15,6 +15,8 @@
 // Package packagelockjson provides the structures for npm's package-lock.json lockfile format.
 package packagelockjson
 
+import "encoding/json"
+
 // LockFile is the npm package-lock.json lockfile.
 type LockFile struct {
 	Version int `json:"lockfileVersion"`
@@ -72,6 +74,42 @@ type Package struct {
 	PeerDependenciesMeta map[string]struct {
 		Optional bool `json:"optional,omitempty"`
 	} `json:"peerDependenciesMeta,omitempty"`
+
+	// Platforms and runtimes the package can be installed on.
+	OS  StringList `json:"os,omitempty"`
+	CPU StringList `json:"cpu,omitempty"`
+	// Engines is usually an object like {"node": ">=18"}, but some old packages
+	// still use the deprecated array form.
+	Engines json.RawMessage `json:"engines,omitempty"`
+}
+
+// NodeEngine returns the Node.js version constraint of the package, or an
+// empty string if it has none.
+func (pkg Package) NodeEngine() string {
+	var engines map[string]string
+	if err := json.Unmarshal(pkg.Engines, &engines); err != nil {
+		return ""
+	}
+	return engines["node"]
+}
+
+// StringList is a list of strings that also accepts a single string when
+// unmarshalled from JSON, as npm does for fields like "os" and "cpu".
+type StringList []string
+
+// UnmarshalJSON unmarshals a string or a list of strings.
+func (l *StringList) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		*l = StringList{s}
+		return nil
+	}
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		return err
+	}
+	*l = list
+	return nil
 }
 
 // DepGroups returns the list of groups this package belongs to.
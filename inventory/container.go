@@ -0,0 +1,38 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inventory
+
+import "strings"
+
+// ContainerImageMetadata is the runtime configuration of a scanned container
+// image, read from its config file. Detectors and enrichers can use it to e.g.
+// flag images that run as root or expose risky ports.
+type ContainerImageMetadata struct {
+	// The user the processes of the container run as, e.g. "nobody" or
+	// "1000:1000". Empty if not set, in which case they run as root.
+	User       string
+	Entrypoint []string
+	Cmd        []string
+	// The environment variables in KEY=value format.
+	Env []string
+	// The exposed ports in port/protocol format, e.g. "8080/tcp", sorted.
+	ExposedPorts []string
+}
+
+// RunsAsRoot returns whether the processes of the container run as root.
+func (m *ContainerImageMetadata) RunsAsRoot() bool {
+	user, _, _ := strings.Cut(m.User, ":")
+	return user == "" || user == "root" || user == "0"
+}
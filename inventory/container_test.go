@@ -0,0 +1,41 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inventory_test
+
+import (
+	"testing"
+
+	"github.com/google/osv-scalibr/inventory"
+)
+
+func TestRunsAsRoot(t *testing.T) {
+	tests := []struct {
+		user string
+		want bool
+	}{
+		{user: "", want: true},
+		{user: "root", want: true},
+		{user: "0", want: true},
+		{user: "0:1000", want: true},
+		{user: "nobody", want: false},
+		{user: "1000:0", want: false},
+	}
+	for _, tt := range tests {
+		m := &inventory.ContainerImageMetadata{User: tt.user}
+		if got := m.RunsAsRoot(); got != tt.want {
+			t.Errorf("RunsAsRoot() for user %q: got %v, want %v", tt.user, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,236 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package dedup merges packages that were reported by several extractors, e.g.
+// a Go module found both in a go.mod file and in a compiled Go binary.
+package dedup
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/google/osv-scalibr/extractor"
+)
+
+// Strategy controls how duplicate packages are merged.
+type Strategy string
+
+// Strategy values.
+const (
+	// UnionLocations keeps the locations of all duplicates in the merged package.
+	UnionLocations Strategy = "union-locations"
+	// PreferLockfile keeps the duplicate that was extracted from a lockfile or
+	// manifest instead of the one that was found first.
+	PreferLockfile Strategy = "prefer-lockfile"
+	// MergeMetadata fills the fields that are missing from the kept package,
+	// such as the metadata, source code identifiers or licenses, from the other
+	// duplicates.
+	MergeMetadata Strategy = "merge-metadata"
+)
+
+// ErrUnknownStrategy is returned when an unsupported strategy is requested.
+var ErrUnknownStrategy = errors.New("unknown dedup strategy")
+
+// lockfiles are the base names of the lockfiles and manifests that declare
+// packages, as opposed to installed files or binaries.
+var lockfiles = map[string]bool{
+	"bun.lock":                    true,
+	"buildscript-gradle.lockfile": true,
+	"cabal.project.freeze":        true,
+	"cargo.lock":                  true,
+	"composer.lock":               true,
+	"conan.lock":                  true,
+	"gemfile.lock":                true,
+	"gems.locked":                 true,
+	"go.mod":                      true,
+	"gradle.lockfile":             true,
+	"mix.lock":                    true,
+	"npm-shrinkwrap.json":         true,
+	"package-lock.json":           true,
+	"package.resolved":            true,
+	"packages.config":             true,
+	"packages.lock.json":          true,
+	"pdm.lock":                    true,
+	"pipfile.lock":                true,
+	"pnpm-lock.yaml":              true,
+	"podfile.lock":                true,
+	"poetry.lock":                 true,
+	"pom.xml":                     true,
+	"pubspec.lock":                true,
+	"renv.lock":                   true,
+	"requirements.txt":            true,
+	"stack.yaml.lock":             true,
+	"uv.lock":                     true,
+	"yarn.lock":                   true,
+}
+
+// Config selects how duplicate packages are merged. Duplicates are merged
+// into a single package even if no strategy is set, in which case the package
+// that was found first is kept as is.
+type Config struct {
+	Strategies []Strategy
+}
+
+// ParseStrategy returns the Strategy for the given name, e.g. "union-locations".
+func ParseStrategy(name string) (Strategy, error) {
+	s := Strategy(strings.ToLower(strings.TrimSpace(name)))
+	switch s {
+	case UnionLocations, PreferLockfile, MergeMetadata:
+		return s, nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrUnknownStrategy, name)
+	}
+}
+
+// Validate checks that all configured strategies are known.
+func (c *Config) Validate() error {
+	if c == nil {
+		return nil
+	}
+	var errs []error
+	for _, s := range c.Strategies {
+		if _, err := ParseStrategy(string(s)); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (c *Config) has(s Strategy) bool {
+	return slices.Contains(c.Strategies, s)
+}
+
+// Packages merges the packages with the same package URL. The order of the
+// remaining packages is preserved. Packages without a PURL or version can't
+// be told apart reliably and are never merged.
+func (c *Config) Packages(pkgs []*extractor.Package) []*extractor.Package {
+	if c == nil {
+		return pkgs
+	}
+
+	// Duplicates grouped by their key, in the order the keys were first seen.
+	groups := map[string][]*extractor.Package{}
+	var result []*extractor.Package
+	var keys []string
+	for _, pkg := range pkgs {
+		key := dedupKey(pkg)
+		if key == "" {
+			result = append(result, pkg)
+			keys = append(keys, "")
+			continue
+		}
+		if _, ok := groups[key]; !ok {
+			result = append(result, nil)
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], pkg)
+	}
+
+	for i, key := range keys {
+		if key != "" {
+			result[i] = c.merge(groups[key])
+		}
+	}
+	return result
+}
+
+func dedupKey(pkg *extractor.Package) string {
+	if pkg.Version == "" {
+		return ""
+	}
+	p := pkg.PURL()
+	if p == nil {
+		return ""
+	}
+	return p.String()
+}
+
+// merge merges the given duplicates into a single package.
+func (c *Config) merge(dups []*extractor.Package) *extractor.Package {
+	if len(dups) == 1 {
+		return dups[0]
+	}
+
+	keepIdx := 0
+	if c.has(PreferLockfile) {
+		if i := slices.IndexFunc(dups, fromLockfile); i >= 0 {
+			keepIdx = i
+		}
+	}
+	merged := *dups[keepIdx]
+	merged.Locations = slices.Clone(merged.Locations)
+	merged.Plugins = slices.Clone(merged.Plugins)
+	merged.Licenses = slices.Clone(merged.Licenses)
+	merged.ExploitabilitySignals = slices.Clone(merged.ExploitabilitySignals)
+	merged.LocationProvenance = slices.Clone(merged.LocationProvenance)
+
+	for i, dup := range dups {
+		if i == keepIdx {
+			continue
+		}
+		merged.Plugins = appendMissing(merged.Plugins, dup.Plugins...)
+		if c.has(UnionLocations) {
+			merged.Locations = appendMissing(merged.Locations, dup.Locations...)
+			for _, lp := range dup.LocationProvenance {
+				if merged.ProvenanceFor(lp.Location) == nil {
+					merged.LocationProvenance = append(merged.LocationProvenance, lp)
+				}
+			}
+		}
+		if c.has(MergeMetadata) {
+			mergeMissingFields(&merged, dup)
+		}
+	}
+	return &merged
+}
+
+// mergeMissingFields copies the fields that are not set in dst from src.
+func mergeMissingFields(dst, src *extractor.Package) {
+	if dst.Metadata == nil {
+		dst.Metadata = src.Metadata
+	}
+	if dst.SourceCode == nil {
+		dst.SourceCode = src.SourceCode
+	}
+	if dst.LayerDetails == nil {
+		dst.LayerDetails = src.LayerDetails
+	}
+	dst.Licenses = appendMissing(dst.Licenses, src.Licenses...)
+	for _, s := range src.ExploitabilitySignals {
+		if !slices.Contains(dst.ExploitabilitySignals, s) {
+			dst.ExploitabilitySignals = append(dst.ExploitabilitySignals, s)
+		}
+	}
+}
+
+func fromLockfile(pkg *extractor.Package) bool {
+	if len(pkg.Locations) == 0 {
+		return false
+	}
+	base := path.Base(filepath.ToSlash(pkg.Locations[0]))
+	return lockfiles[strings.ToLower(base)]
+}
+
+func appendMissing(s []string, values ...string) []string {
+	for _, v := range values {
+		if !slices.Contains(s, v) {
+			s = append(s, v)
+		}
+	}
+	return s
+}
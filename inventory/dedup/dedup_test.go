@@ -0,0 +1,214 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dedup_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/inventory/dedup"
+	"github.com/google/osv-scalibr/purl"
+)
+
+// goBinaryMetadata stands in for the metadata of the Go binary extractor.
+type goBinaryMetadata struct {
+	GoVersion string
+}
+
+func goBinaryPkg() *extractor.Package {
+	return &extractor.Package{
+		Name:      "golang.org/x/net",
+		Version:   "0.23.0",
+		PURLType:  purl.TypeGolang,
+		Locations: []string{"usr/bin/app"},
+		Plugins:   []string{"go/binary"},
+		Metadata:  &goBinaryMetadata{GoVersion: "1.22.1"},
+	}
+}
+
+func goModPkg() *extractor.Package {
+	return &extractor.Package{
+		Name:      "golang.org/x/net",
+		Version:   "0.23.0",
+		PURLType:  purl.TypeGolang,
+		Locations: []string{"src/app/go.mod"},
+		Plugins:   []string{"go/gomod"},
+		Licenses:  []string{"BSD-3-Clause"},
+		LocationProvenance: []*extractor.LocationProvenance{{
+			Location: "src/app/go.mod", LayerDigest: "sha256:abc", Path: "src/app/go.mod",
+		}},
+	}
+}
+
+func otherPkg() *extractor.Package {
+	return &extractor.Package{
+		Name:      "golang.org/x/text",
+		Version:   "0.14.0",
+		PURLType:  purl.TypeGolang,
+		Locations: []string{"usr/bin/app"},
+		Plugins:   []string{"go/binary"},
+	}
+}
+
+func TestPackages(t *testing.T) {
+	testCases := []struct {
+		desc string
+		cfg  *dedup.Config
+		pkgs []*extractor.Package
+		want []*extractor.Package
+	}{
+		{
+			desc: "nil_config_keeps_duplicates",
+			cfg:  nil,
+			pkgs: []*extractor.Package{goBinaryPkg(), goModPkg()},
+			want: []*extractor.Package{goBinaryPkg(), goModPkg()},
+		},
+		{
+			desc: "no_duplicates",
+			cfg:  &dedup.Config{Strategies: []dedup.Strategy{dedup.UnionLocations}},
+			pkgs: []*extractor.Package{goBinaryPkg(), otherPkg()},
+			want: []*extractor.Package{goBinaryPkg(), otherPkg()},
+		},
+		{
+			desc: "keep_first",
+			cfg:  &dedup.Config{},
+			pkgs: []*extractor.Package{goBinaryPkg(), otherPkg(), goModPkg()},
+			want: []*extractor.Package{
+				{
+					Name:      "golang.org/x/net",
+					Version:   "0.23.0",
+					PURLType:  purl.TypeGolang,
+					Locations: []string{"usr/bin/app"},
+					Plugins:   []string{"go/binary", "go/gomod"},
+					Metadata:  &goBinaryMetadata{GoVersion: "1.22.1"},
+				},
+				otherPkg(),
+			},
+		},
+		{
+			desc: "union_locations",
+			cfg:  &dedup.Config{Strategies: []dedup.Strategy{dedup.UnionLocations}},
+			pkgs: []*extractor.Package{goBinaryPkg(), goModPkg()},
+			want: []*extractor.Package{{
+				Name:      "golang.org/x/net",
+				Version:   "0.23.0",
+				PURLType:  purl.TypeGolang,
+				Locations: []string{"usr/bin/app", "src/app/go.mod"},
+				Plugins:   []string{"go/binary", "go/gomod"},
+				Metadata:  &goBinaryMetadata{GoVersion: "1.22.1"},
+				LocationProvenance: []*extractor.LocationProvenance{{
+					Location: "src/app/go.mod", LayerDigest: "sha256:abc", Path: "src/app/go.mod",
+				}},
+			}},
+		},
+		{
+			desc: "prefer_lockfile",
+			cfg:  &dedup.Config{Strategies: []dedup.Strategy{dedup.PreferLockfile}},
+			pkgs: []*extractor.Package{goBinaryPkg(), goModPkg()},
+			want: []*extractor.Package{{
+				Name:      "golang.org/x/net",
+				Version:   "0.23.0",
+				PURLType:  purl.TypeGolang,
+				Locations: []string{"src/app/go.mod"},
+				Plugins:   []string{"go/gomod", "go/binary"},
+				Licenses:  []string{"BSD-3-Clause"},
+				LocationProvenance: []*extractor.LocationProvenance{{
+					Location: "src/app/go.mod", LayerDigest: "sha256:abc", Path: "src/app/go.mod",
+				}},
+			}},
+		},
+		{
+			desc: "all_strategies",
+			cfg: &dedup.Config{Strategies: []dedup.Strategy{
+				dedup.UnionLocations, dedup.PreferLockfile, dedup.MergeMetadata,
+			}},
+			pkgs: []*extractor.Package{goBinaryPkg(), goModPkg()},
+			want: []*extractor.Package{{
+				Name:      "golang.org/x/net",
+				Version:   "0.23.0",
+				PURLType:  purl.TypeGolang,
+				Locations: []string{"src/app/go.mod", "usr/bin/app"},
+				Plugins:   []string{"go/gomod", "go/binary"},
+				Licenses:  []string{"BSD-3-Clause"},
+				Metadata:  &goBinaryMetadata{GoVersion: "1.22.1"},
+				LocationProvenance: []*extractor.LocationProvenance{{
+					Location: "src/app/go.mod", LayerDigest: "sha256:abc", Path: "src/app/go.mod",
+				}},
+			}},
+		},
+		{
+			desc: "packages_without_version_are_not_merged",
+			cfg:  &dedup.Config{Strategies: []dedup.Strategy{dedup.UnionLocations}},
+			pkgs: []*extractor.Package{
+				{Name: "model.pt", PURLType: purl.TypeGeneric, Locations: []string{"a/model.pt"}},
+				{Name: "model.pt", PURLType: purl.TypeGeneric, Locations: []string{"b/model.pt"}},
+			},
+			want: []*extractor.Package{
+				{Name: "model.pt", PURLType: purl.TypeGeneric, Locations: []string{"a/model.pt"}},
+				{Name: "model.pt", PURLType: purl.TypeGeneric, Locations: []string{"b/model.pt"}},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := tc.cfg.Packages(tc.pkgs)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Packages(%v) returned unexpected diff (-want +got):\n%s", tc.pkgs, diff)
+			}
+		})
+	}
+}
+
+func TestPackagesDoesNotModifyInput(t *testing.T) {
+	cfg := &dedup.Config{Strategies: []dedup.Strategy{dedup.UnionLocations, dedup.MergeMetadata}}
+	pkgs := []*extractor.Package{goBinaryPkg(), goModPkg()}
+	cfg.Packages(pkgs)
+	want := []*extractor.Package{goBinaryPkg(), goModPkg()}
+	if diff := cmp.Diff(want, pkgs); diff != "" {
+		t.Errorf("Packages() modified its input (-want +got):\n%s", diff)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	testCases := []struct {
+		desc    string
+		cfg     *dedup.Config
+		wantErr error
+	}{
+		{
+			desc: "nil_config",
+		},
+		{
+			desc: "known_strategies",
+			cfg:  &dedup.Config{Strategies: []dedup.Strategy{dedup.UnionLocations, dedup.MergeMetadata}},
+		},
+		{
+			desc:    "unknown_strategy",
+			cfg:     &dedup.Config{Strategies: []dedup.Strategy{"prefer-newest"}},
+			wantErr: dedup.ErrUnknownStrategy,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if err := tc.cfg.Validate(); !errors.Is(err, tc.wantErr) {
+				t.Errorf("Validate() returned error %v, want %v", err, tc.wantErr)
+			}
+		})
+	}
+}
@@ -44,6 +44,8 @@ type PackageVuln struct {
 	Plugins []string
 	// Signals that indicate this finding is not exploitable.
 	ExploitabilitySignals []*vex.FindingExploitabilitySignal
+	// Structured data on how to remediate the vuln, populated by enrichers.
+	Remediation *Remediation
 }
 
 // GenericFinding is used to describe generic security findings not associated with any
@@ -59,6 +61,9 @@ type GenericFinding struct {
 	Plugins []string
 	// Signals that indicate this finding is not exploitable.
 	ExploitabilitySignals []*vex.FindingExploitabilitySignal
+	// Structured data on how to remediate the finding. Complements the free-text
+	// recommendation in the advisory.
+	Remediation *Remediation
 }
 
 // GenericFindingAdvisory describes a security finding and how to remediate it. It should not
@@ -105,6 +110,33 @@ type GenericFindingTargetDetails struct {
 	Extra string
 }
 
+// Remediation contains structured remediation targets for a finding, e.g. for
+// use by auto-remediation tooling.
+type Remediation struct {
+	// The lowest version of the affected package that fixes the finding and is
+	// higher than the installed version, according to the version semantics of
+	// the package's ecosystem. Empty if no fix is known.
+	FixedVersion string
+	// Whether the fix is available as a backport in the package repository of
+	// the installed OS release (e.g. "Debian:12"), as opposed to only in newer
+	// releases. Only set for OS packages.
+	Backported bool
+	// The packages to upgrade in order to pull in the fix, starting with the
+	// direct dependency and ending with the affected package. Empty if the
+	// affected package can be upgraded directly.
+	UpgradePath []*UpgradeStep
+	// URLs of patches or commits that fix the finding.
+	PatchURLs []string
+}
+
+// UpgradeStep is a package upgrade that is part of a remediation.
+type UpgradeStep struct {
+	// The name of the package to upgrade.
+	Name string
+	// The version to upgrade the package to.
+	Version string
+}
+
 // LINT.ThenChange(/binary/proto/scan_result.proto)
 
 // PackageToAffected creates an osvschema.Affected struct from the given
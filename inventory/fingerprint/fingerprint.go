@@ -0,0 +1,124 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package fingerprint computes stable IDs for the packages and findings of a
+// scan, so that downstream systems can track the same item across scans.
+//
+// Fingerprints are hex encoded SHA-256 hashes of the properties that identify
+// an item, not of details that change between scans such as the plugins that
+// found it or its exploitability signals.
+package fingerprint
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/inventory"
+)
+
+// Kinds of fingerprinted items. They're part of the hashed data so items of
+// different kinds never share a fingerprint.
+const (
+	kindPackage        = "package"
+	kindPackageVuln    = "package_vuln"
+	kindGenericFinding = "generic_finding"
+	kindSecret         = "secret"
+)
+
+// Package returns the fingerprint of a package, derived from its PURL and
+// locations. The same package found at different locations has different
+// fingerprints. The order of the locations doesn't matter.
+func Package(p *extractor.Package) string {
+	if p == nil {
+		return ""
+	}
+	purl := ""
+	if u := p.PURL(); u != nil {
+		purl = u.String()
+	}
+	locations := slices.Clone(p.Locations)
+	slices.Sort(locations)
+	return hash(kindPackage, append([]string{purl}, locations...)...)
+}
+
+// PackageVuln returns the fingerprint of a package vulnerability, derived from
+// the vulnerability ID and the package name, type and first location. It's
+// independent of the package version so that the fingerprint stays the same
+// after an upgrade that doesn't fix the vulnerability.
+func PackageVuln(v *inventory.PackageVuln) string {
+	if v == nil {
+		return ""
+	}
+	purlType, name, location := "", "", ""
+	if p := v.Package; p != nil {
+		purlType = p.PURLType
+		name = p.Name
+		if len(p.Locations) > 0 {
+			location = p.Locations[0]
+		}
+	}
+	return hash(kindPackageVuln, v.ID, purlType, name, location)
+}
+
+// GenericFinding returns the fingerprint of a generic finding, derived from
+// the advisory ID and the target details, which usually contain the location.
+func GenericFinding(f *inventory.GenericFinding) string {
+	if f == nil {
+		return ""
+	}
+	extra := ""
+	if f.Target != nil {
+		extra = strings.TrimSpace(f.Target.Extra)
+	}
+	return hash(kindGenericFinding, GenericFindingID(f), extra)
+}
+
+// GenericFindingID returns the ID of a generic finding's advisory in the
+// "publisher/reference" format, or an empty string if it has none.
+func GenericFindingID(f *inventory.GenericFinding) string {
+	if f.Adv == nil || f.Adv.ID == nil {
+		return ""
+	}
+	if f.Adv.ID.Publisher == "" {
+		return f.Adv.ID.Reference
+	}
+	return f.Adv.ID.Publisher + "/" + f.Adv.ID.Reference
+}
+
+// Secret returns the fingerprint of a secret, derived from its type, location
+// and properties. Different secrets in the same file have different
+// fingerprints. The secret can't be recovered from it.
+func Secret(s *inventory.Secret) string {
+	if s == nil {
+		return ""
+	}
+	// Secrets that can't be marshaled only fall back to type and location.
+	data, _ := json.Marshal(s.Secret)
+	return hash(kindSecret, SecretType(s), s.Location, string(data))
+}
+
+// SecretType returns the name of a secret's Go type, e.g. "gcpsak.GCPSAK".
+func SecretType(s *inventory.Secret) string {
+	return strings.TrimPrefix(fmt.Sprintf("%T", s.Secret), "*")
+}
+
+func hash(kind string, parts ...string) string {
+	h := sha256.Sum256([]byte(kind + "\x00" + strings.Join(parts, "\x00")))
+	return hex.EncodeToString(h[:])
+}
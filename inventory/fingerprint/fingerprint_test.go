@@ -0,0 +1,198 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fingerprint_test
+
+import (
+	"testing"
+
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/inventory/fingerprint"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scalibr/veles/velestest"
+	"github.com/ossf/osv-schema/bindings/go/osvschema"
+)
+
+func npmPkg(version string, locations ...string) *extractor.Package {
+	return &extractor.Package{
+		Name:      "lodash",
+		Version:   version,
+		PURLType:  purl.TypeNPM,
+		Locations: locations,
+	}
+}
+
+func finding(ref, extra string) *inventory.GenericFinding {
+	return &inventory.GenericFinding{
+		Adv:    &inventory.GenericFindingAdvisory{ID: &inventory.AdvisoryID{Publisher: "SCALIBR", Reference: ref}},
+		Target: &inventory.GenericFindingTargetDetails{Extra: extra},
+	}
+}
+
+func secret(value, location string) *inventory.Secret {
+	return &inventory.Secret{Secret: velestest.NewFakeStringSecret(value), Location: location}
+}
+
+func TestPackage(t *testing.T) {
+	tests := []struct {
+		desc      string
+		a, b      *extractor.Package
+		wantEqual bool
+	}{
+		{
+			desc:      "same_package",
+			a:         npmPkg("4.17.20", "package-lock.json"),
+			b:         npmPkg("4.17.20", "package-lock.json"),
+			wantEqual: true,
+		},
+		{
+			desc:      "location_order",
+			a:         npmPkg("4.17.20", "a/package-lock.json", "b/package-lock.json"),
+			b:         npmPkg("4.17.20", "b/package-lock.json", "a/package-lock.json"),
+			wantEqual: true,
+		},
+		{
+			desc:      "plugins_ignored",
+			a:         npmPkg("4.17.20", "package-lock.json"),
+			b:         &extractor.Package{Name: "lodash", Version: "4.17.20", PURLType: purl.TypeNPM, Locations: []string{"package-lock.json"}, Plugins: []string{"javascript/packagelockjson"}},
+			wantEqual: true,
+		},
+		{
+			desc: "different_version",
+			a:    npmPkg("4.17.20", "package-lock.json"),
+			b:    npmPkg("4.17.21", "package-lock.json"),
+		},
+		{
+			desc: "different_location",
+			a:    npmPkg("4.17.20", "package-lock.json"),
+			b:    npmPkg("4.17.20", "other/package-lock.json"),
+		},
+		{
+			desc: "additional_location",
+			a:    npmPkg("4.17.20", "package-lock.json"),
+			b:    npmPkg("4.17.20", "package-lock.json", "other/package-lock.json"),
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			a, b := fingerprint.Package(tc.a), fingerprint.Package(tc.b)
+			if a == "" || b == "" {
+				t.Fatalf("fingerprint.Package() returned an empty fingerprint: %q, %q", a, b)
+			}
+			if got := a == b; got != tc.wantEqual {
+				t.Errorf("fingerprint.Package(%v) == fingerprint.Package(%v): got %t, want %t", tc.a, tc.b, got, tc.wantEqual)
+			}
+		})
+	}
+}
+
+func TestPackageVuln(t *testing.T) {
+	vuln := func(id string, pkg *extractor.Package) *inventory.PackageVuln {
+		return &inventory.PackageVuln{Vulnerability: osvschema.Vulnerability{ID: id}, Package: pkg}
+	}
+	tests := []struct {
+		desc      string
+		a, b      *inventory.PackageVuln
+		wantEqual bool
+	}{
+		{
+			desc:      "upgraded_package",
+			a:         vuln("GHSA-1", npmPkg("4.17.20", "package-lock.json")),
+			b:         vuln("GHSA-1", npmPkg("4.17.21", "package-lock.json")),
+			wantEqual: true,
+		},
+		{
+			desc: "different_vuln",
+			a:    vuln("GHSA-1", npmPkg("4.17.20", "package-lock.json")),
+			b:    vuln("GHSA-2", npmPkg("4.17.20", "package-lock.json")),
+		},
+		{
+			desc: "different_location",
+			a:    vuln("GHSA-1", npmPkg("4.17.20", "package-lock.json")),
+			b:    vuln("GHSA-1", npmPkg("4.17.20", "other/package-lock.json")),
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := fingerprint.PackageVuln(tc.a) == fingerprint.PackageVuln(tc.b); got != tc.wantEqual {
+				t.Errorf("fingerprint.PackageVuln(%v) == fingerprint.PackageVuln(%v): got %t, want %t", tc.a, tc.b, got, tc.wantEqual)
+			}
+		})
+	}
+}
+
+func TestGenericFinding(t *testing.T) {
+	tests := []struct {
+		desc      string
+		a, b      *inventory.GenericFinding
+		wantEqual bool
+	}{
+		{
+			desc:      "surrounding_whitespace",
+			a:         finding("weak-credentials", "/etc/shadow: user root"),
+			b:         finding("weak-credentials", "\n/etc/shadow: user root\n"),
+			wantEqual: true,
+		},
+		{
+			desc: "different_advisory",
+			a:    finding("weak-credentials", "/etc/shadow: user root"),
+			b:    finding("empty-password", "/etc/shadow: user root"),
+		},
+		{
+			desc: "different_target",
+			a:    finding("weak-credentials", "/etc/shadow: user root"),
+			b:    finding("weak-credentials", "/etc/shadow: user admin"),
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := fingerprint.GenericFinding(tc.a) == fingerprint.GenericFinding(tc.b); got != tc.wantEqual {
+				t.Errorf("fingerprint.GenericFinding(%v) == fingerprint.GenericFinding(%v): got %t, want %t", tc.a, tc.b, got, tc.wantEqual)
+			}
+		})
+	}
+}
+
+func TestSecret(t *testing.T) {
+	tests := []struct {
+		desc      string
+		a, b      *inventory.Secret
+		wantEqual bool
+	}{
+		{
+			desc:      "same_secret",
+			a:         secret("FOO", "config.json"),
+			b:         secret("FOO", "config.json"),
+			wantEqual: true,
+		},
+		{
+			desc: "different_secret_in_same_file",
+			a:    secret("FOO", "config.json"),
+			b:    secret("BAR", "config.json"),
+		},
+		{
+			desc: "different_location",
+			a:    secret("FOO", "config.json"),
+			b:    secret("FOO", "other/config.json"),
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := fingerprint.Secret(tc.a) == fingerprint.Secret(tc.b); got != tc.wantEqual {
+				t.Errorf("fingerprint.Secret(%v) == fingerprint.Secret(%v): got %t, want %t", tc.a, tc.b, got, tc.wantEqual)
+			}
+		})
+	}
+}
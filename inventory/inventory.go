@@ -26,6 +26,8 @@ type Inventory struct {
 	PackageVulns    []*PackageVuln
 	GenericFindings []*GenericFinding
 	Secrets         []*Secret
+	// The runtime configuration of the scanned container images.
+	ContainerImageMetadata []*ContainerImageMetadata
 }
 
 // Append adds one or more inventories to the current one.
@@ -35,10 +37,12 @@ func (i *Inventory) Append(other ...Inventory) {
 		i.PackageVulns = append(i.PackageVulns, o.PackageVulns...)
 		i.GenericFindings = append(i.GenericFindings, o.GenericFindings...)
 		i.Secrets = append(i.Secrets, o.Secrets...)
+		i.ContainerImageMetadata = append(i.ContainerImageMetadata, o.ContainerImageMetadata...)
 	}
 }
 
 // IsEmpty returns true if there are no packages, findings, etc. in this Inventory.
 func (i Inventory) IsEmpty() bool {
-	return len(i.Packages) == 0 && len(i.PackageVulns) == 0 && len(i.GenericFindings) == 0 && len(i.Secrets) == 0
+	return len(i.Packages) == 0 && len(i.PackageVulns) == 0 && len(i.GenericFindings) == 0 && len(i.Secrets) == 0 &&
+		len(i.ContainerImageMetadata) == 0
 }
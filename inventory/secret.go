@@ -17,6 +17,7 @@ package inventory
 import (
 	"time"
 
+	"github.com/google/osv-scalibr/extractor"
 	"github.com/google/osv-scalibr/veles"
 )
 
@@ -26,8 +27,15 @@ import (
 type Secret struct {
 	Secret   veles.Secret
 	Location string
+	// Severity of the secret, e.g. based on whether it's protected by a
+	// passphrase. Unspecified for most secret types.
+	Severity SeverityEnum
 
 	Validation SecretValidationResult
+
+	// Details about the layer that last modified the file containing the secret.
+	// This should be set only for container image scanning.
+	LayerDetails *extractor.LayerDetails
 }
 
 // SecretValidationResult is the result of validating a given Secret with the
@@ -0,0 +1,136 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package severity computes and parses the severities of security findings.
+package severity
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/ossf/osv-schema/bindings/go/osvschema"
+	gocvss30 "github.com/pandatix/go-cvss/30"
+	gocvss31 "github.com/pandatix/go-cvss/31"
+	gocvss40 "github.com/pandatix/go-cvss/40"
+)
+
+var names = map[inventory.SeverityEnum]string{
+	inventory.SeverityUnspecified: "unspecified",
+	inventory.SeverityMinimal:     "minimal",
+	inventory.SeverityLow:         "low",
+	inventory.SeverityMedium:      "medium",
+	inventory.SeverityHigh:        "high",
+	inventory.SeverityCritical:    "critical",
+}
+
+// Name returns the lower case name of the severity, e.g. "high".
+func Name(s inventory.SeverityEnum) string {
+	if n, ok := names[s]; ok {
+		return n
+	}
+	return names[inventory.SeverityUnspecified]
+}
+
+// Parse returns the severity with the given case-insensitive name, e.g. "High".
+// "unspecified" isn't accepted.
+func Parse(name string) (inventory.SeverityEnum, bool) {
+	for s, n := range names {
+		if s != inventory.SeverityUnspecified && strings.EqualFold(n, name) {
+			return s, true
+		}
+	}
+	return inventory.SeverityUnspecified, false
+}
+
+// PackageVuln returns the severity of the package vulnerability.
+func PackageVuln(v *inventory.PackageVuln) inventory.SeverityEnum {
+	return Vuln(&v.Vulnerability)
+}
+
+// GenericFinding returns the severity of the generic finding's advisory.
+func GenericFinding(f *inventory.GenericFinding) inventory.SeverityEnum {
+	if f.Adv == nil {
+		return inventory.SeverityUnspecified
+	}
+	return f.Adv.Sev
+}
+
+// Vuln returns the highest severity rating of the vuln's CVSS vectors,
+// falling back to the database-specific severity (e.g. for GHSA advisories).
+func Vuln(v *osvschema.Vulnerability) inventory.SeverityEnum {
+	severities := slices.Clone(v.Severity)
+	for _, a := range v.Affected {
+		severities = append(severities, a.Severity...)
+	}
+	best := inventory.SeverityUnspecified
+	for _, s := range severities {
+		best = max(best, cvssRating(s))
+	}
+	if best != inventory.SeverityUnspecified {
+		return best
+	}
+	if sev, ok := v.DatabaseSpecific["severity"].(string); ok {
+		return ratingToSeverity(sev)
+	}
+	return inventory.SeverityUnspecified
+}
+func cvssRating(s osvschema.Severity) inventory.SeverityEnum {
+	var score float64
+	switch {
+	case s.Type == osvschema.SeverityCVSSV3 && strings.HasPrefix(s.Score, "CVSS:3.0/"):
+		vec, err := gocvss30.ParseVector(s.Score)
+		if err != nil {
+			return inventory.SeverityUnspecified
+		}
+		score = vec.BaseScore()
+	case s.Type == osvschema.SeverityCVSSV3 && strings.HasPrefix(s.Score, "CVSS:3.1/"):
+		vec, err := gocvss31.ParseVector(s.Score)
+		if err != nil {
+			return inventory.SeverityUnspecified
+		}
+		score = vec.BaseScore()
+	case s.Type == osvschema.SeverityCVSSV4:
+		vec, err := gocvss40.ParseVector(s.Score)
+		if err != nil {
+			return inventory.SeverityUnspecified
+		}
+		score = vec.Score()
+	default:
+		return inventory.SeverityUnspecified
+	}
+	// CVSS v3 and v4 share the same qualitative rating scale.
+	rating, err := gocvss31.Rating(score)
+	if err != nil {
+		return inventory.SeverityUnspecified
+	}
+	return ratingToSeverity(rating)
+}
+
+func ratingToSeverity(rating string) inventory.SeverityEnum {
+	switch strings.ToUpper(rating) {
+	case "NONE":
+		return inventory.SeverityMinimal
+	case "LOW":
+		return inventory.SeverityLow
+	case "MEDIUM", "MODERATE":
+		return inventory.SeverityMedium
+	case "HIGH":
+		return inventory.SeverityHigh
+	case "CRITICAL":
+		return inventory.SeverityCritical
+	default:
+		return inventory.SeverityUnspecified
+	}
+}
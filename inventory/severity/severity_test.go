@@ -0,0 +1,112 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package severity_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/inventory/severity"
+	"github.com/ossf/osv-schema/bindings/go/osvschema"
+)
+
+func TestVuln(t *testing.T) {
+	testCases := []struct {
+		desc string
+		vuln *osvschema.Vulnerability
+		want inventory.SeverityEnum
+	}{
+		{
+			desc: "no_severity",
+			vuln: &osvschema.Vulnerability{ID: "CVE-1"},
+			want: inventory.SeverityUnspecified,
+		},
+		{
+			desc: "cvss_v3",
+			vuln: &osvschema.Vulnerability{
+				Severity: []osvschema.Severity{{
+					Type:  osvschema.SeverityCVSSV3,
+					Score: "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:N/A:N",
+				}},
+			},
+			want: inventory.SeverityHigh,
+		},
+		{
+			desc: "highest_of_vuln_and_affected_severities",
+			vuln: &osvschema.Vulnerability{
+				Severity: []osvschema.Severity{{
+					Type:  osvschema.SeverityCVSSV3,
+					Score: "CVSS:3.0/AV:N/AC:H/PR:H/UI:R/S:U/C:L/I:N/A:N",
+				}},
+				Affected: []osvschema.Affected{{
+					Severity: []osvschema.Severity{{
+						Type:  osvschema.SeverityCVSSV3,
+						Score: "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H",
+					}},
+				}},
+			},
+			want: inventory.SeverityCritical,
+		},
+		{
+			desc: "database_specific_fallback",
+			vuln: &osvschema.Vulnerability{
+				DatabaseSpecific: map[string]any{"severity": "MODERATE"},
+			},
+			want: inventory.SeverityMedium,
+		},
+		{
+			desc: "invalid_vector",
+			vuln: &osvschema.Vulnerability{
+				Severity: []osvschema.Severity{{Type: osvschema.SeverityCVSSV3, Score: "CVSS:3.1/invalid"}},
+			},
+			want: inventory.SeverityUnspecified,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := severity.Vuln(tc.vuln); got != tc.want {
+				t.Errorf("Vuln() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	testCases := []struct {
+		name   string
+		want   inventory.SeverityEnum
+		wantOK bool
+	}{
+		{name: "high", want: inventory.SeverityHigh, wantOK: true},
+		{name: "CRITICAL", want: inventory.SeverityCritical, wantOK: true},
+		{name: "Minimal", want: inventory.SeverityMinimal, wantOK: true},
+		{name: "unspecified", want: inventory.SeverityUnspecified, wantOK: false},
+		{name: "severe", want: inventory.SeverityUnspecified, wantOK: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, ok := severity.Parse(tc.name)
+			if got != tc.want || ok != tc.wantOK {
+				t.Errorf("Parse(%q) = %v, %v, want %v, %v", tc.name, got, ok, tc.want, tc.wantOK)
+			}
+			if ok && severity.Name(got) != strings.ToLower(tc.name) {
+				t.Errorf("Name(%v) = %q, want %q", got, severity.Name(got), strings.ToLower(tc.name))
+			}
+		})
+	}
+}
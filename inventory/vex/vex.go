@@ -15,7 +15,11 @@
 // Package vex stores data structures used to represent exploitability signals in SCALIBR scan results.
 package vex
 
-import "slices"
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
 
 // PackageExploitabilitySignal is used to indicate that specific vulnerabilities
 // are not applicable to a given package.
@@ -24,12 +28,17 @@ type PackageExploitabilitySignal struct {
 	Plugin string
 	// Reason for exclusion.
 	Justification Justification
+	// How the vulns were triaged. Unspecified means not affected.
+	Status Status
 	// Advisory Identifier (CVE, GHSA, ...) and aliases of the vulns that are not
 	// applicable to this package.
 	VulnIdentifiers []string
 	// Indicates that all vulnerabilities associated with the package are irrelevant.
 	// VulnIdentifiers should be empty when this is set to true.
 	MatchesAllVulns bool
+	// Optional: Free-form explanation for the exclusion, e.g. from a
+	// suppression comment in a manifest file.
+	Reason string
 }
 
 // FindingExploitabilitySignal is used to indicate that a finding is not exploitable.
@@ -38,6 +47,57 @@ type FindingExploitabilitySignal struct {
 	Plugin string
 	// Reason for exclusion.
 	Justification Justification
+	// How the finding was triaged. Unspecified means not affected.
+	Status Status
+	// Optional: Free-form explanation for the exclusion.
+	Reason string
+}
+
+// Status enumerates the triage outcomes of an exploitability signal.
+type Status int64
+
+const (
+	// StatusUnspecified indicates the status has not been specified. Signals
+	// without a status mark their findings as not affected.
+	StatusUnspecified Status = iota
+	// StatusNotAffected indicates the finding doesn't affect the artifact, e.g.
+	// because the vulnerable code isn't present.
+	StatusNotAffected
+	// StatusFalsePositive indicates the finding was reported in error, e.g.
+	// because a package was misidentified.
+	StatusFalsePositive
+	// StatusAcceptedRisk indicates the finding affects the artifact but the risk
+	// was accepted, e.g. until a fix is available.
+	StatusAcceptedRisk
+)
+
+// statusNames are the names of the statuses in configuration files and
+// reports.
+var statusNames = map[Status]string{
+	StatusUnspecified:   "unspecified",
+	StatusNotAffected:   "not-affected",
+	StatusFalsePositive: "false-positive",
+	StatusAcceptedRisk:  "accepted-risk",
+}
+
+// String returns the name of the status, e.g. "accepted-risk".
+func (s Status) String() string {
+	if n, ok := statusNames[s]; ok {
+		return n
+	}
+	return fmt.Sprintf("Status(%d)", int64(s))
+}
+
+// ParseStatus returns the status with the given name, e.g. "false-positive".
+// Underscores are accepted in place of dashes.
+func ParseStatus(name string) (Status, bool) {
+	name = strings.ReplaceAll(strings.ToLower(name), "_", "-")
+	for s, n := range statusNames {
+		if n == name {
+			return s, true
+		}
+	}
+	return StatusUnspecified, false
 }
 
 // Justification enumerates various vuln exclusion reasons.
@@ -65,6 +125,24 @@ const (
 	InlineMitigationAlreadyExists
 )
 
+// justificationNames maps the snake case names used by VEX documents to the
+// justifications. OpenVEX spells the last one "inline_mitigations_already_exist".
+var justificationNames = map[string]Justification{
+	"component_not_present":                             ComponentNotPresent,
+	"vulnerable_code_not_present":                       VulnerableCodeNotPresent,
+	"vulnerable_code_not_in_execute_path":               VulnerableCodeNotInExecutePath,
+	"vulnerable_code_cannot_be_controlled_by_adversary": VulnerableCodeCannotBeControlledByAdversary,
+	"inline_mitigation_already_exists":                  InlineMitigationAlreadyExists,
+	"inline_mitigations_already_exist":                  InlineMitigationAlreadyExists,
+}
+
+// ParseJustification returns the justification with the given snake case
+// name, e.g. "vulnerable_code_not_present".
+func ParseJustification(name string) (Justification, bool) {
+	j, ok := justificationNames[strings.ToLower(name)]
+	return j, ok
+}
+
 // FindingVEXFromPackageVEX converts package VEXes to finding VEXes if they're
 // applicable to a finding with the given ID.
 func FindingVEXFromPackageVEX(vulnID string, pkgVEXes []*PackageExploitabilitySignal) []*FindingExploitabilitySignal {
@@ -74,6 +152,8 @@ func FindingVEXFromPackageVEX(vulnID string, pkgVEXes []*PackageExploitabilitySi
 			result = append(result, &FindingExploitabilitySignal{
 				Plugin:        pkgVEX.Plugin,
 				Justification: pkgVEX.Justification,
+				Status:        pkgVEX.Status,
+				Reason:        pkgVEX.Reason,
 			})
 		}
 	}
@@ -0,0 +1,119 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Keys of the fields SCALIBR adds to the loggers returned by FromContext.
+const (
+	// KeyPlugin is the name of the plugin that is running.
+	KeyPlugin = "plugin"
+	// KeyPath is the path of the file the plugin is processing.
+	KeyPath = "path"
+	// KeyScanID is the ID the caller assigned to the scan.
+	KeyScanID = "scan_id"
+)
+
+// FieldLogger is a Logger that supports structured fields. Loggers set with
+// SetLogger can implement it to receive the fields of FromContext as
+// key-value pairs instead of as a message prefix.
+type FieldLogger interface {
+	Logger
+	// With returns a logger that adds the given alternating keys and values to
+	// every message.
+	With(args ...any) Logger
+}
+
+// With returns a logger that adds the given alternating keys and values to
+// every message logged through l. If l doesn't implement FieldLogger, the
+// fields are prepended to the messages as "key=value" pairs.
+func With(l Logger, args ...any) Logger {
+	if len(args) == 0 {
+		return l
+	}
+	if fl, ok := l.(FieldLogger); ok {
+		return fl.With(args...)
+	}
+	return &prefixLogger{l: l, prefix: formatFields(args) + " "}
+}
+
+type ctxKey struct{}
+
+// NewContext returns a copy of ctx that carries the given alternating keys and
+// values in addition to the fields already present in ctx.
+func NewContext(ctx context.Context, args ...any) context.Context {
+	if len(args) == 0 {
+		return ctx
+	}
+	fields := Fields(ctx)
+	fields = append(fields[:len(fields):len(fields)], args...)
+	return context.WithValue(ctx, ctxKey{}, fields)
+}
+
+// Fields returns the alternating keys and values stored in ctx.
+func Fields(ctx context.Context) []any {
+	fields, _ := ctx.Value(ctxKey{}).([]any)
+	return fields
+}
+
+// FromContext returns the SCALIBR logger with the fields stored in ctx, e.g.
+// the name of the running plugin and the path it's processing.
+func FromContext(ctx context.Context) Logger {
+	return With(logger, Fields(ctx)...)
+}
+
+// formatFields formats the alternating keys and values as "key=value" pairs.
+func formatFields(args []any) string {
+	var parts []string
+	for i := 0; i < len(args); i += 2 {
+		if i+1 == len(args) {
+			parts = append(parts, fmt.Sprintf("!BADKEY=%v", args[i]))
+			break
+		}
+		parts = append(parts, fmt.Sprintf("%v=%v", args[i], args[i+1]))
+	}
+	return strings.Join(parts, " ")
+}
+
+// prefixLogger prepends a fixed prefix to the messages of a Logger that doesn't
+// support structured fields.
+type prefixLogger struct {
+	l      Logger
+	prefix string
+}
+
+func (p *prefixLogger) Errorf(format string, args ...any) { p.l.Errorf(p.prefix+format, args...) }
+func (p *prefixLogger) Warnf(format string, args ...any)  { p.l.Warnf(p.prefix+format, args...) }
+func (p *prefixLogger) Infof(format string, args ...any)  { p.l.Infof(p.prefix+format, args...) }
+func (p *prefixLogger) Debugf(format string, args ...any) { p.l.Debugf(p.prefix+format, args...) }
+func (p *prefixLogger) Error(args ...any)                 { p.l.Error(p.prepend(args)...) }
+func (p *prefixLogger) Warn(args ...any)                  { p.l.Warn(p.prepend(args)...) }
+func (p *prefixLogger) Info(args ...any)                  { p.l.Info(p.prepend(args)...) }
+func (p *prefixLogger) Debug(args ...any)                 { p.l.Debug(p.prepend(args)...) }
+
+func (p *prefixLogger) prepend(args []any) []any {
+	// The unformatted functions separate their arguments with spaces so the
+	// prefix's trailing space is dropped.
+	return append([]any{strings.TrimSuffix(p.prefix, " ")}, args...)
+}
+
+// With implements FieldLogger so that nested fields are added to the prefix.
+func (p *prefixLogger) With(args ...any) Logger {
+	return &prefixLogger{l: p.l, prefix: p.prefix + formatFields(args) + " "}
+}
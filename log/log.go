@@ -13,7 +13,9 @@
 // limitations under the License.
 
 // Package log defines SCALIBR's logger interface. By default it uses the Go logger
-// but it can be replaced with user-defined loggers.
+// but it can be replaced with user-defined loggers, e.g. a slog handler through
+// NewSlogLogger. Plugins log through FromContext to include the plugin name,
+// the processed path and the scan ID as structured fields.
 package log
 
 import "log"
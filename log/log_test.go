@@ -0,0 +1,124 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/osv-scalibr/log"
+)
+
+// recordingLogger records the messages logged through its formatted functions.
+type recordingLogger struct {
+	msgs []string
+}
+
+func (l *recordingLogger) record(level, format string, args ...any) {
+	l.msgs = append(l.msgs, level+": "+strings.TrimSpace(fmt.Sprintf(format, args...)))
+}
+
+func (l *recordingLogger) Errorf(format string, args ...any) { l.record("E", format, args...) }
+func (l *recordingLogger) Warnf(format string, args ...any)  { l.record("W", format, args...) }
+func (l *recordingLogger) Infof(format string, args ...any)  { l.record("I", format, args...) }
+func (l *recordingLogger) Debugf(format string, args ...any) { l.record("D", format, args...) }
+func (l *recordingLogger) Error(args ...any)                 { l.record("E", "%s", fmt.Sprintln(args...)) }
+func (l *recordingLogger) Warn(args ...any)                  { l.record("W", "%s", fmt.Sprintln(args...)) }
+func (l *recordingLogger) Info(args ...any)                  { l.record("I", "%s", fmt.Sprintln(args...)) }
+func (l *recordingLogger) Debug(args ...any)                 { l.record("D", "%s", fmt.Sprintln(args...)) }
+
+func TestFromContext(t *testing.T) {
+	rec := &recordingLogger{}
+	log.SetLogger(rec)
+	defer log.SetLogger(&log.DefaultLogger{})
+
+	ctx := log.NewContext(context.Background(), log.KeyScanID, "scan-1")
+	ctx = log.NewContext(ctx, log.KeyPlugin, "python/wheelegg", log.KeyPath, "a/b.whl")
+	log.FromContext(ctx).Warnf("bad %s", "wheel")
+	log.FromContext(ctx).Info("done", 3)
+	log.FromContext(context.Background()).Errorf("no fields")
+
+	want := []string{
+		"W: scan_id=scan-1 plugin=python/wheelegg path=a/b.whl bad wheel",
+		"I: scan_id=scan-1 plugin=python/wheelegg path=a/b.whl done 3",
+		"E: no fields",
+	}
+	if diff := cmp.Diff(want, rec.msgs); diff != "" {
+		t.Errorf("FromContext() logged unexpected messages (-want +got):\n%s", diff)
+	}
+}
+
+func TestNewContext_DoesNotModifyParent(t *testing.T) {
+	parent := log.NewContext(context.Background(), "a", 1, "b", 2)
+	log.NewContext(parent, "c", 3)
+	log.NewContext(parent, "d", 4)
+	want := []any{"a", 1, "b", 2}
+	if diff := cmp.Diff(want, log.Fields(parent)); diff != "" {
+		t.Errorf("Fields() returned unexpected diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestSlogLogger(t *testing.T) {
+	var buf bytes.Buffer
+	h := slog.NewTextHandler(&buf, &slog.HandlerOptions{
+		Level: slog.LevelInfo,
+		ReplaceAttr: func(_ []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	})
+	l := log.With(log.NewSlogLogger(h), log.KeyPlugin, "os/dpkg")
+	l.Infof("found %d packages", 2)
+	l.Debugf("not logged")
+	l.Error("failed", "twice")
+
+	want := "level=INFO msg=\"found 2 packages\" plugin=os/dpkg\n" +
+		"level=ERROR msg=\"failed twice\" plugin=os/dpkg\n"
+	if diff := cmp.Diff(want, buf.String()); diff != "" {
+		t.Errorf("SlogLogger logged unexpected output (-want +got):\n%s", diff)
+	}
+}
+
+func TestSampler(t *testing.T) {
+	rec := &recordingLogger{}
+	s := log.NewSampler(rec, 2, 3)
+	for i := range 9 {
+		s.Infof("skipping %d", i)
+	}
+	log.With(s, "k", "v").Infof("skipping %d", 9)
+	s.Errorf("error %d", 0)
+	s.Errorf("error %d", 1)
+	s.Errorf("error %d", 2)
+
+	want := []string{
+		"I: skipping 0",
+		"I: skipping 1",
+		"I: skipping 4",
+		"I: skipping 7",
+		"E: error 0",
+		"E: error 1",
+		"E: error 2",
+	}
+	if diff := cmp.Diff(want, rec.msgs); diff != "" {
+		t.Errorf("Sampler logged unexpected messages (-want +got):\n%s", diff)
+	}
+}
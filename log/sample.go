@@ -0,0 +1,126 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Sampler is a Logger that drops repeated messages, e.g. per-file warnings
+// that are logged for every file of a large filesystem. Messages are grouped
+// by their format string, or by their first argument for the unformatted
+// functions. Of each group, the first First messages are logged and after that
+// only every Thereafter-th one. Error messages are never dropped.
+type Sampler struct {
+	l          Logger
+	first      int
+	thereafter int
+	counts     *sampleCounts
+}
+
+type sampleCounts struct {
+	mu     sync.Mutex
+	counts map[string]int
+}
+
+// NewSampler returns a Logger that samples the messages logged through l. If
+// thereafter is 0, messages are dropped after the first ones.
+func NewSampler(l Logger, first, thereafter int) *Sampler {
+	return &Sampler{
+		l:          l,
+		first:      first,
+		thereafter: thereafter,
+		counts:     &sampleCounts{counts: make(map[string]int)},
+	}
+}
+
+// With returns a sampler that adds the given alternating keys and values to
+// every message. Messages are counted together with those of s.
+func (s *Sampler) With(args ...any) Logger {
+	return &Sampler{
+		l:          With(s.l, args...),
+		first:      s.first,
+		thereafter: s.thereafter,
+		counts:     s.counts,
+	}
+}
+
+// keep returns whether the next message of the given group should be logged.
+func (s *Sampler) keep(key string) bool {
+	s.counts.mu.Lock()
+	n := s.counts.counts[key]
+	s.counts.counts[key] = n + 1
+	s.counts.mu.Unlock()
+	if n < s.first {
+		return true
+	}
+	return s.thereafter > 0 && (n-s.first+1)%s.thereafter == 0
+}
+
+func firstArg(args []any) string {
+	if len(args) == 0 {
+		return ""
+	}
+	return fmt.Sprint(args[0])
+}
+
+// Errorf is the formatted error logging function.
+func (s *Sampler) Errorf(format string, args ...any) { s.l.Errorf(format, args...) }
+
+// Error is the error logging function.
+func (s *Sampler) Error(args ...any) { s.l.Error(args...) }
+
+// Warnf is the formatted warning logging function.
+func (s *Sampler) Warnf(format string, args ...any) {
+	if s.keep(format) {
+		s.l.Warnf(format, args...)
+	}
+}
+
+// Warn is the warning logging function.
+func (s *Sampler) Warn(args ...any) {
+	if s.keep(firstArg(args)) {
+		s.l.Warn(args...)
+	}
+}
+
+// Infof is the formatted info logging function.
+func (s *Sampler) Infof(format string, args ...any) {
+	if s.keep(format) {
+		s.l.Infof(format, args...)
+	}
+}
+
+// Info is the info logging function.
+func (s *Sampler) Info(args ...any) {
+	if s.keep(firstArg(args)) {
+		s.l.Info(args...)
+	}
+}
+
+// Debugf is the formatted debug logging function.
+func (s *Sampler) Debugf(format string, args ...any) {
+	if s.keep(format) {
+		s.l.Debugf(format, args...)
+	}
+}
+
+// Debug is the debug logging function.
+func (s *Sampler) Debug(args ...any) {
+	if s.keep(firstArg(args)) {
+		s.l.Debug(args...)
+	}
+}
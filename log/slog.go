@@ -0,0 +1,113 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"runtime"
+	"time"
+)
+
+// SlogLogger is a Logger that writes structured records to a slog.Handler.
+// Fields added with With or through FromContext become record attributes.
+type SlogLogger struct {
+	h slog.Handler
+}
+
+// NewSlogLogger returns a Logger that writes to the given slog handler, e.g.
+//
+//	log.SetLogger(log.NewSlogLogger(slog.NewJSONHandler(os.Stderr, nil)))
+func NewSlogLogger(h slog.Handler) *SlogLogger {
+	return &SlogLogger{h: h}
+}
+
+// With returns a logger that adds the given alternating keys and values to
+// every record.
+func (l *SlogLogger) With(args ...any) Logger {
+	if len(args) == 0 {
+		return l
+	}
+	r := slog.NewRecord(time.Time{}, 0, "", 0)
+	r.Add(args...)
+	attrs := make([]slog.Attr, 0, r.NumAttrs())
+	r.Attrs(func(a slog.Attr) bool {
+		attrs = append(attrs, a)
+		return true
+	})
+	return &SlogLogger{h: l.h.WithAttrs(attrs)}
+}
+
+// Errorf is the formatted error logging function.
+func (l *SlogLogger) Errorf(format string, args ...any) {
+	l.log(slog.LevelError, func() string { return fmt.Sprintf(format, args...) })
+}
+
+// Warnf is the formatted warning logging function.
+func (l *SlogLogger) Warnf(format string, args ...any) {
+	l.log(slog.LevelWarn, func() string { return fmt.Sprintf(format, args...) })
+}
+
+// Infof is the formatted info logging function.
+func (l *SlogLogger) Infof(format string, args ...any) {
+	l.log(slog.LevelInfo, func() string { return fmt.Sprintf(format, args...) })
+}
+
+// Debugf is the formatted debug logging function.
+func (l *SlogLogger) Debugf(format string, args ...any) {
+	l.log(slog.LevelDebug, func() string { return fmt.Sprintf(format, args...) })
+}
+
+// Error is the error logging function.
+func (l *SlogLogger) Error(args ...any) {
+	l.log(slog.LevelError, func() string { return sprint(args) })
+}
+
+// Warn is the warning logging function.
+func (l *SlogLogger) Warn(args ...any) {
+	l.log(slog.LevelWarn, func() string { return sprint(args) })
+}
+
+// Info is the info logging function.
+func (l *SlogLogger) Info(args ...any) {
+	l.log(slog.LevelInfo, func() string { return sprint(args) })
+}
+
+// Debug is the debug logging function.
+func (l *SlogLogger) Debug(args ...any) {
+	l.log(slog.LevelDebug, func() string { return sprint(args) })
+}
+
+// log writes a record if the handler is enabled for the level. The message is
+// only formatted if it's written.
+func (l *SlogLogger) log(level slog.Level, msg func() string) {
+	ctx := context.Background()
+	if !l.h.Enabled(ctx, level) {
+		return
+	}
+	var pcs [1]uintptr
+	// Skip runtime.Callers, log and the SlogLogger method.
+	runtime.Callers(3, pcs[:])
+	r := slog.NewRecord(time.Now(), level, msg(), pcs[0])
+	_ = l.h.Handle(ctx, r)
+}
+
+// sprint formats the arguments like the unformatted DefaultLogger functions,
+// separated by spaces and without a trailing newline.
+func sprint(args []any) string {
+	s := fmt.Sprintln(args...)
+	return s[:len(s)-1]
+}
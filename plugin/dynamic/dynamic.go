@@ -0,0 +1,97 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package dynamic loads additional SCALIBR plugins at runtime from a plugin
+// directory so that new extractors and detectors can be added without
+// recompiling the scanner binary.
+//
+// Two kinds of plugin files are supported:
+//   - Go plugins (.so files built with `go build -buildmode=plugin`) that export
+//     a `func Plugins() []plugin.Plugin` function. These are only supported on
+//     Linux, macOS and FreeBSD with cgo enabled, and need to be built with the same
+//     Go toolchain and dependency versions as the scanner.
+//   - Executables implementing the exec protocol described in exec.go. These
+//     work on all platforms and can be written in any language.
+package dynamic
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+
+	"github.com/google/osv-scalibr/log"
+	"github.com/google/osv-scalibr/plugin"
+)
+
+// PluginsSymbol is the name of the function that Go plugins need to export.
+// Its signature has to be `func() []plugin.Plugin`.
+const PluginsSymbol = "Plugins"
+
+// Load loads all plugins from the files in the given directory. Sub-directories
+// and hidden files are ignored.
+func Load(ctx context.Context, dir string) ([]plugin.Plugin, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []plugin.Plugin
+	names := map[string]string{}
+	for _, e := range entries {
+		if e.IsDir() || strings.HasPrefix(e.Name(), ".") {
+			continue
+		}
+		path := filepath.Join(dir, e.Name())
+		var plugins []plugin.Plugin
+		switch {
+		case filepath.Ext(path) == ".so":
+			plugins, err = loadGoPlugin(path)
+		case isExecutable(e):
+			var p plugin.Plugin
+			p, err = LoadExec(ctx, path)
+			plugins = []plugin.Plugin{p}
+		default:
+			log.Warnf("Skipping %s: not a Go plugin or executable", path)
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("loading plugin %s: %w", path, err)
+		}
+
+		for _, p := range plugins {
+			if other, ok := names[p.Name()]; ok {
+				return nil, fmt.Errorf("plugin %q is defined in both %s and %s", p.Name(), other, path)
+			}
+			names[p.Name()] = path
+			log.Infof("Loaded plugin %s from %s", p.Name(), path)
+		}
+		result = append(result, plugins...)
+	}
+	return result, nil
+}
+
+func isExecutable(e os.DirEntry) bool {
+	if runtime.GOOS == "windows" {
+		return slices.Contains([]string{".exe", ".bat", ".cmd"}, strings.ToLower(filepath.Ext(e.Name())))
+	}
+	info, err := e.Info()
+	if err != nil {
+		return false
+	}
+	return info.Mode().IsRegular() && info.Mode().Perm()&0111 != 0
+}
@@ -0,0 +1,261 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dynamic_test
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/osv-scalibr/detector"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/extractor/filesystem/simplefileapi"
+	scalibrfs "github.com/google/osv-scalibr/fs"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/plugin"
+	"github.com/google/osv-scalibr/plugin/dynamic"
+)
+
+const extractorScript = `#!/bin/sh
+case "$1" in
+describe)
+  echo '{"name": "test/pkgdb", "version": 2, "kind": "extractor", "file_patterns": ["*.pkgdb"], "requirements": {"os": "unix"}}'
+  ;;
+extract)
+  read -r name version
+  echo "{\"packages\": [{\"name\": \"$name\", \"version\": \"$version\", \"purl_type\": \"generic\"}]}"
+  ;;
+esac
+`
+
+const detectorScript = `#!/bin/sh
+case "$1" in
+describe)
+  echo '{"name": "test/acmeconf", "version": 1, "kind": "detector",
+    "findings": [{"publisher": "ACME", "reference": "ACME-1", "title": "Insecure config", "severity": "high"}]}'
+  ;;
+detect)
+  if [ -f "$2/etc/acme.conf" ]; then
+    echo '{"findings": [{"reference": "ACME-1", "extra": "/etc/acme.conf"}]}'
+  else
+    echo '{}'
+  fi
+  ;;
+esac
+`
+
+const failingScript = `#!/bin/sh
+echo "something went wrong" >&2
+exit 1
+`
+
+func writeFile(t *testing.T, path, content string, perm os.FileMode) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), perm); err != nil {
+		t.Fatalf("os.WriteFile(%s): %v", path, err)
+	}
+}
+
+func skipOnWindows(t *testing.T) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("Test uses shell scripts as plugins")
+	}
+}
+
+func TestLoad(t *testing.T) {
+	skipOnWindows(t)
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "pkgdb"), extractorScript, 0755)
+	writeFile(t, filepath.Join(dir, "acmeconf"), detectorScript, 0755)
+	writeFile(t, filepath.Join(dir, "README"), "not a plugin", 0644)
+	writeFile(t, filepath.Join(dir, ".hidden"), failingScript, 0755)
+
+	plugins, err := dynamic.Load(t.Context(), dir)
+	if err != nil {
+		t.Fatalf("Load(%s): %v", dir, err)
+	}
+
+	type pluginInfo struct {
+		Name         string
+		Version      int
+		Requirements *plugin.Capabilities
+		IsExtractor  bool
+		IsDetector   bool
+	}
+	var got []pluginInfo
+	for _, p := range plugins {
+		_, isExtractor := p.(filesystem.Extractor)
+		_, isDetector := p.(detector.Detector)
+		got = append(got, pluginInfo{p.Name(), p.Version(), p.Requirements(), isExtractor, isDetector})
+	}
+	want := []pluginInfo{
+		{"test/acmeconf", 1, &plugin.Capabilities{DirectFS: true}, false, true},
+		{"test/pkgdb", 2, &plugin.Capabilities{OS: plugin.OSUnix}, true, false},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Load(%s) returned unexpected plugins (-want +got):\n%s", dir, diff)
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	skipOnWindows(t)
+	tests := []struct {
+		desc    string
+		files   map[string]string
+		wantErr string
+	}{
+		{
+			desc:    "failing_plugin",
+			files:   map[string]string{"fail": failingScript},
+			wantErr: "something went wrong",
+		},
+		{
+			desc:    "invalid_description",
+			files:   map[string]string{"invalid": "#!/bin/sh\necho 'not json'\n"},
+			wantErr: "invalid plugin description",
+		},
+		{
+			desc:    "unsupported_kind",
+			files:   map[string]string{"annotator": "#!/bin/sh\necho '{\"name\": \"a\", \"kind\": \"annotator\"}'\n"},
+			wantErr: "unsupported plugin kind",
+		},
+		{
+			desc:    "extractor_without_patterns",
+			files:   map[string]string{"ex": "#!/bin/sh\necho '{\"name\": \"a\", \"kind\": \"extractor\"}'\n"},
+			wantErr: "file_patterns",
+		},
+		{
+			desc:    "duplicate_names",
+			files:   map[string]string{"a": extractorScript, "b": extractorScript},
+			wantErr: "is defined in both",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			dir := t.TempDir()
+			for name, content := range tc.files {
+				writeFile(t, filepath.Join(dir, name), content, 0755)
+			}
+			_, err := dynamic.Load(t.Context(), dir)
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Errorf("Load(%s) returned error %v, want error containing %q", dir, err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestExecExtractor(t *testing.T) {
+	skipOnWindows(t)
+	binPath := filepath.Join(t.TempDir(), "pkgdb")
+	writeFile(t, binPath, extractorScript, 0755)
+	p, err := dynamic.LoadExec(t.Context(), binPath)
+	if err != nil {
+		t.Fatalf("LoadExec(%s): %v", binPath, err)
+	}
+	e := p.(filesystem.Extractor)
+
+	for path, want := range map[string]bool{
+		"var/lib/acme/installed.pkgdb": true,
+		"installed.pkgdb":              true,
+		"installed.pkgdb.bak":          false,
+	} {
+		if got := e.FileRequired(simplefileapi.New(path, nil)); got != want {
+			t.Errorf("FileRequired(%s) = %v, want %v", path, got, want)
+		}
+	}
+
+	input := &filesystem.ScanInput{
+		Path:   "var/lib/acme/installed.pkgdb",
+		Reader: strings.NewReader("foo 1.2.3\n"),
+	}
+	got, err := e.Extract(t.Context(), input)
+	if err != nil {
+		t.Fatalf("Extract(%s): %v", input.Path, err)
+	}
+	want := inventory.Inventory{Packages: []*extractor.Package{{
+		Name:      "foo",
+		Version:   "1.2.3",
+		PURLType:  "generic",
+		Locations: []string{"var/lib/acme/installed.pkgdb"},
+	}}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Extract(%s) returned unexpected inventory (-want +got):\n%s", input.Path, diff)
+	}
+}
+
+func TestExecDetector(t *testing.T) {
+	skipOnWindows(t)
+	binPath := filepath.Join(t.TempDir(), "acmeconf")
+	writeFile(t, binPath, detectorScript, 0755)
+	p, err := dynamic.LoadExec(t.Context(), binPath)
+	if err != nil {
+		t.Fatalf("LoadExec(%s): %v", binPath, err)
+	}
+	d := p.(detector.Detector)
+
+	adv := &inventory.GenericFindingAdvisory{
+		ID:    &inventory.AdvisoryID{Publisher: "ACME", Reference: "ACME-1"},
+		Title: "Insecure config",
+		Sev:   inventory.SeverityHigh,
+	}
+	if diff := cmp.Diff(inventory.Finding{GenericFindings: []*inventory.GenericFinding{{Adv: adv}}}, d.DetectedFinding()); diff != "" {
+		t.Errorf("DetectedFinding() returned unexpected finding (-want +got):\n%s", diff)
+	}
+
+	tests := []struct {
+		desc  string
+		files []string
+		want  inventory.Finding
+	}{
+		{
+			desc: "no_finding",
+			want: inventory.Finding{GenericFindings: []*inventory.GenericFinding{}},
+		},
+		{
+			desc:  "finding",
+			files: []string{"etc/acme.conf"},
+			want: inventory.Finding{GenericFindings: []*inventory.GenericFinding{{
+				Adv:    adv,
+				Target: &inventory.GenericFindingTargetDetails{Extra: "/etc/acme.conf"},
+			}}},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			root := t.TempDir()
+			for _, f := range tc.files {
+				path := filepath.Join(root, f)
+				if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+					t.Fatalf("os.MkdirAll(%s): %v", filepath.Dir(path), err)
+				}
+				writeFile(t, path, "", 0644)
+			}
+			got, err := d.Scan(t.Context(), scalibrfs.RealFSScanRoot(root), nil)
+			if err != nil {
+				t.Fatalf("Scan(%s): %v", root, err)
+			}
+			if diff := cmp.Diff(tc.want, got, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("Scan(%s) returned unexpected finding (-want +got):\n%s", root, diff)
+			}
+		})
+	}
+}
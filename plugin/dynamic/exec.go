@@ -0,0 +1,340 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dynamic
+
+// The exec protocol lets any executable act as a SCALIBR extractor or detector.
+// The scanner calls the executable with one of the following sub-commands and
+// reads a JSON document from its stdout. A non-zero exit code is treated as a
+// plugin error and the stderr output is included in the error message.
+//
+//   - `describe`: Called once when the plugin is loaded. Prints a description
+//     of the plugin, e.g.
+//     {"name": "acme/pkgdb", "version": 1, "kind": "extractor",
+//     "file_patterns": ["*.pkgdb"], "requirements": {"os": "linux"}}
+//     Detectors list the findings they can report under "findings" and the
+//     extractors they depend on under "required_extractors".
+//   - `extract <path>`: Extractors only. The contents of the file are passed
+//     on stdin and <path> is the file's path relative to the scan root.
+//     Prints the found packages, e.g.
+//     {"packages": [{"name": "foo", "version": "1.0", "purl_type": "generic"}]}
+//   - `detect <root>`: Detectors only. <root> is the absolute path of the scan
+//     root. Prints the detected findings, referencing the findings from the
+//     description, e.g.
+//     {"findings": [{"reference": "ACME-2025-1", "extra": "/etc/acme.conf"}]}
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	scalibrfs "github.com/google/osv-scalibr/fs"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/packageindex"
+	"github.com/google/osv-scalibr/plugin"
+)
+
+// Plugin kinds supported by the exec protocol.
+const (
+	KindExtractor = "extractor"
+	KindDetector  = "detector"
+)
+
+// Description is the output of the `describe` command of exec plugins.
+type Description struct {
+	Name               string       `json:"name"`
+	Version            int          `json:"version"`
+	Kind               string       `json:"kind"`
+	FilePatterns       []string     `json:"file_patterns,omitempty"`
+	RequiredExtractors []string     `json:"required_extractors,omitempty"`
+	Findings           []Advisory   `json:"findings,omitempty"`
+	Requirements       Requirements `json:"requirements"`
+}
+
+// Requirements are the requirements of an exec plugin on the scanning environment.
+type Requirements struct {
+	// One of "linux", "windows", "mac", "unix" or empty for any OS.
+	OS string `json:"os,omitempty"`
+	// One of "online", "offline" or empty if the plugin works in both modes.
+	Network       string `json:"network,omitempty"`
+	DirectFS      bool   `json:"direct_fs,omitempty"`
+	RunningSystem bool   `json:"running_system,omitempty"`
+}
+
+// Advisory describes a finding that an exec detector can report.
+type Advisory struct {
+	Publisher      string `json:"publisher"`
+	Reference      string `json:"reference"`
+	Title          string `json:"title"`
+	Description    string `json:"description"`
+	Recommendation string `json:"recommendation"`
+	// One of "minimal", "low", "medium", "high", "critical".
+	Severity string `json:"severity"`
+}
+
+// Package is a package found by an exec extractor.
+type Package struct {
+	Name     string `json:"name"`
+	Version  string `json:"version"`
+	PURLType string `json:"purl_type"`
+	// Defaults to the path of the extracted file if empty.
+	Locations []string `json:"locations,omitempty"`
+}
+
+// Finding is a finding reported by an exec detector.
+type Finding struct {
+	// The reference of one of the findings from the plugin description.
+	Reference string `json:"reference"`
+	Extra     string `json:"extra,omitempty"`
+}
+
+type extractOutput struct {
+	Packages []Package `json:"packages"`
+}
+
+type detectOutput struct {
+	Findings []Finding `json:"findings"`
+}
+
+var osNames = map[string]plugin.OS{
+	"":        plugin.OSAny,
+	"linux":   plugin.OSLinux,
+	"windows": plugin.OSWindows,
+	"mac":     plugin.OSMac,
+	"unix":    plugin.OSUnix,
+}
+
+var networkNames = map[string]plugin.Network{
+	"":        plugin.NetworkAny,
+	"offline": plugin.NetworkOffline,
+	"online":  plugin.NetworkOnline,
+}
+
+var severityNames = map[string]inventory.SeverityEnum{
+	"":         inventory.SeverityUnspecified,
+	"minimal":  inventory.SeverityMinimal,
+	"low":      inventory.SeverityLow,
+	"medium":   inventory.SeverityMedium,
+	"high":     inventory.SeverityHigh,
+	"critical": inventory.SeverityCritical,
+}
+
+// LoadExec runs the `describe` command of the given executable and returns an
+// extractor or detector that runs it.
+func LoadExec(ctx context.Context, binPath string) (plugin.Plugin, error) {
+	out, err := run(ctx, binPath, nil, "describe")
+	if err != nil {
+		return nil, err
+	}
+	var d Description
+	if err := json.Unmarshal(out, &d); err != nil {
+		return nil, fmt.Errorf("invalid plugin description: %w", err)
+	}
+	if d.Name == "" {
+		return nil, errors.New("invalid plugin description: no name set")
+	}
+	reqs, err := d.Requirements.capabilities()
+	if err != nil {
+		return nil, err
+	}
+	base := execPlugin{path: binPath, name: d.Name, version: d.Version, reqs: reqs}
+
+	switch d.Kind {
+	case KindExtractor:
+		if len(d.FilePatterns) == 0 {
+			return nil, errors.New("invalid plugin description: extractors need file_patterns")
+		}
+		for _, p := range d.FilePatterns {
+			if _, err := path.Match(p, ""); err != nil {
+				return nil, fmt.Errorf("invalid file pattern %q: %w", p, err)
+			}
+		}
+		return &execExtractor{execPlugin: base, patterns: d.FilePatterns}, nil
+	case KindDetector:
+		// The detector is passed the scan root's path so it needs direct FS access.
+		base.reqs.DirectFS = true
+		det := &execDetector{
+			execPlugin:         base,
+			requiredExtractors: d.RequiredExtractors,
+			advisories:         make(map[string]*inventory.GenericFindingAdvisory, len(d.Findings)),
+		}
+		for _, a := range d.Findings {
+			sev, ok := severityNames[strings.ToLower(a.Severity)]
+			if !ok {
+				return nil, fmt.Errorf("invalid severity %q for finding %q", a.Severity, a.Reference)
+			}
+			adv := &inventory.GenericFindingAdvisory{
+				ID:             &inventory.AdvisoryID{Publisher: a.Publisher, Reference: a.Reference},
+				Title:          a.Title,
+				Description:    a.Description,
+				Recommendation: a.Recommendation,
+				Sev:            sev,
+			}
+			det.advisories[a.Reference] = adv
+			det.findings = append(det.findings, &inventory.GenericFinding{Adv: adv})
+		}
+		return det, nil
+	default:
+		return nil, fmt.Errorf("unsupported plugin kind %q, want %q or %q", d.Kind, KindExtractor, KindDetector)
+	}
+}
+
+func (r Requirements) capabilities() (*plugin.Capabilities, error) {
+	reqOS, ok := osNames[r.OS]
+	if !ok {
+		return nil, fmt.Errorf("invalid OS requirement %q", r.OS)
+	}
+	network, ok := networkNames[r.Network]
+	if !ok {
+		return nil, fmt.Errorf("invalid network requirement %q", r.Network)
+	}
+	return &plugin.Capabilities{
+		OS:            reqOS,
+		Network:       network,
+		DirectFS:      r.DirectFS,
+		RunningSystem: r.RunningSystem,
+	}, nil
+}
+
+// run executes the plugin binary and returns its stdout.
+func run(ctx context.Context, path string, stdin io.Reader, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, path, args...)
+	cmd.Stdin = stdin
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("%s %s: %w: %s", filepath.Base(path), args[0], err, msg)
+		}
+		return nil, fmt.Errorf("%s %s: %w", filepath.Base(path), args[0], err)
+	}
+	return stdout.Bytes(), nil
+}
+
+type execPlugin struct {
+	path    string
+	name    string
+	version int
+	reqs    *plugin.Capabilities
+}
+
+// Name of the plugin.
+func (p *execPlugin) Name() string { return p.name }
+
+// Version of the plugin.
+func (p *execPlugin) Version() int { return p.version }
+
+// Requirements of the plugin.
+func (p *execPlugin) Requirements() *plugin.Capabilities { return p.reqs }
+
+// execExtractor is a filesystem extractor that runs an external binary.
+type execExtractor struct {
+	execPlugin
+
+	patterns []string
+}
+
+// FileRequired returns true if the file's name or path relative to the scan root
+// matches one of the file patterns of the plugin.
+func (e *execExtractor) FileRequired(api filesystem.FileAPI) bool {
+	p := filepath.ToSlash(api.Path())
+	for _, pattern := range e.patterns {
+		if ok, _ := path.Match(pattern, path.Base(p)); ok {
+			return true
+		}
+		if ok, _ := path.Match(pattern, p); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Extract runs the `extract` command of the plugin on the file.
+func (e *execExtractor) Extract(ctx context.Context, input *filesystem.ScanInput) (inventory.Inventory, error) {
+	out, err := run(ctx, e.path, input.Reader, "extract", input.Path)
+	if err != nil {
+		return inventory.Inventory{}, err
+	}
+	var res extractOutput
+	if err := json.Unmarshal(out, &res); err != nil {
+		return inventory.Inventory{}, fmt.Errorf("invalid extract output: %w", err)
+	}
+
+	pkgs := make([]*extractor.Package, 0, len(res.Packages))
+	for _, p := range res.Packages {
+		locations := p.Locations
+		if len(locations) == 0 {
+			locations = []string{input.Path}
+		}
+		pkgs = append(pkgs, &extractor.Package{
+			Name:      p.Name,
+			Version:   p.Version,
+			PURLType:  p.PURLType,
+			Locations: locations,
+		})
+	}
+	return inventory.Inventory{Packages: pkgs}, nil
+}
+
+// execDetector is a detector that runs an external binary.
+type execDetector struct {
+	execPlugin
+
+	requiredExtractors []string
+	advisories         map[string]*inventory.GenericFindingAdvisory
+	findings           []*inventory.GenericFinding
+}
+
+// RequiredExtractors returns the extractors listed in the plugin description.
+func (d *execDetector) RequiredExtractors() []string { return d.requiredExtractors }
+
+// DetectedFinding returns the findings listed in the plugin description.
+func (d *execDetector) DetectedFinding() inventory.Finding {
+	return inventory.Finding{GenericFindings: d.findings}
+}
+
+// Scan runs the `detect` command of the plugin on the scan root.
+func (d *execDetector) Scan(ctx context.Context, scanRoot *scalibrfs.ScanRoot, px *packageindex.PackageIndex) (inventory.Finding, error) {
+	out, err := run(ctx, d.path, nil, "detect", scanRoot.Path)
+	if err != nil {
+		return inventory.Finding{}, err
+	}
+	var res detectOutput
+	if err := json.Unmarshal(out, &res); err != nil {
+		return inventory.Finding{}, fmt.Errorf("invalid detect output: %w", err)
+	}
+
+	findings := make([]*inventory.GenericFinding, 0, len(res.Findings))
+	for _, f := range res.Findings {
+		adv, ok := d.advisories[f.Reference]
+		if !ok {
+			return inventory.Finding{}, fmt.Errorf("finding %q is not listed in the plugin description", f.Reference)
+		}
+		findings = append(findings, &inventory.GenericFinding{
+			Adv:    adv,
+			Target: &inventory.GenericFindingTargetDetails{Extra: f.Extra},
+		})
+	}
+	return inventory.Finding{GenericFindings: findings}, nil
+}
@@ -0,0 +1,40 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build (linux || darwin || freebsd) && cgo
+
+package dynamic
+
+import (
+	"fmt"
+	goplugin "plugin"
+
+	"github.com/google/osv-scalibr/plugin"
+)
+
+func loadGoPlugin(path string) ([]plugin.Plugin, error) {
+	p, err := goplugin.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	sym, err := p.Lookup(PluginsSymbol)
+	if err != nil {
+		return nil, err
+	}
+	f, ok := sym.(func() []plugin.Plugin)
+	if !ok {
+		return nil, fmt.Errorf("symbol %s has type %T, want func() []plugin.Plugin", PluginsSymbol, sym)
+	}
+	return f(), nil
+}
@@ -0,0 +1,149 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"io"
+	"io/fs"
+	"slices"
+
+	scalibrfs "github.com/google/osv-scalibr/fs"
+)
+
+// Sentinel errors plugins can wrap to categorize their failures, e.g.
+// fmt.Errorf("%w: unknown lockfile version %d", plugin.ErrUnsupportedVersion, v).
+var (
+	// ErrParse is wrapped by errors about malformed input.
+	ErrParse = errors.New("parse error")
+	// ErrUnsupportedVersion is wrapped by errors about input in a format version
+	// the plugin doesn't support.
+	ErrUnsupportedVersion = errors.New("unsupported version")
+	// ErrPartial is wrapped by errors that caused the plugin to return incomplete
+	// results, e.g. because a size limit was reached.
+	ErrPartial = errors.New("partial results")
+)
+
+// LINT.IfChange
+
+// ErrorCategory is the machine-readable category of a plugin error.
+type ErrorCategory int
+
+// ErrorCategory values.
+const (
+	ErrorCategoryUnspecified ErrorCategory = iota
+	// The error doesn't belong to any of the other categories.
+	ErrorCategoryOther
+	ErrorCategoryPermissionDenied
+	ErrorCategoryParse
+	ErrorCategoryUnsupportedVersion
+	ErrorCategoryTimeout
+	ErrorCategoryPartial
+	// The plugin tried to read more data than the scan's IO budget allows.
+	ErrorCategoryBudgetExceeded
+)
+
+// ErrorCount is the number of errors of a category a plugin encountered.
+type ErrorCount struct {
+	Category ErrorCategory
+	Count    int
+}
+
+// LINT.ThenChange(/binary/proto/scan_result.proto)
+
+// String returns a string representation of the error category.
+func (c ErrorCategory) String() string {
+	switch c {
+	case ErrorCategoryOther:
+		return "OTHER"
+	case ErrorCategoryPermissionDenied:
+		return "PERMISSION_DENIED"
+	case ErrorCategoryParse:
+		return "PARSE_ERROR"
+	case ErrorCategoryUnsupportedVersion:
+		return "UNSUPPORTED_VERSION"
+	case ErrorCategoryTimeout:
+		return "TIMEOUT"
+	case ErrorCategoryPartial:
+		return "PARTIAL"
+	case ErrorCategoryBudgetExceeded:
+		return "BUDGET_EXCEEDED"
+	case ErrorCategoryUnspecified:
+		fallthrough
+	default:
+		return "UNSPECIFIED"
+	}
+}
+
+// CategorizeError returns the category of a plugin error.
+func CategorizeError(err error) ErrorCategory {
+	var jsonSyntaxErr *json.SyntaxError
+	var jsonTypeErr *json.UnmarshalTypeError
+	var xmlSyntaxErr *xml.SyntaxError
+	switch {
+	case err == nil:
+		return ErrorCategoryUnspecified
+	case errors.Is(err, ErrTimeout), errors.Is(err, context.DeadlineExceeded):
+		return ErrorCategoryTimeout
+	case errors.Is(err, fs.ErrPermission):
+		return ErrorCategoryPermissionDenied
+	case errors.Is(err, ErrUnsupportedVersion):
+		return ErrorCategoryUnsupportedVersion
+	case errors.Is(err, ErrPartial):
+		return ErrorCategoryPartial
+	case errors.Is(err, scalibrfs.ErrBudgetExceeded):
+		return ErrorCategoryBudgetExceeded
+	case errors.Is(err, ErrParse), errors.Is(err, io.ErrUnexpectedEOF),
+		errors.As(err, &jsonSyntaxErr), errors.As(err, &jsonTypeErr), errors.As(err, &xmlSyntaxErr):
+		return ErrorCategoryParse
+	default:
+		return ErrorCategoryOther
+	}
+}
+
+// CountErrors returns the number of errors per category in err, ordered by
+// category. Errors combined with errors.Join or with multiple %w verbs are
+// counted separately.
+func CountErrors(err error) []*ErrorCount {
+	counts := map[ErrorCategory]int{}
+	for _, e := range splitErrors(err) {
+		counts[CategorizeError(e)]++
+	}
+	result := make([]*ErrorCount, 0, len(counts))
+	for c, n := range counts {
+		result = append(result, &ErrorCount{Category: c, Count: n})
+	}
+	slices.SortFunc(result, func(a, b *ErrorCount) int { return int(a.Category - b.Category) })
+	return result
+}
+
+// splitErrors returns the individual errors combined in err.
+func splitErrors(err error) []error {
+	if err == nil {
+		return nil
+	}
+	multi, ok := err.(interface{ Unwrap() []error })
+	if !ok {
+		return []error{err}
+	}
+	var errs []error
+	for _, e := range multi.Unwrap() {
+		errs = append(errs, splitErrors(e)...)
+	}
+	return errs
+}
@@ -0,0 +1,137 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	scalibrfs "github.com/google/osv-scalibr/fs"
+	"github.com/google/osv-scalibr/plugin"
+)
+
+func TestCategorizeError(t *testing.T) {
+	syntaxErr := json.Unmarshal([]byte("{"), &struct{}{})
+	testCases := []struct {
+		desc string
+		err  error
+		want plugin.ErrorCategory
+	}{
+		{
+			desc: "nil",
+			err:  nil,
+			want: plugin.ErrorCategoryUnspecified,
+		},
+		{
+			desc: "permission_denied",
+			err:  &fs.PathError{Op: "open", Path: "/etc/shadow", Err: fs.ErrPermission},
+			want: plugin.ErrorCategoryPermissionDenied,
+		},
+		{
+			desc: "wrapped_parse_sentinel",
+			err:  fmt.Errorf("could not extract: %w: missing header", plugin.ErrParse),
+			want: plugin.ErrorCategoryParse,
+		},
+		{
+			desc: "json_syntax_error",
+			err:  fmt.Errorf("could not extract: %w", syntaxErr),
+			want: plugin.ErrorCategoryParse,
+		},
+		{
+			desc: "unsupported_version",
+			err:  fmt.Errorf("%w: lockfile version 4", plugin.ErrUnsupportedVersion),
+			want: plugin.ErrorCategoryUnsupportedVersion,
+		},
+		{
+			desc: "timeout",
+			err:  fmt.Errorf("%w after 1s: context deadline exceeded", plugin.ErrTimeout),
+			want: plugin.ErrorCategoryTimeout,
+		},
+		{
+			desc: "partial",
+			err:  fmt.Errorf("%w: file too large", plugin.ErrPartial),
+			want: plugin.ErrorCategoryPartial,
+		},
+		{
+			desc: "budget_exceeded",
+			err:  fmt.Errorf("Open(big.log): %w", &scalibrfs.BudgetExceededError{Path: "big.log", Limit: "max file size", Bytes: 10}),
+			want: plugin.ErrorCategoryBudgetExceeded,
+		},
+		{
+			desc: "other",
+			err:  errors.New("something went wrong"),
+			want: plugin.ErrorCategoryOther,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := plugin.CategorizeError(tc.err); got != tc.want {
+				t.Errorf("CategorizeError(%v) = %v, want %v", tc.err, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCountErrors(t *testing.T) {
+	parseErr := fmt.Errorf("%w: bad line", plugin.ErrParse)
+	permErr := &fs.PathError{Op: "open", Path: "a", Err: fs.ErrPermission}
+	testCases := []struct {
+		desc string
+		err  error
+		want []*plugin.ErrorCount
+	}{
+		{
+			desc: "nil",
+			err:  nil,
+			want: []*plugin.ErrorCount{},
+		},
+		{
+			desc: "single_error",
+			err:  parseErr,
+			want: []*plugin.ErrorCount{{Category: plugin.ErrorCategoryParse, Count: 1}},
+		},
+		{
+			desc: "joined_errors",
+			err:  errors.Join(parseErr, errors.New("other"), permErr, parseErr),
+			want: []*plugin.ErrorCount{
+				{Category: plugin.ErrorCategoryOther, Count: 1},
+				{Category: plugin.ErrorCategoryPermissionDenied, Count: 1},
+				{Category: plugin.ErrorCategoryParse, Count: 2},
+			},
+		},
+		{
+			desc: "nested_multi_wrapped_errors",
+			err:  fmt.Errorf("%w\n%w", fmt.Errorf("%w\n%w", parseErr, permErr), parseErr),
+			want: []*plugin.ErrorCount{
+				{Category: plugin.ErrorCategoryPermissionDenied, Count: 1},
+				{Category: plugin.ErrorCategoryParse, Count: 2},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := plugin.CountErrors(tc.err)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("CountErrors(%v) returned unexpected diff (-want +got):\n%s", tc.err, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package list
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/google/osv-scalibr/annotator/misc/fromnpm"
+	apkanno "github.com/google/osv-scalibr/annotator/osduplicate/apk"
+	dpkganno "github.com/google/osv-scalibr/annotator/osduplicate/dpkg"
+	rpmanno "github.com/google/osv-scalibr/annotator/osduplicate/rpm"
+	"github.com/google/osv-scalibr/detector/govulncheck/binary"
+	"github.com/google/osv-scalibr/extractor/filesystem/language/swift/packageresolved"
+	"github.com/google/osv-scalibr/extractor/filesystem/language/swift/podfilelock"
+	"github.com/google/osv-scalibr/extractor/filesystem/os/apk"
+	"github.com/google/osv-scalibr/extractor/filesystem/os/dpkg"
+	"github.com/google/osv-scalibr/extractor/filesystem/os/rpm"
+)
+
+// ecosystemPlugins maps ecosystems to the names of the plugins and plugin
+// groups that find, annotate and scan packages of that ecosystem. The keys are
+// normalized OSV ecosystem names, see normalizeEcosystem.
+var ecosystemPlugins = map[string][]string{
+	// Language ecosystems.
+	"conancenter": {"cpp"},
+	"cocoapods":   {podfilelock.Name},
+	"cran":        {"r"},
+	"cratesio":    {"rust"},
+	"go":          {"go", binary.Name},
+	"hackage":     {"haskell"},
+	"hex":         {"erlang", "elixir"},
+	"maven":       {"java"},
+	"npm":         {"javascript", fromnpm.Name},
+	"nuget":       {"dotnet"},
+	"packagist":   {"php"},
+	"pub":         {"dart"},
+	"pypi":        {"python"},
+	"rubygems":    {"ruby"},
+	"swifturl":    {packageresolved.Name},
+
+	// OS ecosystems.
+	"alpine":     {apk.Name, apkanno.Name},
+	"debian":     {dpkg.Name, dpkganno.Name},
+	"ubuntu":     {dpkg.Name, dpkganno.Name},
+	"almalinux":  {rpm.Name, rpmanno.Name},
+	"mageia":     {rpm.Name, rpmanno.Name},
+	"opensuse":   {rpm.Name, rpmanno.Name},
+	"redhat":     {rpm.Name, rpmanno.Name},
+	"rockylinux": {rpm.Name, rpmanno.Name},
+	"suse":       {rpm.Name, rpmanno.Name},
+}
+
+// normalizeEcosystem lowercases the ecosystem name and strips separators so
+// that e.g. "Red Hat", "red-hat" and "crates.io" match their keys.
+func normalizeEcosystem(ecosystem string) string {
+	return strings.NewReplacer(" ", "", "-", "", "_", "", ".", "").Replace(strings.ToLower(strings.TrimSpace(ecosystem)))
+}
+
+// Ecosystems returns the ecosystems that can be used to select plugins with
+// PluginNamesFromEcosystems, in their normalized form.
+func Ecosystems() []string {
+	return slices.Sorted(maps.Keys(ecosystemPlugins))
+}
+
+// PluginNamesFromEcosystems returns the names of the plugins and plugin groups
+// that handle packages of the given ecosystems, e.g. "PyPI" or "debian". The
+// names can be resolved with FromNames.
+func PluginNamesFromEcosystems(ecosystems []string) ([]string, error) {
+	var result []string
+	for _, e := range ecosystems {
+		names, ok := ecosystemPlugins[normalizeEcosystem(e)]
+		if !ok {
+			return nil, fmt.Errorf("unknown ecosystem %q, supported ecosystems are %v", e, Ecosystems())
+		}
+		for _, name := range names {
+			if !slices.Contains(result, name) {
+				result = append(result, name)
+			}
+		}
+	}
+	return result, nil
+}
@@ -115,12 +115,12 @@ func TestFromNames(t *testing.T) {
 		{
 			desc:      "Find_all_Plugins_of_a_type",
 			names:     []string{"python", "windows", "cis", "vex", "layerdetails"},
-			wantNames: []string{"python/pdmlock", "python/pipfilelock", "python/poetrylock", "python/condameta", "python/uvlock", "python/wheelegg", "python/requirements", "python/setup", "windows/dismpatch", "cis/generic-linux/etcpasswdpermissions", "vex/cachedir", "vex/filter", "vex/os-duplicate/apk", "vex/os-duplicate/cos", "vex/os-duplicate/dpkg", "vex/os-duplicate/rpm", "vex/no-executable/dpkg", "baseimage"},
+			wantNames: []string{"python/pdmlock", "python/pipfilelock", "python/poetrylock", "python/pythonenv", "python/condameta", "python/uvlock", "python/wheelegg", "python/requirements", "python/setup", "windows/dismpatch", "cis/generic-linux/etcpasswdpermissions", "vex/baseline", "vex/cachedir", "vex/filter", "vex/suppression", "vex/os-duplicate/apk", "vex/os-duplicate/cos", "vex/os-duplicate/dpkg", "vex/os-duplicate/rpm", "vex/no-executable/dpkg", "baseimage"},
 		},
 		{
 			desc:      "Remove_duplicates",
 			names:     []string{"python", "python"},
-			wantNames: []string{"python/pdmlock", "python/pipfilelock", "python/poetrylock", "python/condameta", "python/uvlock", "python/wheelegg", "python/requirements", "python/setup"},
+			wantNames: []string{"python/pdmlock", "python/pipfilelock", "python/poetrylock", "python/pythonenv", "python/condameta", "python/uvlock", "python/wheelegg", "python/requirements", "python/setup"},
 		},
 		{
 			desc:      "Nonexistent_plugin",
@@ -187,3 +187,69 @@ func TestFromName(t *testing.T) {
 		})
 	}
 }
+
+func TestPluginNamesFromEcosystems(t *testing.T) {
+	testCases := []struct {
+		desc        string
+		ecosystems  []string
+		wantPlugins []string
+		wantErr     error
+	}{
+		{
+			desc:        "Language_ecosystem",
+			ecosystems:  []string{"PyPI"},
+			wantPlugins: []string{"python/pdmlock", "python/pipfilelock", "python/poetrylock", "python/pythonenv", "python/condameta", "python/uvlock", "python/wheelegg", "python/requirements", "python/setup"},
+		},
+		{
+			desc:        "OS_ecosystems_with_shared_plugins",
+			ecosystems:  []string{"debian", "Ubuntu", "Red Hat"},
+			wantPlugins: []string{"os/dpkg", "vex/os-duplicate/dpkg", "os/rpm", "vex/os-duplicate/rpm"},
+		},
+		{
+			desc:        "Extractors_and_detectors",
+			ecosystems:  []string{"go"},
+			wantPlugins: []string{"go/gomod", "go/binary", "govulncheck/binary"},
+		},
+		{
+			desc:       "Unknown_ecosystem",
+			ecosystems: []string{"npm", "cobol"},
+			wantErr:    cmpopts.AnyError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			names, err := pl.PluginNamesFromEcosystems(tc.ecosystems)
+			if diff := cmp.Diff(tc.wantErr, err, cmpopts.EquateErrors()); diff != "" {
+				t.Fatalf("pl.PluginNamesFromEcosystems(%v) error got diff (-want +got):\n%s", tc.ecosystems, diff)
+			}
+			if err != nil {
+				return
+			}
+			plugins, err := pl.FromNames(names)
+			if err != nil {
+				t.Fatalf("pl.FromNames(%v): %v", names, err)
+			}
+			gotPlugins := []string{}
+			for _, p := range plugins {
+				gotPlugins = append(gotPlugins, p.Name())
+			}
+			sort := func(p1, p2 string) bool { return p1 < p2 }
+			if diff := cmp.Diff(tc.wantPlugins, gotPlugins, cmpopts.SortSlices(sort)); diff != "" {
+				t.Errorf("pl.PluginNamesFromEcosystems(%v): got diff (-want +got):\n%s", tc.ecosystems, diff)
+			}
+		})
+	}
+}
+
+func TestEcosystemPluginsExist(t *testing.T) {
+	for _, e := range pl.Ecosystems() {
+		names, err := pl.PluginNamesFromEcosystems([]string{e})
+		if err != nil {
+			t.Fatalf("pl.PluginNamesFromEcosystems(%q): %v", e, err)
+		}
+		if _, err := pl.FromNames(names); err != nil {
+			t.Errorf("pl.FromNames(%v) for ecosystem %q: %v", names, e, err)
+		}
+	}
+}
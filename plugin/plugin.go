@@ -102,6 +102,9 @@ type Status struct {
 type ScanStatus struct {
 	Status        ScanStatusEnum
 	FailureReason string
+	// The number of errors encountered by category, for aggregating failure
+	// causes programmatically.
+	ErrorCounts []*ErrorCount
 }
 
 // ScanStatusEnum is the enum for the scan status.
@@ -113,6 +116,9 @@ const (
 	ScanStatusSucceeded
 	ScanStatusPartiallySucceeded
 	ScanStatusFailed
+	// ScanStatusInterrupted means that the scan was cancelled, e.g. on SIGTERM,
+	// and only contains the results found until then.
+	ScanStatusInterrupted
 )
 
 // LINT.ThenChange(/binary/proto/scan_result.proto)
@@ -175,6 +181,7 @@ func StatusFromErr(p Plugin, partial bool, err error) *Status {
 			status.Status = ScanStatusFailed
 		}
 		status.FailureReason = err.Error()
+		status.ErrorCounts = CountErrors(err)
 	}
 	return &Status{
 		Name:    p.Name(),
@@ -192,6 +199,8 @@ func (s *ScanStatus) String() string {
 		return "PARTIALLY_SUCCEEDED"
 	case ScanStatusFailed:
 		return "FAILED: " + s.FailureReason
+	case ScanStatusInterrupted:
+		return "INTERRUPTED: " + s.FailureReason
 	case ScanStatusUnspecified:
 		fallthrough
 	default:
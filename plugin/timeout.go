@@ -0,0 +1,85 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrTimeout is returned by Timeouts.Run if a plugin didn't finish in time.
+var ErrTimeout = errors.New("plugin timed out")
+
+// Timeouts configures how long a single run of a plugin can take before its
+// context is cancelled. For filesystem extractors a run is the extraction of a
+// single file, for other plugin types it's the whole Scan, Annotate or Enrich
+// call.
+//
+// Timeouts are enforced through the context passed to the plugin, so plugins
+// that don't check it can't be interrupted. Results that were returned before
+// the timeout are kept and the plugin is marked as partially succeeded.
+type Timeouts struct {
+	// The timeout used for plugins without an override. If 0, no limit is applied.
+	Default time.Duration
+	// Plugin name to timeout. Overrides the default for the given plugins.
+	PerPlugin map[string]time.Duration
+}
+
+// For returns the timeout of the given plugin, or 0 if it has none.
+func (t *Timeouts) For(name string) time.Duration {
+	if t == nil {
+		return 0
+	}
+	if d, ok := t.PerPlugin[name]; ok {
+		return d
+	}
+	return t.Default
+}
+
+// Validate checks that the timeouts aren't negative.
+func (t *Timeouts) Validate() error {
+	if t == nil {
+		return nil
+	}
+	if t.Default < 0 {
+		return fmt.Errorf("negative default plugin timeout %v", t.Default)
+	}
+	for name, d := range t.PerPlugin {
+		if d < 0 {
+			return fmt.Errorf("negative timeout %v for plugin %q", d, name)
+		}
+	}
+	return nil
+}
+
+// Run calls f with a context that's cancelled once the timeout of the given
+// plugin expires. If f fails because it ran out of time, the returned error
+// wraps ErrTimeout. Cancellation of the parent context isn't reported as a
+// timeout.
+func (t *Timeouts) Run(ctx context.Context, name string, f func(ctx context.Context) error) error {
+	d := t.For(name)
+	if d <= 0 {
+		return f(ctx)
+	}
+	pctx, cancel := context.WithTimeout(ctx, d)
+	defer cancel()
+	err := f(pctx)
+	if err != nil && ctx.Err() == nil && errors.Is(pctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("%w after %v: %w", ErrTimeout, d, err)
+	}
+	return err
+}
@@ -0,0 +1,118 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/osv-scalibr/plugin"
+)
+
+func TestTimeoutsFor(t *testing.T) {
+	timeouts := &plugin.Timeouts{
+		Default:   time.Minute,
+		PerPlugin: map[string]time.Duration{"slow": time.Hour, "unlimited": 0},
+	}
+	testCases := []struct {
+		desc     string
+		timeouts *plugin.Timeouts
+		name     string
+		want     time.Duration
+	}{
+		{desc: "nil", timeouts: nil, name: "slow", want: 0},
+		{desc: "default", timeouts: timeouts, name: "other", want: time.Minute},
+		{desc: "override", timeouts: timeouts, name: "slow", want: time.Hour},
+		{desc: "override_without_limit", timeouts: timeouts, name: "unlimited", want: 0},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := tc.timeouts.For(tc.name); got != tc.want {
+				t.Errorf("For(%q) = %v, want %v", tc.name, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestTimeoutsValidate(t *testing.T) {
+	if err := (&plugin.Timeouts{Default: time.Second}).Validate(); err != nil {
+		t.Errorf("Validate(): %v", err)
+	}
+	if err := (&plugin.Timeouts{PerPlugin: map[string]time.Duration{"p": -time.Second}}).Validate(); err == nil {
+		t.Error("Validate() succeeded for negative timeout, want error")
+	}
+}
+
+// blockUntilDone waits for the context to be cancelled and returns its error.
+func blockUntilDone(ctx context.Context) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func TestTimeoutsRun(t *testing.T) {
+	errPlugin := errors.New("plugin error")
+	testCases := []struct {
+		desc     string
+		timeouts *plugin.Timeouts
+		cancel   bool
+		f        func(ctx context.Context) error
+		wantErr  error
+	}{
+		{
+			desc:     "no_timeout",
+			timeouts: nil,
+			f:        func(context.Context) error { return nil },
+		},
+		{
+			desc:     "finishes_in_time",
+			timeouts: &plugin.Timeouts{Default: time.Minute},
+			f:        func(context.Context) error { return errPlugin },
+			wantErr:  errPlugin,
+		},
+		{
+			desc:     "times_out",
+			timeouts: &plugin.Timeouts{Default: time.Millisecond},
+			f:        blockUntilDone,
+			wantErr:  plugin.ErrTimeout,
+		},
+		{
+			desc:     "parent_cancelled",
+			timeouts: &plugin.Timeouts{Default: time.Minute},
+			cancel:   true,
+			f:        blockUntilDone,
+			wantErr:  context.Canceled,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			if tc.cancel {
+				cancel()
+			}
+			err := tc.timeouts.Run(ctx, "plugin", tc.f)
+			if diff := cmp.Diff(tc.wantErr, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("Run() returned unexpected error (-want +got):\n%s", diff)
+			}
+			if tc.cancel && errors.Is(err, plugin.ErrTimeout) {
+				t.Errorf("Run() = %v, cancellation of the parent context shouldn't be reported as a timeout", err)
+			}
+		})
+	}
+}
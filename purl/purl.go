@@ -31,6 +31,8 @@ const (
 	TypeAlpm = "alpm"
 	// TypeApk is a pkg:apk purl.
 	TypeApk = "apk"
+	// TypeBioconductor is a pkg:bioconductor purl.
+	TypeBioconductor = "bioconductor"
 	// TypeBitbucket is a pkg:bitbucket purl.
 	TypeBitbucket = "bitbucket"
 	// TypeBrew is a pkg:brew purl.
@@ -67,6 +69,8 @@ const (
 	TypeHackage = "hackage"
 	// Type Haskell is a pkg:haskell purl.
 	TypeHaskell = "haskell"
+	// TypeJulia is pkg:julia purl
+	TypeJulia = "julia"
 	// TypeMacApps is a pkg:macapps purl.
 	TypeMacApps = "macapps"
 	// TypeHex is a pkg:hex purl.
@@ -83,6 +87,8 @@ const (
 	TypeNuget = "nuget"
 	// TypeOCI is a pkg:oci purl
 	TypeOCI = "oci"
+	// TypeOpam is a pkg:opam purl.
+	TypeOpam = "opam"
 	// TypeOpkg is a pkg:opkg purl.
 	TypeOpkg = "opkg"
 	// TypePub is a pkg:pub purl.
@@ -99,8 +105,18 @@ const (
 	TypeSwift = "swift"
 	// TypeGooget is pkg:googet purl
 	TypeGooget = "googet"
+	// TypeTerraform is pkg:terraform purl, used for Terraform providers and modules.
+	TypeTerraform = "terraform"
 	// TypeWordpress is pkg:wordpress purl
 	TypeWordpress = "wordpress"
+	// TypePlatformIO is pkg:platformio purl
+	TypePlatformIO = "platformio"
+	// TypeESPIDF is pkg:espidf purl, used for ESP-IDF components.
+	TypeESPIDF = "espidf"
+	// TypeArduino is pkg:arduino purl, used for Arduino libraries.
+	TypeArduino = "arduino"
+	// TypeYocto is pkg:yocto purl, used for packages of Yocto Project images.
+	TypeYocto = "yocto"
 )
 
 // PackageURL is the struct representation of the parts that make a package url.
@@ -167,42 +183,50 @@ func FromString(purl string) (PackageURL, error) {
 
 func validType(t string) bool {
 	types := map[string]bool{
-		TypeAlpm:      true,
-		TypeApk:       true,
-		TypeBitbucket: true,
-		TypeBrew:      true,
-		TypeCargo:     true,
-		TypeCocoapods: true,
-		TypeComposer:  true,
-		TypeConan:     true,
-		TypeConda:     true,
-		TypeCOS:       true,
-		TypeCran:      true,
-		TypeDebian:    true,
-		TypePacman:    true,
-		TypeDocker:    true,
-		TypeFlatpak:   true,
-		TypeGem:       true,
-		TypeGeneric:   true,
-		TypeGithub:    true,
-		TypeGolang:    true,
-		TypeHackage:   true,
-		TypeHaskell:   true,
-		TypeHex:       true,
-		TypeMacApps:   true,
-		TypeMaven:     true,
-		TypeNix:       true,
-		TypeNPM:       true,
-		TypeNuget:     true,
-		TypeOCI:       true,
-		TypeOpkg:      true,
-		TypePub:       true,
-		TypePortage:   true,
-		TypePyPi:      true,
-		TypeRPM:       true,
-		TypeSwift:     true,
-		TypeGooget:    true,
-		TypeWordpress: true,
+		TypeAlpm:         true,
+		TypeApk:          true,
+		TypeBioconductor: true,
+		TypeBitbucket:    true,
+		TypeBrew:         true,
+		TypeCargo:        true,
+		TypeCocoapods:    true,
+		TypeComposer:     true,
+		TypeConan:        true,
+		TypeConda:        true,
+		TypeCOS:          true,
+		TypeCran:         true,
+		TypeDebian:       true,
+		TypePacman:       true,
+		TypeDocker:       true,
+		TypeFlatpak:      true,
+		TypeGem:          true,
+		TypeGeneric:      true,
+		TypeGithub:       true,
+		TypeGolang:       true,
+		TypeHackage:      true,
+		TypeHaskell:      true,
+		TypeHex:          true,
+		TypeJulia:        true,
+		TypeMacApps:      true,
+		TypeMaven:        true,
+		TypeNix:          true,
+		TypeNPM:          true,
+		TypeNuget:        true,
+		TypeOCI:          true,
+		TypeOpam:         true,
+		TypeOpkg:         true,
+		TypePub:          true,
+		TypePortage:      true,
+		TypePyPi:         true,
+		TypeRPM:          true,
+		TypeSwift:        true,
+		TypeGooget:       true,
+		TypeTerraform:    true,
+		TypeWordpress:    true,
+		TypePlatformIO:   true,
+		TypeESPIDF:       true,
+		TypeArduino:      true,
+		TypeYocto:        true,
 	}
 
 	// purl type is case-insensitive, canonical form is lower-case
@@ -224,4 +248,5 @@ const (
 	PackageDependencies = "packagedependencies"
 	Classifier          = "classifier" // Maven specific qualifier
 	Type                = "type"       // Maven specific qualifier
+	UUID                = "uuid"       // Julia specific qualifier
 )
@@ -0,0 +1,199 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package baseline records the security findings of a scan in a baseline file
+// and removes them from the results of later scans, so that a repository with
+// existing findings only gets reported the ones introduced afterwards.
+//
+// Findings are identified by fingerprints derived from the advisory and the
+// location of the finding, not from details such as the package version, so
+// they still match after unrelated changes.
+package baseline
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/inventory/fingerprint"
+)
+
+// FormatVersion is the version of the baseline file format.
+const FormatVersion = 1
+
+// Kind is the type of a baselined finding.
+type Kind string
+
+// Kind values.
+const (
+	KindPackageVuln    Kind = "package_vuln"
+	KindGenericFinding Kind = "generic_finding"
+	KindSecret         Kind = "secret"
+)
+
+// Entry is a single baselined finding. Only the fingerprint is used for
+// matching, the other fields make the file reviewable.
+type Entry struct {
+	Fingerprint string `json:"fingerprint"`
+	Kind        Kind   `json:"kind"`
+	// ID of the vulnerability or advisory, or the type of the secret.
+	ID string `json:"id"`
+	// Package the vulnerability was found in, e.g. "lodash".
+	Package string `json:"package,omitempty"`
+	// Location of the finding, e.g. the lockfile of the package.
+	Location string `json:"location,omitempty"`
+}
+
+// Baseline is the content of a baseline file.
+type Baseline struct {
+	Version int      `json:"version"`
+	Entries []*Entry `json:"entries"`
+
+	// fingerprints is the set of fingerprints of the entries, built lazily.
+	fingerprints map[string]bool
+}
+
+// New returns a baseline of the findings in the inventory.
+func New(inv *inventory.Inventory) *Baseline {
+	b := &Baseline{Version: FormatVersion}
+	seen := map[string]bool{}
+	add := func(e *Entry) {
+		if !seen[e.Fingerprint] {
+			seen[e.Fingerprint] = true
+			b.Entries = append(b.Entries, e)
+		}
+	}
+	for _, v := range inv.PackageVulns {
+		add(packageVulnEntry(v))
+	}
+	for _, f := range inv.GenericFindings {
+		add(genericFindingEntry(f))
+	}
+	for _, s := range inv.Secrets {
+		add(secretEntry(s))
+	}
+	// Keep the file stable across scans to minimize diffs.
+	slices.SortFunc(b.Entries, func(a, b *Entry) int {
+		return strings.Compare(a.Fingerprint, b.Fingerprint)
+	})
+	return b
+}
+
+// Read parses a baseline file.
+func Read(r io.Reader) (*Baseline, error) {
+	b := &Baseline{}
+	if err := json.NewDecoder(r).Decode(b); err != nil {
+		return nil, fmt.Errorf("parsing baseline: %w", err)
+	}
+	if b.Version != FormatVersion {
+		return nil, fmt.Errorf("unsupported baseline version %d, want %d", b.Version, FormatVersion)
+	}
+	for _, e := range b.Entries {
+		if e == nil || e.Fingerprint == "" {
+			return nil, errors.New("baseline entry without fingerprint")
+		}
+	}
+	return b, nil
+}
+
+// ReadFile parses the baseline file at the given path.
+func ReadFile(path string) (*Baseline, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Read(f)
+}
+
+// Write writes the baseline as indented JSON.
+func (b *Baseline) Write(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(b)
+}
+
+// Filter removes the baselined findings from the inventory and returns how
+// many were removed.
+func (b *Baseline) Filter(inv *inventory.Inventory) int {
+	if b.fingerprints == nil {
+		b.fingerprints = make(map[string]bool, len(b.Entries))
+		for _, e := range b.Entries {
+			b.fingerprints[e.Fingerprint] = true
+		}
+	}
+	n := len(inv.PackageVulns) + len(inv.GenericFindings) + len(inv.Secrets)
+	inv.PackageVulns = slices.DeleteFunc(inv.PackageVulns, func(v *inventory.PackageVuln) bool {
+		return b.fingerprints[PackageVulnFingerprint(v)]
+	})
+	inv.GenericFindings = slices.DeleteFunc(inv.GenericFindings, func(f *inventory.GenericFinding) bool {
+		return b.fingerprints[GenericFindingFingerprint(f)]
+	})
+	inv.Secrets = slices.DeleteFunc(inv.Secrets, func(s *inventory.Secret) bool {
+		return b.fingerprints[SecretFingerprint(s)]
+	})
+	return n - len(inv.PackageVulns) - len(inv.GenericFindings) - len(inv.Secrets)
+}
+
+// PackageVulnFingerprint returns the fingerprint of a package vulnerability.
+// It's independent of the package version so that a baselined vulnerability
+// stays baselined after an upgrade that doesn't fix it.
+func PackageVulnFingerprint(v *inventory.PackageVuln) string {
+	return fingerprint.PackageVuln(v)
+}
+
+// GenericFindingFingerprint returns the fingerprint of a generic finding.
+func GenericFindingFingerprint(f *inventory.GenericFinding) string {
+	return fingerprint.GenericFinding(f)
+}
+
+// SecretFingerprint returns the fingerprint of a secret. Different secrets in
+// the same file have different fingerprints. The secret can't be recovered
+// from it.
+func SecretFingerprint(s *inventory.Secret) string {
+	return fingerprint.Secret(s)
+}
+
+func packageVulnEntry(v *inventory.PackageVuln) *Entry {
+	e := &Entry{Kind: KindPackageVuln, ID: v.ID, Fingerprint: fingerprint.PackageVuln(v)}
+	if p := v.Package; p != nil {
+		e.Package = p.Name
+		if len(p.Locations) > 0 {
+			e.Location = p.Locations[0]
+		}
+	}
+	return e
+}
+
+func genericFindingEntry(f *inventory.GenericFinding) *Entry {
+	return &Entry{
+		Kind:        KindGenericFinding,
+		ID:          fingerprint.GenericFindingID(f),
+		Fingerprint: fingerprint.GenericFinding(f),
+	}
+}
+
+func secretEntry(s *inventory.Secret) *Entry {
+	return &Entry{
+		Kind:        KindSecret,
+		ID:          fingerprint.SecretType(s),
+		Location:    s.Location,
+		Fingerprint: fingerprint.Secret(s),
+	}
+}
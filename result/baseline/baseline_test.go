@@ -0,0 +1,187 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package baseline_test
+
+import (
+	"bytes"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scalibr/result/baseline"
+	"github.com/google/osv-scalibr/veles/velestest"
+	"github.com/ossf/osv-schema/bindings/go/osvschema"
+)
+
+func npmPkg(name, version, location string) *extractor.Package {
+	return &extractor.Package{
+		Name:      name,
+		Version:   version,
+		PURLType:  purl.TypeNPM,
+		Locations: []string{location},
+	}
+}
+
+func vuln(id string, pkg *extractor.Package) *inventory.PackageVuln {
+	return &inventory.PackageVuln{Vulnerability: osvschema.Vulnerability{ID: id}, Package: pkg}
+}
+
+func finding(ref, extra string) *inventory.GenericFinding {
+	return &inventory.GenericFinding{
+		Adv:    &inventory.GenericFindingAdvisory{ID: &inventory.AdvisoryID{Publisher: "SCALIBR", Reference: ref}},
+		Target: &inventory.GenericFindingTargetDetails{Extra: extra},
+	}
+}
+
+func secret(value, location string) *inventory.Secret {
+	return &inventory.Secret{Secret: velestest.NewFakeStringSecret(value), Location: location}
+}
+
+func TestFilter(t *testing.T) {
+	lodash := npmPkg("lodash", "4.17.20", "package-lock.json")
+	lodashUpgraded := npmPkg("lodash", "4.17.21", "package-lock.json")
+	lodashOther := npmPkg("lodash", "4.17.20", "other/package-lock.json")
+	weakCreds := finding("weak-credentials", "user root")
+
+	base := &inventory.Inventory{
+		PackageVulns:    []*inventory.PackageVuln{vuln("GHSA-1", lodash)},
+		GenericFindings: []*inventory.GenericFinding{weakCreds},
+		Secrets:         []*inventory.Secret{secret("FOO", "config.json")},
+	}
+	tests := []struct {
+		desc        string
+		inv         *inventory.Inventory
+		want        *inventory.Inventory
+		wantRemoved int
+	}{
+		{
+			desc:        "same_findings",
+			inv:         &inventory.Inventory{PackageVulns: slices.Clone(base.PackageVulns), GenericFindings: slices.Clone(base.GenericFindings), Secrets: slices.Clone(base.Secrets)},
+			want:        &inventory.Inventory{PackageVulns: []*inventory.PackageVuln{}, GenericFindings: []*inventory.GenericFinding{}, Secrets: []*inventory.Secret{}},
+			wantRemoved: 3,
+		},
+		{
+			desc: "vuln_in_upgraded_package",
+			inv:  &inventory.Inventory{PackageVulns: []*inventory.PackageVuln{vuln("GHSA-1", lodashUpgraded)}},
+			want: &inventory.Inventory{PackageVulns: []*inventory.PackageVuln{}},
+			// The vulnerability is still the same one.
+			wantRemoved: 1,
+		},
+		{
+			desc: "new_vulns",
+			inv:  &inventory.Inventory{PackageVulns: []*inventory.PackageVuln{vuln("GHSA-2", lodash), vuln("GHSA-1", lodashOther)}},
+			want: &inventory.Inventory{PackageVulns: []*inventory.PackageVuln{vuln("GHSA-2", lodash), vuln("GHSA-1", lodashOther)}},
+		},
+		{
+			desc: "new_finding",
+			inv:  &inventory.Inventory{GenericFindings: []*inventory.GenericFinding{finding("weak-credentials", "user admin")}},
+			want: &inventory.Inventory{GenericFindings: []*inventory.GenericFinding{finding("weak-credentials", "user admin")}},
+		},
+		{
+			desc: "new_secrets",
+			inv:  &inventory.Inventory{Secrets: []*inventory.Secret{secret("BAR", "config.json"), secret("FOO", "other.json")}},
+			want: &inventory.Inventory{Secrets: []*inventory.Secret{secret("BAR", "config.json"), secret("FOO", "other.json")}},
+		},
+		{
+			desc:        "packages_are_kept",
+			inv:         &inventory.Inventory{Packages: []*extractor.Package{lodash}, PackageVulns: []*inventory.PackageVuln{vuln("GHSA-1", lodash)}},
+			want:        &inventory.Inventory{Packages: []*extractor.Package{lodash}, PackageVulns: []*inventory.PackageVuln{}},
+			wantRemoved: 1,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			// Round-trip the baseline through its file format.
+			var buf bytes.Buffer
+			if err := baseline.New(base).Write(&buf); err != nil {
+				t.Fatalf("Write(): %v", err)
+			}
+			b, err := baseline.Read(&buf)
+			if err != nil {
+				t.Fatalf("Read(): %v", err)
+			}
+
+			if got := b.Filter(tc.inv); got != tc.wantRemoved {
+				t.Errorf("Filter() removed %d findings, want %d", got, tc.wantRemoved)
+			}
+			if diff := cmp.Diff(tc.want, tc.inv); diff != "" {
+				t.Errorf("Filter() unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	lodash := npmPkg("lodash", "4.17.20", "package-lock.json")
+	inv := &inventory.Inventory{
+		PackageVulns: []*inventory.PackageVuln{vuln("GHSA-1", lodash), vuln("GHSA-1", lodash)},
+		Secrets:      []*inventory.Secret{secret("FOO", "config.json")},
+	}
+	want := &baseline.Baseline{
+		Version: baseline.FormatVersion,
+		Entries: []*baseline.Entry{
+			{
+				Fingerprint: baseline.PackageVulnFingerprint(inv.PackageVulns[0]),
+				Kind:        baseline.KindPackageVuln,
+				ID:          "GHSA-1",
+				Package:     "lodash",
+				Location:    "package-lock.json",
+			},
+			{
+				Fingerprint: baseline.SecretFingerprint(inv.Secrets[0]),
+				Kind:        baseline.KindSecret,
+				ID:          "velestest.FakeStringSecret",
+				Location:    "config.json",
+			},
+		},
+	}
+	if want.Entries[0].Fingerprint > want.Entries[1].Fingerprint {
+		want.Entries[0], want.Entries[1] = want.Entries[1], want.Entries[0]
+	}
+	got := baseline.New(inv)
+	if diff := cmp.Diff(want, got, cmp.AllowUnexported(baseline.Baseline{})); diff != "" {
+		t.Errorf("New() unexpected diff (-want +got):\n%s", diff)
+	}
+
+	var buf bytes.Buffer
+	if err := got.Write(&buf); err != nil {
+		t.Fatalf("Write(): %v", err)
+	}
+	if strings.Contains(buf.String(), "FOO") {
+		t.Errorf("Write() leaked the secret value:\n%s", buf.String())
+	}
+}
+
+func TestRead_Errors(t *testing.T) {
+	tests := []struct {
+		desc  string
+		input string
+	}{
+		{desc: "invalid_json", input: "{"},
+		{desc: "unsupported_version", input: `{"version": 2, "entries": []}`},
+		{desc: "missing_fingerprint", input: `{"version": 1, "entries": [{"kind": "secret"}]}`},
+	}
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			if _, err := baseline.Read(strings.NewReader(tc.input)); err == nil {
+				t.Errorf("Read(%q) succeeded, want error", tc.input)
+			}
+		})
+	}
+}
@@ -0,0 +1,218 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package diff compares the inventories of two scans, e.g. of the base and the
+// head commit of a pull request, and reports the packages and security
+// findings that changed between them.
+package diff
+
+import (
+	"strings"
+
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/semantic"
+)
+
+// ChangeType describes how the version of a package changed.
+type ChangeType string
+
+// ChangeType values.
+const (
+	// Upgraded means that the new version is higher than the old one.
+	Upgraded ChangeType = "upgraded"
+	// Downgraded means that the new version is lower than the old one.
+	Downgraded ChangeType = "downgraded"
+	// Changed means that the versions can't be compared, e.g. because the
+	// ecosystem doesn't define a version ordering.
+	Changed ChangeType = "changed"
+)
+
+// PackageChange is a package whose version changed between the scans.
+type PackageChange struct {
+	Base *extractor.Package
+	Head *extractor.Package
+	Type ChangeType
+}
+
+// Result holds the differences between two inventories.
+type Result struct {
+	// Packages only found in the head inventory.
+	AddedPackages []*extractor.Package
+	// Packages only found in the base inventory.
+	RemovedPackages []*extractor.Package
+	// Packages found in both inventories with different versions.
+	ChangedPackages []*PackageChange
+	// Findings only found in the head inventory.
+	NewFindings []*inventory.GenericFinding
+	// Findings only found in the base inventory.
+	FixedFindings []*inventory.GenericFinding
+	// Package vulnerabilities only found in the head inventory.
+	NewPackageVulns []*inventory.PackageVuln
+	// Package vulnerabilities only found in the base inventory.
+	FixedPackageVulns []*inventory.PackageVuln
+}
+
+// IsEmpty returns true if the inventories had no differences.
+func (r *Result) IsEmpty() bool {
+	return len(r.AddedPackages) == 0 && len(r.RemovedPackages) == 0 && len(r.ChangedPackages) == 0 &&
+		len(r.NewFindings) == 0 && len(r.FixedFindings) == 0 &&
+		len(r.NewPackageVulns) == 0 && len(r.FixedPackageVulns) == 0
+}
+
+// pkgKey identifies the same package across scans, independently of its version.
+type pkgKey struct {
+	purlType string
+	name     string
+	location string
+}
+
+func keyOf(pkg *extractor.Package) pkgKey {
+	k := pkgKey{purlType: pkg.PURLType, name: pkg.Name}
+	if len(pkg.Locations) > 0 {
+		k.location = pkg.Locations[0]
+	}
+	return k
+}
+
+// Compare returns the differences between the base and the head inventory.
+// Packages are matched by their type, name and first location so that a
+// version bump shows up as a change instead of a removal and an addition.
+func Compare(base, head *inventory.Inventory) *Result {
+	r := &Result{}
+	r.comparePackages(base.Packages, head.Packages)
+
+	r.FixedFindings, r.NewFindings = compareByKey(base.GenericFindings, head.GenericFindings, findingKey)
+	r.FixedPackageVulns, r.NewPackageVulns = compareByKey(base.PackageVulns, head.PackageVulns, packageVulnKey)
+	return r
+}
+
+func (r *Result) comparePackages(base, head []*extractor.Package) {
+	var keys []pkgKey
+	baseByKey := map[pkgKey][]*extractor.Package{}
+	headByKey := map[pkgKey][]*extractor.Package{}
+	for _, pkg := range base {
+		k := keyOf(pkg)
+		if _, ok := baseByKey[k]; !ok {
+			keys = append(keys, k)
+		}
+		baseByKey[k] = append(baseByKey[k], pkg)
+	}
+	for _, pkg := range head {
+		k := keyOf(pkg)
+		_, inBase := baseByKey[k]
+		_, inHead := headByKey[k]
+		if !inBase && !inHead {
+			keys = append(keys, k)
+		}
+		headByKey[k] = append(headByKey[k], pkg)
+	}
+
+	for _, k := range keys {
+		removed, added := removeSameVersions(baseByKey[k], headByKey[k])
+		if len(removed) == 1 && len(added) == 1 {
+			r.ChangedPackages = append(r.ChangedPackages, &PackageChange{
+				Base: removed[0],
+				Head: added[0],
+				Type: changeType(removed[0], added[0]),
+			})
+			continue
+		}
+		r.RemovedPackages = append(r.RemovedPackages, removed...)
+		r.AddedPackages = append(r.AddedPackages, added...)
+	}
+}
+
+// removeSameVersions returns the packages whose version isn't in the other list.
+func removeSameVersions(base, head []*extractor.Package) (removed, added []*extractor.Package) {
+	matched := make([]bool, len(base))
+	for _, h := range head {
+		found := false
+		for i, b := range base {
+			if !matched[i] && b.Version == h.Version {
+				matched[i] = true
+				found = true
+				break
+			}
+		}
+		if !found {
+			added = append(added, h)
+		}
+	}
+	for i, b := range base {
+		if !matched[i] {
+			removed = append(removed, b)
+		}
+	}
+	return removed, added
+}
+
+func changeType(base, head *extractor.Package) ChangeType {
+	// Strip the ecosystem version suffix, e.g. "Debian:12".
+	ecosystem, _, _ := strings.Cut(head.Ecosystem(), ":")
+	v, err := semantic.Parse(head.Version, ecosystem)
+	if err != nil {
+		return Changed
+	}
+	cmp, err := v.CompareStr(base.Version)
+	switch {
+	case err != nil || cmp == 0:
+		return Changed
+	case cmp > 0:
+		return Upgraded
+	default:
+		return Downgraded
+	}
+}
+
+// compareByKey returns the elements that are only in base and only in head.
+func compareByKey[T any](base, head []T, key func(T) string) (onlyBase, onlyHead []T) {
+	baseKeys := map[string]bool{}
+	for _, b := range base {
+		baseKeys[key(b)] = true
+	}
+	headKeys := map[string]bool{}
+	for _, h := range head {
+		k := key(h)
+		headKeys[k] = true
+		if !baseKeys[k] {
+			onlyHead = append(onlyHead, h)
+		}
+	}
+	for _, b := range base {
+		if !headKeys[key(b)] {
+			onlyBase = append(onlyBase, b)
+		}
+	}
+	return onlyBase, onlyHead
+}
+
+func findingKey(f *inventory.GenericFinding) string {
+	var parts []string
+	if f.Adv != nil && f.Adv.ID != nil {
+		parts = append(parts, f.Adv.ID.Publisher, f.Adv.ID.Reference)
+	}
+	if f.Target != nil {
+		parts = append(parts, f.Target.Extra)
+	}
+	return strings.Join(parts, "\x00")
+}
+
+func packageVulnKey(v *inventory.PackageVuln) string {
+	k := pkgKey{}
+	if v.Package != nil {
+		k = keyOf(v.Package)
+	}
+	return strings.Join([]string{v.ID, k.purlType, k.name, k.location}, "\x00")
+}
@@ -0,0 +1,140 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diff_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scalibr/result/diff"
+	"github.com/ossf/osv-schema/bindings/go/osvschema"
+)
+
+func npmPkg(name, version, location string) *extractor.Package {
+	return &extractor.Package{
+		Name:      name,
+		Version:   version,
+		PURLType:  purl.TypeNPM,
+		Locations: []string{location},
+	}
+}
+
+func finding(ref, extra string) *inventory.GenericFinding {
+	return &inventory.GenericFinding{
+		Adv:    &inventory.GenericFindingAdvisory{ID: &inventory.AdvisoryID{Publisher: "SCALIBR", Reference: ref}},
+		Target: &inventory.GenericFindingTargetDetails{Extra: extra},
+	}
+}
+
+func TestCompare(t *testing.T) {
+	lodashOld := npmPkg("lodash", "4.17.20", "package-lock.json")
+	lodashNew := npmPkg("lodash", "4.17.21", "package-lock.json")
+	reactOld := npmPkg("react", "18.2.0", "package-lock.json")
+	reactNew := npmPkg("react", "17.0.2", "package-lock.json")
+	left := npmPkg("left-pad", "1.3.0", "package-lock.json")
+	chalk := npmPkg("chalk", "5.3.0", "package-lock.json")
+	chalkOther := npmPkg("chalk", "5.3.0", "other/package-lock.json")
+	genericOld := &extractor.Package{Name: "foo", Version: "abc", PURLType: purl.TypeGeneric, Locations: []string{"foo"}}
+	genericNew := &extractor.Package{Name: "foo", Version: "def", PURLType: purl.TypeGeneric, Locations: []string{"foo"}}
+	weakCreds := finding("weak-credentials", "user root")
+	weakCredsOther := finding("weak-credentials", "user admin")
+	cis := finding("cis-benchmark", "")
+	vulnOld := &inventory.PackageVuln{Vulnerability: osvschema.Vulnerability{ID: "GHSA-1"}, Package: lodashOld}
+	vulnOldOnNew := &inventory.PackageVuln{Vulnerability: osvschema.Vulnerability{ID: "GHSA-1"}, Package: lodashNew}
+	vulnNew := &inventory.PackageVuln{Vulnerability: osvschema.Vulnerability{ID: "GHSA-2"}, Package: reactNew}
+
+	tests := []struct {
+		desc string
+		base *inventory.Inventory
+		head *inventory.Inventory
+		want *diff.Result
+	}{
+		{
+			desc: "empty",
+			base: &inventory.Inventory{},
+			head: &inventory.Inventory{},
+			want: &diff.Result{},
+		},
+		{
+			desc: "same_inventory",
+			base: &inventory.Inventory{Packages: []*extractor.Package{lodashOld, chalk}, GenericFindings: []*inventory.GenericFinding{cis}},
+			head: &inventory.Inventory{Packages: []*extractor.Package{lodashOld, chalk}, GenericFindings: []*inventory.GenericFinding{cis}},
+			want: &diff.Result{},
+		},
+		{
+			desc: "added_and_removed_packages",
+			base: &inventory.Inventory{Packages: []*extractor.Package{left, chalk}},
+			head: &inventory.Inventory{Packages: []*extractor.Package{chalk, chalkOther}},
+			want: &diff.Result{
+				AddedPackages:   []*extractor.Package{chalkOther},
+				RemovedPackages: []*extractor.Package{left},
+			},
+		},
+		{
+			desc: "changed_versions",
+			base: &inventory.Inventory{Packages: []*extractor.Package{lodashOld, reactOld, genericOld}},
+			head: &inventory.Inventory{Packages: []*extractor.Package{genericNew, reactNew, lodashNew}},
+			want: &diff.Result{
+				ChangedPackages: []*diff.PackageChange{
+					{Base: lodashOld, Head: lodashNew, Type: diff.Upgraded},
+					{Base: reactOld, Head: reactNew, Type: diff.Downgraded},
+					{Base: genericOld, Head: genericNew, Type: diff.Changed},
+				},
+			},
+		},
+		{
+			desc: "ambiguous_versions_are_added_and_removed",
+			base: &inventory.Inventory{Packages: []*extractor.Package{lodashOld}},
+			head: &inventory.Inventory{Packages: []*extractor.Package{lodashNew, npmPkg("lodash", "4.17.15", "package-lock.json")}},
+			want: &diff.Result{
+				AddedPackages:   []*extractor.Package{lodashNew, npmPkg("lodash", "4.17.15", "package-lock.json")},
+				RemovedPackages: []*extractor.Package{lodashOld},
+			},
+		},
+		{
+			desc: "new_and_fixed_findings",
+			base: &inventory.Inventory{GenericFindings: []*inventory.GenericFinding{weakCreds, cis}},
+			head: &inventory.Inventory{GenericFindings: []*inventory.GenericFinding{cis, weakCredsOther}},
+			want: &diff.Result{
+				NewFindings:   []*inventory.GenericFinding{weakCredsOther},
+				FixedFindings: []*inventory.GenericFinding{weakCreds},
+			},
+		},
+		{
+			desc: "package_vulns",
+			base: &inventory.Inventory{PackageVulns: []*inventory.PackageVuln{vulnOld}},
+			head: &inventory.Inventory{PackageVulns: []*inventory.PackageVuln{vulnOldOnNew, vulnNew}},
+			want: &diff.Result{
+				// The vuln is still present after the version bump.
+				NewPackageVulns: []*inventory.PackageVuln{vulnNew},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := diff.Compare(tc.base, tc.head)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Compare() returned unexpected diff (-want +got):\n%s", diff)
+			}
+			if got.IsEmpty() != cmp.Equal(tc.want, &diff.Result{}) {
+				t.Errorf("Compare().IsEmpty() = %v, want %v", got.IsEmpty(), !got.IsEmpty())
+			}
+		})
+	}
+}
@@ -0,0 +1,154 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package result
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/google/osv-scalibr/plugin"
+)
+
+// Merge combines the results of several scans of disjoint parts of the same
+// target, e.g. the shards of a distributed filesystem scan, into a single
+// result. The inventories are concatenated: Use the dedup package to merge
+// packages that were found by more than one scan.
+//
+// The merged scan and plugin statuses are the worst of the individual ones,
+// the failure reasons are joined and the error counts summed. Resource usage
+// is summed, except for the peak memory which is the highest one. Scan roots
+// that several scans share are only listed once.
+func Merge(results ...*ScanResult) *ScanResult {
+	merged := &ScanResult{}
+	var statuses []*plugin.ScanStatus
+	pluginStatuses := map[string][]*plugin.ScanStatus{}
+	for _, r := range results {
+		if r == nil {
+			continue
+		}
+		if merged.Version == "" {
+			merged.Version = r.Version
+		}
+		if merged.StartTime.IsZero() || r.StartTime.Before(merged.StartTime) {
+			merged.StartTime = r.StartTime
+		}
+		if r.EndTime.After(merged.EndTime) {
+			merged.EndTime = r.EndTime
+		}
+		if r.Status != nil {
+			statuses = append(statuses, r.Status)
+		}
+		for _, s := range r.PluginStatus {
+			if _, ok := pluginStatuses[s.Name]; !ok {
+				merged.PluginStatus = append(merged.PluginStatus, &plugin.Status{Name: s.Name, Version: s.Version})
+			}
+			pluginStatuses[s.Name] = append(pluginStatuses[s.Name], s.Status)
+		}
+		merged.Inventory.Append(r.Inventory)
+		merged.ResourceUsage = mergeResourceUsage(merged.ResourceUsage, r.ResourceUsage)
+		for _, root := range r.ScanRoots {
+			if !slices.ContainsFunc(merged.ScanRoots, func(m *ScanRoot) bool { return *m == *root }) {
+				merged.ScanRoots = append(merged.ScanRoots, root)
+			}
+		}
+	}
+
+	merged.Status = mergeStatuses(statuses)
+	for _, s := range merged.PluginStatus {
+		s.Status = mergeStatuses(pluginStatuses[s.Name])
+	}
+	return merged
+}
+
+// mergeResourceUsage adds the resource usage of a scan to the merged one.
+func mergeResourceUsage(merged, u *ResourceUsage) *ResourceUsage {
+	if u == nil {
+		return merged
+	}
+	if merged == nil {
+		merged = &ResourceUsage{}
+	}
+	merged.PeakMemoryBytes = max(merged.PeakMemoryBytes, u.PeakMemoryBytes)
+	merged.CPUTime += u.CPUTime
+	merged.FilesOpened += u.FilesOpened
+	merged.BytesRead += u.BytesRead
+	for _, p := range u.Plugins {
+		i, found := slices.BinarySearchFunc(merged.Plugins, p.Name, func(m *PluginResourceUsage, name string) int {
+			return strings.Compare(m.Name, name)
+		})
+		if !found {
+			merged.Plugins = slices.Insert(merged.Plugins, i, &PluginResourceUsage{Name: p.Name})
+		}
+		m := merged.Plugins[i]
+		m.WallTime += p.WallTime
+		m.CPUTime += p.CPUTime
+		m.FilesExtracted += p.FilesExtracted
+		m.BytesRead += p.BytesRead
+		m.AllocatedBytes += p.AllocatedBytes
+	}
+	return merged
+}
+
+// mergeStatuses returns the worst of the given statuses.
+func mergeStatuses(statuses []*plugin.ScanStatus) *plugin.ScanStatus {
+	merged := &plugin.ScanStatus{Status: plugin.ScanStatusUnspecified}
+	var reasons []string
+	for _, s := range statuses {
+		if s == nil {
+			continue
+		}
+		if severity(s.Status) > severity(merged.Status) {
+			merged.Status = s.Status
+		}
+		if s.FailureReason != "" {
+			reasons = append(reasons, s.FailureReason)
+		}
+		merged.ErrorCounts = mergeErrorCounts(merged.ErrorCounts, s.ErrorCounts)
+	}
+	merged.FailureReason = strings.Join(reasons, "\n")
+	return merged
+}
+
+// mergeErrorCounts adds the error counts of a status to the merged ones,
+// keeping them ordered by category.
+func mergeErrorCounts(merged, counts []*plugin.ErrorCount) []*plugin.ErrorCount {
+	for _, c := range counts {
+		i, found := slices.BinarySearchFunc(merged, c.Category, func(m *plugin.ErrorCount, cat plugin.ErrorCategory) int {
+			return int(m.Category - cat)
+		})
+		if found {
+			merged[i].Count += c.Count
+		} else {
+			merged = slices.Insert(merged, i, &plugin.ErrorCount{Category: c.Category, Count: c.Count})
+		}
+	}
+	return merged
+}
+
+// severity orders the scan statuses from best to worst.
+func severity(s plugin.ScanStatusEnum) int {
+	switch s {
+	case plugin.ScanStatusSucceeded:
+		return 1
+	case plugin.ScanStatusPartiallySucceeded:
+		return 2
+	case plugin.ScanStatusInterrupted:
+		return 3
+	case plugin.ScanStatusFailed:
+		return 4
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,148 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package result_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/plugin"
+	"github.com/google/osv-scalibr/result"
+)
+
+func TestMerge(t *testing.T) {
+	t1 := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	t2 := t1.Add(time.Minute)
+	t3 := t1.Add(2 * time.Minute)
+	t4 := t1.Add(3 * time.Minute)
+	succeeded := &plugin.ScanStatus{Status: plugin.ScanStatusSucceeded}
+	pkgA := &extractor.Package{Name: "a", Locations: []string{"a/package.json"}}
+	pkgB := &extractor.Package{Name: "b", Locations: []string{"b/package.json"}}
+
+	testCases := []struct {
+		desc    string
+		results []*result.ScanResult
+		want    *result.ScanResult
+	}{
+		{
+			desc: "no_results",
+			want: &result.ScanResult{
+				Status: &plugin.ScanStatus{},
+			},
+		},
+		{
+			desc: "shards",
+			results: []*result.ScanResult{
+				{
+					Version:   "1.0.0",
+					StartTime: t2,
+					EndTime:   t3,
+					Status:    succeeded,
+					PluginStatus: []*plugin.Status{
+						{Name: "javascript/packagejson", Version: 1, Status: succeeded},
+						{Name: "python/wheelegg", Version: 2, Status: &plugin.ScanStatus{
+							Status:        plugin.ScanStatusPartiallySucceeded,
+							FailureReason: "slow wheel",
+							ErrorCounts: []*plugin.ErrorCount{
+								{Category: plugin.ErrorCategoryParse, Count: 1},
+								{Category: plugin.ErrorCategoryTimeout, Count: 1},
+							},
+						}},
+					},
+					Inventory: inventory.Inventory{Packages: []*extractor.Package{pkgA}},
+					ResourceUsage: &result.ResourceUsage{
+						PeakMemoryBytes: 100, CPUTime: time.Second, FilesOpened: 3, BytesRead: 1000,
+						Plugins: []*result.PluginResourceUsage{
+							{Name: "python/wheelegg", WallTime: time.Second, FilesExtracted: 3, BytesRead: 1000},
+						},
+					},
+					ScanRoots: []*result.ScanRoot{{Path: "/scan", CanonicalPath: "/mnt/scan"}},
+				},
+				nil,
+				{
+					Version:   "1.0.0",
+					StartTime: t1,
+					EndTime:   t4,
+					Status:    &plugin.ScanStatus{Status: plugin.ScanStatusPartiallySucceeded, FailureReason: "plugin failed"},
+					PluginStatus: []*plugin.Status{
+						{Name: "javascript/packagejson", Version: 1, Status: succeeded},
+						{Name: "python/wheelegg", Version: 2, Status: &plugin.ScanStatus{
+							Status:        plugin.ScanStatusFailed,
+							FailureReason: "bad wheel",
+							ErrorCounts: []*plugin.ErrorCount{
+								{Category: plugin.ErrorCategoryPermissionDenied, Count: 1},
+								{Category: plugin.ErrorCategoryParse, Count: 2},
+							},
+						}},
+					},
+					Inventory: inventory.Inventory{Packages: []*extractor.Package{pkgB}},
+					ResourceUsage: &result.ResourceUsage{
+						PeakMemoryBytes: 200, CPUTime: 2 * time.Second, FilesOpened: 4, BytesRead: 500,
+						Plugins: []*result.PluginResourceUsage{
+							{Name: "cve/cve-2023-38408", WallTime: time.Second, AllocatedBytes: 100},
+							{Name: "python/wheelegg", WallTime: 2 * time.Second, FilesExtracted: 4, BytesRead: 500},
+						},
+					},
+					ScanRoots: []*result.ScanRoot{
+						{Path: "/scan", CanonicalPath: "/mnt/scan"},
+						{Path: "/other", CanonicalPath: "/other"},
+					},
+				},
+			},
+			want: &result.ScanResult{
+				Version:   "1.0.0",
+				StartTime: t1,
+				EndTime:   t4,
+				Status:    &plugin.ScanStatus{Status: plugin.ScanStatusPartiallySucceeded, FailureReason: "plugin failed"},
+				PluginStatus: []*plugin.Status{
+					{Name: "javascript/packagejson", Version: 1, Status: succeeded},
+					{Name: "python/wheelegg", Version: 2, Status: &plugin.ScanStatus{
+						Status:        plugin.ScanStatusFailed,
+						FailureReason: "slow wheel\nbad wheel",
+						ErrorCounts: []*plugin.ErrorCount{
+							{Category: plugin.ErrorCategoryPermissionDenied, Count: 1},
+							{Category: plugin.ErrorCategoryParse, Count: 3},
+							{Category: plugin.ErrorCategoryTimeout, Count: 1},
+						},
+					}},
+				},
+				Inventory: inventory.Inventory{Packages: []*extractor.Package{pkgA, pkgB}},
+				ResourceUsage: &result.ResourceUsage{
+					PeakMemoryBytes: 200, CPUTime: 3 * time.Second, FilesOpened: 7, BytesRead: 1500,
+					Plugins: []*result.PluginResourceUsage{
+						{Name: "cve/cve-2023-38408", WallTime: time.Second, AllocatedBytes: 100},
+						{Name: "python/wheelegg", WallTime: 3 * time.Second, FilesExtracted: 7, BytesRead: 1500},
+					},
+				},
+				ScanRoots: []*result.ScanRoot{
+					{Path: "/scan", CanonicalPath: "/mnt/scan"},
+					{Path: "/other", CanonicalPath: "/other"},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := result.Merge(tc.results...)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Merge() returned unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,141 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import "strings"
+
+// licenseBanned returns whether a package with the given license can't be used
+// because of the banned licenses, which are keyed by their lower case SPDX IDs.
+// SPDX expressions are evaluated so that e.g. "MIT OR GPL-3.0-only" is allowed
+// if only GPL-3.0-only is banned. Licenses that aren't valid expressions are
+// banned if any of their words is.
+func licenseBanned(license string, banned map[string]bool) bool {
+	if banned[strings.ToLower(strings.TrimSpace(license))] {
+		return true
+	}
+	e := &licenseEvaluator{tokens: tokenizeLicense(license), banned: banned}
+	if res, ok := e.evalOr(); ok && e.pos == len(e.tokens) {
+		return res
+	}
+	for _, tok := range e.tokens {
+		if banned[strings.ToLower(tok)] {
+			return true
+		}
+	}
+	return false
+}
+
+func tokenizeLicense(license string) []string {
+	license = strings.NewReplacer("(", " ( ", ")", " ) ").Replace(license)
+	return strings.Fields(license)
+}
+
+// licenseEvaluator is a recursive descent evaluator of SPDX license
+// expressions:
+//
+//	or   = and *("OR" and)
+//	and  = with *("AND" with)
+//	with = atom ["WITH" exception-id]
+//	atom = license-id / "(" or ")"
+//
+// Each rule returns whether its part of the expression is banned and whether
+// it could be parsed. Operators are case-insensitive.
+type licenseEvaluator struct {
+	tokens []string
+	pos    int
+	banned map[string]bool
+}
+
+func (e *licenseEvaluator) peek() string {
+	if e.pos >= len(e.tokens) {
+		return ""
+	}
+	return strings.ToUpper(e.tokens[e.pos])
+}
+
+// evalOr returns true if all alternatives are banned.
+func (e *licenseEvaluator) evalOr() (bool, bool) {
+	res, ok := e.evalAnd()
+	if !ok {
+		return false, false
+	}
+	for e.peek() == "OR" {
+		e.pos++
+		b, ok := e.evalAnd()
+		if !ok {
+			return false, false
+		}
+		res = res && b
+	}
+	return res, true
+}
+
+// evalAnd returns true if any of the combined licenses is banned.
+func (e *licenseEvaluator) evalAnd() (bool, bool) {
+	res, ok := e.evalWith()
+	if !ok {
+		return false, false
+	}
+	for e.peek() == "AND" {
+		e.pos++
+		b, ok := e.evalWith()
+		if !ok {
+			return false, false
+		}
+		res = res || b
+	}
+	return res, true
+}
+
+func (e *licenseEvaluator) evalWith() (bool, bool) {
+	res, ok := e.evalAtom()
+	if !ok {
+		return false, false
+	}
+	if e.peek() == "WITH" {
+		e.pos++
+		if !isLicenseID(e.peek()) {
+			return false, false
+		}
+		e.pos++
+	}
+	return res, true
+}
+
+func (e *licenseEvaluator) evalAtom() (bool, bool) {
+	tok := e.peek()
+	if tok == "(" {
+		e.pos++
+		res, ok := e.evalOr()
+		if !ok || e.peek() != ")" {
+			return false, false
+		}
+		e.pos++
+		return res, true
+	}
+	if !isLicenseID(tok) {
+		return false, false
+	}
+	e.pos++
+	return e.banned[strings.ToLower(tok)], true
+}
+
+func isLicenseID(tok string) bool {
+	switch tok {
+	case "", "(", ")", "AND", "OR", "WITH":
+		return false
+	}
+	return true
+}
@@ -0,0 +1,49 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import "testing"
+
+func TestLicenseBanned(t *testing.T) {
+	banned := map[string]bool{"gpl-3.0-only": true, "agpl-3.0-only": true}
+	testCases := []struct {
+		license string
+		want    bool
+	}{
+		{license: "MIT", want: false},
+		{license: "GPL-3.0-only", want: true},
+		{license: "gpl-3.0-only", want: true},
+		{license: "MIT OR GPL-3.0-only", want: false},
+		{license: "GPL-3.0-only OR AGPL-3.0-only", want: true},
+		{license: "MIT AND GPL-3.0-only", want: true},
+		{license: "MIT and (Apache-2.0 or GPL-3.0-only)", want: false},
+		{license: "(MIT OR Apache-2.0) AND AGPL-3.0-only", want: true},
+		{license: "GPL-3.0-only WITH GCC-exception-3.1", want: true},
+		{license: "Apache-2.0 WITH LLVM-exception", want: false},
+		// Not valid expressions.
+		{license: "Dual GPL-3.0-only", want: true},
+		{license: "MIT OR (GPL-3.0-only", want: true},
+		{license: "Apache License 2.0", want: false},
+		{license: "", want: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.license, func(t *testing.T) {
+			if got := licenseBanned(tc.license, banned); got != tc.want {
+				t.Errorf("licenseBanned(%q) = %v, want %v", tc.license, got, tc.want)
+			}
+		})
+	}
+}
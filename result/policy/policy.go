@@ -0,0 +1,270 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package policy evaluates scan results against a declarative policy, e.g. to
+// fail CI builds on severe findings or on packages with banned licenses.
+package policy
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	scalibr "github.com/google/osv-scalibr"
+	"github.com/google/osv-scalibr/detector/misconfig/containerspolicy"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/inventory/fingerprint"
+	"github.com/google/osv-scalibr/inventory/severity"
+	"github.com/google/osv-scalibr/purl"
+	"gopkg.in/yaml.v3"
+)
+
+// signatureVerificationFindingID is the ID of the containerspolicy finding
+// reported when image signature verification is disabled.
+const signatureVerificationFindingID = "SCALIBR/containers-signature-verification-disabled"
+
+// Policy is a set of requirements for the result of a scan. The zero value
+// accepts every result.
+type Policy struct {
+	// Findings with a higher severity violate the policy. SeverityUnspecified
+	// disables the check. Findings with exploitability signals, e.g. from VEX
+	// statements or suppression rules, are ignored.
+	MaxSeverity inventory.SeverityEnum
+	// SPDX IDs of the licenses that packages can't be used under, compared
+	// case-insensitively. A package with a license expression like
+	// "MIT OR GPL-3.0-only" only violates the policy if all alternatives are
+	// banned.
+	BannedLicenses []string
+	// Packages that can't be present.
+	DisallowedPackages []*PackageRule
+	// Requires the scanned system to verify the signatures of the container
+	// images it pulls. Package signatures aren't part of the inventory, so this
+	// is checked with the misconfig/containerspolicy detector, which needs to be
+	// enabled for the scan.
+	RequireSignatureVerification bool
+}
+
+// PackageRule matches the packages that a policy doesn't allow.
+type PackageRule struct {
+	// Package URL of the disallowed packages. The version is only compared if set.
+	PURL purl.PackageURL
+	// Why the packages aren't allowed, added to the violations.
+	Reason string
+}
+
+// Rule is the requirement of a policy that a violation breaks.
+type Rule string
+
+// Rule values.
+const (
+	RuleMaxSeverity           Rule = "max_severity"
+	RuleBannedLicense         Rule = "banned_license"
+	RuleDisallowedPackage     Rule = "disallowed_package"
+	RuleSignatureVerification Rule = "signature_verification"
+)
+
+// Violation describes how a scan result doesn't meet the policy.
+type Violation struct {
+	Rule    Rule   `json:"rule"`
+	Message string `json:"message"`
+	// Fingerprint of the package or finding that violates the policy, if any.
+	Fingerprint string `json:"fingerprint,omitempty"`
+}
+
+// Report is the outcome of evaluating a policy.
+type Report struct {
+	Violations []*Violation `json:"violations"`
+}
+
+// Passed returns whether the scan result meets the policy.
+func (r *Report) Passed() bool {
+	return len(r.Violations) == 0
+}
+
+// policyFile is the format of a policy file, e.g.
+//
+//	max_severity: medium
+//	banned_licenses: [AGPL-3.0-only, SSPL-1.0]
+//	disallowed_packages:
+//	  - purl: pkg:npm/event-stream@3.3.6
+//	    reason: Compromised release.
+//	require_signature_verification: true
+type policyFile struct {
+	MaxSeverity        string   `yaml:"max_severity"`
+	BannedLicenses     []string `yaml:"banned_licenses"`
+	DisallowedPackages []struct {
+		PURL   string `yaml:"purl"`
+		Reason string `yaml:"reason"`
+	} `yaml:"disallowed_packages"`
+	RequireSignatureVerification bool `yaml:"require_signature_verification"`
+}
+
+// Read parses a YAML or JSON policy file. Unknown keys are rejected so that
+// typos don't silently weaken the policy.
+func Read(r io.Reader) (*Policy, error) {
+	dec := yaml.NewDecoder(r)
+	dec.KnownFields(true)
+	var f policyFile
+	if err := dec.Decode(&f); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("parsing policy: %w", err)
+	}
+
+	p := &Policy{
+		BannedLicenses:               f.BannedLicenses,
+		RequireSignatureVerification: f.RequireSignatureVerification,
+	}
+	if f.MaxSeverity != "" {
+		sev, ok := severity.Parse(f.MaxSeverity)
+		if !ok {
+			return nil, fmt.Errorf("max_severity %q not recognized, supported values are minimal, low, medium, high, critical", f.MaxSeverity)
+		}
+		p.MaxSeverity = sev
+	}
+	for i, d := range f.DisallowedPackages {
+		pu, err := purl.FromString(d.PURL)
+		if err != nil {
+			return nil, fmt.Errorf("disallowed_packages[%d]: %w", i, err)
+		}
+		p.DisallowedPackages = append(p.DisallowedPackages, &PackageRule{
+			PURL:   pu,
+			Reason: strings.TrimSpace(d.Reason),
+		})
+	}
+	return p, nil
+}
+
+// ReadFile parses the policy file at the given path.
+func ReadFile(path string) (*Policy, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Read(f)
+}
+
+// Evaluate checks the scan result against the policy.
+func (p *Policy) Evaluate(result *scalibr.ScanResult) *Report {
+	r := &Report{Violations: []*Violation{}}
+	inv := &result.Inventory
+
+	if p.MaxSeverity != inventory.SeverityUnspecified {
+		for _, v := range inv.PackageVulns {
+			if sev := severity.PackageVuln(v); sev > p.MaxSeverity && len(v.ExploitabilitySignals) == 0 {
+				r.add(RuleMaxSeverity, fingerprint.PackageVuln(v),
+					"%s in %s has severity %s, above the maximum of %s",
+					v.ID, packageName(v.Package), severity.Name(sev), severity.Name(p.MaxSeverity))
+			}
+		}
+		for _, f := range inv.GenericFindings {
+			if sev := severity.GenericFinding(f); sev > p.MaxSeverity && len(f.ExploitabilitySignals) == 0 {
+				r.add(RuleMaxSeverity, fingerprint.GenericFinding(f),
+					"%s has severity %s, above the maximum of %s",
+					fingerprint.GenericFindingID(f), severity.Name(sev), severity.Name(p.MaxSeverity))
+			}
+		}
+	}
+
+	if len(p.BannedLicenses) > 0 {
+		banned := make(map[string]bool, len(p.BannedLicenses))
+		for _, l := range p.BannedLicenses {
+			banned[strings.ToLower(l)] = true
+		}
+		for _, pkg := range inv.Packages {
+			for _, l := range pkg.Licenses {
+				if licenseBanned(l, banned) {
+					r.add(RuleBannedLicense, fingerprint.Package(pkg),
+						"%s is licensed under %q, which is banned", packageName(pkg), l)
+				}
+			}
+		}
+	}
+
+	for _, pkg := range inv.Packages {
+		if rule := p.disallowedBy(pkg); rule != nil {
+			msg := packageName(pkg) + " is disallowed"
+			if rule.Reason != "" {
+				msg += ": " + rule.Reason
+			}
+			r.add(RuleDisallowedPackage, fingerprint.Package(pkg), "%s", msg)
+		}
+	}
+
+	if p.RequireSignatureVerification {
+		p.checkSignatureVerification(result, r)
+	}
+	return r
+}
+
+func (p *Policy) checkSignatureVerification(result *scalibr.ScanResult, r *Report) {
+	ran := false
+	for _, s := range result.PluginStatus {
+		if s.Name == containerspolicy.Name {
+			ran = true
+			break
+		}
+	}
+	if !ran {
+		r.add(RuleSignatureVerification, "",
+			"signature verification can't be checked because the %s detector didn't run", containerspolicy.Name)
+		return
+	}
+	for _, f := range result.Inventory.GenericFindings {
+		if fingerprint.GenericFindingID(f) == signatureVerificationFindingID && len(f.ExploitabilitySignals) == 0 {
+			msg := "container image signature verification is disabled"
+			if f.Target != nil && f.Target.Extra != "" {
+				msg += ": " + f.Target.Extra
+			}
+			r.add(RuleSignatureVerification, fingerprint.GenericFinding(f), "%s", msg)
+		}
+	}
+}
+
+func (p *Policy) disallowedBy(pkg *extractor.Package) *PackageRule {
+	pu := pkg.PURL()
+	if pu == nil {
+		return nil
+	}
+	for _, rule := range p.DisallowedPackages {
+		want := rule.PURL
+		if want.Type == pu.Type &&
+			strings.EqualFold(want.Namespace, pu.Namespace) &&
+			strings.EqualFold(want.Name, pu.Name) &&
+			(want.Version == "" || want.Version == pu.Version) {
+			return rule
+		}
+	}
+	return nil
+}
+
+func (r *Report) add(rule Rule, fp string, format string, args ...any) {
+	r.Violations = append(r.Violations, &Violation{
+		Rule:        rule,
+		Message:     fmt.Sprintf(format, args...),
+		Fingerprint: fp,
+	})
+}
+
+func packageName(pkg *extractor.Package) string {
+	if pkg == nil {
+		return "unknown package"
+	}
+	if pkg.Version == "" {
+		return pkg.Name
+	}
+	return pkg.Name + "@" + pkg.Version
+}
@@ -0,0 +1,230 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	scalibr "github.com/google/osv-scalibr"
+	"github.com/google/osv-scalibr/detector/misconfig/containerspolicy"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/inventory/vex"
+	"github.com/google/osv-scalibr/plugin"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scalibr/result/policy"
+	"github.com/ossf/osv-schema/bindings/go/osvschema"
+)
+
+func mustPURL(t *testing.T, s string) purl.PackageURL {
+	t.Helper()
+	p, err := purl.FromString(s)
+	if err != nil {
+		t.Fatalf("purl.FromString(%q): %v", s, err)
+	}
+	return p
+}
+
+func TestReadFile(t *testing.T) {
+	got, err := policy.ReadFile("testdata/policy.yaml")
+	if err != nil {
+		t.Fatalf("ReadFile(): %v", err)
+	}
+	want := &policy.Policy{
+		MaxSeverity:    inventory.SeverityMedium,
+		BannedLicenses: []string{"AGPL-3.0-only", "SSPL-1.0"},
+		DisallowedPackages: []*policy.PackageRule{
+			{PURL: mustPURL(t, "pkg:npm/event-stream@3.3.6"), Reason: "Compromised release."},
+			{PURL: mustPURL(t, "pkg:pypi/pycrypto")},
+		},
+		RequireSignatureVerification: true,
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ReadFile() unexpected diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestRead_Errors(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		policy string
+	}{
+		{desc: "unknown_key", policy: "max_severity: high\nbanned_licences: [GPL-3.0-only]\n"},
+		{desc: "unknown_severity", policy: "max_severity: severe\n"},
+		{desc: "invalid_purl", policy: "disallowed_packages:\n  - purl: event-stream\n"},
+		{desc: "invalid_yaml", policy: "banned_licenses: [MIT\n"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if _, err := policy.Read(strings.NewReader(tc.policy)); err == nil {
+				t.Errorf("Read(%q) succeeded, want error", tc.policy)
+			}
+		})
+	}
+}
+
+func TestRead_Empty(t *testing.T) {
+	got, err := policy.Read(strings.NewReader(""))
+	if err != nil {
+		t.Fatalf("Read(): %v", err)
+	}
+	if diff := cmp.Diff(&policy.Policy{}, got); diff != "" {
+		t.Errorf("Read() unexpected diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	lodash := &extractor.Package{Name: "lodash", Version: "4.17.20", PURLType: purl.TypeNPM, Licenses: []string{"MIT"}}
+	eventStream := &extractor.Package{Name: "event-stream", Version: "3.3.6", PURLType: purl.TypeNPM}
+	eventStreamFixed := &extractor.Package{Name: "event-stream", Version: "4.0.1", PURLType: purl.TypeNPM}
+	mongo := &extractor.Package{Name: "mongodb", Version: "7.0.0", PURLType: purl.TypeGeneric, Licenses: []string{"SSPL-1.0"}}
+	dual := &extractor.Package{Name: "dual", Version: "1.0", PURLType: purl.TypeNPM, Licenses: []string{"MIT OR AGPL-3.0-only"}}
+
+	criticalVuln := &inventory.PackageVuln{
+		Vulnerability: osvschema.Vulnerability{
+			ID: "GHSA-1",
+			Severity: []osvschema.Severity{{
+				Type:  osvschema.SeverityCVSSV3,
+				Score: "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H",
+			}},
+		},
+		Package: lodash,
+	}
+	moderateVuln := &inventory.PackageVuln{
+		Vulnerability: osvschema.Vulnerability{
+			ID:               "GHSA-2",
+			DatabaseSpecific: map[string]any{"severity": "MODERATE"},
+		},
+		Package: lodash,
+	}
+	suppressedVuln := &inventory.PackageVuln{
+		Vulnerability:         criticalVuln.Vulnerability,
+		Package:               lodash,
+		ExploitabilitySignals: []*vex.FindingExploitabilitySignal{{Plugin: "vex/suppression"}},
+	}
+	highFinding := &inventory.GenericFinding{
+		Adv: &inventory.GenericFindingAdvisory{
+			ID:  &inventory.AdvisoryID{Publisher: "SCALIBR", Reference: "weak-credentials"},
+			Sev: inventory.SeverityHigh,
+		},
+	}
+	signatureFinding := &inventory.GenericFinding{
+		Adv: &inventory.GenericFindingAdvisory{
+			ID:  &inventory.AdvisoryID{Publisher: "SCALIBR", Reference: "containers-signature-verification-disabled"},
+			Sev: inventory.SeverityMedium,
+		},
+		Target: &inventory.GenericFindingTargetDetails{Extra: "/etc/containers/policy.json: default"},
+	}
+	succeeded := &plugin.ScanStatus{Status: plugin.ScanStatusSucceeded}
+
+	testCases := []struct {
+		desc   string
+		policy *policy.Policy
+		result *scalibr.ScanResult
+		want   []*policy.Violation
+	}{
+		{
+			desc:   "empty_policy",
+			policy: &policy.Policy{},
+			result: &scalibr.ScanResult{Inventory: inventory.Inventory{
+				Packages:        []*extractor.Package{lodash, eventStream, mongo},
+				PackageVulns:    []*inventory.PackageVuln{criticalVuln},
+				GenericFindings: []*inventory.GenericFinding{highFinding},
+			}},
+			want: []*policy.Violation{},
+		},
+		{
+			desc:   "max_severity",
+			policy: &policy.Policy{MaxSeverity: inventory.SeverityMedium},
+			result: &scalibr.ScanResult{Inventory: inventory.Inventory{
+				PackageVulns:    []*inventory.PackageVuln{criticalVuln, moderateVuln, suppressedVuln},
+				GenericFindings: []*inventory.GenericFinding{highFinding},
+			}},
+			want: []*policy.Violation{
+				{Rule: policy.RuleMaxSeverity, Message: "GHSA-1 in lodash@4.17.20 has severity critical, above the maximum of medium"},
+				{Rule: policy.RuleMaxSeverity, Message: "SCALIBR/weak-credentials has severity high, above the maximum of medium"},
+			},
+		},
+		{
+			desc:   "banned_licenses",
+			policy: &policy.Policy{BannedLicenses: []string{"sspl-1.0", "AGPL-3.0-only"}},
+			result: &scalibr.ScanResult{Inventory: inventory.Inventory{
+				Packages: []*extractor.Package{lodash, mongo, dual},
+			}},
+			want: []*policy.Violation{
+				{Rule: policy.RuleBannedLicense, Message: `mongodb@7.0.0 is licensed under "SSPL-1.0", which is banned`},
+			},
+		},
+		{
+			desc: "disallowed_packages",
+			policy: &policy.Policy{DisallowedPackages: []*policy.PackageRule{
+				{PURL: mustPURL(t, "pkg:npm/event-stream@3.3.6"), Reason: "Compromised release."},
+				{PURL: mustPURL(t, "pkg:npm/LODASH")},
+			}},
+			result: &scalibr.ScanResult{Inventory: inventory.Inventory{
+				Packages: []*extractor.Package{lodash, eventStream, eventStreamFixed},
+			}},
+			want: []*policy.Violation{
+				{Rule: policy.RuleDisallowedPackage, Message: "lodash@4.17.20 is disallowed"},
+				{Rule: policy.RuleDisallowedPackage, Message: "event-stream@3.3.6 is disallowed: Compromised release."},
+			},
+		},
+		{
+			desc:   "signature_verification_disabled",
+			policy: &policy.Policy{RequireSignatureVerification: true},
+			result: &scalibr.ScanResult{
+				PluginStatus: []*plugin.Status{{Name: containerspolicy.Name, Status: succeeded}},
+				Inventory: inventory.Inventory{
+					GenericFindings: []*inventory.GenericFinding{highFinding, signatureFinding},
+				},
+			},
+			want: []*policy.Violation{
+				{Rule: policy.RuleSignatureVerification, Message: "container image signature verification is disabled: /etc/containers/policy.json: default"},
+			},
+		},
+		{
+			desc:   "signature_verification_enabled",
+			policy: &policy.Policy{RequireSignatureVerification: true},
+			result: &scalibr.ScanResult{
+				PluginStatus: []*plugin.Status{{Name: containerspolicy.Name, Status: succeeded}},
+			},
+			want: []*policy.Violation{},
+		},
+		{
+			desc:   "signature_verification_not_checked",
+			policy: &policy.Policy{RequireSignatureVerification: true},
+			result: &scalibr.ScanResult{},
+			want: []*policy.Violation{
+				{Rule: policy.RuleSignatureVerification, Message: "signature verification can't be checked because the misconfig/containerspolicy detector didn't run"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := tc.policy.Evaluate(tc.result)
+			if diff := cmp.Diff(tc.want, got.Violations, cmpopts.IgnoreFields(policy.Violation{}, "Fingerprint")); diff != "" {
+				t.Errorf("Evaluate() unexpected diff (-want +got):\n%s", diff)
+			}
+			if got.Passed() != (len(tc.want) == 0) {
+				t.Errorf("Evaluate().Passed() = %v, want %v", got.Passed(), len(tc.want) == 0)
+			}
+		})
+	}
+}
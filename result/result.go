@@ -34,6 +34,56 @@ type ScanResult struct {
 	// Status and versions of the plugins that ran.
 	PluginStatus []*plugin.Status
 	Inventory    inventory.Inventory
+	// The resources the scanner process used for the scan, if they were
+	// tracked.
+	ResourceUsage *ResourceUsage
+	// The real filesystem directories that were scanned.
+	ScanRoots []*ScanRoot
+}
+
+// ScanRoot is a real filesystem directory that was scanned.
+type ScanRoot struct {
+	// The path of the scan root as supplied by the user.
+	Path string
+	// The absolute path of the scan root with symlinks and bind mounts resolved.
+	// Empty if it couldn't be determined.
+	CanonicalPath string
+}
+
+// ResourceUsage is the cost of a scan to the scanner process.
+type ResourceUsage struct {
+	// The peak resident set size of the process. This covers the lifetime of the
+	// process, not only the scan. Zero if unsupported on the platform.
+	PeakMemoryBytes int64
+	// The user and system CPU time the process spent during the scan. Zero if
+	// unsupported on the platform.
+	CPUTime time.Duration
+	// The number of files that filesystem extractors opened.
+	FilesOpened int64
+	// The number of bytes that filesystem extractors read from files.
+	BytesRead int64
+	// The resources used by the individual plugins, sorted by name.
+	Plugins []*PluginResourceUsage
+}
+
+// PluginResourceUsage is the cost of the runs of a single plugin. The plugins
+// run sequentially, so the process-wide counters measured before and after a
+// run are attributed to the plugin.
+type PluginResourceUsage struct {
+	Name string
+	// The total time the plugin ran for.
+	WallTime time.Duration
+	// The user and system CPU time spent while the plugin ran. Zero if
+	// unsupported on the platform.
+	CPUTime time.Duration
+	// The number of files passed to the plugin. Only set for filesystem
+	// extractors.
+	FilesExtracted int64
+	// The number of bytes the plugin read from the files passed to it. Only set
+	// for filesystem extractors.
+	BytesRead int64
+	// The number of bytes allocated on the heap while the plugin ran.
+	AllocatedBytes int64
 }
 
 // LINT.ThenChange(/binary/proto/scan_result.proto)
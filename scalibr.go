@@ -28,30 +28,39 @@ import (
 
 	"github.com/gobwas/glob"
 	"github.com/google/osv-scalibr/annotator"
+	"github.com/google/osv-scalibr/artifact/appliance"
 	"github.com/google/osv-scalibr/artifact/image"
 	"github.com/google/osv-scalibr/artifact/image/layerscanning/trace"
+	"github.com/google/osv-scalibr/artifact/lxd"
 	"github.com/google/osv-scalibr/detector"
 	"github.com/google/osv-scalibr/detector/detectorrunner"
 	"github.com/google/osv-scalibr/enricher"
 	"github.com/google/osv-scalibr/extractor"
 	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/extractor/filesystem/pathfilter"
+	"github.com/google/osv-scalibr/extractor/filesystem/targetenv"
 	"github.com/google/osv-scalibr/extractor/standalone"
+	"github.com/google/osv-scalibr/hashing"
 	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/inventory/dedup"
 	"github.com/google/osv-scalibr/log"
 	"github.com/google/osv-scalibr/packageindex"
 	"github.com/google/osv-scalibr/plugin"
 	pl "github.com/google/osv-scalibr/plugin/list"
 	"github.com/google/osv-scalibr/result"
 	"github.com/google/osv-scalibr/stats"
+	"github.com/google/osv-scalibr/stats/resourceusage"
 	"github.com/google/osv-scalibr/version"
 	"go.uber.org/multierr"
 
 	scalibrfs "github.com/google/osv-scalibr/fs"
+	"github.com/google/osv-scalibr/fs/mounts"
 )
 
 var (
 	errNoScanRoot            = errors.New("no scan root specified")
 	errFilesWithSeveralRoots = errors.New("can't extract specific files with several scan roots")
+	errScopeWithoutRoot      = errors.New("scan root scope doesn't refer to one of the scan roots")
 )
 
 // Scanner is the main entry point of the scanner.
@@ -72,6 +81,11 @@ type ScanConfig struct {
 	// Example use case: Scanning a container image or source code repo that is
 	// mounted to a local dir.
 	ScanRoots []*scalibrfs.ScanRoot
+	// Optional: Restricts the filesystem extractors that run on individual scan
+	// roots, e.g. to only run OS extractors on "/" and language extractors on
+	// "/srv/apps" in the same scan. Scan roots without a scope run all
+	// filesystem extractors in Plugins. Other plugin types aren't affected.
+	ScanRootScopes []*ScanRootScope
 	// Optional: Individual file or dir paths to extract inventory from. If specified,
 	// the extractors will only look at the specified files or at the contents of the
 	// specified directories during the filesystem traversal.
@@ -90,10 +104,20 @@ type ScanConfig struct {
 	SkipDirRegex *regexp.Regexp
 	// Optional: If the glob matches a directory, it will be skipped.
 	SkipDirGlob glob.Glob
+	// Optional: Ordered include and exclude rules for the paths visited during
+	// the filesystem walk.
+	PathFilter *pathfilter.Filter
+	// Optional: Which mounted filesystems under the scan roots are skipped or
+	// walked last, e.g. to avoid hanging on unresponsive NFS mounts. If nil,
+	// mount points are walked like any other directory.
+	MountPolicy *mounts.Policy
 	// Optional: Files larger than this size in bytes are skipped. If 0, no limit is applied.
 	MaxFileSize int
 	// Optional: Skip files declared in .gitignore files in source repos.
 	UseGitignore bool
+	// Optional: Only extract from files tracked in the git index of each scan
+	// root, including staged changes. Untracked and ignored files are skipped.
+	OnlyGitTracked bool
 	// Optional: stats allows to enter a metric hook. If left nil, no metrics will be recorded.
 	Stats stats.Collector
 	// Optional: Whether to read symlinks.
@@ -103,10 +127,62 @@ type ScanConfig struct {
 	// Optional: By default, inventories stores a path relative to the scan root. If StoreAbsolutePath
 	// is set, the absolute path is stored instead.
 	StoreAbsolutePath bool
+	// Optional: If the scan roots are symlinks or bind mounts, absolute paths are
+	// reported under the scan roots as supplied by default. If ReportCanonicalPaths
+	// is set, they are reported under the canonical scan roots instead.
+	ReportCanonicalPaths bool
 	// Optional: If true, print a detailed analysis of the duration of each extractor.
 	PrintDurationAnalysis bool
 	// Optional: If true, fail the scan if any permission errors are encountered.
 	ErrorOnFSErrors bool
+	// Optional: The digest algorithms used by plugins that hash files and whether
+	// only FIPS 140 approved algorithms are allowed. If nil, plugins use their defaults.
+	Hashing *hashing.Config
+	// Optional: How to merge packages that were reported by several extractors.
+	// If nil, duplicates are kept.
+	Dedup *dedup.Config
+	// Optional: Receives periodic progress events during the filesystem walk,
+	// e.g. to display a progress bar.
+	Progress stats.ProgressReporter
+	// Optional: The interval between two progress events. Defaults to
+	// stats.DefaultProgressInterval.
+	ProgressInterval time.Duration
+	// Optional: The expected number of inodes to visit, e.g. from a previous
+	// scan of the same host. Used to estimate the remaining scan time.
+	ExpectedInodes int
+	// Optional: The OS, architecture and runtime versions the scanned software
+	// is deployed to. If set, lockfile extractors evaluate conditional
+	// dependencies such as PEP 508 markers against it instead of reporting all
+	// of them.
+	TargetEnv *targetenv.Env
+	// Optional: How long individual plugin runs can take before they're
+	// cancelled. Plugins that time out are reported in the plugin status
+	// together with the results they found so far instead of failing the scan.
+	PluginTimeouts *plugin.Timeouts
+	// Optional: If true, the peak memory, CPU time, files opened and bytes read
+	// of the scan are recorded in the ResourceUsage field of the scan result.
+	ReportResourceUsage bool
+	// Optional: Limits on the size of the files plugins can open and on the
+	// number of bytes they can read from the scan roots. File accesses past a
+	// limit fail with an error wrapping fs.ErrBudgetExceeded.
+	IOBudget *scalibrfs.IOBudget
+	// Optional: An ID for the scan that's added to the messages plugins log
+	// through log.FromContext, e.g. to correlate the logs of concurrent scans.
+	ScanID string
+}
+
+// ScanRootScope selects the filesystem extractors that run on one of the scan
+// roots.
+type ScanRootScope struct {
+	// The scan root the scope applies to. Has to be one of ScanConfig.ScanRoots.
+	ScanRoot *scalibrfs.ScanRoot
+	// Optional: Names of the plugins or plugin groups to run on the scan root,
+	// e.g. "os" or "python". Only extractors enabled in ScanConfig.Plugins are
+	// run. If empty, all of them run on the scan root.
+	Plugins []string
+	// Optional: Names of the plugins or plugin groups that don't run on the scan
+	// root.
+	ExcludePlugins []string
 }
 
 // EnableRequiredPlugins adds those plugins to the config that are required by enabled
@@ -117,31 +193,33 @@ func (cfg *ScanConfig) EnableRequiredPlugins() error {
 		enabledPlugins[e.Name()] = struct{}{}
 	}
 
-	requiredPlugins := map[string]struct{}{}
-	for _, p := range cfg.Plugins {
-		if d, ok := p.(detector.Detector); ok {
-			for _, req := range d.RequiredExtractors() {
-				requiredPlugins[req] = struct{}{}
+	// Required plugins can have requirements of their own so the newly enabled
+	// plugins are checked as well.
+	toCheck := cfg.Plugins
+	for len(toCheck) > 0 {
+		var requiredPlugins []string
+		for _, p := range toCheck {
+			if d, ok := p.(detector.Detector); ok {
+				requiredPlugins = append(requiredPlugins, d.RequiredExtractors()...)
 			}
-		}
-		if e, ok := p.(enricher.Enricher); ok {
-			for _, req := range e.RequiredPlugins() {
-				requiredPlugins[req] = struct{}{}
+			if e, ok := p.(enricher.Enricher); ok {
+				requiredPlugins = append(requiredPlugins, e.RequiredPlugins()...)
 			}
 		}
-	}
 
-	for p := range requiredPlugins {
-		if _, enabled := enabledPlugins[p]; enabled {
-			continue
-		}
-		requiredPlugin, err := pl.FromName(p)
-		// TODO: b/416106602 - Implement transitive enablement for required enrichers.
-		if err != nil {
-			return fmt.Errorf("required plugin %q not present in any list.go: %w", p, err)
+		toCheck = nil
+		for _, p := range requiredPlugins {
+			if _, enabled := enabledPlugins[p]; enabled {
+				continue
+			}
+			requiredPlugin, err := pl.FromName(p)
+			if err != nil {
+				return fmt.Errorf("required plugin %q not present in any list.go: %w", p, err)
+			}
+			enabledPlugins[p] = struct{}{}
+			cfg.Plugins = append(cfg.Plugins, requiredPlugin)
+			toCheck = append(toCheck, requiredPlugin)
 		}
-		enabledPlugins[p] = struct{}{}
-		cfg.Plugins = append(cfg.Plugins, requiredPlugin)
 	}
 	return nil
 }
@@ -158,6 +236,113 @@ func (cfg *ScanConfig) ValidatePluginRequirements() error {
 	return errors.Join(errs...)
 }
 
+// scanRootExtractors returns the filesystem extractors that run on each of the
+// scan roots as selected by the scan root scopes, in the order of ScanRoots.
+// Scan roots without a scope have a nil entry.
+func (cfg *ScanConfig) scanRootExtractors() ([][]filesystem.Extractor, error) {
+	if len(cfg.ScanRootScopes) == 0 {
+		return nil, nil
+	}
+	result := make([][]filesystem.Extractor, len(cfg.ScanRoots))
+	for _, scope := range cfg.ScanRootScopes {
+		i := slices.Index(cfg.ScanRoots, scope.ScanRoot)
+		if i < 0 || scope.ScanRoot == nil {
+			return nil, errScopeWithoutRoot
+		}
+		if result[i] != nil {
+			return nil, fmt.Errorf("several scopes for scan root %q", scope.ScanRoot.Path)
+		}
+		include, err := pluginNames(scope.Plugins)
+		if err != nil {
+			return nil, fmt.Errorf("scope for scan root %q: %w", scope.ScanRoot.Path, err)
+		}
+		exclude, err := pluginNames(scope.ExcludePlugins)
+		if err != nil {
+			return nil, fmt.Errorf("scope for scan root %q: %w", scope.ScanRoot.Path, err)
+		}
+		// Non-nil so that a scope which excludes all extractors doesn't fall back
+		// to running all of them.
+		extractors := []filesystem.Extractor{}
+		for _, ex := range pl.FilesystemExtractors(cfg.Plugins) {
+			if len(scope.Plugins) > 0 && !include[ex.Name()] {
+				continue
+			}
+			if exclude[ex.Name()] {
+				continue
+			}
+			extractors = append(extractors, ex)
+		}
+		result[i] = extractors
+	}
+	return result, nil
+}
+
+// pluginNames resolves plugin and plugin group names to the set of names of
+// the plugins they refer to.
+func pluginNames(names []string) (map[string]bool, error) {
+	plugins, err := pl.FromNames(names)
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[string]bool, len(plugins))
+	for _, p := range plugins {
+		result[p.Name()] = true
+	}
+	return result, nil
+}
+
+// applyHashingConfig passes the hashing config to all plugins that compute digests.
+func (cfg *ScanConfig) applyHashingConfig() {
+	if cfg.Hashing == nil {
+		return
+	}
+	for _, p := range cfg.Plugins {
+		if h, ok := p.(hashing.Configurable); ok {
+			h.SetHashingConfig(cfg.Hashing)
+		}
+	}
+}
+
+// canonicalScanRoots records the canonical paths of the real filesystem scan
+// roots and, if useCanonical is set, returns scan roots with these paths.
+func canonicalScanRoots(roots []*scalibrfs.ScanRoot, useCanonical bool) ([]*scalibrfs.ScanRoot, []*result.ScanRoot) {
+	scanRoots := make([]*scalibrfs.ScanRoot, 0, len(roots))
+	var info []*result.ScanRoot
+	for _, r := range roots {
+		if r.IsVirtual() {
+			scanRoots = append(scanRoots, r)
+			continue
+		}
+		canonical, err := r.WithCanonicalPath()
+		if err != nil {
+			log.Warnf("Failed to resolve the canonical path of scan root %q: %v", r.Path, err)
+			info = append(info, &result.ScanRoot{Path: r.Path})
+			scanRoots = append(scanRoots, r)
+			continue
+		}
+		info = append(info, &result.ScanRoot{Path: r.Path, CanonicalPath: canonical.Path})
+		if useCanonical {
+			scanRoots = append(scanRoots, canonical)
+		} else {
+			scanRoots = append(scanRoots, r)
+		}
+	}
+	return scanRoots, info
+}
+
+// withIOBudget returns copies of the scan roots whose filesystems enforce the
+// IO budget.
+func withIOBudget(roots []*scalibrfs.ScanRoot, b *scalibrfs.IOBudget) []*scalibrfs.ScanRoot {
+	if b == nil {
+		return roots
+	}
+	result := make([]*scalibrfs.ScanRoot, 0, len(roots))
+	for _, r := range roots {
+		result = append(result, &scalibrfs.ScanRoot{FS: b.WrapFS(r.FS), Path: r.Path})
+	}
+	return result
+}
+
 // LINT.IfChange
 
 // ScanResult stores the results of a scan incl. scan status and inventory found.
@@ -167,17 +352,31 @@ type ScanResult = result.ScanResult
 // LINT.ThenChange(/binary/proto/scan_result.proto)
 
 // Scan executes the extraction/detection/annotation/etc. plugins using the provided scan config.
+// If ctx is cancelled, the remaining plugins are skipped and the results found
+// until then are returned with the ScanStatusInterrupted status.
 func (Scanner) Scan(ctx context.Context, config *ScanConfig) (sr *ScanResult) {
 	if config.Stats == nil {
 		config.Stats = stats.NoopCollector{}
 	}
+	var tracker *resourceusage.Tracker
+	if config.ReportResourceUsage {
+		tracker = resourceusage.Start()
+	}
 	defer func() {
+		if tracker != nil {
+			sr.ResourceUsage = tracker.Usage()
+		}
 		config.Stats.AfterScan(time.Since(sr.StartTime), sr.Status)
 	}()
+	if config.ScanID != "" {
+		ctx = log.NewContext(ctx, log.KeyScanID, config.ScanID)
+	}
 	sro := &newScanResultOptions{
 		StartTime: time.Now(),
 	}
-	if err := config.EnableRequiredPlugins(); err != nil {
+	var rootExtractors [][]filesystem.Extractor
+	var err error
+	if err = config.EnableRequiredPlugins(); err != nil {
 		sro.Err = err
 	} else if err := config.ValidatePluginRequirements(); err != nil {
 		sro.Err = err
@@ -185,11 +384,23 @@ func (Scanner) Scan(ctx context.Context, config *ScanConfig) (sr *ScanResult) {
 		sro.Err = errNoScanRoot
 	} else if len(config.PathsToExtract) > 0 && len(config.ScanRoots) > 1 {
 		sro.Err = errFilesWithSeveralRoots
+	} else if err := config.Hashing.Validate(); err != nil {
+		sro.Err = err
+	} else if err := config.Dedup.Validate(); err != nil {
+		sro.Err = err
+	} else if err := config.PluginTimeouts.Validate(); err != nil {
+		sro.Err = err
+	} else if rootExtractors, err = config.scanRootExtractors(); err != nil {
+		sro.Err = err
 	}
 	if sro.Err != nil {
 		sro.EndTime = time.Now()
 		return newScanResult(sro)
 	}
+	config.applyHashingConfig()
+	scanRoots, rootInfo := canonicalScanRoots(config.ScanRoots, config.ReportCanonicalPaths)
+	sro.ScanRoots = rootInfo
+	scanRoots = withIOBudget(scanRoots, config.IOBudget)
 	extractorConfig := &filesystem.Config{
 		Stats:                 config.Stats,
 		ReadSymlinks:          config.ReadSymlinks,
@@ -200,14 +411,29 @@ func (Scanner) Scan(ctx context.Context, config *ScanConfig) (sr *ScanResult) {
 		SkipDirRegex:          config.SkipDirRegex,
 		MaxFileSize:           config.MaxFileSize,
 		SkipDirGlob:           config.SkipDirGlob,
+		PathFilter:            config.PathFilter,
+		MountPolicy:           config.MountPolicy,
 		UseGitignore:          config.UseGitignore,
-		ScanRoots:             config.ScanRoots,
+		OnlyGitTracked:        config.OnlyGitTracked,
+		ScanRoots:             scanRoots,
+		ScanRootExtractors:    rootExtractors,
 		MaxInodes:             config.MaxInodes,
 		StoreAbsolutePath:     config.StoreAbsolutePath,
 		PrintDurationAnalysis: config.PrintDurationAnalysis,
 		ErrorOnFSErrors:       config.ErrorOnFSErrors,
+		Progress:              config.Progress,
+		ProgressInterval:      config.ProgressInterval,
+		ExpectedInodes:        config.ExpectedInodes,
+		TargetEnv:             config.TargetEnv,
+		PluginTimeouts:        config.PluginTimeouts,
+		ResourceTracker:       tracker,
 	}
 	inv, extractorStatus, err := filesystem.Run(ctx, extractorConfig)
+	if ctx.Err() != nil {
+		sro.Inventory = inv
+		sro.PluginStatus = append(sro.PluginStatus, extractorStatus...)
+		return interruptedScanResult(ctx, sro)
+	}
 	if err != nil {
 		sro.Err = err
 		sro.EndTime = time.Now()
@@ -216,12 +442,18 @@ func (Scanner) Scan(ctx context.Context, config *ScanConfig) (sr *ScanResult) {
 
 	sro.Inventory = inv
 	sro.PluginStatus = append(sro.PluginStatus, extractorStatus...)
-	sysroot := config.ScanRoots[0]
+	sysroot := scanRoots[0]
 	standaloneCfg := &standalone.Config{
-		Extractors: pl.StandaloneExtractors(config.Plugins),
-		ScanRoot:   &scalibrfs.ScanRoot{FS: sysroot.FS, Path: sysroot.Path},
+		Extractors:      pl.StandaloneExtractors(config.Plugins),
+		ScanRoot:        &scalibrfs.ScanRoot{FS: sysroot.FS, Path: sysroot.Path},
+		ResourceTracker: tracker,
 	}
 	standaloneInv, standaloneStatus, err := standalone.Run(ctx, standaloneCfg)
+	if ctx.Err() != nil {
+		sro.Inventory.Append(standaloneInv)
+		sro.PluginStatus = append(sro.PluginStatus, standaloneStatus...)
+		return interruptedScanResult(ctx, sro)
+	}
 	if err != nil {
 		sro.Err = err
 		sro.EndTime = time.Now()
@@ -230,6 +462,7 @@ func (Scanner) Scan(ctx context.Context, config *ScanConfig) (sr *ScanResult) {
 
 	sro.Inventory.Append(standaloneInv)
 	sro.PluginStatus = append(sro.PluginStatus, standaloneStatus...)
+	sro.Inventory.Packages = config.Dedup.Packages(sro.Inventory.Packages)
 
 	px, err := packageindex.New(sro.Inventory.Packages)
 	if err != nil {
@@ -239,21 +472,29 @@ func (Scanner) Scan(ctx context.Context, config *ScanConfig) (sr *ScanResult) {
 	}
 
 	findings, detectorStatus, err := detectorrunner.Run(
-		ctx, config.Stats, pl.Detectors(config.Plugins), &scalibrfs.ScanRoot{FS: sysroot.FS, Path: sysroot.Path}, px,
+		ctx, config.Stats, pl.Detectors(config.Plugins), &scalibrfs.ScanRoot{FS: sysroot.FS, Path: sysroot.Path}, px, config.PluginTimeouts, tracker,
 	)
 	sro.Inventory.PackageVulns = findings.PackageVulns
 	sro.Inventory.GenericFindings = findings.GenericFindings
 	sro.PluginStatus = append(sro.PluginStatus, detectorStatus...)
+	if ctx.Err() != nil {
+		return interruptedScanResult(ctx, sro)
+	}
 	if err != nil {
 		sro.Err = err
 	}
 
 	annotatorCfg := &annotator.Config{
-		Annotators: pl.Annotators(config.Plugins),
-		ScanRoot:   sysroot,
+		Annotators:      pl.Annotators(config.Plugins),
+		ScanRoot:        sysroot,
+		PluginTimeouts:  config.PluginTimeouts,
+		ResourceTracker: tracker,
 	}
 	annotatorStatus, err := annotator.Run(ctx, annotatorCfg, &sro.Inventory)
 	sro.PluginStatus = append(sro.PluginStatus, annotatorStatus...)
+	if ctx.Err() != nil {
+		return interruptedScanResult(ctx, sro)
+	}
 	if err != nil {
 		sro.Err = multierr.Append(sro.Err, err)
 	}
@@ -264,9 +505,14 @@ func (Scanner) Scan(ctx context.Context, config *ScanConfig) (sr *ScanResult) {
 			FS:   sysroot.FS,
 			Path: sysroot.Path,
 		},
+		PluginTimeouts:  config.PluginTimeouts,
+		ResourceTracker: tracker,
 	}
 	enricherStatus, err := enricher.Run(ctx, enricherCfg, &sro.Inventory)
 	sro.PluginStatus = append(sro.PluginStatus, enricherStatus...)
+	if ctx.Err() != nil {
+		return interruptedScanResult(ctx, sro)
+	}
 	if err != nil {
 		sro.Err = multierr.Append(sro.Err, err)
 	}
@@ -275,6 +521,121 @@ func (Scanner) Scan(ctx context.Context, config *ScanConfig) (sr *ScanResult) {
 	return newScanResult(sro)
 }
 
+// interruptedScanResult returns the results found before the scan was
+// cancelled.
+func interruptedScanResult(ctx context.Context, sro *newScanResultOptions) *ScanResult {
+	sro.Interrupted = true
+	sro.Err = context.Cause(ctx)
+	sro.EndTime = time.Now()
+	return newScanResult(sro)
+}
+
+// Enrich runs the enrichers of the config on the inventory of a previous scan
+// result, e.g. one created on an air-gapped machine by a scan that didn't run
+// any enrichers. Only the enrichers, the capabilities, the first scan root and
+// the plugin timeouts of the config are used. Enrichers that need direct
+// filesystem access can only run if a scan root is set. The scan result is
+// updated in place and returned.
+func (Scanner) Enrich(ctx context.Context, sr *ScanResult, config *ScanConfig) *ScanResult {
+	enrichers := pl.Enrichers(config.Plugins)
+	var errs []error
+	for _, e := range enrichers {
+		if err := plugin.ValidateRequirements(e, config.Capabilities); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	err := errors.Join(errs...)
+	if err == nil {
+		err = config.PluginTimeouts.Validate()
+	}
+	if err == nil {
+		var root *scalibrfs.ScanRoot
+		if len(config.ScanRoots) > 0 {
+			root = &scalibrfs.ScanRoot{FS: config.ScanRoots[0].FS, Path: config.ScanRoots[0].Path}
+		}
+		var statuses []*plugin.Status
+		statuses, err = enricher.Run(ctx, &enricher.Config{
+			Enrichers:      enrichers,
+			ScanRoot:       root,
+			PluginTimeouts: config.PluginTimeouts,
+		}, &sr.Inventory)
+		sr.PluginStatus = append(sr.PluginStatus, statuses...)
+	}
+	if err != nil {
+		sr.Status = &plugin.ScanStatus{Status: plugin.ScanStatusFailed, FailureReason: err.Error()}
+	}
+
+	sortResults(sr)
+	return sr
+}
+
+// LXDContainerResult is the result of scanning a single LXD, Incus or LXC
+// container.
+type LXDContainerResult struct {
+	Container *lxd.Container
+	Result    *ScanResult
+}
+
+// ScanLXDContainers finds the system containers stored on the host, e.g. in
+// the dir or ZFS storage pools of LXD, and scans the root filesystem of each of
+// them using the provided scan config. The scan roots of the config are
+// replaced by the root filesystem of the scanned container.
+func (s Scanner) ScanLXDContainers(ctx context.Context, host *scalibrfs.ScanRoot, config *ScanConfig) ([]*LXDContainerResult, error) {
+	containers, err := lxd.Containers(host)
+	if err != nil {
+		return nil, fmt.Errorf("lxd.Containers(): %w", err)
+	}
+	results := make([]*LXDContainerResult, 0, len(containers))
+	for _, c := range containers {
+		if err := ctx.Err(); err != nil {
+			return results, err
+		}
+		containerConfig := *config
+		containerConfig.ScanRoots = []*scalibrfs.ScanRoot{c.ScanRoot(host)}
+		results = append(results, &LXDContainerResult{
+			Container: c,
+			Result:    s.Scan(ctx, &containerConfig),
+		})
+	}
+	return results, nil
+}
+
+// ApplianceDiskResult is the result of scanning a single disk of a virtual
+// appliance.
+type ApplianceDiskResult struct {
+	// The metadata of the appliance the disk belongs to. Nil for standalone
+	// disk images.
+	Appliance *appliance.Metadata
+	Disk      *appliance.Disk
+	Result    *ScanResult
+}
+
+// ScanAppliance scans the filesystem of each disk of the given appliance using
+// the provided scan config. The filesystems are read with the given opener.
+// The scan roots of the config are replaced by the filesystem of the scanned
+// disk. Disks which can't be opened are reported as failed scans.
+func (s Scanner) ScanAppliance(ctx context.Context, app *appliance.Appliance, open appliance.DiskOpener, config *ScanConfig) ([]*ApplianceDiskResult, error) {
+	results := make([]*ApplianceDiskResult, 0, len(app.Disks))
+	for _, d := range app.Disks {
+		if err := ctx.Err(); err != nil {
+			return results, err
+		}
+		res := &ApplianceDiskResult{Appliance: app.Metadata, Disk: d}
+		fsys, err := app.DiskFS(d, open)
+		if err != nil {
+			now := time.Now()
+			res.Result = newScanResult(&newScanResultOptions{StartTime: now, EndTime: now, Err: err})
+			results = append(results, res)
+			continue
+		}
+		diskConfig := *config
+		diskConfig.ScanRoots = []*scalibrfs.ScanRoot{{FS: fsys}}
+		res.Result = s.Scan(ctx, &diskConfig)
+		results = append(results, res)
+	}
+	return results, nil
+}
+
 // ScanContainer scans the provided container image for packages and security findings using the
 // provided scan config. It populates the LayerDetails field of the packages with the origin layer
 // details. Functions to create an Image from a tarball, remote name, or v1.Image are available in
@@ -316,6 +677,11 @@ func (s Scanner) ScanContainer(ctx context.Context, img image.Image, config *Sca
 	}
 
 	scanResult := s.Scan(ctx, config)
+	if ci, ok := img.(image.ConfiguredImage); ok {
+		if m := ci.ContainerImageMetadata(); m != nil {
+			scanResult.Inventory.ContainerImageMetadata = append(scanResult.Inventory.ContainerImageMetadata, m)
+		}
+	}
 	extractorConfig := &filesystem.Config{
 		Stats:                 config.Stats,
 		ReadSymlinks:          config.ReadSymlinks,
@@ -326,15 +692,26 @@ func (s Scanner) ScanContainer(ctx context.Context, img image.Image, config *Sca
 		SkipDirRegex:          config.SkipDirRegex,
 		MaxFileSize:           config.MaxFileSize,
 		SkipDirGlob:           config.SkipDirGlob,
+		PathFilter:            config.PathFilter,
 		UseGitignore:          config.UseGitignore,
 		ScanRoots:             config.ScanRoots,
 		MaxInodes:             config.MaxInodes,
 		StoreAbsolutePath:     config.StoreAbsolutePath,
 		PrintDurationAnalysis: config.PrintDurationAnalysis,
+		TargetEnv:             config.TargetEnv,
+		PluginTimeouts:        config.PluginTimeouts,
 	}
 
 	// Populate the LayerDetails field of the inventory by tracing the layer origins.
-	trace.PopulateLayerDetails(ctx, scanResult.Inventory, chainLayers, pl.FilesystemExtractors(config.Plugins), extractorConfig)
+	interrupted := scanResult.Status.Status == plugin.ScanStatusInterrupted
+	if !interrupted {
+		trace.PopulateLayerDetails(ctx, scanResult.Inventory, chainLayers, pl.FilesystemExtractors(config.Plugins), extractorConfig)
+	}
+	for _, pkg := range scanResult.Inventory.Packages {
+		if pkg.LayerDetails != nil {
+			pkg.SetLayerDigest(pkg.LayerDetails.DiffID)
+		}
+	}
 
 	// Since we skipped storing absolute path in the main Scan function.
 	// Actually convert it to absolute path here.
@@ -343,21 +720,40 @@ func (s Scanner) ScanContainer(ctx context.Context, img image.Image, config *Sca
 			for i := range pkg.Locations {
 				pkg.Locations[i] = "/" + pkg.Locations[i]
 			}
+			for _, lp := range pkg.LocationProvenance {
+				lp.Location = "/" + lp.Location
+				if len(lp.ArchiveChain) > 0 {
+					lp.ArchiveChain[0] = "/" + lp.ArchiveChain[0]
+				} else {
+					lp.Path = "/" + lp.Path
+				}
+			}
+			for _, h := range pkg.OwnershipHints {
+				h.Location = "/" + h.Location
+			}
+			for _, d := range pkg.FileDigests {
+				d.Location = "/" + d.Location
+			}
 		}
 	}
 
 	// Run enrichers with the updated inventory.
-	enricherCfg := &enricher.Config{
-		Enrichers: enrichers,
-		ScanRoot: &scalibrfs.ScanRoot{
-			FS: imagefs,
-		},
-	}
-	enricherStatus, err := enricher.Run(ctx, enricherCfg, &scanResult.Inventory)
-	scanResult.PluginStatus = append(scanResult.PluginStatus, enricherStatus...)
-	if err != nil {
-		scanResult.Status.Status = plugin.ScanStatusFailed
-		scanResult.Status.FailureReason = err.Error()
+	if !interrupted {
+		enricherCfg := &enricher.Config{
+			Enrichers: enrichers,
+			ScanRoot: &scalibrfs.ScanRoot{
+				FS: imagefs,
+			},
+		}
+		enricherStatus, err := enricher.Run(ctx, enricherCfg, &scanResult.Inventory)
+		scanResult.PluginStatus = append(scanResult.PluginStatus, enricherStatus...)
+		if ctx.Err() != nil {
+			scanResult.Status.Status = plugin.ScanStatusInterrupted
+			scanResult.Status.FailureReason = "scan interrupted: " + context.Cause(ctx).Error()
+		} else if err != nil {
+			scanResult.Status.Status = plugin.ScanStatusFailed
+			scanResult.Status.FailureReason = err.Error()
+		}
 	}
 
 	// Keep the img variable alive till the end incase cleanup is not called on the parent.
@@ -371,12 +767,18 @@ type newScanResultOptions struct {
 	EndTime      time.Time
 	PluginStatus []*plugin.Status
 	Inventory    inventory.Inventory
+	ScanRoots    []*result.ScanRoot
 	Err          error
+	// Whether the scan was cancelled before all plugins ran.
+	Interrupted bool
 }
 
 func newScanResult(o *newScanResultOptions) *ScanResult {
 	status := &plugin.ScanStatus{}
-	if o.Err != nil {
+	if o.Interrupted {
+		status.Status = plugin.ScanStatusInterrupted
+		status.FailureReason = "scan interrupted: " + o.Err.Error()
+	} else if o.Err != nil {
 		status.Status = plugin.ScanStatusFailed
 		status.FailureReason = o.Err.Error()
 	} else {
@@ -389,6 +791,7 @@ func newScanResult(o *newScanResultOptions) *ScanResult {
 		Status:       status,
 		PluginStatus: o.PluginStatus,
 		Inventory:    o.Inventory,
+		ScanRoots:    o.ScanRoots,
 	}
 
 	// Sort results for better diffing.
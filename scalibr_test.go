@@ -17,15 +17,18 @@ package scalibr_test
 import (
 	"context"
 	"errors"
+	"fmt"
 	"io/fs"
 	"os"
 	"path/filepath"
 	"testing"
+	"testing/fstest"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	scalibr "github.com/google/osv-scalibr"
 	"github.com/google/osv-scalibr/annotator/cachedir"
+	"github.com/google/osv-scalibr/artifact/appliance"
 	"github.com/google/osv-scalibr/artifact/image"
 	"github.com/google/osv-scalibr/artifact/image/layerscanning/testing/fakeimage"
 	"github.com/google/osv-scalibr/artifact/image/layerscanning/testing/fakelayerbuilder"
@@ -38,6 +41,7 @@ import (
 	"github.com/google/osv-scalibr/log"
 	"github.com/google/osv-scalibr/packageindex"
 	"github.com/google/osv-scalibr/plugin"
+	"github.com/google/osv-scalibr/result"
 	fd "github.com/google/osv-scalibr/testing/fakedetector"
 	fen "github.com/google/osv-scalibr/testing/fakeenricher"
 	fe "github.com/google/osv-scalibr/testing/fakeextractor"
@@ -51,14 +55,17 @@ func TestScan(t *testing.T) {
 	extFailure := &plugin.ScanStatus{
 		Status:        plugin.ScanStatusFailed,
 		FailureReason: "file.txt: " + pluginFailure,
+		ErrorCounts:   []*plugin.ErrorCount{{Category: plugin.ErrorCategoryOther, Count: 1}},
 	}
 	detFailure := &plugin.ScanStatus{
 		Status:        plugin.ScanStatusFailed,
 		FailureReason: pluginFailure,
+		ErrorCounts:   []*plugin.ErrorCount{{Category: plugin.ErrorCategoryOther, Count: 1}},
 	}
 	enrFailure := &plugin.ScanStatus{
 		Status:        plugin.ScanStatusFailed,
 		FailureReason: "API: " + pluginFailure,
+		ErrorCounts:   []*plugin.ErrorCount{{Category: plugin.ErrorCategoryOther, Count: 1}},
 	}
 
 	tmp := t.TempDir()
@@ -292,7 +299,9 @@ func TestScan(t *testing.T) {
 			tc.want.StartTime = got.StartTime
 			tc.want.EndTime = got.EndTime
 
-			if diff := cmp.Diff(tc.want, got, fe.AllowUnexported); diff != "" {
+			// The scan roots are covered by TestScan_ScanRoots.
+			ignoreRoots := cmpopts.IgnoreFields(scalibr.ScanResult{}, "ScanRoots")
+			if diff := cmp.Diff(tc.want, got, fe.AllowUnexported, ignoreRoots); diff != "" {
 				t.Errorf("scalibr.New().Scan(%v): unexpected diff (-want +got):\n%s", tc.cfg, diff)
 			}
 		})
@@ -336,6 +345,9 @@ func TestScanContainer(t *testing.T) {
 								DiffID:  "diff-id-0",
 								Command: "command-0",
 							},
+							LocationProvenance: []*extractor.LocationProvenance{{
+								Location: "bar.txt", LayerDigest: "diff-id-0", Path: "bar.txt",
+							}},
 						},
 						{
 							Name:      "foo",
@@ -347,6 +359,9 @@ func TestScanContainer(t *testing.T) {
 								DiffID:  "diff-id-0",
 								Command: "command-0",
 							},
+							LocationProvenance: []*extractor.LocationProvenance{{
+								Location: "foo.txt", LayerDigest: "diff-id-0", Path: "foo.txt",
+							}},
 						},
 					},
 				},
@@ -380,6 +395,9 @@ func TestScanContainer(t *testing.T) {
 								DiffID:  "diff-id-0",
 								Command: "command-0",
 							},
+							LocationProvenance: []*extractor.LocationProvenance{{
+								Location: "foo.txt", LayerDigest: "diff-id-0", Path: "foo.txt",
+							}},
 						},
 					},
 				},
@@ -414,6 +432,9 @@ func TestScanContainer(t *testing.T) {
 								DiffID:  "diff-id-2",
 								Command: "command-2",
 							},
+							LocationProvenance: []*extractor.LocationProvenance{{
+								Location: "baz.txt", LayerDigest: "diff-id-2", Path: "baz.txt",
+							}},
 						},
 						{
 							Name:      "foo",
@@ -425,6 +446,9 @@ func TestScanContainer(t *testing.T) {
 								DiffID:  "diff-id-0",
 								Command: "command-0",
 							},
+							LocationProvenance: []*extractor.LocationProvenance{{
+								Location: "foo.txt", LayerDigest: "diff-id-0", Path: "foo.txt",
+							}},
 						},
 					},
 				},
@@ -460,6 +484,9 @@ func TestScanContainer(t *testing.T) {
 								DiffID:  "diff-id-3",
 								Command: "command-3",
 							},
+							LocationProvenance: []*extractor.LocationProvenance{{
+								Location: "bar.txt", LayerDigest: "diff-id-3", Path: "bar.txt",
+							}},
 						},
 						{
 							Name:      "baz",
@@ -471,6 +498,9 @@ func TestScanContainer(t *testing.T) {
 								DiffID:  "diff-id-2",
 								Command: "command-2",
 							},
+							LocationProvenance: []*extractor.LocationProvenance{{
+								Location: "baz.txt", LayerDigest: "diff-id-2", Path: "baz.txt",
+							}},
 						},
 						{
 							Name:      "foo",
@@ -482,6 +512,9 @@ func TestScanContainer(t *testing.T) {
 								DiffID:  "diff-id-0",
 								Command: "command-0",
 							},
+							LocationProvenance: []*extractor.LocationProvenance{{
+								Location: "foo.txt", LayerDigest: "diff-id-0", Path: "foo.txt",
+							}},
 						},
 					},
 				},
@@ -518,6 +551,9 @@ func TestScanContainer(t *testing.T) {
 								DiffID:  "diff-id-3",
 								Command: "command-3",
 							},
+							LocationProvenance: []*extractor.LocationProvenance{{
+								Location: "bar.txt", LayerDigest: "diff-id-3", Path: "bar.txt",
+							}},
 						},
 						{
 							Name:      "baz",
@@ -529,6 +565,9 @@ func TestScanContainer(t *testing.T) {
 								DiffID:  "diff-id-2",
 								Command: "command-2",
 							},
+							LocationProvenance: []*extractor.LocationProvenance{{
+								Location: "baz.txt", LayerDigest: "diff-id-2", Path: "baz.txt",
+							}},
 						},
 						{
 							Name:      "foo",
@@ -540,6 +579,9 @@ func TestScanContainer(t *testing.T) {
 								DiffID:  "diff-id-0",
 								Command: "command-0",
 							},
+							LocationProvenance: []*extractor.LocationProvenance{{
+								Location: "foo.txt", LayerDigest: "diff-id-0", Path: "foo.txt",
+							}},
 						},
 						{
 							Name:      "foo2",
@@ -551,6 +593,9 @@ func TestScanContainer(t *testing.T) {
 								DiffID:  "diff-id-4",
 								Command: "command-4",
 							},
+							LocationProvenance: []*extractor.LocationProvenance{{
+								Location: "foo.txt", LayerDigest: "diff-id-4", Path: "foo.txt",
+							}},
 						},
 					},
 				},
@@ -636,6 +681,15 @@ func TestEnableRequiredPlugins(t *testing.T) {
 			},
 			wantPlugins: []string{"foo", "python/wheelegg"},
 		},
+		{
+			name: "transitively auto-loaded required plugins",
+			cfg: scalibr.ScanConfig{
+				Plugins: []plugin.Plugin{
+					fen.MustNew(t, &fen.Config{Name: "foo", RequiredPlugins: []string{"reachability/java"}}),
+				},
+			},
+			wantPlugins: []string{"foo", "reachability/java", "java/archive"},
+		},
 		{
 			name: "required extractor doesn't exist",
 			cfg: scalibr.ScanConfig{
@@ -831,6 +885,274 @@ func TestErrorOnFSErrors(t *testing.T) {
 	}
 }
 
+func TestScan_ScanRoots(t *testing.T) {
+	tmp, err := filepath.EvalSymlinks(t.TempDir())
+	if err != nil {
+		t.Fatalf("filepath.EvalSymlinks(): %v", err)
+	}
+	dir := filepath.Join(tmp, "dir")
+	link := filepath.Join(tmp, "link")
+	if err := os.MkdirAll(filepath.Join(dir, "sub"), 0755); err != nil {
+		t.Fatalf("os.MkdirAll(): %v", err)
+	}
+	if err := os.Symlink(dir, link); err != nil {
+		t.Fatalf("os.Symlink(): %v", err)
+	}
+	_ = os.WriteFile(filepath.Join(dir, "sub", "file.txt"), []byte("Content"), 0644)
+
+	for _, canonical := range []bool{false, true} {
+		t.Run(fmt.Sprintf("canonical_%t", canonical), func(t *testing.T) {
+			cfg := &scalibr.ScanConfig{
+				ScanRoots: []*scalibrfs.ScanRoot{{FS: scalibrfs.DirFS(link), Path: link}},
+				Plugins: []plugin.Plugin{
+					fe.New("python/wheelegg", 1, []string{"sub/file.txt"}, map[string]fe.NamesErr{"sub/file.txt": {Names: []string{"software"}}}),
+				},
+				// The file is referenced through the canonical path of the scan root.
+				PathsToExtract:       []string{filepath.Join(dir, "sub", "file.txt")},
+				StoreAbsolutePath:    true,
+				ReportCanonicalPaths: canonical,
+			}
+
+			got := scalibr.New().Scan(context.Background(), cfg)
+			if got.Status.Status != plugin.ScanStatusSucceeded {
+				t.Fatalf("Scan() status: %v, want %v", got.Status, plugin.ScanStatusSucceeded)
+			}
+
+			wantRoots := []*result.ScanRoot{{Path: link, CanonicalPath: dir}}
+			if diff := cmp.Diff(wantRoots, got.ScanRoots); diff != "" {
+				t.Errorf("Scan() returned unexpected scan roots (-want +got):\n%s", diff)
+			}
+
+			root := link
+			if canonical {
+				root = dir
+			}
+			wantLocations := []string{filepath.Join(root, "sub", "file.txt")}
+			if len(got.Inventory.Packages) != 1 {
+				t.Fatalf("Scan() returned %d packages, want 1", len(got.Inventory.Packages))
+			}
+			if diff := cmp.Diff(wantLocations, got.Inventory.Packages[0].Locations); diff != "" {
+				t.Errorf("Scan() returned unexpected package locations (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestScan_ScanRootScopes(t *testing.T) {
+	osDir := t.TempDir()
+	appDir := t.TempDir()
+	for _, dir := range []string{osDir, appDir} {
+		for _, f := range []string{"status", "file.txt"} {
+			if err := os.WriteFile(filepath.Join(dir, f), []byte("content"), 0644); err != nil {
+				t.Fatalf("os.WriteFile(%q): %v", f, err)
+			}
+		}
+	}
+	osRoot := &scalibrfs.ScanRoot{FS: scalibrfs.DirFS(osDir), Path: osDir}
+	appRoot := &scalibrfs.ScanRoot{FS: scalibrfs.DirFS(appDir), Path: appDir}
+	dpkgEx := fe.New("os/dpkg", 1, []string{"status"}, map[string]fe.NamesErr{"status": {Names: []string{"dpkg-pkg"}}})
+	pyEx := fe.New("python/wheelegg", 1, []string{"file.txt"}, map[string]fe.NamesErr{"file.txt": {Names: []string{"py-pkg"}}})
+
+	testCases := []struct {
+		desc       string
+		scopes     []*scalibr.ScanRootScope
+		wantPkgs   []string
+		wantStatus plugin.ScanStatusEnum
+	}{
+		{
+			desc:       "no_scopes",
+			wantPkgs:   []string{"dpkg-pkg@" + osDir, "dpkg-pkg@" + appDir, "py-pkg@" + osDir, "py-pkg@" + appDir},
+			wantStatus: plugin.ScanStatusSucceeded,
+		},
+		{
+			desc: "plugins_per_root",
+			scopes: []*scalibr.ScanRootScope{
+				{ScanRoot: osRoot, Plugins: []string{"os"}},
+				{ScanRoot: appRoot, Plugins: []string{"python"}},
+			},
+			wantPkgs:   []string{"dpkg-pkg@" + osDir, "py-pkg@" + appDir},
+			wantStatus: plugin.ScanStatusSucceeded,
+		},
+		{
+			desc: "excluded_plugins",
+			scopes: []*scalibr.ScanRootScope{
+				{ScanRoot: appRoot, ExcludePlugins: []string{"os/dpkg"}},
+			},
+			wantPkgs:   []string{"dpkg-pkg@" + osDir, "py-pkg@" + osDir, "py-pkg@" + appDir},
+			wantStatus: plugin.ScanStatusSucceeded,
+		},
+		{
+			desc: "unknown_scan_root",
+			scopes: []*scalibr.ScanRootScope{
+				{ScanRoot: &scalibrfs.ScanRoot{FS: scalibrfs.DirFS(osDir), Path: osDir}, Plugins: []string{"os"}},
+			},
+			wantStatus: plugin.ScanStatusFailed,
+		},
+		{
+			desc: "several_scopes_for_root",
+			scopes: []*scalibr.ScanRootScope{
+				{ScanRoot: osRoot, Plugins: []string{"os"}},
+				{ScanRoot: osRoot, Plugins: []string{"python"}},
+			},
+			wantStatus: plugin.ScanStatusFailed,
+		},
+		{
+			desc: "unknown_plugin",
+			scopes: []*scalibr.ScanRootScope{
+				{ScanRoot: osRoot, Plugins: []string{"unknown"}},
+			},
+			wantStatus: plugin.ScanStatusFailed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			cfg := &scalibr.ScanConfig{
+				Plugins:           []plugin.Plugin{dpkgEx, pyEx},
+				ScanRoots:         []*scalibrfs.ScanRoot{osRoot, appRoot},
+				ScanRootScopes:    tc.scopes,
+				StoreAbsolutePath: true,
+			}
+			got := scalibr.New().Scan(context.Background(), cfg)
+			if got.Status.Status != tc.wantStatus {
+				t.Fatalf("scalibr.New().Scan(%v): got status %v, want %v (%s)", cfg, got.Status.Status, tc.wantStatus, got.Status.FailureReason)
+			}
+
+			var gotPkgs []string
+			for _, p := range got.Inventory.Packages {
+				gotPkgs = append(gotPkgs, p.Name+"@"+filepath.Dir(p.Locations[0]))
+			}
+			sortStrings := cmpopts.SortSlices(func(a, b string) bool { return a < b })
+			if diff := cmp.Diff(tc.wantPkgs, gotPkgs, sortStrings, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("scalibr.New().Scan(%v): unexpected packages (-want +got):\n%s", cfg, diff)
+			}
+		})
+	}
+}
+
+func TestReportResourceUsage(t *testing.T) {
+	tmp := t.TempDir()
+	_ = os.WriteFile(filepath.Join(tmp, "file.txt"), []byte("Content"), 0644)
+
+	for _, report := range []bool{false, true} {
+		t.Run(fmt.Sprintf("report_%t", report), func(t *testing.T) {
+			cfg := &scalibr.ScanConfig{
+				ScanRoots: []*scalibrfs.ScanRoot{{FS: scalibrfs.DirFS(tmp), Path: tmp}},
+				Plugins: []plugin.Plugin{
+					fe.New("python/wheelegg", 1, []string{"file.txt"}, map[string]fe.NamesErr{"file.txt": {Names: []string{"software"}}}),
+				},
+				ReportResourceUsage: report,
+			}
+
+			got := scalibr.New().Scan(context.Background(), cfg).ResourceUsage
+
+			if !report {
+				if got != nil {
+					t.Errorf("Scan() returned resource usage %+v, want nil", got)
+				}
+				return
+			}
+			if got == nil {
+				t.Fatal("Scan() returned no resource usage")
+			}
+			if got.FilesOpened != 1 {
+				t.Errorf("Scan() resource usage: FilesOpened = %d, want 1", got.FilesOpened)
+			}
+			if len(got.Plugins) != 1 || got.Plugins[0].Name != "python/wheelegg" {
+				t.Fatalf("Scan() resource usage: Plugins = %v, want python/wheelegg", got.Plugins)
+			}
+			if p := got.Plugins[0]; p.FilesExtracted != 1 {
+				t.Errorf("Scan() resource usage of python/wheelegg: FilesExtracted = %d, want 1", p.FilesExtracted)
+			}
+		})
+	}
+}
+
+func TestIOBudget(t *testing.T) {
+	tmp := t.TempDir()
+	_ = os.WriteFile(filepath.Join(tmp, "file.txt"), []byte("Content"), 0644)
+
+	cfg := &scalibr.ScanConfig{
+		ScanRoots: []*scalibrfs.ScanRoot{{FS: scalibrfs.DirFS(tmp), Path: tmp}},
+		Plugins: []plugin.Plugin{
+			fe.New("python/wheelegg", 1, []string{"file.txt"}, map[string]fe.NamesErr{"file.txt": {Names: []string{"software"}}}),
+		},
+		IOBudget: &scalibrfs.IOBudget{MaxFileSizeBytes: 3},
+	}
+
+	got := scalibr.New().Scan(context.Background(), cfg)
+
+	if len(got.Inventory.Packages) != 0 {
+		t.Errorf("Scan() returned %d packages, want 0", len(got.Inventory.Packages))
+	}
+	want := []*plugin.ErrorCount{{Category: plugin.ErrorCategoryBudgetExceeded, Count: 1}}
+	for _, s := range got.PluginStatus {
+		if s.Name != "python/wheelegg" {
+			continue
+		}
+		if diff := cmp.Diff(want, s.Status.ErrorCounts); diff != "" {
+			t.Errorf("Scan() returned unexpected error counts (-want +got):\n%s", diff)
+		}
+	}
+}
+
+// cancelingDetector cancels the scan when it runs.
+type cancelingDetector struct {
+	cancel context.CancelCauseFunc
+}
+
+func (cancelingDetector) Name() string                       { return "canceling-detector" }
+func (cancelingDetector) Version() int                       { return 0 }
+func (cancelingDetector) RequiredExtractors() []string       { return nil }
+func (cancelingDetector) DetectedFinding() inventory.Finding { return inventory.Finding{} }
+func (cancelingDetector) Requirements() *plugin.Capabilities { return &plugin.Capabilities{} }
+func (d cancelingDetector) Scan(ctx context.Context, scanRoot *scalibrfs.ScanRoot, px *packageindex.PackageIndex) (inventory.Finding, error) {
+	d.cancel(errors.New("received SIGTERM"))
+	return inventory.Finding{}, nil
+}
+
+func TestScan_Interrupted(t *testing.T) {
+	tmp := t.TempDir()
+	_ = os.WriteFile(filepath.Join(tmp, "file.txt"), []byte("Content"), 0644)
+
+	ctx, cancel := context.WithCancelCause(context.Background())
+	defer cancel(nil)
+	fakeExtractor := fe.New("python/wheelegg", 1, []string{"file.txt"}, map[string]fe.NamesErr{"file.txt": {Names: []string{"software"}}})
+	cfg := &scalibr.ScanConfig{
+		ScanRoots: []*scalibrfs.ScanRoot{{FS: scalibrfs.DirFS(tmp), Path: tmp}},
+		Plugins: []plugin.Plugin{
+			fakeExtractor,
+			cancelingDetector{cancel: cancel},
+			fd.New().WithName("detector").WithGenericFinding(&inventory.GenericFinding{
+				Adv: &inventory.GenericFindingAdvisory{ID: &inventory.AdvisoryID{Reference: "CVE-1234"}},
+			}),
+		},
+	}
+
+	got := scalibr.New().Scan(ctx, cfg)
+
+	wantStatus := &plugin.ScanStatus{
+		Status:        plugin.ScanStatusInterrupted,
+		FailureReason: "scan interrupted: received SIGTERM",
+	}
+	if diff := cmp.Diff(wantStatus, got.Status); diff != "" {
+		t.Errorf("Scan() returned unexpected status (-want +got):\n%s", diff)
+	}
+	// The packages found before the scan was cancelled are kept.
+	wantPkgs := []*extractor.Package{{
+		Name:      "software",
+		Locations: []string{"file.txt"},
+		Plugins:   []string{fakeExtractor.Name()},
+	}}
+	if diff := cmp.Diff(wantPkgs, got.Inventory.Packages, fe.AllowUnexported); diff != "" {
+		t.Errorf("Scan() returned unexpected packages (-want +got):\n%s", diff)
+	}
+	// The detectors after the canceling one don't run.
+	if len(got.Inventory.GenericFindings) != 0 {
+		t.Errorf("Scan() returned %d findings, want 0", len(got.Inventory.GenericFindings))
+	}
+}
+
 func TestAnnotator(t *testing.T) {
 	tmp := t.TempDir()
 	tmpRoot := []*scalibrfs.ScanRoot{{FS: scalibrfs.DirFS(tmp), Path: tmp}}
@@ -868,3 +1190,172 @@ func TestAnnotator(t *testing.T) {
 		t.Errorf("scalibr.New().Scan(%v): unexpected diff (-want +got):\n%s", cfg, diff)
 	}
 }
+
+func TestScanLXDContainers(t *testing.T) {
+	tmp := t.TempDir()
+	pools := filepath.Join(tmp, "var", "lib", "lxd", "storage-pools", "default", "containers")
+	for _, name := range []string{"web", "db"} {
+		dir := filepath.Join(pools, name, "rootfs")
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatalf("os.MkdirAll(%s): %v", dir, err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, "file.txt"), []byte(name), 0644); err != nil {
+			t.Fatalf("os.WriteFile(): %v", err)
+		}
+	}
+
+	fakeExtractor := fe.New(
+		"python/wheelegg", 1, []string{"file.txt"},
+		map[string]fe.NamesErr{"file.txt": {Names: []string{"software"}}},
+	)
+	cfg := &scalibr.ScanConfig{Plugins: []plugin.Plugin{fakeExtractor}}
+	host := &scalibrfs.ScanRoot{FS: scalibrfs.DirFS(tmp), Path: tmp}
+
+	got, err := scalibr.New().ScanLXDContainers(context.Background(), host, cfg)
+	if err != nil {
+		t.Fatalf("ScanLXDContainers(): %v", err)
+	}
+
+	var gotNames []string
+	for _, r := range got {
+		gotNames = append(gotNames, r.Container.Name)
+		if r.Result.Status.Status != plugin.ScanStatusSucceeded {
+			t.Errorf("ScanLXDContainers(): container %s: got status %v, want success", r.Container.Name, r.Result.Status)
+		}
+		wantPkgs := []*extractor.Package{{
+			Name:      "software",
+			Locations: []string{"file.txt"},
+			Plugins:   []string{fakeExtractor.Name()},
+		}}
+		if diff := cmp.Diff(wantPkgs, r.Result.Inventory.Packages, fe.AllowUnexported); diff != "" {
+			t.Errorf("ScanLXDContainers(): container %s: unexpected packages (-want +got):\n%s", r.Container.Name, diff)
+		}
+	}
+	if diff := cmp.Diff([]string{"db", "web"}, gotNames); diff != "" {
+		t.Errorf("ScanLXDContainers(): unexpected containers (-want +got):\n%s", diff)
+	}
+}
+
+func TestScanAppliance(t *testing.T) {
+	vhd := make([]byte, 1024)
+	copy(vhd[len(vhd)-512:], "conectix")
+	path := filepath.Join(t.TempDir(), "disk.vhd")
+	if err := os.WriteFile(path, vhd, 0644); err != nil {
+		t.Fatalf("os.WriteFile(): %v", err)
+	}
+	app, err := appliance.Open(path)
+	if err != nil {
+		t.Fatalf("appliance.Open(%q): %v", path, err)
+	}
+	defer app.Close()
+
+	fakeExtractor := fe.New(
+		"python/wheelegg", 1, []string{"file.txt"},
+		map[string]fe.NamesErr{"file.txt": {Names: []string{"software"}}},
+	)
+	cfg := &scalibr.ScanConfig{Plugins: []plugin.Plugin{fakeExtractor}}
+	opener := func(d *appliance.Disk) (scalibrfs.FS, error) {
+		return fstest.MapFS{"file.txt": {Data: []byte(d.ID)}}, nil
+	}
+
+	got, err := scalibr.New().ScanAppliance(context.Background(), app, opener, cfg)
+	if err != nil {
+		t.Fatalf("ScanAppliance(): %v", err)
+	}
+	if len(got) != 1 {
+		t.Fatalf("ScanAppliance(): got %d results, want 1", len(got))
+	}
+	if got[0].Disk.ID != "disk.vhd" {
+		t.Errorf("ScanAppliance(): got disk %q, want disk.vhd", got[0].Disk.ID)
+	}
+	if got[0].Result.Status.Status != plugin.ScanStatusSucceeded {
+		t.Errorf("ScanAppliance(): got status %v, want success", got[0].Result.Status)
+	}
+	wantPkgs := []*extractor.Package{{
+		Name:      "software",
+		Locations: []string{"file.txt"},
+		Plugins:   []string{fakeExtractor.Name()},
+	}}
+	if diff := cmp.Diff(wantPkgs, got[0].Result.Inventory.Packages, fe.AllowUnexported); diff != "" {
+		t.Errorf("ScanAppliance(): unexpected packages (-want +got):\n%s", diff)
+	}
+
+	got, err = scalibr.New().ScanAppliance(context.Background(), app, nil, cfg)
+	if err != nil {
+		t.Fatalf("ScanAppliance(): %v", err)
+	}
+	if got[0].Result.Status.Status != plugin.ScanStatusFailed {
+		t.Errorf("ScanAppliance() without disk opener: got status %v, want failure", got[0].Result.Status)
+	}
+}
+
+func TestEnrich(t *testing.T) {
+	pkg := &extractor.Package{Name: "software", Locations: []string{"file.txt"}}
+	enrichedPkg := &extractor.Package{Name: "software", Version: "1.0", Locations: []string{"file.txt"}}
+	newEnricher := func() *fen.Enricher {
+		return fen.MustNew(t, &fen.Config{
+			Name:         "enricher",
+			Version:      1,
+			Capabilities: &plugin.Capabilities{Network: plugin.NetworkOnline},
+			WantEnrich: map[uint64]fen.InventoryAndErr{
+				fen.MustHash(t, &enricher.ScanInput{}, &inventory.Inventory{Packages: []*extractor.Package{pkg}}): {
+					Inventory: &inventory.Inventory{Packages: []*extractor.Package{enrichedPkg}},
+				},
+			},
+		})
+	}
+	success := &plugin.ScanStatus{Status: plugin.ScanStatusSucceeded}
+
+	testCases := []struct {
+		desc string
+		cfg  *scalibr.ScanConfig
+		want *scalibr.ScanResult
+	}{
+		{
+			desc: "enrichers_run",
+			cfg: &scalibr.ScanConfig{
+				Plugins:      []plugin.Plugin{newEnricher()},
+				Capabilities: &plugin.Capabilities{Network: plugin.NetworkOnline},
+			},
+			want: &scalibr.ScanResult{
+				Status: success,
+				PluginStatus: []*plugin.Status{
+					{Name: "enricher", Version: 1, Status: success},
+					{Name: "python/wheelegg", Version: 1, Status: success},
+				},
+				Inventory: inventory.Inventory{Packages: []*extractor.Package{enrichedPkg}},
+			},
+		},
+		{
+			desc: "unsatisfied_requirements",
+			cfg: &scalibr.ScanConfig{
+				Plugins:      []plugin.Plugin{newEnricher()},
+				Capabilities: &plugin.Capabilities{Network: plugin.NetworkOffline},
+			},
+			want: &scalibr.ScanResult{
+				Status: &plugin.ScanStatus{
+					Status:        plugin.ScanStatusFailed,
+					FailureReason: "plugin enricher can't be enabled: needs network access but scan environment doesn't provide it",
+				},
+				PluginStatus: []*plugin.Status{
+					{Name: "python/wheelegg", Version: 1, Status: success},
+				},
+				Inventory: inventory.Inventory{Packages: []*extractor.Package{pkg}},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			sr := &scalibr.ScanResult{
+				Status:       success,
+				PluginStatus: []*plugin.Status{{Name: "python/wheelegg", Version: 1, Status: success}},
+				Inventory:    inventory.Inventory{Packages: []*extractor.Package{deepcopy.Copy(pkg).(*extractor.Package)}},
+			}
+			got := scalibr.New().Enrich(t.Context(), sr, tc.cfg)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Enrich() returned unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -212,10 +212,18 @@ func TestVersion_Compare_Ecosystems(t *testing.T) {
 			name: "Hex",
 			file: "semver-versions.txt",
 		},
+		{
+			name: "Julia",
+			file: "semver-versions.txt",
+		},
 		{
 			name: "Maven",
 			file: "maven-versions.txt",
 		},
+		{
+			name: "opam",
+			file: "opam-versions.txt",
+		},
 		{
 			name: "Maven",
 			file: "maven-versions-generated.txt",
@@ -71,6 +71,8 @@ func Parse(str string, ecosystem string) (Version, error) {
 		return parseHackageVersion(str)
 	case "Hex":
 		return parseSemverVersion(str), nil
+	case "Julia":
+		return parseSemverVersion(str), nil
 	case "Mageia":
 		return parseRedHatVersion(str), nil
 	case "Maven":
@@ -81,6 +83,8 @@ func Parse(str string, ecosystem string) (Version, error) {
 		return parseSemverVersion(str), nil
 	case "NuGet":
 		return parseNuGetVersion(str), nil
+	case "opam":
+		return parseOpamVersion(str), nil
 	case "openEuler":
 		return parseRedHatVersion(str), nil
 	case "openSUSE":
@@ -35,11 +35,13 @@ var ecosystems = []string{
 	"GHC",
 	"Go",
 	"Hex",
+	"Julia",
 	"Mageia",
 	"Maven",
 	"MinimOS",
 	"npm",
 	"NuGet",
+	"opam",
 	"openEuler",
 	"openSUSE",
 	"Packagist",
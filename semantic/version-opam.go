@@ -0,0 +1,32 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package semantic
+
+import (
+	"strings"
+)
+
+// opamVersion is the version of an opam package. opam orders versions like
+// Debian upstream versions, without epochs or revisions.
+// See https://opam.ocaml.org/doc/Manual.html#Version-ordering
+type opamVersion string
+
+func (v opamVersion) CompareStr(str string) (int, error) {
+	return compareDebianVersions(string(v), strings.TrimSpace(str))
+}
+
+func parseOpamVersion(str string) opamVersion {
+	return opamVersion(strings.TrimSpace(str))
+}
@@ -0,0 +1,76 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stats
+
+import "time"
+
+// DefaultProgressInterval is the interval between two progress events if none is configured.
+const DefaultProgressInterval = 2 * time.Second
+
+// Progress is a snapshot of the progress of a filesystem scan.
+type Progress struct {
+	// The scan root that is currently being walked.
+	ScanRoot string
+	// The path of the inode that was visited last, relative to ScanRoot.
+	CurrentPath string
+	// Number of inodes (files and directories) visited so far.
+	InodesVisited int
+	// Number of directories visited so far.
+	DirsVisited int
+	// Number of files that were required by at least one extractor.
+	FilesMatched int
+	// Number of Extract calls per extractor name.
+	ExtractorCalls map[string]int
+	// Number of packages found per extractor name.
+	ExtractorPackages map[string]int
+	// Time since the filesystem walk started.
+	Elapsed time.Duration
+	// Expected number of inodes to visit, e.g. from a previous scan of the same
+	// host. 0 if unknown.
+	ExpectedInodes int
+	// Whether this is the last event of the filesystem walk.
+	Done bool
+}
+
+// ETA estimates the remaining time of the filesystem walk based on the rate of
+// visited inodes so far. Returns false if the expected number of inodes is unknown
+// or no estimate can be made yet.
+func (p *Progress) ETA() (time.Duration, bool) {
+	if p.Done {
+		return 0, true
+	}
+	if p.ExpectedInodes <= 0 || p.InodesVisited == 0 || p.Elapsed <= 0 {
+		return 0, false
+	}
+	remaining := p.ExpectedInodes - p.InodesVisited
+	if remaining <= 0 {
+		return 0, true
+	}
+	perInode := p.Elapsed / time.Duration(p.InodesVisited)
+	return perInode * time.Duration(remaining), true
+}
+
+// ProgressReporter receives periodic progress events during a scan, e.g. to
+// display a progress bar. ReportProgress is called from the scanning goroutine
+// so implementations should return quickly.
+type ProgressReporter interface {
+	ReportProgress(p *Progress)
+}
+
+// ProgressFunc is an adapter to use an ordinary function as a ProgressReporter.
+type ProgressFunc func(p *Progress)
+
+// ReportProgress calls f(p).
+func (f ProgressFunc) ReportProgress(p *Progress) { f(p) }
@@ -0,0 +1,65 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stats_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/osv-scalibr/stats"
+)
+
+func TestETA(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		progress *stats.Progress
+		wantETA  time.Duration
+		wantOK   bool
+	}{
+		{
+			desc:     "unknown_total",
+			progress: &stats.Progress{InodesVisited: 100, Elapsed: time.Second},
+		},
+		{
+			desc:     "nothing_visited_yet",
+			progress: &stats.Progress{ExpectedInodes: 100},
+		},
+		{
+			desc:     "halfway",
+			progress: &stats.Progress{InodesVisited: 50, ExpectedInodes: 100, Elapsed: 10 * time.Second},
+			wantETA:  10 * time.Second,
+			wantOK:   true,
+		},
+		{
+			desc:     "more_inodes_than_expected",
+			progress: &stats.Progress{InodesVisited: 150, ExpectedInodes: 100, Elapsed: 10 * time.Second},
+			wantOK:   true,
+		},
+		{
+			desc:     "done",
+			progress: &stats.Progress{InodesVisited: 150, Elapsed: 10 * time.Second, Done: true},
+			wantOK:   true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			gotETA, gotOK := tc.progress.ETA()
+			if gotETA != tc.wantETA || gotOK != tc.wantOK {
+				t.Errorf("ETA() = %v, %t, want %v, %t", gotETA, gotOK, tc.wantETA, tc.wantOK)
+			}
+		})
+	}
+}
@@ -0,0 +1,211 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package resourceusage measures the resources the scanner process uses during
+// a scan.
+package resourceusage
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"runtime/metrics"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	scalibrfs "github.com/google/osv-scalibr/fs"
+	"github.com/google/osv-scalibr/result"
+)
+
+// Tracker tracks the resource usage of a scan. It's safe for concurrent use.
+type Tracker struct {
+	startCPU    time.Duration
+	filesOpened atomic.Int64
+	bytesRead   atomic.Int64
+
+	mu      sync.Mutex
+	plugins map[string]*result.PluginResourceUsage
+}
+
+// Start returns a tracker that measures the resources used from now on.
+func Start() *Tracker {
+	return &Tracker{startCPU: cpuTime()}
+}
+
+// Usage returns the resources used since the tracker was started.
+func (t *Tracker) Usage() *result.ResourceUsage {
+	u := &result.ResourceUsage{
+		PeakMemoryBytes: peakMemoryBytes(),
+		CPUTime:         cpuTime() - t.startCPU,
+		FilesOpened:     t.filesOpened.Load(),
+		BytesRead:       t.bytesRead.Load(),
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, p := range t.plugins {
+		c := *p
+		u.Plugins = append(u.Plugins, &c)
+	}
+	slices.SortFunc(u.Plugins, func(a, b *result.PluginResourceUsage) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return u
+}
+
+// PluginRun measures a single run of a plugin, e.g. the extraction of a file.
+type PluginRun struct {
+	t         *Tracker
+	name      string
+	start     time.Time
+	startCPU  time.Duration
+	bytesRead int64
+	allocs    int64
+}
+
+// StartPlugin starts measuring a run of the named plugin. The plugins have to
+// run sequentially for the measurement to be accurate. A nil tracker returns a
+// nil run, which doesn't record anything.
+func (t *Tracker) StartPlugin(name string) *PluginRun {
+	if t == nil {
+		return nil
+	}
+	return &PluginRun{
+		t:         t,
+		name:      name,
+		start:     time.Now(),
+		startCPU:  cpuTime(),
+		bytesRead: t.bytesRead.Load(),
+		allocs:    allocatedBytes(),
+	}
+}
+
+// Stop adds the resources used since the run was started to the usage of the
+// plugin. filesExtracted is the number of files that were passed to the plugin.
+func (r *PluginRun) Stop(filesExtracted int64) {
+	if r == nil {
+		return
+	}
+	wall := time.Since(r.start)
+	cpu := cpuTime() - r.startCPU
+	bytesRead := r.t.bytesRead.Load() - r.bytesRead
+	allocs := allocatedBytes() - r.allocs
+
+	r.t.mu.Lock()
+	defer r.t.mu.Unlock()
+	if r.t.plugins == nil {
+		r.t.plugins = make(map[string]*result.PluginResourceUsage)
+	}
+	p, ok := r.t.plugins[r.name]
+	if !ok {
+		p = &result.PluginResourceUsage{Name: r.name}
+		r.t.plugins[r.name] = p
+	}
+	p.WallTime += wall
+	p.CPUTime += cpu
+	p.FilesExtracted += filesExtracted
+	p.BytesRead += bytesRead
+	p.AllocatedBytes += allocs
+}
+
+// allocatedBytes returns the cumulative number of bytes allocated on the heap
+// by the process.
+func allocatedBytes() int64 {
+	s := []metrics.Sample{{Name: "/gc/heap/allocs:bytes"}}
+	metrics.Read(s)
+	if s[0].Value.Kind() != metrics.KindUint64 {
+		return 0
+	}
+	return int64(s[0].Value.Uint64())
+}
+
+// WrapFS returns a filesystem that counts the files opened and the bytes read
+// through it. A nil tracker returns fsys unchanged.
+func (t *Tracker) WrapFS(fsys scalibrfs.FS) scalibrfs.FS {
+	if t == nil {
+		return fsys
+	}
+	return &countingFS{FS: fsys, t: t}
+}
+
+type countingFS struct {
+	scalibrfs.FS
+
+	t *Tracker
+}
+
+// Open opens the named file.
+func (c *countingFS) Open(name string) (fs.File, error) {
+	f, err := c.FS.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	// Directories are opened to list their entries, which isn't a file read.
+	if info, err := f.Stat(); err == nil && !info.IsDir() {
+		c.t.filesOpened.Add(1)
+	}
+	cf := &countingFile{File: f, t: c.t}
+	if d, ok := f.(fs.ReadDirFile); ok {
+		return &countingDirFile{countingFile: cf, dir: d}, nil
+	}
+	return cf, nil
+}
+
+// countingFile counts the bytes read from a file.
+type countingFile struct {
+	fs.File
+
+	t *Tracker
+}
+
+func (f *countingFile) Read(p []byte) (int, error) {
+	n, err := f.File.Read(p)
+	f.t.bytesRead.Add(int64(n))
+	return n, err
+}
+
+// ReadAt implements io.ReaderAt, which scalibrfs.FS files are required to
+// support.
+func (f *countingFile) ReadAt(p []byte, off int64) (int, error) {
+	r, ok := f.File.(io.ReaderAt)
+	if !ok {
+		return 0, &fs.PathError{Op: "readat", Err: errors.ErrUnsupported}
+	}
+	n, err := r.ReadAt(p, off)
+	f.t.bytesRead.Add(int64(n))
+	return n, err
+}
+
+// Seek implements io.Seeker if the underlying file does.
+func (f *countingFile) Seek(offset int64, whence int) (int64, error) {
+	s, ok := f.File.(io.Seeker)
+	if !ok {
+		return 0, &fs.PathError{Op: "seek", Err: errors.ErrUnsupported}
+	}
+	return s.Seek(offset, whence)
+}
+
+// countingDirFile keeps the fs.ReadDirFile implementation of the underlying
+// file so that directories can still be listed incrementally.
+type countingDirFile struct {
+	*countingFile
+
+	dir fs.ReadDirFile
+}
+
+func (f *countingDirFile) ReadDir(n int) ([]fs.DirEntry, error) {
+	return f.dir.ReadDir(n)
+}
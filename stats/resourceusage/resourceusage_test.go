@@ -0,0 +1,118 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resourceusage_test
+
+import (
+	"io"
+	"io/fs"
+	"testing"
+	"testing/fstest"
+
+	"github.com/google/osv-scalibr/stats/resourceusage"
+)
+
+func TestWrapFS(t *testing.T) {
+	tracker := resourceusage.Start()
+	fsys := tracker.WrapFS(fstest.MapFS{
+		"a.txt":     {Data: []byte("hello")},
+		"dir/b.txt": {Data: []byte("world!")},
+	})
+	for _, path := range []string{"a.txt", "dir/b.txt"} {
+		f, err := fsys.Open(path)
+		if err != nil {
+			t.Fatalf("Open(%q): %v", path, err)
+		}
+		if _, err := io.ReadAll(f); err != nil {
+			t.Fatalf("ReadAll(%q): %v", path, err)
+		}
+		f.Close()
+	}
+	d, err := fsys.Open("dir")
+	if err != nil {
+		t.Fatalf("Open(dir): %v", err)
+	}
+	if _, ok := d.(fs.ReadDirFile); !ok {
+		t.Errorf("Open(dir) returned %T, want fs.ReadDirFile", d)
+	}
+	d.Close()
+
+	got := tracker.Usage()
+	if got.FilesOpened != 2 {
+		t.Errorf("Usage().FilesOpened = %d, want 2", got.FilesOpened)
+	}
+	if got.BytesRead != 11 {
+		t.Errorf("Usage().BytesRead = %d, want 11", got.BytesRead)
+	}
+	if got.CPUTime < 0 {
+		t.Errorf("Usage().CPUTime = %v, want >= 0", got.CPUTime)
+	}
+}
+
+func TestWrapFS_NilTracker(t *testing.T) {
+	var tracker *resourceusage.Tracker
+	got := tracker.WrapFS(fstest.MapFS{})
+	if _, ok := got.(fstest.MapFS); !ok {
+		t.Errorf("WrapFS() on a nil tracker returned %T, want the unwrapped filesystem", got)
+	}
+}
+
+var sink []byte
+
+func TestStartPlugin(t *testing.T) {
+	tracker := resourceusage.Start()
+	fsys := tracker.WrapFS(fstest.MapFS{"a.txt": {Data: []byte("hello")}})
+
+	for range 2 {
+		run := tracker.StartPlugin("python/wheelegg")
+		f, err := fsys.Open("a.txt")
+		if err != nil {
+			t.Fatalf("Open(a.txt): %v", err)
+		}
+		if _, err := io.ReadAll(f); err != nil {
+			t.Fatalf("ReadAll(a.txt): %v", err)
+		}
+		f.Close()
+		sink = make([]byte, 1<<20)
+		run.Stop(1)
+	}
+	tracker.StartPlugin("detector").Stop(0)
+
+	got := tracker.Usage().Plugins
+	if len(got) != 2 || got[0].Name != "detector" || got[1].Name != "python/wheelegg" {
+		t.Fatalf("Usage().Plugins = %v, want the usage of detector and python/wheelegg", got)
+	}
+	if got[0].FilesExtracted != 0 || got[0].BytesRead != 0 {
+		t.Errorf("Usage().Plugins[detector] = %+v, want no files extracted or bytes read", got[0])
+	}
+	p := got[1]
+	if p.FilesExtracted != 2 {
+		t.Errorf("Usage().Plugins[python/wheelegg].FilesExtracted = %d, want 2", p.FilesExtracted)
+	}
+	if p.BytesRead != 10 {
+		t.Errorf("Usage().Plugins[python/wheelegg].BytesRead = %d, want 10", p.BytesRead)
+	}
+	if p.AllocatedBytes < 2<<20 {
+		t.Errorf("Usage().Plugins[python/wheelegg].AllocatedBytes = %d, want >= %d", p.AllocatedBytes, 2<<20)
+	}
+	if p.WallTime <= 0 {
+		t.Errorf("Usage().Plugins[python/wheelegg].WallTime = %v, want > 0", p.WallTime)
+	}
+}
+
+func TestStartPlugin_NilTracker(t *testing.T) {
+	var tracker *resourceusage.Tracker
+	// Doesn't panic.
+	tracker.StartPlugin("python/wheelegg").Stop(1)
+}
@@ -0,0 +1,37 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package resourceusage
+
+import (
+	"time"
+
+	"golang.org/x/sys/windows"
+)
+
+func cpuTime() time.Duration {
+	var creation, exit, kernel, user windows.Filetime
+	if err := windows.GetProcessTimes(windows.CurrentProcess(), &creation, &exit, &kernel, &user); err != nil {
+		return 0
+	}
+	// Filetime durations are in 100-nanosecond intervals.
+	ticks := int64(kernel.HighDateTime)<<32 | int64(kernel.LowDateTime)
+	ticks += int64(user.HighDateTime)<<32 | int64(user.LowDateTime)
+	return time.Duration(ticks * 100)
+}
+
+// peakMemoryBytes isn't supported on Windows yet.
+func peakMemoryBytes() int64 { return 0 }
@@ -0,0 +1,52 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package faultinject wraps filesystems and plugins with configurable faults,
+// such as permission errors, slow reads, corrupted file contents or failing
+// plugins. Integrators can use it to test how their services handle the
+// partial and failed scan results that occur on real hosts.
+//
+// Faults are deterministic: whether a file or plugin run is affected depends
+// only on its path and the configured seed, so failing tests can be
+// reproduced.
+package faultinject
+
+import (
+	"encoding/binary"
+	"errors"
+	"hash/fnv"
+)
+
+// ErrInjected is returned by plugins that fail due to an injected fault.
+var ErrInjected = errors.New("injected fault")
+
+// affected returns whether the item identified by key is affected by a fault
+// that occurs at the given rate, between 0 (never) and 1 (always).
+func affected(seed int64, fault, key string, rate float64) bool {
+	if rate <= 0 {
+		return false
+	}
+	if rate >= 1 {
+		return true
+	}
+	h := fnv.New64a()
+	var b [8]byte
+	binary.LittleEndian.PutUint64(b[:], uint64(seed))
+	h.Write(b[:])
+	h.Write([]byte(fault))
+	h.Write([]byte{0})
+	h.Write([]byte(key))
+	// Use the top 53 bits to get a uniformly distributed float in [0, 1).
+	return float64(h.Sum64()>>11)/(1<<53) < rate
+}
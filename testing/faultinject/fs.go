@@ -0,0 +1,147 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package faultinject
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"time"
+
+	scalibrfs "github.com/google/osv-scalibr/fs"
+)
+
+// FSConfig configures the faults of a filesystem.
+type FSConfig struct {
+	// The fraction of paths, between 0 and 1, for which Open, Stat and ReadDir
+	// fail with a permission error.
+	PermissionErrorRate float64
+	// The fraction of files, between 0 and 1, whose contents are corrupted:
+	// Every 16th byte read from them is inverted.
+	CorruptionRate float64
+	// A delay added to every read from a file, simulating slow disks or network
+	// filesystems.
+	ReadDelay time.Duration
+	// Seed for selecting the affected paths.
+	Seed int64
+}
+
+// NewFS returns a filesystem that injects the configured faults into the
+// operations of fsys. The root directory is never affected.
+func NewFS(fsys scalibrfs.FS, cfg *FSConfig) scalibrfs.FS {
+	return &faultFS{fsys: fsys, cfg: *cfg}
+}
+
+type faultFS struct {
+	fsys scalibrfs.FS
+	cfg  FSConfig
+}
+
+func (f *faultFS) permissionError(op, name string) error {
+	if name == "." || !affected(f.cfg.Seed, "permission", name, f.cfg.PermissionErrorRate) {
+		return nil
+	}
+	return &fs.PathError{Op: op, Path: name, Err: fs.ErrPermission}
+}
+
+// Open opens the named file.
+func (f *faultFS) Open(name string) (fs.File, error) {
+	if err := f.permissionError("open", name); err != nil {
+		return nil, err
+	}
+	file, err := f.fsys.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	corrupt := affected(f.cfg.Seed, "corruption", name, f.cfg.CorruptionRate)
+	if !corrupt && f.cfg.ReadDelay == 0 {
+		return file, nil
+	}
+	return &faultFile{File: file, corrupt: corrupt, delay: f.cfg.ReadDelay}, nil
+}
+
+// ReadDir reads the named directory.
+func (f *faultFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	if err := f.permissionError("readdir", name); err != nil {
+		return nil, err
+	}
+	return f.fsys.ReadDir(name)
+}
+
+// Stat returns a FileInfo describing the file.
+func (f *faultFS) Stat(name string) (fs.FileInfo, error) {
+	if err := f.permissionError("stat", name); err != nil {
+		return nil, err
+	}
+	return f.fsys.Stat(name)
+}
+
+// faultFile is a file whose reads are delayed or corrupted.
+type faultFile struct {
+	fs.File
+
+	corrupt bool
+	delay   time.Duration
+	// The offset of the next Read call.
+	offset int64
+}
+
+// corruptEvery is the distance between two corrupted bytes.
+const corruptEvery = 16
+
+func (f *faultFile) Read(p []byte) (int, error) {
+	time.Sleep(f.delay)
+	n, err := f.File.Read(p)
+	f.corruptBytes(p[:n], f.offset)
+	f.offset += int64(n)
+	return n, err
+}
+
+// ReadAt implements io.ReaderAt, which scalibrfs.FS files are required to
+// support.
+func (f *faultFile) ReadAt(p []byte, off int64) (int, error) {
+	r, ok := f.File.(io.ReaderAt)
+	if !ok {
+		return 0, &fs.PathError{Op: "readat", Err: errors.ErrUnsupported}
+	}
+	time.Sleep(f.delay)
+	n, err := r.ReadAt(p, off)
+	f.corruptBytes(p[:n], off)
+	return n, err
+}
+
+// Seek implements io.Seeker if the underlying file does.
+func (f *faultFile) Seek(offset int64, whence int) (int64, error) {
+	s, ok := f.File.(io.Seeker)
+	if !ok {
+		return 0, &fs.PathError{Op: "seek", Err: errors.ErrUnsupported}
+	}
+	pos, err := s.Seek(offset, whence)
+	if err == nil {
+		f.offset = pos
+	}
+	return pos, err
+}
+
+func (f *faultFile) corruptBytes(p []byte, off int64) {
+	if !f.corrupt {
+		return
+	}
+	for i := range p {
+		if (off+int64(i))%corruptEvery == 0 {
+			p[i] = ^p[i]
+		}
+	}
+}
@@ -0,0 +1,158 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package faultinject_test
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/osv-scalibr/testing/faultinject"
+)
+
+func testFS(files int) fstest.MapFS {
+	fsys := fstest.MapFS{}
+	for i := range files {
+		fsys[fmt.Sprintf("dir/%d.txt", i)] = &fstest.MapFile{Data: []byte("0123456789abcdefghijklmnopqrstuvwxyz")}
+	}
+	return fsys
+}
+
+func TestNewFS_PermissionErrors(t *testing.T) {
+	testCases := []struct {
+		desc    string
+		rate    float64
+		wantMin int
+		wantMax int
+	}{
+		{desc: "no_errors", rate: 0, wantMin: 0, wantMax: 0},
+		{desc: "some_errors", rate: 0.3, wantMin: 15, wantMax: 45},
+		{desc: "all_errors", rate: 1, wantMin: 100, wantMax: 100},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			fsys := faultinject.NewFS(testFS(100), &faultinject.FSConfig{PermissionErrorRate: tc.rate, Seed: 1})
+			failed := 0
+			for i := range 100 {
+				name := fmt.Sprintf("dir/%d.txt", i)
+				_, openErr := fsys.Open(name)
+				_, statErr := fsys.Stat(name)
+				if (openErr == nil) != (statErr == nil) {
+					t.Errorf("%s: Open() error %v and Stat() error %v differ", name, openErr, statErr)
+				}
+				if openErr == nil {
+					continue
+				}
+				if !errors.Is(openErr, fs.ErrPermission) {
+					t.Errorf("Open(%s): got error %v, want permission error", name, openErr)
+				}
+				failed++
+			}
+			if failed < tc.wantMin || failed > tc.wantMax {
+				t.Errorf("got %d failing files, want between %d and %d", failed, tc.wantMin, tc.wantMax)
+			}
+			if _, err := fsys.ReadDir("."); err != nil {
+				t.Errorf("ReadDir(.): %v, root should never fail", err)
+			}
+		})
+	}
+}
+
+func TestNewFS_Deterministic(t *testing.T) {
+	failing := func(seed int64) []string {
+		fsys := faultinject.NewFS(testFS(50), &faultinject.FSConfig{PermissionErrorRate: 0.5, Seed: seed})
+		var names []string
+		for i := range 50 {
+			name := fmt.Sprintf("dir/%d.txt", i)
+			if _, err := fsys.Open(name); err != nil {
+				names = append(names, name)
+			}
+		}
+		return names
+	}
+	if diff := cmp.Diff(failing(1), failing(1)); diff != "" {
+		t.Errorf("failing files differ for the same seed (-first +second):\n%s", diff)
+	}
+	if cmp.Equal(failing(1), failing(2)) {
+		t.Errorf("failing files are the same for different seeds")
+	}
+}
+
+func TestNewFS_Corruption(t *testing.T) {
+	fsys := faultinject.NewFS(testFS(1), &faultinject.FSConfig{CorruptionRate: 1})
+	f, err := fsys.Open("dir/0.txt")
+	if err != nil {
+		t.Fatalf("Open(): %v", err)
+	}
+	defer f.Close()
+
+	want := []byte("0123456789abcdefghijklmnopqrstuvwxyz")
+	for _, i := range []int{0, 16, 32} {
+		want[i] = ^want[i]
+	}
+
+	// Read in small chunks to check that offsets are tracked across reads.
+	var got []byte
+	buf := make([]byte, 7)
+	for {
+		n, err := f.Read(buf)
+		got = append(got, buf[:n]...)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Read(): %v", err)
+		}
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Read() returned unexpected content (-want +got):\n%s", diff)
+	}
+
+	r, ok := f.(io.ReaderAt)
+	if !ok {
+		t.Fatalf("file doesn't implement io.ReaderAt")
+	}
+	at := make([]byte, 4)
+	if _, err := r.ReadAt(at, 15); err != nil {
+		t.Fatalf("ReadAt(): %v", err)
+	}
+	if diff := cmp.Diff(want[15:19], at); diff != "" {
+		t.Errorf("ReadAt() returned unexpected content (-want +got):\n%s", diff)
+	}
+}
+
+func TestNewFS_ReadDelay(t *testing.T) {
+	delay := 20 * time.Millisecond
+	fsys := faultinject.NewFS(testFS(1), &faultinject.FSConfig{ReadDelay: delay})
+	f, err := fsys.Open("dir/0.txt")
+	if err != nil {
+		t.Fatalf("Open(): %v", err)
+	}
+	defer f.Close()
+
+	start := time.Now()
+	if _, err := f.Read(make([]byte, 10)); err != nil {
+		t.Fatalf("Read(): %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < delay {
+		t.Errorf("Read() took %v, want at least %v", elapsed, delay)
+	}
+}
@@ -0,0 +1,160 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package faultinject
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/osv-scalibr/detector"
+	"github.com/google/osv-scalibr/enricher"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	scalibrfs "github.com/google/osv-scalibr/fs"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/packageindex"
+)
+
+// PluginConfig configures the faults of a plugin.
+type PluginConfig struct {
+	// The fraction of runs, between 0 and 1, that fail with ErrInjected.
+	// Extractor runs are identified by the path of the extracted file, other
+	// plugins run once per scan.
+	ErrorRate float64
+	// If set, failing runs still return the results found before the failure:
+	// the first half of the packages and findings.
+	PartialResults bool
+	// A delay added to every run, simulating slow plugins. Runs are aborted if
+	// the context is cancelled during the delay.
+	Delay time.Duration
+	// Seed for selecting the failing runs.
+	Seed int64
+}
+
+func (c *PluginConfig) wait(ctx context.Context) error {
+	if c.Delay == 0 {
+		return nil
+	}
+	t := time.NewTimer(c.Delay)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// WrapExtractor returns an extractor that injects the configured faults into
+// the runs of e.
+func WrapExtractor(e filesystem.Extractor, cfg *PluginConfig) filesystem.Extractor {
+	return &faultExtractor{Extractor: e, cfg: *cfg}
+}
+
+type faultExtractor struct {
+	filesystem.Extractor
+
+	cfg PluginConfig
+}
+
+// Extract extracts the file using the wrapped extractor unless the run fails.
+func (e *faultExtractor) Extract(ctx context.Context, input *filesystem.ScanInput) (inventory.Inventory, error) {
+	if err := e.cfg.wait(ctx); err != nil {
+		return inventory.Inventory{}, err
+	}
+	if !affected(e.cfg.Seed, "error", input.Path, e.cfg.ErrorRate) {
+		return e.Extractor.Extract(ctx, input)
+	}
+	if !e.cfg.PartialResults {
+		return inventory.Inventory{}, ErrInjected
+	}
+	inv, err := e.Extractor.Extract(ctx, input)
+	if err != nil {
+		return inv, err
+	}
+	return truncate(inv), ErrInjected
+}
+
+// WrapDetector returns a detector that injects the configured faults into the
+// runs of d.
+func WrapDetector(d detector.Detector, cfg *PluginConfig) detector.Detector {
+	return &faultDetector{Detector: d, cfg: *cfg}
+}
+
+type faultDetector struct {
+	detector.Detector
+
+	cfg PluginConfig
+}
+
+// Scan runs the wrapped detector unless the run fails.
+func (d *faultDetector) Scan(ctx context.Context, scanRoot *scalibrfs.ScanRoot, px *packageindex.PackageIndex) (inventory.Finding, error) {
+	if err := d.cfg.wait(ctx); err != nil {
+		return inventory.Finding{}, err
+	}
+	if !affected(d.cfg.Seed, "error", d.Name(), d.cfg.ErrorRate) {
+		return d.Detector.Scan(ctx, scanRoot, px)
+	}
+	if !d.cfg.PartialResults {
+		return inventory.Finding{}, ErrInjected
+	}
+	f, err := d.Detector.Scan(ctx, scanRoot, px)
+	if err != nil {
+		return f, err
+	}
+	return inventory.Finding{
+		PackageVulns:    f.PackageVulns[:len(f.PackageVulns)/2],
+		GenericFindings: f.GenericFindings[:len(f.GenericFindings)/2],
+	}, ErrInjected
+}
+
+// WrapEnricher returns an enricher that injects the configured faults into the
+// runs of e. With PartialResults, failing runs enrich the inventory before
+// returning the error.
+func WrapEnricher(e enricher.Enricher, cfg *PluginConfig) enricher.Enricher {
+	return &faultEnricher{Enricher: e, cfg: *cfg}
+}
+
+type faultEnricher struct {
+	enricher.Enricher
+
+	cfg PluginConfig
+}
+
+// Enrich runs the wrapped enricher unless the run fails.
+func (e *faultEnricher) Enrich(ctx context.Context, input *enricher.ScanInput, inv *inventory.Inventory) error {
+	if err := e.cfg.wait(ctx); err != nil {
+		return err
+	}
+	if !affected(e.cfg.Seed, "error", e.Name(), e.cfg.ErrorRate) {
+		return e.Enricher.Enrich(ctx, input, inv)
+	}
+	if !e.cfg.PartialResults {
+		return ErrInjected
+	}
+	if err := e.Enricher.Enrich(ctx, input, inv); err != nil {
+		return err
+	}
+	return ErrInjected
+}
+
+// truncate returns the first half of the packages and findings of inv.
+func truncate(inv inventory.Inventory) inventory.Inventory {
+	return inventory.Inventory{
+		Packages:        inv.Packages[:len(inv.Packages)/2],
+		PackageVulns:    inv.PackageVulns[:len(inv.PackageVulns)/2],
+		GenericFindings: inv.GenericFindings[:len(inv.GenericFindings)/2],
+		Secrets:         inv.Secrets[:len(inv.Secrets)/2],
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package faultinject_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/inventory"
+	fd "github.com/google/osv-scalibr/testing/fakedetector"
+	fe "github.com/google/osv-scalibr/testing/fakeextractor"
+	"github.com/google/osv-scalibr/testing/faultinject"
+)
+
+func TestWrapExtractor(t *testing.T) {
+	fake := fe.New("fake", 1, []string{"file.txt"}, map[string]fe.NamesErr{
+		"file.txt": {Names: []string{"a", "b", "c", "d"}},
+	})
+
+	testCases := []struct {
+		desc      string
+		cfg       *faultinject.PluginConfig
+		wantNames []string
+		wantErr   error
+	}{
+		{
+			desc:      "no_faults",
+			cfg:       &faultinject.PluginConfig{},
+			wantNames: []string{"a", "b", "c", "d"},
+		},
+		{
+			desc:    "failure",
+			cfg:     &faultinject.PluginConfig{ErrorRate: 1},
+			wantErr: faultinject.ErrInjected,
+		},
+		{
+			desc:      "partial_results",
+			cfg:       &faultinject.PluginConfig{ErrorRate: 1, PartialResults: true},
+			wantNames: []string{"a", "b"},
+			wantErr:   faultinject.ErrInjected,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			e := faultinject.WrapExtractor(fake, tc.cfg)
+			if e.Name() != fake.Name() {
+				t.Errorf("Name() = %q, want %q", e.Name(), fake.Name())
+			}
+			inv, err := e.Extract(context.Background(), &filesystem.ScanInput{Path: "file.txt"})
+			if !errors.Is(err, tc.wantErr) {
+				t.Errorf("Extract(): got error %v, want %v", err, tc.wantErr)
+			}
+			var gotNames []string
+			for _, p := range inv.Packages {
+				gotNames = append(gotNames, p.Name)
+			}
+			if diff := cmp.Diff(tc.wantNames, gotNames); diff != "" {
+				t.Errorf("Extract() returned unexpected packages (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestWrapExtractor_DelayRespectsContext(t *testing.T) {
+	e := faultinject.WrapExtractor(fe.New("fake", 1, nil, nil), &faultinject.PluginConfig{Delay: time.Hour})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := e.Extract(ctx, &filesystem.ScanInput{Path: "file.txt"}); !errors.Is(err, context.Canceled) {
+		t.Errorf("Extract() with cancelled context: got error %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestWrapDetector(t *testing.T) {
+	vuln1 := &inventory.PackageVuln{Package: &extractor.Package{Name: "a"}}
+	vuln2 := &inventory.PackageVuln{Package: &extractor.Package{Name: "b"}}
+	fake := fd.New().WithName("fake")
+	fake.Findings = inventory.Finding{PackageVulns: []*inventory.PackageVuln{vuln1, vuln2}}
+
+	d := faultinject.WrapDetector(fake, &faultinject.PluginConfig{ErrorRate: 1, PartialResults: true})
+	got, err := d.Scan(context.Background(), nil, nil)
+	if !errors.Is(err, faultinject.ErrInjected) {
+		t.Errorf("Scan(): got error %v, want %v", err, faultinject.ErrInjected)
+	}
+	want := inventory.Finding{PackageVulns: []*inventory.PackageVuln{vuln1}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Scan() returned unexpected findings (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,129 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awsaccesskey
+
+import (
+	"regexp"
+
+	"github.com/google/osv-scalibr/veles"
+)
+
+const (
+	// maxDistance is the maximum number of bytes between an access key ID and
+	// its secret access key. Both are usually on adjacent lines, e.g. in
+	// credential files, environment variables or Packer templates.
+	maxDistance = 512
+	// idLen and secretLen are the lengths of access key IDs and secret access
+	// keys.
+	idLen     = 20
+	secretLen = 40
+
+	// temporaryPrefix is the prefix of access key IDs issued by AWS STS.
+	temporaryPrefix = "ASIA"
+)
+
+var (
+	// idRe matches long-term and temporary access key IDs.
+	idRe = regexp.MustCompile(`(?:AKIA|ASIA)[A-Z2-7]{16}`)
+	// secretRe matches candidates for secret access keys. Their boundaries are
+	// checked separately since Go regexps don't support lookarounds.
+	secretRe = regexp.MustCompile(`[A-Za-z0-9/+]{40}`)
+)
+
+var _ veles.Detector = NewDetector()
+
+// Detector is a Veles Detector that finds AWS access keys.
+type Detector struct{}
+
+// NewDetector returns a new Veles Detector that finds AWS access keys.
+func NewDetector() veles.Detector {
+	return Detector{}
+}
+
+// MaxSecretLen returns the maximum length of an access key ID together with
+// its surrounding secret access key.
+func (Detector) MaxSecretLen() uint32 {
+	return 2*maxDistance + idLen
+}
+
+// Detect finds access key IDs in the data and returns the ones that have a
+// secret access key nearby. IDs on their own aren't reported since they can't
+// be used without the secret.
+func (Detector) Detect(data []byte) (secrets []veles.Secret, positions []int) {
+	for _, m := range idRe.FindAllIndex(data, -1) {
+		l, r := m[0], m[1]
+		if !isBoundary(data, l-1, isAlnum) || !isBoundary(data, r, isAlnum) {
+			continue
+		}
+		if !hasSecret(data, max(0, l-maxDistance), min(len(data), r+maxDistance)) {
+			continue
+		}
+		secrets = append(secrets, AccessKey{ID: string(data[l:r])})
+		positions = append(positions, l)
+	}
+	return secrets, positions
+}
+
+// hasSecret returns whether data[l:r] contains something that looks like a
+// secret access key.
+func hasSecret(data []byte, l, r int) bool {
+	for _, m := range secretRe.FindAllIndex(data[l:r], -1) {
+		sl, sr := l+m[0], l+m[1]
+		// Trailing padding means the candidate is part of a longer base64 string
+		// but "=" also separates keys from values.
+		if !isBoundary(data, sl-1, isSecretChar) || !isBoundary(data, sr, isBase64) {
+			continue
+		}
+		if looksRandom(data[sl:sr]) {
+			return true
+		}
+	}
+	return false
+}
+
+// looksRandom filters out candidates that are unlikely to be randomly
+// generated secrets, e.g. hex-encoded hashes or words.
+func looksRandom(s []byte) bool {
+	var upper, lower, digit bool
+	for _, c := range s {
+		switch {
+		case 'A' <= c && c <= 'Z':
+			upper = true
+		case 'a' <= c && c <= 'z':
+			lower = true
+		case '0' <= c && c <= '9':
+			digit = true
+		}
+	}
+	return upper && lower && digit
+}
+
+// isBoundary returns whether data[i] is outside the data or doesn't belong to
+// the character class of the match.
+func isBoundary(data []byte, i int, inClass func(byte) bool) bool {
+	return i < 0 || i >= len(data) || !inClass(data[i])
+}
+
+func isAlnum(c byte) bool {
+	return 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z' || '0' <= c && c <= '9'
+}
+
+func isSecretChar(c byte) bool {
+	return isAlnum(c) || c == '+' || c == '/'
+}
+
+func isBase64(c byte) bool {
+	return isSecretChar(c) || c == '='
+}
@@ -0,0 +1,119 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8ssatoken
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"regexp"
+	"strings"
+
+	"github.com/google/osv-scalibr/veles"
+)
+
+// maxTokenLen is the maximum length of a service account token. Bound tokens
+// are usually around 1 KiB.
+const maxTokenLen = 8 * 1024
+
+const (
+	// legacyIssuer is the issuer of secret-based service account tokens.
+	legacyIssuer = "kubernetes/serviceaccount"
+	// subjectPrefix is the prefix of the subject of service account tokens,
+	// followed by "<namespace>:<name>".
+	subjectPrefix = "system:serviceaccount:"
+)
+
+// jwtRe matches JSON Web Tokens with a JSON header and payload.
+var jwtRe = regexp.MustCompile(`eyJ[A-Za-z0-9_-]+\.eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+`)
+
+var _ veles.Detector = NewDetector()
+
+// Detector is a Veles Detector that finds Kubernetes service account tokens.
+type Detector struct{}
+
+// NewDetector returns a new Veles Detector that finds Kubernetes service
+// account tokens.
+func NewDetector() veles.Detector {
+	return Detector{}
+}
+
+// MaxSecretLen returns the maximum length of a service account token.
+func (Detector) MaxSecretLen() uint32 {
+	return maxTokenLen
+}
+
+// Detect finds JWTs in the data and returns the ones issued for a Kubernetes
+// service account.
+func (Detector) Detect(data []byte) (secrets []veles.Secret, positions []int) {
+	for _, m := range jwtRe.FindAllIndex(data, -1) {
+		l, r := m[0], m[1]
+		if r-l > maxTokenLen {
+			continue
+		}
+		token, ok := parse(string(data[l:r]))
+		if !ok {
+			continue
+		}
+		secrets = append(secrets, token)
+		positions = append(positions, l)
+	}
+	return secrets, positions
+}
+
+type claims struct {
+	Issuer     string `json:"iss"`
+	Subject    string `json:"sub"`
+	Kubernetes *struct {
+		Namespace      string `json:"namespace"`
+		ServiceAccount struct {
+			Name string `json:"name"`
+		} `json:"serviceaccount"`
+	} `json:"kubernetes.io"`
+	LegacyNamespace      string `json:"kubernetes.io/serviceaccount/namespace"`
+	LegacyServiceAccount string `json:"kubernetes.io/serviceaccount/service-account.name"`
+}
+
+// parse extracts the service account from the payload of the given JWT.
+// Returns false if the token wasn't issued for a service account.
+func parse(token string) (ServiceAccountToken, bool) {
+	payload, err := base64.RawURLEncoding.DecodeString(strings.Split(token, ".")[1])
+	if err != nil {
+		return ServiceAccountToken{}, false
+	}
+	var c claims
+	if err := json.Unmarshal(payload, &c); err != nil {
+		return ServiceAccountToken{}, false
+	}
+	t := ServiceAccountToken{Issuer: c.Issuer}
+	switch {
+	case c.Kubernetes != nil:
+		t.Namespace = c.Kubernetes.Namespace
+		t.ServiceAccount = c.Kubernetes.ServiceAccount.Name
+	case c.Issuer == legacyIssuer:
+		t.Namespace = c.LegacyNamespace
+		t.ServiceAccount = c.LegacyServiceAccount
+	default:
+		return ServiceAccountToken{}, false
+	}
+	if t.Namespace == "" || t.ServiceAccount == "" {
+		// Fall back to the subject, e.g. "system:serviceaccount:default:builder".
+		ns, name, ok := strings.Cut(strings.TrimPrefix(c.Subject, subjectPrefix), ":")
+		if !ok || !strings.HasPrefix(c.Subject, subjectPrefix) {
+			return ServiceAccountToken{}, false
+		}
+		t.Namespace, t.ServiceAccount = ns, name
+	}
+	return t, true
+}
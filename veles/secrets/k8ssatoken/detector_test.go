@@ -0,0 +1,114 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8ssatoken_test
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/osv-scalibr/veles"
+	"github.com/google/osv-scalibr/veles/secrets/k8ssatoken"
+)
+
+// jwt returns an unsigned JWT with the given payload and a fake signature.
+func jwt(payload string) string {
+	enc := base64.RawURLEncoding
+	return enc.EncodeToString([]byte(`{"alg":"RS256","kid":"abc"}`)) + "." +
+		enc.EncodeToString([]byte(payload)) + "." +
+		enc.EncodeToString([]byte("signature"))
+}
+
+func TestDetector(t *testing.T) {
+	boundToken := jwt(`{"aud":["https://kubernetes.default.svc.cluster.local"],` +
+		`"iss":"https://kubernetes.default.svc.cluster.local",` +
+		`"kubernetes.io":{"namespace":"ci","pod":{"name":"runner-1","uid":"1"},"serviceaccount":{"name":"builder","uid":"2"}},` +
+		`"sub":"system:serviceaccount:ci:builder"}`)
+	boundSecret := k8ssatoken.ServiceAccountToken{
+		Issuer:         "https://kubernetes.default.svc.cluster.local",
+		Namespace:      "ci",
+		ServiceAccount: "builder",
+	}
+	legacyToken := jwt(`{"iss":"kubernetes/serviceaccount",` +
+		`"kubernetes.io/serviceaccount/namespace":"kube-system",` +
+		`"kubernetes.io/serviceaccount/secret.name":"deployer-token-abcde",` +
+		`"kubernetes.io/serviceaccount/service-account.name":"deployer",` +
+		`"sub":"system:serviceaccount:kube-system:deployer"}`)
+	engine, err := veles.NewDetectionEngine([]veles.Detector{k8ssatoken.NewDetector()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cases := []struct {
+		name  string
+		input string
+		want  []veles.Secret
+	}{{
+		name:  "bound token",
+		input: boundToken,
+		want:  []veles.Secret{boundSecret},
+	}, {
+		name:  "legacy token",
+		input: legacyToken,
+		want: []veles.Secret{k8ssatoken.ServiceAccountToken{
+			Issuer:         "kubernetes/serviceaccount",
+			Namespace:      "kube-system",
+			ServiceAccount: "deployer",
+		}},
+	}, {
+		name:  "token in other text",
+		input: "export TOKEN=" + boundToken + "\n",
+		want:  []veles.Secret{boundSecret},
+	}, {
+		name:  "subject fallback",
+		input: jwt(`{"iss":"https://oidc.example.com","kubernetes.io":{},"sub":"system:serviceaccount:default:app"}`),
+		want: []veles.Secret{k8ssatoken.ServiceAccountToken{
+			Issuer:         "https://oidc.example.com",
+			Namespace:      "default",
+			ServiceAccount: "app",
+		}},
+	}, {
+		name:  "multiple tokens",
+		input: boundToken + "\n" + legacyToken,
+		want: []veles.Secret{boundSecret, k8ssatoken.ServiceAccountToken{
+			Issuer:         "kubernetes/serviceaccount",
+			Namespace:      "kube-system",
+			ServiceAccount: "deployer",
+		}},
+	}, {
+		name:  "other JWT",
+		input: jwt(`{"iss":"https://accounts.google.com","sub":"1234"}`),
+	}, {
+		name:  "kubernetes claim without service account",
+		input: jwt(`{"iss":"https://kubernetes.default.svc","kubernetes.io":{},"sub":"alice"}`),
+	}, {
+		name:  "invalid payload",
+		input: "eyJhbGciOiJSUzI1NiJ9.eyJub3QganNvbg.c2ln",
+	}, {
+		name: "empty input",
+	}}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := engine.Detect(t.Context(), strings.NewReader(tc.input))
+			if err != nil {
+				t.Errorf("Detect() error: %v, want nil", err)
+			}
+			if diff := cmp.Diff(tc.want, got, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("Detect() diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,30 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package k8ssatoken contains a Veles Secret type and a Detector for
+// Kubernetes service account tokens.
+package k8ssatoken
+
+// ServiceAccountToken is a Veles Secret that holds the identifying properties
+// of a Kubernetes service account token. The token itself is not retained.
+type ServiceAccountToken struct {
+	// Issuer is the "iss" claim of the token. For bound tokens this is usually
+	// the URL of the cluster's API server or OIDC issuer, legacy tokens use
+	// "kubernetes/serviceaccount".
+	Issuer string
+	// Namespace is the namespace of the service account.
+	Namespace string
+	// ServiceAccount is the name of the service account.
+	ServiceAccount string
+}
@@ -0,0 +1,147 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubeconfig
+
+import (
+	"bytes"
+	"regexp"
+
+	"github.com/google/osv-scalibr/veles"
+	"gopkg.in/yaml.v3"
+)
+
+// maxConfigLen is the maximum size of a kubeconfig document. Configs with many
+// embedded certificates can get large but rarely exceed this.
+const maxConfigLen = 64 * 1024
+
+var (
+	// kindRe matches the top-level "kind: Config" line of a kubeconfig.
+	kindRe = regexp.MustCompile(`(?m)^kind:[ \t]*["']?Config["']?[ \t]*\r?$`)
+	// separatorRe matches YAML document separators.
+	separatorRe = regexp.MustCompile(`(?m)^---[ \t]*\r?$`)
+)
+
+var _ veles.Detector = NewDetector()
+
+// Detector is a Veles Detector that finds kubeconfig users with embedded
+// credentials.
+type Detector struct{}
+
+// NewDetector returns a new Veles Detector that finds kubeconfig users with
+// embedded credentials.
+func NewDetector() veles.Detector {
+	return Detector{}
+}
+
+// MaxSecretLen returns the maximum length of a kubeconfig document.
+func (Detector) MaxSecretLen() uint32 {
+	return maxConfigLen
+}
+
+// Detect finds kubeconfig documents in the data and returns one Secret for
+// each user with an embedded client key or token.
+func (Detector) Detect(data []byte) (secrets []veles.Secret, positions []int) {
+	end := -1
+	for _, m := range kindRe.FindAllIndex(data, -1) {
+		if m[0] < end {
+			// Already parsed as part of the previous document.
+			continue
+		}
+		start, e := documentBounds(data, m[0])
+		end = e
+		if end-start > maxConfigLen {
+			continue
+		}
+		for _, s := range parse(data[start:end]) {
+			secrets = append(secrets, s)
+			positions = append(positions, start)
+		}
+	}
+	return secrets, positions
+}
+
+// documentBounds returns the start and end of the YAML document that contains
+// the given position.
+func documentBounds(data []byte, pos int) (start, end int) {
+	for _, m := range separatorRe.FindAllIndex(data, -1) {
+		if m[1] <= pos {
+			start = m[1]
+			continue
+		}
+		return start, m[0]
+	}
+	return start, len(data)
+}
+
+type config struct {
+	Kind           string `yaml:"kind"`
+	CurrentContext string `yaml:"current-context"`
+	Clusters       []struct {
+		Name    string `yaml:"name"`
+		Cluster struct {
+			Server string `yaml:"server"`
+		} `yaml:"cluster"`
+	} `yaml:"clusters"`
+	Contexts []struct {
+		Name    string `yaml:"name"`
+		Context struct {
+			Cluster string `yaml:"cluster"`
+			User    string `yaml:"user"`
+		} `yaml:"context"`
+	} `yaml:"contexts"`
+	Users []struct {
+		Name string `yaml:"name"`
+		User struct {
+			ClientKeyData string `yaml:"client-key-data"`
+			Token         string `yaml:"token"`
+		} `yaml:"user"`
+	} `yaml:"users"`
+}
+
+// parse returns the users with embedded credentials of the given kubeconfig.
+// Returns nothing if the data isn't a valid kubeconfig.
+func parse(data []byte) []veles.Secret {
+	var cfg config
+	if err := yaml.NewDecoder(bytes.NewReader(data)).Decode(&cfg); err != nil || cfg.Kind != "Config" {
+		return nil
+	}
+	servers := make(map[string]string, len(cfg.Clusters))
+	for _, c := range cfg.Clusters {
+		servers[c.Name] = c.Cluster.Server
+	}
+	var secrets []veles.Secret
+	for _, u := range cfg.Users {
+		k := Kubeconfig{
+			User:              u.Name,
+			ClientCertificate: u.User.ClientKeyData != "",
+			Token:             u.User.Token != "",
+		}
+		if !k.ClientCertificate && !k.Token {
+			continue
+		}
+		// Prefer the current context if it uses this user.
+		for _, c := range cfg.Contexts {
+			if c.Context.User != u.Name {
+				continue
+			}
+			if k.Cluster == "" || c.Name == cfg.CurrentContext {
+				k.Cluster = c.Context.Cluster
+				k.Server = servers[c.Context.Cluster]
+			}
+		}
+		secrets = append(secrets, k)
+	}
+	return secrets
+}
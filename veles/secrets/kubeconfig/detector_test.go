@@ -0,0 +1,138 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubeconfig_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/osv-scalibr/veles"
+	"github.com/google/osv-scalibr/veles/secrets/kubeconfig"
+)
+
+const kubeconfigFile = `apiVersion: v1
+kind: Config
+current-context: prod
+clusters:
+- name: staging
+  cluster:
+    server: https://staging.example.com:6443
+- name: prod
+  cluster:
+    certificate-authority-data: LS0tLS1CRUdJTi==
+    server: https://10.0.0.1:6443
+contexts:
+- name: staging
+  context:
+    cluster: staging
+    user: admin
+- name: prod
+  context:
+    cluster: prod
+    user: admin
+- name: ci
+  context:
+    cluster: staging
+    user: ci-bot
+users:
+- name: admin
+  user:
+    client-certificate-data: LS0tLS1CRUdJTi==
+    client-key-data: LS0tLS1CRUdJTi==
+- name: ci-bot
+  user:
+    token: c2VjcmV0LXRva2Vu
+- name: oidc
+  user:
+    exec:
+      apiVersion: client.authentication.k8s.io/v1
+      command: gke-gcloud-auth-plugin
+`
+
+func TestDetector(t *testing.T) {
+	admin := kubeconfig.Kubeconfig{
+		User:              "admin",
+		Cluster:           "prod",
+		Server:            "https://10.0.0.1:6443",
+		ClientCertificate: true,
+	}
+	ciBot := kubeconfig.Kubeconfig{
+		User:    "ci-bot",
+		Cluster: "staging",
+		Server:  "https://staging.example.com:6443",
+		Token:   true,
+	}
+	engine, err := veles.NewDetectionEngine([]veles.Detector{kubeconfig.NewDetector()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cases := []struct {
+		name  string
+		input string
+		want  []veles.Secret
+	}{{
+		name:  "kubeconfig",
+		input: kubeconfigFile,
+		want:  []veles.Secret{admin, ciBot},
+	}, {
+		name:  "crlf line endings",
+		input: strings.ReplaceAll(kubeconfigFile, "\n", "\r\n"),
+		want:  []veles.Secret{admin, ciBot},
+	}, {
+		name:  "multi-document YAML",
+		input: "kind: Secret\nmetadata:\n  name: foo\n---\n" + kubeconfigFile + "---\nkind: ConfigMap\n",
+		want:  []veles.Secret{admin, ciBot},
+	}, {
+		name: "user without context",
+		input: `kind: Config
+users:
+- name: orphan
+  user:
+    token: abc
+`,
+		want: []veles.Secret{kubeconfig.Kubeconfig{User: "orphan", Token: true}},
+	}, {
+		name: "no embedded credentials",
+		input: `kind: Config
+users:
+- name: file
+  user:
+    client-certificate: /etc/kubernetes/pki/admin.crt
+    client-key: /etc/kubernetes/pki/admin.key
+    tokenFile: /var/run/token
+`,
+	}, {
+		name:  "other kind",
+		input: strings.Replace(kubeconfigFile, "kind: Config", "kind: ConfigMap", 1),
+	}, {
+		name:  "invalid YAML",
+		input: "kind: Config\nusers: [\n",
+	}, {
+		name: "empty input",
+	}}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := engine.Detect(t.Context(), strings.NewReader(tc.input))
+			if err != nil {
+				t.Errorf("Detect() error: %v, want nil", err)
+			}
+			if diff := cmp.Diff(tc.want, got, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("Detect() diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,36 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package kubeconfig contains a Veles Secret type and a Detector for
+// Kubernetes kubeconfig files with embedded user credentials.
+package kubeconfig
+
+// Kubeconfig is a Veles Secret that holds the identifying properties of a
+// kubeconfig user with embedded credentials. The credentials themselves are
+// not retained.
+type Kubeconfig struct {
+	// User is the name of the user entry in the kubeconfig.
+	User string
+	// Cluster is the name of the cluster the user is used for. Empty if no
+	// context references the user.
+	Cluster string
+	// Server is the API server endpoint of the cluster, e.g.
+	// "https://10.0.0.1:6443". Empty if no context references the user.
+	Server string
+	// ClientCertificate is true if the user authenticates with an embedded
+	// client certificate and private key.
+	ClientCertificate bool
+	// Token is true if the user authenticates with an embedded bearer token.
+	Token bool
+}
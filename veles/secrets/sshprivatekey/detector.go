@@ -0,0 +1,96 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sshprivatekey
+
+import (
+	"encoding/pem"
+	"errors"
+	"regexp"
+
+	"github.com/google/osv-scalibr/veles"
+	"golang.org/x/crypto/ssh"
+)
+
+// maxKeyLen is the maximum length of a PEM-encoded private key. This leaves
+// enough room for 16384-bit RSA keys.
+const maxKeyLen = 16 * 1024
+
+// keyRe matches PEM blocks of private keys, including the legacy encryption
+// headers of PEM-encoded keys.
+var keyRe = regexp.MustCompile(`-----BEGIN ((?:OPENSSH |RSA |DSA |EC |ENCRYPTED )?PRIVATE KEY)-----[A-Za-z0-9+/=:,\s-]*?-----END ((?:OPENSSH |RSA |DSA |EC |ENCRYPTED )?PRIVATE KEY)-----`)
+
+var _ veles.Detector = NewDetector()
+
+// Detector is a Veles Detector that finds SSH private keys.
+type Detector struct{}
+
+// NewDetector returns a new Veles Detector that finds SSH private keys.
+func NewDetector() veles.Detector {
+	return Detector{}
+}
+
+// MaxSecretLen returns the maximum length of a private key.
+func (Detector) MaxSecretLen() uint32 {
+	return maxKeyLen
+}
+
+// Detect finds PEM-encoded private keys in the data and returns the ones that
+// can be parsed as SSH keys.
+func (Detector) Detect(data []byte) (secrets []veles.Secret, positions []int) {
+	for _, m := range keyRe.FindAllSubmatchIndex(data, -1) {
+		l, r := m[0], m[1]
+		if r-l > maxKeyLen || string(data[m[2]:m[3]]) != string(data[m[4]:m[5]]) {
+			continue
+		}
+		key, ok := parse(data[l:r])
+		if !ok {
+			continue
+		}
+		secrets = append(secrets, key)
+		positions = append(positions, l)
+	}
+	return secrets, positions
+}
+
+// parse extracts the algorithm and fingerprint of the given private key.
+// Returns false if the data isn't a valid private key.
+func parse(data []byte) (SSHPrivateKey, bool) {
+	raw, err := ssh.ParseRawPrivateKey(data)
+	if err != nil {
+		var missing *ssh.PassphraseMissingError
+		if errors.As(err, &missing) {
+			// Encrypted OpenSSH keys expose the public key, encrypted PEM keys don't.
+			key := SSHPrivateKey{Encrypted: true}
+			if missing.PublicKey != nil {
+				key.Algorithm = missing.PublicKey.Type()
+				key.Fingerprint = ssh.FingerprintSHA256(missing.PublicKey)
+			}
+			return key, true
+		}
+		// Encrypted PKCS #8 keys aren't supported by the ssh package.
+		if block, _ := pem.Decode(data); block != nil && block.Type == "ENCRYPTED PRIVATE KEY" {
+			return SSHPrivateKey{Encrypted: true}, true
+		}
+		return SSHPrivateKey{}, false
+	}
+	signer, err := ssh.NewSignerFromKey(raw)
+	if err != nil {
+		return SSHPrivateKey{}, false
+	}
+	return SSHPrivateKey{
+		Algorithm:   signer.PublicKey().Type(),
+		Fingerprint: ssh.FingerprintSHA256(signer.PublicKey()),
+	}, true
+}
@@ -0,0 +1,32 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package sshprivatekey contains a Veles Secret type and a Detector for SSH
+// private keys in the PEM and OpenSSH formats.
+package sshprivatekey
+
+// SSHPrivateKey is a Veles Secret that holds the identifying properties of an
+// SSH private key. The key material itself is not retained.
+type SSHPrivateKey struct {
+	// Algorithm is the SSH key type, e.g. "ssh-ed25519" or "ssh-rsa". Empty if
+	// the key is encrypted in a format that doesn't expose the public key.
+	Algorithm string
+	// Fingerprint is the SHA256 fingerprint of the public key in the format used
+	// by ssh-keygen, e.g. "SHA256:ZwJ0...". Empty if the key is encrypted in a
+	// format that doesn't expose the public key.
+	Fingerprint string
+	// Encrypted is true if the key is protected by a passphrase. Unencrypted keys
+	// can be used by anyone who can read the file.
+	Encrypted bool
+}